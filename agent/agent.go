@@ -163,6 +163,18 @@ const (
 
 	MgoStatsEnabled = "MGO_STATS_ENABLED"
 
+	// RaftSnapshotThreshold and RaftTrailingLogs hold the controller's
+	// raft log thresholds, copied from the controller config.
+	RaftSnapshotThreshold = "RAFT_SNAPSHOT_THRESHOLD"
+	RaftTrailingLogs      = "RAFT_TRAILING_LOGS"
+
+	// AgentLogTarget, AgentLogfileMaxSizeMB and AgentLogfileMaxBackups
+	// hold where the agent writes its logs and how its log file is
+	// rotated, copied from the controller config.
+	AgentLogTarget         = "AGENT_LOG_TARGET"
+	AgentLogfileMaxSizeMB  = "AGENT_LOGFILE_MAX_SIZE_MB"
+	AgentLogfileMaxBackups = "AGENT_LOGFILE_MAX_BACKUPS"
+
 	// LoggingOverride will set the logging for this agent to the value
 	// specified. Model configuration will be ignored and this value takes
 	// precidence for the agent.
@@ -328,6 +340,12 @@ func MachineLockLogFilename(c Config) string {
 	return filepath.Join(c.LogDir(), machinelock.Filename)
 }
 
+// CrashReportDir returns the directory holding the crash reports of the
+// agent until they are uploaded to the controller.
+func CrashReportDir(c Config) string {
+	return filepath.Join(c.DataDir(), "crash-reports", c.Tag().String())
+}
+
 type ConfigMutator func(ConfigSetter) error
 
 type ConfigRenderer interface {
@@ -0,0 +1,58 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/juju/juju/mongo"
+)
+
+// NewEnsureServerParams creates an EnsureServerParams from an agent
+// configuration.
+func NewEnsureServerParams(agentConfig Config) (mongo.EnsureServerParams, error) {
+	// If oplog size is specified in the agent configuration, use that.
+	// Otherwise leave the default zero value to indicate to EnsureServer
+	// that it should calculate the size.
+	var oplogSize int
+	if oplogSizeString := agentConfig.Value(MongoOplogSize); oplogSizeString != "" {
+		var err error
+		if oplogSize, err = strconv.Atoi(oplogSizeString); err != nil {
+			return mongo.EnsureServerParams{}, fmt.Errorf("invalid oplog size: %q", oplogSizeString)
+		}
+	}
+
+	// If numa ctl preference is specified in the agent configuration, use that.
+	// Otherwise leave the default false value to indicate to EnsureServer
+	// that numactl should not be used.
+	var numaCtlPolicy bool
+	if numaCtlString := agentConfig.Value(NUMACtlPreference); numaCtlString != "" {
+		var err error
+		if numaCtlPolicy, err = strconv.ParseBool(numaCtlString); err != nil {
+			return mongo.EnsureServerParams{}, fmt.Errorf("invalid numactl preference: %q", numaCtlString)
+		}
+	}
+
+	si, ok := agentConfig.StateServingInfo()
+	if !ok {
+		return mongo.EnsureServerParams{}, fmt.Errorf("agent config has no state serving info")
+	}
+
+	return mongo.EnsureServerParams{
+		APIPort:        si.APIPort,
+		StatePort:      si.StatePort,
+		Cert:           si.Cert,
+		PrivateKey:     si.PrivateKey,
+		CAPrivateKey:   si.CAPrivateKey,
+		SharedSecret:   si.SharedSecret,
+		SystemIdentity: si.SystemIdentity,
+
+		DataDir:              agentConfig.DataDir(),
+		OplogSize:            oplogSize,
+		SetNUMAControlPolicy: numaCtlPolicy,
+
+		MemoryProfile: agentConfig.MongoMemoryProfile(),
+	}, nil
+}
@@ -6,6 +6,9 @@ package action
 import (
 	"time"
 
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
 	"github.com/juju/juju/apiserver/params"
 )
 
@@ -25,3 +28,23 @@ func (c *Client) Run(run params.RunParams) ([]params.ActionResult, error) {
 	err := c.facade.FacadeCall("Run", run, &results)
 	return results.Results, err
 }
+
+// Introspect fetches the named report, one of "engine-report",
+// "goroutines" or "metrics", from the agents of the given units and
+// machines. An action is enqueued on the machine hosting each agent,
+// and the report is in the action's results once it has completed.
+func (c *Client) Introspect(agents []names.Tag, query string) ([]params.ActionResult, error) {
+	if v := c.BestAPIVersion(); v < 4 {
+		return nil, errors.NotSupportedf("Introspect for Action facade v%v", v)
+	}
+	args := params.IntrospectArgs{
+		Agents: make([]string, len(agents)),
+		Query:  query,
+	}
+	for i, agent := range agents {
+		args.Agents[i] = agent.String()
+	}
+	var results params.ActionResults
+	err := c.facade.FacadeCall("Introspect", args, &results)
+	return results.Results, err
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/action"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type introspectSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&introspectSuite{})
+
+func (s *introspectSuite) TestIntrospect(c *gc.C) {
+	expected := []params.ActionResult{{
+		Action: &params.Action{Tag: "action-1", Receiver: "machine-0", Name: "juju-introspect"},
+		Status: params.ActionPending,
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Action")
+			c.Check(request, gc.Equals, "Introspect")
+			c.Check(arg, jc.DeepEquals, params.IntrospectArgs{
+				Agents: []string{"unit-mysql-0"},
+				Query:  "goroutines",
+			})
+			*(result.(*params.ActionResults)) = params.ActionResults{Results: expected}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	results, err := action.NewClient(apiCaller).Introspect([]names.Tag{names.NewUnitTag("mysql/0")}, "goroutines")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *introspectSuite) TestIntrospectNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 3,
+	}
+	_, err := action.NewClient(apiCaller).Introspect([]names.Tag{names.NewMachineTag("0")}, "goroutines")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
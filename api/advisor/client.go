@@ -0,0 +1,38 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package advisor
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the Advisor API facade, which reports a
+// model's idle machines and units.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Advisor client.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Advisor")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Advice returns the model's machines and units that have been idle
+// for at least the model's idle-resource-days, along with the commands
+// that would remove them.
+func (c *Client) Advice() ([]params.Advice, error) {
+	var result params.AdviceResults
+	if err := c.facade.FacadeCall("Advice", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Results, nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package advisor_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/advisor"
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type advisorSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&advisorSuite{})
+
+func (s *advisorSuite) TestAdvice(c *gc.C) {
+	expected := []params.Advice{{
+		Tag:        "machine-1",
+		IdleSince:  time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC),
+		Reason:     "no units or containers",
+		Suggestion: "juju remove-machine 1",
+	}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Advisor")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "Advice")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.AdviceResults)) = params.AdviceResults{
+			Results: expected,
+		}
+		return nil
+	})
+	client := advisor.NewClient(apiCaller)
+	advice, err := client.Advice()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(advice, jc.DeepEquals, expected)
+}
+
+func (s *advisorSuite) TestAdviceError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.AdviceResults)) = params.AdviceResults{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	client := advisor.NewClient(apiCaller)
+	_, err := client.Advice()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -138,6 +138,10 @@ type state struct {
 	macaroons []macaroon.Slice
 	nonce     string
 
+	// readOnly holds whether the client asks for a read-only
+	// connection when logging in.
+	readOnly bool
+
 	// serverRootAddress holds the cached API server address and port used
 	// to login.
 	serverRootAddress string
@@ -243,6 +247,7 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 		password:     info.Password,
 		macaroons:    info.Macaroons,
 		nonce:        info.Nonce,
+		readOnly:     info.ReadOnly,
 		tlsConfig:    dialResult.tlsConfig,
 		bakeryClient: bakeryClient,
 		modelTag:     info.ModelTag,
@@ -8,6 +8,8 @@
 package application
 
 import (
+	"time"
+
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -533,6 +535,16 @@ type DestroyApplicationsParams struct {
 	// to units of the applications will be destroyed.
 	DestroyStorage bool
 
+	// ReleaseStorage controls whether or not storage attached to
+	// units of the applications will be released from the model,
+	// rather than detached.
+	ReleaseStorage bool
+
+	// StorageDisposition maps the IDs of storage instances attached to
+	// units of the applications to "destroy", "detach" or "release",
+	// overriding DestroyStorage and ReleaseStorage for those instances.
+	StorageDisposition map[string]string
+
 	// Force controls whether or not the removal of applications
 	// will be forced, i.e. ignore removal errors.
 	Force bool
@@ -540,6 +552,10 @@ type DestroyApplicationsParams struct {
 
 // DestroyApplications destroys the given applications.
 func (c *Client) DestroyApplications(in DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+	disposition, err := c.storageDisposition(in)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	argsV5 := params.DestroyApplicationsParams{
 		Applications: make([]params.DestroyApplicationParams, 0, len(in.Applications)),
 	}
@@ -554,9 +570,11 @@ func (c *Client) DestroyApplications(in DestroyApplicationsParams) ([]params.Des
 		}
 		index = append(index, i)
 		argsV5.Applications = append(argsV5.Applications, params.DestroyApplicationParams{
-			ApplicationTag: names.NewApplicationTag(name).String(),
-			DestroyStorage: in.DestroyStorage,
-			Force:          in.Force,
+			ApplicationTag:     names.NewApplicationTag(name).String(),
+			DestroyStorage:     in.DestroyStorage,
+			ReleaseStorage:     in.ReleaseStorage,
+			StorageDisposition: disposition,
+			Force:              in.Force,
 		})
 	}
 	if len(argsV5.Applications) == 0 {
@@ -590,6 +608,79 @@ func (c *Client) DestroyApplications(in DestroyApplicationsParams) ([]params.Des
 	return allResults, nil
 }
 
+// storageDisposition returns the requested disposition of individual
+// storage instances keyed by storage tag, as expected by the facade.
+func (c *Client) storageDisposition(in DestroyApplicationsParams) (map[string]string, error) {
+	if !in.ReleaseStorage && len(in.StorageDisposition) == 0 {
+		return nil, nil
+	}
+	if c.BestAPIVersion() < 11 {
+		return nil, errors.New("releasing storage or choosing what happens to individual storage is not supported by this version of Juju")
+	}
+	if in.DestroyStorage && in.ReleaseStorage {
+		return nil, errors.New("cannot both destroy and release storage")
+	}
+	var result map[string]string
+	for id, what := range in.StorageDisposition {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[names.NewStorageTag(id).String()] = what
+	}
+	return result, nil
+}
+
+// PreviewDestroyApplications reports what would be removed by destroying
+// the given applications, without destroying them.
+func (c *Client) PreviewDestroyApplications(in DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+	if c.BestAPIVersion() < 10 {
+		return nil, errors.NotSupportedf("PreviewDestroyApplication not supported by this version of Juju")
+	}
+	disposition, err := c.storageDisposition(in)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := params.DestroyApplicationsParams{
+		Applications: make([]params.DestroyApplicationParams, 0, len(in.Applications)),
+	}
+	allResults := make([]params.DestroyApplicationResult, len(in.Applications))
+	index := make([]int, 0, len(in.Applications))
+	for i, name := range in.Applications {
+		if !names.IsValidApplication(name) {
+			allResults[i].Error = &params.Error{
+				Message: errors.NotValidf("application name %q", name).Error(),
+			}
+			continue
+		}
+		index = append(index, i)
+		args.Applications = append(args.Applications, params.DestroyApplicationParams{
+			ApplicationTag:     names.NewApplicationTag(name).String(),
+			DestroyStorage:     in.DestroyStorage,
+			ReleaseStorage:     in.ReleaseStorage,
+			StorageDisposition: disposition,
+			Force:              in.Force,
+		})
+	}
+	if len(args.Applications) == 0 {
+		return allResults, nil
+	}
+
+	var result params.DestroyApplicationResults
+	if err := c.facade.FacadeCall("PreviewDestroyApplication", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(result.Results); n != len(args.Applications) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(args.Applications), n)
+	}
+	for i, result := range result.Results {
+		allResults[index[i]] = result
+	}
+	return allResults, nil
+}
+
 // DestroyConsumedApplication destroys the given consumed (remote) applications.
 func (c *Client) DestroyConsumedApplication(saasNames ...string) ([]params.ErrorResult, error) {
 	args := params.DestroyConsumedApplicationsParams{
@@ -723,6 +814,21 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", args, nil)
 }
 
+// ExposeWithLoadBalancer exposes the application as Expose does, and
+// also has a provider load balancer provisioned in front of the
+// application's units. Unexposing the application removes the load
+// balancer.
+func (c *Client) ExposeWithLoadBalancer(application string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 17 {
+		return errors.NotSupportedf("ExposeWithLoadBalancer for Application facade v%v", apiVersion)
+	}
+	args := params.ApplicationExpose{
+		ApplicationName: application,
+		LoadBalancer:    true,
+	}
+	return c.facade.FacadeCall("Expose", args, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
@@ -945,3 +1051,272 @@ func (c *Client) ApplicationsInfo(applications []names.ApplicationTag) ([]params
 	}
 	return out.Results, nil
 }
+
+// SetRefreshPolicy sets how the given application follows new revisions
+// of its charm.
+func (c *Client) SetRefreshPolicy(application string, policy params.RefreshPolicy) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 12 {
+		return errors.NotSupportedf("SetRefreshPolicy for Application facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.SetApplicationRefreshPolicies{
+		Policies: []params.ApplicationRefreshPolicy{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Policy:         policy,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetRefreshPolicy", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RefreshPolicy returns how the given application follows new revisions
+// of its charm.
+func (c *Client) RefreshPolicy(application string) (params.RefreshPolicy, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 12 {
+		return params.RefreshPolicy{}, errors.NotSupportedf("RefreshPolicies for Application facade v%v", apiVersion)
+	}
+	if !names.IsValidApplication(application) {
+		return params.RefreshPolicy{}, errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.RefreshPolicyResults
+	if err := c.facade.FacadeCall("RefreshPolicies", args, &results); err != nil {
+		return params.RefreshPolicy{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.RefreshPolicy{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.RefreshPolicy{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// Pause stops hook execution for the given applications and units, so
+// that operators can carry out maintenance without the unit agents
+// reacting to it.
+func (c *Client) Pause(entities ...string) error {
+	return c.setPaused("Pause", entities)
+}
+
+// Resume resumes hook execution for the given applications and units.
+func (c *Client) Resume(entities ...string) error {
+	return c.setPaused("Resume", entities)
+}
+
+func (c *Client) setPaused(request string, entities []string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 13 {
+		return errors.NotSupportedf("%s for Application facade v%v", request, apiVersion)
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(entities)),
+	}
+	for i, entity := range entities {
+		switch {
+		case names.IsValidUnit(entity):
+			args.Entities[i].Tag = names.NewUnitTag(entity).String()
+		case names.IsValidApplication(entity):
+			args.Entities[i].Tag = names.NewApplicationTag(entity).String()
+		default:
+			return errors.NotValidf("application or unit name %q", entity)
+		}
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(request, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// CreateShadowApplication creates a shadow of the given application,
+// running the given charm, as the first step of a blue/green deployment.
+// The charm must already have been added to the model.
+func (c *Client) CreateShadowApplication(arg params.CreateShadowApplicationArg) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return errors.NotSupportedf("CreateShadowApplication for Application facade v%v", apiVersion)
+	}
+	args := params.CreateShadowApplicationArgs{
+		Args: []params.CreateShadowApplicationArg{arg},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("CreateShadowApplication", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// MigrateRelations moves the relations of the given application's
+// endpoints to its shadow application. If no endpoints are given,
+// all of the application's relations are moved.
+func (c *Client) MigrateRelations(application, shadow string, endpoints ...string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return errors.NotSupportedf("MigrateRelations for Application facade v%v", apiVersion)
+	}
+	args := params.MigrateRelationsArgs{
+		Args: []params.MigrateRelationsArg{{
+			ApplicationName: application,
+			ShadowName:      shadow,
+			Endpoints:       endpoints,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("MigrateRelations", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// FinaliseShadowApplication completes a blue/green deployment by
+// removing the given application, once all of its relations have been
+// moved to its shadow application.
+func (c *Client) FinaliseShadowApplication(application, shadow string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 14 {
+		return errors.NotSupportedf("FinaliseShadowApplication for Application facade v%v", apiVersion)
+	}
+	args := params.FinaliseShadowApplicationArgs{
+		Args: []params.FinaliseShadowApplicationArg{{
+			ApplicationName: application,
+			ShadowName:      shadow,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("FinaliseShadowApplication", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CheckCharmCompatibility returns an error if the charm of the given
+// application cannot be changed to the given charm, which must already
+// have been added to the model, without changing the application's
+// subordinacy, breaking its relations or losing its storage.
+func (c *Client) CheckCharmCompatibility(application, charmURL string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 15 {
+		return errors.NotSupportedf("CheckCharmCompatibility for Application facade v%v", apiVersion)
+	}
+	args := params.CheckCharmCompatibilityArgs{
+		Args: []params.CheckCharmCompatibilityArg{{
+			ApplicationName: application,
+			CharmURL:        charmURL,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("CheckCharmCompatibility", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AssignUnits assigns the named units to machines using the given
+// placement policy, either "pack" or "spread", and returns the machine
+// chosen for each unit. The units are assigned in a small number of
+// transactions, rather than one each. If zones are given, the spread
+// policy distributes new machines across them instead of across all
+// of the provider's zones.
+func (c *Client) AssignUnits(units []string, policy string, zones []string) ([]params.AssignUnitResult, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 18 {
+		return nil, errors.NotSupportedf("AssignUnits for Application facade v%v", apiVersion)
+	}
+	for _, unit := range units {
+		if !names.IsValidUnit(unit) {
+			return nil, errors.NotValidf("unit name %q", unit)
+		}
+	}
+	args := params.AssignUnitsArgs{
+		Units:  units,
+		Policy: policy,
+		Zones:  zones,
+	}
+	var results params.AssignUnitsResults
+	if err := c.facade.FacadeCall("AssignUnits", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(units) {
+		return nil, errors.Errorf("expected %d results, got %d", len(units), len(results.Results))
+	}
+	return results.Results, nil
+}
+
+// SetColocationRule sets whether the units of the two applications may
+// be placed on the same machines: rule is "affinity" if they may,
+// "anti-affinity" if they must not, or empty to remove the rule.
+func (c *Client) SetColocationRule(app1, app2, rule string) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 19 {
+		return errors.NotSupportedf("SetColocationRule for Application facade v%v", apiVersion)
+	}
+	args := params.ColocationRules{
+		Rules: []params.ColocationRule{{
+			Applications: []string{app1, app2},
+			Rule:         rule,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetColocationRules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ColocationRules returns the model's co-location rules.
+func (c *Client) ColocationRules() ([]params.ColocationRule, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 19 {
+		return nil, errors.NotSupportedf("ColocationRules for Application facade v%v", apiVersion)
+	}
+	var result params.ColocationRules
+	if err := c.facade.FacadeCall("ColocationRules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Rules, nil
+}
+
+// RelationData returns the current relation data of the units in the
+// scope of the relation with the given id, grouped by application.
+func (c *Client) RelationData(relationId int) (params.RelationDataResult, error) {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 20 {
+		return params.RelationDataResult{}, errors.NotSupportedf("RelationData for Application facade v%v", apiVersion)
+	}
+	args := params.RelationIds{RelationIds: []int{relationId}}
+	var results params.RelationDataResults
+	if err := c.facade.FacadeCall("RelationData", args, &results); err != nil {
+		return params.RelationDataResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.RelationDataResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.RelationDataResult{}, err
+	}
+	return results.Results[0], nil
+}
+
+// SetRelationDrainTimeout sets the drain timeout of the given relations.
+// Units departing those relations remain in scope until their
+// counterparts have run their departed hooks, or until the timeout
+// expires. A zero timeout makes units leave scope immediately.
+func (c *Client) SetRelationDrainTimeout(relationIds []int, timeout time.Duration) error {
+	if apiVersion := c.BestAPIVersion(); apiVersion < 16 {
+		return errors.NotSupportedf("SetRelationDrainTimeout for Application facade v%v", apiVersion)
+	}
+	var args params.RelationDrainTimeoutArgs
+	for _, relId := range relationIds {
+		args.Args = append(args.Args, params.RelationDrainTimeoutArg{
+			RelationId: relId,
+			Timeout:    timeout,
+		})
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetRelationsDrainTimeout", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(args.Args) {
+		return errors.Errorf("expected %d results, got %d", len(args.Args), len(results.Results))
+	}
+	return results.Combine()
+}
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -365,6 +367,44 @@ func (s *applicationSuite) TestDestroyApplications(c *gc.C) {
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
 
+func (s *applicationSuite) TestDestroyApplicationsStorageDisposition(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "DestroyApplication")
+			c.Assert(a, jc.DeepEquals, params.DestroyApplicationsParams{
+				Applications: []params.DestroyApplicationParams{{
+					ApplicationTag:     "application-foo",
+					ReleaseStorage:     true,
+					StorageDisposition: map[string]string{"storage-data-0": "destroy"},
+				}},
+			})
+			out := response.(*params.DestroyApplicationResults)
+			*out = params.DestroyApplicationResults{[]params.DestroyApplicationResult{{}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.DestroyApplications(application.DestroyApplicationsParams{
+		Applications:       []string{"foo"},
+		ReleaseStorage:     true,
+		StorageDisposition: map[string]string{"data/0": "destroy"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestDestroyApplicationsStorageDispositionNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.DestroyApplications(application.DestroyApplicationsParams{
+		Applications:       []string{"foo"},
+		StorageDisposition: map[string]string{"data/0": "release"},
+	})
+	c.Assert(err, gc.ErrorMatches, "releasing storage or choosing what happens to individual storage is not supported by this version of Juju")
+}
+
 func (s *applicationSuite) TestDestroyApplicationsV4(c *gc.C) {
 	expectedResults := []params.DestroyApplicationResult{{
 		Error: &params.Error{Message: "boo"},
@@ -395,6 +435,50 @@ func (s *applicationSuite) TestDestroyApplicationsV4(c *gc.C) {
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
 
+func (s *applicationSuite) TestPreviewDestroyApplications(c *gc.C) {
+	expectedResults := []params.DestroyApplicationResult{{
+		Error: &params.Error{Message: `application name "foo/0" not valid`},
+	}, {
+		Info: &params.DestroyApplicationInfo{
+			DestroyedUnits:    []params.Entity{{Tag: "unit-bar-1"}},
+			DestroyedMachines: []params.Entity{{Tag: "machine-1"}},
+			DestroyedOffers:   []params.DestroyedOffer{{OfferName: "bar", Connections: 2}},
+		},
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "PreviewDestroyApplication")
+			c.Assert(a, jc.DeepEquals, params.DestroyApplicationsParams{
+				Applications: []params.DestroyApplicationParams{
+					{ApplicationTag: "application-bar", DestroyStorage: true},
+				},
+			})
+			out := response.(*params.DestroyApplicationResults)
+			*out = params.DestroyApplicationResults{expectedResults[1:]}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	results, err := client.PreviewDestroyApplications(application.DestroyApplicationsParams{
+		Applications:   []string{"foo/0", "bar"},
+		DestroyStorage: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *applicationSuite) TestPreviewDestroyApplicationsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.PreviewDestroyApplications(application.DestroyApplicationsParams{
+		Applications: []string{"foo"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *applicationSuite) TestDestroyApplicationsArity(c *gc.C) {
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
 		return nil
@@ -1432,3 +1516,450 @@ func (s *applicationSuite) TestApplicationsInfoResultMismatch(c *gc.C) {
 	c.Check(called, jc.IsTrue)
 	c.Assert(err, gc.ErrorMatches, "expected 2 results, got 3")
 }
+
+func (s *applicationSuite) TestSetRefreshPolicy(c *gc.C) {
+	policy := params.RefreshPolicy{
+		Channel:     "candidate",
+		Auto:        true,
+		WindowStart: 2 * time.Hour,
+		WindowEnd:   4 * time.Hour,
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "SetRefreshPolicy")
+			c.Assert(a, jc.DeepEquals, params.SetApplicationRefreshPolicies{
+				Policies: []params.ApplicationRefreshPolicy{{
+					ApplicationTag: "application-foo",
+					Policy:         policy,
+				}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{[]params.ErrorResult{{Error: &params.Error{Message: "boom"}}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetRefreshPolicy("foo", policy)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestSetRefreshPolicyNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.SetRefreshPolicy("foo", params.RefreshPolicy{Hold: true})
+	c.Assert(err, gc.ErrorMatches, "SetRefreshPolicy for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestRefreshPolicy(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "RefreshPolicies")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-foo"}},
+			})
+			out := response.(*params.RefreshPolicyResults)
+			*out = params.RefreshPolicyResults{[]params.RefreshPolicyResult{{
+				Result: &params.RefreshPolicy{Channel: "edge", Hold: true},
+			}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	policy, err := client.RefreshPolicy("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, params.RefreshPolicy{Channel: "edge", Hold: true})
+}
+
+func (s *applicationSuite) TestPause(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "Pause")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-foo"}, {Tag: "unit-bar-0"}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{[]params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.Pause("foo", "bar/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestResume(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "Resume")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-bar-0"}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{[]params.ErrorResult{{}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.Resume("bar/0")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestPauseInvalidEntity(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.Pause("foo/bar")
+	c.Assert(err, gc.ErrorMatches, `application or unit name "foo/bar" not valid`)
+}
+
+func (s *applicationSuite) TestPauseNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.Pause("foo")
+	c.Assert(err, gc.ErrorMatches, "Pause for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestCreateShadowApplication(c *gc.C) {
+	numUnits := 1
+	arg := params.CreateShadowApplicationArg{
+		ApplicationName: "foo",
+		ShadowName:      "foo-green",
+		CharmURL:        "cs:foo-2",
+		NumUnits:        &numUnits,
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "CreateShadowApplication")
+			c.Assert(a, jc.DeepEquals, params.CreateShadowApplicationArgs{
+				Args: []params.CreateShadowApplicationArg{arg},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{[]params.ErrorResult{{Error: &params.Error{Message: "boom"}}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.CreateShadowApplication(arg)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestMigrateRelations(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "MigrateRelations")
+			c.Assert(a, jc.DeepEquals, params.MigrateRelationsArgs{
+				Args: []params.MigrateRelationsArg{{
+					ApplicationName: "foo",
+					ShadowName:      "foo-green",
+					Endpoints:       []string{"db", "website"},
+				}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{[]params.ErrorResult{{}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.MigrateRelations("foo", "foo-green", "db", "website")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestFinaliseShadowApplication(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 14,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "FinaliseShadowApplication")
+			c.Assert(a, jc.DeepEquals, params.FinaliseShadowApplicationArgs{
+				Args: []params.FinaliseShadowApplicationArg{{
+					ApplicationName: "foo",
+					ShadowName:      "foo-green",
+				}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{[]params.ErrorResult{{}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.FinaliseShadowApplication("foo", "foo-green")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestMigrateRelationsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.MigrateRelations("foo", "foo-green")
+	c.Assert(err, gc.ErrorMatches, "MigrateRelations for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestCheckCharmCompatibility(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 15,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "CheckCharmCompatibility")
+			c.Assert(a, jc.DeepEquals, params.CheckCharmCompatibilityArgs{
+				Args: []params.CheckCharmCompatibilityArg{{
+					ApplicationName: "foo",
+					CharmURL:        "cs:bar-1",
+				}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{[]params.ErrorResult{{
+				Error: &params.Error{Message: `required storage "data" removed`},
+			}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.CheckCharmCompatibility("foo", "cs:bar-1")
+	c.Assert(err, gc.ErrorMatches, `required storage "data" removed`)
+}
+
+func (s *applicationSuite) TestCheckCharmCompatibilityNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.CheckCharmCompatibility("foo", "cs:bar-1")
+	c.Assert(err, gc.ErrorMatches, "CheckCharmCompatibility for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestSetRelationDrainTimeout(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 16,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "SetRelationsDrainTimeout")
+			c.Assert(a, jc.DeepEquals, params.RelationDrainTimeoutArgs{
+				Args: []params.RelationDrainTimeoutArg{{
+					RelationId: 123,
+					Timeout:    time.Minute,
+				}, {
+					RelationId: 456,
+					Timeout:    time.Minute,
+				}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{[]params.ErrorResult{{}, {
+				Error: &params.Error{Message: "boom"},
+			}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetRelationDrainTimeout([]int{123, 456}, time.Minute)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestSetRelationDrainTimeoutNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.SetRelationDrainTimeout([]int{123}, time.Minute)
+	c.Assert(err, gc.ErrorMatches, "SetRelationDrainTimeout for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestAssignUnits(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 18,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "AssignUnits")
+			c.Assert(a, jc.DeepEquals, params.AssignUnitsArgs{
+				Units:  []string{"foo/0", "foo/1"},
+				Policy: "spread",
+				Zones:  []string{"az1", "az2"},
+			})
+			out := response.(*params.AssignUnitsResults)
+			*out = params.AssignUnitsResults{[]params.AssignUnitResult{
+				{Unit: "foo/0", Machine: "3", Zone: "az1", NewMachine: true},
+				{Unit: "foo/1", Error: &params.Error{Message: "boom"}},
+			}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	results, err := client.AssignUnits([]string{"foo/0", "foo/1"}, "spread", []string{"az1", "az2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.AssignUnitResult{
+		{Unit: "foo/0", Machine: "3", Zone: "az1", NewMachine: true},
+		{Unit: "foo/1", Error: &params.Error{Message: "boom"}},
+	})
+}
+
+func (s *applicationSuite) TestAssignUnitsInvalidUnit(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 18,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.AssignUnits([]string{"foo"}, "pack", nil)
+	c.Assert(err, gc.ErrorMatches, `unit name "foo" not valid`)
+}
+
+func (s *applicationSuite) TestAssignUnitsNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.AssignUnits([]string{"foo/0"}, "pack", nil)
+	c.Assert(err, gc.ErrorMatches, "AssignUnits for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestSetColocationRule(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 19,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "SetColocationRules")
+			c.Assert(a, jc.DeepEquals, params.ColocationRules{
+				Rules: []params.ColocationRule{{
+					Applications: []string{"foo", "bar"},
+					Rule:         "anti-affinity",
+				}},
+			})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{[]params.ErrorResult{{
+				Error: &params.Error{Message: "boom"},
+			}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetColocationRule("foo", "bar", "anti-affinity")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *applicationSuite) TestColocationRules(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 19,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "ColocationRules")
+			c.Assert(a, gc.IsNil)
+			out := response.(*params.ColocationRules)
+			*out = params.ColocationRules{[]params.ColocationRule{{
+				Applications: []string{"bar", "foo"},
+				Rule:         "affinity",
+			}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	rules, err := client.ColocationRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []params.ColocationRule{{
+		Applications: []string{"bar", "foo"},
+		Rule:         "affinity",
+	}})
+}
+
+func (s *applicationSuite) TestColocationRulesNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.ColocationRules()
+	c.Assert(err, gc.ErrorMatches, "ColocationRules for Application facade v8 not supported")
+	err = client.SetColocationRule("foo", "bar", "")
+	c.Assert(err, gc.ErrorMatches, "SetColocationRule for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestRelationData(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 20,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "RelationData")
+			c.Assert(a, jc.DeepEquals, params.RelationIds{RelationIds: []int{123}})
+			out := response.(*params.RelationDataResults)
+			*out = params.RelationDataResults{[]params.RelationDataResult{{
+				Id:  123,
+				Key: "wordpress:db mysql:db",
+				Applications: []params.RelationApplicationData{{
+					Application: "mysql",
+					Endpoint:    "db",
+					Role:        "provider",
+					UnitData: map[string]params.Settings{
+						"mysql/0": {"host": "10.0.0.1"},
+					},
+				}},
+			}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	result, err := client.RelationData(123)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Key, gc.Equals, "wordpress:db mysql:db")
+	c.Assert(result.Applications, gc.HasLen, 1)
+	c.Assert(result.Applications[0].UnitData["mysql/0"], jc.DeepEquals, params.Settings{"host": "10.0.0.1"})
+}
+
+func (s *applicationSuite) TestRelationDataError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 20,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			out := response.(*params.RelationDataResults)
+			*out = params.RelationDataResults{[]params.RelationDataResult{{
+				Error: &params.Error{Message: "relation not found", Code: params.CodeNotFound},
+			}}}
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	_, err := client.RelationData(123)
+	c.Assert(err, gc.ErrorMatches, "relation not found")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *applicationSuite) TestRelationDataNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	_, err := client.RelationData(123)
+	c.Assert(err, gc.ErrorMatches, "RelationData for Application facade v8 not supported")
+}
+
+func (s *applicationSuite) TestExposeWithLoadBalancer(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 17,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "Expose")
+			c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+				ApplicationName: "foo",
+				LoadBalancer:    true,
+			})
+			return nil
+		},
+	}
+	client := application.NewClient(apiCaller)
+	err := client.ExposeWithLoadBalancer("foo")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *applicationSuite) TestExposeWithLoadBalancerNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected API call")
+		return nil
+	})
+	err := client.ExposeWithLoadBalancer("foo")
+	c.Assert(err, gc.ErrorMatches, "ExposeWithLoadBalancer for Application facade v8 not supported")
+}
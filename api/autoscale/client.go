@@ -0,0 +1,81 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscale
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the Autoscale API facade, which manages
+// the autoscaling policies of a model's applications.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Autoscale client.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Autoscale")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// AutoscalePolicy returns the autoscaling policy of the application,
+// the most recent changes made to it by the autoscaler, and its number
+// of units. The policy is nil if the application isn't autoscaled.
+func (c *Client) AutoscalePolicy(application string) (params.AutoscaleResult, error) {
+	if !names.IsValidApplication(application) {
+		return params.AutoscaleResult{}, errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.AutoscaleResults
+	if err := c.facade.FacadeCall("AutoscalePolicies", args, &results); err != nil {
+		return params.AutoscaleResult{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.AutoscaleResult{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.AutoscaleResult{}, err
+	}
+	return results.Results[0], nil
+}
+
+// SetAutoscalePolicy sets the autoscaling policy of the application.
+func (c *Client) SetAutoscalePolicy(application string, policy params.AutoscalePolicy) error {
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.SetAutoscalePolicyArgs{
+		Args: []params.SetAutoscalePolicyArg{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Policy:         policy,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetAutoscalePolicies", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RemoveAutoscalePolicy stops the application from being autoscaled.
+func (c *Client) RemoveAutoscalePolicy(application string) error {
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveAutoscalePolicies", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
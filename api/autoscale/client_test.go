@@ -0,0 +1,98 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscale_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/autoscale"
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type autoscaleSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&autoscaleSuite{})
+
+var testPolicy = params.AutoscalePolicy{
+	MinUnits:       1,
+	MaxUnits:       3,
+	Metric:         "cpu",
+	ScaleUpAbove:   80,
+	ScaleDownBelow: 20,
+	Cooldown:       time.Minute,
+}
+
+func (s *autoscaleSuite) TestAutoscalePolicy(c *gc.C) {
+	expected := params.AutoscaleResult{
+		ApplicationTag: "application-mysql",
+		Policy:         &testPolicy,
+		Units:          2,
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Autoscale")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "AutoscalePolicies")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		*(result.(*params.AutoscaleResults)) = params.AutoscaleResults{
+			Results: []params.AutoscaleResult{expected},
+		}
+		return nil
+	})
+	client := autoscale.NewClient(apiCaller)
+	result, err := client.AutoscalePolicy("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *autoscaleSuite) TestAutoscalePolicyInvalidApplication(c *gc.C) {
+	client := autoscale.NewClient(testing.APICallerFunc(nil))
+	_, err := client.AutoscalePolicy("mysql/0")
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+}
+
+func (s *autoscaleSuite) TestSetAutoscalePolicy(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Autoscale")
+		c.Check(request, gc.Equals, "SetAutoscalePolicies")
+		c.Check(arg, jc.DeepEquals, params.SetAutoscalePolicyArgs{
+			Args: []params.SetAutoscalePolicyArg{{
+				ApplicationTag: "application-mysql",
+				Policy:         testPolicy,
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := autoscale.NewClient(apiCaller)
+	err := client.SetAutoscalePolicy("mysql", testPolicy)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *autoscaleSuite) TestRemoveAutoscalePolicy(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Autoscale")
+		c.Check(request, gc.Equals, "RemoveAutoscalePolicies")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-mysql"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := autoscale.NewClient(apiCaller)
+	err := client.RemoveAutoscalePolicy("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,129 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/autoscale"
+)
+
+const autoscalerFacade = "Autoscaler"
+
+// Application holds the autoscaling policy of an application, the most
+// recent changes made by the autoscaler, oldest first, and the number of
+// alive units of the application.
+type Application struct {
+	Tag    names.ApplicationTag
+	Policy autoscale.Policy
+	Events []autoscale.Event
+	Units  int
+}
+
+// API provides access to the Autoscaler API facade.
+type API struct {
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side Autoscaler facade.
+func NewAPI(caller base.APICaller) *API {
+	if caller == nil {
+		panic("caller is nil")
+	}
+	return &API{
+		facade: base.NewFacadeCaller(caller, autoscalerFacade),
+	}
+}
+
+// AutoscaledApplications returns the model's autoscaled applications.
+func (api *API) AutoscaledApplications() ([]Application, error) {
+	var results params.AutoscaleResults
+	if err := api.facade.FacadeCall("AutoscaledApplications", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	apps := make([]Application, len(results.Results))
+	for i, result := range results.Results {
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		tag, err := names.ParseApplicationTag(result.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if result.Policy == nil {
+			return nil, errors.Errorf("no autoscale policy for %s", names.ReadableString(tag))
+		}
+		apps[i] = Application{
+			Tag: tag,
+			Policy: autoscale.Policy{
+				MinUnits:       result.Policy.MinUnits,
+				MaxUnits:       result.Policy.MaxUnits,
+				Metric:         result.Policy.Metric,
+				ScaleUpAbove:   result.Policy.ScaleUpAbove,
+				ScaleDownBelow: result.Policy.ScaleDownBelow,
+				Cooldown:       result.Policy.Cooldown,
+			},
+			Units: result.Units,
+		}
+		for _, e := range result.Events {
+			apps[i].Events = append(apps[i].Events, autoscale.Event{
+				Time:   e.Time,
+				From:   e.From,
+				To:     e.To,
+				Reason: e.Reason,
+			})
+		}
+	}
+	return apps, nil
+}
+
+// ApplicationMetric returns the most recent value of the charm metric
+// recorded since the given time by each of the application's alive
+// units.
+func (api *API) ApplicationMetric(app names.ApplicationTag, metric string, since time.Time) ([]float64, error) {
+	args := params.ApplicationMetricArgs{
+		Args: []params.ApplicationMetricArg{{
+			ApplicationTag: app.String(),
+			Metric:         metric,
+			Since:          since,
+		}},
+	}
+	var results params.ApplicationMetricResults
+	if err := api.facade.FacadeCall("ApplicationMetrics", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return nil, err
+	}
+	return results.Results[0].Values, nil
+}
+
+// ScaleApplication changes the number of units of the application, as
+// described by the event, and records the event.
+func (api *API) ScaleApplication(app names.ApplicationTag, event autoscale.Event) error {
+	args := params.AutoscaleApplicationArgs{
+		Args: []params.AutoscaleApplicationArg{{
+			ApplicationTag: app.String(),
+			Event: params.AutoscaleEvent{
+				Time:   event.Time,
+				From:   event.From,
+				To:     event.To,
+				Reason: event.Reason,
+			},
+		}},
+	}
+	var results params.ErrorResults
+	if err := api.facade.FacadeCall("ScaleApplications", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
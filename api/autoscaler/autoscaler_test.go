@@ -0,0 +1,123 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaler_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/autoscaler"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/autoscale"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AutoscalerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&AutoscalerSuite{})
+
+func (s *AutoscalerSuite) TestNewAPIWithNilCaller(c *gc.C) {
+	panicFunc := func() { autoscaler.NewAPI(nil) }
+	c.Assert(panicFunc, gc.PanicMatches, "caller is nil")
+}
+
+func (s *AutoscalerSuite) TestAutoscaledApplications(c *gc.C) {
+	now := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := apiCaller(c, "AutoscaledApplications", nil, params.AutoscaleResults{
+		Results: []params.AutoscaleResult{{
+			ApplicationTag: "application-mysql",
+			Policy: &params.AutoscalePolicy{
+				MinUnits:       1,
+				MaxUnits:       3,
+				Metric:         "cpu",
+				ScaleUpAbove:   80,
+				ScaleDownBelow: 20,
+				Cooldown:       time.Minute,
+			},
+			Events: []params.AutoscaleEvent{{Time: now, From: 1, To: 2, Reason: "avg(cpu) 90 above 80"}},
+			Units:  2,
+		}},
+	})
+	api := autoscaler.NewAPI(apiCaller)
+	apps, err := api.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiCaller.CallCount, gc.Equals, 1)
+	c.Assert(apps, jc.DeepEquals, []autoscaler.Application{{
+		Tag: names.NewApplicationTag("mysql"),
+		Policy: autoscale.Policy{
+			MinUnits:       1,
+			MaxUnits:       3,
+			Metric:         "cpu",
+			ScaleUpAbove:   80,
+			ScaleDownBelow: 20,
+			Cooldown:       time.Minute,
+		},
+		Events: []autoscale.Event{{Time: now, From: 1, To: 2, Reason: "avg(cpu) 90 above 80"}},
+		Units:  2,
+	}})
+}
+
+func (s *AutoscalerSuite) TestAutoscaledApplicationsError(c *gc.C) {
+	apiCaller := apiCaller(c, "AutoscaledApplications", nil, params.AutoscaleResults{
+		Results: []params.AutoscaleResult{{
+			ApplicationTag: "application-mysql",
+			Error:          apiservertesting.ServerError("server boom!"),
+		}},
+	})
+	api := autoscaler.NewAPI(apiCaller)
+	_, err := api.AutoscaledApplications()
+	c.Assert(err, gc.ErrorMatches, "server boom!")
+}
+
+func (s *AutoscalerSuite) TestApplicationMetric(c *gc.C) {
+	since := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := apiCaller(c, "ApplicationMetrics", params.ApplicationMetricArgs{
+		Args: []params.ApplicationMetricArg{{
+			ApplicationTag: "application-mysql",
+			Metric:         "cpu",
+			Since:          since,
+		}},
+	}, params.ApplicationMetricResults{
+		Results: []params.ApplicationMetricResult{{Values: []float64{1, 2.5}}},
+	})
+	api := autoscaler.NewAPI(apiCaller)
+	values, err := api.ApplicationMetric(names.NewApplicationTag("mysql"), "cpu", since)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(values, jc.DeepEquals, []float64{1, 2.5})
+}
+
+func (s *AutoscalerSuite) TestScaleApplication(c *gc.C) {
+	now := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := apiCaller(c, "ScaleApplications", params.AutoscaleApplicationArgs{
+		Args: []params.AutoscaleApplicationArg{{
+			ApplicationTag: "application-mysql",
+			Event:          params.AutoscaleEvent{Time: now, From: 1, To: 2, Reason: "more"},
+		}},
+	}, params.ErrorResults{
+		Results: []params.ErrorResult{{Error: apiservertesting.ServerError("server boom!")}},
+	})
+	api := autoscaler.NewAPI(apiCaller)
+	err := api.ScaleApplication(names.NewApplicationTag("mysql"), autoscale.Event{
+		Time: now, From: 1, To: 2, Reason: "more",
+	})
+	c.Assert(err, gc.ErrorMatches, "server boom!")
+}
+
+func apiCaller(c *gc.C, method string, args, results interface{}) *apitesting.CallChecker {
+	return apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "Autoscaler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        method,
+		Args:          args,
+		Results:       results,
+	})
+}
@@ -68,7 +68,12 @@ func prepareRestore(newClient ClientConnection) error {
 }
 
 // RestoreReader restores the contents of backupFile as backup.
-func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient ClientConnection) error {
+// If rollback is true and the restore fails, the controller is put back
+// to how it was before the restore started.
+func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, rollback bool, newClient ClientConnection) error {
+	if err := c.checkRollback(rollback); err != nil {
+		return errors.Trace(err)
+	}
 	if err := prepareRestore(newClient); err != nil {
 		return errors.Trace(err)
 	}
@@ -83,7 +88,7 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 	list := results.List
 	for _, b := range list {
 		if b.Checksum == meta.Checksum {
-			return c.restore(b.ID, newClient)
+			return c.restore(b.ID, rollback, newClient)
 		}
 	}
 
@@ -95,16 +100,30 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 		return errors.Annotatef(err, "cannot upload backup file")
 	}
 
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, rollback, newClient)
 }
 
 // Restore performs restore using a backup id corresponding to a backup stored in the server.
-func (c *Client) Restore(backupId string, newClient ClientConnection) error {
+// If rollback is true and the restore fails, the controller is put back
+// to how it was before the restore started.
+func (c *Client) Restore(backupId string, rollback bool, newClient ClientConnection) error {
+	if err := c.checkRollback(rollback); err != nil {
+		return errors.Trace(err)
+	}
 	if err := prepareRestore(newClient); err != nil {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Server in 'about to restore' mode")
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, rollback, newClient)
+}
+
+// checkRollback returns an error if a rollback is asked for but
+// the controller is too old to support it.
+func (c *Client) checkRollback(rollback bool) error {
+	if rollback && c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("rolling back a failed restore on this controller")
+	}
+	return nil
 }
 
 func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, error) {
@@ -117,15 +136,16 @@ func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, erro
 // restore is responsible for triggering the whole restore process in a remote
 // machine. The backup information for the process should already be in the
 // server and loaded in the backup storage under the backupId id.
-// It takes backupId as the identifier for the remote backup file and a
-// client connection factory newClient (newClient should no longer be
+// It takes backupId as the identifier for the remote backup file, whether
+// to roll back if the restore fails, and a client connection factory newClient (newClient should no longer be
 // necessary when lp:1399722 is sorted out).
-func (c *Client) restore(backupId string, newClient ClientConnection) error {
+func (c *Client) restore(backupId string, rollback bool, newClient ClientConnection) error {
 	var err, remoteError error
 
 	// Restore
 	restoreArgs := params.RestoreArgs{
 		BackupId: backupId,
+		Rollback: rollback,
 	}
 
 	cleanExit := false
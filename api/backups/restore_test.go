@@ -4,6 +4,8 @@
 package backups_test
 
 import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
 	"github.com/golang/mock/gomock"
@@ -50,5 +52,89 @@ func (s *restoreSuite) TestRestoreFromFileBackupExistsOnController(c *gc.C) {
 		return backups.MakeClient(mockBackupClientFacade, mockBackupFacadeCaller, nil), nil
 	}
 	mockBackupsClient, _ := connFunc()
-	mockBackupsClient.RestoreReader(nil, &testBackupResults, connFunc)
+	mockBackupsClient.RestoreReader(nil, &testBackupResults, false, connFunc)
+}
+
+func (s *restoreSuite) TestRestoreWithRollback(c *gc.C) {
+	mockController := gomock.NewController(c)
+	mockBackupFacadeCaller := mocks.NewMockFacadeCaller(mockController)
+	mockBackupClientFacade := mocks.NewMockClientFacade(mockController)
+	mockBackupClientFacade.EXPECT().Close().AnyTimes()
+	mockBackupClientFacade.EXPECT().BestAPIVersion().Return(3)
+
+	args := params.RestoreArgs{BackupId: "an_id", Rollback: true}
+	gomock.InOrder(
+		mockBackupFacadeCaller.EXPECT().FacadeCall("PrepareRestore", nil, gomock.Any()),
+		mockBackupFacadeCaller.EXPECT().FacadeCall("Restore", args, gomock.Any()).Times(1),
+		mockBackupFacadeCaller.EXPECT().FacadeCall("FinishRestore", gomock.Any(), gomock.Any()).Times(1),
+	)
+
+	connFunc := func() (*backups.Client, error) {
+		return backups.MakeClient(mockBackupClientFacade, mockBackupFacadeCaller, nil), nil
+	}
+	client, _ := connFunc()
+	err := client.Restore("an_id", true, connFunc)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *restoreSuite) TestRestoreWithRollbackNotSupported(c *gc.C) {
+	mockController := gomock.NewController(c)
+	mockBackupFacadeCaller := mocks.NewMockFacadeCaller(mockController)
+	mockBackupClientFacade := mocks.NewMockClientFacade(mockController)
+	mockBackupClientFacade.EXPECT().BestAPIVersion().Return(2)
+
+	connFunc := func() (*backups.Client, error) {
+		return backups.MakeClient(mockBackupClientFacade, mockBackupFacadeCaller, nil), nil
+	}
+	client, _ := connFunc()
+	err := client.Restore("an_id", true, connFunc)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *restoreSuite) TestVerifyRestore(c *gc.C) {
+	mockController := gomock.NewController(c)
+	defer mockController.Finish()
+	mockBackupFacadeCaller := mocks.NewMockFacadeCaller(mockController)
+	mockBackupClientFacade := mocks.NewMockClientFacade(mockController)
+	mockBackupClientFacade.EXPECT().BestAPIVersion().Return(4)
+
+	args := params.VerifyRestoreArgs{BackupId: "an_id", Rollback: true}
+	expected := params.VerifyRestoreResult{
+		Checks: []params.RestoreCheck{{Name: "series", Problem: "wrong series"}},
+		Steps:  []string{"restore"},
+	}
+	mockBackupFacadeCaller.EXPECT().FacadeCall("VerifyRestore", args, gomock.Any()).SetArg(2, expected)
+
+	client := backups.MakeClient(mockBackupClientFacade, mockBackupFacadeCaller, nil)
+	result, err := client.VerifyRestore("an_id", nil, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, expected)
+}
+
+func (s *restoreSuite) TestVerifyRestoreMetadata(c *gc.C) {
+	mockController := gomock.NewController(c)
+	defer mockController.Finish()
+	mockBackupFacadeCaller := mocks.NewMockFacadeCaller(mockController)
+	mockBackupClientFacade := mocks.NewMockClientFacade(mockController)
+	mockBackupClientFacade.EXPECT().BestAPIVersion().Return(4)
+
+	meta := &params.BackupsMetadataResult{Checksum: "testCheckSum"}
+	args := params.VerifyRestoreArgs{Metadata: meta}
+	mockBackupFacadeCaller.EXPECT().FacadeCall("VerifyRestore", args, gomock.Any())
+
+	client := backups.MakeClient(mockBackupClientFacade, mockBackupFacadeCaller, nil)
+	_, err := client.VerifyRestore("ignored", meta, false)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *restoreSuite) TestVerifyRestoreNotSupported(c *gc.C) {
+	mockController := gomock.NewController(c)
+	defer mockController.Finish()
+	mockBackupFacadeCaller := mocks.NewMockFacadeCaller(mockController)
+	mockBackupClientFacade := mocks.NewMockClientFacade(mockController)
+	mockBackupClientFacade.EXPECT().BestAPIVersion().Return(3)
+
+	client := backups.MakeClient(mockBackupClientFacade, mockBackupFacadeCaller, nil)
+	_, err := client.VerifyRestore("an_id", nil, false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
 }
@@ -0,0 +1,32 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// VerifyRestore asks the controller to check whether the backup with
+// the given id could be restored, without restoring it. If meta is
+// not nil it describes a backup archive that has not been uploaded,
+// and backupId is ignored.
+func (c *Client) VerifyRestore(backupId string, meta *params.BackupsMetadataResult, rollback bool) (params.VerifyRestoreResult, error) {
+	var result params.VerifyRestoreResult
+	if c.BestAPIVersion() < 4 {
+		return result, errors.NotSupportedf("verifying a restore on this controller")
+	}
+	args := params.VerifyRestoreArgs{
+		Metadata: meta,
+		Rollback: rollback,
+	}
+	if meta == nil {
+		args.BackupId = backupId
+	}
+	if err := c.facade.FacadeCall("VerifyRestore", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
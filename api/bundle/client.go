@@ -47,3 +47,19 @@ func (c *Client) ExportBundle() (string, error) {
 
 	return result.Result, nil
 }
+
+// CheckPlacements asks the controller to report every zone placement,
+// zone or space constraint and endpoint binding in the given bundle
+// that cannot be satisfied by the model. An empty result means the
+// bundle's placements are all satisfiable.
+func (c *Client) CheckPlacements(bundleYAML string) ([]string, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return nil, errors.NotSupportedf("CheckPlacements on v%d facade", bestVer)
+	}
+	var result params.BundlePlacementResults
+	args := params.BundleChangesParams{BundleDataYAML: bundleYAML}
+	if err := c.facade.FacadeCall("CheckPlacements", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Errors, nil
+}
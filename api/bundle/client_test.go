@@ -129,3 +129,45 @@ func (s *bundleMockSuite) TestExportBundleFailNoParamsErrorv2(c *gc.C) {
 	c.Assert(result, jc.DeepEquals, "")
 	c.Check(err.Error(), gc.Matches, "foo")
 }
+
+func (s *bundleMockSuite) TestCheckPlacementsNotSupportedv2(c *gc.C) {
+	client := newClient(
+		func(objType string, version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		}, 2,
+	)
+	result, err := client.CheckPlacements("applications: {}")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(result, gc.IsNil)
+}
+
+func (s *bundleMockSuite) TestCheckPlacementsv3(c *gc.C) {
+	client := newClient(
+		func(objType string, version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(request, gc.Equals, "CheckPlacements")
+			c.Check(args, jc.DeepEquals, params.BundleChangesParams{
+				BundleDataYAML: "applications: {}",
+			})
+			result := response.(*params.BundlePlacementResults)
+			result.Errors = []string{`application "mysql": placement "zone=nowhere": availability zone "nowhere" not found`}
+			return nil
+		}, 3,
+	)
+	result, err := client.CheckPlacements("applications: {}")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []string{
+		`application "mysql": placement "zone=nowhere": availability zone "nowhere" not found`,
+	})
+}
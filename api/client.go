@@ -281,6 +281,30 @@ func (c *Client) SetModelAgentVersion(version version.Number, ignoreAgentVersion
 	return c.facade.FacadeCall("SetModelAgentVersion", args, nil)
 }
 
+// UpgradePrecheck runs the checks the controller makes before
+// upgrading the model to the given version, without changing anything.
+func (c *Client) UpgradePrecheck(version version.Number) ([]params.UpgradePrecheckResult, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("UpgradePrecheck not supported by this version of Juju")
+	}
+	args := params.UpgradePrecheckArgs{TargetVersion: version}
+	var results params.UpgradePrecheckResults
+	if err := c.facade.FacadeCall("UpgradePrecheck", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
+// PinModelAgentVersion pins the model's agent version so that it can
+// only be upgraded to that version. The zero version removes the pin.
+func (c *Client) PinModelAgentVersion(version version.Number) error {
+	if c.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("PinModelAgentVersion not supported by this version of Juju")
+	}
+	args := params.PinModelAgentVersion{Version: version}
+	return errors.Trace(c.facade.FacadeCall("PinModelAgentVersion", args, nil))
+}
+
 // AbortCurrentUpgrade aborts and archives the current upgrade
 // synchronisation record, if any.
 func (c *Client) AbortCurrentUpgrade() error {
@@ -613,16 +613,18 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 	s.PatchValue(api.WebsocketDial, catcher.recordLocation)
 
 	params := common.DebugLogParams{
-		IncludeEntity: []string{"a", "b"},
-		IncludeModule: []string{"c", "d"},
-		ExcludeEntity: []string{"e", "f"},
-		ExcludeModule: []string{"g", "h"},
-		Limit:         100,
-		Backlog:       200,
-		Level:         loggo.ERROR,
-		Replay:        true,
-		NoTail:        true,
-		StartTime:     time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
+		IncludeEntity:  []string{"a", "b"},
+		IncludeModule:  []string{"c", "d"},
+		ExcludeEntity:  []string{"e", "f"},
+		ExcludeModule:  []string{"g", "h"},
+		IncludeMessage: "fail(ed|ure)",
+		IncludeLabels:  map[string]string{"stage": "dump"},
+		Limit:          100,
+		Backlog:        200,
+		Level:          loggo.ERROR,
+		Replay:         true,
+		NoTail:         true,
+		StartTime:      time.Date(2016, 11, 30, 11, 48, 0, 100, time.UTC),
 	}
 
 	client := s.APIState.Client()
@@ -634,16 +636,18 @@ func (s *clientSuite) TestWatchDebugLogParamsEncoded(c *gc.C) {
 
 	values := connectURL.Query()
 	c.Assert(values, jc.DeepEquals, url.Values{
-		"includeEntity": params.IncludeEntity,
-		"includeModule": params.IncludeModule,
-		"excludeEntity": params.ExcludeEntity,
-		"excludeModule": params.ExcludeModule,
-		"maxLines":      {"100"},
-		"backlog":       {"200"},
-		"level":         {"ERROR"},
-		"replay":        {"true"},
-		"noTail":        {"true"},
-		"startTime":     {"2016-11-30T11:48:00.0000001Z"},
+		"includeEntity":  params.IncludeEntity,
+		"includeModule":  params.IncludeModule,
+		"excludeEntity":  params.ExcludeEntity,
+		"excludeModule":  params.ExcludeModule,
+		"includeMessage": {"fail(ed|ure)"},
+		"includeLabel":   {"stage=dump"},
+		"maxLines":       {"100"},
+		"backlog":        {"200"},
+		"level":          {"ERROR"},
+		"replay":         {"true"},
+		"noTail":         {"true"},
+		"startTime":      {"2016-11-30T11:48:00.0000001Z"},
 	})
 }
 
@@ -871,3 +875,67 @@ func (s *IsolatedClientSuite) TestFindAllErrorsOnOlderController(c *gc.C) {
 	_, err := client.FindTools(0, 0, "", "", "proposed")
 	c.Assert(err, gc.ErrorMatches, "passing agent-stream not supported by the controller")
 }
+
+func (s *IsolatedClientSuite) TestUpgradePrecheckOlderController(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 2}
+	client := api.APIClient(apiCaller)
+	_, err := client.UpgradePrecheck(version.MustParse("2.7.0"))
+	c.Assert(err, gc.ErrorMatches, "UpgradePrecheck not supported by this version of Juju not supported")
+}
+
+func (s *IsolatedClientSuite) TestUpgradePrecheck(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string, _ int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Client")
+			c.Check(request, gc.Equals, "UpgradePrecheck")
+			c.Check(arg, jc.DeepEquals, params.UpgradePrecheckArgs{
+				TargetVersion: version.MustParse("2.7.0"),
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.UpgradePrecheckResults{})
+			*(result.(*params.UpgradePrecheckResults)) = params.UpgradePrecheckResults{
+				Results: []params.UpgradePrecheckResult{{
+					Name:     "provider-api",
+					Blocking: true,
+					Problems: []string{"boom"},
+				}},
+			}
+			return nil
+		},
+	}
+	client := api.APIClient(apiCaller)
+	results, err := client.UpgradePrecheck(version.MustParse("2.7.0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.UpgradePrecheckResult{{
+		Name:     "provider-api",
+		Blocking: true,
+		Problems: []string{"boom"},
+	}})
+}
+
+func (s *IsolatedClientSuite) TestPinModelAgentVersionOlderController(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 3}
+	client := api.APIClient(apiCaller)
+	err := client.PinModelAgentVersion(version.MustParse("2.5.4"))
+	c.Assert(err, gc.ErrorMatches, "PinModelAgentVersion not supported by this version of Juju not supported")
+}
+
+func (s *IsolatedClientSuite) TestPinModelAgentVersion(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: func(objType string, _ int, id, request string, arg, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Client")
+			c.Check(request, gc.Equals, "PinModelAgentVersion")
+			c.Check(arg, jc.DeepEquals, params.PinModelAgentVersion{
+				Version: version.MustParse("2.5.4"),
+			})
+			return nil
+		},
+	}
+	client := api.APIClient(apiCaller)
+	err := client.PinModelAgentVersion(version.MustParse("2.5.4"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
@@ -67,6 +67,31 @@ func (c *Client) Cloud(tag names.CloudTag) (jujucloud.Cloud, error) {
 	return common.CloudFromParams(tag.Id(), *results.Results[0].Cloud), nil
 }
 
+// CloudCapabilities returns the optional features that the provider
+// of the cloud with the given tag supports, and whether the provider
+// reports them at all.
+func (c *Client) CloudCapabilities(tag names.CloudTag) ([]string, bool, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 6 {
+		return nil, false, errors.NotSupportedf("CloudCapabilities for Cloud facade v%d", bestVer)
+	}
+	var results params.CloudCapabilitiesResults
+	args := params.Entities{[]params.Entity{{tag.String()}}}
+	if err := c.facade.FacadeCall("CloudCapabilities", args, &results); err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		if params.IsCodeNotFound(err) {
+			return nil, false, errors.NotFoundf("cloud %s", tag.Id())
+		}
+		return nil, false, err
+	}
+	result := results.Results[0].Result
+	return result.Capabilities, result.Reported, nil
+}
+
 // UserCredentials returns the tags for cloud credentials available to a user for
 // use with a specific cloud.
 func (c *Client) UserCredentials(user names.UserTag, cloud names.CloudTag) ([]names.CloudCredentialTag, error) {
@@ -61,6 +61,61 @@ func (s *cloudSuite) TestCloud(c *gc.C) {
 	})
 }
 
+func (s *cloudSuite) TestCloudCapabilities(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Cloud")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "CloudCapabilities")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "cloud-foo"}},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.CloudCapabilitiesResults{})
+				results := result.(*params.CloudCapabilitiesResults)
+				results.Results = append(results.Results, params.CloudCapabilitiesResult{
+					Result: &params.CloudCapabilities{
+						Reported:     true,
+						Capabilities: []string{"availability-zones", "spaces"},
+					},
+				})
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+
+	client := cloudapi.NewClient(apiCaller)
+	capabilities, reported, err := client.CloudCapabilities(names.NewCloudTag("foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reported, jc.IsTrue)
+	c.Assert(capabilities, jc.DeepEquals, []string{"availability-zones", "spaces"})
+}
+
+func (s *cloudSuite) TestCloudCapabilitiesNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fatalf("unexpected call to %s", request)
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+
+	client := cloudapi.NewClient(apiCaller)
+	_, _, err := client.CloudCapabilities(names.NewCloudTag("foo"))
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *cloudSuite) TestClouds(c *gc.C) {
 	apiCaller := basetesting.APICallerFunc(
 		func(objType string,
@@ -34,6 +34,12 @@ type DebugLogParams struct {
 	// ExcludeModule lists logging modules to exclude from the resposne. If a
 	// module is specified, all the submodules are also excluded.
 	ExcludeModule []string
+	// IncludeMessage is a regular expression which the messages in the
+	// response must match. If empty, all messages match.
+	IncludeMessage string
+	// IncludeLabels holds the labels the messages in the response must
+	// have. If none are set, all messages are considered included.
+	IncludeLabels map[string]string
 	// Limit defines the maximum number of lines to return. Once this many
 	// have been sent, the socket is closed.  If zero, all filtered lines are
 	// sent down the connection until the client closes the connection.
@@ -45,7 +51,8 @@ type DebugLogParams struct {
 	// Level specifies the minimum logging level to be sent back in the response.
 	Level loggo.Level
 	// Replay tells the server to start at the start of the log file rather
-	// than the end. If replay is true, backlog is ignored.
+	// than the end. If replay is true, backlog is ignored. The server
+	// bounds how many existing lines are replayed.
 	Replay bool
 	// NoTail tells the server to only return the logs it has now, and not
 	// to wait for new logs to arrive.
@@ -62,6 +69,12 @@ func (args DebugLogParams) URLQuery() url.Values {
 		"excludeEntity": args.ExcludeEntity,
 		"excludeModule": args.ExcludeModule,
 	}
+	if args.IncludeMessage != "" {
+		attrs.Set("includeMessage", args.IncludeMessage)
+	}
+	for key, value := range args.IncludeLabels {
+		attrs.Add("includeLabel", key+"="+value)
+	}
 	if args.Replay {
 		attrs.Set("replay", fmt.Sprint(args.Replay))
 	}
@@ -91,6 +104,7 @@ type LogMessage struct {
 	Module    string
 	Location  string
 	Message   string
+	Labels    map[string]string
 }
 
 // StreamDebugLog requests the specified debug log records from the
@@ -128,6 +142,7 @@ func StreamDebugLog(source base.StreamConnector, args DebugLogParams) (<-chan Lo
 				Module:    msg.Module,
 				Location:  msg.Location,
 				Message:   msg.Message,
+				Labels:    msg.Labels,
 			}
 		}
 	}()
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/api/common/cloudspec"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/permission"
@@ -267,6 +268,14 @@ type MigrationSpec struct {
 	TargetUser           string
 	TargetPassword       string
 	TargetMacaroons      []macaroon.Slice
+
+	// TargetRegion, if set, moves the model to another region of its
+	// cloud, provisioning its machines again there.
+	TargetRegion string
+
+	// Copy leaves the model running in the source controller, rather
+	// than removing it once migrated. It requires TargetRegion.
+	Copy bool
 }
 
 // Validate performs sanity checks on the migration configuration it
@@ -287,6 +296,9 @@ func (s *MigrationSpec) Validate() error {
 	if s.TargetPassword == "" && len(s.TargetMacaroons) == 0 {
 		return errors.NotValidf("missing authentication secrets")
 	}
+	if s.Copy && s.TargetRegion == "" {
+		return errors.NotValidf("copy without target region")
+	}
 	return nil
 }
 
@@ -305,6 +317,13 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 	if err != nil {
 		return "", errors.Annotatef(err, "client-side validation failed")
 	}
+	if spec.TargetRegion != "" && c.BestAPIVersion() < 14 {
+		return "", errors.New("this controller version doesn't support migrating models to another region")
+	}
+	var mode string
+	if spec.Copy {
+		mode = string(migration.ModeCopy)
+	}
 
 	args := params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
@@ -317,6 +336,8 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Password:      spec.TargetPassword,
 				Macaroons:     macsJSON,
 			},
+			TargetRegion: spec.TargetRegion,
+			Mode:         mode,
 		}},
 	}
 	response := params.InitiateMigrationResults{}
@@ -127,6 +127,53 @@ func specToArgs(spec controller.MigrationSpec) params.InitiateMigrationArgs {
 	}
 }
 
+func (s *Suite) TestInitiateMigrationToRegion(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, arg)
+			out := result.(*params.InitiateMigrationResults)
+			*out = params.InitiateMigrationResults{
+				Results: []params.InitiateMigrationResult{{MigrationId: "id"}},
+			}
+			return nil
+		},
+		BestVersion: 14,
+	}
+	client := controller.NewClient(apiCaller)
+	spec := makeSpec()
+	spec.TargetRegion = "us-west-2"
+	spec.Copy = true
+	id, err := client.InitiateMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(id, gc.Equals, "id")
+
+	args := specToArgs(spec)
+	args.Specs[0].TargetRegion = "us-west-2"
+	args.Specs[0].Mode = "copy"
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"Controller.InitiateMigration", []interface{}{args}},
+	})
+}
+
+func (s *Suite) TestInitiateMigrationToRegionNotSupported(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	spec := makeSpec()
+	spec.TargetRegion = "us-west-2"
+	_, err := client.InitiateMigration(spec)
+	c.Check(err, gc.ErrorMatches, "this controller version doesn't support migrating models to another region")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
+func (s *Suite) TestInitiateMigrationCopyWithoutRegion(c *gc.C) {
+	client, stub := makeInitiateMigrationClient(params.InitiateMigrationResults{})
+	spec := makeSpec()
+	spec.Copy = true
+	_, err := client.InitiateMigration(spec)
+	c.Check(err, gc.ErrorMatches, "client-side validation failed: copy without target region not valid")
+	c.Check(stub.Calls(), gc.HasLen, 0)
+}
+
 func (s *Suite) TestInitiateMigrationError(c *gc.C) {
 	client, _ := makeInitiateMigrationClient(params.InitiateMigrationResults{
 		Results: []params.InitiateMigrationResult{{
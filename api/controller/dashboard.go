@@ -0,0 +1,25 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// DashboardSummary returns the aggregated status of each model the
+// user has access to. If all is true and the user is a controller
+// superuser, the models of all users are included.
+func (c *Client) DashboardSummary(all bool) (params.DashboardSummaryResult, error) {
+	if c.BestAPIVersion() < 10 {
+		return params.DashboardSummaryResult{}, errors.NotSupportedf("DashboardSummary not supported by this version of Juju")
+	}
+	var result params.DashboardSummaryResult
+	args := params.DashboardSummaryArgs{All: all}
+	if err := c.facade.FacadeCall("DashboardSummary", args, &result); err != nil {
+		return params.DashboardSummaryResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
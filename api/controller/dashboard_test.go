@@ -0,0 +1,58 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestDashboardSummaryPriorV10(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.DashboardSummary(false)
+	c.Check(err, gc.ErrorMatches, "DashboardSummary not supported by this version of Juju not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestDashboardSummary(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, _ int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "DashboardSummary")
+			c.Check(arg, jc.DeepEquals, params.DashboardSummaryArgs{All: true})
+			c.Assert(result, gc.FitsTypeOf, &params.DashboardSummaryResult{})
+			*(result.(*params.DashboardSummaryResult)) = params.DashboardSummaryResult{
+				Models: []params.ModelDashboardSummary{{
+					ModelTag:   "model-" + modelUUID,
+					Name:       "busy",
+					UnitStatus: map[string]int{"error": 1},
+				}},
+				UnitStatus: map[string]int{"error": 1},
+			}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	result, err := client.DashboardSummary(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Models, gc.HasLen, 1)
+	c.Check(result.Models[0].Name, gc.Equals, "busy")
+	c.Check(result.UnitStatus, jc.DeepEquals, map[string]int{"error": 1})
+}
@@ -0,0 +1,36 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ModelsFullStatus returns the full status of each of the given
+// models, filtered by the patterns. The results are in the same order
+// as the models; the status of a model the user can't read holds an
+// error.
+func (c *Client) ModelsFullStatus(models []names.ModelTag, patterns []string) ([]params.ModelFullStatusResult, error) {
+	if c.BestAPIVersion() < 11 {
+		return nil, errors.NotSupportedf("ModelsFullStatus not supported by this version of Juju")
+	}
+	args := params.ModelsFullStatusArgs{
+		ModelTags: make([]string, len(models)),
+		Patterns:  patterns,
+	}
+	for i, tag := range models {
+		args.ModelTags[i] = tag.String()
+	}
+	var results params.ModelFullStatusResults
+	if err := c.facade.FacadeCall("ModelsFullStatus", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(models) {
+		return nil, errors.Errorf("expected %d results, got %d", len(models), len(results.Results))
+	}
+	return results.Results, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestModelsFullStatusPriorV11(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.ModelsFullStatus(nil, nil)
+	c.Check(err, gc.ErrorMatches, "ModelsFullStatus not supported by this version of Juju not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestModelsFullStatus(c *gc.C) {
+	modelTag := names.NewModelTag(modelUUID)
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, _ int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ModelsFullStatus")
+			c.Check(arg, jc.DeepEquals, params.ModelsFullStatusArgs{
+				ModelTags: []string{modelTag.String()},
+				Patterns:  []string{"mysql"},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ModelFullStatusResults{})
+			*(result.(*params.ModelFullStatusResults)) = params.ModelFullStatusResults{
+				Results: []params.ModelFullStatusResult{{
+					ModelTag: modelTag.String(),
+					Result:   &params.FullStatus{Model: params.ModelStatusInfo{Name: "busy"}},
+				}},
+			}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	results, err := client.ModelsFullStatus([]names.ModelTag{modelTag}, []string{"mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Check(results[0].Result.Model.Name, gc.Equals, "busy")
+}
+
+func (s *Suite) TestModelsFullStatusResultCount(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(objType string, _ int, id, request string, arg, result interface{}) error {
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.ModelsFullStatus([]names.ModelTag{names.NewModelTag(modelUUID)}, nil)
+	c.Assert(err, gc.ErrorMatches, "expected 1 results, got 0")
+}
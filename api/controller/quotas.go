@@ -0,0 +1,40 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ListQuotas returns the quotas set on models and users, along with
+// the resources each of them currently uses.
+func (c *Client) ListQuotas() ([]params.EntityQuota, error) {
+	if c.BestAPIVersion() < 13 {
+		return nil, errors.NotSupportedf("ListQuotas not supported by this version of Juju")
+	}
+	var result params.EntityQuotas
+	if err := c.facade.FacadeCall("ListQuotas", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
+
+// SetQuotas sets the quotas of the given models and users. Setting a
+// zero quota removes any limits.
+func (c *Client) SetQuotas(quotas []params.SetQuotaArg) error {
+	if c.BestAPIVersion() < 13 {
+		return errors.NotSupportedf("SetQuotas not supported by this version of Juju")
+	}
+	args := params.SetQuotasArgs{Args: quotas}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetQuotas", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(quotas) {
+		return errors.Errorf("expected %d results, got %d", len(quotas), len(results.Results))
+	}
+	return results.Combine()
+}
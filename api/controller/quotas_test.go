@@ -0,0 +1,85 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestQuotasPriorV13(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.ListQuotas()
+	c.Check(err, gc.ErrorMatches, "ListQuotas not supported by this version of Juju not supported")
+	err = client.SetQuotas(nil)
+	c.Check(err, gc.ErrorMatches, "SetQuotas not supported by this version of Juju not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestListQuotas(c *gc.C) {
+	quota := params.EntityQuota{
+		Tag:   "user-bob",
+		Name:  "bob",
+		Quota: params.Quota{Units: 10},
+		Usage: &params.QuotaUsage{Units: 3},
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, _ int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListQuotas")
+			c.Check(arg, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.EntityQuotas{})
+			*(result.(*params.EntityQuotas)) = params.EntityQuotas{
+				Results: []params.EntityQuota{quota},
+			}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	quotas, err := client.ListQuotas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quotas, jc.DeepEquals, []params.EntityQuota{quota})
+}
+
+func (s *Suite) TestSetQuotas(c *gc.C) {
+	args := []params.SetQuotaArg{{
+		Tag:   "user-bob",
+		Quota: params.Quota{Machines: 2},
+	}}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 13,
+		APICallerFunc: func(objType string, _ int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "SetQuotas")
+			c.Check(arg, jc.DeepEquals, params.SetQuotasArgs{Args: args})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}},
+			}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	err := client.SetQuotas(args)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,24 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// CompactRaftLogs asks each controller to snapshot its raft log and
+// returns the disk space used by each raft node afterwards.
+func (c *Client) CompactRaftLogs() ([]params.RaftStorageResult, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("CompactRaftLogs not supported by this version of Juju")
+	}
+	var results params.RaftStorageResults
+	err := c.facade.FacadeCall("CompactRaftLogs", nil, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestCompactRaftLogsPriorV8(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 7,
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.CompactRaftLogs()
+	c.Assert(err, gc.ErrorMatches, "CompactRaftLogs not supported by this version of Juju not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestCompactRaftLogsCallError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			return errors.New("boom")
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.CompactRaftLogs()
+	c.Check(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestCompactRaftLogs(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CompactRaftLogs")
+			c.Check(arg, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.RaftStorageResults{})
+
+			out := result.(*params.RaftStorageResults)
+			out.Results = []params.RaftStorageResult{{
+				MachineId:  "0",
+				LogBytes:   1024,
+				LogEntries: 12,
+			}, {
+				MachineId: "1",
+				Error:     &params.Error{Message: "no raft"},
+			}}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	results, err := client.CompactRaftLogs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.RaftStorageResult{{
+		MachineId:  "0",
+		LogBytes:   1024,
+		LogEntries: 12,
+	}, {
+		MachineId: "1",
+		Error:     &params.Error{Message: "no raft"},
+	}})
+}
@@ -0,0 +1,26 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+)
+
+const schemaPath = "/schema"
+
+// FacadeSchemas returns the JSON schemas of the methods of every facade
+// served by the controller, as generated from its facade registry.
+func (c *Client) FacadeSchemas() (json.RawMessage, error) {
+	httpClient, err := c.facade.RawAPICaller().HTTPClient()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot retrieve HTTP client")
+	}
+	var resp json.RawMessage
+	if err = httpClient.Get(schemaPath, &resp); err != nil {
+		return nil, errors.Annotate(err, "cannot retrieve facade schemas")
+	}
+	return resp, nil
+}
@@ -0,0 +1,38 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/controller"
+)
+
+func (s *Suite) TestFacadeSchemas(c *gc.C) {
+	response := []map[string]interface{}{{
+		"name":    "Client",
+		"version": 2,
+	}}
+	withHTTPClient(c, "/schema", "GET", func(w http.ResponseWriter, req *http.Request) {
+		defer req.Body.Close()
+		sendJSONResponse(c, w, response)
+	}, func(client *controller.Client) {
+		schemas, err := client.FacadeSchemas()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(string(schemas), gc.Equals, `[{"name":"Client","version":2}]`)
+	})
+}
+
+func (s *Suite) TestFacadeSchemasError(c *gc.C) {
+	withHTTPClient(c, "/schema", "GET", func(w http.ResponseWriter, req *http.Request) {
+		defer req.Body.Close()
+		w.WriteHeader(http.StatusForbidden)
+	}, func(client *controller.Client) {
+		_, err := client.FacadeSchemas()
+		c.Assert(err, gc.ErrorMatches, "cannot retrieve facade schemas: .*")
+	})
+}
@@ -0,0 +1,40 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ListSessions returns the API connections that have logged in to any
+// of the controller's API servers.
+func (c *Client) ListSessions() ([]params.APISession, error) {
+	if c.BestAPIVersion() < 12 {
+		return nil, errors.NotSupportedf("ListSessions not supported by this version of Juju")
+	}
+	var result params.APISessionsResult
+	if err := c.facade.FacadeCall("ListSessions", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Sessions, nil
+}
+
+// RevokeSessions closes the given API connections, on whichever API
+// server they are connected to, and revokes the users' login macaroons.
+func (c *Client) RevokeSessions(sessions []params.RevokeSession) error {
+	if c.BestAPIVersion() < 12 {
+		return errors.NotSupportedf("RevokeSessions not supported by this version of Juju")
+	}
+	args := params.RevokeSessionsArgs{Sessions: sessions}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RevokeSessions", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(sessions) {
+		return errors.Errorf("expected %d results, got %d", len(sessions), len(results.Results))
+	}
+	return results.Combine()
+}
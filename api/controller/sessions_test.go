@@ -0,0 +1,86 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *Suite) TestSessionsPriorV12(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.ListSessions()
+	c.Check(err, gc.ErrorMatches, "ListSessions not supported by this version of Juju not supported")
+	err = client.RevokeSessions(nil)
+	c.Check(err, gc.ErrorMatches, "RevokeSessions not supported by this version of Juju not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestListSessions(c *gc.C) {
+	session := params.APISession{
+		Server:       "0",
+		ConnectionID: 42,
+		Tag:          "user-bob",
+		RemoteAddr:   "10.0.0.1:34567",
+		LoggedIn:     time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, _ int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ListSessions")
+			c.Check(arg, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.APISessionsResult{})
+			*(result.(*params.APISessionsResult)) = params.APISessionsResult{
+				Sessions: []params.APISession{session},
+			}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	sessions, err := client.ListSessions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sessions, jc.DeepEquals, []params.APISession{session})
+}
+
+func (s *Suite) TestRevokeSessions(c *gc.C) {
+	sessions := []params.RevokeSession{
+		{Server: "0", ConnectionID: 42},
+		{UserTag: "user-bob"},
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 12,
+		APICallerFunc: func(objType string, _ int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "RevokeSessions")
+			c.Check(arg, jc.DeepEquals, params.RevokeSessionsArgs{Sessions: sessions})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	err := client.RevokeSessions(sessions)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
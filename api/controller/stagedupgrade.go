@@ -0,0 +1,59 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// StartStagedUpgrade starts upgrading the given models to the target
+// version, one wave at a time. Each wave must upgrade within the wave
+// timeout, with no agents in error, for the next one to start.
+func (c *Client) StartStagedUpgrade(target version.Number, waves [][]names.ModelTag, waveTimeout time.Duration) error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("StartStagedUpgrade not supported by this version of Juju")
+	}
+	args := params.StartStagedUpgradeArgs{
+		TargetVersion: target,
+		Waves:         make([][]string, len(waves)),
+		WaveTimeout:   waveTimeout,
+	}
+	for i, wave := range waves {
+		for _, tag := range wave {
+			args.Waves[i] = append(args.Waves[i], tag.String())
+		}
+	}
+	return errors.Trace(c.facade.FacadeCall("StartStagedUpgrade", args, nil))
+}
+
+// StagedUpgradeStatus returns the progress of the latest staged
+// upgrade. The error satisfies params.IsCodeNotFound if no staged
+// upgrade was started.
+func (c *Client) StagedUpgradeStatus() (params.StagedUpgradeStatus, error) {
+	if c.BestAPIVersion() < 9 {
+		return params.StagedUpgradeStatus{}, errors.NotSupportedf("StagedUpgradeStatus not supported by this version of Juju")
+	}
+	var result params.StagedUpgradeStatusResult
+	if err := c.facade.FacadeCall("StagedUpgradeStatus", nil, &result); err != nil {
+		return params.StagedUpgradeStatus{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.StagedUpgradeStatus{}, result.Error
+	}
+	return *result.Result, nil
+}
+
+// AbortStagedUpgrade stops the running staged upgrade.
+func (c *Client) AbortStagedUpgrade() error {
+	if c.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("AbortStagedUpgrade not supported by this version of Juju")
+	}
+	return errors.Trace(c.facade.FacadeCall("AbortStagedUpgrade", nil, nil))
+}
@@ -0,0 +1,102 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const modelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+func (s *Suite) TestStagedUpgradePriorV9(c *gc.C) {
+	called := false
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			called = true
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	err := client.StartStagedUpgrade(version.MustParse("2.6.1"), nil, time.Hour)
+	c.Check(err, gc.ErrorMatches, "StartStagedUpgrade not supported by this version of Juju not supported")
+	_, err = client.StagedUpgradeStatus()
+	c.Check(err, gc.ErrorMatches, "StagedUpgradeStatus not supported by this version of Juju not supported")
+	err = client.AbortStagedUpgrade()
+	c.Check(err, gc.ErrorMatches, "AbortStagedUpgrade not supported by this version of Juju not supported")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *Suite) TestStartStagedUpgrade(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, _ int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "StartStagedUpgrade")
+			c.Check(arg, jc.DeepEquals, params.StartStagedUpgradeArgs{
+				TargetVersion: version.MustParse("2.6.1"),
+				Waves:         [][]string{{"model-" + modelUUID}},
+				WaveTimeout:   time.Hour,
+			})
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	err := client.StartStagedUpgrade(version.MustParse("2.6.1"),
+		[][]names.ModelTag{{names.NewModelTag(modelUUID)}}, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *Suite) TestStagedUpgradeStatus(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, _ int, id, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "StagedUpgradeStatus")
+			c.Assert(result, gc.FitsTypeOf, &params.StagedUpgradeStatusResult{})
+			*(result.(*params.StagedUpgradeStatusResult)) = params.StagedUpgradeStatusResult{
+				Result: &params.StagedUpgradeStatus{
+					TargetVersion: version.MustParse("2.6.1"),
+					Status:        "running",
+				},
+			}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	status, err := client.StagedUpgradeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, params.StagedUpgradeStatus{
+		TargetVersion: version.MustParse("2.6.1"),
+		Status:        "running",
+	})
+}
+
+func (s *Suite) TestStagedUpgradeStatusNotFound(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(_ string, _ int, _, _ string, _, result interface{}) error {
+			*(result.(*params.StagedUpgradeStatusResult)) = params.StagedUpgradeStatusResult{
+				Error: &params.Error{Code: params.CodeNotFound, Message: "staged upgrade not found"},
+			}
+			return nil
+		},
+	}
+
+	client := controller.NewClient(apiCaller)
+	_, err := client.StagedUpgradeStatus()
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
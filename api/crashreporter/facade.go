@@ -0,0 +1,44 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crashreporter implements the client-side API facade used
+// by the crashreporter worker.
+package crashreporter
+
+import (
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crashreport"
+)
+
+// Facade provides access to the CrashReporter API facade.
+type Facade struct {
+	caller base.FacadeCaller
+}
+
+// NewFacade creates a new client-side CrashReporter facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{
+		caller: base.NewFacadeCaller(caller, "CrashReporter"),
+	}
+}
+
+// Upload uploads a crash report of the agent to the controller.
+// Uploading a report more than once is harmless.
+func (f *Facade) Upload(r crashreport.Report) error {
+	args := params.CrashReports{Reports: []params.CrashReport{{
+		ID:         r.ID,
+		Agent:      r.Agent,
+		Version:    r.Version,
+		Time:       r.Time,
+		Panic:      r.Panic,
+		Goroutines: r.Goroutines,
+		LogTail:    r.LogTail,
+	}}}
+	var result params.ErrorResults
+	err := f.caller.FacadeCall("Upload", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
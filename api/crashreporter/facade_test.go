@@ -0,0 +1,103 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter_test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/crashreporter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crashreport"
+)
+
+type facadeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+var report = crashreport.Report{
+	ID:         "20190503-101112-deadbeef",
+	Agent:      "machine-0",
+	Version:    "2.6.1",
+	Time:       time.Date(2019, 5, 3, 10, 11, 12, 0, time.UTC),
+	Panic:      "panic: boom",
+	Goroutines: "goroutine 1 [running]:",
+	LogTail:    "log\n",
+}
+
+func (s *facadeSuite) TestUpload(c *gc.C) {
+	stub := new(testing.Stub)
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		c.Check(objType, gc.Equals, "CrashReporter")
+		c.Check(version, gc.Equals, 0)
+		c.Check(id, gc.Equals, "")
+		stub.AddCall(request, args)
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	facade := crashreporter.NewFacade(apiCaller)
+
+	err := facade.Upload(report)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCalls(c, []testing.StubCall{{
+		"Upload", []interface{}{params.CrashReports{
+			Reports: []params.CrashReport{{
+				ID:         "20190503-101112-deadbeef",
+				Agent:      "machine-0",
+				Version:    "2.6.1",
+				Time:       time.Date(2019, 5, 3, 10, 11, 12, 0, time.UTC),
+				Panic:      "panic: boom",
+				Goroutines: "goroutine 1 [running]:",
+				LogTail:    "log\n",
+			}},
+		}},
+	}})
+}
+
+func (s *facadeSuite) TestCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		return errors.New("blam")
+	})
+	facade := crashreporter.NewFacade(apiCaller)
+
+	err := facade.Upload(report)
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
+
+func (s *facadeSuite) TestInnerError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string, version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		*response.(*params.ErrorResults) = params.ErrorResults{
+			Results: []params.ErrorResult{{
+				&params.Error{Message: "blam"},
+			}},
+		}
+		return nil
+	})
+	facade := crashreporter.NewFacade(apiCaller)
+
+	err := facade.Upload(report)
+	c.Assert(err, gc.ErrorMatches, "blam")
+}
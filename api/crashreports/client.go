@@ -0,0 +1,51 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreports
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the CrashReports API facade, which lists
+// and returns the crash reports uploaded by the agents of a model.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new CrashReports client.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "CrashReports")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// List returns information about the crash reports of the model,
+// oldest first.
+func (c *Client) List() ([]params.CrashReportInfo, error) {
+	var result params.CrashReportInfos
+	if err := c.facade.FacadeCall("List", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Reports, nil
+}
+
+// Get returns the crash report with the given ID.
+func (c *Client) Get(id string) (params.CrashReport, error) {
+	args := params.CrashReportIDs{IDs: []string{id}}
+	var results params.CrashReportResults
+	if err := c.facade.FacadeCall("Get", args, &results); err != nil {
+		return params.CrashReport{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.CrashReport{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.CrashReport{}, result.Error
+	}
+	return *result.Report, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreports_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/crashreports"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type crashReportsSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&crashReportsSuite{})
+
+var crashTime = time.Date(2019, 5, 3, 10, 11, 12, 0, time.UTC)
+
+func (s *crashReportsSuite) TestList(c *gc.C) {
+	expected := []params.CrashReportInfo{{
+		ID:       "20190503-101112-deadbeef",
+		Agent:    "machine-0",
+		Version:  "2.6.1",
+		Time:     crashTime,
+		Panic:    "panic: boom",
+		Size:     1024,
+		Uploaded: crashTime.Add(time.Minute),
+	}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CrashReports")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "List")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.CrashReportInfos)) = params.CrashReportInfos{Reports: expected}
+		return nil
+	})
+	infos, err := crashreports.NewClient(apiCaller).List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, jc.DeepEquals, expected)
+}
+
+func (s *crashReportsSuite) TestGet(c *gc.C) {
+	expected := params.CrashReport{
+		ID:         "20190503-101112-deadbeef",
+		Agent:      "machine-0",
+		Version:    "2.6.1",
+		Time:       crashTime,
+		Panic:      "panic: boom",
+		Goroutines: "goroutine 1 [running]:",
+		LogTail:    "log\n",
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CrashReports")
+		c.Check(request, gc.Equals, "Get")
+		c.Check(arg, jc.DeepEquals, params.CrashReportIDs{IDs: []string{"20190503-101112-deadbeef"}})
+		*(result.(*params.CrashReportResults)) = params.CrashReportResults{
+			Results: []params.CrashReportResult{{Report: &expected}},
+		}
+		return nil
+	})
+	r, err := crashreports.NewClient(apiCaller).Get("20190503-101112-deadbeef")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r, jc.DeepEquals, expected)
+}
+
+func (s *crashReportsSuite) TestGetError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.CrashReportResults)) = params.CrashReportResults{
+			Results: []params.CrashReportResult{{
+				Error: &params.Error{Message: `crash report "missing" not found`, Code: params.CodeNotFound},
+			}},
+		}
+		return nil
+	})
+	_, err := crashreports.NewClient(apiCaller).Get("missing")
+	c.Assert(err, gc.ErrorMatches, `crash report "missing" not found`)
+}
@@ -0,0 +1,64 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnspublisher
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+const dnsPublisherFacade = "DNSPublisher"
+
+// API provides access to the DNSPublisher API facade.
+type API struct {
+	*common.ModelWatcher
+
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side DNSPublisher facade.
+func NewAPI(caller base.APICaller) *API {
+	if caller == nil {
+		panic("caller is nil")
+	}
+	facadeCaller := base.NewFacadeCaller(caller, dnsPublisherFacade)
+	return &API{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+var newStringsWatcher = apiwatcher.NewStringsWatcher
+
+// WatchApplications returns a StringsWatcher that notifies of changes
+// to the lifecycles of the model's applications.
+func (api *API) WatchApplications() (watcher.StringsWatcher, error) {
+	var result params.StringsWatchResult
+	err := api.facade.FacadeCall("WatchApplications", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return newStringsWatcher(api.facade.RawAPICaller(), result), nil
+}
+
+// Applications returns the model's alive applications, along with the
+// public addresses of their alive units.
+func (api *API) Applications() ([]params.DNSApplication, error) {
+	var result params.DNSApplicationsResult
+	if err := api.facade.FacadeCall("Applications", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Applications, nil
+}
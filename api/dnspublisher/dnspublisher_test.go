@@ -0,0 +1,117 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnspublisher_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/dnspublisher"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/watcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type DNSPublisherSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&DNSPublisherSuite{})
+
+func (s *DNSPublisherSuite) TestNewAPIWithNilCaller(c *gc.C) {
+	panicFunc := func() { dnspublisher.NewAPI(nil) }
+	c.Assert(panicFunc, gc.PanicMatches, "caller is nil")
+}
+
+func (s *DNSPublisherSuite) TestWatchApplicationsSuccess(c *gc.C) {
+	var numWatcherCalls int
+	expectResult := params.StringsWatchResult{
+		StringsWatcherId: "42",
+		Changes:          []string{"mysql", "wordpress"},
+	}
+	watcherFunc := func(caller base.APICaller, result params.StringsWatchResult) watcher.StringsWatcher {
+		numWatcherCalls++
+		c.Check(caller, gc.NotNil)
+		c.Check(result, jc.DeepEquals, expectResult)
+		return nil
+	}
+	s.PatchValue(dnspublisher.NewStringsWatcher, watcherFunc)
+
+	apiCaller := successAPICaller(c, "WatchApplications", nil, expectResult)
+	api := dnspublisher.NewAPI(apiCaller)
+	w, err := api.WatchApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiCaller.CallCount, gc.Equals, 1)
+	c.Assert(numWatcherCalls, gc.Equals, 1)
+	c.Assert(w, gc.IsNil)
+}
+
+func (s *DNSPublisherSuite) TestWatchApplicationsServerError(c *gc.C) {
+	expectResult := params.StringsWatchResult{
+		Error: apiservertesting.ServerError("server boom!"),
+	}
+	apiCaller := successAPICaller(c, "WatchApplications", nil, expectResult)
+	api := dnspublisher.NewAPI(apiCaller)
+	w, err := api.WatchApplications()
+	c.Assert(err, gc.ErrorMatches, "server boom!")
+	c.Assert(w, gc.IsNil)
+}
+
+func (s *DNSPublisherSuite) TestApplications(c *gc.C) {
+	expectApps := []params.DNSApplication{{
+		Name: "wordpress",
+		Units: []params.DNSUnit{{
+			Name:    "wordpress/0",
+			Address: "203.0.113.10",
+		}},
+	}}
+	apiCaller := successAPICaller(c, "Applications", nil, params.DNSApplicationsResult{
+		Applications: expectApps,
+	})
+	api := dnspublisher.NewAPI(apiCaller)
+	apps, err := api.Applications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiCaller.CallCount, gc.Equals, 1)
+	c.Assert(apps, jc.DeepEquals, expectApps)
+}
+
+func (s *DNSPublisherSuite) TestApplicationsClientError(c *gc.C) {
+	apiCaller := apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "DNSPublisher",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "Applications",
+		Error:         errors.New("client error!"),
+	})
+	api := dnspublisher.NewAPI(apiCaller)
+	apps, err := api.Applications()
+	c.Assert(err, gc.ErrorMatches, "client error!")
+	c.Assert(apps, gc.IsNil)
+}
+
+func (s *DNSPublisherSuite) TestApplicationsServerError(c *gc.C) {
+	apiCaller := successAPICaller(c, "Applications", nil, params.DNSApplicationsResult{
+		Error: apiservertesting.ServerError("server boom!"),
+	})
+	api := dnspublisher.NewAPI(apiCaller)
+	apps, err := api.Applications()
+	c.Assert(err, gc.ErrorMatches, "server boom!")
+	c.Assert(apps, gc.IsNil)
+}
+
+func successAPICaller(c *gc.C, method string, expectArgs, useResults interface{}) *apitesting.CallChecker {
+	return apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "DNSPublisher",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        method,
+		Args:          expectArgs,
+		Results:       useResults,
+	})
+}
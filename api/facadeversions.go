@@ -11,19 +11,22 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       3,
+	"Action":                       4,
 	"ActionPruner":                 1,
+	"Advisor":                      1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  9,
+	"Application":                  20,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
-	"Backups":                      2,
+	"Autoscale":                    1,
+	"Autoscaler":                   1,
+	"Backups":                      4,
 	"Block":                        2,
-	"Bundle":                       2,
+	"Bundle":                       3,
 	"CAASAgent":                    1,
 	"CAASFirewaller":               1,
 	"CAASOperator":                 1,
@@ -33,23 +36,27 @@ var facadeVersions = map[string]int{
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       2,
-	"Cloud":                        5,
-	"Controller":                   7,
+	"Client":                       4,
+	"Cloud":                        6,
+	"Controller":                   14,
+	"CrashReporter":                1,
+	"CrashReports":                 1,
 	"CredentialManager":            1,
 	"CredentialValidator":          2,
 	"CrossController":              1,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
 	"DiskManager":                  2,
+	"DNSPublisher":                 1,
 	"EntityWatcher":                2,
 	"ExternalControllerUpdater":    1,
 	"FanConfigurer":                1,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   5,
+	"Firewaller":                   6,
 	"FirewallRules":                1,
-	"HighAvailability":             2,
+	"HighAvailability":             3,
 	"HostKeyReporter":              1,
+	"IdleAdvisor":                  1,
 	"ImageManager":                 2,
 	"ImageMetadata":                3,
 	"ImageMetadataManager":         1,
@@ -62,7 +69,7 @@ var facadeVersions = map[string]int{
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               5,
+	"MachineManager":               6,
 	"MachineUndertaker":            1,
 	"Machiner":                     1,
 	"MeterStatus":                  1,
@@ -73,13 +80,16 @@ var facadeVersions = map[string]int{
 	"MigrationMaster":              1,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
-	"ModelConfig":                  2,
+	"MigrationTarget":              4,
+	"ModelConfig":                  3,
 	"ModelGeneration":              1,
-	"ModelManager":                 7,
+	"ModelManager":                 8,
+	"ModelPlan":                    1,
 	"ModelUpgrader":                1,
 	"NotifyWatcher":                1,
 	"OfferStatusWatcher":           1,
+	"OrphanFinder":                 1,
+	"Orphans":                      1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
 	"Pinger":                       1,
@@ -95,16 +105,19 @@ var facadeVersions = map[string]int{
 	"RetryStrategy":                1,
 	"Singular":                     2,
 	"Spaces":                       3,
+	"SpareMachines":                1,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      5,
-	"StorageProvisioner":           4,
+	"Storage":                      6,
+	"StorageProvisioner":           5,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
+	"TagReconciler":                1,
+	"Timeline":                     2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       11,
-	"Upgrader":                     1,
+	"Uniter":                       13,
+	"Upgrader":                     2,
 	"UpgradeSeries":                1,
 	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/network"
 )
 
 // Application represents the state of an application.
@@ -63,3 +64,58 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// LoadBalancer returns whether this application is exposed through a
+// load balancer, and the addresses recorded for its load balancer. The
+// addresses remain recorded after the application is unexposed, until
+// the load balancer has been removed. It returns false and no addresses
+// if the Firewaller facade does not support load balancers.
+func (s *Application) LoadBalancer() (bool, []network.Address, error) {
+	if s.st.BestAPIVersion() < 6 {
+		return false, nil, nil
+	}
+	var results params.ApplicationLoadBalancerResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetLoadBalancers", args, &results)
+	if err != nil {
+		return false, nil, err
+	}
+	if len(results.Results) != 1 {
+		return false, nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		if params.IsCodeNotFound(result.Error) {
+			return false, nil, errors.NewNotFound(result.Error, "")
+		}
+		return false, nil, result.Error
+	}
+	var addrs []network.Address
+	if len(result.Addresses) > 0 {
+		addrs = params.NetworkAddresses(result.Addresses...)
+	}
+	return result.LoadBalanced, addrs, nil
+}
+
+// SetLoadBalancerAddresses records the addresses of this application's
+// load balancer. Recording no addresses marks the load balancer as
+// removed.
+func (s *Application) SetLoadBalancerAddresses(addrs []network.Address) error {
+	if apiVersion := s.st.BestAPIVersion(); apiVersion < 6 {
+		return errors.NotSupportedf("SetLoadBalancerAddresses for Firewaller facade v%v", apiVersion)
+	}
+	var results params.ErrorResults
+	args := params.SetApplicationsAddresses{
+		ApplicationAddresses: []params.ApplicationAddresses{{
+			Tag:       s.tag.String(),
+			Addresses: params.FromNetworkAddresses(addrs...),
+		}},
+	}
+	err := s.st.facade.FacadeCall("SetLoadBalancerAddresses", args, &results)
+	if err != nil {
+		return err
+	}
+	return results.OneError()
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/network"
 )
 
 type applicationSuite struct {
@@ -78,3 +79,27 @@ func (s *applicationSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *applicationSuite) TestLoadBalancer(c *gc.C) {
+	loadBalanced, addrs, err := s.apiApplication.LoadBalancer()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(loadBalanced, jc.IsFalse)
+	c.Assert(addrs, gc.HasLen, 0)
+
+	err = s.application.SetExposedWithLoadBalancer()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiApplication.SetLoadBalancerAddresses(network.NewAddresses("203.0.113.10"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	loadBalanced, addrs, err = s.apiApplication.LoadBalancer()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(loadBalanced, jc.IsTrue)
+	c.Assert(addrs, jc.DeepEquals, network.NewAddresses("203.0.113.10"))
+
+	err = s.application.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	loadBalanced, addrs, err = s.apiApplication.LoadBalancer()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(loadBalanced, jc.IsFalse)
+	c.Assert(addrs, gc.HasLen, 1)
+}
@@ -111,6 +111,30 @@ func (m *Machine) ActiveSubnets() ([]names.SubnetTag, error) {
 // OpenedPorts returns a map of network.PortRange to unit tag for all opened
 // port ranges on the machine for the subnet matching given subnetTag.
 func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]names.UnitTag, error) {
+	portRanges, err := m.OpenedPortRanges(subnetTag)
+	if err != nil {
+		return nil, err
+	}
+	endResult := make(map[network.PortRange]names.UnitTag)
+	for _, portRange := range portRanges {
+		endResult[portRange.PortRange] = portRange.UnitTag
+	}
+	return endResult, nil
+}
+
+// MachinePortRange is a port range opened on a machine, with the unit
+// that opened it and the endpoint it was opened for. An empty endpoint
+// means all of the unit's endpoints.
+type MachinePortRange struct {
+	UnitTag   names.UnitTag
+	Endpoint  string
+	PortRange network.PortRange
+}
+
+// OpenedPortRanges returns all opened port ranges on the machine for the
+// subnet matching given subnetTag, with the units that opened them and
+// the endpoints they were opened for.
+func (m *Machine) OpenedPortRanges(subnetTag names.SubnetTag) ([]MachinePortRange, error) {
 	var results params.MachinePortsResults
 	var subnetTagAsString string
 	if subnetTag.Id() != "" {
@@ -133,13 +157,17 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 		return nil, result.Error
 	}
 	// Convert string tags to names.UnitTag before returning.
-	endResult := make(map[network.PortRange]names.UnitTag)
-	for _, ports := range result.Ports {
+	endResult := make([]MachinePortRange, len(result.Ports))
+	for i, ports := range result.Ports {
 		unitTag, err := names.ParseUnitTag(ports.UnitTag)
 		if err != nil {
 			return nil, err
 		}
-		endResult[ports.PortRange.NetworkPortRange()] = unitTag
+		endResult[i] = MachinePortRange{
+			UnitTag:   unitTag,
+			Endpoint:  ports.Endpoint,
+			PortRange: ports.PortRange.NetworkPortRange(),
+		}
 	}
 	return endResult, nil
 }
@@ -83,3 +83,51 @@ func (c *Client) ResumeHAReplicationAfterUpgrade(members []replicaset.Member) er
 	}
 	return nil
 }
+
+// CheckMongoUpgrade reports whether the controller's database can be
+// migrated to the target version and storage engine, and estimates
+// how long the migration would take.
+func (c *Client) CheckMongoUpgrade(target mongo.Version) (params.MongoUpgradeCheckResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.MongoUpgradeCheckResult{}, errors.NotSupportedf("checking mongo upgrades")
+	}
+	var result params.MongoUpgradeCheckResult
+	if err := c.facade.FacadeCall("CheckMongoUpgrade", upgradeMongoParams(target), &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// StartMongoUpgrade requests that the controller's database is
+// migrated to the target version and storage engine. The controllers
+// start migrating their databases, one at a time, straight away.
+func (c *Client) StartMongoUpgrade(target mongo.Version) error {
+	if c.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("starting mongo upgrades")
+	}
+	return errors.Trace(c.facade.FacadeCall("StartMongoUpgrade", upgradeMongoParams(target), nil))
+}
+
+// MongoUpgradeStatus returns the progress of the latest migration of
+// the controller's database.
+func (c *Client) MongoUpgradeStatus() (params.MongoUpgradeStatusResult, error) {
+	if c.BestAPIVersion() < 3 {
+		return params.MongoUpgradeStatusResult{}, errors.NotSupportedf("mongo upgrade status")
+	}
+	var result params.MongoUpgradeStatusResult
+	if err := c.facade.FacadeCall("MongoUpgradeStatus", nil, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+func upgradeMongoParams(v mongo.Version) params.UpgradeMongoParams {
+	return params.UpgradeMongoParams{
+		Target: params.MongoVersion{
+			Major:         v.Major,
+			Minor:         v.Minor,
+			Patch:         v.Patch,
+			StorageEngine: string(v.StorageEngine),
+		},
+	}
+}
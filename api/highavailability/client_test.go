@@ -10,6 +10,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/api/highavailability"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/constraints"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
@@ -87,3 +88,9 @@ func (s *clientSuite) TestClientEnableHAVersion(c *gc.C) {
 	client := highavailability.NewClient(s.APIState)
 	c.Assert(client.BestAPIVersion(), gc.Equals, 2)
 }
+
+func (s *clientSuite) TestMongoUpgradeStatusNotStarted(c *gc.C) {
+	client := highavailability.NewClient(s.APIState)
+	_, err := client.MongoUpgradeStatus()
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package idleadvisor
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const idleAdvisorFacade = "IdleAdvisor"
+
+// MachineUtilisation holds the number of units and containers on a
+// machine.
+type MachineUtilisation struct {
+	Tag        names.MachineTag
+	Units      int
+	Containers int
+}
+
+// UnitUtilisation holds the highest value of the utilisation metric
+// recorded for a unit. Peak is nil if no values were recorded.
+type UnitUtilisation struct {
+	Tag  names.UnitTag
+	Peak *float64
+}
+
+// ModelUtilisation holds the utilisation of a model's machines and
+// units.
+type ModelUtilisation struct {
+	Machines []MachineUtilisation
+	Units    []UnitUtilisation
+}
+
+// IdleResource records that a machine or unit has been idle since a
+// given time.
+type IdleResource struct {
+	Entity names.Tag
+	Since  time.Time
+	Reason string
+}
+
+// API provides access to the IdleAdvisor API facade.
+type API struct {
+	*common.ModelWatcher
+
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side IdleAdvisor facade.
+func NewAPI(caller base.APICaller) *API {
+	if caller == nil {
+		panic("caller is nil")
+	}
+	facadeCaller := base.NewFacadeCaller(caller, idleAdvisorFacade)
+	return &API{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// ModelUtilisation returns the number of units and containers on each
+// of the model's machines, and the highest value of the given charm
+// metric recorded since the given time by each of the model's units.
+func (api *API) ModelUtilisation(metric string, since time.Time) (ModelUtilisation, error) {
+	args := params.ModelUtilisationArgs{
+		Metric: metric,
+		Since:  since,
+	}
+	var result params.ModelUtilisationResult
+	if err := api.facade.FacadeCall("ModelUtilisation", args, &result); err != nil {
+		return ModelUtilisation{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return ModelUtilisation{}, result.Error
+	}
+	var utilisation ModelUtilisation
+	for _, m := range result.Result.Machines {
+		tag, err := names.ParseMachineTag(m.Tag)
+		if err != nil {
+			return ModelUtilisation{}, errors.Trace(err)
+		}
+		utilisation.Machines = append(utilisation.Machines, MachineUtilisation{
+			Tag:        tag,
+			Units:      m.Units,
+			Containers: m.Containers,
+		})
+	}
+	for _, u := range result.Result.Units {
+		tag, err := names.ParseUnitTag(u.Tag)
+		if err != nil {
+			return ModelUtilisation{}, errors.Trace(err)
+		}
+		utilisation.Units = append(utilisation.Units, UnitUtilisation{
+			Tag:  tag,
+			Peak: u.Peak,
+		})
+	}
+	return utilisation, nil
+}
+
+// IdleResources returns the model's idle machines and units.
+func (api *API) IdleResources() ([]IdleResource, error) {
+	var result params.IdleResourcesResult
+	if err := api.facade.FacadeCall("IdleResources", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	resources := make([]IdleResource, len(result.Resources))
+	for i, r := range result.Resources {
+		tag, err := names.ParseTag(r.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		resources[i] = IdleResource{
+			Entity: tag,
+			Since:  r.Since,
+			Reason: r.Reason,
+		}
+	}
+	return resources, nil
+}
+
+// SetIdleResources replaces the model's idle machines and units.
+func (api *API) SetIdleResources(resources []IdleResource) error {
+	args := params.IdleResources{
+		Resources: make([]params.IdleResource, len(resources)),
+	}
+	for i, r := range resources {
+		args.Resources[i] = params.IdleResource{
+			Tag:    r.Entity.String(),
+			Since:  r.Since,
+			Reason: r.Reason,
+		}
+	}
+	var result params.ErrorResult
+	if err := api.facade.FacadeCall("SetIdleResources", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
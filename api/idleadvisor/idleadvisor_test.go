@@ -0,0 +1,106 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package idleadvisor_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/idleadvisor"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type IdleAdvisorSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&IdleAdvisorSuite{})
+
+func (s *IdleAdvisorSuite) TestNewAPIWithNilCaller(c *gc.C) {
+	panicFunc := func() { idleadvisor.NewAPI(nil) }
+	c.Assert(panicFunc, gc.PanicMatches, "caller is nil")
+}
+
+func (s *IdleAdvisorSuite) TestModelUtilisation(c *gc.C) {
+	since := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	peak := 2.5
+	apiCaller := apiCaller(c, "ModelUtilisation", params.ModelUtilisationArgs{
+		Metric: "cpu",
+		Since:  since,
+	}, params.ModelUtilisationResult{
+		Result: params.ModelUtilisation{
+			Machines: []params.MachineUtilisation{{Tag: "machine-1", Units: 2, Containers: 1}},
+			Units:    []params.UnitUtilisation{{Tag: "unit-mysql-0", Peak: &peak}},
+		},
+	})
+	api := idleadvisor.NewAPI(apiCaller)
+	utilisation, err := api.ModelUtilisation("cpu", since)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiCaller.CallCount, gc.Equals, 1)
+	c.Assert(utilisation, jc.DeepEquals, idleadvisor.ModelUtilisation{
+		Machines: []idleadvisor.MachineUtilisation{{Tag: names.NewMachineTag("1"), Units: 2, Containers: 1}},
+		Units:    []idleadvisor.UnitUtilisation{{Tag: names.NewUnitTag("mysql/0"), Peak: &peak}},
+	})
+}
+
+func (s *IdleAdvisorSuite) TestModelUtilisationServerError(c *gc.C) {
+	apiCaller := apiCaller(c, "ModelUtilisation", nil, params.ModelUtilisationResult{
+		Error: apiservertesting.ServerError("server boom!"),
+	})
+	api := idleadvisor.NewAPI(apiCaller)
+	_, err := api.ModelUtilisation("", time.Time{})
+	c.Assert(err, gc.ErrorMatches, "server boom!")
+}
+
+func (s *IdleAdvisorSuite) TestIdleResources(c *gc.C) {
+	since := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := apiCaller(c, "IdleResources", nil, params.IdleResourcesResult{
+		Resources: []params.IdleResource{{Tag: "machine-1", Since: since, Reason: "no units or containers"}},
+	})
+	api := idleadvisor.NewAPI(apiCaller)
+	resources, err := api.IdleResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, []idleadvisor.IdleResource{
+		{Entity: names.NewMachineTag("1"), Since: since, Reason: "no units or containers"},
+	})
+}
+
+func (s *IdleAdvisorSuite) TestSetIdleResources(c *gc.C) {
+	since := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := apiCaller(c, "SetIdleResources", params.IdleResources{
+		Resources: []params.IdleResource{{Tag: "unit-mysql-0", Since: since, Reason: "cpu below 5"}},
+	}, params.ErrorResult{})
+	api := idleadvisor.NewAPI(apiCaller)
+	err := api.SetIdleResources([]idleadvisor.IdleResource{
+		{Entity: names.NewUnitTag("mysql/0"), Since: since, Reason: "cpu below 5"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiCaller.CallCount, gc.Equals, 1)
+}
+
+func (s *IdleAdvisorSuite) TestSetIdleResourcesServerError(c *gc.C) {
+	apiCaller := apiCaller(c, "SetIdleResources", nil, params.ErrorResult{
+		Error: apiservertesting.ServerError("server boom!"),
+	})
+	api := idleadvisor.NewAPI(apiCaller)
+	err := api.SetIdleResources(nil)
+	c.Assert(err, gc.ErrorMatches, "server boom!")
+}
+
+func apiCaller(c *gc.C, method string, args, results interface{}) *apitesting.CallChecker {
+	return apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "IdleAdvisor",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        method,
+		Args:          args,
+		Results:       results,
+	})
+}
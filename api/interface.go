@@ -82,6 +82,14 @@ type Info struct {
 	// Nonce holds the nonce used when provisioning the machine. Used
 	// only by the machine agent.
 	Nonce string `yaml:",omitempty"`
+
+	// ReadOnly, if true, asks the controller for a connection that
+	// only allows read-only calls and watchers, which are served from
+	// secondary members of the controller's replica set where
+	// possible without waiting for them to catch up with the primary,
+	// so results may lag slightly behind. It is only valid for user
+	// logins.
+	ReadOnly bool `yaml:",omitempty"`
 }
 
 // Ports returns the unique ports for the api addresses.
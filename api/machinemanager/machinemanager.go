@@ -252,3 +252,20 @@ func (client *Client) GetUpgradeSeriesMessages(machineName, watcherId string) ([
 
 	return result.Result, nil
 }
+
+// EstimateCosts returns the estimated cost of running new machines
+// with the given constraints.
+func (client *Client) EstimateCosts(estimates []params.CostEstimateArg) ([]params.CostEstimateResult, error) {
+	if client.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("estimating costs")
+	}
+	args := params.CostEstimateArgs{Estimates: estimates}
+	var results params.CostEstimateResults
+	if err := client.facade.FacadeCall("EstimateCosts", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(estimates) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(estimates), n)
+	}
+	return results.Results, nil
+}
@@ -13,6 +13,7 @@ import (
 	basetesting "github.com/juju/juju/api/base/testing"
 	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -210,3 +211,43 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParams(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
+
+func (s *MachinemanagerSuite) TestEstimateCosts(c *gc.C) {
+	estimates := []params.CostEstimateArg{{
+		Constraints: constraints.MustParse("mem=4G"),
+		Count:       2,
+	}}
+	expected := []params.CostEstimateResult{{
+		InstanceType: "m5.large",
+		Count:        2,
+		HourlyCost:   0.5,
+		MonthlyCost:  730,
+		Currency:     "USD",
+	}}
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		BestVersion: 6,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "MachineManager")
+			c.Check(request, gc.Equals, "EstimateCosts")
+			c.Check(arg, jc.DeepEquals, params.CostEstimateArgs{Estimates: estimates})
+			c.Assert(result, gc.FitsTypeOf, &params.CostEstimateResults{})
+			*(result.(*params.CostEstimateResults)) = params.CostEstimateResults{Results: expected}
+			return nil
+		},
+	})
+	results, err := client.EstimateCosts(estimates)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *MachinemanagerSuite) TestEstimateCostsNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(basetesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+	})
+	_, err := client.EstimateCosts(nil)
+	c.Assert(err, gc.ErrorMatches, "estimating costs not supported")
+}
@@ -124,6 +124,25 @@ func (m *Machine) SetObservedNetworkConfig(netConfig []params.NetworkConfig) err
 	return nil
 }
 
+// RecordNetworkConfigDrift compares the machine network config as observed
+// on the machine with the one recorded for it. Any differences found are
+// recorded against the machine and returned.
+func (m *Machine) RecordNetworkConfigDrift(netConfig []params.NetworkConfig) ([]string, error) {
+	var result params.StringsResult
+	args := params.SetMachineNetworkConfig{
+		Tag:    m.Tag().String(),
+		Config: netConfig,
+	}
+	err := m.st.facade.FacadeCall("RecordNetworkConfigDrift", args, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Result, nil
+}
+
 // SetProviderNetworkConfig sets the machine network config as seen by the
 // provider.
 func (m *Machine) SetProviderNetworkConfig() error {
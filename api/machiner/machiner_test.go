@@ -178,6 +178,26 @@ func (s *machinerSuite) TestSetEmptyMachineAddresses(c *gc.C) {
 	c.Assert(s.machine.MachineAddresses(), gc.HasLen, 0)
 }
 
+func (s *machinerSuite) TestRecordNetworkConfigDrift(c *gc.C) {
+	machine, err := s.machiner.Machine(names.NewMachineTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	drift, err := machine.RecordNetworkConfigDrift([]params.NetworkConfig{{
+		InterfaceName: "eth0",
+		InterfaceType: "ethernet",
+		MACAddress:    "aa:bb:cc:dd:ee:f0",
+		CIDR:          "10.0.0.0/24",
+		Address:       "10.0.0.2",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []string{`device "eth0" observed but not recorded`}
+	c.Assert(drift, jc.DeepEquals, expected)
+
+	recorded, err := s.machine.NetworkConfigDrift()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(recorded, jc.DeepEquals, expected)
+}
+
 func (s *machinerSuite) TestWatch(c *gc.C) {
 	machine, err := s.machiner.Machine(names.NewMachineTag("1"))
 	c.Assert(err, jc.ErrorIsNil)
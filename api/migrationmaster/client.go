@@ -97,6 +97,11 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 		}
 	}
 
+	mode, err := migration.ParseMode(status.Spec.Mode)
+	if err != nil {
+		return empty, errors.Trace(err)
+	}
+
 	return migration.MigrationStatus{
 		MigrationId:      status.MigrationId,
 		ModelUUID:        modelTag.Id(),
@@ -110,6 +115,8 @@ func (c *Client) MigrationStatus() (migration.MigrationStatus, error) {
 			Password:      target.Password,
 			Macaroons:     macs,
 		},
+		TargetRegion: status.Spec.TargetRegion,
+		Mode:         mode,
 	}, nil
 }
 
@@ -211,6 +218,138 @@ func (c *Client) Reap() error {
 	return c.caller.FacadeCall("Reap", nil, nil)
 }
 
+// CheckVolumeExports returns an error if any of the given volumes of
+// the model associated with the API connection cannot be exported.
+func (c *Client) CheckVolumeExports(tags []names.VolumeTag) error {
+	var results params.ErrorResults
+	args := params.Entities{Entities: make([]params.Entity, len(tags))}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	err := c.caller.FacadeCall("CheckVolumeExports", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(tags) {
+		return errors.Errorf("expected %d results, got %d", len(tags), len(results.Results))
+	}
+	return results.Combine()
+}
+
+// ExportVolume makes a portable copy of a volume of the model
+// associated with the API connection, so that it can be recreated by
+// the target controller.
+func (c *Client) ExportVolume(tag names.VolumeTag) (migration.VolumeExport, error) {
+	var results params.MigrationVolumeExportResults
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	err := c.caller.FacadeCall("ExportVolumes", args, &results)
+	if err != nil {
+		return migration.VolumeExport{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return migration.VolumeExport{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return migration.VolumeExport{}, err
+	}
+	result := results.Results[0].Result
+	return migration.VolumeExport{
+		Tag:      tag,
+		Location: result.Location,
+		Format:   result.Format,
+		Size:     result.Size,
+	}, nil
+}
+
+// RemoveVolumeExport removes a copy of a volume made by ExportVolume.
+func (c *Client) RemoveVolumeExport(export migration.VolumeExport) error {
+	var results params.ErrorResults
+	args := params.MigrationVolumeExports{Exports: []params.MigrationVolumeExport{{
+		VolumeTag: export.Tag.String(),
+		Location:  export.Location,
+		Format:    export.Format,
+		Size:      export.Size,
+	}}}
+	err := c.caller.FacadeCall("RemoveVolumeExports", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// MachinesToReprovision returns the machines of the model that must be
+// provisioned again when it moves to another region of its cloud.
+func (c *Client) MachinesToReprovision() ([]names.MachineTag, error) {
+	var result params.Entities
+	err := c.caller.FacadeCall("MachinesToReprovision", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tags := make([]names.MachineTag, len(result.Entities))
+	for i, entity := range result.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tags[i] = tag
+	}
+	return tags, nil
+}
+
+// SnapshotMachine makes an image of a machine's instance in the
+// specified region, so that the target controller can provision the
+// machine again from it.
+func (c *Client) SnapshotMachine(tag names.MachineTag, region string) (migration.MachineSnapshot, error) {
+	var results params.MigrationMachineSnapshotResults
+	args := params.SnapshotMachinesArgs{
+		Region:   region,
+		Machines: params.Entities{Entities: []params.Entity{{Tag: tag.String()}}},
+	}
+	err := c.caller.FacadeCall("SnapshotMachines", args, &results)
+	if err != nil {
+		return migration.MachineSnapshot{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return migration.MachineSnapshot{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return migration.MachineSnapshot{}, err
+	}
+	result := results.Results[0].Result
+	return migration.MachineSnapshot{
+		Tag:     tag,
+		Region:  result.Region,
+		ImageId: result.ImageId,
+	}, nil
+}
+
+// RemoveMachineSnapshot removes an image made by SnapshotMachine.
+func (c *Client) RemoveMachineSnapshot(snapshot migration.MachineSnapshot) error {
+	var results params.ErrorResults
+	args := params.MigrationMachineSnapshots{Snapshots: []params.MigrationMachineSnapshot{{
+		MachineTag: snapshot.Tag.String(),
+		Region:     snapshot.Region,
+		ImageId:    snapshot.ImageId,
+	}}}
+	err := c.caller.FacadeCall("RemoveMachineSnapshots", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// StopMachineInstance stops the instance of a machine which has been
+// provisioned again by the target controller.
+func (c *Client) StopMachineInstance(tag names.MachineTag) error {
+	var results params.ErrorResults
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	err := c.caller.FacadeCall("StopMachineInstances", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
 // WatchMinionReports returns a watcher which reports when a migration
 // minion has made a report for the current migration phase.
 func (c *Client) WatchMinionReports() (watcher.NotifyWatcher, error) {
@@ -121,9 +121,34 @@ func (s *ClientSuite) TestMigrationStatus(c *gc.C) {
 			AuthTag:       names.NewUserTag("admin"),
 			Password:      "secret",
 		},
+		Mode: migration.ModeMove,
 	})
 }
 
+func (s *ClientSuite) TestMigrationStatusToRegion(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(_ string, _ int, _, _ string, _, result interface{}) error {
+		out := result.(*params.MasterMigrationStatus)
+		*out = params.MasterMigrationStatus{
+			Spec: params.MigrationSpec{
+				ModelTag: names.NewModelTag(utils.MustNewUUID().String()).String(),
+				TargetInfo: params.MigrationTargetInfo{
+					ControllerTag: names.NewControllerTag(utils.MustNewUUID().String()).String(),
+					AuthTag:       names.NewUserTag("admin").String(),
+				},
+				TargetRegion: "nether-region",
+				Mode:         "copy",
+			},
+			Phase: "SUCCESS",
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	status, err := client.MigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.TargetRegion, gc.Equals, "nether-region")
+	c.Check(status.Mode, gc.Equals, migration.ModeCopy)
+}
+
 func (s *ClientSuite) TestSetPhase(c *gc.C) {
 	var stub jujutesting.Stub
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
@@ -403,6 +428,213 @@ func (s *ClientSuite) TestReapError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "blam")
 }
 
+func (s *ClientSuite) TestCheckVolumeExports(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {
+				Error: &params.Error{Message: `exporting volumes from pool "ebs" not supported`},
+			}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.CheckVolumeExports([]names.VolumeTag{names.NewVolumeTag("0"), names.NewVolumeTag("1")})
+	c.Assert(err, gc.ErrorMatches, `exporting volumes from pool "ebs" not supported`)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.CheckVolumeExports", []interface{}{"", params.Entities{
+			Entities: []params.Entity{{Tag: "volume-0"}, {Tag: "volume-1"}},
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestExportVolume(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationVolumeExportResults)) = params.MigrationVolumeExportResults{
+			Results: []params.MigrationVolumeExportResult{{
+				Result: params.MigrationVolumeExport{
+					VolumeTag: "volume-0",
+					Location:  "swift://exports/0",
+					Format:    "raw",
+					Size:      1024,
+				},
+			}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	export, err := client.ExportVolume(names.NewVolumeTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(export, jc.DeepEquals, migration.VolumeExport{
+		Tag:      names.NewVolumeTag("0"),
+		Location: "swift://exports/0",
+		Format:   "raw",
+		Size:     1024,
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.ExportVolumes", []interface{}{"", params.Entities{
+			Entities: []params.Entity{{Tag: "volume-0"}},
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestExportVolumeError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.MigrationVolumeExportResults)) = params.MigrationVolumeExportResults{
+			Results: []params.MigrationVolumeExportResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	_, err := client.ExportVolume(names.NewVolumeTag("0"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestRemoveVolumeExport(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.RemoveVolumeExport(migration.VolumeExport{
+		Tag:      names.NewVolumeTag("0"),
+		Location: "swift://exports/0",
+		Format:   "raw",
+		Size:     1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.RemoveVolumeExports", []interface{}{"", params.MigrationVolumeExports{
+			Exports: []params.MigrationVolumeExport{{
+				VolumeTag: "volume-0",
+				Location:  "swift://exports/0",
+				Format:    "raw",
+				Size:      1024,
+			}},
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestMachinesToReprovision(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.Entities)) = params.Entities{
+			Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-2"}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	tags, err := client.MachinesToReprovision()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, []names.MachineTag{
+		names.NewMachineTag("0"), names.NewMachineTag("2"),
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.MachinesToReprovision", []interface{}{"", nil}},
+	})
+}
+
+func (s *ClientSuite) TestSnapshotMachine(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.MigrationMachineSnapshotResults)) = params.MigrationMachineSnapshotResults{
+			Results: []params.MigrationMachineSnapshotResult{{
+				Result: params.MigrationMachineSnapshot{
+					MachineTag: "machine-0",
+					Region:     "nether-region",
+					ImageId:    "image-0",
+				},
+			}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	snapshot, err := client.SnapshotMachine(names.NewMachineTag("0"), "nether-region")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot, jc.DeepEquals, migration.MachineSnapshot{
+		Tag:     names.NewMachineTag("0"),
+		Region:  "nether-region",
+		ImageId: "image-0",
+	})
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.SnapshotMachines", []interface{}{"", params.SnapshotMachinesArgs{
+			Region:   "nether-region",
+			Machines: params.Entities{Entities: []params.Entity{{Tag: "machine-0"}}},
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestSnapshotMachineError(c *gc.C) {
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.MigrationMachineSnapshotResults)) = params.MigrationMachineSnapshotResults{
+			Results: []params.MigrationMachineSnapshotResult{{
+				Error: &params.Error{Message: "boom"},
+			}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	_, err := client.SnapshotMachine(names.NewMachineTag("0"), "nether-region")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *ClientSuite) TestRemoveMachineSnapshot(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.RemoveMachineSnapshot(migration.MachineSnapshot{
+		Tag:     names.NewMachineTag("0"),
+		Region:  "nether-region",
+		ImageId: "image-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.RemoveMachineSnapshots", []interface{}{"", params.MigrationMachineSnapshots{
+			Snapshots: []params.MigrationMachineSnapshot{{
+				MachineTag: "machine-0",
+				Region:     "nether-region",
+				ImageId:    "image-0",
+			}},
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestStopMachineInstance(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := migrationmaster.NewClient(apiCaller, nil)
+	err := client.StopMachineInstance(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationMaster.StopMachineInstances", []interface{}{"", params.Entities{
+			Entities: []params.Entity{{Tag: "machine-0"}},
+		}}},
+	})
+}
+
 func (s *ClientSuite) TestWatchMinionReports(c *gc.C) {
 	var stub jujutesting.Stub
 	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
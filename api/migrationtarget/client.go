@@ -247,3 +247,89 @@ func (c *Client) CheckMachines(modelUUID string) ([]error, error) {
 	}
 	return results, nil
 }
+
+// StorageTranslations returns the volumes of an imported model that
+// must be recreated on the target controller, because the storage
+// providers that created them are not available there. Target
+// controllers that predate storage translation report none.
+func (c *Client) StorageTranslations(modelUUID string) ([]coremigration.VolumeTranslation, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, nil
+	}
+	var result params.MigrationVolumeTranslations
+	args := params.ModelArgs{names.NewModelTag(modelUUID).String()}
+	err := c.caller.FacadeCall("StorageTranslations", args, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	translations := make([]coremigration.VolumeTranslation, len(result.Volumes))
+	for i, v := range result.Volumes {
+		tag, err := names.ParseVolumeTag(v.VolumeTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		translations[i] = coremigration.VolumeTranslation{
+			Tag:        tag,
+			VolumeId:   v.VolumeId,
+			Pool:       v.Pool,
+			TargetPool: v.TargetPool,
+			Size:       v.Size,
+		}
+	}
+	return translations, nil
+}
+
+// ImportVolumeExport recreates a volume of an imported model from a
+// copy exported by the source controller.
+func (c *Client) ImportVolumeExport(modelUUID string, export coremigration.VolumeExport) error {
+	args := params.ImportVolumeExportArgs{
+		ModelTag: names.NewModelTag(modelUUID).String(),
+		Export: params.MigrationVolumeExport{
+			VolumeTag: export.Tag.String(),
+			Location:  export.Location,
+			Format:    export.Format,
+			Size:      export.Size,
+		},
+	}
+	return errors.Trace(c.caller.FacadeCall("ImportVolumeExport", args, nil))
+}
+
+// RemoveImportedVolumes destroys the volumes of an imported model that
+// were recreated by ImportVolumeExport.
+func (c *Client) RemoveImportedVolumes(modelUUID string, volumes []names.VolumeTag) error {
+	args := params.MigrationVolumesArgs{
+		ModelTag:   names.NewModelTag(modelUUID).String(),
+		VolumeTags: make([]string, len(volumes)),
+	}
+	for i, tag := range volumes {
+		args.VolumeTags[i] = tag.String()
+	}
+	var results params.ErrorResults
+	err := c.caller.FacadeCall("RemoveImportedVolumes", args, &results)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
+
+// ReprovisionMachines moves a migrated model to another region of its
+// cloud, and has the target controller provision the model's machines
+// again there from the snapshots of their instances.
+func (c *Client) ReprovisionMachines(modelUUID, region string, snapshots []coremigration.MachineSnapshot) error {
+	if c.caller.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("re-provisioning machines on this target controller")
+	}
+	args := params.ReprovisionMachinesArgs{
+		ModelTag:  names.NewModelTag(modelUUID).String(),
+		Region:    region,
+		Snapshots: make([]params.MigrationMachineSnapshot, len(snapshots)),
+	}
+	for i, snapshot := range snapshots {
+		args.Snapshots[i] = params.MigrationMachineSnapshot{
+			MachineTag: snapshot.Tag.String(),
+			Region:     snapshot.Region,
+			ImageId:    snapshot.ImageId,
+		}
+	}
+	return errors.Trace(c.caller.FacadeCall("ReprovisionMachines", args, nil))
+}
@@ -174,6 +174,90 @@ func (s *ClientSuite) TestCheckMachines(c *gc.C) {
 	s.AssertModelCall(c, &stub, names.NewModelTag("django"), "CheckMachines", err, false)
 }
 
+func (s *ClientSuite) TestStorageTranslations(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			*(result.(*params.MigrationVolumeTranslations)) = params.MigrationVolumeTranslations{
+				Volumes: []params.MigrationVolumeTranslation{{
+					VolumeTag:  "volume-0",
+					VolumeId:   "vol-0",
+					Pool:       "ebs",
+					TargetPool: "cinder",
+					Size:       1024,
+				}},
+			}
+			return nil
+		},
+		BestVersion: 2,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	translations, err := client.StorageTranslations("django")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(translations, jc.DeepEquals, []coremigration.VolumeTranslation{{
+		Tag:        names.NewVolumeTag("0"),
+		VolumeId:   "vol-0",
+		Pool:       "ebs",
+		TargetPool: "cinder",
+		Size:       1024,
+	}})
+	s.AssertModelCall(c, &stub, names.NewModelTag("django"), "StorageTranslations", err, false)
+}
+
+func (s *ClientSuite) TestStorageTranslationsV1(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	translations, err := client.StorageTranslations("django")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(translations, gc.HasLen, 0)
+	stub.CheckNoCalls(c)
+}
+
+func (s *ClientSuite) TestImportVolumeExport(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	err := client.ImportVolumeExport("django", coremigration.VolumeExport{
+		Tag:      names.NewVolumeTag("0"),
+		Location: "swift://exports/vol-0",
+		Format:   "raw",
+		Size:     1024,
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ImportVolumeExport", []interface{}{"", params.ImportVolumeExportArgs{
+			ModelTag: names.NewModelTag("django").String(),
+			Export: params.MigrationVolumeExport{
+				VolumeTag: "volume-0",
+				Location:  "swift://exports/vol-0",
+				Format:    "raw",
+				Size:      1024,
+			},
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestRemoveImportedVolumes(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, id, arg)
+		*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "oops"}},
+		}}
+		return nil
+	})
+	client := migrationtarget.NewClient(apiCaller)
+	err := client.RemoveImportedVolumes("django", []names.VolumeTag{
+		names.NewVolumeTag("0"), names.NewVolumeTag("1"),
+	})
+	c.Assert(err, gc.ErrorMatches, "oops")
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.RemoveImportedVolumes", []interface{}{"", params.MigrationVolumesArgs{
+			ModelTag:   names.NewModelTag("django").String(),
+			VolumeTags: []string{"volume-0", "volume-1"},
+		}}},
+	})
+}
+
 func (s *ClientSuite) TestUploadCharm(c *gc.C) {
 	const charmBody = "charming"
 	curl := charm.MustParseURL("cs:~user/foo-2")
@@ -362,3 +446,39 @@ func (d *fakeDoer) Do(req *http.Request) (*http.Response, error) {
 	d.body = string(body)
 	return d.response, nil
 }
+
+func (s *ClientSuite) TestReprovisionMachines(c *gc.C) {
+	var stub jujutesting.Stub
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			stub.AddCall(objType+"."+request, id, arg)
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := migrationtarget.NewClient(apiCaller)
+	err := client.ReprovisionMachines("django", "nether-region", []coremigration.MachineSnapshot{{
+		Tag:     names.NewMachineTag("0"),
+		Region:  "nether-region",
+		ImageId: "image-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []jujutesting.StubCall{
+		{"MigrationTarget.ReprovisionMachines", []interface{}{"", params.ReprovisionMachinesArgs{
+			ModelTag: names.NewModelTag("django").String(),
+			Region:   "nether-region",
+			Snapshots: []params.MigrationMachineSnapshot{{
+				MachineTag: "machine-0",
+				Region:     "nether-region",
+				ImageId:    "image-0",
+			}},
+		}}},
+	})
+}
+
+func (s *ClientSuite) TestReprovisionMachinesV2(c *gc.C) {
+	client, stub := s.getClientAndStub(c)
+	err := client.ReprovisionMachines("django", "nether-region", nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	stub.CheckNoCalls(c)
+}
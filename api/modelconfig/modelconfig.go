@@ -103,3 +103,38 @@ func (c *Client) Sequences() (map[string]int, error) {
 	}
 	return result.Sequences, nil
 }
+
+// ModelConfigTrace returns the value each level of the model's config
+// inheritance chain holds for the given attribute, along with the
+// effective value and the level it is attributed to.
+func (c *Client) ModelConfigTrace(key string) (config.ConfigTrace, error) {
+	if c.BestAPIVersion() < 3 {
+		return config.ConfigTrace{}, errors.NotSupportedf("ModelConfigTrace on v%d facade", c.BestAPIVersion())
+	}
+	args := params.ModelConfigTraceArgs{Keys: []string{key}}
+	var results params.ModelConfigTraceResults
+	err := c.facade.FacadeCall("ModelConfigTrace", args, &results)
+	if err != nil {
+		return config.ConfigTrace{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return config.ConfigTrace{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return config.ConfigTrace{}, result.Error
+	}
+	trace := config.ConfigTrace{
+		Effective: config.ConfigValue{
+			Value:  result.Effective.Value,
+			Source: result.Effective.Source,
+		},
+	}
+	for _, level := range result.Levels {
+		trace.Levels = append(trace.Levels, config.ConfigValue{
+			Value:  level.Value,
+			Source: level.Source,
+		})
+	}
+	return trace, nil
+}
@@ -220,3 +220,55 @@ func (s *modelconfigSuite) TestSequences(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(sequences, jc.DeepEquals, map[string]int{"foo": 5, "bar": 2})
 }
+
+func (s *modelconfigSuite) TestModelConfigTraceV2(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(_ string, _ int, _, _ string, _, _ interface{}) error {
+				c.Errorf("shouldn't be called")
+				return nil
+			},
+		), 2}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.ModelConfigTrace("apt-mirror")
+	c.Assert(err, gc.ErrorMatches, "ModelConfigTrace on v2 facade not supported")
+}
+
+func (s *modelconfigSuite) TestModelConfigTrace(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ModelConfigTrace")
+				c.Check(a, jc.DeepEquals, params.ModelConfigTraceArgs{Keys: []string{"apt-mirror"}})
+				results := result.(*params.ModelConfigTraceResults)
+				results.Results = []params.ModelConfigTraceResult{{
+					Key:       "apt-mirror",
+					Effective: params.ConfigValue{Value: "http://mirror", Source: "region"},
+					Levels: []params.ConfigValue{
+						{Value: "", Source: "default"},
+						{Value: "http://mirror", Source: "region"},
+					},
+				}}
+				called = true
+				return nil
+			},
+		), 3}
+	client := modelconfig.NewClient(apiCaller)
+	trace, err := client.ModelConfigTrace("apt-mirror")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(trace, jc.DeepEquals, config.ConfigTrace{
+		Effective: config.ConfigValue{Value: "http://mirror", Source: "region"},
+		Levels: []config.ConfigValue{
+			{Value: "", Source: "default"},
+			{Value: "http://mirror", Source: "region"},
+		},
+	})
+}
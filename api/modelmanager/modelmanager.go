@@ -425,6 +425,35 @@ func (c *Client) DestroyModel(tag names.ModelTag, destroyStorage, force *bool, m
 	return nil
 }
 
+// PreviewDestroyModel reports what would be removed by destroying the
+// specified model with the given storage disposition, without
+// destroying it.
+func (c *Client) PreviewDestroyModel(tag names.ModelTag, destroyStorage *bool) (params.DestroyModelImpact, error) {
+	if c.BestAPIVersion() < 8 {
+		return params.DestroyModelImpact{}, errors.NotSupportedf("PreviewDestroyModels not supported by this version of Juju")
+	}
+	args := params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{
+			ModelTag:       tag.String(),
+			DestroyStorage: destroyStorage,
+		}},
+	}
+	var results params.DestroyModelImpactResults
+	if err := c.facade.FacadeCall("PreviewDestroyModels", args, &results); err != nil {
+		return params.DestroyModelImpact{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.DestroyModelImpact{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.DestroyModelImpact{}, errors.Trace(err)
+	}
+	if results.Results[0].Impact == nil {
+		return params.DestroyModelImpact{}, nil
+	}
+	return *results.Results[0].Impact, nil
+}
+
 // GrantModel grants a user access to the specified models.
 func (c *Client) GrantModel(user, access string, modelUUIDs ...string) error {
 	return c.modifyModelUser(params.GrantModelAccess, user, access, modelUUIDs)
@@ -219,6 +219,52 @@ func (s *modelmanagerSuite) testDestroyModel(c *gc.C, v int, destroyStorage, for
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *modelmanagerSuite) TestPreviewDestroyModel(c *gc.C) {
+	destroyStorage := true
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 8,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, req string,
+				args, resp interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(req, gc.Equals, "PreviewDestroyModels")
+				c.Check(args, jc.DeepEquals, params.DestroyModelsParams{
+					Models: []params.DestroyModelParams{{
+						ModelTag:       coretesting.ModelTag.String(),
+						DestroyStorage: &destroyStorage,
+					}},
+				})
+				results := resp.(*params.DestroyModelImpactResults)
+				*results = params.DestroyModelImpactResults{
+					Results: []params.DestroyModelImpactResult{{
+						Impact: &params.DestroyModelImpact{
+							Machines:         []params.Entity{{Tag: "machine-0"}},
+							DestroyedStorage: []params.Entity{{Tag: "storage-data-0"}},
+						},
+					}},
+				}
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	impact, err := client.PreviewDestroyModel(coretesting.ModelTag, &destroyStorage)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(impact, jc.DeepEquals, params.DestroyModelImpact{
+		Machines:         []params.Entity{{Tag: "machine-0"}},
+		DestroyedStorage: []params.Entity{{Tag: "storage-data-0"}},
+	})
+}
+
+func (s *modelmanagerSuite) TestPreviewDestroyModelNotSupported(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 7})
+	_, err := client.PreviewDestroyModel(coretesting.ModelTag, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *modelmanagerSuite) TestDestroyModelV3(c *gc.C) {
 	var called bool
 	apiCaller := basetesting.APICallerFunc(
@@ -0,0 +1,53 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelplan
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the ModelPlan API facade, which computes
+// and applies plans of changes to a model.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new ModelPlan client.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "ModelPlan")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ComputePlan returns a plan of the operations needed to make the
+// given changes to the model. The plan is applied by passing its ID
+// to ApplyPlan.
+func (c *Client) ComputePlan(changes []params.ModelPlanChange) (params.ModelPlanResult, error) {
+	args := params.ModelPlanArgs{Changes: changes}
+	var result params.ModelPlanResult
+	if err := c.facade.FacadeCall("ComputePlan", args, &result); err != nil {
+		return params.ModelPlanResult{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return params.ModelPlanResult{}, result.Error
+	}
+	return result, nil
+}
+
+// ApplyPlan applies the plan with the given ID, and returns the
+// outcome of each operation that was attempted.
+func (c *Client) ApplyPlan(planID string) ([]params.ModelPlanOperationResult, error) {
+	args := params.ApplyModelPlanArgs{PlanID: planID}
+	var result params.ApplyModelPlanResult
+	if err := c.facade.FacadeCall("ApplyPlan", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Results, nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelplan_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelplan"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelPlanSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&modelPlanSuite{})
+
+var testChanges = []params.ModelPlanChange{{
+	Kind:        "deploy",
+	Application: "mysql",
+	CharmURL:    "cs:mysql-42",
+	NumUnits:    1,
+}}
+
+func (s *modelPlanSuite) TestComputePlan(c *gc.C) {
+	expected := params.ModelPlanResult{
+		PlanID: "deadbeef",
+		Operations: []params.ModelPlanOperation{{
+			ID:          "1",
+			Kind:        "deploy",
+			Description: `deploy application "mysql" from cs:mysql-42 with 1 unit(s)`,
+			Application: "mysql",
+			CharmURL:    "cs:mysql-42",
+			NumUnits:    1,
+		}},
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelPlan")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "ComputePlan")
+		c.Check(arg, jc.DeepEquals, params.ModelPlanArgs{Changes: testChanges})
+		*(result.(*params.ModelPlanResult)) = expected
+		return nil
+	})
+	plan, err := modelplan.NewClient(apiCaller).ComputePlan(testChanges)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plan, jc.DeepEquals, expected)
+}
+
+func (s *modelPlanSuite) TestComputePlanError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ModelPlanResult)) = params.ModelPlanResult{
+			Error: &params.Error{Message: `change 0: charm "cs:mysql-42" in the model not found`},
+		}
+		return nil
+	})
+	_, err := modelplan.NewClient(apiCaller).ComputePlan(testChanges)
+	c.Assert(err, gc.ErrorMatches, `change 0: charm "cs:mysql-42" in the model not found`)
+}
+
+func (s *modelPlanSuite) TestApplyPlan(c *gc.C) {
+	expected := []params.ModelPlanOperationResult{{ID: "1"}, {ID: "2", Error: &params.Error{Message: "boom"}}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "ModelPlan")
+		c.Check(request, gc.Equals, "ApplyPlan")
+		c.Check(arg, jc.DeepEquals, params.ApplyModelPlanArgs{PlanID: "deadbeef"})
+		*(result.(*params.ApplyModelPlanResult)) = params.ApplyModelPlanResult{Results: expected}
+		return nil
+	})
+	results, err := modelplan.NewClient(apiCaller).ApplyPlan("deadbeef")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expected)
+}
+
+func (s *modelPlanSuite) TestApplyPlanError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ApplyModelPlanResult)) = params.ApplyModelPlanResult{
+			Error: &params.Error{Message: `model plan "deadbeef" has expired`},
+		}
+		return nil
+	})
+	_, err := modelplan.NewClient(apiCaller).ApplyPlan("deadbeef")
+	c.Assert(err, gc.ErrorMatches, `model plan "deadbeef" has expired`)
+}
@@ -0,0 +1,52 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphanfinder
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+)
+
+const orphanFinderFacade = "OrphanFinder"
+
+// API provides access to the OrphanFinder API facade.
+type API struct {
+	*common.ModelWatcher
+
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side OrphanFinder facade.
+func NewAPI(caller base.APICaller) *API {
+	if caller == nil {
+		panic("caller is nil")
+	}
+	facadeCaller := base.NewFacadeCaller(caller, orphanFinderFacade)
+	return &API{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// KnownModelResources returns the ids of the provider resources, and
+// the tags of the entities, that Juju knows about for the model.
+func (api *API) KnownModelResources() (environs.KnownModelResources, error) {
+	var result params.KnownModelResourcesResult
+	if err := api.facade.FacadeCall("KnownModelResources", nil, &result); err != nil {
+		return environs.KnownModelResources{}, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return environs.KnownModelResources{}, result.Error
+	}
+	return environs.KnownModelResources{
+		InstanceIds:  result.Result.InstanceIds,
+		VolumeIds:    result.Result.VolumeIds,
+		Machines:     result.Result.Machines,
+		Applications: result.Result.Applications,
+	}, nil
+}
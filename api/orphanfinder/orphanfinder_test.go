@@ -0,0 +1,67 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphanfinder_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/orphanfinder"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type OrphanFinderSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&OrphanFinderSuite{})
+
+func (s *OrphanFinderSuite) TestNewAPIWithNilCaller(c *gc.C) {
+	panicFunc := func() { orphanfinder.NewAPI(nil) }
+	c.Assert(panicFunc, gc.PanicMatches, "caller is nil")
+}
+
+func (s *OrphanFinderSuite) TestKnownModelResources(c *gc.C) {
+	apiCaller := apiCaller(c, params.KnownModelResourcesResult{
+		Result: params.KnownModelResources{
+			InstanceIds:  []string{"i-0"},
+			VolumeIds:    []string{"vol-0"},
+			Machines:     []string{"machine-0"},
+			Applications: []string{"application-wordpress"},
+		},
+	})
+	api := orphanfinder.NewAPI(apiCaller)
+	known, err := api.KnownModelResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiCaller.CallCount, gc.Equals, 1)
+	c.Assert(known, jc.DeepEquals, environs.KnownModelResources{
+		InstanceIds:  []string{"i-0"},
+		VolumeIds:    []string{"vol-0"},
+		Machines:     []string{"machine-0"},
+		Applications: []string{"application-wordpress"},
+	})
+}
+
+func (s *OrphanFinderSuite) TestKnownModelResourcesServerError(c *gc.C) {
+	apiCaller := apiCaller(c, params.KnownModelResourcesResult{
+		Error: apiservertesting.ServerError("server boom!"),
+	})
+	api := orphanfinder.NewAPI(apiCaller)
+	_, err := api.KnownModelResources()
+	c.Assert(err, gc.ErrorMatches, "server boom!")
+}
+
+func apiCaller(c *gc.C, results interface{}) *apitesting.CallChecker {
+	return apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "OrphanFinder",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "KnownModelResources",
+		Results:       results,
+	})
+}
@@ -0,0 +1,37 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphans
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the Orphans API facade, which finds
+// provider resources tagged with a model but unknown to Juju.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Orphans client.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Orphans")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// FindOrphans returns the provider resources tagged with the model that
+// Juju does not know about.
+func (c *Client) FindOrphans() ([]params.ModelResource, error) {
+	var result params.ModelResourcesResult
+	if err := c.facade.FacadeCall("FindOrphans", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Resources, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphans_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/orphans"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type orphansSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&orphansSuite{})
+
+func (s *orphansSuite) TestFindOrphans(c *gc.C) {
+	expected := []params.ModelResource{
+		{Kind: "instance", Id: "i-leaked"},
+		{Kind: "security-group", Id: "sg-1", Owner: "machine-1"},
+	}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Orphans")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "FindOrphans")
+		c.Check(arg, gc.IsNil)
+		*(result.(*params.ModelResourcesResult)) = params.ModelResourcesResult{
+			Resources: expected,
+		}
+		return nil
+	})
+	client := orphans.NewClient(apiCaller)
+	resources, err := client.FindOrphans()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, expected)
+}
+
+func (s *orphansSuite) TestFindOrphansError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ModelResourcesResult)) = params.ModelResourcesResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	client := orphans.NewClient(apiCaller)
+	_, err := client.FindOrphans()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -82,6 +82,8 @@ type ProxyConfiguration struct {
 
 	SnapStoreProxyId         string
 	SnapStoreProxyAssertions string
+
+	AptMirror string
 }
 
 // ProxyConfig returns the proxy settings for the current model.
@@ -118,6 +120,8 @@ func (api *API) ProxyConfig() (ProxyConfiguration, error) {
 
 		SnapStoreProxyId:         result.SnapStoreProxyId,
 		SnapStoreProxyAssertions: result.SnapStoreProxyAssertions,
+
+		AptMirror: result.AptMirror,
 	}, nil
 }
 
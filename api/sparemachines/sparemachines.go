@@ -0,0 +1,89 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sparemachines
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+)
+
+const spareMachinesFacade = "SpareMachines"
+
+// API provides access to the SpareMachines API facade.
+type API struct {
+	*common.ModelWatcher
+
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side SpareMachines facade.
+func NewAPI(caller base.APICaller) *API {
+	if caller == nil {
+		panic("caller is nil")
+	}
+	facadeCaller := base.NewFacadeCaller(caller, spareMachinesFacade)
+	return &API{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// SpareMachines returns the machines in the model's pool of spare
+// machines, ordered by id.
+func (api *API) SpareMachines() ([]names.MachineTag, error) {
+	var result params.SpareMachinesResult
+	if err := api.facade.FacadeCall("SpareMachines", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	tags := make([]names.MachineTag, len(result.Machines))
+	for i, m := range result.Machines {
+		tag, err := names.ParseMachineTag(m)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tags[i] = tag
+	}
+	return tags, nil
+}
+
+// AddSpareMachines adds the given number of machines, with the given
+// constraints, to the model's pool of spare machines.
+func (api *API) AddSpareMachines(count int, cons constraints.Value) error {
+	args := params.AddSpareMachinesArgs{
+		Count:       count,
+		Constraints: cons,
+	}
+	var result params.ErrorResult
+	if err := api.facade.FacadeCall("AddSpareMachines", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
+// RemoveSpareMachines destroys the given machines, which must still be
+// in the model's pool of spare machines.
+func (api *API) RemoveSpareMachines(tags []names.MachineTag) error {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ErrorResults
+	if err := api.facade.FacadeCall("RemoveSpareMachines", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.Combine()
+}
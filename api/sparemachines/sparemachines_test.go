@@ -0,0 +1,100 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sparemachines_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/sparemachines"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/constraints"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SpareMachinesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&SpareMachinesSuite{})
+
+func (s *SpareMachinesSuite) TestNewAPIWithNilCaller(c *gc.C) {
+	panicFunc := func() { sparemachines.NewAPI(nil) }
+	c.Assert(panicFunc, gc.PanicMatches, "caller is nil")
+}
+
+func (s *SpareMachinesSuite) TestSpareMachines(c *gc.C) {
+	apiCaller := apiCaller(c, "SpareMachines", nil, params.SpareMachinesResult{
+		Machines: []string{"machine-1", "machine-3"},
+	})
+	api := sparemachines.NewAPI(apiCaller)
+	machines, err := api.SpareMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiCaller.CallCount, gc.Equals, 1)
+	c.Assert(machines, jc.DeepEquals, []names.MachineTag{
+		names.NewMachineTag("1"),
+		names.NewMachineTag("3"),
+	})
+}
+
+func (s *SpareMachinesSuite) TestSpareMachinesServerError(c *gc.C) {
+	apiCaller := apiCaller(c, "SpareMachines", nil, params.SpareMachinesResult{
+		Error: apiservertesting.ServerError("server boom!"),
+	})
+	api := sparemachines.NewAPI(apiCaller)
+	_, err := api.SpareMachines()
+	c.Assert(err, gc.ErrorMatches, "server boom!")
+}
+
+func (s *SpareMachinesSuite) TestAddSpareMachines(c *gc.C) {
+	apiCaller := apiCaller(c, "AddSpareMachines", params.AddSpareMachinesArgs{
+		Count:       2,
+		Constraints: constraints.MustParse("mem=4G"),
+	}, params.ErrorResult{})
+	api := sparemachines.NewAPI(apiCaller)
+	err := api.AddSpareMachines(2, constraints.MustParse("mem=4G"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiCaller.CallCount, gc.Equals, 1)
+}
+
+func (s *SpareMachinesSuite) TestAddSpareMachinesServerError(c *gc.C) {
+	apiCaller := apiCaller(c, "AddSpareMachines", nil, params.ErrorResult{
+		Error: apiservertesting.ServerError("server boom!"),
+	})
+	api := sparemachines.NewAPI(apiCaller)
+	err := api.AddSpareMachines(1, constraints.Value{})
+	c.Assert(err, gc.ErrorMatches, "server boom!")
+}
+
+func (s *SpareMachinesSuite) TestRemoveSpareMachines(c *gc.C) {
+	apiCaller := apiCaller(c, "RemoveSpareMachines", params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}, {Tag: "machine-3"}},
+	}, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ServerError("machine 3 is not spare")},
+		},
+	})
+	api := sparemachines.NewAPI(apiCaller)
+	err := api.RemoveSpareMachines([]names.MachineTag{
+		names.NewMachineTag("1"),
+		names.NewMachineTag("3"),
+	})
+	c.Assert(err, gc.ErrorMatches, "machine 3 is not spare")
+	c.Assert(apiCaller.CallCount, gc.Equals, 1)
+}
+
+func apiCaller(c *gc.C, method string, args, results interface{}) *apitesting.CallChecker {
+	return apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "SpareMachines",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        method,
+		Args:          args,
+		Results:       results,
+	})
+}
@@ -44,6 +44,7 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 		Nonce:       nonce,
 		Macaroons:   macaroons,
 		CLIArgs:     utils.CommandString(os.Args...),
+		ReadOnly:    st.readOnly,
 	}
 	// If we are in developer mode, add the stack location as user data to the
 	// login request. This will allow the apiserver to connect connection ids
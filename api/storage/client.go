@@ -310,6 +310,37 @@ func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
 	return results.Results, nil
 }
 
+// RetryStorage requests that the failed operations on the specified
+// storage entities be retried immediately, rather than at their next
+// scheduled attempt.
+func (c *Client) RetryStorage(storageIds []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("retrying storage operations with this version of Juju")
+	}
+	results := params.ErrorResults{}
+	entities := make([]params.Entity, len(storageIds))
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		entities[i].Tag = names.NewStorageTag(id).String()
+	}
+	if err := c.facade.FacadeCall(
+		"RetryStorage",
+		params.Entities{entities},
+		&results,
+	); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
 // Import imports storage into the model.
 func (c *Client) Import(
 	kind storage.StorageKind,
@@ -1021,3 +1021,59 @@ func (s *storageMockSuite) TestUpdatePoolFacadeCallError(c *gc.C) {
 	err := storageClient.UpdatePool("", "", nil)
 	c.Assert(errors.Cause(err), gc.ErrorMatches, msg)
 }
+
+func (s *storageMockSuite) TestRetryStorage(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string,
+			version int,
+			id, request string,
+			a, result interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Storage")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "RetryStorage")
+			c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+				{Tag: "storage-foo-0"},
+				{Tag: "storage-bar-1"},
+			}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			results := result.(*params.ErrorResults)
+			results.Results = []params.ErrorResult{
+				{},
+				{Error: &params.Error{Message: "qux"}},
+			}
+			return nil
+		},
+	)
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 6, APICallerFunc: apiCaller})
+	results, err := client.RetryStorage([]string{"foo/0", "bar/1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "qux"}},
+	})
+}
+
+func (s *storageMockSuite) TestRetryStorageInvalidId(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatal("should not be called")
+			return nil
+		},
+	)
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 6, APICallerFunc: apiCaller})
+	_, err := client.RetryStorage([]string{"foo"})
+	c.Check(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *storageMockSuite) TestRetryStorageNotSupported(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Fatal("should not be called")
+			return nil
+		},
+	)
+	client := storage.NewClient(basetesting.BestVersionCaller{BestVersion: 5, APICallerFunc: apiCaller})
+	_, err := client.RetryStorage([]string{"foo/0"})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
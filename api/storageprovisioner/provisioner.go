@@ -40,6 +40,23 @@ func (st *State) WatchApplications() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchRetryRequests returns a NotifyWatcher that notifies when users
+// may have requested that failed storage operations be retried.
+func (st *State) WatchRetryRequests() (watcher.NotifyWatcher, error) {
+	if st.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("watching storage retry requests")
+	}
+	var result params.NotifyWatchResult
+	if err := st.facade.FacadeCall("WatchRetryRequests", nil, &result); err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result)
+	return w, nil
+}
+
 // WatchBlockDevices watches for changes to the specified machine's block devices.
 func (st *State) WatchBlockDevices(m names.MachineTag) (watcher.NotifyWatcher, error) {
 	var results params.NotifyWatchResults
@@ -471,6 +488,26 @@ func (st *State) Life(tags []names.Tag) ([]params.LifeResult, error) {
 	return results.Results, nil
 }
 
+// RetryRequested reports whether users have requested that the failed
+// operations on the volumes and filesystems with the specified tags be
+// retried.
+func (st *State) RetryRequested(tags []names.Tag) ([]params.BoolResult, error) {
+	var results params.BoolResults
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	if err := st.facade.FacadeCall("RetryRequested", args, &results); err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results))
+	}
+	return results.Results, nil
+}
+
 // AttachmentLife requests the life cycle of the attachments with the specified IDs.
 func (st *State) AttachmentLife(ids []params.MachineStorageId) ([]params.LifeResult, error) {
 	var results params.LifeResults
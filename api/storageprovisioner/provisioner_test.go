@@ -1036,6 +1036,66 @@ func (s *provisionerSuite) TestLife(c *gc.C) {
 	c.Assert(lifeResults, jc.DeepEquals, []params.LifeResult{{Life: params.Alive}})
 }
 
+func (s *provisionerSuite) TestWatchRetryRequests(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(version, gc.Equals, 5)
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "WatchRetryRequests")
+		c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResult{})
+		*(result.(*params.NotifyWatchResult)) = params.NotifyWatchResult{
+			Error: &params.Error{Message: "FAIL"},
+		}
+		return nil
+	}), 5}
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	watcher, err := st.WatchRetryRequests()
+	c.Assert(watcher, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestWatchRetryRequestsNotSupported(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchRetryRequests()
+	c.Assert(err, gc.ErrorMatches, "watching storage retry requests not supported")
+}
+
+func (s *provisionerSuite) TestRetryRequested(c *gc.C) {
+	var callCount int
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StorageProvisioner")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "RetryRequested")
+		c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{
+			{Tag: "volume-100"}, {Tag: "filesystem-200"},
+		}})
+		c.Assert(result, gc.FitsTypeOf, &params.BoolResults{})
+		*(result.(*params.BoolResults)) = params.BoolResults{
+			Results: []params.BoolResult{{Result: true}, {}},
+		}
+		callCount++
+		return nil
+	})
+
+	st, err := storageprovisioner.NewState(apiCaller)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := st.RetryRequested([]names.Tag{
+		names.NewVolumeTag("100"),
+		names.NewFilesystemTag("200"),
+	})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(results, jc.DeepEquals, []params.BoolResult{{Result: true}, {}})
+}
+
 func (s *provisionerSuite) testClientError(c *gc.C, apiCall func(*storageprovisioner.State) error) {
 	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
 		return errors.New("blargh")
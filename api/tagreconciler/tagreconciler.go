@@ -0,0 +1,58 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tagreconciler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+)
+
+const tagReconcilerFacade = "TagReconciler"
+
+// API provides access to the TagReconciler API facade.
+type API struct {
+	*common.ModelWatcher
+
+	facade base.FacadeCaller
+}
+
+// NewAPI creates a new client-side TagReconciler facade.
+func NewAPI(caller base.APICaller) *API {
+	if caller == nil {
+		panic("caller is nil")
+	}
+	facadeCaller := base.NewFacadeCaller(caller, tagReconcilerFacade)
+	return &API{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// ExpectedResourceTags returns the model's provisioned instances and
+// volumes, and the tags Juju expects each of them to have.
+func (api *API) ExpectedResourceTags() ([]environs.TaggedResource, error) {
+	var result params.TaggedResourcesResult
+	if err := api.facade.FacadeCall("ExpectedResourceTags", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	resources := make([]environs.TaggedResource, len(result.Result))
+	for i, resource := range result.Result {
+		resources[i] = environs.TaggedResource{
+			ModelResource: environs.ModelResource{
+				Kind:  environs.ModelResourceKind(resource.Kind),
+				Id:    resource.Id,
+				Owner: resource.Owner,
+			},
+			Tags: resource.Tags,
+		}
+	}
+	return resources, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tagreconciler_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/tagreconciler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type TagReconcilerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&TagReconcilerSuite{})
+
+func (s *TagReconcilerSuite) TestNewAPIWithNilCaller(c *gc.C) {
+	panicFunc := func() { tagreconciler.NewAPI(nil) }
+	c.Assert(panicFunc, gc.PanicMatches, "caller is nil")
+}
+
+func (s *TagReconcilerSuite) TestExpectedResourceTags(c *gc.C) {
+	apiCaller := apiCaller(c, params.TaggedResourcesResult{
+		Result: []params.TaggedResource{{
+			Kind:  "instance",
+			Id:    "i-0",
+			Owner: "machine-0",
+			Tags:  map[string]string{"juju-model-uuid": "deadbeef"},
+		}},
+	})
+	api := tagreconciler.NewAPI(apiCaller)
+	resources, err := api.ExpectedResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apiCaller.CallCount, gc.Equals, 1)
+	c.Assert(resources, jc.DeepEquals, []environs.TaggedResource{{
+		ModelResource: environs.ModelResource{
+			Kind:  environs.InstanceResource,
+			Id:    "i-0",
+			Owner: "machine-0",
+		},
+		Tags: map[string]string{"juju-model-uuid": "deadbeef"},
+	}})
+}
+
+func (s *TagReconcilerSuite) TestExpectedResourceTagsServerError(c *gc.C) {
+	apiCaller := apiCaller(c, params.TaggedResourcesResult{
+		Error: apiservertesting.ServerError("server boom!"),
+	})
+	api := tagreconciler.NewAPI(apiCaller)
+	_, err := api.ExpectedResourceTags()
+	c.Assert(err, gc.ErrorMatches, "server boom!")
+}
+
+func apiCaller(c *gc.C, results interface{}) *apitesting.CallChecker {
+	return apitesting.APICallChecker(c, apitesting.APICall{
+		Facade:        "TagReconciler",
+		VersionIsZero: true,
+		IdIsEmpty:     true,
+		Method:        "ExpectedResourceTags",
+		Results:       results,
+	})
+}
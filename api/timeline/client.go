@@ -0,0 +1,38 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package timeline
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the Timeline API facade, which returns
+// the status changes, hook executions, actions and pending cleanups of
+// a model or its entities as a single stream of events.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Timeline client.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Timeline")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Timeline returns the events matching the given arguments, oldest
+// first.
+func (c *Client) Timeline(args params.TimelineArgs) ([]params.TimelineEvent, error) {
+	var result params.TimelineResult
+	if err := c.facade.FacadeCall("Timeline", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Events, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package timeline_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/timeline"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type timelineSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&timelineSuite{})
+
+func (s *timelineSuite) TestTimeline(c *gc.C) {
+	args := params.TimelineArgs{
+		Entity: "unit-mysql-0",
+		Kinds:  []string{"hook"},
+		Size:   10,
+	}
+	expected := []params.TimelineEvent{{
+		Time:    time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC),
+		Kind:    "hook",
+		Entity:  "unit-mysql-0",
+		Status:  "executing",
+		Message: "running install hook",
+		Data:    map[string]interface{}{"hook": "install"},
+	}}
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Timeline")
+		c.Check(id, gc.Equals, "")
+		c.Check(request, gc.Equals, "Timeline")
+		c.Check(arg, jc.DeepEquals, args)
+		*(result.(*params.TimelineResult)) = params.TimelineResult{Events: expected}
+		return nil
+	})
+	events, err := timeline.NewClient(apiCaller).Timeline(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, jc.DeepEquals, expected)
+}
+
+func (s *timelineSuite) TestTimelineError(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.TimelineResult)) = params.TimelineResult{
+			Error: &params.Error{Message: `event kind "gossip" not valid`},
+		}
+		return nil
+	})
+	_, err := timeline.NewClient(apiCaller).Timeline(params.TimelineArgs{Kinds: []string{"gossip"}})
+	c.Assert(err, gc.ErrorMatches, `event kind "gossip" not valid`)
+}
@@ -5,6 +5,7 @@ package uniter
 
 import (
 	"fmt"
+	"time"
 
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
@@ -20,12 +21,13 @@ import (
 // Relation represents a relation between one or two application
 // endpoints.
 type Relation struct {
-	st        *State
-	tag       names.RelationTag
-	id        int
-	life      params.Life
-	suspended bool
-	otherApp  string
+	st           *State
+	tag          names.RelationTag
+	id           int
+	life         params.Life
+	suspended    bool
+	otherApp     string
+	drainTimeout time.Duration
 }
 
 // Tag returns the relation tag.
@@ -62,6 +64,13 @@ func (r *Relation) UpdateSuspended(suspended bool) {
 	r.suspended = suspended
 }
 
+// DrainTimeout returns how long a unit departing the relation is kept
+// in its scope, so that its counterparts can run their
+// relation-departed hooks.
+func (r *Relation) DrainTimeout() time.Duration {
+	return r.drainTimeout
+}
+
 // OtherApplication returns the name of the application on the other
 // end of the relation (from this unit's perspective).
 func (r *Relation) OtherApplication() string {
@@ -76,11 +85,12 @@ func (r *Relation) Refresh() error {
 	if err != nil {
 		return err
 	}
-	// NOTE: The status and life cycle information are the only
-	// things that can change - id, tag and endpoint
+	// NOTE: The status, life cycle and drain timeout information
+	// are the only things that can change - id, tag and endpoint
 	// information are static.
 	r.life = result.Life
 	r.suspended = result.Suspended
+	r.drainTimeout = result.DrainTimeout
 
 	return nil
 }
@@ -53,6 +53,16 @@ func (s *relationSuite) TestOtherApplication(c *gc.C) {
 	c.Assert(s.apiRelation.OtherApplication(), gc.Equals, "mysql")
 }
 
+func (s *relationSuite) TestDrainTimeout(c *gc.C) {
+	c.Assert(s.apiRelation.DrainTimeout(), gc.Equals, time.Duration(0))
+
+	err := s.stateRelation.SetDrainTimeout(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiRelation.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiRelation.DrainTimeout(), gc.Equals, time.Minute)
+}
+
 func (s *relationSuite) TestRefresh(c *gc.C) {
 	c.Assert(s.apiRelation.Life(), gc.Equals, params.Alive)
 	c.Assert(s.apiRelation.Suspended(), jc.IsTrue)
@@ -94,6 +94,29 @@ func (ru *RelationUnit) LeaveScope() error {
 	return result.OneError()
 }
 
+// DrainScope signals that the unit is done with its scope in the
+// relation. If the relation has a drain timeout, the unit is reported as
+// departed but kept in the scope until the timeout expires, so that its
+// counterparts can run their relation-departed hooks; otherwise it leaves
+// the scope immediately.
+func (ru *RelationUnit) DrainScope() error {
+	if ru.st.BestAPIVersion() < 12 {
+		return errors.NotImplementedf("RelationUnit.DrainScope() (need V12+)")
+	}
+	var result params.ErrorResults
+	args := params.RelationUnits{
+		RelationUnits: []params.RelationUnit{{
+			Relation: ru.relation.tag.String(),
+			Unit:     ru.unit.tag.String(),
+		}},
+	}
+	err := ru.st.facade.FacadeCall("DrainScope", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // Settings returns a Settings which allows access to the unit's settings
 // within the relation.
 func (ru *RelationUnit) Settings() (*Settings, error) {
@@ -4,6 +4,8 @@
 package uniter_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
@@ -173,6 +175,27 @@ func (s *relationUnitSuite) TestLeaveScope(c *gc.C) {
 	s.assertInScope(c, wpRelUnit, false)
 }
 
+func (s *relationUnitSuite) TestDrainScope(c *gc.C) {
+	err := s.stateRelation.SetDrainTimeout(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	myRelUnit, err := s.stateRelation.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = myRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	wpRelUnit, apiRelUnit := s.getRelationUnits(c)
+	err = wpRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertInScope(c, wpRelUnit, true)
+
+	err = apiRelUnit.DrainScope()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertInScope(c, wpRelUnit, true)
+	deadline, err := wpRelUnit.DrainDeadline()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deadline.IsZero(), jc.IsFalse)
+}
+
 func (s *relationUnitSuite) TestSettings(c *gc.C) {
 	wpRelUnit, apiRelUnit := s.getRelationUnits(c)
 	settings := map[string]interface{}{
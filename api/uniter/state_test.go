@@ -71,3 +71,23 @@ func (s *stateSuite) TestAllMachinePorts(c *gc.C) {
 		{1, 8, "udp"}:     {Unit: wordpressUnit1.Tag().String()},
 	})
 }
+
+func (s *stateSuite) TestOpenedEndpointPorts(c *gc.C) {
+	err := s.wordpressUnit.OpenPorts("tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressUnit.OpenPortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressUnit.OpenPortsOnEndpoint("monitoring-port", "tcp", 8080, 8080)
+	c.Assert(err, jc.ErrorIsNil)
+
+	portsMap, err := s.uniter.OpenedEndpointPorts(
+		s.wordpressMachine.Tag().(names.MachineTag),
+		s.wordpressUnit.Tag().(names.UnitTag),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(portsMap, jc.DeepEquals, map[string][]network.PortRange{
+		"":                {{80, 80, "tcp"}},
+		"url":             {{80, 80, "tcp"}},
+		"monitoring-port": {{8080, 8080, "tcp"}},
+	})
+}
@@ -22,6 +22,7 @@ type Unit struct {
 	tag          names.UnitTag
 	life         params.Life
 	resolvedMode params.ResolvedMode
+	paused       bool
 }
 
 // Tag returns the unit's tag.
@@ -49,6 +50,12 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// Paused reports whether hook execution is paused for the unit, either
+// for the unit itself or for its application.
+func (u *Unit) Paused() bool {
+	return u.paused
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -71,6 +78,7 @@ func (u *Unit) Refresh() error {
 
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
+	u.paused = result.Paused
 	return nil
 }
 
@@ -451,25 +459,35 @@ func (u *Unit) AvailabilityZone() (string, error) {
 // OpenPorts sets the policy of the port range with protocol to be
 // opened.
 func (u *Unit) OpenPorts(protocol string, fromPort, toPort int) error {
-	var result params.ErrorResults
-	args := params.EntitiesPortRanges{
-		Entities: []params.EntityPortRange{{
-			Tag:      u.tag.String(),
-			Protocol: protocol,
-			FromPort: fromPort,
-			ToPort:   toPort,
-		}},
-	}
-	err := u.st.facade.FacadeCall("OpenPorts", args, &result)
-	if err != nil {
-		return err
-	}
-	return result.OneError()
+	return u.OpenPortsOnEndpoint("", protocol, fromPort, toPort)
 }
 
 // ClosePorts sets the policy of the port range with protocol to be
 // closed.
 func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
+	return u.ClosePortsOnEndpoint("", protocol, fromPort, toPort)
+}
+
+// OpenPortsOnEndpoint sets the policy of the port range with protocol
+// to be opened for the given endpoint. An empty endpoint opens the
+// range for all of the unit's endpoints.
+func (u *Unit) OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	if endpoint != "" && u.st.BestAPIVersion() < 13 {
+		return errors.NotImplementedf("Unit.OpenPortsOnEndpoint() (need V13+)")
+	}
+	return u.changePorts("OpenPorts", endpoint, protocol, fromPort, toPort)
+}
+
+// ClosePortsOnEndpoint sets the policy of the port range with protocol
+// to be closed for the given endpoint.
+func (u *Unit) ClosePortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	if endpoint != "" && u.st.BestAPIVersion() < 13 {
+		return errors.NotImplementedf("Unit.ClosePortsOnEndpoint() (need V13+)")
+	}
+	return u.changePorts("ClosePorts", endpoint, protocol, fromPort, toPort)
+}
+
+func (u *Unit) changePorts(method, endpoint, protocol string, fromPort, toPort int) error {
 	var result params.ErrorResults
 	args := params.EntitiesPortRanges{
 		Entities: []params.EntityPortRange{{
@@ -477,9 +495,10 @@ func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) error {
 			Protocol: protocol,
 			FromPort: fromPort,
 			ToPort:   toPort,
+			Endpoint: endpoint,
 		}},
 	}
-	err := u.st.facade.FacadeCall("ClosePorts", args, &result)
+	err := u.st.facade.FacadeCall(method, args, &result)
 	if err != nil {
 		return err
 	}
@@ -242,6 +242,18 @@ func (s *unitSuite) TestRefreshResolve(c *gc.C) {
 	c.Assert(mode, gc.Equals, params.ResolvedNone)
 }
 
+func (s *unitSuite) TestRefreshPaused(c *gc.C) {
+	c.Assert(s.apiUnit.Paused(), jc.IsFalse)
+
+	err := s.wordpressUnit.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiUnit.Paused(), jc.IsFalse)
+
+	err = s.apiUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.apiUnit.Paused(), jc.IsTrue)
+}
+
 func (s *unitSuite) TestWatch(c *gc.C) {
 	c.Assert(s.apiUnit.Life(), gc.Equals, params.Alive)
 
@@ -442,6 +454,33 @@ func (s *unitSuite) TestOpenClosePortRanges(c *gc.C) {
 	c.Assert(ports, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestOpenClosePortsOnEndpointV12(c *gc.C) {
+	apiCaller := testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "OpenPorts")
+		c.Check(arg, jc.DeepEquals, params.EntitiesPortRanges{
+			Entities: []params.EntityPortRange{{
+				Tag:      "unit-wordpress-0",
+				Protocol: "tcp",
+				FromPort: 80,
+				ToPort:   80,
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	st := uniter.NewStateV12(apiCaller, names.NewUnitTag("wordpress/0"))
+	unit := uniter.CreateUnit(st, names.NewUnitTag("wordpress/0"))
+
+	err := unit.OpenPortsOnEndpoint("", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.OpenPortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	err = unit.ClosePortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *unitSuite) TestGetSetCharmURL(c *gc.C) {
 	// No charm URL set yet.
 	curl, ok := s.wordpressUnit.CharmURL()
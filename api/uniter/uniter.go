@@ -209,12 +209,13 @@ func (st *State) Relation(relationTag names.RelationTag) (*Relation, error) {
 		return nil, err
 	}
 	return &Relation{
-		id:        result.Id,
-		tag:       relationTag,
-		life:      result.Life,
-		suspended: result.Suspended,
-		st:        st,
-		otherApp:  result.OtherApplication,
+		id:           result.Id,
+		tag:          relationTag,
+		life:         result.Life,
+		suspended:    result.Suspended,
+		st:           st,
+		otherApp:     result.OtherApplication,
+		drainTimeout: result.DrainTimeout,
 	}, nil
 }
 
@@ -302,12 +303,13 @@ func (st *State) RelationById(id int) (*Relation, error) {
 	}
 	relationTag := names.NewRelationTag(result.Key)
 	return &Relation{
-		id:        result.Id,
-		tag:       relationTag,
-		life:      result.Life,
-		suspended: result.Suspended,
-		st:        st,
-		otherApp:  result.OtherApplication,
+		id:           result.Id,
+		tag:          relationTag,
+		life:         result.Life,
+		suspended:    result.Suspended,
+		st:           st,
+		otherApp:     result.OtherApplication,
+		drainTimeout: result.DrainTimeout,
 	}, nil
 }
 
@@ -336,6 +338,41 @@ func (st *State) Model() (*model.Model, error) {
 // machine, mapped to the tags of the unit that opened them and the
 // relation that applies.
 func (st *State) AllMachinePorts(machineTag names.MachineTag) (map[corenetwork.PortRange]params.RelationUnit, error) {
+	machinePorts, err := st.machinePorts(machineTag)
+	if err != nil {
+		return nil, err
+	}
+	portsMap := make(map[corenetwork.PortRange]params.RelationUnit)
+	for _, ports := range machinePorts {
+		portRange := ports.PortRange.NetworkPortRange()
+		portsMap[portRange] = params.RelationUnit{
+			Unit:     ports.UnitTag,
+			Relation: ports.RelationTag,
+		}
+	}
+	return portsMap, nil
+}
+
+// OpenedEndpointPorts returns the port ranges the given unit has opened
+// on the given machine, grouped by the endpoint they were opened for.
+// Ranges opened for all of the unit's endpoints are keyed by the empty
+// string.
+func (st *State) OpenedEndpointPorts(machineTag names.MachineTag, unitTag names.UnitTag) (map[string][]corenetwork.PortRange, error) {
+	machinePorts, err := st.machinePorts(machineTag)
+	if err != nil {
+		return nil, err
+	}
+	portsMap := make(map[string][]corenetwork.PortRange)
+	for _, ports := range machinePorts {
+		if ports.UnitTag != unitTag.String() {
+			continue
+		}
+		portsMap[ports.Endpoint] = append(portsMap[ports.Endpoint], ports.PortRange.NetworkPortRange())
+	}
+	return portsMap, nil
+}
+
+func (st *State) machinePorts(machineTag names.MachineTag) ([]params.MachinePortRange, error) {
 	if st.BestAPIVersion() < 1 {
 		// AllMachinePorts() was introduced in UniterAPIV1.
 		return nil, errors.NotImplementedf("AllMachinePorts() (need V1+)")
@@ -355,15 +392,7 @@ func (st *State) AllMachinePorts(machineTag names.MachineTag) (map[corenetwork.P
 	if result.Error != nil {
 		return nil, result.Error
 	}
-	portsMap := make(map[corenetwork.PortRange]params.RelationUnit)
-	for _, ports := range result.Ports {
-		portRange := ports.PortRange.NetworkPortRange()
-		portsMap[portRange] = params.RelationUnit{
-			Unit:     ports.UnitTag,
-			Relation: ports.RelationTag,
-		}
-	}
-	return portsMap, nil
+	return result.Ports, nil
 }
 
 // WatchRelationUnits returns a watcher that notifies of changes to the
@@ -4,6 +4,8 @@
 package upgrader_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/os/series"
 	jc "github.com/juju/testing/checkers"
@@ -11,10 +13,13 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/upgrader"
 	"github.com/juju/juju/apiserver/params"
+	coreapplication "github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/maintenance"
 	"github.com/juju/juju/core/watcher/watchertest"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -176,3 +181,25 @@ func (s *unitUpgraderSuite) TestDesiredVersionNotUnit(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
 }
+
+func (s *unitUpgraderSuite) TestMaintenanceWindow(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"maintenance-window": "22:00-02:00"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	window, err := s.st.MaintenanceWindow(s.rawUnit.Tag().String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(window, gc.Equals, maintenance.Window{Start: 22 * time.Hour, End: 2 * time.Hour})
+
+	// The application's own maintenance window takes precedence.
+	app, err := s.rawUnit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.UpdateApplicationConfig(
+		coreapplication.ConfigAttributes{"maintenance-window": "03:00-04:00"},
+		nil,
+		environschema.Fields{"maintenance-window": {Type: environschema.Tstring}},
+		nil,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	window, err = s.st.MaintenanceWindow(s.rawUnit.Tag().String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(window, gc.Equals, maintenance.Window{Start: 3 * time.Hour, End: 4 * time.Hour})
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/juju/api/base"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/maintenance"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/tools"
 )
@@ -70,6 +71,31 @@ func (st *State) DesiredVersion(tag string) (version.Number, error) {
 	return *result.Version, nil
 }
 
+// MaintenanceWindow returns the maintenance window within which the
+// entity with the given tag may be upgraded. Controllers that don't
+// support maintenance windows allow upgrades at any time.
+func (st *State) MaintenanceWindow(tag string) (maintenance.Window, error) {
+	if st.facade.BestAPIVersion() < 2 {
+		return maintenance.Window{}, nil
+	}
+	var results params.MaintenanceWindowResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag}},
+	}
+	err := st.facade.FacadeCall("MaintenanceWindows", args, &results)
+	if err != nil {
+		return maintenance.Window{}, err
+	}
+	if len(results.Results) != 1 {
+		return maintenance.Window{}, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if err := result.Error; err != nil {
+		return maintenance.Window{}, err
+	}
+	return maintenance.ParseWindow(result.Window)
+}
+
 // Tools returns the agent tools that should run on the given entity,
 // along with a flag whether to disable SSL hostname verification.
 func (st *State) Tools(tag string) (tools.List, error) {
@@ -5,6 +5,7 @@ package upgrader_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/upgrader"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/maintenance"
 	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -142,3 +144,17 @@ func (s *machineUpgraderSuite) TestDesiredVersion(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(stateVersion, gc.Equals, current.Number)
 }
+
+func (s *machineUpgraderSuite) TestMaintenanceWindow(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"maintenance-window": "22:00-02:00"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	window, err := s.st.MaintenanceWindow(s.rawMachine.Tag().String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(window, gc.Equals, maintenance.Window{Start: 22 * time.Hour, End: 2 * time.Hour})
+}
+
+func (s *machineUpgraderSuite) TestMaintenanceWindowWrongMachine(c *gc.C) {
+	_, err := s.st.MaintenanceWindow("machine-42")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(err, jc.Satisfies, params.IsCodeUnauthorized)
+}
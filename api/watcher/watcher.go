@@ -631,6 +631,7 @@ func (w *migrationStatusWatcher) loop() error {
 			SourceCACert:   inStatus.SourceCACert,
 			TargetAPIAddrs: inStatus.TargetAPIAddrs,
 			TargetCACert:   inStatus.TargetCACert,
+			Copy:           inStatus.Copy,
 		}
 		select {
 		case w.out <- outStatus:
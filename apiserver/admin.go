@@ -111,14 +111,28 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		return fail, errors.Trace(err)
 	}
 
+	if req.ReadOnly && !authResult.userLogin {
+		return fail, errors.NotSupportedf("read-only login for non-users")
+	}
+
 	// apiRoot is the API root exposed to the client after login.
-	var apiRoot rpc.Root = newAPIRoot(
+	root := newAPIRoot(
 		a.root.state,
 		a.root.shared,
 		a.srv.facades,
 		a.root.resources,
 		a.root,
 	)
+	// Users' read-only queries and watchers are served from the
+	// secondaries of the controller's replica set, so that reporting
+	// doesn't load the primary. Unless the login asked for read-only
+	// access, each call first waits for the secondaries to catch up,
+	// so that users see the changes they've just made. Agents read
+	// from the primary.
+	if authResult.userLogin {
+		root.replica = newReadReplica(a.root.state, a.root.shared.statePool, a.root.resources, !req.ReadOnly)
+	}
+	var apiRoot rpc.Root = root
 	apiRoot, err = restrictAPIRoot(
 		a.srv,
 		apiRoot,
@@ -128,6 +142,9 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 	if err != nil {
 		return fail, errors.Trace(err)
 	}
+	if req.ReadOnly {
+		apiRoot = restrictRoot(apiRoot, readOnlyMethodsOnly)
+	}
 
 	var facadeFilters []facadeFilterFunc
 	var modelTag string
@@ -151,6 +168,15 @@ func (a *admin) login(req params.LoginRequest, loginVersion int) (params.LoginRe
 		a.apiObserver, auditRecorder, auditConfig.CaptureAPIArgs,
 	)
 	a.root.rpcConn.ServeRoot(apiRoot, recorderFactory, serverError)
+	if a.root.entity != nil {
+		a.srv.shared.sessions.add(state.APISession{
+			ConnectionID: a.root.connectionID,
+			Tag:          a.root.entity.Tag().String(),
+			ModelUUID:    a.root.modelUUID,
+			RemoteAddr:   a.root.remoteAddr,
+			LoggedIn:     a.srv.clock.Now(),
+		}, a.root.rpcConn)
+	}
 	return params.LoginResult{
 		Servers:       params.FromNetworkHostsPorts(hostPorts),
 		ControllerTag: a.root.model.ControllerTag().String(),
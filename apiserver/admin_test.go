@@ -657,6 +657,67 @@ func (s *loginSuite) TestMachineLoginDuringMaintenance(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "login for machine "+machine.Id()+" blocked because upgrade is in progress")
 }
 
+func (s *loginSuite) TestReadOnlyLogin(c *gc.C) {
+	info, srv := s.newServer(c)
+	defer assertStop(c, srv)
+	info.ModelTag = s.Model.ModelTag()
+	info.Tag = s.AdminUserTag(c)
+	info.Password = "dummy-secret"
+	info.ReadOnly = true
+
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	var statusResult params.FullStatus
+	err = st.APICall("Client", 1, "", "FullStatus", params.StatusParams{}, &statusResult)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusResult.Model.Name, gc.Equals, s.Model.Name())
+
+	err = st.APICall("Client", 1, "", "ModelSet", params.ModelSet{}, nil)
+	c.Assert(err, gc.ErrorMatches, "method Client.ModelSet not supported for read-only API connection")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *loginSuite) TestReadOnlyLoginWatchAll(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	info, srv := s.newServer(c)
+	defer assertStop(c, srv)
+	info.ModelTag = s.Model.ModelTag()
+	info.Tag = s.AdminUserTag(c)
+	info.Password = "dummy-secret"
+	info.ReadOnly = true
+
+	st, err := api.Open(info, fastDialOpts)
+	c.Assert(err, jc.ErrorIsNil)
+	defer st.Close()
+
+	var watcherId params.AllWatcherId
+	err = st.APICall("Client", 1, "", "WatchAll", nil, &watcherId)
+	c.Assert(err, jc.ErrorIsNil)
+	var next params.AllWatcherNextResults
+	err = st.APICall("AllWatcher", 1, watcherId.AllWatcherId, "Next", nil, &next)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(next.Deltas, gc.Not(gc.HasLen), 0)
+	err = st.APICall("AllWatcher", 1, watcherId.AllWatcherId, "Stop", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *loginSuite) TestReadOnlyMachineLoginFails(c *gc.C) {
+	info, srv := s.newServer(c)
+	defer assertStop(c, srv)
+	info.ModelTag = s.Model.ModelTag()
+
+	machine, password := s.addMachine(c, state.JobHostUnits)
+	info.Tag = machine.Tag()
+	info.Password = password
+	info.Nonce = "fake_nonce"
+	info.ReadOnly = true
+
+	_, err := api.Open(info, fastDialOpts)
+	c.Assert(err, gc.ErrorMatches, "read-only login for non-users not supported")
+}
+
 func (s *loginSuite) TestControllerMachineLoginDuringMaintenance(c *gc.C) {
 	cfg := testserver.DefaultServerConfig(c)
 	cfg.UpgradeComplete = func() bool {
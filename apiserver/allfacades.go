@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/agent"
 	"github.com/juju/juju/apiserver/facades/agent/caasagent"
 	"github.com/juju/juju/apiserver/facades/agent/caasoperator"
+	"github.com/juju/juju/apiserver/facades/agent/crashreporter"
 	"github.com/juju/juju/apiserver/facades/agent/credentialvalidator"
 	"github.com/juju/juju/apiserver/facades/agent/deployer"
 	"github.com/juju/juju/apiserver/facades/agent/diskmanager"
@@ -42,9 +43,11 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/upgrader"
 	"github.com/juju/juju/apiserver/facades/agent/upgradeseries"
 	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/facades/client/advisor"
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/application" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
+	"github.com/juju/juju/apiserver/facades/client/autoscale"
 	"github.com/juju/juju/apiserver/facades/client/backups" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/block"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/bundle"
@@ -52,6 +55,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/client"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/cloud"      // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/controller" // ModelUser Admin (although some methods check for read only)
+	"github.com/juju/juju/apiserver/facades/client/crashreports"
 	"github.com/juju/juju/apiserver/facades/client/credentialmanager"
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
 	"github.com/juju/juju/apiserver/facades/client/highavailability" // ModelUser Write
@@ -63,16 +67,20 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration"
 	"github.com/juju/juju/apiserver/facades/client/modelmanager" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelplan"
+	"github.com/juju/juju/apiserver/facades/client/orphans"
 	"github.com/juju/juju/apiserver/facades/client/payloads"
 	"github.com/juju/juju/apiserver/facades/client/resources"
 	"github.com/juju/juju/apiserver/facades/client/spaces"    // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/sshclient" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/storage"
 	"github.com/juju/juju/apiserver/facades/client/subnets"
+	"github.com/juju/juju/apiserver/facades/client/timeline"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
+	"github.com/juju/juju/apiserver/facades/controller/autoscaler"
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorprovisioner"
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorupgrader"
@@ -81,8 +89,10 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/cleaner"
 	"github.com/juju/juju/apiserver/facades/controller/crosscontroller"
 	"github.com/juju/juju/apiserver/facades/controller/crossmodelrelations"
+	"github.com/juju/juju/apiserver/facades/controller/dnspublisher"
 	"github.com/juju/juju/apiserver/facades/controller/externalcontrollerupdater"
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
+	"github.com/juju/juju/apiserver/facades/controller/idleadvisor"
 	"github.com/juju/juju/apiserver/facades/controller/imagemetadata"
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
 	"github.com/juju/juju/apiserver/facades/controller/lifeflag"
@@ -92,10 +102,13 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/migrationmaster"
 	"github.com/juju/juju/apiserver/facades/controller/migrationtarget"
 	"github.com/juju/juju/apiserver/facades/controller/modelupgrader"
+	"github.com/juju/juju/apiserver/facades/controller/orphanfinder"
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
+	"github.com/juju/juju/apiserver/facades/controller/sparemachines"
 	"github.com/juju/juju/apiserver/facades/controller/statushistory"
+	"github.com/juju/juju/apiserver/facades/controller/tagreconciler"
 	"github.com/juju/juju/apiserver/facades/controller/undertaker"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/state"
@@ -131,7 +144,9 @@ func AllFacades() *facade.Registry {
 
 	reg("Action", 2, action.NewActionAPIV2)
 	reg("Action", 3, action.NewActionAPIV3)
+	reg("Action", 4, action.NewActionAPIV4) // adds Introspect
 	reg("ActionPruner", 1, actionpruner.NewAPI)
+	reg("Advisor", 1, advisor.NewAPI)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
@@ -147,26 +162,45 @@ func AllFacades() *facade.Registry {
 	reg("Application", 6, application.NewFacadeV6)
 	reg("Application", 7, application.NewFacadeV7)
 	reg("Application", 8, application.NewFacadeV8)
-	reg("Application", 9, application.NewFacadeV9) // ApplicationInfo, generational config, Force on App and Unit Removal.
+	reg("Application", 9, application.NewFacadeV9)   // ApplicationInfo, generational config, Force on App and Unit Removal.
+	reg("Application", 10, application.NewFacadeV10) // adds PreviewDestroyApplication
+	reg("Application", 11, application.NewFacadeV11) // adds per-storage disposition to DestroyApplication
+	reg("Application", 12, application.NewFacadeV12) // adds SetRefreshPolicy & RefreshPolicies
+	reg("Application", 13, application.NewFacadeV13) // adds Pause & Resume
+	reg("Application", 14, application.NewFacadeV14) // adds CreateShadowApplication, MigrateRelations & FinaliseShadowApplication
+	reg("Application", 15, application.NewFacadeV15) // adds CheckCharmCompatibility
+	reg("Application", 16, application.NewFacadeV16) // adds SetRelationsDrainTimeout
+	reg("Application", 17, application.NewFacadeV17) // adds load balancers to Expose
+	reg("Application", 18, application.NewFacadeV18) // adds AssignUnits
+	reg("Application", 19, application.NewFacadeV19) // adds SetColocationRules & ColocationRules
+	reg("Application", 20, application.NewFacadeV20) // adds RelationData
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
+	reg("Autoscale", 1, autoscale.NewAPI)
+	reg("Autoscaler", 1, autoscaler.NewAPI)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Backups", 2, backups.NewFacadeV2)
+	reg("Backups", 3, backups.NewFacadeV3)
+	reg("Backups", 4, backups.NewFacadeV4) // adds VerifyRestore
 	reg("Block", 2, block.NewAPI)
 	reg("Bundle", 1, bundle.NewFacadeV1)
 	reg("Bundle", 2, bundle.NewFacadeV2)
+	reg("Bundle", 3, bundle.NewFacadeV3)
 	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPI)
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacadeV1)
-	reg("Client", 2, client.NewFacade)
+	reg("Client", 2, client.NewFacadeV2)
+	reg("Client", 3, client.NewFacadeV3) // adds UpgradePrecheck
+	reg("Client", 4, client.NewFacade)   // adds PinModelAgentVersion
 	reg("Cloud", 1, cloud.NewFacadeV1)
 	reg("Cloud", 2, cloud.NewFacadeV2) // adds AddCloud, AddCredentials, CredentialContents, RemoveClouds
 	reg("Cloud", 3, cloud.NewFacadeV3) // changes signature of UpdateCredentials, adds ModifyCloudAccess
 	reg("Cloud", 4, cloud.NewFacadeV4) // adds UpdateCloud
 	reg("Cloud", 5, cloud.NewFacadeV5) // Removes DefaultCloud, handles config in AddCloud
+	reg("Cloud", 6, cloud.NewFacadeV6) // adds CloudCapabilities
 
 	// CAAS related facades.
 	// Move these to the correct place above once the feature flag disappears.
@@ -182,22 +216,35 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 5, controller.NewControllerAPIv5)
 	reg("Controller", 6, controller.NewControllerAPIv6)
 	reg("Controller", 7, controller.NewControllerAPIv7)
+	reg("Controller", 8, controller.NewControllerAPIv8)
+	reg("Controller", 9, controller.NewControllerAPIv9)   // adds staged upgrades
+	reg("Controller", 10, controller.NewControllerAPIv10) // adds dashboard summary
+	reg("Controller", 11, controller.NewControllerAPIv11) // adds models full status
+	reg("Controller", 12, controller.NewControllerAPIv12) // adds API sessions
+	reg("Controller", 13, controller.NewControllerAPIv13) // adds quotas
+	reg("Controller", 14, controller.NewControllerAPIv14) // adds cross-region migration
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPI)
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
 	reg("CredentialManager", 1, credentialmanager.NewCredentialManagerAPI)
+	reg("CrashReporter", 1, crashreporter.NewFacade)
+	reg("CrashReports", 1, crashreports.NewAPI)
 	reg("CredentialValidator", 1, credentialvalidator.NewCredentialValidatorAPIv1)
 	reg("CredentialValidator", 2, credentialvalidator.NewCredentialValidatorAPI) // adds WatchModelCredential
 	reg("ExternalControllerUpdater", 1, externalcontrollerupdater.NewStateAPI)
 
 	reg("Deployer", 1, deployer.NewDeployerAPI)
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
+	reg("DNSPublisher", 1, dnspublisher.NewAPI)
 	reg("FanConfigurer", 1, fanconfigurer.NewFanConfigurerAPI)
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
 	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5)
+	reg("Firewaller", 6, firewaller.NewStateFirewallerAPIV6) // adds GetLoadBalancers & SetLoadBalancerAddresses
 	reg("FirewallRules", 1, firewallrules.NewFacade)
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
+	reg("HighAvailability", 3, highavailability.NewHighAvailabilityAPIV3)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
+	reg("IdleAdvisor", 1, idleadvisor.NewAPI)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
 	reg("ImageMetadata", 3, imagemetadata.NewAPI)
 
@@ -224,6 +271,7 @@ func AllFacades() *facade.Registry {
 	reg("MachineManager", 3, machinemanager.NewFacade)   // Adds DestroyMachine and ForceDestroyMachine.
 	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Adds DestroyMachineWithParams.
 	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Adds UpgradeSeriesPrepare, removes UpdateMachineSeries.
+	reg("MachineManager", 6, machinemanager.NewFacadeV6) // Adds EstimateCosts.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
 	reg("Machiner", 1, machine.NewMachinerAPI)
@@ -236,10 +284,14 @@ func AllFacades() *facade.Registry {
 	reg("MigrationFlag", 1, migrationflag.NewFacade)
 	reg("MigrationMaster", 1, migrationmaster.NewFacade)
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
-	reg("MigrationTarget", 1, migrationtarget.NewFacade)
+	reg("MigrationTarget", 1, migrationtarget.NewFacadeV1)
+	reg("MigrationTarget", 2, migrationtarget.NewFacadeV2) // adds storage translation
+	reg("MigrationTarget", 3, migrationtarget.NewFacadeV3) // adds machine re-provisioning
+	reg("MigrationTarget", 4, migrationtarget.NewFacade)   // imports pinned agent versions
 
 	reg("ModelConfig", 1, modelconfig.NewFacadeV1)
 	reg("ModelConfig", 2, modelconfig.NewFacadeV2)
+	reg("ModelConfig", 3, modelconfig.NewFacadeV3)
 	reg("ModelGeneration", 1, modelgeneration.NewModelGenerationFacade)
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
@@ -247,8 +299,12 @@ func AllFacades() *facade.Registry {
 	reg("ModelManager", 5, modelmanager.NewFacadeV5) // adds ChangeModelCredential
 	reg("ModelManager", 6, modelmanager.NewFacadeV6) // adds cloud specific default config
 	reg("ModelManager", 7, modelmanager.NewFacadeV7) // DestroyModels gains 'force' and max-wait' parameters.
+	reg("ModelManager", 8, modelmanager.NewFacadeV8) // adds PreviewDestroyModels
+	reg("ModelPlan", 1, modelplan.NewAPI)
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
 
+	reg("OrphanFinder", 1, orphanfinder.NewAPI)
+	reg("Orphans", 1, orphans.NewAPI)
 	reg("Payloads", 1, payloads.NewFacade)
 	regHookContext(
 		"PayloadsHookContext", 1,
@@ -283,15 +339,22 @@ func AllFacades() *facade.Registry {
 	reg("Spaces", 2, spaces.NewAPIV2)
 	reg("Spaces", 3, spaces.NewAPI)
 
+	reg("SpareMachines", 1, sparemachines.NewAPI)
+
 	reg("StatusHistory", 2, statushistory.NewAPI)
 
 	reg("Storage", 3, storage.NewStorageAPIV3)
 	reg("Storage", 4, storage.NewStorageAPIV4) // changes Destroy() method signature.
-	reg("Storage", 5, storage.NewStorageAPI)   // Update and Delete storage pools and CreatePool bulk calls.
+	reg("Storage", 5, storage.NewStorageAPIV5) // Update and Delete storage pools and CreatePool bulk calls.
+	reg("Storage", 6, storage.NewStorageAPI)   // RetryStorage.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
+	reg("StorageProvisioner", 5, storageprovisioner.NewFacadeV5)
 	reg("Subnets", 2, subnets.NewAPI)
+	reg("TagReconciler", 1, tagreconciler.NewAPI)
+	reg("Timeline", 1, timeline.NewAPIV1)
+	reg("Timeline", 2, timeline.NewAPI) // adds Archived
 	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
 	reg("UnitAssigner", 1, unitassigner.New)
 
@@ -302,9 +365,12 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 8, uniter.NewUniterAPIV8)
 	reg("Uniter", 9, uniter.NewUniterAPIV9)
 	reg("Uniter", 10, uniter.NewUniterAPIV10)
-	reg("Uniter", 11, uniter.NewUniterAPI)
+	reg("Uniter", 11, uniter.NewUniterAPIV11)
+	reg("Uniter", 12, uniter.NewUniterAPIV12) // adds DrainScope
+	reg("Uniter", 13, uniter.NewUniterAPI)    // adds endpoints to port ranges
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
+	reg("Upgrader", 2, upgrader.NewUpgraderFacadeV2) // adds MaintenanceWindows
 	reg("UpgradeSeries", 1, upgradeseries.NewAPI)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
 	reg("UserManager", 2, usermanager.NewUserManagerAPI) // Adds ResetPassword
@@ -0,0 +1,205 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package apipolicy provides a hook through which the API server asks
+// whether an API method call may go ahead, before it is dispatched to
+// the facade. This allows site-specific policy engines to make
+// decisions that are finer grained than juju's own permission model.
+package apipolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// Request describes an API method call to be authorised. It only
+// identifies the call; the call's arguments are never passed on, as
+// they may hold passwords, credentials or secrets.
+type Request struct {
+	// Entity is the authenticated entity making the call.
+	Entity names.Tag
+
+	// ModelUUID is the UUID of the model the connection is for.
+	ModelUUID string
+
+	// Facade, Version, ObjectID and Method identify the method
+	// being called.
+	Facade   string
+	Version  int
+	ObjectID string
+	Method   string
+}
+
+// Checker decides whether API method calls are allowed.
+type Checker interface {
+	// Allowed reports whether the described call may go ahead.
+	// An error is returned if no decision could be made.
+	Allowed(ctx context.Context, req Request) (bool, error)
+}
+
+// AllowAll is a Checker that allows every call.
+var AllowAll Checker = allowAll{}
+
+type allowAll struct{}
+
+// Allowed is part of the Checker interface.
+func (allowAll) Allowed(context.Context, Request) (bool, error) {
+	return true, nil
+}
+
+// DefaultTimeout is how long HTTPChecker waits for the policy
+// endpoint to respond if its client has no timeout of its own.
+const DefaultTimeout = 10 * time.Second
+
+// HTTPChecker is a Checker that consults an external policy endpoint.
+//
+// The request is POSTed to the URL as a JSON document of the form
+// {"input": {...}}, where the input holds the fields of Input. The
+// endpoint must respond with {"result": true} to allow the call;
+// any other result denies it. This is the form used by the Open
+// Policy Agent's data API, so an OPA rule may be used directly.
+type HTTPChecker struct {
+	// URL is the policy endpoint.
+	URL string
+
+	// Client is used to make requests. If it is nil, a client
+	// with DefaultTimeout is used.
+	Client *http.Client
+}
+
+// Input is the description of a call sent to a policy endpoint.
+type Input struct {
+	Entity    string `json:"entity,omitempty"`
+	ModelUUID string `json:"model-uuid,omitempty"`
+	Facade    string `json:"facade"`
+	Version   int    `json:"version"`
+	ObjectID  string `json:"object-id,omitempty"`
+	Method    string `json:"method"`
+}
+
+// NewInput returns the description of the request that is sent
+// to policy endpoints.
+func NewInput(req Request) Input {
+	input := Input{
+		ModelUUID: req.ModelUUID,
+		Facade:    req.Facade,
+		Version:   req.Version,
+		ObjectID:  req.ObjectID,
+		Method:    req.Method,
+	}
+	if req.Entity != nil {
+		input.Entity = req.Entity.String()
+	}
+	return input
+}
+
+var defaultClient = &http.Client{Timeout: DefaultTimeout}
+
+// Allowed is part of the Checker interface.
+func (c *HTTPChecker) Allowed(ctx context.Context, req Request) (bool, error) {
+	body, err := json.Marshal(struct {
+		Input Input `json:"input"`
+	}{NewInput(req)})
+	if err != nil {
+		return false, errors.Annotate(err, "marshalling policy input")
+	}
+	httpReq, err := http.NewRequest("POST", c.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	client := c.Client
+	if client == nil {
+		client = defaultClient
+	}
+	resp, err := client.Do(httpReq.WithContext(ctx))
+	if err != nil {
+		return false, errors.Annotate(err, "querying policy endpoint")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, errors.Errorf("policy endpoint returned %s", resp.Status)
+	}
+	var result struct {
+		Result interface{} `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, errors.Annotate(err, "decoding policy response")
+	}
+	allowed, _ := result.Result.(bool)
+	return allowed, nil
+}
+
+// DefaultCacheTTL is how long the decisions of a policy endpoint
+// are cached for.
+const DefaultCacheTTL = time.Minute
+
+// maxCachedDecisions bounds the number of decisions held by a
+// caching checker.
+const maxCachedDecisions = 10000
+
+// NewCachingChecker returns a Checker that remembers the decisions
+// made by the given checker for ttl, so that repeated calls do not
+// each consult the policy endpoint. Errors are not cached.
+func NewCachingChecker(checker Checker, clock clock.Clock, ttl time.Duration) Checker {
+	return &cachingChecker{
+		checker:   checker,
+		clock:     clock,
+		ttl:       ttl,
+		decisions: make(map[Input]decision),
+	}
+}
+
+type cachingChecker struct {
+	checker Checker
+	clock   clock.Clock
+	ttl     time.Duration
+
+	mu        sync.Mutex
+	decisions map[Input]decision
+}
+
+type decision struct {
+	allowed bool
+	expires time.Time
+}
+
+// Allowed is part of the Checker interface.
+func (c *cachingChecker) Allowed(ctx context.Context, req Request) (bool, error) {
+	key := NewInput(req)
+	c.mu.Lock()
+	d, ok := c.decisions[key]
+	c.mu.Unlock()
+	if ok && c.clock.Now().Before(d.expires) {
+		return d.allowed, nil
+	}
+
+	allowed, err := c.checker.Allowed(ctx, req)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	if len(c.decisions) >= maxCachedDecisions {
+		for k, d := range c.decisions {
+			if !now.Before(d.expires) {
+				delete(c.decisions, k)
+			}
+		}
+		if len(c.decisions) >= maxCachedDecisions {
+			c.decisions = make(map[Input]decision)
+		}
+	}
+	c.decisions[key] = decision{allowed: allowed, expires: now.Add(c.ttl)}
+	return allowed, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apipolicy_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/apipolicy"
+)
+
+type policySuite struct{}
+
+var _ = gc.Suite(&policySuite{})
+
+var testRequest = apipolicy.Request{
+	Entity:    names.NewUserTag("bob"),
+	ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	Facade:    "Application",
+	Version:   8,
+	Method:    "Destroy",
+}
+
+func (s *policySuite) TestAllowAll(c *gc.C) {
+	allowed, err := apipolicy.AllowAll.Allowed(context.Background(), testRequest)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allowed, jc.IsTrue)
+}
+
+func (s *policySuite) TestNewInput(c *gc.C) {
+	input := apipolicy.NewInput(testRequest)
+	c.Assert(input, jc.DeepEquals, apipolicy.Input{
+		Entity:    "user-bob",
+		ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Facade:    "Application",
+		Version:   8,
+		Method:    "Destroy",
+	})
+}
+
+func (s *policySuite) TestHTTPCheckerAllowed(c *gc.C) {
+	var received struct {
+		Input apipolicy.Input `json:"input"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		c.Check(req.Method, gc.Equals, "POST")
+		c.Check(req.Header.Get("Content-Type"), gc.Equals, "application/json")
+		err := json.NewDecoder(req.Body).Decode(&received)
+		c.Check(err, jc.ErrorIsNil)
+		w.Write([]byte(`{"result": true}`))
+	}))
+	defer srv.Close()
+
+	checker := &apipolicy.HTTPChecker{URL: srv.URL}
+	allowed, err := checker.Allowed(context.Background(), testRequest)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allowed, jc.IsTrue)
+	c.Assert(received.Input.Entity, gc.Equals, "user-bob")
+	c.Assert(received.Input.Facade, gc.Equals, "Application")
+	c.Assert(received.Input.Method, gc.Equals, "Destroy")
+}
+
+func (s *policySuite) TestHTTPCheckerDenied(c *gc.C) {
+	for _, body := range []string{`{"result": false}`, `{}`, `{"result": "yes"}`} {
+		c.Logf("response %s", body)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(body))
+		}))
+		checker := &apipolicy.HTTPChecker{URL: srv.URL}
+		allowed, err := checker.Allowed(context.Background(), testRequest)
+		srv.Close()
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(allowed, jc.IsFalse)
+	}
+}
+
+func (s *policySuite) TestHTTPCheckerError(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		http.Error(w, "boom", http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	checker := &apipolicy.HTTPChecker{URL: srv.URL}
+	allowed, err := checker.Allowed(context.Background(), testRequest)
+	c.Assert(err, gc.ErrorMatches, "policy endpoint returned 500 Internal Server Error")
+	c.Assert(allowed, jc.IsFalse)
+}
+
+type countingChecker struct {
+	calls   int
+	allowed bool
+	err     error
+}
+
+func (c *countingChecker) Allowed(context.Context, apipolicy.Request) (bool, error) {
+	c.calls++
+	return c.allowed, c.err
+}
+
+func (s *policySuite) TestCachingChecker(c *gc.C) {
+	clock := testclock.NewClock(time.Time{})
+	inner := &countingChecker{allowed: true}
+	checker := apipolicy.NewCachingChecker(inner, clock, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		allowed, err := checker.Allowed(context.Background(), testRequest)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(allowed, jc.IsTrue)
+	}
+	c.Assert(inner.calls, gc.Equals, 1)
+
+	// Other calls are decided separately.
+	other := testRequest
+	other.Method = "AddUnits"
+	_, err := checker.Allowed(context.Background(), other)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inner.calls, gc.Equals, 2)
+
+	// Decisions expire.
+	inner.allowed = false
+	clock.Advance(time.Minute)
+	allowed, err := checker.Allowed(context.Background(), testRequest)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allowed, jc.IsFalse)
+	c.Assert(inner.calls, gc.Equals, 3)
+}
+
+func (s *policySuite) TestCachingCheckerDoesNotCacheErrors(c *gc.C) {
+	clock := testclock.NewClock(time.Time{})
+	inner := &countingChecker{err: errors.New("boom")}
+	checker := apipolicy.NewCachingChecker(inner, clock, time.Minute)
+
+	_, err := checker.Allowed(context.Background(), testRequest)
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	inner.err = nil
+	inner.allowed = true
+	allowed, err := checker.Allowed(context.Background(), testRequest)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(allowed, jc.IsTrue)
+	c.Assert(inner.calls, gc.Equals, 2)
+}
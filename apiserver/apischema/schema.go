@@ -0,0 +1,204 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package apischema generates JSON schemas describing the methods of
+// the facades served by the API server, so that clients written in
+// other languages can be generated rather than reverse-engineered.
+package apischema
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/rpc/rpcreflect"
+)
+
+// Schema is a JSON schema describing the parameters or result of a
+// facade method, or a type that they refer to.
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// MethodSchema describes a single facade method. A nil Params or
+// Result means that the method takes no parameters or returns no
+// result.
+type MethodSchema struct {
+	Params *Schema `json:"params,omitempty"`
+	Result *Schema `json:"result,omitempty"`
+}
+
+// FacadeSchema describes a version of a facade.
+type FacadeSchema struct {
+	Name    string                  `json:"name"`
+	Version int                     `json:"version"`
+	Methods map[string]MethodSchema `json:"methods"`
+
+	// Definitions holds the schemas of the named struct types
+	// referred to by the methods, keyed by type name.
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// Generate returns the schemas of all the facades in the registry,
+// ordered by name and version.
+func Generate(registry *facade.Registry) []FacadeSchema {
+	details := registry.ListDetails()
+	sort.Slice(details, func(i, j int) bool {
+		if details[i].Name != details[j].Name {
+			return details[i].Name < details[j].Name
+		}
+		return details[i].Version < details[j].Version
+	})
+	schemas := make([]FacadeSchema, len(details))
+	for i, d := range details {
+		schemas[i] = FacadeSchemaOf(d.Name, d.Version, d.Type)
+	}
+	return schemas
+}
+
+// FacadeSchemaOf returns the schema of the given version of a facade
+// implemented by the given type.
+func FacadeSchemaOf(name string, version int, facadeType reflect.Type) FacadeSchema {
+	r := &reflector{definitions: make(map[string]*Schema)}
+	schema := FacadeSchema{
+		Name:        name,
+		Version:     version,
+		Methods:     make(map[string]MethodSchema),
+		Definitions: r.definitions,
+	}
+	objType := rpcreflect.ObjTypeOf(facadeType)
+	if objType == nil {
+		return schema
+	}
+	for _, methodName := range objType.MethodNames() {
+		method, err := objType.Method(methodName)
+		if err != nil {
+			continue
+		}
+		var methodSchema MethodSchema
+		if method.Params != nil {
+			methodSchema.Params = r.schemaOf(method.Params)
+		}
+		if method.Result != nil {
+			methodSchema.Result = r.schemaOf(method.Result)
+		}
+		schema.Methods[methodName] = methodSchema
+	}
+	return schema
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+type reflector struct {
+	definitions map[string]*Schema
+}
+
+func (r *reflector) schemaOf(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType):
+		return &Schema{Type: "string"}
+	case t.Implements(jsonMarshalerType) || reflect.PtrTo(t).Implements(jsonMarshalerType):
+		// The type has its own encoding, so it could be anything.
+		return &Schema{}
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings.
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return r.structSchema(t)
+		}
+		if _, ok := r.definitions[t.Name()]; !ok {
+			// Add the definition before filling it in, so that
+			// recursive types refer to it rather than recursing.
+			schema := &Schema{}
+			r.definitions[t.Name()] = schema
+			*schema = *r.structSchema(t)
+		}
+		return &Schema{Ref: "#/definitions/" + t.Name()}
+	}
+	// Interfaces, and anything else, can hold any value.
+	return &Schema{}
+}
+
+func (r *reflector) structSchema(t reflect.Type) *Schema {
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+	r.addFields(schema, t)
+	sort.Strings(schema.Required)
+	return schema
+}
+
+// addFields adds the JSON-encoded fields of the struct type to the
+// schema, following the rules of encoding/json.
+func (r *reflector) addFields(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options := tag, ""
+		if comma := strings.Index(tag, ","); comma >= 0 {
+			name, options = tag[:comma], tag[comma+1:]
+		}
+		fieldType := field.Type
+		if field.Anonymous && name == "" {
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				r.addFields(schema, fieldType)
+				continue
+			}
+		}
+		if field.PkgPath != "" {
+			// Unexported fields aren't encoded.
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = r.schemaOf(fieldType)
+		optional := strings.Contains(options, "omitempty") || fieldType.Kind() == reflect.Ptr
+		if !optional {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apischema_test
+
+import (
+	"reflect"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/apischema"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/testing"
+)
+
+type SchemaSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&SchemaSuite{})
+
+type Args struct {
+	Names   []string          `json:"names"`
+	Limit   int               `json:"limit,omitempty"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Since   *time.Time        `json:"since"`
+	Ignored string            `json:"-"`
+	Tree    *Tree             `json:"tree,omitempty"`
+}
+
+type Tree struct {
+	Children []Tree `json:"children"`
+}
+
+type Result struct {
+	Common
+	Data []byte `json:"data"`
+}
+
+type Common struct {
+	Error string `json:"error,omitempty"`
+}
+
+type testFacade struct{}
+
+func (testFacade) Find(Args) (Result, error) {
+	return Result{}, nil
+}
+
+func (testFacade) Ping() {}
+
+func (s *SchemaSuite) TestFacadeSchemaOf(c *gc.C) {
+	schema := apischema.FacadeSchemaOf("Test", 2, reflect.TypeOf(testFacade{}))
+	c.Assert(schema, jc.DeepEquals, apischema.FacadeSchema{
+		Name:    "Test",
+		Version: 2,
+		Methods: map[string]apischema.MethodSchema{
+			"Find": {
+				Params: &apischema.Schema{Ref: "#/definitions/Args"},
+				Result: &apischema.Schema{Ref: "#/definitions/Result"},
+			},
+			"Ping": {},
+		},
+		Definitions: map[string]*apischema.Schema{
+			"Args": {
+				Type: "object",
+				Properties: map[string]*apischema.Schema{
+					"names":  {Type: "array", Items: &apischema.Schema{Type: "string"}},
+					"limit":  {Type: "integer"},
+					"labels": {Type: "object", AdditionalProperties: &apischema.Schema{Type: "string"}},
+					"since":  {Type: "string", Format: "date-time"},
+					"tree":   {Ref: "#/definitions/Tree"},
+				},
+				Required: []string{"names"},
+			},
+			"Tree": {
+				Type: "object",
+				Properties: map[string]*apischema.Schema{
+					"children": {Type: "array", Items: &apischema.Schema{Ref: "#/definitions/Tree"}},
+				},
+				Required: []string{"children"},
+			},
+			"Result": {
+				Type: "object",
+				Properties: map[string]*apischema.Schema{
+					"error": {Type: "string"},
+					"data":  {Type: "string", Format: "byte"},
+				},
+				Required: []string{"data"},
+			},
+		},
+	})
+}
+
+func (s *SchemaSuite) TestGenerate(c *gc.C) {
+	registry := &facade.Registry{}
+	for _, version := range []int{2, 1} {
+		err := registry.Register("Test", version, func(facade.Context) (facade.Facade, error) {
+			return testFacade{}, nil
+		}, reflect.TypeOf(testFacade{}))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	schemas := apischema.Generate(registry)
+	c.Assert(schemas, gc.HasLen, 2)
+	c.Check(schemas[0].Name, gc.Equals, "Test")
+	c.Check(schemas[0].Version, gc.Equals, 1)
+	c.Check(schemas[1].Version, gc.Equals, 2)
+	c.Check(schemas[1].Methods, gc.HasLen, 2)
+}
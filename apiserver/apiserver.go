@@ -263,6 +263,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		presence:     cfg.Presence,
 		leaseManager: cfg.LeaseManager,
 		logger:       loggo.GetLogger("juju.apiserver"),
+		machineID:    cfg.Tag.Id(),
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -319,12 +320,23 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		}
 	}
 
+	// Forget the connections that were logged in when the API server
+	// last stopped.
+	if err := srv.shared.statePool.SystemState().RemoveAPISessions(srv.tag.Id()); err != nil {
+		return nil, errors.Annotate(err, "removing old API sessions")
+	}
+
 	unsubscribe, err := cfg.Hub.Subscribe(apiserver.RestartTopic, func(string, map[string]interface{}) {
 		srv.tomb.Kill(dependency.ErrBounce)
 	})
 	if err != nil {
 		return nil, errors.Annotate(err, "unable to subscribe to restart message")
 	}
+	unsubscribeSessions, err := cfg.Hub.Subscribe(apiserver.CloseSessionsTopic, srv.closeSessions)
+	if err != nil {
+		unsubscribe()
+		return nil, errors.Annotate(err, "unable to subscribe to close sessions message")
+	}
 
 	ready := make(chan struct{})
 	srv.tomb.Go(func() error {
@@ -332,6 +344,7 @@ func newServer(cfg ServerConfig) (_ *Server, err error) {
 		defer srv.logSinkWriter.Close()
 		defer srv.shared.Close()
 		defer unsubscribe()
+		defer unsubscribeSessions()
 		return srv.loop(ready)
 	})
 
@@ -481,6 +494,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 
 	httpCtxt := httpContext{srv: srv}
 	mainAPIHandler := http.HandlerFunc(srv.apiHandler)
+	grpcAPIHandler := newGRPCHandler(srv)
 	logStreamHandler := newLogStreamEndpointHandler(httpCtxt)
 	debugLogHandler := newDebugLogDBHandler(
 		httpCtxt, srv.authenticator,
@@ -542,6 +556,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			return rst, st, entity.Tag(), nil
 		},
 	}
+	resourceDownloadLimiter := newBandwidthLimiter()
 	unitResourcesHandler := &UnitResourcesHandler{
 		NewOpener: func(req *http.Request, tagKinds ...string) (resource.Opener, state.PoolHelper, error) {
 			st, _, err := httpCtxt.stateForRequestAuthenticatedTag(req, tagKinds...)
@@ -557,6 +572,16 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 			if err != nil {
 				return nil, nil, errors.Trace(err)
 			}
+			model, err := st.Model()
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			cfg, err := model.Config()
+			if err != nil {
+				return nil, nil, errors.Trace(err)
+			}
+			opener = resourceDownloadLimiter.wrap(opener, model.UUID(), cfg.ResourceDownloadRateLimit())
+			opener = srv.shared.bandwidth.wrap(opener, resourceTraffic, srv.shared.bandwidthLimit(resourceTraffic))
 			return opener, st, nil
 		},
 	}
@@ -687,6 +712,15 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		tracked:         true,
 		unauthenticated: true,
 		noModelUUID:     true,
+	}, {
+		// The experimental gRPC transport, enabled by the "grpc-api"
+		// feature flag. The model is passed in the call metadata.
+		pattern:         "/" + grpcServicePrefix + ":facade/:method",
+		methods:         []string{"POST"},
+		handler:         grpcAPIHandler,
+		tracked:         true,
+		unauthenticated: true,
+		noModelUUID:     true,
 	}, {
 		pattern:         "/register",
 		handler:         registerHandler,
@@ -728,6 +762,11 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	}, {
 		pattern: "/gui-version",
 		handler: guiVersionHandler,
+	}, {
+		pattern:    "/schema",
+		methods:    []string{"GET"},
+		handler:    schemaHandler{},
+		authorizer: tagKindAuthorizer{names.UserTagKind},
 	}, {
 		pattern:         localOfferAccessLocationPath + "/discharge",
 		handler:         appOfferDischargeMux,
@@ -818,11 +857,12 @@ func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
 		logger.Tracef("got a request for model %q", modelUUID)
 		if err := srv.serveConn(
 			req.Context(),
-			conn,
+			jsoncodec.NewWebsocket(conn.Conn),
 			modelUUID,
 			connectionID,
 			apiObserver,
 			req.Host,
+			req.RemoteAddr,
 		); err != nil {
 			logger.Errorf("error serving RPCs: %v", err)
 		}
@@ -831,13 +871,13 @@ func (srv *Server) apiHandler(w http.ResponseWriter, req *http.Request) {
 
 func (srv *Server) serveConn(
 	ctx context.Context,
-	wsConn *websocket.Conn,
+	codec rpc.Codec,
 	modelUUID string,
 	connectionID uint64,
 	apiObserver observer.Observer,
 	host string,
+	remoteAddr string,
 ) error {
-	codec := jsoncodec.NewWebsocket(wsConn.Conn)
 	recorderFactory := observer.NewRecorderFactory(
 		apiObserver, nil, observer.NoCaptureArgs)
 	conn := rpc.NewConn(codec, recorderFactory)
@@ -858,7 +898,7 @@ func (srv *Server) serveConn(
 	st, err := statePool.Get(resolvedModelUUID)
 	if err == nil {
 		defer st.Release()
-		h, err = newAPIHandler(srv, st.State, conn, modelUUID, connectionID, host)
+		h, err = newAPIHandler(srv, st.State, conn, modelUUID, connectionID, host, remoteAddr)
 	}
 	if errors.IsNotFound(err) {
 		err = errors.Wrap(err, common.UnknownModelError(resolvedModelUUID))
@@ -877,6 +917,7 @@ func (srv *Server) serveConn(
 		}
 		conn.ServeRoot(newAdminRoot(h, adminAPIs), recorderFactory, serverError)
 	}
+	defer srv.shared.sessions.remove(connectionID)
 	conn.Start(ctx)
 	select {
 	case <-conn.Dead():
@@ -885,6 +926,16 @@ func (srv *Server) serveConn(
 	return conn.Close()
 }
 
+// closeSessions closes the logged in API connections identified by
+// the message.
+func (srv *Server) closeSessions(topic string, data apiserver.CloseSessions, err error) {
+	if err != nil {
+		logger.Criticalf("programming error in %s message data: %v", topic, err)
+		return
+	}
+	srv.shared.sessions.close(data)
+}
+
 // publicDNSName returns the current public hostname.
 func (srv *Server) publicDNSName() string {
 	srv.mu.Lock()
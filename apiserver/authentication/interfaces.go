@@ -4,6 +4,8 @@
 package authentication
 
 import (
+	"time"
+
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
@@ -21,3 +23,10 @@ type EntityAuthenticator interface {
 type EntityFinder interface {
 	FindEntity(tag names.Tag) (state.Entity, error)
 }
+
+// LoginRevocations reports when users' logins were revoked.
+type LoginRevocations interface {
+	// LoginsRevokedAt returns when the user's logins were last
+	// revoked, or the zero time if they never have been.
+	LoginsRevokedAt(user names.UserTag) (time.Time, error)
+}
@@ -0,0 +1,478 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication
+
+import (
+	"crypto"
+	"crypto/rsa"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// OIDCKeyGetter provides the public keys used by an OpenID Connect
+// provider to sign ID tokens.
+type OIDCKeyGetter interface {
+	// Key returns the public key with the specified key ID.
+	Key(kid string) (*rsa.PublicKey, error)
+
+	// SigningAlgorithms returns the algorithms the provider
+	// uses to sign ID tokens.
+	SigningAlgorithms() ([]string, error)
+}
+
+// oidcHashes holds the hash used by each of the token signing
+// algorithms that can be verified.
+var oidcHashes = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+}
+
+// OIDCAuthenticator performs authentication for external users
+// presenting an OpenID Connect ID token.
+type OIDCAuthenticator struct {
+	// IssuerURL holds the URL of the OpenID Connect provider.
+	// Tokens must name this URL as their issuer.
+	IssuerURL string
+
+	// ClientID holds the client ID registered with the provider
+	// for this controller. Tokens must name this ID as an audience.
+	ClientID string
+
+	// GroupsClaim holds the name of the token claim listing the
+	// groups the user belongs to.
+	GroupsClaim string
+
+	// UserDomain holds the domain given to the users of the
+	// provider. If it is empty, the host name of IssuerURL is
+	// used, so that users of different providers, and users
+	// authenticated by other means, are never confused.
+	UserDomain string
+
+	// Clock is used to check the expiry time of tokens.
+	Clock clock.Clock
+
+	// Keys provides the keys used to verify token signatures.
+	Keys OIDCKeyGetter
+
+	// UpdateAccess, if non-nil, is called with the user's tag and
+	// groups once the token has been verified, and before the user
+	// entity is looked up.
+	UpdateAccess func(tag names.UserTag, groups []string) error
+}
+
+var _ EntityAuthenticator = (*OIDCAuthenticator)(nil)
+
+// oidcHeader holds the fields of the token header that we care about.
+type oidcHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// oidcAudience holds the "aud" claim of a token, which may
+// be either a single string or a list of strings.
+type oidcAudience []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (a *oidcAudience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = oidcAudience{single}
+		return nil
+	}
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err != nil {
+		return errors.Trace(err)
+	}
+	*a = multi
+	return nil
+}
+
+// Authenticate authenticates the user identified by the ID token
+// held in the request. The tag passed in must be the tag of the
+// user identified by the token's claims.
+func (a *OIDCAuthenticator) Authenticate(entityFinder EntityFinder, authTag names.Tag, req params.LoginRequest) (state.Entity, error) {
+	if _, ok := authTag.(names.UserTag); !ok {
+		return nil, errors.Annotate(common.ErrBadRequest, "token logins must be for a user")
+	}
+	claims, err := a.verify(req.Token)
+	if errors.IsNotSupported(err) {
+		return nil, errors.Annotate(common.ErrBadCreds, err.Error())
+	} else if err != nil {
+		logger.Debugf("invalid ID token: %v", err)
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	tag, err := a.userTag(claims)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if tag != authTag {
+		logger.Debugf("ID token for %q used to log in as %q", tag.Id(), authTag.Id())
+		return nil, errors.Trace(common.ErrBadCreds)
+	}
+	if a.UpdateAccess != nil {
+		groups, err := a.groups(claims)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := a.UpdateAccess(tag, groups); err != nil {
+			return nil, errors.Annotatef(err, "updating access for %q", tag.Id())
+		}
+	}
+	entity, err := entityFinder.FindEntity(tag)
+	if errors.IsNotFound(err) {
+		return nil, errors.Trace(common.ErrBadCreds)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return entity, nil
+}
+
+// verify checks the signature, issuer, audience and expiry of
+// the token, returning its claims.
+func (a *OIDCAuthenticator) verify(token string) (map[string]json.RawMessage, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed token")
+	}
+	var header oidcHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, errors.Annotate(err, "decoding header")
+	}
+	hash, err := a.signatureHash(header.Algorithm)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	key, err := a.Keys.Key(header.KeyID)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting key %q", header.KeyID)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[2], "="))
+	if err != nil {
+		return nil, errors.Annotate(err, "decoding signature")
+	}
+	h := hash.New()
+	h.Write([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(key, hash, h.Sum(nil), signature); err != nil {
+		return nil, errors.Annotate(err, "verifying signature")
+	}
+
+	var claims map[string]json.RawMessage
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, errors.Annotate(err, "decoding claims")
+	}
+	var standard struct {
+		Issuer   string       `json:"iss"`
+		Audience oidcAudience `json:"aud"`
+		Expiry   int64        `json:"exp"`
+	}
+	if err := decodeSegment(parts[1], &standard); err != nil {
+		return nil, errors.Annotate(err, "decoding claims")
+	}
+	if strings.TrimSuffix(standard.Issuer, "/") != strings.TrimSuffix(a.IssuerURL, "/") {
+		return nil, errors.Errorf("unexpected issuer %q", standard.Issuer)
+	}
+	var audienceOK bool
+	for _, aud := range standard.Audience {
+		if aud == a.ClientID {
+			audienceOK = true
+			break
+		}
+	}
+	if !audienceOK {
+		return nil, errors.Errorf("token not issued for client %q", a.ClientID)
+	}
+	if !a.Clock.Now().Before(time.Unix(standard.Expiry, 0)) {
+		return nil, errors.New("token expired")
+	}
+	return claims, nil
+}
+
+// signatureHash returns the hash with which to verify a token signed
+// with the given algorithm. The algorithm must be one the provider
+// says it signs ID tokens with, and one that can be verified;
+// otherwise an error satisfying errors.IsNotSupported is returned.
+func (a *OIDCAuthenticator) signatureHash(algorithm string) (crypto.Hash, error) {
+	supported, err := a.Keys.SigningAlgorithms()
+	if err != nil {
+		return 0, errors.Annotate(err, "getting signing algorithms")
+	}
+	var providerSupported bool
+	for _, alg := range supported {
+		if alg == algorithm {
+			providerSupported = true
+			break
+		}
+	}
+	if !providerSupported {
+		return 0, errors.NotSupportedf(
+			"ID token signing algorithm %q (provider signs with %s)",
+			algorithm, strings.Join(supported, ", "),
+		)
+	}
+	hash, ok := oidcHashes[algorithm]
+	if !ok {
+		return 0, errors.NotSupportedf("ID token signing algorithm %q", algorithm)
+	}
+	return hash, nil
+}
+
+// userTag returns the tag of the user identified by the token. Users
+// are identified by the "sub" claim, which the provider guarantees is
+// unique and never reassigned; claims such as "preferred_username" and
+// "email" may be chosen by the users themselves, so they cannot be
+// used to identify them.
+func (a *OIDCAuthenticator) userTag(claims map[string]json.RawMessage) (names.UserTag, error) {
+	raw, ok := claims["sub"]
+	if !ok {
+		return names.UserTag{}, errors.New("ID token does not identify a user")
+	}
+	var subject string
+	if err := json.Unmarshal(raw, &subject); err != nil {
+		return names.UserTag{}, errors.Annotate(err, "decoding \"sub\" claim")
+	}
+	if subject == "" {
+		return names.UserTag{}, errors.New("ID token does not identify a user")
+	}
+	if !names.IsValidUserName(subject) {
+		return names.UserTag{}, errors.Errorf("subject %q is not a valid user name", subject)
+	}
+	domain := a.UserDomain
+	if domain == "" {
+		var err error
+		if domain, err = OIDCUserDomain(a.IssuerURL); err != nil {
+			return names.UserTag{}, errors.Trace(err)
+		}
+	}
+	return names.NewLocalUserTag(subject).WithDomain(domain), nil
+}
+
+// OIDCUserDomain returns the user domain derived from the URL of
+// an OpenID Connect provider: the URL's host name.
+func OIDCUserDomain(issuerURL string) (string, error) {
+	u, err := url.Parse(issuerURL)
+	if err != nil {
+		return "", errors.Annotate(err, "parsing issuer URL")
+	}
+	domain := u.Hostname()
+	if domain == "" || domain == "local" || !names.IsValidUser("user@"+domain) {
+		return "", errors.Errorf("cannot derive a user domain from issuer URL %q", issuerURL)
+	}
+	return domain, nil
+}
+
+// groups returns the groups listed in the token's groups claim.
+func (a *OIDCAuthenticator) groups(claims map[string]json.RawMessage) ([]string, error) {
+	raw, ok := claims[a.GroupsClaim]
+	if !ok {
+		return nil, nil
+	}
+	var groups []string
+	if err := json.Unmarshal(raw, &groups); err != nil {
+		return nil, errors.Annotatef(err, "decoding %q claim", a.GroupsClaim)
+	}
+	return groups, nil
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(json.Unmarshal(data, v))
+}
+
+// OIDCKeySet is an OIDCKeyGetter that fetches the signing keys and
+// algorithms published by an OpenID Connect provider. They are cached,
+// and fetched again when a token refers to a key that is not known,
+// at most once every MinRefetchInterval.
+type OIDCKeySet struct {
+	// IssuerURL holds the URL of the OpenID Connect provider.
+	IssuerURL string
+
+	// Client is used to make requests to the provider. If it is
+	// nil, a client with a timeout of DefaultOIDCFetchTimeout
+	// is used.
+	Client *http.Client
+
+	// Clock is used to limit how often keys are fetched. If it
+	// is nil, the wall clock is used.
+	Clock clock.Clock
+
+	mu         sync.Mutex
+	keys       map[string]*rsa.PublicKey
+	algorithms []string
+	lastFetch  time.Time
+	fetching   chan struct{}
+}
+
+const (
+	// DefaultOIDCFetchTimeout is how long OIDCKeySet waits for the
+	// provider to respond, if its client has no timeout of its own.
+	DefaultOIDCFetchTimeout = 10 * time.Second
+
+	// MinRefetchInterval is the minimum time between the fetches of
+	// an OIDCKeySet, so that tokens naming unknown keys cannot be
+	// used to keep the controller fetching keys.
+	MinRefetchInterval = time.Minute
+)
+
+var oidcClient = &http.Client{Timeout: DefaultOIDCFetchTimeout}
+
+// Key is part of the OIDCKeyGetter interface.
+func (s *OIDCKeySet) Key(kid string) (*rsa.PublicKey, error) {
+	err := s.fetch(func() bool {
+		_, ok := s.keys[kid]
+		return ok
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if key, ok := s.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, errors.NotFoundf("key %q", kid)
+}
+
+// SigningAlgorithms is part of the OIDCKeyGetter interface.
+func (s *OIDCKeySet) SigningAlgorithms() ([]string, error) {
+	err := s.fetch(func() bool {
+		return s.algorithms != nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.algorithms == nil {
+		return nil, errors.NotFoundf("provider signing algorithms")
+	}
+	return s.algorithms, nil
+}
+
+// fetch fetches the provider's keys and algorithms unless cached,
+// called with s.mu held, reports that what is needed is known, or
+// they were fetched less than MinRefetchInterval ago.
+func (s *OIDCKeySet) fetch(cached func() bool) error {
+	clk := s.Clock
+	if clk == nil {
+		clk = clock.WallClock
+	}
+	s.mu.Lock()
+	if cached() {
+		s.mu.Unlock()
+		return nil
+	}
+	if fetching := s.fetching; fetching != nil {
+		// Wait for the fetch already in progress rather
+		// than starting another.
+		s.mu.Unlock()
+		<-fetching
+		return nil
+	}
+	if !s.lastFetch.IsZero() && clk.Now().Sub(s.lastFetch) < MinRefetchInterval {
+		s.mu.Unlock()
+		return nil
+	}
+	fetching := make(chan struct{})
+	s.fetching = fetching
+	s.mu.Unlock()
+
+	keys, algorithms, err := s.fetchKeys()
+
+	s.mu.Lock()
+	s.lastFetch = clk.Now()
+	if err == nil {
+		s.keys = keys
+		s.algorithms = algorithms
+	}
+	s.fetching = nil
+	close(fetching)
+	s.mu.Unlock()
+	return errors.Trace(err)
+}
+
+func (s *OIDCKeySet) fetchKeys() (map[string]*rsa.PublicKey, []string, error) {
+	var discovery struct {
+		JWKSURI           string   `json:"jwks_uri"`
+		SigningAlgorithms []string `json:"id_token_signing_alg_values_supported"`
+	}
+	discoveryURL := strings.TrimSuffix(s.IssuerURL, "/") + "/.well-known/openid-configuration"
+	if err := s.getJSON(discoveryURL, &discovery); err != nil {
+		return nil, nil, errors.Annotate(err, "fetching provider configuration")
+	}
+	if discovery.JWKSURI == "" {
+		return nil, nil, errors.New("provider configuration does not specify jwks_uri")
+	}
+	algorithms := discovery.SigningAlgorithms
+	if len(algorithms) == 0 {
+		// Providers are required to publish their algorithms,
+		// and to support RS256.
+		algorithms = []string{"RS256"}
+	}
+	var keySet struct {
+		Keys []struct {
+			KeyType string `json:"kty"`
+			KeyID   string `json:"kid"`
+			N       string `json:"n"`
+			E       string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := s.getJSON(discovery.JWKSURI, &keySet); err != nil {
+		return nil, nil, errors.Annotate(err, "fetching provider keys")
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range keySet.Keys {
+		if k.KeyType != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "decoding modulus of key %q", k.KeyID)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, nil, errors.Annotatef(err, "decoding exponent of key %q", k.KeyID)
+		}
+		keys[k.KeyID] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	return keys, algorithms, nil
+}
+
+func (s *OIDCKeySet) getJSON(location string, v interface{}) error {
+	client := s.Client
+	if client == nil {
+		client = oidcClient
+	}
+	resp, err := client.Get(location)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Errorf("%s returned %s", location, resp.Status)
+	}
+	return errors.Trace(json.NewDecoder(resp.Body).Decode(v))
+}
@@ -0,0 +1,355 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package authentication_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"golang.org/x/oauth2/jws"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+const (
+	testIssuer   = "https://idp.example.com"
+	testClientID = "juju-controller"
+)
+
+var testUser = names.NewUserTag("1234@idp.example.com")
+
+type oidcAuthenticatorSuite struct {
+	testing.IsolationSuite
+
+	key   *rsa.PrivateKey
+	clock *testclock.Clock
+	auth  *authentication.OIDCAuthenticator
+}
+
+var _ = gc.Suite(&oidcAuthenticatorSuite{})
+
+func (s *oidcAuthenticatorSuite) SetUpSuite(c *gc.C) {
+	s.IsolationSuite.SetUpSuite(c)
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	s.key = key
+}
+
+func (s *oidcAuthenticatorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.auth = &authentication.OIDCAuthenticator{
+		IssuerURL:   testIssuer,
+		ClientID:    testClientID,
+		GroupsClaim: "groups",
+		Clock:       s.clock,
+		Keys: &staticKeys{
+			keys:       map[string]*rsa.PublicKey{"k1": &s.key.PublicKey},
+			algorithms: []string{"RS256"},
+		},
+	}
+}
+
+func (s *oidcAuthenticatorSuite) token(c *gc.C, kid string, claims map[string]interface{}) string {
+	claimSet := &jws.ClaimSet{
+		Iss:           testIssuer,
+		Aud:           testClientID,
+		Sub:           "1234",
+		Iat:           s.clock.Now().Unix(),
+		Exp:           s.clock.Now().Add(time.Hour).Unix(),
+		PrivateClaims: claims,
+	}
+	token, err := jws.Encode(&jws.Header{Algorithm: "RS256", Typ: "JWT", KeyID: kid}, claimSet, s.key)
+	c.Assert(err, jc.ErrorIsNil)
+	return token
+}
+
+// signedToken returns a token for the test user signed with the
+// test key, using the given hash, and naming the given algorithm.
+func (s *oidcAuthenticatorSuite) signedToken(c *gc.C, algorithm string, hash crypto.Hash) string {
+	encode := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		c.Assert(err, jc.ErrorIsNil)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signed := encode(map[string]string{"alg": algorithm, "typ": "JWT", "kid": "k1"}) + "." + encode(map[string]interface{}{
+		"iss": testIssuer,
+		"aud": testClientID,
+		"sub": "1234",
+		"exp": s.clock.Now().Add(time.Hour).Unix(),
+	})
+	h := hash.New()
+	h.Write([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, hash, h.Sum(nil))
+	c.Assert(err, jc.ErrorIsNil)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticate(c *gc.C) {
+	var updated names.UserTag
+	var updatedGroups []string
+	s.auth.UpdateAccess = func(tag names.UserTag, groups []string) error {
+		updated = tag
+		updatedGroups = groups
+		return nil
+	}
+	token := s.token(c, "k1", map[string]interface{}{
+		"preferred_username": "bob",
+		"groups":             []string{"admins", "devs"},
+	})
+	finder := &recordingFinder{}
+	entity, err := s.auth.Authenticate(finder, testUser, params.LoginRequest{Token: token})
+	c.Assert(err, jc.ErrorIsNil)
+	// The user is identified by the subject, not by the name it
+	// has chosen, in a domain belonging to the provider.
+	c.Assert(entity.Tag(), gc.Equals, names.NewUserTag("1234@idp.example.com"))
+	c.Assert(finder.tag, gc.Equals, names.NewUserTag("1234@idp.example.com"))
+	c.Assert(updated, gc.Equals, names.NewUserTag("1234@idp.example.com"))
+	c.Assert(updatedGroups, jc.DeepEquals, []string{"admins", "devs"})
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateIgnoresChosenNames(c *gc.C) {
+	for _, claims := range []map[string]interface{}{
+		{"preferred_username": "admin"},
+		{"email": "admin@external"},
+		nil,
+	} {
+		token := s.token(c, "k1", claims)
+		entity, err := s.auth.Authenticate(&recordingFinder{}, testUser, params.LoginRequest{Token: token})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(entity.Tag(), gc.Equals, names.NewUserTag("1234@idp.example.com"))
+	}
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateUserDomain(c *gc.C) {
+	s.auth.UserDomain = "corp"
+	token := s.token(c, "k1", nil)
+	entity, err := s.auth.Authenticate(&recordingFinder{}, names.NewUserTag("1234@corp"), params.LoginRequest{Token: token})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Tag(), gc.Equals, names.NewUserTag("1234@corp"))
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateInvalidSubject(c *gc.C) {
+	token := s.token(c, "k1", map[string]interface{}{"sub": "auth0|1234"})
+	_, err := s.auth.Authenticate(&recordingFinder{}, testUser, params.LoginRequest{Token: token})
+	c.Assert(err, gc.ErrorMatches, `subject "auth0\|1234" is not a valid user name`)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateRequiresUserTag(c *gc.C) {
+	token := s.token(c, "k1", nil)
+	for _, tag := range []names.Tag{nil, names.NewMachineTag("0"), names.NewUnitTag("wordpress/0")} {
+		finder := &recordingFinder{}
+		_, err := s.auth.Authenticate(finder, tag, params.LoginRequest{Token: token})
+		c.Check(err, gc.ErrorMatches, "token logins must be for a user: invalid request")
+		c.Check(finder.tag, gc.IsNil)
+	}
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateOtherUser(c *gc.C) {
+	token := s.token(c, "k1", nil)
+	for _, tag := range []names.UserTag{
+		names.NewUserTag("admin"),
+		names.NewUserTag("1234"),
+		names.NewUserTag("1234@external"),
+		names.NewUserTag("5678@idp.example.com"),
+	} {
+		finder := &recordingFinder{}
+		_, err := s.auth.Authenticate(finder, tag, params.LoginRequest{Token: token})
+		c.Check(errors.Cause(err), gc.Equals, common.ErrBadCreds)
+		c.Check(finder.tag, gc.IsNil)
+	}
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateSigningAlgorithms(c *gc.C) {
+	s.auth.Keys.(*staticKeys).algorithms = []string{"RS256", "RS512"}
+	token := s.signedToken(c, "RS512", crypto.SHA512)
+	_, err := s.auth.Authenticate(&recordingFinder{}, testUser, params.LoginRequest{Token: token})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// RS384 can be verified, but the provider does not use it.
+	token = s.signedToken(c, "RS384", crypto.SHA384)
+	_, err = s.auth.Authenticate(&recordingFinder{}, testUser, params.LoginRequest{Token: token})
+	c.Assert(err, gc.ErrorMatches, `ID token signing algorithm "RS384" \(provider signs with RS256, RS512\) not supported: invalid entity name or password`)
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrBadCreds)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateUnsupportedSigningAlgorithm(c *gc.C) {
+	s.auth.Keys.(*staticKeys).algorithms = []string{"RS256", "ES256"}
+	token := s.signedToken(c, "ES256", crypto.SHA256)
+	_, err := s.auth.Authenticate(&recordingFinder{}, testUser, params.LoginRequest{Token: token})
+	c.Assert(err, gc.ErrorMatches, `ID token signing algorithm "ES256" not supported: invalid entity name or password`)
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrBadCreds)
+}
+
+func (s *oidcAuthenticatorSuite) TestOIDCUserDomain(c *gc.C) {
+	domain, err := authentication.OIDCUserDomain("https://login.example.com:8443/realms/juju")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(domain, gc.Equals, "login.example.com")
+
+	_, err = authentication.OIDCUserDomain("https://local/")
+	c.Assert(err, gc.ErrorMatches, `cannot derive a user domain from issuer URL "https://local/"`)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateAudienceList(c *gc.C) {
+	token := s.token(c, "k1", map[string]interface{}{
+		"aud": []string{"other", testClientID},
+	})
+	_, err := s.auth.Authenticate(&recordingFinder{}, testUser, params.LoginRequest{Token: token})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateInvalidTokens(c *gc.C) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+	forged, err := jws.Encode(
+		&jws.Header{Algorithm: "RS256", Typ: "JWT", KeyID: "k1"},
+		&jws.ClaimSet{Iss: testIssuer, Aud: testClientID, Sub: "1234", Exp: s.clock.Now().Add(time.Hour).Unix()},
+		otherKey,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		about string
+		token string
+	}{{
+		about: "malformed",
+		token: "not-a-token",
+	}, {
+		about: "unknown key",
+		token: s.token(c, "k2", nil),
+	}, {
+		about: "bad signature",
+		token: forged,
+	}, {
+		about: "wrong issuer",
+		token: s.token(c, "k1", map[string]interface{}{"iss": "https://evil.example.com"}),
+	}, {
+		about: "wrong audience",
+		token: s.token(c, "k1", map[string]interface{}{"aud": "someone-else"}),
+	}, {
+		about: "expired",
+		token: s.token(c, "k1", map[string]interface{}{"exp": s.clock.Now().Add(-time.Minute).Unix()}),
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		_, err := s.auth.Authenticate(&recordingFinder{}, testUser, params.LoginRequest{Token: test.token})
+		c.Check(errors.Cause(err), gc.Equals, common.ErrBadCreds)
+	}
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateUserNotFound(c *gc.C) {
+	token := s.token(c, "k1", nil)
+	finder := &recordingFinder{err: errors.NotFoundf("user")}
+	_, err := s.auth.Authenticate(finder, testUser, params.LoginRequest{Token: token})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrBadCreds)
+}
+
+func (s *oidcAuthenticatorSuite) TestAuthenticateUpdateAccessError(c *gc.C) {
+	s.auth.UpdateAccess = func(names.UserTag, []string) error {
+		return errors.New("boom")
+	}
+	token := s.token(c, "k1", nil)
+	_, err := s.auth.Authenticate(&recordingFinder{}, testUser, params.LoginRequest{Token: token})
+	c.Assert(err, gc.ErrorMatches, `updating access for "1234@idp.example.com": boom`)
+}
+
+func (s *oidcAuthenticatorSuite) TestKeySet(c *gc.C) {
+	var keyRequests int
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                server.URL,
+			"jwks_uri":                              server.URL + "/keys",
+			"id_token_signing_alg_values_supported": []string{"RS256", "RS512"},
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		keyRequests++
+		fmt.Fprintf(w, `{"keys": [{"kty": "RSA", "kid": "k1", "n": %q, "e": %q}]}`,
+			base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+			base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		)
+	})
+
+	keySet := &authentication.OIDCKeySet{IssuerURL: server.URL, Clock: s.clock}
+	key, err := keySet.Key("k1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(key, jc.DeepEquals, &s.key.PublicKey)
+	_, err = keySet.Key("k1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(keyRequests, gc.Equals, 1)
+	algorithms, err := keySet.SigningAlgorithms()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(algorithms, jc.DeepEquals, []string{"RS256", "RS512"})
+	c.Assert(keyRequests, gc.Equals, 1)
+
+	// Unknown keys only cause the keys to be fetched again
+	// once the refetch interval has passed.
+	for i := 0; i < 3; i++ {
+		_, err = keySet.Key("k2")
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+	c.Assert(keyRequests, gc.Equals, 1)
+
+	s.clock.Advance(authentication.MinRefetchInterval)
+	_, err = keySet.Key("k2")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(keyRequests, gc.Equals, 2)
+}
+
+type staticKeys struct {
+	keys       map[string]*rsa.PublicKey
+	algorithms []string
+}
+
+func (k *staticKeys) Key(kid string) (*rsa.PublicKey, error) {
+	key, ok := k.keys[kid]
+	if !ok {
+		return nil, errors.NotFoundf("key %q", kid)
+	}
+	return key, nil
+}
+
+func (k *staticKeys) SigningAlgorithms() ([]string, error) {
+	return k.algorithms, nil
+}
+
+type recordingFinder struct {
+	tag names.Tag
+	err error
+}
+
+func (f *recordingFinder) FindEntity(tag names.Tag) (state.Entity, error) {
+	f.tag = tag
+	if f.err != nil {
+		return nil, f.err
+	}
+	return tagEntity{tag}, nil
+}
+
+type tagEntity struct {
+	tag names.Tag
+}
+
+func (e tagEntity) Tag() names.Tag {
+	return e.tag
+}
@@ -38,11 +38,19 @@ type UserAuthenticator struct {
 	// to for local users. This always points at the same controller
 	// agent that is servicing the authorisation request.
 	LocalUserIdentityLocation string
+
+	// Revocations, if non-nil, is used to reject macaroons issued
+	// before the user's logins were revoked.
+	Revocations LoginRevocations
 }
 
 const (
 	usernameKey = "username"
 
+	// loginTimeKey is the key of the declared caveat that records
+	// when the user logged in, so that the login can be revoked.
+	loginTimeKey = "login-time"
+
 	// LocalLoginInteractionTimeout is how long a user has to complete
 	// an interactive login before it is expired.
 	LocalLoginInteractionTimeout = 2 * time.Minute
@@ -142,9 +150,11 @@ func CheckLocalLoginRequest(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	now := clock.Now()
 	firstPartyCaveats := []checkers.Caveat{
 		checkers.DeclaredCaveat("username", tag.Id()),
-		checkers.TimeBeforeCaveat(clock.Now().Add(localLoginExpiryTime)),
+		loginTimeCaveat(now),
+		checkers.TimeBeforeCaveat(now.Add(localLoginExpiryTime)),
 	}
 	return firstPartyCaveats, nil
 }
@@ -154,7 +164,10 @@ func (u *UserAuthenticator) authenticateMacaroons(
 ) (state.Entity, error) {
 	// Check for a valid request macaroon.
 	assert := map[string]string{usernameKey: tag.Id()}
-	_, err := u.Service.CheckAny(req.Macaroons, assert, checkers.New(checkers.TimeBefore))
+	declared, err := u.Service.CheckAny(req.Macaroons, assert, checkers.New(checkers.TimeBefore))
+	if err == nil {
+		err = checkLoginNotRevoked(u.Revocations, tag, declared)
+	}
 	if err != nil {
 		cause := err
 		logger.Debugf("local-login macaroon authentication failed: %v", cause)
@@ -213,6 +226,10 @@ type ExternalMacaroonAuthenticator struct {
 	// sending it to a client.
 	Macaroon *macaroon.Macaroon
 
+	// Revocations, if non-nil, is used to reject macaroons issued
+	// before the user's logins were revoked.
+	Revocations LoginRevocations
+
 	// IdentityLocation holds the URL of the trusted third party
 	// that is used to address the is-authenticated-user
 	// third party caveat to.
@@ -227,7 +244,11 @@ func (m *ExternalMacaroonAuthenticator) newDischargeRequiredError(cause error) e
 	}
 	mac := m.Macaroon.Clone()
 	// TODO(fwereade): 2016-03-17 lp:1558657
-	expiryTime := time.Now().Add(externalLoginExpiryTime)
+	now := time.Now()
+	if err := m.Service.AddCaveat(mac, loginTimeCaveat(now)); err != nil {
+		return errors.Annotatef(err, "cannot create macaroon")
+	}
+	expiryTime := now.Add(externalLoginExpiryTime)
 	if err := addMacaroonTimeBeforeCaveat(m.Service, mac, expiryTime); err != nil {
 		return errors.Annotatef(err, "cannot create macaroon")
 	}
@@ -257,8 +278,29 @@ func (m *ExternalMacaroonAuthenticator) Authenticate(entityFinder EntityFinder,
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	username := declared[usernameKey]
-	var tag names.UserTag
+	tag, err := externalUserTag(declared[usernameKey])
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	err = checkLoginNotRevoked(m.Revocations, tag, declared)
+	if _, ok := errors.Cause(err).(*bakery.VerificationError); ok {
+		return nil, m.newDischargeRequiredError(err)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	entity, err := entityFinder.FindEntity(tag)
+	if errors.IsNotFound(err) {
+		return nil, errors.Trace(common.ErrBadCreds)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return entity, nil
+}
+
+// externalUserTag returns the tag for a user name provided by an
+// external identity provider.
+func externalUserTag(username string) (names.UserTag, error) {
 	if names.IsValidUserName(username) {
 		// The name is a local name without an explicit @local suffix.
 		// In this case, for compatibility with 3rd parties that don't
@@ -267,24 +309,48 @@ func (m *ExternalMacaroonAuthenticator) Authenticate(entityFinder EntityFinder,
 		// users.
 		// TODO(rog) remove this logic when deployed dischargers
 		// always add an @ domain.
-		tag = names.NewLocalUserTag(username).WithDomain("external")
-	} else {
-		// We have a name with an explicit domain (or an invalid user name).
-		if !names.IsValidUser(username) {
-			return nil, errors.Errorf("%q is an invalid user name", username)
-		}
-		tag = names.NewUserTag(username)
-		if tag.IsLocal() {
-			return nil, errors.Errorf("external identity provider has provided ostensibly local name %q", username)
-		}
+		return names.NewLocalUserTag(username).WithDomain("external"), nil
 	}
-	entity, err := entityFinder.FindEntity(tag)
-	if errors.IsNotFound(err) {
-		return nil, errors.Trace(common.ErrBadCreds)
-	} else if err != nil {
-		return nil, errors.Trace(err)
+	// We have a name with an explicit domain (or an invalid user name).
+	if !names.IsValidUser(username) {
+		return names.UserTag{}, errors.Errorf("%q is an invalid user name", username)
+	}
+	tag := names.NewUserTag(username)
+	if tag.IsLocal() {
+		return names.UserTag{}, errors.Errorf("external identity provider has provided ostensibly local name %q", username)
+	}
+	return tag, nil
+}
+
+// loginTimeCaveat returns a caveat declaring that the user logged in
+// at the given time.
+func loginTimeCaveat(t time.Time) checkers.Caveat {
+	return checkers.DeclaredCaveat(loginTimeKey, t.UTC().Format(time.RFC3339Nano))
+}
+
+// checkLoginNotRevoked returns an error with a *bakery.VerificationError
+// cause if the login time declared by the user's macaroons is not after
+// the user's logins were last revoked. Macaroons that don't declare a
+// login time were issued before logins could be revoked, and are
+// rejected by any revocation.
+func checkLoginNotRevoked(revocations LoginRevocations, tag names.UserTag, declared map[string]string) error {
+	if revocations == nil {
+		return nil
+	}
+	revokedAt, err := revocations.LoginsRevokedAt(tag)
+	if err != nil {
+		return errors.Annotatef(err, "cannot check login revocation for %q", tag.Id())
+	}
+	if revokedAt.IsZero() {
+		return nil
+	}
+	loginTime, err := time.Parse(time.RFC3339Nano, declared[loginTimeKey])
+	if err == nil && loginTime.After(revokedAt) {
+		return nil
+	}
+	return &bakery.VerificationError{
+		Reason: errors.Errorf("logins for %q have been revoked", tag.Id()),
 	}
-	return entity, nil
 }
 
 func addMacaroonTimeBeforeCaveat(svc BakeryService, m *macaroon.Macaroon, t time.Time) error {
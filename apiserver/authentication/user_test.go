@@ -214,8 +214,42 @@ func (s *userAuthenticatorSuite) TestAuthenticateLocalLoginMacaroon(c *gc.C) {
 	})
 }
 
+func (s *userAuthenticatorSuite) TestRevokedMacaroonUserLogin(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name: "bobbrown",
+	})
+	err := s.State.RevokeLogins(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+	revokedAt, err := s.State.LoginsRevokedAt(user.UserTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	macaroons := []macaroon.Slice{{&macaroon.Macaroon{}}}
+	service := mockBakeryService{}
+	authenticator := &authentication.UserAuthenticator{
+		Service:     &service,
+		Clock:       testclock.NewClock(time.Time{}),
+		Revocations: s.State,
+	}
+	login := func(loginTime time.Time) error {
+		service.declared = map[string]string{
+			"username":   "bobbrown",
+			"login-time": loginTime.Format(time.RFC3339Nano),
+		}
+		_, err := authenticator.Authenticate(s.State, user.Tag(), params.LoginRequest{
+			Macaroons: macaroons,
+		})
+		return err
+	}
+
+	err = login(revokedAt.Add(-time.Second))
+	c.Assert(err, gc.FitsTypeOf, &common.DischargeRequiredError{})
+	err = login(revokedAt.Add(time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 type mockBakeryService struct {
 	testing.Stub
+	declared map[string]string
 }
 
 func (s *mockBakeryService) AddCaveat(m *macaroon.Macaroon, caveat checkers.Caveat) error {
@@ -225,7 +259,10 @@ func (s *mockBakeryService) AddCaveat(m *macaroon.Macaroon, caveat checkers.Cave
 
 func (s *mockBakeryService) CheckAny(ms []macaroon.Slice, assert map[string]string, checker checkers.Checker) (map[string]string, error) {
 	s.MethodCall(s, "CheckAny", ms, assert, checker)
-	return nil, s.NextErr()
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	return s.declared, nil
 }
 
 func (s *mockBakeryService) NewMacaroon(caveats []checkers.Caveat) (*macaroon.Macaroon, error) {
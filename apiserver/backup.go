@@ -168,7 +168,7 @@ func (h *backupHandler) sendFile(file io.Reader, checksum string, resp http.Resp
 	resp.Header().Set("Content-Type", params.ContentTypeRaw)
 	resp.Header().Set("Digest", params.EncodeChecksum(checksum))
 	resp.WriteHeader(http.StatusOK)
-	if _, err := io.Copy(resp, file); err != nil {
+	if _, err := io.Copy(resp, h.ctxt.limitBandwidth(backupTraffic, file)); err != nil {
 		return errors.Annotate(err, "while streaming archive")
 	}
 	return nil
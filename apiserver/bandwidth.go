@@ -0,0 +1,133 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"io"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/ratelimit"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/resource"
+)
+
+// The kinds of traffic from the controller to agents and clients whose
+// bandwidth can be limited by controller config.
+const (
+	agentBinaryTraffic = "agent-binary"
+	resourceTraffic    = "resource"
+	backupTraffic      = "backup"
+)
+
+// bandwidthLimits returns the bandwidth limits, in KiB per second, set
+// in the controller config for each kind of traffic.
+func bandwidthLimits(cfg controller.Config) map[string]int {
+	return map[string]int{
+		agentBinaryTraffic: cfg.AgentBinaryBandwidthLimit(),
+		resourceTraffic:    cfg.ResourceBandwidthLimit(),
+		backupTraffic:      cfg.BackupBandwidthLimit(),
+	}
+}
+
+// bandwidthLimiter limits the rate at which data is sent, sharing the
+// bandwidth available for each key between all of the readers using
+// that key.
+type bandwidthLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bandwidthBucket
+}
+
+type bandwidthBucket struct {
+	*ratelimit.Bucket
+	rate int
+}
+
+func newBandwidthLimiter() *bandwidthLimiter {
+	return &bandwidthLimiter{
+		buckets: make(map[string]*bandwidthBucket),
+	}
+}
+
+// bucket returns the bucket limiting the given key to the given rate,
+// in KiB per second, or nil if the rate is zero.
+func (l *bandwidthLimiter) bucket(key string, rate int) *ratelimit.Bucket {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if rate <= 0 {
+		delete(l.buckets, key)
+		return nil
+	}
+	bucket, ok := l.buckets[key]
+	if !ok || bucket.rate != rate {
+		bytesPerSecond := int64(rate) * 1024
+		bucket = &bandwidthBucket{
+			Bucket: ratelimit.NewBucketWithRate(float64(bytesPerSecond), bytesPerSecond),
+			rate:   rate,
+		}
+		l.buckets[key] = bucket
+	}
+	return bucket.Bucket
+}
+
+// reader returns a reader that reads from r no faster than the given
+// rate, in KiB per second, summed over all readers for the key. A rate
+// of zero leaves r unlimited.
+func (l *bandwidthLimiter) reader(r io.Reader, key string, rate int) io.Reader {
+	bucket := l.bucket(key, rate)
+	if bucket == nil {
+		return r
+	}
+	return ratelimit.Reader(r, bucket)
+}
+
+// wrap returns an opener whose resources are read no faster than the
+// given rate, in KiB per second, summed over all downloads for the
+// key. A rate of zero leaves the opener unlimited.
+func (l *bandwidthLimiter) wrap(opener resource.Opener, key string, rate int) resource.Opener {
+	bucket := l.bucket(key, rate)
+	if bucket == nil {
+		return opener
+	}
+	return &rateLimitedOpener{Opener: opener, bucket: bucket}
+}
+
+type rateLimitedOpener struct {
+	resource.Opener
+	bucket *ratelimit.Bucket
+}
+
+// OpenResource is part of the resource.Opener interface.
+func (o *rateLimitedOpener) OpenResource(name string) (resource.Opened, error) {
+	opened, err := o.Opener.OpenResource(name)
+	if err != nil {
+		return resource.Opened{}, err
+	}
+	opened.ReadCloser = &rateLimitedReadCloser{
+		Reader: ratelimit.Reader(opened.ReadCloser, o.bucket),
+		source: opened.ReadCloser,
+	}
+	return opened, nil
+}
+
+type rateLimitedReadCloser struct {
+	io.Reader
+	source io.ReadCloser
+}
+
+// Close is part of the io.Closer interface.
+func (r *rateLimitedReadCloser) Close() error {
+	return r.source.Close()
+}
+
+// Seek is part of the io.Seeker interface. Seeking is not rate limited,
+// so that resumed downloads don't wait for the bytes they skip.
+func (r *rateLimitedReadCloser) Seek(offset int64, whence int) (int64, error) {
+	seeker, ok := r.source.(io.Seeker)
+	if !ok {
+		return 0, errors.NotSupportedf("seeking resource")
+	}
+	return seeker.Seek(offset, whence)
+}
@@ -0,0 +1,53 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/autoscale"
+)
+
+// AutoscalePolicyToParams converts an autoscaling policy to its API
+// representation.
+func AutoscalePolicyToParams(p autoscale.Policy) params.AutoscalePolicy {
+	return params.AutoscalePolicy{
+		MinUnits:       p.MinUnits,
+		MaxUnits:       p.MaxUnits,
+		Metric:         p.Metric,
+		ScaleUpAbove:   p.ScaleUpAbove,
+		ScaleDownBelow: p.ScaleDownBelow,
+		Cooldown:       p.Cooldown,
+	}
+}
+
+// AutoscalePolicyFromParams converts the API representation of an
+// autoscaling policy to a policy.
+func AutoscalePolicyFromParams(p params.AutoscalePolicy) autoscale.Policy {
+	return autoscale.Policy{
+		MinUnits:       p.MinUnits,
+		MaxUnits:       p.MaxUnits,
+		Metric:         p.Metric,
+		ScaleUpAbove:   p.ScaleUpAbove,
+		ScaleDownBelow: p.ScaleDownBelow,
+		Cooldown:       p.Cooldown,
+	}
+}
+
+// AutoscaleEventsToParams converts autoscale events to their API
+// representation.
+func AutoscaleEventsToParams(events []autoscale.Event) []params.AutoscaleEvent {
+	if len(events) == 0 {
+		return nil
+	}
+	result := make([]params.AutoscaleEvent, len(events))
+	for i, e := range events {
+		result[i] = params.AutoscaleEvent{
+			Time:   e.Time,
+			From:   e.From,
+			To:     e.To,
+			Reason: e.Reason,
+		}
+	}
+	return result
+}
@@ -6,6 +6,7 @@ package common
 import (
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 )
 
@@ -67,3 +68,13 @@ func (c *BlockChecker) checkBlock(blockType state.BlockType) error {
 	}
 	return nil
 }
+
+// CheckModelNotProtected returns an error satisfying
+// params.IsCodeModelProtected if the model with the given
+// configuration is protected.
+func CheckModelNotProtected(cfg *config.Config) error {
+	if cfg.Protected() {
+		return ModelProtectedError(cfg.Name())
+	}
+	return nil
+}
@@ -77,6 +77,36 @@ func IsDischargeRequiredError(err error) bool {
 	return ok
 }
 
+// SettingsTooLargeError is returned when writing a unit's settings in a
+// relation would take them over the controller's size limit.
+type SettingsTooLargeError struct {
+	// Size is the size, in bytes, that the settings would have had.
+	Size int
+
+	// Limit is the maximum size, in bytes, of the settings.
+	Limit int
+
+	// LargestKeys holds the keys with the largest values, largest
+	// first.
+	LargestKeys []string
+}
+
+// Error implements the error interface.
+func (e *SettingsTooLargeError) Error() string {
+	msg := fmt.Sprintf("relation settings too large: %d bytes exceeds the limit of %d bytes", e.Size, e.Limit)
+	if len(e.LargestKeys) > 0 {
+		msg += fmt.Sprintf(" (largest keys: %s)", strings.Join(e.LargestKeys, ", "))
+	}
+	return msg
+}
+
+// IsSettingsTooLargeError reports whether the cause
+// of the error is a *SettingsTooLargeError.
+func IsSettingsTooLargeError(err error) bool {
+	_, ok := errors.Cause(err).(*SettingsTooLargeError)
+	return ok
+}
+
 // IsUpgradeInProgress returns true if this error is caused
 // by an upgrade in progress.
 func IsUpgradeInProgressError(err error) bool {
@@ -113,6 +143,16 @@ func OperationBlockedError(msg string) error {
 	}
 }
 
+// ModelProtectedError returns an error which signifies that the
+// named model is protected from destruction and from the removal of
+// its applications and machines.
+func ModelProtectedError(modelName string) error {
+	return &params.Error{
+		Message: fmt.Sprintf("model %q is protected from destruction and from removal of its applications and machines", modelName),
+		Code:    params.CodeModelProtected,
+	}
+}
+
 var singletonErrorCodes = map[error]string{
 	state.ErrCannotEnterScopeYet: params.CodeCannotEnterScopeYet,
 	state.ErrCannotEnterScope:    params.CodeCannotEnterScope,
@@ -225,6 +265,8 @@ func ServerError(err error) *params.Error {
 		code = params.CodeHasPersistentStorage
 	case state.IsModelNotEmptyError(err):
 		code = params.CodeModelNotEmpty
+	case state.IsQuotaExceededError(err):
+		code = params.CodeQuotaExceeded
 	case isNoAddressSetError(err):
 		code = params.CodeNoAddressSet
 	case errors.IsNotProvisioned(err):
@@ -255,6 +297,14 @@ func ServerError(err error) *params.Error {
 			// One macaroon fits all.
 			MacaroonPath: "/",
 		}.AsMap()
+	case IsSettingsTooLargeError(err):
+		sizeErr := errors.Cause(err).(*SettingsTooLargeError)
+		code = params.CodeSettingsTooLarge
+		info = params.SettingsTooLargeErrorInfo{
+			Size:        sizeErr.Size,
+			Limit:       sizeErr.Limit,
+			LargestKeys: sizeErr.LargestKeys,
+		}.AsMap()
 	default:
 		code = params.ErrCode(err)
 	}
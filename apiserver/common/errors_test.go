@@ -75,6 +75,13 @@ var errorTransformTests = []struct {
 	code:       params.CodeUnitHasSubordinates,
 	status:     http.StatusInternalServerError,
 	helperFunc: params.IsCodeUnitHasSubordinates,
+}, {
+	err: &state.QuotaExceededError{
+		Entity: `model "foo"`, Resource: "units", Limit: 1, Used: 1, Requested: 1,
+	},
+	code:       params.CodeQuotaExceeded,
+	status:     http.StatusInternalServerError,
+	helperFunc: params.IsCodeQuotaExceeded,
 }, {
 	err:        common.ErrBadId,
 	code:       params.CodeNotFound,
@@ -184,6 +191,25 @@ var errorTransformTests = []struct {
 		}
 		return true
 	},
+}, {
+	err: &common.SettingsTooLargeError{
+		Size: 2048, Limit: 1024, LargestKeys: []string{"blob"},
+	},
+	status: http.StatusInternalServerError,
+	code:   params.CodeSettingsTooLarge,
+	helperFunc: func(err error) bool {
+		err1, ok := err.(*params.Error)
+		if !ok || !params.IsCodeSettingsTooLarge(err1) {
+			return false
+		}
+		var info params.SettingsTooLargeErrorInfo
+		if err := err1.UnmarshalInfo(&info); err != nil {
+			return false
+		}
+		return reflect.DeepEqual(info, params.SettingsTooLargeErrorInfo{
+			Size: 2048, Limit: 1024, LargestKeys: []string{"blob"},
+		})
+	},
 }, {
 	err:    unhashableError{"foo"},
 	status: http.StatusInternalServerError,
@@ -250,6 +276,8 @@ func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 			params.CodeMachineHasAttachedStorage,
 			params.CodeDischargeRequired,
 			params.CodeModelNotFound,
+			params.CodeQuotaExceeded,
+			params.CodeSettingsTooLarge,
 			params.CodeRetry:
 			continue
 		case params.CodeOperationBlocked:
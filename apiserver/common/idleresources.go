@@ -0,0 +1,28 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"time"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// ReportedIdleResources returns those of the given idle resources that
+// had been idle for at least the model's idle-resource-days at the given
+// time. No resources are reported if idle-resource-days is zero.
+func ReportedIdleResources(resources []state.IdleResource, cfg *config.Config, now time.Time) []state.IdleResource {
+	days := cfg.IdleResourceDays()
+	if days == 0 {
+		return nil
+	}
+	var reported []state.IdleResource
+	for _, r := range resources {
+		if r.IdleFor(time.Duration(days)*24*time.Hour, now) {
+			reported = append(reported, r)
+		}
+	}
+	return reported
+}
@@ -4,6 +4,8 @@
 package common
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/apiserver/params"
@@ -64,7 +66,7 @@ type Machine interface {
 	ContainerType() instance.ContainerType
 	HardwareCharacteristics() (*instance.HardwareCharacteristics, error)
 	Life() state.Life
-	ForceDestroy() error
+	ForceDestroy(time.Duration) error
 	Destroy() error
 	AgentPresence() (bool, error)
 	IsManager() bool
@@ -83,7 +85,7 @@ func destroyMachines(st stateInterface, force bool, ids ...string) error {
 			err = errors.Errorf("machine %s does not exist", id)
 		case err != nil:
 		case force:
-			err = machine.ForceDestroy()
+			err = machine.ForceDestroy(MaxWait(nil))
 		case machine.Life() != state.Alive:
 			continue
 		default:
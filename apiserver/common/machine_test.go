@@ -4,6 +4,8 @@
 package common_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/naturalsort"
 	jc "github.com/juju/testing/checkers"
@@ -298,7 +300,7 @@ func (m *mockMachine) AgentPresence() (bool, error) {
 	return !m.agentDead, m.presenceErr
 }
 
-func (m *mockMachine) ForceDestroy() error {
+func (m *mockMachine) ForceDestroy(time.Duration) error {
 	m.forceDestroyCalled = true
 	if m.forceDestroyErr != nil {
 		return m.forceDestroyErr
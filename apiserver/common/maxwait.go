@@ -0,0 +1,20 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import "time"
+
+// DefaultMaxWait is how long each step of a forced removal waits for
+// things to happen gracefully before forcing the next step, if the
+// client does not say.
+const DefaultMaxWait = time.Minute
+
+// MaxWait returns the max-wait requested by a client, or DefaultMaxWait
+// if none was given.
+func MaxWait(in *time.Duration) time.Duration {
+	if in != nil {
+		return *in
+	}
+	return DefaultMaxWait
+}
@@ -4,6 +4,8 @@
 package common
 
 import (
+	"time"
+
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 
@@ -83,13 +85,20 @@ func DestroyController(
 }
 
 // DestroyModel sets the model to Dying, such that the model's resources will
-// be destroyed and the model removed from the controller.
+// be destroyed and the model removed from the controller. If force is true,
+// each step of the destruction waits at most maxWait before being forced,
+// and the provider resources of the model will be destroyed even if the
+// model gets stuck dying.
 func DestroyModel(
 	st ModelManagerBackend,
 	destroyStorage *bool,
+	force *bool,
+	maxWait *time.Duration,
 ) error {
 	return destroyModel(st, state.DestroyModelParams{
 		DestroyStorage: destroyStorage,
+		Force:          force,
+		MaxWait:        MaxWait(maxWait),
 	})
 }
 
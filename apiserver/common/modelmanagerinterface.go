@@ -58,6 +58,8 @@ type ModelManagerBackend interface {
 	AllApplications() (applications []Application, err error)
 	AllFilesystems() ([]state.Filesystem, error)
 	AllVolumes() ([]state.Volume, error)
+	AllStorageInstances() ([]state.StorageInstance, error)
+	OfferConnectionCounts() (map[string]int, error)
 	ControllerUUID() string
 	ControllerTag() names.ControllerTag
 	Export() (description.Model, error)
@@ -217,6 +219,7 @@ func (st modelManagerStateShim) AllMachines() ([]Machine, error) {
 
 // Application defines methods provided by a state.Application instance.
 type Application interface {
+	Name() string
 	UnitCount() int
 }
 
@@ -252,6 +255,32 @@ func (st modelManagerStateShim) AllVolumes() ([]state.Volume, error) {
 	return sb.AllVolumes()
 }
 
+func (st modelManagerStateShim) AllStorageInstances() ([]state.StorageInstance, error) {
+	sb, err := state.NewStorageBackend(st.State)
+	if err != nil {
+		return nil, err
+	}
+	return sb.AllStorageInstances()
+}
+
+// OfferConnectionCounts returns the number of consumer connections to
+// each offer in the model, keyed by offer name.
+func (st modelManagerStateShim) OfferConnectionCounts() (map[string]int, error) {
+	offers, err := state.NewApplicationOffers(st.State).AllApplicationOffers()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	counts := make(map[string]int)
+	for _, offer := range offers {
+		conns, err := st.State.OfferConnections(offer.OfferUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		counts[offer.OfferName] = len(conns)
+	}
+	return counts, nil
+}
+
 // ModelConfig returns the underlying model's config. Exposed here to satisfy the
 // ModelBackend interface.
 func (st modelManagerStateShim) ModelConfig() (*config.Config, error) {
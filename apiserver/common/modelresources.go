@@ -0,0 +1,183 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/multiwatcher"
+)
+
+// KnownModelResources returns the ids of the provider resources, and the
+// tags of the entities, that state knows about for the model. Machines
+// are included whatever their life, as their instances and volumes are
+// removed by the provisioners before the machines are removed.
+func KnownModelResources(st *state.State) (environs.KnownModelResources, error) {
+	var known environs.KnownModelResources
+	machines, err := st.AllMachines()
+	if err != nil {
+		return known, errors.Trace(err)
+	}
+	for _, m := range machines {
+		known.Machines = append(known.Machines, m.Tag().String())
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return known, errors.Trace(err)
+		}
+		known.InstanceIds = append(known.InstanceIds, string(instId))
+	}
+
+	apps, err := st.AllApplications()
+	if err != nil {
+		return known, errors.Trace(err)
+	}
+	for _, app := range apps {
+		known.Applications = append(known.Applications, app.Tag().String())
+	}
+
+	sb, err := state.NewStorageBackend(st)
+	if err != nil {
+		return known, errors.Trace(err)
+	}
+	volumes, err := sb.AllVolumes()
+	if err != nil {
+		return known, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return known, errors.Trace(err)
+		}
+		known.VolumeIds = append(known.VolumeIds, info.VolumeId)
+	}
+	return known, nil
+}
+
+// MachineInstanceTags returns the tags Juju sets on the instance of the
+// machine.
+func MachineInstanceTags(m *state.Machine, cfg *config.Config, controllerUUID string) (map[string]string, error) {
+	// Names of all units deployed to the machine.
+	units, err := m.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitNames := make([]string, 0, len(units))
+	for _, unit := range units {
+		if !unit.IsPrincipal() {
+			continue
+		}
+		unitNames = append(unitNames, unit.Name())
+	}
+	sort.Strings(unitNames)
+
+	var jobs []multiwatcher.MachineJob
+	for _, job := range m.Jobs() {
+		jobs = append(jobs, job.ToParams())
+	}
+	machineTags := instancecfg.InstanceTags(cfg.UUID(), controllerUUID, cfg, jobs)
+	if len(unitNames) > 0 {
+		machineTags[tags.JujuUnitsDeployed] = strings.Join(unitNames, " ")
+	}
+	machineId := fmt.Sprintf("%s-%s", cfg.Name(), m.Tag().String())
+	machineTags[tags.JujuMachine] = machineId
+	return machineTags, nil
+}
+
+// ExpectedResourceTags returns the provisioned instances and volumes of
+// the model, with the tags Juju expects each of them to have. The
+// instances of containers, and of dead machines, are omitted.
+func ExpectedResourceTags(st *state.State) ([]environs.TaggedResource, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := m.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	controllerUUID := st.ControllerUUID()
+
+	var result []environs.TaggedResource
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, m := range machines {
+		if m.Life() == state.Dead || m.IsContainer() {
+			continue
+		}
+		instId, err := m.InstanceId()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineTags, err := MachineInstanceTags(m, cfg, controllerUUID)
+		if err != nil {
+			return nil, errors.Annotatef(err, "computing tags of machine %q", m.Id())
+		}
+		result = append(result, environs.TaggedResource{
+			ModelResource: environs.ModelResource{
+				Kind:  environs.InstanceResource,
+				Id:    string(instId),
+				Owner: m.Tag().String(),
+			},
+			Tags: machineTags,
+		})
+	}
+
+	sb, err := state.NewStorageBackend(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumes, err := sb.AllVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		if v.Life() == state.Dead {
+			continue
+		}
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		storageInstance, err := storagecommon.MaybeAssignedStorageInstance(
+			v.StorageInstance,
+			sb.StorageInstance,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		volumeTags, err := storagecommon.StorageTags(storageInstance, cfg.UUID(), controllerUUID, cfg)
+		if err != nil {
+			return nil, errors.Annotatef(err, "computing tags of volume %q", v.Tag().Id())
+		}
+		result = append(result, environs.TaggedResource{
+			ModelResource: environs.ModelResource{
+				Kind:  environs.VolumeResource,
+				Id:    info.VolumeId,
+				Owner: v.Tag().String(),
+			},
+			Tags: volumeTags,
+		})
+	}
+	return result, nil
+}
@@ -56,7 +56,7 @@ func (s *modelStatusSuite) SetUpTest(c *gc.C) {
 		AdminTag: s.Owner,
 	}
 
-	controller, err := controller.NewControllerAPIv7(
+	controller, err := controller.NewControllerAPIv14(
 		facadetest.Context{
 			State_:     s.State,
 			Resources_: s.resources,
@@ -322,6 +322,10 @@ func (statePolicy) StorageProviderRegistry() (storage.ProviderRegistry, error) {
 	}, nil
 }
 
+func (statePolicy) CloudDestroyer() (environs.CloudDestroyer, error) {
+	return nil, errors.NotImplementedf("CloudDestroyer")
+}
+
 func (statePolicy) ProviderConfigSchemaSource(cloudName string) (config.ConfigSchemaSource, error) {
 	return nil, errors.NotImplementedf("ConfigSchemaSource")
 }
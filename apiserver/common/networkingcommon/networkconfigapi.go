@@ -74,6 +74,65 @@ func (api *NetworkConfigAPI) SetObservedNetworkConfig(args params.SetMachineNetw
 	return api.setOneMachineNetworkConfig(m, mergedConfig)
 }
 
+// RecordNetworkConfigDrift compares the network config observed on the
+// machine identified by the input args with the link-layer devices and
+// addresses recorded for it, records any differences found against the
+// machine and returns them. The recorded network config is not changed.
+func (api *NetworkConfigAPI) RecordNetworkConfigDrift(args params.SetMachineNetworkConfig) (params.StringsResult, error) {
+	m, err := api.getMachineForSettingNetworkConfig(args.Tag)
+	if err != nil {
+		return params.StringsResult{Error: common.ServerError(err)}, nil
+	}
+	if m.IsContainer() || len(args.Config) == 0 {
+		return params.StringsResult{}, nil
+	}
+	recordedConfig, err := machineNetworkConfig(m)
+	if err != nil {
+		return params.StringsResult{Error: common.ServerError(err)}, nil
+	}
+	drift := NetworkConfigDrift(recordedConfig, args.Config)
+	if len(drift) > 0 {
+		logger.Warningf("network config of machine %q has drifted: %v", m.Id(), drift)
+	}
+	err = m.SetNetworkConfigDrift(drift)
+	if errors.IsNotProvisioned(errors.Cause(err)) {
+		logger.Infof("not recording machine %q network config drift: %v", m.Id(), err)
+		return params.StringsResult{}, nil
+	} else if err != nil {
+		return params.StringsResult{Error: common.ServerError(err)}, nil
+	}
+	return params.StringsResult{Result: drift}, nil
+}
+
+// machineNetworkConfig returns the link-layer devices and addresses
+// recorded for the machine as network config.
+func machineNetworkConfig(m *state.Machine) ([]params.NetworkConfig, error) {
+	devices, err := m.AllLinkLayerDevices()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	addresses, err := m.AllAddresses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var config []params.NetworkConfig
+	for _, dev := range devices {
+		config = append(config, params.NetworkConfig{
+			InterfaceName: dev.Name(),
+			InterfaceType: string(dev.Type()),
+			MACAddress:    dev.MACAddress(),
+		})
+	}
+	for _, addr := range addresses {
+		config = append(config, params.NetworkConfig{
+			InterfaceName: addr.DeviceName(),
+			Address:       addr.Value(),
+			CIDR:          addr.SubnetCIDR(),
+		})
+	}
+	return config, nil
+}
+
 // fixUpFanSubnets takes network config and updates FAN subnets with proper CIDR, providerId and providerSubnetId.
 // The method how fan overlay is cut into segments is described in network/fan.go.
 func (api *NetworkConfigAPI) fixUpFanSubnets(networkConfig []params.NetworkConfig) ([]params.NetworkConfig, error) {
@@ -86,6 +86,69 @@ func (s *networkConfigSuite) TestSetObservedNetworkConfig(c *gc.C) {
 	}
 }
 
+func (s *networkConfigSuite) TestRecordNetworkConfigDrift(c *gc.C) {
+	err := s.machine.SetInstanceInfo("i-foo", "", "FAKE_NONCE", nil, nil, nil, nil, nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	recordedConfig := []params.NetworkConfig{{
+		InterfaceName: "lo",
+		InterfaceType: "loopback",
+		CIDR:          "127.0.0.0/8",
+		Address:       "127.0.0.1",
+	}, {
+		InterfaceName: "eth0",
+		InterfaceType: "ethernet",
+		MACAddress:    "aa:bb:cc:dd:ee:f0",
+		CIDR:          "0.10.0.0/24",
+		Address:       "0.10.0.2",
+	}, {
+		InterfaceName: "eth1",
+		InterfaceType: "ethernet",
+		MACAddress:    "aa:bb:cc:dd:ee:f1",
+		CIDR:          "0.20.0.0/24",
+		Address:       "0.20.0.2",
+	}}
+	err = s.networkconfig.SetObservedNetworkConfig(params.SetMachineNetworkConfig{
+		Tag:    s.machine.Tag().String(),
+		Config: recordedConfig,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.SetMachineNetworkConfig{
+		Tag:    s.machine.Tag().String(),
+		Config: recordedConfig,
+	}
+	result, err := s.networkconfig.RecordNetworkConfigDrift(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResult{})
+
+	args.Config = []params.NetworkConfig{recordedConfig[1], {
+		InterfaceName: "eth0",
+		InterfaceType: "ethernet",
+		MACAddress:    "aa:bb:cc:dd:ee:f0",
+		CIDR:          "0.10.0.0/24",
+		Address:       "0.10.0.3",
+	}}
+	result, err = s.networkconfig.RecordNetworkConfigDrift(args)
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []string{
+		`address "0.10.0.3" observed on device "eth0" but not recorded`,
+		`device "eth1" not observed`,
+	}
+	c.Assert(result, jc.DeepEquals, params.StringsResult{Result: expected})
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	drift, err := s.machine.NetworkConfigDrift()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(drift, jc.DeepEquals, expected)
+
+	// The recorded network config is left untouched.
+	devices, err := s.machine.AllLinkLayerDevices()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(devices, gc.HasLen, 3)
+}
+
 func (s *networkConfigSuite) TestSetObservedNetworkConfigPermissions(c *gc.C) {
 	args := params.SetMachineNetworkConfig{
 		Tag:    "machine-1",
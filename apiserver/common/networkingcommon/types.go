@@ -4,7 +4,9 @@
 package networkingcommon
 
 import (
+	"fmt"
 	"net"
+	"sort"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -279,6 +281,71 @@ func NetworkConfigsToStateArgs(networkConfig []params.NetworkConfig) (
 	return devicesArgs, devicesAddrs
 }
 
+// NetworkConfigDrift compares the network config recorded for a machine
+// with the network config observed on it, and returns a sorted description
+// of each link-layer device or address which differs. Loopback devices
+// are ignored.
+func NetworkConfigDrift(recorded, observed []params.NetworkConfig) []string {
+	recordedMACs, recordedAddresses := networkConfigDevices(recorded)
+	observedMACs, observedAddresses := networkConfigDevices(observed)
+
+	var drift []string
+	for name, mac := range recordedMACs {
+		observedMAC, ok := observedMACs[name]
+		if !ok {
+			drift = append(drift, fmt.Sprintf("device %q not observed", name))
+			continue
+		}
+		if mac != "" && observedMAC != "" && mac != observedMAC {
+			drift = append(drift, fmt.Sprintf(
+				"device %q MAC address changed from %q to %q", name, mac, observedMAC))
+		}
+		for _, address := range recordedAddresses[name].Difference(observedAddresses[name]).Values() {
+			drift = append(drift, fmt.Sprintf("address %q of device %q not observed", address, name))
+		}
+		for _, address := range observedAddresses[name].Difference(recordedAddresses[name]).Values() {
+			drift = append(drift, fmt.Sprintf("address %q observed on device %q but not recorded", address, name))
+		}
+	}
+	for name := range observedMACs {
+		if _, ok := recordedMACs[name]; !ok {
+			drift = append(drift, fmt.Sprintf("device %q observed but not recorded", name))
+		}
+	}
+	sort.Strings(drift)
+	return drift
+}
+
+// networkConfigDevices returns the MAC address and the set of addresses
+// of each non-loopback device in the network config, by device name.
+func networkConfigDevices(config []params.NetworkConfig) (map[string]string, map[string]set.Strings) {
+	loopbacks := set.NewStrings()
+	for _, c := range config {
+		if c.InterfaceType == string(network.LoopbackInterface) {
+			loopbacks.Add(c.InterfaceName)
+		}
+	}
+	macs := make(map[string]string)
+	addresses := make(map[string]set.Strings)
+	for _, c := range config {
+		if c.InterfaceName == "" || loopbacks.Contains(c.InterfaceName) {
+			continue
+		}
+		if _, ok := addresses[c.InterfaceName]; !ok {
+			addresses[c.InterfaceName] = set.NewStrings()
+		}
+		if c.MACAddress != "" {
+			macs[c.InterfaceName] = c.MACAddress
+		} else if _, ok := macs[c.InterfaceName]; !ok {
+			macs[c.InterfaceName] = ""
+		}
+		if c.Address != "" {
+			addresses[c.InterfaceName].Add(c.Address)
+		}
+	}
+	return macs, addresses
+}
+
 // NetworkingEnvironFromModelConfig constructs and returns
 // environs.NetworkingEnviron using the given configGetter. Returns an error
 // satisfying errors.IsNotSupported() if the model config does not support
@@ -904,3 +904,43 @@ func (s *TypesSuite) TestMergeProviderAndObservedNetworkConfigs(c *gc.C) {
 	result := networkingcommon.MergeProviderAndObservedNetworkConfigs(providerConfig, observedConfig)
 	c.Check(result, jc.DeepEquals, expectedFinalNetworkConfigs)
 }
+
+func (s *TypesSuite) TestNetworkConfigDrift(c *gc.C) {
+	recorded := []params.NetworkConfig{{
+		InterfaceName: "lo",
+		InterfaceType: "loopback",
+	}, {
+		InterfaceName: "lo",
+		Address:       "127.0.0.1",
+	}, {
+		InterfaceName: "eth0",
+		InterfaceType: "ethernet",
+		MACAddress:    "aa:bb:cc:dd:ee:f0",
+	}, {
+		InterfaceName: "eth0",
+		Address:       "10.0.0.2",
+	}, {
+		InterfaceName: "eth1",
+		InterfaceType: "ethernet",
+		MACAddress:    "aa:bb:cc:dd:ee:f1",
+	}}
+	c.Assert(networkingcommon.NetworkConfigDrift(recorded, recorded), gc.HasLen, 0)
+
+	observed := []params.NetworkConfig{{
+		InterfaceName: "eth0",
+		InterfaceType: "ethernet",
+		MACAddress:    "aa:bb:cc:dd:ee:ff",
+		Address:       "10.0.0.3",
+	}, {
+		InterfaceName: "eth2",
+		InterfaceType: "ethernet",
+		MACAddress:    "aa:bb:cc:dd:ee:f2",
+	}}
+	c.Assert(networkingcommon.NetworkConfigDrift(recorded, observed), jc.DeepEquals, []string{
+		`address "10.0.0.2" of device "eth0" not observed`,
+		`address "10.0.0.3" observed on device "eth0" but not recorded`,
+		`device "eth0" MAC address changed from "aa:bb:cc:dd:ee:f0" to "aa:bb:cc:dd:ee:ff"`,
+		`device "eth1" not observed`,
+		`device "eth2" observed but not recorded`,
+	})
+}
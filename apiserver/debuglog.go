@@ -8,7 +8,9 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 	"syscall"
 	"time"
 
@@ -18,6 +20,7 @@ import (
 	"github.com/juju/juju/apiserver/httpcontext"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/apiserver/websocket"
+	corelogger "github.com/juju/juju/core/logger"
 	"github.com/juju/juju/state"
 )
 
@@ -74,12 +77,15 @@ func newDebugLogHandler(
 //   excludeEntity -> []string - lists entity tags to exclude from the response
 //      - as with include, it may finish with a '*'
 //   excludeModule -> []string - lists logging modules to exclude from the response
+//   includeMessage -> string - a regular expression the messages must match
+//   includeLabel -> []string - lists key=value labels the messages must have
 //   limit -> uint - show *at most* this many lines
 //   backlog -> uint
 //      - go back this many lines from the end before starting to filter
 //      - has no meaning if 'replay' is true
 //   level -> string one of [TRACE, DEBUG, INFO, WARNING, ERROR]
 //   replay -> string - one of [true, false], if true, start the file from the start
+//      - at most the last 100000 (possibly filtered) lines are replayed
 //   noTail -> string - one of [true, false], if true, existing logs are sent back,
 //      - but the command does not wait for new ones.
 func (h *debugLogHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
@@ -174,16 +180,18 @@ func (s *debugLogSocketImpl) sendLogRecord(record *params.LogMessage) error {
 
 // debugLogParams contains the parsed debuglog API request parameters.
 type debugLogParams struct {
-	startTime     time.Time
-	maxLines      uint
-	fromTheStart  bool
-	noTail        bool
-	backlog       uint
-	filterLevel   loggo.Level
-	includeEntity []string
-	excludeEntity []string
-	includeModule []string
-	excludeModule []string
+	startTime      time.Time
+	maxLines       uint
+	fromTheStart   bool
+	noTail         bool
+	backlog        uint
+	filterLevel    loggo.Level
+	includeEntity  []string
+	excludeEntity  []string
+	includeModule  []string
+	excludeModule  []string
+	includeMessage string
+	includeLabels  map[string]string
 }
 
 func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
@@ -239,6 +247,27 @@ func readDebugLogParams(queryMap url.Values) (debugLogParams, error) {
 		params.startTime = startTime
 	}
 
+	if value := queryMap.Get("includeMessage"); value != "" {
+		if _, err := regexp.Compile(value); err != nil {
+			return params, errors.Errorf("includeMessage value %q is not a valid regular expression", value)
+		}
+		params.includeMessage = value
+	}
+
+	for _, value := range queryMap["includeLabel"] {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			return params, errors.Errorf("includeLabel value %q is not a key=value pair", value)
+		}
+		if err := corelogger.ValidateLabel(parts[0], parts[1]); err != nil {
+			return params, errors.Trace(err)
+		}
+		if params.includeLabels == nil {
+			params.includeLabels = make(map[string]string)
+		}
+		params.includeLabels[parts[0]] = parts[1]
+	}
+
 	params.includeEntity = queryMap["includeEntity"]
 	params.excludeEntity = queryMap["excludeEntity"]
 	params.includeModule = queryMap["includeModule"]
@@ -61,17 +61,20 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 	params := state.LogTailerParams{
-		MinLevel:      reqParams.filterLevel,
-		NoTail:        reqParams.noTail,
-		StartTime:     reqParams.startTime,
-		InitialLines:  int(reqParams.backlog),
-		IncludeEntity: reqParams.includeEntity,
-		ExcludeEntity: reqParams.excludeEntity,
-		IncludeModule: reqParams.includeModule,
-		ExcludeModule: reqParams.excludeModule,
+		MinLevel:       reqParams.filterLevel,
+		NoTail:         reqParams.noTail,
+		StartTime:      reqParams.startTime,
+		InitialLines:   int(reqParams.backlog),
+		IncludeEntity:  reqParams.includeEntity,
+		ExcludeEntity:  reqParams.excludeEntity,
+		IncludeModule:  reqParams.includeModule,
+		ExcludeModule:  reqParams.excludeModule,
+		IncludeMessage: reqParams.includeMessage,
+		IncludeLabels:  reqParams.includeLabels,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
+		params.MaxReplayLines = maxReplayLines
 	}
 	return params
 }
@@ -84,9 +87,15 @@ func formatLogRecord(r *state.LogRecord) *params.LogMessage {
 		Module:    r.Module,
 		Location:  r.Location,
 		Message:   r.Message,
+		Labels:    r.Labels,
 	}
 }
 
+// maxReplayLines bounds the number of lines replayed from the logs
+// collection, so that replaying the logs of a long-lived model doesn't
+// flood the client.
+const maxReplayLines = 100000
+
 var newLogTailer = _newLogTailer // For replacing in tests
 
 func _newLogTailer(st state.LogTailerState, params state.LogTailerParams) (state.LogTailer, error) {
@@ -32,15 +32,17 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	t1 := time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC)
 	reqParams := debugLogParams{
-		fromTheStart:  false,
-		noTail:        true,
-		backlog:       11,
-		startTime:     t1,
-		filterLevel:   loggo.INFO,
-		includeEntity: []string{"foo"},
-		includeModule: []string{"bar"},
-		excludeEntity: []string{"baz"},
-		excludeModule: []string{"qux"},
+		fromTheStart:   false,
+		noTail:         true,
+		backlog:        11,
+		startTime:      t1,
+		filterLevel:    loggo.INFO,
+		includeEntity:  []string{"foo"},
+		includeModule:  []string{"bar"},
+		excludeEntity:  []string{"baz"},
+		excludeModule:  []string{"qux"},
+		includeMessage: "fail(ed|ure)",
+		includeLabels:  map[string]string{"stage": "dump"},
 	}
 
 	called := false
@@ -57,6 +59,9 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.IncludeMessage, gc.Equals, "fail(ed|ure)")
+		c.Assert(params.IncludeLabels, jc.DeepEquals, map[string]string{"stage": "dump"})
+		c.Assert(params.MaxReplayLines, gc.Equals, 0)
 
 		return newFakeLogTailer(), nil
 	})
@@ -80,6 +85,7 @@ func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 
 		c.Assert(params.StartTime.IsZero(), jc.IsTrue)
 		c.Assert(params.InitialLines, gc.Equals, 0)
+		c.Assert(params.MaxReplayLines, gc.Equals, maxReplayLines)
 
 		return newFakeLogTailer(), nil
 	})
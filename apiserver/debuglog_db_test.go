@@ -35,6 +35,22 @@ func (s *debugLogDBSuite) TestBadParams(c *gc.C) {
 	websockettest.AssertWebsocketClosed(c, conn)
 }
 
+func (s *debugLogDBSuite) TestBadMessageRegexp(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{"includeMessage": {"fail(ed"}})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `includeMessage value "fail\(ed" is not a valid regular expression`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
+func (s *debugLogDBSuite) TestBadLabel(c *gc.C) {
+	conn := s.dialWebsocket(c, url.Values{"includeLabel": {"stage"}})
+	defer conn.Close()
+
+	websockettest.AssertJSONError(c, conn, `includeLabel value "stage" is not a key=value pair`)
+	websockettest.AssertWebsocketClosed(c, conn)
+}
+
 func (s *debugLogDBSuite) TestWithHTTP(c *gc.C) {
 	uri := s.logURL("http", nil).String()
 	apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
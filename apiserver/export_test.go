@@ -60,7 +60,7 @@ func TestingAPIHandler(c *gc.C, pool *state.StatePool, st *state.State) (*apiHan
 		shared:        &sharedServerContext{statePool: pool},
 		tag:           names.NewMachineTag("0"),
 	}
-	h, err := newAPIHandler(srv, st, nil, st.ModelUUID(), 6543, "testing.invalid:1234")
+	h, err := newAPIHandler(srv, st, nil, st.ModelUUID(), 6543, "testing.invalid:1234", "testing.invalid:4321")
 	c.Assert(err, jc.ErrorIsNil)
 	return h, h.getResources()
 }
@@ -129,6 +129,25 @@ func TestingAboutToRestoreRoot() rpc.Root {
 	return restrictRoot(r, aboutToRestoreMethodsOnly)
 }
 
+// TestingReadOnlyRoot returns a limited root which allows only the
+// methods permitted for a read-only login.
+func TestingReadOnlyRoot() rpc.Root {
+	r := TestingAPIRoot(AllFacades())
+	return restrictRoot(r, readOnlyMethodsOnly)
+}
+
+// TestingReplicaRoot returns a root for a user connection to st,
+// which serves read-only methods from the controller's secondaries.
+func TestingReplicaRoot(facades *facade.Registry, pool *state.StatePool, st *state.State, consistent bool) rpc.Root {
+	resources := common.NewResources()
+	r := newAPIRoot(st, &sharedServerContext{statePool: pool}, facades, resources, nil)
+	r.replica = newReadReplica(st, pool, resources, consistent)
+	return r
+}
+
+// ServedFromReplica exposes servedFromReplica for testing.
+var ServedFromReplica = servedFromReplica
+
 // PatchGetMigrationBackend overrides the getMigrationBackend function
 // to support testing.
 func PatchGetMigrationBackend(p Patcher, st migrationBackend) {
@@ -0,0 +1,66 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crashreporter implements the API facade used by the
+// crashreporter worker to upload the crash reports of agents.
+package crashreporter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crashreport"
+)
+
+// Backend defines the State API used by the crashreporter facade.
+type Backend interface {
+	AddCrashReport(crashreport.Report) error
+}
+
+// Facade implements the API required by the crashreporter worker.
+type Facade struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// New returns a new API facade for the crashreporter worker. If auth
+// doesn't identify the client as a machine agent or a unit agent, it
+// will return common.ErrPerm.
+func New(backend Backend, _ facade.Resources, authorizer facade.Authorizer) (*Facade, error) {
+	if !authorizer.AuthMachineAgent() && !authorizer.AuthUnitAgent() {
+		return nil, common.ErrPerm
+	}
+	return &Facade{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// Upload records the given crash reports of the authenticated agent.
+// Reports larger than the maximum size of a crash report are refused.
+func (facade *Facade) Upload(args params.CrashReports) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Reports)),
+	}
+	agent := facade.authorizer.GetAuthTag().String()
+	for i, arg := range args.Reports {
+		if arg.Agent != agent {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		r := crashreport.Report{
+			ID:         arg.ID,
+			Agent:      arg.Agent,
+			Version:    arg.Version,
+			Time:       arg.Time,
+			Panic:      arg.Panic,
+			Goroutines: arg.Goroutines,
+			LogTail:    arg.LogTail,
+		}
+		err := facade.backend.AddCrashReport(r)
+		results.Results[i].Error = common.ServerError(errors.Annotatef(err, "uploading crash report %q", arg.ID))
+	}
+	return results, nil
+}
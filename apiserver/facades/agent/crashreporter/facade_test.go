@@ -0,0 +1,104 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/crashreporter"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/crashreport"
+	"github.com/juju/juju/testing"
+)
+
+type facadeSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	facade     *crashreporter.Facade
+}
+
+var _ = gc.Suite(&facadeSuite{})
+
+var crashTime = time.Date(2019, 5, 3, 10, 11, 12, 0, time.UTC)
+
+func (s *facadeSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = new(mockBackend)
+	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: names.NewUnitTag("mysql/0")}
+	facade, err := crashreporter.New(s.backend, nil, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = facade
+}
+
+func (s *facadeSuite) TestNewNotAgent(c *gc.C) {
+	authorizer := &apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("bob")}
+	_, err := crashreporter.New(s.backend, nil, authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *facadeSuite) TestUpload(c *gc.C) {
+	s.backend.stub.SetErrors(nil, errors.NotValidf("crash report of 2000000 bytes (maximum 1048576)"))
+	result, err := s.facade.Upload(params.CrashReports{Reports: []params.CrashReport{{
+		ID:         "20190503-101112-deadbeef",
+		Agent:      "unit-mysql-0",
+		Version:    "2.6.1",
+		Time:       crashTime,
+		Panic:      "panic: boom",
+		Goroutines: "goroutine 1 [running]:",
+		LogTail:    "log\n",
+	}, {
+		ID:    "20190503-101112-cafef00d",
+		Agent: "unit-mysql-0",
+		Panic: "panic: boom",
+	}, {
+		ID:    "20190503-101112-feedface",
+		Agent: "machine-0",
+		Panic: "panic: boom",
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{
+				Message: `uploading crash report "20190503-101112-cafef00d": crash report of 2000000 bytes (maximum 1048576) not valid`,
+			}},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{{
+		"AddCrashReport", []interface{}{crashreport.Report{
+			ID:         "20190503-101112-deadbeef",
+			Agent:      "unit-mysql-0",
+			Version:    "2.6.1",
+			Time:       crashTime,
+			Panic:      "panic: boom",
+			Goroutines: "goroutine 1 [running]:",
+			LogTail:    "log\n",
+		}},
+	}, {
+		"AddCrashReport", []interface{}{crashreport.Report{
+			ID:    "20190503-101112-cafef00d",
+			Agent: "unit-mysql-0",
+			Panic: "panic: boom",
+		}},
+	}})
+}
+
+type mockBackend struct {
+	stub jujutesting.Stub
+}
+
+func (backend *mockBackend) AddCrashReport(r crashreport.Report) error {
+	backend.stub.AddCall("AddCrashReport", r)
+	return backend.stub.NextErr()
+}
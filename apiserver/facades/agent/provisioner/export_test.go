@@ -16,3 +16,5 @@ func NewContainerProfileContext(result params.ContainerProfileResults, modelName
 func MachineChangeProfileChangeInfo(machine ProfileMachine, st ProfileBackend, unitName string) (params.ProfileChangeResult, error) {
 	return machineChangeProfileChangeInfo(machine, st, unitName)
 }
+
+var InstanceTypeArches = instanceTypeArches
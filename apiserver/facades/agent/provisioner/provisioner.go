@@ -527,6 +527,7 @@ func (p *ProvisionerAPI) ContainerConfig() (params.ContainerConfig, error) {
 	result.LegacyProxy = config.LegacyProxySettings()
 	result.JujuProxy = config.JujuProxySettings()
 	result.AptProxy = config.AptProxySettings()
+	result.SnapProxy = config.SnapProxySettings()
 	result.AptMirror = config.AptMirror()
 	result.CloudInitUserData = config.CloudInitUserData()
 	result.ContainerInheritProperties = config.ContainerInheritProperies()
@@ -1518,6 +1518,7 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 	attrs := map[string]interface{}{
 		"juju-http-proxy":              "http://proxy.example.com:9000",
 		"apt-https-proxy":              "https://proxy.example.com:9000",
+		"snap-https-proxy":             "https://snap-proxy.example.com:3128",
 		"allow-lxd-loop-mounts":        true,
 		"apt-mirror":                   "http://example.mirror.com",
 		"cloudinit-userdata":           validCloudInitUserData,
@@ -1545,6 +1546,7 @@ func (s *withoutControllerSuite) TestContainerConfig(c *gc.C) {
 	c.Check(results.LegacyProxy.HasProxySet(), jc.IsFalse)
 	c.Check(results.JujuProxy, gc.DeepEquals, expectedProxy)
 	c.Check(results.AptProxy, gc.DeepEquals, expectedAPTProxy)
+	c.Check(results.SnapProxy, gc.DeepEquals, proxy.Settings{Https: "https://snap-proxy.example.com:3128"})
 	c.Check(results.AptMirror, gc.DeepEquals, "http://example.mirror.com")
 	c.Check(results.CloudInitUserData, gc.DeepEquals, map[string]interface{}{
 		"packages":        []interface{}{"python-keystoneclient", "python-glanceclient"},
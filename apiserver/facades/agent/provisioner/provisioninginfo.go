@@ -6,7 +6,6 @@ package provisioner
 import (
 	"fmt"
 	"sort"
-	"strings"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -16,12 +15,13 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
-	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/cloudimagemetadata"
 	"github.com/juju/juju/state/multiwatcher"
@@ -72,7 +72,7 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		jobs = append(jobs, job.ToParams())
 	}
 
-	tags, err := p.machineTags(m, jobs)
+	tags, err := p.machineTags(m)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -102,6 +102,15 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		return nil, errors.Annotate(err, "cannot get controller configuration")
 	}
 
+	userData := env.Config().CloudInitUserData()
+	if raw := m.CloudInitUserData(); raw != "" {
+		machineUserData, err := config.ParseCloudInitUserData(raw)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot parse machine cloudinit-userdata")
+		}
+		userData = config.MergeCloudInitUserData(userData, machineUserData)
+	}
+
 	return &params.ProvisioningInfo{
 		Constraints:       cons,
 		Series:            m.Series(),
@@ -114,7 +123,7 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     imageMetadata,
 		ControllerConfig:  controllerCfg,
-		CloudInitUserData: env.Config().CloudInitUserData(),
+		CloudInitUserData: userData,
 		CharmLXDProfiles:  pNames,
 	}, nil
 }
@@ -211,25 +220,7 @@ func (p *ProvisionerAPI) machineVolumeParams(
 }
 
 // machineTags returns machine-specific tags to set on the instance.
-func (p *ProvisionerAPI) machineTags(m *state.Machine, jobs []multiwatcher.MachineJob) (map[string]string, error) {
-	// Names of all units deployed to the machine.
-	//
-	// TODO(axw) 2015-06-02 #1461358
-	// We need a worker that periodically updates
-	// instance tags with current deployment info.
-	units, err := m.Units()
-	if err != nil {
-		return nil, errors.Trace(err)
-	}
-	unitNames := make([]string, 0, len(units))
-	for _, unit := range units {
-		if !unit.IsPrincipal() {
-			continue
-		}
-		unitNames = append(unitNames, unit.Name())
-	}
-	sort.Strings(unitNames)
-
+func (p *ProvisionerAPI) machineTags(m *state.Machine) (map[string]string, error) {
 	cfg, err := p.m.ModelConfig()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -238,13 +229,7 @@ func (p *ProvisionerAPI) machineTags(m *state.Machine, jobs []multiwatcher.Machi
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	machineTags := instancecfg.InstanceTags(cfg.UUID(), controllerCfg.ControllerUUID(), cfg, jobs)
-	if len(unitNames) > 0 {
-		machineTags[tags.JujuUnitsDeployed] = strings.Join(unitNames, " ")
-	}
-	machineId := fmt.Sprintf("%s-%s", cfg.Name(), m.Tag().String())
-	machineTags[tags.JujuMachine] = machineId
-	return machineTags, nil
+	return common.MachineInstanceTags(m, cfg, controllerCfg.ControllerUUID())
 }
 
 // machineSubnetsAndZones returns a map of subnet provider-specific id
@@ -463,6 +448,14 @@ func (p *ProvisionerAPI) constructImageConstraint(m *state.Machine, env environs
 
 	if mcons.Arch != nil {
 		lookup.Arches = []string{*mcons.Arch}
+	} else if mcons.HasInstanceType() {
+		// The instance type decides the architecture, which may not
+		// be that of the images already cached in state.
+		arches, err := instanceTypeArches(p.providerCallContext, env, mcons)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot get architectures of instance type %q", *mcons.InstanceType)
+		}
+		lookup.Arches = arches
 	}
 
 	if hasRegion, ok := env.(simplestreams.HasRegion); ok {
@@ -480,6 +473,23 @@ func (p *ProvisionerAPI) constructImageConstraint(m *state.Machine, env environs
 	return imagemetadata.NewImageConstraint(lookup), nil
 }
 
+// instanceTypeArches returns the architectures of the instance type
+// named by the constraints, or nil if the environ can't list its
+// instance types.
+func instanceTypeArches(ctx context.ProviderCallContext, env environs.InstanceTypesFetcher, cons constraints.Value) ([]string, error) {
+	instanceTypes, err := env.InstanceTypes(ctx, constraints.Value{InstanceType: cons.InstanceType})
+	if errors.IsNotSupported(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	arches := set.NewStrings()
+	for _, itype := range instanceTypes.InstanceTypes {
+		arches = arches.Union(set.NewStrings(itype.Arches...))
+	}
+	return arches.SortedValues(), nil
+}
+
 // findImageMetadata returns all image metadata or an error fetching them.
 // It looks for image metadata in state.
 // If none are found, we fall back on original image search in simple streams.
@@ -6,6 +6,7 @@ package provisioner_test
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -14,6 +15,8 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/provider/dummy"
@@ -518,3 +521,46 @@ func (s *withoutControllerSuite) TestProvisioningInfoPermissions(c *gc.C) {
 		},
 	})
 }
+
+type instanceTypeArchesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&instanceTypeArchesSuite{})
+
+// instanceTypesFetcher is an environs.InstanceTypesFetcher that
+// returns the instance types named by the constraints.
+type instanceTypesFetcher []instances.InstanceType
+
+func (f instanceTypesFetcher) InstanceTypes(ctx context.ProviderCallContext, cons constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+	if f == nil {
+		return instances.InstanceTypesWithCostMetadata{}, errors.NotSupportedf("InstanceTypes")
+	}
+	var result instances.InstanceTypesWithCostMetadata
+	for _, itype := range f {
+		if itype.Name == *cons.InstanceType {
+			result.InstanceTypes = append(result.InstanceTypes, itype)
+		}
+	}
+	return result, nil
+}
+
+func (s *instanceTypeArchesSuite) TestInstanceTypeArches(c *gc.C) {
+	fetcher := instanceTypesFetcher{
+		{Name: "a1.large", Arches: []string{"arm64"}},
+		{Name: "m5.large", Arches: []string{"amd64"}},
+	}
+	arches, err := provisioner.InstanceTypeArches(
+		context.NewCloudCallContext(), fetcher, constraints.MustParse("instance-type=a1.large mem=4G"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(arches, jc.DeepEquals, []string{"arm64"})
+}
+
+func (s *instanceTypeArchesSuite) TestInstanceTypeArchesNotSupported(c *gc.C) {
+	arches, err := provisioner.InstanceTypeArches(
+		context.NewCloudCallContext(), instanceTypesFetcher(nil), constraints.MustParse("instance-type=a1.large"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(arches, gc.HasLen, 0)
+}
@@ -196,6 +196,7 @@ func (api *APIBase) proxyConfig() params.ProxyConfigResult {
 	result.LegacyProxySettings = toParams(legacyProxySettings)
 
 	result.APTProxySettings = toParams(config.AptProxySettings())
+	result.AptMirror = config.AptMirror()
 
 	result.SnapProxySettings = toParams(config.SnapProxySettings())
 	result.SnapStoreProxyId = config.SnapStoreProxy()
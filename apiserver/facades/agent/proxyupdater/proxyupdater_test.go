@@ -248,6 +248,24 @@ func (s *ProxyUpdaterSuite) TestSnapProxyConfig(c *gc.C) {
 	})
 }
 
+func (s *ProxyUpdaterSuite) TestAptMirrorConfig(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"apt-mirror": "http://mirror.example.com/ubuntu",
+	})
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	s.state.Stub.CheckCallNames(c,
+		"ModelConfig",
+		"APIHostPortsForAgents",
+	)
+
+	expectedNoProxy := "0.1.2.3,0.1.2.4,0.1.2.5"
+
+	c.Assert(cfg.Results[0], jc.DeepEquals, params.ProxyConfigResult{
+		LegacyProxySettings: params.ProxyConfig{NoProxy: expectedNoProxy},
+		AptMirror:           "http://mirror.example.com/ubuntu",
+	})
+}
+
 type stubBackend struct {
 	*testing.Stub
 
@@ -50,6 +50,15 @@ func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.
 	return NewStorageProvisionerAPIv4(v3), nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPIv5, error) {
+	v4, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewStorageProvisionerAPIv5(v4), nil
+}
+
 type Backend interface {
 	state.EntityFinder
 	state.ModelAccessor
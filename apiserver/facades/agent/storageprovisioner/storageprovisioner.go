@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/storageprovisioner/internal/filesystemwatcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/watcher"
 	"github.com/juju/juju/storage"
@@ -22,6 +23,11 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.storageprovisioner")
 
+// StorageProvisionerAPIv5 provides the StorageProvisioner API v5 facade.
+type StorageProvisionerAPIv5 struct {
+	*StorageProvisionerAPIv4
+}
+
 // StorageProvisionerAPIv4 provides the StorageProvisioner API v4 facade.
 type StorageProvisionerAPIv4 struct {
 	*StorageProvisionerAPIv3
@@ -47,6 +53,11 @@ type StorageProvisionerAPIv3 struct {
 	getAttachmentAuthFunc    func() (func(names.Tag, names.Tag) bool, error)
 }
 
+// NewStorageProvisionerAPIv5 creates a new server-side StorageProvisioner v5 facade.
+func NewStorageProvisionerAPIv5(v4 *StorageProvisionerAPIv4) *StorageProvisionerAPIv5 {
+	return &StorageProvisionerAPIv5{v4}
+}
+
 // NewStorageProvisionerAPIv4 creates a new server-side StorageProvisioner v4 facade.
 func NewStorageProvisionerAPIv4(v3 *StorageProvisionerAPIv3) *StorageProvisionerAPIv4 {
 	return &StorageProvisionerAPIv4{v3}
@@ -149,6 +160,26 @@ func NewStorageProvisionerAPIv3(
 			return canAccessStorageEntity(tag, false)
 		}, nil
 	}
+	getStatusAuthFunc := func() (common.AuthFunc, error) {
+		return func(tag names.Tag) bool {
+			if canAccessStorageEntity(tag, false) {
+				return true
+			}
+			// Machine agents carry out the attachment plans of
+			// the volumes attached to their machines, and report
+			// their progress in the status of the volumes.
+			volumeTag, ok := tag.(names.VolumeTag)
+			if !ok {
+				return false
+			}
+			machineTag, ok := authorizer.GetAuthTag().(names.MachineTag)
+			if !ok {
+				return false
+			}
+			_, err := sb.VolumeAttachmentPlan(machineTag, volumeTag)
+			return err == nil
+		}, nil
+	}
 	getLifeAuthFunc := func() (common.AuthFunc, error) {
 		return func(tag names.Tag) bool {
 			return canAccessStorageEntity(tag, true)
@@ -209,7 +240,7 @@ func NewStorageProvisionerAPIv3(
 		LifeGetter:       common.NewLifeGetter(st, getLifeAuthFunc),
 		DeadEnsurer:      common.NewDeadEnsurer(st, getStorageEntityAuthFunc),
 		InstanceIdGetter: common.NewInstanceIdGetter(st, getMachineAuthFunc),
-		StatusSetter:     common.NewStatusSetter(st, getStorageEntityAuthFunc),
+		StatusSetter:     common.NewStatusSetter(st, getStatusAuthFunc),
 
 		st:                       st,
 		sb:                       sb,
@@ -238,6 +269,62 @@ func (s *StorageProvisionerAPIv4) WatchApplications() (params.StringsWatchResult
 	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
 }
 
+// WatchRetryRequests returns a NotifyWatcher that notifies when the
+// storage provisioner should check whether users have requested that
+// failed storage operations be retried.
+func (s *StorageProvisionerAPIv5) WatchRetryRequests() (params.NotifyWatchResult, error) {
+	result := params.NotifyWatchResult{}
+	watch := newStorageRetryRequests()
+	// Consume any initial event and forward it to the result.
+	if _, ok := <-watch.Changes(); ok {
+		result.NotifyWatcherId = s.resources.Register(watch)
+	} else {
+		return result, watcher.EnsureErr(watch)
+	}
+	return result, nil
+}
+
+// RetryRequested reports whether users have requested that the failed
+// operations on the specified volumes and filesystems be retried.
+func (s *StorageProvisionerAPIv5) RetryRequested(args params.Entities) (params.BoolResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.BoolResults{}, common.ServerError(common.ErrPerm)
+	}
+	results := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (bool, error) {
+		tag, err := names.ParseTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return false, common.ErrPerm
+		}
+		entity, err := s.st.FindEntity(tag)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		getter, ok := entity.(status.StatusGetter)
+		if !ok {
+			return false, common.NotSupportedError(tag, "getting status")
+		}
+		info, err := getter.Status()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		requested, _ := info.Data[storage.StatusDataRetryRequested].(bool)
+		return requested, nil
+	}
+	for i, arg := range args.Entities {
+		requested, err := one(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = requested
+	}
+	return results, nil
+}
+
 // WatchBlockDevices watches for changes to the specified machines' block devices.
 func (s *StorageProvisionerAPIv3) WatchBlockDevices(args params.Entities) (params.NotifyWatchResults, error) {
 	canAccess, err := s.getBlockDevicesAuthFunc()
@@ -1115,7 +1202,7 @@ func (s *StorageProvisionerAPIv3) FilesystemAttachmentParams(
 			filesystemId = filesystemInfo.FilesystemId
 			pool = filesystemInfo.Pool
 		}
-		providerType, _, err := storagecommon.StoragePoolConfig(pool, s.poolManager, s.registry)
+		providerType, cfg, err := storagecommon.StoragePoolConfig(pool, s.poolManager, s.registry)
 		if err != nil {
 			return params.FilesystemAttachmentParams{}, errors.Trace(err)
 		}
@@ -1145,6 +1232,7 @@ func (s *StorageProvisionerAPIv3) FilesystemAttachmentParams(
 			// parts of the codebase.
 			MountPoint: location,
 			ReadOnly:   readOnly,
+			Attributes: cfg.Attrs(),
 		}, nil
 	}
 	for i, arg := range args.Ids {
@@ -5,6 +5,7 @@ package storageprovisioner_test
 
 import (
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -17,6 +18,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/tags"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -1365,6 +1367,92 @@ func (s *iaasProvisionerSuite) TestVolumeBlockDevicesPlanBlockInfoSet(c *gc.C) {
 	})
 }
 
+func (s *iaasProvisionerSuite) TestSetStatusVolumeAttachmentPlan(c *gc.C) {
+	s.setupVolumes(c)
+	s.authorizer.Controller = false
+
+	// Machine agents may set the status of the model-scoped
+	// volumes they have attachment plans for.
+	err := s.storageBackend.SetVolumeAttachmentInfo(
+		names.NewMachineTag("0"),
+		names.NewVolumeTag("2"),
+		state.VolumeAttachmentInfo{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.storageBackend.CreateVolumeAttachmentPlan(
+		names.NewMachineTag("0"), names.NewVolumeTag("2"), state.VolumeAttachmentPlanInfo{
+			DeviceType:       storage.DeviceTypeISCSI,
+			DeviceAttributes: map[string]string{"iqn": "bogusIQN"},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.SetStatus(params.SetStatus{
+		Entities: []params.EntityStatusArgs{
+			{Tag: "volume-0-0", Status: "attached"},
+			{Tag: "volume-1", Status: "attached"},
+			{Tag: "volume-2", Status: "error", Info: "attaching volume: login failed"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+			{},
+		},
+	})
+
+	volume, err := s.storageBackend.Volume(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	volumeStatus, err := volume.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeStatus.Status, gc.Equals, status.Error)
+	c.Assert(volumeStatus.Message, gc.Equals, "attaching volume: login failed")
+}
+
+func (s *iaasProvisionerSuite) TestWatchRetryRequests(c *gc.C) {
+	api := storageprovisioner.NewStorageProvisionerAPIv5(s.api)
+	result, err := api.WatchRetryRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	// Verify the resource was registered and stop it when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+}
+
+func (s *iaasProvisionerSuite) TestRetryRequested(c *gc.C) {
+	s.setupVolumes(c)
+	volume, err := s.storageBackend.Volume(names.NewVolumeTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	err = volume.SetStatus(status.StatusInfo{
+		Status:  status.Pending,
+		Message: "badness",
+		Data: map[string]interface{}{
+			"attempts":        1,
+			"retry-requested": true,
+		},
+		Since: &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := storageprovisioner.NewStorageProvisionerAPIv5(s.api)
+	results, err := api.RetryRequested(params.Entities{
+		Entities: []params.Entity{{"volume-0-0"}, {"volume-1"}, {"volume-42"}, {"machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Result: false},
+			{Result: true},
+			{Error: common.ServerError(errors.NotFoundf(`volume "42"`))},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
 func (s *iaasProvisionerSuite) TestLife(c *gc.C) {
 	// Only IAAS models support block storage right now.
 	s.setupVolumes(c)
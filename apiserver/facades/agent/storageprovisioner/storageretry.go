@@ -0,0 +1,82 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"time"
+
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// storageRetryRequests is a notify watcher that fires when it is
+// appropriate for storage provisioners to check whether users have
+// requested that failed storage operations be retried.
+type storageRetryRequests struct {
+	tomb tomb.Tomb
+	out  chan struct{}
+}
+
+func newStorageRetryRequests() state.NotifyWatcher {
+	w := &storageRetryRequests{
+		out: make(chan struct{}),
+	}
+	w.tomb.Go(func() error {
+		defer close(w.out)
+		return w.loop()
+	})
+	return w
+}
+
+// Stop stops the watcher, and returns any error encountered while running
+// or shutting down.
+func (w *storageRetryRequests) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+// Kill kills the watcher without waiting for it to shut down.
+func (w *storageRetryRequests) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait waits for the watcher to die and returns any
+// error encountered when it was running.
+func (w *storageRetryRequests) Wait() error {
+	return w.tomb.Wait()
+}
+
+// Err returns any error encountered while running or shutting down, or
+// tomb.ErrStillAlive if the watcher is still running.
+func (w *storageRetryRequests) Err() error {
+	return w.tomb.Err()
+}
+
+// Changes returns the event channel for the storageRetryRequests watcher.
+func (w *storageRetryRequests) Changes() <-chan struct{} {
+	return w.out
+}
+
+// RetryRequestsPollDelay is the poll time currently used to trigger
+// the watcher. It is short, as users requesting retries expect them
+// to happen promptly.
+var RetryRequestsPollDelay = 10 * time.Second
+
+// Like the watcher of machine errors in the provisioner facade, this
+// watcher simply acts as a poller, triggering every
+// RetryRequestsPollDelay.
+func (w *storageRetryRequests) loop() error {
+	out := w.out
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case <-time.After(RetryRequestsPollDelay):
+			out = w.out
+		case out <- struct{}{}:
+			out = nil
+		}
+	}
+}
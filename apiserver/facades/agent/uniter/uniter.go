@@ -6,6 +6,7 @@ package uniter
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/juju/collections/set"
@@ -36,8 +37,8 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v11) of the Uniter API,
-// which adds CloudAPIVersion.
+// UniterAPI implements the latest version (v12) of the Uniter API,
+// which adds DrainScope.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -68,12 +69,22 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
-// UniterAPI implements the latest version (v11) of the Uniter API,
-// which adds WatchUnitLXDProfileUpgradeNotifications.
-type UniterAPIV10 struct {
+// UniterAPIV12 doesn't support opening or closing port ranges for
+// a single endpoint.
+type UniterAPIV12 struct {
 	UniterAPI
 }
 
+// UniterAPIV11 adds CloudAPIVersion.
+type UniterAPIV11 struct {
+	UniterAPIV12
+}
+
+// UniterAPIV10 adds WatchUnitLXDProfileUpgradeNotifications.
+type UniterAPIV10 struct {
+	UniterAPIV11
+}
+
 // UniterAPIV9 adds WatchConfigSettingsHash, WatchTrustConfigSettingsHash,
 // WatchUnitAddressesHash, WatchLXDProfileUpgradeNotifications, and
 // RemoveUpgradeCharmProfileData.
@@ -280,14 +291,36 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV12 creates an instance of the V12 uniter API.
+func NewUniterAPIV12(context facade.Context) (*UniterAPIV12, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV12{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV11 creates an instance of the V11 uniter API.
+func NewUniterAPIV11(context facade.Context) (*UniterAPIV11, error) {
+	uniterAPI, err := NewUniterAPIV12(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV11{
+		UniterAPIV12: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV10 creates an instance of the V10 uniter API.
 func NewUniterAPIV10(context facade.Context) (*UniterAPIV10, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV11(context)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV10{
-		UniterAPI: *uniterAPI,
+		UniterAPIV11: *uniterAPI,
 	}, nil
 }
 
@@ -431,19 +464,31 @@ func (u *UniterAPI) getOneMachinePorts(canAccess common.AuthFunc, machineTag str
 	}
 	var resultPorts []params.MachinePortRange
 	for _, ports := range allPorts {
-		// AllPortRanges gives a map, but apis require a stable order
-		// for results, so sort the port ranges.
-		portRangesToUnits := ports.AllPortRanges()
-		portRanges := make([]corenetwork.PortRange, 0, len(portRangesToUnits))
-		for portRange := range portRangesToUnits {
-			portRanges = append(portRanges, portRange)
-		}
-		corenetwork.SortPortRanges(portRanges)
+		// Apis require a stable order for results, so sort the port
+		// ranges first by protocol and number, then by endpoint.
+		portRanges := ports.PortRanges()
+		sort.Slice(portRanges, func(i, j int) bool {
+			p1, p2 := portRanges[i], portRanges[j]
+			if p1.Protocol != p2.Protocol {
+				return p1.Protocol < p2.Protocol
+			}
+			if p1.FromPort != p2.FromPort {
+				return p1.FromPort < p2.FromPort
+			}
+			if p1.ToPort != p2.ToPort {
+				return p1.ToPort < p2.ToPort
+			}
+			return p1.Endpoint < p2.Endpoint
+		})
 		for _, portRange := range portRanges {
-			unitName := portRangesToUnits[portRange]
 			resultPorts = append(resultPorts, params.MachinePortRange{
-				UnitTag:   names.NewUnitTag(unitName).String(),
-				PortRange: params.FromNetworkPortRange(portRange),
+				UnitTag: names.NewUnitTag(portRange.UnitName).String(),
+				PortRange: params.FromNetworkPortRange(corenetwork.PortRange{
+					FromPort: portRange.FromPort,
+					ToPort:   portRange.ToPort,
+					Protocol: portRange.Protocol,
+				}),
+				Endpoint: portRange.Endpoint,
 			})
 		}
 	}
@@ -952,7 +997,7 @@ func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResul
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.OpenPorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.OpenPortsOnEndpoint(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -960,6 +1005,13 @@ func (u *UniterAPI) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResul
 	return result, nil
 }
 
+// OpenPorts sets the policy of the port range with protocol to be
+// opened, for all given units.
+// V12 OpenPorts opens ranges for all of the units' endpoints.
+func (u *UniterAPIV12) OpenPorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
+	return u.UniterAPI.OpenPorts(withoutEndpoints(args))
+}
+
 // ClosePorts sets the policy of the port range with protocol to be
 // closed, for all given units.
 func (u *UniterAPI) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
@@ -981,7 +1033,7 @@ func (u *UniterAPI) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResu
 			var unit *state.Unit
 			unit, err = u.getUnit(tag)
 			if err == nil {
-				err = unit.ClosePorts(entity.Protocol, entity.FromPort, entity.ToPort)
+				err = unit.ClosePortsOnEndpoint(entity.Endpoint, entity.Protocol, entity.FromPort, entity.ToPort)
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -989,6 +1041,24 @@ func (u *UniterAPI) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResu
 	return result, nil
 }
 
+// ClosePorts sets the policy of the port range with protocol to be
+// closed, for all given units.
+// V12 ClosePorts closes ranges opened for all of the units' endpoints.
+func (u *UniterAPIV12) ClosePorts(args params.EntitiesPortRanges) (params.ErrorResults, error) {
+	return u.UniterAPI.ClosePorts(withoutEndpoints(args))
+}
+
+// withoutEndpoints returns the port ranges as they would have been
+// received by V12 of the facade, which had no endpoints.
+func withoutEndpoints(args params.EntitiesPortRanges) params.EntitiesPortRanges {
+	entities := make([]params.EntityPortRange, len(args.Entities))
+	for i, entity := range args.Entities {
+		entity.Endpoint = ""
+		entities[i] = entity
+	}
+	return params.EntitiesPortRanges{Entities: entities}
+}
+
 // WatchConfigSettings returns a NotifyWatcher for observing changes
 // to each unit's application configuration settings. See also
 // state/watcher.go:Unit.WatchConfigSettings().
@@ -1322,6 +1392,7 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 			if unit, err = u.getUnit(tag); err == nil {
 				result.Results[i].Life = params.Life(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				result.Results[i].Paused, err = unit.IsPaused()
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -1456,6 +1527,36 @@ func (u *UniterAPI) LeaveScope(args params.RelationUnits) (params.ErrorResults,
 	return result, nil
 }
 
+// DrainScope isn't on the v11 API.
+func (u *UniterAPIV11) DrainScope(_, _ struct{}) {}
+
+// DrainScope signals each unit is done with its scope in the relation,
+// for all of the given relation/unit pairs, while allowing its
+// counterparts time to run their relation-departed hooks. See also
+// state.RelationUnit.DrainScope().
+func (u *UniterAPI) DrainScope(args params.RelationUnits) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.RelationUnits)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.RelationUnits {
+		unit, err := names.ParseUnitTag(arg.Unit)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		relUnit, err := u.getRelationUnit(canAccess, arg.Relation, unit)
+		if err == nil {
+			err = relUnit.DrainScope()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // ReadSettings returns the local settings of each given set of
 // relation/unit.
 func (u *UniterAPI) ReadSettings(args params.RelationUnits) (params.SettingsResults, error) {
@@ -1521,7 +1622,9 @@ func (u *UniterAPI) ReadRemoteSettings(args params.RelationUnitPairs) (params.Se
 
 // UpdateSettings persists all changes made to the local settings of
 // all given pairs of relation and unit. Keys with empty values are
-// considered a signal to delete these values.
+// considered a signal to delete these values. Changes that would take
+// the settings over the controller's max-relation-settings-size are
+// rejected with a SettingsTooLarge error.
 func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.RelationUnits)),
@@ -1530,6 +1633,11 @@ func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.Er
 	if err != nil {
 		return params.ErrorResults{}, err
 	}
+	controllerConfig, err := u.st.ControllerConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	limit := controllerConfig.MaxRelationSettingsSize() * 1024
 	for i, arg := range args.RelationUnits {
 		unit, err := names.ParseUnitTag(arg.Unit)
 		if err != nil {
@@ -1548,7 +1656,10 @@ func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.Er
 						settings.Set(k, v)
 					}
 				}
-				_, err = settings.Write()
+				err = checkRelationSettingsSize(settings.Map(), limit)
+				if err == nil {
+					_, err = settings.Write()
+				}
 			}
 		}
 		result.Results[i].Error = common.ServerError(err)
@@ -1556,6 +1667,42 @@ func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.Er
 	return result, nil
 }
 
+// maxLargestKeys is the number of keys named by a SettingsTooLarge error.
+const maxLargestKeys = 3
+
+// checkRelationSettingsSize returns a *common.SettingsTooLargeError if
+// the settings are larger than the limit, in bytes. A zero limit means
+// there is no limit.
+func checkRelationSettingsSize(settings map[string]interface{}, limit int) error {
+	if limit <= 0 {
+		return nil
+	}
+	size := state.RelationSettingsSize(settings)
+	if size <= limit {
+		return nil
+	}
+	keys := make([]string, 0, len(settings))
+	keySizes := make(map[string]int)
+	for k, v := range settings {
+		keys = append(keys, k)
+		keySizes[k] = state.RelationSettingsSize(map[string]interface{}{k: v})
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keySizes[keys[i]] != keySizes[keys[j]] {
+			return keySizes[keys[i]] > keySizes[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	if len(keys) > maxLargestKeys {
+		keys = keys[:maxLargestKeys]
+	}
+	return &common.SettingsTooLargeError{
+		Size:        size,
+		Limit:       limit,
+		LargestKeys: keys,
+	}
+}
+
 // WatchRelationUnits returns a RelationUnitsWatcher for observing
 // changes to every unit in the supplied relation that is visible to
 // the supplied unit. See also state/watcher.go:RelationUnit.Watch().
@@ -1775,10 +1922,11 @@ func (u *UniterAPI) prepareRelationResult(rel *state.Relation, applicationName s
 		otherAppName = otherEp.ApplicationName
 	}
 	return params.RelationResult{
-		Id:        rel.Id(),
-		Key:       rel.String(),
-		Life:      params.Life(rel.Life().String()),
-		Suspended: rel.Suspended(),
+		Id:           rel.Id(),
+		Key:          rel.String(),
+		Life:         params.Life(rel.Life().String()),
+		Suspended:    rel.Suspended(),
+		DrainTimeout: rel.DrainTimeout(),
 		Endpoint: multiwatcher.Endpoint{
 			ApplicationName: ep.ApplicationName,
 			Relation:        multiwatcher.NewCharmRelation(ep.Relation),
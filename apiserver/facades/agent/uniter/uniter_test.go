@@ -5,6 +5,7 @@ package uniter_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -26,6 +27,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/controller"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/model"
 	corenetwork "github.com/juju/juju/core/network"
@@ -1031,6 +1033,34 @@ func (s *uniterSuite) TestClosePorts(c *gc.C) {
 	c.Assert(openedPorts, gc.HasLen, 0)
 }
 
+func (s *uniterSuite) TestOpenPortsV12IgnoresEndpoint(c *gc.C) {
+	apiV12, err := uniter.NewUniterAPIV12(facadetest.Context{
+		State_:             s.State,
+		Resources_:         s.resources,
+		Auth_:              s.authorizer,
+		LeadershipChecker_: s.State.LeadershipChecker(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.EntitiesPortRanges{Entities: []params.EntityPortRange{
+		{Tag: "unit-wordpress-0", Protocol: "tcp", FromPort: 80, ToPort: 80, Endpoint: "url"},
+	}}
+	result, err := apiV12.OpenPorts(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{nil}},
+	})
+
+	machineId, err := s.wordpressUnit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err := machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRanges(), jc.DeepEquals, []state.PortRange{
+		{UnitName: "wordpress/0", Protocol: "tcp", FromPort: 80, ToPort: 80},
+	})
+}
+
 func (s *uniterSuite) TestWatchConfigSettingsHash(c *gc.C) {
 	err := s.wordpressUnit.SetCharmURL(s.wpCharm.URL())
 	c.Assert(err, jc.ErrorIsNil)
@@ -2063,6 +2093,41 @@ func (s *uniterSuite) TestLeaveScope(c *gc.C) {
 	c.Assert(readSettings, gc.DeepEquals, settings)
 }
 
+func (s *uniterSuite) TestDrainScope(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	err := rel.SetDrainTimeout(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	mysqlRelUnit, err := rel.Unit(s.mysqlUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = mysqlRelUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnits{RelationUnits: []params.RelationUnit{
+		{Relation: rel.Tag().String(), Unit: "unit-wordpress-0"},
+		{Relation: rel.Tag().String(), Unit: "unit-mysql-0"},
+		{Relation: "relation-42", Unit: "unit-wordpress-0"},
+	}}
+	result, err := s.uniter.DrainScope(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// The unit stays in scope, draining, until the timeout expires.
+	s.assertInScope(c, relUnit, true)
+	deadline, err := relUnit.DrainDeadline()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deadline.IsZero(), jc.IsFalse)
+}
+
 func (s *uniterSuite) TestRelationsSuspended(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	relUnit, err := rel.Unit(s.wordpressUnit)
@@ -2428,6 +2493,48 @@ func (s *uniterSuite) TestUpdateSettings(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestUpdateSettingsTooLarge(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MaxRelationSettingsSize: 1,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(map[string]interface{}{"some": "settings"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{{
+		Relation: rel.Tag().String(),
+		Unit:     "unit-wordpress-0",
+		Settings: params.Settings{
+			"blob":  strings.Repeat("x", 1024),
+			"other": "stuff",
+		},
+	}}}
+	result, err := s.uniter.UpdateSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	resultErr := result.Results[0].Error
+	c.Assert(resultErr, gc.ErrorMatches, `relation settings too large: 1050 bytes exceeds the limit of 1024 bytes \(largest keys: blob, some, other\)`)
+	c.Assert(resultErr, jc.Satisfies, params.IsCodeSettingsTooLarge)
+	var info params.SettingsTooLargeErrorInfo
+	err = resultErr.UnmarshalInfo(&info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, params.SettingsTooLargeErrorInfo{
+		Size:        1050,
+		Limit:       1024,
+		LargestKeys: []string{"blob", "some", "other"},
+	})
+
+	// Verify the settings were not changed.
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"some": "settings",
+	})
+}
+
 func (s *uniterSuite) TestWatchRelationUnits(c *gc.C) {
 	// Add a relation between wordpress and mysql and enter scope with
 	// mysqlUnit.
@@ -2844,6 +2951,8 @@ func (s *uniterSuite) TestAllMachinePorts(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	err = s.wordpressUnit.OpenPorts("udp", 10, 20)
 	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpressUnit.OpenPortsOnEndpoint("url", "tcp", 100, 200)
+	c.Assert(err, jc.ErrorIsNil)
 	err = mysqlUnit1.OpenPorts("tcp", 201, 250)
 	c.Assert(err, jc.ErrorIsNil)
 	err = mysqlUnit1.OpenPorts("udp", 1, 8)
@@ -2859,6 +2968,7 @@ func (s *uniterSuite) TestAllMachinePorts(c *gc.C) {
 	}}
 	expectPorts := []params.MachinePortRange{
 		{UnitTag: "unit-wordpress-0", PortRange: params.PortRange{100, 200, "tcp"}},
+		{UnitTag: "unit-wordpress-0", PortRange: params.PortRange{100, 200, "tcp"}, Endpoint: "url"},
 		{UnitTag: "unit-mysql-1", PortRange: params.PortRange{201, 250, "tcp"}},
 		{UnitTag: "unit-mysql-1", PortRange: params.PortRange{1, 8, "udp"}},
 		{UnitTag: "unit-wordpress-0", PortRange: params.PortRange{10, 20, "udp"}},
@@ -3170,6 +3280,21 @@ func (s *uniterSuite) TestRefresh(c *gc.C) {
 	c.Assert(results, gc.DeepEquals, expect)
 }
 
+func (s *uniterSuite) TestRefreshPaused(c *gc.C) {
+	err := s.wordpress.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.Entities{
+		Entities: []params.Entity{{s.wordpressUnit.Tag().String()}},
+	}
+	results, err := s.uniter.Refresh(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.UnitRefreshResults{
+		Results: []params.UnitRefreshResult{
+			{Life: params.Alive, Resolved: params.ResolvedNone, Paused: true},
+		},
+	})
+}
+
 func (s *uniterSuite) TestRefreshNoArgs(c *gc.C) {
 	results, err := s.uniter.Refresh(params.Entities{Entities: []params.Entity{}})
 	c.Assert(err, jc.ErrorIsNil)
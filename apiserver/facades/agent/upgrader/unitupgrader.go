@@ -106,6 +106,12 @@ func (u *UnitUpgraderAPI) DesiredVersion(args params.Entities) (params.VersionRe
 	return params.VersionResults{Results: result}, nil
 }
 
+// MaintenanceWindows reports the maintenance windows within which the
+// given units' agents may be upgraded.
+func (u *UnitUpgraderAPI) MaintenanceWindows(args params.Entities) (params.MaintenanceWindowResults, error) {
+	return maintenanceWindows(u.st, u.authorizer.AuthOwner, args), nil
+}
+
 // Tools finds the tools necessary for the given agents.
 func (u *UnitUpgraderAPI) Tools(args params.Entities) (params.ToolsResults, error) {
 	result := params.ToolsResults{
@@ -11,7 +11,9 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/maintenance"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
@@ -27,8 +29,15 @@ var logger = loggo.GetLogger("juju.apiserver.upgrader")
 // to the exact Upgrader API, so the actual calls that are available
 // do not depend on who is currently connected.
 
-// NewUpgraderFacade provides the signature required for facade registration.
+// NewUpgraderFacade provides the signature required for facade registration
+// for version 1.
 func NewUpgraderFacade(st *state.State, resources facade.Resources, auth facade.Authorizer) (Upgrader, error) {
+	return NewUpgraderFacadeV2(st, resources, auth)
+}
+
+// NewUpgraderFacadeV2 provides the signature required for facade
+// registration for version 2.
+func NewUpgraderFacadeV2(st *state.State, resources facade.Resources, auth facade.Authorizer) (UpgraderV2, error) {
 	// The type of upgrader we return depends on who is asking.
 	// Machines get an UpgraderAPI, units get a UnitUpgraderAPI.
 	// This is tested in the api/upgrader package since there
@@ -55,6 +64,71 @@ type Upgrader interface {
 	SetTools(args params.EntitiesVersion) (params.ErrorResults, error)
 }
 
+// UpgraderV2 extends Upgrader with the maintenance windows within
+// which agents may be upgraded.
+type UpgraderV2 interface {
+	Upgrader
+	MaintenanceWindows(args params.Entities) (params.MaintenanceWindowResults, error)
+}
+
+// maintenanceWindow returns the maintenance window within which the
+// agent with the given tag may be upgraded. Unit and application agents
+// use their application's window, if it has one; all other agents use
+// the model's.
+func maintenanceWindow(st *state.State, tag names.Tag) (maintenance.Window, error) {
+	model, err := st.Model()
+	if err != nil {
+		return maintenance.Window{}, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return maintenance.Window{}, errors.Trace(err)
+	}
+	var appName string
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		appName, err = names.UnitApplication(tag.Id())
+		if err != nil {
+			return maintenance.Window{}, errors.Trace(err)
+		}
+	case names.ApplicationTag:
+		appName = tag.Id()
+	default:
+		return cfg.MaintenanceWindow(), nil
+	}
+	app, err := st.Application(appName)
+	if err != nil {
+		return maintenance.Window{}, errors.Trace(err)
+	}
+	appConfig, err := app.ApplicationConfig()
+	if err != nil {
+		return maintenance.Window{}, errors.Trace(err)
+	}
+	return application.MaintenanceWindow(appConfig, cfg), nil
+}
+
+// maintenanceWindows returns the maintenance windows for the given
+// agents, which must be authorized by authOwner.
+func maintenanceWindows(st *state.State, authOwner common.AuthFunc, args params.Entities) params.MaintenanceWindowResults {
+	results := make([]params.MaintenanceWindowResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if authOwner(tag) {
+			var window maintenance.Window
+			if window, err = maintenanceWindow(st, tag); err == nil {
+				results[i].Window = window.String()
+			}
+		}
+		results[i].Error = common.ServerError(err)
+	}
+	return params.MaintenanceWindowResults{Results: results}
+}
+
 // UpgraderAPI provides access to the Upgrader API facade.
 type UpgraderAPI struct {
 	*common.ToolsGetter
@@ -195,3 +269,9 @@ func (u *UpgraderAPI) DesiredVersion(args params.Entities) (params.VersionResult
 	}
 	return params.VersionResults{Results: results}, nil
 }
+
+// MaintenanceWindows reports the maintenance windows within which the
+// given agents may be upgraded.
+func (u *UpgraderAPI) MaintenanceWindows(args params.Entities) (params.MaintenanceWindowResults, error) {
+	return maintenanceWindows(u.st, u.authorizer.AuthOwner, args), nil
+}
@@ -318,6 +318,30 @@ func (s *upgraderSuite) TestDesiredVersionForAgent(c *gc.C) {
 	c.Check(*agentVersion, gc.DeepEquals, jujuversion.Current)
 }
 
+func (s *upgraderSuite) TestMaintenanceWindows(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"maintenance-window": "22:00-02:00"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: s.rawMachine.Tag().String()},
+		{Tag: s.apiMachine.Tag().String()},
+	}}
+	results, err := s.upgrader.MaintenanceWindows(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.MaintenanceWindowResults{
+		Results: []params.MaintenanceWindowResult{
+			{Window: "22:00-02:00"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *upgraderSuite) TestMaintenanceWindowsDefault(c *gc.C) {
+	args := params.Entities{Entities: []params.Entity{{Tag: s.rawMachine.Tag().String()}}}
+	results, err := s.upgrader.MaintenanceWindows(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.MaintenanceWindowResult{{Window: "any"}})
+}
+
 func (s *upgraderSuite) bumpDesiredAgentVersion(c *gc.C) version.Number {
 	// In order to call SetModelAgentVersion we have to first SetTools on
 	// all the existing machines
@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
@@ -32,9 +33,17 @@ type APIv2 struct {
 
 // APIv3 provides the Action API facade for version 3.
 type APIv3 struct {
+	*APIv4
+}
+
+// APIv4 provides the Action API facade for version 4.
+type APIv4 struct {
 	*ActionAPI
 }
 
+// Introspect isn't on the v3 API.
+func (*APIv3) Introspect(_, _ struct{}) {}
+
 // NewActionAPIV2 returns an initialized ActionAPI for version 2.
 func NewActionAPIV2(ctx facade.Context) (*APIv2, error) {
 	api, err := NewActionAPIV3(ctx)
@@ -46,13 +55,22 @@ func NewActionAPIV2(ctx facade.Context) (*APIv2, error) {
 
 // NewActionAPIV3 returns an initialized ActionAPI for version 3.
 func NewActionAPIV3(ctx facade.Context) (*APIv3, error) {
-	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	api, err := NewActionAPIV4(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv3{api}, nil
 }
 
+// NewActionAPIV4 returns an initialized ActionAPI for version 4.
+func NewActionAPIV4(ctx facade.Context) (*APIv4, error) {
+	api, err := newActionAPI(ctx.State(), ctx.Resources(), ctx.Auth())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv4{api}, nil
+}
+
 func newActionAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*ActionAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -247,6 +265,14 @@ func (a *ActionAPI) Enqueue(arg params.Actions) (params.ActionResults, error) {
 			currentResult.Error = common.ServerError(err)
 			continue
 		}
+		// Introspecting agents is as privileged as running commands
+		// with Run.
+		if action.Name == actions.JujuIntrospectActionName {
+			if err := a.checkCanAdmin(); err != nil {
+				currentResult.Error = common.ServerError(err)
+				continue
+			}
+		}
 		enqueued, err := receiver.AddAction(action.Name, action.Parameters)
 		if err != nil {
 			currentResult.Error = common.ServerError(err)
@@ -127,6 +127,58 @@ func (a *ActionAPI) RunOnAllMachines(run params.RunParams) (results params.Actio
 	return queueActions(a, actionParams)
 }
 
+// Introspect fetches a report from the introspection workers of the
+// agents of the given units and machines. The introspection socket of
+// an agent is only reachable on its machine, so an action is enqueued
+// on the machine hosting each agent, which fetches the report for it.
+func (a *ActionAPI) Introspect(args params.IntrospectArgs) (results params.ActionResults, err error) {
+	if err := a.checkCanAdmin(); err != nil {
+		return results, err
+	}
+
+	results.Results = make([]params.ActionResult, len(args.Agents))
+	for i, agent := range args.Agents {
+		machine, err := a.agentMachine(agent)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		enqueued, err := machine.AddAction(actions.JujuIntrospectActionName, map[string]interface{}{
+			"agent": agent,
+			"query": args.Query,
+		})
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = common.MakeActionResult(machine.Tag(), enqueued)
+	}
+	return results, nil
+}
+
+// agentMachine returns the machine hosting the agent with the given tag.
+func (a *ActionAPI) agentMachine(agent string) (*state.Machine, error) {
+	tag, err := names.ParseTag(agent)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		return a.state.Machine(tag.Id())
+	case names.UnitTag:
+		unit, err := a.state.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineId, err := unit.AssignedMachineId()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return a.state.Machine(machineId)
+	}
+	return nil, errors.NotValidf("agent %q", agent)
+}
+
 func (a *ActionAPI) createActionsParams(actionReceiverTags []names.Tag, quotedCommands string, timeout time.Duration) params.Actions {
 
 	apiActionParams := params.Actions{Actions: []params.Action{}}
@@ -294,3 +294,89 @@ func (s *runSuite) TestRunOnAllMachinesRequiresAdmin(c *gc.C) {
 	_, err = client.RunOnAllMachines(params.RunParams{})
 	c.Assert(err, jc.ErrorIsNil)
 }
+
+func (s *runSuite) TestIntrospect(c *gc.C) {
+	machine := s.addMachine(c)
+	magic, err := s.State.AddApplication(state.AddApplicationArgs{Name: "magic", Charm: s.AddTestingCharm(c, "dummy")})
+	c.Assert(err, jc.ErrorIsNil)
+	unit := s.addUnit(c, magic)
+	unitMachineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	notAssigned, err := magic.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.client.Introspect(params.IntrospectArgs{
+		Agents: []string{
+			machine.Tag().String(),
+			unit.Tag().String(),
+			notAssigned.Tag().String(),
+			"application-magic",
+		},
+		Query: "goroutines",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+
+	for i, expected := range []struct {
+		receiver string
+		agent    string
+	}{
+		{machine.Tag().String(), machine.Tag().String()},
+		{names.NewMachineTag(unitMachineId).String(), unit.Tag().String()},
+	} {
+		result := results.Results[i]
+		c.Assert(result.Error, gc.IsNil)
+		c.Check(result.Action.Name, gc.Equals, "juju-introspect")
+		c.Check(result.Action.Receiver, gc.Equals, expected.receiver)
+		c.Check(result.Action.Parameters, jc.DeepEquals, map[string]interface{}{
+			"agent": expected.agent,
+			"query": "goroutines",
+		})
+		c.Check(result.Status, gc.Equals, params.ActionPending)
+	}
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `unit "magic/1" is not assigned to a machine`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `agent "application-magic" not valid`)
+}
+
+func (s *runSuite) TestIntrospectInvalidQuery(c *gc.C) {
+	machine := s.addMachine(c)
+	results, err := s.client.Introspect(params.IntrospectArgs{
+		Agents: []string{machine.Tag().String()},
+		Query:  "heap",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `validation failed: .*`)
+}
+
+func (s *runSuite) TestIntrospectRequiresAdmin(c *gc.C) {
+	machine := s.addMachine(c)
+	alpha := names.NewUserTag("alpha@bravo")
+	auth := apiservertesting.FakeAuthorizer{
+		Tag:         alpha,
+		HasWriteTag: alpha,
+	}
+	client, err := action.NewActionAPI(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.Introspect(params.IntrospectArgs{})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+
+	// Nor can the action be enqueued directly.
+	results, err := client.Enqueue(params.Actions{Actions: []params.Action{{
+		Receiver: machine.Tag().String(),
+		Name:     "juju-introspect",
+		Parameters: map[string]interface{}{
+			"agent": machine.Tag().String(),
+			"query": "goroutines",
+		},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+
+	auth.AdminTag = alpha
+	client, err = action.NewActionAPI(s.State, nil, auth)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = client.Introspect(params.IntrospectArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package advisor
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides the state methods used by the Advisor facade.
+type Backend interface {
+	ModelConfig() (*config.Config, error)
+	IdleResources() ([]state.IdleResource, error)
+	Application(name string) (Application, error)
+}
+
+// Application provides the state methods used by the Advisor facade
+// for an application.
+type Application interface {
+	UnitCount() int
+	MinUnits() int
+}
+
+// API provides access to the Advisor API facade, which reports the
+// model's idle machines and units, and suggests how to remove them.
+type API struct {
+	backend Backend
+	clock   clock.Clock
+}
+
+// createAPI returns a new Advisor API facade for the model.
+func createAPI(
+	backend Backend,
+	clock clock.Clock,
+	authorizer facade.Authorizer,
+	modelTag names.ModelTag,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	canRead, err := authorizer.HasPermission(permission.ReadAccess, modelTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !canRead {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		clock:   clock,
+	}, nil
+}
+
+// NewAPI returns a new Advisor API facade.
+func NewAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return createAPI(stateShim{st: st, model: m}, clock.WallClock, authorizer, m.ModelTag())
+}
+
+// Advice returns the model's machines and units that have been idle for
+// at least the model's idle-resource-days, along with the command that
+// would remove each of them. Removing a unit is only suggested while
+// its application would keep at least one unit, and no fewer than its
+// minimum number of units.
+func (api *API) Advice() (params.AdviceResults, error) {
+	advice, err := api.advice()
+	if err != nil {
+		return params.AdviceResults{Error: common.ServerError(err)}, nil
+	}
+	return params.AdviceResults{Results: advice}, nil
+}
+
+func (api *API) advice() ([]params.Advice, error) {
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	all, err := api.backend.IdleResources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// removable holds the number of units of each application that may
+	// still be suggested for removal.
+	removable := make(map[string]int)
+	var result []params.Advice
+	for _, r := range common.ReportedIdleResources(all, cfg, api.clock.Now()) {
+		advice := params.Advice{
+			Tag:       r.Entity.String(),
+			IdleSince: r.Since,
+			Reason:    r.Reason,
+		}
+		switch tag := r.Entity.(type) {
+		case names.MachineTag:
+			advice.Suggestion = "juju remove-machine " + tag.Id()
+		case names.UnitTag:
+			appName, err := names.UnitApplication(tag.Id())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			n, ok := removable[appName]
+			if !ok {
+				app, err := api.backend.Application(appName)
+				if errors.IsNotFound(err) {
+					continue
+				} else if err != nil {
+					return nil, errors.Trace(err)
+				}
+				keep := app.MinUnits()
+				if keep < 1 {
+					keep = 1
+				}
+				n = app.UnitCount() - keep
+			}
+			if n > 0 {
+				advice.Suggestion = "juju remove-unit " + tag.Id()
+				n--
+			}
+			removable[appName] = n
+		}
+		result = append(result, advice)
+	}
+	return result, nil
+}
+
+type stateShim struct {
+	st    *state.State
+	model *state.Model
+}
+
+func (s stateShim) ModelConfig() (*config.Config, error) {
+	return s.model.Config()
+}
+
+func (s stateShim) IdleResources() ([]state.IdleResource, error) {
+	return s.st.IdleResources()
+}
+
+func (s stateShim) Application(name string) (Application, error) {
+	return s.st.Application(name)
+}
@@ -0,0 +1,163 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package advisor_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/advisor"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type advisorSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+	clock      *testclock.Clock
+}
+
+var _ = gc.Suite(&advisorSuite{})
+
+var now = time.Date(2019, 5, 8, 12, 0, 0, 0, time.UTC)
+
+func (s *advisorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+	s.backend = &mockBackend{
+		config: coretesting.ModelConfig(c),
+		idle: []state.IdleResource{
+			{Entity: names.NewMachineTag("1"), Since: weekAgo, Reason: "no units or containers"},
+			{Entity: names.NewMachineTag("2"), Since: now.Add(-time.Hour), Reason: "no units or containers"},
+			{Entity: names.NewUnitTag("mysql/0"), Since: weekAgo, Reason: "cpu below 5"},
+			{Entity: names.NewUnitTag("mysql/1"), Since: weekAgo, Reason: "cpu below 5"},
+			{Entity: names.NewUnitTag("wordpress/0"), Since: weekAgo, Reason: "cpu below 5"},
+		},
+		applications: map[string]*mockApplication{
+			"mysql":     {unitCount: 3, minUnits: 0},
+			"wordpress": {unitCount: 2, minUnits: 2},
+		},
+	}
+	s.clock = testclock.NewClock(now)
+}
+
+func (s *advisorSuite) newAPI() (*advisor.API, error) {
+	return advisor.CreateAPI(s.backend, s.clock, s.authorizer, coretesting.ModelTag)
+}
+
+func (s *advisorSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := s.newAPI()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *advisorSuite) TestNewAPIRequiresReadAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *advisorSuite) TestAdvice(c *gc.C) {
+	api, err := s.newAPI()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.Advice()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+	c.Assert(result.Results, jc.DeepEquals, []params.Advice{{
+		Tag:        "machine-1",
+		IdleSince:  weekAgo,
+		Reason:     "no units or containers",
+		Suggestion: "juju remove-machine 1",
+	}, {
+		Tag:        "unit-mysql-0",
+		IdleSince:  weekAgo,
+		Reason:     "cpu below 5",
+		Suggestion: "juju remove-unit mysql/0",
+	}, {
+		Tag:        "unit-mysql-1",
+		IdleSince:  weekAgo,
+		Reason:     "cpu below 5",
+		Suggestion: "juju remove-unit mysql/1",
+	}, {
+		Tag:       "unit-wordpress-0",
+		IdleSince: weekAgo,
+		Reason:    "cpu below 5",
+	}})
+}
+
+func (s *advisorSuite) TestAdviceKeepsOneUnit(c *gc.C) {
+	s.backend.applications["mysql"].unitCount = 2
+	api, err := s.newAPI()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.Advice()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Check(result.Results[1].Suggestion, gc.Equals, "juju remove-unit mysql/0")
+	c.Check(result.Results[2].Suggestion, gc.Equals, "")
+}
+
+func (s *advisorSuite) TestAdviceDisabled(c *gc.C) {
+	cfg, err := s.backend.config.Apply(map[string]interface{}{
+		"idle-resource-days": 0,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.config = cfg
+	api, err := s.newAPI()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.Advice()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 0)
+}
+
+type mockBackend struct {
+	config       *config.Config
+	idle         []state.IdleResource
+	applications map[string]*mockApplication
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	return b.config, nil
+}
+
+func (b *mockBackend) IdleResources() ([]state.IdleResource, error) {
+	return b.idle, nil
+}
+
+func (b *mockBackend) Application(name string) (advisor.Application, error) {
+	app, ok := b.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+type mockApplication struct {
+	unitCount int
+	minUnits  int
+}
+
+func (a *mockApplication) UnitCount() int {
+	return a.unitCount
+}
+
+func (a *mockApplication) MinUnits() int {
+	return a.minUnits
+}
@@ -11,6 +11,7 @@ import (
 	"math"
 	"net"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/schema"
@@ -43,6 +44,7 @@ import (
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/poolmanager"
 	"github.com/juju/juju/tools"
 )
@@ -76,6 +78,61 @@ type APIv8 struct {
 
 // APIv9 provides the Application API facade for version 9.
 type APIv9 struct {
+	*APIv10
+}
+
+// APIv10 provides the Application API facade for version 10.
+type APIv10 struct {
+	*APIv11
+}
+
+// APIv11 provides the Application API facade for version 11.
+type APIv11 struct {
+	*APIv12
+}
+
+// APIv12 provides the Application API facade for version 12.
+type APIv12 struct {
+	*APIv13
+}
+
+// APIv13 provides the Application API facade for version 13.
+type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+type APIv14 struct {
+	*APIv15
+}
+
+// APIv15 provides the Application API facade for version 15.
+type APIv15 struct {
+	*APIv16
+}
+
+// APIv16 provides the Application API facade for version 16.
+type APIv16 struct {
+	*APIv17
+}
+
+// APIv17 provides the Application API facade for version 17.
+type APIv17 struct {
+	*APIv18
+}
+
+// APIv18 provides the Application API facade for version 18.
+type APIv18 struct {
+	*APIv19
+}
+
+// APIv19 provides the Application API facade for version 19.
+type APIv19 struct {
+	*APIv20
+}
+
+// APIv20 provides the Application API facade for version 20.
+type APIv20 struct {
 	*APIBase
 }
 
@@ -156,14 +213,124 @@ func NewFacadeV8(ctx facade.Context) (*APIv8, error) {
 	return &APIv8{api}, nil
 }
 
+// NewFacadeV9 provides the signature required for facade registration
+// for version 9.
 func NewFacadeV9(ctx facade.Context) (*APIv9, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV10(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv9{api}, nil
 }
 
+// NewFacadeV10 provides the signature required for facade registration
+// for version 10.
+func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
+	api, err := NewFacadeV11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv10{api}, nil
+}
+
+func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
+	api, err := NewFacadeV12(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv11{api}, nil
+}
+
+// NewFacadeV12 provides the signature required for facade registration
+// for version 12.
+func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
+	api, err := NewFacadeV13(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv12{api}, nil
+}
+
+// NewFacadeV13 provides the signature required for facade registration
+// for version 13.
+func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+	api, err := NewFacadeV14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv13{api}, nil
+}
+
+// NewFacadeV14 provides the signature required for facade registration
+// for version 14.
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := NewFacadeV15(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
+// NewFacadeV15 provides the signature required for facade registration
+// for version 15.
+func NewFacadeV15(ctx facade.Context) (*APIv15, error) {
+	api, err := NewFacadeV16(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv15{api}, nil
+}
+
+// NewFacadeV16 provides the signature required for facade registration
+// for version 16.
+func NewFacadeV16(ctx facade.Context) (*APIv16, error) {
+	api, err := NewFacadeV17(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv16{api}, nil
+}
+
+// NewFacadeV17 provides the signature required for facade registration
+// for version 17.
+func NewFacadeV17(ctx facade.Context) (*APIv17, error) {
+	api, err := NewFacadeV18(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv17{api}, nil
+}
+
+// NewFacadeV18 provides the signature required for facade registration
+// for version 18.
+func NewFacadeV18(ctx facade.Context) (*APIv18, error) {
+	api, err := NewFacadeV19(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv18{api}, nil
+}
+
+// NewFacadeV19 provides the signature required for facade registration
+// for version 19.
+func NewFacadeV19(ctx facade.Context) (*APIv19, error) {
+	api, err := NewFacadeV20(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv19{api}, nil
+}
+
+// NewFacadeV20 provides the signature required for facade registration
+// for version 20.
+func NewFacadeV20(ctx facade.Context) (*APIv20, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv20{api}, nil
+}
+
 func newFacadeBase(ctx facade.Context) (*APIBase, error) {
 	model, err := ctx.State().Model()
 	if err != nil {
@@ -373,7 +540,7 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		return AddTrustSchemaAndDefaults(maintenanceWindowFields, nil)
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -381,6 +548,10 @@ func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, s
 	if err != nil {
 		return nil, nil, err
 	}
+	schema, err = addMaintenanceWindowSchema(schema)
+	if err != nil {
+		return nil, nil, err
+	}
 	return AddTrustSchemaAndDefaults(schema, defaults)
 }
 
@@ -538,6 +709,9 @@ func deployApplication(
 	if err := checkMinVersion(ch); err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkDeployArchitecture(backend, ch, curl, args); err != nil {
+		return errors.Trace(err)
+	}
 
 	// Split out the app config from the charm config for any config
 	// passed in as a map as opposed to YAML.
@@ -573,6 +747,9 @@ func deployApplication(
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := validateMaintenanceWindow(applicationConfig.Attributes()); err != nil {
+		return errors.Trace(err)
+	}
 
 	var settings = make(charm.Settings)
 	if len(charmYamlConfig) > 0 {
@@ -606,6 +783,11 @@ func deployApplication(
 		attachStorage[i] = tag
 	}
 
+	add := unitsQuotaUsage(modelType, args.NumUnits, args.Placement, args.Storage)
+	if err := backend.CheckQuotas(model.ModelTag().Id(), add); err != nil {
+		return errors.Trace(err)
+	}
+
 	_, err = deployApplicationFunc(backend, DeployApplicationParams{
 		ApplicationName:   args.ApplicationName,
 		Series:            args.Series,
@@ -625,6 +807,46 @@ func deployApplication(
 	return errors.Trace(err)
 }
 
+// unitsQuotaUsage returns the resources counted against quotas when
+// adding the given number of units, each with the given storage. On
+// IAAS models, each unit not placed on an existing machine is assumed
+// to need a new machine, and a container placed on a new machine needs
+// its host too. Storage without a size is sized by the charm, and
+// isn't counted here.
+func unitsQuotaUsage(
+	modelType state.ModelType,
+	numUnits int,
+	placement []*instance.Placement,
+	unitStorage map[string]storage.Constraints,
+) state.QuotaUsage {
+	add := state.QuotaUsage{Units: numUnits}
+	for _, cons := range unitStorage {
+		count := cons.Count
+		if count == 0 {
+			count = 1
+		}
+		add.StorageMiB += uint64(numUnits) * cons.Size * count
+	}
+	if modelType != state.ModelTypeIAAS {
+		return add
+	}
+	for i := 0; i < numUnits; i++ {
+		if i >= len(placement) || placement[i] == nil {
+			add.Machines++
+			continue
+		}
+		p := placement[i]
+		if p.Scope == instance.MachineScope {
+			continue
+		}
+		add.Machines++
+		if _, err := instance.ParseContainerType(p.Scope); err == nil && p.Directive == "" {
+			add.Machines++
+		}
+	}
+	return add
+}
+
 // checkMachinePlacement does a non-exhaustive validation of any supplied
 // placement directives.
 // If the placement scope is for a machine, ensure that the machine exists.
@@ -906,6 +1128,43 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 	)
 }
 
+// CheckCharmCompatibility isn't on the v14 API.
+func (u *APIv14) CheckCharmCompatibility(_, _ struct{}) {}
+
+// CheckCharmCompatibility checks that the charms of applications can be
+// changed to the given charms without changing their subordinacy,
+// breaking their relations or losing their storage. This allows clients
+// to find out before uploading resources for the new charms.
+func (api *APIBase) CheckCharmCompatibility(args params.CheckCharmCompatibilityArgs) (params.ErrorResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.checkCharmCompatibility(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *APIBase) checkCharmCompatibility(arg params.CheckCharmCompatibilityArg) error {
+	app, err := api.backend.Application(arg.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	curl, err := charm.ParseURL(arg.CharmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, err := api.backend.Charm(curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(app.CheckCharmCompatibility(api.stateCharm(ch)))
+}
+
 // setCharmWithAgentValidation checks the agent versions of the application
 // and unit before continuing on. These checks are important to prevent old
 // code running at the same time as the new code. If you encounter the error,
@@ -1153,7 +1412,9 @@ func (api *APIBase) CharmRelations(p params.ApplicationCharmRelations) (params.A
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If a load balancer is
+// requested, the firewaller also provisions one in front of the
+// application's units.
 func (api *APIBase) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return errors.Trace(err)
@@ -1166,6 +1427,9 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 		return errors.Trace(err)
 	}
 	if api.modelType == state.ModelTypeCAAS {
+		if args.LoadBalancer {
+			return errors.NotSupportedf("exposing a CAAS application through a load balancer")
+		}
 		appConfig, err := app.ApplicationConfig()
 		if err != nil {
 			return errors.Trace(err)
@@ -1176,9 +1440,18 @@ func (api *APIBase) Expose(args params.ApplicationExpose) error {
 					"juju config %s %s=<value>", caas.JujuExternalHostNameKey, args.ApplicationName, caas.JujuExternalHostNameKey)
 		}
 	}
+	if args.LoadBalancer {
+		return app.SetExposedWithLoadBalancer()
+	}
 	return app.SetExposed()
 }
 
+// Expose on the v16 API does not provision load balancers.
+func (api *APIv16) Expose(args params.ApplicationExpose) error {
+	args.LoadBalancer = false
+	return api.APIv17.Expose(args)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (api *APIBase) Unexpose(args params.ApplicationUnexpose) error {
@@ -1218,6 +1491,10 @@ func (api *APIBase) AddUnits(args params.AddApplicationUnits) (params.AddApplica
 	if err := api.check.ChangeAllowed(); err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
 	}
+	add := unitsQuotaUsage(api.modelType, args.NumUnits, args.Placement, nil)
+	if err := api.backend.CheckQuotas(api.model.ModelTag().Id(), add); err != nil {
+		return params.AddApplicationUnitsResults{}, errors.Trace(err)
+	}
 	units, err := addApplicationUnits(api.backend, api.modelType, args)
 	if err != nil {
 		return params.AddApplicationUnitsResults{}, errors.Trace(err)
@@ -1229,6 +1506,129 @@ func (api *APIBase) AddUnits(args params.AddApplicationUnits) (params.AddApplica
 	return params.AddApplicationUnitsResults{Units: unitNames}, nil
 }
 
+// AssignUnits isn't on the v17 API.
+func (u *APIv17) AssignUnits(_, _ struct{}) {}
+
+// AssignUnits assigns the given units to machines in bulk, in a small
+// number of transactions rather than one per unit. The "pack" policy
+// assigns units to existing clean, empty machines before creating new
+// ones; the "spread" policy creates a new machine for each unit,
+// balancing each application's units across availability zones. The
+// machine chosen for each unit is returned.
+func (api *APIBase) AssignUnits(args params.AssignUnitsArgs) (params.AssignUnitsResults, error) {
+	if api.modelType == state.ModelTypeCAAS {
+		return params.AssignUnitsResults{}, errors.NotSupportedf("assigning units to machines on a container model")
+	}
+	if err := api.checkCanWrite(); err != nil {
+		return params.AssignUnitsResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.AssignUnitsResults{}, errors.Trace(err)
+	}
+	policy := state.UnitPlacementPolicy(args.Policy)
+	if policy == "" {
+		policy = state.PlacementPack
+	}
+	zones := args.Zones
+	if policy == state.PlacementSpread && len(zones) == 0 {
+		var err error
+		zones, err = api.backend.AvailabilityZones()
+		if errors.IsNotSupported(err) {
+			zones = nil
+		} else if err != nil {
+			return params.AssignUnitsResults{}, errors.Annotate(err, "cannot get availability zones")
+		}
+	}
+	placements, err := api.backend.AssignUnits(state.AssignUnitsArgs{
+		Units:  args.Units,
+		Policy: policy,
+		Zones:  zones,
+	})
+	if err != nil {
+		return params.AssignUnitsResults{}, errors.Trace(err)
+	}
+	results := make([]params.AssignUnitResult, len(placements))
+	for i, placement := range placements {
+		results[i] = params.AssignUnitResult{
+			Unit:       placement.Unit,
+			Machine:    placement.MachineId,
+			Zone:       placement.Zone,
+			NewMachine: placement.NewMachine,
+			Error:      common.ServerError(placement.Error),
+		}
+	}
+	return params.AssignUnitsResults{Results: results}, nil
+}
+
+// SetColocationRules isn't on the v18 API.
+func (u *APIv18) SetColocationRules(_, _ struct{}) {}
+
+// ColocationRules isn't on the v18 API.
+func (u *APIv18) ColocationRules(_, _ struct{}) {}
+
+const (
+	colocationAffinity     = "affinity"
+	colocationAntiAffinity = "anti-affinity"
+)
+
+// SetColocationRules sets or removes the rules deciding whether the
+// units of pairs of applications may be placed on the same machines.
+// Depending on the model's colocation-policy, a unit may only be placed
+// on a machine hosting units of other applications if no anti-affinity
+// rule forbids it, or if an affinity rule allows it.
+func (api *APIBase) SetColocationRules(args params.ColocationRules) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	setOne := func(rule params.ColocationRule) error {
+		if len(rule.Applications) != 2 {
+			return errors.Errorf("expected 2 applications, got %d", len(rule.Applications))
+		}
+		app1, app2 := rule.Applications[0], rule.Applications[1]
+		switch rule.Rule {
+		case "":
+			return api.backend.RemoveColocationRule(app1, app2)
+		case colocationAffinity:
+			return api.backend.SetColocationRule(app1, app2, true)
+		case colocationAntiAffinity:
+			return api.backend.SetColocationRule(app1, app2, false)
+		}
+		return errors.NotValidf("co-location rule %q", rule.Rule)
+	}
+	results := make([]params.ErrorResult, len(args.Rules))
+	for i, rule := range args.Rules {
+		results[i].Error = common.ServerError(setOne(rule))
+	}
+	return params.ErrorResults{Results: results}, nil
+}
+
+// ColocationRules returns the model's co-location rules.
+func (api *APIBase) ColocationRules() (params.ColocationRules, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.ColocationRules{}, errors.Trace(err)
+	}
+	rules, err := api.backend.ColocationRules()
+	if err != nil {
+		return params.ColocationRules{}, errors.Trace(err)
+	}
+	result := params.ColocationRules{
+		Rules: make([]params.ColocationRule, len(rules)),
+	}
+	for i, rule := range rules {
+		result.Rules[i] = params.ColocationRule{
+			Applications: []string{rule.Applications[0], rule.Applications[1]},
+			Rule:         colocationAntiAffinity,
+		}
+		if rule.Affinity {
+			result.Rules[i].Rule = colocationAffinity
+		}
+	}
+	return result, nil
+}
+
 // addApplicationUnits adds a given number of units to an application.
 func addApplicationUnits(backend Backend, modelType state.ModelType, args params.AddApplicationUnits) ([]Unit, error) {
 	if args.NumUnits < 1 {
@@ -1271,6 +1671,9 @@ func addApplicationUnits(backend Backend, modelType state.ModelType, args params
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := checkApplicationArchitecture(backend, application, args.ApplicationName); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return addUnits(
 		application,
 		args.ApplicationName,
@@ -1380,6 +1783,7 @@ func (api *APIBase) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyU
 		op := unit.DestroyOperation()
 		op.DestroyStorage = arg.DestroyStorage
 		op.Force = arg.Force
+		op.MaxWait = common.MaxWait(arg.MaxWait)
 		if err := api.backend.ApplyOperation(op); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -1453,65 +1857,43 @@ func (api *APIBase) DestroyApplication(args params.DestroyApplicationsParams) (p
 	if err := api.check.RemoveAllowed(); err != nil {
 		return params.DestroyApplicationResults{}, errors.Trace(err)
 	}
+	cfg, err := api.model.ModelConfig()
+	if err != nil {
+		return params.DestroyApplicationResults{}, errors.Trace(err)
+	}
+	if err := common.CheckModelNotProtected(cfg); err != nil {
+		return params.DestroyApplicationResults{}, errors.Trace(err)
+	}
 	destroyApp := func(arg params.DestroyApplicationParams) (*params.DestroyApplicationInfo, error) {
 		tag, err := names.ParseApplicationTag(arg.ApplicationTag)
 		if err != nil {
 			return nil, err
 		}
-		var info params.DestroyApplicationInfo
 		app, err := api.backend.Application(tag.Id())
 		if err != nil {
 			return nil, err
 		}
+		disposition, err := parseStorageDisposition(arg)
+		if err != nil {
+			return nil, err
+		}
 		units, err := app.AllUnits()
 		if err != nil {
 			return nil, err
 		}
-		storageSeen := names.NewSet()
-		for _, unit := range units {
-			info.DestroyedUnits = append(
-				info.DestroyedUnits,
-				params.Entity{unit.UnitTag().String()},
-			)
-			storage, err := storagecommon.UnitStorage(api.storageAccess, unit.UnitTag())
-			if err != nil {
-				return nil, err
-			}
-
-			// Filter out storage we've already seen. Shared
-			// storage may be attached to multiple units.
-			var unseen []state.StorageInstance
-			for _, stor := range storage {
-				storageTag := stor.StorageTag()
-				if storageSeen.Contains(storageTag) {
-					continue
-				}
-				storageSeen.Add(storageTag)
-				unseen = append(unseen, stor)
-			}
-			storage = unseen
-
-			if arg.DestroyStorage {
-				for _, s := range storage {
-					info.DestroyedStorage = append(
-						info.DestroyedStorage,
-						params.Entity{s.StorageTag().String()},
-					)
-				}
-			} else {
-				destroyed, detached, err := storagecommon.ClassifyDetachedStorage(
-					api.storageAccess.VolumeAccess(), api.storageAccess.FilesystemAccess(), storage,
-				)
-				if err != nil {
-					return nil, err
-				}
-				info.DestroyedStorage = append(info.DestroyedStorage, destroyed...)
-				info.DetachedStorage = append(info.DetachedStorage, detached...)
-			}
+		info, err := api.destroyApplicationInfo(units, disposition)
+		if err != nil {
+			return nil, err
 		}
 		op := app.DestroyOperation()
 		op.DestroyStorage = arg.DestroyStorage
+		if !disposition.isDefault() {
+			if op.StorageDisposition, err = stateStorageDisposition(info); err != nil {
+				return nil, err
+			}
+		}
 		op.Force = arg.Force
+		op.MaxWait = common.MaxWait(arg.MaxWait)
 		if err := api.backend.ApplyOperation(op); err != nil {
 			return nil, err
 		}
@@ -1521,7 +1903,7 @@ func (api *APIBase) DestroyApplication(args params.DestroyApplicationsParams) (p
 		// However, there is a provision for this functionality for the near-future: destroy operation itself
 		// contains Errors that have been encountered during its application.
 		logger.Warningf("operational errors destroying application %v: %v", tag.Id(), op.Errors)
-		return &info, nil
+		return info, nil
 	}
 	results := make([]params.DestroyApplicationResult, len(args.Applications))
 	for i, arg := range args.Applications {
@@ -1535,6 +1917,253 @@ func (api *APIBase) DestroyApplication(args params.DestroyApplicationsParams) (p
 	return params.DestroyApplicationResults{results}, nil
 }
 
+// storageDisposition records what will happen to the storage attached
+// to an application's units when the application is destroyed.
+type storageDisposition struct {
+	// fallback applies to storage not named in overrides.
+	fallback state.StorageDisposition
+
+	// overrides maps storage IDs to their requested disposition.
+	overrides map[string]state.StorageDisposition
+}
+
+// parseStorageDisposition returns the storage disposition requested by
+// the given destroy parameters.
+func parseStorageDisposition(arg params.DestroyApplicationParams) (storageDisposition, error) {
+	if arg.DestroyStorage && arg.ReleaseStorage {
+		return storageDisposition{}, errors.New("cannot both destroy and release storage")
+	}
+	disposition := storageDisposition{fallback: state.StorageDetach}
+	if arg.DestroyStorage {
+		disposition.fallback = state.StorageDestroy
+	} else if arg.ReleaseStorage {
+		disposition.fallback = state.StorageRelease
+	}
+	for tagString, what := range arg.StorageDisposition {
+		tag, err := names.ParseStorageTag(tagString)
+		if err != nil {
+			return storageDisposition{}, errors.Trace(err)
+		}
+		switch d := state.StorageDisposition(what); d {
+		case state.StorageDestroy, state.StorageDetach, state.StorageRelease:
+			if disposition.overrides == nil {
+				disposition.overrides = make(map[string]state.StorageDisposition)
+			}
+			disposition.overrides[tag.Id()] = d
+		default:
+			return storageDisposition{}, errors.NotValidf("disposition %q for storage %s", what, tag.Id())
+		}
+	}
+	return disposition, nil
+}
+
+// isDefault reports whether the disposition is one that the destroy
+// operation's DestroyStorage flag alone can express.
+func (d storageDisposition) isDefault() bool {
+	return len(d.overrides) == 0 && d.fallback != state.StorageRelease
+}
+
+// of returns the disposition of the given storage instance.
+func (d storageDisposition) of(tag names.StorageTag) state.StorageDisposition {
+	if what, ok := d.overrides[tag.Id()]; ok {
+		return what
+	}
+	return d.fallback
+}
+
+// stateStorageDisposition returns the disposition of each storage
+// instance reported in the given destroy info, keyed by storage ID.
+func stateStorageDisposition(info *params.DestroyApplicationInfo) (map[string]state.StorageDisposition, error) {
+	result := make(map[string]state.StorageDisposition)
+	add := func(entities []params.Entity, what state.StorageDisposition) error {
+		for _, entity := range entities {
+			tag, err := names.ParseStorageTag(entity.Tag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			result[tag.Id()] = what
+		}
+		return nil
+	}
+	if err := add(info.DestroyedStorage, state.StorageDestroy); err != nil {
+		return nil, err
+	}
+	if err := add(info.ReleasedStorage, state.StorageRelease); err != nil {
+		return nil, err
+	}
+	if err := add(info.DetachedStorage, state.StorageDetach); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// destroyApplicationInfo returns the units and storage that will be
+// removed by destroying an application with the given units.
+func (api *APIBase) destroyApplicationInfo(units []Unit, disposition storageDisposition) (*params.DestroyApplicationInfo, error) {
+	var info params.DestroyApplicationInfo
+	storageSeen := names.NewSet()
+	for _, unit := range units {
+		info.DestroyedUnits = append(
+			info.DestroyedUnits,
+			params.Entity{unit.UnitTag().String()},
+		)
+		storage, err := storagecommon.UnitStorage(api.storageAccess, unit.UnitTag())
+		if err != nil {
+			return nil, err
+		}
+
+		// Filter out storage we've already seen. Shared
+		// storage may be attached to multiple units.
+		var detaching []state.StorageInstance
+		for _, stor := range storage {
+			storageTag := stor.StorageTag()
+			if storageSeen.Contains(storageTag) {
+				continue
+			}
+			storageSeen.Add(storageTag)
+			switch disposition.of(storageTag) {
+			case state.StorageDestroy:
+				info.DestroyedStorage = append(
+					info.DestroyedStorage,
+					params.Entity{storageTag.String()},
+				)
+			case state.StorageRelease:
+				info.ReleasedStorage = append(
+					info.ReleasedStorage,
+					params.Entity{storageTag.String()},
+				)
+			default:
+				detaching = append(detaching, stor)
+			}
+		}
+
+		destroyed, detached, err := storagecommon.ClassifyDetachedStorage(
+			api.storageAccess.VolumeAccess(), api.storageAccess.FilesystemAccess(), detaching,
+		)
+		if err != nil {
+			return nil, err
+		}
+		info.DestroyedStorage = append(info.DestroyedStorage, destroyed...)
+		info.DetachedStorage = append(info.DetachedStorage, detached...)
+	}
+	for id := range disposition.overrides {
+		if !storageSeen.Contains(names.NewStorageTag(id)) {
+			return nil, errors.NotFoundf("storage %s attached to the application's units", id)
+		}
+	}
+	return &info, nil
+}
+
+// destroyedHostMachines returns the tags of the machines that will be
+// removed once the given units are, because they host no other units
+// or containers.
+func (api *APIBase) destroyedHostMachines(units []Unit) ([]params.Entity, error) {
+	unitNames := set.NewStrings()
+	machineIds := set.NewStrings()
+	for _, unit := range units {
+		unitNames.Add(unit.Name())
+		if !unit.IsPrincipal() {
+			continue
+		}
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		if machineId != "" {
+			machineIds.Add(machineId)
+		}
+	}
+	var result []params.Entity
+	for _, machineId := range machineIds.SortedValues() {
+		machine, err := api.backend.Machine(machineId)
+		if err != nil {
+			return nil, err
+		}
+		if machine.IsManager() || machine.HasVote() {
+			continue
+		}
+		containers, err := machine.Containers()
+		if err != nil {
+			return nil, err
+		}
+		if len(containers) > 0 {
+			continue
+		}
+		if !set.NewStrings(machine.Principals()...).Difference(unitNames).IsEmpty() {
+			continue
+		}
+		result = append(result, params.Entity{names.NewMachineTag(machine.Id()).String()})
+	}
+	return result, nil
+}
+
+// PreviewDestroyApplication isn't on the V9 API.
+func (u *APIv9) PreviewDestroyApplication(_, _ struct{}) {}
+
+// PreviewDestroyApplication reports what would be removed by destroying
+// the given applications with the same parameters, without destroying
+// them.
+func (api *APIBase) PreviewDestroyApplication(args params.DestroyApplicationsParams) (params.DestroyApplicationResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.DestroyApplicationResults{}, err
+	}
+	previewApp := func(arg params.DestroyApplicationParams) (*params.DestroyApplicationInfo, error) {
+		tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			return nil, err
+		}
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			return nil, err
+		}
+		disposition, err := parseStorageDisposition(arg)
+		if err != nil {
+			return nil, err
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, err
+		}
+		info, err := api.destroyApplicationInfo(units, disposition)
+		if err != nil {
+			return nil, err
+		}
+		if info.DestroyedMachines, err = api.destroyedHostMachines(units); err != nil {
+			return nil, err
+		}
+		// Offers are reported so that users can see which consumers
+		// will be affected; destroying an application with offers
+		// fails unless it is forced.
+		offers, err := api.backend.OfferConnectionCounts(tag.Id())
+		if err != nil {
+			return nil, err
+		}
+		offerNames := set.NewStrings()
+		for name := range offers {
+			offerNames.Add(name)
+		}
+		for _, name := range offerNames.SortedValues() {
+			info.DestroyedOffers = append(info.DestroyedOffers, params.DestroyedOffer{
+				OfferName:   name,
+				Connections: offers[name],
+			})
+		}
+		return info, nil
+	}
+	results := make([]params.DestroyApplicationResult, len(args.Applications))
+	for i, arg := range args.Applications {
+		info, err := previewApp(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Info = info
+	}
+	return params.DestroyApplicationResults{results}, nil
+}
+
 // DestroyConsumedApplications removes a given set of consumed (remote) applications.
 func (api *APIBase) DestroyConsumedApplications(args params.DestroyConsumedApplicationsParams) (params.ErrorResults, error) {
 	if err := api.checkCanWrite(); err != nil {
@@ -1597,6 +2226,15 @@ func (api *APIBase) ScaleApplications(args params.ScaleApplicationsParams) (para
 		} else if err != nil {
 			return nil, errors.Trace(err)
 		}
+		add := arg.ScaleChange
+		if arg.ScaleChange == 0 {
+			add = arg.Scale - app.GetScale()
+		}
+		if add > 0 {
+			if err := api.backend.CheckQuotas(api.model.ModelTag().Id(), state.QuotaUsage{Units: add}); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 		var info params.ScaleApplicationInfo
 		if arg.ScaleChange != 0 {
 			newScale, err := app.ChangeScale(arg.ScaleChange)
@@ -1806,6 +2444,95 @@ func (api *APIBase) SetRelationsSuspended(args params.RelationSuspendedArgs) (pa
 	return statusResults, nil
 }
 
+// SetRelationsDrainTimeout isn't on the v15 API.
+func (u *APIv15) SetRelationsDrainTimeout(_, _ struct{}) {}
+
+// SetRelationsDrainTimeout sets the drain timeout of the specified
+// relations. Units departing a relation with a drain timeout remain in
+// scope until the timeout expires, or until their counterparts have
+// departed, whichever comes first.
+func (api *APIBase) SetRelationsDrainTimeout(args params.RelationDrainTimeoutArgs) (params.ErrorResults, error) {
+	var results params.ErrorResults
+	if err := api.checkCanWrite(); err != nil {
+		return results, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+
+	changeOne := func(arg params.RelationDrainTimeoutArg) error {
+		rel, err := api.backend.Relation(arg.RelationId)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return rel.SetDrainTimeout(arg.Timeout)
+	}
+	results.Results = make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		err := changeOne(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RelationData isn't on the v19 API.
+func (u *APIv19) RelationData(_, _ struct{}) {}
+
+// RelationData returns the current relation data of the units in the
+// scope of each of the specified relations, grouped by application.
+func (api *APIBase) RelationData(args params.RelationIds) (params.RelationDataResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RelationDataResults{}, errors.Trace(err)
+	}
+	oneRelationData := func(id int) (params.RelationDataResult, error) {
+		rel, err := api.backend.Relation(id)
+		if err != nil {
+			return params.RelationDataResult{}, errors.Trace(err)
+		}
+		unitSettings, err := rel.UnitSettings()
+		if err != nil {
+			return params.RelationDataResult{}, errors.Trace(err)
+		}
+		result := params.RelationDataResult{
+			Id:  id,
+			Key: rel.Tag().Id(),
+		}
+		for _, ep := range rel.Endpoints() {
+			appData := params.RelationApplicationData{
+				Application: ep.ApplicationName,
+				Endpoint:    ep.Name,
+				Role:        string(ep.Role),
+				UnitData:    make(map[string]params.Settings),
+			}
+			for unitName, settings := range unitSettings {
+				appName, err := names.UnitApplication(unitName)
+				if err != nil {
+					return params.RelationDataResult{}, errors.Trace(err)
+				}
+				if appName != ep.ApplicationName {
+					continue
+				}
+				data := make(params.Settings)
+				for k, v := range settings {
+					data[k] = fmt.Sprint(v)
+				}
+				appData.UnitData[unitName] = data
+			}
+			result.Applications = append(result.Applications, appData)
+		}
+		return result, nil
+	}
+	results := make([]params.RelationDataResult, len(args.RelationIds))
+	for i, id := range args.RelationIds {
+		result, err := oneRelationData(id)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		results[i] = result
+	}
+	return params.RelationDataResults{Results: results}, nil
+}
+
 // Consume adds remote applications to the model without creating any
 // relations.
 func (api *APIBase) Consume(args params.ConsumeApplicationArgs) (params.ErrorResults, error) {
@@ -2124,6 +2851,9 @@ func (api *APIBase) setApplicationConfig(arg params.ApplicationConfigSet) error
 	}
 
 	if len(appConfigAttrs) > 0 {
+		if err := validateMaintenanceWindow(appConfigAttrs); err != nil {
+			return errors.Trace(err)
+		}
 		if err := app.UpdateApplicationConfig(appConfigAttrs, nil, schema, defaults); err != nil {
 			return errors.Annotate(err, "updating application config values")
 		}
@@ -2308,6 +3038,133 @@ func (api *APIBase) ApplicationsInfo(in params.Entities) (params.ApplicationInfo
 	return params.ApplicationInfoResults{out}, nil
 }
 
+// SetRefreshPolicy isn't on the v11 API.
+func (u *APIv11) SetRefreshPolicy(_, _ struct{}) {}
+
+// RefreshPolicies isn't on the v11 API.
+func (u *APIv11) RefreshPolicies(_, _ struct{}) {}
+
+// SetRefreshPolicy sets how each of the given applications follows new
+// revisions of its charm.
+func (api *APIBase) SetRefreshPolicy(args params.SetApplicationRefreshPolicies) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Policies))
+	for i, arg := range args.Policies {
+		err := api.setRefreshPolicy(arg)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{results}, nil
+}
+
+func (api *APIBase) setRefreshPolicy(arg params.ApplicationRefreshPolicy) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return app.SetRefreshPolicy(state.RefreshPolicy{
+		Channel:     csparams.Channel(arg.Policy.Channel),
+		Auto:        arg.Policy.Auto,
+		WindowStart: arg.Policy.WindowStart,
+		WindowEnd:   arg.Policy.WindowEnd,
+		Hold:        arg.Policy.Hold,
+	})
+}
+
+// RefreshPolicies returns how each of the given applications follows
+// new revisions of its charm.
+func (api *APIBase) RefreshPolicies(args params.Entities) (params.RefreshPolicyResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.RefreshPolicyResults{}, errors.Trace(err)
+	}
+	results := make([]params.RefreshPolicyResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		app, err := api.backend.Application(tag.Name)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		policy := app.RefreshPolicy()
+		results[i].Result = &params.RefreshPolicy{
+			Channel:     string(policy.Channel),
+			Auto:        policy.Auto,
+			WindowStart: policy.WindowStart,
+			WindowEnd:   policy.WindowEnd,
+			Hold:        policy.Hold,
+		}
+	}
+	return params.RefreshPolicyResults{results}, nil
+}
+
+// Pause isn't on the v12 API.
+func (u *APIv12) Pause(_, _ struct{}) {}
+
+// Resume isn't on the v12 API.
+func (u *APIv12) Resume(_, _ struct{}) {}
+
+// Pause stops hook execution for each of the given applications or
+// units, so that operators can carry out maintenance without the unit
+// agents reacting to it.
+func (api *APIBase) Pause(args params.Entities) (params.ErrorResults, error) {
+	return api.setPaused(args, true)
+}
+
+// Resume resumes hook execution for each of the given applications or
+// units.
+func (api *APIBase) Resume(args params.Entities) (params.ErrorResults, error) {
+	return api.setPaused(args, false)
+}
+
+func (api *APIBase) setPaused(args params.Entities, paused bool) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := make([]params.ErrorResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		err := api.setEntityPaused(entity.Tag, paused)
+		results[i].Error = common.ServerError(err)
+	}
+	return params.ErrorResults{results}, nil
+}
+
+func (api *APIBase) setEntityPaused(entity string, paused bool) error {
+	tag, err := names.ParseTag(entity)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch tag.Kind() {
+	case names.ApplicationTagKind:
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return app.SetPaused(paused)
+	case names.UnitTagKind:
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return unit.SetPaused(paused)
+	}
+	return errors.Errorf("unexpected tag type, expected application or unit, got %s", tag.Kind())
+}
+
 // lxdCharmProfiler massages a *state.Charm into a LXDProfiler
 // inside of the core package.
 type lxdCharmProfiler struct {
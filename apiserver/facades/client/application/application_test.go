@@ -110,7 +110,7 @@ func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv9 {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	return &application.APIv9{api}
+	return &application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{&application.APIv17{&application.APIv18{&application.APIv19{&application.APIv20{api}}}}}}}}}}}}
 }
 
 func (s *applicationSuite) TestCharmConfig(c *gc.C) {
@@ -2420,7 +2420,7 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	c.Assert(apps[1].IsExposed(), jc.IsTrue)
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -2472,7 +2472,7 @@ var applicationExposeTests = []struct {
 func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -2487,7 +2487,7 @@ func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 func (s *applicationSuite) assertApplicationExposeBlocked(c *gc.C, msg string) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		s.AssertBlocked(c, err, msg)
 	}
 }
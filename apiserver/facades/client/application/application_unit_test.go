@@ -4,7 +4,10 @@
 package application_test
 
 import (
+	"debug/elf"
+	"encoding/binary"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -13,6 +16,7 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
 	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
 	"gopkg.in/juju/names.v2"
 
@@ -32,6 +36,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/provider"
@@ -53,7 +58,7 @@ type ApplicationSuite struct {
 	env              environs.Environ
 	blockChecker     mockBlockChecker
 	authorizer       apiservertesting.FakeAuthorizer
-	api              *application.APIv9
+	api              *application.APIv20
 	deployParams     map[string]application.DeployApplicationParams
 }
 
@@ -81,7 +86,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.storageValidator,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv9{api}
+	s.api = &application.APIv20{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -318,6 +323,35 @@ func (s *ApplicationSuite) TestSetCharmStorageConstraints(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestCheckCharmCompatibility(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.SetErrors(errors.New(`would break relation "wordpress:db postgresql:db"`))
+	result, err := s.api.CheckCharmCompatibility(params.CheckCharmCompatibilityArgs{
+		Args: []params.CheckCharmCompatibilityArg{{
+			ApplicationName: "postgresql",
+			CharmURL:        "cs:postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `would break relation "wordpress:db postgresql:db"`)
+	s.backend.CheckCallNames(c, "Application", "Charm")
+	app.CheckCallNames(c, "CheckCharmCompatibility")
+	app.CheckCall(c, 0, "CheckCharmCompatibility", &state.Charm{})
+}
+
+func (s *ApplicationSuite) TestCheckCharmCompatibilityCharmNotFound(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotFoundf(`charm "cs:postgresql"`))
+	result, err := s.api.CheckCharmCompatibility(params.CheckCharmCompatibilityArgs{
+		Args: []params.CheckCharmCompatibilityArg{{
+			ApplicationName: "postgresql",
+			CharmURL:        "cs:postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `charm "cs:postgresql" not found`)
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestSetCharmConfigSettings(c *gc.C) {
 	err := s.api.SetCharm(params.ApplicationSetCharm{
 		ApplicationName: "postgresql",
@@ -507,7 +541,80 @@ func (s *ApplicationSuite) assertDestroyApplication(c *gc.C, force bool) {
 		"UnitStorageAttachments",
 		"ApplyOperation",
 	)
-	s.backend.CheckCall(c, 7, "ApplyOperation", &state.DestroyApplicationOperation{ForcedOperation: state.ForcedOperation{Force: force}})
+	s.backend.CheckCall(c, 7, "ApplyOperation", &state.DestroyApplicationOperation{
+		ForcedOperation: state.ForcedOperation{Force: force, MaxWait: common.DefaultMaxWait},
+	})
+}
+
+func (s *ApplicationSuite) TestPreviewDestroyApplication(c *gc.C) {
+	s.backend.offerConnectionCounts = map[string]map[string]int{
+		"postgresql": {"pgsql": 2, "db": 0},
+	}
+	results, err := s.api.PreviewDestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0], jc.DeepEquals, params.DestroyApplicationResult{
+		Info: &params.DestroyApplicationInfo{
+			DestroyedUnits: []params.Entity{
+				{Tag: "unit-postgresql-0"},
+				{Tag: "unit-postgresql-1"},
+			},
+			DetachedStorage: []params.Entity{
+				{Tag: "storage-pgdata-0"},
+			},
+			DestroyedStorage: []params.Entity{
+				{Tag: "storage-pgdata-1"},
+			},
+			DestroyedOffers: []params.DestroyedOffer{
+				{OfferName: "db", Connections: 0},
+				{OfferName: "pgsql", Connections: 2},
+			},
+		},
+	})
+
+	s.backend.CheckCallNames(c,
+		"Application",
+		"UnitStorageAttachments",
+		"StorageInstance",
+		"StorageInstance",
+		"StorageInstanceFilesystem",
+		"StorageInstanceFilesystem",
+		"UnitStorageAttachments",
+		"OfferConnectionCounts",
+	)
+	s.backend.applications["postgresql"].CheckCallNames(c, "AllUnits")
+}
+
+func (s *ApplicationSuite) TestPreviewDestroyApplicationMachines(c *gc.C) {
+	s.backend.machines["machine-0"].principals = []string{"redis/0"}
+	s.backend.machines["machine-1"].principals = []string{"redis/1", "postgresql/0"}
+	results, err := s.api.PreviewDestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{{
+			ApplicationTag: "application-redis",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Info.DestroyedMachines, jc.DeepEquals, []params.Entity{
+		{Tag: "machine-0"},
+	})
+}
+
+func (s *ApplicationSuite) TestPreviewDestroyApplicationNotFound(c *gc.C) {
+	results, err := s.api.PreviewDestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{{
+			ApplicationTag: "application-unknown",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(results.Results[0].Info, gc.IsNil)
 }
 
 func (s *ApplicationSuite) TestDestroyApplicationDestroyStorage(c *gc.C) {
@@ -541,10 +648,121 @@ func (s *ApplicationSuite) TestDestroyApplicationDestroyStorage(c *gc.C) {
 		"ApplyOperation",
 	)
 	s.backend.CheckCall(c, 5, "ApplyOperation", &state.DestroyApplicationOperation{
-		DestroyStorage: true,
+		DestroyStorage:  true,
+		ForcedOperation: state.ForcedOperation{MaxWait: common.DefaultMaxWait},
+	})
+}
+
+func (s *ApplicationSuite) TestDestroyApplicationStorageDisposition(c *gc.C) {
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			StorageDisposition: map[string]string{
+				"storage-pgdata-0": "release",
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0], jc.DeepEquals, params.DestroyApplicationResult{
+		Info: &params.DestroyApplicationInfo{
+			DestroyedUnits: []params.Entity{
+				{Tag: "unit-postgresql-0"},
+				{Tag: "unit-postgresql-1"},
+			},
+			ReleasedStorage: []params.Entity{
+				{Tag: "storage-pgdata-0"},
+			},
+			DestroyedStorage: []params.Entity{
+				{Tag: "storage-pgdata-1"},
+			},
+		},
+	})
+
+	s.backend.CheckCallNames(c,
+		"Application",
+		"UnitStorageAttachments",
+		"StorageInstance",
+		"StorageInstance",
+		"StorageInstanceFilesystem",
+		"UnitStorageAttachments",
+		"ApplyOperation",
+	)
+	s.backend.CheckCall(c, 6, "ApplyOperation", &state.DestroyApplicationOperation{
+		StorageDisposition: map[string]state.StorageDisposition{
+			"pgdata/0": state.StorageRelease,
+			"pgdata/1": state.StorageDestroy,
+		},
+		ForcedOperation: state.ForcedOperation{MaxWait: common.DefaultMaxWait},
+	})
+}
+
+func (s *ApplicationSuite) TestDestroyApplicationReleaseStorage(c *gc.C) {
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			ReleaseStorage: true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Info.ReleasedStorage, jc.DeepEquals, []params.Entity{
+		{Tag: "storage-pgdata-0"},
+		{Tag: "storage-pgdata-1"},
+	})
+	s.backend.CheckCall(c, 5, "ApplyOperation", &state.DestroyApplicationOperation{
+		StorageDisposition: map[string]state.StorageDisposition{
+			"pgdata/0": state.StorageRelease,
+			"pgdata/1": state.StorageRelease,
+		},
+		ForcedOperation: state.ForcedOperation{MaxWait: common.DefaultMaxWait},
 	})
 }
 
+func (s *ApplicationSuite) TestDestroyApplicationDestroyAndReleaseStorage(c *gc.C) {
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			DestroyStorage: true,
+			ReleaseStorage: true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "cannot both destroy and release storage")
+	s.backend.CheckCallNames(c, "Application")
+}
+
+func (s *ApplicationSuite) TestDestroyApplicationStorageDispositionInvalid(c *gc.C) {
+	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{{
+			ApplicationTag:     "application-postgresql",
+			StorageDisposition: map[string]string{"storage-pgdata-0": "shred"},
+		}, {
+			ApplicationTag:     "application-postgresql",
+			StorageDisposition: map[string]string{"storage-logs-0": "destroy"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `disposition "shred" for storage pgdata/0 not valid`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `storage logs/0 attached to the application's units not found`)
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *ApplicationSuite) TestDestroyApplicationProtectedModel(c *gc.C) {
+	s.model.cfg["protected"] = true
+	_, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
+		Applications: []params.DestroyApplicationParams{
+			{ApplicationTag: "application-postgresql"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `model "testmodel" is protected from destruction and from removal of its applications and machines`)
+	c.Assert(err, jc.Satisfies, params.IsCodeModelProtected)
+	s.backend.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestDestroyApplicationNotFound(c *gc.C) {
 	delete(s.backend.applications, "postgresql")
 	results, err := s.api.DestroyApplication(params.DestroyApplicationsParams{
@@ -638,9 +856,12 @@ func (s *ApplicationSuite) assertDestroyUnit(c *gc.C, force bool) {
 		"UnitStorageAttachments",
 		"ApplyOperation",
 	)
-	s.backend.CheckCall(c, 6, "ApplyOperation", &state.DestroyUnitOperation{ForcedOperation: state.ForcedOperation{Force: force}})
+	s.backend.CheckCall(c, 6, "ApplyOperation", &state.DestroyUnitOperation{
+		ForcedOperation: state.ForcedOperation{Force: force, MaxWait: common.DefaultMaxWait},
+	})
 	s.backend.CheckCall(c, 9, "ApplyOperation", &state.DestroyUnitOperation{
-		DestroyStorage: true,
+		DestroyStorage:  true,
+		ForcedOperation: state.ForcedOperation{MaxWait: common.DefaultMaxWait},
 	})
 }
 
@@ -671,6 +892,127 @@ func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"volume-baz-0" is not a valid volume tag`)
 }
 
+func (s *ApplicationSuite) TestDeployQuotaCountsStorage(c *gc.C) {
+	s.backend.quotaErr = &state.QuotaExceededError{
+		Entity: `model "foo"`, Resource: "MiB of storage", Limit: 4096, Used: 1024, Requested: 5120,
+	}
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        2,
+			Storage: map[string]storage.Constraints{
+				"data": {Size: 1024, Count: 2},
+				"logs": {Size: 512},
+			},
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `quota exceeded: model "foo" is limited to 4096 MiB of storage \(using 1024, requested 5120\)`)
+	c.Assert(s.deployParams, gc.HasLen, 0)
+
+	var found bool
+	for _, call := range s.backend.Calls() {
+		if call.FuncName != "CheckQuotas" {
+			continue
+		}
+		found = true
+		c.Check(call.Args, jc.DeepEquals, []interface{}{s.model.UUID(), state.QuotaUsage{
+			Units:      2,
+			Machines:   2,
+			StorageMiB: 5120,
+		}})
+	}
+	c.Assert(found, jc.IsTrue)
+}
+
+func (s *ApplicationSuite) TestDeployCharmArchitecture(c *gc.C) {
+	s.backend.charm.arches = []string{"amd64"}
+	arch := "arm64"
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+			Constraints:     constraints.Value{Arch: &arch},
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `charm "local:foo-0" only contains binaries for amd64, not arm64`)
+	c.Assert(s.deployParams, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestDeployCharmArchitectureMatches(c *gc.C) {
+	s.backend.charm.arches = []string{"amd64", "arm64"}
+	arch := "arm64"
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+			Constraints:     constraints.Value{Arch: &arch},
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(s.deployParams, gc.HasLen, 1)
+}
+
+func (s *ApplicationSuite) TestDeployResourceArchitecture(c *gc.C) {
+	s.backend.resources.content = map[string]string{
+		"server": elfBinary(elf.EM_X86_64),
+		"docs":   "not a binary",
+	}
+	arch := "arm64"
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        1,
+			Constraints:     constraints.Value{Arch: &arch},
+			Resources: map[string]string{
+				"server": "pending-server",
+				"docs":   "pending-docs",
+				"store":  "pending-store",
+			},
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `resource "server" is a binary for amd64, not arm64`)
+	c.Assert(s.deployParams, gc.HasLen, 0)
+
+	var found bool
+	for _, call := range s.backend.resources.Calls() {
+		if call.FuncName == "OpenPendingResource" && call.Args[1] == "server" {
+			found = true
+			c.Check(call.Args, jc.DeepEquals, []interface{}{"foo", "server", "pending-server"})
+		}
+	}
+	c.Assert(found, jc.IsTrue)
+	s.backend.resources.CheckCall(c, len(s.backend.resources.Calls())-1,
+		"RemovePendingAppResources", "foo", args.Applications[0].Resources,
+	)
+}
+
+// elfBinary returns the start of an ELF executable built for the
+// given machine.
+func elfBinary(machine elf.Machine) string {
+	header := make([]byte, 64)
+	copy(header, elf.ELFMAG)
+	header[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	header[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	binary.LittleEndian.PutUint16(header[18:], uint16(machine))
+	return string(header)
+}
+
 func (s *ApplicationSuite) TestDeployCAASModel(c *gc.C) {
 	s.model.modelType = state.ModelTypeCAAS
 	s.backend.charm = &mockCharm{
@@ -820,10 +1162,61 @@ func (s *ApplicationSuite) TestAddUnits(c *gc.C) {
 		Units: []string{"postgresql/99"},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 0, "AddUnit", state.AddUnitParams{})
+	app.CheckCallNames(c, "Constraints", "Charm", "CharmURL", "AddUnit")
+	app.CheckCall(c, 3, "AddUnit", state.AddUnitParams{})
 	app.addedUnit.CheckCall(c, 0, "AssignWithPolicy", state.AssignCleanEmpty)
 }
 
+func (s *ApplicationSuite) TestAddUnitsQuotaExceeded(c *gc.C) {
+	s.backend.quotaErr = &state.QuotaExceededError{
+		Entity: `model "foo"`, Resource: "units", Limit: 2, Used: 1, Requested: 3,
+	}
+	s.backend.ResetCalls()
+	_, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        3,
+		Placement: []*instance.Placement{
+			{Scope: instance.MachineScope, Directive: "0"},
+			{Scope: "lxd", Directive: ""},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `quota exceeded: model "foo" is limited to 2 units \(using 1, requested 3\)`)
+	s.backend.CheckCallNames(c, "CheckQuotas")
+	s.backend.CheckCall(c, 0, "CheckQuotas", s.model.UUID(), state.QuotaUsage{
+		Units:    3,
+		Machines: 3,
+	})
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestAddUnitsCharmArchitecture(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.curl = charm.MustParseURL("cs:postgresql")
+	app.charm.arches = []string{"arm64", "s390x"}
+	_, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+	})
+	c.Assert(err, gc.ErrorMatches, `charm "cs:postgresql" only contains binaries for arm64, s390x, not amd64`)
+	app.CheckCallNames(c, "Constraints", "Charm", "CharmURL")
+}
+
+func (s *ApplicationSuite) TestAddUnitsResourceArchitecture(c *gc.C) {
+	res := resource.Resource{}
+	res.Name = "server"
+	res.Type = charmresource.TypeFile
+	s.backend.resources.resources = []resource.Resource{res}
+	s.backend.resources.content = map[string]string{"server": elfBinary(elf.EM_AARCH64)}
+	_, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+	})
+	c.Assert(err, gc.ErrorMatches, `resource "server" is a binary for arm64, not amd64`)
+	s.backend.resources.CheckCall(c, 1, "OpenResource", "postgresql", "server")
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "Constraints", "Charm", "CharmURL")
+}
+
 func (s *ApplicationSuite) TestAddUnitsCAASModel(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	_, err := s.api.AddUnits(params.AddApplicationUnits{
@@ -835,6 +1228,101 @@ func (s *ApplicationSuite) TestAddUnitsCAASModel(c *gc.C) {
 	app.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestAssignUnits(c *gc.C) {
+	results, err := s.api.AssignUnits(params.AssignUnitsArgs{
+		Units: []string{"postgresql/0", "postgresql/1"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.AssignUnitsResults{
+		Results: []params.AssignUnitResult{
+			{Unit: "postgresql/0", Machine: "1", NewMachine: true},
+			{Unit: "postgresql/1", Machine: "2", NewMachine: true},
+		},
+	})
+	s.backend.CheckCallNames(c, "AssignUnits")
+	s.backend.CheckCall(c, 0, "AssignUnits", state.AssignUnitsArgs{
+		Units:  []string{"postgresql/0", "postgresql/1"},
+		Policy: state.PlacementPack,
+	})
+}
+
+func (s *ApplicationSuite) TestAssignUnitsSpreadProviderZones(c *gc.C) {
+	results, err := s.api.AssignUnits(params.AssignUnitsArgs{
+		Units:  []string{"postgresql/0", "postgresql/1"},
+		Policy: "spread",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.AssignUnitsResults{
+		Results: []params.AssignUnitResult{
+			{Unit: "postgresql/0", Machine: "1", Zone: "az1", NewMachine: true},
+			{Unit: "postgresql/1", Machine: "2", Zone: "az2", NewMachine: true},
+		},
+	})
+	s.backend.CheckCallNames(c, "AvailabilityZones", "AssignUnits")
+	s.backend.CheckCall(c, 1, "AssignUnits", state.AssignUnitsArgs{
+		Units:  []string{"postgresql/0", "postgresql/1"},
+		Policy: state.PlacementSpread,
+		Zones:  []string{"az1", "az2"},
+	})
+}
+
+func (s *ApplicationSuite) TestAssignUnitsSpreadZonesNotSupported(c *gc.C) {
+	s.backend.SetErrors(errors.NotSupportedf("availability zones"))
+	_, err := s.api.AssignUnits(params.AssignUnitsArgs{
+		Units:  []string{"postgresql/0"},
+		Policy: "spread",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 1, "AssignUnits", state.AssignUnitsArgs{
+		Units:  []string{"postgresql/0"},
+		Policy: state.PlacementSpread,
+	})
+}
+
+func (s *ApplicationSuite) TestAssignUnitsCAASModel(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	_, err := s.api.AssignUnits(params.AssignUnitsArgs{
+		Units: []string{"postgresql/0"},
+	})
+	c.Assert(err, gc.ErrorMatches, "assigning units to machines on a container model not supported")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetColocationRules(c *gc.C) {
+	results, err := s.api.SetColocationRules(params.ColocationRules{
+		Rules: []params.ColocationRule{
+			{Applications: []string{"wordpress", "mysql"}, Rule: "affinity"},
+			{Applications: []string{"wordpress", "postgresql"}, Rule: "anti-affinity"},
+			{Applications: []string{"mysql", "postgresql"}},
+			{Applications: []string{"mysql"}, Rule: "affinity"},
+			{Applications: []string{"mysql", "wordpress"}, Rule: "sometimes"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 5)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.IsNil)
+	c.Check(results.Results[2].Error, gc.IsNil)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, "expected 2 applications, got 1")
+	c.Check(results.Results[4].Error, gc.ErrorMatches, `co-location rule "sometimes" not valid`)
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"SetColocationRule", []interface{}{"wordpress", "mysql", true}},
+		{"SetColocationRule", []interface{}{"wordpress", "postgresql", false}},
+		{"RemoveColocationRule", []interface{}{"mysql", "postgresql"}},
+	})
+}
+
+func (s *ApplicationSuite) TestColocationRules(c *gc.C) {
+	result, err := s.api.ColocationRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ColocationRules{
+		Rules: []params.ColocationRule{
+			{Applications: []string{"mysql", "wordpress"}, Rule: "anti-affinity"},
+			{Applications: []string{"postgresql", "wordpress"}, Rule: "affinity"},
+		},
+	})
+}
+
 func (s *ApplicationSuite) TestDestroyUnitsCAASModel(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	_, err := s.api.DestroyUnit(params.DestroyUnitsParams{
@@ -866,7 +1354,40 @@ func (s *ApplicationSuite) TestScaleApplicationsCAASModel(c *gc.C) {
 		}},
 	})
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 0, "Scale", 5)
+	app.CheckCall(c, 1, "Scale", 5)
+	s.backend.CheckCall(c, 1, "CheckQuotas", s.model.UUID(), state.QuotaUsage{Units: 5})
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsQuotaExceeded(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.applications["postgresql"].scale = 2
+	s.backend.quotaErr = &state.QuotaExceededError{
+		Entity: `model "foo"`, Resource: "units", Limit: 5, Used: 2, Requested: 5,
+	}
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			ScaleChange:    5,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `quota exceeded: model "foo" is limited to 5 units \(using 2, requested 5\)`)
+	s.backend.CheckCall(c, 1, "CheckQuotas", s.model.UUID(), state.QuotaUsage{Units: 5})
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestScaleApplicationsDownNoQuotaCheck(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	s.backend.applications["postgresql"].scale = 5
+	s.backend.quotaErr = errors.New("should not be called")
+	results, err := s.api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: "application-postgresql",
+			Scale:          3,
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.backend.CheckCallNames(c, "Application")
 }
 
 func (s *ApplicationSuite) TestScaleApplicationsCAASModelScaleChange(c *gc.C) {
@@ -942,7 +1463,7 @@ func (s *ApplicationSuite) TestAddUnitsAttachStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	app := s.backend.applications["postgresql"]
-	app.CheckCall(c, 0, "AddUnit", state.AddUnitParams{
+	app.CheckCall(c, 3, "AddUnit", state.AddUnitParams{
 		AttachStorage: []names.StorageTag{names.NewStorageTag("pgdata/0")},
 	})
 }
@@ -1053,6 +1574,88 @@ func (s *ApplicationSuite) TestSetRelationSuspendedPermissionDenied(c *gc.C) {
 	s.relation.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestSetRelationsDrainTimeout(c *gc.C) {
+	results, err := s.api.SetRelationsDrainTimeout(params.RelationDrainTimeoutArgs{
+		Args: []params.RelationDrainTimeoutArg{{
+			RelationId: 123,
+			Timeout:    time.Minute,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.IsNil)
+	s.relation.CheckCall(c, 0, "SetDrainTimeout", time.Minute)
+	c.Assert(s.relation.drainTimeout, gc.Equals, time.Minute)
+}
+
+func (s *ApplicationSuite) TestSetRelationsDrainTimeoutNotFound(c *gc.C) {
+	results, err := s.api.SetRelationsDrainTimeout(params.RelationDrainTimeoutArgs{
+		Args: []params.RelationDrainTimeoutArg{{
+			RelationId: 456,
+			Timeout:    time.Minute,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.Satisfies, params.IsCodeNotFound)
+	s.relation.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestBlockSetRelationsDrainTimeout(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetRelationsDrainTimeout(params.RelationDrainTimeoutArgs{
+		Args: []params.RelationDrainTimeoutArg{{
+			RelationId: 123,
+			Timeout:    time.Minute,
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.relation.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestRelationData(c *gc.C) {
+	s.relation.endpoints = []state.Endpoint{{
+		ApplicationName: "wordpress",
+		Relation:        charm.Relation{Name: "db", Role: charm.RoleRequirer},
+	}, {
+		ApplicationName: "mysql",
+		Relation:        charm.Relation{Name: "db", Role: charm.RoleProvider},
+	}}
+	s.relation.unitSettings = map[string]map[string]interface{}{
+		"wordpress/0": {"database": "wordpress"},
+		"mysql/0":     {"host": "10.0.0.1", "port": 3306},
+	}
+	results, err := s.api.RelationData(params.RelationIds{RelationIds: []int{123, 456}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0], jc.DeepEquals, params.RelationDataResult{
+		Id:  123,
+		Key: "wordpress:db mysql:db",
+		Applications: []params.RelationApplicationData{{
+			Application: "wordpress",
+			Endpoint:    "db",
+			Role:        "requirer",
+			UnitData: map[string]params.Settings{
+				"wordpress/0": {"database": "wordpress"},
+			},
+		}, {
+			Application: "mysql",
+			Endpoint:    "db",
+			Role:        "provider",
+			UnitData: map[string]params.Settings{
+				"mysql/0": {"host": "10.0.0.1", "port": "3306"},
+			},
+		}},
+	})
+	c.Assert(results.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *ApplicationSuite) TestRelationDataPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.RelationData(params.RelationIds{RelationIds: []int{123}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.relation.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestConsumeIdempotent(c *gc.C) {
 	for i := 0; i < 2; i++ {
 		results, err := s.api.Consume(params.ConsumeApplicationArgs{
@@ -1446,6 +2049,8 @@ func (s *ApplicationSuite) TestSetApplicationConfig(c *gc.C) {
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, err = application.AddMaintenanceWindowSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1458,6 +2063,26 @@ func (s *ApplicationSuite) TestSetApplicationConfig(c *gc.C) {
 	c.Check(s.backend.generation, gc.IsNil)
 }
 
+func (s *ApplicationSuite) TestSetApplicationConfigMaintenanceWindow(c *gc.C) {
+	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
+		Args: []params.ApplicationConfigSet{{
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"maintenance-window": "02:00-04:00"},
+		}, {
+			ApplicationName: "postgresql",
+			Config:          map[string]string{"maintenance-window": "02:00"},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `maintenance window "02:00" \(expected HH:MM-HH:MM\) not valid`)
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "UpdateApplicationConfig")
+	c.Assert(app.Calls()[0].Args[0], jc.DeepEquals, coreapplication.ConfigAttributes{
+		"maintenance-window": "02:00-04:00",
+	})
+}
+
 func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	result, err := s.api.SetApplicationsConfig(params.ApplicationConfigSetArgs{
@@ -1478,6 +2103,8 @@ func (s *ApplicationSuite) TestSetApplicationConfigBranch(c *gc.C) {
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, err = application.AddMaintenanceWindowSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1525,6 +2152,8 @@ func (s *ApplicationSuite) TestUnsetApplicationConfig(c *gc.C) {
 	schema, err := caas.ConfigSchema(k8s.ConfigSchema())
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
+	schema, err = application.AddMaintenanceWindowSchema(schema)
+	c.Assert(err, jc.ErrorIsNil)
 	schema, defaults, err = application.AddTrustSchemaAndDefaults(schema, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1628,6 +2257,35 @@ func (s *ApplicationSuite) TestCAASExposeWithHostname(c *gc.C) {
 	app.CheckCallNames(c, "ApplicationConfig", "SetExposed")
 }
 
+func (s *ApplicationSuite) TestExposeWithLoadBalancer(c *gc.C) {
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		LoadBalancer:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.applications["postgresql"].CheckCallNames(c, "SetExposedWithLoadBalancer")
+}
+
+func (s *ApplicationSuite) TestCAASExposeWithLoadBalancer(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	err := s.api.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		LoadBalancer:    true,
+	})
+	c.Assert(err, gc.ErrorMatches, "exposing a CAAS application through a load balancer not supported")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestExposeWithLoadBalancerV16(c *gc.C) {
+	apiV16 := &application.APIv16{&application.APIv17{&application.APIv18{s.api}}}
+	err := apiV16.Expose(params.ApplicationExpose{
+		ApplicationName: "postgresql",
+		LoadBalancer:    true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.applications["postgresql"].CheckCallNames(c, "SetExposed")
+}
+
 func (s *ApplicationSuite) TestApplicationsInfoOne(c *gc.C) {
 	entities := []params.Entity{{Tag: "application-postgresql"}}
 	result, err := s.api.ApplicationsInfo(params.Entities{entities})
@@ -1698,3 +2356,259 @@ func (s *ApplicationSuite) TestApplicationsInfoMany(c *gc.C) {
 	app := s.backend.applications["postgresql"]
 	app.CheckCallNames(c, "CharmConfig", "Charm", "ApplicationConfig", "IsPrincipal", "Constraints", "Series", "Channel", "EndpointBindings", "IsPrincipal", "IsExposed", "IsRemote")
 }
+
+func (s *ApplicationSuite) TestSetRefreshPolicy(c *gc.C) {
+	result, err := s.api.SetRefreshPolicy(params.SetApplicationRefreshPolicies{
+		Policies: []params.ApplicationRefreshPolicy{{
+			ApplicationTag: "application-postgresql",
+			Policy: params.RefreshPolicy{
+				Channel:     "candidate",
+				Auto:        true,
+				WindowStart: 2 * time.Hour,
+				WindowEnd:   4 * time.Hour,
+			},
+		}, {
+			ApplicationTag: "application-wordpress",
+		}, {
+			ApplicationTag: "unit-postgresql-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "wordpress" not found`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "SetRefreshPolicy")
+	app.CheckCall(c, 0, "SetRefreshPolicy", state.RefreshPolicy{
+		Channel:     csparams.Channel("candidate"),
+		Auto:        true,
+		WindowStart: 2 * time.Hour,
+		WindowEnd:   4 * time.Hour,
+	})
+}
+
+func (s *ApplicationSuite) TestSetRefreshPolicyBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetRefreshPolicy(params.SetApplicationRefreshPolicies{
+		Policies: []params.ApplicationRefreshPolicy{{
+			ApplicationTag: "application-postgresql",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestRefreshPolicies(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.refreshPolicy = state.RefreshPolicy{
+		Channel:     csparams.Channel("candidate"),
+		Auto:        true,
+		WindowStart: 22 * time.Hour,
+		WindowEnd:   2 * time.Hour,
+		Hold:        true,
+	}
+	result, err := s.api.RefreshPolicies(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}, {Tag: "application-wordpress"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(*result.Results[0].Result, jc.DeepEquals, params.RefreshPolicy{
+		Channel:     "candidate",
+		Auto:        true,
+		WindowStart: 22 * time.Hour,
+		WindowEnd:   2 * time.Hour,
+		Hold:        true,
+	})
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "wordpress" not found`)
+}
+
+func (s *ApplicationSuite) TestPause(c *gc.C) {
+	result, err := s.api.Pause(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "unit-postgresql-0"},
+			{Tag: "application-wordpress"},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `application "wordpress" not found`)
+	c.Assert(result.Results[3].Error, gc.ErrorMatches, `unexpected tag type, expected application or unit, got machine`)
+
+	app := s.backend.applications["postgresql"]
+	app.CheckCall(c, 0, "SetPaused", true)
+	app.units[0].CheckCall(c, 0, "SetPaused", true)
+}
+
+func (s *ApplicationSuite) TestResume(c *gc.C) {
+	result, err := s.api.Resume(params.Entities{
+		Entities: []params.Entity{{Tag: "unit-postgresql-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.backend.applications["postgresql"].units[0].CheckCall(c, 0, "SetPaused", false)
+}
+
+func (s *ApplicationSuite) TestPauseBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.Pause(params.Entities{
+		Entities: []params.Entity{{Tag: "application-postgresql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestCreateShadowApplication(c *gc.C) {
+	result, err := s.api.CreateShadowApplication(params.CreateShadowApplicationArgs{
+		Args: []params.CreateShadowApplicationArg{{
+			ApplicationName: "postgresql",
+			ShadowName:      "postgresql-green",
+			CharmURL:        "cs:postgresql-2",
+			Channel:         "candidate",
+			ConfigYAML:      "postgresql:\n  stringOption: foo\n",
+		}, {
+			ApplicationName: "postgresql",
+			ShadowName:      "postgresql",
+			CharmURL:        "cs:postgresql-2",
+		}, {
+			ApplicationName: "postgresql",
+			ShadowName:      "postgresql-green",
+			CharmURL:        "cs:postgresql",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `shadow application must have a different name to "postgresql"`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `charm url must include revision`)
+
+	deployParams := s.deployParams["postgresql-green"]
+	c.Assert(deployParams.Series, gc.Equals, "quantal")
+	c.Assert(deployParams.NumUnits, gc.Equals, 2)
+	c.Assert(deployParams.Channel, gc.Equals, csparams.Channel("candidate"))
+	c.Assert(deployParams.CharmConfig, jc.DeepEquals, charm.Settings{"stringOption": "foo"})
+	c.Assert(deployParams.Constraints, jc.DeepEquals, constraints.MustParse("arch=amd64 mem=4G cores=1 root-disk=8G"))
+	// The new charm has no juju-info endpoint to bind.
+	c.Assert(deployParams.EndpointBindings, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestCreateShadowApplicationBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.CreateShadowApplication(params.CreateShadowApplicationArgs{
+		Args: []params.CreateShadowApplicationArg{{
+			ApplicationName: "postgresql",
+			ShadowName:      "postgresql-green",
+			CharmURL:        "cs:postgresql-2",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	c.Assert(s.deployParams, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) setupShadowRelations() (*mockRelation, *mockRelation) {
+	delete(s.backend.relations, 123)
+	s.backend.applications["postgresql-green"] = &mockApplication{name: "postgresql-green"}
+	app := s.backend.applications["postgresql"]
+	app.endpoints = []state.Endpoint{
+		{ApplicationName: "postgresql", Relation: charm.Relation{Name: "db", Role: charm.RoleProvider}},
+		{ApplicationName: "postgresql", Relation: charm.Relation{Name: "replicas", Role: charm.RolePeer}},
+	}
+	rel := &mockRelation{
+		tag: names.NewRelationTag("wordpress:db postgresql:db"),
+		endpoints: []state.Endpoint{
+			{ApplicationName: "wordpress", Relation: charm.Relation{Name: "db", Role: charm.RoleRequirer}},
+			{ApplicationName: "postgresql", Relation: charm.Relation{Name: "db", Role: charm.RoleProvider}},
+		},
+	}
+	peer := &mockRelation{
+		tag: names.NewRelationTag("postgresql:replicas"),
+		endpoints: []state.Endpoint{
+			{ApplicationName: "postgresql", Relation: charm.Relation{Name: "replicas", Role: charm.RolePeer}},
+		},
+	}
+	app.relations = []*mockRelation{rel, peer}
+	return rel, peer
+}
+
+func (s *ApplicationSuite) TestMigrateRelations(c *gc.C) {
+	rel, peer := s.setupShadowRelations()
+	result, err := s.api.MigrateRelations(params.MigrateRelationsArgs{
+		Args: []params.MigrateRelationsArg{{
+			ApplicationName: "postgresql",
+			ShadowName:      "postgresql-green",
+			Endpoints:       []string{"db"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	s.backend.CheckCallNames(c, "Application", "Application", "InferEndpoints", "EndpointsRelation", "AddRelation")
+	s.backend.CheckCall(c, 2, "InferEndpoints", []string{"postgresql-green:db", "wordpress:db"})
+	rel.CheckCallNames(c, "Endpoints", "Destroy")
+	peer.CheckCallNames(c, "Endpoints")
+}
+
+func (s *ApplicationSuite) TestMigrateRelationsExisting(c *gc.C) {
+	rel, _ := s.setupShadowRelations()
+	s.backend.relations[123] = &s.relation
+	result, err := s.api.MigrateRelations(params.MigrateRelationsArgs{
+		Args: []params.MigrateRelationsArg{{
+			ApplicationName: "postgresql",
+			ShadowName:      "postgresql-green",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Application", "Application", "InferEndpoints", "EndpointsRelation")
+	rel.CheckCallNames(c, "Endpoints", "Destroy")
+}
+
+func (s *ApplicationSuite) TestMigrateRelationsUnknownEndpoint(c *gc.C) {
+	s.setupShadowRelations()
+	result, err := s.api.MigrateRelations(params.MigrateRelationsArgs{
+		Args: []params.MigrateRelationsArg{{
+			ApplicationName: "postgresql",
+			ShadowName:      "postgresql-green",
+			Endpoints:       []string{"db", "website"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `endpoints website of application "postgresql" not found`)
+}
+
+func (s *ApplicationSuite) TestFinaliseShadowApplication(c *gc.C) {
+	_, peer := s.setupShadowRelations()
+	app := s.backend.applications["postgresql"]
+	app.relations = []*mockRelation{peer}
+	result, err := s.api.FinaliseShadowApplication(params.FinaliseShadowApplicationArgs{
+		Args: []params.FinaliseShadowApplicationArg{{
+			ApplicationName: "postgresql",
+			ShadowName:      "postgresql-green",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	app.CheckCallNames(c, "Relations", "Destroy")
+}
+
+func (s *ApplicationSuite) TestFinaliseShadowApplicationRemainingRelations(c *gc.C) {
+	s.setupShadowRelations()
+	result, err := s.api.FinaliseShadowApplication(params.FinaliseShadowApplicationArgs{
+		Args: []params.FinaliseShadowApplicationArg{{
+			ApplicationName: "postgresql",
+			ShadowName:      "postgresql-green",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches,
+		`application "postgresql" still has relations \(wordpress:db postgresql:db\), migrate them to "postgresql-green" first`)
+	s.backend.applications["postgresql"].CheckCallNames(c, "Relations")
+}
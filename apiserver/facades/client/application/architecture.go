@@ -0,0 +1,138 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/binaryarch"
+	"github.com/juju/juju/resource"
+)
+
+// checkDeployArchitecture returns an error if the arch constraint of
+// the application being deployed rules out the binaries in its charm,
+// or in any of the file resources uploaded for it.
+func checkDeployArchitecture(backend Backend, ch Charm, curl *charm.URL, args params.ApplicationDeploy) error {
+	if !args.Constraints.HasArch() {
+		return nil
+	}
+	arch := *args.Constraints.Arch
+	if err := checkCharmArchitecture(ch, curl, arch); err != nil {
+		return errors.Trace(err)
+	}
+	if len(args.Resources) == 0 {
+		return nil
+	}
+	resources, err := backend.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for name, pendingID := range args.Resources {
+		res, r, err := resources.OpenPendingResource(args.ApplicationName, name, pendingID)
+		if errors.IsNotFound(err) || errors.IsNotSupported(err) {
+			// Resources that haven't been uploaded are fetched
+			// from the charm store, and container images aren't
+			// binaries.
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		err = checkResourceArchitecture(res, r, arch)
+		r.Close()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// checkApplicationArchitecture returns an error if the application's
+// arch constraint rules out the binaries in its charm, or in any of its
+// file resources, so that units added to it could not run them.
+func checkApplicationArchitecture(backend Backend, app Application, appName string) error {
+	cons, err := app.Constraints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !cons.HasArch() {
+		return nil
+	}
+	arch := *cons.Arch
+	ch, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	curl, _ := app.CharmURL()
+	if err := checkCharmArchitecture(ch, curl, arch); err != nil {
+		return errors.Trace(err)
+	}
+	resources, err := backend.Resources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appResources, err := resources.ListResources(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, res := range appResources.Resources {
+		if res.Type != charmresource.TypeFile {
+			continue
+		}
+		_, r, err := resources.OpenResource(appName, res.Name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		err = checkResourceArchitecture(res, r, arch)
+		r.Close()
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// checkCharmArchitecture returns an error if the charm contains
+// binaries, none of which are built for the given architecture.
+func checkCharmArchitecture(ch Charm, curl *charm.URL, arch string) error {
+	arches, err := ch.Architectures()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(arches) == 0 {
+		return nil
+	}
+	for _, a := range arches {
+		if a == arch {
+			return nil
+		}
+	}
+	return errors.Errorf(
+		"charm %q only contains binaries for %s, not %s",
+		curl, strings.Join(arches, ", "), arch,
+	)
+}
+
+// checkResourceArchitecture returns an error if the resource read from
+// r is a binary built for an architecture other than the given one.
+func checkResourceArchitecture(res resource.Resource, r io.Reader, arch string) error {
+	resourceArch, err := binaryarch.Read(r)
+	if err != nil {
+		return errors.Annotatef(err, "reading resource %q", res.Name)
+	}
+	if resourceArch == "" || resourceArch == arch {
+		return nil
+	}
+	return errors.Errorf(
+		"resource %q is a binary for %s, not %s",
+		res.Name, resourceArch, arch,
+	)
+}
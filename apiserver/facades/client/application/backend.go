@@ -4,6 +4,10 @@
 package application
 
 import (
+	"io"
+	"time"
+
+	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6"
@@ -17,9 +21,13 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
+	providercommon "github.com/juju/juju/provider/common"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/tools"
 )
 
@@ -30,13 +38,18 @@ type Backend interface {
 	AllModelUUIDs() ([]string, error)
 	Application(string) (Application, error)
 	ApplyOperation(state.ModelOperation) error
+	AssignUnits(state.AssignUnitsArgs) ([]state.UnitPlacement, error)
 	AddApplication(state.AddApplicationArgs) (Application, error)
 	RemoteApplication(string) (RemoteApplication, error)
 	AddRemoteApplication(state.AddRemoteApplicationParams) (RemoteApplication, error)
 	AddRelation(...state.Endpoint) (Relation, error)
 	Charm(*charm.URL) (Charm, error)
+	CheckQuotas(string, state.QuotaUsage) error
+	ColocationRules() ([]state.ColocationRule, error)
 	EndpointsRelation(...state.Endpoint) (Relation, error)
 	Relation(int) (Relation, error)
+	RemoveColocationRule(string, string) error
+	SetColocationRule(string, string, bool) error
 	InferEndpoints(...string) ([]state.Endpoint, error)
 	Machine(string) (Machine, error)
 	Unit(string) (Unit, error)
@@ -45,8 +58,14 @@ type Backend interface {
 	ControllerTag() names.ControllerTag
 	Resources() (Resources, error)
 	OfferConnectionForRelation(string) (OfferConnection, error)
+	OfferConnectionCounts(string) (map[string]int, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
 	Branch(string) (Generation, error)
+
+	// AvailabilityZones returns the names of the availability zones
+	// known to the model's provider. If the provider does not support
+	// zones, an error satisfying errors.IsNotSupported is returned.
+	AvailabilityZones() ([]string, error)
 }
 
 // BlockChecker defines the block-checking functionality required by
@@ -67,6 +86,7 @@ type Application interface {
 	ApplicationConfig() (application.ConfigAttributes, error)
 	Charm() (Charm, bool, error)
 	CharmURL() (*charm.URL, bool)
+	CheckCharmCompatibility(*state.Charm) error
 	Channel() csparams.Channel
 	ClearExposed() error
 	CharmConfig(string) (charm.Settings, error)
@@ -82,12 +102,18 @@ type Application interface {
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
 	SetExposed() error
+	SetExposedWithLoadBalancer() error
 	SetCharmProfile(string) error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	RefreshPolicy() state.RefreshPolicy
+	Relations() ([]Relation, error)
+	SetRefreshPolicy(state.RefreshPolicy) error
+	SetPaused(bool) error
 	UpdateApplicationSeries(string, bool) error
 	UpdateCharmConfig(string, charm.Settings) error
 	UpdateApplicationConfig(application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
+	GetScale() int
 	SetScale(int) error
 	ChangeScale(int) (int, error)
 	AgentTools() (*tools.Tools, error)
@@ -99,6 +125,7 @@ type Application interface {
 // the same names.
 type Charm interface {
 	charm.Charm
+	Architectures() ([]string, error)
 }
 
 // Machine defines a subset of the functionality provided by the
@@ -106,6 +133,11 @@ type Charm interface {
 // details on the methods, see the methods on state.Machine with
 // the same names.
 type Machine interface {
+	Id() string
+	Principals() []string
+	Containers() ([]string, error)
+	IsManager() bool
+	HasVote() bool
 	IsLockedForSeriesUpgrade() (bool, error)
 	IsParentLockedForSeriesUpgrade() (bool, error)
 	UpgradeCharmProfileComplete(string) (string, error)
@@ -121,9 +153,12 @@ type Relation interface {
 	Tag() names.Tag
 	Destroy() error
 	Endpoint(string) (state.Endpoint, error)
+	Endpoints() []state.Endpoint
+	SetDrainTimeout(time.Duration) error
 	SetSuspended(bool, string) error
 	Suspended() bool
 	SuspendedReason() string
+	UnitSettings() (map[string]map[string]interface{}, error)
 }
 
 // Unit defines a subset of the functionality provided by the
@@ -140,6 +175,7 @@ type Unit interface {
 	Life() state.Life
 	Resolve(retryHooks bool) error
 	AgentTools() (*tools.Tools, error)
+	SetPaused(bool) error
 
 	AssignedMachineId() (string, error)
 	AssignWithPolicy(state.AssignmentPolicy) error
@@ -165,6 +201,9 @@ type Model interface {
 // the state.Resources type for details on the methods.
 type Resources interface {
 	RemovePendingAppResources(string, map[string]string) error
+	ListResources(string) (resource.ApplicationResources, error)
+	OpenResource(string, string) (resource.Resource, io.ReadCloser, error)
+	OpenPendingResource(string, string, string) (resource.Resource, io.ReadCloser, error)
 }
 
 type Generation interface {
@@ -351,6 +390,26 @@ func (s stateShim) OfferConnectionForRelation(key string) (OfferConnection, erro
 	return s.State.OfferConnectionForRelation(key)
 }
 
+// OfferConnectionCounts returns the number of consumer connections
+// to each offer of the named application, keyed by offer name.
+func (s stateShim) OfferConnectionCounts(appName string) (map[string]int, error) {
+	offers, err := state.NewApplicationOffers(s.State).ListOffers(
+		crossmodel.ApplicationOfferFilter{ApplicationName: appName},
+	)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, offer := range offers {
+		conns, err := s.State.OfferConnections(offer.OfferUUID)
+		if err != nil {
+			return nil, err
+		}
+		counts[offer.OfferName] = len(conns)
+	}
+	return counts, nil
+}
+
 func (s stateShim) Branch(name string) (Generation, error) {
 	gen, err := s.State.Branch(name)
 	if err != nil {
@@ -359,6 +418,28 @@ func (s stateShim) Branch(name string) (Generation, error) {
 	return Generation(gen), nil
 }
 
+func (s stateShim) AvailabilityZones() ([]string, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(s.State)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening environment")
+	}
+	zonedEnv, ok := env.(providercommon.ZonedEnviron)
+	if !ok {
+		return nil, errors.NotSupportedf("availability zones")
+	}
+	zones, err := zonedEnv.AvailabilityZones(state.CallContext(s.State))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var names []string
+	for _, zone := range zones {
+		if zone.Available() {
+			names = append(names, zone.Name())
+		}
+	}
+	return names, nil
+}
+
 type stateApplicationShim struct {
 	*state.Application
 	st *state.State
@@ -392,6 +473,18 @@ func (a stateApplicationShim) AllUnits() ([]Unit, error) {
 	return out, nil
 }
 
+func (a stateApplicationShim) Relations() ([]Relation, error) {
+	relations, err := a.Application.Relations()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Relation, len(relations))
+	for i, rel := range relations {
+		out[i] = stateRelationShim{rel}
+	}
+	return out, nil
+}
+
 type stateCharmShim struct {
 	*state.Charm
 }
@@ -0,0 +1,326 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/model"
+)
+
+// A blue/green deployment replaces an application with a shadow
+// application running a new charm revision, without interrupting the
+// applications related to it:
+//  1. CreateShadowApplication deploys the shadow alongside the
+//     application, with the same settings, constraints and bindings.
+//  2. MigrateRelations moves the application's relations to the
+//     shadow, one endpoint at a time if need be.
+//  3. FinaliseShadowApplication removes the application once all of
+//     its relations have been moved.
+//
+// Application names cannot be changed, so the shadow keeps its own name.
+
+// CreateShadowApplication isn't on the v13 API.
+func (u *APIv13) CreateShadowApplication(_, _ struct{}) {}
+
+// MigrateRelations isn't on the v13 API.
+func (u *APIv13) MigrateRelations(_, _ struct{}) {}
+
+// FinaliseShadowApplication isn't on the v13 API.
+func (u *APIv13) FinaliseShadowApplication(_, _ struct{}) {}
+
+// CreateShadowApplication creates shadow applications, as the first
+// step of blue/green deployments.
+func (api *APIBase) CreateShadowApplication(args params.CreateShadowApplicationArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.createShadowApplication(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *APIBase) createShadowApplication(arg params.CreateShadowApplicationArg) error {
+	if !names.IsValidApplication(arg.ShadowName) {
+		return errors.NotValidf("shadow application name %q", arg.ShadowName)
+	}
+	if arg.ShadowName == arg.ApplicationName {
+		return errors.Errorf("shadow application must have a different name to %q", arg.ApplicationName)
+	}
+	app, err := api.backend.Application(arg.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	curl, err := charm.ParseURL(arg.CharmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if curl.Revision < 0 {
+		return errors.Errorf("charm url must include revision")
+	}
+	ch, err := api.backend.Charm(curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkMinVersion(ch); err != nil {
+		return errors.Trace(err)
+	}
+	currentCharm, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// Copy over the settings changed from the current charm's defaults
+	// that the new charm still has, so that the shadow picks up any new
+	// defaults.
+	currentSettings, err := app.CharmConfig(model.GenerationMaster)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	settings := make(charm.Settings)
+	for name, value := range currentSettings {
+		if _, ok := ch.Config().Options[name]; !ok {
+			continue
+		}
+		if option, ok := currentCharm.Config().Options[name]; ok && option.Default == value {
+			continue
+		}
+		settings[name] = value
+	}
+	if arg.ConfigYAML != "" {
+		overrides, err := ch.Config().ParseSettingsYAML([]byte(arg.ConfigYAML), arg.ApplicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for name, value := range overrides {
+			settings[name] = value
+		}
+	}
+
+	appConfigAttrs, err := app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	schema, defaults, err := applicationConfigSchema(api.modelType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	appConfig, err := application.NewConfig(appConfigAttrs, schema, defaults)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cons, err := app.Constraints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	currentBindings, err := app.EndpointBindings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	bindings := make(map[string]string)
+	for endpoint, space := range currentBindings {
+		if endpoint == "" || hasEndpoint(ch.Meta(), endpoint) {
+			bindings[endpoint] = space
+		}
+	}
+
+	numUnits := 0
+	if arg.NumUnits != nil {
+		numUnits = *arg.NumUnits
+	} else if !ch.Meta().Subordinate {
+		units, err := app.AllUnits()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		numUnits = len(units)
+	}
+
+	_, err = api.deployApplicationFunc(api.backend, DeployApplicationParams{
+		ApplicationName:   arg.ShadowName,
+		Series:            app.Series(),
+		Charm:             api.stateCharm(ch),
+		Channel:           csparams.Channel(arg.Channel),
+		NumUnits:          numUnits,
+		ApplicationConfig: appConfig,
+		CharmConfig:       settings,
+		Constraints:       cons,
+		EndpointBindings:  bindings,
+	})
+	return errors.Trace(err)
+}
+
+// hasEndpoint reports whether the charm has an endpoint, or extra
+// binding, with the given name.
+func hasEndpoint(meta *charm.Meta, name string) bool {
+	if _, ok := meta.Provides[name]; ok {
+		return true
+	}
+	if _, ok := meta.Requires[name]; ok {
+		return true
+	}
+	if _, ok := meta.Peers[name]; ok {
+		return true
+	}
+	_, ok := meta.ExtraBindings[name]
+	return ok
+}
+
+// MigrateRelations moves relations of applications to their shadow
+// applications. Each relation is added for the shadow before it is
+// removed from the application, so the related applications are never
+// left without a counterpart.
+func (api *APIBase) MigrateRelations(args params.MigrateRelationsArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.migrateRelations(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *APIBase) migrateRelations(arg params.MigrateRelationsArg) error {
+	app, err := api.backend.Application(arg.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := api.backend.Application(arg.ShadowName); err != nil {
+		return errors.Trace(err)
+	}
+
+	endpoints := set.NewStrings(arg.Endpoints...)
+	appEndpoints, err := app.Endpoints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	known := set.NewStrings()
+	for _, ep := range appEndpoints {
+		known.Add(ep.Name)
+	}
+	if unknown := endpoints.Difference(known); !unknown.IsEmpty() {
+		return errors.NotFoundf("endpoints %s of application %q", strings.Join(unknown.SortedValues(), ", "), arg.ApplicationName)
+	}
+
+	relations, err := app.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, rel := range relations {
+		eps := rel.Endpoints()
+		if len(eps) != 2 {
+			// Peer relations are established for the
+			// shadow application when it is deployed.
+			continue
+		}
+		own, other := eps[0], eps[1]
+		if other.ApplicationName == arg.ApplicationName {
+			own, other = other, own
+		}
+		if !endpoints.IsEmpty() && !endpoints.Contains(own.Name) {
+			continue
+		}
+		if err := api.moveRelation(rel, arg.ShadowName+":"+own.Name, other.String()); err != nil {
+			return errors.Annotatef(err, "moving relation %q", rel.Tag().Id())
+		}
+	}
+	return nil
+}
+
+// moveRelation replaces the relation with one between the given
+// endpoints, unless that relation already exists.
+func (api *APIBase) moveRelation(rel Relation, endpoints ...string) error {
+	eps, err := api.backend.InferEndpoints(endpoints...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := api.backend.EndpointsRelation(eps...); errors.IsNotFound(err) {
+		if _, err := api.backend.AddRelation(eps...); err != nil {
+			return errors.Trace(err)
+		}
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(rel.Destroy())
+}
+
+// FinaliseShadowApplication completes blue/green deployments, by
+// removing the applications that have been replaced by their shadow
+// applications.
+func (api *APIBase) FinaliseShadowApplication(args params.FinaliseShadowApplicationArgs) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	cfg, err := api.model.ModelConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := common.CheckModelNotProtected(cfg); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.finaliseShadowApplication(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *APIBase) finaliseShadowApplication(arg params.FinaliseShadowApplicationArg) error {
+	if _, err := api.backend.Application(arg.ShadowName); err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(arg.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	relations, err := app.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var remaining []string
+	for _, rel := range relations {
+		if len(rel.Endpoints()) == 2 {
+			remaining = append(remaining, rel.Tag().Id())
+		}
+	}
+	if len(remaining) > 0 {
+		return errors.Errorf(
+			"application %q still has relations (%s), migrate them to %q first",
+			arg.ApplicationName, strings.Join(remaining, ", "), arg.ShadowName,
+		)
+	}
+	return errors.Trace(app.Destroy())
+}
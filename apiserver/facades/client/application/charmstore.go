@@ -20,6 +20,7 @@ import (
 	"gopkg.in/macaroon.v2-unstable"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/lxdprofile"
 	"github.com/juju/juju/environs/config"
@@ -207,10 +208,26 @@ func AddCharmWithAuthorization(st State, args params.AddCharmWithAuthorization)
 			return nil, errors.Trace(err)
 		}
 		repo = config.SpecializeCharmRepo(repo, modelConfig).(*charmrepo.CharmStore)
-		return repo, nil
+		return withLocalRepository(repo, controllerCfg), nil
 	})
 }
 
+// withLocalRepository returns a repository that falls back to the
+// controller's local charm repository, if one is configured, when the
+// given repository cannot resolve or provide a charm.
+func withLocalRepository(repo charmrepo.Interface, controllerCfg controller.Config) charmrepo.Interface {
+	path := controllerCfg.LocalCharmRepository()
+	if path == "" {
+		return repo
+	}
+	local, err := charmstore.NewLocalRepository(path)
+	if err != nil {
+		logger.Warningf("cannot use local charm repository: %v", err)
+		return repo
+	}
+	return charmstore.NewFallbackRepository(repo, local)
+}
+
 func openCSRepo(csURL string, args params.AddCharmWithAuthorization) (charmrepo.Interface, error) {
 	csClient, err := openCSClient(csURL, args)
 	if err != nil {
@@ -363,9 +380,9 @@ func ResolveCharms(st State, args params.ResolveCharms) (params.ResolveCharmResu
 	csParams := csclient.Params{
 		URL: controllerCfg.CharmStoreURL(),
 	}
-	repo := config.SpecializeCharmRepo(
+	repo := withLocalRepository(config.SpecializeCharmRepo(
 		NewCharmStoreRepo(csclient.New(csParams)),
-		envConfig)
+		envConfig), controllerCfg)
 
 	for _, ref := range args.References {
 		result := params.ResolveCharmResult{}
@@ -5,21 +5,26 @@ package application_test
 
 import (
 	"fmt"
+	"path/filepath"
 
 	"github.com/golang/mock/gomock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	charm "gopkg.in/juju/charm.v6"
 	charmrepo "gopkg.in/juju/charmrepo.v3"
+	"gopkg.in/juju/charmrepo.v3/csclient"
 	"gopkg.in/mgo.v2"
 
-	"github.com/juju/testing"
-
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/facades/client/application/mocks"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testcharms"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type CharmStoreSuite struct {
@@ -261,6 +266,39 @@ func (s *CharmStoreSuite) TestAddVersionedCharmWithAuthorization(c *gc.C) {
 	c.Assert(err, gc.IsNil)
 }
 
+func (s *CharmStoreSuite) TestResolveCharmsFallsBackToLocalRepository(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	repoDir := c.MkDir()
+	testcharms.Repo.ClonedDirPath(filepath.Join(repoDir, "bionic"), "dummy")
+
+	mockState := mocks.NewMockState(ctrl)
+	mockModel := mocks.NewMockStateModel(ctrl)
+	mockInterface := mocks.NewMockInterface(ctrl)
+	s.PatchValue(&application.NewCharmStoreRepo, func(*csclient.Client) charmrepo.Interface {
+		return mockInterface
+	})
+
+	sExp := mockState.EXPECT()
+	sExp.Model().Return(mockModel, nil)
+	sExp.ControllerConfig().Return(controller.Config{
+		controller.CharmStoreURL:        "https://api.jujucharms.com/charmstore",
+		controller.LocalCharmRepository: repoDir,
+	}, nil)
+	mockModel.EXPECT().ModelConfig().Return(coretesting.ModelConfig(c), nil)
+	mockInterface.EXPECT().Resolve(gomock.Any()).Return(nil, nil, errors.New("cannot reach charm store")).Times(2)
+
+	results, err := application.ResolveCharms(mockState, params.ResolveCharms{
+		References: []string{"cs:dummy", "cs:mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.URLs, gc.HasLen, 2)
+	c.Check(results.URLs[0].Error, gc.Equals, "")
+	c.Check(results.URLs[0].URL, gc.Equals, "cs:bionic/dummy")
+	c.Check(results.URLs[1].Error, gc.Equals, "cannot reach charm store")
+}
+
 type charmVersionMatcher struct {
 	expVersion string
 }
@@ -6,15 +6,16 @@ package application
 import "github.com/juju/juju/state"
 
 var (
-	ParseSettingsCompatible = parseSettingsCompatible
-	NewStateStorage         = &newStateStorage
-	GetStorageState         = getStorageState
+	ParseSettingsCompatible    = parseSettingsCompatible
+	NewStateStorage            = &newStateStorage
+	GetStorageState            = getStorageState
+	AddMaintenanceWindowSchema = addMaintenanceWindowSchema
 )
 
 func GetState(st *state.State) Backend {
 	return stateShim{st}
 }
 
-func SetModelType(api *APIv9, modelType state.ModelType) {
+func SetModelType(api *APIv20, modelType state.ModelType) {
 	api.modelType = modelType
 }
@@ -58,7 +58,7 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv9{api}
+	s.applicationAPI = &application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{&application.APIv17{&application.APIv18{&application.APIv19{&application.APIv20{api}}}}}}}}}}}}
 }
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
@@ -120,6 +120,11 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmokeTest(c *gc.C) {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"maintenance-window": map[string]interface{}{
+				"description": "Overrides the model maintenance-window for this application",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
 			"trust": map[string]interface{}{
 				"default":     false,
 				"description": "Does this application have access to trusted credentials",
@@ -142,6 +147,8 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
 
+	schemaFields, err = application.AddMaintenanceWindowSchema(schemaFields)
+	c.Assert(err, jc.ErrorIsNil)
 	schemaFields, defaults, err = application.AddTrustSchemaAndDefaults(schemaFields, defaults)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -199,7 +206,7 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	apiV8 := &application.APIv8{&application.APIv9{api}}
+	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{&application.APIv17{&application.APIv18{&application.APIv19{&application.APIv20{api}}}}}}}}}}}}}
 
 	results, err := apiV8.Get(params.ApplicationGet{ApplicationName: "dashboard4miner"})
 	c.Assert(err, jc.ErrorIsNil)
@@ -272,6 +279,11 @@ var getTests = []struct {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"maintenance-window": map[string]interface{}{
+				"description": "Overrides the model maintenance-window for this application",
+				"source":      "unset",
+				"type":        "string",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -330,6 +342,11 @@ var getTests = []struct {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"maintenance-window": map[string]interface{}{
+				"description": "Overrides the model maintenance-window for this application",
+				"source":      "unset",
+				"type":        "string",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -347,6 +364,11 @@ var getTests = []struct {
 		CharmConfig: map[string]interface{}{},
 		Series:      "quantal",
 		ApplicationConfig: map[string]interface{}{
+			"maintenance-window": map[string]interface{}{
+				"description": "Overrides the model maintenance-window for this application",
+				"source":      "unset",
+				"type":        "string",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -0,0 +1,62 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/maintenance"
+	"github.com/juju/juju/environs/config"
+)
+
+// MaintenanceWindowConfigOptionName is the option name used to set the
+// application's maintenance window in application configuration.
+const MaintenanceWindowConfigOptionName = "maintenance-window"
+
+var maintenanceWindowFields = environschema.Fields{
+	MaintenanceWindowConfigOptionName: {
+		Description: "Overrides the model maintenance-window for this application",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+// addMaintenanceWindowSchema adds the maintenance window schema fields
+// to an existing set of schema fields.
+func addMaintenanceWindowSchema(extra environschema.Fields) (environschema.Fields, error) {
+	fields := make(environschema.Fields)
+	for name, field := range maintenanceWindowFields {
+		fields[name] = field
+	}
+	for name, field := range extra {
+		if _, ok := maintenanceWindowFields[name]; ok {
+			return nil, errors.Errorf("config field %q clashes with common config", name)
+		}
+		fields[name] = field
+	}
+	return fields, nil
+}
+
+// validateMaintenanceWindow returns an error if the application config
+// holds a maintenance window that is not valid.
+func validateMaintenanceWindow(attrs map[string]interface{}) error {
+	v, _ := attrs[MaintenanceWindowConfigOptionName].(string)
+	_, err := maintenance.ParseWindow(v)
+	return errors.Trace(err)
+}
+
+// MaintenanceWindow returns the window within which automatic
+// operations may take place for an application: the application's own
+// maintenance window if it has one, and otherwise the model's.
+func MaintenanceWindow(appConfig application.ConfigAttributes, modelConfig *config.Config) maintenance.Window {
+	if v := appConfig.GetString(MaintenanceWindowConfigOptionName, ""); v != "" {
+		// Value has already been validated.
+		if w, err := maintenance.ParseWindow(v); err == nil {
+			return w
+		}
+	}
+	return modelConfig.MaintenanceWindow()
+}
@@ -4,9 +4,12 @@
 package application_test
 
 import (
+	"fmt"
 	"io"
+	"io/ioutil"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -31,6 +34,7 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/storage"
@@ -62,6 +66,7 @@ type mockCharm struct {
 	config     *charm.Config
 	meta       *charm.Meta
 	lxdProfile *charm.LXDProfile
+	arches     []string
 }
 
 func (c *mockCharm) Meta() *charm.Meta {
@@ -80,6 +85,11 @@ func (c *mockCharm) LXDProfile() *charm.LXDProfile {
 	return c.lxdProfile
 }
 
+func (c *mockCharm) Architectures() ([]string, error) {
+	c.MethodCall(c, "Architectures")
+	return c.arches, nil
+}
+
 type mockApplication struct {
 	jtesting.Stub
 	application.Application
@@ -101,6 +111,8 @@ type mockApplication struct {
 	exposed                  bool
 	remote                   bool
 	agentTools               *tools.Tools
+	refreshPolicy            state.RefreshPolicy
+	relations                []*mockRelation
 }
 
 func (m *mockApplication) Name() string {
@@ -165,6 +177,11 @@ func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) CheckCharmCompatibility(ch *state.Charm) error {
+	a.MethodCall(a, "CheckCharmCompatibility", ch)
+	return a.NextErr()
+}
+
 func (a *mockApplication) DestroyOperation() *state.DestroyApplicationOperation {
 	a.MethodCall(a, "DestroyOperation")
 	return &state.DestroyApplicationOperation{}
@@ -241,6 +258,40 @@ func (a *mockApplication) SetExposed() error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) SetExposedWithLoadBalancer() error {
+	a.MethodCall(a, "SetExposedWithLoadBalancer")
+	return a.NextErr()
+}
+
+func (a *mockApplication) RefreshPolicy() state.RefreshPolicy {
+	a.MethodCall(a, "RefreshPolicy")
+	return a.refreshPolicy
+}
+
+func (a *mockApplication) SetRefreshPolicy(policy state.RefreshPolicy) error {
+	a.MethodCall(a, "SetRefreshPolicy", policy)
+	return a.NextErr()
+}
+
+func (a *mockApplication) Relations() ([]application.Relation, error) {
+	a.MethodCall(a, "Relations")
+	relations := make([]application.Relation, len(a.relations))
+	for i, rel := range a.relations {
+		relations[i] = rel
+	}
+	return relations, a.NextErr()
+}
+
+func (a *mockApplication) Destroy() error {
+	a.MethodCall(a, "Destroy")
+	return a.NextErr()
+}
+
+func (a *mockApplication) SetPaused(paused bool) error {
+	a.MethodCall(a, "SetPaused", paused)
+	return a.NextErr()
+}
+
 func (a *mockApplication) WatchLXDProfileUpgradeNotifications() (state.NotifyWatcher, error) {
 	a.MethodCall(a, "WatchLXDProfileUpgradeNotifications")
 	return &mockNotifyWatcher{ch: a.lxdProfileUpgradeChanges}, a.NextErr()
@@ -339,12 +390,53 @@ type mockBackend struct {
 	endpoints                  *[]state.Endpoint
 	relations                  map[int]*mockRelation
 	offerConnections           map[string]application.OfferConnection
+	offerConnectionCounts      map[string]map[string]int
 	unitStorageAttachments     map[string][]state.StorageAttachment
 	storageInstances           map[string]*mockStorage
 	storageInstanceFilesystems map[string]*mockFilesystem
 	controllers                map[string]crossmodel.ControllerInfo
 	machines                   map[string]*mockMachine
 	generation                 *mockGeneration
+	quotaErr                   error
+	resources                  mockResources
+}
+
+type mockResources struct {
+	jtesting.Stub
+	application.Resources
+
+	resources []resource.Resource
+	content   map[string]string
+}
+
+func (m *mockResources) RemovePendingAppResources(applicationID string, pendingIDs map[string]string) error {
+	m.MethodCall(m, "RemovePendingAppResources", applicationID, pendingIDs)
+	return m.NextErr()
+}
+
+func (m *mockResources) ListResources(applicationID string) (resource.ApplicationResources, error) {
+	m.MethodCall(m, "ListResources", applicationID)
+	return resource.ApplicationResources{Resources: m.resources}, nil
+}
+
+func (m *mockResources) OpenResource(applicationID, name string) (resource.Resource, io.ReadCloser, error) {
+	m.MethodCall(m, "OpenResource", applicationID, name)
+	return m.open(name)
+}
+
+func (m *mockResources) OpenPendingResource(applicationID, name, pendingID string) (resource.Resource, io.ReadCloser, error) {
+	m.MethodCall(m, "OpenPendingResource", applicationID, name, pendingID)
+	return m.open(name)
+}
+
+func (m *mockResources) open(name string) (resource.Resource, io.ReadCloser, error) {
+	content, ok := m.content[name]
+	if !ok {
+		return resource.Resource{}, nil, errors.NotFoundf("resource %q", name)
+	}
+	res := resource.Resource{}
+	res.Name = name
+	return res, ioutil.NopCloser(strings.NewReader(content)), nil
 }
 
 type mockFilesystemAccess struct {
@@ -368,6 +460,10 @@ func (m *mockBackend) GetBlockForType(t state.BlockType) (state.Block, bool, err
 	return nil, false, nil
 }
 
+func (m *mockBackend) Resources() (application.Resources, error) {
+	return &m.resources, nil
+}
+
 func (m *mockBackend) Charm(curl *charm.URL) (application.Charm, error) {
 	m.MethodCall(m, "Charm", curl)
 	if err := m.NextErr(); err != nil {
@@ -407,6 +503,11 @@ func (m *mockBackend) UnitsInError() ([]application.Unit, error) {
 	}, nil
 }
 
+func (m *mockBackend) CheckQuotas(modelUUID string, add state.QuotaUsage) error {
+	m.MethodCall(m, "CheckQuotas", modelUUID, add)
+	return m.quotaErr
+}
+
 func (m *mockBackend) Machine(id string) (application.Machine, error) {
 	m.MethodCall(m, "Machine", id)
 	for machineId, machine := range m.machines {
@@ -474,6 +575,29 @@ type mockMachine struct {
 
 	id                          string
 	upgradeCharmProfileComplete string
+	principals                  []string
+	containers                  []string
+	manager                     bool
+}
+
+func (m *mockMachine) Principals() []string {
+	m.MethodCall(m, "Principals")
+	return m.principals
+}
+
+func (m *mockMachine) Containers() ([]string, error) {
+	m.MethodCall(m, "Containers")
+	return m.containers, m.NextErr()
+}
+
+func (m *mockMachine) IsManager() bool {
+	m.MethodCall(m, "IsManager")
+	return m.manager
+}
+
+func (m *mockMachine) HasVote() bool {
+	m.MethodCall(m, "HasVote")
+	return false
 }
 
 func (m *mockMachine) IsLockedForSeriesUpgrade() (bool, error) {
@@ -523,6 +647,14 @@ func (m *mockBackend) EndpointsRelation(endpoints ...state.Endpoint) (applicatio
 	return nil, errors.NotFoundf("relation")
 }
 
+func (m *mockBackend) AddRelation(endpoints ...state.Endpoint) (application.Relation, error) {
+	m.MethodCall(m, "AddRelation", endpoints)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return &mockRelation{}, nil
+}
+
 func (m *mockBackend) Relation(id int) (application.Relation, error) {
 	m.MethodCall(m, "Relation", id)
 	if err := m.NextErr(); err != nil {
@@ -549,6 +681,14 @@ func (m *mockBackend) OfferConnectionForRelation(key string) (application.OfferC
 	return nil, errors.NotFoundf("offer connection for relation")
 }
 
+func (m *mockBackend) OfferConnectionCounts(appName string) (map[string]int, error) {
+	m.MethodCall(m, "OfferConnectionCounts", appName)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.offerConnectionCounts[appName], nil
+}
+
 func (m *mockBackend) UnitStorageAttachments(tag names.UnitTag) ([]state.StorageAttachment, error) {
 	m.MethodCall(m, "UnitStorageAttachments", tag)
 	if err := m.NextErr(); err != nil {
@@ -660,6 +800,56 @@ func (m *mockBackend) SaveController(controllerInfo crossmodel.ControllerInfo, m
 	return &mockExternalController{controllerInfo.ControllerTag.Id(), controllerInfo}, nil
 }
 
+func (m *mockBackend) AssignUnits(args state.AssignUnitsArgs) ([]state.UnitPlacement, error) {
+	m.MethodCall(m, "AssignUnits", args)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	placements := make([]state.UnitPlacement, len(args.Units))
+	for i, unit := range args.Units {
+		placements[i] = state.UnitPlacement{
+			Unit:       unit,
+			MachineId:  fmt.Sprint(i + 1),
+			NewMachine: true,
+		}
+		if len(args.Zones) > 0 {
+			placements[i].Zone = args.Zones[i%len(args.Zones)]
+		}
+	}
+	return placements, nil
+}
+
+func (m *mockBackend) AvailabilityZones() ([]string, error) {
+	m.MethodCall(m, "AvailabilityZones")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return []string{"az1", "az2"}, nil
+}
+
+func (m *mockBackend) SetColocationRule(app1, app2 string, affinity bool) error {
+	m.MethodCall(m, "SetColocationRule", app1, app2, affinity)
+	return m.NextErr()
+}
+
+func (m *mockBackend) RemoveColocationRule(app1, app2 string) error {
+	m.MethodCall(m, "RemoveColocationRule", app1, app2)
+	return m.NextErr()
+}
+
+func (m *mockBackend) ColocationRules() ([]state.ColocationRule, error) {
+	m.MethodCall(m, "ColocationRules")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return []state.ColocationRule{{
+		Applications: [2]string{"mysql", "wordpress"},
+	}, {
+		Applications: [2]string{"postgresql", "wordpress"},
+		Affinity:     true,
+	}}, nil
+}
+
 func (m *mockBackend) Branch(branchName string) (application.Generation, error) {
 	if branchName != "new-branch" {
 		return nil, errors.NotFoundf("branch %q", branchName)
@@ -706,6 +896,9 @@ type mockRelation struct {
 	message         string
 	suspended       bool
 	suspendedReason string
+	drainTimeout    time.Duration
+	endpoints       []state.Endpoint
+	unitSettings    map[string]map[string]interface{}
 }
 
 func (r *mockRelation) Tag() names.Tag {
@@ -726,6 +919,12 @@ func (r *mockRelation) SetSuspended(suspended bool, reason string) error {
 	return r.NextErr()
 }
 
+func (r *mockRelation) SetDrainTimeout(timeout time.Duration) error {
+	r.MethodCall(r, "SetDrainTimeout", timeout)
+	r.drainTimeout = timeout
+	return r.NextErr()
+}
+
 func (r *mockRelation) Suspended() bool {
 	r.MethodCall(r, "Suspended")
 	return r.suspended
@@ -736,6 +935,16 @@ func (r *mockRelation) SuspendedReason() string {
 	return r.suspendedReason
 }
 
+func (r *mockRelation) Endpoints() []state.Endpoint {
+	r.MethodCall(r, "Endpoints")
+	return r.endpoints
+}
+
+func (r *mockRelation) UnitSettings() (map[string]map[string]interface{}, error) {
+	r.MethodCall(r, "UnitSettings")
+	return r.unitSettings, r.NextErr()
+}
+
 func (r *mockRelation) Destroy() error {
 	r.MethodCall(r, "Destroy")
 	return r.NextErr()
@@ -764,6 +973,11 @@ func (u *mockUnit) IsPrincipal() bool {
 	return true
 }
 
+func (u *mockUnit) SetPaused(paused bool) error {
+	u.MethodCall(u, "SetPaused", paused)
+	return u.NextErr()
+}
+
 func (u *mockUnit) DestroyOperation() *state.DestroyUnitOperation {
 	u.MethodCall(u, "DestroyOperation")
 	return &state.DestroyUnitOperation{}
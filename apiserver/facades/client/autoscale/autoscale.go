@@ -0,0 +1,191 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscale
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/autoscale"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend provides the state methods used by the Autoscale facade.
+type Backend interface {
+	Application(name string) (Application, error)
+}
+
+// Application provides the state methods used by the Autoscale facade
+// for an application.
+type Application interface {
+	AutoscalePolicy() (autoscale.Policy, error)
+	AutoscaleEvents() ([]autoscale.Event, error)
+	SetAutoscalePolicy(autoscale.Policy) error
+	RemoveAutoscalePolicy() error
+	UnitCount() int
+}
+
+// BlockChecker checks for current blocks if any.
+type BlockChecker interface {
+	ChangeAllowed() error
+}
+
+// API provides access to the Autoscale API facade, which manages the
+// autoscaling policies of the model's applications.
+type API struct {
+	backend    Backend
+	check      BlockChecker
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+	modelType  state.ModelType
+}
+
+// createAPI returns a new Autoscale API facade for the model.
+func createAPI(
+	backend Backend,
+	check BlockChecker,
+	authorizer facade.Authorizer,
+	modelTag names.ModelTag,
+	modelType state.ModelType,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		check:      check,
+		authorizer: authorizer,
+		modelTag:   modelTag,
+		modelType:  modelType,
+	}, nil
+}
+
+// NewAPI returns a new Autoscale API facade.
+func NewAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return createAPI(stateShim{st}, common.NewBlockChecker(st), authorizer, m.ModelTag(), m.Type())
+}
+
+func (api *API) checkPermission(access permission.Access) error {
+	allowed, err := api.authorizer.HasPermission(access, api.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (api *API) application(tag string) (Application, error) {
+	appTag, err := names.ParseApplicationTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return api.backend.Application(appTag.Id())
+}
+
+// AutoscalePolicies returns the autoscaling policies of the
+// applications, the most recent changes made to them by the autoscaler,
+// and their number of units. An application that isn't autoscaled has
+// no policy.
+func (api *API) AutoscalePolicies(args params.Entities) (params.AutoscaleResults, error) {
+	if err := api.checkPermission(permission.ReadAccess); err != nil {
+		return params.AutoscaleResults{}, errors.Trace(err)
+	}
+	results := params.AutoscaleResults{
+		Results: make([]params.AutoscaleResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		result, err := api.autoscalePolicy(entity.Tag)
+		result.ApplicationTag = entity.Tag
+		result.Error = common.ServerError(err)
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (api *API) autoscalePolicy(tag string) (params.AutoscaleResult, error) {
+	var result params.AutoscaleResult
+	app, err := api.application(tag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	policy, err := app.AutoscalePolicy()
+	if err == nil {
+		p := common.AutoscalePolicyToParams(policy)
+		result.Policy = &p
+	} else if !errors.IsNotFound(err) {
+		return result, errors.Trace(err)
+	}
+	events, err := app.AutoscaleEvents()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Events = common.AutoscaleEventsToParams(events)
+	result.Units = app.UnitCount()
+	return result, nil
+}
+
+// SetAutoscalePolicies sets the autoscaling policies of the
+// applications.
+func (api *API) SetAutoscalePolicies(args params.SetAutoscalePolicyArgs) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if api.modelType == state.ModelTypeCAAS {
+		return params.ErrorResults{}, errors.NotSupportedf("autoscaling applications on a container model")
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		app, err := api.application(arg.ApplicationTag)
+		if err == nil {
+			err = app.SetAutoscalePolicy(common.AutoscalePolicyFromParams(arg.Policy))
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RemoveAutoscalePolicies stops the applications from being
+// autoscaled.
+func (api *API) RemoveAutoscalePolicies(args params.Entities) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.WriteAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		app, err := api.application(entity.Tag)
+		if err == nil {
+			err = app.RemoveAutoscalePolicy()
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+type stateShim struct {
+	st *state.State
+}
+
+func (s stateShim) Application(name string) (Application, error) {
+	return s.st.Application(name)
+}
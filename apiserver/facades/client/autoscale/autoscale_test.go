@@ -0,0 +1,202 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscale_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/autoscale"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coreautoscale "github.com/juju/juju/core/autoscale"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type autoscaleSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	backend    *mockBackend
+	blocked    error
+	modelType  state.ModelType
+}
+
+var _ = gc.Suite(&autoscaleSuite{})
+
+var (
+	now         = time.Date(2019, 5, 8, 12, 0, 0, 0, time.UTC)
+	mysqlPolicy = coreautoscale.Policy{
+		MinUnits:       1,
+		MaxUnits:       3,
+		Metric:         "cpu",
+		ScaleUpAbove:   80,
+		ScaleDownBelow: 20,
+		Cooldown:       time.Minute,
+	}
+)
+
+func (s *autoscaleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("write"),
+	}
+	s.backend = &mockBackend{
+		applications: map[string]*mockApplication{
+			"mysql": {
+				policy:    &mysqlPolicy,
+				events:    []coreautoscale.Event{{Time: now, From: 1, To: 2, Reason: "avg(cpu) 90 above 80"}},
+				unitCount: 2,
+			},
+			"wordpress": {unitCount: 1},
+		},
+	}
+	s.blocked = nil
+	s.modelType = state.ModelTypeIAAS
+}
+
+func (s *autoscaleSuite) newAPI(c *gc.C) *autoscale.API {
+	api, err := autoscale.CreateAPI(s.backend, s, s.authorizer, coretesting.ModelTag, s.modelType)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+// ChangeAllowed is part of the autoscale.BlockChecker interface.
+func (s *autoscaleSuite) ChangeAllowed() error {
+	return s.blocked
+}
+
+func (s *autoscaleSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := autoscale.CreateAPI(s.backend, s, s.authorizer, coretesting.ModelTag, s.modelType)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *autoscaleSuite) TestAutoscalePolicies(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	results, err := s.newAPI(c).AutoscalePolicies(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-mysql"},
+			{Tag: "application-wordpress"},
+			{Tag: "application-missing"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	policy := common.AutoscalePolicyToParams(mysqlPolicy)
+	c.Assert(results, jc.DeepEquals, params.AutoscaleResults{
+		Results: []params.AutoscaleResult{{
+			ApplicationTag: "application-mysql",
+			Policy:         &policy,
+			Events:         []params.AutoscaleEvent{{Time: now, From: 1, To: 2, Reason: "avg(cpu) 90 above 80"}},
+			Units:          2,
+		}, {
+			ApplicationTag: "application-wordpress",
+			Units:          1,
+		}, {
+			ApplicationTag: "application-missing",
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `application "missing" not found`,
+			},
+		}},
+	})
+}
+
+func (s *autoscaleSuite) TestAutoscalePoliciesRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI(c).AutoscalePolicies(params.Entities{})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *autoscaleSuite) TestSetAutoscalePolicies(c *gc.C) {
+	policy := common.AutoscalePolicyToParams(mysqlPolicy)
+	results, err := s.newAPI(c).SetAutoscalePolicies(params.SetAutoscalePolicyArgs{
+		Args: []params.SetAutoscalePolicyArg{
+			{ApplicationTag: "application-wordpress", Policy: policy},
+			{ApplicationTag: "unit-mysql-0", Policy: policy},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `"unit-mysql-0" is not a valid application tag`)
+	c.Check(s.backend.applications["wordpress"].policy, jc.DeepEquals, &mysqlPolicy)
+}
+
+func (s *autoscaleSuite) TestSetAutoscalePoliciesRequiresWrite(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.newAPI(c).SetAutoscalePolicies(params.SetAutoscalePolicyArgs{})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *autoscaleSuite) TestSetAutoscalePoliciesBlocked(c *gc.C) {
+	s.blocked = errors.New("blocked")
+	_, err := s.newAPI(c).SetAutoscalePolicies(params.SetAutoscalePolicyArgs{})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+}
+
+func (s *autoscaleSuite) TestSetAutoscalePoliciesCAAS(c *gc.C) {
+	s.modelType = state.ModelTypeCAAS
+	_, err := s.newAPI(c).SetAutoscalePolicies(params.SetAutoscalePolicyArgs{})
+	c.Assert(err, gc.ErrorMatches, "autoscaling applications on a container model not supported")
+}
+
+func (s *autoscaleSuite) TestRemoveAutoscalePolicies(c *gc.C) {
+	results, err := s.newAPI(c).RemoveAutoscalePolicies(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Combine(), jc.ErrorIsNil)
+	c.Assert(s.backend.applications["mysql"].policy, gc.IsNil)
+}
+
+type mockBackend struct {
+	applications map[string]*mockApplication
+}
+
+func (b *mockBackend) Application(name string) (autoscale.Application, error) {
+	app, ok := b.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+type mockApplication struct {
+	policy    *coreautoscale.Policy
+	events    []coreautoscale.Event
+	unitCount int
+}
+
+func (a *mockApplication) AutoscalePolicy() (coreautoscale.Policy, error) {
+	if a.policy == nil {
+		return coreautoscale.Policy{}, errors.NotFoundf("autoscale policy")
+	}
+	return *a.policy, nil
+}
+
+func (a *mockApplication) AutoscaleEvents() ([]coreautoscale.Event, error) {
+	return a.events, nil
+}
+
+func (a *mockApplication) SetAutoscalePolicy(policy coreautoscale.Policy) error {
+	a.policy = &policy
+	return nil
+}
+
+func (a *mockApplication) RemoveAutoscalePolicy() error {
+	a.policy = nil
+	return nil
+}
+
+func (a *mockApplication) UnitCount() int {
+	return a.unitCount
+}
@@ -37,6 +37,8 @@ type Backend interface {
 	ControllerConfig() (controller.Config, error)
 	StateServingInfo() (state.StateServingInfo, error)
 	RestoreInfo() *state.RestoreInfo
+	ModelCredential() (names.CloudCredentialTag, bool)
+	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 }
 
 // API provides backup-specific API methods.
@@ -61,6 +63,34 @@ func NewAPIv2(backend Backend, resources facade.Resources, authorizer facade.Aut
 	return &APIv2{api}, nil
 }
 
+// APIv3 serves backup-specific API methods for version 3.
+// It adds support for rolling back a failed restore.
+type APIv3 struct {
+	*APIv2
+}
+
+func NewAPIv3(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	api, err := NewAPIv2(backend, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
+// APIv4 serves backup-specific API methods for version 4.
+// It adds VerifyRestore.
+type APIv4 struct {
+	*APIv3
+}
+
+func NewAPIv4(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*APIv4, error) {
+	api, err := NewAPIv3(backend, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv4{api}, nil
+}
+
 // NewAPI creates a new instance of the Backups API facade.
 func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
 	isControllerAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
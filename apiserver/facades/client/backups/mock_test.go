@@ -36,3 +36,11 @@ func (s *stateShim) ControllerTag() names.ControllerTag {
 func (s *stateShim) ModelType() state.ModelType {
 	return s.Model.Type()
 }
+
+func (s *stateShim) ModelCredential() (names.CloudCredentialTag, bool) {
+	return s.Model.CloudCredential()
+}
+
+func (s *stateShim) CloudCredential(tag names.CloudCredentialTag) (state.Credential, error) {
+	return s.State.CloudCredential(tag)
+}
@@ -6,6 +6,8 @@ package backups
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
@@ -15,6 +17,7 @@ import (
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/backups"
+	jujuversion "github.com/juju/juju/version"
 )
 
 var bootstrapNode = names.NewMachineTag("0")
@@ -43,6 +46,12 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		return errors.Annotatef(err, "error fetching public address for machine %q", machine)
 	}
 
+	// Check the backup belongs to this controller before anything
+	// is changed.
+	if err := a.validateRestore(backup, p.BackupId, machine.Series()); err != nil {
+		return errors.Trace(err)
+	}
+
 	info := a.backend.RestoreInfo()
 	// Signal to current state and api server that restore will begin
 	err = info.SetStatus(state.RestoreInProgress)
@@ -77,9 +86,35 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		return errors.Annotatef(err, "HA not ready; try again later")
 	}
 
+	// Take a safety snapshot of the controller as it is now, so it can
+	// be put back if the restore fails part way through. The snapshot
+	// is kept in the backup dir, which the restore leaves alone, rather
+	// than in the database that is about to be replaced.
+	snapshot, err := (&APIv2{a}).Create(params.BackupsCreateArgs{
+		Notes: fmt.Sprintf("safety snapshot taken before restoring %q", p.BackupId),
+	})
+	if err != nil {
+		return errors.Annotate(err, "cannot create safety snapshot")
+	}
+	logger.Infof("created safety snapshot %q", snapshot.Filename)
+
 	oldTagString, err := backup.Restore(p.BackupId, restoreArgs)
 	if err != nil {
-		return errors.Annotate(err, "restore failed")
+		if !p.Rollback {
+			return errors.Annotatef(err, "restore failed, safety snapshot kept in %q", snapshot.Filename)
+		}
+		logger.Errorf("restore failed, rolling back to safety snapshot %q: %v", snapshot.Filename, err)
+		restoreArgs.Rollback = true
+		if _, rollbackErr := backup.Restore(snapshot.Filename, restoreArgs); rollbackErr != nil {
+			return errors.Annotatef(err, "restore failed, and so did rolling back to safety snapshot %q: %v", snapshot.Filename, rollbackErr)
+		}
+		// The rolled back controller has been replaced just as
+		// a restored one would be, so it needs the same restart.
+		logger.Infof("rolled back to safety snapshot")
+		os.Exit(1)
+	}
+	if err := os.RemoveAll(filepath.Dir(snapshot.Filename)); err != nil {
+		logger.Warningf("cannot remove safety snapshot %q: %v", snapshot.Filename, err)
 	}
 
 	// A backup can be made of any component of an ha array.
@@ -121,6 +156,22 @@ func (a *API) Restore(p params.RestoreArgs) error {
 	return nil
 }
 
+// validateRestore returns an error if the backup with the given id
+// can't be restored into this controller. Archives given by filename
+// only carry their metadata inside them, so they are checked by the
+// restore itself once unpacked.
+func (a *API) validateRestore(backup backups.Backups, backupId, series string) error {
+	if strings.Contains(backupId, backups.TempFilename) {
+		return nil
+	}
+	meta, archive, err := backup.Get(backupId)
+	if err != nil {
+		return errors.Annotatef(err, "could not fetch backup %q", backupId)
+	}
+	archive.Close()
+	return backups.ValidateRestore(meta, a.backend.ModelTag().Id(), series, jujuversion.Current)
+}
+
 // PrepareRestore implements the server side of Backups.PrepareRestore.
 func (a *API) PrepareRestore() error {
 	info := a.backend.RestoreInfo()
@@ -32,6 +32,24 @@ func (s *stateShim) MachineSeries(id string) (string, error) {
 	return m.Series(), nil
 }
 
+// NewFacadeV4 provides the required signature for version 4 facade registration.
+func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv4, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPIv4(&stateShim{st, model}, resources, authorizer)
+}
+
+// NewFacadeV3 provides the required signature for version 3 facade registration.
+func NewFacadeV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv3, error) {
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPIv3(&stateShim{st, model}, resources, authorizer)
+}
+
 // NewFacadeV2 provides the required signature for version 2 facade registration.
 func NewFacadeV2(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv2, error) {
 	model, err := st.Model()
@@ -65,3 +83,15 @@ func (s *stateShim) ModelTag() names.ModelTag {
 func (s *stateShim) ModelType() state.ModelType {
 	return s.Model.Type()
 }
+
+// ModelCredential returns the tag of the cloud credential used by the
+// controller model.
+func (s *stateShim) ModelCredential() (names.CloudCredentialTag, bool) {
+	return s.Model.CloudCredential()
+}
+
+// CloudCredential disambiguates the CloudCredential method pending further
+// refactoring to separate model functionality from state functionality.
+func (s *stateShim) CloudCredential(tag names.CloudCredentialTag) (state.Credential, error) {
+	return s.State.CloudCredential(tag)
+}
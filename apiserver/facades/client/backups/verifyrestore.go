@@ -0,0 +1,150 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	jujuversion "github.com/juju/juju/version"
+)
+
+// VerifyRestore implements the server side of Backups.VerifyRestore.
+// It makes the checks Restore would make against this controller,
+// along with a few more that Restore leaves to fail part way
+// through, and reports the steps Restore would take. Nothing on the
+// controller is changed.
+func (a *APIv4) VerifyRestore(p params.VerifyRestoreArgs) (params.VerifyRestoreResult, error) {
+	var result params.VerifyRestoreResult
+	check := func(name, description string, err error) {
+		c := params.RestoreCheck{Name: name, Description: description}
+		if err != nil {
+			c.Problem = err.Error()
+		}
+		result.Checks = append(result.Checks, c)
+	}
+
+	machine, err := a.backend.Machine(a.machineID)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	meta, err := a.verifyRestoreMetadata(p)
+	check("backup", "backup metadata can be read", err)
+	if meta != nil {
+		check("controller", "backup was made of this controller",
+			backups.ValidateRestoreModel(meta, a.backend.ModelTag().Id()))
+		check("series", "backup was made on a machine with the same series",
+			backups.ValidateRestoreSeries(meta, machine.Series()))
+		check("version", fmt.Sprintf("backup can be restored by Juju %v", jujuversion.Current),
+			backups.ValidateRestoreVersion(meta, jujuversion.Current))
+	}
+	check("instance", fmt.Sprintf("controller machine %s is running", machine.Id()),
+		verifyRestoreInstance(machine))
+	check("credential", "controller model cloud credential is valid",
+		a.verifyRestoreCredential())
+	check("restore", "no other restore is under way",
+		a.verifyRestoreStatus())
+
+	if meta != nil {
+		result.Steps = verifyRestoreSteps(meta, machine.Id(), p.Rollback)
+	}
+	return result, nil
+}
+
+// verifyRestoreMetadata returns the metadata for the backup being
+// verified, either fetched from backup storage or as given.
+func (a *APIv4) verifyRestoreMetadata(p params.VerifyRestoreArgs) (*backups.Metadata, error) {
+	if p.Metadata != nil {
+		return MetadataFromResult(*p.Metadata), nil
+	}
+	if p.BackupId == "" {
+		return nil, errors.New("no backup id or metadata given")
+	}
+	backup, closer := newBackups(a.backend)
+	defer closer.Close()
+	meta, archive, err := backup.Get(p.BackupId)
+	if err != nil {
+		return nil, errors.Annotatef(err, "could not fetch backup %q", p.BackupId)
+	}
+	if archive != nil {
+		archive.Close()
+	}
+	return meta, nil
+}
+
+// verifyRestoreInstance returns an error if the machine the restore
+// would run on isn't provisioned and running.
+func verifyRestoreInstance(machine *state.Machine) error {
+	if _, err := machine.InstanceId(); err != nil {
+		return errors.Annotatef(err, "cannot obtain instance id for machine %s", machine.Id())
+	}
+	instStatus, err := machine.InstanceStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if instStatus.Status != status.Running {
+		return errors.Errorf("instance for machine %s is %q", machine.Id(), instStatus.Status)
+	}
+	return nil
+}
+
+// verifyRestoreCredential returns an error if the controller model's
+// cloud credential has been marked as invalid.
+func (a *APIv4) verifyRestoreCredential() error {
+	tag, ok := a.backend.ModelCredential()
+	if !ok {
+		// Some clouds, such as lxd, don't need a credential.
+		return nil
+	}
+	credential, err := a.backend.CloudCredential(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !credential.IsValid() {
+		return errors.Errorf("credential %q is not valid: %s", tag.Id(), credential.InvalidReason)
+	}
+	return nil
+}
+
+// verifyRestoreStatus returns an error if a restore has already been
+// started on the controller.
+func (a *APIv4) verifyRestoreStatus() error {
+	current, err := a.backend.RestoreInfo().Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch current {
+	case state.RestorePending, state.RestoreInProgress, state.RestoreFinished:
+		return errors.Errorf("restore status is %q", current)
+	}
+	return nil
+}
+
+// verifyRestoreSteps returns a description of each step Restore would
+// take to restore the backup onto the given machine.
+func verifyRestoreSteps(meta *backups.Metadata, machineId string, rollback bool) []string {
+	steps := []string{
+		"put the controller into restore mode",
+		"take a safety snapshot of the controller",
+		fmt.Sprintf("replace the controller database and files with the backup of machine %s made at %s",
+			meta.Origin.Machine, meta.Started.UTC().Format("2006-01-02 15:04:05")),
+	}
+	if meta.Origin.Machine != machineId {
+		steps = append(steps, fmt.Sprintf("start the agent for machine %s in place of machine %s", meta.Origin.Machine, machineId))
+	} else {
+		steps = append(steps, fmt.Sprintf("restart the agent for machine %s", machineId))
+	}
+	if rollback {
+		steps = append(steps, "roll back to the safety snapshot if the restore fails")
+	} else {
+		steps = append(steps, "keep the safety snapshot if the restore fails")
+	}
+	return steps
+}
@@ -0,0 +1,112 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	backupsAPI "github.com/juju/juju/apiserver/facades/client/backups"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	jujuversion "github.com/juju/juju/version"
+)
+
+func (s *backupsSuite) newAPIv4(c *gc.C) *backupsAPI.APIv4 {
+	api, err := backupsAPI.NewAPIv4(&stateShim{State: s.State, Model: s.Model}, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *backupsSuite) setInstanceRunning(c *gc.C) {
+	machine, err := s.State.Machine(s.machineTag.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	now := time.Now()
+	err = machine.SetInstanceStatus(status.StatusInfo{
+		Status: status.Running,
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.meta.Origin.Series = machine.Series()
+}
+
+func (s *backupsSuite) problems(result params.VerifyRestoreResult) map[string]string {
+	problems := make(map[string]string)
+	for _, check := range result.Checks {
+		problems[check.Name] = check.Problem
+	}
+	return problems
+}
+
+func (s *backupsSuite) TestVerifyRestore(c *gc.C) {
+	s.setInstanceRunning(c)
+	s.meta.Origin.Model = s.State.ModelUUID()
+	s.meta.Origin.Version = jujuversion.Current
+	s.setBackups(c, s.meta, "")
+
+	result, err := s.newAPIv4(c).VerifyRestore(params.VerifyRestoreArgs{BackupId: "an-id"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.problems(result), jc.DeepEquals, map[string]string{
+		"backup":     "",
+		"controller": "",
+		"series":     "",
+		"version":    "",
+		"instance":   "",
+		"credential": "",
+		"restore":    "",
+	})
+	c.Assert(result.Steps, gc.HasLen, 5)
+	c.Check(result.Steps[3], gc.Equals, "restart the agent for machine 0")
+	c.Check(result.Steps[4], gc.Equals, "keep the safety snapshot if the restore fails")
+}
+
+func (s *backupsSuite) TestVerifyRestoreMetadata(c *gc.C) {
+	s.setInstanceRunning(c)
+	s.meta.Origin.Model = s.State.ModelUUID()
+	s.meta.Origin.Machine = "2"
+	s.meta.Origin.Version = version.MustParse("1.25.6")
+	meta := backupsAPI.CreateResult(s.meta, "")
+
+	result, err := s.newAPIv4(c).VerifyRestore(params.VerifyRestoreArgs{
+		Metadata: &meta,
+		Rollback: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	problems := s.problems(result)
+	c.Check(problems["controller"], gc.Equals, "")
+	c.Check(problems["version"], gc.Matches, "Juju version .* cannot restore backups made using Juju version 1.25.6")
+	c.Assert(result.Steps, gc.HasLen, 5)
+	c.Check(result.Steps[3], gc.Equals, "start the agent for machine 2 in place of machine 0")
+	c.Check(result.Steps[4], gc.Equals, "roll back to the safety snapshot if the restore fails")
+}
+
+func (s *backupsSuite) TestVerifyRestoreBackupNotFound(c *gc.C) {
+	s.setBackups(c, nil, "backup not found")
+
+	result, err := s.newAPIv4(c).VerifyRestore(params.VerifyRestoreArgs{BackupId: "an-id"})
+	c.Assert(err, jc.ErrorIsNil)
+	problems := s.problems(result)
+	c.Check(problems["backup"], gc.Equals, `could not fetch backup "an-id": backup not found`)
+	_, ok := problems["controller"]
+	c.Check(ok, jc.IsFalse)
+	c.Check(result.Steps, gc.HasLen, 0)
+}
+
+func (s *backupsSuite) TestVerifyRestoreProblems(c *gc.C) {
+	s.meta.Origin.Series = "precise"
+	s.setBackups(c, s.meta, "")
+	err := s.State.RestoreInfo().SetStatus(state.RestorePending)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.newAPIv4(c).VerifyRestore(params.VerifyRestoreArgs{BackupId: "an-id"})
+	c.Assert(err, jc.ErrorIsNil)
+	problems := s.problems(result)
+	c.Check(problems["controller"], gc.Matches, `cannot restore a backup of controller model .* into controller model .*`)
+	c.Check(problems["series"], gc.Matches, `cannot restore a backup made in a machine with series "precise" into .*`)
+	c.Check(problems["restore"], gc.Equals, `restore status is "PENDING"`)
+}
@@ -37,6 +37,11 @@ type APIv1 struct {
 
 // APIv2 provides the Bundle API facade for version 2.
 type APIv2 struct {
+	*APIv3
+}
+
+// APIv3 provides the Bundle API facade for version 3.
+type APIv3 struct {
 	*BundleAPI
 }
 
@@ -62,13 +67,23 @@ func NewFacadeV1(ctx facade.Context) (*APIv1, error) {
 // NewFacadeV2 provides the signature required for facade registration
 // for version 2.
 func NewFacadeV2(ctx facade.Context) (*APIv2, error) {
-	api, err := newFacade(ctx)
+	api, err := NewFacadeV3(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv2{api}, nil
 }
 
+// NewFacadeV3 provides the signature required for facade registration
+// for version 3.
+func NewFacadeV3(ctx facade.Context) (*APIv3, error) {
+	api, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv3{api}, nil
+}
+
 // NewFacade provides the required signature for facade registration.
 func newFacade(ctx facade.Context) (*BundleAPI, error) {
 	authorizer := ctx.Auth()
@@ -108,7 +123,7 @@ func NewBundleAPIv1(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &APIv1{&APIv2{api}}, nil
+	return &APIv1{&APIv2{&APIv3{api}}}, nil
 }
 
 func (b *BundleAPI) checkCanRead() error {
@@ -231,6 +246,30 @@ func (b *BundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 	})
 }
 
+// CheckPlacements reports every zone placement, zone or space constraint
+// and endpoint binding in the given bundle that cannot be satisfied by
+// the model. All problems are reported together so that a bundle can be
+// rejected before any of it is deployed.
+func (b *BundleAPI) CheckPlacements(args params.BundleChangesParams) (params.BundlePlacementResults, error) {
+	var results params.BundlePlacementResults
+	if err := b.checkCanRead(); err != nil {
+		return results, err
+	}
+	data, err := charm.ReadBundleData(strings.NewReader(args.BundleDataYAML))
+	if err != nil {
+		return results, errors.Annotate(err, "cannot read bundle YAML")
+	}
+	checker := placementChecker{backend: b.backend}
+	results.Errors, err = checker.check(data)
+	if err != nil {
+		return params.BundlePlacementResults{}, common.ServerError(err)
+	}
+	return results, nil
+}
+
+// CheckPlacements isn't on the v2 API.
+func (u *APIv2) CheckPlacements(_, _ struct{}) {}
+
 // ExportBundle exports the current model configuration as bundle.
 func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
 	fail := func(failErr error) (params.StringResult, error) {
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/juju/description"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -20,7 +21,7 @@ import (
 type bundleSuite struct {
 	coretesting.BaseSuite
 	auth     *apiservertesting.FakeAuthorizer
-	facade   *bundle.APIv2
+	facade   *bundle.APIv3
 	apiv1    *bundle.APIv1
 	st       *mockState
 	modelTag names.ModelTag
@@ -41,19 +42,19 @@ func (s *bundleSuite) SetUpTest(c *gc.C) {
 	s.facade = s.makeAPI(c)
 }
 
-func (s *bundleSuite) makeAPI(c *gc.C) *bundle.APIv2 {
+func (s *bundleSuite) makeAPI(c *gc.C) *bundle.APIv3 {
 	api, err := bundle.NewBundleAPI(
 		s.st,
 		s.auth,
 		s.modelTag,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	return &bundle.APIv2{api}
+	return &bundle.APIv3{api}
 }
 
 func (s *bundleSuite) makeAPIv1(c *gc.C) *bundle.APIv1 {
 	api := s.makeAPI(c)
-	return &bundle.APIv1{api}
+	return &bundle.APIv1{&bundle.APIv2{api}}
 }
 
 func (s *bundleSuite) TestGetChangesBundleContentError(c *gc.C) {
@@ -1130,3 +1131,97 @@ relations:
 	c.Assert(result, gc.Equals, expectedResult)
 	s.st.CheckCall(c, 0, "ExportPartial", s.st.GetExportConfig())
 }
+
+func (s *bundleSuite) TestCheckPlacementsSatisfied(c *gc.C) {
+	s.st.zones = []string{"us-east-1a", "us-east-1b"}
+	s.st.spaces = []string{"db", "public"}
+	args := params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                mysql:
+                    charm: mysql
+                    num_units: 2
+                    to: ["zone=us-east-1a", "zone=us-east-1b"]
+                    bindings:
+                        "": db
+                        website: public
+                wordpress:
+                    charm: wordpress
+                    num_units: 1
+                    to: ["0"]
+            machines:
+                "0":
+                    constraints: zones=us-east-1b spaces=public
+        `,
+	}
+	r, err := s.facade.CheckPlacements(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Errors, gc.HasLen, 0)
+	s.st.CheckCallNames(c, "AvailabilityZones", "SpaceNames")
+}
+
+func (s *bundleSuite) TestCheckPlacementsReportsAllErrors(c *gc.C) {
+	s.st.zones = []string{"us-east-1a"}
+	s.st.spaces = []string{"db"}
+	args := params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                mysql:
+                    charm: mysql
+                    num_units: 1
+                    to: ["zone=us-east-1c", "0"]
+                    bindings:
+                        website: public
+                wordpress:
+                    charm: wordpress
+                    num_units: 1
+                    constraints: spaces=^dmz
+            machines:
+                "0":
+                    constraints: zones=us-east-1z
+        `,
+	}
+	r, err := s.facade.CheckPlacements(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Errors, jc.DeepEquals, []string{
+		`application "mysql": placement "zone=us-east-1c": availability zone "us-east-1c" not found`,
+		`application "mysql": zone placements cannot be mixed with machine placements`,
+		`application "mysql": binding for endpoint "website": space "public" not found`,
+		`application "wordpress": constraint "spaces=^dmz": space "dmz" not found`,
+		`machine "0": constraint "zones=us-east-1z": availability zone "us-east-1z" not found`,
+	})
+}
+
+func (s *bundleSuite) TestCheckPlacementsZonesNotSupported(c *gc.C) {
+	s.st.SetErrors(errors.NotSupportedf("availability zones"))
+	args := params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                mysql:
+                    charm: mysql
+                    num_units: 1
+                    to: ["zone=us-east-1a"]
+        `,
+	}
+	r, err := s.facade.CheckPlacements(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Errors, jc.DeepEquals, []string{
+		`application "mysql": placement "zone=us-east-1a": zone placement in this model not supported`,
+	})
+}
+
+func (s *bundleSuite) TestCheckPlacementsBackendError(c *gc.C) {
+	s.st.SetErrors(errors.New("boom"))
+	args := params.BundleChangesParams{
+		BundleDataYAML: `
+            applications:
+                mysql:
+                    charm: mysql
+                    num_units: 1
+                    bindings:
+                        "": db
+        `,
+	}
+	_, err := s.facade.CheckPlacements(args)
+	c.Assert(err, gc.ErrorMatches, "cannot get spaces: boom")
+}
@@ -14,7 +14,9 @@ import (
 type mockState struct {
 	testing.Stub
 	bundle.Backend
-	model description.Model
+	model  description.Model
+	zones  []string
+	spaces []string
 }
 
 func (m *mockState) ExportPartial(config state.ExportConfig) (description.Model, error) {
@@ -38,6 +40,22 @@ func (m *mockState) GetExportConfig() state.ExportConfig {
 	}
 }
 
+func (m *mockState) AvailabilityZones() ([]string, error) {
+	m.MethodCall(m, "AvailabilityZones")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.zones, nil
+}
+
+func (m *mockState) SpaceNames() ([]string, error) {
+	m.MethodCall(m, "SpaceNames")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.spaces, nil
+}
+
 func newMockState() *mockState {
 	st := &mockState{
 		Stub: testing.Stub{},
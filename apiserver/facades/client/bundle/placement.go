@@ -0,0 +1,208 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/constraints"
+)
+
+// zonePlacementPrefix identifies a bundle unit placement naming the
+// availability zone a unit's machine should be started in, rather than
+// a machine or container defined by the bundle.
+const zonePlacementPrefix = "zone="
+
+// placementChecker verifies the zone placements, zone and space
+// constraints and endpoint bindings of a bundle against the model.
+// Zones and spaces are only fetched if the bundle refers to them.
+type placementChecker struct {
+	backend Backend
+
+	zones       set.Strings
+	zonesErr    error
+	zonesLoaded bool
+
+	spaces       set.Strings
+	spacesErr    error
+	spacesLoaded bool
+}
+
+// check returns a description of every placement in the bundle that
+// cannot be satisfied. An error is only returned if the model could not
+// be queried.
+func (pc *placementChecker) check(data *charm.BundleData) ([]string, error) {
+	var problems []string
+	report := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	appNames := set.NewStrings()
+	for name := range data.Applications {
+		appNames.Add(name)
+	}
+	for _, name := range appNames.SortedValues() {
+		app := data.Applications[name]
+		if app == nil {
+			continue
+		}
+		var zonePlacements, otherPlacements int
+		for _, placement := range app.To {
+			zone, ok := zonePlacement(placement)
+			if !ok {
+				otherPlacements++
+				continue
+			}
+			zonePlacements++
+			if err := pc.checkZone(zone); err != nil {
+				if !errors.IsNotFound(err) && !errors.IsNotSupported(err) {
+					return nil, errors.Trace(err)
+				}
+				report("application %q: placement %q: %v", name, placement, err)
+			}
+		}
+		if zonePlacements > 0 && otherPlacements > 0 {
+			report("application %q: zone placements cannot be mixed with machine placements", name)
+		}
+		if zonePlacements > app.NumUnits {
+			report("application %q: too many zone placements for %d unit(s)", name, app.NumUnits)
+		}
+		if err := pc.checkConstraints(app.Constraints, func(format string, args ...interface{}) {
+			report("application %q: "+format, append([]interface{}{name}, args...)...)
+		}); err != nil {
+			return nil, errors.Trace(err)
+		}
+		endpoints := set.NewStrings()
+		for endpoint := range app.EndpointBindings {
+			endpoints.Add(endpoint)
+		}
+		for _, endpoint := range endpoints.SortedValues() {
+			space := app.EndpointBindings[endpoint]
+			if space == "" {
+				continue
+			}
+			if err := pc.checkSpace(space); err != nil {
+				if !errors.IsNotFound(err) {
+					return nil, errors.Trace(err)
+				}
+				if endpoint == "" {
+					report("application %q: default binding: %v", name, err)
+				} else {
+					report("application %q: binding for endpoint %q: %v", name, endpoint, err)
+				}
+			}
+		}
+	}
+
+	machineIds := set.NewStrings()
+	for id := range data.Machines {
+		machineIds.Add(id)
+	}
+	for _, id := range machineIds.SortedValues() {
+		machine := data.Machines[id]
+		if machine == nil {
+			continue
+		}
+		if err := pc.checkConstraints(machine.Constraints, func(format string, args ...interface{}) {
+			report("machine %q: "+format, append([]interface{}{id}, args...)...)
+		}); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return problems, nil
+}
+
+// checkConstraints reports any zones or spaces named in the given
+// constraints that do not exist in the model. Constraints that cannot be
+// parsed are left for bundle verification to report.
+func (pc *placementChecker) checkConstraints(cons string, report func(string, ...interface{})) error {
+	if cons == "" {
+		return nil
+	}
+	value, err := constraints.Parse(cons)
+	if err != nil {
+		return nil
+	}
+	if value.HasZones() {
+		for _, zone := range *value.Zones {
+			if err := pc.checkZone(zone); err != nil {
+				if !errors.IsNotFound(err) && !errors.IsNotSupported(err) {
+					return errors.Trace(err)
+				}
+				report("constraint %q: %v", "zones="+zone, err)
+			}
+		}
+	}
+	checkSpaces := func(spaces []string, prefix string) error {
+		for _, space := range spaces {
+			if err := pc.checkSpace(space); err != nil {
+				if !errors.IsNotFound(err) {
+					return errors.Trace(err)
+				}
+				report("constraint %q: %v", "spaces="+prefix+space, err)
+			}
+		}
+		return nil
+	}
+	if err := checkSpaces(value.IncludeSpaces(), ""); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(checkSpaces(value.ExcludeSpaces(), "^"))
+}
+
+// checkZone returns an error satisfying errors.IsNotFound if the zone
+// is not known to the provider, or errors.IsNotSupported if the provider
+// has no availability zones.
+func (pc *placementChecker) checkZone(zone string) error {
+	if !pc.zonesLoaded {
+		pc.zonesLoaded = true
+		zones, err := pc.backend.AvailabilityZones()
+		if errors.IsNotSupported(err) {
+			pc.zonesErr = errors.NotSupportedf("zone placement in this model")
+		} else if err != nil {
+			pc.zonesErr = errors.Annotate(err, "cannot get availability zones")
+		}
+		pc.zones = set.NewStrings(zones...)
+	}
+	if pc.zonesErr != nil {
+		return pc.zonesErr
+	}
+	if !pc.zones.Contains(zone) {
+		return errors.NotFoundf("availability zone %q", zone)
+	}
+	return nil
+}
+
+// checkSpace returns an error satisfying errors.IsNotFound if the space
+// does not exist in the model.
+func (pc *placementChecker) checkSpace(space string) error {
+	if !pc.spacesLoaded {
+		pc.spacesLoaded = true
+		spaces, err := pc.backend.SpaceNames()
+		if err != nil {
+			pc.spacesErr = errors.Annotate(err, "cannot get spaces")
+		}
+		pc.spaces = set.NewStrings(spaces...)
+	}
+	if pc.spacesErr != nil {
+		return pc.spacesErr
+	}
+	if !pc.spaces.Contains(space) {
+		return errors.NotFoundf("space %q", space)
+	}
+	return nil
+}
+
+// zonePlacement returns the zone named by a "zone=<name>" unit placement.
+func zonePlacement(placement string) (string, bool) {
+	if !strings.HasPrefix(placement, zonePlacementPrefix) {
+		return "", false
+	}
+	return strings.TrimPrefix(placement, zonePlacementPrefix), true
+}
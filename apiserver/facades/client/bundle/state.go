@@ -5,13 +5,25 @@ package bundle
 
 import (
 	"github.com/juju/description"
+	"github.com/juju/errors"
 
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 )
 
 type Backend interface {
 	ExportPartial(cfg state.ExportConfig) (description.Model, error)
 	GetExportConfig() state.ExportConfig
+
+	// AvailabilityZones returns the names of the availability zones
+	// known to the model's provider. If the provider does not support
+	// zones, an error satisfying errors.IsNotSupported is returned.
+	AvailabilityZones() ([]string, error)
+
+	// SpaceNames returns the names of all spaces in the model.
+	SpaceNames() ([]string, error)
 }
 
 type stateShim struct {
@@ -40,3 +52,37 @@ func (m *stateShim) GetExportConfig() state.ExportConfig {
 func NewStateShim(st *state.State) Backend {
 	return &stateShim{st}
 }
+
+// AvailabilityZones implements Backend.AvailabilityZones.
+func (m *stateShim) AvailabilityZones() ([]string, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(m.State)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening environment")
+	}
+	zonedEnv, ok := env.(common.ZonedEnviron)
+	if !ok {
+		return nil, errors.NotSupportedf("availability zones")
+	}
+	zones, err := zonedEnv.AvailabilityZones(state.CallContext(m.State))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(zones))
+	for i, zone := range zones {
+		names[i] = zone.Name()
+	}
+	return names, nil
+}
+
+// SpaceNames implements Backend.SpaceNames.
+func (m *stateShim) SpaceNames() ([]string, error) {
+	spaces, err := m.State.AllSpaces()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := make([]string, len(spaces))
+	for i, space := range spaces {
+		names[i] = space.Name()
+	}
+	return names, nil
+}
@@ -44,11 +44,13 @@ type Backend interface {
 	APIHostPortsForClients() ([][]network.HostPort, error)
 	Application(string) (*state.Application, error)
 	Charm(*charm.URL) (*state.Charm, error)
+	CheckQuotas(string, state.QuotaUsage) error
 	ControllerConfig() (controller.Config, error)
 	ControllerTag() names.ControllerTag
 	ControllerTimestamp() (*time.Time, error)
 	EndpointsRelation(...state.Endpoint) (*state.Relation, error)
 	FindEntity(names.Tag) (state.Entity, error)
+	IdleResources() ([]state.IdleResource, error)
 	InferEndpoints(...string) ([]state.Endpoint, error)
 	IsController() bool
 	LatestMigration() (state.ModelMigration, error)
@@ -60,6 +62,8 @@ type Backend interface {
 	ModelConstraints() (constraints.Value, error)
 	ModelTag() names.ModelTag
 	ModelUUID() string
+	MongoUpgradeStatus() (*state.MongoUpgradeStatus, error)
+	MongoVersion() (string, error)
 	RemoteApplication(string) (*state.RemoteApplication, error)
 	RemoteConnectionStatus(string) (*state.RemoteConnectionStatus, error)
 	RemoveUserAccess(names.UserTag, names.Tag) error
@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/environs"
@@ -25,6 +26,7 @@ import (
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/manual/sshprovisioner"
 	"github.com/juju/juju/environs/manual/winrmprovisioner"
+	"github.com/juju/juju/feature"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
@@ -46,6 +48,10 @@ type API struct {
 	statusSetter     *common.StatusSetter
 	toolsFinder      *common.ToolsFinder
 	leadershipReader leadership.Reader
+
+	// modelCache, if set, is used to serve the full status of the
+	// model from memory.
+	modelCache *cache.Controller
 }
 
 // TODO(wallyworld) - remove this method
@@ -68,9 +74,23 @@ type Client struct {
 	callContext context.ProviderCallContext
 }
 
+// ClientV3 serves the (v3) client-specific API methods. The only
+// difference between this and v4 is that v3 doesn't have the
+// PinModelAgentVersion method.
+type ClientV3 struct {
+	*Client
+}
+
+// ClientV2 serves the (v2) client-specific API methods. The only
+// difference between this and v3 is that v2 doesn't have the
+// UpgradePrecheck method.
+type ClientV2 struct {
+	*ClientV3
+}
+
 // ClientV1 serves the (v1) client-specific API methods.
 type ClientV1 struct {
-	*Client
+	*ClientV2
 }
 
 func (c *Client) checkCanRead() error {
@@ -121,14 +141,32 @@ func (c *Client) checkIsAdmin() error {
 	return nil
 }
 
-// NewFacade creates a version 1 Client facade to handle API requests.
+// NewFacade creates a version 4 Client facade to handle API requests.
 func NewFacade(ctx facade.Context) (*Client, error) {
 	return newFacade(ctx)
 }
 
+// NewFacadeV3 creates a version 3 Client facade to handle API requests.
+func NewFacadeV3(ctx facade.Context) (*ClientV3, error) {
+	client, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV3{client}, nil
+}
+
+// NewFacadeV2 creates a version 2 Client facade to handle API requests.
+func NewFacadeV2(ctx facade.Context) (*ClientV2, error) {
+	client, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV2{client}, nil
+}
+
 // NewFacadeV1 creates a version 1 Client facade to handle API requests.
 func NewFacadeV1(ctx facade.Context) (*ClientV1, error) {
-	client, err := newFacade(ctx)
+	client, err := NewFacadeV2(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -175,7 +213,12 @@ func newFacade(ctx facade.Context) (*Client, error) {
 		return nil, errors.Trace(err)
 	}
 
-	return NewClient(
+	controllerConfig, err := st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	client, err := NewClient(
 		&stateShim{st, model},
 		&poolShim{ctx.StatePool()},
 		&modelconfig.ModelConfigAPIV1{modelConfigAPI},
@@ -189,6 +232,13 @@ func newFacade(ctx facade.Context) (*Client, error) {
 		state.CallContext(st),
 		leadershipReader,
 	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if controllerConfig.Features().Contains(feature.CachedStatus) {
+		client.api.modelCache = ctx.Controller()
+	}
+	return client, nil
 }
 
 // NewClient creates a new instance of the Client Facade.
@@ -443,10 +493,23 @@ func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error
 		placementDirective = p.Placement.Directive
 	}
 
+	if p.CloudInitUserData != "" {
+		if _, err := config.ParseCloudInitUserData(p.CloudInitUserData); err != nil {
+			return nil, errors.Annotate(err, "invalid cloudinit-userdata")
+		}
+	}
+
 	jobs, err := common.StateJobs(p.Jobs)
 	if err != nil {
 		return nil, err
 	}
+	add := state.QuotaUsage{Machines: 1}
+	if p.ContainerType != "" && p.ParentId == "" {
+		add.Machines++
+	}
+	if err := c.api.stateAccessor.CheckQuotas(c.api.stateAccessor.ModelUUID(), add); err != nil {
+		return nil, errors.Trace(err)
+	}
 	template := state.MachineTemplate{
 		Series:                  p.Series,
 		Constraints:             p.Constraints,
@@ -456,6 +519,7 @@ func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error
 		HardwareCharacteristics: p.HardwareCharacteristics,
 		Addresses:               params.NetworkAddresses(p.Addrs...),
 		Placement:               placementDirective,
+		CloudInitUserData:       p.CloudInitUserData,
 	}
 	if p.ContainerType == "" {
 		return c.api.stateAccessor.AddOneMachine(template)
@@ -630,6 +694,46 @@ func (c *Client) SetModelAgentVersion(args params.SetModelAgentVersion) error {
 	}
 	// Before changing the agent version to trigger an upgrade or downgrade,
 	// we'll do a very basic check to ensure the environment is accessible.
+	if err := c.checkProviderAPI(); err != nil {
+		return err
+	}
+	// If this is the controller model, also check to make sure that there are
+	// no running migrations.  All models should have migration mode of None.
+	if c.api.stateAccessor.IsController() {
+		migrating, err := c.migratingModels()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(migrating) > 0 {
+			return errors.Errorf("%s, upgrade blocked", migrating[0])
+		}
+	}
+
+	return c.api.stateAccessor.SetModelAgentVersion(args.Version, args.IgnoreAgentVersions)
+}
+
+// PinModelAgentVersion pins the model's agent version, so that it can
+// only be upgraded to the pinned version. A zero version removes the pin.
+func (c *Client) PinModelAgentVersion(args params.PinModelAgentVersion) error {
+	if err := c.checkIsAdmin(); err != nil {
+		return err
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return errors.Trace(err)
+	}
+	model, err := c.api.stateAccessor.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(model.SetPinnedAgentVersion(args.Version))
+}
+
+// PinModelAgentVersion isn't on the v3 API.
+func (c *ClientV3) PinModelAgentVersion() {}
+
+// checkProviderAPI returns an error if a simple call to the model's
+// cloud fails.
+func (c *Client) checkProviderAPI() error {
 	envOrBroker, err := c.newEnviron()
 	if err != nil {
 		return errors.Trace(err)
@@ -646,28 +750,28 @@ func (c *Client) SetModelAgentVersion(args params.SetModelAgentVersion) error {
 			return errors.Annotate(err, "cannot make API call to provider")
 		}
 	}
-	// If this is the controller model, also check to make sure that there are
-	// no running migrations.  All models should have migration mode of None.
-	if c.api.stateAccessor.IsController() {
-		modelUUIDs, err := c.api.stateAccessor.AllModelUUIDs()
+	return nil
+}
+
+// migratingModels describes each model on the controller that is
+// being imported or exported.
+func (c *Client) migratingModels() ([]string, error) {
+	modelUUIDs, err := c.api.stateAccessor.AllModelUUIDs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var migrating []string
+	for _, modelUUID := range modelUUIDs {
+		model, release, err := c.api.pool.GetModel(modelUUID)
 		if err != nil {
-			return errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
-
-		for _, modelUUID := range modelUUIDs {
-			model, release, err := c.api.pool.GetModel(modelUUID)
-			if err != nil {
-				return errors.Trace(err)
-			}
-			if mode := model.MigrationMode(); mode != state.MigrationModeNone {
-				release()
-				return errors.Errorf("model \"%s/%s\" is %s, upgrade blocked", model.Owner().Name(), model.Name(), mode)
-			}
-			release()
+		if mode := model.MigrationMode(); mode != state.MigrationModeNone {
+			migrating = append(migrating, fmt.Sprintf("model \"%s/%s\" is %s", model.Owner().Name(), model.Name(), mode))
 		}
+		release()
 	}
-
-	return c.api.stateAccessor.SetModelAgentVersion(args.Version, args.IgnoreAgentVersions)
+	return migrating, nil
 }
 
 // AbortCurrentUpgrade aborts and archives the current upgrade
@@ -17,6 +17,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/workertest"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver/common"
@@ -27,6 +28,7 @@ import (
 	"github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
@@ -94,6 +96,46 @@ func (s *serverSuite) clientForState(c *gc.C, st *state.State) *client.Client {
 	})
 }
 
+func (s *serverSuite) TestFullStatusFromModelCache(c *gc.C) {
+	changes := make(chan interface{})
+	processed := make(chan interface{})
+	modelCache, err := cache.NewController(cache.ControllerConfig{
+		Changes: changes,
+		Notify: func(change interface{}) {
+			processed <- change
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, modelCache)
+	select {
+	case changes <- cache.ModelChange{ModelUUID: s.State.ModelUUID(), Name: "controller"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("model change not read")
+	}
+	select {
+	case <-processed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("model change not processed")
+	}
+	client.SetModelCache(s.client, modelCache)
+
+	status, err := s.client.FullStatus(params.StatusParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Machines, gc.HasLen, 0)
+
+	// The status is served from memory until the model is invalidated.
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	status, err = s.client.FullStatus(params.StatusParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Machines, gc.HasLen, 0)
+
+	modelCache.Invalidate(s.State.ModelUUID())
+	status, err = s.client.FullStatus(params.StatusParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Machines, gc.HasLen, 1)
+}
+
 func (s *serverSuite) TestModelInfo(c *gc.C) {
 	model, err := s.State.Model()
 	c.Assert(err, jc.ErrorIsNil)
@@ -442,6 +484,49 @@ func (s *serverSuite) TestBlockChangesSetModelAgentVersion(c *gc.C) {
 	s.assertSetModelAgentVersionBlocked(c, "TestBlockChangesSetModelAgentVersion")
 }
 
+func (s *serverSuite) TestPinModelAgentVersion(c *gc.C) {
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	client := s.clientForState(c, otherSt)
+
+	err := client.PinModelAgentVersion(params.PinModelAgentVersion{
+		Version: version.MustParse("2.5.4"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := otherSt.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	pinned, ok := model.PinnedAgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(pinned, gc.Equals, version.MustParse("2.5.4"))
+
+	err = client.SetModelAgentVersion(params.SetModelAgentVersion{
+		Version: version.MustParse("2.5.5"),
+	})
+	c.Assert(err, gc.ErrorMatches, "model agent version is pinned to 2.5.4")
+
+	err = client.PinModelAgentVersion(params.PinModelAgentVersion{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = model.PinnedAgentVersion()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *serverSuite) TestPinModelAgentVersionControllerModel(c *gc.C) {
+	err := s.client.PinModelAgentVersion(params.PinModelAgentVersion{
+		Version: version.MustParse("2.5.4"),
+	})
+	c.Assert(err, gc.ErrorMatches, "cannot pin the agent version of the controller model")
+}
+
+func (s *serverSuite) TestPinModelAgentVersionRequiresAdmin(c *gc.C) {
+	client := s.authClientForState(c, s.State, testing.FakeAuthorizer{
+		Tag: names.NewUserTag("write"),
+	})
+	err := client.PinModelAgentVersion(params.PinModelAgentVersion{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *serverSuite) TestAbortCurrentUpgrade(c *gc.C) {
 	// Create a provisioned controller.
 	machine, err := s.State.AddMachine("series", state.JobManageModel)
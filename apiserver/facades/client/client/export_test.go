@@ -4,6 +4,7 @@
 package client
 
 import (
+	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/environs"
 )
 
@@ -16,3 +17,7 @@ var (
 func SetNewEnviron(c *Client, newEnviron func() (environs.BootstrapEnviron, error)) {
 	c.newEnviron = newEnviron
 }
+
+func SetModelCache(c *Client, controller *cache.Controller) {
+	c.api.modelCache = controller
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/multiwatcher"
@@ -171,7 +172,36 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if err := c.checkCanRead(); err != nil {
 		return params.FullStatus{}, err
 	}
+	// Only admins can see offer details.
+	isAdmin := c.checkIsAdmin() == nil
+
+	if c.api.modelCache == nil {
+		return c.fullStatus(args, isAdmin)
+	}
+	cachedModel, err := c.api.modelCache.Model(c.api.stateAccessor.ModelUUID())
+	if err != nil {
+		// The model has not reached the cache yet.
+		return c.fullStatus(args, isAdmin)
+	}
+	// The status seen depends on the caller's access and the
+	// patterns requested, so both form part of the key.
+	key := fmt.Sprintf("full-status:%t:%s", isAdmin, strings.Join(args.Patterns, " "))
+	result, err := cachedModel.Memo(key, statusMemoMaxAge, func() (interface{}, error) {
+		return c.fullStatus(args, isAdmin)
+	})
+	if err != nil {
+		return params.FullStatus{}, err
+	}
+	return result.(params.FullStatus), nil
+}
+
+// statusMemoMaxAge is the longest time for which the full status of a
+// model is served from the model cache. Changes to the model invalidate
+// the cached status sooner, but agent presence and leadership are not
+// tracked by the cache.
+const statusMemoMaxAge = 5 * time.Second
 
+func (c *Client) fullStatus(args params.StatusParams, isAdmin bool) (params.FullStatus, error) {
 	var noStatus params.FullStatus
 	var context statusContext
 
@@ -200,8 +230,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 		fetchConsumerRemoteApplications(c.api.stateAccessor); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch remote applications")
 	}
-	// Only admins can see offer details.
-	if err := c.checkIsAdmin(); err == nil {
+	if isAdmin {
 		if context.offers, err =
 			fetchOffers(c.api.stateAccessor, context.allAppsUnitsCharmBindings.applications); err != nil {
 			return noStatus, errors.Annotate(err, "could not fetch application offers")
@@ -226,6 +255,9 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if context.controllerTimestamp, err = c.api.stateAccessor.ControllerTimestamp(); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch controller timestamp")
 	}
+	if context.idleResources, err = fetchIdleResources(c.api.stateAccessor, cfg, *context.controllerTimestamp); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch idle resources")
+	}
 
 	logger.Tracef("Applications: %v", context.allAppsUnitsCharmBindings.applications)
 	logger.Tracef("Remote applications: %v", context.consumerRemoteApplications)
@@ -470,6 +502,9 @@ type statusContext struct {
 	// controller current timestamp
 	controllerTimestamp *time.Time
 
+	// idleResources: machine or unit tag -> idle resource
+	idleResources map[string]state.IdleResource
+
 	allAppsUnitsCharmBindings applicationStatusInfo
 	relations                 map[string][]*state.Relation
 	relationsById             map[int]*state.Relation
@@ -478,6 +513,30 @@ type statusContext struct {
 	leaders                   map[string]string
 }
 
+// fetchIdleResources returns a map from machine or unit tag to the
+// idle resources that should be reported at the given time.
+func fetchIdleResources(st Backend, cfg *config.Config, now time.Time) (map[string]state.IdleResource, error) {
+	all, err := st.IdleResources()
+	if err != nil {
+		return nil, err
+	}
+	v := make(map[string]state.IdleResource)
+	for _, r := range common.ReportedIdleResources(all, cfg, now) {
+		v[r.Entity.String()] = r
+	}
+	return v, nil
+}
+
+// idleAnnotation returns a description of the entity's idleness for
+// status, or "" if the entity isn't reported as idle.
+func (context *statusContext) idleAnnotation(tag names.Tag) string {
+	r, ok := context.idleResources[tag.String()]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("idle since %s (%s)", r.Since.Format("2006-01-02"), r.Reason)
+}
+
 // fetchMachines returns a map from top level machine id to machines, where machines[0] is the host
 // machine and machines[1..n] are any containers (including nested ones).
 //
@@ -928,6 +987,14 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine, appStatusInfo
 	}
 	status.LXDProfiles = lxdProfiles
 
+	networkConfigDrift, err := machine.NetworkConfigDrift()
+	if err == nil {
+		status.NetworkConfigDrift = networkConfigDrift
+	} else {
+		logger.Tracef("error fetching network config drift for %s: %q", machine.String(), err.Error())
+	}
+	status.Idle = c.idleAnnotation(machine.MachineTag())
+
 	return
 }
 
@@ -1029,6 +1096,9 @@ func (context *statusContext) processApplication(application *state.Application)
 		CharmVersion: applicationCharm.Version(),
 		CharmProfile: charmProfileName,
 	}
+	for _, addr := range application.LoadBalancerAddresses() {
+		processedStatus.LoadBalancerAddresses = append(processedStatus.LoadBalancerAddresses, addr.Value)
+	}
 
 	if latestCharm, ok := context.allAppsUnitsCharmBindings.latestCharms[*applicationCharm.URL().WithRevision(-1)]; ok && latestCharm != nil {
 		if latestCharm.Revision() > applicationCharm.URL().Revision {
@@ -1277,6 +1347,7 @@ func (context *statusContext) processUnit(unit *state.Unit, applicationCharm str
 	if leader := context.leaders[unit.ApplicationName()]; leader == unit.Name() {
 		result.Leader = true
 	}
+	result.Idle = context.idleAnnotation(unit.UnitTag())
 	containerInfo, err := unit.ContainerInfo()
 	if err != nil && !errors.IsNotFound(err) {
 		logger.Debugf("error fetching container info: %v", err)
@@ -592,6 +592,25 @@ func (s *statusUnitTestSuite) TestMachineWithDisplayNameHasItsDisplayNameSent(c
 	c.Assert(status.Machines[machine.Id()].DisplayName, gc.Equals, "snowflake")
 }
 
+func (s *statusUnitTestSuite) TestIdleResourcesAnnotated(c *gc.C) {
+	idle := s.Factory.MakeMachine(c, nil)
+	recent := s.Factory.MakeMachine(c, nil)
+	since := time.Now().Add(-8 * 24 * time.Hour).UTC()
+	err := s.State.SetIdleResources([]state.IdleResource{
+		{Entity: idle.MachineTag(), Since: since, Reason: "no units or containers"},
+		{Entity: recent.MachineTag(), Since: time.Now(), Reason: "no units or containers"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	client := s.APIState.Client()
+	status, err := client.Status(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status.Machines, gc.HasLen, 2)
+	c.Assert(status.Machines[idle.Id()].Idle, gc.Equals,
+		"idle since "+since.Format("2006-01-02")+" (no units or containers)")
+	c.Assert(status.Machines[recent.Id()].Idle, gc.Equals, "")
+}
+
 func assertApplicationRelations(c *gc.C, appName string, expectedNumber int, relations []params.RelationStatus) {
 	c.Assert(relations, gc.HasLen, expectedNumber)
 	for _, relation := range relations {
@@ -0,0 +1,215 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+)
+
+// upgradeCheck is a validation run by UpgradePrecheck before a model
+// is upgraded.
+type upgradeCheck struct {
+	name        string
+	description string
+
+	// controllerOnly checks are only run against the controller model.
+	controllerOnly bool
+
+	// blocking checks find problems that would prevent a successful
+	// upgrade; the others only warn.
+	blocking bool
+
+	// run returns a description of each problem found.
+	run func(c *Client, target version.Number) ([]string, error)
+}
+
+// upgradeChecks holds the checks run by UpgradePrecheck, in the order
+// they are reported.
+var upgradeChecks = []upgradeCheck{{
+	name:           "mongo-version",
+	description:    "the controller database is supported and not being migrated",
+	controllerOnly: true,
+	blocking:       true,
+	run:            checkMongoVersion,
+}, {
+	name:           "model-migrations",
+	description:    "no models are being migrated",
+	controllerOnly: true,
+	blocking:       true,
+	run:            checkModelMigrations,
+}, {
+	name:        "provider-api",
+	description: "the cloud provider API is reachable",
+	blocking:    true,
+	run:         checkProviderAPIReachable,
+}, {
+	name:        "deprecated-config",
+	description: "the model config does not use deprecated settings",
+	run:         checkDeprecatedConfig,
+}, {
+	name:        "series-support",
+	description: "the target version has agent binaries for every machine",
+	blocking:    true,
+	run:         checkSeriesSupport,
+}}
+
+// UpgradePrecheck runs the checks that would be made before upgrading
+// the model to the given version, without changing anything.
+func (c *Client) UpgradePrecheck(args params.UpgradePrecheckArgs) (params.UpgradePrecheckResults, error) {
+	if err := c.checkCanRead(); err != nil {
+		return params.UpgradePrecheckResults{}, err
+	}
+	isController := c.api.stateAccessor.IsController()
+	var results []params.UpgradePrecheckResult
+	for _, check := range upgradeChecks {
+		if check.controllerOnly && !isController {
+			continue
+		}
+		result := params.UpgradePrecheckResult{
+			Name:        check.name,
+			Description: check.description,
+			Blocking:    check.blocking,
+		}
+		problems, err := check.run(c, args.TargetVersion)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		result.Problems = problems
+		results = append(results, result)
+	}
+	return params.UpgradePrecheckResults{Results: results}, nil
+}
+
+// UpgradePrecheck isn't on the v2 API.
+func (c *ClientV2) UpgradePrecheck() {}
+
+// minimumMongoVersion is the oldest mongo that the controller database
+// can run on.
+var minimumMongoVersion = mongo.Mongo32wt
+
+func checkMongoVersion(c *Client, _ version.Number) ([]string, error) {
+	var problems []string
+	versionString, err := c.api.stateAccessor.MongoVersion()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	running, err := version.Parse(versionString)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing mongo version %q", versionString)
+	}
+	if running.Major < minimumMongoVersion.Major ||
+		running.Major == minimumMongoVersion.Major && running.Minor < minimumMongoVersion.Minor {
+		problems = append(problems, fmt.Sprintf(
+			"mongo %s is older than the minimum supported version %d.%d",
+			versionString, minimumMongoVersion.Major, minimumMongoVersion.Minor))
+	}
+
+	status, err := c.api.stateAccessor.MongoUpgradeStatus()
+	if errors.IsNotFound(err) {
+		return problems, nil
+	} else if err != nil {
+		return problems, errors.Trace(err)
+	}
+	for _, member := range status.Members {
+		if member.Phase == state.MongoUpgradePending || member.Phase == state.MongoUpgradeResyncing {
+			problems = append(problems, fmt.Sprintf(
+				"machine %s is migrating its database to %s", member.MachineId, status.Target))
+		}
+	}
+	return problems, nil
+}
+
+func checkModelMigrations(c *Client, _ version.Number) ([]string, error) {
+	return c.migratingModels()
+}
+
+func checkProviderAPIReachable(c *Client, _ version.Number) ([]string, error) {
+	if err := c.checkProviderAPI(); err != nil {
+		return []string{err.Error()}, nil
+	}
+	return nil, nil
+}
+
+func checkDeprecatedConfig(c *Client, _ version.Number) ([]string, error) {
+	values, err := c.api.stateAccessor.ModelConfigValues()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var problems []string
+	for _, key := range config.DeprecatedAttributes() {
+		if value, ok := values[key]; ok && value.Source == config.JujuModelConfigSource {
+			problems = append(problems, fmt.Sprintf("%q is deprecated", key))
+		}
+	}
+	return problems, nil
+}
+
+// checkSeriesSupport reports the machines whose series and architecture
+// have no agent binaries at the target version. Their agents, and the
+// units they host, could not be upgraded.
+func checkSeriesSupport(c *Client, target version.Number) ([]string, error) {
+	if target == version.Zero {
+		return nil, nil
+	}
+	machines, err := c.api.stateAccessor.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var platforms []string
+	unsupported := make(map[string][]string)
+	available := make(map[string]bool)
+	for _, m := range machines {
+		var arch string
+		if hc, err := m.HardwareCharacteristics(); err == nil && hc.Arch != nil {
+			arch = *hc.Arch
+		} else if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "getting hardware of machine %s", m.Id())
+		}
+		platform := m.Series()
+		if arch != "" {
+			platform += "/" + arch
+		}
+		found, ok := available[platform]
+		if !ok {
+			result, err := c.api.toolsFinder.FindTools(params.FindToolsParams{
+				Number:       target,
+				MajorVersion: target.Major,
+				MinorVersion: target.Minor,
+				Series:       m.Series(),
+				Arch:         arch,
+			})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if result.Error != nil && !params.IsCodeNotFound(result.Error) {
+				return nil, errors.Annotatef(result.Error, "finding agent binaries for %s", platform)
+			}
+			found = result.Error == nil && len(result.List) > 0
+			available[platform] = found
+		}
+		if !found {
+			if len(unsupported[platform]) == 0 {
+				platforms = append(platforms, platform)
+			}
+			unsupported[platform] = append(unsupported[platform], m.Id())
+		}
+	}
+	var problems []string
+	for _, platform := range platforms {
+		problems = append(problems, fmt.Sprintf(
+			"no agent binaries for juju %s on %s, used by machines %s",
+			target, platform, strings.Join(unsupported[platform], ", ")))
+	}
+	return problems, nil
+}
@@ -0,0 +1,156 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	toolstesting "github.com/juju/juju/environs/tools/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *serverSuite) upgradePrecheck(c *gc.C, target string) map[string]params.UpgradePrecheckResult {
+	results, err := s.client.UpgradePrecheck(params.UpgradePrecheckArgs{
+		TargetVersion: version.MustParse(target),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	byName := make(map[string]params.UpgradePrecheckResult)
+	for _, result := range results.Results {
+		byName[result.Name] = result
+	}
+	return byName
+}
+
+func (s *serverSuite) TestUpgradePrecheckControllerModel(c *gc.C) {
+	s.newEnviron = func() (environs.BootstrapEnviron, error) {
+		return &mockEnviron{}, nil
+	}
+	results, err := s.client.UpgradePrecheck(params.UpgradePrecheckArgs{
+		TargetVersion: version.MustParse("9.8.7"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, result := range results.Results {
+		names = append(names, result.Name)
+		c.Check(result.Problems, gc.HasLen, 0, gc.Commentf("check %s", result.Name))
+		c.Check(result.Error, gc.IsNil, gc.Commentf("check %s", result.Name))
+	}
+	c.Assert(names, jc.DeepEquals, []string{
+		"mongo-version",
+		"model-migrations",
+		"provider-api",
+		"deprecated-config",
+		"series-support",
+	})
+}
+
+func (s *serverSuite) TestUpgradePrecheckHostedModelSkipsControllerChecks(c *gc.C) {
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	client := s.clientForState(c, otherSt)
+	s.newEnviron = func() (environs.BootstrapEnviron, error) {
+		return &mockEnviron{}, nil
+	}
+
+	results, err := client.UpgradePrecheck(params.UpgradePrecheckArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	var names []string
+	for _, result := range results.Results {
+		names = append(names, result.Name)
+	}
+	c.Assert(names, jc.DeepEquals, []string{
+		"provider-api",
+		"deprecated-config",
+		"series-support",
+	})
+}
+
+func (s *serverSuite) TestUpgradePrecheckModelMigrations(c *gc.C) {
+	s.newEnviron = func() (environs.BootstrapEnviron, error) {
+		return &mockEnviron{}, nil
+	}
+	s.Factory.MakeUser(c, &factory.UserParams{Name: "some-user"})
+	s.makeMigratingModel(c, "to-migrate", state.MigrationModeExporting)
+
+	result := s.upgradePrecheck(c, "9.8.7")["model-migrations"]
+	c.Assert(result.Blocking, jc.IsTrue)
+	c.Assert(result.Problems, jc.DeepEquals, []string{`model "some-user/to-migrate" is exporting`})
+}
+
+func (s *serverSuite) TestUpgradePrecheckProviderAPI(c *gc.C) {
+	s.newEnviron = func() (environs.BootstrapEnviron, error) {
+		return &mockEnviron{err: errors.New("instances error")}, nil
+	}
+
+	result := s.upgradePrecheck(c, "9.8.7")["provider-api"]
+	c.Assert(result.Blocking, jc.IsTrue)
+	c.Assert(result.Problems, jc.DeepEquals, []string{"cannot make API call to provider: instances error"})
+}
+
+func (s *serverSuite) TestUpgradePrecheckDeprecatedConfig(c *gc.C) {
+	s.newEnviron = func() (environs.BootstrapEnviron, error) {
+		return &mockEnviron{}, nil
+	}
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"ignore-machine-addresses": true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := s.upgradePrecheck(c, "9.8.7")["deprecated-config"]
+	c.Assert(result.Blocking, jc.IsFalse)
+	c.Assert(result.Problems, jc.DeepEquals, []string{`"ignore-machine-addresses" is deprecated`})
+}
+
+func (s *serverSuite) TestUpgradePrecheckSeriesSupport(c *gc.C) {
+	s.newEnviron = func() (environs.BootstrapEnviron, error) {
+		return &mockEnviron{}, nil
+	}
+	toolstesting.UploadToStorage(c, s.DefaultToolsStorage, "released", version.MustParseBinary("9.8.7-quantal-amd64"))
+	s.Factory.MakeMachine(c, &factory.MachineParams{Series: "quantal"})
+	m1 := s.Factory.MakeMachine(c, &factory.MachineParams{Series: "trusty"})
+	m2 := s.Factory.MakeMachine(c, &factory.MachineParams{Series: "trusty"})
+	arch := "arm64"
+	m3 := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Series:          "quantal",
+		Characteristics: &instance.HardwareCharacteristics{Arch: &arch},
+	})
+
+	result := s.upgradePrecheck(c, "9.8.7")["series-support"]
+	c.Assert(result.Blocking, jc.IsTrue)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Problems, jc.DeepEquals, []string{
+		fmt.Sprintf("no agent binaries for juju 9.8.7 on trusty/amd64, used by machines %s, %s", m1.Id(), m2.Id()),
+		fmt.Sprintf("no agent binaries for juju 9.8.7 on quantal/arm64, used by machines %s", m3.Id()),
+	})
+}
+
+func (s *serverSuite) TestUpgradePrecheckReadOnly(c *gc.C) {
+	s.newEnviron = func() (environs.BootstrapEnviron, error) {
+		return &mockEnviron{}, nil
+	}
+	client := s.authClientForState(c, s.State, testing.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	})
+	_, err := client.UpgradePrecheck(params.UpgradePrecheckArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *serverSuite) TestUpgradePrecheckRequiresRead(c *gc.C) {
+	client := s.authClientForState(c, s.State, testing.FakeAuthorizer{
+		Tag: names.NewUserTag("nobody"),
+	})
+	_, err := client.UpgradePrecheck(params.UpgradePrecheckArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -28,6 +28,23 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.cloud")
 
+// CloudV6 defines the methods on the cloud API facade, version 6.
+type CloudV6 interface {
+	AddCloud(cloudArgs params.AddCloudArgs) error
+	AddCredentials(args params.TaggedCredentials) (params.ErrorResults, error)
+	CheckCredentialsModels(args params.TaggedCredentials) (params.UpdateCredentialResults, error)
+	Cloud(args params.Entities) (params.CloudResults, error)
+	CloudCapabilities(args params.Entities) (params.CloudCapabilitiesResults, error)
+	Clouds() (params.CloudsResult, error)
+	Credential(args params.Entities) (params.CloudCredentialResults, error)
+	CredentialContents(credentialArgs params.CloudCredentialArgs) (params.CredentialContentResults, error)
+	ModifyCloudAccess(args params.ModifyCloudAccessRequest) (params.ErrorResults, error)
+	RevokeCredentialsCheckModels(args params.RevokeCredentialArgs) (params.ErrorResults, error)
+	UpdateCredentialsCheckModels(args params.UpdateCredentialArgs) (params.UpdateCredentialResults, error)
+	UserCredentials(args params.UserClouds) (params.StringsResults, error)
+	UpdateCloud(cloudArgs params.UpdateCloudArgs) (params.ErrorResults, error)
+}
+
 // CloudV5 defines the methods on the cloud API facade, version 5.
 type CloudV5 interface {
 	AddCloud(cloudArgs params.AddCloudArgs) error
@@ -115,10 +132,16 @@ type CloudAPI struct {
 	pool                   ModelPoolBackend
 }
 
+// CloudAPIV5 provides a way to wrap the different calls
+// between version 5 and version 6 of the cloud API.
+type CloudAPIV5 struct {
+	*CloudAPI
+}
+
 // CloudAPIV4 provides a way to wrap the different calls
 // between version 4 and version 5 of the cloud API.
 type CloudAPIV4 struct {
-	*CloudAPI
+	*CloudAPIV5
 }
 
 // CloudAPIV3 provides a way to wrap the different calls
@@ -140,21 +163,31 @@ type CloudAPIV1 struct {
 }
 
 var (
-	_ CloudV5 = (*CloudAPI)(nil)
+	_ CloudV6 = (*CloudAPI)(nil)
+	_ CloudV5 = (*CloudAPIV5)(nil)
 	_ CloudV4 = (*CloudAPIV4)(nil)
 	_ CloudV3 = (*CloudAPIV3)(nil)
 	_ CloudV2 = (*CloudAPIV2)(nil)
 	_ CloudV1 = (*CloudAPIV1)(nil)
 )
 
-// NewFacadeV5 is used for API registration.
-func NewFacadeV5(context facade.Context) (*CloudAPI, error) {
+// NewFacadeV6 is used for API registration.
+func NewFacadeV6(context facade.Context) (*CloudAPI, error) {
 	st := NewStateBackend(context.State())
 	pool := NewModelPoolBackend(context.StatePool())
 	ctlrSt := NewStateBackend(pool.SystemState())
 	return NewCloudAPI(st, ctlrSt, pool, context.Auth(), state.CallContext(context.State()))
 }
 
+// NewFacadeV5 is used for API registration.
+func NewFacadeV5(context facade.Context) (*CloudAPIV5, error) {
+	v6, err := NewFacadeV6(context)
+	if err != nil {
+		return nil, err
+	}
+	return &CloudAPIV5{v6}, nil
+}
+
 // NewFacadeV3 is used for API registration.
 func NewFacadeV4(context facade.Context) (*CloudAPIV4, error) {
 	v5, err := NewFacadeV5(context)
@@ -331,6 +364,56 @@ func (api *CloudAPI) CloudInfo(args params.Entities) (params.CloudInfoResults, e
 	return results, nil
 }
 
+// CloudCapabilities returns the optional features, such as spaces,
+// availability zones and load balancers, that the providers of the
+// specified clouds support.
+func (api *CloudAPI) CloudCapabilities(args params.Entities) (params.CloudCapabilitiesResults, error) {
+	results := params.CloudCapabilitiesResults{
+		Results: make([]params.CloudCapabilitiesResult, len(args.Entities)),
+	}
+	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.ctlrBackend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return results, errors.Trace(err)
+	}
+	one := func(arg params.Entity) (*params.CloudCapabilities, error) {
+		tag, err := names.ParseCloudTag(arg.Tag)
+		if err != nil {
+			return nil, err
+		}
+		if !isAdmin {
+			canAccess, err := api.canAccessCloud(tag.Id(), api.apiUser, permission.AddModelAccess)
+			if err != nil {
+				return nil, err
+			}
+			if !canAccess {
+				return nil, errors.NotFoundf("cloud %q", tag.Id())
+			}
+		}
+		aCloud, err := api.backend.Cloud(tag.Id())
+		if err != nil {
+			return nil, err
+		}
+		capabilities, reported, err := environs.ProviderCapabilities(aCloud.Type)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result := &params.CloudCapabilities{Reported: reported}
+		for _, capability := range capabilities {
+			result.Capabilities = append(result.Capabilities, string(capability))
+		}
+		return result, nil
+	}
+	for i, arg := range args.Entities {
+		capabilities, err := one(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = capabilities
+	}
+	return results, nil
+}
+
 func cloudToParams(cloud cloud.Cloud) params.CloudDetails {
 	authTypes := make([]string, len(cloud.AuthTypes))
 	for i, authType := range cloud.AuthTypes {
@@ -699,6 +782,9 @@ func (*CloudAPIV2) CheckCredentialsModels(_, _ struct{}) {}
 // DefaultCloud is gone in V5.
 func (*CloudAPI) DefaultCloud(_, _ struct{}) {}
 
+// CloudCapabilities did not exist before V6.
+func (*CloudAPIV5) CloudCapabilities(_, _ struct{}) {}
+
 // UpdateCredentials updates a set of cloud credentials' content.
 func (api *CloudAPIV2) UpdateCredentials(args params.TaggedCredentials) (params.ErrorResults, error) {
 	results := params.ErrorResults{
@@ -23,6 +23,7 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	_ "github.com/juju/juju/provider/dummy"
+	_ "github.com/juju/juju/provider/ec2"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -128,6 +129,37 @@ func (s *cloudSuite) TestCloudNotFound(c *gc.C) {
 	c.Assert(results.Results[0].Error, gc.ErrorMatches, "cloud \"no-dice\" not found")
 }
 
+func (s *cloudSuite) TestCloudCapabilities(c *gc.C) {
+	s.backend.cloud.Type = "ec2"
+	results, err := s.api.CloudCapabilities(params.Entities{
+		Entities: []params.Entity{{Tag: "cloud-my-cloud"}, {Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCalls(c, []gitjujutesting.StubCall{
+		{"Cloud", []interface{}{"my-cloud"}},
+	})
+	c.Assert(results.Results, jc.DeepEquals, []params.CloudCapabilitiesResult{{
+		Result: &params.CloudCapabilities{
+			Reported: true,
+			Capabilities: []string{
+				"availability-zones", "block-storage", "dns-zones", "load-balancers", "spaces",
+			},
+		},
+	}, {
+		Error: &params.Error{Message: `"machine-0" is not a valid cloud tag`},
+	}})
+}
+
+func (s *cloudSuite) TestCloudCapabilitiesNotReported(c *gc.C) {
+	results, err := s.api.CloudCapabilities(params.Entities{
+		Entities: []params.Entity{{Tag: "cloud-my-cloud"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.CloudCapabilitiesResult{{
+		Result: &params.CloudCapabilities{},
+	}})
+}
+
 func (s *cloudSuite) TestClouds(c *gc.C) {
 	s.setTestAPIForUser(c, names.NewUserTag("bruce"))
 	s.ctlrBackend.cloudAccess = permission.AddModelAccess
@@ -23,10 +23,12 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	corecontroller "github.com/juju/juju/controller"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.controller")
@@ -44,12 +46,64 @@ type ControllerAPI struct {
 	resources  facade.Resources
 	presence   facade.Presence
 	hub        facade.Hub
+
+	// newModelStatusAPI returns the API used to get the full status
+	// of a hosted model.
+	newModelStatusAPI func(*state.State) (modelStatusAPI, error)
+}
+
+// ControllerAPIv13 provides the v13 Controller API. The only difference
+// between this and v14 is that v13 doesn't support migrating models to
+// another region.
+type ControllerAPIv13 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv12 provides the v12 Controller API. The only difference
+// between this and v13 is that v12 doesn't have the ListQuotas and
+// SetQuotas methods.
+type ControllerAPIv12 struct {
+	*ControllerAPIv13
+}
+
+// ControllerAPIv11 provides the v11 Controller API. The only difference
+// between this and v12 is that v11 doesn't have the ListSessions and
+// RevokeSessions methods.
+type ControllerAPIv11 struct {
+	*ControllerAPIv12
+}
+
+// ControllerAPIv10 provides the v10 Controller API. The only difference
+// between this and v11 is that v10 doesn't have the ModelsFullStatus
+// method.
+type ControllerAPIv10 struct {
+	*ControllerAPIv11
+}
+
+// ControllerAPIv9 provides the v9 Controller API. The only difference
+// between this and v10 is that v9 doesn't have the DashboardSummary
+// method.
+type ControllerAPIv9 struct {
+	*ControllerAPIv10
+}
+
+// ControllerAPIv8 provides the v8 Controller API. The only difference
+// between this and v9 is that v8 doesn't have the staged upgrade
+// methods.
+type ControllerAPIv8 struct {
+	*ControllerAPIv9
+}
+
+// ControllerAPIv7 provides the v7 Controller API. The only difference
+// between this and v8 is that v7 doesn't have the CompactRaftLogs method.
+type ControllerAPIv7 struct {
+	*ControllerAPIv8
 }
 
 // ControllerAPIv6 provides the v6 Controller API. The only difference
 // between this and v7 is that v6 doesn't have the IdentityProviderURL method.
 type ControllerAPIv6 struct {
-	*ControllerAPI
+	*ControllerAPIv7
 }
 
 // ControllerAPIv5 provides the v5 Controller API. The only difference
@@ -70,8 +124,8 @@ type ControllerAPIv3 struct {
 	*ControllerAPIv4
 }
 
-// NewControllerAPIv7 creates a new ControllerAPIv7.
-func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv14 creates a new ControllerAPIv14.
+func NewControllerAPIv14(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -79,7 +133,7 @@ func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
 	presence := ctx.Presence()
 	hub := ctx.Hub()
 
-	return NewControllerAPI(
+	api, err := NewControllerAPI(
 		st,
 		pool,
 		authorizer,
@@ -87,6 +141,76 @@ func NewControllerAPIv7(ctx facade.Context) (*ControllerAPI, error) {
 		presence,
 		hub,
 	)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.newModelStatusAPI = func(st *state.State) (modelStatusAPI, error) {
+		return newModelStatusAPI(modelContext{Context: ctx, st: st})
+	}
+	return api, nil
+}
+
+// NewControllerAPIv13 creates a new ControllerAPIv13.
+func NewControllerAPIv13(ctx facade.Context) (*ControllerAPIv13, error) {
+	v14, err := NewControllerAPIv14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv13{v14}, nil
+}
+
+// NewControllerAPIv12 creates a new ControllerAPIv12.
+func NewControllerAPIv12(ctx facade.Context) (*ControllerAPIv12, error) {
+	v13, err := NewControllerAPIv13(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv12{v13}, nil
+}
+
+// NewControllerAPIv11 creates a new ControllerAPIv11.
+func NewControllerAPIv11(ctx facade.Context) (*ControllerAPIv11, error) {
+	v12, err := NewControllerAPIv12(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv11{v12}, nil
+}
+
+// NewControllerAPIv10 creates a new ControllerAPIv10.
+func NewControllerAPIv10(ctx facade.Context) (*ControllerAPIv10, error) {
+	v11, err := NewControllerAPIv11(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv10{v11}, nil
+}
+
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPIv9, error) {
+	v10, err := NewControllerAPIv10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv9{v10}, nil
+}
+
+// NewControllerAPIv8 creates a new ControllerAPIv8.
+func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
+	v9, err := NewControllerAPIv9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv8{v9}, nil
+}
+
+// NewControllerAPIv7 creates a new ControllerAPIv7.
+func NewControllerAPIv7(ctx facade.Context) (*ControllerAPIv7, error) {
+	v8, err := NewControllerAPIv8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv7{v8}, nil
 }
 
 // NewControllerAPIv6 creates a new ControllerAPIv6.
@@ -500,6 +624,18 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 	return out, nil
 }
 
+// InitiateMigration on the v13 API doesn't support migrating models
+// to another region, so any target region or mode is ignored.
+func (c *ControllerAPIv13) InitiateMigration(reqArgs params.InitiateMigrationArgs) (
+	params.InitiateMigrationResults, error,
+) {
+	for i := range reqArgs.Specs {
+		reqArgs.Specs[i].TargetRegion = ""
+		reqArgs.Specs[i].Mode = ""
+	}
+	return c.ControllerAPI.InitiateMigration(reqArgs)
+}
+
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
 	modelTag, err := names.ParseModelTag(spec.ModelTag)
 	if err != nil {
@@ -543,6 +679,19 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Password:      specTarget.Password,
 		Macaroons:     macs,
 	}
+	mode, err := coremigration.ParseMode(spec.Mode)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+
+	// Moving a model to another region needs a provider that can
+	// snapshot the model's instances. Without one, the migration would
+	// only fail once it is past the point of no return.
+	if spec.TargetRegion != "" {
+		if err := checkRelocationSupported(hostedState.State); err != nil {
+			return "", errors.Annotatef(err, "cannot move model to region %q", spec.TargetRegion)
+		}
+	}
 
 	// Check if the migration is likely to succeed.
 	if err := runMigrationPrechecks(hostedState.State, c.statePool.SystemState(), &targetInfo, c.presence); err != nil {
@@ -551,8 +700,10 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 
 	// Trigger the migration.
 	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
+		InitiatedBy:  c.apiUser,
+		TargetInfo:   targetInfo,
+		TargetRegion: spec.TargetRegion,
+		Mode:         mode,
 	})
 	if err != nil {
 		return "", errors.Trace(err)
@@ -634,6 +785,20 @@ func (c *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
 // ConfigSet isn't on the v4 API.
 func (c *ControllerAPIv4) ConfigSet(_, _ struct{}) {}
 
+// checkRelocationSupported returns an error satisfying
+// errors.IsNotSupported if the provider of the model can't
+// re-provision its machines in another region.
+var checkRelocationSupported = func(st *state.State) error {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := env.(environs.InstanceSnapshotter); !ok {
+		return errors.NotSupportedf("snapshotting instances")
+	}
+	return nil
+}
+
 // runMigrationPrechecks runs prechecks on the migration and updates
 // information in targetInfo as needed based on information
 // retrieved from the target controller.
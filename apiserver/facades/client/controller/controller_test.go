@@ -25,6 +25,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
 	corecontroller "github.com/juju/juju/controller"
+	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
@@ -64,7 +65,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 	}
 	s.hub = pubsub.NewStructuredHub(nil)
 
-	controller, err := controller.NewControllerAPIv7(
+	controller, err := controller.NewControllerAPIv14(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -454,6 +455,140 @@ func (s *controllerSuite) TestInitiateMigration(c *gc.C) {
 	}
 }
 
+func (s *controllerSuite) TestInitiateMigrationToRegion(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetPrecheckResult(s, nil)
+	controller.SetRelocationSupported(s, nil)
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+			TargetRegion: "nether-region",
+			Mode:         "copy",
+		}},
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.TargetRegion(), gc.Equals, "nether-region")
+	c.Check(mig.Mode(), gc.Equals, coremigration.ModeCopy)
+}
+
+func (s *controllerSuite) TestInitiateMigrationToRegionNotSupported(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetPrecheckResult(s, nil)
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The dummy provider can't snapshot instances.
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+			TargetRegion: "nether-region",
+		}},
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, `cannot move model to region "nether-region": snapshotting instances not supported`)
+
+	_, err = st.LatestMigration()
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *controllerSuite) TestInitiateMigrationInvalidMode(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+			Mode: "clone",
+		}},
+	}
+	out, err := s.controller.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, `migration mode "clone" not valid`)
+}
+
+func (s *controllerSuite) TestInitiateMigrationV13IgnoresRegion(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	controller.SetPrecheckResult(s, nil)
+
+	m, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := controller.NewControllerAPIv13(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      s.authorizer,
+			Hub_:       s.hub,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: m.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert",
+				AuthTag:       names.NewUserTag("admin").String(),
+				Password:      "secret",
+			},
+			TargetRegion: "nether-region",
+			Mode:         "copy",
+		}},
+	}
+	out, err := api.InitiateMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Assert(out.Results[0].Error, gc.IsNil)
+
+	mig, err := st.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.TargetRegion(), gc.Equals, "")
+	c.Check(mig.Mode(), gc.Equals, coremigration.ModeMove)
+}
+
 func (s *controllerSuite) TestInitiateMigrationSpecError(c *gc.C) {
 	// Create a hosted model to migrate.
 	st := s.Factory.MakeModel(c, nil)
@@ -0,0 +1,69 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+)
+
+// DashboardSummary returns the aggregated status of each model the
+// user has access to, so that dashboards can show an overview of many
+// models without watching each of them.
+func (c *ControllerAPI) DashboardSummary(args params.DashboardSummaryArgs) (params.DashboardSummaryResult, error) {
+	result := params.DashboardSummaryResult{
+		ApplicationStatus: make(map[string]int),
+		UnitStatus:        make(map[string]int),
+	}
+	summaries, err := c.state.ModelDashboardSummariesForUser(c.apiUser, args.All)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Models = make([]params.ModelDashboardSummary, len(summaries))
+	for i, summary := range summaries {
+		model := params.ModelDashboardSummary{
+			ModelTag: names.NewModelTag(summary.UUID).String(),
+			Name:     summary.Name,
+			Type:     string(summary.Type),
+			OwnerTag: names.NewUserTag(summary.Owner).String(),
+			Life:     params.Life(summary.Life.String()),
+			Status: params.EntityStatus{
+				Status: summary.Status.Status,
+				Info:   summary.Status.Message,
+				Data:   summary.Status.Data,
+				Since:  summary.Status.Since,
+			},
+			ApplicationStatus: statusCounts(summary.ApplicationStatus, result.ApplicationStatus),
+			UnitStatus:        statusCounts(summary.UnitStatus, result.UnitStatus),
+			AgentVersion:      summary.AgentVersion,
+			AvailableVersion:  summary.AvailableVersion,
+		}
+		for _, statusErr := range summary.RecentErrors {
+			model.RecentErrors = append(model.RecentErrors, params.EntityStatusError{
+				Tag:     statusErr.Entity.String(),
+				Message: statusErr.Message,
+				Since:   statusErr.Since,
+			})
+		}
+		result.Models[i] = model
+	}
+	return result, nil
+}
+
+// statusCounts converts the status counts for the API, adding them to
+// the totals.
+func statusCounts(counts map[status.Status]int, totals map[string]int) map[string]int {
+	result := make(map[string]int, len(counts))
+	for s, n := range counts {
+		result[string(s)] = n
+		totals[string(s)] += n
+	}
+	return result
+}
+
+// DashboardSummary isn't on the v9 API.
+func (c *ControllerAPIv9) DashboardSummary() {}
@@ -0,0 +1,72 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/controller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *controllerSuite) TestDashboardSummary(c *gc.C) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Name: "busy"})
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	app := f.MakeApplication(c, nil)
+	f.MakeUnit(c, &factory.UnitParams{Application: app})
+	unit := f.MakeUnit(c, &factory.UnitParams{Application: app})
+	now := time.Now()
+	err := unit.SetStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "hook failed",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.DashboardSummary(params.DashboardSummaryArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.ApplicationStatus, jc.DeepEquals, map[string]int{"unknown": 1})
+	c.Check(result.UnitStatus, jc.DeepEquals, map[string]int{"waiting": 1, "error": 1})
+
+	var busy *params.ModelDashboardSummary
+	for i, model := range result.Models {
+		if model.Name == "busy" {
+			busy = &result.Models[i]
+		}
+	}
+	c.Assert(busy, gc.NotNil)
+	c.Check(busy.ModelTag, gc.Equals, names.NewModelTag(st.ModelUUID()).String())
+	c.Check(busy.OwnerTag, gc.Equals, s.Owner.String())
+	c.Check(busy.UnitStatus, jc.DeepEquals, map[string]int{"waiting": 1, "error": 1})
+	c.Assert(busy.RecentErrors, gc.HasLen, 1)
+	c.Check(busy.RecentErrors[0].Tag, gc.Equals, unit.Tag().String())
+	c.Check(busy.RecentErrors[0].Message, gc.Equals, "hook failed")
+}
+
+func (s *controllerSuite) TestDashboardSummaryOnlyAccessibleModels(c *gc.C) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Name: "hidden"})
+	defer st.Close()
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	endpoint, err := controller.NewControllerAPIv14(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+			Hub_:       s.hub,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := endpoint.DashboardSummary(params.DashboardSummaryArgs{All: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Models, gc.HasLen, 0)
+}
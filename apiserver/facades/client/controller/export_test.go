@@ -4,7 +4,10 @@
 package controller
 
 import (
+	"time"
+
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/state"
 )
@@ -18,3 +21,28 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetRelocationSupported(p patcher, err error) {
+	p.PatchValue(&checkRelocationSupported, func(*state.State) error {
+		return err
+	})
+}
+
+func SetRaftSnapshotTimeout(p patcher, timeout time.Duration) {
+	p.PatchValue(&raftSnapshotTimeout, timeout)
+}
+
+func SetModelFullStatus(p patcher, f func(*state.State, params.StatusParams) (params.FullStatus, error)) {
+	p.PatchValue(&newModelStatusAPI, func(ctx facade.Context) (modelStatusAPI, error) {
+		return fullStatusFunc{st: ctx.State(), f: f}, nil
+	})
+}
+
+type fullStatusFunc struct {
+	st *state.State
+	f  func(*state.State, params.StatusParams) (params.FullStatus, error)
+}
+
+func (f fullStatusFunc) FullStatus(args params.StatusParams) (params.FullStatus, error) {
+	return f.f(f.st, args)
+}
@@ -0,0 +1,97 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/client"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// maxConcurrentModelStatus is the number of models whose status is
+// gathered at once by ModelsFullStatus.
+const maxConcurrentModelStatus = 8
+
+// modelStatusAPI is the part of the Client facade used to get the full
+// status of a model.
+type modelStatusAPI interface {
+	FullStatus(params.StatusParams) (params.FullStatus, error)
+}
+
+// newModelStatusAPI returns the Client facade for the model of the
+// given context. It's a variable so that tests can replace it.
+var newModelStatusAPI = func(ctx facade.Context) (modelStatusAPI, error) {
+	return client.NewFacade(ctx)
+}
+
+// modelContext is a facade.Context for a hosted model, used to make
+// the Client facades of the models whose status is requested.
+type modelContext struct {
+	facade.Context
+	st *state.State
+}
+
+// State is part of the facade.Context interface.
+func (ctx modelContext) State() *state.State {
+	return ctx.st
+}
+
+// ModelsFullStatus returns the full status of each of the given
+// models, filtered by the patterns as for Client.FullStatus. The
+// models' statuses are gathered concurrently, so that a client
+// doesn't have to connect to each model in turn. The user needs read
+// access to each model.
+func (c *ControllerAPI) ModelsFullStatus(args params.ModelsFullStatusArgs) (params.ModelFullStatusResults, error) {
+	results := params.ModelFullStatusResults{
+		Results: make([]params.ModelFullStatusResult, len(args.ModelTags)),
+	}
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentModelStatus)
+	for i, modelTag := range args.ModelTags {
+		results.Results[i].ModelTag = modelTag
+		wg.Add(1)
+		go func(result *params.ModelFullStatusResult) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			status, err := c.modelFullStatus(result.ModelTag, args.Patterns)
+			if err != nil {
+				result.Error = common.ServerError(err)
+				return
+			}
+			result.Result = &status
+		}(&results.Results[i])
+	}
+	wg.Wait()
+	return results, nil
+}
+
+func (c *ControllerAPI) modelFullStatus(tagString string, patterns []string) (params.FullStatus, error) {
+	modelTag, err := names.ParseModelTag(tagString)
+	if err != nil {
+		return params.FullStatus{}, errors.Trace(err)
+	}
+	st, err := c.statePool.Get(modelTag.Id())
+	if err != nil {
+		return params.FullStatus{}, errors.Trace(err)
+	}
+	defer st.Release()
+	api, err := c.newModelStatusAPI(st.State)
+	if err != nil {
+		return params.FullStatus{}, errors.Trace(err)
+	}
+	// The Client facade checks the user can read the model.
+	status, err := api.FullStatus(params.StatusParams{Patterns: patterns})
+	return status, errors.Trace(err)
+}
+
+// ModelsFullStatus isn't on the v10 API.
+func (c *ControllerAPIv10) ModelsFullStatus() {}
@@ -0,0 +1,63 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *controllerSuite) TestModelsFullStatus(c *gc.C) {
+	st1 := s.Factory.MakeModel(c, &factory.ModelParams{Name: "one"})
+	defer st1.Close()
+	st2 := s.Factory.MakeModel(c, &factory.ModelParams{Name: "two"})
+	defer st2.Close()
+	controller.SetModelFullStatus(s, func(st *state.State, args params.StatusParams) (params.FullStatus, error) {
+		c.Check(args.Patterns, jc.DeepEquals, []string{"mysql"})
+		if st.ModelUUID() == st2.ModelUUID() {
+			return params.FullStatus{}, errors.New("boom")
+		}
+		return params.FullStatus{
+			Model: params.ModelStatusInfo{Name: st.ModelUUID()},
+		}, nil
+	})
+
+	tag1 := names.NewModelTag(st1.ModelUUID()).String()
+	tag2 := names.NewModelTag(st2.ModelUUID()).String()
+	results, err := s.controller.ModelsFullStatus(params.ModelsFullStatusArgs{
+		ModelTags: []string{tag1, tag2, "machine-0"},
+		Patterns:  []string{"mysql"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	c.Check(results.Results[0].ModelTag, gc.Equals, tag1)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result, gc.NotNil)
+	c.Check(results.Results[0].Result.Model.Name, gc.Equals, st1.ModelUUID())
+
+	c.Check(results.Results[1].ModelTag, gc.Equals, tag2)
+	c.Check(results.Results[1].Result, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "boom")
+
+	c.Check(results.Results[2].Result, gc.IsNil)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+}
+
+func (s *controllerSuite) TestModelsFullStatusModelNotFound(c *gc.C) {
+	tag := names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d").String()
+	results, err := s.controller.ModelsFullStatus(params.ModelsFullStatusArgs{
+		ModelTags: []string{tag},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.NotNil)
+}
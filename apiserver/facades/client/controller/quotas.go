@@ -0,0 +1,110 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ListQuotas returns the quotas set on models and users, along with
+// the resources each of them currently uses.
+func (c *ControllerAPI) ListQuotas() (params.EntityQuotas, error) {
+	if err := c.checkHasAdmin(); err != nil {
+		return params.EntityQuotas{}, errors.Trace(err)
+	}
+	quotas, err := c.state.AllQuotas()
+	if err != nil {
+		return params.EntityQuotas{}, errors.Trace(err)
+	}
+	results := params.EntityQuotas{
+		Results: make([]params.EntityQuota, 0, len(quotas)),
+	}
+	for tag, quota := range quotas {
+		results.Results = append(results.Results, c.entityQuota(tag, quota))
+	}
+	sort.Slice(results.Results, func(i, j int) bool {
+		return results.Results[i].Tag < results.Results[j].Tag
+	})
+	return results, nil
+}
+
+func (c *ControllerAPI) entityQuota(tag names.Tag, quota state.Quota) params.EntityQuota {
+	result := params.EntityQuota{
+		Tag:  tag.String(),
+		Name: tag.Id(),
+		Quota: params.Quota{
+			Machines:   quota.Machines,
+			Units:      quota.Units,
+			StorageMiB: quota.StorageMiB,
+		},
+	}
+	if tag.Kind() == names.ModelTagKind {
+		model, ph, err := c.statePool.GetModel(tag.Id())
+		if err != nil {
+			result.Error = common.ServerError(err)
+			return result
+		}
+		result.Name = model.Owner().Id() + "/" + model.Name()
+		ph.Release()
+	}
+	usage, err := c.state.QuotaUsage(tag)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result
+	}
+	result.Usage = &params.QuotaUsage{
+		Machines:   usage.Machines,
+		Units:      usage.Units,
+		StorageMiB: usage.StorageMiB,
+	}
+	return result
+}
+
+// SetQuotas sets the quotas of models and users. Setting a zero quota
+// removes any limits.
+func (c *ControllerAPI) SetQuotas(args params.SetQuotasArgs) (params.ErrorResults, error) {
+	if err := c.checkHasAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := c.setQuota(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (c *ControllerAPI) setQuota(arg params.SetQuotaArg) error {
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if tag.Kind() == names.ModelTagKind {
+		_, ph, err := c.statePool.GetModel(tag.Id())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ph.Release()
+	}
+	return c.state.SetQuota(tag, state.Quota{
+		Machines:   arg.Quota.Machines,
+		Units:      arg.Quota.Units,
+		StorageMiB: arg.Quota.StorageMiB,
+	})
+}
+
+// ListQuotas isn't on the v12 API.
+func (c *ControllerAPIv12) ListQuotas() {}
+
+// SetQuotas isn't on the v12 API.
+func (c *ControllerAPIv12) SetQuotas() {}
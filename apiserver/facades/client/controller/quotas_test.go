@@ -0,0 +1,90 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/controller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *controllerSuite) TestSetQuotas(c *gc.C) {
+	modelTag := s.Model.ModelTag()
+	results, err := s.controller.SetQuotas(params.SetQuotasArgs{
+		Args: []params.SetQuotaArg{
+			{Tag: modelTag.String(), Quota: params.Quota{Machines: 5, StorageMiB: 1024}},
+			{Tag: "user-bob", Quota: params.Quota{Units: 10}},
+			{Tag: "machine-0", Quota: params.Quota{Units: 1}},
+			{Tag: "model-deadbeef-0bad-400d-8000-4b1d0d06f00d", Quota: params.Quota{Units: 1}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.IsNil)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `quota for "machine-0" not valid`)
+	c.Check(results.Results[3].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	quota, err := s.State.Quota(modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, gc.Equals, state.Quota{Machines: 5, StorageMiB: 1024})
+	quota, err = s.State.Quota(names.NewUserTag("bob"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, gc.Equals, state.Quota{Units: 10})
+}
+
+func (s *controllerSuite) TestListQuotas(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	err := s.State.SetQuota(s.Model.ModelTag(), state.Quota{Machines: 5})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetQuota(names.NewUserTag("bob"), state.Quota{Units: 10})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.ListQuotas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EntityQuotas{
+		Results: []params.EntityQuota{{
+			Tag:   s.Model.ModelTag().String(),
+			Name:  s.Model.Owner().Id() + "/controller",
+			Quota: params.Quota{Machines: 5},
+			Usage: &params.QuotaUsage{Machines: 1},
+		}, {
+			Tag:   "user-bob",
+			Name:  "bob",
+			Quota: params.Quota{Units: 10},
+			Usage: &params.QuotaUsage{},
+		}},
+	})
+}
+
+func (s *controllerSuite) TestQuotasRequireSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv14(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+			Hub_:       s.hub,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.ListQuotas()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = endpoint.SetQuotas(params.SetQuotasArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,105 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/pubsub/raft"
+)
+
+// raftSnapshotTimeout is how long CompactRaftLogs waits for the
+// controllers to report back. It is patched out in tests.
+var raftSnapshotTimeout = time.Minute
+
+// CompactRaftLogs asks every controller to snapshot its raft log,
+// discarding the entries the snapshot covers, and reports the disk
+// space each raft node uses afterwards.
+func (c *ControllerAPI) CompactRaftLogs() (params.RaftStorageResults, error) {
+	if err := c.checkHasAdmin(); err != nil {
+		return params.RaftStorageResults{}, errors.Trace(err)
+	}
+	info, err := c.state.ControllerInfo()
+	if err != nil {
+		return params.RaftStorageResults{}, errors.Trace(err)
+	}
+	requestID, err := utils.NewUUID()
+	if err != nil {
+		return params.RaftStorageResults{}, errors.Trace(err)
+	}
+	responseTopic := "raft.snapshot-response." + requestID.String()
+
+	responses := make(chan raft.SnapshotResponse, len(info.MachineIds))
+	unsubscribe, err := c.hub.Subscribe(
+		responseTopic,
+		func(_ string, resp raft.SnapshotResponse, err error) {
+			if err != nil {
+				logger.Warningf("cannot decode raft snapshot response: %v", err)
+				return
+			}
+			select {
+			case responses <- resp:
+			default:
+			}
+		},
+	)
+	if err != nil {
+		return params.RaftStorageResults{}, errors.Trace(err)
+	}
+	defer unsubscribe()
+
+	if _, err := c.hub.Publish(raft.SnapshotRequestTopic, raft.SnapshotRequest{
+		ResponseTopic: responseTopic,
+	}); err != nil {
+		return params.RaftStorageResults{}, errors.Trace(err)
+	}
+
+	pending := make(map[string]bool)
+	for _, id := range info.MachineIds {
+		pending[id] = true
+	}
+	var results []params.RaftStorageResult
+	timeout := time.After(raftSnapshotTimeout)
+	for len(pending) > 0 {
+		select {
+		case resp := <-responses:
+			if !pending[resp.MachineID] {
+				continue
+			}
+			delete(pending, resp.MachineID)
+			result := params.RaftStorageResult{
+				MachineId:     resp.MachineID,
+				LogBytes:      resp.LogBytes,
+				LogEntries:    resp.LogEntries,
+				SnapshotBytes: resp.SnapshotBytes,
+			}
+			if resp.Error != "" {
+				result.Error = &params.Error{Message: resp.Error}
+			}
+			results = append(results, result)
+		case <-timeout:
+			for id := range pending {
+				results = append(results, params.RaftStorageResult{
+					MachineId: id,
+					Error: common.ServerError(errors.Timeoutf(
+						"waiting for controller %s to snapshot its raft log", id)),
+				})
+			}
+			pending = nil
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].MachineId < results[j].MachineId
+	})
+	return params.RaftStorageResults{Results: results}, nil
+}
+
+// CompactRaftLogs isn't on the v7 API.
+func (c *ControllerAPIv7) CompactRaftLogs() {}
@@ -0,0 +1,82 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/controller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/pubsub/raft"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *controllerSuite) TestCompactRaftLogs(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+
+	unsubscribe, err := s.hub.Subscribe(raft.SnapshotRequestTopic, func(_ string, req raft.SnapshotRequest, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		_, err = s.hub.Publish(req.ResponseTopic, raft.SnapshotResponse{
+			MachineID:     "0",
+			LogBytes:      1024,
+			LogEntries:    12,
+			SnapshotBytes: 2048,
+		})
+		c.Check(err, jc.ErrorIsNil)
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+
+	results, err := s.controller.CompactRaftLogs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.RaftStorageResults{
+		Results: []params.RaftStorageResult{{
+			MachineId:     "0",
+			LogBytes:      1024,
+			LogEntries:    12,
+			SnapshotBytes: 2048,
+		}},
+	})
+}
+
+func (s *controllerSuite) TestCompactRaftLogsTimeout(c *gc.C) {
+	controller.SetRaftSnapshotTimeout(s, 10*time.Millisecond)
+	_, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.controller.CompactRaftLogs()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].MachineId, gc.Equals, "0")
+	c.Check(results.Results[0].Error, gc.ErrorMatches,
+		"waiting for controller 0 to snapshot its raft log timeout")
+}
+
+func (s *controllerSuite) TestCompactRaftLogsRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv14(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+			Hub_:       s.hub,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.CompactRaftLogs()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,108 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/pubsub/apiserver"
+)
+
+// ListSessions returns the API connections that have logged in to any
+// of the controller's API servers.
+func (c *ControllerAPI) ListSessions() (params.APISessionsResult, error) {
+	if err := c.checkHasAdmin(); err != nil {
+		return params.APISessionsResult{}, errors.Trace(err)
+	}
+	sessions, err := c.state.APISessions()
+	if err != nil {
+		return params.APISessionsResult{}, errors.Trace(err)
+	}
+	result := params.APISessionsResult{
+		Sessions: make([]params.APISession, len(sessions)),
+	}
+	for i, session := range sessions {
+		var modelTag string
+		if session.ModelUUID != "" {
+			modelTag = names.NewModelTag(session.ModelUUID).String()
+		}
+		result.Sessions[i] = params.APISession{
+			Server:       session.Server,
+			ConnectionID: session.ConnectionID,
+			Tag:          session.Tag,
+			ModelTag:     modelTag,
+			RemoteAddr:   session.RemoteAddr,
+			LoggedIn:     session.LoggedIn,
+		}
+	}
+	return result, nil
+}
+
+// RevokeSessions closes the API connections identified by the args.
+// The request is published to every API server, so a user's
+// connections are closed wherever they are connected. The macaroons
+// that the user logged in with are revoked too, so that the user must
+// authenticate again to reconnect.
+func (c *ControllerAPI) RevokeSessions(args params.RevokeSessionsArgs) (params.ErrorResults, error) {
+	if err := c.checkHasAdmin(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Sessions)),
+	}
+	for i, arg := range args.Sessions {
+		err := c.revokeSession(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (c *ControllerAPI) revokeSession(arg params.RevokeSession) error {
+	var msg apiserver.CloseSessions
+	switch {
+	case arg.UserTag != "" && arg.Server != "":
+		return errors.NotValidf("specifying both a user and a connection")
+	case arg.UserTag != "":
+		tag, err := names.ParseUserTag(arg.UserTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := c.state.RevokeLogins(tag); err != nil {
+			return errors.Trace(err)
+		}
+		msg.User = tag.String()
+	case arg.Server != "":
+		if !names.IsValidMachine(arg.Server) {
+			return errors.NotValidf("API server %q", arg.Server)
+		}
+		session, err := c.state.APISession(arg.Server, arg.ConnectionID)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// The controller can't tell which of a user's macaroons
+		// the connection logged in with, so all of them are
+		// revoked. Agents log in with passwords, which are
+		// unaffected.
+		if tag, err := names.ParseUserTag(session.Tag); err == nil {
+			if err := c.state.RevokeLogins(tag); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		msg.Server = arg.Server
+		msg.ConnectionIDs = []uint64{arg.ConnectionID}
+	default:
+		return errors.NotValidf("missing user or connection")
+	}
+	_, err := c.hub.Publish(apiserver.CloseSessionsTopic, msg)
+	return errors.Trace(err)
+}
+
+// ListSessions isn't on the v11 API.
+func (c *ControllerAPIv11) ListSessions() {}
+
+// RevokeSessions isn't on the v11 API.
+func (c *ControllerAPIv11) RevokeSessions() {}
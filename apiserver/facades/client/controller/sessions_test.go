@@ -0,0 +1,140 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/controller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *controllerSuite) TestListSessions(c *gc.C) {
+	loggedIn := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.addSessions(c, loggedIn)
+
+	result, err := s.controller.ListSessions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.APISessionsResult{
+		Sessions: []params.APISession{{
+			Server:       "0",
+			ConnectionID: 42,
+			Tag:          "user-bob",
+			ModelTag:     names.NewModelTag(s.State.ModelUUID()).String(),
+			RemoteAddr:   "10.0.0.1:34567",
+			LoggedIn:     loggedIn,
+		}, {
+			Server:       "1",
+			ConnectionID: 7,
+			Tag:          "machine-1",
+			RemoteAddr:   "10.0.0.2:45678",
+			LoggedIn:     loggedIn,
+		}},
+	})
+}
+
+func (s *controllerSuite) addSessions(c *gc.C, loggedIn time.Time) {
+	for _, session := range []state.APISession{{
+		Server:       "0",
+		ConnectionID: 42,
+		Tag:          "user-bob",
+		ModelUUID:    s.State.ModelUUID(),
+		RemoteAddr:   "10.0.0.1:34567",
+		LoggedIn:     loggedIn,
+	}, {
+		Server:       "1",
+		ConnectionID: 7,
+		Tag:          "machine-1",
+		RemoteAddr:   "10.0.0.2:45678",
+		LoggedIn:     loggedIn,
+	}} {
+		err := s.State.AddAPISession(session)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *controllerSuite) TestRevokeSessions(c *gc.C) {
+	messages := make(chan apiserver.CloseSessions, 3)
+	unsubscribe, err := s.hub.Subscribe(apiserver.CloseSessionsTopic, func(_ string, msg apiserver.CloseSessions, err error) {
+		c.Check(err, jc.ErrorIsNil)
+		messages <- msg
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+	s.addSessions(c, time.Now())
+
+	results, err := s.controller.RevokeSessions(params.RevokeSessionsArgs{
+		Sessions: []params.RevokeSession{
+			{Server: "0", ConnectionID: 42},
+			{Server: "1", ConnectionID: 7},
+			{UserTag: "user-mary"},
+			{Server: "0", ConnectionID: 43},
+			{UserTag: "machine-0"},
+			{Server: "0", UserTag: "user-bob"},
+			{},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 7)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.IsNil)
+	c.Check(results.Results[2].Error, gc.IsNil)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `API connection 43 to server "0" not found`)
+	c.Check(results.Results[4].Error, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+	c.Check(results.Results[5].Error, gc.ErrorMatches, "specifying both a user and a connection not valid")
+	c.Check(results.Results[6].Error, gc.ErrorMatches, "missing user or connection not valid")
+
+	// The users' logins are revoked, so they must authenticate again.
+	for _, user := range []string{"bob", "mary"} {
+		revokedAt, err := s.State.LoginsRevokedAt(names.NewUserTag(user))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(revokedAt.IsZero(), jc.IsFalse)
+	}
+
+	for _, expected := range []apiserver.CloseSessions{
+		{Server: "0", ConnectionIDs: []uint64{42}},
+		{Server: "1", ConnectionIDs: []uint64{7}},
+		{User: "user-mary"},
+	} {
+		select {
+		case msg := <-messages:
+			c.Check(msg, jc.DeepEquals, expected)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for close sessions message")
+		}
+	}
+}
+
+func (s *controllerSuite) TestSessionsRequireSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv14(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+			Hub_:       s.hub,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.ListSessions()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = endpoint.RevokeSessions(params.RevokeSessionsArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
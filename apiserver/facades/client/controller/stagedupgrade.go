@@ -0,0 +1,80 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// StartStagedUpgrade starts upgrading the given hosted models to the
+// target version in waves. Each wave has to upgrade cleanly before the
+// next one is started.
+func (c *ControllerAPI) StartStagedUpgrade(args params.StartStagedUpgradeArgs) error {
+	if err := c.checkHasAdmin(); err != nil {
+		return errors.Trace(err)
+	}
+	waves := make([][]string, len(args.Waves))
+	for i, wave := range args.Waves {
+		for _, tagString := range wave {
+			tag, err := names.ParseModelTag(tagString)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			waves[i] = append(waves[i], tag.Id())
+		}
+	}
+	return errors.Trace(c.state.StartStagedUpgrade(args.TargetVersion, waves, args.WaveTimeout))
+}
+
+// StagedUpgradeStatus returns the progress of the latest staged
+// upgrade.
+func (c *ControllerAPI) StagedUpgradeStatus() (params.StagedUpgradeStatusResult, error) {
+	if err := c.checkHasAdmin(); err != nil {
+		return params.StagedUpgradeStatusResult{}, errors.Trace(err)
+	}
+	upgrade, err := c.state.StagedUpgrade()
+	if err != nil {
+		return params.StagedUpgradeStatusResult{Error: common.ServerError(err)}, nil
+	}
+	waves := make([][]string, len(upgrade.Waves))
+	for i, wave := range upgrade.Waves {
+		for _, uuid := range wave {
+			waves[i] = append(waves[i], names.NewModelTag(uuid).String())
+		}
+	}
+	return params.StagedUpgradeStatusResult{
+		Result: &params.StagedUpgradeStatus{
+			TargetVersion: upgrade.Target,
+			Waves:         waves,
+			Wave:          upgrade.Wave,
+			WaveTimeout:   upgrade.WaveTimeout,
+			Status:        string(upgrade.Status),
+			Message:       upgrade.Message,
+			Started:       upgrade.Started,
+			WaveStarted:   upgrade.WaveStarted,
+		},
+	}, nil
+}
+
+// AbortStagedUpgrade stops the running staged upgrade. Models that
+// were already upgraded keep their new version.
+func (c *ControllerAPI) AbortStagedUpgrade() error {
+	if err := c.checkHasAdmin(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.state.AbortStagedUpgrade())
+}
+
+// StartStagedUpgrade isn't on the v8 API.
+func (c *ControllerAPIv8) StartStagedUpgrade() {}
+
+// StagedUpgradeStatus isn't on the v8 API.
+func (c *ControllerAPIv8) StagedUpgradeStatus() {}
+
+// AbortStagedUpgrade isn't on the v8 API.
+func (c *ControllerAPIv8) AbortStagedUpgrade() {}
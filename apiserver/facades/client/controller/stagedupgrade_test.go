@@ -0,0 +1,91 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facade/facadetest"
+	"github.com/juju/juju/apiserver/facades/client/controller"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+func (s *controllerSuite) TestStartStagedUpgrade(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	cfg, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	target, _ := cfg.AgentVersion()
+	modelTag := names.NewModelTag(st.ModelUUID()).String()
+
+	err = s.controller.StartStagedUpgrade(params.StartStagedUpgradeArgs{
+		TargetVersion: target,
+		Waves:         [][]string{{modelTag}},
+		WaveTimeout:   time.Hour,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	upgrade, err := s.State.StagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(upgrade.Target, gc.Equals, target)
+	c.Check(upgrade.Waves, jc.DeepEquals, [][]string{{st.ModelUUID()}})
+
+	result, err := s.controller.StagedUpgradeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.Result.TargetVersion, gc.Equals, target)
+	c.Check(result.Result.Waves, jc.DeepEquals, [][]string{{modelTag}})
+	c.Check(result.Result.Wave, gc.Equals, 0)
+	c.Check(result.Result.WaveTimeout, gc.Equals, time.Hour)
+	c.Check(result.Result.Status, gc.Equals, "running")
+
+	err = s.controller.AbortStagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err = s.State.StagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(upgrade.Status, gc.Equals, state.StagedUpgradeAborted)
+}
+
+func (s *controllerSuite) TestStartStagedUpgradeInvalidTag(c *gc.C) {
+	err := s.controller.StartStagedUpgrade(params.StartStagedUpgradeArgs{
+		Waves:       [][]string{{"machine-0"}},
+		WaveTimeout: time.Hour,
+	})
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+}
+
+func (s *controllerSuite) TestStagedUpgradeStatusNotFound(c *gc.C) {
+	result, err := s.controller.StagedUpgradeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *controllerSuite) TestStagedUpgradeRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	endpoint, err := controller.NewControllerAPIv14(
+		facadetest.Context{
+			State_:     s.State,
+			Resources_: s.resources,
+			Auth_:      apiservertesting.FakeAuthorizer{Tag: user.Tag()},
+			Hub_:       s.hub,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = endpoint.StartStagedUpgrade(params.StartStagedUpgradeArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	_, err = endpoint.StagedUpgradeStatus()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	err = endpoint.AbortStagedUpgrade()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
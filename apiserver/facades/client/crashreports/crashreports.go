@@ -0,0 +1,111 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crashreports implements the API facade used to list and read
+// the crash reports uploaded by the agents of a model.
+package crashreports
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crashreport"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the State API used by the CrashReports facade.
+type Backend interface {
+	ModelUUID() string
+	CrashReports() ([]state.CrashReportInfo, error)
+	CrashReport(id string) (crashreport.Report, error)
+}
+
+// API provides access to the CrashReports API facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new CrashReports API facade.
+func NewAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return newAPI(st, authorizer)
+}
+
+func newAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	allowed, err := api.authorizer.HasPermission(permission.ReadAccess, names.NewModelTag(api.backend.ModelUUID()))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// List returns information about the crash reports uploaded by the
+// agents of the model, oldest first.
+func (api *API) List() (params.CrashReportInfos, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.CrashReportInfos{}, errors.Trace(err)
+	}
+	infos, err := api.backend.CrashReports()
+	if err != nil {
+		return params.CrashReportInfos{}, errors.Trace(err)
+	}
+	result := params.CrashReportInfos{
+		Reports: make([]params.CrashReportInfo, len(infos)),
+	}
+	for i, info := range infos {
+		result.Reports[i] = params.CrashReportInfo{
+			ID:       info.ID,
+			Agent:    info.Agent,
+			Version:  info.Version,
+			Time:     info.Time,
+			Panic:    info.Panic,
+			Size:     info.Size,
+			Uploaded: info.Uploaded,
+		}
+	}
+	return result, nil
+}
+
+// Get returns the crash reports with the given IDs.
+func (api *API) Get(args params.CrashReportIDs) (params.CrashReportResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.CrashReportResults{}, errors.Trace(err)
+	}
+	results := params.CrashReportResults{
+		Results: make([]params.CrashReportResult, len(args.IDs)),
+	}
+	for i, id := range args.IDs {
+		r, err := api.backend.CrashReport(id)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Report = &params.CrashReport{
+			ID:         r.ID,
+			Agent:      r.Agent,
+			Version:    r.Version,
+			Time:       r.Time,
+			Panic:      r.Panic,
+			Goroutines: r.Goroutines,
+			LogTail:    r.LogTail,
+		}
+	}
+	return results, nil
+}
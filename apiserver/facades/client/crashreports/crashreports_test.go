@@ -0,0 +1,150 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreports_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/crashreports"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/crashreport"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+)
+
+type crashReportsSuite struct {
+	testing.BaseSuite
+	backend    *mockBackend
+	authorizer *apiservertesting.FakeAuthorizer
+	api        *crashreports.API
+}
+
+var _ = gc.Suite(&crashReportsSuite{})
+
+var crashTime = time.Date(2019, 5, 3, 10, 11, 12, 0, time.UTC)
+
+func (s *crashReportsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.authorizer = &apiservertesting.FakeAuthorizer{Tag: names.NewUserTag("read")}
+	api, err := crashreports.NewAPIForTest(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *crashReportsSuite) TestNewAPINotClient(c *gc.C) {
+	authorizer := &apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")}
+	_, err := crashreports.NewAPIForTest(s.backend, authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *crashReportsSuite) TestList(c *gc.C) {
+	s.backend.infos = []state.CrashReportInfo{{
+		ID:       "20190503-101112-deadbeef",
+		Agent:    "machine-0",
+		Version:  "2.6.1",
+		Panic:    "panic: boom",
+		Time:     crashTime,
+		Size:     1024,
+		Uploaded: crashTime.Add(time.Minute),
+	}}
+	result, err := s.api.List()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CrashReportInfos{
+		Reports: []params.CrashReportInfo{{
+			ID:       "20190503-101112-deadbeef",
+			Agent:    "machine-0",
+			Version:  "2.6.1",
+			Panic:    "panic: boom",
+			Time:     crashTime,
+			Size:     1024,
+			Uploaded: crashTime.Add(time.Minute),
+		}},
+	})
+	s.backend.stub.CheckCallNames(c, "ModelUUID", "CrashReports")
+}
+
+func (s *crashReportsSuite) TestListPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.api.List()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.stub.CheckCallNames(c, "ModelUUID")
+}
+
+func (s *crashReportsSuite) TestGet(c *gc.C) {
+	s.backend.stub.SetErrors(nil, nil, errors.NotFoundf(`crash report "missing"`))
+	result, err := s.api.Get(params.CrashReportIDs{
+		IDs: []string{"20190503-101112-deadbeef", "missing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.CrashReportResults{
+		Results: []params.CrashReportResult{{
+			Report: &params.CrashReport{
+				ID:         "20190503-101112-deadbeef",
+				Agent:      "machine-0",
+				Version:    "2.6.1",
+				Time:       crashTime,
+				Panic:      "panic: boom",
+				Goroutines: "goroutine 1 [running]:",
+				LogTail:    "log\n",
+			},
+		}, {
+			Error: &params.Error{
+				Message: `crash report "missing" not found`,
+				Code:    params.CodeNotFound,
+			},
+		}},
+	})
+	s.backend.stub.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelUUID", nil},
+		{"CrashReport", []interface{}{"20190503-101112-deadbeef"}},
+		{"CrashReport", []interface{}{"missing"}},
+	})
+}
+
+func (s *crashReportsSuite) TestGetPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.api.Get(params.CrashReportIDs{IDs: []string{"20190503-101112-deadbeef"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type mockBackend struct {
+	stub  jujutesting.Stub
+	infos []state.CrashReportInfo
+}
+
+func (b *mockBackend) ModelUUID() string {
+	b.stub.AddCall("ModelUUID")
+	b.stub.PopNoErr()
+	return testing.ModelTag.Id()
+}
+
+func (b *mockBackend) CrashReports() ([]state.CrashReportInfo, error) {
+	b.stub.AddCall("CrashReports")
+	return b.infos, b.stub.NextErr()
+}
+
+func (b *mockBackend) CrashReport(id string) (crashreport.Report, error) {
+	b.stub.AddCall("CrashReport", id)
+	if err := b.stub.NextErr(); err != nil {
+		return crashreport.Report{}, err
+	}
+	return crashreport.Report{
+		ID:         id,
+		Agent:      "machine-0",
+		Version:    "2.6.1",
+		Time:       crashTime,
+		Panic:      "panic: boom",
+		Goroutines: "goroutine 1 [running]:",
+		LogTail:    "log\n",
+	}, nil
+}
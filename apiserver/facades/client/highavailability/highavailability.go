@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -326,3 +327,112 @@ func (api *HighAvailabilityAPI) StopHAReplicationForUpgrade(args params.UpgradeM
 func (api *HighAvailabilityAPI) ResumeHAReplicationAfterUpgrade(args params.ResumeReplicationParams) error {
 	return api.state.ResumeReplication(args.Members)
 }
+
+// HighAvailabilityAPIV3 implements version 3 of the HighAvailability
+// facade, which adds the migration of the controller's database to a
+// new storage engine.
+type HighAvailabilityAPIV3 struct {
+	*HighAvailabilityAPI
+	clock clock.Clock
+}
+
+// NewHighAvailabilityAPIV3 creates a new server-side highavailability
+// API end point, version 3.
+func NewHighAvailabilityAPIV3(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*HighAvailabilityAPIV3, error) {
+	api, err := NewHighAvailabilityAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &HighAvailabilityAPIV3{api, clock.WallClock}, nil
+}
+
+func (api *HighAvailabilityAPIV3) checkIsSuperuser() error {
+	admin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.state.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !admin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// CheckMongoUpgrade reports whether the controller's database can be
+// migrated to the target version and storage engine, and estimates
+// how long the migration would take.
+func (api *HighAvailabilityAPIV3) CheckMongoUpgrade(args params.UpgradeMongoParams) (params.MongoUpgradeCheckResult, error) {
+	if err := api.checkIsSuperuser(); err != nil {
+		return params.MongoUpgradeCheckResult{}, errors.Trace(err)
+	}
+	check, err := api.state.CheckMongoUpgrade(fromParamsMongoVersion(args.Target))
+	if err != nil {
+		return params.MongoUpgradeCheckResult{}, errors.Trace(err)
+	}
+	return params.MongoUpgradeCheckResult{
+		Current:           toParamsMongoVersion(check.Current),
+		Target:            toParamsMongoVersion(check.Target),
+		DataSize:          check.DataSize,
+		Members:           check.Members,
+		EstimatedDuration: check.Estimate.Duration,
+		EstimatedDowntime: check.Estimate.Downtime,
+		Problems:          check.Problems,
+	}, nil
+}
+
+// StartMongoUpgrade requests that the controller's database is
+// migrated to the target version and storage engine. The controllers
+// start migrating their databases, one at a time, straight away.
+func (api *HighAvailabilityAPIV3) StartMongoUpgrade(args params.UpgradeMongoParams) error {
+	if err := api.checkIsSuperuser(); err != nil {
+		return errors.Trace(err)
+	}
+	return api.state.StartMongoUpgrade(fromParamsMongoVersion(args.Target))
+}
+
+// MongoUpgradeStatus returns the progress of the latest migration of
+// the controller's database.
+func (api *HighAvailabilityAPIV3) MongoUpgradeStatus() (params.MongoUpgradeStatusResult, error) {
+	if err := api.checkIsSuperuser(); err != nil {
+		return params.MongoUpgradeStatusResult{}, errors.Trace(err)
+	}
+	status, err := api.state.MongoUpgradeStatus()
+	if err != nil {
+		return params.MongoUpgradeStatusResult{}, errors.Trace(err)
+	}
+	result := params.MongoUpgradeStatusResult{
+		Target:             toParamsMongoVersion(status.Target),
+		Started:            status.Started,
+		DataSize:           status.DataSize,
+		EstimatedDuration:  status.Estimate.Duration,
+		EstimatedDowntime:  status.Estimate.Downtime,
+		EstimatedRemaining: status.Remaining(api.clock.Now()),
+		Members:            make([]params.MongoUpgradeMemberStatus, len(status.Members)),
+	}
+	for i, member := range status.Members {
+		result.Members[i] = params.MongoUpgradeMemberStatus{
+			MachineId: member.MachineId,
+			Phase:     string(member.Phase),
+			Message:   member.Message,
+			Updated:   member.Updated,
+		}
+	}
+	return result, nil
+}
+
+func fromParamsMongoVersion(v params.MongoVersion) mongo.Version {
+	return mongo.Version{
+		Major:         v.Major,
+		Minor:         v.Minor,
+		Patch:         v.Patch,
+		StorageEngine: mongo.StorageEngine(v.StorageEngine),
+	}
+}
+
+func toParamsMongoVersion(v mongo.Version) params.MongoVersion {
+	return params.MongoVersion{
+		Major:         v.Major,
+		Minor:         v.Minor,
+		Patch:         v.Patch,
+		StorageEngine: string(v.StorageEngine),
+	}
+}
@@ -607,3 +607,27 @@ func (s *clientSuite) TestHighAvailabilityCAASFails(c *gc.C) {
 	_, err := highavailability.NewHighAvailabilityAPI(st, s.resources, s.authoriser)
 	c.Assert(err, gc.ErrorMatches, "high availability on kubernetes controllers not supported")
 }
+
+func (s *clientSuite) TestMongoUpgradeStatusNotStarted(c *gc.C) {
+	api, err := highavailability.NewHighAvailabilityAPIV3(s.State, s.resources, s.authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.MongoUpgradeStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *clientSuite) TestMongoUpgradeRequiresSuperuser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{NoModelUser: true})
+	authoriser := apiservertesting.FakeAuthorizer{Tag: user.UserTag()}
+	api, err := highavailability.NewHighAvailabilityAPIV3(s.State, s.resources, authoriser)
+	c.Assert(err, jc.ErrorIsNil)
+
+	target := params.UpgradeMongoParams{Target: params.MongoVersion{
+		Major: 3, Minor: 6, StorageEngine: "wiredTiger",
+	}}
+	_, err = api.CheckMongoUpgrade(target)
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+	err = api.StartMongoUpgrade(target)
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+	_, err = api.MongoUpgradeStatus()
+	c.Check(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
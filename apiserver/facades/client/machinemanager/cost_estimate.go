@@ -0,0 +1,76 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+)
+
+// hoursPerMonth is the average number of hours in a month, used to
+// turn hourly costs into monthly ones.
+const hoursPerMonth = 730
+
+// EstimateCosts returns the estimated cost of running new machines
+// with the given constraints, using the pricing of the model's cloud.
+func (mm *MachineManagerAPI) EstimateCosts(args params.CostEstimateArgs) (params.CostEstimateResults, error) {
+	return estimateCosts(mm, environs.GetEnviron, args)
+}
+
+func estimateCosts(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.CostEstimateArgs,
+) (params.CostEstimateResults, error) {
+	if err := mm.checkCanRead(); err != nil {
+		return params.CostEstimateResults{}, errors.Trace(err)
+	}
+	env, err := mm.environ(getEnviron)
+	if err != nil {
+		return params.CostEstimateResults{}, errors.Trace(err)
+	}
+	pricer, ok := env.(environs.InstancePricer)
+	if !ok {
+		return params.CostEstimateResults{}, errors.NotSupportedf("estimating costs on this cloud")
+	}
+
+	results := params.CostEstimateResults{
+		Results: make([]params.CostEstimateResult, len(args.Estimates)),
+	}
+	for i, arg := range args.Estimates {
+		result, err := mm.estimateCost(pricer, arg)
+		if err != nil {
+			result = params.CostEstimateResult{Error: common.ServerError(err)}
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) estimateCost(pricer environs.InstancePricer, arg params.CostEstimateArg) (params.CostEstimateResult, error) {
+	if arg.Count < 1 {
+		return params.CostEstimateResult{}, errors.NotValidf("machine count %d", arg.Count)
+	}
+	cons, err := mm.st.ResolveConstraints(arg.Constraints)
+	if err != nil {
+		return params.CostEstimateResult{}, errors.Trace(err)
+	}
+	pricing, err := pricer.InstancePricing(mm.callContext, cons)
+	if err != nil {
+		return params.CostEstimateResult{}, errors.Trace(err)
+	}
+	return params.CostEstimateResult{
+		InstanceType: pricing.InstanceType,
+		Count:        arg.Count,
+		HourlyCost:   pricing.HourlyCost,
+		MonthlyCost:  pricing.HourlyCost * hoursPerMonth * float64(arg.Count),
+		Currency:     pricing.Currency,
+	}, nil
+}
+
+// EstimateCosts isn't on the v5 API.
+func (mm *MachineManagerAPIV5) EstimateCosts(_, _ struct{}) {}
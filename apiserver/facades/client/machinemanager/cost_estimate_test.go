@@ -0,0 +1,94 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+type costEstimateSuite struct{}
+
+var _ = gc.Suite(&costEstimateSuite{})
+
+func (s *costEstimateSuite) api(c *gc.C, backend *mockBackend) *machinemanager.MachineManagerAPI {
+	authorizer := testing.FakeAuthorizer{Tag: names.NewUserTag("admin")}
+	api, err := machinemanager.NewMachineManagerAPI(backend, backend, &mockPool{}, authorizer, backend.ModelTag(), context.NewCloudCallContext(), common.NewResources())
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *costEstimateSuite) TestEstimateCosts(c *gc.C) {
+	backend := &mockBackend{
+		modelCons: constraints.MustParse("mem=4G"),
+	}
+	env := &mockPricingEnviron{
+		pricing: map[string]environs.InstancePricing{
+			"mem=4096M":         {InstanceType: "m5.large", HourlyCost: 0.5, Currency: "USD"},
+			"cores=8 mem=4096M": {InstanceType: "c5.2xlarge", HourlyCost: 0.25, Currency: "USD"},
+		},
+	}
+	fakeEnvironGet := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+
+	results, err := machinemanager.EstimateCosts(s.api(c, backend), fakeEnvironGet, params.CostEstimateArgs{
+		Estimates: []params.CostEstimateArg{
+			{Count: 2},
+			{Constraints: constraints.MustParse("cores=8"), Count: 1},
+			{Constraints: constraints.MustParse("cores=64"), Count: 1},
+			{Count: 0},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0], jc.DeepEquals, params.CostEstimateResult{
+		InstanceType: "m5.large",
+		Count:        2,
+		HourlyCost:   0.5,
+		MonthlyCost:  0.5 * 730 * 2,
+		Currency:     "USD",
+	})
+	c.Check(results.Results[1], jc.DeepEquals, params.CostEstimateResult{
+		InstanceType: "c5.2xlarge",
+		Count:        1,
+		HourlyCost:   0.25,
+		MonthlyCost:  0.25 * 730,
+		Currency:     "USD",
+	})
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `no instance types matching "cores=64 mem=4096M"`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, "machine count 0 not valid")
+}
+
+func (s *costEstimateSuite) TestEstimateCostsNotSupported(c *gc.C) {
+	fakeEnvironGet := func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return &mockEnviron{}, nil
+	}
+	_, err := machinemanager.EstimateCosts(s.api(c, &mockBackend{}), fakeEnvironGet, params.CostEstimateArgs{})
+	c.Assert(err, gc.ErrorMatches, "estimating costs on this cloud not supported")
+}
+
+type mockPricingEnviron struct {
+	environs.Environ
+
+	pricing map[string]environs.InstancePricing
+}
+
+func (m *mockPricingEnviron) InstancePricing(ctx context.ProviderCallContext, cons constraints.Value) (environs.InstancePricing, error) {
+	pricing, ok := m.pricing[cons.String()]
+	if !ok {
+		return environs.InstancePricing{}, errors.Errorf("no instance types matching %q", cons)
+	}
+	return pricing, nil
+}
@@ -5,3 +5,4 @@ package machinemanager
 
 var InstanceTypes = instanceTypes
 var IsSeriesLessThan = isSeriesLessThan
+var EstimateCosts = estimateCosts
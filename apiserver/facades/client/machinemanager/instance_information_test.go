@@ -89,6 +89,7 @@ type mockBackend struct {
 	storagecommon.StorageAccess
 
 	cloudSpec environs.CloudSpec
+	modelCons constraints.Value
 }
 
 func (st *mockBackend) VolumeAccess() storagecommon.VolumeAccess {
@@ -107,6 +108,10 @@ func (b *mockBackend) Model() (machinemanager.Model, error) {
 	return &mockModel{}, nil
 }
 
+func (b *mockBackend) ResolveConstraints(cons constraints.Value) (constraints.Value, error) {
+	return constraints.Merge(b.modelCons, cons)
+}
+
 func (b *mockBackend) CloudSpec(names.ModelTag) (environs.CloudSpec, error) {
 	return b.cloudSpec, nil
 }
@@ -144,6 +149,8 @@ func (m *mockEnviron) InstanceTypes(ctx context.ProviderCallContext, c constrain
 
 type mockModel struct {
 	machinemanager.Model
+	protected         bool
+	maintenanceWindow string
 }
 
 func (mockModel) CloudCredential() (names.CloudCredentialTag, bool) {
@@ -154,8 +161,15 @@ func (mockModel) ModelTag() names.ModelTag {
 	return names.NewModelTag("beef1beef1-0000-0000-000011112222")
 }
 
-func (*mockModel) Config() (*config.Config, error) {
-	return config.New(config.UseDefaults, dummy.SampleConfig())
+func (m *mockModel) Config() (*config.Config, error) {
+	attrs := dummy.SampleConfig()
+	if m.protected {
+		attrs["protected"] = true
+	}
+	if m.maintenanceWindow != "" {
+		attrs["maintenance-window"] = m.maintenanceWindow
+	}
+	return config.New(config.UseDefaults, attrs)
 }
 
 func (*mockModel) Cloud() string {
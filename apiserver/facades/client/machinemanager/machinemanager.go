@@ -5,6 +5,7 @@ package machinemanager
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -64,6 +65,12 @@ type MachineManagerAPIV4 struct {
 // Version 5 of Machine Manager API.
 // Adds CreateUpgradeSeriesLock and removes UpdateMachineSeries.
 type MachineManagerAPIV5 struct {
+	*MachineManagerAPIV6
+}
+
+// Version 6 of Machine Manager API.
+// Adds EstimateCosts.
+type MachineManagerAPIV6 struct {
 	*MachineManagerAPI
 }
 
@@ -78,11 +85,20 @@ func NewFacadeV4(ctx facade.Context) (*MachineManagerAPIV4, error) {
 
 // NewFacadeV5 creates a new server-side MachineManager API facade.
 func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
+	machineManagerAPIV6, err := NewFacadeV6(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV5{machineManagerAPIV6}, nil
+}
+
+// NewFacadeV6 creates a new server-side MachineManager API facade.
+func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
 	machineManagerAPI, err := NewFacade(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &MachineManagerAPIV5{machineManagerAPI}, nil
+	return &MachineManagerAPIV6{machineManagerAPI}, nil
 }
 
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
@@ -203,11 +219,17 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		placementDirective = p.Placement.Directive
 	}
 
+	add := state.QuotaUsage{Machines: 1}
+	if p.ContainerType != "" && p.ParentId == "" {
+		// The container's host machine is added too.
+		add.Machines++
+	}
 	volumes := make([]state.HostVolumeParams, 0, len(p.Disks))
 	for _, cons := range p.Disks {
 		if cons.Count == 0 {
 			return nil, errors.Errorf("invalid volume params: count not specified")
 		}
+		add.StorageMiB += cons.Size * cons.Count
 		// Pool and Size are validated by AddMachineX.
 		volumeParams := state.VolumeParams{
 			Pool: cons.Pool,
@@ -221,10 +243,19 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		}
 	}
 
+	if p.CloudInitUserData != "" {
+		if _, err := config.ParseCloudInitUserData(p.CloudInitUserData); err != nil {
+			return nil, errors.Annotate(err, "invalid cloudinit-userdata")
+		}
+	}
+
 	jobs, err := common.StateJobs(p.Jobs)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := mm.st.CheckQuotas(mm.modelTag.Id(), add); err != nil {
+		return nil, errors.Trace(err)
+	}
 	template := state.MachineTemplate{
 		Series:                  p.Series,
 		Constraints:             p.Constraints,
@@ -235,6 +266,7 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		HardwareCharacteristics: p.HardwareCharacteristics,
 		Addresses:               params.NetworkAddresses(p.Addrs...),
 		Placement:               placementDirective,
+		CloudInitUserData:       p.CloudInitUserData,
 	}
 	if p.ContainerType == "" {
 		return mm.st.AddOneMachine(template)
@@ -247,12 +279,12 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 
 // DestroyMachine removes a set of machines from the model.
 func (mm *MachineManagerAPI) DestroyMachine(args params.Entities) (params.DestroyMachineResults, error) {
-	return mm.destroyMachine(args, false, false)
+	return mm.destroyMachine(args, false, false, nil)
 }
 
 // ForceDestroyMachine forcibly removes a set of machines from the model.
 func (mm *MachineManagerAPI) ForceDestroyMachine(args params.Entities) (params.DestroyMachineResults, error) {
-	return mm.destroyMachine(args, true, false)
+	return mm.destroyMachine(args, true, false, nil)
 }
 
 // DestroyMachineWithParams removes a set of machines from the model.
@@ -261,16 +293,27 @@ func (mm *MachineManagerAPI) DestroyMachineWithParams(args params.DestroyMachine
 	for i, tag := range args.MachineTags {
 		entities.Entities[i].Tag = tag
 	}
-	return mm.destroyMachine(entities, args.Force, args.Keep)
+	return mm.destroyMachine(entities, args.Force, args.Keep, args.MaxWait)
 }
 
-func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force, keep bool) (params.DestroyMachineResults, error) {
+func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force, keep bool, maxWait *time.Duration) (params.DestroyMachineResults, error) {
 	if err := mm.checkCanWrite(); err != nil {
 		return params.DestroyMachineResults{}, err
 	}
 	if err := mm.check.RemoveAllowed(); err != nil {
 		return params.DestroyMachineResults{}, err
 	}
+	model, err := mm.st.Model()
+	if err != nil {
+		return params.DestroyMachineResults{}, errors.Trace(err)
+	}
+	cfg, err := model.Config()
+	if err != nil {
+		return params.DestroyMachineResults{}, errors.Trace(err)
+	}
+	if err := common.CheckModelNotProtected(cfg); err != nil {
+		return params.DestroyMachineResults{}, errors.Trace(err)
+	}
 	destroyMachine := func(entity params.Entity) params.DestroyMachineResult {
 		result := params.DestroyMachineResult{}
 		fail := func(e error) params.DestroyMachineResult {
@@ -351,7 +394,9 @@ func (mm *MachineManagerAPI) destroyMachine(args params.Entities, force, keep bo
 
 		destroy := machine.Destroy
 		if force {
-			destroy = machine.ForceDestroy
+			destroy = func() error {
+				return machine.ForceDestroy(common.MaxWait(maxWait))
+			}
 		}
 		if err := destroy(); err != nil {
 			return fail(err)
@@ -444,6 +489,9 @@ func (mm *MachineManagerAPI) upgradeSeriesPrepare(arg params.UpdateSeriesArg) er
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err := mm.checkMaintenanceWindow(); err != nil {
+		return errors.Trace(err)
+	}
 
 	if err = machine.CreateUpgradeSeriesLock(unitNames, arg.Series); err != nil {
 		// TODO 2018-06-28 managed series upgrade
@@ -461,6 +509,25 @@ func (mm *MachineManagerAPI) upgradeSeriesPrepare(arg params.UpdateSeriesArg) er
 	return nil
 }
 
+// checkMaintenanceWindow returns an error if the current time is outside
+// the model's maintenance window. Series upgrades are held back at this
+// point because the units start running their pre-series-upgrade hooks
+// as soon as the upgrade series lock is created.
+func (mm *MachineManagerAPI) checkMaintenanceWindow() error {
+	model, err := mm.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := model.Config()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if window := cfg.MaintenanceWindow(); !window.Contains(time.Now()) {
+		return errors.Errorf("series upgrades are only allowed within the model's maintenance window (%v)", window)
+	}
+	return nil
+}
+
 // UpgradeSeriesComplete marks a machine as having completed a managed series upgrade.
 func (mm *MachineManagerAPI) UpgradeSeriesComplete(args params.UpdateSeriesArg) (params.ErrorResult, error) {
 	if err := mm.checkCanWrite(); err != nil {
@@ -4,8 +4,10 @@
 package machinemanager_test
 
 import (
+	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/os/series"
@@ -20,6 +22,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
@@ -142,6 +145,29 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+func (s *MachineManagerSuite) TestAddMachinesQuotaExceeded(c *gc.C) {
+	s.st.quotaErr = &state.QuotaExceededError{
+		Entity: `model "only"`, Resource: "machines", Limit: 1, Used: 1, Requested: 2,
+	}
+	results, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:        "trusty",
+			ContainerType: instance.LXD,
+			Disks:         []storage.Constraints{{Size: 1024, Count: 2}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines, gc.HasLen, 1)
+	c.Assert(results.Machines[0].Error, gc.ErrorMatches,
+		`quota exceeded: model "only" is limited to 1 machines \(using 1, requested 2\)`)
+	c.Assert(results.Machines[0].Error, jc.Satisfies, params.IsCodeQuotaExceeded)
+	c.Assert(s.st.calls, gc.Equals, 0)
+	s.st.CheckCall(c, 2, "CheckQuotas", "deadbeef-2f18-4fd2-967d-db9663db7bea", state.QuotaUsage{
+		Machines:   2,
+		StorageMiB: 2048,
+	})
+}
+
 func (s *MachineManagerSuite) TestDestroyMachine(c *gc.C) {
 	s.st.machines["0"] = &mockMachine{}
 	results, err := s.api.DestroyMachine(params.Entities{
@@ -167,6 +193,17 @@ func (s *MachineManagerSuite) TestDestroyMachine(c *gc.C) {
 	})
 }
 
+func (s *MachineManagerSuite) TestDestroyMachineProtectedModel(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{}
+	s.st.protected = true
+	_, err := s.api.DestroyMachine(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `model "only" is protected from destruction and from removal of its applications and machines`)
+	c.Assert(err, jc.Satisfies, params.IsCodeModelProtected)
+	s.st.CheckCallNames(c, "ModelTag", "GetBlockForType", "GetBlockForType", "Model")
+}
+
 func (s *MachineManagerSuite) assertMachinesDestroyed(c *gc.C, in []params.Entity, out params.DestroyMachineResults, expectedCalls ...string) {
 	results, err := s.api.DestroyMachine(params.Entities{in})
 	c.Assert(err, jc.ErrorIsNil)
@@ -191,6 +228,7 @@ func (s *MachineManagerSuite) TestDestroyMachineFailedAllStorageRetrieval(c *gc.
 		"ModelTag",
 		"GetBlockForType",
 		"GetBlockForType",
+		"Model",
 		"Machine",
 		"UnitStorageAttachments",
 		"UnitStorageAttachments",
@@ -213,6 +251,7 @@ func (s *MachineManagerSuite) TestDestroyMachineFailedAllStorageClassification(c
 		"ModelTag",
 		"GetBlockForType",
 		"GetBlockForType",
+		"Model",
 		"Machine",
 		"UnitStorageAttachments",
 		"StorageInstance",
@@ -248,6 +287,7 @@ func (s *MachineManagerSuite) TestDestroyMachineFailedSomeUnitStorageRetrieval(c
 		"ModelTag",
 		"GetBlockForType",
 		"GetBlockForType",
+		"Model",
 		"Machine",
 		"UnitStorageAttachments",
 		"VolumeAccess",
@@ -301,6 +341,7 @@ func (s *MachineManagerSuite) TestDestroyMachineFailedSomeStorageRetrievalManyMa
 		"ModelTag",
 		"GetBlockForType",
 		"GetBlockForType",
+		"Model",
 		"Machine",
 		"UnitStorageAttachments",
 		"VolumeAccess",
@@ -349,6 +390,22 @@ func (s *MachineManagerSuite) TestDestroyMachineWithParams(c *gc.C) {
 	})
 }
 
+func (s *MachineManagerSuite) TestDestroyMachineWithParamsMaxWait(c *gc.C) {
+	apiV4 := s.machineManagerAPIV4()
+	s.st.machines["0"] = &mockMachine{}
+	maxWait := 10 * time.Minute
+	_, err := apiV4.DestroyMachineWithParams(params.DestroyMachinesParams{
+		Force:       true,
+		MaxWait:     &maxWait,
+		MachineTags: []string{"machine-0"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.machines["0"].CheckCalls(c, []jtesting.StubCall{
+		{"Units", nil},
+		{"ForceDestroy", []interface{}{maxWait}},
+	})
+}
+
 func (s *MachineManagerSuite) setupUpgradeSeries(c *gc.C) {
 	s.st.machines = map[string]*mockMachine{
 		"0": {series: "trusty", units: []string{"foo/0", "test/0"}},
@@ -362,7 +419,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesValidateOK(c *gc.C) {
 	s.setupUpgradeSeries(c)
 	s.st.machines["0"].unitAgentState = status.Idle
 
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	args := params.UpdateSeriesArgs{
 		Args: []params.UpdateSeriesArg{{
 			Entity: params.Entity{Tag: names.NewMachineTag("0").String()},
@@ -384,7 +441,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesValidateOK(c *gc.C) {
 
 func (s *MachineManagerSuite) TestUpgradeSeriesValidateIsControllerError(c *gc.C) {
 	s.setupUpgradeSeries(c)
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	args := params.UpdateSeriesArgs{
 		Args: []params.UpdateSeriesArg{{
 			Entity: params.Entity{Tag: names.NewMachineTag("3").String()},
@@ -399,7 +456,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesValidateIsControllerError(c *gc.C
 
 func (s *MachineManagerSuite) TestUpgradeSeriesValidateNoSeriesError(c *gc.C) {
 	s.setupUpgradeSeries(c)
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	args := params.UpdateSeriesArgs{
 		Args: []params.UpdateSeriesArg{{
 			Entity: params.Entity{Tag: names.NewMachineTag("1").String()},
@@ -413,7 +470,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesValidateNoSeriesError(c *gc.C) {
 
 func (s *MachineManagerSuite) TestUpgradeSeriesValidateNotFromUbuntuError(c *gc.C) {
 	s.setupUpgradeSeries(c)
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	args := params.UpdateSeriesArgs{
 		Args: []params.UpdateSeriesArg{{
 			Entity: params.Entity{Tag: names.NewMachineTag("2").String()},
@@ -429,7 +486,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesValidateNotFromUbuntuError(c *gc.
 
 func (s *MachineManagerSuite) TestUpgradeSeriesValidateNotToUbuntuError(c *gc.C) {
 	s.setupUpgradeSeries(c)
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	args := params.UpdateSeriesArgs{
 		Args: []params.UpdateSeriesArg{{
 			Entity: params.Entity{Tag: names.NewMachineTag("1").String()},
@@ -445,7 +502,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesValidateNotToUbuntuError(c *gc.C)
 
 func (s *MachineManagerSuite) TestUpgradeSeriesValidateAlreadyRunningSeriesError(c *gc.C) {
 	s.setupUpgradeSeries(c)
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	args := params.UpdateSeriesArgs{
 		Args: []params.UpdateSeriesArg{{
 			Entity: params.Entity{Tag: names.NewMachineTag("1").String()},
@@ -460,7 +517,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesValidateAlreadyRunningSeriesError
 
 func (s *MachineManagerSuite) TestUpgradeSeriesValidateOlderSeriesError(c *gc.C) {
 	s.setupUpgradeSeries(c)
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	args := params.UpdateSeriesArgs{
 		Args: []params.UpdateSeriesArg{{
 			Entity: params.Entity{Tag: names.NewMachineTag("1").String()},
@@ -479,7 +536,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesValidateUnitNotIdleError(c *gc.C)
 	s.st.machines["0"].unitAgentState = status.Executing
 	s.st.machines["0"].unitState = status.Active
 
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	args := params.UpdateSeriesArgs{
 		Args: []params.UpdateSeriesArg{{
 			Entity: params.Entity{Tag: names.NewMachineTag("0").String()},
@@ -497,7 +554,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesValidateUnitStatusError(c *gc.C)
 	s.st.machines["0"].unitAgentState = status.Idle
 	s.st.machines["0"].unitState = status.Error
 
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	args := params.UpdateSeriesArgs{
 		Args: []params.UpdateSeriesArg{{
 			Entity: params.Entity{Tag: names.NewMachineTag("0").String()},
@@ -514,7 +571,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesPrepare(c *gc.C) {
 	s.setupUpgradeSeries(c)
 	s.st.machines["0"].unitAgentState = status.Idle
 
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	machineTag := names.NewMachineTag("0")
 	result, err := apiV5.UpgradeSeriesPrepare(
 		params.UpdateSeriesArg{
@@ -532,8 +589,29 @@ func (s *MachineManagerSuite) TestUpgradeSeriesPrepare(c *gc.C) {
 	mach.CheckCall(c, 2, "CreateUpgradeSeriesLock", []string{"foo/0", "test/0"}, "xenial")
 }
 
+func (s *MachineManagerSuite) TestUpgradeSeriesPrepareOutsideMaintenanceWindow(c *gc.C) {
+	s.setupUpgradeSeries(c)
+	s.st.machines["0"].unitAgentState = status.Idle
+	now := time.Now().UTC()
+	start := now.Add(2 * time.Hour)
+	end := now.Add(3 * time.Hour)
+	s.st.window = fmt.Sprintf("%02d:%02d-%02d:%02d", start.Hour(), start.Minute(), end.Hour(), end.Minute())
+
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
+	result, err := apiV5.UpgradeSeriesPrepare(
+		params.UpdateSeriesArg{
+			Entity: params.Entity{
+				Tag: names.NewMachineTag("0").String()},
+			Series: "xenial",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `series upgrades are only allowed within the model's maintenance window \(.*\)`)
+	s.st.machines["0"].CheckCallNames(c, "Principals", "VerifyUnitsSeries")
+}
+
 func (s *MachineManagerSuite) TestUpgradeSeriesPrepareMachineNotFound(c *gc.C) {
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	machineTag := names.NewMachineTag("76")
 	result, err := apiV5.UpgradeSeriesPrepare(
 		params.UpdateSeriesArg{
@@ -547,7 +625,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesPrepareMachineNotFound(c *gc.C) {
 }
 
 func (s *MachineManagerSuite) TestUpgradeSeriesPrepareNotMachineTag(c *gc.C) {
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	unitTag := names.NewUnitTag("mysql/0")
 	result, err := apiV5.UpgradeSeriesPrepare(
 		params.UpdateSeriesArg{
@@ -563,7 +641,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesPrepareNotMachineTag(c *gc.C) {
 func (s *MachineManagerSuite) TestUpgradeSeriesPreparePermissionDenied(c *gc.C) {
 	user := names.NewUserTag("fred")
 	s.setAPIUser(c, user)
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	machineTag := names.NewMachineTag("0")
 	_, err := apiV5.UpgradeSeriesPrepare(
 		params.UpdateSeriesArg{
@@ -576,7 +654,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesPreparePermissionDenied(c *gc.C)
 }
 
 func (s *MachineManagerSuite) TestUpgradeSeriesPrepareBlockedChanges(c *gc.C) {
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	s.st.blockMsg = "TestUpgradeSeriesPrepareBlockedChanges"
 	s.st.block = state.ChangeBlock
 	_, err := apiV5.UpgradeSeriesPrepare(
@@ -594,7 +672,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesPrepareBlockedChanges(c *gc.C) {
 }
 
 func (s *MachineManagerSuite) TestUpgradeSeriesPrepareNoSeries(c *gc.C) {
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	result, err := apiV5.UpgradeSeriesPrepare(
 		params.UpdateSeriesArg{
 			Entity: params.Entity{Tag: names.NewMachineTag("0").String()},
@@ -616,7 +694,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesPrepareIncompatibleSeries(c *gc.C
 		Series:     "xenial",
 		CharmName:  "TestCharm",
 	})
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	result, err := apiV5.UpgradeSeriesPrepare(
 		params.UpdateSeriesArg{
 			Entity: params.Entity{Tag: names.NewMachineTag("0").String()},
@@ -639,7 +717,7 @@ func (s *MachineManagerSuite) TestUpgradeSeriesPrepareRemoveLockAfterFail(c *gc.
 
 func (s *MachineManagerSuite) TestUpgradeSeriesComplete(c *gc.C) {
 	s.setupUpgradeSeries(c)
-	apiV5 := machinemanager.MachineManagerAPIV5{MachineManagerAPI: s.api}
+	apiV5 := machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	_, err := apiV5.UpgradeSeriesComplete(
 		params.UpdateSeriesArg{
 			Entity: params.Entity{Tag: names.NewMachineTag("0").String()},
@@ -695,6 +773,9 @@ type mockState struct {
 	err              error
 	blockMsg         string
 	block            state.BlockType
+	protected        bool
+	window           string
+	quotaErr         error
 
 	unitStorageAttachmentsF func(tag names.UnitTag) ([]state.StorageAttachment, error)
 }
@@ -745,6 +826,11 @@ func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machi
 	return &m, st.err
 }
 
+func (st *mockState) CheckQuotas(modelUUID string, add state.QuotaUsage) error {
+	st.MethodCall(st, "CheckQuotas", modelUUID, add)
+	return st.quotaErr
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	st.MethodCall(st, "GetBlockForType", t)
 	if st.block == t {
@@ -761,7 +847,7 @@ func (st *mockState) ModelTag() names.ModelTag {
 
 func (st *mockState) Model() (machinemanager.Model, error) {
 	st.MethodCall(st, "Model")
-	return &mockModel{}, nil
+	return &mockModel{protected: st.protected, maintenanceWindow: st.window}, nil
 }
 
 func (st *mockState) CloudCredential(tag names.CloudCredentialTag) (state.Credential, error) {
@@ -841,8 +927,8 @@ func (m *mockMachine) Destroy() error {
 	return nil
 }
 
-func (m *mockMachine) ForceDestroy() error {
-	m.MethodCall(m, "ForceDestroy")
+func (m *mockMachine) ForceDestroy(maxWait time.Duration) error {
+	m.MethodCall(m, "ForceDestroy", maxWait)
 	return nil
 }
 
@@ -971,6 +1057,6 @@ func (v *mockVolume) Detachable() bool {
 }
 
 func (s *MachineManagerSuite) machineManagerAPIV4() machinemanager.MachineManagerAPIV4 {
-	managerV5 := &machinemanager.MachineManagerAPIV5{s.api}
+	managerV5 := &machinemanager.MachineManagerAPIV5{&machinemanager.MachineManagerAPIV6{s.api}}
 	return machinemanager.MachineManagerAPIV4{managerV5}
 }
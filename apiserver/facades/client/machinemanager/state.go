@@ -4,10 +4,13 @@
 package machinemanager
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
@@ -23,6 +26,8 @@ type Backend interface {
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	CheckQuotas(modelUUID string, add state.QuotaUsage) error
+	ResolveConstraints(cons constraints.Value) (constraints.Value, error)
 }
 
 type Pool interface {
@@ -40,7 +45,7 @@ type Model interface {
 
 type Machine interface {
 	Destroy() error
-	ForceDestroy() error
+	ForceDestroy(time.Duration) error
 	Series() string
 	Units() ([]Unit, error)
 	SetKeepInstance(keepInstance bool) error
@@ -18,6 +18,7 @@ type Backend interface {
 	ControllerTag() names.ControllerTag
 	ModelTag() names.ModelTag
 	ModelConfigValues() (config.ConfigValues, error)
+	ModelConfigTrace(string) (config.ConfigTrace, error)
 	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
 	Sequences() (map[string]int, error)
 	SetSLA(level, owner string, credentials []byte) error
@@ -37,6 +38,10 @@ func (st stateShim) ModelConfigValues() (config.ConfigValues, error) {
 	return st.model.ModelConfigValues()
 }
 
+func (st stateShim) ModelConfigTrace(attr string) (config.ConfigTrace, error) {
+	return st.model.ModelConfigTrace(attr)
+}
+
 func (st stateShim) ModelTag() names.ModelTag {
 	m, err := st.State.Model()
 	if err != nil {
@@ -14,8 +14,8 @@ import (
 	"github.com/juju/juju/permission"
 )
 
-// NewFacade is used for API registration.
-func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
+// NewFacadeV3 is used for API registration.
+func NewFacadeV3(ctx facade.Context) (*ModelConfigAPIV3, error) {
 	auth := ctx.Auth()
 
 	model, err := ctx.State().Model()
@@ -25,6 +25,15 @@ func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
 	return NewModelConfigAPI(NewStateBackend(model), auth)
 }
 
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
+	api, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV2{api}, nil
+}
+
 // NewFacadeV1 is used for API registration.
 func NewFacadeV1(ctx facade.Context) (*ModelConfigAPIV1, error) {
 	api, err := NewFacadeV2(ctx)
@@ -42,18 +51,23 @@ type ModelConfigAPI struct {
 	check   *common.BlockChecker
 }
 
-// ModelConfigAPIV2 is currently the latest.
-type ModelConfigAPIV2 struct {
+// ModelConfigAPIV3 is currently the latest.
+type ModelConfigAPIV3 struct {
 	*ModelConfigAPI
 }
 
+// ModelConfigAPIV2 hides V3 functionality
+type ModelConfigAPIV2 struct {
+	*ModelConfigAPIV3
+}
+
 // ModelConfigAPIV1 hides V2 functionality
 type ModelConfigAPIV1 struct {
 	*ModelConfigAPIV2
 }
 
 // NewModelConfigAPI creates a new instance of the ModelConfig Facade.
-func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV2, error) {
+func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV3, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
 	}
@@ -62,7 +76,7 @@ func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelCon
 		auth:    authorizer,
 		check:   common.NewBlockChecker(backend),
 	}
-	return &ModelConfigAPIV2{client}, nil
+	return &ModelConfigAPIV3{client}, nil
 }
 
 func (c *ModelConfigAPI) checkCanWrite() error {
@@ -87,6 +101,43 @@ func (c *ModelConfigAPI) isControllerAdmin() error {
 	return nil
 }
 
+func (c *ModelConfigAPI) isModelAdmin() error {
+	isAdmin, err := c.auth.HasPermission(permission.SuperuserAccess, c.backend.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isAdmin {
+		return nil
+	}
+	isModelAdmin, err := c.auth.HasPermission(permission.AdminAccess, c.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isModelAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// checkProtected ensures that only model admins can protect or
+// unprotect a model.
+func (c *ModelConfigAPI) checkProtected(updateAttrs map[string]interface{}, removeAttrs []string, oldConfig *config.Config) error {
+	_, changed := updateAttrs[config.ProtectedKey]
+	for _, key := range removeAttrs {
+		changed = changed || key == config.ProtectedKey
+	}
+	if !changed {
+		return nil
+	}
+	if err := c.isModelAdmin(); err != nil {
+		if errors.Cause(err) != common.ErrPerm {
+			return errors.Trace(err)
+		}
+		return errors.New("only model admins can change whether a model is protected")
+	}
+	return nil
+}
+
 func (c *ModelConfigAPI) canReadModel() error {
 	isAdmin, err := c.auth.HasPermission(permission.SuperuserAccess, c.backend.ControllerTag())
 	if err != nil {
@@ -131,6 +182,37 @@ func (c *ModelConfigAPI) ModelGet() (params.ModelConfigResults, error) {
 	return result, nil
 }
 
+// ModelConfigTrace implements the server-side part of the
+// model-config --trace CLI command. It reports the value each level
+// of the model's config inheritance chain holds for the given keys.
+func (c *ModelConfigAPI) ModelConfigTrace(args params.ModelConfigTraceArgs) (params.ModelConfigTraceResults, error) {
+	result := params.ModelConfigTraceResults{
+		Results: make([]params.ModelConfigTraceResult, len(args.Keys)),
+	}
+	if err := c.canReadModel(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, key := range args.Keys {
+		result.Results[i].Key = key
+		trace, err := c.backend.ModelConfigTrace(key)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Effective = params.ConfigValue{
+			Value:  trace.Effective.Value,
+			Source: trace.Effective.Source,
+		}
+		for _, level := range trace.Levels {
+			result.Results[i].Levels = append(result.Results[i].Levels, params.ConfigValue{
+				Value:  level.Value,
+				Source: level.Source,
+			})
+		}
+	}
+	return result, nil
+}
+
 // ModelSet implements the server-side part of the
 // set-model-config CLI command.
 func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
@@ -184,7 +266,7 @@ func (c *ModelConfigAPI) ModelSet(args params.ModelSet) error {
 
 	// Replace any deprecated attributes with their new values.
 	attrs := config.ProcessDeprecatedAttributes(args.Config)
-	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace)
+	return c.backend.UpdateModelConfig(attrs, nil, checkAgentVersion, checkLogTrace, c.checkProtected)
 }
 
 // ModelUnset implements the server-side part of the
@@ -196,7 +278,7 @@ func (c *ModelConfigAPI) ModelUnset(args params.ModelUnset) error {
 	if err := c.check.ChangeAllowed(); err != nil {
 		return errors.Trace(err)
 	}
-	return c.backend.UpdateModelConfig(nil, args.Keys)
+	return c.backend.UpdateModelConfig(nil, args.Keys, c.checkProtected)
 }
 
 // SetSLALevel sets the sla level on the model.
@@ -241,3 +323,6 @@ func (c *ModelConfigAPI) Sequences() (params.ModelSequencesResult, error) {
 
 // Sequences isn't on the V1 API.
 func (a *ModelConfigAPIV1) Sequences(_, _ struct{}) {}
+
+// ModelConfigTrace isn't on the V2 API.
+func (a *ModelConfigAPIV2) ModelConfigTrace(_, _ struct{}) {}
@@ -24,7 +24,7 @@ type modelconfigSuite struct {
 	gitjujutesting.IsolationSuite
 	backend    *mockBackend
 	authorizer apiservertesting.FakeAuthorizer
-	api        *modelconfig.ModelConfigAPIV2
+	api        *modelconfig.ModelConfigAPIV3
 }
 
 var _ = gc.Suite(&modelconfigSuite{})
@@ -175,6 +175,25 @@ func (s *modelconfigSuite) TestUserCannotSetLogTrace(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `only controller admins can set a model's logging level to TRACE`)
 }
 
+func (s *modelconfigSuite) TestAdminCanSetProtected(c *gc.C) {
+	err := s.api.ModelSet(params.ModelSet{map[string]interface{}{"protected": true}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.ModelGet()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Config["protected"].Value, jc.IsTrue)
+}
+
+func (s *modelconfigSuite) TestUserCannotChangeProtected(c *gc.C) {
+	apiUser := names.NewUserTag("fred")
+	s.authorizer.Tag = apiUser
+	s.authorizer.HasWriteTag = apiUser
+	err := s.api.ModelSet(params.ModelSet{map[string]interface{}{"protected": false}})
+	c.Assert(err, gc.ErrorMatches, `only model admins can change whether a model is protected`)
+	err = s.api.ModelUnset(params.ModelUnset{[]string{"protected"}})
+	c.Assert(err, gc.ErrorMatches, `only model admins can change whether a model is protected`)
+}
+
 func (s *modelconfigSuite) TestModelUnset(c *gc.C) {
 	err := s.backend.UpdateModelConfig(map[string]interface{}{"abc": 123}, nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -207,6 +226,39 @@ func (s *modelconfigSuite) TestSetSupportCredentals(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestModelConfigTrace(c *gc.C) {
+	result, err := s.api.ModelConfigTrace(params.ModelConfigTraceArgs{
+		Keys: []string{"ftp-proxy", "no-such-key"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelConfigTraceResults{
+		Results: []params.ModelConfigTraceResult{{
+			Key:       "ftp-proxy",
+			Effective: params.ConfigValue{Value: "http://proxy", Source: "model"},
+			Levels: []params.ConfigValue{
+				{Value: "", Source: "default"},
+				{Value: "http://proxy", Source: "model"},
+			},
+		}, {
+			Key: "no-such-key",
+			Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `model config attribute "no-such-key" not found`,
+			},
+		}},
+	})
+}
+
+func (s *modelconfigSuite) TestModelConfigTraceNoReadAccess(c *gc.C) {
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("charlie@local"),
+	}
+	api, err := modelconfig.NewModelConfigAPI(s.backend, &s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ModelConfigTrace(params.ModelConfigTraceArgs{Keys: []string{"ftp-proxy"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 type mockBackend struct {
 	cfg config.ConfigValues
 	old *config.Config
@@ -218,6 +270,21 @@ func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
 	return m.cfg, nil
 }
 
+func (m *mockBackend) ModelConfigTrace(attr string) (config.ConfigTrace, error) {
+	value, ok := m.cfg[attr]
+	if !ok {
+		return config.ConfigTrace{}, errors.NotFoundf("model config attribute %q", attr)
+	}
+	trace := config.ConfigTrace{
+		Effective: value,
+		Levels:    []config.ConfigValue{{Value: "", Source: "default"}},
+	}
+	if value.Source != "default" {
+		trace.Levels = append(trace.Levels, value)
+	}
+	return trace, nil
+}
+
 func (m *mockBackend) Sequences() (map[string]int, error) {
 	return nil, nil
 }
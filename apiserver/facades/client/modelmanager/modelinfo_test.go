@@ -576,6 +576,9 @@ type mockState struct {
 	users           []permission.UserAccess
 	cred            state.Credential
 	machines        []common.Machine
+	applications    []common.Application
+	storage         []state.StorageInstance
+	offerConns      map[string]int
 	cfgDefaults     config.ModelDefaultAttributes
 	blockMsg        string
 	block           state.BlockType
@@ -643,7 +646,17 @@ func (st *mockState) ModelUUIDsForUser(user names.UserTag) ([]string, error) {
 
 func (st *mockState) AllApplications() ([]common.Application, error) {
 	st.MethodCall(st, "AllApplications")
-	return nil, st.NextErr()
+	return st.applications, st.NextErr()
+}
+
+func (st *mockState) AllStorageInstances() ([]state.StorageInstance, error) {
+	st.MethodCall(st, "AllStorageInstances")
+	return st.storage, st.NextErr()
+}
+
+func (st *mockState) OfferConnectionCounts() (map[string]int, error) {
+	st.MethodCall(st, "OfferConnectionCounts")
+	return st.offerConns, st.NextErr()
 }
 
 func (st *mockState) AllVolumes() ([]state.Volume, error) {
@@ -911,6 +924,11 @@ type mockMachine struct {
 	life          state.Life
 	containerType instance.ContainerType
 	hw            *instance.HardwareCharacteristics
+	manager       bool
+}
+
+func (m *mockMachine) IsManager() bool {
+	return m.manager
 }
 
 func (m *mockMachine) Id() string {
@@ -953,6 +971,24 @@ func (m *mockMachine) Status() (status.StatusInfo, error) {
 	return status.StatusInfo{}, nil
 }
 
+type mockApplication struct {
+	common.Application
+	name string
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+type mockStorageInstance struct {
+	state.StorageInstance
+	tag names.StorageTag
+}
+
+func (s *mockStorageInstance) StorageTag() names.StorageTag {
+	return s.tag
+}
+
 type mockModel struct {
 	gitjujutesting.Stub
 	owner               names.UserTag
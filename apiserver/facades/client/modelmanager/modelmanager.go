@@ -12,6 +12,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -39,6 +40,13 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
+// ModelManagerV8 defines the methods on the version 8 facade for the
+// modelmanager API endpoint.
+type ModelManagerV8 interface {
+	ModelManagerV7
+	PreviewDestroyModels(args params.DestroyModelsParams) (params.DestroyModelImpactResults, error)
+}
+
 // ModelManagerV7 defines the methods on the version 7 facade for the
 // modelmanager API endpoint.
 type ModelManagerV7 interface {
@@ -121,10 +129,16 @@ type ModelManagerAPI struct {
 	callContext context.ProviderCallContext
 }
 
+// ModelManagerAPIV7 provides a way to wrap the different calls between
+// version 7 and version 8 of the model manager API
+type ModelManagerAPIV7 struct {
+	*ModelManagerAPI
+}
+
 // ModelManagerAPIV6 provides a way to wrap the different calls between
-// version 6 and version 6 of the model manager API
+// version 6 and version 7 of the model manager API
 type ModelManagerAPIV6 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV7
 }
 
 // ModelManagerAPIV5 provides a way to wrap the different calls between
@@ -152,7 +166,8 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV7 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV8 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV7 = (*ModelManagerAPIV7)(nil)
 	_ ModelManagerV6 = (*ModelManagerAPIV6)(nil)
 	_ ModelManagerV5 = (*ModelManagerAPIV5)(nil)
 	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
@@ -160,8 +175,8 @@ var (
 	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV7 is used for API registration.
-func NewFacadeV7(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV8 is used for API registration.
+func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -191,6 +206,15 @@ func NewFacadeV7(ctx facade.Context) (*ModelManagerAPI, error) {
 	)
 }
 
+// NewFacadeV7 is used for API registration.
+func NewFacadeV7(ctx facade.Context) (*ModelManagerAPIV7, error) {
+	v8, err := NewFacadeV8(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV7{v8}, nil
+}
+
 // NewFacadeV6 is used for API registration.
 func NewFacadeV6(ctx facade.Context) (*ModelManagerAPIV6, error) {
 	v7, err := NewFacadeV7(ctx)
@@ -985,7 +1009,7 @@ func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params
 		Results: make([]params.ErrorResult, len(args.Models)),
 	}
 
-	destroyModel := func(modelUUID string, destroyStorage *bool) error {
+	destroyModel := func(modelUUID string, destroyStorage, force *bool, maxWait *time.Duration) error {
 		st, releaseSt, err := m.state.GetBackend(modelUUID)
 		if err != nil {
 			return errors.Trace(err)
@@ -1005,8 +1029,59 @@ func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params
 				return errors.Trace(common.ErrPerm)
 			}
 		}
+		cfg, err := st.ModelConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := common.CheckModelNotProtected(cfg); err != nil {
+			return errors.Trace(err)
+		}
+
+		return errors.Trace(common.DestroyModel(st, destroyStorage, force, maxWait))
+	}
+
+	for i, arg := range args.Models {
+		tag, err := names.ParseModelTag(arg.ModelTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := destroyModel(tag.Id(), arg.DestroyStorage, arg.Force, arg.MaxWait); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+	}
+	return results, nil
+}
+
+// PreviewDestroyModels reports what would be removed by destroying the
+// specified models with the same parameters, without destroying them.
+func (m *ModelManagerAPI) PreviewDestroyModels(args params.DestroyModelsParams) (params.DestroyModelImpactResults, error) {
+	results := params.DestroyModelImpactResults{
+		Results: make([]params.DestroyModelImpactResult, len(args.Models)),
+	}
+
+	previewModel := func(modelUUID string, destroyStorage *bool) (*params.DestroyModelImpact, error) {
+		st, releaseSt, err := m.state.GetBackend(modelUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer releaseSt()
 
-		return errors.Trace(common.DestroyModel(st, destroyStorage))
+		model, err := st.Model()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !m.isAdmin {
+			hasAdmin, err := m.authorizer.HasPermission(permission.AdminAccess, model.ModelTag())
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if !hasAdmin {
+				return nil, errors.Trace(common.ErrPerm)
+			}
+		}
+		return destroyModelImpact(st, destroyStorage)
 	}
 
 	for i, arg := range args.Models {
@@ -1015,14 +1090,69 @@ func (m *ModelManagerAPI) DestroyModels(args params.DestroyModelsParams) (params
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
-		if err := destroyModel(tag.Id(), arg.DestroyStorage); err != nil {
+		impact, err := previewModel(tag.Id(), arg.DestroyStorage)
+		if err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
 		}
+		results.Results[i].Impact = impact
 	}
 	return results, nil
 }
 
+// destroyModelImpact returns what would be removed by destroying the
+// model with the given storage disposition.
+func destroyModelImpact(st common.ModelManagerBackend, destroyStorage *bool) (*params.DestroyModelImpact, error) {
+	var impact params.DestroyModelImpact
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, machine := range machines {
+		if machine.IsManager() {
+			continue
+		}
+		impact.Machines = append(impact.Machines, params.Entity{names.NewMachineTag(machine.Id()).String()})
+	}
+	applications, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, app := range applications {
+		impact.Applications = append(impact.Applications, params.Entity{names.NewApplicationTag(app.Name()).String()})
+	}
+	storage, err := st.AllStorageInstances()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, stor := range storage {
+		entity := params.Entity{stor.StorageTag().String()}
+		switch {
+		case destroyStorage == nil:
+			impact.UndecidedStorage = append(impact.UndecidedStorage, entity)
+		case *destroyStorage:
+			impact.DestroyedStorage = append(impact.DestroyedStorage, entity)
+		default:
+			impact.ReleasedStorage = append(impact.ReleasedStorage, entity)
+		}
+	}
+	offers, err := st.OfferConnectionCounts()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	offerNames := set.NewStrings()
+	for name := range offers {
+		offerNames.Add(name)
+	}
+	for _, name := range offerNames.SortedValues() {
+		impact.Offers = append(impact.Offers, params.DestroyedOffer{
+			OfferName:   name,
+			Connections: offers[name],
+		})
+	}
+	return &impact, nil
+}
+
 // ModelInfo returns information about the specified models.
 func (m *ModelManagerAPI) ModelInfo(args params.Entities) (params.ModelInfoResults, error) {
 	results := params.ModelInfoResults{
@@ -1575,3 +1705,6 @@ func (*ModelManagerAPIV4) ChangeModelCredential(_, _ struct{}) {}
 
 // ModelDefaultsForClouds did not exist prior to v6.
 func (*ModelManagerAPIV5) ModelDefaultsForClouds(_, _ struct{}) {}
+
+// PreviewDestroyModels did not exist prior to v8.
+func (*ModelManagerAPIV7) PreviewDestroyModels(_, _ struct{}) {}
@@ -674,7 +674,7 @@ func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 			&modelmanager.ModelManagerAPIV4{
 				&modelmanager.ModelManagerAPIV5{
 					&modelmanager.ModelManagerAPIV6{
-						s.api,
+						&modelmanager.ModelManagerAPIV7{s.api},
 					},
 				},
 			},
@@ -835,12 +835,71 @@ func (s *modelManagerSuite) TestAddModelCantCreateModelForSomeoneElse(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "\"add-model\" permission does not permit creation of models for different owners: permission denied")
 }
 
+func (s *modelManagerSuite) TestPreviewDestroyModels(c *gc.C) {
+	s.st.machines = []common.Machine{
+		&mockMachine{id: "0"},
+		&mockMachine{id: "0/lxd/0"},
+	}
+	s.st.applications = []common.Application{
+		&mockApplication{name: "mysql"},
+	}
+	s.st.storage = []state.StorageInstance{
+		&mockStorageInstance{tag: names.NewStorageTag("data/0")},
+	}
+	s.st.offerConns = map[string]int{"hosted-mysql": 1}
+
+	releaseStorage := false
+	results, err := s.api.PreviewDestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{
+			ModelTag:       coretesting.ModelTag.String(),
+			DestroyStorage: &releaseStorage,
+		}, {
+			ModelTag: coretesting.ModelTag.String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Impact, jc.DeepEquals, &params.DestroyModelImpact{
+		Machines: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-0-lxd-0"},
+		},
+		Applications: []params.Entity{
+			{Tag: "application-mysql"},
+		},
+		ReleasedStorage: []params.Entity{
+			{Tag: "storage-data-0"},
+		},
+		Offers: []params.DestroyedOffer{
+			{OfferName: "hosted-mysql", Connections: 1},
+		},
+	})
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[1].Impact.UndecidedStorage, jc.DeepEquals, []params.Entity{
+		{Tag: "storage-data-0"},
+	})
+	c.Assert(results.Results[1].Impact.ReleasedStorage, gc.HasLen, 0)
+
+	// Nothing is destroyed.
+	for _, call := range s.st.model.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "Destroy")
+	}
+}
+
+func (s *modelManagerSuite) TestPreviewDestroyModelsNotOnV7(c *gc.C) {
+	_, ok := interface{}(&modelmanager.ModelManagerAPIV7{s.api}).(interface {
+		PreviewDestroyModels(params.DestroyModelsParams) (params.DestroyModelImpactResults, error)
+	})
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 	api := &modelmanager.ModelManagerAPIV3{
 		&modelmanager.ModelManagerAPIV4{
 			&modelmanager.ModelManagerAPIV5{
 				&modelmanager.ModelManagerAPIV6{
-					s.api,
+					&modelmanager.ModelManagerAPIV7{s.api},
 				},
 			},
 		},
@@ -855,6 +914,7 @@ func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 		"ModelUUID",
 		"GetBackend",
 		"Model",
+		"ModelConfig",
 		"GetBlockForType",
 		"GetBlockForType",
 		"GetBlockForType",
@@ -868,10 +928,22 @@ func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 		{"UUID", nil},
 		{"Destroy", []interface{}{state.DestroyModelParams{
 			DestroyStorage: &destroyStorage,
+			MaxWait:        common.DefaultMaxWait,
 		}}},
 	})
 }
 
+func (s *modelManagerSuite) TestDestroyModelsProtected(c *gc.C) {
+	s.st.modelConfig = coretesting.CustomModelConfig(c, coretesting.Attrs{"protected": true})
+	results, err := s.api.DestroyModels(params.DestroyModelsParams{
+		Models: []params.DestroyModelParams{{ModelTag: coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `model "testmodel" is protected from destruction and from removal of its applications and machines`)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeModelProtected)
+}
+
 // modelManagerStateSuite contains end-to-end tests.
 // Prefer adding tests to modelManagerSuite above.
 type modelManagerStateSuite struct {
@@ -1610,7 +1682,7 @@ func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 			&modelmanager.ModelManagerAPIV4{
 				&modelmanager.ModelManagerAPIV5{
 					&modelmanager.ModelManagerAPIV6{
-						s.api,
+						&modelmanager.ModelManagerAPIV7{s.api},
 					},
 				},
 			},
@@ -1647,7 +1719,7 @@ func (s *modelManagerSuite) TestModelStatusV3(c *gc.C) {
 		&modelmanager.ModelManagerAPIV4{
 			&modelmanager.ModelManagerAPIV5{
 				&modelmanager.ModelManagerAPIV6{
-					s.api,
+					&modelmanager.ModelManagerAPIV7{s.api},
 				},
 			},
 		},
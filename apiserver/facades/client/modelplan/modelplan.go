@@ -0,0 +1,487 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelplan
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// planExpiry is how long a plan may be applied for after it has been
+// computed. Plans that are not applied in time are discarded.
+const planExpiry = time.Hour
+
+// API provides access to the ModelPlan API facade, which computes
+// plans of the operations needed to make a set of changes to a model,
+// and applies them. A plan is only applied if the model hasn't changed
+// in a way that affects it since it was computed, so that the changes
+// made are those that were reviewed.
+type API struct {
+	st         *state.State
+	check      *common.BlockChecker
+	authorizer facade.Authorizer
+	clock      clock.Clock
+}
+
+// NewAPI returns a new ModelPlan API facade.
+func NewAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return newAPI(st, authorizer, clock.WallClock)
+}
+
+func newAPI(st *state.State, authorizer facade.Authorizer, clock clock.Clock) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		st:         st,
+		check:      common.NewBlockChecker(st),
+		authorizer: authorizer,
+		clock:      clock,
+	}, nil
+}
+
+func (api *API) checkCanWrite() error {
+	allowed, err := api.authorizer.HasPermission(permission.WriteAccess, names.NewModelTag(api.st.ModelUUID()))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ComputePlan computes the operations needed to make the given changes
+// to the model, and records them as a plan that can be applied with
+// ApplyPlan. Changes that would not alter the model are left out of
+// the plan.
+func (api *API) ComputePlan(args params.ModelPlanArgs) (params.ModelPlanResult, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ModelPlanResult{}, errors.Trace(err)
+	}
+	result, err := api.computePlan(args.Changes)
+	if err != nil {
+		return params.ModelPlanResult{Error: common.ServerError(err)}, nil
+	}
+	return result, nil
+}
+
+func (api *API) computePlan(changes []params.ModelPlanChange) (params.ModelPlanResult, error) {
+	p := planner{
+		st:       api.st,
+		deployed: make(map[string]bool),
+	}
+	for i, change := range changes {
+		if err := p.add(change); err != nil {
+			return params.ModelPlanResult{}, errors.Annotatef(err, "change %d", i)
+		}
+	}
+	if err := api.st.RemoveModelPlansCreatedBefore(api.clock.Now().Add(-planExpiry)); err != nil {
+		return params.ModelPlanResult{}, errors.Trace(err)
+	}
+	plan, err := api.st.AddModelPlan(p.operations)
+	if err != nil {
+		return params.ModelPlanResult{}, errors.Trace(err)
+	}
+	result := params.ModelPlanResult{
+		PlanID:     plan.ID,
+		Expires:    plan.Created.Add(planExpiry),
+		Operations: make([]params.ModelPlanOperation, len(plan.Operations)),
+	}
+	for i, op := range plan.Operations {
+		result.Operations[i] = params.ModelPlanOperation{
+			ID:             op.ID,
+			Kind:           string(op.Kind),
+			Description:    describe(op),
+			Application:    op.Application,
+			CharmURL:       op.CharmURL,
+			NumUnits:       op.NumUnits,
+			Config:         op.Config,
+			PreviousConfig: op.PreviousConfig,
+			Endpoints:      op.Endpoints,
+		}
+	}
+	return result, nil
+}
+
+// ApplyPlan applies the operations of the given plan, in order. None
+// are applied if the plan has expired, or if the model has changed
+// since the plan was computed so that any operation would no longer
+// have the effect it was planned to have. If an operation fails, the
+// later operations are not attempted. Either way, a plan can only be
+// applied once.
+func (api *API) ApplyPlan(args params.ApplyModelPlanArgs) (params.ApplyModelPlanResult, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ApplyModelPlanResult{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ApplyModelPlanResult{}, errors.Trace(err)
+	}
+	results, err := api.applyPlan(args.PlanID)
+	return params.ApplyModelPlanResult{
+		Results: results,
+		Error:   common.ServerError(err),
+	}, nil
+}
+
+func (api *API) applyPlan(id string) ([]params.ModelPlanOperationResult, error) {
+	plan, err := api.st.ModelPlan(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if api.clock.Now().After(plan.Created.Add(planExpiry)) {
+		if err := api.st.RemoveModelPlan(id); err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.Errorf("model plan %q has expired", id)
+	}
+	for _, op := range plan.Operations {
+		if err := api.checkOperation(op); err != nil {
+			return nil, errors.Annotatef(err, "model has changed since plan %q was computed", id)
+		}
+	}
+	// Claim the plan before changing anything, so that it is applied
+	// at most once.
+	if err := api.st.RemoveModelPlan(id); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []params.ModelPlanOperationResult
+	for _, op := range plan.Operations {
+		err := api.applyOperation(op)
+		results = append(results, params.ModelPlanOperationResult{
+			ID:    op.ID,
+			Error: common.ServerError(err),
+		})
+		if err != nil {
+			break
+		}
+	}
+	return results, nil
+}
+
+// checkOperation returns an error if the operation would no longer
+// have the effect it was planned to have.
+func (api *API) checkOperation(op state.ModelPlanOperation) error {
+	switch op.Kind {
+	case state.ModelPlanDeploy:
+		if _, err := api.st.Application(op.Application); err == nil {
+			return errors.AlreadyExistsf("application %q", op.Application)
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		return nil
+	case state.ModelPlanSetConfig:
+		app, err := api.st.Application(op.Application)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		current, err := app.CharmConfig(model.GenerationMaster)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, key := range sortedKeys(op.PreviousConfig) {
+			if !reflect.DeepEqual(current[key], op.PreviousConfig[key]) {
+				return errors.Errorf("config %q of application %q has changed", key, op.Application)
+			}
+		}
+		return nil
+	case state.ModelPlanAddRelation:
+		eps, err := api.st.InferEndpoints(op.Endpoints...)
+		if err != nil {
+			// The applications may be deployed by earlier
+			// operations of the plan.
+			return nil
+		}
+		if _, err := api.st.EndpointsRelation(eps...); err == nil {
+			return errors.AlreadyExistsf("relation %s", strings.Join(op.Endpoints, " "))
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		return nil
+	}
+	return errors.NotValidf("operation kind %q", op.Kind)
+}
+
+func (api *API) applyOperation(op state.ModelPlanOperation) error {
+	switch op.Kind {
+	case state.ModelPlanDeploy:
+		return errors.Trace(api.deploy(op))
+	case state.ModelPlanSetConfig:
+		app, err := api.st.Application(op.Application)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return errors.Trace(app.UpdateCharmConfig(model.GenerationMaster, charm.Settings(op.Config)))
+	case state.ModelPlanAddRelation:
+		eps, err := api.st.InferEndpoints(op.Endpoints...)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = api.st.AddRelation(eps...)
+		return errors.Trace(err)
+	}
+	return errors.NotValidf("operation kind %q", op.Kind)
+}
+
+func (api *API) deploy(op state.ModelPlanOperation) error {
+	curl, err := charm.ParseURL(op.CharmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, err := api.st.Charm(curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, err := api.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	series := curl.Series
+	if series == "" {
+		cfg, err := m.Config()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		series = config.PreferredSeries(cfg)
+	}
+	add := state.QuotaUsage{Units: op.NumUnits}
+	if m.Type() == state.ModelTypeIAAS {
+		add.Machines = op.NumUnits
+	}
+	if err := api.st.CheckQuotas(m.UUID(), add); err != nil {
+		return errors.Trace(err)
+	}
+	_, err = application.DeployApplication(stateDeployer{api.st}, application.DeployApplicationParams{
+		ApplicationName: op.Application,
+		Series:          series,
+		Charm:           ch,
+		CharmConfig:     charm.Settings(op.Config),
+		NumUnits:        op.NumUnits,
+	})
+	return errors.Trace(err)
+}
+
+// stateDeployer adds applications to the model for
+// application.DeployApplication.
+type stateDeployer struct {
+	*state.State
+}
+
+func (d stateDeployer) AddApplication(args state.AddApplicationArgs) (application.Application, error) {
+	app, err := d.State.AddApplication(args)
+	if err != nil {
+		return nil, err
+	}
+	return application.NewStateApplication(d.State, app), nil
+}
+
+// planner computes the operations of a plan from the desired changes
+// to the model.
+type planner struct {
+	st         *state.State
+	operations []state.ModelPlanOperation
+
+	// deployed records the applications deployed by the plan.
+	deployed map[string]bool
+}
+
+func (p *planner) add(change params.ModelPlanChange) error {
+	switch state.ModelPlanOperationKind(change.Kind) {
+	case state.ModelPlanDeploy:
+		return errors.Trace(p.addDeploy(change))
+	case state.ModelPlanSetConfig:
+		return errors.Trace(p.addSetConfig(change))
+	case state.ModelPlanAddRelation:
+		return errors.Trace(p.addRelation(change))
+	}
+	return errors.NotValidf("change kind %q", change.Kind)
+}
+
+func (p *planner) addOperation(op state.ModelPlanOperation) {
+	op.ID = strconv.Itoa(len(p.operations) + 1)
+	p.operations = append(p.operations, op)
+}
+
+// applicationExists reports whether the application is in the model,
+// or is deployed by the plan.
+func (p *planner) applicationExists(name string) (bool, error) {
+	if p.deployed[name] {
+		return true, nil
+	}
+	_, err := p.st.Application(name)
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+func (p *planner) addDeploy(change params.ModelPlanChange) error {
+	if !names.IsValidApplication(change.Application) {
+		return errors.NotValidf("application name %q", change.Application)
+	}
+	if exists, err := p.applicationExists(change.Application); err != nil {
+		return errors.Trace(err)
+	} else if exists {
+		return errors.AlreadyExistsf("application %q", change.Application)
+	}
+	if change.NumUnits < 0 {
+		return errors.NotValidf("number of units %d", change.NumUnits)
+	}
+	curl, err := charm.ParseURL(change.CharmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, err := p.st.Charm(curl)
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("charm %q in the model", curl)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if ch.Meta().Subordinate && change.NumUnits != 0 {
+		return errors.New("subordinate application must be deployed without units")
+	}
+	settings, err := ch.Config().ValidateSettings(charm.Settings(change.Config))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	op := state.ModelPlanOperation{
+		Kind:        state.ModelPlanDeploy,
+		Application: change.Application,
+		CharmURL:    curl.String(),
+		NumUnits:    change.NumUnits,
+	}
+	if len(settings) > 0 {
+		op.Config = settings
+	}
+	p.deployed[change.Application] = true
+	p.addOperation(op)
+	return nil
+}
+
+func (p *planner) addSetConfig(change params.ModelPlanChange) error {
+	if p.deployed[change.Application] {
+		return errors.Errorf("application %q is deployed by the plan; include its config in the deploy change", change.Application)
+	}
+	app, err := p.st.Application(change.Application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ch, _, err := app.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	settings, err := ch.Config().ValidateSettings(charm.Settings(change.Config))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, err := app.CharmConfig(model.GenerationMaster)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	changed := make(map[string]interface{})
+	previous := make(map[string]interface{})
+	for key, value := range settings {
+		if !reflect.DeepEqual(current[key], value) {
+			changed[key] = value
+			previous[key] = current[key]
+		}
+	}
+	if len(changed) == 0 {
+		return nil
+	}
+	p.addOperation(state.ModelPlanOperation{
+		Kind:           state.ModelPlanSetConfig,
+		Application:    change.Application,
+		Config:         changed,
+		PreviousConfig: previous,
+	})
+	return nil
+}
+
+func (p *planner) addRelation(change params.ModelPlanChange) error {
+	if len(change.Endpoints) != 2 {
+		return errors.Errorf("a relation must have two endpoints, got %d", len(change.Endpoints))
+	}
+	planned := false
+	for _, ep := range change.Endpoints {
+		name := strings.Split(ep, ":")[0]
+		exists, err := p.applicationExists(name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if !exists {
+			return errors.NotFoundf("application %q", name)
+		}
+		planned = planned || p.deployed[name]
+	}
+	endpoints := change.Endpoints
+	if !planned {
+		// Both applications are already in the model, so the
+		// endpoints can be resolved now.
+		eps, err := p.st.InferEndpoints(change.Endpoints...)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := p.st.EndpointsRelation(eps...); err == nil {
+			return nil
+		} else if !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+		endpoints = make([]string, len(eps))
+		for i, ep := range eps {
+			endpoints[i] = ep.String()
+		}
+	}
+	p.addOperation(state.ModelPlanOperation{
+		Kind:      state.ModelPlanAddRelation,
+		Endpoints: endpoints,
+	})
+	return nil
+}
+
+// describe returns a human readable description of the operation.
+func describe(op state.ModelPlanOperation) string {
+	switch op.Kind {
+	case state.ModelPlanDeploy:
+		return fmt.Sprintf("deploy application %q from %s with %d unit(s)", op.Application, op.CharmURL, op.NumUnits)
+	case state.ModelPlanSetConfig:
+		changes := make([]string, 0, len(op.Config))
+		for _, key := range sortedKeys(op.Config) {
+			changes = append(changes, fmt.Sprintf("%s: %v -> %v", key, op.PreviousConfig[key], op.Config[key]))
+		}
+		return fmt.Sprintf("set config of application %q (%s)", op.Application, strings.Join(changes, ", "))
+	case state.ModelPlanAddRelation:
+		return fmt.Sprintf("relate %s", strings.Join(op.Endpoints, " and "))
+	}
+	return string(op.Kind)
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,194 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelplan_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/modelplan"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/model"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type modelPlanSuite struct {
+	jujutesting.JujuConnSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	clock      *testclock.Clock
+	api        *modelplan.API
+	mysqlURL   string
+	wordpress  *state.Application
+}
+
+var _ = gc.Suite(&modelPlanSuite{})
+
+func (s *modelPlanSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      s.AdminUserTag(c),
+		AdminTag: s.AdminUserTag(c),
+	}
+	s.clock = testclock.NewClock(time.Now())
+	var err error
+	s.api, err = modelplan.NewAPIForTest(s.State, s.authorizer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.mysqlURL = s.AddTestingCharm(c, "mysql").URL().String()
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *modelPlanSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelplan.NewAPIForTest(s.State, s.authorizer, s.clock)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *modelPlanSuite) TestComputePlanRequiresWriteAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	api, err := modelplan.NewAPIForTest(s.State, s.authorizer, s.clock)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.ComputePlan(params.ModelPlanArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelPlanSuite) computePlan(c *gc.C, changes ...params.ModelPlanChange) params.ModelPlanResult {
+	result, err := s.api.ComputePlan(params.ModelPlanArgs{Changes: changes})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	return result
+}
+
+func (s *modelPlanSuite) TestComputeAndApplyPlan(c *gc.C) {
+	plan := s.computePlan(c, params.ModelPlanChange{
+		Kind:        "deploy",
+		Application: "mysql",
+		CharmURL:    s.mysqlURL,
+		NumUnits:    1,
+	}, params.ModelPlanChange{
+		Kind:        "set-config",
+		Application: "wordpress",
+		Config:      map[string]interface{}{"blog-title": "Planned"},
+	}, params.ModelPlanChange{
+		Kind:      "add-relation",
+		Endpoints: []string{"wordpress", "mysql"},
+	})
+	c.Assert(plan.PlanID, gc.Not(gc.Equals), "")
+	c.Assert(plan.Operations, jc.DeepEquals, []params.ModelPlanOperation{{
+		ID:          "1",
+		Kind:        "deploy",
+		Description: `deploy application "mysql" from ` + s.mysqlURL + ` with 1 unit(s)`,
+		Application: "mysql",
+		CharmURL:    s.mysqlURL,
+		NumUnits:    1,
+	}, {
+		ID:             "2",
+		Kind:           "set-config",
+		Description:    `set config of application "wordpress" (blog-title: My Title -> Planned)`,
+		Application:    "wordpress",
+		Config:         map[string]interface{}{"blog-title": "Planned"},
+		PreviousConfig: map[string]interface{}{"blog-title": "My Title"},
+	}, {
+		ID:          "3",
+		Kind:        "add-relation",
+		Description: "relate wordpress and mysql",
+		Endpoints:   []string{"wordpress", "mysql"},
+	}})
+
+	result, err := s.api.ApplyPlan(params.ApplyModelPlanArgs{PlanID: plan.PlanID})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ApplyModelPlanResult{
+		Results: []params.ModelPlanOperationResult{{ID: "1"}, {ID: "2"}, {ID: "3"}},
+	})
+
+	mysql, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mysql.UnitCount(), gc.Equals, 1)
+	cfg, err := s.wordpress.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg["blog-title"], gc.Equals, "Planned")
+	_, err = s.State.KeyRelation("wordpress:db mysql:server")
+	c.Check(err, jc.ErrorIsNil)
+
+	// A plan can only be applied once.
+	result, err = s.api.ApplyPlan(params.ApplyModelPlanArgs{PlanID: plan.PlanID})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `model plan ".*" not found`)
+}
+
+func (s *modelPlanSuite) TestComputePlanSkipsUnchanged(c *gc.C) {
+	plan := s.computePlan(c, params.ModelPlanChange{
+		Kind:        "set-config",
+		Application: "wordpress",
+		Config:      map[string]interface{}{"blog-title": "My Title"},
+	})
+	c.Assert(plan.Operations, gc.HasLen, 0)
+}
+
+func (s *modelPlanSuite) TestComputePlanErrors(c *gc.C) {
+	for _, test := range []struct {
+		change params.ModelPlanChange
+		err    string
+	}{{
+		change: params.ModelPlanChange{Kind: "remove-unit"},
+		err:    `change 0: change kind "remove-unit" not valid`,
+	}, {
+		change: params.ModelPlanChange{Kind: "deploy", Application: "wordpress", CharmURL: s.mysqlURL},
+		err:    `change 0: application "wordpress" already exists`,
+	}, {
+		change: params.ModelPlanChange{Kind: "deploy", Application: "riak", CharmURL: "cs:quantal/riak-7"},
+		err:    `change 0: charm "cs:quantal/riak-7" in the model not found`,
+	}, {
+		change: params.ModelPlanChange{Kind: "set-config", Application: "wordpress", Config: map[string]interface{}{"no-such": "option"}},
+		err:    `change 0: unknown option "no-such"`,
+	}, {
+		change: params.ModelPlanChange{Kind: "add-relation", Endpoints: []string{"wordpress", "mysql"}},
+		err:    `change 0: application "mysql" not found`,
+	}} {
+		result, err := s.api.ComputePlan(params.ModelPlanArgs{Changes: []params.ModelPlanChange{test.change}})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(result.Error, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *modelPlanSuite) TestApplyPlanModelChanged(c *gc.C) {
+	plan := s.computePlan(c, params.ModelPlanChange{
+		Kind:        "set-config",
+		Application: "wordpress",
+		Config:      map[string]interface{}{"blog-title": "Planned"},
+	})
+	err := s.wordpress.UpdateCharmConfig(model.GenerationMaster, charm.Settings{"blog-title": "Changed"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.ApplyPlan(params.ApplyModelPlanArgs{PlanID: plan.PlanID})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `model has changed since plan ".*" was computed: config "blog-title" of application "wordpress" has changed`)
+	cfg, err := s.wordpress.CharmConfig(model.GenerationMaster)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg["blog-title"], gc.Equals, "Changed")
+}
+
+func (s *modelPlanSuite) TestApplyPlanExpired(c *gc.C) {
+	plan := s.computePlan(c, params.ModelPlanChange{
+		Kind:        "set-config",
+		Application: "wordpress",
+		Config:      map[string]interface{}{"blog-title": "Planned"},
+	})
+	s.clock.Advance(2 * time.Hour)
+
+	result, err := s.api.ApplyPlan(params.ApplyModelPlanArgs{PlanID: plan.PlanID})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `model plan ".*" has expired`)
+	_, err = s.State.ModelPlan(plan.PlanID)
+	c.Assert(err, gc.ErrorMatches, `model plan ".*" not found`)
+}
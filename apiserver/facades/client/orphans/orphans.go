@@ -0,0 +1,119 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphans
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// API provides access to the Orphans API facade, which finds provider
+// resources tagged with a model but unknown to Juju.
+type API struct {
+	knownResources func() (environs.KnownModelResources, error)
+	newEnviron     func() (environs.Environ, error)
+	callContext    context.ProviderCallContext
+}
+
+// createAPI returns a new Orphans API facade for the model.
+func createAPI(
+	knownResources func() (environs.KnownModelResources, error),
+	newEnviron func() (environs.Environ, error),
+	callContext context.ProviderCallContext,
+	authorizer facade.Authorizer,
+	controllerTag names.ControllerTag,
+	modelTag names.ModelTag,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, controllerTag)
+	if err == nil && !isAdmin {
+		isAdmin, err = authorizer.HasPermission(permission.AdminAccess, modelTag)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		knownResources: knownResources,
+		newEnviron:     newEnviron,
+		callContext:    callContext,
+	}, nil
+}
+
+// NewAPI returns a new Orphans API facade.
+func NewAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return createAPI(
+		func() (environs.KnownModelResources, error) {
+			return common.KnownModelResources(st)
+		},
+		func() (environs.Environ, error) {
+			return stateenvirons.GetNewEnvironFunc(environs.New)(st)
+		},
+		state.CallContext(st),
+		authorizer,
+		st.ControllerTag(),
+		m.ModelTag(),
+	)
+}
+
+// FindOrphans returns the provider resources tagged with the model that
+// Juju does not know about. Such resources are usually left behind by
+// operations that were interrupted or forced, and may be removed by
+// enabling the remove-orphaned-resources model config.
+func (api *API) FindOrphans() (params.ModelResourcesResult, error) {
+	orphans, err := api.findOrphans()
+	if err != nil {
+		return params.ModelResourcesResult{Error: common.ServerError(err)}, nil
+	}
+	result := params.ModelResourcesResult{
+		Resources: make([]params.ModelResource, len(orphans)),
+	}
+	for i, r := range orphans {
+		result.Resources[i] = params.ModelResource{
+			Kind:  string(r.Kind),
+			Id:    r.Id,
+			Owner: r.Owner,
+		}
+	}
+	return result, nil
+}
+
+func (api *API) findOrphans() ([]environs.ModelResource, error) {
+	env, err := api.newEnviron()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelResources, ok := environs.SupportsModelResources(env)
+	if !ok {
+		return nil, errors.NotSupportedf("finding orphaned resources on %q", env.Config().Type())
+	}
+	// The provider's resources are listed before reading what Juju knows
+	// about, so that resources created in between are not reported.
+	resources, err := modelResources.AllModelResources(api.callContext)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing provider resources")
+	}
+	known, err := api.knownResources()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return environs.OrphanedModelResources(resources, known), nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphans_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/orphans"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type orphansSuite struct {
+	testing.IsolationSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	environ    *mockEnviron
+	known      environs.KnownModelResources
+}
+
+var _ = gc.Suite(&orphansSuite{})
+
+func (s *orphansSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	admin := names.NewUserTag("admin")
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      admin,
+		AdminTag: admin,
+	}
+	s.environ = &mockEnviron{
+		config: coretesting.ModelConfig(c),
+		resources: []environs.ModelResource{
+			{Kind: environs.InstanceResource, Id: "i-known"},
+			{Kind: environs.InstanceResource, Id: "i-leaked"},
+			{Kind: environs.SecurityGroupResource, Id: "sg-1", Owner: "machine-1"},
+		},
+	}
+	s.known = environs.KnownModelResources{
+		InstanceIds: []string{"i-known"},
+		Machines:    []string{"machine-0"},
+	}
+}
+
+func (s *orphansSuite) newAPI() (*orphans.API, error) {
+	return orphans.CreateAPI(
+		func() (environs.KnownModelResources, error) { return s.known, nil },
+		func() (environs.Environ, error) { return s.environ, nil },
+		context.NewCloudCallContext(),
+		s.authorizer,
+		coretesting.ControllerTag,
+		coretesting.ModelTag,
+	)
+}
+
+func (s *orphansSuite) TestNewAPIRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	_, err := s.newAPI()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *orphansSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := s.newAPI()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *orphansSuite) TestFindOrphans(c *gc.C) {
+	api, err := s.newAPI()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.FindOrphans()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelResourcesResult{
+		Resources: []params.ModelResource{
+			{Kind: "instance", Id: "i-leaked"},
+			{Kind: "security-group", Id: "sg-1", Owner: "machine-1"},
+		},
+	})
+}
+
+func (s *orphansSuite) TestFindOrphansNotSupported(c *gc.C) {
+	api, err := orphans.CreateAPI(
+		func() (environs.KnownModelResources, error) { return s.known, nil },
+		func() (environs.Environ, error) { return &unsupportedEnviron{config: s.environ.config}, nil },
+		context.NewCloudCallContext(),
+		s.authorizer,
+		coretesting.ControllerTag,
+		coretesting.ModelTag,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.FindOrphans()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `finding orphaned resources on "someprovider" not supported`)
+	c.Assert(result.Error.Code, gc.Equals, params.CodeNotSupported)
+}
+
+type unsupportedEnviron struct {
+	environs.Environ
+	config *config.Config
+}
+
+func (e *unsupportedEnviron) Config() *config.Config {
+	return e.config
+}
+
+type mockEnviron struct {
+	environs.Environ
+	config    *config.Config
+	resources []environs.ModelResource
+}
+
+func (e *mockEnviron) Config() *config.Config {
+	return e.config
+}
+
+func (e *mockEnviron) AllModelResources(context.ProviderCallContext) ([]environs.ModelResource, error) {
+	return e.resources, nil
+}
+
+func (e *mockEnviron) DestroyModelResources(context.ProviderCallContext, []environs.ModelResource) error {
+	return nil
+}
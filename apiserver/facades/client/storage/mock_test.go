@@ -193,6 +193,7 @@ type mockVolume struct {
 	storage *names.StorageTag
 	info    *state.VolumeInfo
 	life    state.Life
+	status  *status.StatusInfo
 }
 
 func (m *mockVolume) StorageInstance() (names.StorageTag, error) {
@@ -225,9 +226,17 @@ func (m *mockVolume) Life() state.Life {
 }
 
 func (m *mockVolume) Status() (status.StatusInfo, error) {
+	if m.status != nil {
+		return *m.status, nil
+	}
 	return status.StatusInfo{Status: status.Attached}, nil
 }
 
+func (m *mockVolume) SetStatus(info status.StatusInfo) error {
+	m.status = &info
+	return nil
+}
+
 type mockFilesystem struct {
 	state.Filesystem
 	tag     names.FilesystemTag
@@ -235,6 +244,7 @@ type mockFilesystem struct {
 	volume  *names.VolumeTag
 	info    *state.FilesystemInfo
 	life    state.Life
+	status  *status.StatusInfo
 }
 
 func (m *mockFilesystem) Storage() (names.StorageTag, error) {
@@ -267,9 +277,17 @@ func (m *mockFilesystem) Life() state.Life {
 }
 
 func (m *mockFilesystem) Status() (status.StatusInfo, error) {
+	if m.status != nil {
+		return *m.status, nil
+	}
 	return status.StatusInfo{Status: status.Attached}, nil
 }
 
+func (m *mockFilesystem) SetStatus(info status.StatusInfo) error {
+	m.status = &info
+	return nil
+}
+
 type mockFilesystemAttachment struct {
 	state.FilesystemAttachment
 	filesystem names.FilesystemTag
@@ -436,6 +454,7 @@ func (u *mockUnit) AssignedMachineId() (string, error) {
 type mockState struct {
 	modelTag        names.ModelTag
 	getBlockForType func(t state.BlockType) (state.Block, bool, error)
+	checkQuotas     func(modelUUID string, add state.QuotaUsage) error
 	unitName        string
 	unitErr         string
 	assignedMachine string
@@ -453,6 +472,13 @@ func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, erro
 	return st.getBlockForType(t)
 }
 
+func (st *mockState) CheckQuotas(modelUUID string, add state.QuotaUsage) error {
+	if st.checkQuotas == nil {
+		return nil
+	}
+	return st.checkQuotas(modelUUID, add)
+}
+
 func (st *mockState) Unit(unitName string) (storage.Unit, error) {
 	if st.unitErr != "" {
 		return nil, errors.New(st.unitErr)
@@ -148,6 +148,7 @@ type backend interface {
 	ModelTag() names.ModelTag
 	Unit(string) (Unit, error)
 	GetBlockForType(state.BlockType) (state.Block, bool, error)
+	CheckQuotas(string, state.QuotaUsage) error
 }
 
 type Unit interface {
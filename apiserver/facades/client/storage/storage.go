@@ -27,7 +27,7 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
-// StorageAPI implements the latest version (v5) of the Storage API which adds Update and Delete.
+// StorageAPI implements the latest version (v6) of the Storage API which adds RetryStorage.
 type StorageAPI struct {
 	backend       backend
 	storageAccess storageAccess
@@ -38,9 +38,14 @@ type StorageAPI struct {
 	modelType     state.ModelType
 }
 
+// APIv5 implements the storage v5 API adding Update and Delete.
+type StorageAPIv5 struct {
+	StorageAPI
+}
+
 // APIv4 implements the storage v4 API adding AddToUnit, Import and Remove (replacing Destroy)
 type StorageAPIv4 struct {
-	StorageAPI
+	StorageAPIv5
 }
 
 // APIv3 implements the storage v3 API.
@@ -96,14 +101,25 @@ func newStorageAPI(
 	}
 }
 
+// NewStorageAPIV5 returns a new storage v5 API facade.
+func NewStorageAPIV5(context facade.Context) (*StorageAPIv5, error) {
+	storageAPI, err := NewStorageAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &StorageAPIv5{
+		StorageAPI: *storageAPI,
+	}, nil
+}
+
 // NewStorageAPIV4 returns a new storage v4 API facade.
 func NewStorageAPIV4(context facade.Context) (*StorageAPIv4, error) {
-	storageAPI, err := NewStorageAPI(context)
+	storageAPI, err := NewStorageAPIV5(context)
 	if err != nil {
 		return nil, err
 	}
 	return &StorageAPIv4{
-		StorageAPI: *storageAPI,
+		StorageAPIv5: *storageAPI,
 	}, nil
 }
 
@@ -869,9 +885,13 @@ func (a *StorageAPI) addToUnit(args params.StoragesAddParams) (params.AddStorage
 			continue
 		}
 
-		tags, err := a.storageAccess.AddStorageForUnit(
-			u, one.StorageName, paramsToState(one.Constraints),
-		)
+		cons := paramsToState(one.Constraints)
+		if err := a.checkStorageQuota(cons); err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+
+		tags, err := a.storageAccess.AddStorageForUnit(u, one.StorageName, cons)
 		if err != nil {
 			result[i].Error = common.ServerError(err)
 		}
@@ -886,6 +906,18 @@ func (a *StorageAPI) addToUnit(args params.StoragesAddParams) (params.AddStorage
 	return params.AddStorageResults{Results: result}, nil
 }
 
+// checkStorageQuota checks that adding storage with the given
+// constraints doesn't exceed the storage quotas. Storage added without
+// a size is sized by the charm, and isn't counted.
+func (a *StorageAPI) checkStorageQuota(cons state.StorageConstraints) error {
+	count := cons.Count
+	if count == 0 {
+		count = 1
+	}
+	add := state.QuotaUsage{StorageMiB: cons.Size * count}
+	return errors.Trace(a.backend.CheckQuotas(a.backend.ModelTag().Id(), add))
+}
+
 // Remove sets the specified storage entities to Dying, unless they are
 // already Dying or Dead, such that the storage will eventually be removed
 // from the model. If the arguments specify that the storage should be
@@ -1030,6 +1062,84 @@ func (a *StorageAPI) attachStorage(storageTag names.StorageTag, unitTag names.Un
 	return a.storageAccess.AttachStorage(storageTag, unitTag)
 }
 
+// RetryStorage requests that the storage provisioner immediately retry
+// the failed operations on the volumes or filesystems of the specified
+// storage instances, rather than wait for their next scheduled attempt.
+// A "CHANGE" block can block this operation.
+func (a *StorageAPI) RetryStorage(args params.Entities) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	blockChecker := common.NewBlockChecker(a.backend)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := make([]params.ErrorResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		storageTag, err := names.ParseStorageTag(arg.Tag)
+		if err != nil {
+			result[i].Error = common.ServerError(err)
+			continue
+		}
+		result[i].Error = common.ServerError(a.retryStorage(storageTag))
+	}
+	return params.ErrorResults{Results: result}, nil
+}
+
+func (a *StorageAPI) retryStorage(storageTag names.StorageTag) error {
+	storageInstance, err := a.storageAccess.StorageInstance(storageTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The provisioner retries the failed operations on the volume or
+	// filesystem of the storage instance, whose status records them.
+	var entity interface {
+		status.StatusGetter
+		status.StatusSetter
+	}
+	if storageInstance.Kind() == state.StorageKindFilesystem {
+		stFile := a.storageAccess.FilesystemAccess()
+		if stFile == nil {
+			return errors.NotImplementedf("FilesystemStorage instance")
+		}
+		filesystem, err := stFile.StorageInstanceFilesystem(storageTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		entity = filesystem
+	} else {
+		stVolume := a.storageAccess.VolumeAccess()
+		if stVolume == nil {
+			return errors.NotImplementedf("BlockStorage instance")
+		}
+		volume, err := stVolume.StorageInstanceVolume(storageTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		entity = volume
+	}
+
+	info, err := entity.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := info.Data[storage.StatusDataNextRetry]; !ok {
+		return errors.Errorf(
+			"storage %s has no failed operations to retry",
+			names.ReadableString(storageTag),
+		)
+	}
+	data := make(map[string]interface{})
+	for k, v := range info.Data {
+		data[k] = v
+	}
+	data[storage.StatusDataRetryRequested] = true
+	info.Data = data
+	return errors.Trace(entity.SetStatus(info))
+}
+
 // Import imports existing storage into the model.
 // A "CHANGE" block can block this operation.
 func (a *StorageAPI) Import(args params.BulkImportStorageParams) (params.ImportStorageResults, error) {
@@ -1190,6 +1300,9 @@ func (a *StorageAPI) UpdatePool(p params.StoragePoolArgs) (params.ErrorResults,
 // so this removes the method as far as the RPC machinery is concerned.
 
 // Added in current api version
+func (*StorageAPIv5) RetryStorage(_, _ struct{}) {}
+
+// Added in v5
 func (*StorageAPIv4) RemovePool(_, _ struct{}) {}
 func (*StorageAPIv4) UpdatePool(_, _ struct{}) {}
 
@@ -498,6 +498,88 @@ func (s *storageSuite) TestAttach(c *gc.C) {
 	})
 }
 
+func (s *storageSuite) TestRetryStorage(c *gc.C) {
+	s.filesystem.status = &status.StatusInfo{
+		Status:  status.Error,
+		Message: "creating filesystem: boom",
+		Data: map[string]interface{}{
+			"attempts":   2,
+			"next-retry": "2019-01-01T00:00:40Z",
+		},
+	}
+	results, err := s.api.RetryStorage(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+		{Tag: "storage-foo-0"},
+		{Tag: "volume-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: nil},
+		{Error: &params.Error{Code: params.CodeNotFound, Message: "foo/0 not found"}},
+		{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+	})
+	c.Assert(s.filesystem.status, jc.DeepEquals, &status.StatusInfo{
+		Status:  status.Error,
+		Message: "creating filesystem: boom",
+		Data: map[string]interface{}{
+			"attempts":        2,
+			"next-retry":      "2019-01-01T00:00:40Z",
+			"retry-requested": true,
+		},
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{storageInstanceCall, []interface{}{s.storageTag}},
+		{storageInstanceFilesystemCall, nil},
+		{storageInstanceCall, []interface{}{names.NewStorageTag("foo/0")}},
+	})
+}
+
+func (s *storageSuite) TestRetryStorageVolume(c *gc.C) {
+	s.storageInstance.kind = state.StorageKindBlock
+	s.volume.status = &status.StatusInfo{
+		Status: status.Pending,
+		Data: map[string]interface{}{
+			"attempts":   1,
+			"next-retry": "2019-01-01T00:00:30Z",
+		},
+	}
+	results, err := s.api.RetryStorage(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{{}})
+	c.Assert(s.volume.status.Data, jc.DeepEquals, map[string]interface{}{
+		"attempts":        1,
+		"next-retry":      "2019-01-01T00:00:30Z",
+		"retry-requested": true,
+	})
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{getBlockForTypeCall, []interface{}{state.ChangeBlock}},
+		{storageInstanceCall, []interface{}{s.storageTag}},
+		{storageInstanceVolumeCall, nil},
+	})
+}
+
+func (s *storageSuite) TestRetryStorageNoFailedOperations(c *gc.C) {
+	results, err := s.api.RetryStorage(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{Error: &params.Error{Message: "storage data/0 has no failed operations to retry"}},
+	})
+	c.Assert(s.filesystem.status, gc.IsNil)
+}
+
+func (s *storageSuite) TestRetryStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestRetryStorageBlocked")
+	_, err := s.api.RetryStorage(params.Entities{[]params.Entity{
+		{Tag: "storage-data-0"},
+	}})
+	s.assertBlocked(c, err, "TestRetryStorageBlocked")
+}
+
 func (s *storageSuite) TestImportFilesystem(c *gc.C) {
 	s.state.modelTag = coretesting.ModelTag
 	filesystemSource := filesystemImporter{&dummy.FilesystemSource{}}
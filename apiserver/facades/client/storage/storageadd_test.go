@@ -177,3 +177,27 @@ func (s *storageAddSuite) TestStorageAddUnitNotFoundErr(c *gc.C) {
 	c.Assert(failures.Results[0].Error.Error(), gc.Matches, "sanity not found")
 	c.Assert(failures.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
 }
+
+func (s *storageAddSuite) TestStorageAddUnitQuotaExceeded(c *gc.C) {
+	var requested state.QuotaUsage
+	s.state.checkQuotas = func(modelUUID string, add state.QuotaUsage) error {
+		requested = add
+		return &state.QuotaExceededError{
+			Entity: `model "foo"`, Resource: "MiB of storage", Limit: 4096, Used: 2048, Requested: 3072,
+		}
+	}
+	size, count := uint64(1024), uint64(3)
+	args := params.StorageAddParams{
+		UnitTag:     s.unitTag.String(),
+		StorageName: "data",
+		Constraints: params.StorageConstraints{Size: &size, Count: &count},
+	}
+	results, err := s.api.AddToUnit(params.StoragesAddParams{[]params.StorageAddParams{args}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`quota exceeded: model "foo" is limited to 4096 MiB of storage \(using 2048, requested 3072\)`)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeQuotaExceeded)
+	c.Assert(requested, gc.Equals, state.QuotaUsage{StorageMiB: 3072})
+	s.assertCalls(c, []string{getBlockForTypeCall})
+}
@@ -0,0 +1,501 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package timeline
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// The kinds of events in a timeline.
+const (
+	KindStatus  = "status"
+	KindAgent   = "agent"
+	KindHook    = "hook"
+	KindAction  = "action"
+	KindCleanup = "cleanup"
+)
+
+// AllKinds holds all the kinds of events in a timeline.
+var AllKinds = set.NewStrings(KindStatus, KindAgent, KindHook, KindAction, KindCleanup)
+
+// defaultSize is the number of events returned when neither a size
+// nor a start time is given.
+const defaultSize = 100
+
+// hookMessage matches the agent status message set by the uniter
+// while it runs a hook.
+var hookMessage = regexp.MustCompile(`^running (\S+) hook$`)
+
+// API provides access to the Timeline API facade, which merges the
+// status history, hook executions, actions and pending cleanups of a
+// model or its entities into a single stream of events ordered by time.
+type API struct {
+	st         *state.State
+	authorizer facade.Authorizer
+}
+
+// APIv1 provides the Timeline API facade for version 1, which cannot
+// include archived status history.
+type APIv1 struct {
+	*API
+}
+
+// NewAPI returns a new Timeline API facade.
+func NewAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	return newAPI(st, authorizer)
+}
+
+// NewAPIV1 returns a new Timeline API facade for version 1.
+func NewAPIV1(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*APIv1, error) {
+	api, err := newAPI(st, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
+func newAPI(st *state.State, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		st:         st,
+		authorizer: authorizer,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	allowed, err := api.authorizer.HasPermission(permission.ReadAccess, names.NewModelTag(api.st.ModelUUID()))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Timeline returns the events of the entity with the given tag, or of
+// the whole model if no entity is given, oldest first.
+func (api *API) Timeline(args params.TimelineArgs) (params.TimelineResult, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.TimelineResult{}, errors.Trace(err)
+	}
+	events, err := api.timeline(args)
+	if err != nil {
+		return params.TimelineResult{Error: common.ServerError(err)}, nil
+	}
+	return params.TimelineResult{Events: events}, nil
+}
+
+// Timeline returns the events of the entity with the given tag, or of
+// the whole model if no entity is given, oldest first.
+// V1 Timeline did not support archived status history.
+func (api *APIv1) Timeline(args params.TimelineArgs) (params.TimelineResult, error) {
+	args.Archived = false
+	return api.API.Timeline(args)
+}
+
+func (api *API) timeline(args params.TimelineArgs) ([]params.TimelineEvent, error) {
+	kinds := AllKinds
+	if len(args.Kinds) > 0 {
+		kinds = set.NewStrings(args.Kinds...)
+		if unknown := kinds.Difference(AllKinds); !unknown.IsEmpty() {
+			return nil, errors.NotValidf("event kind %q", unknown.SortedValues()[0])
+		}
+	}
+	if args.Size < 0 {
+		return nil, errors.NotValidf("negative size")
+	}
+	size := args.Size
+	filter := status.StatusHistoryFilter{FromDate: args.Since}
+	if args.Since == nil {
+		if size == 0 {
+			size = defaultSize
+		}
+		filter.Size = size
+	}
+	c := collector{
+		kinds:  kinds,
+		filter: filter,
+	}
+	if args.Archived {
+		var from, to time.Time
+		if args.Since != nil {
+			from = *args.Since
+		}
+		if args.Until != nil {
+			to = *args.Until
+		}
+		c.archived = func(kind status.HistoryKind, tag names.Tag) ([]status.StatusInfo, error) {
+			return api.st.ArchivedStatusHistory(kind, tag, from, to)
+		}
+	}
+
+	var (
+		prefixes []string
+		err      error
+	)
+	if args.Entity == "" {
+		err = api.collectModel(&c)
+	} else {
+		var tag names.Tag
+		tag, err = names.ParseTag(args.Entity)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch tag := tag.(type) {
+		case names.ModelTag:
+			if tag.Id() != api.st.ModelUUID() {
+				return nil, errors.NotFoundf("model %q", tag.Id())
+			}
+			err = api.collectModel(&c)
+		case names.ApplicationTag:
+			prefixes, err = api.collectApplication(&c, tag.Id())
+		case names.UnitTag:
+			prefixes = []string{tag.Id()}
+			err = api.collectUnit(&c, tag.Id())
+		case names.MachineTag:
+			prefixes = []string{tag.Id()}
+			err = api.collectMachine(&c, tag.Id())
+		default:
+			return nil, errors.NotValidf("timeline of %q", args.Entity)
+		}
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if kinds.Contains(KindCleanup) {
+		if err := api.collectCleanups(&c, prefixes); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+
+	events := make([]params.TimelineEvent, 0, len(c.events))
+	for _, event := range c.events {
+		if args.Since != nil && event.Time.Before(*args.Since) {
+			continue
+		}
+		if args.Until != nil && event.Time.After(*args.Until) {
+			continue
+		}
+		events = append(events, event)
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Time.Before(events[j].Time)
+	})
+	if size > 0 && len(events) > size {
+		events = events[len(events)-size:]
+	}
+	return events, nil
+}
+
+func (api *API) collectModel(c *collector) error {
+	model, err := api.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.kinds.Contains(KindStatus) {
+		history, err := model.StatusHistory(c.filter)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// Models have a single status history, of no kind.
+		history, err = c.withArchived("", model.ModelTag(), history)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.addStatuses(KindStatus, model.ModelTag(), history)
+	}
+	apps, err := api.st.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, app := range apps {
+		if _, err := api.collectApplication(c, app.Name()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	machines, err := api.st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, machine := range machines {
+		if err := c.addMachine(machine); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// collectApplication collects the events of the named application and
+// its units, and returns the prefixes of the cleanups that affect them.
+func (api *API) collectApplication(c *collector, name string) ([]string, error) {
+	app, err := api.st.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if c.kinds.Contains(KindStatus) {
+		history, err := app.StatusHistory(c.filter)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Applications have a single status history, of no kind.
+		history, err = c.withArchived("", app.ApplicationTag(), history)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		c.addStatuses(KindStatus, app.ApplicationTag(), history)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	prefixes := []string{name}
+	for _, unit := range units {
+		if err := c.addUnit(unit); err != nil {
+			return nil, errors.Trace(err)
+		}
+		prefixes = append(prefixes, unit.Name())
+	}
+	return prefixes, nil
+}
+
+func (api *API) collectUnit(c *collector, name string) error {
+	unit, err := api.st.Unit(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.addUnit(unit)
+}
+
+func (api *API) collectMachine(c *collector, id string) error {
+	machine, err := api.st.Machine(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.addMachine(machine)
+}
+
+// collectCleanups collects the pending cleanups with the given
+// prefixes, or all of them if no prefixes are given.
+func (api *API) collectCleanups(c *collector, prefixes []string) error {
+	cleanups, err := api.st.PendingCleanups()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	wanted := set.NewStrings(prefixes...)
+	for _, cleanup := range cleanups {
+		if len(prefixes) > 0 && !wanted.Contains(cleanup.Prefix) {
+			continue
+		}
+		data := map[string]interface{}{
+			"cleanup": cleanup.Kind,
+		}
+		if !cleanup.When.IsZero() {
+			data["when"] = cleanup.When
+		}
+		c.events = append(c.events, params.TimelineEvent{
+			Time:    cleanup.Scheduled,
+			Kind:    KindCleanup,
+			Entity:  api.cleanupEntity(cleanup.Prefix).String(),
+			Status:  "pending",
+			Message: fmt.Sprintf("%s cleanup scheduled", cleanup.Kind),
+			Data:    data,
+		})
+	}
+	return nil
+}
+
+// cleanupEntity returns the tag of the entity that a cleanup with the
+// given prefix affects, or the model's tag if it affects no one entity.
+func (api *API) cleanupEntity(prefix string) names.Tag {
+	switch {
+	case names.IsValidUnit(prefix):
+		return names.NewUnitTag(prefix)
+	case names.IsValidMachine(prefix):
+		return names.NewMachineTag(prefix)
+	case names.IsValidApplication(prefix):
+		return names.NewApplicationTag(prefix)
+	}
+	return names.NewModelTag(api.st.ModelUUID())
+}
+
+// collector accumulates the events of a timeline.
+type collector struct {
+	kinds  set.Strings
+	filter status.StatusHistoryFilter
+	events []params.TimelineEvent
+
+	// archived, if not nil, returns the archived status history
+	// of the given kind of the entity with the given tag.
+	archived func(kind status.HistoryKind, tag names.Tag) ([]status.StatusInfo, error)
+}
+
+// withArchived returns the given status history of the entity with
+// the given tag, preceded by its archived history of the given kind
+// if archived history was asked for.
+func (c *collector) withArchived(kind status.HistoryKind, tag names.Tag, history []status.StatusInfo) ([]status.StatusInfo, error) {
+	if c.archived == nil {
+		return history, nil
+	}
+	archived, err := c.archived(kind, tag)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting archived status history of %s", names.ReadableString(tag))
+	}
+	return append(archived, history...), nil
+}
+
+func (c *collector) addStatuses(kind string, tag names.Tag, history []status.StatusInfo) {
+	for _, info := range history {
+		if info.Since == nil {
+			continue
+		}
+		eventKind, data := kind, info.Data
+		if kind == KindAgent {
+			if m := hookMessage.FindStringSubmatch(info.Message); m != nil {
+				eventKind = KindHook
+				data = map[string]interface{}{"hook": m[1]}
+			}
+		}
+		if !c.kinds.Contains(eventKind) {
+			continue
+		}
+		if len(data) == 0 {
+			data = nil
+		}
+		c.events = append(c.events, params.TimelineEvent{
+			Time:    *info.Since,
+			Kind:    eventKind,
+			Entity:  tag.String(),
+			Status:  string(info.Status),
+			Message: info.Message,
+			Data:    data,
+		})
+	}
+}
+
+func (c *collector) addUnit(unit *state.Unit) error {
+	if c.kinds.Contains(KindStatus) {
+		history, err := unit.StatusHistory(c.filter)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		history, err = c.withArchived(status.KindWorkload, unit.UnitTag(), history)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.addStatuses(KindStatus, unit.UnitTag(), history)
+	}
+	// Hooks are recorded in the history of the unit agent.
+	if c.kinds.Contains(KindAgent) || c.kinds.Contains(KindHook) {
+		history, err := unit.AgentHistory().StatusHistory(c.filter)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		history, err = c.withArchived(status.KindUnitAgent, unit.UnitTag(), history)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.addStatuses(KindAgent, unit.UnitTag(), history)
+	}
+	if c.kinds.Contains(KindAction) {
+		actions, err := unit.Actions()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.addActions(unit.UnitTag(), actions)
+	}
+	return nil
+}
+
+func (c *collector) addMachine(machine *state.Machine) error {
+	if c.kinds.Contains(KindStatus) {
+		history, err := machine.InstanceStatusHistory(c.filter)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		history, err = c.withArchived(status.KindMachineInstance, machine.MachineTag(), history)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.addStatuses(KindStatus, machine.MachineTag(), history)
+	}
+	if c.kinds.Contains(KindAgent) {
+		history, err := machine.StatusHistory(c.filter)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		history, err = c.withArchived(status.KindMachine, machine.MachineTag(), history)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.addStatuses(KindAgent, machine.MachineTag(), history)
+	}
+	if c.kinds.Contains(KindAction) {
+		actions, err := machine.Actions()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.addActions(machine.MachineTag(), actions)
+	}
+	return nil
+}
+
+// addActions adds an event for each action being enqueued, started
+// and completed.
+func (c *collector) addActions(tag names.Tag, actions []state.Action) {
+	for _, action := range actions {
+		data := map[string]interface{}{
+			"action":    action.Name(),
+			"action-id": action.Id(),
+		}
+		c.events = append(c.events, params.TimelineEvent{
+			Time:    action.Enqueued(),
+			Kind:    KindAction,
+			Entity:  tag.String(),
+			Status:  string(state.ActionPending),
+			Message: fmt.Sprintf("action %s enqueued", action.Name()),
+			Data:    data,
+		})
+		if started := action.Started(); !started.IsZero() {
+			c.events = append(c.events, params.TimelineEvent{
+				Time:    started,
+				Kind:    KindAction,
+				Entity:  tag.String(),
+				Status:  string(state.ActionRunning),
+				Message: fmt.Sprintf("action %s started", action.Name()),
+				Data:    data,
+			})
+		}
+		if completed := action.Completed(); !completed.IsZero() {
+			message := fmt.Sprintf("action %s %s", action.Name(), action.Status())
+			if _, resultMessage := action.Results(); resultMessage != "" {
+				message += ": " + resultMessage
+			}
+			c.events = append(c.events, params.TimelineEvent{
+				Time:    completed,
+				Kind:    KindAction,
+				Entity:  tag.String(),
+				Status:  string(action.Status()),
+				Message: message,
+				Data:    data,
+			})
+		}
+	}
+}
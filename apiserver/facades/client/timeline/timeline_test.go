@@ -0,0 +1,271 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package timeline_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/timeline"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type timelineSuite struct {
+	jujutesting.JujuConnSuite
+
+	authorizer apiservertesting.FakeAuthorizer
+	api        *timeline.API
+	unit       *state.Unit
+	start      time.Time
+}
+
+var _ = gc.Suite(&timelineSuite{})
+
+func (s *timelineSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:      s.AdminUserTag(c),
+		AdminTag: s.AdminUserTag(c),
+	}
+	var err error
+	s.api, err = timeline.NewAPIForTest(s.State, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	app := s.AddTestingApplication(c, "dummy", s.AddTestingCharm(c, "dummy"))
+	s.unit, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Record the events of the test a little in the future, so that
+	// they follow those recorded when the unit was added.
+	s.start = time.Now().Add(time.Minute).Truncate(time.Second)
+	s.setAgentStatus(c, status.Executing, "running install hook", 0)
+	s.setAgentStatus(c, status.Idle, "", 2)
+	now := s.start.Add(3 * time.Second)
+	err = s.unit.SetStatus(status.StatusInfo{
+		Status:  status.Active,
+		Message: "ready",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *timelineSuite) setAgentStatus(c *gc.C, value status.Status, message string, offset int) {
+	since := s.start.Add(time.Duration(offset) * time.Second)
+	err := s.unit.Agent().SetStatus(status.StatusInfo{
+		Status:  value,
+		Message: message,
+		Since:   &since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *timelineSuite) timeline(c *gc.C, args params.TimelineArgs) []params.TimelineEvent {
+	result, err := s.api.Timeline(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	return result.Events
+}
+
+func (s *timelineSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := timeline.NewAPIForTest(s.State, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *timelineSuite) TestTimelineRequiresReadAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("fred")
+	api, err := timeline.NewAPIForTest(s.State, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.Timeline(params.TimelineArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *timelineSuite) TestTimelineUnit(c *gc.C) {
+	events := s.timeline(c, params.TimelineArgs{
+		Entity: s.unit.Tag().String(),
+		Since:  &s.start,
+	})
+	c.Assert(events, jc.DeepEquals, []params.TimelineEvent{{
+		Time:    s.start,
+		Kind:    timeline.KindHook,
+		Entity:  "unit-dummy-0",
+		Status:  "executing",
+		Message: "running install hook",
+		Data:    map[string]interface{}{"hook": "install"},
+	}, {
+		Time:   s.start.Add(2 * time.Second),
+		Kind:   timeline.KindAgent,
+		Entity: "unit-dummy-0",
+		Status: "idle",
+	}, {
+		Time:    s.start.Add(3 * time.Second),
+		Kind:    timeline.KindStatus,
+		Entity:  "unit-dummy-0",
+		Status:  "active",
+		Message: "ready",
+	}})
+}
+
+func (s *timelineSuite) TestTimelineKindsAndSize(c *gc.C) {
+	events := s.timeline(c, params.TimelineArgs{
+		Entity: s.unit.Tag().String(),
+		Kinds:  []string{timeline.KindHook, timeline.KindStatus},
+		Since:  &s.start,
+		Size:   1,
+	})
+	c.Assert(events, gc.HasLen, 1)
+	c.Check(events[0].Kind, gc.Equals, timeline.KindStatus)
+	c.Check(events[0].Message, gc.Equals, "ready")
+
+	until := s.start.Add(time.Second)
+	events = s.timeline(c, params.TimelineArgs{
+		Entity: s.unit.Tag().String(),
+		Since:  &s.start,
+		Until:  &until,
+	})
+	c.Assert(events, gc.HasLen, 1)
+	c.Check(events[0].Kind, gc.Equals, timeline.KindHook)
+}
+
+func (s *timelineSuite) TestTimelineArchived(c *gc.C) {
+	since := s.start.Add(-48 * time.Hour)
+	err := s.unit.SetStatus(status.StatusInfo{
+		Status:  status.Maintenance,
+		Message: "installing",
+		Since:   &since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = state.PruneStatusHistory(s.State, 24*time.Hour, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.TimelineArgs{
+		Entity: s.unit.Tag().String(),
+		Kinds:  []string{timeline.KindStatus},
+		Since:  &since,
+	}
+	events := s.timeline(c, args)
+	c.Assert(events, gc.HasLen, 1)
+	c.Check(events[0].Message, gc.Equals, "ready")
+
+	args.Archived = true
+	events = s.timeline(c, args)
+	c.Assert(events, jc.DeepEquals, []params.TimelineEvent{{
+		Time:    since,
+		Kind:    timeline.KindStatus,
+		Entity:  "unit-dummy-0",
+		Status:  "maintenance",
+		Message: "installing",
+	}, {
+		Time:    s.start.Add(3 * time.Second),
+		Kind:    timeline.KindStatus,
+		Entity:  "unit-dummy-0",
+		Status:  "active",
+		Message: "ready",
+	}})
+}
+
+func (s *timelineSuite) TestTimelineV1IgnoresArchived(c *gc.C) {
+	since := s.start.Add(-48 * time.Hour)
+	err := s.unit.SetStatus(status.StatusInfo{
+		Status:  status.Maintenance,
+		Message: "installing",
+		Since:   &since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = state.PruneStatusHistory(s.State, 24*time.Hour, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := &timeline.APIv1{API: s.api}
+	result, err := api.Timeline(params.TimelineArgs{
+		Entity:   s.unit.Tag().String(),
+		Kinds:    []string{timeline.KindStatus},
+		Since:    &since,
+		Archived: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Events, gc.HasLen, 1)
+	c.Check(result.Events[0].Message, gc.Equals, "ready")
+}
+
+func (s *timelineSuite) TestTimelineActions(c *gc.C) {
+	action, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	action, err = action.Finish(state.ActionResults{
+		Status:  state.ActionFailed,
+		Message: "no space left",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	events := s.timeline(c, params.TimelineArgs{
+		Entity: s.unit.Tag().String(),
+		Kinds:  []string{timeline.KindAction},
+	})
+	c.Assert(events, gc.HasLen, 3)
+	var messages []string
+	for _, event := range events {
+		c.Check(event.Data, jc.DeepEquals, map[string]interface{}{
+			"action":    "snapshot",
+			"action-id": action.Id(),
+		})
+		messages = append(messages, event.Message)
+	}
+	c.Check(messages, jc.DeepEquals, []string{
+		"action snapshot enqueued",
+		"action snapshot started",
+		"action snapshot failed: no space left",
+	})
+}
+
+func (s *timelineSuite) TestTimelineModelCleanups(c *gc.C) {
+	app, err := s.unit.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	events := s.timeline(c, params.TimelineArgs{
+		Kinds: []string{timeline.KindCleanup},
+	})
+	c.Assert(events, gc.HasLen, 1)
+	c.Check(events[0].Entity, gc.Equals, "application-dummy")
+	c.Check(events[0].Status, gc.Equals, "pending")
+	c.Check(events[0].Message, gc.Equals, "units cleanup scheduled")
+
+	// The cleanups of other entities are left out.
+	events = s.timeline(c, params.TimelineArgs{
+		Entity: s.unit.Tag().String(),
+		Kinds:  []string{timeline.KindCleanup},
+	})
+	c.Assert(events, gc.HasLen, 0)
+}
+
+func (s *timelineSuite) TestTimelineModel(c *gc.C) {
+	events := s.timeline(c, params.TimelineArgs{Since: &s.start})
+	c.Assert(events, gc.HasLen, 3)
+	c.Check(events[2].Message, gc.Equals, "ready")
+}
+
+func (s *timelineSuite) TestTimelineInvalidArgs(c *gc.C) {
+	result, err := s.api.Timeline(params.TimelineArgs{Kinds: []string{"gossip"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `event kind "gossip" not valid`)
+
+	result, err = s.api.Timeline(params.TimelineArgs{Entity: "user-bob"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `timeline of "user-bob" not valid`)
+
+	result, err = s.api.Timeline(params.TimelineArgs{Entity: "unit-foo-9"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+}
@@ -0,0 +1,238 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaler
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/autoscale"
+	"github.com/juju/juju/state"
+)
+
+// API provides access to the Autoscaler API facade, used by the worker
+// that scales applications according to their autoscaling policies.
+type API struct {
+	st *state.State
+}
+
+// NewAPI returns a new Autoscaler API facade.
+func NewAPI(st *state.State, _ facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{st: st}, nil
+}
+
+// AutoscaledApplications returns the policies, recent autoscale events
+// and number of alive units of the model's autoscaled applications.
+func (api *API) AutoscaledApplications() (params.AutoscaleResults, error) {
+	policies, err := api.st.AutoscalePolicies()
+	if err != nil {
+		return params.AutoscaleResults{}, common.ServerError(err)
+	}
+	appNames := make([]string, 0, len(policies))
+	for name := range policies {
+		appNames = append(appNames, name)
+	}
+	sort.Strings(appNames)
+
+	results := params.AutoscaleResults{
+		Results: make([]params.AutoscaleResult, len(appNames)),
+	}
+	for i, name := range appNames {
+		policy := common.AutoscalePolicyToParams(policies[name])
+		results.Results[i] = params.AutoscaleResult{
+			ApplicationTag: names.NewApplicationTag(name).String(),
+			Policy:         &policy,
+		}
+		app, err := api.st.Application(name)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		events, err := app.AutoscaleEvents()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		units, err := aliveUnits(app)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Events = common.AutoscaleEventsToParams(events)
+		results.Results[i].Units = len(units)
+	}
+	return results, nil
+}
+
+// ApplicationMetrics returns the most recent value of a charm metric
+// recorded since a given time by each alive unit of the applications.
+// Units that haven't recorded the metric since then are ignored.
+func (api *API) ApplicationMetrics(args params.ApplicationMetricArgs) (params.ApplicationMetricResults, error) {
+	results := params.ApplicationMetricResults{
+		Results: make([]params.ApplicationMetricResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		values, err := api.applicationMetric(arg)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Values = values
+	}
+	return results, nil
+}
+
+func (api *API) applicationMetric(arg params.ApplicationMetricArg) ([]float64, error) {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.st.Application(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := aliveUnits(app)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	batches, err := api.st.MetricBatchesForApplication(tag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	latest := make(map[string]state.Metric)
+	for _, batch := range batches {
+		for _, metric := range batch.Metrics() {
+			if metric.Key != arg.Metric || metric.Time.Before(arg.Since) {
+				continue
+			}
+			if l, ok := latest[batch.Unit()]; !ok || metric.Time.After(l.Time) {
+				latest[batch.Unit()] = metric
+			}
+		}
+	}
+	var values []float64
+	for _, u := range units {
+		metric, ok := latest[u.Name()]
+		if !ok {
+			continue
+		}
+		value, err := strconv.ParseFloat(metric.Value, 64)
+		if err != nil {
+			continue
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// ScaleApplications changes the number of units of the applications,
+// and records the changes as autoscale events. A change is rejected if
+// the application's number of alive units is no longer the number the
+// change was made from.
+func (api *API) ScaleApplications(args params.AutoscaleApplicationArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.scaleApplication(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) scaleApplication(arg params.AutoscaleApplicationArg) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.st.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := app.AutoscalePolicy(); err != nil {
+		return errors.Trace(err)
+	}
+	units, err := aliveUnits(app)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	from, to := arg.Event.From, arg.Event.To
+	if len(units) != from {
+		return errors.Errorf("application %q has %d units, not %d", tag.Id(), len(units), from)
+	}
+	if to < 0 {
+		return errors.NotValidf("unit count %d", to)
+	}
+
+	if to > from {
+		n := to - from
+		add := state.QuotaUsage{Machines: n, Units: n}
+		if err := api.st.CheckQuotas(api.st.ModelUUID(), add); err != nil {
+			return errors.Trace(err)
+		}
+		for i := 0; i < n; i++ {
+			unit, err := app.AddUnit(state.AddUnitParams{})
+			if err != nil {
+				return errors.Annotatef(err, "cannot add unit to application %q", tag.Id())
+			}
+			if err := api.st.AssignUnit(unit, state.AssignCleanEmpty); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	} else {
+		// Remove the most recently added units first, along with
+		// their storage, so that scaling in gives back everything
+		// scaling out took. A machine left without units is
+		// destroyed when its last unit is removed, as it is for
+		// remove-unit.
+		for _, unit := range units[to:] {
+			op := unit.DestroyOperation()
+			op.DestroyStorage = true
+			if err := api.st.ApplyOperation(op); err != nil {
+				return errors.Annotatef(err, "cannot remove unit %q", unit.Name())
+			}
+		}
+	}
+	return errors.Trace(app.RecordAutoscaleEvent(autoscale.Event{
+		Time:   arg.Event.Time,
+		From:   from,
+		To:     to,
+		Reason: arg.Event.Reason,
+	}))
+}
+
+// aliveUnits returns the alive units of the application, in the order
+// they were added.
+func aliveUnits(app *state.Application) ([]*state.Unit, error) {
+	all, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var units []*state.Unit
+	for _, u := range all {
+		if u.Life() == state.Alive {
+			units = append(units, u)
+		}
+	}
+	sort.Slice(units, func(i, j int) bool {
+		return unitNumber(units[i]) < unitNumber(units[j])
+	})
+	return units, nil
+}
+
+func unitNumber(u *state.Unit) int {
+	name := u.Name()
+	n, _ := strconv.Atoi(name[strings.LastIndex(name, "/")+1:])
+	return n
+}
@@ -0,0 +1,216 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaler_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/autoscaler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/autoscale"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type autoscalerSuite struct {
+	jujutesting.JujuConnSuite
+
+	authorizer  apiservertesting.FakeAuthorizer
+	api         *autoscaler.API
+	application *state.Application
+}
+
+var _ = gc.Suite(&autoscalerSuite{})
+
+var testPolicy = autoscale.Policy{
+	MinUnits:       1,
+	MaxUnits:       3,
+	Metric:         "pings",
+	ScaleUpAbove:   10,
+	ScaleDownBelow: 2,
+	Cooldown:       time.Minute,
+}
+
+func (s *autoscalerSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	var err error
+	s.api, err = autoscaler.NewAPI(s.State, common.NewResources(), s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "local:quantal/metered-1"})
+	s.application = s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+	err = s.application.SetAutoscalePolicy(testPolicy)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *autoscalerSuite) makeUnit(c *gc.C) *state.Unit {
+	return s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.application, SetCharmURL: true})
+}
+
+func (s *autoscalerSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := autoscaler.NewAPI(s.State, common.NewResources(), s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *autoscalerSuite) TestAutoscaledApplications(c *gc.C) {
+	s.makeUnit(c)
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "unscaled"})
+
+	results, err := s.api.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	policy := common.AutoscalePolicyToParams(testPolicy)
+	c.Assert(results, jc.DeepEquals, params.AutoscaleResults{
+		Results: []params.AutoscaleResult{{
+			ApplicationTag: s.application.Tag().String(),
+			Policy:         &policy,
+			Units:          1,
+		}},
+	})
+}
+
+func (s *autoscalerSuite) TestApplicationMetrics(c *gc.C) {
+	unit0 := s.makeUnit(c)
+	unit1 := s.makeUnit(c)
+	s.makeUnit(c)
+
+	since := time.Now().Round(time.Second)
+	s.Factory.MakeMetric(c, &factory.MetricParams{Unit: unit0, Metrics: []state.Metric{
+		{Key: "pings", Value: "50", Time: since.Add(-time.Minute)},
+		{Key: "pings", Value: "5", Time: since.Add(time.Second)},
+		{Key: "pings", Value: "7", Time: since.Add(2 * time.Second)},
+	}})
+	s.Factory.MakeMetric(c, &factory.MetricParams{Unit: unit1, Metrics: []state.Metric{
+		{Key: "pings", Value: "3", Time: since.Add(time.Second)},
+	}})
+
+	results, err := s.api.ApplicationMetrics(params.ApplicationMetricArgs{
+		Args: []params.ApplicationMetricArg{
+			{ApplicationTag: s.application.Tag().String(), Metric: "pings", Since: since},
+			{ApplicationTag: "application-missing", Metric: "pings", Since: since},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Values, jc.DeepEquals, []float64{7, 3})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `application "missing" not found`)
+}
+
+func (s *autoscalerSuite) TestScaleApplicationsUp(c *gc.C) {
+	s.makeUnit(c)
+	now := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	results, err := s.api.ScaleApplications(params.AutoscaleApplicationArgs{
+		Args: []params.AutoscaleApplicationArg{{
+			ApplicationTag: s.application.Tag().String(),
+			Event:          params.AutoscaleEvent{Time: now, From: 1, To: 2, Reason: "avg(pings) 12 above 10"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Combine(), jc.ErrorIsNil)
+
+	units, err := s.application.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	_, err = units[1].AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	events, err := s.application.AutoscaleEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, jc.DeepEquals, []autoscale.Event{
+		{Time: now, From: 1, To: 2, Reason: "avg(pings) 12 above 10"},
+	})
+}
+
+func (s *autoscalerSuite) TestScaleApplicationsDown(c *gc.C) {
+	unit0 := s.makeUnit(c)
+	unit1 := s.makeUnit(c)
+	results, err := s.api.ScaleApplications(params.AutoscaleApplicationArgs{
+		Args: []params.AutoscaleApplicationArg{{
+			ApplicationTag: s.application.Tag().String(),
+			Event:          params.AutoscaleEvent{Time: time.Now(), From: 2, To: 1, Reason: "avg(pings) 1 below 2"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Combine(), jc.ErrorIsNil)
+
+	c.Assert(unit0.Refresh(), jc.ErrorIsNil)
+	c.Assert(unit0.Life(), gc.Equals, state.Alive)
+	err = unit1.Refresh()
+	if err == nil {
+		c.Assert(unit1.Life(), gc.Not(gc.Equals), state.Alive)
+	}
+}
+
+func (s *autoscalerSuite) TestScaleApplicationsDownReleasesMachines(c *gc.C) {
+	s.makeUnit(c)
+	results, err := s.api.ScaleApplications(params.AutoscaleApplicationArgs{
+		Args: []params.AutoscaleApplicationArg{{
+			ApplicationTag: s.application.Tag().String(),
+			Event:          params.AutoscaleEvent{Time: time.Now(), From: 1, To: 2},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Combine(), jc.ErrorIsNil)
+	units, err := s.application.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+	added := units[1]
+	machineId, err := added.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err = s.api.ScaleApplications(params.AutoscaleApplicationArgs{
+		Args: []params.AutoscaleApplicationArg{{
+			ApplicationTag: s.application.Tag().String(),
+			Event:          params.AutoscaleEvent{Time: time.Now(), From: 2, To: 1},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Combine(), jc.ErrorIsNil)
+
+	// Once the unit agent has removed the unit, the machine added
+	// for it is destroyed, and the provisioner stops its instance.
+	err = added.Refresh()
+	if err == nil {
+		c.Assert(added.EnsureDead(), jc.ErrorIsNil)
+		c.Assert(added.Remove(), jc.ErrorIsNil)
+	}
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machine.Life(), gc.Not(gc.Equals), state.Alive)
+}
+
+func (s *autoscalerSuite) TestScaleApplicationsUnitCountChanged(c *gc.C) {
+	s.makeUnit(c)
+	results, err := s.api.ScaleApplications(params.AutoscaleApplicationArgs{
+		Args: []params.AutoscaleApplicationArg{{
+			ApplicationTag: s.application.Tag().String(),
+			Event:          params.AutoscaleEvent{Time: time.Now(), From: 2, To: 3},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `application "metered" has 1 units, not 2`)
+}
+
+func (s *autoscalerSuite) TestScaleApplicationsNotAutoscaled(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "unscaled"})
+	results, err := s.api.ScaleApplications(params.AutoscaleApplicationArgs{
+		Args: []params.AutoscaleApplicationArg{{
+			ApplicationTag: app.Tag().String(),
+			Event:          params.AutoscaleEvent{Time: time.Now(), From: 0, To: 1},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `autoscale policy for application "unscaled" not found`)
+}
@@ -6,6 +6,7 @@ package caasfirewaller
 import (
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/state"
 )
@@ -33,3 +34,27 @@ type stateShim struct {
 func (s stateShim) Application(id string) (Application, error) {
 	return s.State.Application(id)
 }
+
+// FindEntity returns the entity with the given tag. Applications
+// are wrapped so that watching them also reports changes to their
+// config, allowing the firewaller to reconcile exposed applications.
+func (s stateShim) FindEntity(tag names.Tag) (state.Entity, error) {
+	entity, err := s.State.FindEntity(tag)
+	if err != nil {
+		return nil, err
+	}
+	if app, ok := entity.(*state.Application); ok {
+		return applicationShim{app}, nil
+	}
+	return entity, nil
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+// Watch returns a watcher notifying of changes to the
+// application or its config.
+func (a applicationShim) Watch() state.NotifyWatcher {
+	return common.NewMultiNotifyWatcher(a.Application.Watch(), a.Application.WatchApplicationConfig())
+}
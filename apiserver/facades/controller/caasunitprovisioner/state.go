@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
@@ -101,7 +102,32 @@ func (s stateShim) Model() (Model, error) {
 	if err != nil {
 		return nil, err
 	}
-	return model.CAASModel()
+	caasModel, err := model.CAASModel()
+	if err != nil {
+		return nil, err
+	}
+	return modelShim{CAASModel: caasModel, st: s.State}, nil
+}
+
+type modelShim struct {
+	*state.CAASModel
+	st *state.State
+}
+
+// WatchPodSpec returns a watcher notifying of changes to the
+// application's pod spec or to its config, so that the unit
+// provisioner reconciles the k8s resources the config controls.
+func (m modelShim) WatchPodSpec(tag names.ApplicationTag) (state.NotifyWatcher, error) {
+	podSpecWatcher, err := m.CAASModel.WatchPodSpec(tag)
+	if err != nil {
+		return nil, err
+	}
+	app, err := m.st.Application(tag.Id())
+	if err != nil {
+		podSpecWatcher.Kill()
+		return nil, err
+	}
+	return common.NewMultiNotifyWatcher(podSpecWatcher, app.WatchApplicationConfig()), nil
 }
 
 type applicationShim struct {
@@ -6,13 +6,17 @@ package charmrevisionupdater
 import (
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/state"
@@ -88,9 +92,76 @@ func (api *CharmRevisionUpdaterAPI) updateLatestRevisions() error {
 		}
 	}
 
+	// Finally, refresh the applications whose policy asks for it. The
+	// new revisions have been recorded above, so those applications
+	// that aren't refreshed are reported as having an upgrade available.
+	if err := common.NewBlockChecker(api.state).ChangeAllowed(); err != nil {
+		logger.Debugf("not refreshing applications: %v", err)
+		return nil
+	}
+	model, err := api.state.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	modelConfig, err := model.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := time.Now()
+	for _, info := range latest {
+		app := info.application
+		curl, _ := app.CharmURL()
+		if info.LatestRevision <= curl.Revision {
+			continue
+		}
+		if !app.RefreshPolicy().ShouldRefresh(now) {
+			continue
+		}
+		appConfig, err := app.ApplicationConfig()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if window := application.MaintenanceWindow(appConfig, modelConfig); !window.Contains(now) {
+			logger.Debugf("not refreshing application %q outside of maintenance window %v", app.Name(), window)
+			continue
+		}
+		if err := refreshApplication(api.state, app, info.LatestURL()); err != nil {
+			// A failure to refresh one application shouldn't prevent
+			// the others from being refreshed; the refresh will be
+			// attempted again at the next update.
+			logger.Errorf("cannot refresh application %q to %s: %v", app.Name(), info.LatestURL(), err)
+		}
+	}
 	return nil
 }
 
+// AddCharm adds the given charm from the charm store to the model.
+// Exported so we can change it during testing.
+var AddCharm = func(st *state.State, curl *charm.URL, channel csparams.Channel) error {
+	return application.AddCharmWithAuthorization(application.NewStateShim(st), params.AddCharmWithAuthorization{
+		URL:     curl.String(),
+		Channel: string(channel),
+	})
+}
+
+// refreshApplication upgrades the application to the given charm,
+// adding the charm to the model first if necessary.
+func refreshApplication(st *state.State, app *state.Application, curl *charm.URL) error {
+	channel := app.TrackedChannel()
+	if err := AddCharm(st, curl, channel); err != nil {
+		return errors.Annotate(err, "adding charm")
+	}
+	ch, err := st.Charm(curl)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("refreshing application %q to %s", app.Name(), curl)
+	return app.SetCharm(state.SetCharmConfig{
+		Charm:   ch,
+		Channel: channel,
+	})
+}
+
 // NewCharmStoreClient instantiates a new charm store repository.  Exported so
 // we can change it during testing.
 var NewCharmStoreClient = func(st *state.State) (charmstore.Client, error) {
@@ -139,7 +210,7 @@ func retrieveLatestCharmInfo(st *state.State) ([]latestCharmInfo, error) {
 
 		cid := charmstore.CharmID{
 			URL:     curl,
-			Channel: application.Channel(),
+			Channel: application.TrackedChannel(),
 			Metadata: map[string]string{
 				"series": application.Series(),
 				"arch":   strings.Join(archs, ","),
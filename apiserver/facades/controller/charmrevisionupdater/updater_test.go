@@ -4,14 +4,18 @@
 package charmrevisionupdater_test
 
 import (
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/charmrepo.v3"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
@@ -19,6 +23,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater/testing"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/charmstore"
+	coreapplication "github.com/juju/juju/core/application"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/version"
@@ -130,6 +135,116 @@ func (s *charmVersionSuite) TestUpdateRevisions(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *charmVersionSuite) TestUpdateRevisionsAutoRefresh(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+	s.PatchValue(&charmrevisionupdater.AddCharm, func(st *state.State, curl *charm.URL, channel csparams.Channel) error {
+		s.AddCharmWithRevision(c, curl.Name, curl.Revision)
+		return nil
+	})
+
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetRefreshPolicy(state.RefreshPolicy{Auto: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-23")
+}
+
+func (s *charmVersionSuite) TestUpdateRevisionsNoRefresh(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+	s.PatchValue(&charmrevisionupdater.AddCharm, func(*state.State, *charm.URL, csparams.Channel) error {
+		c.Fatalf("unexpected refresh")
+		return nil
+	})
+
+	// A window that starts a couple of hours from now.
+	start := time.Duration((time.Now().UTC().Hour()+2)%24) * time.Hour
+	outsideWindow := state.RefreshPolicy{
+		Auto:        true,
+		WindowStart: start,
+		WindowEnd:   (start + time.Hour) % (24 * time.Hour),
+	}
+	for i, policy := range []state.RefreshPolicy{
+		{},
+		{Auto: true, Hold: true},
+		outsideWindow,
+	} {
+		c.Logf("test %d: %+v", i, policy)
+		app, err := s.State.Application("mysql")
+		c.Assert(err, jc.ErrorIsNil)
+		err = app.SetRefreshPolicy(policy)
+		c.Assert(err, jc.ErrorIsNil)
+
+		result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.Error, gc.IsNil)
+
+		// The new revision is recorded, so that it's reported as
+		// available, but the application isn't refreshed to it.
+		pending, err := s.State.LatestPlaceholderCharm(charm.MustParseURL("cs:quantal/mysql"))
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(pending.String(), gc.Equals, "cs:quantal/mysql-23")
+		err = app.Refresh()
+		c.Assert(err, jc.ErrorIsNil)
+		curl, _ := app.CharmURL()
+		c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-22")
+	}
+}
+
+func (s *charmVersionSuite) TestUpdateRevisionsMaintenanceWindow(c *gc.C) {
+	s.AddMachine(c, "0", state.JobManageModel)
+	s.SetupScenario(c)
+	s.PatchValue(&charmrevisionupdater.AddCharm, func(st *state.State, curl *charm.URL, channel csparams.Channel) error {
+		s.AddCharmWithRevision(c, curl.Name, curl.Revision)
+		return nil
+	})
+
+	app, err := s.State.Application("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetRefreshPolicy(state.RefreshPolicy{Auto: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A model maintenance window that opens a couple of hours from now.
+	start := (time.Now().UTC().Hour() + 2) % 24
+	window := fmt.Sprintf("%02d:00-%02d:00", start, (start+1)%24)
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"maintenance-window": window}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-22")
+
+	// The application's own maintenance window takes precedence.
+	err = app.UpdateApplicationConfig(
+		coreapplication.ConfigAttributes{"maintenance-window": "any"},
+		nil,
+		environschema.Fields{"maintenance-window": {Type: environschema.Tstring}},
+		nil,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err = s.charmrevisionupdater.UpdateLatestRevisions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ = app.CharmURL()
+	c.Assert(curl.String(), gc.Equals, "cs:quantal/mysql-23")
+}
+
 func (s *charmVersionSuite) TestWordpressCharmNoReadAccessIsNotVisible(c *gc.C) {
 	s.AddMachine(c, "0", state.JobManageModel)
 	s.SetupScenario(c)
@@ -0,0 +1,100 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnspublisher
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// API provides access to the DNSPublisher API facade, used by the
+// worker that publishes application and unit addresses in an external
+// DNS service.
+type API struct {
+	*common.ModelWatcher
+
+	st        *state.State
+	resources facade.Resources
+}
+
+// NewAPI returns a new DNSPublisher API facade.
+func NewAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(m, resources, authorizer),
+		st:           st,
+		resources:    resources,
+	}, nil
+}
+
+// WatchApplications returns a watcher that notifies of changes to the
+// lifecycles of the model's applications.
+func (api *API) WatchApplications() (params.StringsWatchResult, error) {
+	w := api.st.WatchApplications()
+	if changes, ok := <-w.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: api.resources.Register(w),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(w)
+}
+
+// Applications returns the model's alive applications, along with the
+// public addresses of their alive units. Units without a public address
+// are omitted.
+func (api *API) Applications() (params.DNSApplicationsResult, error) {
+	applications, err := api.applications()
+	if err != nil {
+		return params.DNSApplicationsResult{Error: common.ServerError(err)}, nil
+	}
+	return params.DNSApplicationsResult{Applications: applications}, nil
+}
+
+func (api *API) applications() ([]params.DNSApplication, error) {
+	apps, err := api.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []params.DNSApplication
+	for _, app := range apps {
+		if app.Life() != state.Alive {
+			continue
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		dnsApp := params.DNSApplication{Name: app.Name()}
+		for _, unit := range units {
+			if unit.Life() != state.Alive {
+				continue
+			}
+			addr, err := unit.PublicAddress()
+			if network.IsNoAddressError(err) {
+				continue
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			dnsApp.Units = append(dnsApp.Units, params.DNSUnit{
+				Name:    unit.Name(),
+				Address: addr.Value,
+			})
+		}
+		result = append(result, dnsApp)
+	}
+	return result, nil
+}
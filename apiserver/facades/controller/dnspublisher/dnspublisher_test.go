@@ -0,0 +1,108 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnspublisher_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/dnspublisher"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type dnsPublisherSuite struct {
+	jujutesting.JujuConnSuite
+
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *dnspublisher.API
+}
+
+var _ = gc.Suite(&dnsPublisherSuite{})
+
+func (s *dnsPublisherSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	var err error
+	s.api, err = dnspublisher.NewAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *dnsPublisherSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := dnspublisher.NewAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *dnsPublisherSuite) TestApplications(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+
+	machineId, err := unit0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(machineId)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProviderAddresses(network.NewScopedAddress("203.0.113.1", network.ScopePublic))
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.Applications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.Applications, jc.SameContents, []params.DNSApplication{{
+		Name: "mysql",
+	}, {
+		Name: "wordpress",
+		Units: []params.DNSUnit{{
+			Name:    "wordpress/0",
+			Address: "203.0.113.1",
+		}},
+	}})
+}
+
+func (s *dnsPublisherSuite) TestApplicationsSkipsDyingApplications(c *gc.C) {
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	err := app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.api.Applications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Applications, gc.HasLen, 0)
+}
+
+func (s *dnsPublisherSuite) TestWatchApplications(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+
+	result, err := s.api.WatchApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Changes, jc.DeepEquals, []string{"wordpress"})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+
+	w := s.resources.Get(result.StringsWatcherId).(state.StringsWatcher)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "mysql",
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	wc.AssertChange("mysql")
+	wc.AssertNoChange()
+}
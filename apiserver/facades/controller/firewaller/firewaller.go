@@ -4,6 +4,8 @@
 package firewaller
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v2"
@@ -51,6 +53,11 @@ type FirewallerAPIV5 struct {
 	*FirewallerAPIV4
 }
 
+// FirewallerAPIV6 provides access to the Firewaller v6 API facade.
+type FirewallerAPIV6 struct {
+	*FirewallerAPIV5
+}
+
 // NewStateFirewallerAPIV3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -92,6 +99,17 @@ func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV6 creates a new server-side FirewallerAPIV6 facade.
+func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
+	facadev5, err := NewStateFirewallerAPIV5(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV6{
+		FirewallerAPIV5: facadev5,
+	}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -211,7 +229,7 @@ func (f *FirewallerAPIV3) watchOneModelOpenedPorts(tag names.Tag) (string, []str
 
 // GetMachinePorts returns the port ranges opened on a machine for the specified
 // subnet as a map mapping port ranges to the tags of the units that opened
-// them.
+// them, and the endpoints they were opened for.
 func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (params.MachinePortsResults, error) {
 	result := params.MachinePortsResults{
 		Results: make([]params.MachinePortsResult, len(args.Params)),
@@ -245,19 +263,33 @@ func (f *FirewallerAPIV3) GetMachinePorts(args params.MachinePortsParams) (param
 			continue
 		}
 		if ports != nil {
-			portRangeMap := ports.AllPortRanges()
-			var portRanges []network.PortRange
-			for portRange := range portRangeMap {
-				portRanges = append(portRanges, portRange)
-			}
-			network.SortPortRanges(portRanges)
-
+			// Sort the port ranges for a stable order, first by
+			// protocol and number, then by endpoint.
+			portRanges := ports.PortRanges()
+			sort.Slice(portRanges, func(i, j int) bool {
+				p1, p2 := portRanges[i], portRanges[j]
+				if p1.Protocol != p2.Protocol {
+					return p1.Protocol < p2.Protocol
+				}
+				if p1.FromPort != p2.FromPort {
+					return p1.FromPort < p2.FromPort
+				}
+				if p1.ToPort != p2.ToPort {
+					return p1.ToPort < p2.ToPort
+				}
+				return p1.Endpoint < p2.Endpoint
+			})
 			for _, portRange := range portRanges {
-				unitTag := names.NewUnitTag(portRangeMap[portRange]).String()
+				unitTag := names.NewUnitTag(portRange.UnitName).String()
 				result.Results[i].Ports = append(result.Results[i].Ports,
 					params.MachinePortRange{
-						UnitTag:   unitTag,
-						PortRange: params.FromNetworkPortRange(portRange),
+						UnitTag: unitTag,
+						PortRange: params.FromNetworkPortRange(network.PortRange{
+							FromPort: portRange.FromPort,
+							ToPort:   portRange.ToPort,
+							Protocol: portRange.Protocol,
+						}),
+						Endpoint: portRange.Endpoint,
 					})
 			}
 		}
@@ -534,3 +566,58 @@ func (f *FirewallerAPIV5) AreManuallyProvisioned(args params.Entities) (params.B
 	}
 	return result, nil
 }
+
+// GetLoadBalancers returns, for each given application, whether it is
+// exposed through a load balancer and the addresses recorded for the
+// load balancer.
+func (f *FirewallerAPIV6) GetLoadBalancers(args params.Entities) (params.ApplicationLoadBalancerResults, error) {
+	result := params.ApplicationLoadBalancerResults{
+		Results: make([]params.ApplicationLoadBalancerResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ApplicationLoadBalancerResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			result.Results[i].LoadBalanced = application.IsExposed() && application.IsLoadBalanced()
+			if addrs := application.LoadBalancerAddresses(); len(addrs) > 0 {
+				result.Results[i].Addresses = params.FromNetworkAddresses(addrs...)
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetLoadBalancerAddresses records the addresses of the load balancers
+// of the given applications. An empty list of addresses records that
+// an application's load balancer has been removed.
+func (f *FirewallerAPIV6) SetLoadBalancerAddresses(args params.SetApplicationsAddresses) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.ApplicationAddresses)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, arg := range args.ApplicationAddresses {
+		tag, err := names.ParseApplicationTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			err = application.SetLoadBalancerAddresses(params.NetworkAddresses(arg.Addresses...))
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
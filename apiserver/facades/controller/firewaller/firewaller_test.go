@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	coretesting "github.com/juju/juju/testing"
@@ -272,3 +273,56 @@ func (s *firewallerSuite) TestAreManuallyProvisioned(c *gc.C) {
 		},
 	})
 }
+
+func (s *firewallerSuite) TestGetLoadBalancers(c *gc.C) {
+	err := s.application.SetExposedWithLoadBalancer()
+	c.Assert(err, jc.ErrorIsNil)
+	addrs := network.NewAddresses("203.0.113.10")
+	err = s.application.SetLoadBalancerAddresses(addrs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	apiv6 := s.firewallerV6()
+	result, err := apiv6.GetLoadBalancers(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+		{Tag: s.units[0].Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ApplicationLoadBalancerResults{
+		Results: []params.ApplicationLoadBalancerResult{
+			{LoadBalanced: true, Addresses: params.FromNetworkAddresses(addrs...)},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *firewallerSuite) TestSetLoadBalancerAddresses(c *gc.C) {
+	addrs := network.NewAddresses("203.0.113.10")
+	apiv6 := s.firewallerV6()
+	result, err := apiv6.SetLoadBalancerAddresses(params.SetApplicationsAddresses{
+		ApplicationAddresses: []params.ApplicationAddresses{{
+			Tag:       s.application.Tag().String(),
+			Addresses: params.FromNetworkAddresses(addrs...),
+		}, {
+			Tag: s.units[0].Tag().String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	err = s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.LoadBalancerAddresses(), jc.DeepEquals, addrs)
+}
+
+func (s *firewallerSuite) firewallerV6() *firewaller.FirewallerAPIV6 {
+	return &firewaller.FirewallerAPIV6{
+		&firewaller.FirewallerAPIV5{
+			&firewaller.FirewallerAPIV4{
+				FirewallerAPIV3:     s.firewaller,
+				ControllerConfigAPI: common.NewControllerConfig(newMockState(coretesting.ModelTag.Id())),
+			}}}
+}
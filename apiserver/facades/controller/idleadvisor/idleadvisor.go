@@ -0,0 +1,162 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package idleadvisor
+
+import (
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// API provides access to the IdleAdvisor API facade, used by the
+// worker that finds idle machines and units.
+type API struct {
+	*common.ModelWatcher
+
+	st *state.State
+}
+
+// NewAPI returns a new IdleAdvisor API facade.
+func NewAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(m, resources, authorizer),
+		st:           st,
+	}, nil
+}
+
+// ModelUtilisation returns the number of units and containers on each
+// of the model's alive machines, other than controllers and spare
+// machines, which are kept empty on purpose, and the highest
+// value of the given charm metric recorded since the given time by each
+// of the model's alive units.
+func (api *API) ModelUtilisation(args params.ModelUtilisationArgs) (params.ModelUtilisationResult, error) {
+	utilisation, err := api.modelUtilisation(args)
+	if err != nil {
+		return params.ModelUtilisationResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ModelUtilisationResult{Result: utilisation}, nil
+}
+
+func (api *API) modelUtilisation(args params.ModelUtilisationArgs) (params.ModelUtilisation, error) {
+	var result params.ModelUtilisation
+	machines, err := api.st.AllMachines()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, m := range machines {
+		if m.Life() != state.Alive || m.IsManager() || m.IsSpare() {
+			continue
+		}
+		units, err := m.Units()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		containers, err := m.Containers()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		result.Machines = append(result.Machines, params.MachineUtilisation{
+			Tag:        m.Tag().String(),
+			Units:      len(units),
+			Containers: len(containers),
+		})
+	}
+	if args.Metric == "" {
+		return result, nil
+	}
+
+	batches, err := api.st.MetricBatchesForModel()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	peaks := make(map[string]float64)
+	for _, batch := range batches {
+		if batch.Unit() == "" {
+			continue
+		}
+		for _, metric := range batch.Metrics() {
+			if metric.Key != args.Metric || metric.Time.Before(args.Since) {
+				continue
+			}
+			value, err := strconv.ParseFloat(metric.Value, 64)
+			if err != nil {
+				continue
+			}
+			if peak, ok := peaks[batch.Unit()]; !ok || value > peak {
+				peaks[batch.Unit()] = value
+			}
+		}
+	}
+	apps, err := api.st.AllApplications()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, app := range apps {
+		units, err := app.AllUnits()
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		for _, u := range units {
+			if u.Life() != state.Alive {
+				continue
+			}
+			utilisation := params.UnitUtilisation{Tag: u.Tag().String()}
+			if peak, ok := peaks[u.Name()]; ok {
+				utilisation.Peak = &peak
+			}
+			result.Units = append(result.Units, utilisation)
+		}
+	}
+	return result, nil
+}
+
+// IdleResources returns the model's idle machines and units.
+func (api *API) IdleResources() (params.IdleResourcesResult, error) {
+	resources, err := api.st.IdleResources()
+	if err != nil {
+		return params.IdleResourcesResult{Error: common.ServerError(err)}, nil
+	}
+	result := params.IdleResourcesResult{
+		Resources: make([]params.IdleResource, len(resources)),
+	}
+	for i, r := range resources {
+		result.Resources[i] = params.IdleResource{
+			Tag:    r.Entity.String(),
+			Since:  r.Since,
+			Reason: r.Reason,
+		}
+	}
+	return result, nil
+}
+
+// SetIdleResources replaces the model's idle machines and units.
+func (api *API) SetIdleResources(args params.IdleResources) (params.ErrorResult, error) {
+	resources := make([]state.IdleResource, len(args.Resources))
+	for i, r := range args.Resources {
+		tag, err := names.ParseTag(r.Tag)
+		if err != nil {
+			return params.ErrorResult{Error: common.ServerError(err)}, nil
+		}
+		resources[i] = state.IdleResource{
+			Entity: tag,
+			Since:  r.Since,
+			Reason: r.Reason,
+		}
+	}
+	err := api.st.SetIdleResources(resources)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
@@ -0,0 +1,131 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package idleadvisor_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/idleadvisor"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type idleAdvisorSuite struct {
+	jujutesting.JujuConnSuite
+
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *idleadvisor.API
+}
+
+var _ = gc.Suite(&idleAdvisorSuite{})
+
+func (s *idleAdvisorSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	var err error
+	s.api, err = idleadvisor.NewAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *idleAdvisorSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := idleadvisor.NewAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *idleAdvisorSuite) TestModelUtilisation(c *gc.C) {
+	empty := s.Factory.MakeMachine(c, nil)
+	meteredCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: "metered", URL: "local:quantal/metered-1"})
+	meteredApplication := s.Factory.MakeApplication(c, &factory.ApplicationParams{Charm: meteredCharm})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: meteredApplication, SetCharmURL: true})
+	machineId, err := unit.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+
+	since := time.Now().Round(time.Second)
+	s.Factory.MakeMetric(c, &factory.MetricParams{Unit: unit, Metrics: []state.Metric{
+		{Key: "pings", Value: "50", Time: since.Add(-time.Minute)},
+		{Key: "pings", Value: "2.5", Time: since.Add(time.Second)},
+		{Key: "pongs", Value: "20", Time: since.Add(time.Second)},
+	}})
+
+	result, err := s.api.ModelUtilisation(params.ModelUtilisationArgs{
+		Metric: "pings",
+		Since:  since,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	peak := 2.5
+	c.Check(result.Result, jc.DeepEquals, params.ModelUtilisation{
+		Machines: []params.MachineUtilisation{
+			{Tag: empty.Tag().String()},
+			{Tag: names.NewMachineTag(machineId).String(), Units: 1},
+		},
+		Units: []params.UnitUtilisation{
+			{Tag: unit.Tag().String(), Peak: &peak},
+		},
+	})
+}
+
+func (s *idleAdvisorSuite) TestModelUtilisationNoMetric(c *gc.C) {
+	s.Factory.MakeUnit(c, nil)
+	result, err := s.api.ModelUtilisation(params.ModelUtilisationArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.Result.Machines, gc.HasLen, 1)
+	c.Check(result.Result.Units, gc.HasLen, 0)
+}
+
+func (s *idleAdvisorSuite) TestModelUtilisationExcludesSpareMachines(c *gc.C) {
+	_, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Spare:  true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := s.api.ModelUtilisation(params.ModelUtilisationArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Check(result.Result.Machines, gc.HasLen, 0)
+}
+
+func (s *idleAdvisorSuite) TestSetIdleResources(c *gc.C) {
+	since := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	result, err := s.api.SetIdleResources(params.IdleResources{
+		Resources: []params.IdleResource{
+			{Tag: "machine-1", Since: since, Reason: "no units or containers"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	resources, err := s.api.IdleResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, params.IdleResourcesResult{
+		Resources: []params.IdleResource{
+			{Tag: "machine-1", Since: since, Reason: "no units or containers"},
+		},
+	})
+}
+
+func (s *idleAdvisorSuite) TestSetIdleResourcesInvalidTag(c *gc.C) {
+	result, err := s.api.SetIdleResources(params.IdleResources{
+		Resources: []params.IdleResource{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `idle resource "application-mysql" not valid`)
+}
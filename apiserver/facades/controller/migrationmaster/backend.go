@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
 )
 
 // Backend defines the state functionality required by the
@@ -21,6 +22,14 @@ type Backend interface {
 	ModelOwner() (names.UserTag, error)
 	AgentVersion() (version.Number, error)
 	RemoveExportingModelDocs() error
+	CheckVolumeExport(names.VolumeTag) error
+	ExportVolume(names.VolumeTag) (storage.VolumeExport, error)
+	RemoveVolumeExport(names.VolumeTag, storage.VolumeExport) error
+	CheckRelocationSupported() error
+	MachinesToReprovision() ([]names.MachineTag, error)
+	SnapshotMachine(tag names.MachineTag, region string) (string, error)
+	RemoveMachineSnapshot(region, imageId string) error
+	StopMachineInstance(names.MachineTag) error
 
 	migration.StateExporter
 }
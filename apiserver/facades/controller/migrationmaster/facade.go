@@ -20,6 +20,7 @@ import (
 	coremodel "github.com/juju/juju/core/model"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/storage"
 )
 
 // API implements the API required for the model migration
@@ -103,6 +104,8 @@ func (api *API) MigrationStatus() (params.MasterMigrationStatus, error) {
 				Password:      target.Password,
 				Macaroons:     string(macsJSON),
 			},
+			TargetRegion: mig.TargetRegion(),
+			Mode:         string(mig.Mode()),
 		},
 		MigrationId:      mig.Id(),
 		Phase:            phase.String(),
@@ -159,6 +162,18 @@ func (api *API) SetPhase(args params.SetMigrationPhaseArgs) error {
 // Prechecks performs pre-migration checks on the model and
 // (source) controller.
 func (api *API) Prechecks() error {
+	// Moving a model to another region needs the provider to be able
+	// to snapshot the model's instances; find out before the point of
+	// no return rather than once the model has been handed over.
+	mig, err := api.backend.LatestMigration()
+	if err != nil {
+		return errors.Annotate(err, "retrieving migration")
+	}
+	if mig.TargetRegion() != "" {
+		if err := api.backend.CheckRelocationSupported(); err != nil {
+			return errors.Annotatef(err, "cannot move model to region %q", mig.TargetRegion())
+		}
+	}
 	model, err := api.precheckBackend.Model()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model")
@@ -229,6 +244,152 @@ func (api *API) Reap() error {
 	return errors.Trace(migration.SetPhase(coremigration.DONE))
 }
 
+// CheckVolumeExports reports, for each of the given volumes of the
+// model associated with the API connection, whether the storage
+// provider that created it is able to export a copy of it. It is used
+// to fail a migration that needs volumes recreated before any are
+// exported.
+func (api *API) CheckVolumeExports(args params.Entities) params.ErrorResults {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err == nil {
+			err = api.backend.CheckVolumeExport(tag)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results
+}
+
+// ExportVolumes makes portable copies of volumes of the model associated
+// with the API connection, so that they can be recreated by a target
+// controller whose cloud does not support the volumes' storage
+// providers.
+func (api *API) ExportVolumes(args params.Entities) params.MigrationVolumeExportResults {
+	results := params.MigrationVolumeExportResults{
+		Results: make([]params.MigrationVolumeExportResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		export, err := api.exportVolume(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = export
+	}
+	return results
+}
+
+func (api *API) exportVolume(volumeTag string) (params.MigrationVolumeExport, error) {
+	tag, err := names.ParseVolumeTag(volumeTag)
+	if err != nil {
+		return params.MigrationVolumeExport{}, errors.Trace(err)
+	}
+	export, err := api.backend.ExportVolume(tag)
+	if err != nil {
+		return params.MigrationVolumeExport{}, errors.Trace(err)
+	}
+	return params.MigrationVolumeExport{
+		VolumeTag: volumeTag,
+		Location:  export.Location,
+		Format:    export.Format,
+		Size:      export.Size,
+	}, nil
+}
+
+// RemoveVolumeExports removes copies of volumes made by ExportVolumes.
+func (api *API) RemoveVolumeExports(args params.MigrationVolumeExports) params.ErrorResults {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Exports)),
+	}
+	for i, arg := range args.Exports {
+		tag, err := names.ParseVolumeTag(arg.VolumeTag)
+		if err == nil {
+			err = api.backend.RemoveVolumeExport(tag, storage.VolumeExport{
+				Location: arg.Location,
+				Format:   arg.Format,
+				Size:     arg.Size,
+			})
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results
+}
+
+// MachinesToReprovision returns the machines of the model associated
+// with the API connection that must be provisioned again when the model
+// moves to another region of its cloud.
+func (api *API) MachinesToReprovision() (params.Entities, error) {
+	tags, err := api.backend.MachinesToReprovision()
+	if err != nil {
+		return params.Entities{}, errors.Trace(err)
+	}
+	result := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		result.Entities[i].Tag = tag.String()
+	}
+	return result, nil
+}
+
+// SnapshotMachines makes images of the instances of machines in the
+// region that the model is moving to, so that a target controller can
+// provision the machines again from them.
+func (api *API) SnapshotMachines(args params.SnapshotMachinesArgs) params.MigrationMachineSnapshotResults {
+	results := params.MigrationMachineSnapshotResults{
+		Results: make([]params.MigrationMachineSnapshotResult, len(args.Machines.Entities)),
+	}
+	for i, arg := range args.Machines.Entities {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		imageId, err := api.backend.SnapshotMachine(tag, args.Region)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Result = params.MigrationMachineSnapshot{
+			MachineTag: arg.Tag,
+			Region:     args.Region,
+			ImageId:    imageId,
+		}
+	}
+	return results
+}
+
+// RemoveMachineSnapshots removes images made by SnapshotMachines.
+func (api *API) RemoveMachineSnapshots(args params.MigrationMachineSnapshots) params.ErrorResults {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Snapshots)),
+	}
+	for i, arg := range args.Snapshots {
+		err := api.backend.RemoveMachineSnapshot(arg.Region, arg.ImageId)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results
+}
+
+// StopMachineInstances stops the instances of machines which have been
+// provisioned again by a target controller.
+func (api *API) StopMachineInstances(args params.Entities) params.ErrorResults {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err == nil {
+			err = api.backend.StopMachineInstance(tag)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results
+}
+
 // WatchMinionReports sets up a watcher which reports when a report
 // for a migration minion has arrived.
 func (api *API) WatchMinionReports() params.NotifyWatchResult {
@@ -26,6 +26,7 @@ import (
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
 )
@@ -110,6 +111,7 @@ func (s *Suite) TestMigrationStatus(c *gc.C) {
 				Password:      "secret",
 				Macaroons:     expectedMacaroons,
 			},
+			Mode: "move",
 		},
 		MigrationId:      "id",
 		Phase:            "IMPORT",
@@ -189,6 +191,14 @@ func (s *Suite) TestPrechecks(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "retrieving model: boom")
 }
 
+func (s *Suite) TestPrechecksRelocationNotSupported(c *gc.C) {
+	s.backend.migration.targetRegion = "nether-region"
+	s.backend.relocateErr = errors.NotSupportedf("snapshotting instances")
+	api := s.mustMakeAPI(c)
+	err := api.Prechecks()
+	c.Assert(err, gc.ErrorMatches, `cannot move model to region "nether-region": snapshotting instances not supported`)
+}
+
 func (s *Suite) TestExportIAAS(c *gc.C) {
 	s.assertExport(c, "iaas")
 }
@@ -349,6 +359,163 @@ func (s *Suite) TestReapError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "boom")
 }
 
+func (s *Suite) TestCheckVolumeExports(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	s.backend.exportErrs = map[string]error{"1": errors.NotSupportedf(`exporting volumes from pool "ebs"`)}
+
+	results := api.CheckVolumeExports(params.Entities{Entities: []params.Entity{
+		{Tag: "volume-0"}, {Tag: "volume-1"}, {Tag: "machine-0"},
+	}})
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}, {
+			Error: &params.Error{
+				Message: `exporting volumes from pool "ebs" not supported`,
+				Code:    params.CodeNotSupported,
+			},
+		}, {
+			Error: &params.Error{Message: `"machine-0" is not a valid volume tag`},
+		}},
+	})
+	s.backend.stub.CheckCalls(c, []testing.StubCall{
+		{"CheckVolumeExport", []interface{}{names.NewVolumeTag("0")}},
+		{"CheckVolumeExport", []interface{}{names.NewVolumeTag("1")}},
+	})
+}
+
+func (s *Suite) TestExportVolumes(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	s.backend.exportErrs = map[string]error{"1": errors.New("boom")}
+
+	results := api.ExportVolumes(params.Entities{Entities: []params.Entity{
+		{Tag: "volume-0"}, {Tag: "volume-1"}, {Tag: "machine-0"},
+	}})
+	c.Assert(results, jc.DeepEquals, params.MigrationVolumeExportResults{
+		Results: []params.MigrationVolumeExportResult{{
+			Result: params.MigrationVolumeExport{
+				VolumeTag: "volume-0",
+				Location:  "swift://exports/0",
+				Format:    "raw",
+				Size:      1024,
+			},
+		}, {
+			Error: &params.Error{Message: "boom"},
+		}, {
+			Error: &params.Error{Message: `"machine-0" is not a valid volume tag`},
+		}},
+	})
+	s.backend.stub.CheckCalls(c, []testing.StubCall{
+		{"ExportVolume", []interface{}{names.NewVolumeTag("0")}},
+		{"ExportVolume", []interface{}{names.NewVolumeTag("1")}},
+	})
+}
+
+func (s *Suite) TestRemoveVolumeExports(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	results := api.RemoveVolumeExports(params.MigrationVolumeExports{
+		Exports: []params.MigrationVolumeExport{{
+			VolumeTag: "volume-0",
+			Location:  "swift://exports/0",
+			Format:    "raw",
+			Size:      1024,
+		}},
+	})
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.backend.stub.CheckCalls(c, []testing.StubCall{
+		{"RemoveVolumeExport", []interface{}{names.NewVolumeTag("0"), storage.VolumeExport{
+			Location: "swift://exports/0",
+			Format:   "raw",
+			Size:     1024,
+		}}},
+	})
+}
+
+func (s *Suite) TestMigrationStatusToRegion(c *gc.C) {
+	s.backend.migration.targetRegion = "nether-region"
+	s.backend.migration.mode = coremigration.ModeCopy
+
+	api := s.mustMakeAPI(c)
+	status, err := api.MigrationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Spec.TargetRegion, gc.Equals, "nether-region")
+	c.Check(status.Spec.Mode, gc.Equals, "copy")
+}
+
+func (s *Suite) TestMachinesToReprovision(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	result, err := api.MachinesToReprovision()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+	})
+}
+
+func (s *Suite) TestSnapshotMachines(c *gc.C) {
+	api := s.mustMakeAPI(c)
+	s.backend.snapshotErrs = map[string]error{"1": errors.New("boom")}
+
+	results := api.SnapshotMachines(params.SnapshotMachinesArgs{
+		Region: "nether-region",
+		Machines: params.Entities{Entities: []params.Entity{
+			{Tag: "machine-0"}, {Tag: "machine-1"}, {Tag: "volume-0"},
+		}},
+	})
+	c.Assert(results, jc.DeepEquals, params.MigrationMachineSnapshotResults{
+		Results: []params.MigrationMachineSnapshotResult{{
+			Result: params.MigrationMachineSnapshot{
+				MachineTag: "machine-0",
+				Region:     "nether-region",
+				ImageId:    "image-0",
+			},
+		}, {
+			Error: &params.Error{Message: "boom"},
+		}, {
+			Error: &params.Error{Message: `"volume-0" is not a valid machine tag`},
+		}},
+	})
+	s.backend.stub.CheckCalls(c, []testing.StubCall{
+		{"SnapshotMachine", []interface{}{names.NewMachineTag("0"), "nether-region"}},
+		{"SnapshotMachine", []interface{}{names.NewMachineTag("1"), "nether-region"}},
+	})
+}
+
+func (s *Suite) TestRemoveMachineSnapshots(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	results := api.RemoveMachineSnapshots(params.MigrationMachineSnapshots{
+		Snapshots: []params.MigrationMachineSnapshot{{
+			MachineTag: "machine-0",
+			Region:     "nether-region",
+			ImageId:    "image-0",
+		}},
+	})
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	s.backend.stub.CheckCalls(c, []testing.StubCall{
+		{"RemoveMachineSnapshot", []interface{}{"nether-region", "image-0"}},
+	})
+}
+
+func (s *Suite) TestStopMachineInstances(c *gc.C) {
+	api := s.mustMakeAPI(c)
+
+	results := api.StopMachineInstances(params.Entities{Entities: []params.Entity{
+		{Tag: "machine-0"}, {Tag: "volume-0"},
+	}})
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}, {
+			Error: &params.Error{Message: `"volume-0" is not a valid machine tag`},
+		}},
+	})
+	s.backend.stub.CheckCalls(c, []testing.StubCall{
+		{"StopMachineInstance", []interface{}{names.NewMachineTag("0")}},
+	})
+}
+
 func (s *Suite) TestWatchMinionReports(c *gc.C) {
 	api := s.mustMakeAPI(c)
 
@@ -437,11 +604,14 @@ func (s *Suite) mustMakeAPI(c *gc.C) *migrationmaster.API {
 type stubBackend struct {
 	migrationmaster.Backend
 
-	stub      *testing.Stub
-	getErr    error
-	removeErr error
-	migration *stubMigration
-	model     description.Model
+	stub         *testing.Stub
+	getErr       error
+	removeErr    error
+	exportErrs   map[string]error
+	snapshotErrs map[string]error
+	relocateErr  error
+	migration    *stubMigration
+	model        description.Model
 }
 
 func (b *stubBackend) WatchForMigration() state.NotifyWatcher {
@@ -478,6 +648,56 @@ func (b *stubBackend) RemoveExportingModelDocs() error {
 	return b.removeErr
 }
 
+func (b *stubBackend) CheckVolumeExport(tag names.VolumeTag) error {
+	b.stub.AddCall("CheckVolumeExport", tag)
+	return b.exportErrs[tag.Id()]
+}
+
+func (b *stubBackend) ExportVolume(tag names.VolumeTag) (storage.VolumeExport, error) {
+	b.stub.AddCall("ExportVolume", tag)
+	if err := b.exportErrs[tag.Id()]; err != nil {
+		return storage.VolumeExport{}, err
+	}
+	return storage.VolumeExport{
+		Location: "swift://exports/" + tag.Id(),
+		Format:   "raw",
+		Size:     1024,
+	}, nil
+}
+
+func (b *stubBackend) RemoveVolumeExport(tag names.VolumeTag, export storage.VolumeExport) error {
+	b.stub.AddCall("RemoveVolumeExport", tag, export)
+	return nil
+}
+
+func (b *stubBackend) CheckRelocationSupported() error {
+	b.stub.AddCall("CheckRelocationSupported")
+	return b.relocateErr
+}
+
+func (b *stubBackend) MachinesToReprovision() ([]names.MachineTag, error) {
+	b.stub.AddCall("MachinesToReprovision")
+	return []names.MachineTag{names.NewMachineTag("0"), names.NewMachineTag("1")}, nil
+}
+
+func (b *stubBackend) SnapshotMachine(tag names.MachineTag, region string) (string, error) {
+	b.stub.AddCall("SnapshotMachine", tag, region)
+	if err := b.snapshotErrs[tag.Id()]; err != nil {
+		return "", err
+	}
+	return "image-" + tag.Id(), nil
+}
+
+func (b *stubBackend) RemoveMachineSnapshot(region, imageId string) error {
+	b.stub.AddCall("RemoveMachineSnapshot", region, imageId)
+	return nil
+}
+
+func (b *stubBackend) StopMachineInstance(tag names.MachineTag) error {
+	b.stub.AddCall("StopMachineInstance", tag)
+	return nil
+}
+
 func (b *stubBackend) Export() (description.Model, error) {
 	b.stub.AddCall("Export")
 	return b.model, nil
@@ -493,6 +713,8 @@ type stubMigration struct {
 	messageSet      string
 	minionReports   *state.MinionReports
 	externalControl bool
+	targetRegion    string
+	mode            coremigration.Mode
 }
 
 func (m *stubMigration) Id() string {
@@ -507,6 +729,17 @@ func (m *stubMigration) PhaseChangedTime() time.Time {
 	return time.Date(2016, 6, 22, 16, 38, 0, 0, time.UTC)
 }
 
+func (m *stubMigration) TargetRegion() string {
+	return m.targetRegion
+}
+
+func (m *stubMigration) Mode() coremigration.Mode {
+	if m.mode == "" {
+		return coremigration.ModeMove
+	}
+	return m.mode
+}
+
 func (m *stubMigration) ModelUUID() string {
 	return modelUUID
 }
@@ -8,9 +8,14 @@ import (
 	"github.com/juju/version"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/migration"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
 )
 
 // NewFacade exists to provide the required signature for API
@@ -72,3 +77,149 @@ func (s *backendShim) AgentVersion() (version.Number, error) {
 	}
 	return vers, nil
 }
+
+// CheckVolumeExport implements Backend.
+func (s *backendShim) CheckVolumeExport(tag names.VolumeTag) error {
+	_, _, err := s.volumeExporter(tag)
+	return errors.Trace(err)
+}
+
+// ExportVolume implements Backend.
+func (s *backendShim) ExportVolume(tag names.VolumeTag) (storage.VolumeExport, error) {
+	exporter, volumeId, err := s.volumeExporter(tag)
+	if err != nil {
+		return storage.VolumeExport{}, errors.Trace(err)
+	}
+	return exporter.ExportVolume(state.CallContext(s.State), volumeId)
+}
+
+// RemoveVolumeExport implements Backend.
+func (s *backendShim) RemoveVolumeExport(tag names.VolumeTag, export storage.VolumeExport) error {
+	exporter, _, err := s.volumeExporter(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return exporter.RemoveVolumeExport(state.CallContext(s.State), export)
+}
+
+// MachinesToReprovision implements Backend.
+func (s *backendShim) MachinesToReprovision() ([]names.MachineTag, error) {
+	machines, err := s.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var tags []names.MachineTag
+	for _, m := range machines {
+		if m.IsContainer() {
+			continue
+		}
+		if _, err := m.InstanceId(); errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		tags = append(tags, m.MachineTag())
+	}
+	return tags, nil
+}
+
+// CheckRelocationSupported implements Backend.
+func (s *backendShim) CheckRelocationSupported() error {
+	_, err := s.instanceSnapshotter()
+	return errors.Trace(err)
+}
+
+// SnapshotMachine implements Backend.
+func (s *backendShim) SnapshotMachine(tag names.MachineTag, region string) (string, error) {
+	m, err := s.Machine(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	instId, err := m.InstanceId()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	snapshotter, err := s.instanceSnapshotter()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return snapshotter.SnapshotInstance(state.CallContext(s.State), instId, region)
+}
+
+// RemoveMachineSnapshot implements Backend.
+func (s *backendShim) RemoveMachineSnapshot(region, imageId string) error {
+	snapshotter, err := s.instanceSnapshotter()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return snapshotter.RemoveSnapshot(state.CallContext(s.State), region, imageId)
+}
+
+// StopMachineInstance implements Backend.
+func (s *backendShim) StopMachineInstance(tag names.MachineTag) error {
+	m, err := s.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	instId, err := m.InstanceId()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(s.State)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return env.StopInstances(state.CallContext(s.State), instId)
+}
+
+func (s *backendShim) instanceSnapshotter() (environs.InstanceSnapshotter, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(s.State)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, ok := env.(environs.InstanceSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf("snapshotting instances")
+	}
+	return snapshotter, nil
+}
+
+// volumeExporter returns the VolumeExporter of the storage pool that
+// the volume was created in, along with the volume's provider ID.
+func (s *backendShim) volumeExporter(tag names.VolumeTag) (storage.VolumeExporter, string, error) {
+	sb, err := state.NewStorageBackend(s.State)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	v, err := sb.Volume(tag)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	info, err := v.Info()
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(s.State)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	poolManager := poolmanager.New(state.NewStateSettings(s.State), registry)
+	providerType, cfg, err := storagecommon.StoragePoolConfig(info.Pool, poolManager, registry)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	provider, err := registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	source, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	exporter, ok := source.(storage.VolumeExporter)
+	if !ok {
+		return nil, "", errors.NotSupportedf("exporting volumes from pool %q", info.Pool)
+	}
+	return exporter, info.VolumeId, nil
+}
@@ -38,6 +38,24 @@ type API struct {
 	callContext   context.ProviderCallContext
 }
 
+// APIV3 implements the V3 API of the MigrationTarget facade, which
+// does not support importing pinned agent versions.
+type APIV3 struct {
+	*API
+}
+
+// APIV2 implements the V2 API of the MigrationTarget facade, which
+// does not support re-provisioning machines in another region.
+type APIV2 struct {
+	*APIV3
+}
+
+// APIV1 implements the V1 API of the MigrationTarget facade, which
+// does not support storage translation.
+type APIV1 struct {
+	*APIV2
+}
+
 // NewFacade is used for API registration.
 func NewFacade(ctx facade.Context) (*API, error) {
 	return NewAPI(
@@ -47,6 +65,33 @@ func NewFacade(ctx facade.Context) (*API, error) {
 		state.CallContext(ctx.State()))
 }
 
+// NewFacadeV3 is used for V3 API registration.
+func NewFacadeV3(ctx facade.Context) (*APIV3, error) {
+	api, err := NewFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV3{api}, nil
+}
+
+// NewFacadeV2 is used for V2 API registration.
+func NewFacadeV2(ctx facade.Context) (*APIV2, error) {
+	api, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{api}, nil
+}
+
+// NewFacadeV1 is used for V1 API registration.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
 // NewAPI returns a new API. Accepts a NewEnvironFunc and context.ProviderCallContext
 // for testing purposes.
 func NewAPI(ctx facade.Context, getEnviron stateenvirons.NewEnvironFunc, getCAASBroker stateenvirons.NewCAASBrokerFunc, callCtx context.ProviderCallContext) (*API, error) {
@@ -273,6 +318,35 @@ func (api *API) CheckMachines(args params.ModelArgs) (params.ErrorResults, error
 	)
 }
 
+// ReprovisionMachines moves a migrated model to another region of its
+// cloud, and re-provisions its machines there from the specified
+// snapshots of their instances.
+func (api *API) ReprovisionMachines(args params.ReprovisionMachinesArgs) error {
+	tag, err := names.ParseModelTag(args.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	st, err := api.pool.Get(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Release()
+
+	images := make(map[string]string)
+	for _, snapshot := range args.Snapshots {
+		machineTag, err := names.ParseMachineTag(snapshot.MachineTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if snapshot.Region != args.Region {
+			return errors.Errorf("snapshot of machine %s is in region %q, not %q",
+				machineTag.Id(), snapshot.Region, args.Region)
+		}
+		images[machineTag.Id()] = snapshot.ImageId
+	}
+	return errors.Trace(st.ReprovisionMachines(args.Region, images))
+}
+
 // CACert returns the certificate used to validate the state connection.
 func (api *API) CACert() (params.BytesResult, error) {
 	cfg, err := api.state.ControllerConfig()
@@ -282,3 +356,16 @@ func (api *API) CACert() (params.BytesResult, error) {
 	caCert, _ := cfg.CACert()
 	return params.BytesResult{Result: []byte(caCert)}, nil
 }
+
+// Mask out new methods from the old API versions. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+//
+// ReprovisionMachines did not exist prior to v3.
+func (*APIV2) ReprovisionMachines(_, _ struct{}) {}
+
+// StorageTranslations, ImportVolumeExport and RemoveImportedVolumes
+// did not exist prior to v2.
+func (*APIV1) StorageTranslations(_, _ struct{})   {}
+func (*APIV1) ImportVolumeExport(_, _ struct{})    {}
+func (*APIV1) RemoveImportedVolumes(_, _ struct{}) {}
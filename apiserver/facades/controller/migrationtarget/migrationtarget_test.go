@@ -84,6 +84,39 @@ func (s *Suite) TestFacadeRegistered(c *gc.C) {
 		Auth_:      s.authorizer,
 	})
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV1))
+
+	factory, err = apiserver.AllFacades().GetFactory("MigrationTarget", 2)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err = factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV2))
+
+	factory, err = apiserver.AllFacades().GetFactory("MigrationTarget", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err = factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.APIV3))
+
+	factory, err = apiserver.AllFacades().GetFactory("MigrationTarget", 4)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err = factory(&facadetest.Context{
+		State_:     s.State,
+		Resources_: s.resources,
+		Auth_:      s.authorizer,
+	})
+	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(api, gc.FitsTypeOf, new(migrationtarget.API))
 }
 
@@ -413,6 +446,98 @@ func (s *Suite) TestCheckMachinesHandlesManual(c *gc.C) {
 	c.Assert(results, gc.DeepEquals, params.ErrorResults{})
 }
 
+func (s *Suite) TestStorageTranslationsNone(c *gc.C) {
+	api := s.mustNewAPIWithModel(c, &mockEnv{Stub: &testing.Stub{}}, &mockBroker{})
+	tag := s.importModel(c, api)
+
+	result, err := api.StorageTranslations(params.ModelArgs{ModelTag: tag.String()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.MigrationVolumeTranslations{})
+}
+
+func (s *Suite) TestStorageTranslationsNotImportingModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	_, err = api.StorageTranslations(params.ModelArgs{ModelTag: model.ModelTag().String()})
+	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
+}
+
+func (s *Suite) TestImportVolumeExportNotAVolume(c *gc.C) {
+	api := s.mustNewAPI(c)
+	tag := s.importModel(c, api)
+
+	err := api.ImportVolumeExport(params.ImportVolumeExportArgs{
+		ModelTag: tag.String(),
+		Export:   params.MigrationVolumeExport{VolumeTag: "machine-0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid volume tag`)
+}
+
+func (s *Suite) TestRemoveImportedVolumesNotImportingModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	_, err = api.RemoveImportedVolumes(params.MigrationVolumesArgs{
+		ModelTag:   model.ModelTag().String(),
+		VolumeTags: []string{"volume-0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `migration mode for the model is not importing`)
+}
+
+func (s *Suite) TestReprovisionMachines(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	machine := factory.NewFactory(st, s.StatePool).MakeMachine(c, nil)
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	err = api.ReprovisionMachines(params.ReprovisionMachinesArgs{
+		ModelTag: model.ModelTag().String(),
+		Region:   "nether-region",
+		Snapshots: []params.MigrationMachineSnapshot{{
+			MachineTag: machine.Tag().String(),
+			Region:     "nether-region",
+			ImageId:    "image-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = model.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.CloudRegion(), gc.Equals, "nether-region")
+	err = machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = machine.InstanceId()
+	c.Check(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
+func (s *Suite) TestReprovisionMachinesSnapshotInOtherRegion(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := s.mustNewAPI(c)
+	err = api.ReprovisionMachines(params.ReprovisionMachinesArgs{
+		ModelTag: model.ModelTag().String(),
+		Region:   "nether-region",
+		Snapshots: []params.MigrationMachineSnapshot{{
+			MachineTag: "machine-0",
+			Region:     "dummy-region",
+			ImageId:    "image-0",
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, `snapshot of machine 0 is in region "dummy-region", not "nether-region"`)
+}
+
 func (s *Suite) newAPI(environFunc stateenvirons.NewEnvironFunc, brokerFunc stateenvirons.NewCAASBrokerFunc) (*migrationtarget.API, error) {
 	api, err := migrationtarget.NewAPI(&s.facadeContext, environFunc, brokerFunc, s.callContext)
 	return api, err
@@ -0,0 +1,322 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migrationtarget
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.migrationtarget")
+
+// storageBackend defines the storage functionality of an importing
+// model that is required to translate its volumes.
+type storageBackend interface {
+	AllVolumes() ([]state.Volume, error)
+	Volume(names.VolumeTag) (state.Volume, error)
+	StorageInstance(names.StorageTag) (state.StorageInstance, error)
+	SetImportedVolumeInfo(names.VolumeTag, state.VolumeInfo) error
+}
+
+// modelStorage holds the storage of an importing model.
+type modelStorage struct {
+	st          *state.State
+	config      *config.Config
+	backend     storageBackend
+	registry    storage.ProviderRegistry
+	poolManager poolmanager.PoolManager
+}
+
+// importingModelStorage returns the storage of the importing model
+// with the specified tag, and a function to release it.
+func (api *API) importingModelStorage(modelTag string) (*modelStorage, func(), error) {
+	model, releaseModel, err := api.getImportingModel(params.ModelArgs{ModelTag: modelTag})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	defer releaseModel()
+	if model.Type() != state.ModelTypeIAAS {
+		return nil, nil, errors.NotSupportedf("translating storage of %s models", model.Type())
+	}
+
+	st, err := api.pool.Get(model.UUID())
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	release := func() { st.Release() }
+	ms, err := api.newModelStorage(st.State)
+	if err != nil {
+		release()
+		return nil, nil, errors.Trace(err)
+	}
+	return ms, release, nil
+}
+
+func (api *API) newModelStorage(st *state.State) (*modelStorage, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	cfg, err := m.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sb, err := state.NewStorageBackend(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	env, err := api.getEnviron(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	return &modelStorage{
+		st:          st,
+		config:      cfg,
+		backend:     sb,
+		registry:    registry,
+		poolManager: poolmanager.New(state.NewStateSettings(st), registry),
+	}, nil
+}
+
+// targetPool returns the storage pool that volumes are recreated in,
+// which is the model's default block storage pool.
+func (ms *modelStorage) targetPool() (string, error) {
+	pool, ok := ms.config.StorageDefaultBlockSource()
+	if !ok {
+		return "", errors.Errorf("%s not set", config.StorageDefaultBlockSourceKey)
+	}
+	return pool, nil
+}
+
+// volumeSource returns the volume source of the storage pool with the
+// specified name, along with the pool's provider type and config.
+func (ms *modelStorage) volumeSource(pool string) (storage.VolumeSource, storage.ProviderType, *storage.Config, error) {
+	providerType, cfg, err := storagecommon.StoragePoolConfig(pool, ms.poolManager, ms.registry)
+	if err != nil {
+		return nil, "", nil, errors.Trace(err)
+	}
+	provider, err := ms.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, "", nil, errors.Trace(err)
+	}
+	if !provider.Supports(storage.StorageKindBlock) {
+		return nil, "", nil, errors.NotSupportedf("volumes in pool %q", pool)
+	}
+	source, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, "", nil, errors.Trace(err)
+	}
+	return source, providerType, cfg, nil
+}
+
+// checkImporter returns an error if volumes can't be recreated in the
+// storage pool with the specified name from exported copies.
+func (ms *modelStorage) checkImporter(pool string) error {
+	source, _, _, err := ms.volumeSource(pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := source.(storage.VolumeExportImporter); !ok {
+		return errors.NotSupportedf("recreating volumes in pool %q", pool)
+	}
+	return nil
+}
+
+// StorageTranslations returns the provisioned volumes of an importing
+// model whose storage providers are not available in the target
+// controller, such as when a model's EBS volumes must be moved to
+// Cinder. These volumes must be recreated in the model's default
+// block storage pool from copies exported by the source controller.
+// An error is returned if the default block storage pool can't
+// recreate volumes, so that the migration fails before any volumes
+// are exported.
+func (api *API) StorageTranslations(args params.ModelArgs) (params.MigrationVolumeTranslations, error) {
+	var result params.MigrationVolumeTranslations
+	ms, release, err := api.importingModelStorage(args.ModelTag)
+	if errors.IsNotSupported(err) {
+		return result, nil
+	} else if err != nil {
+		return result, errors.Trace(err)
+	}
+	defer release()
+
+	volumes, err := ms.backend.AllVolumes()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		if v.Life() == state.Dead {
+			continue
+		}
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return result, errors.Trace(err)
+		}
+		_, _, err = storagecommon.StoragePoolConfig(info.Pool, ms.poolManager, ms.registry)
+		if err == nil {
+			continue
+		} else if !errors.IsNotFound(err) {
+			return result, errors.Annotatef(err, "volume %q", v.Tag().Id())
+		}
+		targetPool, err := ms.targetPool()
+		if err == nil {
+			err = ms.checkImporter(targetPool)
+		}
+		if err != nil {
+			return result, errors.Annotatef(err, "cannot translate volume %q in pool %q", v.Tag().Id(), info.Pool)
+		}
+		result.Volumes = append(result.Volumes, params.MigrationVolumeTranslation{
+			VolumeTag:  v.Tag().String(),
+			VolumeId:   info.VolumeId,
+			Pool:       info.Pool,
+			TargetPool: targetPool,
+			Size:       info.Size,
+		})
+	}
+	return result, nil
+}
+
+// ImportVolumeExport recreates a volume of an importing model in the
+// model's default block storage pool, from a copy exported by the
+// source controller, and records the new volume in the model. Volumes
+// which have already been recreated are left alone.
+func (api *API) ImportVolumeExport(args params.ImportVolumeExportArgs) error {
+	tag, err := names.ParseVolumeTag(args.Export.VolumeTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ms, release, err := api.importingModelStorage(args.ModelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer release()
+
+	v, err := ms.backend.Volume(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := v.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	targetPool, err := ms.targetPool()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if info.Pool == targetPool {
+		return nil
+	}
+	source, providerType, cfg, err := ms.volumeSource(targetPool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	importer, ok := source.(storage.VolumeExportImporter)
+	if !ok {
+		return errors.NotSupportedf("recreating volumes in pool %q", targetPool)
+	}
+
+	storageInstance, err := storagecommon.MaybeAssignedStorageInstance(
+		v.StorageInstance,
+		ms.backend.StorageInstance,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumeTags, err := storagecommon.StorageTags(storageInstance, ms.config.UUID(), ms.st.ControllerUUID(), ms.config)
+	if err != nil {
+		return errors.Annotatef(err, "computing tags of volume %q", tag.Id())
+	}
+
+	volume, err := importer.ImportVolumeExport(api.callContext, storage.VolumeExport{
+		Location: args.Export.Location,
+		Format:   args.Export.Format,
+		Size:     args.Export.Size,
+	}, storage.VolumeParams{
+		Tag:          tag,
+		Size:         info.Size,
+		Provider:     providerType,
+		Attributes:   cfg.Attrs(),
+		ResourceTags: volumeTags,
+	})
+	if err != nil {
+		return errors.Annotatef(err, "recreating volume %q", tag.Id())
+	}
+	err = ms.backend.SetImportedVolumeInfo(tag, state.VolumeInfo{
+		HardwareId: volume.HardwareId,
+		WWN:        volume.WWN,
+		Size:       volume.Size,
+		Pool:       targetPool,
+		VolumeId:   volume.VolumeId,
+		Persistent: volume.Persistent,
+	})
+	if err != nil {
+		// Don't leave the new volume behind if it can't be used.
+		if err := api.destroyVolume(source, volume.VolumeId); err != nil {
+			logger.Warningf("cannot destroy volume %q: %v", volume.VolumeId, err)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// RemoveImportedVolumes destroys the cloud volumes of an importing model
+// that were recreated by ImportVolumeExport. It is used to roll back
+// storage translation when a migration is aborted.
+func (api *API) RemoveImportedVolumes(args params.MigrationVolumesArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.VolumeTags)),
+	}
+	ms, release, err := api.importingModelStorage(args.ModelTag)
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	defer release()
+
+	for i, arg := range args.VolumeTags {
+		err := api.removeImportedVolume(ms, arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) removeImportedVolume(ms *modelStorage, volumeTag string) error {
+	tag, err := names.ParseVolumeTag(volumeTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	v, err := ms.backend.Volume(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	info, err := v.Info()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	source, _, _, err := ms.volumeSource(info.Pool)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.destroyVolume(source, info.VolumeId))
+}
+
+func (api *API) destroyVolume(source storage.VolumeSource, volumeId string) error {
+	errs, err := source.DestroyVolumes(api.callContext, []string{volumeId})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(errs[0])
+}
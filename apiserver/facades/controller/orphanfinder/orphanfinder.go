@@ -0,0 +1,53 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphanfinder
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// API provides access to the OrphanFinder API facade, used by the
+// worker that finds provider resources leaked by a model.
+type API struct {
+	*common.ModelWatcher
+
+	st *state.State
+}
+
+// NewAPI returns a new OrphanFinder API facade.
+func NewAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(m, resources, authorizer),
+		st:           st,
+	}, nil
+}
+
+// KnownModelResources returns the ids of the provider resources, and
+// the tags of the entities, that Juju knows about for the model.
+func (api *API) KnownModelResources() (params.KnownModelResourcesResult, error) {
+	known, err := common.KnownModelResources(api.st)
+	if err != nil {
+		return params.KnownModelResourcesResult{Error: common.ServerError(err)}, nil
+	}
+	return params.KnownModelResourcesResult{
+		Result: params.KnownModelResources{
+			InstanceIds:  known.InstanceIds,
+			VolumeIds:    known.VolumeIds,
+			Machines:     known.Machines,
+			Applications: known.Applications,
+		},
+	}, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphanfinder_test
+
+import (
+	"github.com/juju/collections/set"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/orphanfinder"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/instance"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type orphanFinderSuite struct {
+	jujutesting.JujuConnSuite
+
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *orphanfinder.API
+}
+
+var _ = gc.Suite(&orphanFinderSuite{})
+
+func (s *orphanFinderSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	var err error
+	s.api, err = orphanfinder.NewAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *orphanFinderSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := orphanfinder.NewAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *orphanFinderSuite) TestKnownModelResources(c *gc.C) {
+	provisioned := s.Factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: instance.Id("i-provisioned"),
+	})
+	unprovisioned, err := s.State.AddMachine("quantal", "host")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "wordpress"})
+
+	result, err := s.api.KnownModelResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	known := result.Result
+	c.Check(known.Applications, jc.DeepEquals, []string{"application-wordpress"})
+	machines := set.NewStrings(known.Machines...)
+	c.Check(machines.Contains(provisioned.Tag().String()), jc.IsTrue)
+	c.Check(machines.Contains(unprovisioned.Tag().String()), jc.IsTrue)
+	instanceIds := set.NewStrings(known.InstanceIds...)
+	c.Check(instanceIds.Contains("i-provisioned"), jc.IsTrue)
+	c.Check(instanceIds.Size(), gc.Equals, machines.Size()-1)
+}
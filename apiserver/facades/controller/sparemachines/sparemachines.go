@@ -0,0 +1,117 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sparemachines
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// API provides access to the SpareMachines API facade, used by the
+// worker that keeps the model's pool of spare machines replenished.
+type API struct {
+	*common.ModelWatcher
+
+	st *state.State
+}
+
+// NewAPI returns a new SpareMachines API facade.
+func NewAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(m, resources, authorizer),
+		st:           st,
+	}, nil
+}
+
+// SpareMachines returns the machines in the model's pool of spare
+// machines.
+func (api *API) SpareMachines() (params.SpareMachinesResult, error) {
+	machines, err := api.st.SpareMachines()
+	if err != nil {
+		return params.SpareMachinesResult{Error: common.ServerError(err)}, nil
+	}
+	result := params.SpareMachinesResult{
+		Machines: make([]string, len(machines)),
+	}
+	for i, m := range machines {
+		result.Machines[i] = m.Tag().String()
+	}
+	return result, nil
+}
+
+// AddSpareMachines adds machines with the given constraints, and the
+// model's default series, to the model's pool of spare machines.
+func (api *API) AddSpareMachines(args params.AddSpareMachinesArgs) (params.ErrorResult, error) {
+	err := api.addSpareMachines(args)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+func (api *API) addSpareMachines(args params.AddSpareMachinesArgs) error {
+	if args.Count <= 0 {
+		return errors.NotValidf("machine count %d", args.Count)
+	}
+	if err := api.st.CheckQuotas(api.st.ModelUUID(), state.QuotaUsage{Machines: args.Count}); err != nil {
+		return errors.Trace(err)
+	}
+	m, err := api.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := m.Config()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	templates := make([]state.MachineTemplate, args.Count)
+	for i := range templates {
+		templates[i] = state.MachineTemplate{
+			Series:      config.PreferredSeries(cfg),
+			Constraints: args.Constraints,
+			Jobs:        []state.MachineJob{state.JobHostUnits},
+			Spare:       true,
+		}
+	}
+	_, err = api.st.AddMachines(templates...)
+	return errors.Trace(err)
+}
+
+// RemoveSpareMachines destroys the given machines, which must still be
+// in the model's pool of spare machines.
+func (api *API) RemoveSpareMachines(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		err := api.removeSpareMachine(entity.Tag)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) removeSpareMachine(tagString string) error {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, err := api.st.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !m.IsSpare() {
+		return errors.Errorf("machine %s is not spare", tag.Id())
+	}
+	return errors.Trace(m.Destroy())
+}
@@ -0,0 +1,99 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sparemachines_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/sparemachines"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/constraints"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+)
+
+type spareMachinesSuite struct {
+	jujutesting.JujuConnSuite
+
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *sparemachines.API
+}
+
+var _ = gc.Suite(&spareMachinesSuite{})
+
+func (s *spareMachinesSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	var err error
+	s.api, err = sparemachines.NewAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *spareMachinesSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := sparemachines.NewAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *spareMachinesSuite) TestAddSpareMachines(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	result, err := s.api.AddSpareMachines(params.AddSpareMachinesArgs{
+		Count:       2,
+		Constraints: constraints.MustParse("mem=4G"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	spares, err := s.api.SpareMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spares, jc.DeepEquals, params.SpareMachinesResult{
+		Machines: []string{"machine-1", "machine-2"},
+	})
+	m, err := s.State.Machine("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(m.Jobs(), jc.DeepEquals, []state.MachineJob{state.JobHostUnits})
+	cons, err := m.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons.String(), gc.Equals, "mem=4096M")
+}
+
+func (s *spareMachinesSuite) TestAddSpareMachinesInvalidCount(c *gc.C) {
+	result, err := s.api.AddSpareMachines(params.AddSpareMachinesArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "machine count 0 not valid")
+}
+
+func (s *spareMachinesSuite) TestRemoveSpareMachines(c *gc.C) {
+	other := s.Factory.MakeMachine(c, nil)
+	result, err := s.api.AddSpareMachines(params.AddSpareMachinesArgs{Count: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+
+	results, err := s.api.RemoveSpareMachines(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-1"},
+			{Tag: other.Tag().String()},
+			{Tag: "application-mysql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "machine 0 is not spare")
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"application-mysql" is not a valid machine tag`)
+
+	spares, err := s.api.SpareMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spares.Machines, gc.HasLen, 0)
+}
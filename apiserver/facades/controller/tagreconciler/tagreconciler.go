@@ -0,0 +1,57 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tagreconciler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// API provides access to the TagReconciler API facade, used by the
+// worker that corrects the tags of a model's instances and volumes.
+type API struct {
+	*common.ModelWatcher
+
+	st *state.State
+}
+
+// NewAPI returns a new TagReconciler API facade.
+func NewAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(m, resources, authorizer),
+		st:           st,
+	}, nil
+}
+
+// ExpectedResourceTags returns the model's provisioned instances and
+// volumes, and the tags Juju expects each of them to have.
+func (api *API) ExpectedResourceTags() (params.TaggedResourcesResult, error) {
+	resources, err := common.ExpectedResourceTags(api.st)
+	if err != nil {
+		return params.TaggedResourcesResult{Error: common.ServerError(err)}, nil
+	}
+	result := params.TaggedResourcesResult{
+		Result: make([]params.TaggedResource, len(resources)),
+	}
+	for i, resource := range resources {
+		result.Result[i] = params.TaggedResource{
+			Kind:  string(resource.Kind),
+			Id:    resource.Id,
+			Owner: resource.Owner,
+			Tags:  resource.Tags,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tagreconciler_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/tagreconciler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/tags"
+	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type tagReconcilerSuite struct {
+	jujutesting.JujuConnSuite
+
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	api        *tagreconciler.API
+}
+
+var _ = gc.Suite(&tagReconcilerSuite{})
+
+func (s *tagReconcilerSuite) SetUpTest(c *gc.C) {
+	s.JujuConnSuite.SetUpTest(c)
+	s.resources = common.NewResources()
+	s.AddCleanup(func(_ *gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag:        names.NewMachineTag("0"),
+		Controller: true,
+	}
+	var err error
+	s.api, err = tagreconciler.NewAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *tagReconcilerSuite) TestNewAPIRequiresController(c *gc.C) {
+	s.authorizer.Controller = false
+	_, err := tagreconciler.NewAPI(s.State, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *tagReconcilerSuite) TestExpectedResourceTags(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, &factory.MachineParams{
+		InstanceId: instance.Id("i-provisioned"),
+		Volumes: []state.HostVolumeParams{
+			{Volume: state.VolumeParams{Size: 1000, Pool: "modelscoped"}},
+			{Volume: state.VolumeParams{Size: 1000, Pool: "modelscoped"}},
+		},
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+	unprovisioned, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only one of the volumes is provisioned.
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	volumes, err := machine.VolumeAttachments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes, gc.HasLen, 2)
+	volumeTag := volumes[0].Volume()
+	err = sb.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		Pool:     "modelscoped",
+		Size:     1000,
+		VolumeId: "vol-ume",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	units, err := machine.Units()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 1)
+
+	result, err := s.api.ExpectedResourceTags()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	// Unprovisioned machines and volumes are omitted.
+	var found []params.TaggedResource
+	for _, resource := range result.Result {
+		c.Check(resource.Owner, gc.Not(gc.Equals), unprovisioned.Tag().String())
+		c.Check(resource.Owner, gc.Not(gc.Equals), volumes[1].Volume().String())
+		if resource.Owner == machine.Tag().String() || resource.Owner == volumeTag.String() {
+			found = append(found, resource)
+		}
+	}
+	c.Assert(found, jc.DeepEquals, []params.TaggedResource{{
+		Kind:  "instance",
+		Id:    "i-provisioned",
+		Owner: machine.Tag().String(),
+		Tags: map[string]string{
+			tags.JujuController:    coretesting.ControllerTag.Id(),
+			tags.JujuModel:         coretesting.ModelTag.Id(),
+			tags.JujuMachine:       "controller-" + machine.Tag().String(),
+			tags.JujuUnitsDeployed: units[0].Name(),
+		},
+	}, {
+		Kind:  "volume",
+		Id:    "vol-ume",
+		Owner: volumeTag.String(),
+		Tags: map[string]string{
+			tags.JujuController: coretesting.ControllerTag.Id(),
+			tags.JujuModel:      coretesting.ModelTag.Id(),
+		},
+	}})
+}
@@ -0,0 +1,264 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+
+	"github.com/juju/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/rpc/jsoncodec"
+)
+
+const (
+	// grpcServicePrefix prefixes the names of the gRPC services, each
+	// of which corresponds to a facade, so a call to Client.FullStatus
+	// is made to "/juju.Client/FullStatus".
+	grpcServicePrefix = "juju."
+
+	// grpcSchemaService is the gRPC service that returns the schemas
+	// of the facades, from its Get method.
+	grpcSchemaService = "Schema"
+)
+
+// grpcHandler serves an experimental gRPC transport for the API,
+// enabled by the "grpc-api" controller feature flag. Each gRPC service
+// is a facade, and each message is the JSON encoding of a facade
+// method's parameters or result, as described by the schemas returned
+// by the Schema service. A stream may carry any number of calls to the
+// same method, each answered in order.
+//
+// The facade version, the model UUID (empty for the controller), and
+// the credentials of the calling user are passed in the "version",
+// "model-uuid", "username", "password" and "token" metadata.
+type grpcHandler struct {
+	srv    *Server
+	server *grpc.Server
+}
+
+func newGRPCHandler(srv *Server) *grpcHandler {
+	h := &grpcHandler{srv: srv}
+	h.server = grpc.NewServer(
+		grpc.CustomCodec(grpcJSONCodec{}),
+		grpc.UnknownServiceHandler(h.serveStream),
+	)
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *grpcHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !h.srv.shared.featureEnabled(feature.GRPCAPI) {
+		http.NotFound(w, req)
+		return
+	}
+	connectionID := atomic.AddUint64(&h.srv.lastConnectionID, 1)
+	apiObserver := h.srv.newObserver()
+	apiObserver.Join(req, connectionID)
+	defer apiObserver.Leave()
+
+	// Each gRPC call is a single HTTP request, so the details of the
+	// request are passed through to the stream handler in its context.
+	ctx := context.WithValue(req.Context(), grpcRequestKey{}, grpcRequest{
+		connectionID: connectionID,
+		observer:     apiObserver,
+		host:         req.Host,
+		remoteAddr:   req.RemoteAddr,
+	})
+	h.server.ServeHTTP(w, req.WithContext(ctx))
+}
+
+type grpcRequestKey struct{}
+
+type grpcRequest struct {
+	connectionID uint64
+	observer     observer.Observer
+	host         string
+	remoteAddr   string
+}
+
+func (h *grpcHandler) serveStream(_ interface{}, stream grpc.ServerStream) error {
+	fullMethod, _ := grpc.MethodFromServerStream(stream)
+	facadeName, method, err := parseGRPCMethod(fullMethod)
+	if err != nil {
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	if facadeName == grpcSchemaService {
+		if method != "Get" {
+			return status.Errorf(codes.Unimplemented, "unknown method %q", method)
+		}
+		return stream.SendMsg(facadeSchemas())
+	}
+
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	version := 0
+	if v := grpcMetadata(md, "version"); v != "" {
+		if version, err = strconv.Atoi(v); err != nil {
+			return status.Errorf(codes.InvalidArgument, "version %q not valid", v)
+		}
+	}
+	conn, err := h.login(stream.Context(), md)
+	if err != nil {
+		return grpcError(err)
+	}
+	defer conn.Close()
+
+	request := rpc.Request{
+		Type:    facadeName,
+		Version: version,
+		Action:  method,
+	}
+	for {
+		var args json.RawMessage
+		if err := stream.RecvMsg(&args); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		var callArgs interface{}
+		if len(args) > 0 {
+			callArgs = args
+		}
+		var result json.RawMessage
+		if err := conn.Call(request, callArgs, &result); err != nil {
+			return grpcError(err)
+		}
+		if err := stream.SendMsg(result); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// login returns an RPC connection to the API, served in process and
+// logged in with the credentials in the metadata, so that gRPC calls
+// are authorized and restricted exactly as websocket calls are.
+func (h *grpcHandler) login(ctx context.Context, md metadata.MD) (*rpc.Conn, error) {
+	modelUUID := grpcMetadata(md, "model-uuid")
+	username := grpcMetadata(md, "username")
+	request := params.LoginRequest{
+		Credentials: grpcMetadata(md, "password"),
+		Token:       grpcMetadata(md, "token"),
+	}
+	if username != "" {
+		if !names.IsValidUser(username) {
+			return nil, errors.NotValidf("username %q", username)
+		}
+		request.AuthTag = names.NewUserTag(username).String()
+	}
+
+	info, ok := ctx.Value(grpcRequestKey{}).(grpcRequest)
+	if !ok {
+		return nil, errors.New("gRPC call without HTTP request")
+	}
+	serverEnd, clientEnd := net.Pipe()
+	go func() {
+		codec := jsoncodec.NewNet(serverEnd)
+		if err := h.srv.serveConn(
+			ctx,
+			codec,
+			modelUUID,
+			info.connectionID,
+			info.observer,
+			info.host,
+			info.remoteAddr,
+		); err != nil {
+			logger.Errorf("error serving gRPC calls: %v", err)
+		}
+	}()
+
+	conn := rpc.NewConn(jsoncodec.NewNet(clientEnd), nil)
+	conn.Start(ctx)
+	var result params.LoginResult
+	if err := conn.Call(rpc.Request{Type: "Admin", Version: 3, Action: "Login"}, &request, &result); err != nil {
+		conn.Close()
+		return nil, errors.Trace(err)
+	}
+	return conn, nil
+}
+
+// parseGRPCMethod returns the facade and method named by a full gRPC
+// method name, eg "/juju.Client/FullStatus".
+func parseGRPCMethod(fullMethod string) (facadeName, method string, err error) {
+	parts := strings.Split(strings.TrimPrefix(fullMethod, "/"), "/")
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], grpcServicePrefix) {
+		return "", "", errors.NotValidf("gRPC method %q", fullMethod)
+	}
+	facadeName = strings.TrimPrefix(parts[0], grpcServicePrefix)
+	if facadeName == "" || parts[1] == "" {
+		return "", "", errors.NotValidf("gRPC method %q", fullMethod)
+	}
+	return facadeName, parts[1], nil
+}
+
+func grpcMetadata(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// grpcErrorCodes maps the codes of API errors to gRPC status codes.
+var grpcErrorCodes = map[string]codes.Code{
+	params.CodeNotFound:       codes.NotFound,
+	params.CodeUnauthorized:   codes.Unauthenticated,
+	params.CodeLoginExpired:   codes.Unauthenticated,
+	params.CodeForbidden:      codes.PermissionDenied,
+	params.CodeAlreadyExists:  codes.AlreadyExists,
+	params.CodeNotImplemented: codes.Unimplemented,
+	params.CodeNotSupported:   codes.Unimplemented,
+	params.CodeBadRequest:     codes.InvalidArgument,
+	params.CodeTryAgain:       codes.Unavailable,
+}
+
+// grpcError returns the gRPC status error corresponding to an error
+// returned by the API.
+func grpcError(err error) error {
+	code, ok := grpcErrorCodes[params.ErrCode(err)]
+	if !ok {
+		code = codes.Unknown
+	}
+	message := err.Error()
+	if rerr, ok := errors.Cause(err).(*rpc.RequestError); ok {
+		message = rerr.Message
+	}
+	return status.Error(code, message)
+}
+
+// grpcJSONCodec encodes gRPC messages as JSON, so that they match the
+// facade schemas and can be passed through to the API unchanged.
+type grpcJSONCodec struct{}
+
+// Marshal is part of the grpc.Codec interface.
+func (grpcJSONCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal is part of the grpc.Codec interface.
+func (grpcJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		// Methods without parameters may be sent empty messages.
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// String is part of the grpc.Codec interface.
+func (grpcJSONCodec) String() string {
+	return "json"
+}
@@ -0,0 +1,87 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/rpc"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type grpcSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&grpcSuite{})
+
+func (s *grpcSuite) TestParseGRPCMethod(c *gc.C) {
+	facadeName, method, err := parseGRPCMethod("/juju.Client/FullStatus")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(facadeName, gc.Equals, "Client")
+	c.Assert(method, gc.Equals, "FullStatus")
+
+	for _, fullMethod := range []string{
+		"",
+		"/Client/FullStatus",
+		"/juju./FullStatus",
+		"/juju.Client/",
+		"/juju.Client/FullStatus/extra",
+	} {
+		_, _, err := parseGRPCMethod(fullMethod)
+		c.Check(err, jc.Satisfies, errors.IsNotValid, gc.Commentf("%q", fullMethod))
+	}
+}
+
+func (s *grpcSuite) TestGRPCError(c *gc.C) {
+	for _, test := range []struct {
+		err     error
+		code    codes.Code
+		message string
+	}{{
+		err:     &rpc.RequestError{Message: "no such model", Code: params.CodeNotFound},
+		code:    codes.NotFound,
+		message: "no such model",
+	}, {
+		err:     &rpc.RequestError{Message: "invalid entity name or password", Code: params.CodeUnauthorized},
+		code:    codes.Unauthenticated,
+		message: "invalid entity name or password",
+	}, {
+		err:     errors.Annotate(&rpc.RequestError{Message: "upgrading", Code: params.CodeTryAgain}, "calling"),
+		code:    codes.Unavailable,
+		message: "upgrading",
+	}, {
+		err:     errors.New("boom"),
+		code:    codes.Unknown,
+		message: "boom",
+	}} {
+		st, ok := status.FromError(grpcError(test.err))
+		c.Assert(ok, jc.IsTrue)
+		c.Check(st.Code(), gc.Equals, test.code)
+		c.Check(st.Message(), gc.Equals, test.message)
+	}
+}
+
+func (s *grpcSuite) TestGRPCJSONCodec(c *gc.C) {
+	codec := grpcJSONCodec{}
+	data, err := codec.Marshal(params.Entity{Tag: "machine-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, `{"tag":"machine-0"}`)
+
+	var raw json.RawMessage
+	c.Assert(codec.Unmarshal(data, &raw), jc.ErrorIsNil)
+	c.Assert(string(raw), gc.Equals, `{"tag":"machine-0"}`)
+
+	// Empty messages are accepted for methods without parameters.
+	raw = nil
+	c.Assert(codec.Unmarshal(nil, &raw), jc.ErrorIsNil)
+	c.Assert(raw, gc.IsNil)
+}
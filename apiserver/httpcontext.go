@@ -6,6 +6,7 @@ package apiserver
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/juju/errors"
@@ -160,6 +161,13 @@ func (ctxt *httpContext) stop() <-chan struct{} {
 	return ctxt.srv.tomb.Dying()
 }
 
+// limitBandwidth returns a reader that reads from r no faster than the
+// controller's bandwidth limit for the given kind of traffic allows.
+func (ctxt *httpContext) limitBandwidth(traffic string, r io.Reader) io.Reader {
+	shared := ctxt.srv.shared
+	return shared.bandwidth.reader(r, traffic, shared.bandwidthLimit(traffic))
+}
+
 // sendStatusAndJSON sends an HTTP status code and
 // a JSON-encoded response to a client.
 func sendStatusAndJSON(w http.ResponseWriter, statusCode int, response interface{}) error {
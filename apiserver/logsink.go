@@ -18,6 +18,7 @@ import (
 
 	"github.com/juju/juju/apiserver/logsink"
 	"github.com/juju/juju/apiserver/params"
+	corelogger "github.com/juju/juju/core/logger"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/logdb"
 )
@@ -166,6 +167,12 @@ func (s *agentLoggingStrategy) Close() error {
 // WriteLog is part of the logsink.LogWriteCloser interface.
 func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
 	level, _ := loggo.ParseLevel(m.Level)
+	// The labels of the messages of juju-log are appended to them,
+	// so that they are also written to the agent's log file.
+	message, labels := m.Message, m.Labels
+	if len(labels) == 0 {
+		message, labels = corelogger.ParseMessage(m.Message)
+	}
 	dbErr := errors.Annotate(s.dblogger.Log([]state.LogRecord{{
 		Time:     m.Time,
 		Entity:   s.entity,
@@ -173,7 +180,8 @@ func (s *agentLoggingStrategy) WriteLog(m params.LogRecord) error {
 		Module:   m.Module,
 		Location: m.Location,
 		Level:    level,
-		Message:  m.Message,
+		Message:  message,
+		Labels:   labels,
 	}}), "logging to DB failed")
 
 	m.Entity = s.entity.String()
@@ -199,7 +207,7 @@ func logToFile(writer io.Writer, prefix string, m params.LogRecord) error {
 		m.Level,
 		m.Module,
 		m.Location,
-		m.Message,
+		corelogger.FormatMessage(m.Message, m.Labels),
 	}, " ") + "\n"))
 	return err
 }
@@ -106,7 +106,7 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 		Module:   "else.where",
 		Location: "bar.go:99",
 		Level:    loggo.ERROR.String(),
-		Message:  "oh noes",
+		Message:  "oh noes [labels: stage=dump]",
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -142,6 +142,7 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 	c.Assert(docs[1]["l"], gc.Equals, "bar.go:99")
 	c.Assert(docs[1]["v"], gc.Equals, int(loggo.ERROR))
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+	c.Assert(docs[1]["lb"], jc.DeepEquals, bson.M{"stage": "dump"})
 
 	// Close connection.
 	err = conn.Close()
@@ -164,7 +165,7 @@ func (s *logsinkSuite) TestLogging(c *gc.C) {
 	logContents, err := ioutil.ReadFile(logPath)
 	c.Assert(err, jc.ErrorIsNil)
 	line0 := modelUUID + ": machine-0 2015-06-01 23:02:01 INFO some.where foo.go:42 all is well\n"
-	line1 := modelUUID + ": machine-0 2015-06-01 23:02:02 ERROR else.where bar.go:99 oh noes\n"
+	line1 := modelUUID + ": machine-0 2015-06-01 23:02:02 ERROR else.where bar.go:99 oh noes [labels: stage=dump]\n"
 	c.Assert(string(logContents), gc.Equals, line0+line1)
 
 	// Check the file mode is as expected. This doesn't work on
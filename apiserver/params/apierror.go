@@ -95,6 +95,26 @@ func (e DischargeRequiredErrorInfo) AsMap() map[string]interface{} {
 	return serializeToMap(e)
 }
 
+// SettingsTooLargeErrorInfo describes the settings that were rejected
+// with a SettingsTooLarge error, so that the writer can tell which keys
+// to shrink.
+type SettingsTooLargeErrorInfo struct {
+	// Size is the size, in bytes, that the settings would have had.
+	Size int `json:"size"`
+
+	// Limit is the maximum size, in bytes, of the settings.
+	Limit int `json:"limit"`
+
+	// LargestKeys holds the keys with the largest values, largest
+	// first.
+	LargestKeys []string `json:"largest-keys,omitempty"`
+}
+
+// AsMap encodes the error info as a map that can be attached to an Error.
+func (e SettingsTooLargeErrorInfo) AsMap() map[string]interface{} {
+	return serializeToMap(e)
+}
+
 // serializeToMap is a convenience function for marshaling v into a
 // map[string]interface{}. It works by marshalling v into json and then
 // unmarshaling back to a map.
@@ -145,6 +165,9 @@ const (
 	CodeMigrationInProgress       = "model migration in progress"
 	CodeActionNotAvailable        = "action no longer available"
 	CodeOperationBlocked          = "operation is blocked"
+	CodeModelProtected            = "model is protected"
+	CodeQuotaExceeded             = "quota exceeded"
+	CodeSettingsTooLarge          = "settings too large"
 	CodeLeadershipClaimDenied     = "leadership claim denied"
 	CodeLeaseClaimDenied          = "lease claim denied"
 	CodeNotSupported              = "not supported"
@@ -294,6 +317,18 @@ func IsCodeOperationBlocked(err error) bool {
 	return ErrCode(err) == CodeOperationBlocked
 }
 
+func IsCodeModelProtected(err error) bool {
+	return ErrCode(err) == CodeModelProtected
+}
+
+func IsCodeQuotaExceeded(err error) bool {
+	return ErrCode(err) == CodeQuotaExceeded
+}
+
+func IsCodeSettingsTooLarge(err error) bool {
+	return ErrCode(err) == CodeSettingsTooLarge
+}
+
 func IsCodeLeadershipClaimDenied(err error) bool {
 	return ErrCode(err) == CodeLeadershipClaimDenied
 }
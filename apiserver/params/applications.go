@@ -4,6 +4,8 @@
 package params
 
 import (
+	"time"
+
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/instance"
@@ -147,6 +149,11 @@ type ApplicationSetCharm struct {
 // ApplicationExpose holds the parameters for making the application Expose call.
 type ApplicationExpose struct {
 	ApplicationName string `json:"application"`
+
+	// LoadBalancer is true if a provider load balancer should be
+	// provisioned in front of the application's units. This field is
+	// only understood by Application facade version 17 and greater.
+	LoadBalancer bool `json:"load-balancer,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -292,9 +299,25 @@ type DestroyApplicationParams struct {
 	// units of the application should be destroyed.
 	DestroyStorage bool `json:"destroy-storage,omitempty"`
 
+	// ReleaseStorage controls whether or not storage attached to
+	// units of the application should be released from the model
+	// rather than detached. It may not be combined with DestroyStorage.
+	ReleaseStorage bool `json:"release-storage,omitempty"`
+
+	// StorageDisposition maps the tags of storage instances attached
+	// to units of the application to what should happen to them:
+	// "destroy", "detach" or "release". Storage that is not named is
+	// handled according to DestroyStorage and ReleaseStorage.
+	StorageDisposition map[string]string `json:"storage-disposition,omitempty"`
+
 	// Force controls whether or not the destruction of an application
 	// will be forced, i.e. ignore operational errors.
 	Force bool
+
+	// MaxWait specifies the amount of time that each step in application removal
+	// will wait before forcing the next step to kick-off. This parameter
+	// only makes sense in combination with 'force' set to 'true'.
+	MaxWait *time.Duration `json:"max-wait,omitempty"`
 }
 
 // DestroyConsumedApplicationsParams holds bulk parameters for the
@@ -351,9 +374,31 @@ type DestroyApplicationInfo struct {
 	// destroyed as a result of destroying the application.
 	DestroyedStorage []Entity `json:"destroyed-storage,omitempty"`
 
+	// ReleasedStorage is the tags of storage instances that will be
+	// removed from the model without being destroyed.
+	ReleasedStorage []Entity `json:"released-storage,omitempty"`
+
 	// DestroyedUnits is the tags of units that will be destroyed
 	// as a result of destroying the application.
 	DestroyedUnits []Entity `json:"destroyed-units,omitempty"`
+
+	// DestroyedMachines is the tags of machines that will be removed
+	// because they host no units other than those being destroyed.
+	DestroyedMachines []Entity `json:"destroyed-machines,omitempty"`
+
+	// DestroyedOffers describes the offers of the application that
+	// will be removed as a result of destroying the application.
+	DestroyedOffers []DestroyedOffer `json:"destroyed-offers,omitempty"`
+}
+
+// DestroyedOffer describes an application offer that will be removed
+// as a result of destroying the offered application or its model.
+type DestroyedOffer struct {
+	// OfferName is the name of the offer.
+	OfferName string `json:"offer-name"`
+
+	// Connections is the number of consumers connected to the offer.
+	Connections int `json:"connections"`
 }
 
 // ScaleApplicationsParams holds bulk parameters for the Application.ScaleApplication call.
@@ -420,3 +465,222 @@ type ApplicationInfoResult struct {
 type ApplicationInfoResults struct {
 	Results []ApplicationInfoResult `json:"results"`
 }
+
+// RefreshPolicy describes how an application follows new revisions of
+// its charm.
+type RefreshPolicy struct {
+	// Channel is the charm store channel tracked for new revisions.
+	// If empty, the channel the application was deployed from is
+	// tracked.
+	Channel string `json:"channel,omitempty"`
+
+	// Auto is true if the application is refreshed to new revisions
+	// automatically, rather than only being notified of them.
+	Auto bool `json:"auto"`
+
+	// WindowStart and WindowEnd delimit the time of day, as offsets
+	// from midnight UTC, within which automatic refreshes may take
+	// place.
+	WindowStart time.Duration `json:"window-start"`
+	WindowEnd   time.Duration `json:"window-end"`
+
+	// Hold suspends automatic refreshes.
+	Hold bool `json:"hold"`
+}
+
+// ApplicationRefreshPolicy holds the refresh policy of an application.
+type ApplicationRefreshPolicy struct {
+	ApplicationTag string        `json:"application-tag"`
+	Policy         RefreshPolicy `json:"policy"`
+}
+
+// SetApplicationRefreshPolicies holds the refresh policies to set for
+// a number of applications.
+type SetApplicationRefreshPolicies struct {
+	Policies []ApplicationRefreshPolicy `json:"policies"`
+}
+
+// RefreshPolicyResult holds an application's refresh policy or an
+// error.
+type RefreshPolicyResult struct {
+	Result *RefreshPolicy `json:"result,omitempty"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// RefreshPolicyResults holds the refresh policies of a number of
+// applications.
+type RefreshPolicyResults struct {
+	Results []RefreshPolicyResult `json:"results"`
+}
+
+// CreateShadowApplicationArg holds the parameters for creating a shadow
+// of an existing application, running a different charm revision, as
+// the first step of a blue/green deployment.
+type CreateShadowApplicationArg struct {
+	// ApplicationName is the name of the existing application.
+	ApplicationName string `json:"application"`
+
+	// ShadowName is the name of the shadow application to create.
+	ShadowName string `json:"shadow"`
+
+	// CharmURL is the URL of the charm the shadow application is
+	// deployed with. The charm must already have been added to the
+	// model.
+	CharmURL string `json:"charm-url"`
+
+	// Channel is the charm store channel the charm was taken from.
+	Channel string `json:"channel,omitempty"`
+
+	// ConfigYAML holds charm settings, in the format accepted by
+	// deploy, that override those copied from the application.
+	ConfigYAML string `json:"config-yaml,omitempty"`
+
+	// NumUnits is the number of units to add to the shadow
+	// application. If nil, as many units as the application has
+	// are added.
+	NumUnits *int `json:"num-units,omitempty"`
+}
+
+// CreateShadowApplicationArgs holds the parameters for creating a
+// number of shadow applications.
+type CreateShadowApplicationArgs struct {
+	Args []CreateShadowApplicationArg `json:"args"`
+}
+
+// MigrateRelationsArg holds the parameters for moving relations from
+// an application to its shadow.
+type MigrateRelationsArg struct {
+	// ApplicationName is the name of the application whose relations
+	// are moved.
+	ApplicationName string `json:"application"`
+
+	// ShadowName is the name of the shadow application the relations
+	// are moved to.
+	ShadowName string `json:"shadow"`
+
+	// Endpoints holds the names of the application's endpoints whose
+	// relations are moved. If empty, all relations are moved.
+	Endpoints []string `json:"endpoints,omitempty"`
+}
+
+// MigrateRelationsArgs holds the parameters for moving the relations
+// of a number of applications to their shadows.
+type MigrateRelationsArgs struct {
+	Args []MigrateRelationsArg `json:"args"`
+}
+
+// FinaliseShadowApplicationArg holds the parameters for completing a
+// blue/green deployment, by removing the application that was replaced
+// by its shadow.
+type FinaliseShadowApplicationArg struct {
+	ApplicationName string `json:"application"`
+	ShadowName      string `json:"shadow"`
+}
+
+// FinaliseShadowApplicationArgs holds the parameters for completing a
+// number of blue/green deployments.
+type FinaliseShadowApplicationArgs struct {
+	Args []FinaliseShadowApplicationArg `json:"args"`
+}
+
+// CheckCharmCompatibilityArg holds an application and a charm that the
+// application's charm is to be changed to.
+type CheckCharmCompatibilityArg struct {
+	ApplicationName string `json:"application"`
+	CharmURL        string `json:"charm-url"`
+}
+
+// CheckCharmCompatibilityArgs holds a number of applications and charms
+// to check are compatible.
+type CheckCharmCompatibilityArgs struct {
+	Args []CheckCharmCompatibilityArg `json:"args"`
+}
+
+// AutoscalePolicy holds an application's autoscaling policy.
+type AutoscalePolicy struct {
+	MinUnits       int           `json:"min-units"`
+	MaxUnits       int           `json:"max-units"`
+	Metric         string        `json:"metric"`
+	ScaleUpAbove   float64       `json:"scale-up-above"`
+	ScaleDownBelow float64       `json:"scale-down-below"`
+	Cooldown       time.Duration `json:"cooldown"`
+}
+
+// AutoscaleEvent records a change to the number of units of an
+// application made by the autoscaler.
+type AutoscaleEvent struct {
+	Time   time.Time `json:"time"`
+	From   int       `json:"from"`
+	To     int       `json:"to"`
+	Reason string    `json:"reason"`
+}
+
+// SetAutoscalePolicyArg holds the autoscaling policy to set for an
+// application.
+type SetAutoscalePolicyArg struct {
+	ApplicationTag string          `json:"application-tag"`
+	Policy         AutoscalePolicy `json:"policy"`
+}
+
+// SetAutoscalePolicyArgs holds the autoscaling policies to set for a
+// number of applications.
+type SetAutoscalePolicyArgs struct {
+	Args []SetAutoscalePolicyArg `json:"args"`
+}
+
+// AutoscaleResult holds an application's autoscaling policy, the most
+// recent changes made by the autoscaler, oldest first, and the number
+// of alive units of the application.
+type AutoscaleResult struct {
+	ApplicationTag string           `json:"application-tag"`
+	Policy         *AutoscalePolicy `json:"policy,omitempty"`
+	Events         []AutoscaleEvent `json:"events,omitempty"`
+	Units          int              `json:"units"`
+	Error          *Error           `json:"error,omitempty"`
+}
+
+// AutoscaleResults holds the autoscaling details of a number of
+// applications.
+type AutoscaleResults struct {
+	Results []AutoscaleResult `json:"results"`
+}
+
+// ApplicationMetricArg identifies a charm metric, and the time since
+// when the values recorded by an application's units are wanted.
+type ApplicationMetricArg struct {
+	ApplicationTag string    `json:"application-tag"`
+	Metric         string    `json:"metric"`
+	Since          time.Time `json:"since"`
+}
+
+// ApplicationMetricArgs holds the charm metrics wanted for a number of
+// applications.
+type ApplicationMetricArgs struct {
+	Args []ApplicationMetricArg `json:"args"`
+}
+
+// ApplicationMetricResult holds the most recent value of a charm metric
+// recorded by each of an application's alive units.
+type ApplicationMetricResult struct {
+	Values []float64 `json:"values"`
+	Error  *Error    `json:"error,omitempty"`
+}
+
+// ApplicationMetricResults holds the charm metric values of a number of
+// applications.
+type ApplicationMetricResults struct {
+	Results []ApplicationMetricResult `json:"results"`
+}
+
+// AutoscaleApplicationArg holds a change to be made to the number of
+// units of an application by the autoscaler.
+type AutoscaleApplicationArg struct {
+	ApplicationTag string         `json:"application-tag"`
+	Event          AutoscaleEvent `json:"event"`
+}
+
+// AutoscaleApplicationArgs holds changes to be made to the number of
+// units of a number of applications.
+type AutoscaleApplicationArgs struct {
+	Args []AutoscaleApplicationArg `json:"args"`
+}
@@ -79,4 +79,35 @@ type BackupsMetadataResult struct {
 type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
+
+	// Rollback, if true, restores the safety snapshot taken before
+	// the restore started if the restore fails.
+	Rollback bool `json:"rollback,omitempty"`
+}
+
+// VerifyRestoreArgs holds the args for the API VerifyRestore method.
+// Either BackupId names a backup stored on the controller, or Metadata
+// describes a backup archive that hasn't been uploaded yet.
+type VerifyRestoreArgs struct {
+	BackupId string                 `json:"backup-id,omitempty"`
+	Metadata *BackupsMetadataResult `json:"metadata,omitempty"`
+
+	// Rollback reports whether the restore being verified would
+	// roll back to the safety snapshot if it failed.
+	Rollback bool `json:"rollback,omitempty"`
+}
+
+// RestoreCheck holds the outcome of one of the checks made before a
+// restore. Problem is empty if the check passed.
+type RestoreCheck struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Problem     string `json:"problem,omitempty"`
+}
+
+// VerifyRestoreResult holds the checks made against the controller
+// for a restore, and the steps the restore would take.
+type VerifyRestoreResult struct {
+	Checks []RestoreCheck `json:"checks"`
+	Steps  []string       `json:"steps"`
 }
@@ -93,6 +93,29 @@ type CloudInfoResults struct {
 	Results []CloudInfoResult `json:"results"`
 }
 
+// CloudCapabilities holds the optional features that a cloud's
+// provider supports.
+type CloudCapabilities struct {
+	// Reported is false if the provider does not report which
+	// optional features it supports.
+	Reported bool `json:"reported"`
+
+	// Capabilities holds the names of the supported features.
+	Capabilities []string `json:"capabilities,omitempty"`
+}
+
+// CloudCapabilitiesResult holds the result of a CloudCapabilities call.
+type CloudCapabilitiesResult struct {
+	Result *CloudCapabilities `json:"result,omitempty"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// CloudCapabilitiesResults holds the result of a bulk CloudCapabilities
+// call.
+type CloudCapabilitiesResults struct {
+	Results []CloudCapabilitiesResult `json:"results"`
+}
+
 // ListCloudsRequest encapsulates how we request a list of cloud details for a user.
 type ListCloudsRequest struct {
 	UserTag string `json:"user-tag"`
@@ -32,3 +32,7 @@ const (
 )
 
 const MachineNonceHeader = "X-Juju-Nonce"
+
+// UserTagHeader holds the tag of the user an OpenID Connect ID token,
+// sent as an HTTP Bearer token, was issued for.
+const UserTagHeader = "X-Juju-User"
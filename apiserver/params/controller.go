@@ -3,6 +3,12 @@
 
 package params
 
+import (
+	"time"
+
+	"github.com/juju/version"
+)
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -106,3 +112,202 @@ const (
 	GrantControllerAccess  ControllerAction = "grant"
 	RevokeControllerAccess ControllerAction = "revoke"
 )
+
+// RaftStorageResult holds the disk space used by a controller's raft
+// node after a snapshot was taken.
+type RaftStorageResult struct {
+	MachineId     string `json:"machine-id"`
+	LogBytes      int64  `json:"log-bytes"`
+	LogEntries    uint64 `json:"log-entries"`
+	SnapshotBytes int64  `json:"snapshot-bytes"`
+	Error         *Error `json:"error,omitempty"`
+}
+
+// RaftStorageResults holds the results of Controller.CompactRaftLogs.
+type RaftStorageResults struct {
+	Results []RaftStorageResult `json:"results"`
+}
+
+// StartStagedUpgradeArgs holds the arguments to
+// Controller.StartStagedUpgrade.
+type StartStagedUpgradeArgs struct {
+	TargetVersion version.Number `json:"target-version"`
+
+	// Waves holds the tags of the models upgraded in each wave.
+	Waves [][]string `json:"waves"`
+
+	// WaveTimeout is how long the agents in a wave have to upgrade
+	// before the staged upgrade fails.
+	WaveTimeout time.Duration `json:"wave-timeout"`
+}
+
+// StagedUpgradeStatus describes the progress of a staged upgrade.
+type StagedUpgradeStatus struct {
+	TargetVersion version.Number `json:"target-version"`
+	Waves         [][]string     `json:"waves"`
+	Wave          int            `json:"wave"`
+	WaveTimeout   time.Duration  `json:"wave-timeout"`
+	Status        string         `json:"status"`
+	Message       string         `json:"message,omitempty"`
+	Started       time.Time      `json:"started"`
+	WaveStarted   time.Time      `json:"wave-started"`
+}
+
+// StagedUpgradeStatusResult holds the result of
+// Controller.StagedUpgradeStatus.
+type StagedUpgradeStatusResult struct {
+	Result *StagedUpgradeStatus `json:"result,omitempty"`
+	Error  *Error               `json:"error,omitempty"`
+}
+
+// DashboardSummaryArgs holds the arguments to Controller.DashboardSummary.
+type DashboardSummaryArgs struct {
+	// All requests the models of all users, and is only honoured for
+	// controller superusers.
+	All bool `json:"all,omitempty"`
+}
+
+// EntityStatusError describes an entity in an error state.
+type EntityStatusError struct {
+	Tag     string     `json:"tag"`
+	Message string     `json:"message"`
+	Since   *time.Time `json:"since,omitempty"`
+}
+
+// ModelDashboardSummary holds the aggregated status of a model.
+type ModelDashboardSummary struct {
+	ModelTag string       `json:"model-tag"`
+	Name     string       `json:"name"`
+	Type     string       `json:"type"`
+	OwnerTag string       `json:"owner-tag"`
+	Life     Life         `json:"life"`
+	Status   EntityStatus `json:"status"`
+
+	// ApplicationStatus and UnitStatus count the model's
+	// applications and units by status.
+	ApplicationStatus map[string]int `json:"application-status"`
+	UnitStatus        map[string]int `json:"unit-status"`
+
+	// RecentErrors holds the model's most recent errors, newest first.
+	RecentErrors []EntityStatusError `json:"recent-errors,omitempty"`
+
+	AgentVersion *version.Number `json:"agent-version,omitempty"`
+
+	// AvailableVersion is set if the model can be upgraded to a newer
+	// agent version.
+	AvailableVersion *version.Number `json:"available-version,omitempty"`
+}
+
+// DashboardSummaryResult holds the result of Controller.DashboardSummary.
+type DashboardSummaryResult struct {
+	Models []ModelDashboardSummary `json:"models"`
+
+	// ApplicationStatus and UnitStatus total the counts of all the
+	// models.
+	ApplicationStatus map[string]int `json:"application-status"`
+	UnitStatus        map[string]int `json:"unit-status"`
+}
+
+// ModelsFullStatusArgs holds the arguments to Controller.ModelsFullStatus.
+type ModelsFullStatusArgs struct {
+	ModelTags []string `json:"model-tags"`
+
+	// Patterns filters the status of each model, as for
+	// Client.FullStatus.
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// ModelFullStatusResult holds the full status of a model, or the
+// error getting it.
+type ModelFullStatusResult struct {
+	ModelTag string      `json:"model-tag"`
+	Result   *FullStatus `json:"result,omitempty"`
+	Error    *Error      `json:"error,omitempty"`
+}
+
+// ModelFullStatusResults holds the results of Controller.ModelsFullStatus.
+type ModelFullStatusResults struct {
+	Results []ModelFullStatusResult `json:"results"`
+}
+
+// APISession describes an API connection that has logged in to an API
+// server.
+type APISession struct {
+	// Server is the machine ID of the API server.
+	Server       string `json:"server"`
+	ConnectionID uint64 `json:"connection-id"`
+
+	// Tag is the tag of the user or agent that logged in.
+	Tag string `json:"tag"`
+
+	// ModelTag is the model the connection is for, or empty for a
+	// connection to the controller only.
+	ModelTag   string    `json:"model-tag,omitempty"`
+	RemoteAddr string    `json:"remote-addr"`
+	LoggedIn   time.Time `json:"logged-in"`
+}
+
+// APISessionsResult holds the result of Controller.ListSessions.
+type APISessionsResult struct {
+	Sessions []APISession `json:"sessions"`
+}
+
+// RevokeSession identifies API connections to close, either by the API
+// server and connection ID, or by the tag of the user that logged in,
+// in which case all of that user's connections are closed.
+type RevokeSession struct {
+	Server       string `json:"server,omitempty"`
+	ConnectionID uint64 `json:"connection-id,omitempty"`
+	UserTag      string `json:"user-tag,omitempty"`
+}
+
+// RevokeSessionsArgs holds the arguments to Controller.RevokeSessions.
+type RevokeSessionsArgs struct {
+	Sessions []RevokeSession `json:"sessions"`
+}
+
+// Quota limits the resources that a model, or all of the models owned
+// by a user, may consume. A zero limit means there is no limit.
+type Quota struct {
+	Machines   int    `json:"machines,omitempty"`
+	Units      int    `json:"units,omitempty"`
+	StorageMiB uint64 `json:"storage-mib,omitempty"`
+}
+
+// QuotaUsage holds the resources that a model, or all of the models
+// owned by a user, consume.
+type QuotaUsage struct {
+	Machines   int    `json:"machines"`
+	Units      int    `json:"units"`
+	StorageMiB uint64 `json:"storage-mib"`
+}
+
+// EntityQuota holds the quota of a model or user, and what it
+// currently uses.
+type EntityQuota struct {
+	// Tag is the tag of the model or user.
+	Tag string `json:"tag"`
+
+	// Name is the name of the model, qualified by its owner, or the
+	// name of the user.
+	Name  string      `json:"name"`
+	Quota Quota       `json:"quota"`
+	Usage *QuotaUsage `json:"usage,omitempty"`
+	Error *Error      `json:"error,omitempty"`
+}
+
+// EntityQuotas holds the results of Controller.ListQuotas.
+type EntityQuotas struct {
+	Results []EntityQuota `json:"results"`
+}
+
+// SetQuotaArg sets the quota of a model or user.
+type SetQuotaArg struct {
+	Tag   string `json:"tag"`
+	Quota Quota  `json:"quota"`
+}
+
+// SetQuotasArgs holds the arguments to Controller.SetQuotas.
+type SetQuotasArgs struct {
+	Args []SetQuotaArg `json:"args"`
+}
@@ -0,0 +1,61 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// CrashReport holds a report of the crash of an agent: the panic that
+// crashed it, the stacks of its goroutines and the last lines it
+// logged.
+type CrashReport struct {
+	ID         string    `json:"id"`
+	Agent      string    `json:"agent"`
+	Version    string    `json:"version"`
+	Time       time.Time `json:"time"`
+	Panic      string    `json:"panic"`
+	Goroutines string    `json:"goroutines,omitempty"`
+	LogTail    string    `json:"log-tail,omitempty"`
+}
+
+// CrashReports holds crash reports uploaded by an agent.
+type CrashReports struct {
+	Reports []CrashReport `json:"reports"`
+}
+
+// CrashReportInfo describes a crash report uploaded by an agent. Panic
+// holds the first line of the panic that crashed the agent.
+type CrashReportInfo struct {
+	ID       string    `json:"id"`
+	Agent    string    `json:"agent"`
+	Version  string    `json:"version"`
+	Time     time.Time `json:"time"`
+	Panic    string    `json:"panic"`
+	Size     int64     `json:"size"`
+	Uploaded time.Time `json:"uploaded"`
+}
+
+// CrashReportInfos holds information about the crash reports of a
+// model, oldest first.
+type CrashReportInfos struct {
+	Reports []CrashReportInfo `json:"reports"`
+}
+
+// CrashReportIDs holds the IDs of crash reports.
+type CrashReportIDs struct {
+	IDs []string `json:"ids"`
+}
+
+// CrashReportResult holds a crash report, or an error.
+type CrashReportResult struct {
+	Report *CrashReport `json:"report,omitempty"`
+	Error  *Error       `json:"error,omitempty"`
+}
+
+// CrashReportResults holds the results of an API call returning
+// crash reports.
+type CrashReportResults struct {
+	Results []CrashReportResult `json:"results"`
+}
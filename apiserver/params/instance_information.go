@@ -72,3 +72,44 @@ type InstanceType struct {
 	Deprecated   bool     `json:"deprecated,omitempty"`
 	Cost         int      `json:"cost,omitempty"`
 }
+
+// CostEstimateArgs holds the arguments to MachineManager.EstimateCosts.
+type CostEstimateArgs struct {
+	Estimates []CostEstimateArg `json:"estimates"`
+}
+
+// CostEstimateArg describes new machines whose cost is to be estimated.
+type CostEstimateArg struct {
+	// Constraints holds the constraints of the machines. They are
+	// merged with the model's constraints.
+	Constraints constraints.Value `json:"constraints"`
+
+	// Count is the number of machines.
+	Count int `json:"count"`
+}
+
+// CostEstimateResults holds the results of MachineManager.EstimateCosts.
+type CostEstimateResults struct {
+	Results []CostEstimateResult `json:"results"`
+}
+
+// CostEstimateResult holds the estimated cost of running new machines.
+type CostEstimateResult struct {
+	// InstanceType is the instance type that would be started for
+	// each machine.
+	InstanceType string `json:"instance-type,omitempty"`
+
+	// Count is the number of machines.
+	Count int `json:"count"`
+
+	// HourlyCost is the cost of running one of the machines for an
+	// hour, expressed in Currency.
+	HourlyCost float64 `json:"hourly-cost"`
+
+	// MonthlyCost is the cost of running all of the machines for a
+	// month, expressed in Currency.
+	MonthlyCost float64 `json:"monthly-cost"`
+
+	Currency string `json:"currency,omitempty"`
+	Error    *Error `json:"error,omitempty"`
+}
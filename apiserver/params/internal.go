@@ -338,6 +338,7 @@ type RelationResult struct {
 	Key              string                `json:"key"`
 	Endpoint         multiwatcher.Endpoint `json:"endpoint"`
 	OtherApplication string                `json:"other-application,omitempty"`
+	DrainTimeout     time.Duration         `json:"drain-timeout,omitempty"`
 }
 
 // RelationResultV5 returns information about a single relation,
@@ -489,6 +490,20 @@ type VersionResults struct {
 	Results []VersionResult `json:"results"`
 }
 
+// MaintenanceWindowResult holds the maintenance window, in the form
+// HH:MM-HH:MM or "any", and possibly error for a given
+// MaintenanceWindows() API call.
+type MaintenanceWindowResult struct {
+	Window string `json:"window"`
+	Error  *Error `json:"error,omitempty"`
+}
+
+// MaintenanceWindowResults is a list of maintenance windows for the
+// requested entities.
+type MaintenanceWindowResults struct {
+	Results []MaintenanceWindowResult `json:"results"`
+}
+
 // SetModelEnvironVersions holds the tags and associated environ versions
 // of a collection of models.
 type SetModelEnvironVersions struct {
@@ -674,6 +689,14 @@ type RunParams struct {
 	Units        []string      `json:"units,omitempty"`
 }
 
+// IntrospectArgs is used to provide the parameters to the Introspect
+// method. Agents holds the tags of the units and machines whose agents
+// are introspected, and Query the report to fetch from them.
+type IntrospectArgs struct {
+	Agents []string `json:"agents"`
+	Query  string   `json:"query"`
+}
+
 // RunResult contains the result from an individual run call on a machine.
 // UnitId is populated if the command was run inside the unit context.
 type RunResult struct {
@@ -813,12 +836,13 @@ type GUIVersionRequest struct {
 
 // LogMessage is a structured logging entry.
 type LogMessage struct {
-	Entity    string    `json:"tag"`
-	Timestamp time.Time `json:"ts"`
-	Severity  string    `json:"sev"`
-	Module    string    `json:"mod"`
-	Location  string    `json:"loc"`
-	Message   string    `json:"msg"`
+	Entity    string            `json:"tag"`
+	Timestamp time.Time         `json:"ts"`
+	Severity  string            `json:"sev"`
+	Module    string            `json:"mod"`
+	Location  string            `json:"loc"`
+	Message   string            `json:"msg"`
+	Labels    map[string]string `json:"labels,omitempty"`
 }
 
 // ResourceUploadResult is used to return some details about an
@@ -839,6 +863,7 @@ type ResourceUploadResult struct {
 type UnitRefreshResult struct {
 	Life     Life
 	Resolved ResolvedMode
+	Paused   bool
 	Error    *Error
 }
 
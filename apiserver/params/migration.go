@@ -23,8 +23,10 @@ type InitiateMigrationArgs struct {
 // MigrationSpec holds the details required to start the migration of
 // a single model.
 type MigrationSpec struct {
-	ModelTag   string              `json:"model-tag"`
-	TargetInfo MigrationTargetInfo `json:"target-info"`
+	ModelTag     string              `json:"model-tag"`
+	TargetInfo   MigrationTargetInfo `json:"target-info"`
+	TargetRegion string              `json:"target-region,omitempty"`
+	Mode         string              `json:"mode,omitempty"`
 }
 
 // MigrationTargetInfo holds the details required to connect to and
@@ -146,6 +148,10 @@ type MigrationStatus struct {
 
 	TargetAPIAddrs []string `json:"target-api-addrs"`
 	TargetCACert   string   `json:"target-ca-cert"`
+
+	// Copy is true when the model is being copied to the target
+	// controller, and its agents remain with the source controller.
+	Copy bool `json:"copy,omitempty"`
 }
 
 // PhasesResults holds the phase of one or more model migrations.
@@ -217,3 +223,108 @@ type AdoptResourcesArgs struct {
 	// that version.
 	SourceControllerVersion version.Number `json:"source-controller-version"`
 }
+
+// MigrationVolumeTranslation describes a volume of a migrating model
+// that must be recreated in another storage pool on the target
+// controller.
+type MigrationVolumeTranslation struct {
+	VolumeTag  string `json:"volume-tag"`
+	VolumeId   string `json:"volume-id"`
+	Pool       string `json:"pool"`
+	TargetPool string `json:"target-pool"`
+	Size       uint64 `json:"size"`
+}
+
+// MigrationVolumeTranslations holds the volumes of a migrating model
+// that must be recreated on the target controller.
+type MigrationVolumeTranslations struct {
+	Volumes []MigrationVolumeTranslation `json:"volumes"`
+}
+
+// MigrationVolumeExport describes a portable copy of a volume made by
+// the source controller of a migration.
+type MigrationVolumeExport struct {
+	VolumeTag string `json:"volume-tag"`
+	Location  string `json:"location"`
+	Format    string `json:"format"`
+	Size      uint64 `json:"size"`
+}
+
+// MigrationVolumeExportResult holds a volume export or an error.
+type MigrationVolumeExportResult struct {
+	Result MigrationVolumeExport `json:"result"`
+	Error  *Error                `json:"error,omitempty"`
+}
+
+// MigrationVolumeExportResults holds the results of exporting volumes.
+type MigrationVolumeExportResults struct {
+	Results []MigrationVolumeExportResult `json:"results"`
+}
+
+// MigrationVolumeExports holds volume exports to remove.
+type MigrationVolumeExports struct {
+	Exports []MigrationVolumeExport `json:"exports"`
+}
+
+// ImportVolumeExportArgs holds the information required to recreate
+// a volume of a migrating model from a copy made by the source
+// controller.
+type ImportVolumeExportArgs struct {
+	// ModelTag identifies the model that owns the volume.
+	ModelTag string `json:"model-tag"`
+
+	// Export describes the copy of the volume.
+	Export MigrationVolumeExport `json:"export"`
+}
+
+// MigrationVolumesArgs identifies volumes of a migrating model.
+type MigrationVolumesArgs struct {
+	ModelTag   string   `json:"model-tag"`
+	VolumeTags []string `json:"volume-tags"`
+}
+
+// MigrationMachineSnapshot describes an image of a machine's instance,
+// made in the region a migrating model is moving to.
+type MigrationMachineSnapshot struct {
+	MachineTag string `json:"machine-tag"`
+	Region     string `json:"region"`
+	ImageId    string `json:"image-id"`
+}
+
+// MigrationMachineSnapshotResult holds a machine snapshot or an error.
+type MigrationMachineSnapshotResult struct {
+	Result MigrationMachineSnapshot `json:"result"`
+	Error  *Error                   `json:"error,omitempty"`
+}
+
+// MigrationMachineSnapshotResults holds the results of snapshotting
+// machines.
+type MigrationMachineSnapshotResults struct {
+	Results []MigrationMachineSnapshotResult `json:"results"`
+}
+
+// SnapshotMachinesArgs holds the machines to snapshot, and the region
+// to make the images in.
+type SnapshotMachinesArgs struct {
+	Region   string   `json:"region"`
+	Machines Entities `json:"machines"`
+}
+
+// MigrationMachineSnapshots holds machine snapshots to remove.
+type MigrationMachineSnapshots struct {
+	Snapshots []MigrationMachineSnapshot `json:"snapshots"`
+}
+
+// ReprovisionMachinesArgs holds the information required to move a
+// migrated model to another region, and provision its machines again
+// there.
+type ReprovisionMachinesArgs struct {
+	// ModelTag identifies the model being moved.
+	ModelTag string `json:"model-tag"`
+
+	// Region is the region of the model's cloud to move it to.
+	Region string `json:"region"`
+
+	// Snapshots holds the images to provision the machines from.
+	Snapshots []MigrationMachineSnapshot `json:"snapshots"`
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/juju/version"
+
+	"github.com/juju/juju/core/constraints"
 )
 
 // ConfigValue encapsulates a configuration
@@ -22,6 +24,29 @@ type ModelConfigResults struct {
 	Config map[string]ConfigValue `json:"config"`
 }
 
+// ModelConfigTraceArgs holds the names of model config attributes
+// whose inheritance is to be traced.
+type ModelConfigTraceArgs struct {
+	Keys []string `json:"keys"`
+}
+
+// ModelConfigTraceResults holds the results of a call to
+// ModelConfigTrace.
+type ModelConfigTraceResults struct {
+	Results []ModelConfigTraceResult `json:"results"`
+}
+
+// ModelConfigTraceResult describes where the effective value of a
+// model config attribute came from. Levels holds the value set at
+// each level of the inheritance chain that sets the attribute, from
+// lowest to highest precedence.
+type ModelConfigTraceResult struct {
+	Key       string        `json:"key"`
+	Effective ConfigValue   `json:"effective"`
+	Levels    []ConfigValue `json:"levels,omitempty"`
+	Error     *Error        `json:"error,omitempty"`
+}
+
 // HostedModelConfig contains the model config and the cloud spec
 // for the model, both things that a client needs to talk directly
 // with the provider. This is used to take down mis-behaving models
@@ -126,6 +151,42 @@ type SetModelAgentVersion struct {
 	IgnoreAgentVersions bool           `json:"force,omitempty"`
 }
 
+// PinModelAgentVersion contains the arguments for the
+// PinModelAgentVersion client API call.
+type PinModelAgentVersion struct {
+	// Version is the version the model is pinned to. The zero
+	// version removes the pin.
+	Version version.Number `json:"version"`
+}
+
+// UpgradePrecheckArgs contains the arguments for the UpgradePrecheck
+// client API call.
+type UpgradePrecheckArgs struct {
+	// TargetVersion is the version the model would be upgraded to.
+	// Checks that depend on it are skipped if it is zero.
+	TargetVersion version.Number `json:"target-version"`
+}
+
+// UpgradePrecheckResult holds the outcome of a single upgrade check.
+type UpgradePrecheckResult struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+
+	// Blocking is true if the problems found would prevent a
+	// successful upgrade, rather than being worth a warning.
+	Blocking bool     `json:"blocking"`
+	Problems []string `json:"problems,omitempty"`
+
+	// Error is set if the check could not be run.
+	Error *Error `json:"error,omitempty"`
+}
+
+// UpgradePrecheckResults holds the results of the UpgradePrecheck
+// client API call.
+type UpgradePrecheckResults struct {
+	Results []UpgradePrecheckResult `json:"results"`
+}
+
 // ModelMigrationStatus holds information about the progress of a (possibly
 // failed) migration.
 type ModelMigrationStatus struct {
@@ -410,6 +471,45 @@ type DestroyModelParams struct {
 	MaxWait *time.Duration `json:"max-wait,omitempty"`
 }
 
+// DestroyModelImpactResults holds the results of a
+// ModelManager.PreviewDestroyModels call.
+type DestroyModelImpactResults struct {
+	Results []DestroyModelImpactResult `json:"results"`
+}
+
+// DestroyModelImpactResult holds what would be removed by destroying
+// a model, or an error.
+type DestroyModelImpactResult struct {
+	Impact *DestroyModelImpact `json:"impact,omitempty"`
+	Error  *Error              `json:"error,omitempty"`
+}
+
+// DestroyModelImpact describes what would be removed by destroying a
+// model with the given DestroyModelParams.
+type DestroyModelImpact struct {
+	// Machines is the tags of the machines that will be removed.
+	Machines []Entity `json:"machines,omitempty"`
+
+	// Applications is the tags of the applications that will be removed.
+	Applications []Entity `json:"applications,omitempty"`
+
+	// DestroyedStorage is the tags of storage instances that will be
+	// destroyed along with the model.
+	DestroyedStorage []Entity `json:"destroyed-storage,omitempty"`
+
+	// ReleasedStorage is the tags of storage instances that will be
+	// released from the model, without being destroyed.
+	ReleasedStorage []Entity `json:"released-storage,omitempty"`
+
+	// UndecidedStorage is the tags of storage instances in the model
+	// when DestroyStorage was not specified. Destroying the model
+	// will fail until it is if any of them are persistent.
+	UndecidedStorage []Entity `json:"undecided-storage,omitempty"`
+
+	// Offers describes the offers that will be removed.
+	Offers []DestroyedOffer `json:"offers,omitempty"`
+}
+
 // ModelCredential stores information about cloud credential that a model uses:
 // what credential is being used, is it valid for this model, etc.
 type ModelCredential struct {
@@ -445,3 +545,210 @@ type ChangeModelCredentialParams struct {
 type ChangeModelCredentialsParams struct {
 	Models []ChangeModelCredentialParams `json:"model-credentials"`
 }
+
+// ModelResource identifies a provider resource created for a model.
+type ModelResource struct {
+	Kind  string `json:"kind"`
+	Id    string `json:"id"`
+	Owner string `json:"owner,omitempty"`
+}
+
+// ModelResourcesResult holds provider resources created for a model,
+// or an error.
+type ModelResourcesResult struct {
+	Resources []ModelResource `json:"resources,omitempty"`
+	Error     *Error          `json:"error,omitempty"`
+}
+
+// KnownModelResources holds the ids of the provider resources, and the
+// tags of the entities, that Juju knows about for a model.
+type KnownModelResources struct {
+	InstanceIds  []string `json:"instance-ids,omitempty"`
+	VolumeIds    []string `json:"volume-ids,omitempty"`
+	Machines     []string `json:"machines,omitempty"`
+	Applications []string `json:"applications,omitempty"`
+}
+
+// KnownModelResourcesResult holds the resources Juju knows about for a
+// model, or an error.
+type KnownModelResourcesResult struct {
+	Result KnownModelResources `json:"result"`
+	Error  *Error              `json:"error,omitempty"`
+}
+
+// TaggedResource holds the id of an instance or volume, the tag of the
+// machine or volume it was created for, and the tags Juju expects it to
+// have.
+type TaggedResource struct {
+	Kind  string            `json:"kind"`
+	Id    string            `json:"id"`
+	Owner string            `json:"owner"`
+	Tags  map[string]string `json:"tags"`
+}
+
+// TaggedResourcesResult holds the instances and volumes of a model, and
+// the tags Juju expects them to have, or an error.
+type TaggedResourcesResult struct {
+	Result []TaggedResource `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// ModelUtilisationArgs holds the arguments to
+// IdleAdvisor.ModelUtilisation.
+type ModelUtilisationArgs struct {
+	// Metric is the name of the charm metric giving the utilisation
+	// of units. If empty, no unit utilisation is returned.
+	Metric string `json:"metric,omitempty"`
+
+	// Since is the time from which metric values are considered.
+	Since time.Time `json:"since"`
+}
+
+// MachineUtilisation holds the number of units and containers on a
+// machine.
+type MachineUtilisation struct {
+	Tag        string `json:"tag"`
+	Units      int    `json:"units"`
+	Containers int    `json:"containers"`
+}
+
+// UnitUtilisation holds the highest value of the utilisation metric
+// recorded for a unit. Peak is nil if no values were recorded.
+type UnitUtilisation struct {
+	Tag  string   `json:"tag"`
+	Peak *float64 `json:"peak,omitempty"`
+}
+
+// ModelUtilisation holds the utilisation of a model's machines and
+// units.
+type ModelUtilisation struct {
+	Machines []MachineUtilisation `json:"machines,omitempty"`
+	Units    []UnitUtilisation    `json:"units,omitempty"`
+}
+
+// ModelUtilisationResult holds the utilisation of a model's machines
+// and units, or an error.
+type ModelUtilisationResult struct {
+	Result ModelUtilisation `json:"result"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// IdleResource records that a machine or unit has been idle since a
+// given time.
+type IdleResource struct {
+	Tag    string    `json:"tag"`
+	Since  time.Time `json:"since"`
+	Reason string    `json:"reason"`
+}
+
+// IdleResources holds the idle machines and units of a model.
+type IdleResources struct {
+	Resources []IdleResource `json:"resources"`
+}
+
+// IdleResourcesResult holds the idle machines and units of a model, or
+// an error.
+type IdleResourcesResult struct {
+	Resources []IdleResource `json:"resources,omitempty"`
+	Error     *Error         `json:"error,omitempty"`
+}
+
+// Advice describes an idle machine or unit, and the command that would
+// remove it.
+type Advice struct {
+	Tag        string    `json:"tag"`
+	IdleSince  time.Time `json:"idle-since"`
+	Reason     string    `json:"reason"`
+	Suggestion string    `json:"suggestion,omitempty"`
+}
+
+// AdviceResults holds the advice for a model, or an error.
+type AdviceResults struct {
+	Results []Advice `json:"results,omitempty"`
+	Error   *Error   `json:"error,omitempty"`
+}
+
+// SpareMachinesResult holds the machines in a model's pool of spare
+// machines, or an error.
+type SpareMachinesResult struct {
+	Machines []string `json:"machines,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
+}
+
+// AddSpareMachinesArgs holds the arguments to
+// SpareMachines.AddSpareMachines.
+type AddSpareMachinesArgs struct {
+	// Count is the number of machines to add to the pool.
+	Count int `json:"count"`
+
+	// Constraints are the constraints of the machines added.
+	Constraints constraints.Value `json:"constraints"`
+}
+
+// ModelPlanChange describes a change to make to a model. Kind is one
+// of "deploy", "set-config" or "add-relation".
+type ModelPlanChange struct {
+	Kind        string `json:"kind"`
+	Application string `json:"application,omitempty"`
+
+	// CharmURL and NumUnits describe the application to deploy. The
+	// charm must already have been added to the model.
+	CharmURL string `json:"charm-url,omitempty"`
+	NumUnits int    `json:"num-units,omitempty"`
+
+	// Config holds the charm config of the application to deploy, or
+	// the charm config to set.
+	Config map[string]interface{} `json:"config,omitempty"`
+
+	// Endpoints holds the two endpoints to relate.
+	Endpoints []string `json:"endpoints,omitempty"`
+}
+
+// ModelPlanArgs holds the desired changes to a model, from which a
+// plan is computed.
+type ModelPlanArgs struct {
+	Changes []ModelPlanChange `json:"changes"`
+}
+
+// ModelPlanOperation describes a single change to a model made when
+// its plan is applied.
+type ModelPlanOperation struct {
+	ID          string `json:"id"`
+	Kind        string `json:"kind"`
+	Description string `json:"description"`
+	Application string `json:"application,omitempty"`
+	CharmURL    string `json:"charm-url,omitempty"`
+	NumUnits    int    `json:"num-units,omitempty"`
+
+	Config         map[string]interface{} `json:"config,omitempty"`
+	PreviousConfig map[string]interface{} `json:"previous-config,omitempty"`
+	Endpoints      []string               `json:"endpoints,omitempty"`
+}
+
+// ModelPlanResult holds a computed model plan, or an error.
+type ModelPlanResult struct {
+	PlanID     string               `json:"plan-id,omitempty"`
+	Expires    time.Time            `json:"expires,omitempty"`
+	Operations []ModelPlanOperation `json:"operations,omitempty"`
+	Error      *Error               `json:"error,omitempty"`
+}
+
+// ApplyModelPlanArgs identifies the model plan to apply.
+type ApplyModelPlanArgs struct {
+	PlanID string `json:"plan-id"`
+}
+
+// ModelPlanOperationResult holds the outcome of applying a model plan
+// operation.
+type ModelPlanOperationResult struct {
+	ID    string `json:"id"`
+	Error *Error `json:"error,omitempty"`
+}
+
+// ApplyModelPlanResult holds the outcome of each operation of a model
+// plan that was attempted, in order, or an error if the plan could not
+// be applied at all.
+type ApplyModelPlanResult struct {
+	Results []ModelPlanOperationResult `json:"results,omitempty"`
+	Error   *Error                     `json:"error,omitempty"`
+}
@@ -264,12 +264,14 @@ type EntitiesPorts struct {
 	Entities []EntityPort `json:"entities"`
 }
 
-// EntityPortRange holds an entity's tag, a protocol and a port range.
+// EntityPortRange holds an entity's tag, a protocol and a port range,
+// and optionally the endpoint the range applies to.
 type EntityPortRange struct {
 	Tag      string `json:"tag"`
 	Protocol string `json:"protocol"`
 	FromPort int    `json:"from-port"`
 	ToPort   int    `json:"to-port"`
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
 // EntitiesPortRanges holds the parameters for making an OpenPorts or
@@ -416,6 +418,32 @@ type SetMachinesAddresses struct {
 	MachineAddresses []MachineAddresses `json:"machine-addresses"`
 }
 
+// ApplicationAddresses holds an application tag and addresses.
+type ApplicationAddresses struct {
+	Tag       string    `json:"tag"`
+	Addresses []Address `json:"addresses"`
+}
+
+// SetApplicationsAddresses holds the parameters for making an
+// API call to update the addresses of application load balancers.
+type SetApplicationsAddresses struct {
+	ApplicationAddresses []ApplicationAddresses `json:"application-addresses"`
+}
+
+// ApplicationLoadBalancerResult holds whether an application is exposed
+// through a load balancer, and the addresses recorded for it.
+type ApplicationLoadBalancerResult struct {
+	Error        *Error    `json:"error,omitempty"`
+	LoadBalanced bool      `json:"load-balanced"`
+	Addresses    []Address `json:"addresses,omitempty"`
+}
+
+// ApplicationLoadBalancerResults holds the results of a
+// FirewallerAPIV6.GetLoadBalancers() API call.
+type ApplicationLoadBalancerResults struct {
+	Results []ApplicationLoadBalancerResult `json:"results"`
+}
+
 // SetMachineNetworkConfig holds the parameters for making an API call to update
 // machine network config.
 type SetMachineNetworkConfig struct {
@@ -437,11 +465,13 @@ type MachineAddressesResults struct {
 }
 
 // MachinePortRange holds a single port range open on a machine for
-// the given unit and relation tags.
+// the given unit and relation tags, and the unit endpoint it is opened
+// for. An empty endpoint means all of the unit's endpoints.
 type MachinePortRange struct {
 	UnitTag     string    `json:"unit-tag"`
 	RelationTag string    `json:"relation-tag"`
 	PortRange   PortRange `json:"port-range"`
+	Endpoint    string    `json:"endpoint,omitempty"`
 }
 
 // MachinePorts holds a machine and subnet tags. It's used when referring to
@@ -666,6 +696,7 @@ type ProxyConfigResult struct {
 	SnapProxySettings        ProxyConfig `json:"snap-proxy-settings,omitempty"`
 	SnapStoreProxyId         string      `json:"snap-store-id,omitempty"`
 	SnapStoreProxyAssertions string      `json:"snap-store-assertions,omitempty"`
+	AptMirror                string      `json:"apt-mirror,omitempty"`
 	Error                    *Error      `json:"error,omitempty"`
 }
 
@@ -751,3 +782,23 @@ type FanConfigEntry struct {
 type FanConfigResult struct {
 	Fans []FanConfigEntry `json:"fans"`
 }
+
+// DNSUnit holds the public address of a unit, for publishing in DNS.
+type DNSUnit struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// DNSApplication holds the public addresses of an application's units,
+// for publishing in DNS.
+type DNSApplication struct {
+	Name  string    `json:"name"`
+	Units []DNSUnit `json:"units,omitempty"`
+}
+
+// DNSApplicationsResult holds the applications in a model, along with
+// the public addresses of their units, or an error.
+type DNSApplicationsResult struct {
+	Applications []DNSApplication `json:"applications,omitempty"`
+	Error        *Error           `json:"error,omitempty"`
+}
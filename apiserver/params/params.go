@@ -160,6 +160,40 @@ type RelationSuspendedArg struct {
 	Suspended  bool   `json:"suspended"`
 }
 
+// RelationDrainTimeoutArgs holds the parameters for setting
+// the drain timeout of one or more relations.
+type RelationDrainTimeoutArgs struct {
+	Args []RelationDrainTimeoutArg `json:"args"`
+}
+
+// RelationDrainTimeoutArg holds the new drain timeout for a relation.
+type RelationDrainTimeoutArg struct {
+	RelationId int           `json:"relation-id"`
+	Timeout    time.Duration `json:"timeout"`
+}
+
+// RelationDataResults holds the results of a RelationData call.
+type RelationDataResults struct {
+	Results []RelationDataResult `json:"results"`
+}
+
+// RelationDataResult holds the relation data of a relation, or an error.
+type RelationDataResult struct {
+	Id           int                       `json:"id"`
+	Key          string                    `json:"key,omitempty"`
+	Applications []RelationApplicationData `json:"applications,omitempty"`
+	Error        *Error                    `json:"error,omitempty"`
+}
+
+// RelationApplicationData holds the relation data of the units of an
+// application in a relation, keyed by unit name.
+type RelationApplicationData struct {
+	Application string              `json:"application"`
+	Endpoint    string              `json:"endpoint"`
+	Role        string              `json:"role"`
+	UnitData    map[string]Settings `json:"unit-data"`
+}
+
 // AddCharm holds the arguments for making an AddCharm API call.
 type AddCharm struct {
 	URL     string `json:"url"`
@@ -214,6 +248,11 @@ type AddMachineParams struct {
 	Nonce                   string                           `json:"nonce"`
 	HardwareCharacteristics instance.HardwareCharacteristics `json:"hardware-characteristics"`
 	Addrs                   []Address                        `json:"addresses"`
+
+	// CloudInitUserData optionally holds cloud-init user data, in
+	// the form accepted by the cloudinit-userdata model config, to
+	// merge with that of the model when provisioning the machine.
+	CloudInitUserData string `json:"cloudinit-userdata,omitempty"`
 }
 
 // AddMachines holds the parameters for making the AddMachines call.
@@ -246,6 +285,11 @@ type DestroyMachinesParams struct {
 	MachineTags []string `json:"machine-tags"`
 	Force       bool     `json:"force,omitempty"`
 	Keep        bool     `json:"keep,omitempty"`
+
+	// MaxWait specifies the amount of time that each step in machine destroy process
+	// will wait before forcing the next step to kick-off. This parameter
+	// only makes sense in combination with 'force' set to 'true'.
+	MaxWait *time.Duration `json:"max-wait,omitempty"`
 }
 
 // UpdateSeriesArg holds the parameters for updating the series for the
@@ -356,6 +400,53 @@ type AddApplicationUnits struct {
 	AttachStorage   []string              `json:"attach-storage,omitempty"`
 }
 
+// AssignUnitsArgs holds parameters for the AssignUnits call.
+type AssignUnitsArgs struct {
+	// Units holds the names of the units to assign.
+	Units []string `json:"units"`
+
+	// Policy is the placement policy, either "pack" or "spread".
+	// If empty, "pack" is used.
+	Policy string `json:"policy,omitempty"`
+
+	// Zones holds the availability zones across which the "spread"
+	// policy distributes new machines. If empty, the zones known to
+	// the model's provider are used.
+	Zones []string `json:"zones,omitempty"`
+}
+
+// AssignUnitsResults holds the results of the AssignUnits call.
+type AssignUnitsResults struct {
+	Results []AssignUnitResult `json:"results"`
+}
+
+// AssignUnitResult holds the machine a unit was assigned to by the
+// AssignUnits call.
+type AssignUnitResult struct {
+	Unit       string `json:"unit"`
+	Machine    string `json:"machine,omitempty"`
+	Zone       string `json:"zone,omitempty"`
+	NewMachine bool   `json:"new-machine,omitempty"`
+	Error      *Error `json:"error,omitempty"`
+}
+
+// ColocationRule describes whether the units of two applications may
+// be placed on the same machines.
+type ColocationRule struct {
+	// Applications holds the names of the two applications.
+	Applications []string `json:"applications"`
+
+	// Rule is "affinity" if the applications' units may share
+	// machines, or "anti-affinity" if they must not. When setting
+	// rules, an empty Rule removes the rule for the applications.
+	Rule string `json:"rule,omitempty"`
+}
+
+// ColocationRules holds a set of co-location rules.
+type ColocationRules struct {
+	Rules []ColocationRule `json:"rules"`
+}
+
 // AddApplicationUnitsV5 holds parameters for the AddUnits call.
 // V5 is missing the new policy arg.
 type AddApplicationUnitsV5 struct {
@@ -416,6 +507,11 @@ type DestroyUnitParams struct {
 	// will be forced, i.e. ignore operational errors.
 	Force bool
 
+	// MaxWait specifies the amount of time that each step in unit removal
+	// will wait before forcing the next step to kick-off. This parameter
+	// only makes sense in combination with 'force' set to 'true'.
+	MaxWait *time.Duration `json:"max-wait,omitempty"`
+
 	// Errors contains errors encountered while applying this operation.
 	// Generally, these are non-fatal errors that have been encountered
 	// during, say, force. They may not have prevented the operation from being
@@ -444,6 +540,16 @@ type LoginRequest struct {
 	Macaroons   []macaroon.Slice `json:"macaroons"`
 	CLIArgs     string           `json:"cli-args,omitempty"`
 	UserData    string           `json:"user-data"`
+
+	// Token holds an OpenID Connect ID token, for users logging in
+	// with an external identity provider.
+	Token string `json:"token,omitempty"`
+
+	// ReadOnly, if true, requests a read-only connection for a user,
+	// whose queries and watchers are served from secondary members of
+	// the controller's replica set where possible, without waiting
+	// for them to catch up with the primary.
+	ReadOnly bool `json:"read-only,omitempty"`
 }
 
 // LoginRequestCompat holds credentials for identifying an entity to the Login v1
@@ -846,12 +952,13 @@ type RebootActionResult struct {
 // endpoint.  Single character field names are used for serialisation
 // to keep the size down. These messages are going to be sent a lot.
 type LogRecord struct {
-	Time     time.Time `json:"t"`
-	Module   string    `json:"m"`
-	Location string    `json:"l"`
-	Level    string    `json:"v"`
-	Message  string    `json:"x"`
-	Entity   string    `json:"e,omitempty"`
+	Time     time.Time         `json:"t"`
+	Module   string            `json:"m"`
+	Location string            `json:"l"`
+	Level    string            `json:"v"`
+	Message  string            `json:"x"`
+	Entity   string            `json:"e,omitempty"`
+	Labels   map[string]string `json:"lb,omitempty"`
 }
 
 // PubSubMessage is used to propagate pubsub messages from one api server to the
@@ -878,6 +985,13 @@ type BundleChangesResults struct {
 	Errors []string `json:"errors,omitempty"`
 }
 
+// BundlePlacementResults holds results of the Bundle.CheckPlacements call.
+type BundlePlacementResults struct {
+	// Errors holds a description of each placement, constraint or
+	// binding in the bundle that cannot be satisfied by the model.
+	Errors []string `json:"errors,omitempty"`
+}
+
 // BundleChange holds a single change required to deploy a bundle.
 type BundleChange struct {
 	// Id is the unique identifier for this change.
@@ -927,6 +1041,39 @@ type ResumeReplicationParams struct {
 	Members []replicaset.Member `json:"members"`
 }
 
+// MongoUpgradeCheckResult holds the result of checking whether the
+// controller's database can be migrated to a new storage engine.
+type MongoUpgradeCheckResult struct {
+	Current           MongoVersion  `json:"current"`
+	Target            MongoVersion  `json:"target"`
+	DataSize          int64         `json:"data-size"`
+	Members           int           `json:"members"`
+	EstimatedDuration time.Duration `json:"estimated-duration"`
+	EstimatedDowntime time.Duration `json:"estimated-downtime"`
+	Problems          []string      `json:"problems,omitempty"`
+}
+
+// MongoUpgradeMemberStatus holds the progress of a controller
+// migrating its database to a new storage engine.
+type MongoUpgradeMemberStatus struct {
+	MachineId string    `json:"machine-id"`
+	Phase     string    `json:"phase"`
+	Message   string    `json:"message,omitempty"`
+	Updated   time.Time `json:"updated"`
+}
+
+// MongoUpgradeStatusResult holds the progress of a migration of the
+// controller's database to a new storage engine.
+type MongoUpgradeStatusResult struct {
+	Target             MongoVersion               `json:"target"`
+	Started            time.Time                  `json:"started"`
+	DataSize           int64                      `json:"data-size"`
+	EstimatedDuration  time.Duration              `json:"estimated-duration"`
+	EstimatedDowntime  time.Duration              `json:"estimated-downtime"`
+	EstimatedRemaining time.Duration              `json:"estimated-remaining"`
+	Members            []MongoUpgradeMemberStatus `json:"members"`
+}
+
 // MeterStatusParam holds meter status information to be set for the specified tag.
 type MeterStatusParam struct {
 	Tag  string `json:"tag"`
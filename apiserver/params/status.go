@@ -122,6 +122,13 @@ type MachineStatus struct {
 	// LXDProfiles holds all the machines current LXD profiles that have
 	// been applied to the machine
 	LXDProfiles map[string]LXDProfile `json:"lxd-profiles,omitempty"`
+
+	// NetworkConfigDrift holds the differences last found between the
+	// network config observed on the machine and the one recorded for it.
+	NetworkConfigDrift []string `json:"network-config-drift,omitempty"`
+
+	// Idle describes why the machine is considered idle, if it is.
+	Idle string `json:"idle,omitempty"`
 }
 
 // LXDProfile holds status info about a LXDProfile
@@ -149,6 +156,10 @@ type ApplicationStatus struct {
 	CharmProfile     string                 `json:"charm-profile"`
 	EndpointBindings map[string]string      `json:"endpoint-bindings"`
 
+	// LoadBalancerAddresses holds the addresses of the application's
+	// load balancer, if it is exposed through one.
+	LoadBalancerAddresses []string `json:"load-balancer-addresses,omitempty"`
+
 	// The following are for CAAS models.
 	Scale         int    `json:"int,omitempty"`
 	ProviderId    string `json:"provider-id,omitempty"`
@@ -198,6 +209,7 @@ type UnitStatus struct {
 	Charm         string                `json:"charm"`
 	Subordinates  map[string]UnitStatus `json:"subordinates"`
 	Leader        bool                  `json:"leader,omitempty"`
+	Idle          string                `json:"idle,omitempty"`
 
 	// The following are for CAAS models.
 	ProviderId string `json:"provider-id,omitempty"`
@@ -285,6 +297,38 @@ type StatusHistoryPruneArgs struct {
 	MaxHistoryMB   int           `json:"max-history-mb"`
 }
 
+// TimelineArgs holds the parameters to filter a timeline of events.
+// Entity is the tag of a unit, machine or application; the timeline
+// of the whole model is returned when it is empty. Kinds restricts the
+// events returned to those of the given kinds. Archived includes the
+// status history archived when it was pruned.
+type TimelineArgs struct {
+	Entity   string     `json:"entity,omitempty"`
+	Kinds    []string   `json:"kinds,omitempty"`
+	Since    *time.Time `json:"since,omitempty"`
+	Until    *time.Time `json:"until,omitempty"`
+	Size     int        `json:"size,omitempty"`
+	Archived bool       `json:"archived,omitempty"`
+}
+
+// TimelineEvent describes a single event in a timeline. Kind is one
+// of "status", "agent", "hook", "action" or "cleanup".
+type TimelineEvent struct {
+	Time    time.Time              `json:"time"`
+	Kind    string                 `json:"kind"`
+	Entity  string                 `json:"entity"`
+	Status  string                 `json:"status,omitempty"`
+	Message string                 `json:"message,omitempty"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
+
+// TimelineResult holds the events of a timeline, oldest first, or an
+// error.
+type TimelineResult struct {
+	Events []TimelineEvent `json:"events,omitempty"`
+	Error  *Error          `json:"error,omitempty"`
+}
+
 // StatusResult holds an entity status, extra information, or an
 // error.
 type StatusResult struct {
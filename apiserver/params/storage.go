@@ -420,13 +420,14 @@ type RemoveFilesystemParams struct {
 // FilesystemAttachmentParams holds the parameters for creating a filesystem
 // attachment.
 type FilesystemAttachmentParams struct {
-	FilesystemTag string `json:"filesystem-tag"`
-	MachineTag    string `json:"machine-tag"`
-	FilesystemId  string `json:"filesystem-id,omitempty"`
-	InstanceId    string `json:"instance-id,omitempty"`
-	Provider      string `json:"provider"`
-	MountPoint    string `json:"mount-point,omitempty"`
-	ReadOnly      bool   `json:"read-only,omitempty"`
+	FilesystemTag string                 `json:"filesystem-tag"`
+	MachineTag    string                 `json:"machine-tag"`
+	FilesystemId  string                 `json:"filesystem-id,omitempty"`
+	InstanceId    string                 `json:"instance-id,omitempty"`
+	Provider      string                 `json:"provider"`
+	MountPoint    string                 `json:"mount-point,omitempty"`
+	ReadOnly      bool                   `json:"read-only,omitempty"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
 }
 
 // FilesystemAttachmentResult holds the details of a single filesystem attachment,
@@ -4,8 +4,11 @@
 package apiserver
 
 import (
+	"crypto/sha512"
+	"encoding/hex"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 
 	"github.com/juju/errors"
@@ -48,19 +51,69 @@ func (h *UnitResourcesHandler) ServeHTTP(resp http.ResponseWriter, req *http.Req
 		}
 		defer opened.Close()
 
-		hdr := resp.Header()
-		hdr.Set("Content-Type", params.ContentTypeRaw)
-		hdr.Set("Content-Length", fmt.Sprint(opened.Size))
-		hdr.Set("Content-Sha384", opened.Fingerprint.String())
-
-		resp.WriteHeader(http.StatusOK)
-		if _, err := io.Copy(resp, opened); err != nil {
-			// We cannot use SendHTTPError here, so we log the error
-			// and move on.
-			logger.Errorf("unable to complete stream for resource: %v", err)
+		start, end, ranged, err := api.ExtractDownloadRange(req, opened.Size)
+		if err != nil {
+			api.SendHTTPError(resp, err)
+			return
+		}
+		if !ranged {
+			sendResource(resp, opened)
 			return
 		}
+		sendResourceRange(resp, opened, start, end)
 	default:
 		api.SendHTTPError(resp, errors.MethodNotAllowedf("unsupported method: %q", req.Method))
 	}
 }
+
+func sendResource(resp http.ResponseWriter, opened resource.Opened) {
+	hdr := resp.Header()
+	hdr.Set("Content-Type", params.ContentTypeRaw)
+	hdr.Set("Content-Length", fmt.Sprint(opened.Size))
+	hdr.Set("Content-Sha384", opened.Fingerprint.String())
+
+	resp.WriteHeader(http.StatusOK)
+	if _, err := io.Copy(resp, opened); err != nil {
+		// We cannot use SendHTTPError here, so we log the error
+		// and move on.
+		logger.Errorf("unable to complete stream for resource: %v", err)
+		return
+	}
+}
+
+// sendResourceRange sends the bytes of the resource from start to end,
+// inclusive, followed by a trailer holding their hash so that the unit
+// can verify each chunk of the resource as it arrives.
+func sendResourceRange(resp http.ResponseWriter, opened resource.Opened, start, end int64) {
+	if err := skipResourceBytes(opened.ReadCloser, start); err != nil {
+		api.SendHTTPError(resp, errors.Annotate(err, "seeking resource"))
+		return
+	}
+
+	hdr := resp.Header()
+	hdr.Set("Content-Type", params.ContentTypeRaw)
+	hdr.Set("Content-Length", fmt.Sprint(end-start+1))
+	hdr.Set("Content-Sha384", opened.Fingerprint.String())
+	hdr.Set(api.HeaderContentRange, fmt.Sprintf("bytes %d-%d/%d", start, end, opened.Size))
+	hdr.Set("Trailer", api.HeaderChunkSha384)
+
+	resp.WriteHeader(http.StatusPartialContent)
+	hasher := sha512.New384()
+	if _, err := io.CopyN(io.MultiWriter(resp, hasher), opened, end-start+1); err != nil {
+		logger.Errorf("unable to complete stream for resource: %v", err)
+		return
+	}
+	hdr.Set(api.HeaderChunkSha384, hex.EncodeToString(hasher.Sum(nil)))
+}
+
+// skipResourceBytes moves the reader past the first n bytes of the
+// resource, seeking rather than reading them where it can.
+func skipResourceBytes(r io.Reader, n int64) error {
+	if seeker, ok := r.(io.Seeker); ok {
+		if _, err := seeker.Seek(n, io.SeekStart); err == nil {
+			return nil
+		}
+	}
+	_, err := io.CopyN(ioutil.Discard, r, n)
+	return errors.Trace(err)
+}
@@ -4,6 +4,8 @@
 package apiserver_test
 
 import (
+	"crypto/sha512"
+	"encoding/hex"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -132,6 +134,53 @@ func (s *UnitResourcesHandlerSuite) TestSuccess(c *gc.C) {
 		{"Close", nil},
 	})
 }
+
+func (s *UnitResourcesHandlerSuite) TestSuccessRange(c *gc.C) {
+	const body = "some data"
+	opened := resourcetesting.NewResource(c, new(testing.Stub), "blob", "app", body)
+	opener := &stubResourceOpener{
+		Stub:               s.stub,
+		ReturnOpenResource: opened,
+	}
+	handler := &apiserver.UnitResourcesHandler{
+		NewOpener: func(_ *http.Request, kinds ...string) (resource.Opener, state.PoolHelper, error) {
+			return opener, apiservertesting.StubPoolHelper{StubRelease: s.closer}, nil
+		},
+	}
+
+	req, err := http.NewRequest("GET", s.urlStr, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	req.Header.Set("Range", "bytes=5-8")
+
+	handler.ServeHTTP(s.recorder, req)
+
+	s.checkResp(c, http.StatusPartialContent, "application/octet-stream", "data")
+	c.Check(s.recorder.Header().Get("Content-Range"), gc.Equals, "bytes 5-8/9")
+	chunkHash := sha512.Sum384([]byte("data"))
+	c.Check(s.recorder.Result().Trailer.Get("Chunk-Sha384"), gc.Equals, hex.EncodeToString(chunkHash[:]))
+}
+
+func (s *UnitResourcesHandlerSuite) TestInvalidRange(c *gc.C) {
+	opened := resourcetesting.NewResource(c, new(testing.Stub), "blob", "app", "some data")
+	opener := &stubResourceOpener{
+		Stub:               s.stub,
+		ReturnOpenResource: opened,
+	}
+	handler := &apiserver.UnitResourcesHandler{
+		NewOpener: func(_ *http.Request, kinds ...string) (resource.Opener, state.PoolHelper, error) {
+			return opener, apiservertesting.StubPoolHelper{StubRelease: s.closer}, nil
+		},
+	}
+
+	req, err := http.NewRequest("GET", s.urlStr, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	req.Header.Set("Range", "bytes=20-30")
+
+	handler.ServeHTTP(s.recorder, req)
+
+	c.Assert(s.recorder.Code, gc.Equals, http.StatusBadRequest)
+}
+
 func (s *UnitResourcesHandlerSuite) checkResp(c *gc.C, status int, ctype, body string) {
 	checkHTTPResp(c, s.recorder, status, ctype, body)
 }
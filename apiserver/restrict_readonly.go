@@ -0,0 +1,124 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/observer"
+	"github.com/juju/juju/state"
+)
+
+// readOnlyMethodsOnly can be used with restrictRoot to restrict the
+// API to the methods allowed for a read-only login: the fixed list of
+// read-only methods, and watchers.
+func readOnlyMethodsOnly(facadeName, methodName string) error {
+	if observer.IsReadOnlyMethod(facadeName, methodName) || isWatchMethod(facadeName, methodName) {
+		return nil
+	}
+	return errors.NewNotSupported(nil, fmt.Sprintf("method %s.%s not supported for read-only API connection", facadeName, methodName))
+}
+
+func isWatchMethod(facadeName, methodName string) bool {
+	return strings.HasSuffix(facadeName, "Watcher") || strings.HasPrefix(methodName, "Watch")
+}
+
+// servedFromReplica reports whether calls to the given method made by
+// a user are served from the secondaries of the controller's replica
+// set: the fixed list of read-only methods, and the methods starting
+// watchers, which are notified of a change only once the secondaries
+// have it. Calls on the watcher facades only use the watchers already
+// started, and Pinger.Ping reads nothing, so they're served as usual.
+func servedFromReplica(facadeName, methodName string) bool {
+	if facadeName == "Pinger" || strings.HasSuffix(facadeName, "Watcher") {
+		return false
+	}
+	return observer.IsReadOnlyMethod(facadeName, methodName) || strings.HasPrefix(methodName, "Watch")
+}
+
+// readReplica holds the States a connection reads from when serving
+// calls to the methods servedFromReplica allows.
+type readReplica struct {
+	primary   *state.State
+	pool      *state.StatePool
+	resources *common.Resources
+
+	// consistent is set if each call waits for the secondaries to
+	// catch up with the primary, so that callers always see their
+	// own changes; read-only logins ask for the replica, and accept
+	// that it may lag behind.
+	consistent bool
+
+	mu sync.Mutex
+	st *state.State
+}
+
+func newReadReplica(st *state.State, pool *state.StatePool, resources *common.Resources, consistent bool) *readReplica {
+	return &readReplica{
+		primary:    st,
+		pool:       pool.ReadOnlyReplica(),
+		resources:  resources,
+		consistent: consistent,
+	}
+}
+
+// open opens the replica of the connection's State, if it isn't
+// already open.
+func (r *readReplica) open() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.st != nil {
+		return nil
+	}
+	st, err := readOnlyReplica(r.primary, r.resources)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	r.st = st
+	return nil
+}
+
+// state returns the replica of the connection's State, which must
+// have been opened.
+func (r *readReplica) state() *state.State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.st
+}
+
+// wait waits for the secondaries to catch up with the primary.
+func (r *readReplica) wait() {
+	if st := r.state(); st != nil {
+		st.WaitForSecondaries()
+	}
+}
+
+// replicaResource closes a State reading from secondaries when the
+// connection using it is closed.
+type replicaResource struct {
+	st *state.State
+}
+
+// Stop is part of the facade.Resource interface.
+func (r replicaResource) Stop() error {
+	return r.st.Close()
+}
+
+// readOnlyReplica returns a State reading from the secondaries of the
+// controller's replica set, which is closed with the given resources.
+// It is opened before any watcher reading from it is started, so it
+// is closed after them.
+func readOnlyReplica(st *state.State, resources *common.Resources) (*state.State, error) {
+	replica, err := st.ReadOnlyReplica()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resources.Register(replicaResource{replica})
+	return replica, nil
+}
@@ -0,0 +1,161 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+)
+
+type restrictReadOnlySuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&restrictReadOnlySuite{})
+
+func (r *restrictReadOnlySuite) TestAllowed(c *gc.C) {
+	root := apiserver.TestingReadOnlyRoot()
+	for _, call := range []struct {
+		facade  string
+		version int
+		method  string
+	}{
+		{"Client", 2, "FullStatus"},
+		{"Pinger", 1, "Ping"},
+	} {
+		c.Logf("%s.%s", call.facade, call.method)
+		caller, err := root.FindMethod(call.facade, call.version, call.method)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(caller, gc.NotNil)
+	}
+}
+
+func (r *restrictReadOnlySuite) TestNotAllowed(c *gc.C) {
+	root := apiserver.TestingReadOnlyRoot()
+	caller, err := root.FindMethod("Application", 8, "Deploy")
+	c.Assert(err, gc.ErrorMatches, "method Application.Deploy not supported for read-only API connection")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(caller, gc.IsNil)
+}
+
+func (r *restrictReadOnlySuite) TestWatchersAllowed(c *gc.C) {
+	root := apiserver.TestingReadOnlyRoot()
+	for _, call := range []struct {
+		facade  string
+		version int
+		method  string
+	}{
+		{"Client", 2, "WatchAll"},
+		{"AllWatcher", 1, "Next"},
+		{"AllWatcher", 1, "Stop"},
+	} {
+		c.Logf("%s.%s", call.facade, call.method)
+		caller, err := root.FindMethod(call.facade, call.version, call.method)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(caller, gc.NotNil)
+	}
+}
+
+func (r *restrictReadOnlySuite) TestServedFromReplica(c *gc.C) {
+	for _, call := range []struct {
+		facade  string
+		method  string
+		replica bool
+	}{
+		{"Client", "FullStatus", true},
+		{"Controller", "AllModels", true},
+		{"ModelManager", "ListModels", true},
+		{"Client", "WatchAll", true},
+		{"ModelManager", "WatchModelSummaries", true},
+		{"Application", "Deploy", false},
+		{"Client", "ModelSet", false},
+		{"AllWatcher", "Next", false},
+		{"Pinger", "Ping", false},
+	} {
+		c.Check(apiserver.ServedFromReplica(call.facade, call.method), gc.Equals, call.replica,
+			gc.Commentf("%s.%s", call.facade, call.method))
+	}
+}
+
+type replicaRootSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&replicaRootSuite{})
+
+type stateRecorder struct {
+	st   *state.State
+	pool *state.StatePool
+}
+
+func (r *stateRecorder) WatchThings() error  { return nil }
+func (r *stateRecorder) ChangeThings() error { return nil }
+
+func (s *replicaRootSuite) TestFacadesForReadOnlyMethodsReadFromReplica(c *gc.C) {
+	registry := new(facade.Registry)
+	var recorders []*stateRecorder
+	registry.Register("Things", 1, func(ctx facade.Context) (facade.Facade, error) {
+		r := &stateRecorder{st: ctx.State(), pool: ctx.StatePool()}
+		recorders = append(recorders, r)
+		return r, nil
+	}, reflect.TypeOf((*stateRecorder)(nil)))
+	root := apiserver.TestingReplicaRoot(registry, s.StatePool, s.State, true)
+	defer root.Kill()
+
+	for _, method := range []string{"WatchThings", "ChangeThings"} {
+		caller, err := root.FindMethod("Things", 1, method)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = caller.Call(context.TODO(), "", reflect.Value{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(recorders, gc.HasLen, 2)
+
+	// Watchers are started from a replica of the State.
+	replica := recorders[0]
+	c.Check(replica.st, gc.Not(gc.Equals), s.State)
+	c.Check(replica.st.ModelUUID(), gc.Equals, s.State.ModelUUID())
+	c.Check(replica.pool, gc.Not(gc.Equals), s.StatePool)
+	ps, err := replica.pool.Get(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ps.State, gc.Not(gc.Equals), s.State)
+	ps.Release()
+
+	// Everything else is served from the State itself.
+	primary := recorders[1]
+	c.Check(primary.st, gc.Equals, s.State)
+	c.Check(primary.pool, gc.Equals, s.StatePool)
+}
+
+func (s *replicaRootSuite) TestReplicaSeesEarlierChanges(c *gc.C) {
+	registry := new(facade.Registry)
+	var replica *state.State
+	registry.Register("Things", 1, func(ctx facade.Context) (facade.Facade, error) {
+		replica = ctx.State()
+		return &stateRecorder{}, nil
+	}, reflect.TypeOf((*stateRecorder)(nil)))
+	root := apiserver.TestingReplicaRoot(registry, s.StatePool, s.State, true)
+	defer root.Kill()
+
+	caller, err := root.FindMethod("Things", 1, "WatchThings")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = caller.Call(context.TODO(), "", reflect.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = caller.Call(context.TODO(), "", reflect.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = replica.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -8,12 +8,14 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/apiserver/apipolicy"
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
@@ -39,6 +41,10 @@ type objectKey struct {
 	name    string
 	version int
 	objId   string
+
+	// replica is set for facades reading from the secondaries of
+	// the controller's replica set.
+	replica bool
 }
 
 // apiHandler represents a single client's connection to the state
@@ -65,12 +71,15 @@ type apiHandler struct {
 	// serverHost is the host:port of the API server that the client
 	// connected to.
 	serverHost string
+
+	// remoteAddr is the address the client connected from.
+	remoteAddr string
 }
 
 var _ = (*apiHandler)(nil)
 
 // newAPIHandler returns a new apiHandler.
-func newAPIHandler(srv *Server, st *state.State, rpcConn *rpc.Conn, modelUUID string, connectionID uint64, serverHost string) (*apiHandler, error) {
+func newAPIHandler(srv *Server, st *state.State, rpcConn *rpc.Conn, modelUUID string, connectionID uint64, serverHost, remoteAddr string) (*apiHandler, error) {
 	m, err := st.Model()
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -84,6 +93,7 @@ func newAPIHandler(srv *Server, st *state.State, rpcConn *rpc.Conn, modelUUID st
 		modelUUID:    modelUUID,
 		connectionID: connectionID,
 		serverHost:   serverHost,
+		remoteAddr:   remoteAddr,
 	}
 
 	if err := r.resources.RegisterNamed("machineID", common.StringResource(srv.tag.Id())); err != nil {
@@ -135,6 +145,14 @@ type srvCaller struct {
 	objMethod rpcreflect.ObjMethod
 	goType    reflect.Type
 	creator   func(id string) (reflect.Value, error)
+
+	// checkPolicy, if non-nil, is called before the method is
+	// called, and prevents the call if it returns an error.
+	checkPolicy func(ctx context.Context, objId string) error
+
+	// waitForReplica, if non-nil, is called before the method is
+	// called, to let the secondaries it reads from catch up.
+	waitForReplica func()
 }
 
 // ParamsType defines the parameters that should be supplied to this function.
@@ -152,10 +170,18 @@ func (s *srvCaller) ResultType() reflect.Type {
 // Call takes the object Id and an instance of ParamsType to create an object and place
 // a call on its method. It then returns an instance of ResultType.
 func (s *srvCaller) Call(ctx context.Context, objId string, arg reflect.Value) (reflect.Value, error) {
+	if s.checkPolicy != nil {
+		if err := s.checkPolicy(ctx, objId); err != nil {
+			return reflect.Value{}, err
+		}
+	}
 	objVal, err := s.creator(objId)
 	if err != nil {
 		return reflect.Value{}, err
 	}
+	if s.waitForReplica != nil {
+		s.waitForReplica()
+	}
 	return s.objMethod.Call(ctx, objVal, arg)
 }
 
@@ -168,6 +194,10 @@ type apiRoot struct {
 	authorizer  facade.Authorizer
 	objectMutex sync.RWMutex
 	objectCache map[objectKey]reflect.Value
+
+	// replica, if set, serves the calls to the methods
+	// servedFromReplica allows.
+	replica *readReplica
 }
 
 // newAPIRoot returns a new apiRoot.
@@ -290,8 +320,9 @@ func (r *apiRoot) FindMethod(rootName string, version int, methodName string) (r
 		return nil, err
 	}
 
+	replica := r.replica != nil && servedFromReplica(rootName, methodName)
 	creator := func(id string) (reflect.Value, error) {
-		objKey := objectKey{name: rootName, version: version, objId: id}
+		objKey := objectKey{name: rootName, version: version, objId: id, replica: replica}
 		r.objectMutex.RLock()
 		objValue, ok := r.objectCache[objKey]
 		r.objectMutex.RUnlock()
@@ -312,6 +343,11 @@ func (r *apiRoot) FindMethod(rootName string, version int, methodName string) (r
 			// check.
 			return reflect.Value{}, err
 		}
+		if replica {
+			if err := r.replica.open(); err != nil {
+				return reflect.Value{}, errors.Trace(err)
+			}
+		}
 		obj, err := factory(r.facadeContext(objKey))
 		if err != nil {
 			return reflect.Value{}, err
@@ -335,10 +371,62 @@ func (r *apiRoot) FindMethod(rootName string, version int, methodName string) (r
 		r.objectCache[objKey] = objValue
 		return objValue, nil
 	}
-	return &srvCaller{
+	caller := &srvCaller{
 		creator:   creator,
 		objMethod: objMethod,
-	}, nil
+	}
+	if replica && r.replica.consistent {
+		caller.waitForReplica = r.replica.wait
+	}
+	if r.policyApplies(rootName) {
+		caller.checkPolicy = func(ctx context.Context, objId string) error {
+			return r.checkPolicy(ctx, apipolicy.Request{
+				Facade:   rootName,
+				Version:  version,
+				ObjectID: objId,
+				Method:   methodName,
+			})
+		}
+	}
+	return caller, nil
+}
+
+// policyApplies reports whether calls to the given facade are subject
+// to the controller's API policy. Only calls made by users are; agents
+// must keep working whatever the state of the policy endpoint, and
+// pinging and watching are needed to keep any connection alive.
+func (r *apiRoot) policyApplies(rootName string) bool {
+	if r.shared == nil || r.authorizer == nil {
+		return false
+	}
+	if _, ok := r.authorizer.GetAuthTag().(names.UserTag); !ok {
+		return false
+	}
+	return rootName != "Pinger" && !strings.HasSuffix(rootName, "Watcher")
+}
+
+// checkPolicy consults the controller's API policy about the given
+// call, returning common.ErrPerm if the call is not allowed. If the
+// policy endpoint cannot be consulted, the call is refused unless the
+// controller is configured to fail open.
+func (r *apiRoot) checkPolicy(ctx context.Context, req apipolicy.Request) error {
+	req.Entity = r.authorizer.GetAuthTag()
+	req.ModelUUID = r.state.ModelUUID()
+	checker, failOpen := r.shared.apiPolicy()
+	allowed, err := checker.Allowed(ctx, req)
+	if err != nil {
+		if failOpen {
+			logger.Warningf("checking API policy for %s(%d).%s, allowing call: %v", req.Facade, req.Version, req.Method, err)
+			return nil
+		}
+		logger.Errorf("checking API policy for %s(%d).%s: %v", req.Facade, req.Version, req.Method, err)
+		return common.ErrPerm
+	}
+	if !allowed {
+		logger.Debugf("API policy denied %s(%d).%s for %s", req.Facade, req.Version, req.Method, names.ReadableString(req.Entity))
+		return common.ErrPerm
+	}
+	return nil
 }
 
 func (r *apiRoot) lookupMethod(rootName string, version int, methodName string) (reflect.Type, rpcreflect.ObjMethod, error) {
@@ -428,11 +516,17 @@ func (ctx *facadeContext) Controller() *cache.Controller {
 
 // State is part of of the facade.Context interface.
 func (ctx *facadeContext) State() *state.State {
+	if ctx.key.replica {
+		return ctx.r.replica.state()
+	}
 	return ctx.r.state
 }
 
 // StatePool is part of of the facade.Context interface.
 func (ctx *facadeContext) StatePool() *state.StatePool {
+	if ctx.key.replica {
+		return ctx.r.replica.pool
+	}
 	return ctx.r.shared.statePool
 }
 
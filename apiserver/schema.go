@@ -0,0 +1,46 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/apischema"
+)
+
+var (
+	schemasOnce sync.Once
+	schemas     []apischema.FacadeSchema
+)
+
+// facadeSchemas returns the schemas of all the facades served by the
+// controller. They never change while the controller is running, so
+// they are only generated once.
+func facadeSchemas() []apischema.FacadeSchema {
+	schemasOnce.Do(func() {
+		schemas = apischema.Generate(AllFacades())
+	})
+	return schemas
+}
+
+// schemaHandler serves the schemas of the controller's facades, as
+// JSON, so that clients can be generated from and checked against the
+// running controller.
+type schemaHandler struct{}
+
+// ServeHTTP implements http.Handler.
+func (schemaHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != "GET" {
+		if err := sendError(w, errors.MethodNotAllowedf("unsupported method: %q", req.Method)); err != nil {
+			logger.Errorf("%v", err)
+		}
+		return
+	}
+	if err := sendStatusAndJSON(w, http.StatusOK, facadeSchemas()); err != nil {
+		logger.Errorf("%v", err)
+	}
+}
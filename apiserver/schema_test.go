@@ -0,0 +1,60 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/apischema"
+	"github.com/juju/juju/apiserver/params"
+	apitesting "github.com/juju/juju/apiserver/testing"
+)
+
+type schemaSuite struct {
+	apiserverBaseSuite
+	schemaURL string
+}
+
+var _ = gc.Suite(&schemaSuite{})
+
+func (s *schemaSuite) SetUpTest(c *gc.C) {
+	s.apiserverBaseSuite.SetUpTest(c)
+	s.schemaURL = s.URL("/schema", nil).String()
+}
+
+func (s *schemaSuite) TestSchema(c *gc.C) {
+	resp := s.sendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.schemaURL,
+	})
+	body := apitesting.AssertResponse(c, resp, http.StatusOK, params.ContentTypeJSON)
+	var schemas []apischema.FacadeSchema
+	err := json.Unmarshal(body, &schemas)
+	c.Assert(err, jc.ErrorIsNil, gc.Commentf("Body: %s", body))
+
+	var found bool
+	for _, schema := range schemas {
+		if schema.Name == "Pinger" {
+			found = true
+			c.Check(schema.Methods, jc.DeepEquals, map[string]apischema.MethodSchema{
+				"Ping": {},
+				"Stop": {},
+			})
+		}
+	}
+	c.Assert(found, jc.IsTrue)
+}
+
+func (s *schemaSuite) TestSchemaUnauthorized(c *gc.C) {
+	resp := apitesting.SendHTTPRequest(c, apitesting.HTTPRequestParams{
+		Method: "GET",
+		URL:    s.schemaURL,
+	})
+	body := apitesting.AssertResponse(c, resp, http.StatusUnauthorized, "text/plain; charset=utf-8")
+	c.Assert(string(body), gc.Equals, "authentication failed: no credentials provided\n")
+}
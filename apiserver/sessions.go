@@ -0,0 +1,108 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"io"
+	"sync"
+
+	"github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/state"
+)
+
+// sessionStore records the API connections that have logged in to
+// each of the controller's API servers, so that they can be listed
+// by any of them.
+type sessionStore interface {
+	AddAPISession(state.APISession) error
+	RemoveAPISession(server string, connectionID uint64) error
+}
+
+// sessionRegistry records the API connections to the API server that
+// have logged in, so that they can be listed and closed.
+type sessionRegistry struct {
+	server string
+	store  sessionStore
+
+	mu       sync.Mutex
+	sessions map[uint64]registeredSession
+}
+
+type registeredSession struct {
+	state.APISession
+	conn io.Closer
+}
+
+// newSessionRegistry returns a registry for the API server with the
+// given machine ID, recording the sessions in the given store.
+func newSessionRegistry(server string, store sessionStore) *sessionRegistry {
+	return &sessionRegistry{
+		server:   server,
+		store:    store,
+		sessions: make(map[uint64]registeredSession),
+	}
+}
+
+// add records the session, which is closed with conn.
+func (r *sessionRegistry) add(session state.APISession, conn io.Closer) {
+	session.Server = r.server
+	r.mu.Lock()
+	r.sessions[session.ConnectionID] = registeredSession{
+		APISession: session,
+		conn:       conn,
+	}
+	r.mu.Unlock()
+
+	// Failing to record the session only means that it isn't
+	// listed, so it mustn't fail the login.
+	if err := r.store.AddAPISession(session); err != nil {
+		logger.Warningf("recording API connection %d: %v", session.ConnectionID, err)
+	}
+}
+
+// remove forgets the session with the given connection ID, if there
+// is one.
+func (r *sessionRegistry) remove(connectionID uint64) {
+	r.mu.Lock()
+	_, ok := r.sessions[connectionID]
+	delete(r.sessions, connectionID)
+	r.mu.Unlock()
+
+	if !ok {
+		return
+	}
+	if err := r.store.RemoveAPISession(r.server, connectionID); err != nil {
+		logger.Warningf("removing API connection %d: %v", connectionID, err)
+	}
+}
+
+// close closes the sessions identified by the message that are
+// connected to this API server.
+func (r *sessionRegistry) close(msg apiserver.CloseSessions) {
+	var toClose []registeredSession
+	r.mu.Lock()
+	if msg.Server == r.server {
+		for _, id := range msg.ConnectionIDs {
+			if session, ok := r.sessions[id]; ok {
+				toClose = append(toClose, session)
+			}
+		}
+	}
+	if msg.User != "" {
+		for _, session := range r.sessions {
+			if session.Tag == msg.User {
+				toClose = append(toClose, session)
+			}
+		}
+	}
+	r.mu.Unlock()
+
+	// The sessions are removed when their connections finish closing.
+	for _, session := range toClose {
+		logger.Infof("closing API connection %d for %s", session.ConnectionID, session.Tag)
+		if err := session.conn.Close(); err != nil {
+			logger.Warningf("closing API connection %d: %v", session.ConnectionID, err)
+		}
+	}
+}
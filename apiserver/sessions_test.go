@@ -0,0 +1,108 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/pubsub/apiserver"
+	"github.com/juju/juju/state"
+)
+
+type sessionRegistrySuite struct {
+	store    *fakeSessionStore
+	registry *sessionRegistry
+	conns    map[uint64]*fakeSessionConn
+}
+
+var _ = gc.Suite(&sessionRegistrySuite{})
+
+type fakeSessionConn struct {
+	closed bool
+}
+
+func (c *fakeSessionConn) Close() error {
+	c.closed = true
+	return nil
+}
+
+type fakeSessionStore struct {
+	sessions map[uint64]state.APISession
+}
+
+func (s *fakeSessionStore) AddAPISession(session state.APISession) error {
+	s.sessions[session.ConnectionID] = session
+	return nil
+}
+
+func (s *fakeSessionStore) RemoveAPISession(server string, connectionID uint64) error {
+	delete(s.sessions, connectionID)
+	return nil
+}
+
+func (s *sessionRegistrySuite) SetUpTest(c *gc.C) {
+	s.store = &fakeSessionStore{sessions: make(map[uint64]state.APISession)}
+	s.registry = newSessionRegistry("0", s.store)
+	s.conns = make(map[uint64]*fakeSessionConn)
+	for id, tag := range map[uint64]names.Tag{
+		3: names.NewUserTag("bob"),
+		1: names.NewUserTag("bob"),
+		2: names.NewMachineTag("0"),
+	} {
+		s.conns[id] = &fakeSessionConn{}
+		s.registry.add(state.APISession{
+			ConnectionID: id,
+			Tag:          tag.String(),
+			RemoteAddr:   "10.0.0.1:1234",
+			LoggedIn:     time.Now(),
+		}, s.conns[id])
+	}
+}
+
+func (s *sessionRegistrySuite) TestAdd(c *gc.C) {
+	c.Assert(s.store.sessions, gc.HasLen, 3)
+	session := s.store.sessions[2]
+	c.Assert(session.Server, gc.Equals, "0")
+	c.Assert(session.Tag, gc.Equals, "machine-0")
+	c.Assert(session.RemoteAddr, gc.Equals, "10.0.0.1:1234")
+}
+
+func (s *sessionRegistrySuite) TestRemove(c *gc.C) {
+	s.registry.remove(2)
+	s.registry.remove(4)
+	c.Assert(s.store.sessions, gc.HasLen, 2)
+	c.Assert(s.store.sessions[1].Tag, gc.Equals, "user-bob")
+	c.Assert(s.store.sessions[3].Tag, gc.Equals, "user-bob")
+}
+
+func (s *sessionRegistrySuite) TestCloseConnection(c *gc.C) {
+	s.registry.close(apiserver.CloseSessions{
+		Server:        "0",
+		ConnectionIDs: []uint64{2, 4},
+	})
+	c.Assert(s.conns[1].closed, jc.IsFalse)
+	c.Assert(s.conns[2].closed, jc.IsTrue)
+	c.Assert(s.conns[3].closed, jc.IsFalse)
+}
+
+func (s *sessionRegistrySuite) TestCloseConnectionOtherServer(c *gc.C) {
+	s.registry.close(apiserver.CloseSessions{
+		Server:        "1",
+		ConnectionIDs: []uint64{2},
+	})
+	c.Assert(s.conns[2].closed, jc.IsFalse)
+}
+
+func (s *sessionRegistrySuite) TestCloseUser(c *gc.C) {
+	s.registry.close(apiserver.CloseSessions{
+		User: "user-bob",
+	})
+	c.Assert(s.conns[1].closed, jc.IsTrue)
+	c.Assert(s.conns[2].closed, jc.IsFalse)
+	c.Assert(s.conns[3].closed, jc.IsTrue)
+}
@@ -6,10 +6,13 @@ package apiserver
 import (
 	"sync"
 
+	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/apiserver/apipolicy"
+	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/lease"
 	"github.com/juju/juju/core/presence"
@@ -41,10 +44,20 @@ type sharedServerContext struct {
 	presence     presence.Recorder
 	leaseManager lease.Manager
 	logger       loggo.Logger
+	sessions     *sessionRegistry
 
 	featuresMutex sync.RWMutex
 	features      set.Strings
 
+	policyMutex    sync.RWMutex
+	policyURL      string
+	policy         apipolicy.Checker
+	policyFailOpen bool
+
+	bandwidthMutex  sync.RWMutex
+	bandwidthLimits map[string]int
+	bandwidth       *bandwidthLimiter
+
 	unsubscribe func()
 }
 
@@ -55,6 +68,7 @@ type sharedServerConfig struct {
 	presence     presence.Recorder
 	leaseManager lease.Manager
 	logger       loggo.Logger
+	machineID    string
 }
 
 func (c *sharedServerConfig) validate() error {
@@ -87,12 +101,16 @@ func newSharedServerContex(config sharedServerConfig) (*sharedServerContext, err
 		presence:     config.presence,
 		leaseManager: config.leaseManager,
 		logger:       config.logger,
+		sessions:     newSessionRegistry(config.machineID, config.statePool.SystemState()),
+		bandwidth:    newBandwidthLimiter(),
 	}
 	controllerConfig, err := ctx.statePool.SystemState().ControllerConfig()
 	if err != nil {
 		return nil, errors.Annotate(err, "unable to get controller config")
 	}
 	ctx.features = controllerConfig.Features()
+	ctx.setPolicy(controllerConfig)
+	ctx.setBandwidthLimits(controllerConfig)
 	// We are able to get the current controller config before subscribing to changes
 	// because the changes are only ever published in response to an API call, and
 	// this function is called in the newServer call to create the API server,
@@ -115,6 +133,9 @@ func (c *sharedServerContext) onConfigChanged(topic string, data controller.Conf
 		return
 	}
 
+	c.setPolicy(data.Config)
+	c.setBandwidthLimits(data.Config)
+
 	features := data.Config.Features()
 
 	c.featuresMutex.Lock()
@@ -145,3 +166,55 @@ func (c *sharedServerContext) featureEnabled(flag string) bool {
 	defer c.featuresMutex.RUnlock()
 	return c.features.Contains(flag)
 }
+
+// setPolicy updates the checker consulted before API calls are
+// dispatched, if the policy endpoint has changed, and whether calls
+// are allowed when the endpoint cannot be consulted.
+func (c *sharedServerContext) setPolicy(cfg corecontroller.Config) {
+	policyURL := cfg.APIPolicyURL()
+	c.policyMutex.Lock()
+	defer c.policyMutex.Unlock()
+	c.policyFailOpen = cfg.APIPolicyFailOpen()
+	if c.policy != nil && policyURL == c.policyURL {
+		return
+	}
+	c.policyURL = policyURL
+	if policyURL == "" {
+		c.policy = apipolicy.AllowAll
+		return
+	}
+	c.logger.Infof("consulting API policy endpoint %s", policyURL)
+	c.policy = apipolicy.NewCachingChecker(
+		&apipolicy.HTTPChecker{URL: policyURL},
+		clock.WallClock,
+		apipolicy.DefaultCacheTTL,
+	)
+}
+
+// apiPolicy returns the checker to consult before API calls
+// are dispatched, and whether calls are allowed if it fails.
+func (c *sharedServerContext) apiPolicy() (apipolicy.Checker, bool) {
+	c.policyMutex.RLock()
+	defer c.policyMutex.RUnlock()
+	if c.policy == nil {
+		return apipolicy.AllowAll, false
+	}
+	return c.policy, c.policyFailOpen
+}
+
+// setBandwidthLimits updates the limits on the bandwidth used to send
+// agent binaries, resources and backups.
+func (c *sharedServerContext) setBandwidthLimits(cfg corecontroller.Config) {
+	limits := bandwidthLimits(cfg)
+	c.bandwidthMutex.Lock()
+	defer c.bandwidthMutex.Unlock()
+	c.bandwidthLimits = limits
+}
+
+// bandwidthLimit returns the bandwidth limit, in KiB per second, for
+// the given kind of traffic, or zero if it is not limited.
+func (c *sharedServerContext) bandwidthLimit(traffic string) int {
+	c.bandwidthMutex.RLock()
+	defer c.bandwidthMutex.RUnlock()
+	return c.bandwidthLimits[traffic]
+}
@@ -4,6 +4,9 @@
 package apiserver
 
 import (
+	"io"
+	"io/ioutil"
+	"strings"
 	"time"
 
 	"github.com/juju/clock"
@@ -15,6 +18,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
+	"github.com/juju/juju/apiserver/apipolicy"
 	corecontroller "github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/presence"
@@ -201,6 +205,44 @@ func (s *sharedServerContextSuite) TestRemovingOldPresenceFeature(c *gc.C) {
 	c.Check(stub.published, jc.DeepEquals, []string{"apiserver.restart"})
 }
 
+func (s *sharedServerContextSuite) TestAPIPolicyChanged(c *gc.C) {
+	ctx := s.newContext(c)
+	checker, failOpen := ctx.apiPolicy()
+	c.Assert(checker, gc.Equals, apipolicy.AllowAll)
+	c.Assert(failOpen, jc.IsFalse)
+
+	publish := func(cfg corecontroller.Config) {
+		msg := controller.ConfigChangedMessage{Config: cfg}
+		done, err := s.hub.Publish(controller.ConfigChanged, msg)
+		c.Assert(err, jc.ErrorIsNil)
+		select {
+		case <-done:
+		case <-time.After(testing.LongWait):
+			c.Fatalf("handler didn't")
+		}
+	}
+
+	publish(corecontroller.Config{
+		corecontroller.APIPolicyURL: "http://localhost:8181/v1/data/juju/allow",
+	})
+	checker, failOpen = ctx.apiPolicy()
+	c.Assert(checker, gc.Not(gc.Equals), apipolicy.AllowAll)
+	c.Assert(failOpen, jc.IsFalse)
+
+	// Changing only the failure mode keeps the cached decisions.
+	publish(corecontroller.Config{
+		corecontroller.APIPolicyURL:      "http://localhost:8181/v1/data/juju/allow",
+		corecontroller.APIPolicyFailOpen: true,
+	})
+	sameChecker, failOpen := ctx.apiPolicy()
+	c.Assert(sameChecker, gc.Equals, checker)
+	c.Assert(failOpen, jc.IsTrue)
+
+	publish(corecontroller.Config{})
+	checker, _ = ctx.apiPolicy()
+	c.Assert(checker, gc.Equals, apipolicy.AllowAll)
+}
+
 type noopRegisterer struct {
 	prometheus.Registerer
 }
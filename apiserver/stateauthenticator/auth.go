@@ -191,6 +191,14 @@ func LoginRequest(req *http.Request) (params.LoginRequest, error) {
 		return params.LoginRequest{Macaroons: macaroons}, nil
 	}
 	parts := strings.Fields(authHeader)
+	if len(parts) == 2 && parts[0] == "Bearer" {
+		// An OpenID Connect ID token, for the user named
+		// in the user header.
+		return params.LoginRequest{
+			AuthTag: req.Header.Get(params.UserTagHeader),
+			Token:   parts[1],
+		}, nil
+	}
 	if len(parts) != 2 || parts[0] != "Basic" {
 		// Invalid header format or no header provided.
 		return params.LoginRequest{}, errors.NotValidf("request format")
@@ -53,6 +53,11 @@ type authContext struct {
 	macaroonAuthOnce   sync.Once
 	_macaroonAuth      *authentication.ExternalMacaroonAuthenticator
 	_macaroonAuthError error
+
+	// oidcKeysMutex guards oidcKeys, the signing keys of the
+	// configured OpenID Connect provider.
+	oidcKeysMutex sync.Mutex
+	oidcKeys      *authentication.OIDCKeySet
 }
 
 // newAuthContext creates a new authentication context for st.
@@ -140,6 +145,21 @@ func (a authenticator) Authenticate(
 	tag names.Tag,
 	req params.LoginRequest,
 ) (state.Entity, error) {
+	if req.Token != "" {
+		// Only users log in with ID tokens. Logins for any other
+		// kind of entity are treated as agent logins.
+		if kind, _ := names.TagKind(req.AuthTag); kind != names.UserTagKind {
+			return nil, errors.Annotate(common.ErrBadRequest, "token logins must be for a user")
+		}
+		auth, err := a.ctxt.oidcAuth()
+		if errors.Cause(err) == errOIDCAuthNotConfigured {
+			err = errors.Annotate(common.ErrBadRequest, "token logins are not supported")
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return auth.Authenticate(entityFinder, tag, req)
+	}
 	auth, err := a.authenticatorForTag(tag)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -182,6 +202,7 @@ func (a authenticator) localUserAuth() *authentication.UserAuthenticator {
 		Service:                   a.ctxt.localUserBakeryService,
 		Clock:                     a.ctxt.clock,
 		LocalUserIdentityLocation: localUserIdentityLocation.String(),
+		Revocations:               a.ctxt.st,
 	}
 }
 
@@ -197,6 +218,22 @@ func (ctxt *authContext) externalMacaroonAuth() (authentication.EntityAuthentica
 	return ctxt._macaroonAuth, nil
 }
 
+// oidcAuth returns an authenticator that can authenticate OpenID Connect
+// ID token logins for external users. The controller config is read
+// on each call, so changes to the provider settings take effect
+// without restarting the controller.
+func (ctxt *authContext) oidcAuth() (authentication.EntityAuthenticator, error) {
+	controllerCfg, err := ctxt.st.ControllerConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get controller config")
+	}
+	auth, err := newOIDCAuth(ctxt, controllerCfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auth, nil
+}
+
 var errMacaroonAuthNotConfigured = errors.New("macaroon authentication is not configured")
 
 // newExternalMacaroonAuth returns an authenticator that can authenticate
@@ -242,5 +279,6 @@ func newExternalMacaroonAuth(st *state.State) (*authentication.ExternalMacaroonA
 		return nil, errors.Annotate(err, "cannot make macaroon")
 	}
 	auth.IdentityLocation = idURL
+	auth.Revocations = st
 	return &auth, nil
 }
@@ -8,6 +8,8 @@ import (
 	"gopkg.in/macaroon.v2-unstable"
 
 	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
 )
 
 // TODO update the tests moved from apiserver to test via the public
@@ -31,3 +33,15 @@ func ServerBakeryService(a *Authenticator) (authentication.BakeryService, error)
 	}
 	return auth.(*authentication.ExternalMacaroonAuthenticator).Service, nil
 }
+
+func UpdateOIDCAccess(st *state.State, groupAccess map[string]permission.Access, tag names.UserTag, groups []string) error {
+	return oidcAccessUpdater{st: st, groupAccess: groupAccess}.updateAccess(tag, groups)
+}
+
+func OIDCAuth(a *Authenticator) (*authentication.OIDCAuthenticator, error) {
+	auth, err := a.authContext.oidcAuth()
+	if err != nil {
+		return nil, err
+	}
+	return auth.(*authentication.OIDCAuthenticator), nil
+}
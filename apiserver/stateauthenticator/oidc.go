@@ -0,0 +1,107 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stateauthenticator
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/authentication"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// oidcGrantor is recorded as the creator of controller access
+// granted because of a user's OpenID Connect groups. Only access
+// granted by this user is ever updated or removed on login.
+var oidcGrantor = names.NewUserTag("oidc@external")
+
+var errOIDCAuthNotConfigured = errors.New("OpenID Connect authentication is not configured")
+
+// newOIDCAuth returns an authenticator that can authenticate logins
+// for external users with OpenID Connect ID tokens, using the given
+// controller config. The key set is reused for as long as the issuer
+// is unchanged. This is just a helper function for authContext.oidcAuth.
+func newOIDCAuth(ctxt *authContext, controllerCfg controller.Config) (*authentication.OIDCAuthenticator, error) {
+	issuerURL := controllerCfg.OIDCIssuerURL()
+	if issuerURL == "" {
+		return nil, errOIDCAuthNotConfigured
+	}
+	domain, err := authentication.OIDCUserDomain(issuerURL)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ctxt.oidcKeysMutex.Lock()
+	if ctxt.oidcKeys == nil || ctxt.oidcKeys.IssuerURL != issuerURL {
+		ctxt.oidcKeys = &authentication.OIDCKeySet{
+			IssuerURL: issuerURL,
+			Clock:     ctxt.clock,
+		}
+	}
+	keys := ctxt.oidcKeys
+	ctxt.oidcKeysMutex.Unlock()
+
+	updater := oidcAccessUpdater{
+		st:          ctxt.st,
+		groupAccess: controllerCfg.OIDCGroupAccess(),
+	}
+	return &authentication.OIDCAuthenticator{
+		IssuerURL:    issuerURL,
+		ClientID:     controllerCfg.OIDCClientID(),
+		GroupsClaim:  controllerCfg.OIDCGroupsClaim(),
+		UserDomain:   domain,
+		Clock:        ctxt.clock,
+		Keys:         keys,
+		UpdateAccess: updater.updateAccess,
+	}, nil
+}
+
+// oidcAccessUpdater keeps the controller access of users logging in
+// with OpenID Connect in line with the access mapped to their groups.
+type oidcAccessUpdater struct {
+	st          *state.State
+	groupAccess map[string]permission.Access
+}
+
+// updateAccess grants, changes or revokes the user's controller access
+// according to the highest access mapped to any of the user's groups.
+// Access that was granted explicitly by an administrator is left alone.
+func (u oidcAccessUpdater) updateAccess(tag names.UserTag, groups []string) error {
+	access := permission.NoAccess
+	for _, group := range groups {
+		groupAccess, ok := u.groupAccess[group]
+		if ok && groupAccess.GreaterControllerAccessThan(access) {
+			access = groupAccess
+		}
+	}
+
+	controllerTag := u.st.ControllerTag()
+	current, err := u.st.UserAccess(tag, controllerTag)
+	if errors.IsNotFound(err) {
+		if access == permission.NoAccess {
+			return nil
+		}
+		_, err := u.st.AddControllerUser(state.UserAccessSpec{
+			User:      tag,
+			CreatedBy: oidcGrantor,
+			Access:    access,
+		})
+		return errors.Trace(err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	if current.CreatedBy != oidcGrantor {
+		return nil
+	}
+	if access == permission.NoAccess {
+		return errors.Trace(u.st.RemoveUserAccess(tag, controllerTag))
+	}
+	if current.Access != access {
+		_, err := u.st.SetUserAccess(tag, controllerTag, access)
+		return errors.Trace(err)
+	}
+	return nil
+}
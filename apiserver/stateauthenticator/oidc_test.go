@@ -0,0 +1,139 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stateauthenticator_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/stateauthenticator"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type oidcAccessSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&oidcAccessSuite{})
+
+var (
+	oidcUser        = names.NewUserTag("bob@external")
+	oidcGroupAccess = map[string]permission.Access{
+		"admins": permission.SuperuserAccess,
+		"devs":   permission.LoginAccess,
+	}
+)
+
+func (s *oidcAccessSuite) updateAccess(c *gc.C, groups ...string) {
+	err := stateauthenticator.UpdateOIDCAccess(s.State, oidcGroupAccess, oidcUser, groups)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *oidcAccessSuite) controllerAccess(c *gc.C) permission.UserAccess {
+	access, err := s.State.UserAccess(oidcUser, s.State.ControllerTag())
+	if errors.IsNotFound(err) {
+		return permission.UserAccess{}
+	}
+	c.Assert(err, jc.ErrorIsNil)
+	return access
+}
+
+func (s *oidcAccessSuite) TestGrantsHighestGroupAccess(c *gc.C) {
+	s.updateAccess(c, "devs", "admins", "unmapped")
+	access := s.controllerAccess(c)
+	c.Assert(access.Access, gc.Equals, permission.SuperuserAccess)
+	c.Assert(access.CreatedBy, gc.Equals, names.NewUserTag("oidc@external"))
+}
+
+func (s *oidcAccessSuite) TestNoMappedGroups(c *gc.C) {
+	s.updateAccess(c, "unmapped")
+	c.Assert(permission.IsEmptyUserAccess(s.controllerAccess(c)), jc.IsTrue)
+}
+
+func (s *oidcAccessSuite) TestUpdatesGrantedAccess(c *gc.C) {
+	s.updateAccess(c, "admins")
+	s.updateAccess(c, "devs")
+	c.Assert(s.controllerAccess(c).Access, gc.Equals, permission.LoginAccess)
+
+	s.updateAccess(c)
+	c.Assert(permission.IsEmptyUserAccess(s.controllerAccess(c)), jc.IsTrue)
+}
+
+func (s *oidcAccessSuite) TestLeavesExplicitAccessAlone(c *gc.C) {
+	_, err := s.State.AddControllerUser(state.UserAccessSpec{
+		User:      oidcUser,
+		CreatedBy: s.Owner,
+		Access:    permission.SuperuserAccess,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.updateAccess(c, "devs")
+	c.Assert(s.controllerAccess(c).Access, gc.Equals, permission.SuperuserAccess)
+	s.updateAccess(c)
+	c.Assert(s.controllerAccess(c).Access, gc.Equals, permission.SuperuserAccess)
+}
+
+func (s *oidcAccessSuite) TestAuthenticatorFollowsConfigChanges(c *gc.C) {
+	authenticator, err := stateauthenticator.NewAuthenticator(s.StatePool, s.Clock)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = stateauthenticator.OIDCAuth(authenticator)
+	c.Assert(err, gc.ErrorMatches, "OpenID Connect authentication is not configured")
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.OIDCIssuerURL: "https://idp.example.com",
+		controller.OIDCClientID:  "juju",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	auth, err := stateauthenticator.OIDCAuth(authenticator)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(auth.IssuerURL, gc.Equals, "https://idp.example.com")
+	c.Assert(auth.ClientID, gc.Equals, "juju")
+	c.Assert(auth.UserDomain, gc.Equals, "idp.example.com")
+	keys := auth.Keys
+
+	// The keys are kept while the issuer is unchanged.
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.OIDCClientID: "juju-controller",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	auth, err = stateauthenticator.OIDCAuth(authenticator)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(auth.ClientID, gc.Equals, "juju-controller")
+	c.Assert(auth.Keys, gc.Equals, keys)
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.OIDCIssuerURL: "https://sso.example.com",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	auth, err = stateauthenticator.OIDCAuth(authenticator)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(auth.UserDomain, gc.Equals, "sso.example.com")
+	c.Assert(auth.Keys, gc.Not(gc.Equals), keys)
+}
+
+func (s *oidcAccessSuite) TestTokenLoginRequiresUserTag(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.OIDCIssuerURL: "https://idp.example.com",
+		controller.OIDCClientID:  "juju",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	authenticator, err := stateauthenticator.NewAuthenticator(s.StatePool, s.Clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A token login naming anything other than a user would
+	// otherwise be treated as an agent login.
+	for _, authTag := range []string{"machine-0", "unit-wordpress-0", ""} {
+		_, err = authenticator.AuthenticateLoginRequest("testing.invalid:1234", s.State.ModelUUID(), params.LoginRequest{
+			AuthTag: authTag,
+			Token:   "header.claims.signature",
+		})
+		c.Check(err, gc.ErrorMatches, "token logins must be for a user: invalid request")
+	}
+}
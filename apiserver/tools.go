@@ -224,7 +224,7 @@ func (h *toolsDownloadHandler) sendTools(w http.ResponseWriter, reader io.ReadCl
 	w.Header().Set("Content-Type", "application/x-tar-gz")
 	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
 
-	if _, err := io.Copy(w, reader); err != nil {
+	if _, err := io.Copy(w, h.ctxt.limitBandwidth(agentBinaryTraffic, reader)); err != nil {
 		// Having begun writing, it is too late to send an error response here.
 		return errors.Annotatef(err, "failed to send agent binaries")
 	}
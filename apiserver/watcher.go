@@ -566,6 +566,7 @@ func (w *srvMigrationStatusWatcher) Next() (params.MigrationStatus, error) {
 		SourceCACert:   sourceCACert,
 		TargetAPIAddrs: target.Addrs,
 		TargetCACert:   target.CACert,
+		Copy:           mig.Mode() == migration.ModeCopy,
 	}, nil
 }
 
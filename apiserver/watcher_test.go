@@ -125,6 +125,20 @@ func (s *watcherSuite) TestMigrationStatusWatcher(c *gc.C) {
 	})
 }
 
+func (s *watcherSuite) TestMigrationStatusWatcherCopy(c *gc.C) {
+	w := apiservertesting.NewFakeNotifyWatcher()
+	id := s.resources.Register(w)
+	s.authorizer.Tag = names.NewMachineTag("12")
+	apiserver.PatchGetMigrationBackend(s, &fakeMigrationBackend{mode: migration.ModeCopy})
+	apiserver.PatchGetControllerCACert(s, "no worries")
+
+	facade := s.getFacade(c, "MigrationStatusWatcher", 1, id, nopDispose).(migrationStatusWatcher)
+	defer c.Check(facade.Stop(), jc.ErrorIsNil)
+	result, err := facade.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Copy, jc.IsTrue)
+}
+
 func (s *watcherSuite) TestMigrationStatusWatcherNoMigration(c *gc.C) {
 	w := apiservertesting.NewFakeNotifyWatcher()
 	id := s.resources.Register(w)
@@ -173,13 +187,14 @@ func (w *fakeStringsWatcher) Stop() error {
 
 type fakeMigrationBackend struct {
 	noMigration bool
+	mode        migration.Mode
 }
 
 func (b *fakeMigrationBackend) LatestMigration() (state.ModelMigration, error) {
 	if b.noMigration {
 		return nil, errors.NotFoundf("migration")
 	}
-	return new(fakeModelMigration), nil
+	return &fakeModelMigration{mode: b.mode}, nil
 }
 
 func (b *fakeMigrationBackend) APIHostPortsForClients() ([][]network.HostPort, error) {
@@ -207,6 +222,7 @@ func MustParseHostPorts(hostports ...string) []network.HostPort {
 
 type fakeModelMigration struct {
 	state.ModelMigration
+	mode migration.Mode
 }
 
 func (m *fakeModelMigration) Id() string {
@@ -221,6 +237,13 @@ func (m *fakeModelMigration) Phase() (migration.Phase, error) {
 	return migration.IMPORT, nil
 }
 
+func (m *fakeModelMigration) Mode() migration.Mode {
+	if m.mode == "" {
+		return migration.ModeMove
+	}
+	return m.mode
+}
+
 func (m *fakeModelMigration) TargetInfo() (*migration.TargetInfo, error) {
 	return &migration.TargetInfo{
 		ControllerTag: names.NewControllerTag("uuid"),
@@ -110,7 +110,9 @@ type Broker interface {
 	// DeleteOperator deletes the specified operator.
 	DeleteOperator(appName string) error
 
-	// EnsureCustomResourceDefinition creates or updates a custom resource definition resource.
+	// EnsureCustomResourceDefinition creates or updates the custom resource
+	// definitions and custom resources declared in the pod spec, and
+	// removes the application's custom resources no longer declared.
 	EnsureCustomResourceDefinition(appName string, podSpec *PodSpec) error
 
 	// WatchUnits returns a watcher which notifies when there
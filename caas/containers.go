@@ -6,6 +6,7 @@ package caas
 import (
 	"github.com/juju/errors"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 // FileSet defines a set of files to mount
@@ -64,6 +65,11 @@ type PodSpec struct {
 	InitContainers            []ContainerSpec                                              `yaml:"-"`
 	CustomResourceDefinitions map[string]apiextensionsv1beta1.CustomResourceDefinitionSpec `yaml:"-"`
 
+	// CustomResources holds the custom resources to create for the
+	// application, keyed by the name of the custom resource definition
+	// they are instances of.
+	CustomResources map[string][]unstructured.Unstructured `yaml:"-"`
+
 	// ProviderPod defines config which is specific to a substrate, eg k8s
 	ProviderPod `yaml:"-"`
 }
@@ -85,12 +91,32 @@ func (spec *PodSpec) Validate() error {
 			return errors.Trace(err)
 		}
 	}
+	for crdName, resources := range spec.CustomResources {
+		for _, cr := range resources {
+			if err := validateCustomResource(crdName, cr); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
 	if spec.ProviderPod != nil {
 		return spec.ProviderPod.Validate()
 	}
 	return nil
 }
 
+func validateCustomResource(crdName string, cr unstructured.Unstructured) error {
+	if cr.GetName() == "" {
+		return errors.Errorf("custom resource name is missing for %q", crdName)
+	}
+	if cr.GetAPIVersion() == "" {
+		return errors.Errorf("apiVersion is missing for custom resource %q", cr.GetName())
+	}
+	if cr.GetKind() == "" {
+		return errors.Errorf("kind is missing for custom resource %q", cr.GetName())
+	}
+	return nil
+}
+
 // Validate is defined on ProviderContainer.
 func (spec *ContainerSpec) Validate() error {
 	if spec.Name == "" {
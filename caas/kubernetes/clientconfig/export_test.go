@@ -11,4 +11,5 @@ var (
 	EnsureClusterRoleBinding                      = ensureClusterRoleBinding
 	GetServiceAccountSecret                       = getServiceAccountSecret
 	ReplaceAuthProviderWithServiceAccountAuthData = replaceAuthProviderWithServiceAccountAuthData
+	ServiceAccountDir                             = &serviceAccountDir
 )
@@ -0,0 +1,71 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package clientconfig
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/cloud"
+)
+
+// InClusterName names the cloud, credential and context of the
+// in-cluster client config.
+const InClusterName = "in-cluster"
+
+// serviceAccountDir is where k8s mounts the credential of a pod's
+// service account.
+var serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// NewInClusterClientConfig returns a client config for the cluster the
+// client is running in, using the pod's service account token and the
+// cluster CA certificate. It returns a NotFound error if the client is
+// not running in a k8s pod.
+func NewInClusterClientConfig() (*ClientConfig, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.NewNotFound(nil, "k8s service environment not found, not running in a k8s pod")
+	}
+	token, err := ioutil.ReadFile(filepath.Join(serviceAccountDir, "token"))
+	if os.IsNotExist(err) {
+		return nil, errors.NewNotFound(nil, "k8s service account token not found, not running in a k8s pod")
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "reading service account token")
+	}
+	caData, err := ioutil.ReadFile(filepath.Join(serviceAccountDir, "ca.crt"))
+	if err != nil {
+		return nil, errors.Annotate(err, "reading cluster CA certificate")
+	}
+
+	cred := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{
+		"Token": string(token),
+	})
+	cred.Label = "kubernetes service account credential"
+	return &ClientConfig{
+		Type: "kubernetes",
+		Contexts: map[string]Context{
+			InClusterName: {
+				CloudName:      InClusterName,
+				CredentialName: InClusterName,
+			},
+		},
+		CurrentContext: InClusterName,
+		Clouds: map[string]CloudConfig{
+			InClusterName: {
+				Endpoint: "https://" + net.JoinHostPort(host, port),
+				Attributes: map[string]interface{}{
+					"CAData": string(caData),
+				},
+			},
+		},
+		Credentials: map[string]cloud.Credential{
+			InClusterName: cred,
+		},
+	}, nil
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package clientconfig_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/caas/kubernetes/clientconfig"
+	"github.com/juju/juju/cloud"
+)
+
+type inClusterSuite struct {
+	testing.IsolationSuite
+	dir string
+}
+
+var _ = gc.Suite(&inClusterSuite{})
+
+func (s *inClusterSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.PatchValue(clientconfig.ServiceAccountDir, s.dir)
+	s.PatchEnvironment("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+	s.PatchEnvironment("KUBERNETES_SERVICE_PORT", "443")
+}
+
+func (s *inClusterSuite) writeFile(c *gc.C, name, content string) {
+	err := ioutil.WriteFile(filepath.Join(s.dir, name), []byte(content), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *inClusterSuite) TestNewInClusterClientConfig(c *gc.C) {
+	s.writeFile(c, "token", "sa-token")
+	s.writeFile(c, "ca.crt", "ca-data")
+
+	cfg, err := clientconfig.NewInClusterClientConfig()
+	c.Assert(err, jc.ErrorIsNil)
+
+	cred := cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{"Token": "sa-token"})
+	cred.Label = "kubernetes service account credential"
+	c.Assert(cfg, jc.DeepEquals, &clientconfig.ClientConfig{
+		Type: "kubernetes",
+		Contexts: map[string]clientconfig.Context{
+			"in-cluster": {CloudName: "in-cluster", CredentialName: "in-cluster"},
+		},
+		CurrentContext: "in-cluster",
+		Clouds: map[string]clientconfig.CloudConfig{
+			"in-cluster": {
+				Endpoint:   "https://10.0.0.1:443",
+				Attributes: map[string]interface{}{"CAData": "ca-data"},
+			},
+		},
+		Credentials: map[string]cloud.Credential{"in-cluster": cred},
+	})
+}
+
+func (s *inClusterSuite) TestNewInClusterClientConfigNotInPod(c *gc.C) {
+	s.PatchEnvironment("KUBERNETES_SERVICE_HOST", "")
+
+	_, err := clientconfig.NewInClusterClientConfig()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "k8s service environment not found, not running in a k8s pod")
+}
+
+func (s *inClusterSuite) TestNewInClusterClientConfigNoToken(c *gc.C) {
+	_, err := clientconfig.NewInClusterClientConfig()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "k8s service account token not found, not running in a k8s pod")
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"sort"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -104,6 +105,33 @@ func NewK8sClientConfig(reader io.Reader, contextName, clusterName string, crede
 	}, nil
 }
 
+// K8sContextNames returns the sorted names of the contexts in the
+// Kubernetes config read from the specified reader, or from the
+// kubeconfig file if the reader is nil.
+func K8sContextNames(reader io.Reader) ([]string, error) {
+	if reader == nil {
+		var err error
+		reader, err = readKubeConfigFile()
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to read Kubernetes config file")
+		}
+	}
+	content, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to read Kubernetes config")
+	}
+	config, err := parseKubeConfig(content)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to parse Kubernetes config")
+	}
+	names := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
 func pickContextByClusterName(contexts map[string]Context, clusterName string) (Context, string, error) {
 	for contextName, context := range contexts {
 		if clusterName == context.CloudName {
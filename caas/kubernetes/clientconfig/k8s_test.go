@@ -448,3 +448,23 @@ func (s *k8sConfigSuite) TestGetSingleConfigReadsFilePaths(c *gc.C) {
 		},
 	})
 }
+
+func (s *k8sConfigSuite) TestK8sContextNames(c *gc.C) {
+	f, err := s.writeTempKubeConfig(c, "multiConfig", multiConfigYAML)
+	defer f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	names, err := clientconfig.K8sContextNames(f)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"default-context", "second-context", "the-context"})
+}
+
+func (s *k8sConfigSuite) TestK8sContextNamesFromFile(c *gc.C) {
+	f, err := s.writeTempKubeConfig(c, "singleConfig", singleConfigYAML)
+	defer f.Close()
+	c.Assert(err, jc.ErrorIsNil)
+
+	names, err := clientconfig.K8sContextNames(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(names, jc.DeepEquals, []string{"the-context"})
+}
@@ -0,0 +1,72 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+const (
+	// hostnameNodeLabel and zoneNodeLabel are the well known node
+	// labels holding the name and availability zone of a node.
+	hostnameNodeLabel = "kubernetes.io/hostname"
+	zoneNodeLabel     = "failure-domain.beta.kubernetes.io/zone"
+
+	// antiAffinityNode and antiAffinityZone are the values of the
+	// pod anti-affinity config, spreading an application's pods
+	// across nodes or zones.
+	antiAffinityNode = "node"
+	antiAffinityZone = "zone"
+
+	// antiAffinityPreferredWeight is the weight given to the preferred
+	// pod anti-affinity term, the most a term may have.
+	antiAffinityPreferredWeight = 100
+)
+
+// antiAffinityTopologyKeys maps the pod anti-affinity config values to
+// the node label pods are spread across.
+var antiAffinityTopologyKeys = map[string]string{
+	antiAffinityNode: hostnameNodeLabel,
+	antiAffinityZone: zoneNodeLabel,
+}
+
+// configurePodAntiAffinity adds a pod anti-affinity term to the pod
+// spec, spreading the application's pods across the nodes or zones
+// named by the application config. Unless the anti-affinity is required
+// the scheduler may still place pods together when it has to.
+func configurePodAntiAffinity(pod *core.PodSpec, appName string, config application.ConfigAttributes) error {
+	spread := config.GetString(podAntiAffinityKey, "")
+	if spread == "" {
+		return nil
+	}
+	topologyKey, ok := antiAffinityTopologyKeys[spread]
+	if !ok {
+		return errors.NotValidf("%s %q, expected %q or %q", podAntiAffinityKey, spread, antiAffinityNode, antiAffinityZone)
+	}
+	term := core.PodAffinityTerm{
+		LabelSelector: &v1.LabelSelector{
+			MatchLabels: map[string]string{labelApplication: appName},
+		},
+		TopologyKey: topologyKey,
+	}
+
+	if pod.Affinity == nil {
+		pod.Affinity = &core.Affinity{}
+	}
+	antiAffinity := &core.PodAntiAffinity{}
+	if config.GetBool(podAntiAffinityRequiredKey, defaultPodAntiAffinityRequired) {
+		antiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = []core.PodAffinityTerm{term}
+	} else {
+		antiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = []core.WeightedPodAffinityTerm{{
+			Weight:          antiAffinityPreferredWeight,
+			PodAffinityTerm: term,
+		}}
+	}
+	pod.Affinity.PodAntiAffinity = antiAffinity
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
+)
+
+type affinitySuite struct{}
+
+var _ = gc.Suite(&affinitySuite{})
+
+func appPodAffinityTerm(topologyKey string) core.PodAffinityTerm {
+	return core.PodAffinityTerm{
+		LabelSelector: &v1.LabelSelector{
+			MatchLabels: map[string]string{"juju-app": "gitlab"},
+		},
+		TopologyKey: topologyKey,
+	}
+}
+
+func (s *affinitySuite) TestNoAntiAffinity(c *gc.C) {
+	var pod core.PodSpec
+	err := provider.ConfigurePodAntiAffinity(&pod, "gitlab", application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Affinity, gc.IsNil)
+}
+
+func (s *affinitySuite) TestPreferredNodeAntiAffinity(c *gc.C) {
+	var pod core.PodSpec
+	err := provider.ConfigurePodAntiAffinity(&pod, "gitlab", application.ConfigAttributes{
+		"kubernetes-pod-anti-affinity": "node",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Affinity, jc.DeepEquals, &core.Affinity{
+		PodAntiAffinity: &core.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []core.WeightedPodAffinityTerm{{
+				Weight:          100,
+				PodAffinityTerm: appPodAffinityTerm("kubernetes.io/hostname"),
+			}},
+		},
+	})
+}
+
+func (s *affinitySuite) TestRequiredZoneAntiAffinity(c *gc.C) {
+	pod := core.PodSpec{
+		Affinity: &core.Affinity{
+			NodeAffinity: &core.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{},
+			},
+		},
+	}
+	err := provider.ConfigurePodAntiAffinity(&pod, "gitlab", application.ConfigAttributes{
+		"kubernetes-pod-anti-affinity":          "zone",
+		"kubernetes-pod-anti-affinity-required": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Affinity, jc.DeepEquals, &core.Affinity{
+		NodeAffinity: &core.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{},
+		},
+		PodAntiAffinity: &core.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []core.PodAffinityTerm{
+				appPodAffinityTerm("failure-domain.beta.kubernetes.io/zone"),
+			},
+		},
+	})
+}
+
+func (s *affinitySuite) TestInvalidAntiAffinity(c *gc.C) {
+	var pod core.PodSpec
+	err := provider.ConfigurePodAntiAffinity(&pod, "gitlab", application.ConfigAttributes{
+		"kubernetes-pod-anti-affinity": "rack",
+	})
+	c.Assert(err, gc.ErrorMatches, `kubernetes-pod-anti-affinity "rack", expected "node" or "zone" not valid`)
+}
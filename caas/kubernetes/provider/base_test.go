@@ -9,6 +9,7 @@ import (
 	"github.com/golang/mock/gomock"
 	jujuclock "github.com/juju/clock"
 	testclock "github.com/juju/clock/testclock"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	core "k8s.io/api/core/v1"
@@ -212,6 +213,10 @@ func (s *BaseSuite) k8sAlreadyExistsError() *k8serrors.StatusError {
 	return k8serrors.NewAlreadyExists(schema.GroupResource{}, "test")
 }
 
+func (s *BaseSuite) k8sForbiddenError() *k8serrors.StatusError {
+	return k8serrors.NewForbidden(schema.GroupResource{}, "test", errors.New("not permitted"))
+}
+
 func (s *BaseSuite) deleteOptions(policy v1.DeletionPropagation) *v1.DeleteOptions {
 	return &v1.DeleteOptions{PropagationPolicy: &policy}
 }
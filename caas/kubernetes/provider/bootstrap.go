@@ -15,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	apps "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	intstr "k8s.io/apimachinery/pkg/util/intstr"
@@ -116,6 +117,16 @@ func controllerCorelation(broker *kubernetesClient) (*kubernetesClient, error) {
 	// ensure controller specific annotations.
 	_ = broker.addAnnotations(annotationControllerIsControllerKey, "true")
 
+	if nsName := broker.brokerConfig().controllerNamespace(); nsName != "" {
+		// The controller was bootstrapped into an existing namespace,
+		// which the credential may be restricted to.
+		if _, err := broker.clusterCapabilities(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		broker.SetNamespace(nsName)
+		return broker, nil
+	}
+
 	ns, err := broker.listNamespacesByAnnotations(broker.GetAnnotations())
 	if errors.IsNotFound(err) || ns == nil {
 		// No existing controller found on the cluster.
@@ -133,6 +144,35 @@ func controllerCorelation(broker *kubernetesClient) (*kubernetesClient, error) {
 	return broker, nil
 }
 
+// destroyControllerStack removes the resources of a controller which
+// was bootstrapped into an existing namespace. Juju didn't create that
+// namespace, so it is left behind.
+func (k *kubernetesClient) destroyControllerStack() error {
+	stack := controllerStack{stackName: JujuControllerStackName}
+	if err := k.deleteStatefulSet(JujuControllerStackName); err != nil {
+		return errors.Annotate(err, "deleting controller statefulset")
+	}
+	if err := k.deleteService(stack.getResourceName("service")); err != nil {
+		return errors.Annotate(err, "deleting controller service")
+	}
+	if err := k.deleteConfigMap(stack.getResourceName("configmap")); err != nil {
+		return errors.Annotate(err, "deleting controller configmap")
+	}
+	if err := k.deleteSecret(stack.getResourceName("secret")); err != nil {
+		return errors.Annotate(err, "deleting controller secret")
+	}
+	err := k.CoreV1().PersistentVolumeClaims(k.namespace).DeleteCollection(&v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	}, v1.ListOptions{
+		LabelSelector: applicationSelector(JujuControllerStackName),
+	})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Annotate(err, "deleting controller storage")
+	}
+	logger.Infof("namespace %q was not created by Juju, so it is not removed", k.namespace)
+	return nil
+}
+
 // DecideControllerNamespace decides the namespace name to use for a new controller.
 func DecideControllerNamespace(controllerName string) string {
 	return "controller-" + controllerName
@@ -282,8 +322,10 @@ func (c controllerStack) Deploy() (err error) {
 	// creating namespace for controller stack, this namespace will be removed by broker.DestroyController if bootstrap failed.
 	nsName := c.broker.GetCurrentNamespace()
 	c.ctx.Infof("Creating k8s resources for controller %q", nsName)
-	if err = c.broker.createNamespace(nsName); err != nil {
-		return errors.Annotate(err, "creating namespace for controller stack")
+	if c.broker.brokerConfig().controllerNamespace() == "" {
+		if err = c.broker.createNamespace(nsName); err != nil {
+			return errors.Annotate(err, "creating namespace for controller stack")
+		}
 	}
 
 	defer func() {
@@ -548,13 +590,15 @@ func (c controllerStack) createControllerStatefulset() error {
 }
 
 func (c controllerStack) buildStorageSpecForController(statefulset *apps.StatefulSet) error {
-	sc, err := c.broker.getStorageClass(c.storageClass)
-	if err != nil {
-		return errors.Trace(err)
+	if c.broker.knownCapabilities().StorageClasses {
+		sc, err := c.broker.getStorageClass(c.storageClass)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// try to find <namespace>-<c.storageClass>,
+		// if it's not found, then fallback to c.storageClass.
+		c.storageClass = sc.GetName()
 	}
-	// try to find <namespace>-<c.storageClass>,
-	// if it's not found, then fallback to c.storageClass.
-	c.storageClass = sc.GetName()
 
 	// build persistent volume claim.
 	statefulset.Spec.VolumeClaimTemplates = []core.PersistentVolumeClaim{
@@ -749,7 +793,7 @@ func (c controllerStack) buildContainerSpecForController(statefulset *apps.State
 
 		// add container API server.
 		containerSpec = append(containerSpec, core.Container{
-			Name:            "api-server",
+			Name:            controllerContainerName,
 			ImagePullPolicy: core.PullIfNotPresent,
 			Image:           c.pcfg.GetControllerImagePath(),
 			Command: []string{
@@ -832,5 +876,15 @@ func (c controllerStack) buildContainerSpecForController(statefulset *apps.State
 		loggingOption,
 	)
 	statefulset.Spec.Template.Spec.Containers = generateContainerSpecs(jujudCmd)
+
+	resources, err := c.broker.brokerConfig().operatorResources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i, container := range statefulset.Spec.Template.Spec.Containers {
+		if container.Name == controllerContainerName {
+			statefulset.Spec.Template.Spec.Containers[i].Resources = resources
+		}
+	}
 	return nil
 }
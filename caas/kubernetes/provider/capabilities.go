@@ -0,0 +1,106 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"github.com/juju/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// clusterCapabilities records which cluster scoped resources the
+// broker's credential may use. A service account bound to a role in a
+// single namespace, rather than to a cluster role, may use none of
+// them, and the broker does without.
+type clusterCapabilities struct {
+	// Namespaces is true if namespaces can be listed and created.
+	Namespaces bool
+
+	// StorageClasses is true if storage classes can be listed.
+	StorageClasses bool
+
+	// CustomResourceDefinitions is true if custom resource
+	// definitions can be listed.
+	CustomResourceDefinitions bool
+}
+
+// restricted returns true if the credential may not use some cluster
+// scoped resources.
+func (c clusterCapabilities) restricted() bool {
+	return !c.Namespaces || !c.StorageClasses || !c.CustomResourceDefinitions
+}
+
+// missing returns a description of each thing juju can't do because
+// of the credential's restrictions.
+func (c clusterCapabilities) missing() []string {
+	var missing []string
+	if !c.Namespaces {
+		missing = append(missing, "namespaces: models other than the controller model cannot be added")
+	}
+	if !c.StorageClasses {
+		missing = append(missing, "storage classes: storage classes are not checked or created, and must already exist")
+	}
+	if !c.CustomResourceDefinitions {
+		missing = append(missing, "custom resource definitions: charms needing them cannot be deployed")
+	}
+	return missing
+}
+
+// clusterCapabilities probes the cluster for the cluster scoped
+// resources the broker's credential may use. The result is cached.
+func (k *kubernetesClient) clusterCapabilities() (clusterCapabilities, error) {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if k.capabilities != nil {
+		return *k.capabilities, nil
+	}
+
+	var caps clusterCapabilities
+	var err error
+	listOptions := v1.ListOptions{Limit: 1}
+	_, err = k.CoreV1().Namespaces().List(listOptions)
+	if caps.Namespaces, err = permitted(err); err != nil {
+		return caps, errors.Annotate(err, "listing namespaces")
+	}
+	_, err = k.StorageV1().StorageClasses().List(listOptions)
+	if caps.StorageClasses, err = permitted(err); err != nil {
+		return caps, errors.Annotate(err, "listing storage classes")
+	}
+	_, err = k.apiextensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().List(listOptions)
+	if caps.CustomResourceDefinitions, err = permitted(err); err != nil {
+		return caps, errors.Annotate(err, "listing custom resource definitions")
+	}
+	logger.Debugf("cluster capabilities: %+v", caps)
+	k.capabilities = &caps
+	return caps, nil
+}
+
+// permitted returns whether the error from a k8s API call shows that
+// the call was permitted. It returns any error other than one saying
+// the call was forbidden.
+func permitted(err error) (bool, error) {
+	if err == nil {
+		return true, nil
+	}
+	if k8serrors.IsForbidden(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// knownCapabilities returns the capabilities probed for the broker's
+// credential. Only brokers using an existing controller namespace probe
+// them; other brokers assume the credential may use everything.
+func (k *kubernetesClient) knownCapabilities() clusterCapabilities {
+	k.lock.Lock()
+	defer k.lock.Unlock()
+	if k.capabilities == nil {
+		return clusterCapabilities{
+			Namespaces:                true,
+			StorageClasses:            true,
+			CustomResourceDefinitions: true,
+		}
+	}
+	return *k.capabilities
+}
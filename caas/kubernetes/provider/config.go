@@ -16,6 +16,8 @@ const (
 	defaultIngressSSLPassthrough = false
 	defaultIngressAllowHTTPKey   = false
 
+	defaultPodAntiAffinityRequired = false
+
 	serviceTypeConfigKey               = "kubernetes-service-type"
 	serviceExternalIPsConfigKey        = "kubernetes-service-external-ips"
 	serviceTargetPortConfigKey         = "kubernetes-service-target-port"
@@ -28,6 +30,10 @@ const (
 	ingressSSLRedirectKey    = "kubernetes-ingress-ssl-redirect"
 	ingressSSLPassthroughKey = "kubernetes-ingress-ssl-passthrough"
 	ingressAllowHTTPKey      = "kubernetes-ingress-allow-http"
+	ingressTLSSecretKey      = "kubernetes-ingress-tls-secret"
+
+	podAntiAffinityKey         = "kubernetes-pod-anti-affinity"
+	podAntiAffinityRequiredKey = "kubernetes-pod-anti-affinity-required"
 )
 
 var configFields = environschema.Fields{
@@ -86,6 +92,22 @@ var configFields = environschema.Fields{
 		Type:        environschema.Tbool,
 		Group:       environschema.ProviderGroup,
 	},
+	ingressTLSSecretKey: {
+		Description: "the name of the secret holding the TLS certificate used by the ingress resource",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+	},
+	podAntiAffinityKey: {
+		Description: "spread the application's pods across nodes or zones",
+		Type:        environschema.Tstring,
+		Group:       environschema.ProviderGroup,
+		Values:      []interface{}{antiAffinityNode, antiAffinityZone},
+	},
+	podAntiAffinityRequiredKey: {
+		Description: "whether pods must be spread, rather than spread where possible",
+		Type:        environschema.Tbool,
+		Group:       environschema.ProviderGroup,
+	},
 }
 
 var schemaDefaults = schema.Defaults{
@@ -95,6 +117,10 @@ var schemaDefaults = schema.Defaults{
 	ingressSSLRedirectKey:    defaultIngressSSLRedirect,
 	ingressSSLPassthroughKey: defaultIngressSSLPassthrough,
 	ingressAllowHTTPKey:      defaultIngressAllowHTTPKey,
+	ingressTLSSecretKey:      schema.Omit,
+
+	podAntiAffinityKey:         schema.Omit,
+	podAntiAffinityRequiredKey: defaultPodAntiAffinityRequired,
 }
 
 // ConfigSchema returns the configuration schema for
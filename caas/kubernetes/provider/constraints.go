@@ -4,19 +4,37 @@
 package provider
 
 import (
+	"strings"
+
+	"github.com/juju/utils/arch"
+	core "k8s.io/api/core/v1"
+
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/environs/context"
 )
 
 const CAASProviderType = "kubernetes"
 
+const (
+	// archNodeLabel and osNodeLabel are the well known node labels
+	// holding the architecture and operating system of a node.
+	archNodeLabel = "beta.kubernetes.io/arch"
+	osNodeLabel   = "beta.kubernetes.io/os"
+)
+
+// k8sArches maps Juju architecture names to the names
+// used by Kubernetes, where they differ.
+var k8sArches = map[string]string{
+	arch.PPC64EL: "ppc64le",
+}
+
 var unsupportedConstraints = []string{
 	constraints.Cores,
 	constraints.VirtType,
 	constraints.Container,
-	constraints.Arch,
 	constraints.RootDisk,
 	constraints.InstanceType,
+	constraints.ImageID,
 	constraints.Spaces,
 }
 
@@ -25,5 +43,38 @@ var unsupportedConstraints = []string{
 func (k *kubernetesClient) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
 	validator := constraints.NewValidator()
 	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterVocabulary(constraints.Arch, arch.AllSupportedArches)
 	return validator, nil
 }
+
+// configureArchConstraint restricts the pod to nodes of the specified
+// architecture, tolerating nodes of that architecture being tainted so
+// that only matching workloads are scheduled there.
+func configureArchConstraint(pod *core.PodSpec, jujuArch string) {
+	k8sArch := jujuArch
+	if a, ok := k8sArches[jujuArch]; ok {
+		k8sArch = a
+	}
+	if pod.NodeSelector == nil {
+		pod.NodeSelector = make(map[string]string)
+	}
+	pod.NodeSelector[archNodeLabel] = k8sArch
+	pod.Tolerations = append(pod.Tolerations, nodePoolTolerations(archNodeLabel, []string{k8sArch})...)
+}
+
+// nodePoolTolerations returns the tolerations allowing a pod onto
+// nodes with a NoSchedule taint of the given key and any of the values.
+// Heterogeneous node pools (eg Windows or ARM nodes) are commonly
+// tainted this way so that only workloads targeting them are scheduled.
+func nodePoolTolerations(key string, values []string) []core.Toleration {
+	var result []core.Toleration
+	for _, v := range values {
+		result = append(result, core.Toleration{
+			Key:      key,
+			Operator: core.TolerationOpEqual,
+			Value:    strings.TrimSpace(v),
+			Effect:   core.TaintEffectNoSchedule,
+		})
+	}
+	return result
+}
@@ -78,7 +78,6 @@ func (s *ConstraintsSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	expected := []string{
 		"cores",
 		"virt-type",
-		"arch",
 		"instance-type",
 		"root-disk",
 		"spaces",
@@ -86,3 +85,15 @@ func (s *ConstraintsSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	}
 	c.Check(unsupported, jc.SameContents, expected)
 }
+
+func (s *ConstraintsSuite) TestConstraintsValidatorArch(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	validator, err := s.broker.ConstraintsValidator(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+
+	unsupported, err := validator.Validate(constraints.MustParse("arch=arm64"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unsupported, gc.HasLen, 0)
+}
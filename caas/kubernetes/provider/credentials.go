@@ -55,6 +55,15 @@ var k8sCredentialSchemas = map[cloud.AuthType]cloud.CredentialSchema{
 			},
 		},
 	},
+	cloud.OAuth2AuthType: {
+		{
+			Name: CredAttrToken,
+			CredentialAttr: cloud.CredentialAttr{
+				Description: "the kubernetes service account bearer token",
+				Hidden:      true,
+			},
+		},
+	},
 	cloud.CertificateAuthType: {
 		{
 			Name: CredAttrClientCertificateData,
@@ -34,7 +34,7 @@ func (s *credentialsSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *credentialsSuite) TestCredentialSchemas(c *gc.C) {
-	envtesting.AssertProviderAuthTypes(c, s.provider, "userpass", "certificate", "oauth2withcert")
+	envtesting.AssertProviderAuthTypes(c, s.provider, "userpass", "certificate", "oauth2", "oauth2withcert")
 }
 
 func (s *credentialsSuite) TestCredentialsValid(c *gc.C) {
@@ -48,6 +48,7 @@ func (s *credentialsSuite) TestHiddenAttributes(c *gc.C) {
 	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "userpass", "password")
 	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "oauth2withcert", "Token", "ClientKeyData")
 	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "certificate", "Token")
+	envtesting.AssertProviderCredentialsAttributesHidden(c, s.provider, "oauth2", "Token")
 }
 
 var singleConfigYAML = `
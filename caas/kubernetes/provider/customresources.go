@@ -0,0 +1,335 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/juju/juju/caas"
+)
+
+// labelCustomResources prefixes the labels of the custom resource
+// definitions of which custom resources were created for applications.
+const labelCustomResources = "juju-resources"
+
+// ensureCustomResources creates or updates the custom resources declared
+// in the pod spec, and removes any resources previously created for the
+// application which are no longer declared. The custom resource definitions
+// the resources depend on must already exist.
+func (k *kubernetesClient) ensureCustomResources(appName string, podSpec *caas.PodSpec) error {
+	wanted := make(map[string]set.Strings)
+	for crdName, resources := range podSpec.CustomResources {
+		crd, err := k.getCustomResourceDefinition(crdName)
+		if errors.IsNotFound(err) {
+			return errors.NotFoundf("custom resource definition %q required by custom resources of %q", crdName, appName)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if err := k.labelCustomResourceDefinition(appName, crd, true); err != nil {
+			return errors.Annotatef(err, "labelling custom resource definition %q", crdName)
+		}
+		names := set.NewStrings()
+		for _, cr := range resources {
+			if err := checkCustomResourceMatchesDefinition(cr, crd); err != nil {
+				return errors.Trace(err)
+			}
+			if err := k.ensureCustomResource(appName, crd, cr.DeepCopy()); err != nil {
+				return errors.Annotatef(err, "ensuring custom resource %q", cr.GetName())
+			}
+			names.Add(cr.GetName())
+		}
+		wanted[crdName] = names
+	}
+	return errors.Trace(k.pruneCustomResources(appName, wanted))
+}
+
+// deleteCustomResources deletes the custom resources which were created
+// for the specified application, of the custom resource definitions
+// labelled as having some. Definitions, or resources, the credential
+// isn't permitted to see are skipped, so they don't prevent the
+// application being removed.
+func (k *kubernetesClient) deleteCustomResources(appName string) error {
+	crds, err := k.listCustomResourceDefinitions(appName)
+	if k8serrors.IsForbidden(err) || k8serrors.IsNotFound(err) {
+		logger.Warningf("cannot list custom resource definitions to delete custom resources of %q: %v", appName, err)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		req := k.CoreV1().RESTClient().Delete().
+			AbsPath(k.customResourcePath(crd, servedVersion(crd), "")).
+			Param("labelSelector", k.customResourceSelector(appName))
+		err := req.Do().Error()
+		if k8serrors.IsForbidden(err) {
+			logger.Warningf("cannot delete custom resources of %q for %q: %v", crd.Name, appName, err)
+			continue
+		} else if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Annotatef(err, "deleting custom resources of %q", crd.Name)
+		}
+		if err := k.labelCustomResourceDefinition(appName, crd, false); err != nil {
+			return errors.Annotatef(err, "unlabelling custom resource definition %q", crd.Name)
+		}
+	}
+	return nil
+}
+
+// customResourceDefinitionLabel returns the label of the custom resource
+// definitions of which custom resources were created for the application.
+// A definition may be used by several applications, in several models, so
+// each application has its own label, qualified by the model.
+func (k *kubernetesClient) customResourceDefinitionLabel(appName string) string {
+	return fmt.Sprintf("%s.%s/%s", labelCustomResources, k.namespace, appName)
+}
+
+// listCustomResourceDefinitions returns the custom resource definitions
+// labelled as having custom resources created for the application, so
+// that the application's resources are found without walking every
+// definition in the cluster.
+func (k *kubernetesClient) listCustomResourceDefinitions(appName string) (*apiextensionsv1beta1.CustomResourceDefinitionList, error) {
+	return k.apiextensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().List(v1.ListOptions{
+		LabelSelector: k.customResourceDefinitionLabel(appName),
+	})
+}
+
+// labelCustomResourceDefinition adds, or removes, the label marking the
+// definition as having custom resources created for the application.
+// If the credential isn't permitted to update the definition, it is left
+// alone; the application's resources of the definition are then not
+// removed along with it.
+func (k *kubernetesClient) labelCustomResourceDefinition(
+	appName string, crd *apiextensionsv1beta1.CustomResourceDefinition, used bool,
+) error {
+	key := k.customResourceDefinitionLabel(appName)
+	if _, ok := crd.Labels[key]; ok == used {
+		return nil
+	}
+	crd = crd.DeepCopy()
+	if used {
+		if crd.Labels == nil {
+			crd.Labels = make(map[string]string)
+		}
+		crd.Labels[key] = "true"
+	} else {
+		delete(crd.Labels, key)
+	}
+	_, err := k.apiextensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().Update(crd)
+	if k8serrors.IsForbidden(err) {
+		logger.Warningf("cannot label custom resource definition %q for %q: %v", crd.Name, appName, err)
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// customResourceSelector returns the label selector of the custom
+// resources created for the application. Cluster scoped resources
+// aren't confined to the model's namespace, so the model is selected
+// as well as the application.
+func (k *kubernetesClient) customResourceSelector(appName string) string {
+	return fmt.Sprintf("%s,%s==%s", applicationSelector(appName), labelModel, k.namespace)
+}
+
+func (k *kubernetesClient) getCustomResourceDefinition(name string) (*apiextensionsv1beta1.CustomResourceDefinition, error) {
+	crd, err := k.apiextensionsClient.ApiextensionsV1beta1().CustomResourceDefinitions().Get(name, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return nil, errors.NotFoundf("custom resource definition %q", name)
+	}
+	return crd, errors.Trace(err)
+}
+
+func (k *kubernetesClient) ensureCustomResource(
+	appName string, crd *apiextensionsv1beta1.CustomResourceDefinition, cr *unstructured.Unstructured,
+) error {
+	labels := cr.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[labelApplication] = appName
+	labels[labelModel] = k.namespace
+	cr.SetLabels(labels)
+	if crd.Spec.Scope == apiextensionsv1beta1.NamespaceScoped {
+		cr.SetNamespace(k.namespace)
+	}
+
+	gv, err := schema.ParseGroupVersion(cr.GetAPIVersion())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	collectionPath := k.customResourcePath(crd, gv.Version, "")
+	body, err := cr.MarshalJSON()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("creating custom resource %q of %q", cr.GetName(), crd.Name)
+	err = k.CoreV1().RESTClient().Post().AbsPath(collectionPath).Body(body).Do().Error()
+	if !k8serrors.IsAlreadyExists(err) {
+		return errors.Trace(err)
+	}
+
+	resourcePath := k.customResourcePath(crd, gv.Version, cr.GetName())
+	raw, err := k.CoreV1().RESTClient().Get().AbsPath(resourcePath).Do().Raw()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var existing unstructured.Unstructured
+	if err := existing.UnmarshalJSON(raw); err != nil {
+		return errors.Trace(err)
+	}
+	existingLabels := existing.GetLabels()
+	if existingApp := existingLabels[labelApplication]; existingApp != appName {
+		return errors.AlreadyExistsf("custom resource %q owned by %q", cr.GetName(), existingApp)
+	}
+	if existingModel := existingLabels[labelModel]; existingModel != k.namespace {
+		return errors.AlreadyExistsf("custom resource %q owned by %q in model %q", cr.GetName(), appName, existingModel)
+	}
+	cr.SetResourceVersion(existing.GetResourceVersion())
+	if body, err = cr.MarshalJSON(); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("updating existing custom resource %q of %q", cr.GetName(), crd.Name)
+	return errors.Trace(k.CoreV1().RESTClient().Put().AbsPath(resourcePath).Body(body).Do().Error())
+}
+
+// pruneCustomResources deletes the custom resources which were created
+// for the application but are not wanted, keyed by the name of their
+// definition. The resources of every definition labelled as having some
+// for the application are checked, so those of definitions the pod spec
+// no longer uses are deleted too, and the definitions unlabelled. As when
+// the application is removed, definitions the credential isn't permitted
+// to see are skipped.
+func (k *kubernetesClient) pruneCustomResources(appName string, wanted map[string]set.Strings) error {
+	crds, err := k.listCustomResourceDefinitions(appName)
+	if k8serrors.IsForbidden(err) || k8serrors.IsNotFound(err) {
+		logger.Warningf("cannot list custom resource definitions to remove stale custom resources of %q: %v", appName, err)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	for i := range crds.Items {
+		crd := &crds.Items[i]
+		names, ok := wanted[crd.Name]
+		if err := k.pruneDefinitionCustomResources(appName, crd, names); err != nil {
+			return errors.Annotatef(err, "removing stale custom resources of %q", crd.Name)
+		}
+		if ok {
+			continue
+		}
+		if err := k.labelCustomResourceDefinition(appName, crd, false); err != nil {
+			return errors.Annotatef(err, "unlabelling custom resource definition %q", crd.Name)
+		}
+	}
+	return nil
+}
+
+// pruneDefinitionCustomResources deletes the custom resources of the
+// definition which were created for the application but are not in the
+// wanted set.
+func (k *kubernetesClient) pruneDefinitionCustomResources(
+	appName string, crd *apiextensionsv1beta1.CustomResourceDefinition, wanted set.Strings,
+) error {
+	raw, err := k.CoreV1().RESTClient().Get().
+		AbsPath(k.customResourcePath(crd, servedVersion(crd), "")).
+		Param("labelSelector", k.customResourceSelector(appName)).
+		Do().Raw()
+	if k8serrors.IsForbidden(err) || k8serrors.IsNotFound(err) {
+		logger.Warningf("cannot list custom resources of %q for %q: %v", crd.Name, appName, err)
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	var existing unstructured.UnstructuredList
+	if err := existing.UnmarshalJSON(raw); err != nil {
+		return errors.Trace(err)
+	}
+	for _, cr := range existing.Items {
+		if wanted.Contains(cr.GetName()) {
+			continue
+		}
+		logger.Debugf("deleting custom resource %q of %q", cr.GetName(), crd.Name)
+		err := k.CoreV1().RESTClient().Delete().
+			AbsPath(k.customResourcePath(crd, servedVersion(crd), cr.GetName())).
+			Do().Error()
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// customResourcePath returns the API path of the custom resources of the
+// definition, or of the named resource if name is not empty.
+func (k *kubernetesClient) customResourcePath(
+	crd *apiextensionsv1beta1.CustomResourceDefinition, version, name string,
+) string {
+	parts := []string{"/apis", crd.Spec.Group, version}
+	if crd.Spec.Scope == apiextensionsv1beta1.NamespaceScoped {
+		parts = append(parts, "namespaces", k.namespace)
+	}
+	parts = append(parts, crd.Spec.Names.Plural)
+	if name != "" {
+		parts = append(parts, name)
+	}
+	return path.Join(parts...)
+}
+
+// servedVersion returns a version of the custom resources
+// served by the definition.
+func servedVersion(crd *apiextensionsv1beta1.CustomResourceDefinition) string {
+	if crd.Spec.Version != "" {
+		return crd.Spec.Version
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Served {
+			return v.Name
+		}
+	}
+	return ""
+}
+
+// checkCustomResourceMatchesDefinition returns an error if the custom
+// resource is not of a kind and version served by the definition.
+func checkCustomResourceMatchesDefinition(cr unstructured.Unstructured, crd *apiextensionsv1beta1.CustomResourceDefinition) error {
+	gv, err := schema.ParseGroupVersion(cr.GetAPIVersion())
+	if err != nil {
+		return errors.NotValidf("apiVersion %q of custom resource %q", cr.GetAPIVersion(), cr.GetName())
+	}
+	if gv.Group != crd.Spec.Group {
+		return errors.NotValidf(
+			"custom resource %q with group %q for definition %q of group %q",
+			cr.GetName(), gv.Group, crd.Name, crd.Spec.Group,
+		)
+	}
+	if cr.GetKind() != crd.Spec.Names.Kind {
+		return errors.NotValidf(
+			"custom resource %q of kind %q for definition %q of kind %q",
+			cr.GetName(), cr.GetKind(), crd.Name, crd.Spec.Names.Kind,
+		)
+	}
+	served := set.NewStrings()
+	if crd.Spec.Version != "" {
+		served.Add(crd.Spec.Version)
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Served {
+			served.Add(v.Name)
+		}
+	}
+	if !served.Contains(gv.Version) {
+		return errors.NotValidf(
+			"custom resource %q version %q not served by definition %q (served versions %v)",
+			cr.GetName(), gv.Version, crd.Name, served.SortedValues(),
+		)
+	}
+	return nil
+}
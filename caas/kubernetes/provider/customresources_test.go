@@ -0,0 +1,302 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/golang/mock/gomock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	core "k8s.io/api/core/v1"
+	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/rest"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/testing"
+)
+
+type CustomResourcesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&CustomResourcesSuite{})
+
+var tfJobDefinition = &apiextensionsv1beta1.CustomResourceDefinition{
+	ObjectMeta: v1.ObjectMeta{Name: "tfjobs.kubeflow.org"},
+	Spec: apiextensionsv1beta1.CustomResourceDefinitionSpec{
+		Group: "kubeflow.org",
+		Names: apiextensionsv1beta1.CustomResourceDefinitionNames{
+			Kind:   "TFJob",
+			Plural: "tfjobs",
+		},
+		Scope: apiextensionsv1beta1.NamespaceScoped,
+		Versions: []apiextensionsv1beta1.CustomResourceDefinitionVersion{
+			{Name: "v1alpha2", Served: true, Storage: true},
+			{Name: "v1alpha1", Served: false},
+		},
+	},
+}
+
+func tfJob(apiVersion, kind string) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]interface{}{"name": "mnist"},
+	}}
+}
+
+func (s *CustomResourcesSuite) TestCheckCustomResource(c *gc.C) {
+	err := provider.CheckCustomResource(tfJob("kubeflow.org/v1alpha2", "TFJob"), tfJobDefinition)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CustomResourcesSuite) TestCheckCustomResourceMismatch(c *gc.C) {
+	for _, t := range []struct {
+		cr     unstructured.Unstructured
+		expect string
+	}{{
+		cr:     tfJob("example.com/v1alpha2", "TFJob"),
+		expect: `custom resource "mnist" with group "example.com" for definition "tfjobs.kubeflow.org" of group "kubeflow.org" not valid`,
+	}, {
+		cr:     tfJob("kubeflow.org/v1alpha2", "PyTorchJob"),
+		expect: `custom resource "mnist" of kind "PyTorchJob" for definition "tfjobs.kubeflow.org" of kind "TFJob" not valid`,
+	}, {
+		cr:     tfJob("kubeflow.org/v1alpha1", "TFJob"),
+		expect: `custom resource "mnist" version "v1alpha1" not served by definition "tfjobs.kubeflow.org" \(served versions \[v1alpha2\]\) not valid`,
+	}} {
+		err := provider.CheckCustomResource(t.cr, tfJobDefinition)
+		c.Check(err, gc.ErrorMatches, t.expect)
+	}
+}
+
+// fakeHTTPClient records the requests made through the REST client and
+// responds to each with the next of its response bodies.
+type fakeHTTPClient struct {
+	requests  []*http.Request
+	responses []string
+}
+
+func (f *fakeHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	f.requests = append(f.requests, req)
+	body := "{}"
+	if len(f.responses) > 0 {
+		body, f.responses = f.responses[0], f.responses[1:]
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}, nil
+}
+
+func (f *fakeHTTPClient) request(verb string) *rest.Request {
+	return rest.NewRequest(f, verb, &url.URL{Path: "/"}, "", rest.ContentConfig{}, rest.Serializers{}, nil, nil, 0)
+}
+
+type CustomResourcesBrokerSuite struct {
+	BaseSuite
+	http *fakeHTTPClient
+}
+
+var _ = gc.Suite(&CustomResourcesBrokerSuite{})
+
+func (s *CustomResourcesBrokerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.http = &fakeHTTPClient{}
+}
+
+func (s *CustomResourcesBrokerSuite) clusterScopedDefinition() *apiextensionsv1beta1.CustomResourceDefinition {
+	crd := tfJobDefinition.DeepCopy()
+	crd.Spec.Scope = apiextensionsv1beta1.ClusterScoped
+	return crd
+}
+
+// labelledDefinition returns the cluster scoped definition, labelled as
+// having custom resources of the application, or unlabelled once it no
+// longer has any.
+func (s *CustomResourcesBrokerSuite) labelledDefinition(appName string, labelled bool) *apiextensionsv1beta1.CustomResourceDefinition {
+	crd := s.clusterScopedDefinition()
+	crd.Labels = map[string]string{}
+	if labelled {
+		crd.Labels["juju-resources.test/"+appName] = "true"
+	}
+	return crd
+}
+
+func (s *CustomResourcesBrokerSuite) TestEnsureCustomResourcesLabelsModel(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.http.responses = []string{"{}", `{
+		"apiVersion": "kubeflow.org/v1alpha2",
+		"kind": "TFJobList",
+		"items": [
+			{"apiVersion": "kubeflow.org/v1alpha2", "kind": "TFJob", "metadata": {"name": "mnist"}},
+			{"apiVersion": "kubeflow.org/v1alpha2", "kind": "TFJob", "metadata": {"name": "stale"}}
+		]
+	}`}
+	gomock.InOrder(
+		s.mockCustomResourceDefinition.EXPECT().Get("tfjobs.kubeflow.org", v1.GetOptions{}).Times(1).
+			Return(s.clusterScopedDefinition(), nil),
+		s.mockCustomResourceDefinition.EXPECT().Update(s.labelledDefinition("app-name", true)).Times(1).
+			Return(s.labelledDefinition("app-name", true), nil),
+		s.mockRestClient.EXPECT().Post().Times(1).Return(s.http.request("POST")),
+		s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{LabelSelector: "juju-resources.test/app-name"}).Times(1).
+			Return(&apiextensionsv1beta1.CustomResourceDefinitionList{
+				Items: []apiextensionsv1beta1.CustomResourceDefinition{*s.labelledDefinition("app-name", true)},
+			}, nil),
+		s.mockRestClient.EXPECT().Get().Times(1).Return(s.http.request("GET")),
+		s.mockRestClient.EXPECT().Delete().Times(1).Return(s.http.request("DELETE")),
+	)
+
+	err := s.broker.EnsureCustomResourceDefinition("app-name", &caas.PodSpec{
+		CustomResources: map[string][]unstructured.Unstructured{
+			"tfjobs.kubeflow.org": {tfJob("kubeflow.org/v1alpha2", "TFJob")},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.http.requests, gc.HasLen, 3)
+
+	created, err := ioutil.ReadAll(s.http.requests[0].Body)
+	c.Assert(err, jc.ErrorIsNil)
+	var cr unstructured.Unstructured
+	c.Assert(cr.UnmarshalJSON(created), jc.ErrorIsNil)
+	c.Assert(cr.GetLabels(), jc.DeepEquals, map[string]string{
+		"juju-app":   "app-name",
+		"juju-model": "test",
+	})
+
+	list := s.http.requests[1].URL
+	c.Assert(list.Path, gc.Equals, "/apis/kubeflow.org/v1alpha2/tfjobs")
+	c.Assert(list.Query().Get("labelSelector"), gc.Equals, "juju-app==app-name,juju-model==test")
+	c.Assert(s.http.requests[2].URL.Path, gc.Equals, "/apis/kubeflow.org/v1alpha2/tfjobs/stale")
+}
+
+func (s *CustomResourcesBrokerSuite) TestEnsureCustomResourcesPrunesUndeclaredDefinitions(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.http.responses = []string{`{
+		"apiVersion": "kubeflow.org/v1alpha2",
+		"kind": "TFJobList",
+		"items": [
+			{"apiVersion": "kubeflow.org/v1alpha2", "kind": "TFJob", "metadata": {"name": "mnist"}}
+		]
+	}`}
+	gomock.InOrder(
+		s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{LabelSelector: "juju-resources.test/app-name"}).Times(1).
+			Return(&apiextensionsv1beta1.CustomResourceDefinitionList{
+				Items: []apiextensionsv1beta1.CustomResourceDefinition{*s.labelledDefinition("app-name", true)},
+			}, nil),
+		s.mockRestClient.EXPECT().Get().Times(1).Return(s.http.request("GET")),
+		s.mockRestClient.EXPECT().Delete().Times(1).Return(s.http.request("DELETE")),
+		s.mockCustomResourceDefinition.EXPECT().Update(s.labelledDefinition("app-name", false)).Times(1).
+			Return(s.labelledDefinition("app-name", false), nil),
+	)
+
+	// The pod spec no longer declares any custom resources,
+	// so those created before are deleted, and the definition
+	// is no longer labelled as having any.
+	err := s.broker.EnsureCustomResourceDefinition("app-name", &caas.PodSpec{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.http.requests, gc.HasLen, 2)
+	c.Assert(s.http.requests[1].URL.Path, gc.Equals, "/apis/kubeflow.org/v1alpha2/tfjobs/mnist")
+}
+
+func (s *CustomResourcesBrokerSuite) TestEnsureCustomResourcesPruneForbidden(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{LabelSelector: "juju-resources.test/app-name"}).Times(1).
+		Return(nil, s.k8sForbiddenError())
+
+	err := s.broker.EnsureCustomResourceDefinition("app-name", &caas.PodSpec{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CustomResourcesBrokerSuite) TestDeleteServiceDeletesModelCustomResources(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==test"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{LabelSelector: "juju-resources.test/test"}).Times(1).
+			Return(&apiextensionsv1beta1.CustomResourceDefinitionList{
+				Items: []apiextensionsv1beta1.CustomResourceDefinition{*s.labelledDefinition("test", true)},
+			}, nil),
+		s.mockRestClient.EXPECT().Delete().Times(1).Return(s.http.request("DELETE")),
+		s.mockCustomResourceDefinition.EXPECT().Update(s.labelledDefinition("test", false)).Times(1).
+			Return(s.labelledDefinition("test", false), nil),
+	)
+
+	err := s.broker.DeleteService("test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.http.requests, gc.HasLen, 1)
+	deleted := s.http.requests[0].URL
+	c.Assert(deleted.Path, gc.Equals, "/apis/kubeflow.org/v1alpha2/tfjobs")
+	c.Assert(deleted.Query().Get("labelSelector"), gc.Equals, "juju-app==test,juju-model==test")
+}
+
+func (s *CustomResourcesBrokerSuite) TestDeleteServiceCustomResourcesForbidden(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
+			Return(s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==test"}).Times(1).
+			Return(&core.SecretList{}, nil),
+		s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{LabelSelector: "juju-resources.test/test"}).Times(1).
+			Return(nil, s.k8sForbiddenError()),
+	)
+
+	err := s.broker.DeleteService("test")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CustomResourcesBrokerSuite) TestEnsureCustomResourcesLabelForbidden(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockCustomResourceDefinition.EXPECT().Get("tfjobs.kubeflow.org", v1.GetOptions{}).Times(1).
+			Return(s.clusterScopedDefinition(), nil),
+		s.mockCustomResourceDefinition.EXPECT().Update(s.labelledDefinition("app-name", true)).Times(1).
+			Return(nil, s.k8sForbiddenError()),
+		s.mockRestClient.EXPECT().Post().Times(1).Return(s.http.request("POST")),
+		s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{LabelSelector: "juju-resources.test/app-name"}).Times(1).
+			Return(&apiextensionsv1beta1.CustomResourceDefinitionList{}, nil),
+	)
+
+	// The custom resources are still created without
+	// permission to label their definition.
+	err := s.broker.EnsureCustomResourceDefinition("app-name", &caas.PodSpec{
+		CustomResources: map[string][]unstructured.Unstructured{
+			"tfjobs.kubeflow.org": {tfJob("kubeflow.org/v1alpha2", "TFJob")},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.http.requests, gc.HasLen, 1)
+}
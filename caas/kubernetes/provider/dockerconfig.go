@@ -37,18 +37,22 @@ type DockerConfigEntry struct {
 }
 
 func createDockerConfigJSON(imageDetails *caas.ImageDetails) ([]byte, error) {
-	dockerEntry := DockerConfigEntry{
-		Username: imageDetails.Username,
-		Password: imageDetails.Password,
-	}
 	registryURL, err := extractRegistryURL(imageDetails.ImagePath)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	return registryDockerConfigJSON(registryURL, imageDetails.Username, imageDetails.Password)
+}
 
+// registryDockerConfigJSON returns the dockerconfigjson content
+// holding the specified credentials for a single registry.
+func registryDockerConfigJSON(registryURL, username, password string) ([]byte, error) {
 	dockerConfig := DockerConfigJson{
 		Auths: map[string]DockerConfigEntry{
-			registryURL: dockerEntry,
+			registryURL: {
+				Username: username,
+				Password: password,
+			},
 		},
 	}
 	return json.Marshal(dockerConfig)
@@ -67,3 +67,24 @@ func (s *DockerConfigSuite) TestCreateDockerConfigJSON(c *gc.C) {
 		},
 	})
 }
+
+func (s *DockerConfigSuite) TestMirrorOperatorImagePath(c *gc.C) {
+	for _, t := range []struct {
+		imagePath string
+		mirror    string
+		expected  string
+	}{{
+		imagePath: "jujusolutions/jujud-operator:2.6.0",
+		expected:  "jujusolutions/jujud-operator:2.6.0",
+	}, {
+		imagePath: "jujusolutions/jujud-operator:2.6.0",
+		mirror:    "registry.example.com:5000/juju/",
+		expected:  "registry.example.com:5000/juju/jujud-operator:2.6.0",
+	}, {
+		imagePath: "jujud-operator",
+		mirror:    "localhost:32000",
+		expected:  "localhost:32000/jujud-operator",
+	}} {
+		c.Check(provider.MirrorOperatorImagePath(t.imagePath, t.mirror), gc.Equals, t.expected)
+	}
+}
@@ -25,6 +25,8 @@ var (
 	OperatorPod              = operatorPod
 	ExtractRegistryURL       = extractRegistryURL
 	CreateDockerConfigJSON   = createDockerConfigJSON
+	MirrorOperatorImagePath  = mirrorOperatorImagePath
+	CheckCustomResource      = checkCustomResourceMatchesDefinition
 	NewStorageConfig         = newStorageConfig
 	NewKubernetesWatcher     = newKubernetesWatcher
 	CompileK8sCloudCheckers  = compileK8sCloudCheckers
@@ -33,6 +35,8 @@ var (
 	GetLocalMicroK8sConfig   = getLocalMicroK8sConfig
 	AttemptMicroK8sCloud     = attemptMicroK8sCloud
 	EnsureMicroK8sSuitable   = ensureMicroK8sSuitable
+	ConfigurePodAntiAffinity = configurePodAntiAffinity
+	KeepNodePorts            = keepNodePorts
 )
 
 type (
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"reflect"
 	"regexp"
 	"sort"
 	"strconv"
@@ -73,6 +74,15 @@ const (
 	gpuAffinityNodeSelectorKey = "gpu"
 
 	annotationPrefix = "juju.io"
+
+	// modelImagePullSecretName is the name of the secret holding
+	// the image registry credentials configured for the model.
+	modelImagePullSecretName = "juju-image-pull-secret"
+
+	// operatorContainerName and controllerContainerName are the names
+	// of the containers the operator resource settings apply to.
+	operatorContainerName   = "juju-operator"
+	controllerContainerName = "api-server"
 )
 
 var (
@@ -102,6 +112,10 @@ type kubernetesClient struct {
 
 	// newWatcher is the k8s watcher generator.
 	newWatcher NewK8sWatcherFunc
+
+	// capabilities records which cluster scoped resources the
+	// credential may use, once they have been probed.
+	capabilities *clusterCapabilities
 }
 
 // To regenerate the mocks for the kubernetes Client used by this broker,
@@ -180,21 +194,116 @@ func (k *kubernetesClient) Config() *config.Config {
 
 // SetConfig is specified in the Environ interface.
 func (k *kubernetesClient) SetConfig(cfg *config.Config) error {
+	oldCfg, newCfg, err := k.swapConfig(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if oldCfg == nil {
+		return nil
+	}
+	if imageRegistryConfigChanged(oldCfg, newCfg) {
+		// The new config is in effect whether or not the secret can
+		// be updated now; it's written again when an operator or
+		// application is next deployed.
+		if err := k.rotateModelImagePullSecret(); err != nil {
+			logger.Warningf("cannot update image pull secret: %v", err)
+		}
+	}
+	if operatorResourcesConfigChanged(oldCfg, newCfg) {
+		// As above, the new resources are in effect regardless;
+		// they're set when an operator is next ensured.
+		if err := k.updateOperatorResources(); err != nil {
+			logger.Warningf("cannot update operator resources: %v", err)
+		}
+	}
+	return nil
+}
+
+// swapConfig validates and sets the environ config, returning the
+// previous and the new config.
+func (k *kubernetesClient) swapConfig(cfg *config.Config) (*config.Config, *config.Config, error) {
 	k.lock.Lock()
 	defer k.lock.Unlock()
 	newCfg, err := providerInstance.newConfig(cfg)
 	if err != nil {
-		return errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
+	oldCfg := k.envCfg
 	k.envCfg = newCfg.Config
+	return oldCfg, newCfg.Config, nil
+}
+
+// updateOperatorResources sets the configured resource requests and
+// limits on the existing operator statefulsets, and on the controller
+// statefulset in the controller model, so their pods are recreated with
+// them.
+func (k *kubernetesClient) updateOperatorResources() error {
+	resources, err := k.brokerConfig().operatorResources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	statefulsets := k.AppsV1().StatefulSets(k.namespace)
+	operators, err := statefulsets.List(v1.ListOptions{
+		LabelSelector:        labelOperator,
+		IncludeUninitialized: true,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for i := range operators.Items {
+		if err := k.updateContainerResources(&operators.Items[i], operatorContainerName, resources); err != nil {
+			return errors.Annotatef(err, "updating operator %q", operators.Items[i].Name)
+		}
+	}
+	if k.Config().Name() != environsbootstrap.ControllerModelName {
+		return nil
+	}
+	controller, err := statefulsets.Get(JujuControllerStackName, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Annotate(
+		k.updateContainerResources(controller, controllerContainerName, resources),
+		"updating controller",
+	)
+}
+
+// updateContainerResources updates the statefulset if the resources of
+// the named container differ from those given.
+func (k *kubernetesClient) updateContainerResources(
+	statefulset *apps.StatefulSet, containerName string, resources core.ResourceRequirements,
+) error {
+	containers := statefulset.Spec.Template.Spec.Containers
+	for i := range containers {
+		if containers[i].Name != containerName {
+			continue
+		}
+		if reflect.DeepEqual(containers[i].Resources, resources) {
+			return nil
+		}
+		containers[i].Resources = resources
+		_, err := k.AppsV1().StatefulSets(k.namespace).Update(statefulset)
+		return errors.Trace(err)
+	}
 	return nil
 }
 
+func (k *kubernetesClient) brokerConfig() *brokerConfig {
+	cfg := k.Config()
+	return &brokerConfig{Config: cfg, attrs: cfg.UnknownAttrs()}
+}
+
 func (k *kubernetesClient) validateOperatorStorage() (string, error) {
 	storageClass, _ := k.envCfg.AllAttrs()[OperatorStorageKey].(string)
 	if storageClass == "" {
 		return "", errors.NewNotValid(nil, "config without operator-storage value not valid.\nRun juju add-k8s to reimport your k8s cluster.")
 	}
+	if !k.knownCapabilities().StorageClasses {
+		logger.Debugf("not permitted to check operator storage class %q", storageClass)
+		return storageClass, nil
+	}
 	_, err := k.getStorageClass(storageClass)
 	return storageClass, errors.Trace(err)
 }
@@ -206,6 +315,10 @@ func (k *kubernetesClient) PrepareForBootstrap(ctx environs.BootstrapContext, co
 Please bootstrap again and choose a different controller name.`, k.namespace),
 	)
 
+	if namespace := k.brokerConfig().controllerNamespace(); namespace != "" {
+		return errors.Trace(k.prepareForBootstrapInNamespace(ctx, namespace))
+	}
+
 	k.namespace = DecideControllerNamespace(controllerName)
 
 	// ensure no existing namespace has the same name.
@@ -213,6 +326,10 @@ Please bootstrap again and choose a different controller name.`, k.namespace),
 	if err == nil {
 		return alreadyExistErr
 	}
+	if k8serrors.IsForbidden(errors.Cause(err)) {
+		return errors.Annotatef(err,
+			"cannot create a namespace for the controller, to use an existing namespace set %q", ControllerNamespaceKey)
+	}
 	if !errors.IsNotFound(err) {
 		return errors.Trace(err)
 	}
@@ -235,6 +352,43 @@ Please bootstrap again and choose a different controller name.`, k.namespace),
 	return errors.Trace(err)
 }
 
+// prepareForBootstrapInNamespace prepares for bootstraping a controller
+// into an existing namespace. The credential may only be permitted to
+// use resources in that namespace, in which case the controller does
+// without the cluster scoped resources it can't use.
+func (k *kubernetesClient) prepareForBootstrapInNamespace(ctx environs.BootstrapContext, namespace string) error {
+	caps, err := k.clusterCapabilities()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if caps.restricted() {
+		ctx.Infof("The credential is not permitted to use these cluster wide resources:")
+		for _, missing := range caps.missing() {
+			ctx.Infof("  - %s", missing)
+		}
+	}
+
+	k.namespace = namespace
+	if caps.Namespaces {
+		ns, err := k.getNamespaceByName(namespace)
+		if errors.IsNotFound(err) {
+			return errors.NewNotFound(nil, fmt.Sprintf(
+				"namespace %q not found, it must be created before bootstrapping into it", namespace))
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, ok := ns.GetAnnotations()[annotationControllerUUIDKey]; ok {
+			return errors.NewAlreadyExists(nil, fmt.Sprintf(
+				"namespace %q is already used by a Juju controller or model", namespace))
+		}
+	}
+
+	// do validation on storage class.
+	_, err = k.validateOperatorStorage()
+	return errors.Trace(err)
+}
+
 // Create implements environs.BootstrapEnviron.
 func (k *kubernetesClient) Create(context.ProviderCallContext, environs.CreateParams) error {
 	// must raise errors.AlreadyExistsf if it's already exist.
@@ -270,6 +424,10 @@ func (k *kubernetesClient) Bootstrap(
 
 		// validate hosted model name if we need to create it.
 		if hostedModelName, has := pcfg.GetHostedModel(); has {
+			if !k.knownCapabilities().Namespaces {
+				return errors.Errorf(
+					"cannot add model %q without permission to create namespaces, bootstrap with --no-default-model", hostedModelName)
+			}
 			_, err := k.getNamespaceByName(hostedModelName)
 			if err == nil {
 				return errors.NewAlreadyExists(nil,
@@ -286,6 +444,12 @@ please choose a different hosted model name then try again.`, hostedModelName),
 
 		// we use controller name to name controller namespace in bootstrap time.
 		setControllerNamespace := func(controllerName string, broker *kubernetesClient) error {
+			if nsName := broker.brokerConfig().controllerNamespace(); nsName != "" {
+				// the existing namespace was checked in broker.PrepareForBootstrap.
+				broker.SetNamespace(nsName)
+				_ = broker.addAnnotations(annotationControllerIsControllerKey, "true")
+				return nil
+			}
 			nsName := DecideControllerNamespace(controllerName)
 
 			_, err := broker.GetNamespace(nsName)
@@ -344,6 +508,9 @@ func (*kubernetesClient) Provider() caas.ContainerEnvironProvider {
 
 // Destroy is part of the Broker interface.
 func (k *kubernetesClient) Destroy(callbacks context.ProviderCallContext) error {
+	if k.brokerConfig().controllerNamespace() != "" {
+		return errors.Trace(k.destroyControllerStack())
+	}
 	watcher, err := k.WatchNamespace()
 	if err != nil {
 		return errors.Trace(err)
@@ -430,6 +597,77 @@ func (k *kubernetesClient) ensureOCIImageSecret(
 	return errors.Trace(k.ensureSecret(newSecret))
 }
 
+// ensureModelImagePullSecret creates or updates the secret holding the image
+// registry credentials configured for the model and returns its name. The
+// registry of imagePath is used if the model does not specify one. If no
+// credentials are configured, an empty name is returned.
+func (k *kubernetesClient) ensureModelImagePullSecret(imagePath string) (string, error) {
+	cfg := k.brokerConfig()
+	if _, password := cfg.imageRegistryCredentials(); password == "" {
+		return "", nil
+	}
+	registryURL := cfg.imageRegistry()
+	if registryURL == "" {
+		var err error
+		if registryURL, err = extractRegistryURL(imagePath); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	if err := k.writeModelImagePullSecret(cfg, registryURL); err != nil {
+		return "", errors.Trace(err)
+	}
+	return modelImagePullSecretName, nil
+}
+
+// rotateModelImagePullSecret brings an existing model image pull secret
+// in line with the current model config. The secret is deleted if the
+// credentials have been removed; if no secret exists yet, it is created
+// when an operator or application is next deployed.
+func (k *kubernetesClient) rotateModelImagePullSecret() error {
+	cfg := k.brokerConfig()
+	if _, password := cfg.imageRegistryCredentials(); password == "" {
+		return errors.Trace(k.deleteSecret(modelImagePullSecretName))
+	}
+	existing, err := k.getSecret(modelImagePullSecretName)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	registryURL := cfg.imageRegistry()
+	if registryURL == "" {
+		// Keep using the registry the secret was created for.
+		var dockerConfig DockerConfigJson
+		if err := json.Unmarshal(existing.Data[core.DockerConfigJsonKey], &dockerConfig); err != nil {
+			return errors.Annotate(err, "parsing existing image pull secret")
+		}
+		for url := range dockerConfig.Auths {
+			registryURL = url
+		}
+	}
+	return errors.Trace(k.writeModelImagePullSecret(cfg, registryURL))
+}
+
+func (k *kubernetesClient) writeModelImagePullSecret(cfg *brokerConfig, registryURL string) error {
+	username, password := cfg.imageRegistryCredentials()
+	secretData, err := registryDockerConfigJSON(registryURL, username, password)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newSecret := &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        modelImagePullSecretName,
+			Namespace:   k.namespace,
+			Labels:      map[string]string{labelModel: k.namespace},
+			Annotations: k.annotations.Copy().ToMap()},
+		Type: core.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			core.DockerConfigJsonKey: secretData,
+		},
+	}
+	return errors.Trace(k.ensureSecret(newSecret))
+}
+
 func (k *kubernetesClient) ensureSecret(sec *core.Secret) error {
 	secrets := k.CoreV1().Secrets(k.namespace)
 	_, err := secrets.Update(sec)
@@ -551,17 +789,29 @@ func (k *kubernetesClient) EnsureOperator(appName, agentPath string, config *caa
 			Annotations: resourceTagsToAnnotations(config.CharmStorage.ResourceTags).ToMap()},
 		Spec: *pvcSpec,
 	}
+	brokerCfg := k.brokerConfig()
+	operatorImagePath := mirrorOperatorImagePath(config.OperatorImagePath, brokerCfg.operatorImageMirror())
 	pod, err := operatorPod(
 		operatorName,
 		appName,
 		agentPath,
-		config.OperatorImagePath,
+		operatorImagePath,
 		config.Version.String(),
 		annotations.Copy(),
 	)
 	if err != nil {
 		return errors.Annotate(err, "generating operator podspec")
 	}
+	if pod.Spec.Containers[0].Resources, err = brokerCfg.operatorResources(); err != nil {
+		return errors.Annotate(err, "generating operator podspec")
+	}
+	pullSecretName, err := k.ensureModelImagePullSecret(operatorImagePath)
+	if err != nil {
+		return errors.Annotate(err, "creating image pull secret")
+	}
+	if pullSecretName != "" {
+		pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets, core.LocalObjectReference{Name: pullSecretName})
+	}
 	// Take a copy for use with statefulset.
 	podWithoutStorage := pod
 
@@ -633,14 +883,20 @@ func (k *kubernetesClient) maybeGetVolumeClaimSpec(params volumeParams) (*core.P
 	if storageClassName == "" {
 		return nil, errors.New("cannot create a volume claim spec without a storage class")
 	}
-	// See if the requested storage class exists already.
-	sc, err := k.getStorageClass(storageClassName)
-	if err != nil && !k8serrors.IsNotFound(err) {
-		return nil, errors.Annotatef(err, "looking for storage class %q", storageClassName)
-	}
-	if err == nil {
+	if !k.knownCapabilities().StorageClasses {
+		// We may not look for the storage class, so trust it exists.
+		logger.Debugf("not permitted to check storage class %q", storageClassName)
 		haveStorageClass = true
-		storageClassName = sc.Name
+	} else {
+		// See if the requested storage class exists already.
+		sc, err := k.getStorageClass(storageClassName)
+		if err != nil && !k8serrors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "looking for storage class %q", storageClassName)
+		}
+		if err == nil {
+			haveStorageClass = true
+			storageClassName = sc.Name
+		}
 	}
 	if !haveStorageClass {
 		params.storageConfig.storageClass = storageClassName
@@ -973,11 +1229,16 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 			return errors.Trace(err)
 		}
 	}
-	return nil
+	return errors.Trace(k.deleteCustomResources(appName))
 }
 
-// EnsureCustomResourceDefinition creates or updates a custom resource definition resource.
+// EnsureCustomResourceDefinition creates or updates the custom resource
+// definitions of the pod spec, followed by its custom resources, and
+// removes the application's custom resources no longer declared.
 func (k *kubernetesClient) EnsureCustomResourceDefinition(appName string, podSpec *caas.PodSpec) error {
+	if len(podSpec.CustomResourceDefinitions) > 0 && !k.knownCapabilities().CustomResourceDefinitions {
+		return errors.NotSupportedf("custom resource definitions without permission to manage them")
+	}
 	for name, crd := range podSpec.CustomResourceDefinitions {
 		crd, err := k.ensureCustomResourceDefinitionTemplate(name, crd)
 		if err != nil {
@@ -985,7 +1246,7 @@ func (k *kubernetesClient) EnsureCustomResourceDefinition(appName string, podSpe
 		}
 		logger.Debugf("ensured custom resource definition %q", crd.ObjectMeta.Name)
 	}
-	return nil
+	return errors.Trace(k.ensureCustomResources(appName, podSpec))
 }
 
 func (k *kubernetesClient) ensureCustomResourceDefinitionTemplate(name string, spec apiextensionsv1beta1.CustomResourceDefinitionSpec) (
@@ -1007,6 +1268,8 @@ func (k *kubernetesClient) ensureCustomResourceDefinitionTemplate(name string, s
 		}
 		resourceVersion := crd.ObjectMeta.GetResourceVersion()
 		crdIn.ObjectMeta.SetResourceVersion(resourceVersion)
+		// Keep the labels of the applications using the definition.
+		crdIn.ObjectMeta.SetLabels(crd.ObjectMeta.GetLabels())
 		logger.Debugf("existing crd with resource version %q found, so update it %#v", resourceVersion, crdIn)
 		crd, err = apiextensionsV1beta1.CustomResourceDefinitions().Update(crdIn)
 	}
@@ -1138,6 +1401,13 @@ func (k *kubernetesClient) EnsureService(
 				},
 			},
 		}
+		if osNames, ok := affinityTags[osNodeLabel]; ok {
+			unitSpec.Pod.Tolerations = append(unitSpec.Pod.Tolerations,
+				nodePoolTolerations(osNodeLabel, strings.Split(osNames, "|"))...)
+		}
+	}
+	if params.Constraints.Arch != nil {
+		configureArchConstraint(&unitSpec.Pod, *params.Constraints.Arch)
 	}
 	if params.Constraints.Zones != nil {
 		zones := *params.Constraints.Zones
@@ -1155,11 +1425,14 @@ func (k *kubernetesClient) EnsureService(
 		nodeSelector := &affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0]
 		nodeSelector.MatchExpressions = append(nodeSelector.MatchExpressions,
 			core.NodeSelectorRequirement{
-				Key:      "failure-domain.beta.kubernetes.io/zone",
+				Key:      zoneNodeLabel,
 				Operator: core.NodeSelectorOpIn,
 				Values:   zones,
 			})
 	}
+	if err = configurePodAntiAffinity(&unitSpec.Pod, appName, config); err != nil {
+		return errors.Annotatef(err, "configuring pod anti-affinity for %s", appName)
+	}
 
 	annotations := resourceTagsToAnnotations(params.ResourceTags)
 
@@ -1173,6 +1446,17 @@ func (k *kubernetesClient) EnsureService(
 		}
 		cleanups = append(cleanups, func() { k.deleteSecret(imageSecretName) })
 	}
+	if len(unitSpec.Pod.Containers) > 0 {
+		// Workload images may also come from the registry the model has credentials for.
+		var pullSecretName string
+		pullSecretName, err = k.ensureModelImagePullSecret(unitSpec.Pod.Containers[0].Image)
+		if err != nil {
+			return errors.Annotate(err, "creating image pull secret")
+		}
+		if pullSecretName != "" {
+			unitSpec.Pod.ImagePullSecrets = append(unitSpec.Pod.ImagePullSecrets, core.LocalObjectReference{Name: pullSecretName})
+		}
+	}
 	// Add a deployment controller or stateful set configured to create the specified number of units/pods.
 	// Defensively check to see if a stateful set is already used.
 	useStatefulSet := len(params.Filesystems) > 0
@@ -1697,6 +1981,7 @@ func (k *kubernetesClient) ensureK8sService(spec *core.Service) error {
 	if err == nil {
 		spec.Spec.ClusterIP = existing.Spec.ClusterIP
 		spec.ObjectMeta.ResourceVersion = existing.ObjectMeta.ResourceVersion
+		keepNodePorts(spec, existing)
 	}
 	_, err = services.Update(spec)
 	if k8serrors.IsNotFound(err) {
@@ -1705,6 +1990,26 @@ func (k *kubernetesClient) ensureK8sService(spec *core.Service) error {
 	return errors.Trace(err)
 }
 
+// keepNodePorts copies the node ports allocated to the existing
+// service onto the matching ports of the new spec, so that updating a
+// NodePort or LoadBalancer service doesn't move its ports.
+func keepNodePorts(spec, existing *core.Service) {
+	if spec.Spec.Type != core.ServiceTypeNodePort && spec.Spec.Type != core.ServiceTypeLoadBalancer {
+		return
+	}
+	for i, port := range spec.Spec.Ports {
+		if port.NodePort != 0 {
+			continue
+		}
+		for _, existingPort := range existing.Spec.Ports {
+			if existingPort.Port == port.Port && existingPort.Protocol == port.Protocol {
+				spec.Spec.Ports[i].NodePort = existingPort.NodePort
+				break
+			}
+		}
+	}
+}
+
 // deleteService deletes a service resource.
 func (k *kubernetesClient) deleteService(deploymentName string) error {
 	services := k.CoreV1().Services(k.namespace)
@@ -1770,10 +2075,17 @@ func (k *kubernetesClient) ExposeService(appName string, resourceTags map[string
 				}}},
 		},
 	}
+	if tlsSecret := config.GetString(ingressTLSSecretKey, ""); tlsSecret != "" {
+		spec.Spec.TLS = []v1beta1.IngressTLS{{
+			Hosts:      []string{host},
+			SecretName: tlsSecret,
+		}}
+	}
 	return k.ensureIngress(spec)
 }
 
 // UnexposeService removes external access to the specified service.
+// Any ingress resource created when the service was exposed is removed.
 func (k *kubernetesClient) UnexposeService(appName string) error {
 	logger.Debugf("deleting ingress resource for %s", appName)
 	return k.deleteIngress(appName)
@@ -2311,7 +2623,7 @@ func operatorPod(podName, appName, agentPath, operatorImagePath, version string,
 		},
 		Spec: core.PodSpec{
 			Containers: []core.Container{{
-				Name:            "juju-operator",
+				Name:            operatorContainerName,
 				ImagePullPolicy: core.PullIfNotPresent,
 				Image:           operatorImagePath,
 				WorkingDir:      jujuDataDir,
@@ -2546,6 +2858,16 @@ func applicationConfigMapName(deploymentName, fileSetName string) string {
 	return fmt.Sprintf("%v-%v-config", deploymentName, fileSetName)
 }
 
+// mirrorOperatorImagePath returns the operator image path rewritten to
+// use the specified mirror repository, keeping the image name and tag.
+func mirrorOperatorImagePath(imagePath, mirror string) string {
+	if mirror == "" {
+		return imagePath
+	}
+	nameAndTag := imagePath[strings.LastIndex(imagePath, "/")+1:]
+	return strings.TrimSuffix(mirror, "/") + "/" + nameAndTag
+}
+
 func appSecretName(deploymentName, containerName string) string {
 	// A pod may have multiple containers with different images and thus different secrets
 	return deploymentName + "-" + containerName + "-secret"
@@ -4,6 +4,7 @@
 package provider_test
 
 import (
+	"encoding/json"
 	"net/url"
 	"strings"
 	"time"
@@ -18,6 +19,7 @@ import (
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
 	core "k8s.io/api/core/v1"
+	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	k8sstorage "k8s.io/api/storage/v1"
 	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
@@ -36,6 +38,7 @@ import (
 	"github.com/juju/juju/core/devices"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	envtesting "github.com/juju/juju/environs/testing"
 	"github.com/juju/juju/storage"
@@ -443,6 +446,72 @@ func (s *K8sBrokerSuite) TestSetConfig(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) imagePullSecretArg(c *gc.C, password string) *core.Secret {
+	secretData, err := json.Marshal(provider.DockerConfigJson{
+		Auths: provider.DockerConfig{
+			"registry.example.com": {Username: "fred", Password: password},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return &core.Secret{
+		ObjectMeta: v1.ObjectMeta{
+			Name:      "juju-image-pull-secret",
+			Namespace: "test",
+			Labels:    map[string]string{"juju-model": "test"},
+			Annotations: map[string]string{
+				"juju.io/controller": s.controllerUUID,
+				"juju.io/model":      s.cfg.UUID(),
+			},
+		},
+		Type: "kubernetes.io/dockerconfigjson",
+		Data: map[string][]byte{".dockerconfigjson": secretData},
+	}
+}
+
+func (s *K8sBrokerSuite) setImageRegistryConfig(c *gc.C) *config.Config {
+	cfg, err := s.broker.Config().Apply(map[string]interface{}{
+		"image-registry":          "registry.example.com",
+		"image-registry-username": "fred",
+		"image-registry-password": "new-secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return cfg
+}
+
+func (s *K8sBrokerSuite) TestSetConfigRotatesImagePullSecret(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockSecrets.EXPECT().Get("juju-image-pull-secret", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(s.imagePullSecretArg(c, "secret"), nil),
+		s.mockSecrets.EXPECT().Update(s.imagePullSecretArg(c, "new-secret")).Times(1).
+			Return(nil, nil),
+	)
+	s.setImageRegistryConfig(c)
+}
+
+func (s *K8sBrokerSuite) TestSetConfigNoImagePullSecret(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockSecrets.EXPECT().Get("juju-image-pull-secret", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+		Return(nil, s.k8sNotFoundError())
+	s.setImageRegistryConfig(c)
+}
+
+func (s *K8sBrokerSuite) TestSetConfigImagePullSecretError(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockSecrets.EXPECT().Get("juju-image-pull-secret", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+		Return(nil, s.k8sForbiddenError())
+	cfg := s.setImageRegistryConfig(c)
+	c.Assert(s.broker.Config(), jc.DeepEquals, cfg)
+}
+
 func (s *K8sBrokerSuite) TestBootstrapNoOperatorStorage(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -569,6 +638,113 @@ func (s *K8sBrokerSuite) TestPrepareForBootstrapAlreadyExistControllerAnnotation
 	)
 }
 
+func (s *K8sBrokerSuite) setupControllerNamespaceConfig(c *gc.C) {
+	cfg := s.broker.Config()
+	var err error
+	cfg, err = cfg.Apply(map[string]interface{}{
+		"operator-storage":     "some-storage",
+		"controller-namespace": "juju-ctrl",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestPrepareForBootstrapExistingNamespace(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.setupControllerNamespaceConfig(c)
+
+	ns := &core.Namespace{ObjectMeta: v1.ObjectMeta{Name: "juju-ctrl"}}
+	sc := &k8sstorage.StorageClass{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "some-storage",
+		},
+	}
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().List(v1.ListOptions{Limit: 1}).Times(1).
+			Return(&core.NamespaceList{}, nil),
+		s.mockStorageClass.EXPECT().List(v1.ListOptions{Limit: 1}).Times(1).
+			Return(&k8sstorage.StorageClassList{}, nil),
+		s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{Limit: 1}).Times(1).
+			Return(&apiextensionsv1beta1.CustomResourceDefinitionList{}, nil),
+		s.mockNamespaces.EXPECT().Get("juju-ctrl", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(ns, nil),
+		s.mockStorageClass.EXPECT().Get("juju-ctrl-some-storage", v1.GetOptions{}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStorageClass.EXPECT().Get("some-storage", v1.GetOptions{}).Times(1).
+			Return(sc, nil),
+	)
+	ctx := envtesting.BootstrapContext(c)
+	c.Assert(
+		s.broker.PrepareForBootstrap(ctx, "ctrl-1"), jc.ErrorIsNil,
+	)
+	c.Assert(s.broker.GetCurrentNamespace(), jc.DeepEquals, "juju-ctrl")
+}
+
+func (s *K8sBrokerSuite) TestPrepareForBootstrapExistingNamespaceNotFound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.setupControllerNamespaceConfig(c)
+
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().List(v1.ListOptions{Limit: 1}).Times(1).
+			Return(&core.NamespaceList{}, nil),
+		s.mockStorageClass.EXPECT().List(v1.ListOptions{Limit: 1}).Times(1).
+			Return(&k8sstorage.StorageClassList{}, nil),
+		s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{Limit: 1}).Times(1).
+			Return(&apiextensionsv1beta1.CustomResourceDefinitionList{}, nil),
+		s.mockNamespaces.EXPECT().Get("juju-ctrl", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+	)
+	ctx := envtesting.BootstrapContext(c)
+	err := s.broker.PrepareForBootstrap(ctx, "ctrl-1")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `namespace "juju-ctrl" not found, it must be created before bootstrapping into it`)
+}
+
+func (s *K8sBrokerSuite) TestPrepareForBootstrapRestrictedNamespace(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.setupControllerNamespaceConfig(c)
+
+	// The credential may only use resources in the namespace, so
+	// neither the namespace nor the storage class are checked.
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().List(v1.ListOptions{Limit: 1}).Times(1).
+			Return(nil, s.k8sForbiddenError()),
+		s.mockStorageClass.EXPECT().List(v1.ListOptions{Limit: 1}).Times(1).
+			Return(nil, s.k8sForbiddenError()),
+		s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{Limit: 1}).Times(1).
+			Return(nil, s.k8sForbiddenError()),
+	)
+	ctx := envtesting.BootstrapContext(c)
+	c.Assert(
+		s.broker.PrepareForBootstrap(ctx, "ctrl-1"), jc.ErrorIsNil,
+	)
+	c.Assert(s.broker.GetCurrentNamespace(), jc.DeepEquals, "juju-ctrl")
+}
+
+func (s *K8sBrokerSuite) TestPrepareForBootstrapForbidden(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.setupOperatorStorageConfig(c)
+
+	gomock.InOrder(
+		s.mockNamespaces.EXPECT().Get("controller-ctrl-1", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sForbiddenError()),
+	)
+	ctx := envtesting.BootstrapContext(c)
+	c.Assert(
+		s.broker.PrepareForBootstrap(ctx, "ctrl-1"), gc.ErrorMatches,
+		`cannot create a namespace for the controller, to use an existing namespace set "controller-namespace": .*`,
+	)
+}
+
 func (s *K8sBrokerSuite) TestGetNamespace(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -890,6 +1066,95 @@ func (s *K8sBrokerSuite) TestEnsureOperator(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) setupOperatorResourcesConfig(c *gc.C) {
+	cfg, err := s.broker.Config().Apply(map[string]interface{}{
+		"operator-cpu-request":  "250m",
+		"operator-memory-limit": "1Gi",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.broker.SetConfig(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+var operatorResources = core.ResourceRequirements{
+	Requests: core.ResourceList{core.ResourceCPU: resource.MustParse("250m")},
+	Limits:   core.ResourceList{core.ResourceMemory: resource.MustParse("1Gi")},
+}
+
+func (s *K8sBrokerSuite) TestEnsureOperatorWithResources(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator", IncludeUninitialized: true}).Times(1).
+			Return(&appsv1.StatefulSetList{}, nil),
+	)
+	s.setupOperatorResourcesConfig(c)
+
+	statefulSetArg := operatorStatefulSetArg(1, "test-operator-storage")
+	podSpec := &statefulSetArg.Spec.Template.Spec
+	podSpec.Containers = append([]core.Container(nil), podSpec.Containers...)
+	podSpec.Containers[0].Resources = operatorResources
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-test", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockConfigMaps.EXPECT().Get("test-operator-config", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, nil),
+		s.mockStorageClass.EXPECT().Get("test-operator-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
+			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "test-operator-storage"}}, nil),
+		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
+			Return(nil, nil),
+	)
+
+	err := s.broker.EnsureOperator("test", "path/to/agent", &caas.OperatorConfig{
+		OperatorImagePath: "/path/to/image",
+		Version:           version.MustParse("2.99.0"),
+		ResourceTags:      map[string]string{"fred": "mary"},
+		CharmStorage: caas.CharmStorageParams{
+			Size:         uint64(10),
+			Provider:     "kubernetes",
+			Attributes:   map[string]interface{}{"storage-class": "operator-storage"},
+			ResourceTags: map[string]string{"foo": "bar"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestSetConfigUpdatesOperatorResources(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	existing := operatorStatefulSetArg(1, "test-operator-storage")
+	podSpec := &existing.Spec.Template.Spec
+	podSpec.Containers = append([]core.Container(nil), podSpec.Containers...)
+
+	updated := operatorStatefulSetArg(1, "test-operator-storage")
+	podSpec = &updated.Spec.Template.Spec
+	podSpec.Containers = append([]core.Container(nil), podSpec.Containers...)
+	podSpec.Containers[0].Resources = operatorResources
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator", IncludeUninitialized: true}).Times(1).
+			Return(&appsv1.StatefulSetList{Items: []appsv1.StatefulSet{*existing}}, nil),
+		s.mockStatefulSets.EXPECT().Update(updated).Times(1).
+			Return(updated, nil),
+	)
+	s.setupOperatorResourcesConfig(c)
+}
+
+func (s *K8sBrokerSuite) TestSetConfigOperatorResourcesError(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockStatefulSets.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator", IncludeUninitialized: true}).Times(1).
+		Return(nil, s.k8sForbiddenError())
+	s.setupOperatorResourcesConfig(c)
+	c.Assert(s.broker.Config().AllAttrs()["operator-memory-limit"], gc.Equals, "1Gi")
+}
+
 func (s *K8sBrokerSuite) TestEnsureOperatorNoAgentConfig(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -964,12 +1229,73 @@ func (s *K8sBrokerSuite) TestDeleteServiceForApplication(c *gc.C) {
 			}}}, nil),
 		s.mockSecrets.EXPECT().Delete("secret", s.deleteOptions(v1.DeletePropagationForeground)).Times(1).
 			Return(s.k8sNotFoundError()),
+		s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{LabelSelector: "juju-resources.test/test"}).Times(1).
+			Return(&apiextensionsv1beta1.CustomResourceDefinitionList{}, nil),
 	)
 
 	err := s.broker.DeleteService("test")
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) TestExposeServiceWithTLS(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	svc := &core.Service{
+		ObjectMeta: v1.ObjectMeta{Name: "gitlab"},
+		Spec: core.ServiceSpec{
+			Ports: []core.ServicePort{{Port: 80, TargetPort: intstr.FromInt(80), Protocol: "TCP"}},
+		},
+	}
+	ingress := &extensionsv1beta1.Ingress{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "gitlab",
+			Labels: map[string]string{"juju-model-uuid": testing.ModelTag.Id()},
+			Annotations: map[string]string{
+				"ingress.kubernetes.io/rewrite-target":  "",
+				"ingress.kubernetes.io/ssl-redirect":    "false",
+				"kubernetes.io/ingress.class":           "nginx",
+				"kubernetes.io/ingress.allow-http":      "false",
+				"ingress.kubernetes.io/ssl-passthrough": "false",
+			},
+		},
+		Spec: extensionsv1beta1.IngressSpec{
+			TLS: []extensionsv1beta1.IngressTLS{{
+				Hosts:      []string{"gitlab.example.com"},
+				SecretName: "gitlab-tls",
+			}},
+			Rules: []extensionsv1beta1.IngressRule{{
+				Host: "gitlab.example.com",
+				IngressRuleValue: extensionsv1beta1.IngressRuleValue{
+					HTTP: &extensionsv1beta1.HTTPIngressRuleValue{
+						Paths: []extensionsv1beta1.HTTPIngressPath{{
+							Path: "/",
+							Backend: extensionsv1beta1.IngressBackend{
+								ServiceName: "gitlab", ServicePort: intstr.FromInt(80)},
+						}}},
+				}}},
+		},
+	}
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-gitlab", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("gitlab", v1.GetOptions{}).Times(1).
+			Return(svc, nil),
+		s.mockIngressInterface.EXPECT().Update(ingress).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockIngressInterface.EXPECT().Create(ingress).Times(1).
+			Return(ingress, nil),
+	)
+
+	err := s.broker.ExposeService("gitlab",
+		map[string]string{"juju-model-uuid": testing.ModelTag.Id()},
+		application.ConfigAttributes{
+			"juju-external-hostname":        "gitlab.example.com",
+			"kubernetes-ingress-tls-secret": "gitlab-tls",
+		})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *K8sBrokerSuite) TestEnsureServiceNoUnits(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -995,6 +1321,37 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoUnits(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sSuite) TestKeepNodePorts(c *gc.C) {
+	existing := &core.Service{
+		Spec: core.ServiceSpec{
+			Type: core.ServiceTypeNodePort,
+			Ports: []core.ServicePort{
+				{Port: 80, Protocol: "TCP", NodePort: 30080},
+				{Port: 443, Protocol: "TCP", NodePort: 30443},
+			},
+		},
+	}
+	spec := &core.Service{
+		Spec: core.ServiceSpec{
+			Type: core.ServiceTypeLoadBalancer,
+			Ports: []core.ServicePort{
+				{Port: 80, Protocol: "TCP"},
+				{Port: 8080, Protocol: "TCP"},
+			},
+		},
+	}
+	provider.KeepNodePorts(spec, existing)
+	c.Assert(spec.Spec.Ports, jc.DeepEquals, []core.ServicePort{
+		{Port: 80, Protocol: "TCP", NodePort: 30080},
+		{Port: 8080, Protocol: "TCP"},
+	})
+
+	spec.Spec.Type = core.ServiceTypeClusterIP
+	spec.Spec.Ports = []core.ServicePort{{Port: 80, Protocol: "TCP"}}
+	provider.KeepNodePorts(spec, existing)
+	c.Assert(spec.Spec.Ports, jc.DeepEquals, []core.ServicePort{{Port: 80, Protocol: "TCP"}})
+}
+
 func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -1190,6 +1547,8 @@ func (s *K8sBrokerSuite) TestEnsureCustomResourceDefinitionCreate(c *gc.C) {
 
 	gomock.InOrder(
 		s.mockCustomResourceDefinition.EXPECT().Create(crd).Times(1).Return(crd, nil),
+		s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{LabelSelector: "juju-resources.test/test"}).Times(1).
+			Return(&apiextensionsv1beta1.CustomResourceDefinitionList{}, nil),
 	)
 	err := s.broker.EnsureCustomResourceDefinition("test", podSpec)
 	c.Assert(err, jc.ErrorIsNil)
@@ -1301,6 +1660,8 @@ func (s *K8sBrokerSuite) TestEnsureCustomResourceDefinitionUpdate(c *gc.C) {
 		s.mockCustomResourceDefinition.EXPECT().Create(crd).Times(1).Return(crd, s.k8sAlreadyExistsError()),
 		s.mockCustomResourceDefinition.EXPECT().Get("tfjobs.kubeflow.org", v1.GetOptions{}).Times(1).Return(crd, nil),
 		s.mockCustomResourceDefinition.EXPECT().Update(crd).Times(1).Return(crd, nil),
+		s.mockCustomResourceDefinition.EXPECT().List(v1.ListOptions{LabelSelector: "juju-resources.test/test"}).Times(1).
+			Return(&apiextensionsv1beta1.CustomResourceDefinitionList{}, nil),
 	)
 	err := s.broker.EnsureCustomResourceDefinition("test", podSpec)
 	c.Assert(err, jc.ErrorIsNil)
@@ -1755,6 +2116,71 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithZones(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) TestEnsureServiceWithArch(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	unitSpec, err := provider.MakeUnitSpec("app-name", "app-name", basicPodspec)
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(unitSpec)
+	podSpec.Containers[0].VolumeMounts = []core.VolumeMount{{
+		Name:      "database-appuuid",
+		MountPath: "path/to/here",
+	}}
+	podSpec.NodeSelector = map[string]string{"beta.kubernetes.io/arch": "ppc64le"}
+	podSpec.Tolerations = []core.Toleration{{
+		Key:      "beta.kubernetes.io/arch",
+		Operator: core.TolerationOpEqual,
+		Value:    "ppc64le",
+		Effect:   core.TaintEffectNoSchedule,
+	}}
+	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Update(s.secretArg(c, nil)).Times(1).
+			Return(nil, nil),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(&appsv1.StatefulSet{ObjectMeta: v1.ObjectMeta{Annotations: map[string]string{"juju-app-uuid": "appuuid"}}}, nil),
+		s.mockStorageClass.EXPECT().Get("test-workload-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStorageClass.EXPECT().Get("workload-storage", v1.GetOptions{IncludeUninitialized: false}).Times(1).
+			Return(&storagev1.StorageClass{ObjectMeta: v1.ObjectMeta{Name: "workload-storage"}}, nil),
+		s.mockStatefulSets.EXPECT().Update(statefulSetArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Create(statefulSetArg).Times(1).
+			Return(nil, nil),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(basicServiceArg).Times(1).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(basicServiceArg).Times(1).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodspec,
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+			Provider:    "kubernetes",
+			Attachment: &storage.KubernetesFilesystemAttachmentParams{
+				Path: "path/to/here",
+			},
+			Attributes:   map[string]interface{}{"storage-class": "workload-storage"},
+			ResourceTags: map[string]string{"foo": "bar"},
+		}},
+		Constraints: constraints.MustParse(`arch=ppc64el`),
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *K8sBrokerSuite) TestOperator(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -12,6 +12,7 @@ import (
 	"gopkg.in/yaml.v2"
 	core "k8s.io/api/core/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	k8syaml "k8s.io/apimachinery/pkg/util/yaml"
 
 	"github.com/juju/juju/caas"
@@ -28,6 +29,7 @@ type k8sContainers struct {
 	Containers                []k8sContainer                                               `json:"containers"`
 	InitContainers            []k8sContainer                                               `json:"initContainers"`
 	CustomResourceDefinitions map[string]apiextensionsv1beta1.CustomResourceDefinitionSpec `yaml:"customResourceDefinitions,omitempty"`
+	CustomResources           map[string][]unstructured.Unstructured                       `json:"customResources,omitempty"`
 }
 
 // K8sContainerSpec is a subset of v1.Container which defines
@@ -133,6 +135,7 @@ func parseK8sPodSpec(in string) (*caas.PodSpec, error) {
 		spec.InitContainers[i] = containerFromK8sSpec(c)
 	}
 	spec.CustomResourceDefinitions = containers.CustomResourceDefinitions
+	spec.CustomResources = containers.CustomResources
 	return &spec, nil
 }
 
@@ -406,3 +406,56 @@ containers:
 	err = spec.Validate()
 	c.Assert(err, gc.ErrorMatches, `mount path is missing for file set "configuration"`)
 }
+
+func (s *ContainersSuite) TestParseCustomResources(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+customResources:
+  tfjobs.kubeflow.org:
+    - apiVersion: "kubeflow.org/v1alpha2"
+      kind: "TFJob"
+      metadata:
+        name: "dist-mnist-for-e2e-test"
+      spec:
+        tfReplicaSpecs:
+          PS:
+            replicas: 2
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spec.Validate(), jc.ErrorIsNil)
+	c.Assert(spec.CustomResources, gc.HasLen, 1)
+	resources := spec.CustomResources["tfjobs.kubeflow.org"]
+	c.Assert(resources, gc.HasLen, 1)
+	c.Assert(resources[0].GetAPIVersion(), gc.Equals, "kubeflow.org/v1alpha2")
+	c.Assert(resources[0].GetKind(), gc.Equals, "TFJob")
+	c.Assert(resources[0].GetName(), gc.Equals, "dist-mnist-for-e2e-test")
+	c.Assert(resources[0].Object["spec"], jc.DeepEquals, map[string]interface{}{
+		"tfReplicaSpecs": map[string]interface{}{
+			"PS": map[string]interface{}{"replicas": int64(2)},
+		},
+	})
+}
+
+func (s *ContainersSuite) TestValidateCustomResourceMissingKind(c *gc.C) {
+
+	specStr := `
+containers:
+  - name: gitlab
+    image: gitlab/latest
+customResources:
+  tfjobs.kubeflow.org:
+    - apiVersion: "kubeflow.org/v1alpha2"
+      metadata:
+        name: "dist-mnist-for-e2e-test"
+`[1:]
+
+	spec, err := provider.ParseK8sPodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	err = spec.Validate()
+	c.Assert(err, gc.ErrorMatches, `kind is missing for custom resource "dist-mnist-for-e2e-test"`)
+}
@@ -47,6 +47,13 @@ func (kubernetesEnvironProvider) Version() int {
 	return 0
 }
 
+// Capabilities is part of the CapabilityReporter interface.
+func (kubernetesEnvironProvider) Capabilities() []environs.Capability {
+	return []environs.Capability{
+		environs.CapabilityBlockStorage,
+	}
+}
+
 // CommandRunner allows to run commands on the underlying system
 type CommandRunner interface {
 	RunCommands(run exec.RunParams) (*exec.ExecResponse, error)
@@ -127,3 +127,66 @@ func (s *providerSuite) TestValidate(c *gc.C) {
 	validAttrs := validCfg.AllAttrs()
 	c.Assert(config.AllAttrs(), gc.DeepEquals, validAttrs)
 }
+
+func (s *providerSuite) TestValidateImageRegistry(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{
+		"image-registry":          "registry.example.com:5000",
+		"image-registry-username": "fred",
+		"image-registry-password": "secret",
+		"operator-image-mirror":   "registry.example.com:5000/juju",
+	})
+	validCfg, err := s.provider.Validate(config, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validCfg.AllAttrs(), gc.DeepEquals, config.AllAttrs())
+}
+
+func (s *providerSuite) TestValidateImageRegistryMissingPassword(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{
+		"image-registry-username": "fred",
+	})
+	_, err := s.provider.Validate(config, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid k8s provider config: "image-registry-username" and "image-registry-password" must be specified together`)
+}
+
+func (s *providerSuite) TestValidateControllerNamespaceImmutable(c *gc.C) {
+	old := fakeConfig(c, coretesting.Attrs{
+		"controller-namespace": "juju",
+	})
+	_, err := s.provider.Validate(old, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	config := fakeConfig(c, coretesting.Attrs{
+		"controller-namespace": "other",
+	})
+	_, err = s.provider.Validate(config, old)
+	c.Assert(err, gc.ErrorMatches, `invalid k8s provider config: cannot change controller-namespace from "juju" to "other"`)
+}
+
+func (s *providerSuite) TestValidateOperatorResources(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{
+		"operator-cpu-request":    "250m",
+		"operator-cpu-limit":      "1",
+		"operator-memory-request": "256Mi",
+		"operator-memory-limit":   "1Gi",
+	})
+	validCfg, err := s.provider.Validate(config, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(validCfg.AllAttrs(), gc.DeepEquals, config.AllAttrs())
+}
+
+func (s *providerSuite) TestValidateOperatorResourcesInvalidQuantity(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{
+		"operator-memory-limit": "lots",
+	})
+	_, err := s.provider.Validate(config, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid k8s provider config: operator-memory-limit "lots" not valid`)
+}
+
+func (s *providerSuite) TestValidateOperatorResourcesRequestOverLimit(c *gc.C) {
+	config := fakeConfig(c, coretesting.Attrs{
+		"operator-memory-request": "2Gi",
+		"operator-memory-limit":   "1Gi",
+	})
+	_, err := s.provider.Validate(config, nil)
+	c.Assert(err, gc.ErrorMatches, `invalid k8s provider config: memory request 2Gi greater than limit 1Gi not valid`)
+}
@@ -6,8 +6,11 @@ package provider
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"github.com/juju/juju/environs/config"
 )
@@ -15,8 +18,45 @@ import (
 const (
 	WorkloadStorageKey = "workload-storage"
 	OperatorStorageKey = "operator-storage"
+
+	// ImageRegistryKey is the registry the model image pull
+	// credentials are used for. If not set, the registry of
+	// the operator image path is used.
+	ImageRegistryKey = "image-registry"
+
+	// ImageRegistryUsernameKey and ImageRegistryPasswordKey hold the
+	// credentials used to pull operator and workload images.
+	ImageRegistryUsernameKey = "image-registry-username"
+	ImageRegistryPasswordKey = "image-registry-password"
+
+	// OperatorImageMirrorKey is a repository which mirrors the
+	// jujud operator images, used in place of the controller's
+	// configured operator image repository.
+	OperatorImageMirrorKey = "operator-image-mirror"
+
+	// ControllerNamespaceKey is an existing namespace to bootstrap the
+	// controller into, rather than creating one. It allows bootstrapping
+	// with a credential that is only permitted within that namespace.
+	ControllerNamespaceKey = "controller-namespace"
+
+	// OperatorCPURequestKey, OperatorCPULimitKey, OperatorMemoryRequestKey
+	// and OperatorMemoryLimitKey are the resources requested for, and the
+	// limits on, the operator pods in the model. In the controller model
+	// they also apply to the controller pods.
+	OperatorCPURequestKey    = "operator-cpu-request"
+	OperatorCPULimitKey      = "operator-cpu-limit"
+	OperatorMemoryRequestKey = "operator-memory-request"
+	OperatorMemoryLimitKey   = "operator-memory-limit"
 )
 
+// operatorResourceKeys are the config keys for operator pod resources.
+var operatorResourceKeys = []string{
+	OperatorCPURequestKey,
+	OperatorCPULimitKey,
+	OperatorMemoryRequestKey,
+	OperatorMemoryLimitKey,
+}
+
 var configSchema = environschema.Fields{
 	WorkloadStorageKey: {
 		Description: "The preferred storage class used to provision workload storage.",
@@ -29,6 +69,53 @@ var configSchema = environschema.Fields{
 		Group:       environschema.AccountGroup,
 		Immutable:   true,
 	},
+	ImageRegistryKey: {
+		Description: "The registry the image registry credentials are used for.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	ImageRegistryUsernameKey: {
+		Description: "The username used to pull images from a private registry.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	ImageRegistryPasswordKey: {
+		Description: "The password used to pull images from a private registry.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+		Secret:      true,
+	},
+	OperatorImageMirrorKey: {
+		Description: "The repository mirroring the jujud operator images.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	ControllerNamespaceKey: {
+		Description: "An existing namespace to bootstrap the controller into.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+		Immutable:   true,
+	},
+	OperatorCPURequestKey: {
+		Description: "The CPU requested for operator and controller pods, e.g. 250m.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	OperatorCPULimitKey: {
+		Description: "The CPU limit for operator and controller pods, e.g. 1.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	OperatorMemoryRequestKey: {
+		Description: "The memory requested for operator and controller pods, e.g. 256Mi.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
+	OperatorMemoryLimitKey: {
+		Description: "The memory limit for operator and controller pods, e.g. 1Gi.",
+		Type:        environschema.Tstring,
+		Group:       environschema.AccountGroup,
+	},
 }
 
 var providerConfigFields = func() schema.Fields {
@@ -42,6 +129,16 @@ var providerConfigFields = func() schema.Fields {
 var providerConfigDefaults = schema.Defaults{
 	WorkloadStorageKey: "",
 	OperatorStorageKey: "",
+
+	ImageRegistryKey:         schema.Omit,
+	ImageRegistryUsernameKey: schema.Omit,
+	ImageRegistryPasswordKey: schema.Omit,
+	OperatorImageMirrorKey:   schema.Omit,
+	ControllerNamespaceKey:   schema.Omit,
+	OperatorCPURequestKey:    schema.Omit,
+	OperatorCPULimitKey:      schema.Omit,
+	OperatorMemoryRequestKey: schema.Omit,
+	OperatorMemoryLimitKey:   schema.Omit,
 }
 
 type brokerConfig struct {
@@ -57,6 +154,62 @@ func (c *brokerConfig) operatorStorage() string {
 	return c.attrs[OperatorStorageKey].(string)
 }
 
+func (c *brokerConfig) imageRegistry() string {
+	registry, _ := c.attrs[ImageRegistryKey].(string)
+	return registry
+}
+
+func (c *brokerConfig) imageRegistryCredentials() (string, string) {
+	username, _ := c.attrs[ImageRegistryUsernameKey].(string)
+	password, _ := c.attrs[ImageRegistryPasswordKey].(string)
+	return username, password
+}
+
+func (c *brokerConfig) operatorImageMirror() string {
+	mirror, _ := c.attrs[OperatorImageMirrorKey].(string)
+	return mirror
+}
+
+func (c *brokerConfig) controllerNamespace() string {
+	namespace, _ := c.attrs[ControllerNamespaceKey].(string)
+	return namespace
+}
+
+// operatorResources returns the resource requests and limits for
+// operator pods.
+func (c *brokerConfig) operatorResources() (core.ResourceRequirements, error) {
+	var resources core.ResourceRequirements
+	for _, r := range []struct {
+		key  string
+		list *core.ResourceList
+		name core.ResourceName
+	}{
+		{OperatorCPURequestKey, &resources.Requests, core.ResourceCPU},
+		{OperatorCPULimitKey, &resources.Limits, core.ResourceCPU},
+		{OperatorMemoryRequestKey, &resources.Requests, core.ResourceMemory},
+		{OperatorMemoryLimitKey, &resources.Limits, core.ResourceMemory},
+	} {
+		value, _ := c.attrs[r.key].(string)
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return resources, errors.NotValidf("%s %q", r.key, value)
+		}
+		if *r.list == nil {
+			*r.list = core.ResourceList{}
+		}
+		(*r.list)[r.name] = quantity
+	}
+	for name, limit := range resources.Limits {
+		if request, ok := resources.Requests[name]; ok && request.Cmp(limit) > 0 {
+			return resources, errors.NotValidf("%s request %v greater than limit %v", name, request.String(), limit.String())
+		}
+	}
+	return resources, nil
+}
+
 func (p kubernetesEnvironProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
 	newCfg, err := validateConfig(cfg, old)
 	if err != nil {
@@ -105,5 +258,47 @@ func validateConfig(cfg, old *config.Config) (*brokerConfig, error) {
 	}
 
 	bcfg := &brokerConfig{cfg, validated}
+	username, password := bcfg.imageRegistryCredentials()
+	if (username == "") != (password == "") {
+		return nil, errors.Errorf("%q and %q must be specified together", ImageRegistryUsernameKey, ImageRegistryPasswordKey)
+	}
+	if registry := bcfg.imageRegistry(); registry != "" {
+		if _, err := extractRegistryURL(registry + "/probe"); err != nil {
+			return nil, errors.NotValidf("%s %q", ImageRegistryKey, registry)
+		}
+	}
+	if _, err := bcfg.operatorResources(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if old != nil {
+		oldNamespace, _ := old.UnknownAttrs()[ControllerNamespaceKey].(string)
+		if namespace := bcfg.controllerNamespace(); namespace != oldNamespace {
+			return nil, errors.Errorf("cannot change %s from %q to %q", ControllerNamespaceKey, oldNamespace, namespace)
+		}
+	}
 	return bcfg, nil
 }
+
+// imageRegistryConfigChanged returns true if the image registry
+// settings differ between the two model configs.
+func imageRegistryConfigChanged(oldCfg, newCfg *config.Config) bool {
+	oldAttrs, newAttrs := oldCfg.UnknownAttrs(), newCfg.UnknownAttrs()
+	for _, key := range []string{ImageRegistryKey, ImageRegistryUsernameKey, ImageRegistryPasswordKey} {
+		if oldAttrs[key] != newAttrs[key] {
+			return true
+		}
+	}
+	return false
+}
+
+// operatorResourcesConfigChanged returns true if the operator pod
+// resource settings differ between the two model configs.
+func operatorResourcesConfigChanged(oldCfg, newCfg *config.Config) bool {
+	oldAttrs, newAttrs := oldCfg.UnknownAttrs(), newCfg.UnknownAttrs()
+	for _, key := range operatorResourceKeys {
+		if oldAttrs[key] != newAttrs[key] {
+			return true
+		}
+	}
+	return false
+}
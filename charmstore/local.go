@@ -0,0 +1,336 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3"
+)
+
+// bundleSeries is the name of the directory holding bundles in a
+// local repository.
+const bundleSeries = "bundle"
+
+// LocalRepository is a charm repository backed by a directory, for
+// use where the charm store cannot be reached. Charms are held in a
+// directory named after the series they are for, e.g.
+//
+//     <path>/bionic/mysql
+//     <path>/bionic/mysql-58.charm
+//
+// and bundles in the "bundle" directory. Each entry may be either an
+// expanded charm directory or a charm archive; its name is ignored, the
+// charm's name and revision are taken from its metadata and revision
+// file, so several revisions of a charm may be kept side by side.
+//
+// Charms are identified by charm store URLs, so that a charm deployed
+// from the local repository can later be upgraded from the charm store
+// and vice versa.
+type LocalRepository struct {
+	// Path is the directory holding the repository.
+	Path string
+}
+
+var _ charmrepo.Interface = (*LocalRepository)(nil)
+
+// NewLocalRepository returns a repository backed by the directory at
+// the given path.
+func NewLocalRepository(path string) (*LocalRepository, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot open local charm repository")
+	}
+	if !info.IsDir() {
+		return nil, errors.NotValidf("local charm repository %q (not a directory)", path)
+	}
+	return &LocalRepository{Path: path}, nil
+}
+
+// localEntity describes a charm or bundle found in the repository.
+type localEntity struct {
+	series   string
+	path     string
+	revision int
+	charm    charm.Charm
+	bundle   charm.Bundle
+}
+
+// Resolve implements charmrepo.Interface. It returns the URL of the
+// requested revision of the charm or bundle, or the latest revision if
+// none was requested, along with the series the charm supports.
+func (r *LocalRepository) Resolve(ref *charm.URL) (*charm.URL, []string, error) {
+	entities, err := r.find(ref)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	best := entities[0]
+	for _, e := range entities[1:] {
+		if e.revision > best.revision {
+			best = e
+		}
+	}
+	var supportedSeries []string
+	if best.charm != nil && len(best.charm.Meta().Series) > 0 {
+		supportedSeries = best.charm.Meta().Series
+	} else {
+		series := set.NewStrings()
+		for _, e := range entities {
+			if e.revision == best.revision {
+				series.Add(e.series)
+			}
+		}
+		supportedSeries = series.SortedValues()
+	}
+	resolved := *ref
+	resolved.Schema = "cs"
+	resolved.Series = best.series
+	resolved.Revision = best.revision
+	return &resolved, supportedSeries, nil
+}
+
+// Get implements charmrepo.Interface. The returned charm is a copy of
+// the charm in the repository, written to a temporary archive that
+// the caller is responsible for removing.
+func (r *LocalRepository) Get(curl *charm.URL) (charm.Charm, error) {
+	if curl.Series == bundleSeries {
+		return nil, errors.NotValidf("charm URL %q (a bundle)", curl)
+	}
+	e, err := r.entity(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	archivePath, err := archiveCopy(e)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read charm %q", curl)
+	}
+	ch, err := charm.ReadCharmArchive(archivePath)
+	if err != nil {
+		os.Remove(archivePath)
+		return nil, errors.Trace(err)
+	}
+	return ch, nil
+}
+
+// GetBundle implements charmrepo.Interface.
+func (r *LocalRepository) GetBundle(curl *charm.URL) (charm.Bundle, error) {
+	if curl.Series != bundleSeries {
+		return nil, errors.NotValidf("bundle URL %q", curl)
+	}
+	e, err := r.entity(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return e.bundle, nil
+}
+
+// entity returns the charm or bundle identified by the given URL, which
+// must include a series. If the URL has no revision, the latest
+// revision is returned.
+func (r *LocalRepository) entity(curl *charm.URL) (localEntity, error) {
+	if curl.Series == "" {
+		return localEntity{}, errors.NotValidf("charm URL %q without series", curl)
+	}
+	entities, err := r.find(curl)
+	if err != nil {
+		return localEntity{}, errors.Trace(err)
+	}
+	return entities[0], nil
+}
+
+// find returns the charms or bundles in the repository matching the
+// given reference, sorted by series and then by descending revision.
+func (r *LocalRepository) find(ref *charm.URL) ([]localEntity, error) {
+	var series []string
+	if ref.Series != "" {
+		series = []string{ref.Series}
+	} else {
+		infos, err := ioutil.ReadDir(r.Path)
+		if err != nil {
+			return nil, errors.Annotate(err, "cannot read local charm repository")
+		}
+		for _, info := range infos {
+			if info.IsDir() && !strings.HasPrefix(info.Name(), ".") {
+				series = append(series, info.Name())
+			}
+		}
+	}
+	var entities []localEntity
+	for _, s := range series {
+		found, err := r.findInSeries(s, ref)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		entities = append(entities, found...)
+	}
+	if len(entities) == 0 {
+		return nil, errors.NotFoundf("charm or bundle %q in local repository", ref)
+	}
+	sort.SliceStable(entities, func(i, j int) bool {
+		if entities[i].series != entities[j].series {
+			return entities[i].series < entities[j].series
+		}
+		return entities[i].revision > entities[j].revision
+	})
+	return entities, nil
+}
+
+func (r *LocalRepository) findInSeries(series string, ref *charm.URL) ([]localEntity, error) {
+	dir := filepath.Join(r.Path, series)
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot read local charm repository")
+	}
+	var entities []localEntity
+	for _, info := range infos {
+		if strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		e := localEntity{
+			series: series,
+			path:   filepath.Join(dir, info.Name()),
+		}
+		var name string
+		if series == bundleSeries {
+			b, err := charm.ReadBundle(e.path)
+			if err != nil {
+				logger.Debugf("ignoring %q in local repository: %v", e.path, err)
+				continue
+			}
+			// Bundles have no name of their own; use the name
+			// of the directory or archive holding them.
+			name = strings.TrimSuffix(info.Name(), filepath.Ext(info.Name()))
+			e.bundle = b
+		} else {
+			ch, err := charm.ReadCharm(e.path)
+			if err != nil {
+				logger.Debugf("ignoring %q in local repository: %v", e.path, err)
+				continue
+			}
+			name = ch.Meta().Name
+			e.revision = ch.Revision()
+			e.charm = ch
+		}
+		if name != ref.Name {
+			continue
+		}
+		if ref.Revision >= 0 && e.revision != ref.Revision {
+			continue
+		}
+		entities = append(entities, e)
+	}
+	return entities, nil
+}
+
+// archiveCopy writes the given charm to a new temporary archive and
+// returns its path.
+func archiveCopy(e localEntity) (_ string, err error) {
+	f, err := ioutil.TempFile("", "charm")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer func() {
+		f.Close()
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	switch ch := e.charm.(type) {
+	case *charm.CharmDir:
+		if err := ch.ArchiveTo(f); err != nil {
+			return "", errors.Trace(err)
+		}
+	case *charm.CharmArchive:
+		src, err := os.Open(ch.Path)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		defer src.Close()
+		if _, err := io.Copy(f, src); err != nil {
+			return "", errors.Trace(err)
+		}
+	default:
+		return "", errors.Errorf("unexpected charm type %T", ch)
+	}
+	if err := f.Close(); err != nil {
+		return "", errors.Trace(err)
+	}
+	return f.Name(), nil
+}
+
+// NewFallbackRepository returns a repository that resolves and fetches
+// charms and bundles from the primary repository, and from the fallback
+// repository if the primary one fails, e.g. because the charm store
+// cannot be reached. If neither repository has the charm or bundle, the
+// primary repository's error is returned.
+func NewFallbackRepository(primary, fallback charmrepo.Interface) charmrepo.Interface {
+	return &fallbackRepository{primary: primary, fallback: fallback}
+}
+
+type fallbackRepository struct {
+	primary  charmrepo.Interface
+	fallback charmrepo.Interface
+}
+
+// Resolve implements charmrepo.Interface.
+func (r *fallbackRepository) Resolve(ref *charm.URL) (*charm.URL, []string, error) {
+	resolved, supportedSeries, err := r.primary.Resolve(ref)
+	if err == nil {
+		return resolved, supportedSeries, nil
+	}
+	logger.Debugf("cannot resolve %q, trying local repository: %v", ref, err)
+	resolved, supportedSeries, fallbackErr := r.fallback.Resolve(ref)
+	if fallbackErr != nil {
+		r.logFallbackError(ref, fallbackErr)
+		return nil, nil, err
+	}
+	return resolved, supportedSeries, nil
+}
+
+// Get implements charmrepo.Interface.
+func (r *fallbackRepository) Get(curl *charm.URL) (charm.Charm, error) {
+	ch, err := r.primary.Get(curl)
+	if err == nil {
+		return ch, nil
+	}
+	logger.Debugf("cannot get %q, trying local repository: %v", curl, err)
+	ch, fallbackErr := r.fallback.Get(curl)
+	if fallbackErr != nil {
+		r.logFallbackError(curl, fallbackErr)
+		return nil, err
+	}
+	return ch, nil
+}
+
+// GetBundle implements charmrepo.Interface.
+func (r *fallbackRepository) GetBundle(curl *charm.URL) (charm.Bundle, error) {
+	b, err := r.primary.GetBundle(curl)
+	if err == nil {
+		return b, nil
+	}
+	logger.Debugf("cannot get %q, trying local repository: %v", curl, err)
+	b, fallbackErr := r.fallback.GetBundle(curl)
+	if fallbackErr != nil {
+		r.logFallbackError(curl, fallbackErr)
+		return nil, err
+	}
+	return b, nil
+}
+
+func (r *fallbackRepository) logFallbackError(curl *charm.URL, err error) {
+	if errors.IsNotFound(err) {
+		return
+	}
+	logger.Warningf("cannot use local repository for %q: %v", curl, err)
+}
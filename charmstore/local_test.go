@@ -0,0 +1,159 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/charmstore"
+)
+
+type LocalRepositorySuite struct {
+	testing.IsolationSuite
+
+	repo *charmstore.LocalRepository
+}
+
+var _ = gc.Suite(&LocalRepositorySuite{})
+
+func (s *LocalRepositorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	dir := c.MkDir()
+	writeCharmDir(c, filepath.Join(dir, "bionic", "mysql"), "mysql", 3)
+	writeCharmArchive(c, filepath.Join(dir, "bionic", "mysql-5.charm"), "mysql", 5)
+	writeCharmDir(c, filepath.Join(dir, "xenial", "mysql"), "mysql", 4)
+	writeCharmDir(c, filepath.Join(dir, "xenial", "wordpress"), "wordpress", 1)
+
+	var err error
+	s.repo, err = charmstore.NewLocalRepository(dir)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LocalRepositorySuite) TestNewLocalRepositoryNotDirectory(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "file")
+	err := ioutil.WriteFile(path, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = charmstore.NewLocalRepository(path)
+	c.Assert(err, gc.ErrorMatches, `local charm repository ".*/file" \(not a directory\) not valid`)
+}
+
+func (s *LocalRepositorySuite) TestResolveLatest(c *gc.C) {
+	curl, supportedSeries, err := s.repo.Resolve(charm.MustParseURL("cs:mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, jc.DeepEquals, charm.MustParseURL("cs:bionic/mysql-5"))
+	c.Assert(supportedSeries, jc.DeepEquals, []string{"bionic"})
+}
+
+func (s *LocalRepositorySuite) TestResolveSeries(c *gc.C) {
+	curl, supportedSeries, err := s.repo.Resolve(charm.MustParseURL("cs:xenial/mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, jc.DeepEquals, charm.MustParseURL("cs:xenial/mysql-4"))
+	c.Assert(supportedSeries, jc.DeepEquals, []string{"xenial"})
+}
+
+func (s *LocalRepositorySuite) TestResolveRevision(c *gc.C) {
+	curl, _, err := s.repo.Resolve(charm.MustParseURL("cs:mysql-3"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, jc.DeepEquals, charm.MustParseURL("cs:bionic/mysql-3"))
+}
+
+func (s *LocalRepositorySuite) TestResolveNotFound(c *gc.C) {
+	_, _, err := s.repo.Resolve(charm.MustParseURL("cs:mysql-42"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, _, err = s.repo.Resolve(charm.MustParseURL("cs:trusty/wordpress"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *LocalRepositorySuite) TestGet(c *gc.C) {
+	for _, url := range []string{"cs:bionic/mysql-3", "cs:bionic/mysql-5", "cs:bionic/mysql"} {
+		c.Logf("getting %s", url)
+		ch, err := s.repo.Get(charm.MustParseURL(url))
+		c.Assert(err, jc.ErrorIsNil)
+		archive, ok := ch.(*charm.CharmArchive)
+		c.Assert(ok, jc.IsTrue)
+		c.Check(archive.Meta().Name, gc.Equals, "mysql")
+		expectRevision := 5
+		if url == "cs:bionic/mysql-3" {
+			expectRevision = 3
+		}
+		c.Check(archive.Revision(), gc.Equals, expectRevision)
+
+		// The archive is a copy, so it may be removed by the caller.
+		c.Assert(os.Remove(archive.Path), jc.ErrorIsNil)
+	}
+}
+
+func (s *LocalRepositorySuite) TestGetWithoutSeries(c *gc.C) {
+	_, err := s.repo.Get(charm.MustParseURL("cs:mysql-3"))
+	c.Assert(err, gc.ErrorMatches, `charm URL "cs:mysql-3" without series not valid`)
+}
+
+func (s *LocalRepositorySuite) TestFallbackRepository(c *gc.C) {
+	primary := &failingRepository{err: errors.New("cannot reach charm store")}
+	repo := charmstore.NewFallbackRepository(primary, s.repo)
+
+	curl, _, err := repo.Resolve(charm.MustParseURL("cs:wordpress"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, jc.DeepEquals, charm.MustParseURL("cs:xenial/wordpress-1"))
+
+	ch, err := repo.Get(curl)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ch.Meta().Name, gc.Equals, "wordpress")
+	os.Remove(ch.(*charm.CharmArchive).Path)
+
+	// If the fallback repository doesn't have the charm,
+	// the primary repository's error is returned.
+	_, _, err = repo.Resolve(charm.MustParseURL("cs:haproxy"))
+	c.Assert(err, gc.ErrorMatches, "cannot reach charm store")
+}
+
+// failingRepository is a charm repository whose methods all fail.
+type failingRepository struct {
+	err error
+}
+
+func (r *failingRepository) Resolve(*charm.URL) (*charm.URL, []string, error) {
+	return nil, nil, r.err
+}
+
+func (r *failingRepository) Get(*charm.URL) (charm.Charm, error) {
+	return nil, r.err
+}
+
+func (r *failingRepository) GetBundle(*charm.URL) (charm.Bundle, error) {
+	return nil, r.err
+}
+
+func writeCharmDir(c *gc.C, dir, name string, revision int) {
+	err := os.MkdirAll(dir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	meta := fmt.Sprintf("name: %s\nsummary: a charm\ndescription: a charm\n", name)
+	err = ioutil.WriteFile(filepath.Join(dir, "metadata.yaml"), []byte(meta), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dir, "revision"), []byte(fmt.Sprint(revision)), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func writeCharmArchive(c *gc.C, path, name string, revision int) {
+	dir := filepath.Join(c.MkDir(), name)
+	writeCharmDir(c, dir, name, revision)
+	ch, err := charm.ReadCharmDir(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	err = ch.ArchiveTo(f)
+	c.Assert(err, jc.ErrorIsNil)
+}
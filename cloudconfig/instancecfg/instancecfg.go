@@ -148,6 +148,10 @@ type InstanceConfig struct {
 	// override the default APT sources.
 	AptMirror string
 
+	// SnapProxySettings define the http and https proxy settings to use
+	// for snapd, which may or may not be the same as the normal ProxySettings.
+	SnapProxySettings proxy.Settings
+
 	// The type of Simple Stream to download and deploy on this instance.
 	ImageStream string
 
@@ -826,7 +830,7 @@ func NewBootstrapInstanceConfig(
 func PopulateInstanceConfig(icfg *InstanceConfig,
 	providerType, authorizedKeys string,
 	sslHostnameVerification bool,
-	legacyProxySettings, jujuProxySettings, aptProxySettings proxy.Settings,
+	legacyProxySettings, jujuProxySettings, aptProxySettings, snapProxySettings proxy.Settings,
 	aptMirror string,
 	enableOSRefreshUpdates bool,
 	enableOSUpgrade bool,
@@ -845,6 +849,7 @@ func PopulateInstanceConfig(icfg *InstanceConfig,
 	icfg.JujuProxySettings = jujuProxySettings
 	// No AutoNoProxy needed as juju no proxy values are CIDR aware.
 	icfg.AptProxySettings = aptProxySettings
+	icfg.SnapProxySettings = snapProxySettings
 	icfg.AptMirror = aptMirror
 	icfg.EnableOSRefreshUpdate = enableOSRefreshUpdates
 	icfg.EnableOSUpgrade = enableOSUpgrade
@@ -873,6 +878,7 @@ func FinishInstanceConfig(icfg *InstanceConfig, cfg *config.Config) (err error)
 		cfg.LegacyProxySettings(),
 		cfg.JujuProxySettings(),
 		cfg.AptProxySettings(),
+		cfg.SnapProxySettings(),
 		cfg.AptMirror(),
 		cfg.EnableOSRefreshUpdate(),
 		cfg.EnableOSUpgrade(),
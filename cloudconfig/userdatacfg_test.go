@@ -1265,6 +1265,32 @@ DefaultEnvironment="http_proxy=http://user@10.0.0.1" "HTTP_PROXY=http://user@10.
 	c.Assert(found, jc.IsTrue)
 }
 
+func (s *cloudinitSuite) TestSnapProxyWritten(c *gc.C) {
+	environConfig := minimalModelConfig(c)
+	environConfig, err := environConfig.Apply(map[string]interface{}{
+		"snap-http-proxy":  "http://user@10.0.0.1",
+		"snap-https-proxy": "https://user@10.0.0.1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	instanceCfg := s.createInstanceConfig(c, environConfig)
+	cloudcfg, err := cloudinit.New("bionic")
+	c.Assert(err, jc.ErrorIsNil)
+	udata, err := cloudconfig.NewUserdataConfig(instanceCfg, cloudcfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = udata.Configure()
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := `if [ -x /usr/bin/snap ]; then snap set core 'proxy.http=http://user@10.0.0.1' 'proxy.https=https://user@10.0.0.1'; fi`
+	found := false
+	for _, cmd := range cloudcfg.RunCmds() {
+		if cmd == expected {
+			found = true
+			break
+		}
+	}
+	c.Assert(found, jc.IsTrue)
+}
+
 // Ensure the bootstrap curl which fetch tools respects the proxy settings
 func (s *cloudinitSuite) TestProxyArgsAddedToCurlCommand(c *gc.C) {
 	series := "bionic"
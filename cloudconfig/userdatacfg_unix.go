@@ -290,6 +290,20 @@ func (w *unixConfigure) ConfigureJuju() error {
 			shquote(w.icfg.LegacyProxySettings.AsSystemdDefaultEnv())))
 	}
 
+	// Configure snapd to use the snap proxies, if any. The proxy updater
+	// worker keeps these up to date with subsequent model config changes.
+	var snapSettings []string
+	if w.icfg.SnapProxySettings.Http != "" {
+		snapSettings = append(snapSettings, shquote("proxy.http="+w.icfg.SnapProxySettings.Http))
+	}
+	if w.icfg.SnapProxySettings.Https != "" {
+		snapSettings = append(snapSettings, shquote("proxy.https="+w.icfg.SnapProxySettings.Https))
+	}
+	if len(snapSettings) > 0 {
+		w.conf.AddScripts(fmt.Sprintf(
+			`if [ -x /usr/bin/snap ]; then snap set core %s; fi`, strings.Join(snapSettings, " ")))
+	}
+
 	if w.icfg.Controller != nil && w.icfg.Controller.PublicImageSigningKey != "" {
 		keyFile := filepath.Join(agent.DefaultPaths.ConfDir, simplestreams.SimplestreamsPublicKeyFile)
 		w.conf.AddRunTextFile(keyFile, w.icfg.Controller.PublicImageSigningKey, 0644)
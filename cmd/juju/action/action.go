@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/action"
 	"github.com/juju/juju/apiserver/params"
@@ -60,6 +61,10 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// Introspect enqueues actions fetching the named report from the
+	// introspection workers of the agents with the given tags.
+	Introspect(agents []names.Tag, query string) ([]params.ActionResult, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
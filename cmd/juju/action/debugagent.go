@@ -0,0 +1,150 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
+)
+
+func NewDebugAgentCommand() cmd.Command {
+	return modelcmd.Wrap(&debugAgentCommand{})
+}
+
+// debugAgentCommand fetches a report from the introspection worker of
+// a unit or machine agent.
+type debugAgentCommand struct {
+	ActionCommandBase
+	agent names.Tag
+	query string
+	wait  time.Duration
+}
+
+const debugAgentDoc = `
+Fetch a report from the introspection worker of a unit or machine agent,
+through the controller. The introspection socket of an agent can only be
+reached on its machine, so this saves logging in to the machine to run
+juju-introspect there.
+
+The reports that can be fetched are:
+    engine-report:  the state of the agent's dependency engine and workers
+    goroutines:     the stacks of the agent's goroutines
+    metrics:        the agent's Prometheus metrics
+
+The report is fetched by an action run by the agent of the machine that
+hosts the agent, so the machine agent must be running. If the report is
+not fetched before the --wait duration elapses, the action's ID is shown
+so that the report can be fetched later with show-action-output.
+
+Introspecting agents requires admin access to the model.
+
+Examples:
+    juju debug-agent mysql/0 engine-report
+    juju debug-agent 0 goroutines
+    juju debug-agent 2/lxd/0 metrics --wait 5m
+
+See also:
+    debug-log
+    show-action-output
+`
+
+func (c *debugAgentCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "debug-agent",
+		Args:    "<unit>|<machine> " + strings.Join(actions.IntrospectQueries, "|"),
+		Purpose: "Fetch an introspection report from a unit or machine agent.",
+		Doc:     debugAgentDoc,
+	})
+}
+
+func (c *debugAgentCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.DurationVar(&c.wait, "wait", time.Minute, "How long to wait for the report")
+}
+
+func (c *debugAgentCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no unit or machine specified")
+	case 1:
+		return errors.New("no report specified")
+	}
+	switch name := args[0]; {
+	case names.IsValidUnit(name):
+		c.agent = names.NewUnitTag(name)
+	case names.IsValidMachine(name):
+		c.agent = names.NewMachineTag(name)
+	default:
+		return errors.Errorf("%q is not a valid unit or machine", name)
+	}
+	c.query = args[1]
+	if !set.NewStrings(actions.IntrospectQueries...).Contains(c.query) {
+		return errors.Errorf("unknown report %q, expected one of %s",
+			c.query, strings.Join(actions.IntrospectQueries, ", "))
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+func (c *debugAgentCommand) Run(ctx *cmd.Context) error {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return err
+	}
+	defer api.Close()
+	if api.BestAPIVersion() < 4 {
+		return errors.New("introspecting agents is not supported by this version of Juju")
+	}
+
+	results, err := api.Introspect([]names.Tag{c.agent}, c.query)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	actionTag, err := names.ParseActionTag(results[0].Action.Tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	result, err := GetActionResult(api, actionTag.Id(), time.NewTimer(c.wait))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	switch result.Status {
+	case params.ActionCompleted:
+	case params.ActionPending, params.ActionRunning:
+		return errors.Errorf("timed out waiting for the %s of %s, see juju show-action-output %s",
+			c.query, c.agent.Id(), actionTag.Id())
+	default:
+		return errors.Errorf("fetching the %s of %s failed: %s", c.query, c.agent.Id(), result.Message)
+	}
+
+	report, _ := result.Output["Stdout"].(string)
+	if result.Output["StdoutEncoding"] == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(report)
+		if err != nil {
+			return errors.Annotate(err, "decoding report")
+		}
+		report = string(decoded)
+	}
+	fmt.Fprint(ctx.Stdout, report)
+	return nil
+}
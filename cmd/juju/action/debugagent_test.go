@@ -0,0 +1,122 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type DebugAgentSuite struct {
+	BaseActionSuite
+	client *fakeAPIClient
+}
+
+var _ = gc.Suite(&DebugAgentSuite{})
+
+func (s *DebugAgentSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.store.Models["ctrl"].CurrentModel = "admin/admin"
+	s.client = &fakeAPIClient{
+		apiVersion:       4,
+		actionTagMatches: tagsForIdPrefix(validActionId, validActionTagString),
+		actionResults: []params.ActionResult{{
+			Action: &params.Action{
+				Tag:      validActionTagString,
+				Receiver: "machine-0",
+				Name:     "juju-introspect",
+			},
+			Status: params.ActionCompleted,
+			Output: map[string]interface{}{
+				"Stdout": "goroutine profile: total 42\n",
+			},
+		}},
+	}
+	restore := s.patchAPIClient(s.client)
+	s.AddCleanup(func(*gc.C) { restore() })
+}
+
+func (s *DebugAgentSuite) runDebugAgent(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewDebugAgentCommandForTest(s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *DebugAgentSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args        []string
+		expectError string
+	}{{
+		expectError: "no unit or machine specified",
+	}, {
+		args:        []string{"mysql/0"},
+		expectError: "no report specified",
+	}, {
+		args:        []string{"mysql", "goroutines"},
+		expectError: `"mysql" is not a valid unit or machine`,
+	}, {
+		args:        []string{"0", "heap"},
+		expectError: `unknown report "heap", expected one of engine-report, goroutines, metrics`,
+	}, {
+		args:        []string{"0", "metrics", "engine-report"},
+		expectError: `unrecognized args: \["engine-report"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(action.NewDebugAgentCommandForTest(s.store), test.args)
+		c.Check(err, gc.ErrorMatches, test.expectError)
+	}
+}
+
+func (s *DebugAgentSuite) TestDebugAgent(c *gc.C) {
+	out, err := s.runDebugAgent(c, "mysql/0", "goroutines")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "goroutine profile: total 42\n")
+	c.Assert(s.client.introspectArgs, jc.DeepEquals, []interface{}{
+		[]names.Tag{names.NewUnitTag("mysql/0")}, "goroutines",
+	})
+}
+
+func (s *DebugAgentSuite) TestDebugAgentBase64(c *gc.C) {
+	s.client.actionResults[0].Output = map[string]interface{}{
+		"Stdout":         "aGVsbG8=",
+		"StdoutEncoding": "base64",
+	}
+	out, err := s.runDebugAgent(c, "0", "metrics")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "hello")
+}
+
+func (s *DebugAgentSuite) TestDebugAgentFailed(c *gc.C) {
+	s.client.actionResults[0].Status = params.ActionFailed
+	s.client.actionResults[0].Message = "connection refused"
+	_, err := s.runDebugAgent(c, "mysql/0", "engine-report")
+	c.Assert(err, gc.ErrorMatches, "fetching the engine-report of mysql/0 failed: connection refused")
+}
+
+func (s *DebugAgentSuite) TestDebugAgentTimeout(c *gc.C) {
+	s.client.actionResults[0].Status = params.ActionPending
+	_, err := s.runDebugAgent(c, "mysql/0", "engine-report", "--wait", "0s")
+	c.Assert(err, gc.ErrorMatches, "timed out waiting for the engine-report of mysql/0, see juju show-action-output "+validActionId)
+}
+
+func (s *DebugAgentSuite) TestDebugAgentError(c *gc.C) {
+	s.client.actionResults[0] = params.ActionResult{
+		Error: &params.Error{Message: `unit "mysql/0" is not assigned to a machine`},
+	}
+	_, err := s.runDebugAgent(c, "mysql/0", "engine-report")
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/0" is not assigned to a machine`)
+}
+
+func (s *DebugAgentSuite) TestOldServer(c *gc.C) {
+	s.client.apiVersion = 3
+	_, err := s.runDebugAgent(c, "mysql/0", "engine-report")
+	c.Assert(err, gc.ErrorMatches, "introspecting agents is not supported by this version of Juju")
+}
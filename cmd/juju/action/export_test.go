@@ -95,6 +95,12 @@ func NewRunCommandForTest(store jujuclient.ClientStore) (cmd.Command, *RunComman
 	return modelcmd.Wrap(c, modelcmd.WrapSkipDefaultModel), &RunCommand{c}
 }
 
+func NewDebugAgentCommandForTest(store jujuclient.ClientStore) cmd.Command {
+	c := &debugAgentCommand{}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func ActionResultsToMap(results []params.ActionResult) map[string]interface{} {
 	return resultsToMap(results)
 }
@@ -14,6 +14,7 @@ import (
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/action"
@@ -130,6 +131,7 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	introspectArgs     []interface{}
 	apiVersion         int
 	apiErr             error
 }
@@ -218,3 +220,8 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) Introspect(agents []names.Tag, query string) ([]params.ActionResult, error) {
+	c.introspectArgs = []interface{}{agents, query}
+	return c.actionResults, c.apiErr
+}
@@ -50,6 +50,12 @@ This command also supports the placement directive ("--to") for targeting
 specific machines or containers, which will bypass application and model
 constraints.
 
+A unit may be placed on a machine already hosting units of other
+applications only if the model's "colocation-policy" allows it: with the
+default "permissive" policy, unless an anti-affinity rule for the pair of
+applications forbids it; with the "strict" policy, only if an affinity rule
+for the pair allows it.
+
 Examples:
 
 Add five units of mysql on five new machines:
@@ -0,0 +1,267 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/autoscale"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// defaultAutoscaleCooldown is the cooldown of new autoscaling policies
+// when none is specified.
+const defaultAutoscaleCooldown = 5 * time.Minute
+
+const (
+	autoscaleSummary = `Shows or sets how the number of units of an application is scaled.`
+	autoscaleDetails = `
+The controller can add and remove units of an application automatically,
+according to the values of a charm metric recorded by its units. A unit
+is added when the metric expression is above --scale-up-above, and one
+is removed when it is below --scale-down-below, while keeping between
+--min and --max units. After each change, the controller waits for the
+--cooldown to pass before changing the number of units again.
+
+The metric expression given by --metric is either the name of a charm
+metric, whose most recent values are averaged across the units, or one
+of avg, max, min or sum applied to the name of a metric, as in
+"max(cpu)". Only metrics recorded by the charm, with add-metric, can be
+used; Juju doesn't measure the utilisation of machines itself, so a
+charm must record CPU or memory utilisation as a metric for it to be
+used. Units whose charm doesn't record the metric are never scaled
+because of it, but are still kept between --min and --max units.
+
+Only applications in IAAS models can be autoscaled.
+
+When an application is first autoscaled, --max, --metric,
+--scale-up-above and --scale-down-below must be given. Options given
+later change only the corresponding part of the policy. --disable stops
+the application from being autoscaled, without changing its units.
+
+With no options, the application's autoscaling policy and the most
+recent changes made by the controller are shown.
+
+Examples:
+    juju autoscale wordpress
+    juju autoscale wordpress --max 5 --metric requests --scale-up-above 100 --scale-down-below 20
+    juju autoscale wordpress --metric "max(cpu)" --cooldown 10m
+    juju autoscale wordpress --disable
+
+See also:
+    add-unit
+    remove-unit
+    metrics
+`
+)
+
+// NewAutoscaleCommand returns a command which shows or sets the
+// autoscaling policy of an application.
+func NewAutoscaleCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&autoscaleCommand{})
+}
+
+// autoscaleAPI defines a subset of the autoscale facade, as required
+// by the autoscale command.
+type autoscaleAPI interface {
+	Close() error
+	AutoscalePolicy(string) (params.AutoscaleResult, error)
+	SetAutoscalePolicy(string, params.AutoscalePolicy) error
+	RemoveAutoscalePolicy(string) error
+}
+
+// autoscaleCommand shows or sets the autoscaling policy of an
+// application.
+type autoscaleCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	out cmd.Output
+	fs  *gnuflag.FlagSet
+
+	api autoscaleAPI
+
+	applicationName string
+	minUnits        int
+	maxUnits        int
+	metric          string
+	scaleUpAbove    float64
+	scaleDownBelow  float64
+	cooldown        time.Duration
+	disable         bool
+
+	// set holds the names of the policy options specified.
+	set map[string]bool
+}
+
+// Info is part of the cmd.Command interface.
+func (c *autoscaleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "autoscale",
+		Args:    "<application name>",
+		Purpose: autoscaleSummary,
+		Doc:     autoscaleDetails,
+	})
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *autoscaleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.IntVar(&c.minUnits, "min", 1, "Minimum number of units")
+	f.IntVar(&c.maxUnits, "max", 0, "Maximum number of units")
+	f.StringVar(&c.metric, "metric", "", "Metric expression, such as \"cpu\" or \"max(cpu)\"")
+	f.Float64Var(&c.scaleUpAbove, "scale-up-above", 0, "Add a unit when the metric expression is above this value")
+	f.Float64Var(&c.scaleDownBelow, "scale-down-below", 0, "Remove a unit when the metric expression is below this value")
+	f.DurationVar(&c.cooldown, "cooldown", defaultAutoscaleCooldown, "Minimum time between changes made because of the metric expression")
+	f.BoolVar(&c.disable, "disable", false, "Stop autoscaling the application")
+	c.fs = f
+}
+
+// Init is part of the cmd.Command interface.
+func (c *autoscaleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	c.set = make(map[string]bool)
+	c.fs.Visit(func(flag *gnuflag.Flag) {
+		switch flag.Name {
+		case "min", "max", "metric", "scale-up-above", "scale-down-below", "cooldown":
+			c.set[flag.Name] = true
+		}
+	})
+	if c.disable && len(c.set) > 0 {
+		return errors.New("cannot specify --disable with other autoscaling options")
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *autoscaleCommand) getAPI() (autoscaleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := autoscale.NewClient(root)
+	if client.BestAPIVersion() < 1 {
+		client.Close()
+		return nil, errors.New("autoscaling is not supported by this version of Juju")
+	}
+	return client, nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *autoscaleCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if c.disable {
+		return block.ProcessBlockedError(client.RemoveAutoscalePolicy(c.applicationName), block.BlockChange)
+	}
+	result, err := client.AutoscalePolicy(c.applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(c.set) == 0 {
+		return c.out.Write(ctx, formatAutoscale(result))
+	}
+
+	var policy params.AutoscalePolicy
+	if result.Policy != nil {
+		policy = *result.Policy
+	} else {
+		for _, name := range []string{"max", "metric", "scale-up-above", "scale-down-below"} {
+			if !c.set[name] {
+				return errors.Errorf("--%s must be specified when autoscaling %q for the first time", name, c.applicationName)
+			}
+		}
+		policy.MinUnits = c.minUnits
+		policy.Cooldown = c.cooldown
+	}
+	if c.set["min"] {
+		policy.MinUnits = c.minUnits
+	}
+	if c.set["max"] {
+		policy.MaxUnits = c.maxUnits
+	}
+	if c.set["metric"] {
+		policy.Metric = c.metric
+	}
+	if c.set["scale-up-above"] {
+		policy.ScaleUpAbove = c.scaleUpAbove
+	}
+	if c.set["scale-down-below"] {
+		policy.ScaleDownBelow = c.scaleDownBelow
+	}
+	if c.set["cooldown"] {
+		policy.Cooldown = c.cooldown
+	}
+	return block.ProcessBlockedError(client.SetAutoscalePolicy(c.applicationName, policy), block.BlockChange)
+}
+
+// autoscaleOutput is the output format of an application's autoscaling
+// details.
+type autoscaleOutput struct {
+	Units   int                    `yaml:"units" json:"units"`
+	Policy  *autoscalePolicyOutput `yaml:"policy,omitempty" json:"policy,omitempty"`
+	History []autoscaleEventOutput `yaml:"history,omitempty" json:"history,omitempty"`
+}
+
+type autoscalePolicyOutput struct {
+	MinUnits       int     `yaml:"min-units" json:"min-units"`
+	MaxUnits       int     `yaml:"max-units" json:"max-units"`
+	Metric         string  `yaml:"metric" json:"metric"`
+	ScaleUpAbove   float64 `yaml:"scale-up-above" json:"scale-up-above"`
+	ScaleDownBelow float64 `yaml:"scale-down-below" json:"scale-down-below"`
+	Cooldown       string  `yaml:"cooldown" json:"cooldown"`
+}
+
+type autoscaleEventOutput struct {
+	Time   time.Time `yaml:"time" json:"time"`
+	From   int       `yaml:"from" json:"from"`
+	To     int       `yaml:"to" json:"to"`
+	Reason string    `yaml:"reason" json:"reason"`
+}
+
+func formatAutoscale(result params.AutoscaleResult) autoscaleOutput {
+	out := autoscaleOutput{Units: result.Units}
+	if p := result.Policy; p != nil {
+		out.Policy = &autoscalePolicyOutput{
+			MinUnits:       p.MinUnits,
+			MaxUnits:       p.MaxUnits,
+			Metric:         p.Metric,
+			ScaleUpAbove:   p.ScaleUpAbove,
+			ScaleDownBelow: p.ScaleDownBelow,
+			Cooldown:       p.Cooldown.String(),
+		}
+	}
+	for _, e := range result.Events {
+		out.History = append(out.History, autoscaleEventOutput{
+			Time:   e.Time,
+			From:   e.From,
+			To:     e.To,
+			Reason: e.Reason,
+		})
+	}
+	return out
+}
@@ -0,0 +1,180 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type autoscaleSuite struct {
+	testing.IsolationSuite
+	api *mockAutoscaleAPI
+}
+
+var _ = gc.Suite(&autoscaleSuite{})
+
+func (s *autoscaleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &mockAutoscaleAPI{
+		result: params.AutoscaleResult{
+			ApplicationTag: "application-wordpress",
+			Policy: &params.AutoscalePolicy{
+				MinUnits:       1,
+				MaxUnits:       4,
+				Metric:         "max(cpu)",
+				ScaleUpAbove:   80,
+				ScaleDownBelow: 20.5,
+				Cooldown:       5 * time.Minute,
+			},
+			Events: []params.AutoscaleEvent{{
+				Time:   time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC),
+				From:   1,
+				To:     2,
+				Reason: "max(cpu) 90 above 80",
+			}},
+			Units: 2,
+		},
+	}
+}
+
+func (s *autoscaleSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewAutoscaleCommandForTest(s.api, store), args...)
+}
+
+func (s *autoscaleSuite) TestShow(c *gc.C) {
+	ctx, err := s.run(c, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+units: 2
+policy:
+  min-units: 1
+  max-units: 4
+  metric: max(cpu)
+  scale-up-above: 80
+  scale-down-below: 20.5
+  cooldown: 5m0s
+history:
+- time: 2019-05-01T12:00:00Z
+  from: 1
+  to: 2
+  reason: max(cpu) 90 above 80
+`[1:])
+	s.api.CheckCallNames(c, "AutoscalePolicy", "Close")
+}
+
+func (s *autoscaleSuite) TestShowNotAutoscaled(c *gc.C) {
+	s.api.result = params.AutoscaleResult{Units: 3}
+	ctx, err := s.run(c, "wordpress", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"units":3}`+"\n")
+}
+
+func (s *autoscaleSuite) TestUpdate(c *gc.C) {
+	_, err := s.run(c, "wordpress", "--max", "6", "--cooldown", "10m")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "AutoscalePolicy", "SetAutoscalePolicy", "Close")
+	s.api.CheckCall(c, 1, "SetAutoscalePolicy", "wordpress", params.AutoscalePolicy{
+		MinUnits:       1,
+		MaxUnits:       6,
+		Metric:         "max(cpu)",
+		ScaleUpAbove:   80,
+		ScaleDownBelow: 20.5,
+		Cooldown:       10 * time.Minute,
+	})
+}
+
+func (s *autoscaleSuite) TestCreate(c *gc.C) {
+	s.api.result = params.AutoscaleResult{Units: 1}
+	_, err := s.run(c, "wordpress", "--max", "3", "--metric", "requests",
+		"--scale-up-above", "100", "--scale-down-below", "10")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 1, "SetAutoscalePolicy", "wordpress", params.AutoscalePolicy{
+		MinUnits:       1,
+		MaxUnits:       3,
+		Metric:         "requests",
+		ScaleUpAbove:   100,
+		ScaleDownBelow: 10,
+		Cooldown:       5 * time.Minute,
+	})
+}
+
+func (s *autoscaleSuite) TestCreateMissingOption(c *gc.C) {
+	s.api.result = params.AutoscaleResult{Units: 1}
+	_, err := s.run(c, "wordpress", "--max", "3", "--metric", "requests")
+	c.Assert(err, gc.ErrorMatches, `--scale-up-above must be specified when autoscaling "wordpress" for the first time`)
+	s.api.CheckCallNames(c, "AutoscalePolicy", "Close")
+}
+
+func (s *autoscaleSuite) TestDisable(c *gc.C) {
+	_, err := s.run(c, "wordpress", "--disable")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "RemoveAutoscalePolicy", "Close")
+	s.api.CheckCall(c, 0, "RemoveAutoscalePolicy", "wordpress")
+}
+
+func (s *autoscaleSuite) TestSetError(c *gc.C) {
+	s.api.SetErrors(nil, errors.New("boom"))
+	_, err := s.run(c, "wordpress", "--min", "2")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *autoscaleSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"wordpress/0"},
+		err:  `invalid application name "wordpress/0"`,
+	}, {
+		args: []string{"wordpress", "--disable", "--max", "2"},
+		err:  "cannot specify --disable with other autoscaling options",
+	}, {
+		args: []string{"wordpress", "mysql"},
+		err:  `unrecognized args: \["mysql"\]`,
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type mockAutoscaleAPI struct {
+	testing.Stub
+	result params.AutoscaleResult
+}
+
+func (a *mockAutoscaleAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockAutoscaleAPI) AutoscalePolicy(application string) (params.AutoscaleResult, error) {
+	a.MethodCall(a, "AutoscalePolicy", application)
+	return a.result, a.NextErr()
+}
+
+func (a *mockAutoscaleAPI) SetAutoscalePolicy(application string, policy params.AutoscalePolicy) error {
+	a.MethodCall(a, "SetAutoscalePolicy", application, policy)
+	return a.NextErr()
+}
+
+func (a *mockAutoscaleAPI) RemoveAutoscalePolicy(application string) error {
+	a.MethodCall(a, "RemoveAutoscalePolicy", application)
+	return a.NextErr()
+}
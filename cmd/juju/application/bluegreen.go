@@ -0,0 +1,237 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	blueGreenSummary = `Replaces an application with a shadow application running a new charm revision.`
+	blueGreenDetails = `
+A blue/green deployment replaces an application with a shadow application
+running a new revision of its charm, moving relations over one endpoint at
+a time so that the applications related to it always have a counterpart.
+It is carried out in three steps:
+
+start deploys the shadow application, with the same charm settings,
+application settings, constraints, bindings and number of units as the
+application. The new charm must be given as a charm store URL including
+its revision. Charm settings may be overridden with --config, using the
+same format as "juju deploy".
+
+migrate moves relations from the application to the shadow application.
+Each relation is added for the shadow application before it is removed
+from the application. When endpoints are given, only their relations are
+moved.
+
+finalise removes the application, once all of its relations have been
+moved. Application names cannot be changed, so the shadow application
+keeps its name.
+
+The shadow application is named after the application with a "-green"
+suffix, unless a name is given with --shadow.
+
+Examples:
+    juju bluegreen start mysql cs:mysql-58
+    juju bluegreen migrate mysql db
+    juju bluegreen migrate mysql
+    juju bluegreen finalise mysql
+
+See also:
+    upgrade-charm
+    relate
+    remove-application
+`
+)
+
+const (
+	blueGreenStart    = "start"
+	blueGreenMigrate  = "migrate"
+	blueGreenFinalise = "finalise"
+)
+
+// NewBlueGreenCommand returns a command which carries out the steps of
+// a blue/green deployment.
+func NewBlueGreenCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&blueGreenCommand{})
+}
+
+// blueGreenAPI defines the API methods used by the bluegreen command.
+type blueGreenAPI interface {
+	Close() error
+	AddCharm(*charm.URL, csparams.Channel, bool) error
+	CreateShadowApplication(params.CreateShadowApplicationArg) error
+	MigrateRelations(application, shadow string, endpoints ...string) error
+	FinaliseShadowApplication(application, shadow string) error
+}
+
+// blueGreenCommand carries out the steps of a blue/green deployment.
+type blueGreenCommand struct {
+	modelcmd.ModelCommandBase
+
+	api blueGreenAPI
+
+	step            string
+	applicationName string
+	shadowName      string
+	charmURL        *charm.URL
+	endpoints       []string
+
+	channel  string
+	config   cmd.FileVar
+	numUnits int
+}
+
+// Info is part of the cmd.Command interface.
+func (c *blueGreenCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "bluegreen",
+		Args:    "(start <application> <charm url> | migrate <application> [<endpoint> ...] | finalise <application>)",
+		Purpose: blueGreenSummary,
+		Doc:     blueGreenDetails,
+	})
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *blueGreenCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.shadowName, "shadow", "", "Name of the shadow application")
+	f.StringVar(&c.channel, "channel", "", "Channel of the new charm (start only)")
+	f.Var(&c.config, "config", "Path to yaml-formatted charm config overrides (start only)")
+	f.IntVar(&c.numUnits, "num-units", -1, "Number of units of the shadow application, defaults to those of the application (start only)")
+}
+
+// Init is part of the cmd.Command interface.
+func (c *blueGreenCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("expected a step (start, migrate or finalise) and an application name")
+	}
+	c.step, c.applicationName, args = args[0], args[1], args[2:]
+	if !names.IsValidApplication(c.applicationName) {
+		return errors.Errorf("invalid application name %q", c.applicationName)
+	}
+	if c.shadowName == "" {
+		c.shadowName = c.applicationName + "-green"
+	} else if !names.IsValidApplication(c.shadowName) {
+		return errors.Errorf("invalid shadow application name %q", c.shadowName)
+	}
+	if c.step != blueGreenStart && (c.channel != "" || c.config.Path != "" || c.numUnits >= 0) {
+		return errors.Errorf("--channel, --config and --num-units can only be used with %q", blueGreenStart)
+	}
+
+	switch c.step {
+	case blueGreenStart:
+		if len(args) == 0 {
+			return errors.New("no charm URL specified")
+		}
+		curl, err := charm.ParseURL(args[0])
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if curl.Schema != "cs" || curl.Revision < 0 {
+			return errors.Errorf("charm URL %q must be a charm store URL with a revision", args[0])
+		}
+		c.charmURL = curl
+		return cmd.CheckEmpty(args[1:])
+	case blueGreenMigrate:
+		c.endpoints = args
+		return nil
+	case blueGreenFinalise:
+		return cmd.CheckEmpty(args)
+	}
+	return errors.Errorf("unknown step %q, expected start, migrate or finalise", c.step)
+}
+
+type blueGreenClient struct {
+	*application.Client
+	modelClient *api.Client
+}
+
+// AddCharm is part of the blueGreenAPI interface.
+func (c blueGreenClient) AddCharm(curl *charm.URL, channel csparams.Channel, force bool) error {
+	return c.modelClient.AddCharm(curl, channel, force)
+}
+
+func (c *blueGreenCommand) getAPI() (blueGreenAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := application.NewClient(root)
+	if client.BestAPIVersion() < 14 {
+		client.Close()
+		return nil, errors.New("blue/green deployments are not supported by this version of Juju")
+	}
+	return blueGreenClient{Client: client, modelClient: root.Client()}, nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *blueGreenCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	switch c.step {
+	case blueGreenStart:
+		err = c.start(ctx, client)
+	case blueGreenMigrate:
+		err = client.MigrateRelations(c.applicationName, c.shadowName, c.endpoints...)
+		if err == nil {
+			ctx.Infof("Relations moved to %q.", c.shadowName)
+		}
+	case blueGreenFinalise:
+		err = client.FinaliseShadowApplication(c.applicationName, c.shadowName)
+		if err == nil {
+			ctx.Infof("Removing %q, replaced by %q.", c.applicationName, c.shadowName)
+		}
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
+
+func (c *blueGreenCommand) start(ctx *cmd.Context, client blueGreenAPI) error {
+	channel := csparams.Channel(c.channel)
+	if err := client.AddCharm(c.charmURL, channel, false); err != nil {
+		return errors.Trace(err)
+	}
+	arg := params.CreateShadowApplicationArg{
+		ApplicationName: c.applicationName,
+		ShadowName:      c.shadowName,
+		CharmURL:        c.charmURL.String(),
+		Channel:         c.channel,
+	}
+	if c.config.Path != "" {
+		configYAML, err := c.config.Read(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		arg.ConfigYAML = string(configYAML)
+	}
+	if c.numUnits >= 0 {
+		numUnits := c.numUnits
+		arg.NumUnits = &numUnits
+	}
+	if err := client.CreateShadowApplication(arg); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Deployed %q as the shadow of %q.", c.shadowName, c.applicationName)
+	return nil
+}
@@ -0,0 +1,165 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	jtesting "github.com/juju/juju/testing"
+)
+
+type blueGreenSuite struct {
+	testing.IsolationSuite
+	api *mockBlueGreenAPI
+}
+
+var _ = gc.Suite(&blueGreenSuite{})
+
+func (s *blueGreenSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &mockBlueGreenAPI{}
+}
+
+func (s *blueGreenSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewBlueGreenCommandForTest(s.api, store), args...)
+}
+
+func (s *blueGreenSuite) TestStart(c *gc.C) {
+	ctx, err := s.run(c, "start", "mysql", "cs:mysql-58", "--channel", "candidate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `Deployed "mysql-green" as the shadow of "mysql".`+"\n")
+	s.api.CheckCallNames(c, "AddCharm", "CreateShadowApplication", "Close")
+	s.api.CheckCall(c, 0, "AddCharm", charm.MustParseURL("cs:mysql-58"), csparams.Channel("candidate"), false)
+	s.api.CheckCall(c, 1, "CreateShadowApplication", params.CreateShadowApplicationArg{
+		ApplicationName: "mysql",
+		ShadowName:      "mysql-green",
+		CharmURL:        "cs:mysql-58",
+		Channel:         "candidate",
+	})
+}
+
+func (s *blueGreenSuite) TestStartWithConfigAndUnits(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "config.yaml")
+	err := ioutil.WriteFile(path, []byte("mysql:\n  flavour: percona\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.run(c, "start", "mysql", "cs:mysql-58", "--shadow", "mysql-blue", "--config", path, "--num-units", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	numUnits := 0
+	s.api.CheckCall(c, 1, "CreateShadowApplication", params.CreateShadowApplicationArg{
+		ApplicationName: "mysql",
+		ShadowName:      "mysql-blue",
+		CharmURL:        "cs:mysql-58",
+		ConfigYAML:      "mysql:\n  flavour: percona\n",
+		NumUnits:        &numUnits,
+	})
+}
+
+func (s *blueGreenSuite) TestMigrate(c *gc.C) {
+	_, err := s.run(c, "migrate", "mysql", "db", "db-admin")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "MigrateRelations", "Close")
+	s.api.CheckCall(c, 0, "MigrateRelations", "mysql", "mysql-green", []string{"db", "db-admin"})
+}
+
+func (s *blueGreenSuite) TestFinalise(c *gc.C) {
+	_, err := s.run(c, "finalise", "mysql", "--shadow", "mysql-blue")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "FinaliseShadowApplication", "Close")
+	s.api.CheckCall(c, 0, "FinaliseShadowApplication", "mysql", "mysql-blue")
+}
+
+func (s *blueGreenSuite) TestError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.run(c, "finalise", "mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *blueGreenSuite) TestBlocked(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestBlocked"))
+	_, err := s.run(c, "migrate", "mysql")
+	jtesting.AssertOperationWasBlocked(c, err, ".*TestBlocked.*")
+}
+
+func (s *blueGreenSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"start"},
+		err:  `expected a step \(start, migrate or finalise\) and an application name`,
+	}, {
+		args: []string{"upgrade", "mysql"},
+		err:  `unknown step "upgrade", expected start, migrate or finalise`,
+	}, {
+		args: []string{"start", "mysql/0", "cs:mysql-58"},
+		err:  `invalid application name "mysql/0"`,
+	}, {
+		args: []string{"start", "mysql", "cs:mysql-58", "--shadow", "Mysql"},
+		err:  `invalid shadow application name "Mysql"`,
+	}, {
+		args: []string{"start", "mysql"},
+		err:  "no charm URL specified",
+	}, {
+		args: []string{"start", "mysql", "cs:mysql"},
+		err:  `charm URL "cs:mysql" must be a charm store URL with a revision`,
+	}, {
+		args: []string{"start", "mysql", "local:mysql-1"},
+		err:  `charm URL "local:mysql-1" must be a charm store URL with a revision`,
+	}, {
+		args: []string{"migrate", "mysql", "--num-units", "2"},
+		err:  `--channel, --config and --num-units can only be used with "start"`,
+	}, {
+		args: []string{"finalise", "mysql", "db"},
+		err:  `unrecognized args: \["db"\]`,
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type mockBlueGreenAPI struct {
+	testing.Stub
+}
+
+func (a *mockBlueGreenAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockBlueGreenAPI) AddCharm(curl *charm.URL, channel csparams.Channel, force bool) error {
+	a.MethodCall(a, "AddCharm", curl, channel, force)
+	return a.NextErr()
+}
+
+func (a *mockBlueGreenAPI) CreateShadowApplication(arg params.CreateShadowApplicationArg) error {
+	a.MethodCall(a, "CreateShadowApplication", arg)
+	return a.NextErr()
+}
+
+func (a *mockBlueGreenAPI) MigrateRelations(application, shadow string, endpoints ...string) error {
+	a.MethodCall(a, "MigrateRelations", application, shadow, endpoints)
+	return a.NextErr()
+}
+
+func (a *mockBlueGreenAPI) FinaliseShadowApplication(application, shadow string) error {
+	a.MethodCall(a, "FinaliseShadowApplication", application, shadow)
+	return a.NextErr()
+}
@@ -103,6 +103,62 @@ func verifyBundle(data *charm.BundleData, bundleDir string) error {
 	return errors.Trace(verifyError)
 }
 
+// zonePlacementPrefix identifies a unit placement naming the availability
+// zone a unit's machine should be started in.
+const zonePlacementPrefix = "zone="
+
+// extractZonePlacements removes "zone=<name>" unit placements, which the
+// bundle format does not otherwise accept, from the bundle applications
+// and returns them keyed by application name.
+func extractZonePlacements(data *charm.BundleData) (map[string][]string, error) {
+	zonePlacements := make(map[string][]string)
+	for name, app := range data.Applications {
+		if app == nil {
+			continue
+		}
+		var zones, others []string
+		for _, placement := range app.To {
+			if strings.HasPrefix(placement, zonePlacementPrefix) {
+				zones = append(zones, placement)
+			} else {
+				others = append(others, placement)
+			}
+		}
+		if len(zones) == 0 {
+			continue
+		}
+		if len(others) > 0 {
+			return nil, errors.Errorf("application %q: zone placements cannot be mixed with machine placements", name)
+		}
+		zonePlacements[name] = zones
+		app.To = nil
+	}
+	return zonePlacements, nil
+}
+
+// checkBundlePlacements asks the controller to verify the zone
+// placements, constraints and endpoint bindings of the bundle against
+// the model, so that a bundle which cannot be satisfied is rejected
+// before any machine is created. Controllers that do not support the
+// check are skipped.
+func checkBundlePlacements(data *charm.BundleData, apiRoot DeployAPI) error {
+	if apiRoot.BestFacadeVersion("Bundle") < 3 {
+		return nil
+	}
+	bundleYAML, err := yaml.Marshal(data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	problems, err := apiRoot.CheckBundlePlacements(string(bundleYAML))
+	if err != nil {
+		return errors.Annotate(err, "cannot check bundle placements")
+	}
+	if len(problems) > 0 {
+		return errors.New("the provided bundle has the following placement errors:\n" + strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
 // deployBundle deploys the given bundle data using the given API client and
 // charm store client. The deployment is not transactional, and its progress is
 // notified using the given deployment logger.
@@ -124,12 +180,19 @@ func deployBundle(
 	if err := composeBundle(data, ctx, bundleDir, bundleOverlayFile); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := checkBundlePlacements(data, apiRoot); err != nil {
+		return nil, errors.Trace(err)
+	}
+	zonePlacements, err := extractZonePlacements(data)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	if err := verifyBundle(data, bundleDir); err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	// TODO: move bundle parsing and checking into the handler.
-	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleURL, bundleStorage, bundleDevices)
+	h := makeBundleHandler(dryRun, bundleDir, channel, apiRoot, ctx, data, bundleURL, bundleStorage, bundleDevices, zonePlacements)
 	if err := h.makeModel(useExistingMachines, bundleMachines); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -190,6 +253,15 @@ type bundleHandler struct {
 	// in the bundle itself.
 	bundleDevices map[string]map[string]devices.Constraints
 
+	// zonePlacements holds the "zone=<name>" unit placements of each
+	// application, which are applied in order to units added without a
+	// machine placement.
+	zonePlacements map[string][]string
+
+	// zoneUnits counts, for each application, the units that have been
+	// placed using its zone placements.
+	zoneUnits map[string]int
+
 	// ctx is the command context, which is used to output messages to the
 	// user, so that the user can keep track of the bundle deployment
 	// progress.
@@ -235,26 +307,29 @@ func makeBundleHandler(
 	bundleURL *charm.URL,
 	bundleStorage map[string]map[string]storage.Constraints,
 	bundleDevices map[string]map[string]devices.Constraints,
+	zonePlacements map[string][]string,
 ) *bundleHandler {
 	applications := set.NewStrings()
 	for name := range data.Applications {
 		applications.Add(name)
 	}
 	return &bundleHandler{
-		dryRun:        dryRun,
-		bundleDir:     bundleDir,
-		applications:  applications,
-		results:       make(map[string]string),
-		channel:       channel,
-		api:           api,
-		bundleStorage: bundleStorage,
-		bundleDevices: bundleDevices,
-		ctx:           ctx,
-		data:          data,
-		bundleURL:     bundleURL,
-		unitStatus:    make(map[string]string),
-		macaroons:     make(map[*charm.URL]*macaroon.Macaroon),
-		channels:      make(map[*charm.URL]csparams.Channel),
+		dryRun:         dryRun,
+		bundleDir:      bundleDir,
+		applications:   applications,
+		results:        make(map[string]string),
+		channel:        channel,
+		api:            api,
+		bundleStorage:  bundleStorage,
+		bundleDevices:  bundleDevices,
+		zonePlacements: zonePlacements,
+		zoneUnits:      make(map[string]int),
+		ctx:            ctx,
+		data:           data,
+		bundleURL:      bundleURL,
+		unitStatus:     make(map[string]string),
+		macaroons:      make(map[*charm.URL]*macaroon.Macaroon),
+		channels:       make(map[*charm.URL]csparams.Channel),
 	}
 }
 
@@ -290,13 +365,13 @@ func (h *bundleHandler) makeModel(
 // resolve the charm URLs. From the model the charm names are
 // fully qualified, meaning they have a source and revision id.
 // Effectively the logic this method follows is:
-//   * if the bundle specifies a local charm, and the application
+//   - if the bundle specifies a local charm, and the application
 //     exists already, then override the charm URL in the bundle
 //     spec to match the charm name from the model. We don't
 //     upgrade local charms as part of a bundle deploy.
-//   * the charm URL is resolved and the bundle spec is replaced
+//   - the charm URL is resolved and the bundle spec is replaced
 //     with the fully resolved charm URL - i.e.: with rev id.
-//   * check all endpoints, and if any of them have implicit endpoints,
+//   - check all endpoints, and if any of them have implicit endpoints,
 //     and if they do, resolve the implicitness in order to compare
 //     with relations in the model.
 func (h *bundleHandler) resolveCharmsAndEndpoints() error {
@@ -830,6 +905,15 @@ func (h *bundleHandler) addUnit(change *bundlechanges.AddUnitChange) error {
 		}
 		logger.Debugf("  resolved: placement %q", directive)
 		placementArg = append(placementArg, placement)
+	} else if zones := h.zonePlacements[applicationName]; h.zoneUnits[applicationName] < len(zones) {
+		directive := zones[h.zoneUnits[applicationName]]
+		h.zoneUnits[applicationName]++
+		logger.Debugf("addUnit: zone placement %q", directive)
+		modelUUID, _ := h.api.ModelUUID()
+		placementArg = append(placementArg, &instance.Placement{
+			Scope:     modelUUID,
+			Directive: directive,
+		})
 	}
 	r, err := h.api.AddUnits(application.AddUnitsParams{
 		ApplicationName: applicationName,
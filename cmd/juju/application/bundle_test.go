@@ -277,18 +277,16 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleEndpointBindingsSpaceMissi
 	testcharms.UploadCharm(c, s.client, "xenial/wordpress-extra-bindings-47", "wordpress-extra-bindings")
 	testcharms.UploadBundle(c, s.client, "bundle/wordpress-with-endpoint-bindings-1", "wordpress-with-endpoint-bindings")
 	stdOut, stdErr, err := runDeployWithOutput(c, "bundle/wordpress-with-endpoint-bindings")
-	c.Assert(err, gc.ErrorMatches, ""+
-		"cannot deploy bundle: cannot deploy application \"mysql\": "+
-		"cannot add application \"mysql\": unknown space \"db\" not valid")
-	c.Assert(stdErr, gc.Equals, ""+
-		`Located bundle "cs:bundle/wordpress-with-endpoint-bindings-1"`+"\n"+
-		"Resolving charm: mysql\n"+
-		"Resolving charm: wordpress-extra-bindings")
-	c.Assert(stdOut, gc.Equals, ""+
-		"Executing changes:\n"+
-		"- upload charm cs:xenial/mysql-42 for series xenial\n"+
-		"- deploy application mysql on xenial using cs:xenial/mysql-42")
-	s.assertCharmsUploaded(c, "cs:xenial/mysql-42")
+	c.Assert(err.Error(), gc.Equals, ""+
+		"cannot deploy bundle: the provided bundle has the following placement errors:\n"+
+		`application "mysql": binding for endpoint "server": space "db" not found`+"\n"+
+		`application "wordpress-extra-bindings": binding for endpoint "admin-api": space "public" not found`+"\n"+
+		`application "wordpress-extra-bindings": binding for endpoint "db": space "db" not found`+"\n"+
+		`application "wordpress-extra-bindings": binding for endpoint "db-client": space "db" not found`+"\n"+
+		`application "wordpress-extra-bindings": binding for endpoint "url": space "public" not found`)
+	c.Assert(stdErr, gc.Equals, `Located bundle "cs:bundle/wordpress-with-endpoint-bindings-1"`)
+	c.Assert(stdOut, gc.Equals, "")
+	s.assertCharmsUploaded(c)
 	s.assertApplicationsDeployed(c, map[string]applicationInfo{})
 	s.assertUnitsCreated(c, map[string]string{})
 }
@@ -818,8 +816,10 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleInvalidSeries(c *gc.C) {
 }
 
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleInvalidBinding(c *gc.C) {
+	_, err := s.State.AddSpace("public", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
 	testcharms.UploadCharm(c, s.client, "xenial/wordpress-42", "wordpress")
-	err := s.DeployBundleYAML(c, `
+	err = s.DeployBundleYAML(c, `
         applications:
             wp:
                 charm: xenial/wordpress-42
@@ -840,9 +840,55 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundleInvalidSpace(c *gc.C) {
                 bindings:
                   url: public
     `)
-	// TODO(jam): 2017-02-05 double repeating "cannot deploy application" and "cannot add application" is a bit ugly
-	// https://pad.lv/1661937
-	c.Assert(err, gc.ErrorMatches, `cannot deploy bundle: cannot deploy application "wp": cannot add application "wp": unknown space "public" not valid`)
+	c.Assert(err, gc.ErrorMatches, "cannot deploy bundle: the provided bundle has the following placement errors:\n"+
+		`application "wp": binding for endpoint "url": space "public" not found`)
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleZonePlacement(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	err := s.DeployBundleYAML(c, `
+        applications:
+            mysql:
+                charm: xenial/mysql-42
+                num_units: 2
+                to: ["zone=zone1", "zone=zone3"]
+    `)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertUnitsCreated(c, map[string]string{
+		"mysql/0": "0",
+		"mysql/1": "1",
+	})
+	for id, zone := range map[string]string{"0": "zone1", "1": "zone3"} {
+		m, err := s.State.Machine(id)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(m.Placement(), gc.Equals, "zone="+zone)
+	}
+}
+
+func (s *BundleDeployCharmStoreSuite) TestDeployBundleInvalidPlacementsReportedTogether(c *gc.C) {
+	testcharms.UploadCharm(c, s.client, "xenial/mysql-42", "mysql")
+	testcharms.UploadCharm(c, s.client, "xenial/wordpress-42", "wordpress")
+	err := s.DeployBundleYAML(c, `
+        applications:
+            mysql:
+                charm: xenial/mysql-42
+                num_units: 1
+                to: ["zone=nowhere"]
+            wp:
+                charm: xenial/wordpress-42
+                num_units: 1
+                bindings:
+                  url: public
+    `)
+	c.Assert(err.Error(), gc.Equals, ""+
+		"cannot deploy bundle: the provided bundle has the following placement errors:\n"+
+		`application "mysql": placement "zone=nowhere": availability zone "nowhere" not found`+"\n"+
+		`application "wp": binding for endpoint "url": space "public" not found`)
+	s.assertCharmsUploaded(c)
+	s.assertApplicationsDeployed(c, map[string]applicationInfo{})
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 0)
 }
 
 func (s *BundleDeployCharmStoreSuite) TestDeployBundleWatcherTimeout(c *gc.C) {
@@ -2327,7 +2373,7 @@ func (s *BundleDeployCharmStoreSuite) TestDeployBundlePassesSequences(c *gc.C) {
 		m, err := s.State.Machine(id)
 		c.Assert(err, jc.ErrorIsNil)
 		machines = append(machines, m)
-		c.Assert(m.ForceDestroy(), jc.ErrorIsNil)
+		c.Assert(m.ForceDestroy(time.Duration(0)), jc.ErrorIsNil)
 	}
 	// Tear them down. This is somewhat convoluted, but it is what we need
 	// to do to properly cleanly tear down machines.
@@ -2423,6 +2469,36 @@ func (*removeRelationsSuite) TestRemoveFromLeft(c *gc.C) {
 	})
 }
 
+type extractZonePlacementsSuite struct{}
+
+var _ = gc.Suite(&extractZonePlacementsSuite{})
+
+func (*extractZonePlacementsSuite) TestExtract(c *gc.C) {
+	data := &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"mysql":     {NumUnits: 2, To: []string{"zone=a", "zone=b"}},
+			"wordpress": {NumUnits: 1, To: []string{"0"}},
+		},
+	}
+	zones, err := extractZonePlacements(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, jc.DeepEquals, map[string][]string{
+		"mysql": {"zone=a", "zone=b"},
+	})
+	c.Assert(data.Applications["mysql"].To, gc.IsNil)
+	c.Assert(data.Applications["wordpress"].To, jc.DeepEquals, []string{"0"})
+}
+
+func (*extractZonePlacementsSuite) TestMixedPlacements(c *gc.C) {
+	data := &charm.BundleData{
+		Applications: map[string]*charm.ApplicationSpec{
+			"mysql": {NumUnits: 2, To: []string{"zone=a", "lxd:0"}},
+		},
+	}
+	_, err := extractZonePlacements(data)
+	c.Assert(err, gc.ErrorMatches, `application "mysql": zone placements cannot be mixed with machine placements`)
+}
+
 func missingFileRegex(filename string) string {
 	text := "no such file or directory"
 	if runtime.GOOS == "windows" {
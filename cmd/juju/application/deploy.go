@@ -5,6 +5,7 @@ package application
 
 import (
 	"archive/zip"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -28,8 +29,10 @@ import (
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/annotations"
 	"github.com/juju/juju/api/application"
+	apibundle "github.com/juju/juju/api/bundle"
 	apicharms "github.com/juju/juju/api/charms"
 	"github.com/juju/juju/api/controller"
+	"github.com/juju/juju/api/machinemanager"
 	"github.com/juju/juju/api/modelconfig"
 	app "github.com/juju/juju/apiserver/facades/client/application"
 	apiparams "github.com/juju/juju/apiserver/params"
@@ -108,6 +111,13 @@ type DeployAPI interface {
 
 	GetBundle(*charm.URL) (charm.Bundle, error)
 
+	// CheckBundlePlacements returns a description of each placement,
+	// constraint or binding in the bundle that the model cannot satisfy.
+	CheckBundlePlacements(bundleYAML string) ([]string, error)
+
+	// EstimateCosts returns the estimated cost of running new machines.
+	EstimateCosts([]apiparams.CostEstimateArg) ([]apiparams.CostEstimateResult, error)
+
 	WatchAll() (*api.AllWatcher, error)
 
 	// PlanURL returns the configured URL prefix for the metering plan API.
@@ -209,13 +219,25 @@ func (a *deployAPIAdapter) Deploy(args application.DeployArgs) error {
 	return errors.Trace(a.applicationClient.Deploy(args))
 }
 
+func (a *deployAPIAdapter) CheckBundlePlacements(bundleYAML string) ([]string, error) {
+	return apibundle.NewClient(a.Connection).CheckPlacements(bundleYAML)
+}
+
+func (a *deployAPIAdapter) EstimateCosts(args []apiparams.CostEstimateArg) ([]apiparams.CostEstimateResult, error) {
+	return machinemanager.NewClient(a.Connection).EstimateCosts(args)
+}
+
 func (a *deployAPIAdapter) Resolve(cfg *config.Config, url *charm.URL) (
 	*charm.URL,
 	params.Channel,
 	[]string,
 	error,
 ) {
-	return resolveCharm(a.charmRepoClient.ResolveWithChannel, url)
+	return resolveCharm(a.ResolveWithChannel, url)
+}
+
+func (a *deployAPIAdapter) ResolveWithChannel(url *charm.URL) (*charm.URL, params.Channel, []string, error) {
+	return resolveWithControllerFallback(a.charmRepoClient.ResolveWithChannel, a.apiClient)(url)
 }
 
 func (a *deployAPIAdapter) Get(url *charm.URL) (charm.Charm, error) {
@@ -310,6 +332,11 @@ type DeployCommand struct {
 	// deployed but just output the changes.
 	DryRun bool
 
+	// Estimate is used to specify that the charm shouldn't actually be
+	// deployed, but the estimated cost of the machines it would start
+	// reported instead.
+	Estimate bool
+
 	ApplicationName string
 	ConfigOptions   common.ConfigFlag
 	ConstraintsStr  string
@@ -522,6 +549,11 @@ for security purposes by allowing only certain configurations and devices. Use
 the '--force' option to bypass this check. Doing so is not recommended as it
 can lead to unexpected behaviour.
 
+Use the '--estimate' option to report the estimated monthly cost of the new
+machines that deploying a charm would start, rather than deploying it. Units
+placed on existing machines or containers on them are not counted. Cost
+estimation is only supported on some clouds.
+
 Further reading: https://docs.jujucharms.com/stable/charms-deploying
 
 Examples:
@@ -558,6 +590,11 @@ Deploy to a machine with at least 8 GiB of memory:
 
     juju deploy postgresql --constraints mem=8G
 
+Report the estimated monthly cost of deploying 3 units with at least 8 GiB of
+memory each:
+
+    juju deploy postgresql -n 3 --constraints mem=8G --estimate
+
 Deploy to a specific availability zone (provider-dependent):
 
     juju deploy mysql --to zone=us-east-1a
@@ -647,7 +684,7 @@ var (
 func charmOnlyFlags() []string {
 	charmOnlyFlags := []string{
 		"bind", "config", "constraints", "force", "n", "num-units",
-		"series", "to", "resource", "attach-storage",
+		"series", "to", "resource", "attach-storage", "estimate",
 	}
 
 	charmOnlyFlags = append(charmOnlyFlags, "trust")
@@ -671,6 +708,7 @@ func (c *DeployCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Set application constraints")
 	f.StringVar(&c.Series, "series", "", "The series on which to deploy")
 	f.BoolVar(&c.DryRun, "dry-run", false, "Just show what the bundle deploy would do")
+	f.BoolVar(&c.Estimate, "estimate", false, "Report the estimated monthly cost of the new machines instead of deploying")
 	f.BoolVar(&c.Force, "force", false, "Allow a charm to be deployed which bypasses checks such as supported series or LXD profile allow list")
 	f.Var(storageFlag{&c.Storage, &c.BundleStorage}, "storage", "Charm storage constraints")
 	f.Var(devicesFlag{&c.Devices, &c.BundleDevices}, "device", "Charm device constraints")
@@ -928,6 +966,9 @@ func (c *DeployCommand) deployCharm(
 	if applicationName == "" {
 		applicationName = charmInfo.Meta.Name
 	}
+	if c.Estimate {
+		return errors.Trace(c.estimateCost(ctx, apiRoot, applicationName, numUnits))
+	}
 
 	// Process the --config args.
 	// We may have a single file arg specified, in which case
@@ -1064,6 +1105,50 @@ func (c *DeployCommand) deployCharm(
 	return errors.Trace(apiRoot.Deploy(args))
 }
 
+// estimateCost reports the estimated cost of the new machines that
+// deploying the application's units would start.
+func (c *DeployCommand) estimateCost(ctx *cmd.Context, apiRoot DeployAPI, applicationName string, numUnits int) error {
+	count := newMachineCount(numUnits, c.Placement)
+	if count == 0 {
+		ctx.Infof("Deploying %s would not start any new machines.", applicationName)
+		return nil
+	}
+	results, err := apiRoot.EstimateCosts([]apiparams.CostEstimateArg{{
+		Constraints: c.Constraints,
+		Count:       count,
+	}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	fmt.Fprintf(ctx.Stdout, "Estimated cost of deploying %s: %s\n", applicationName, common.FormatCostEstimate(results[0]))
+	return nil
+}
+
+// newMachineCount returns the number of new machines that would be
+// started to host numUnits units with the given placement. Units
+// without a placement are each deployed to a new machine.
+func newMachineCount(numUnits int, placement []*instance.Placement) int {
+	count := 0
+	for i := 0; i < numUnits; i++ {
+		if i >= len(placement) {
+			count++
+			continue
+		}
+		p := placement[i]
+		if p.Scope == instance.MachineScope {
+			continue
+		}
+		if _, err := instance.ParseContainerType(p.Scope); err == nil && p.Directive != "" {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
 const parseBindErrorPrefix = "--bind must be in the form '[<default-space>] [<endpoint-name>=<space> ...]'. "
 
 // parseBind parses the --bind option. Valid forms are:
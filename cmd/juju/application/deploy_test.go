@@ -2101,6 +2101,63 @@ func (s *DeployUnitTestSuite) TestDeployAttachStorageNotSupported(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "this juju controller does not support --attach-storage")
 }
 
+func (s *DeployUnitTestSuite) TestDeployEstimate(c *gc.C) {
+	charmsPath := c.MkDir()
+	charmDir := testcharms.Repo.ClonedDir(charmsPath, "dummy")
+
+	fakeAPI := vanillaFakeModelAPI(map[string]interface{}{
+		"name": "name",
+		"uuid": "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		"type": "foo",
+	})
+
+	dummyURL := charm.MustParseURL("local:trusty/dummy-0")
+	withLocalCharmDeployable(fakeAPI, dummyURL, charmDir, false)
+	withCharmDeployable(
+		fakeAPI, dummyURL, "trusty", charmDir.Meta(), charmDir.Metrics(), false, false, 3, nil, nil,
+	)
+	fakeAPI.Call("EstimateCosts", []params.CostEstimateArg{{
+		Constraints: constraints.MustParse("mem=8G"),
+		Count:       2,
+	}}).Returns([]params.CostEstimateResult{{
+		InstanceType: "m5.large",
+		Count:        2,
+		HourlyCost:   0.096,
+		MonthlyCost:  140.16,
+		Currency:     "USD",
+	}}, error(nil))
+
+	cmd := NewDeployCommandForTest(func() (DeployAPI, error) { return fakeAPI, nil }, nil)
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, dummyURL.String(),
+		"-n", "3", "--to", "0", "--constraints", "mem=8G", "--estimate",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals,
+		"Estimated cost of deploying dummy: 2 machines (m5.large at 0.0960 USD/hour each): 140.16 USD/month\n")
+	for _, call := range fakeAPI.Calls() {
+		c.Check(call.FuncName, gc.Not(gc.Equals), "Deploy")
+	}
+}
+
+func (s *DeployUnitTestSuite) TestNewMachineCount(c *gc.C) {
+	zone := &instance.Placement{Scope: "deadbeef-0bad-400d-8000-4b1d0d06f00d", Directive: "zone=us-east-1a"}
+	for i, test := range []struct {
+		numUnits  int
+		placement []*instance.Placement
+		expected  int
+	}{
+		{3, nil, 3},
+		{2, []*instance.Placement{instance.MustParsePlacement("0")}, 1},
+		{2, []*instance.Placement{instance.MustParsePlacement("lxd:0"), instance.MustParsePlacement("lxd")}, 1},
+		{1, []*instance.Placement{zone}, 1},
+		{2, []*instance.Placement{instance.MustParsePlacement("0"), instance.MustParsePlacement("1")}, 0},
+	} {
+		c.Logf("test %d", i)
+		c.Check(newMachineCount(test.numUnits, test.placement), gc.Equals, test.expected)
+	}
+}
+
 // fakeDeployAPI is a mock of the API used by the deploy command. It's
 // a little muddled at the moment, but as the DeployAPI interface is
 // sharpened, this will become so as well.
@@ -2218,6 +2275,16 @@ func (f *fakeDeployAPI) GetBundle(url *charm.URL) (charm.Bundle, error) {
 	return results[0].(charm.Bundle), jujutesting.TypeAssertError(results[1])
 }
 
+func (f *fakeDeployAPI) CheckBundlePlacements(bundleYAML string) ([]string, error) {
+	results := f.MethodCall(f, "CheckBundlePlacements", bundleYAML)
+	return results[0].([]string), jujutesting.TypeAssertError(results[1])
+}
+
+func (f *fakeDeployAPI) EstimateCosts(args []params.CostEstimateArg) ([]params.CostEstimateResult, error) {
+	results := f.MethodCall(f, "EstimateCosts", args)
+	return results[0].([]params.CostEstimateResult), jujutesting.TypeAssertError(results[1])
+}
+
 func (f *fakeDeployAPI) Status(patterns []string) (*params.FullStatus, error) {
 	results := f.MethodCall(f, "Status", patterns)
 	return results[0].(*params.FullStatus), jujutesting.TypeAssertError(results[1])
@@ -2294,6 +2361,7 @@ func vanillaFakeModelAPI(cfgAttrs map[string]interface{}) *fakeDeployAPI {
 	fakeAPI.Call("ModelGet").Returns(cfgAttrs, error(nil))
 	fakeAPI.Call("ModelUUID").Returns("deadbeef-0bad-400d-8000-4b1d0d06f00d", true)
 	fakeAPI.Call("BestFacadeVersion", "Application").Returns(6)
+	fakeAPI.Call("BestFacadeVersion", "Bundle").Returns(0)
 
 	return fakeAPI
 }
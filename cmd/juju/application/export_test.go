@@ -18,6 +18,7 @@ import (
 	apicharms "github.com/juju/juju/api/charms"
 	"github.com/juju/juju/api/modelconfig"
 	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/resource/resourceadapters"
@@ -182,9 +183,12 @@ func NewRemoveUnitCommandForTest(api RemoveApplicationAPI, store jujuclient.Clie
 type removeAPIFunc func() (RemoveApplicationAPI, int, error)
 
 // NewRemoveApplicationCommandForTest returns a RemoveApplicationCommand.
-func NewRemoveApplicationCommandForTest(f removeAPIFunc, store jujuclient.ClientStore) modelcmd.ModelCommand {
+func NewRemoveApplicationCommandForTest(f removeAPIFunc, configAPI block.ModelConfigAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	c := &removeApplicationCommand{}
 	c.newAPIFunc = f
+	c.newModelConfigAPIFunc = func() (block.ModelConfigAPI, error) {
+		return configAPI, nil
+	}
 	c.SetClientStore(store)
 	return modelcmd.Wrap(c)
 }
@@ -227,6 +231,34 @@ func NewSetSeriesCommandForTest(
 	return modelcmd.Wrap(cmd)
 }
 
+// NewRefreshPolicyCommandForTest returns a RefreshPolicyCommand with the specified api.
+func NewRefreshPolicyCommandForTest(api refreshPolicyAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &refreshPolicyCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewAutoscaleCommandForTest returns an AutoscaleCommand with the specified api.
+func NewAutoscaleCommandForTest(api autoscaleAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &autoscaleCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewPauseCommandForTest returns a PauseCommand with the specified api.
+func NewPauseCommandForTest(api pauseAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &pauseCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewResumeCommandForTest returns a ResumeCommand with the specified api.
+func NewResumeCommandForTest(api pauseAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &pauseCommand{api: api, resume: true}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewSuspendRelationCommandForTest returns a SuspendRelationCommand with the api provided as specified.
 func NewSuspendRelationCommandForTest(api SetRelationSuspendedAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &suspendRelationCommand{newAPIFunc: func() (SetRelationSuspendedAPI, error) {
@@ -245,6 +277,24 @@ func NewResumeRelationCommandForTest(api SetRelationSuspendedAPI, store jujuclie
 	return modelcmd.Wrap(cmd)
 }
 
+// NewSetRelationDrainTimeoutCommandForTest returns a SetRelationDrainTimeoutCommand with the api provided as specified.
+func NewSetRelationDrainTimeoutCommandForTest(api SetRelationDrainTimeoutAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &setRelationDrainTimeoutCommand{newAPIFunc: func() (SetRelationDrainTimeoutAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowRelationCommandForTest returns a ShowRelationCommand with the api provided as specified.
+func NewShowRelationCommandForTest(api ShowRelationAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &showRelationCommand{newAPIFunc: func() (ShowRelationAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewRemoveSaasCommandForTest returns a RemoveSaasCommand with the api provided as specified.
 func NewRemoveSaasCommandForTest(api RemoveSaasAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &removeSaasCommand{newAPIFunc: func() (RemoveSaasAPI, error) {
@@ -288,3 +338,10 @@ func (c charmstoreClientToTestcharmsClientShim) WithChannel(channel params.Chann
 	client := c.Client.WithChannel(channel)
 	return charmstoreClientToTestcharmsClientShim{client}
 }
+
+// NewBlueGreenCommandForTest returns a BlueGreenCommand with the specified api.
+func NewBlueGreenCommandForTest(api blueGreenAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &blueGreenCommand{api: api}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
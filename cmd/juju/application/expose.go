@@ -6,11 +6,14 @@ package application
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/modelconfig"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs"
 )
 
 var usageExposeSummary = `
@@ -20,8 +23,16 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+With --load-balancer, Juju also provisions a load balancer from the cloud
+in front of the application's units, forwarding the ports they have
+opened. This is an ELB on Amazon EC2, an Octavia load balancer on
+OpenStack and a forwarding rule on Google Compute Engine. The load
+balancer's addresses are shown by "juju status --format yaml", and the
+load balancer is removed when the application is unexposed.
+
 Examples:
     juju expose wordpress
+    juju expose wordpress --load-balancer
 
 See also: 
     unexpose`[1:]
@@ -35,6 +46,7 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	LoadBalancer    bool
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -46,6 +58,11 @@ func (c *exposeCommand) Info() *cmd.Info {
 	})
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.LoadBalancer, "load-balancer", false, "Provision a cloud load balancer in front of the application's units")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
@@ -56,7 +73,9 @@ func (c *exposeCommand) Init(args []string) error {
 
 type applicationExposeAPI interface {
 	Close() error
+	BestAPIVersion() int
 	Expose(applicationName string) error
+	ExposeWithLoadBalancer(applicationName string) error
 	Unexpose(applicationName string) error
 }
 
@@ -68,6 +87,45 @@ func (c *exposeCommand) getAPI() (applicationExposeAPI, error) {
 	return application.NewClient(root), nil
 }
 
+type modelGetAPI interface {
+	Close() error
+	ModelGet() (map[string]interface{}, error)
+}
+
+func (c *exposeCommand) getModelConfigAPI() (modelGetAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelconfig.NewClient(root), nil
+}
+
+// checkLoadBalancerSupport returns an error if the model's provider
+// reports that it cannot provision load balancers, so that the user
+// hears about it now rather than from the provisioner later.
+func (c *exposeCommand) checkLoadBalancerSupport() error {
+	client, err := c.getModelConfigAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	attrs, err := client.ModelGet()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	providerType, _ := attrs["type"].(string)
+	if providerType == "" {
+		return nil
+	}
+	err = environs.CheckProviderCapability(providerType, environs.CapabilityLoadBalancers)
+	if errors.IsNotFound(err) {
+		// The client does not know the provider; leave it to the
+		// controller.
+		return nil
+	}
+	return errors.Annotatef(err, "cannot expose %q with a load balancer", c.ApplicationName)
+}
+
 // Run changes the juju-managed firewall to expose any
 // ports that were also explicitly marked by units as open.
 func (c *exposeCommand) Run(_ *cmd.Context) error {
@@ -76,5 +134,14 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	if !c.LoadBalancer {
+		return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	}
+	if client.BestAPIVersion() < 17 {
+		return errors.New("exposing an application through a load balancer is not supported by this version of Juju")
+	}
+	if err := c.checkLoadBalancerSupport(); err != nil {
+		return err
+	}
+	return block.ProcessBlockedError(client.ExposeWithLoadBalancer(c.ApplicationName), block.BlockChange)
 }
@@ -55,6 +55,17 @@ func (s *ExposeSuite) TestExpose(c *gc.C) {
 	})
 }
 
+func (s *ExposeSuite) TestExposeWithLoadBalancer(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "some-application-name"})
+
+	err := runExpose(c, "some-application-name", "--load-balancer")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name")
+	app, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsLoadBalanced(), jc.IsTrue)
+}
+
 func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "some-application-name"})
 
@@ -0,0 +1,147 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	pauseSummary = `Stops hook execution for applications or units during maintenance.`
+	pauseDetails = `
+Pausing an application or unit stops its unit agents from running hooks,
+so that operators can carry out manual maintenance without the agents
+reacting to it. Pausing an application pauses all of its units.
+
+Actions and "juju run" commands are still run on paused units, and
+removing a paused unit or application is not held back.
+
+Hooks that would have run while paused are run once the application or
+unit is resumed with "juju resume".
+
+Examples:
+    juju pause mysql
+    juju pause mysql/0 mysql/1
+
+See also:
+    resume
+    status
+`
+
+	resumeSummary = `Resumes hook execution for paused applications or units.`
+	resumeDetails = `
+Resuming an application or unit paused with "juju pause" allows its unit
+agents to run hooks again. A unit is only resumed when neither the unit
+itself nor its application is paused.
+
+Examples:
+    juju resume mysql
+    juju resume mysql/0
+
+See also:
+    pause
+    status
+`
+)
+
+// NewPauseCommand returns a command which pauses hook execution for
+// applications or units.
+func NewPauseCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&pauseCommand{})
+}
+
+// NewResumeCommand returns a command which resumes hook execution for
+// applications or units.
+func NewResumeCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&pauseCommand{resume: true})
+}
+
+// pauseAPI defines a subset of the application facade, as required by
+// the pause and resume commands.
+type pauseAPI interface {
+	Close() error
+	Pause(...string) error
+	Resume(...string) error
+}
+
+// pauseCommand pauses or resumes hook execution for applications or
+// units.
+type pauseCommand struct {
+	modelcmd.ModelCommandBase
+
+	api      pauseAPI
+	resume   bool
+	entities []string
+}
+
+// Info is part of the cmd.Command interface.
+func (c *pauseCommand) Info() *cmd.Info {
+	if c.resume {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "resume",
+			Args:    "<application or unit name> [...]",
+			Purpose: resumeSummary,
+			Doc:     resumeDetails,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "pause",
+		Args:    "<application or unit name> [...]",
+		Purpose: pauseSummary,
+		Doc:     pauseDetails,
+	})
+}
+
+// Init is part of the cmd.Command interface.
+func (c *pauseCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application or unit specified")
+	}
+	for _, arg := range args {
+		if !names.IsValidApplication(arg) && !names.IsValidUnit(arg) {
+			return errors.Errorf("invalid application or unit name %q", arg)
+		}
+	}
+	c.entities = args
+	return nil
+}
+
+func (c *pauseCommand) getAPI() (pauseAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := application.NewClient(root)
+	if client.BestAPIVersion() < 13 {
+		client.Close()
+		return nil, errors.New("pausing applications and units is not supported by this version of Juju")
+	}
+	return client, nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *pauseCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if c.resume {
+		err = client.Resume(c.entities...)
+	} else {
+		err = client.Pause(c.entities...)
+	}
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,104 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	jtesting "github.com/juju/juju/testing"
+)
+
+type pauseSuite struct {
+	testing.IsolationSuite
+	api *mockPauseAPI
+}
+
+var _ = gc.Suite(&pauseSuite{})
+
+func (s *pauseSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &mockPauseAPI{}
+}
+
+func (s *pauseSuite) runPause(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewPauseCommandForTest(s.api, store), args...)
+}
+
+func (s *pauseSuite) runResume(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewResumeCommandForTest(s.api, store), args...)
+}
+
+func (s *pauseSuite) TestPause(c *gc.C) {
+	_, err := s.runPause(c, "mysql", "wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Pause", "Close")
+	s.api.CheckCall(c, 0, "Pause", []string{"mysql", "wordpress/0"})
+}
+
+func (s *pauseSuite) TestResume(c *gc.C) {
+	_, err := s.runResume(c, "mysql/1")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Resume", "Close")
+	s.api.CheckCall(c, 0, "Resume", []string{"mysql/1"})
+}
+
+func (s *pauseSuite) TestPauseError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runPause(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *pauseSuite) TestPauseBlocked(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestPauseBlocked"))
+	_, err := s.runPause(c, "mysql")
+	jtesting.AssertOperationWasBlocked(c, err, ".*TestPauseBlocked.*")
+}
+
+func (s *pauseSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application or unit specified",
+	}, {
+		args: []string{"mysql", "mysql/x"},
+		err:  `invalid application or unit name "mysql/x"`,
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := s.runPause(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+		_, err = s.runResume(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type mockPauseAPI struct {
+	testing.Stub
+}
+
+func (a *mockPauseAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockPauseAPI) Pause(entities ...string) error {
+	a.MethodCall(a, "Pause", entities)
+	return a.NextErr()
+}
+
+func (a *mockPauseAPI) Resume(entities ...string) error {
+	a.MethodCall(a, "Resume", entities)
+	return a.NextErr()
+}
@@ -0,0 +1,259 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	refreshPolicySummary = `Shows or sets how an application follows new revisions of its charm.`
+	refreshPolicyDetails = `
+The controller periodically checks the charm store for new revisions of
+each application's charm. By default, new revisions are only reported as
+available in the output of "juju status"; with --auto, the controller
+refreshes the application to them instead.
+
+--channel selects the charm store channel checked for new revisions. By
+default the channel the application was deployed from is checked; pass
+an empty channel to go back to it.
+
+--window restricts automatic refreshes to a time of day, given as
+HH:MM-HH:MM in UTC. A window that ends before it starts spans midnight.
+Use "--window any" to allow refreshes at any time.
+
+--hold suspends automatic refreshes, for instance while investigating a
+problem, without forgetting the rest of the policy; --release resumes
+them.
+
+With no options, the application's current refresh policy is shown.
+
+Examples:
+    juju refresh-policy mysql
+    juju refresh-policy mysql --auto --window 02:00-04:00
+    juju refresh-policy mysql --channel candidate
+    juju refresh-policy mysql --hold
+    juju refresh-policy mysql --notify --window any
+
+See also:
+    upgrade-charm
+    status
+`
+)
+
+// NewRefreshPolicyCommand returns a command which shows or sets the
+// refresh policy of an application.
+func NewRefreshPolicyCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&refreshPolicyCommand{})
+}
+
+// refreshPolicyAPI defines a subset of the application facade, as
+// required by the refresh-policy command.
+type refreshPolicyAPI interface {
+	Close() error
+	RefreshPolicy(string) (params.RefreshPolicy, error)
+	SetRefreshPolicy(string, params.RefreshPolicy) error
+}
+
+// refreshPolicyCommand shows or sets the refresh policy of an
+// application.
+type refreshPolicyCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	fs  *gnuflag.FlagSet
+
+	api refreshPolicyAPI
+
+	applicationName string
+	channel         string
+	auto            bool
+	notify          bool
+	window          string
+	hold            bool
+	release         bool
+
+	channelSet  bool
+	windowStart time.Duration
+	windowEnd   time.Duration
+}
+
+// Info is part of the cmd.Command interface.
+func (c *refreshPolicyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "refresh-policy",
+		Args:    "<application name>",
+		Purpose: refreshPolicySummary,
+		Doc:     refreshPolicyDetails,
+	})
+}
+
+// SetFlags is part of the cmd.Command interface.
+func (c *refreshPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+	f.StringVar(&c.channel, "channel", "", "Charm store channel to check for new revisions")
+	f.BoolVar(&c.auto, "auto", false, "Refresh the application to new revisions automatically")
+	f.BoolVar(&c.notify, "notify", false, "Only report new revisions as available")
+	f.StringVar(&c.window, "window", "", "Time of day (HH:MM-HH:MM UTC) in which automatic refreshes may take place, or \"any\"")
+	f.BoolVar(&c.hold, "hold", false, "Suspend automatic refreshes")
+	f.BoolVar(&c.release, "release", false, "Resume automatic refreshes")
+	c.fs = f
+}
+
+// Init is part of the cmd.Command interface.
+func (c *refreshPolicyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application name specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.Errorf("invalid application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	if c.auto && c.notify {
+		return errors.New("cannot specify both --auto and --notify")
+	}
+	if c.hold && c.release {
+		return errors.New("cannot specify both --hold and --release")
+	}
+	c.fs.Visit(func(flag *gnuflag.Flag) {
+		if flag.Name == "channel" {
+			c.channelSet = true
+		}
+	})
+	if c.window != "" && c.window != "any" {
+		var err error
+		if c.windowStart, c.windowEnd, err = parseRefreshWindow(c.window); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// parseRefreshWindow parses a refresh window of the form HH:MM-HH:MM.
+func parseRefreshWindow(window string) (start, end time.Duration, err error) {
+	parts := strings.Split(window, "-")
+	if len(parts) != 2 {
+		return 0, 0, errors.NotValidf("refresh window %q (expected HH:MM-HH:MM)", window)
+	}
+	if start, err = parseTimeOfDay(parts[0]); err != nil {
+		return 0, 0, errors.Annotatef(err, "refresh window %q", window)
+	}
+	if end, err = parseTimeOfDay(parts[1]); err != nil {
+		return 0, 0, errors.Annotatef(err, "refresh window %q", window)
+	}
+	if start == end {
+		return 0, 0, errors.NotValidf("empty refresh window %q", window)
+	}
+	return start, end, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errors.NotValidf("time of day %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// changesPolicy reports whether any of the options that change the
+// policy were specified.
+func (c *refreshPolicyCommand) changesPolicy() bool {
+	return c.channelSet || c.auto || c.notify || c.window != "" || c.hold || c.release
+}
+
+func (c *refreshPolicyCommand) getAPI() (refreshPolicyAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := application.NewClient(root)
+	if client.BestAPIVersion() < 12 {
+		client.Close()
+		return nil, errors.New("refresh policies are not supported by this version of Juju")
+	}
+	return client, nil
+}
+
+// Run is part of the cmd.Command interface.
+func (c *refreshPolicyCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	policy, err := client.RefreshPolicy(c.applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !c.changesPolicy() {
+		return c.out.Write(ctx, formatRefreshPolicy(policy))
+	}
+
+	if c.channelSet {
+		policy.Channel = c.channel
+	}
+	if c.auto {
+		policy.Auto = true
+	} else if c.notify {
+		policy.Auto = false
+	}
+	if c.window != "" {
+		policy.WindowStart = c.windowStart
+		policy.WindowEnd = c.windowEnd
+	}
+	if c.hold {
+		policy.Hold = true
+	} else if c.release {
+		policy.Hold = false
+	}
+	return block.ProcessBlockedError(client.SetRefreshPolicy(c.applicationName, policy), block.BlockChange)
+}
+
+// refreshPolicyOutput is the output format of a refresh policy.
+type refreshPolicyOutput struct {
+	Channel string `yaml:"channel,omitempty" json:"channel,omitempty"`
+	Mode    string `yaml:"mode" json:"mode"`
+	Window  string `yaml:"window" json:"window"`
+	Hold    bool   `yaml:"hold" json:"hold"`
+}
+
+func formatRefreshPolicy(policy params.RefreshPolicy) refreshPolicyOutput {
+	out := refreshPolicyOutput{
+		Channel: policy.Channel,
+		Mode:    "notify",
+		Window:  "any",
+		Hold:    policy.Hold,
+	}
+	if policy.Auto {
+		out.Mode = "auto"
+	}
+	if policy.WindowStart != policy.WindowEnd {
+		out.Window = formatTimeOfDay(policy.WindowStart) + "-" + formatTimeOfDay(policy.WindowEnd)
+	}
+	return out
+}
@@ -0,0 +1,141 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type refreshPolicySuite struct {
+	testing.IsolationSuite
+	api *mockRefreshPolicyAPI
+}
+
+var _ = gc.Suite(&refreshPolicySuite{})
+
+func (s *refreshPolicySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.api = &mockRefreshPolicyAPI{
+		policy: params.RefreshPolicy{
+			Channel:     "candidate",
+			Auto:        true,
+			WindowStart: 22 * time.Hour,
+			WindowEnd:   2*time.Hour + 30*time.Minute,
+		},
+	}
+}
+
+func (s *refreshPolicySuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewRefreshPolicyCommandForTest(s.api, store), args...)
+}
+
+func (s *refreshPolicySuite) TestShow(c *gc.C) {
+	ctx, err := s.run(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+channel: candidate
+mode: auto
+window: 22:00-02:30
+hold: false
+`[1:])
+	s.api.CheckCallNames(c, "RefreshPolicy", "Close")
+}
+
+func (s *refreshPolicySuite) TestShowDefault(c *gc.C) {
+	s.api.policy = params.RefreshPolicy{}
+	ctx, err := s.run(c, "mysql", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"mode":"notify","window":"any","hold":false}`+"\n")
+}
+
+func (s *refreshPolicySuite) TestSet(c *gc.C) {
+	_, err := s.run(c, "mysql", "--notify", "--window", "03:00-04:00", "--hold")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "RefreshPolicy", "SetRefreshPolicy", "Close")
+	s.api.CheckCall(c, 1, "SetRefreshPolicy", "mysql", params.RefreshPolicy{
+		Channel:     "candidate",
+		WindowStart: 3 * time.Hour,
+		WindowEnd:   4 * time.Hour,
+		Hold:        true,
+	})
+}
+
+func (s *refreshPolicySuite) TestSetResetChannelAndWindow(c *gc.C) {
+	_, err := s.run(c, "mysql", "--channel", "", "--window", "any")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 1, "SetRefreshPolicy", "mysql", params.RefreshPolicy{Auto: true})
+}
+
+func (s *refreshPolicySuite) TestSetError(c *gc.C) {
+	s.api.SetErrors(nil, errors.New("boom"))
+	_, err := s.run(c, "mysql", "--release")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *refreshPolicySuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application name specified",
+	}, {
+		args: []string{"mysql/0"},
+		err:  `invalid application name "mysql/0"`,
+	}, {
+		args: []string{"mysql", "--auto", "--notify"},
+		err:  "cannot specify both --auto and --notify",
+	}, {
+		args: []string{"mysql", "--hold", "--release"},
+		err:  "cannot specify both --hold and --release",
+	}, {
+		args: []string{"mysql", "--window", "02:00"},
+		err:  `refresh window "02:00" \(expected HH:MM-HH:MM\) not valid`,
+	}, {
+		args: []string{"mysql", "--window", "02:00-25:00"},
+		err:  `refresh window "02:00-25:00": time of day "25:00" not valid`,
+	}, {
+		args: []string{"mysql", "--window", "02:00-02:00"},
+		err:  `empty refresh window "02:00-02:00" not valid`,
+	}, {
+		args: []string{"mysql", "wordpress"},
+		err:  `unrecognized args: \["wordpress"\]`,
+	}} {
+		c.Logf("test %d: %q", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type mockRefreshPolicyAPI struct {
+	testing.Stub
+	policy params.RefreshPolicy
+}
+
+func (a *mockRefreshPolicyAPI) Close() error {
+	a.MethodCall(a, "Close")
+	return a.NextErr()
+}
+
+func (a *mockRefreshPolicyAPI) RefreshPolicy(application string) (params.RefreshPolicy, error) {
+	a.MethodCall(a, "RefreshPolicy", application)
+	return a.policy, a.NextErr()
+}
+
+func (a *mockRefreshPolicyAPI) SetRefreshPolicy(application string, policy params.RefreshPolicy) error {
+	a.MethodCall(a, "SetRefreshPolicy", application, policy)
+	return a.NextErr()
+}
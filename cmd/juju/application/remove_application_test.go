@@ -22,7 +22,8 @@ var _ = gc.Suite(&RemoveApplicationCmdSuite{})
 
 type RemoveApplicationCmdSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
-	api *testApplicationRemoveUnitAPI
+	api       *testApplicationRemoveUnitAPI
+	configAPI *testModelConfigAPI
 
 	apiFunc func() (application.RemoveApplicationAPI, int, error)
 	store   *jujuclient.MemStore
@@ -33,6 +34,7 @@ func (s *RemoveApplicationCmdSuite) SetUpTest(c *gc.C) {
 	s.api = &testApplicationRemoveUnitAPI{
 		Stub: &jujutesting.Stub{},
 	}
+	s.configAPI = &testModelConfigAPI{Stub: s.api.Stub}
 	s.store = jujuclienttesting.MinimalStore()
 	s.apiFunc = func() (application.RemoveApplicationAPI, int, error) {
 		return s.api, 5, nil
@@ -48,7 +50,7 @@ func (s *RemoveApplicationCmdSuite) TestForceFlagSet(c *gc.C) {
 }
 
 func (s *RemoveApplicationCmdSuite) runRemoveApplication(c *gc.C, args ...string) (*cmd.Context, error) {
-	return cmdtesting.RunCommand(c, application.NewRemoveApplicationCommandForTest(s.apiFunc, s.store), args...)
+	return cmdtesting.RunCommand(c, application.NewRemoveApplicationCommandForTest(s.apiFunc, s.configAPI, s.store), args...)
 }
 
 func (s *RemoveApplicationCmdSuite) assertAPIForceFlag(c *gc.C, args []string, expectedValue bool) {
@@ -65,10 +67,117 @@ func (s *RemoveApplicationCmdSuite) assertAPIForceFlag(c *gc.C, args []string, e
 	s.api.CheckCallNames(c, "DestroyApplications", "Close")
 }
 
+func (s *RemoveApplicationCmdSuite) TestDryRun(c *gc.C) {
+	s.apiFunc = func() (application.RemoveApplicationAPI, int, error) {
+		return s.api, 10, nil
+	}
+	s.api.previewDestroyApplications = func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+		c.Assert(args, jc.DeepEquals, apiapplication.DestroyApplicationsParams{
+			Applications:   []string{"real-app"},
+			DestroyStorage: true,
+		})
+		return []params.DestroyApplicationResult{{
+			Info: &params.DestroyApplicationInfo{
+				DestroyedUnits:    []params.Entity{{Tag: "unit-real-app-0"}},
+				DestroyedMachines: []params.Entity{{Tag: "machine-2"}},
+				DestroyedStorage:  []params.Entity{{Tag: "storage-data-0"}},
+				DestroyedOffers:   []params.DestroyedOffer{{OfferName: "real-offer", Connections: 1}},
+			},
+		}}, nil
+	}
+	ctx, err := s.runRemoveApplication(c, "real-app", "--dry-run", "--destroy-storage")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+removing application real-app would:
+- remove unit real-app/0
+- remove machine 2
+- destroy storage data/0
+- remove offer real-offer (1 connection)
+`[1:])
+	s.api.CheckCallNames(c, "PreviewDestroyApplications", "Close")
+}
+
+func (s *RemoveApplicationCmdSuite) TestDryRunNotSupported(c *gc.C) {
+	_, err := s.runRemoveApplication(c, "real-app", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "--dry-run is not supported by this controller")
+	s.api.CheckCallNames(c, "Close")
+}
+
+func (s *RemoveApplicationCmdSuite) TestStorageDisposition(c *gc.C) {
+	s.apiFunc = func() (application.RemoveApplicationAPI, int, error) {
+		return s.api, 11, nil
+	}
+	s.api.destroyApplications = func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+		c.Assert(args, jc.DeepEquals, apiapplication.DestroyApplicationsParams{
+			Applications:       []string{"real-app"},
+			ReleaseStorage:     true,
+			StorageDisposition: map[string]string{"data/0": "destroy"},
+		})
+		return []params.DestroyApplicationResult{{
+			Info: &params.DestroyApplicationInfo{
+				DestroyedStorage: []params.Entity{{Tag: "storage-data-0"}},
+				ReleasedStorage:  []params.Entity{{Tag: "storage-logs-0"}},
+			},
+		}}, nil
+	}
+	ctx, err := s.runRemoveApplication(c, "real-app", "--release-storage", "--storage-disposition", "data/0=destroy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removing application real-app
+- will remove storage data/0
+- will release storage logs/0
+`[1:])
+	s.api.CheckCallNames(c, "DestroyApplications", "Close")
+}
+
+func (s *RemoveApplicationCmdSuite) TestStorageDispositionNotSupported(c *gc.C) {
+	_, err := s.runRemoveApplication(c, "real-app", "--release-storage")
+	c.Assert(err, gc.ErrorMatches, "--release-storage and --storage-disposition are not supported by this controller")
+	s.api.CheckCallNames(c, "Close")
+}
+
+func (s *RemoveApplicationCmdSuite) TestStorageDispositionInvalid(c *gc.C) {
+	_, err := s.runRemoveApplication(c, "real-app", "--storage-disposition", "data/0=shred")
+	c.Assert(err, gc.ErrorMatches, `invalid disposition "shred" for storage data/0, expected destroy, detach or release`)
+	_, err = s.runRemoveApplication(c, "real-app", "--storage-disposition", "data=destroy")
+	c.Assert(err, gc.ErrorMatches, `invalid storage ID "data"`)
+	_, err = s.runRemoveApplication(c, "real-app", "--destroy-storage", "--release-storage")
+	c.Assert(err, gc.ErrorMatches, "--destroy-storage and --release-storage cannot both be specified")
+}
+
+func (s *RemoveApplicationCmdSuite) TestUnprotect(c *gc.C) {
+	s.api.destroyApplications = func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+		return []params.DestroyApplicationResult{
+			{Info: &params.DestroyApplicationInfo{}},
+		}, nil
+	}
+	_, err := s.runRemoveApplication(c, "real-app", "--unprotect")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "ModelSet", "Close", "DestroyApplications", "Close")
+	s.api.CheckCall(c, 0, "ModelSet", map[string]interface{}{"protected": false})
+}
+
+func (s *RemoveApplicationCmdSuite) TestUnprotectDryRun(c *gc.C) {
+	_, err := s.runRemoveApplication(c, "real-app", "--unprotect", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "--dry-run and --unprotect cannot both be specified")
+}
+
+func (s *RemoveApplicationCmdSuite) TestProtectedModel(c *gc.C) {
+	s.api.destroyApplications = func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+		return nil, &params.Error{
+			Code:    params.CodeModelProtected,
+			Message: `model "test" is protected from destruction and from removal of its applications and machines`,
+		}
+	}
+	_, err := s.runRemoveApplication(c, "real-app")
+	c.Assert(err, gc.ErrorMatches, `(?s)model "test" is protected.*run the command again with --unprotect.*`)
+}
+
 type testApplicationRemoveUnitAPI struct {
 	*jujutesting.Stub
 
-	destroyApplications func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
+	destroyApplications        func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
+	previewDestroyApplications func(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
 
 	destroyUnits func(args apiapplication.DestroyUnitsParams) ([]params.DestroyUnitResult, error)
 }
@@ -78,6 +187,11 @@ func (a *testApplicationRemoveUnitAPI) DestroyApplications(args apiapplication.D
 	return a.destroyApplications(args)
 }
 
+func (a *testApplicationRemoveUnitAPI) PreviewDestroyApplications(args apiapplication.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error) {
+	a.AddCall("PreviewDestroyApplications", args)
+	return a.previewDestroyApplications(args)
+}
+
 func (a *testApplicationRemoveUnitAPI) DestroyUnits(args apiapplication.DestroyUnitsParams) ([]params.DestroyUnitResult, error) {
 	a.AddCall("DestroyUnits", args)
 	return a.destroyUnits(args)
@@ -107,3 +221,17 @@ func (a *testApplicationRemoveUnitAPI) DestroyDeprecated(appName string) error {
 func (a *testApplicationRemoveUnitAPI) DestroyUnitsDeprecated(unitNames ...string) error {
 	panic("DestroyUnitsDeprecated not implemented here")
 }
+
+type testModelConfigAPI struct {
+	*jujutesting.Stub
+}
+
+func (a *testModelConfigAPI) ModelSet(attrs map[string]interface{}) error {
+	a.AddCall("ModelSet", attrs)
+	return a.NextErr()
+}
+
+func (a *testModelConfigAPI) Close() error {
+	a.AddCall("Close")
+	return a.NextErr()
+}
@@ -4,6 +4,9 @@
 package application
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/juju/cmd"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -11,6 +14,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/modelconfig"
 	"github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
@@ -24,6 +28,7 @@ func NewRemoveApplicationCommand() cmd.Command {
 	c.newAPIFunc = func() (RemoveApplicationAPI, int, error) {
 		return c.getAPI()
 	}
+	c.newModelConfigAPIFunc = c.getModelConfigAPI
 	return modelcmd.Wrap(c)
 }
 
@@ -31,11 +36,16 @@ func NewRemoveApplicationCommand() cmd.Command {
 type removeApplicationCommand struct {
 	modelcmd.ModelCommandBase
 
-	newAPIFunc func() (RemoveApplicationAPI, int, error)
+	newAPIFunc            func() (RemoveApplicationAPI, int, error)
+	newModelConfigAPIFunc func() (block.ModelConfigAPI, error)
 
-	ApplicationNames []string
-	DestroyStorage   bool
-	Force            bool
+	ApplicationNames   []string
+	DestroyStorage     bool
+	ReleaseStorage     bool
+	StorageDisposition map[string]string
+	Force              bool
+	DryRun             bool
+	Unprotect          bool
 }
 
 var helpSummaryRmApp = `
@@ -58,9 +68,27 @@ all operational errors. In these rare cases, use --force option but note
 that --force will also remove all units of the application, its subordinates
 and, potentially, machines without given them the opportunity to shutdown cleanly.
 
+Storage attached to the application's units is detached and left in the
+model, or destroyed if it cannot be detached. Use --destroy-storage to
+destroy all of the storage, or --release-storage to remove it from the
+model without destroying it. What happens to individual storage instances
+can be chosen with --storage-disposition, which may be repeated and takes
+a storage ID and one of "destroy", "detach" or "release".
+
+Applications cannot be removed from a protected model (see the
+"protected" model configuration setting). Use --unprotect to remove the
+model's protection before removing the applications; this requires admin
+access to the model.
+
+Use --dry-run to see which units, machines, storage and offers would be
+removed, without removing anything.
+
 Examples:
     juju remove-application hadoop
     juju remove-application --force hadoop
+    juju remove-application --dry-run --destroy-storage hadoop
+    juju remove-application --release-storage --storage-disposition data/0=destroy hadoop
+    juju remove-application --unprotect hadoop
     juju remove-application -m test-model mariadb`[1:]
 
 func (c *removeApplicationCommand) Info() *cmd.Info {
@@ -75,7 +103,11 @@ func (c *removeApplicationCommand) Info() *cmd.Info {
 func (c *removeApplicationCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.DestroyStorage, "destroy-storage", false, "Destroy storage attached to application units")
+	f.BoolVar(&c.ReleaseStorage, "release-storage", false, "Release storage attached to application units from the model")
+	f.Var(stringMap{&c.StorageDisposition}, "storage-disposition", "What to do with a storage instance attached to application units, as <storage-id>=destroy|detach|release")
 	f.BoolVar(&c.Force, "force", false, "Completely remove an application and all its dependencies")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show what would be removed without removing anything")
+	f.BoolVar(&c.Unprotect, "unprotect", false, "Remove the model's protection before removing the applications")
 }
 
 func (c *removeApplicationCommand) Init(args []string) error {
@@ -87,6 +119,22 @@ func (c *removeApplicationCommand) Init(args []string) error {
 			return errors.Errorf("invalid application name %q", arg)
 		}
 	}
+	if c.DestroyStorage && c.ReleaseStorage {
+		return errors.New("--destroy-storage and --release-storage cannot both be specified")
+	}
+	if c.DryRun && c.Unprotect {
+		return errors.New("--dry-run and --unprotect cannot both be specified")
+	}
+	for id, what := range c.StorageDisposition {
+		if !names.IsValidStorage(id) {
+			return errors.Errorf("invalid storage ID %q", id)
+		}
+		switch what {
+		case "destroy", "detach", "release":
+		default:
+			return errors.Errorf("invalid disposition %q for storage %s, expected destroy, detach or release", what, id)
+		}
+	}
 	c.ApplicationNames = args
 	return nil
 }
@@ -95,6 +143,7 @@ type RemoveApplicationAPI interface {
 	Close() error
 	ScaleApplication(application.ScaleApplicationParams) (params.ScaleApplicationResult, error)
 	DestroyApplications(application.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
+	PreviewDestroyApplications(application.DestroyApplicationsParams) ([]params.DestroyApplicationResult, error)
 	DestroyDeprecated(appName string) error
 	DestroyUnits(application.DestroyUnitsParams) ([]params.DestroyUnitResult, error)
 	DestroyUnitsDeprecated(unitNames ...string) error
@@ -116,6 +165,14 @@ func (c *removeApplicationCommand) getAPI() (RemoveApplicationAPI, int, error) {
 	return application.NewClient(root), version, nil
 }
 
+func (c *removeApplicationCommand) getModelConfigAPI() (block.ModelConfigAPI, error) {
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelconfig.NewClient(root), nil
+}
+
 func (c *removeApplicationCommand) getStorageAPI() (storageAPI, error) {
 	root, err := c.NewAPIRoot()
 	if err != nil {
@@ -171,9 +228,93 @@ func (c *removeApplicationCommand) Run(ctx *cmd.Context) error {
 	if c.DestroyStorage && apiVersion < 5 {
 		return errors.New("--destroy-storage is not supported by this controller")
 	}
+	if (c.ReleaseStorage || len(c.StorageDisposition) > 0) && apiVersion < 11 {
+		return errors.New("--release-storage and --storage-disposition are not supported by this controller")
+	}
+	if c.DryRun {
+		if apiVersion < 10 {
+			return errors.New("--dry-run is not supported by this controller")
+		}
+		return c.previewRemoveApplications(ctx, client)
+	}
+	if c.Unprotect {
+		if err := c.unprotectModel(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return c.removeApplications(ctx, client)
 }
 
+func (c *removeApplicationCommand) unprotectModel() error {
+	client, err := c.newModelConfigAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	return block.UnprotectModel(client)
+}
+
+func (c *removeApplicationCommand) previewRemoveApplications(
+	ctx *cmd.Context,
+	client RemoveApplicationAPI,
+) error {
+	results, err := client.PreviewDestroyApplications(application.DestroyApplicationsParams{
+		Applications:       c.ApplicationNames,
+		DestroyStorage:     c.DestroyStorage,
+		ReleaseStorage:     c.ReleaseStorage,
+		StorageDisposition: c.StorageDisposition,
+		Force:              c.Force,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	anyFailed := false
+	for i, name := range c.ApplicationNames {
+		result := results[i]
+		if result.Error != nil {
+			ctx.Infof("cannot remove application %s: %s", name, result.Error)
+			anyFailed = true
+			continue
+		}
+		printRemoveApplicationImpact(ctx.Stdout, name, result.Info)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// printRemoveApplicationImpact writes a description of what removing
+// the named application would remove.
+func printRemoveApplicationImpact(w io.Writer, name string, info *params.DestroyApplicationInfo) {
+	fmt.Fprintf(w, "removing application %s would:\n", name)
+	if info == nil {
+		return
+	}
+	printEntities := func(action string, entities []params.Entity) {
+		for _, entity := range entities {
+			tag, err := names.ParseTag(entity.Tag)
+			if err != nil {
+				logger.Warningf("%s", err)
+				continue
+			}
+			fmt.Fprintf(w, "- %s %s\n", action, names.ReadableString(tag))
+		}
+	}
+	printEntities("remove", info.DestroyedUnits)
+	printEntities("remove", info.DestroyedMachines)
+	printEntities("destroy", info.DestroyedStorage)
+	printEntities("release", info.ReleasedStorage)
+	printEntities("detach", info.DetachedStorage)
+	for _, offer := range info.DestroyedOffers {
+		suffix := "s"
+		if offer.Connections == 1 {
+			suffix = ""
+		}
+		fmt.Fprintf(w, "- remove offer %s (%d connection%s)\n", offer.OfferName, offer.Connections, suffix)
+	}
+}
+
 // TODO(axw) 2017-03-16 #1673323
 // Drop this in Juju 3.0.
 func (c *removeApplicationCommand) removeApplicationsDeprecated(
@@ -194,11 +335,14 @@ func (c *removeApplicationCommand) removeApplications(
 	client RemoveApplicationAPI,
 ) error {
 	results, err := client.DestroyApplications(application.DestroyApplicationsParams{
-		Applications:   c.ApplicationNames,
-		DestroyStorage: c.DestroyStorage,
-		Force:          c.Force,
+		Applications:       c.ApplicationNames,
+		DestroyStorage:     c.DestroyStorage,
+		ReleaseStorage:     c.ReleaseStorage,
+		StorageDisposition: c.StorageDisposition,
+		Force:              c.Force,
 	})
-	if err := block.ProcessBlockedError(err, block.BlockRemove); err != nil {
+	err = block.ProcessBlockedError(err, block.BlockRemove)
+	if err := block.ProcessProtectedError(err); err != nil {
 		return errors.Trace(err)
 	}
 	anyFailed := false
@@ -226,6 +370,14 @@ func (c *removeApplicationCommand) removeApplications(
 			}
 			ctx.Infof("- will remove %s", names.ReadableString(storageTag))
 		}
+		for _, entity := range result.Info.ReleasedStorage {
+			storageTag, err := names.ParseStorageTag(entity.Tag)
+			if err != nil {
+				logger.Warningf("%s", err)
+				continue
+			}
+			ctx.Infof("- will release %s", names.ReadableString(storageTag))
+		}
 		for _, entity := range result.Info.DetachedStorage {
 			storageTag, err := names.ParseStorageTag(entity.Tag)
 			if err != nil {
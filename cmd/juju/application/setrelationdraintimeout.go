@@ -0,0 +1,116 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/application"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var setDrainTimeoutHelpSummary = `
+Sets how long departing units wait for their counterparts to depart a relation.`[1:]
+
+var setDrainTimeoutHelpDetails = `
+When a unit departs a relation with a drain timeout, it remains in the
+relation's scope until the units on the other side of the relation have
+run their relation-departed hooks for it, or until the timeout expires,
+whichever comes first. Once the timeout expires, Juju forcibly completes
+the unit's departure.
+
+A timeout of 0 disables draining, so units leave the relation's scope as
+soon as they depart. The relations are specified using their ids.
+
+Examples:
+    juju set-relation-drain-timeout 123 5m
+    juju set-relation-drain-timeout 123 456 30s
+    juju set-relation-drain-timeout 123 0
+
+See also: 
+    add-relation
+    remove-relation
+    suspend-relation`
+
+// NewSetRelationDrainTimeoutCommand returns a command to set the drain
+// timeout of relations.
+func NewSetRelationDrainTimeoutCommand() cmd.Command {
+	cmd := &setRelationDrainTimeoutCommand{}
+	cmd.newAPIFunc = func() (SetRelationDrainTimeoutAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type setRelationDrainTimeoutCommand struct {
+	modelcmd.ModelCommandBase
+	relationIds []int
+	timeout     time.Duration
+	newAPIFunc  func() (SetRelationDrainTimeoutAPI, error)
+}
+
+func (c *setRelationDrainTimeoutCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-relation-drain-timeout",
+		Args:    "<relation-id>[ <relation-id>...] <timeout>",
+		Purpose: setDrainTimeoutHelpSummary,
+		Doc:     setDrainTimeoutHelpDetails,
+	})
+}
+
+func (c *setRelationDrainTimeoutCommand) Init(args []string) (err error) {
+	switch len(args) {
+	case 0:
+		return errors.New("no relation ids specified")
+	case 1:
+		return errors.New("no timeout specified")
+	}
+	last := len(args) - 1
+	for _, id := range args[:last] {
+		if relId, err := strconv.Atoi(strings.TrimSpace(id)); err != nil || relId < 0 {
+			return errors.NotValidf("relation ID %q", id)
+		} else {
+			c.relationIds = append(c.relationIds, relId)
+		}
+	}
+	if args[last] == "0" {
+		return nil
+	}
+	if c.timeout, err = time.ParseDuration(args[last]); err != nil || c.timeout < 0 {
+		return errors.NotValidf("timeout %q", args[last])
+	}
+	return nil
+}
+
+// SetRelationDrainTimeoutAPI defines the API methods that the
+// set-relation-drain-timeout command uses.
+type SetRelationDrainTimeoutAPI interface {
+	Close() error
+	BestAPIVersion() int
+	SetRelationDrainTimeout(relationIds []int, timeout time.Duration) error
+}
+
+func (c *setRelationDrainTimeoutCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 16 {
+		return errors.New("setting a relation drain timeout is not supported by this version of Juju")
+	}
+	err = client.SetRelationDrainTimeout(c.relationIds, c.timeout)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,106 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SetRelationDrainTimeoutSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockSetDrainTimeoutAPI
+}
+
+func (s *SetRelationDrainTimeoutSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockSetDrainTimeoutAPI{Stub: &testing.Stub{}, version: 16}
+}
+
+var _ = gc.Suite(&SetRelationDrainTimeoutSuite{})
+
+func (s *SetRelationDrainTimeoutSuite) runSetDrainTimeout(c *gc.C, args ...string) error {
+	store := jujuclienttesting.MinimalStore()
+	_, err := cmdtesting.RunCommand(c, application.NewSetRelationDrainTimeoutCommandForTest(s.mockAPI, store), args...)
+	return err
+}
+
+func (s *SetRelationDrainTimeoutSuite) TestInvalidArguments(c *gc.C) {
+	err := s.runSetDrainTimeout(c)
+	c.Assert(err, gc.ErrorMatches, "no relation ids specified")
+
+	err = s.runSetDrainTimeout(c, "123")
+	c.Assert(err, gc.ErrorMatches, "no timeout specified")
+
+	err = s.runSetDrainTimeout(c, "application1", "5m")
+	c.Assert(err, gc.ErrorMatches, `relation ID "application1" not valid`)
+
+	err = s.runSetDrainTimeout(c, "123", "soon")
+	c.Assert(err, gc.ErrorMatches, `timeout "soon" not valid`)
+
+	err = s.runSetDrainTimeout(c, "123", "-5m")
+	c.Assert(err, gc.ErrorMatches, `timeout "-5m" not valid`)
+}
+
+func (s *SetRelationDrainTimeoutSuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 15
+	err := s.runSetDrainTimeout(c, "123", "5m")
+	c.Assert(err, gc.ErrorMatches, "setting a relation drain timeout is not supported by this version of Juju")
+	s.mockAPI.CheckCall(c, 0, "Close")
+}
+
+func (s *SetRelationDrainTimeoutSuite) TestSuccess(c *gc.C) {
+	err := s.runSetDrainTimeout(c, "123", "456", "5m")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetRelationDrainTimeout", []int{123, 456}, 5*time.Minute)
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+func (s *SetRelationDrainTimeoutSuite) TestZeroTimeout(c *gc.C) {
+	err := s.runSetDrainTimeout(c, "123", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 0, "SetRelationDrainTimeout", []int{123}, time.Duration(0))
+}
+
+func (s *SetRelationDrainTimeoutSuite) TestFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	err := s.runSetDrainTimeout(c, "123", "5m")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+func (s *SetRelationDrainTimeoutSuite) TestBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestBlocked"))
+	err := s.runSetDrainTimeout(c, "123", "5m")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestBlocked.*")
+}
+
+type mockSetDrainTimeoutAPI struct {
+	*testing.Stub
+	version int
+}
+
+func (s mockSetDrainTimeoutAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockSetDrainTimeoutAPI) SetRelationDrainTimeout(relationIds []int, timeout time.Duration) error {
+	s.MethodCall(s, "SetRelationDrainTimeout", relationIds, timeout)
+	return s.NextErr()
+}
+
+func (s mockSetDrainTimeoutAPI) BestAPIVersion() int {
+	return s.version
+}
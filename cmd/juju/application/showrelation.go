@@ -0,0 +1,143 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var showRelationHelpSummary = `
+Displays the relation data of the units in a relation.`[1:]
+
+var showRelationHelpDetails = `
+Shows the settings that each unit in the relation's scope has set with
+relation-set, grouped by application. This is the data that relation-get
+returns to the units on the other side of the relation, so there is no
+need to run relation-get on each unit with "juju run" to inspect it.
+
+Showing relation data requires read access to the model. The relation is
+specified using its id, as shown by "juju status --relations".
+
+Examples:
+    juju show-relation 123
+    juju show-relation 123 --format json
+
+See also:
+    add-relation
+    remove-relation
+    status`
+
+// NewShowRelationCommand returns a command to show the relation data
+// of a relation.
+func NewShowRelationCommand() cmd.Command {
+	cmd := &showRelationCommand{}
+	cmd.newAPIFunc = func() (ShowRelationAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type showRelationCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	relationId int
+	newAPIFunc func() (ShowRelationAPI, error)
+}
+
+func (c *showRelationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-relation",
+		Args:    "<relation-id>",
+		Purpose: showRelationHelpSummary,
+		Doc:     showRelationHelpDetails,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showRelationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+}
+
+func (c *showRelationCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no relation id specified")
+	}
+	relId, err := strconv.Atoi(strings.TrimSpace(args[0]))
+	if err != nil || relId < 0 {
+		return errors.NotValidf("relation ID %q", args[0])
+	}
+	c.relationId = relId
+	return cmd.CheckEmpty(args[1:])
+}
+
+// ShowRelationAPI defines the API methods that the show-relation
+// command uses.
+type ShowRelationAPI interface {
+	Close() error
+	BestAPIVersion() int
+	RelationData(relationId int) (params.RelationDataResult, error)
+}
+
+// RelationData defines the serialization behaviour of the relation data
+// shown by show-relation.
+type RelationData struct {
+	Id           int                                `yaml:"relation-id" json:"relation-id"`
+	Key          string                             `yaml:"key" json:"key"`
+	Applications map[string]ApplicationRelationData `yaml:"applications" json:"applications"`
+}
+
+// ApplicationRelationData defines the serialization behaviour of the
+// relation data of an application's units.
+type ApplicationRelationData struct {
+	Endpoint string                       `yaml:"endpoint" json:"endpoint"`
+	Role     string                       `yaml:"role" json:"role"`
+	Units    map[string]map[string]string `yaml:"units" json:"units"`
+}
+
+func (c *showRelationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 20 {
+		return errors.New("showing relation data is not supported by this version of Juju")
+	}
+	result, err := client.RelationData(c.relationId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	output := RelationData{
+		Id:           result.Id,
+		Key:          result.Key,
+		Applications: make(map[string]ApplicationRelationData),
+	}
+	for _, app := range result.Applications {
+		units := make(map[string]map[string]string)
+		for unitName, settings := range app.UnitData {
+			units[unitName] = settings
+		}
+		output.Applications[app.Application] = ApplicationRelationData{
+			Endpoint: app.Endpoint,
+			Role:     app.Role,
+			Units:    units,
+		}
+	}
+	return c.out.Write(ctx, output)
+}
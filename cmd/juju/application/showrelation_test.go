@@ -0,0 +1,135 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ShowRelationSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockShowRelationAPI
+}
+
+func (s *ShowRelationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockShowRelationAPI{
+		Stub:    &testing.Stub{},
+		version: 20,
+		result: params.RelationDataResult{
+			Id:  123,
+			Key: "wordpress:db mysql:db",
+			Applications: []params.RelationApplicationData{{
+				Application: "wordpress",
+				Endpoint:    "db",
+				Role:        "requirer",
+				UnitData: map[string]params.Settings{
+					"wordpress/0": {"database": "wordpress"},
+				},
+			}, {
+				Application: "mysql",
+				Endpoint:    "db",
+				Role:        "provider",
+				UnitData: map[string]params.Settings{
+					"mysql/0": {"host": "10.0.0.1", "port": "3306"},
+				},
+			}},
+		},
+	}
+}
+
+var _ = gc.Suite(&ShowRelationSuite{})
+
+func (s *ShowRelationSuite) runShowRelation(c *gc.C, args ...string) (string, error) {
+	store := jujuclienttesting.MinimalStore()
+	ctx, err := cmdtesting.RunCommand(c, application.NewShowRelationCommandForTest(s.mockAPI, store), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *ShowRelationSuite) TestInvalidArguments(c *gc.C) {
+	_, err := s.runShowRelation(c)
+	c.Assert(err, gc.ErrorMatches, "no relation id specified")
+
+	_, err = s.runShowRelation(c, "wordpress")
+	c.Assert(err, gc.ErrorMatches, `relation ID "wordpress" not valid`)
+
+	_, err = s.runShowRelation(c, "123", "456")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["456"\]`)
+}
+
+func (s *ShowRelationSuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 19
+	_, err := s.runShowRelation(c, "123")
+	c.Assert(err, gc.ErrorMatches, "showing relation data is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *ShowRelationSuite) TestShowRelation(c *gc.C) {
+	out, err := s.runShowRelation(c, "123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+relation-id: 123
+key: wordpress:db mysql:db
+applications:
+  mysql:
+    endpoint: db
+    role: provider
+    units:
+      mysql/0:
+        host: 10.0.0.1
+        port: "3306"
+  wordpress:
+    endpoint: db
+    role: requirer
+    units:
+      wordpress/0:
+        database: wordpress
+`[1:])
+	s.mockAPI.CheckCall(c, 0, "RelationData", 123)
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+func (s *ShowRelationSuite) TestShowRelationJSON(c *gc.C) {
+	out, err := s.runShowRelation(c, "123", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `{"relation-id":123,"key":"wordpress:db mysql:db","applications":{"mysql":{"endpoint":"db","role":"provider","units":{"mysql/0":{"host":"10.0.0.1","port":"3306"}}},"wordpress":{"endpoint":"db","role":"requirer","units":{"wordpress/0":{"database":"wordpress"}}}}}`+"\n")
+}
+
+func (s *ShowRelationSuite) TestFail(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runShowRelation(c, "123")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+type mockShowRelationAPI struct {
+	*testing.Stub
+	version int
+	result  params.RelationDataResult
+}
+
+func (s mockShowRelationAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return s.NextErr()
+}
+
+func (s mockShowRelationAPI) RelationData(relationId int) (params.RelationDataResult, error) {
+	s.MethodCall(s, "RelationData", relationId)
+	return s.result, s.NextErr()
+}
+
+func (s mockShowRelationAPI) BestAPIVersion() int {
+	return s.version
+}
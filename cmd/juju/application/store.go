@@ -51,6 +51,36 @@ func resolveCharm(
 	return resultURL, channel, supportedSeries, nil
 }
 
+// CharmResolver is the part of the controller's client API used to
+// resolve charm URLs on the controller.
+type CharmResolver interface {
+	ResolveCharm(*charm.URL) (*charm.URL, error)
+}
+
+// resolveWithControllerFallback returns a function that resolves charm
+// URLs with the given function, which normally uses the charm store,
+// and asks the controller to resolve them if that fails. This allows
+// charms to be deployed from a controller's local charm repository
+// when the charm store cannot be reached.
+func resolveWithControllerFallback(
+	resolveWithChannel func(*charm.URL) (*charm.URL, csparams.Channel, []string, error),
+	controller CharmResolver,
+) func(*charm.URL) (*charm.URL, csparams.Channel, []string, error) {
+	return func(url *charm.URL) (*charm.URL, csparams.Channel, []string, error) {
+		resultURL, channel, supportedSeries, err := resolveWithChannel(url)
+		if err == nil {
+			return resultURL, channel, supportedSeries, nil
+		}
+		logger.Debugf("cannot resolve %q with the charm store, trying the controller: %v", url, err)
+		resultURL, controllerErr := controller.ResolveCharm(url)
+		if controllerErr != nil {
+			logger.Debugf("cannot resolve %q with the controller: %v", url, controllerErr)
+			return nil, csparams.NoChannel, nil, err
+		}
+		return resultURL, csparams.NoChannel, nil, nil
+	}
+}
+
 // TODO(ericsnow) Return charmstore.CharmID from addCharmFromURL()?
 
 // addCharmFromURL calls the appropriate client API calls to add the
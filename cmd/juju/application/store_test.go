@@ -0,0 +1,69 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+)
+
+var _ = gc.Suite(&StoreSuite{})
+
+type StoreSuite struct {
+	testing.IsolationSuite
+}
+
+type fakeCharmResolver struct {
+	url *charm.URL
+	err error
+}
+
+func (r fakeCharmResolver) ResolveCharm(*charm.URL) (*charm.URL, error) {
+	return r.url, r.err
+}
+
+func (StoreSuite) TestResolveWithControllerFallbackStoreSucceeds(c *gc.C) {
+	storeURL := charm.MustParseURL("cs:bionic/mysql-58")
+	resolve := resolveWithControllerFallback(
+		func(*charm.URL) (*charm.URL, csparams.Channel, []string, error) {
+			return storeURL, csparams.StableChannel, []string{"bionic", "xenial"}, nil
+		},
+		fakeCharmResolver{err: errors.New("should not be called")},
+	)
+	url, channel, series, err := resolve(charm.MustParseURL("cs:mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(url, gc.Equals, storeURL)
+	c.Assert(channel, gc.Equals, csparams.StableChannel)
+	c.Assert(series, jc.DeepEquals, []string{"bionic", "xenial"})
+}
+
+func (StoreSuite) TestResolveWithControllerFallback(c *gc.C) {
+	controllerURL := charm.MustParseURL("cs:bionic/mysql-3")
+	resolve := resolveWithControllerFallback(
+		func(*charm.URL) (*charm.URL, csparams.Channel, []string, error) {
+			return nil, csparams.NoChannel, nil, errors.New("cannot reach charm store")
+		},
+		fakeCharmResolver{url: controllerURL},
+	)
+	url, channel, series, err := resolve(charm.MustParseURL("cs:mysql"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(url, gc.Equals, controllerURL)
+	c.Assert(channel, gc.Equals, csparams.NoChannel)
+	c.Assert(series, gc.HasLen, 0)
+}
+
+func (StoreSuite) TestResolveWithControllerFallbackBothFail(c *gc.C) {
+	resolve := resolveWithControllerFallback(
+		func(*charm.URL) (*charm.URL, csparams.Channel, []string, error) {
+			return nil, csparams.NoChannel, nil, errors.New("cannot reach charm store")
+		},
+		fakeCharmResolver{err: errors.NotFoundf("charm")},
+	)
+	_, _, _, err := resolve(charm.MustParseURL("cs:mysql"))
+	c.Assert(err, gc.ErrorMatches, "cannot reach charm store")
+}
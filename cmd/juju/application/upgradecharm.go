@@ -76,6 +76,7 @@ type CharmUpgradeClient interface {
 	GetCharmURL(string, string) (*charm.URL, error)
 	Get(string, string) (*params.ApplicationGetResults, error)
 	SetCharm(string, application.SetCharmConfig) error
+	CheckCharmCompatibility(string, string) error
 }
 
 // CharmClient defines a subset of the charms facade, as required
@@ -187,7 +188,10 @@ cause unexpected behavior.
 
 The --switch option allows you to replace the charm with an entirely different one.
 The new charm's URL and revision are inferred as they would be when running a
-deploy command.
+deploy command. The new charm may be a charm store URL or a path to a local
+charm, so an application deployed from a local charm can be switched to a
+charm store charm and back again, and the new charm may have a different name.
+The application keeps its relations, storage and resources.
 
 Please note that --switch is dangerous, because juju only has limited
 information with which to determine compatibility; the operation will succeed,
@@ -195,9 +199,14 @@ regardless of potential havoc, so long as the following conditions hold:
 
 - The new charm must declare all relations that the application is currently
 participating in.
+- The new charm must declare all storage that is required, or in use by the
+application's units, with compatible types, locations and counts.
 - All config settings shared by the old and new charms must
 have the same types.
 
+The relation and storage conditions are checked before any resources are
+uploaded for the new charm.
+
 The new charm may add new relations and configuration settings.
 
 --switch and --path are mutually exclusive.
@@ -355,6 +364,14 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 	}
 	ctx.Infof("Added charm %q to the model.", chID.URL)
 
+	// Check the new charm is compatible before uploading any resources,
+	// so that an incompatible charm leaves the application untouched.
+	if apiRoot.BestFacadeVersion("Application") >= 15 {
+		if err := charmUpgradeClient.CheckCharmCompatibility(c.ApplicationName, chID.URL.String()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// Next, upgrade resources.
 	charmsClient := c.NewCharmClient(apiRoot)
 	resourceLister, err := c.NewResourceLister(apiRoot)
@@ -562,8 +579,10 @@ func (c *upgradeCharmCommand) addCharm(
 	// local charm URL from the deployed series.
 	ch, newURL, err := charmrepo.NewCharmAtPathForceSeries(charmRef, deployedSeries, c.ForceSeries)
 	if err == nil {
+		// Switching to a local charm may change the charm's name;
+		// its compatibility is checked by the controller.
 		newName := ch.Meta().Name
-		if newName != oldURL.Name {
+		if c.SwitchURL == "" && newName != oldURL.Name {
 			return id, nil, errors.Errorf("cannot upgrade %q to %q", oldURL.Name, newName)
 		}
 		addedURL, err := charmAdder.AddLocalCharm(newURL, ch, force)
@@ -585,7 +604,11 @@ func (c *upgradeCharmCommand) addCharm(
 	}
 
 	// Charm has been supplied as a URL so we resolve and deploy using the store.
-	newURL, channel, supportedSeries, err := c.ResolveCharm(charmRepo.ResolveWithChannel, refURL)
+	resolveWithChannel := charmRepo.ResolveWithChannel
+	if resolver, ok := charmAdder.(CharmResolver); ok {
+		resolveWithChannel = resolveWithControllerFallback(resolveWithChannel, resolver)
+	}
+	newURL, channel, supportedSeries, err := c.ResolveCharm(resolveWithChannel, refURL)
 	if err != nil {
 		return id, nil, errors.Trace(err)
 	}
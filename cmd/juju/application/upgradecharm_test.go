@@ -249,6 +249,26 @@ func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 		"updating config at upgrade-charm time is not supported by server version 1.2.3")
 }
 
+func (s *UpgradeCharmSuite) TestCheckCharmCompatibility(c *gc.C) {
+	s.apiConnection.bestFacadeVersion = 15
+	_, err := s.runUpgradeCharm(c, "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get", "CheckCharmCompatibility", "SetCharm")
+	s.charmAPIClient.CheckCall(c, 2, "CheckCharmCompatibility", "foo", s.resolvedCharmURL.String())
+}
+
+func (s *UpgradeCharmSuite) TestCheckCharmCompatibilityFails(c *gc.C) {
+	s.apiConnection.bestFacadeVersion = 15
+	s.charmAPIClient.SetErrors(nil, nil, errors.New(`would break relation "foo:db bar:db"`))
+	_, err := s.runUpgradeCharm(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `would break relation "foo:db bar:db"`)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get", "CheckCharmCompatibility")
+	// Resources are not looked at until the charm is known to be compatible.
+	for _, call := range s.Calls() {
+		c.Assert(call.FuncName, gc.Not(gc.Equals), "NewResourceLister")
+	}
+}
+
 type UpgradeCharmErrorsStateSuite struct {
 	jujutesting.RepoSuite
 	handler charmstore.HTTPCloseHandler
@@ -619,6 +639,21 @@ func (s *UpgradeCharmSuccessStateSuite) TestCharmPathDifferentNameFails(c *gc.C)
 	c.Assert(err, gc.ErrorMatches, `cannot upgrade "riak" to "myriak"`)
 }
 
+func (s *UpgradeCharmSuccessStateSuite) TestSwitchCharmPathDifferentName(c *gc.C) {
+	myriakPath := testcharms.Repo.RenamedClonedDirPath(s.CharmsPath, "riak", "myriak")
+	metadataPath := filepath.Join(myriakPath, "metadata.yaml")
+	metadata, err := ioutil.ReadFile(metadataPath)
+	c.Assert(err, jc.ErrorIsNil)
+	metadata = []byte(strings.Replace(string(metadata), "name: riak", "name: myriak", 1))
+	err = ioutil.WriteFile(metadataPath, metadata, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runUpgradeCharm(c, "riak", "--switch", myriakPath)
+	c.Assert(err, jc.ErrorIsNil)
+	curl := s.assertUpgraded(c, s.riak, 7, false)
+	c.Assert(curl.String(), gc.Equals, "local:quantal/myriak-7")
+}
+
 type UpgradeCharmCharmStoreStateSuite struct {
 	BaseUpgradeCharmStateSuite
 	legacyCharmStoreSuite
@@ -923,6 +958,11 @@ func (m *mockCharmAPIClient) SetCharm(branchName string, cfg application.SetChar
 	return m.NextErr()
 }
 
+func (m *mockCharmAPIClient) CheckCharmCompatibility(appName, charmURL string) error {
+	m.MethodCall(m, "CheckCharmCompatibility", appName, charmURL)
+	return m.NextErr()
+}
+
 func (m *mockCharmAPIClient) Get(branchName, applicationName string) (*params.ApplicationGetResults, error) {
 	m.MethodCall(m, "Get", applicationName)
 	return &params.ApplicationGetResults{}, m.NextErr()
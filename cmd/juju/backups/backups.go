@@ -11,12 +11,16 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/backups"
 	apiserverbackups "github.com/juju/juju/apiserver/facades/client/backups"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/bootstrap"
+	"github.com/juju/juju/jujuclient"
 	statebackups "github.com/juju/juju/state/backups"
 )
 
@@ -41,9 +45,12 @@ type APIClient interface {
 	// Remove removes the stored backups.
 	Remove(ids ...string) ([]params.ErrorResult, error)
 	// Restore will restore a backup with the given id into the controller.
-	Restore(string, backups.ClientConnection) error
+	Restore(string, bool, backups.ClientConnection) error
 	// RestoreReader will restore a backup file into the controller.
-	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, backups.ClientConnection) error
+	RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, bool, backups.ClientConnection) error
+	// VerifyRestore checks whether a backup could be restored into
+	// the controller, without restoring it.
+	VerifyRestore(string, *params.BackupsMetadataResult, bool) (params.VerifyRestoreResult, error)
 }
 
 // CommandBase is the base type for backups sub-commands.
@@ -58,6 +65,13 @@ func (c *CommandBase) NewAPIClient() (APIClient, error) {
 	return newAPIClient(c)
 }
 
+// NewControllerModelAPIClient returns a client for the backups api
+// endpoint on the controller model of the selected controller, whichever
+// of the controller's models is selected.
+func (c *CommandBase) NewControllerModelAPIClient() (APIClient, error) {
+	return newControllerModelAPIClient(c)
+}
+
 // NewAPIClient returns a client for the backups api endpoint.
 func (c *CommandBase) NewGetAPI() (APIClient, int, error) {
 	return getAPI(c)
@@ -87,6 +101,23 @@ var newAPIClient = func(c *CommandBase) (APIClient, error) {
 	return backups.NewClient(root)
 }
 
+var newControllerModelAPIClient = func(c *CommandBase) (APIClient, error) {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	root, err := c.ModelCommandBase.CommandBase.NewAPIRoot(c.ClientStore(), controllerName, controllerModelName())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return backups.NewClient(root)
+}
+
+// controllerModelName returns the qualified name of the controller model.
+func controllerModelName() string {
+	return jujuclient.JoinOwnerModelName(names.NewUserTag(environs.AdminUser), bootstrap.ControllerModelName)
+}
+
 // GetAPI returns a client and the api version of the controller
 var getAPI = func(c *CommandBase) (APIClient, int, error) {
 	root, err := c.NewAPIRoot()
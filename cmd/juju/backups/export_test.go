@@ -16,9 +16,10 @@ const (
 )
 
 var (
-	NewAPIClient = &newAPIClient
-	NewGetAPI    = &getAPI
-	GetArchive   = &getArchive
+	NewAPIClient                = &newAPIClient
+	NewControllerModelAPIClient = &newControllerModelAPIClient
+	NewGetAPI                   = &getAPI
+	GetArchive                  = &getArchive
 )
 
 type CreateCommand struct {
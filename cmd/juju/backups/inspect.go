@@ -0,0 +1,189 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"os"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	statebackups "github.com/juju/juju/state/backups"
+)
+
+const inspectDoc = `
+inspect-backup reads a backup archive on the local machine and shows what
+is in it: where and when it was made, the models it holds and the database
+collections it includes. Nothing is restored, and no controller is needed.
+
+While reading the archive, the checksum of its compressed content and the
+structure of the database dump are verified. The checksum of the whole
+archive is shown; use --checksum to also check it against the checksum
+reported by "juju show-backup" or "juju create-backup".
+
+Examples:
+
+    juju inspect-backup juju-backup-20190506-093512.tar.gz
+    juju inspect-backup backup.tar.gz --checksum n9Vx3kq3t1NZ2zwGiOfY8jHL6h0=
+
+See also:
+    create-backup
+    show-backup
+    restore-backup
+`
+
+// NewInspectCommand returns a command used to inspect a backup archive.
+func NewInspectCommand() cmd.Command {
+	return &inspectCommand{}
+}
+
+// inspectCommand is the sub-command for inspecting a backup archive.
+type inspectCommand struct {
+	cmd.CommandBase
+	out cmd.Output
+
+	// Filename is the path to the backup archive.
+	Filename string
+	// Checksum is the checksum the archive is expected to have.
+	Checksum string
+}
+
+// Info implements Command.Info.
+func (c *inspectCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "inspect-backup",
+		Args:    "<file>",
+		Purpose: "Show the contents of a backup archive and verify it.",
+		Doc:     inspectDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *inspectCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", cmd.DefaultFormatters)
+	f.StringVar(&c.Checksum, "checksum", "", "The checksum the archive is expected to have")
+}
+
+// Init implements Command.Init.
+func (c *inspectCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing filename")
+	}
+	filename, args := args[0], args[1:]
+	if err := cmd.CheckEmpty(args); err != nil {
+		return errors.Trace(err)
+	}
+	c.Filename = filename
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *inspectCommand) Run(ctx *cmd.Context) error {
+	archive, err := os.Open(ctx.AbsPath(c.Filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+
+	info, err := statebackups.InspectArchive(archive)
+	if err != nil {
+		return errors.Annotatef(err, "archive %q is not valid", c.Filename)
+	}
+	if err := c.out.Write(ctx, formatArchiveInfo(info)); err != nil {
+		return errors.Trace(err)
+	}
+	if c.Checksum != "" && c.Checksum != info.Checksum {
+		return errors.Errorf("archive checksum %q does not match expected %q", info.Checksum, c.Checksum)
+	}
+	return nil
+}
+
+// ArchiveInfo is the serialisable form of the contents of a backup
+// archive.
+type ArchiveInfo struct {
+	Checksum        string                   `yaml:"checksum" json:"checksum"`
+	ChecksumFormat  string                   `yaml:"checksum-format" json:"checksum-format"`
+	Size            int64                    `yaml:"size" json:"size"`
+	Started         *time.Time               `yaml:"started,omitempty" json:"started,omitempty"`
+	Notes           string                   `yaml:"notes,omitempty" json:"notes,omitempty"`
+	ControllerModel string                   `yaml:"controller-model-uuid,omitempty" json:"controller-model-uuid,omitempty"`
+	Machine         string                   `yaml:"machine,omitempty" json:"machine,omitempty"`
+	Hostname        string                   `yaml:"hostname,omitempty" json:"hostname,omitempty"`
+	Version         string                   `yaml:"juju-version,omitempty" json:"juju-version,omitempty"`
+	Series          string                   `yaml:"series,omitempty" json:"series,omitempty"`
+	FilesSize       int64                    `yaml:"files-size" json:"files-size"`
+	Models          []ArchiveModel           `yaml:"models,omitempty" json:"models,omitempty"`
+	Databases       map[string]ArchiveDBInfo `yaml:"databases,omitempty" json:"databases,omitempty"`
+}
+
+// ArchiveModel is the serialisable form of a model in a backup archive.
+type ArchiveModel struct {
+	Name  string `yaml:"name" json:"name"`
+	Owner string `yaml:"owner" json:"owner"`
+	UUID  string `yaml:"uuid" json:"uuid"`
+}
+
+// ArchiveDBInfo is the serialisable form of the collections dumped
+// from a database into a backup archive, keyed by collection name.
+type ArchiveDBInfo map[string]ArchiveCollection
+
+// ArchiveCollection is the serialisable form of a collection dumped
+// into a backup archive.
+type ArchiveCollection struct {
+	Size      int64 `yaml:"size" json:"size"`
+	Documents int   `yaml:"documents" json:"documents"`
+}
+
+// oplogDatabase is the database the oplog in a dump was taken from.
+// The dump keeps it apart from the dumped databases.
+const oplogDatabase = "local"
+
+func formatArchiveInfo(info *statebackups.ArchiveInfo) ArchiveInfo {
+	out := ArchiveInfo{
+		Checksum:       info.Checksum,
+		ChecksumFormat: info.ChecksumFormat,
+		Size:           info.Size,
+		FilesSize:      info.FilesSize,
+	}
+	if meta := info.Metadata; meta != nil {
+		if !meta.Started.IsZero() {
+			started := meta.Started
+			out.Started = &started
+		}
+		out.Notes = meta.Notes
+		out.ControllerModel = meta.Origin.Model
+		out.Machine = meta.Origin.Machine
+		out.Hostname = meta.Origin.Hostname
+		out.Version = meta.Origin.Version.String()
+		out.Series = meta.Origin.Series
+	}
+	for _, m := range info.Models {
+		out.Models = append(out.Models, ArchiveModel{
+			Name:  m.Name,
+			Owner: m.Owner,
+			UUID:  m.UUID,
+		})
+	}
+	for _, coll := range info.Collections {
+		if out.Databases == nil {
+			out.Databases = make(map[string]ArchiveDBInfo)
+		}
+		dbName := coll.Database
+		if dbName == "" {
+			dbName = oplogDatabase
+		}
+		if out.Databases[dbName] == nil {
+			out.Databases[dbName] = make(ArchiveDBInfo)
+		}
+		out.Databases[dbName][coll.Name] = ArchiveCollection{
+			Size:      coll.Size,
+			Documents: coll.Documents,
+		}
+	}
+	return out
+}
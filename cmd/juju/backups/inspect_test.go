@@ -0,0 +1,95 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cmd/juju/backups"
+	statebackups "github.com/juju/juju/state/backups"
+	bt "github.com/juju/juju/state/backups/testing"
+)
+
+type inspectSuite struct {
+	testing.IsolationSuite
+	filename string
+	checksum string
+}
+
+var _ = gc.Suite(&inspectSuite{})
+
+func (s *inspectSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	model, err := bson.Marshal(bson.M{"_id": "deadbeef-0bad-400d-8000-4b1d0d06f00d", "name": "controller", "owner": "admin"})
+	c.Assert(err, jc.ErrorIsNil)
+	meta := bt.NewMetadataStarted()
+	meta.Origin.Series = "bionic"
+	archive, err := bt.NewArchive(meta, nil, []bt.File{{
+		Name:  "juju",
+		IsDir: true,
+	}, {
+		Name:    "juju/models.bson",
+		Content: string(model),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	data := archive.Bytes()
+
+	info, err := statebackups.InspectArchive(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	s.checksum = info.Checksum
+
+	s.filename = filepath.Join(c.MkDir(), "juju-backup.tar.gz")
+	err = ioutil.WriteFile(s.filename, data, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *inspectSuite) TestInspect(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, backups.NewInspectCommand(), s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var out backups.ArchiveInfo
+	err = yaml.Unmarshal([]byte(cmdtesting.Stdout(ctx)), &out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(out.Checksum, gc.Equals, s.checksum)
+	c.Check(out.ChecksumFormat, gc.Equals, "SHA-1, base64 encoded")
+	c.Check(out.ControllerModel, gc.Equals, "49db53ac-a42f-4ab2-86e1-0c6fa0fec762")
+	c.Check(out.Series, gc.Equals, "bionic")
+	c.Check(out.Models, jc.DeepEquals, []backups.ArchiveModel{{
+		Name:  "controller",
+		Owner: "admin",
+		UUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+	}})
+	c.Check(out.Databases["juju"]["models"].Documents, gc.Equals, 1)
+}
+
+func (s *inspectSuite) TestInspectChecksumMatches(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, backups.NewInspectCommand(), s.filename, "--checksum", s.checksum)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *inspectSuite) TestInspectChecksumMismatch(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, backups.NewInspectCommand(), s.filename, "--checksum", "bogus")
+	c.Assert(err, gc.ErrorMatches, `archive checksum ".*" does not match expected "bogus"`)
+}
+
+func (s *inspectSuite) TestInspectNotAnArchive(c *gc.C) {
+	err := ioutil.WriteFile(s.filename, []byte("<not an archive>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = cmdtesting.RunCommand(c, backups.NewInspectCommand(), s.filename)
+	c.Assert(err, gc.ErrorMatches, `archive ".*" is not valid: while uncompressing archive file: .*`)
+}
+
+func (s *inspectSuite) TestInspectMissingFilename(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, backups.NewInspectCommand())
+	c.Assert(err, gc.ErrorMatches, "missing filename")
+}
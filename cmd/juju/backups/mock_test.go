@@ -179,27 +179,27 @@ func (mr *MockAPIClientMockRecorder) Remove(arg0 ...interface{}) *gomock.Call {
 }
 
 // Restore mocks base method
-func (m *MockAPIClient) Restore(arg0 string, arg1 backups.ClientConnection) error {
-	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+func (m *MockAPIClient) Restore(arg0 string, arg1 bool, arg2 backups.ClientConnection) error {
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Restore indicates an expected call of Restore
-func (mr *MockAPIClientMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAPIClient)(nil).Restore), arg0, arg1)
+func (mr *MockAPIClientMockRecorder) Restore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockAPIClient)(nil).Restore), arg0, arg1, arg2)
 }
 
 // RestoreReader mocks base method
-func (m *MockAPIClient) RestoreReader(arg0 io.ReadSeeker, arg1 *params.BackupsMetadataResult, arg2 backups.ClientConnection) error {
-	ret := m.ctrl.Call(m, "RestoreReader", arg0, arg1, arg2)
+func (m *MockAPIClient) RestoreReader(arg0 io.ReadSeeker, arg1 *params.BackupsMetadataResult, arg2 bool, arg3 backups.ClientConnection) error {
+	ret := m.ctrl.Call(m, "RestoreReader", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RestoreReader indicates an expected call of RestoreReader
-func (mr *MockAPIClientMockRecorder) RestoreReader(arg0, arg1, arg2 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReader", reflect.TypeOf((*MockAPIClient)(nil).RestoreReader), arg0, arg1, arg2)
+func (mr *MockAPIClientMockRecorder) RestoreReader(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreReader", reflect.TypeOf((*MockAPIClient)(nil).RestoreReader), arg0, arg1, arg2, arg3)
 }
 
 // Upload mocks base method
@@ -214,3 +214,16 @@ func (m *MockAPIClient) Upload(arg0 io.ReadSeeker, arg1 params.BackupsMetadataRe
 func (mr *MockAPIClientMockRecorder) Upload(arg0, arg1 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Upload", reflect.TypeOf((*MockAPIClient)(nil).Upload), arg0, arg1)
 }
+
+// VerifyRestore mocks base method
+func (m *MockAPIClient) VerifyRestore(arg0 string, arg1 *params.BackupsMetadataResult, arg2 bool) (params.VerifyRestoreResult, error) {
+	ret := m.ctrl.Call(m, "VerifyRestore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(params.VerifyRestoreResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyRestore indicates an expected call of VerifyRestore
+func (mr *MockAPIClientMockRecorder) VerifyRestore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyRestore", reflect.TypeOf((*MockAPIClient)(nil).VerifyRestore), arg0, arg1, arg2)
+}
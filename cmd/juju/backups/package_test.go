@@ -241,10 +241,14 @@ func (c *fakeAPIClient) Close() error {
 	return nil
 }
 
-func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, apibackups.ClientConnection) error {
+func (c *fakeAPIClient) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, bool, apibackups.ClientConnection) error {
 	return nil
 }
 
-func (c *fakeAPIClient) Restore(string, apibackups.ClientConnection) error {
+func (c *fakeAPIClient) Restore(string, bool, apibackups.ClientConnection) error {
 	return nil
 }
+
+func (c *fakeAPIClient) VerifyRestore(string, *params.BackupsMetadataResult, bool) (params.VerifyRestoreResult, error) {
+	return params.VerifyRestoreResult{}, nil
+}
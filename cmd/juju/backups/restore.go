@@ -20,9 +20,6 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/cmd/modelcmd"
-	"github.com/juju/juju/environs"
-	"github.com/juju/juju/environs/bootstrap"
-	"github.com/juju/juju/jujuclient"
 )
 
 // NewRestoreCommand returns a command used to restore a backup.
@@ -40,6 +37,8 @@ type restoreCommand struct {
 
 	Filename string
 	BackupId string
+	Rollback bool
+	Verify   bool
 }
 
 // RestoreAPI is used to invoke various API calls.
@@ -48,10 +47,13 @@ type RestoreAPI interface {
 	Close() error
 
 	// Restore is taken from backups.Client.
-	Restore(backupId string, newClient backups.ClientConnection) error
+	Restore(backupId string, rollback bool, newClient backups.ClientConnection) error
 
 	// RestoreReader is taken from backups.Client.
-	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient backups.ClientConnection) error
+	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, rollback bool, newClient backups.ClientConnection) error
+
+	// VerifyRestore is taken from backups.Client.
+	VerifyRestore(backupId string, meta *params.BackupsMetadataResult, rollback bool) (params.VerifyRestoreResult, error)
 }
 
 // ModelStatusAPI is used to invoke common.ModelStatus
@@ -71,9 +73,27 @@ Note: Only the database will be restored.  Juju will not change the existing
 environment to match the restored database, e.g. no units, relations, nor
 machines will be added or removed during the restore process.
 
+The backup is restored into the controller that the current model is on,
+whichever of its models that is, so a controller can be chosen with
+-m <controller>: or JUJU_MODEL without switching to its controller model.
+
 Note: Extra care is needed to restore in an HA environment, please see
 https://docs.jujucharms.com/stable/controllers-backup for more information.
 
+Before anything is changed, the backup is checked to have been made of
+this controller, on the same series and with a compatible Juju version.
+A safety snapshot of the controller is then taken and kept in the
+controller's backup directory. If --rollback is given and the restore
+fails part way through, the controller is put back as it was using the
+safety snapshot.
+
+With --verify, nothing is restored. Instead the controller is checked
+to be ready for the restore: that the backup can be restored into it,
+that the controller machine's instance is running, that the controller
+model's cloud credential is valid, and that no other restore is under
+way. The checks are reported along with the steps the restore would
+take, and the command fails if any check does.
+
 If the provided state cannot be restored, this command will fail with
 an explanation.
 `
@@ -93,6 +113,8 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.StringVar(&c.Filename, "file", "", "Provide a file to be used as the backup")
 	f.StringVar(&c.BackupId, "id", "", "Provide the name of the backup to be restored")
+	f.BoolVar(&c.Rollback, "rollback", false, "Roll back to the safety snapshot if the restore fails")
+	f.BoolVar(&c.Verify, "verify", false, "Check the restore could be done and report what it would do, without restoring")
 }
 
 // Init is where the preconditions for this command can be checked.
@@ -116,9 +138,7 @@ func (c *restoreCommand) Init(args []string) error {
 }
 
 func (c *restoreCommand) modelStatus() (string, []base.ModelStatus, error) {
-	controllerModel := jujuclient.JoinOwnerModelName(
-		names.NewUserTag(environs.AdminUser), bootstrap.ControllerModelName)
-	modelUUIDs, err := c.ModelUUIDs([]string{controllerModel})
+	modelUUIDs, err := c.ModelUUIDs([]string{controllerModelName()})
 	if err != nil {
 		return "", nil, errors.Annotatef(err, "cannot get controller model uuid")
 	}
@@ -145,7 +165,7 @@ func (c *restoreCommand) modelStatus() (string, []base.ModelStatus, error) {
 }
 
 func (c *restoreCommand) newClient() (*backups.Client, error) {
-	client, err := c.NewAPIClient()
+	client, err := c.NewControllerModelAPIClient()
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -173,7 +193,7 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 	activeCount, _ := controller.ControllerMachineCounts(controllerModelUUID, modelStatus)
-	if activeCount > 1 {
+	if activeCount > 1 && !c.Verify {
 		return errors.Errorf("unable to restore backup in HA configuration.  For help see https://docs.jujucharms.com/stable/controllers-backup")
 	}
 
@@ -191,18 +211,22 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		defer archive.Close()
 	}
 
-	client, err := c.NewAPIClient()
+	client, err := c.NewControllerModelAPIClient()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer client.Close()
 
+	if c.Verify {
+		return c.verify(ctx, client, meta, activeCount)
+	}
+
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
 	if c.Filename != "" {
-		err = client.RestoreReader(archive, meta, c.newClient)
+		err = client.RestoreReader(archive, meta, c.Rollback, c.newClient)
 	} else {
-		err = client.Restore(c.BackupId, c.newClient)
+		err = client.Restore(c.BackupId, c.Rollback, c.newClient)
 	}
 	if err != nil {
 		return errors.Trace(err)
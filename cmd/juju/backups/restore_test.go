@@ -68,7 +68,7 @@ func (s *restoreSuite) SetUpTest(c *gc.C) {
 func (s *restoreSuite) patch(c *gc.C, archiveErr error) (*gomock.Controller, *MockAPIClient, *MockArchiveReader, *MockModelStatusAPI) {
 	ctrl := gomock.NewController(c)
 	apiClient := NewMockAPIClient(ctrl)
-	s.PatchValue(backups.NewAPIClient,
+	s.PatchValue(backups.NewControllerModelAPIClient,
 		func(*backups.CommandBase) (backups.APIClient, error) {
 			return apiClient, nil
 		},
@@ -158,7 +158,7 @@ func (s *restoreSuite) TestRestoreFromBackupFilename(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
-		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, gomock.Any()).Return(
+		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, false, gomock.Any()).Return(
 			nil,
 		),
 		apiClient.EXPECT().Close(),
@@ -175,7 +175,7 @@ func (s *restoreSuite) TestRestoreFromBackupFilenameFail(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
-		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, gomock.Any()).Return(
+		apiClient.EXPECT().RestoreReader(archiveReader, &params.BackupsMetadataResult{}, false, gomock.Any()).Return(
 			errors.New("restore failed"),
 		),
 		apiClient.EXPECT().Close(),
@@ -190,7 +190,7 @@ func (s *restoreSuite) TestRestoreFromBackupId(c *gc.C) {
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
-		apiClient.EXPECT().Restore("an_id", gomock.Any()).Return(
+		apiClient.EXPECT().Restore("an_id", false, gomock.Any()).Return(
 			nil,
 		),
 		apiClient.EXPECT().Close(),
@@ -201,12 +201,26 @@ func (s *restoreSuite) TestRestoreFromBackupId(c *gc.C) {
 	c.Check(cmdtesting.Stdout(ctx), gc.Equals, out)
 }
 
+func (s *restoreSuite) TestRestoreFromBackupIdWithRollback(c *gc.C) {
+	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	gomock.InOrder(
+		apiClient.EXPECT().Restore("an_id", true, gomock.Any()).Return(
+			nil,
+		),
+		apiClient.EXPECT().Close(),
+	)
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--rollback")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *restoreSuite) TestRestoreFromBackupIdFail(c *gc.C) {
 	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
 	defer ctlr.Finish()
 	expectModelStatus(modelStatusClient)
 	gomock.InOrder(
-		apiClient.EXPECT().Restore("an_id", gomock.Any()).Return(
+		apiClient.EXPECT().Restore("an_id", false, gomock.Any()).Return(
 			errors.New("restore failed"),
 		),
 		apiClient.EXPECT().Close(),
@@ -215,6 +229,52 @@ func (s *restoreSuite) TestRestoreFromBackupIdFail(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "restore failed")
 }
 
+func (s *restoreSuite) TestRestoreOtherController(c *gc.C) {
+	const otherModelUUID = "deadbeef-0bad-400d-8000-5b1d0d06f0aa"
+	s.store.Controllers["other"] = jujuclient.ControllerDetails{
+		ControllerUUID: "deadbeef-0bad-400d-8000-5b1d0d06f0ff",
+		CACert:         testing.CACert,
+		Cloud:          "mycloud",
+		APIEndpoints:   []string{"10.0.2.1:17777"},
+	}
+	s.store.Accounts["other"] = jujuclient.AccountDetails{User: "bob"}
+	s.store.Models["other"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"bob/test1":        {ModelUUID: test1ModelUUID, ModelType: model.IAAS},
+			"admin/controller": {ModelUUID: otherModelUUID, ModelType: model.IAAS},
+		},
+		CurrentModel: "bob/test1",
+	}
+
+	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	var controllerName string
+	s.PatchValue(backups.NewControllerModelAPIClient,
+		func(c *backups.CommandBase) (backups.APIClient, error) {
+			controllerName, _ = c.ControllerName()
+			return apiClient, nil
+		},
+	)
+	gomock.InOrder(
+		modelStatusClient.EXPECT().ModelStatus(names.NewModelTag(otherModelUUID)).Return(
+			[]base.ModelStatus{{
+				UUID: otherModelUUID,
+				Machines: []base.Machine{
+					{HasVote: true, WantsVote: true, Status: string(status.Active)},
+				},
+			}}, nil,
+		),
+		modelStatusClient.EXPECT().Close(),
+	)
+	gomock.InOrder(
+		apiClient.EXPECT().Restore("an_id", false, gomock.Any()).Return(nil),
+		apiClient.EXPECT().Close(),
+	)
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "-m", "other:")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(controllerName, gc.Equals, "other")
+}
+
 func (s *restoreSuite) TestRestoreFromBackupGetArchiveFail(c *gc.C) {
 	ctlr, _, _, modelStatusClient := s.patch(c, errors.New("get archive fail"))
 	defer ctlr.Finish()
@@ -262,3 +322,96 @@ func (s *restoreSuite) TestRestoreFromBackupHAFail(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id")
 	c.Assert(err, gc.ErrorMatches, "unable to restore backup in HA configuration.  For help see https://docs.jujucharms.com/stable/controllers-backup")
 }
+
+func (s *restoreSuite) TestVerifyFromBackupId(c *gc.C) {
+	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	gomock.InOrder(
+		apiClient.EXPECT().VerifyRestore("an_id", (*params.BackupsMetadataResult)(nil), true).Return(
+			params.VerifyRestoreResult{
+				Checks: []params.RestoreCheck{{Name: "series", Description: "same series"}},
+				Steps:  []string{"put the controller into restore mode", "restore it"},
+			}, nil,
+		),
+		apiClient.EXPECT().Close(),
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--rollback", "--verify")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Check   Description                         Result\n"+
+		"ha      controller is not highly available  ok\n"+
+		"series  same series                         ok\n"+
+		"\n"+
+		"The restore would:\n"+
+		"  1. put the controller into restore mode\n"+
+		"  2. restore it\n"+
+		"\n"+
+		"backup can be restored\n")
+}
+
+func (s *restoreSuite) TestVerifyFromBackupFilename(c *gc.C) {
+	ctlr, apiClient, archiveReader, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	gomock.InOrder(
+		apiClient.EXPECT().VerifyRestore("", &params.BackupsMetadataResult{}, false).Return(
+			params.VerifyRestoreResult{Steps: []string{"restore it"}}, nil,
+		),
+		apiClient.EXPECT().Close(),
+		archiveReader.EXPECT().Close(),
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--file", "afile", "--verify")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, fmt.Sprintf(""+
+		"The restore would:\n"+
+		"  1. upload %q unless the controller already has it\n"+
+		"  2. restore it\n", s.command.Filename))
+}
+
+func (s *restoreSuite) TestVerifyFails(c *gc.C) {
+	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	controllerModelTag := names.NewModelTag(controllerModelUUID)
+	gomock.InOrder(
+		modelStatusClient.EXPECT().ModelStatus(controllerModelTag).Return(
+			[]base.ModelStatus{{
+				UUID: controllerModelUUID,
+				Machines: []base.Machine{
+					{HasVote: true, WantsVote: true, Status: string(status.Active)},
+					{HasVote: true, WantsVote: true, Status: string(status.Active)},
+					{HasVote: true, WantsVote: true, Status: string(status.Active)},
+				},
+			}}, nil,
+		),
+		modelStatusClient.EXPECT().Close(),
+	)
+	gomock.InOrder(
+		apiClient.EXPECT().VerifyRestore("an_id", (*params.BackupsMetadataResult)(nil), false).Return(
+			params.VerifyRestoreResult{
+				Checks: []params.RestoreCheck{{Name: "series", Description: "same series", Problem: "wrong series"}},
+			}, nil,
+		),
+		apiClient.EXPECT().Close(),
+	)
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--verify")
+	c.Assert(err, gc.ErrorMatches, "backup cannot be restored")
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Check   Description                         Result\n"+
+		"ha      controller is not highly available  3 controller machines are voting, restore needs 1\n"+
+		"series  same series                         wrong series\n")
+}
+
+func (s *restoreSuite) TestVerifyNotSupported(c *gc.C) {
+	ctlr, apiClient, _, modelStatusClient := s.patch(c, nil)
+	defer ctlr.Finish()
+	expectModelStatus(modelStatusClient)
+	gomock.InOrder(
+		apiClient.EXPECT().VerifyRestore("an_id", (*params.BackupsMetadataResult)(nil), false).Return(
+			params.VerifyRestoreResult{}, errors.NotSupportedf("verifying a restore on this controller"),
+		),
+		apiClient.EXPECT().Close(),
+	)
+	_, err := cmdtesting.RunCommand(c, s.wrappedCommand, "restore", "--id", "an_id", "--verify")
+	c.Assert(err, gc.ErrorMatches, "verifying a restore on this controller not supported")
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+)
+
+// verify asks the controller whether the backup could be restored,
+// and reports the checks made and the steps the restore would take.
+// activeCount is the number of controller machines with a vote, as a
+// restore can't be done in a highly available controller.
+func (c *restoreCommand) verify(ctx *cmd.Context, client APIClient, meta *params.BackupsMetadataResult, activeCount int) error {
+	result, err := client.VerifyRestore(c.BackupId, meta, c.Rollback)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	haCheck := params.RestoreCheck{
+		Name:        "ha",
+		Description: "controller is not highly available",
+	}
+	if activeCount > 1 {
+		haCheck.Problem = fmt.Sprintf("%d controller machines are voting, restore needs 1", activeCount)
+	}
+	checks := append([]params.RestoreCheck{haCheck}, result.Checks...)
+	steps := result.Steps
+	if c.Filename != "" && len(steps) > 0 {
+		steps = append([]string{
+			fmt.Sprintf("upload %q unless the controller already has it", c.Filename),
+		}, steps...)
+	}
+
+	tw := output.TabWriter(ctx.Stdout)
+	fmt.Fprintln(tw, "Check\tDescription\tResult")
+	failed := false
+	for _, check := range checks {
+		result := "ok"
+		if check.Problem != "" {
+			result = check.Problem
+			failed = true
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", check.Name, check.Description, result)
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+
+	if len(steps) > 0 {
+		fmt.Fprintln(ctx.Stdout, "\nThe restore would:")
+		for i, step := range steps {
+			fmt.Fprintf(ctx.Stdout, "  %d. %s\n", i+1, step)
+		}
+	}
+	if failed {
+		return errors.New("backup cannot be restored")
+	}
+	fmt.Fprintln(ctx.Stdout, "\nbackup can be restored")
+	return nil
+}
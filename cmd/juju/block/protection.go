@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/api"
 	apiblock "github.com/juju/juju/api/block"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/config"
 )
 
 var logger = loggo.GetLogger("juju.cmd.juju.block")
@@ -100,6 +101,44 @@ func ProcessBlockedError(err error, block Block) error {
 	return err
 }
 
+// ProcessProtectedError ensures that a user-friendly message is
+// displayed to the user when an operation is refused because the
+// model is protected.
+func ProcessProtectedError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if params.IsCodeModelProtected(err) {
+		msg := fmt.Sprintf("%v\n%v", err, protectedMsg)
+		logger.Infof(msg)
+		return errors.Errorf(msg)
+	}
+	return err
+}
+
+// ModelConfigAPI defines the methods on the modelconfig API
+// that are used to remove a model's protection.
+type ModelConfigAPI interface {
+	Close() error
+	ModelSet(attrs map[string]interface{}) error
+}
+
+// UnprotectModel removes the protection from the model, so that it
+// can be destroyed and its applications and machines removed.
+func UnprotectModel(api ModelConfigAPI) error {
+	err := api.ModelSet(map[string]interface{}{config.ProtectedKey: false})
+	return errors.Annotate(err, "cannot unprotect model")
+}
+
+var protectedMsg = `
+The model is protected from destruction and from the removal of its
+applications and machines. To remove the protection and continue,
+run the command again with --unprotect, or run
+
+    juju model-config protected=false
+
+`
+
 var removeMsg = `
 All operations that remove machines, applications, units or
 relations have been disabled for the current model.
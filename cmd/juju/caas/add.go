@@ -9,6 +9,8 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/juju/cmd"
@@ -69,6 +71,18 @@ The config file can contain definitions for different k8s clusters,
 use --cluster-name to pick which one to use.
 It's also possible to select a context by name using --context-name.
 
+Several contexts can be added at once, each as its own cloud, by giving
+a comma separated list of contexts to --context-name, or by using
+--all-contexts to add every context in the config. The cloud for each
+context is named by replacing "{context}" in the k8s name with the
+context name, or, if there is no "{context}", by adding "-<context>"
+to the k8s name. Characters not allowed in cloud names are replaced
+by "-".
+
+When juju is run inside a k8s pod, use --in-cluster to add the cluster
+the pod is running in, using the pod's service account token and the
+cluster CA certificate rather than a kubeconfig.
+
 When running add-k8s the underlying cloud/region hosting the cluster needs to be
 detected to enable storage to be correctly configured. If the cloud/region cannot
 be detected automatically, use --region <cloudType/region> to specify the host
@@ -84,6 +98,9 @@ Examples:
     juju add-k8s myk8scloud --controller mycontroller
     juju add-k8s --context-name mycontext myk8scloud
     juju add-k8s myk8scloud --region <cloudType/region>
+    juju add-k8s --context-name staging,production myk8s
+    juju add-k8s --all-contexts k8s-{context}
+    juju add-k8s --in-cluster myk8scloud
 
     KUBECONFIG=path-to-kubuconfig-file juju add-k8s myk8scloud --cluster-name=my_cluster_name
     kubectl config view --raw | juju add-k8s myk8scloud --cluster-name=my_cluster_name
@@ -123,6 +140,17 @@ type AddCAASCommand struct {
 	// contextName is the name of the contex to import.
 	contextName string
 
+	// contextNames are the names of the contexts to import, when
+	// importing more than one.
+	contextNames []string
+
+	// allContexts is true if every context in the config is imported.
+	allContexts bool
+
+	// inCluster is true if the cluster the client is running in is
+	// added, using the credential of the pod's service account.
+	inCluster bool
+
 	// project is the project id for the cluster.
 	project string
 
@@ -146,8 +174,10 @@ type AddCAASCommand struct {
 	aks        bool
 	k8sCluster k8sCluster
 
-	cloudMetadataStore    CloudMetadataStore
-	newClientConfigReader func(string) (clientconfig.ClientConfigFunc, error)
+	cloudMetadataStore       CloudMetadataStore
+	newClientConfigReader    func(string) (clientconfig.ClientConfigFunc, error)
+	newInClusterClientConfig func() (*clientconfig.ClientConfig, error)
+	k8sContextNames          func(io.Reader) ([]string, error)
 
 	getAllCloudDetails func() (map[string]*jujucmdcloud.CloudDetails, error)
 }
@@ -162,6 +192,8 @@ func NewAddCAASCommand(cloudMetadataStore CloudMetadataStore) cmd.Command {
 		newClientConfigReader: func(caasType string) (clientconfig.ClientConfigFunc, error) {
 			return clientconfig.NewClientConfigReader(caasType)
 		},
+		newInClusterClientConfig: clientconfig.NewInClusterClientConfig,
+		k8sContextNames:          clientconfig.K8sContextNames,
 	}
 	cmd.addCloudAPIFunc = func() (AddCloudAPI, error) {
 		root, err := cmd.NewAPIRoot(cmd.store, cmd.controllerName, "")
@@ -190,7 +222,9 @@ func (c *AddCAASCommand) Info() *cmd.Info {
 func (c *AddCAASCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.OptionalControllerCommand.SetFlags(f)
 	f.StringVar(&c.clusterName, "cluster-name", "", "Specify the k8s cluster to import")
-	f.StringVar(&c.contextName, "context-name", "", "Specify the k8s context to import")
+	f.StringVar(&c.contextName, "context-name", "", "Specify the k8s context to import, or a comma separated list of contexts")
+	f.BoolVar(&c.allContexts, "all-contexts", false, "Import every k8s context in the config")
+	f.BoolVar(&c.inCluster, "in-cluster", false, "Add the k8s cluster juju is running in")
 	f.StringVar(&c.hostCloudRegion, "region", "", "kubernetes cluster cloud and/or region")
 	f.StringVar(&c.workloadStorage, "storage", "", "kubernetes storage class for workload storage")
 	f.StringVar(&c.project, "project", "", "project to which the cluster belongs")
@@ -214,6 +248,27 @@ func (c *AddCAASCommand) Init(args []string) (err error) {
 	if c.contextName != "" && c.clusterName != "" {
 		return errors.New("only specify one of cluster-name or context-name, not both")
 	}
+	if c.allContexts && (c.contextName != "" || c.clusterName != "") {
+		return errors.New("do not specify cluster-name or context-name with all-contexts")
+	}
+	if c.inCluster && (c.gke || c.aks || c.allContexts || c.contextName != "" || c.clusterName != "") {
+		return errors.New("do not specify a cluster, context or cluster provider with in-cluster")
+	}
+	if c.allContexts && (c.gke || c.aks) {
+		return errors.New("do not specify all-contexts when adding a GKE or AKS cluster")
+	}
+	if c.contextName != "" {
+		c.contextNames = nil
+		for _, name := range strings.Split(c.contextName, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				c.contextNames = append(c.contextNames, name)
+			}
+		}
+		if len(c.contextNames) == 0 {
+			return errors.New("context-name must not be empty")
+		}
+		c.contextName = c.contextNames[0]
+	}
 	if c.gke {
 		if c.contextName != "" {
 			return errors.New("do not specify context name when adding a GKE cluster")
@@ -272,6 +327,9 @@ func getStdinPipe(ctx *cmd.Context) (io.Reader, error) {
 }
 
 func (c *AddCAASCommand) getConfigReader(ctx *cmd.Context) (io.Reader, string, error) {
+	if c.inCluster {
+		return nil, clientconfig.InClusterName, nil
+	}
 	if c.gke {
 		return c.getGKEKubeConfig(ctx)
 	}
@@ -347,6 +405,9 @@ var noRecommendedStorageErrMsg = `
 
 // Run is defined on the Command interface.
 func (c *AddCAASCommand) Run(ctx *cmd.Context) error {
+	if c.allContexts || len(c.contextNames) > 1 {
+		return errors.Trace(c.addContexts(ctx))
+	}
 	if err := c.verifyName(c.caasName); err != nil {
 		return errors.Trace(err)
 	}
@@ -357,14 +418,94 @@ func (c *AddCAASCommand) Run(ctx *cmd.Context) error {
 	if closer, ok := rdr.(io.Closer); ok {
 		defer closer.Close()
 	}
+	return errors.Trace(c.addCloud(ctx, rdr, clusterName))
+}
 
+// addContexts adds a cloud for each of the selected contexts in the
+// kubeconfig.
+func (c *AddCAASCommand) addContexts(ctx *cmd.Context) error {
+	rdr, err := getStdinPipe(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var data []byte
+	if rdr != nil {
+		if data, err = ioutil.ReadAll(rdr); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	// Each context reads the config afresh; a nil reader reads the
+	// kubeconfig file.
+	configReader := func() io.Reader {
+		if data == nil {
+			return nil
+		}
+		return bytes.NewReader(data)
+	}
+
+	contextNames := c.contextNames
+	if c.allContexts {
+		if contextNames, err = c.k8sContextNames(configReader()); err != nil {
+			return errors.Trace(err)
+		}
+		if len(contextNames) == 0 {
+			return errors.New("No k8s cluster definitions found in config")
+		}
+	}
+	cloudNames := make([]string, len(contextNames))
+	for i, contextName := range contextNames {
+		cloudNames[i] = contextCloudName(c.caasName, contextName)
+		if !names.IsValidCloud(cloudNames[i]) {
+			return errors.NotValidf("cloud name %q for context %q", cloudNames[i], contextName)
+		}
+		if err := c.verifyName(cloudNames[i]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	caasName := c.caasName
+	defer func() {
+		c.caasName, c.contextName = caasName, ""
+	}()
+	for i, contextName := range contextNames {
+		c.caasName, c.contextName = cloudNames[i], contextName
+		if err := c.addCloud(ctx, configReader(), ""); err != nil {
+			return errors.Annotatef(err, "adding context %q", contextName)
+		}
+	}
+	return nil
+}
+
+var invalidCloudNameChars = regexp.MustCompile("[^a-zA-Z0-9.-]+")
+
+// contextCloudName returns the name of the cloud for a kubeconfig
+// context when adding several contexts at once.
+func contextCloudName(k8sName, contextName string) string {
+	contextName = invalidCloudNameChars.ReplaceAllString(contextName, "-")
+	if strings.Contains(k8sName, "{context}") {
+		return strings.Replace(k8sName, "{context}", contextName, -1)
+	}
+	return k8sName + "-" + contextName
+}
+
+// addCloud adds the cloud, and its credential, for the cluster in the
+// config read from the reader.
+func (c *AddCAASCommand) addCloud(ctx *cmd.Context, rdr io.Reader, clusterName string) error {
+	clientConfigGetter := c.newClientConfigReader
+	if c.inCluster {
+		clientConfigGetter = func(string) (clientconfig.ClientConfigFunc, error) {
+			return func(io.Reader, string, string, clientconfig.K8sCredentialResolver) (*clientconfig.ClientConfig, error) {
+				return c.newInClusterClientConfig()
+			}, nil
+		}
+	}
 	config := provider.KubeCloudParams{
 		ClusterName:        clusterName,
 		CaasName:           c.caasName,
 		ContextName:        c.contextName,
 		HostCloudRegion:    c.hostCloudRegion,
 		CaasType:           c.caasType,
-		ClientConfigGetter: c.newClientConfigReader,
+		ClientConfigGetter: clientConfigGetter,
 	}
 
 	newCloud, credential, credentialName, err := provider.CloudFromKubeConfig(rdr, config)
@@ -370,6 +370,26 @@ func (s *addCAASSuite) TestInit(c *gc.C) {
 			args:           []string{"--credential", "a"},
 			expectedErrStr: "do not specify credential unless adding a GKE cluster",
 		},
+		{
+			args:           []string{"--all-contexts", "--context-name", "a"},
+			expectedErrStr: "do not specify cluster-name or context-name with all-contexts",
+		},
+		{
+			args:           []string{"--all-contexts", "--gke"},
+			expectedErrStr: "do not specify all-contexts when adding a GKE or AKS cluster",
+		},
+		{
+			args:           []string{"--in-cluster", "--cluster-name", "a"},
+			expectedErrStr: "do not specify a cluster, context or cluster provider with in-cluster",
+		},
+		{
+			args:           []string{"--in-cluster", "--aks"},
+			expectedErrStr: "do not specify a cluster, context or cluster provider with in-cluster",
+		},
+		{
+			args:           []string{"--context-name", ","},
+			expectedErrStr: "context-name must not be empty",
+		},
 	} {
 		args := append([]string{"myk8s"}, ts.args...)
 		cmd := s.makeCommand(c, true, false, true)
@@ -881,3 +901,60 @@ func (s *addCAASSuite) TestOnlyOneClusterProvider(c *gc.C) {
 	_, err := s.runCommand(c, nil, cmd, "myk8s", "-c", "foo", "--aks", "--gke")
 	c.Assert(err, gc.ErrorMatches, "only one of '--gke' or '--aks' can be supplied")
 }
+
+func (s *addCAASSuite) TestAddContexts(c *gc.C) {
+	cmd := s.makeCommand(c, true, false, true)
+	_, err := s.runCommand(c, nil, cmd, "myk8s", "--local", "--context-name", "key1,key2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.initialCloudMap["myk8s-key1"].Endpoint, gc.Equals, "fakeendpoint1")
+	c.Assert(s.initialCloudMap["myk8s-key2"].Endpoint, gc.Equals, "fakeendpoint2")
+	_, ok := s.initialCloudMap["myk8s"]
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *addCAASSuite) TestAddAllContextsFromStdIn(c *gc.C) {
+	cmd := s.makeCommand(c, true, true, false)
+	stdIn, err := mockStdinPipe(kubeConfigStr)
+	defer stdIn.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.runCommand(c, stdIn, cmd, "k8s-{context}", "--local", "--all-contexts")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.initialCloudMap["k8s-the-context"].Endpoint, gc.Equals, "https://1.1.1.1:8888")
+}
+
+func (s *addCAASSuite) TestAddContextsInvalidCloudName(c *gc.C) {
+	cmd := s.makeCommand(c, true, false, true)
+	_, err := s.runCommand(c, nil, cmd, "{context}", "--local", "--context-name", "key1,-key2")
+	c.Assert(err, gc.ErrorMatches, `cloud name "-key2" for context "-key2" not valid`)
+}
+
+func (s *addCAASSuite) TestAddInCluster(c *gc.C) {
+	cmd := s.makeCommand(c, true, false, false)
+	caas.SetNewInClusterClientConfig(cmd, func() (*clientconfig.ClientConfig, error) {
+		return &clientconfig.ClientConfig{
+			Type:           "kubernetes",
+			CurrentContext: clientconfig.InClusterName,
+			Contexts: map[string]clientconfig.Context{
+				clientconfig.InClusterName: {
+					CloudName:      clientconfig.InClusterName,
+					CredentialName: clientconfig.InClusterName,
+				},
+			},
+			Clouds: map[string]clientconfig.CloudConfig{
+				clientconfig.InClusterName: {
+					Endpoint:   "https://10.0.0.1:443",
+					Attributes: map[string]interface{}{"CAData": "fakecadata"},
+				},
+			},
+			Credentials: map[string]cloud.Credential{
+				clientconfig.InClusterName: cloud.NewCredential(cloud.OAuth2AuthType, map[string]string{"Token": "token"}),
+			},
+		}, nil
+	})
+	ctx, err := s.runCommand(c, nil, cmd, "myk8s", "--local", "--in-cluster")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, `k8s substrate "in-cluster" added as cloud "myk8s"`)
+	c.Assert(s.initialCloudMap["myk8s"].Endpoint, gc.Equals, "https://10.0.0.1:443")
+	c.Assert(s.initialCloudMap["myk8s"].AuthTypes, jc.DeepEquals, cloud.AuthTypes{cloud.OAuth2AuthType})
+	c.Assert(s.initialCloudMap["myk8s"].CACertificates, jc.DeepEquals, []string{"fakecadata"})
+}
@@ -32,11 +32,17 @@ func NewAddCAASCommandForTest(
 		brokerGetter:              brokerGetter,
 		k8sCluster:                k8sCluster,
 		newClientConfigReader:     newClientConfigReaderFunc,
+		newInClusterClientConfig:  clientconfig.NewInClusterClientConfig,
+		k8sContextNames:           clientconfig.K8sContextNames,
 		getAllCloudDetails:        getAllCloudDetails,
 	}
 	return cmd
 }
 
+func SetNewInClusterClientConfig(c cmd.Command, f func() (*clientconfig.ClientConfig, error)) {
+	c.(*AddCAASCommand).newInClusterClientConfig = f
+}
+
 func NewRemoveCAASCommandForTest(
 	cloudMetadataStore CloudMetadataStore,
 	store jujuclient.ClientStore,
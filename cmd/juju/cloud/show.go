@@ -17,6 +17,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/jujuclient"
 )
 
@@ -26,7 +27,8 @@ type showCloudCommand struct {
 
 	CloudName string
 
-	includeConfig bool
+	includeConfig       bool
+	includeCapabilities bool
 
 	// Used when querying a controller for its cloud details
 	controllerName   string
@@ -42,6 +44,10 @@ options.
 If ‘--include-config’ is used, additional configuration (key, type, and
 description) specific to the cloud are displayed if available.
 
+If ‘--capabilities’ is used, the optional features supported by the
+cloud's provider, such as spaces, availability zones, load balancers and
+native storage, are also displayed.
+
 The current controller is used unless the --controller option is specified.
 
 If --local is specified, Juju shows the cloud from internal cache.
@@ -52,6 +58,7 @@ Examples:
     juju show-cloud azure-china --output ~/azure_cloud_details.txt
     juju show-cloud myopenstack --controller mycontroller
     juju show-cloud myopenstack --local
+    juju show-cloud aws --capabilities
 
 See also:
     clouds
@@ -60,6 +67,7 @@ See also:
 
 type showCloudAPI interface {
 	Cloud(tag names.CloudTag) (jujucloud.Cloud, error)
+	CloudCapabilities(tag names.CloudTag) ([]string, bool, error)
 	Close() error
 }
 
@@ -89,6 +97,7 @@ func (c *showCloudCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml": cmd.FormatYaml,
 	})
 	f.BoolVar(&c.includeConfig, "include-config", false, "Print available config option details specific to the specified cloud")
+	f.BoolVar(&c.includeCapabilities, "capabilities", false, "Print the optional features supported by the specified cloud")
 }
 
 func (c *showCloudCommand) Init(args []string) error {
@@ -129,6 +138,7 @@ func (c *showCloudCommand) Run(ctxt *cmd.Context) error {
 		return err
 	}
 
+	cloudType := cloud.CloudType
 	displayCloud := cloud
 	displayCloud.CloudType = displayCloudType(displayCloud.CloudType)
 	if err := c.out.Write(ctxt, displayCloud); err != nil {
@@ -140,12 +150,56 @@ func (c *showCloudCommand) Run(ctxt *cmd.Context) error {
 			fmt.Fprintln(
 				ctxt.Stdout,
 				fmt.Sprintf("\nThe available config options specific to %s clouds are:", displayCloud.CloudType))
-			return c.out.Write(ctxt, config)
+			if err := c.out.Write(ctxt, config); err != nil {
+				return err
+			}
 		}
 	}
+	if c.includeCapabilities {
+		capabilities, reported, err := c.getCapabilities(cloudType)
+		if err != nil {
+			return err
+		}
+		if !reported {
+			fmt.Fprintf(ctxt.Stdout, "\nThe %s provider does not report its capabilities.\n", displayCloud.CloudType)
+			return nil
+		}
+		fmt.Fprintf(ctxt.Stdout, "\nThe optional features supported by %s clouds are:\n", displayCloud.CloudType)
+		if len(capabilities) == 0 {
+			capabilities = []string{}
+		}
+		return c.out.Write(ctxt, capabilities)
+	}
 	return nil
 }
 
+// getCapabilities returns the optional features supported by the
+// cloud, asking the controller if there is one. Controllers too old
+// to report capabilities run the same providers as the client, so the
+// client's own providers are consulted instead.
+func (c *showCloudCommand) getCapabilities(cloudType string) ([]string, bool, error) {
+	if c.controllerName != "" {
+		api, err := c.showCloudAPIFunc(c.controllerName)
+		if err != nil {
+			return nil, false, err
+		}
+		defer api.Close()
+		capabilities, reported, err := api.CloudCapabilities(names.NewCloudTag(c.CloudName))
+		if !errors.IsNotSupported(err) {
+			return capabilities, reported, err
+		}
+	}
+	capabilities, reported, err := environs.ProviderCapabilities(cloudType)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	result := make([]string, len(capabilities))
+	for i, capability := range capabilities {
+		result[i] = string(capability)
+	}
+	return result, reported, nil
+}
+
 func (c *showCloudCommand) getControllerCloud() (*CloudDetails, error) {
 	api, err := c.showCloudAPIFunc(c.controllerName)
 	if err != nil {
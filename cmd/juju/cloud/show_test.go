@@ -142,6 +142,65 @@ regions:
 `[1:])
 }
 
+func (s *showSuite) TestShowLocalWithCapabilities(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, cloud.NewShowCloudCommand(), "aws-china", "--local", "--capabilities")
+	c.Assert(err, jc.ErrorIsNil)
+	out := cmdtesting.Stdout(ctx)
+	c.Assert(out, jc.HasSuffix, `
+The optional features supported by ec2 clouds are:
+- availability-zones
+- block-storage
+- dns-zones
+- load-balancers
+- spaces
+`)
+}
+
+func (s *showSuite) TestShowControllerCloudWithCapabilities(c *gc.C) {
+	s.api.cloud = jujucloud.Cloud{
+		Name:      "beehive",
+		Type:      "openstack",
+		AuthTypes: []jujucloud.AuthType{"userpass"},
+	}
+	s.api.capabilities = []string{"availability-zones", "block-storage"}
+	cmd := cloud.NewShowCloudCommandForTest(
+		s.store,
+		func(controllerName string) (cloud.ShowCloudAPI, error) {
+			return s.api, nil
+		})
+	ctx, err := cmdtesting.RunCommand(c, cmd, "beehive", "--capabilities")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Cloud", "Close", "CloudCapabilities", "Close")
+	s.api.CheckCall(c, 2, "CloudCapabilities", names.NewCloudTag("beehive"))
+	out := cmdtesting.Stdout(ctx)
+	c.Assert(out, gc.Equals, `
+defined: public
+type: openstack
+auth-types: [userpass]
+
+The optional features supported by openstack clouds are:
+- availability-zones
+- block-storage
+`[1:])
+}
+
+func (s *showSuite) TestShowControllerCloudCapabilitiesNotReported(c *gc.C) {
+	s.api.cloud = jujucloud.Cloud{
+		Name:      "beehive",
+		Type:      "manual",
+		AuthTypes: []jujucloud.AuthType{"empty"},
+	}
+	cmd := cloud.NewShowCloudCommandForTest(
+		s.store,
+		func(controllerName string) (cloud.ShowCloudAPI, error) {
+			return s.api, nil
+		})
+	ctx, err := cmdtesting.RunCommand(c, cmd, "beehive", "--capabilities")
+	c.Assert(err, jc.ErrorIsNil)
+	out := cmdtesting.Stdout(ctx)
+	c.Assert(out, jc.HasSuffix, "\nThe manual provider does not report its capabilities.\n")
+}
+
 func (s *showSuite) TestShowWithConfig(c *gc.C) {
 	data := `
 clouds:
@@ -343,7 +402,8 @@ func (s *showSuite) TestShowWithCACertificate(c *gc.C) {
 
 type fakeShowCloudAPI struct {
 	jujutesting.Stub
-	cloud jujucloud.Cloud
+	cloud        jujucloud.Cloud
+	capabilities []string
 }
 
 func (api *fakeShowCloudAPI) Close() error {
@@ -355,3 +415,8 @@ func (api *fakeShowCloudAPI) Cloud(tag names.CloudTag) (jujucloud.Cloud, error)
 	api.AddCall("Cloud", tag)
 	return api.cloud, api.NextErr()
 }
+
+func (api *fakeShowCloudAPI) CloudCapabilities(tag names.CloudTag) ([]string, bool, error) {
+	api.AddCall("CloudCapabilities", tag)
+	return api.capabilities, api.capabilities != nil, api.NextErr()
+}
@@ -57,6 +57,9 @@ var usageBootstrapDetails = `
 Used without arguments, bootstrap will step you through the process of
 initializing a Juju cloud environment. Initialization consists of creating
 a 'controller' model and provisioning a machine to act as controller.
+Where the cloud can say which instance types and network spaces it has,
+you are offered those suitable for the controller machine, and your
+choices are used as bootstrap constraints.
 
 We recommend you call your controller ‘username-region’ e.g. ‘fred-us-east-1’
 See --clouds for a list of clouds and credentials.
@@ -187,6 +190,9 @@ type bootstrapCommand struct {
 	noGUI               bool
 	noSwitch            bool
 	interactive         bool
+
+	// scanner reads the answers to interactive questions.
+	scanner *bufio.Scanner
 }
 
 func (c *bootstrapCommand) Info() *cmd.Info {
@@ -634,6 +640,12 @@ to create a new model to deploy k8s workloads.
 		return errors.Trace(err)
 	}
 
+	if c.interactive {
+		if err := c.runInteractiveProbe(ctx, environ, cloudCallCtx); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	hostedModel, err = c.initializeHostedModel(
 		isCAASController, config, store, environ, &bootstrapParams,
 	)
@@ -1188,11 +1200,13 @@ func (c *bootstrapCommand) bootstrapConfigs(
 	if err != nil {
 		return bootstrapConfigs{}, errors.Annotate(err, "constructing controller config")
 	}
-	if controllerConfig.AutocertDNSName() != "" {
+	if controllerConfig.AutocertDNSName() != "" &&
+		controllerConfig.AutocertChallenge() == controller.AutocertChallengeTLSALPN01 {
 		if _, ok := controllerConfigAttrs[controller.APIPort]; !ok {
 			// The configuration did not explicitly mention the API port,
-			// so default to 443 because it is not usually possible to
-			// obtain autocert certificates without listening on port 443.
+			// so default to 443 because it is not possible to answer
+			// tls-alpn-01 challenges without listening on port 443.
+			// The http-01 challenge is answered on port 80 instead.
 			controllerConfig[controller.APIPort] = 443
 		}
 	}
@@ -1249,7 +1263,8 @@ func (c *bootstrapCommand) hostedModelConfig(
 // runInteractive queries the user about bootstrap config interactively at the
 // command prompt.
 func (c *bootstrapCommand) runInteractive(ctx *cmd.Context) error {
-	scanner := bufio.NewScanner(ctx.Stdin)
+	c.scanner = bufio.NewScanner(ctx.Stdin)
+	scanner := c.scanner
 	clouds, err := assembleClouds()
 	if err != nil {
 		return errors.Trace(err)
@@ -1285,6 +1300,64 @@ func (c *bootstrapCommand) runInteractive(ctx *cmd.Context) error {
 	return nil
 }
 
+// runInteractiveProbe asks the cloud what it can offer the controller
+// machine, and lets the user choose from it interactively at the command
+// prompt. The choices are added to the bootstrap constraints.
+func (c *bootstrapCommand) runInteractiveProbe(
+	ctx *cmd.Context,
+	environ environs.BootstrapEnviron,
+	callCtx context.ProviderCallContext,
+) error {
+	if c.scanner == nil {
+		c.scanner = bufio.NewScanner(ctx.Stdin)
+	}
+	chosen := false
+
+	fetcher, ok := environ.(environs.InstanceTypesFetcher)
+	if ok && !c.Constraints.HasInstanceType() && !c.BootstrapConstraints.HasInstanceType() {
+		result, err := fetcher.InstanceTypes(callCtx, constraints.Value{})
+		if err != nil {
+			logger.Warningf("cannot list instance types for %s: %v", c.Cloud, err)
+		} else {
+			instType, err := queryInstanceType(result.InstanceTypes, c.scanner, ctx.Stdout)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if instType != "" {
+				c.BootstrapConstraints.InstanceType = &instType
+				chosen = true
+			}
+		}
+	}
+
+	if !c.Constraints.HasSpaces() && !c.BootstrapConstraints.HasSpaces() && environs.SupportsSpaces(callCtx, environ) {
+		netEnviron, _ := environs.SupportsNetworking(environ)
+		spaces, err := netEnviron.Spaces(callCtx)
+		if err != nil {
+			logger.Warningf("cannot list spaces for %s: %v", c.Cloud, err)
+		} else {
+			space, err := querySpace(c.Cloud, spaces, c.scanner, ctx.Stdout)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if space != "" {
+				c.BootstrapConstraints.Spaces = &[]string{space}
+				chosen = true
+			}
+		}
+	}
+
+	if chosen {
+		cloudRegion := c.Cloud
+		if c.Region != "" {
+			cloudRegion += "/" + c.Region
+		}
+		fmt.Fprintf(ctx.Stdout, "To bootstrap the same way again, run:\n    juju bootstrap %s %s --bootstrap-constraints %q\n\n",
+			cloudRegion, c.controllerName, c.BootstrapConstraints.String())
+	}
+	return nil
+}
+
 // checkProviderType ensures the provider type is okay.
 func checkProviderType(envType string) error {
 	featureflag.SetFlagsFromEnvironment(osenv.JujuFeatureFlagEnvKey)
@@ -16,6 +16,9 @@ import (
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/juju/interact"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/network"
 )
 
 // assembleClouds
@@ -120,6 +123,105 @@ func queryName(defName string, scanner *bufio.Scanner, w io.Writer) (string, err
 	return name, nil
 }
 
+// controllerMinMem is the least memory, in MiB, an instance type needs
+// to be offered for a controller. It matches the default controller
+// memory constraint.
+const controllerMinMem = 3584
+
+// queryInstanceType asks the user to pick an instance type for the
+// controller from those the cloud offers. Instance types with too little
+// memory for a controller, or which are deprecated, aren't offered. An
+// empty choice leaves the choice to juju.
+func queryInstanceType(instTypes []instances.InstanceType, scanner *bufio.Scanner, w io.Writer) (string, error) {
+	var offered []instances.InstanceType
+	for _, instType := range instTypes {
+		if instType.Deprecated || instType.Mem < controllerMinMem {
+			continue
+		}
+		offered = append(offered, instType)
+	}
+	if len(offered) == 0 {
+		return "", nil
+	}
+	sort.Slice(offered, func(i, j int) bool {
+		a, b := offered[i], offered[j]
+		if a.CpuCores != b.CpuCores {
+			return a.CpuCores < b.CpuCores
+		}
+		if a.Mem != b.Mem {
+			return a.Mem < b.Mem
+		}
+		return a.Name < b.Name
+	})
+
+	fmt.Fprintln(w, "Instance types suitable for a controller:")
+	tw := output.TabWriter(w)
+	fmt.Fprintln(tw, "Name\tCores\tMemory\tArches")
+	names := make([]string, len(offered))
+	for i, instType := range offered {
+		names[i] = instType.Name
+		fmt.Fprintf(tw, "%s\t%d\t%dM\t%s\n",
+			instType.Name, instType.CpuCores, instType.Mem, strings.Join(instType.Arches, ","))
+	}
+	if err := tw.Flush(); err != nil {
+		return "", errors.Trace(err)
+	}
+	fmt.Fprintln(w)
+
+	// add support for a default (empty) selection.
+	names = append(names, "")
+	verify := interact.MatchOptions(names, "Invalid instance type.")
+	instType, err := interact.QueryVerify("Select an instance type for the controller [let juju choose]: ", scanner, w, w, verify)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if instType == "" {
+		return "", nil
+	}
+	name, ok := interact.FindMatch(instType, names)
+	if !ok {
+		// should be impossible
+		return "", errors.Errorf("invalid instance type chosen: %s", instType)
+	}
+	return name, nil
+}
+
+// querySpace asks the user to pick a space, of those the cloud reports,
+// for the controller. An empty choice means any space.
+func querySpace(cloud string, spaces []network.SpaceInfo, scanner *bufio.Scanner, w io.Writer) (string, error) {
+	var names []string
+	for _, space := range spaces {
+		if space.Name != "" {
+			names = append(names, space.Name)
+		}
+	}
+	if len(names) == 0 {
+		return "", nil
+	}
+	sort.Strings(names)
+	// add an empty string to allow for a default value. Also gives us an extra
+	// line return after the list of names.
+	names = append(names, "")
+	fmt.Fprintf(w, "Spaces in %s:\n", cloud)
+	if _, err := fmt.Fprintln(w, strings.Join(names, "\n")); err != nil {
+		return "", errors.Trace(err)
+	}
+	verify := interact.MatchOptions(names, "Invalid space.")
+	space, err := interact.QueryVerify("Select a space for the controller [any]: ", scanner, w, w, verify)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if space == "" {
+		return "", nil
+	}
+	name, ok := interact.FindMatch(space, names)
+	if !ok {
+		// should be impossible
+		return "", errors.Errorf("invalid space chosen: %s", space)
+	}
+	return name, nil
+}
+
 func sortClouds(maps ...map[string]jujucloud.Cloud) []string {
 	var clouds []string
 	for _, m := range maps {
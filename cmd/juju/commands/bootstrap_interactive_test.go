@@ -15,6 +15,8 @@ import (
 	gc "gopkg.in/check.v1"
 
 	jujucloud "github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/version"
 )
 
@@ -177,3 +179,95 @@ func (BSInteractSuite) TestQueryNameDefault(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(name, gc.Equals, "default-cloud")
 }
+
+func (BSInteractSuite) TestQueryInstanceType(c *gc.C) {
+	input := "m1.xlarge\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	instTypes := []instances.InstanceType{
+		{Name: "m1.xlarge", CpuCores: 4, Mem: 15360, Arches: []string{"amd64"}},
+		{Name: "m1.small", CpuCores: 1, Mem: 1740, Arches: []string{"amd64"}},
+		{Name: "m1.large", CpuCores: 2, Mem: 7680, Arches: []string{"amd64"}},
+		{Name: "t1.large", CpuCores: 2, Mem: 8192, Arches: []string{"amd64"}, Deprecated: true},
+	}
+
+	buf := bytes.Buffer{}
+	instType, err := queryInstanceType(instTypes, scanner, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instType, gc.Equals, "m1.xlarge")
+
+	// instance types too small for a controller, or deprecated, should
+	// not be offered, and the rest should be sorted by size.
+	expected := `
+Instance types suitable for a controller:
+Name       Cores  Memory  Arches
+m1.large   2      7680M   amd64
+m1.xlarge  4      15360M  amd64
+
+Select an instance type for the controller [let juju choose]: 
+`[1:]
+	c.Assert(buf.String(), gc.Equals, expected)
+}
+
+func (BSInteractSuite) TestQueryInstanceTypeDefault(c *gc.C) {
+	input := "\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	instTypes := []instances.InstanceType{
+		{Name: "m1.large", CpuCores: 2, Mem: 7680},
+	}
+
+	instType, err := queryInstanceType(instTypes, scanner, ioutil.Discard)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instType, gc.Equals, "")
+}
+
+func (BSInteractSuite) TestQueryInstanceTypeNoneSuitable(c *gc.C) {
+	scanner := bufio.NewScanner(strings.NewReader(""))
+	instTypes := []instances.InstanceType{
+		{Name: "m1.small", CpuCores: 1, Mem: 1740},
+	}
+
+	buf := bytes.Buffer{}
+	instType, err := queryInstanceType(instTypes, scanner, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instType, gc.Equals, "")
+	c.Assert(buf.String(), gc.Equals, "")
+}
+
+func (BSInteractSuite) TestQuerySpace(c *gc.C) {
+	input := "dmz\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	spaces := []network.SpaceInfo{
+		{Name: "internal"},
+		{Name: "dmz"},
+	}
+
+	buf := bytes.Buffer{}
+	space, err := querySpace("goggles", spaces, scanner, &buf)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(space, gc.Equals, "dmz")
+
+	expected := `
+Spaces in goggles:
+dmz
+internal
+
+Select a space for the controller [any]: 
+`[1:]
+	c.Assert(buf.String(), gc.Equals, expected)
+}
+
+func (BSInteractSuite) TestQuerySpaceDefault(c *gc.C) {
+	input := "\n"
+
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	spaces := []network.SpaceInfo{
+		{Name: "internal"},
+	}
+
+	space, err := querySpace("goggles", spaces, scanner, ioutil.Discard)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(space, gc.Equals, "")
+}
@@ -1775,6 +1775,20 @@ func (s *BootstrapSuite) TestBootstrapAutocertDNSNameExplicitAPIPort(c *gc.C) {
 	c.Assert(bootstrap.args.ControllerConfig.APIPort(), gc.Equals, 12345)
 }
 
+func (s *BootstrapSuite) TestBootstrapAutocertHTTPChallengeDefaultPort(c *gc.C) {
+	s.patchVersionAndSeries(c, "raring")
+	var bootstrap fakeBootstrapFuncs
+	s.PatchValue(&getBootstrapFuncs, func() BootstrapInterface {
+		return &bootstrap
+	})
+	cmdtesting.RunCommand(
+		c, s.newBootstrapCommand(), "dummy", "ctrl",
+		"--config", "autocert-dns-name=foo.example",
+		"--config", "autocert-challenge=http-01",
+	)
+	c.Assert(bootstrap.args.ControllerConfig.APIPort(), gc.Equals, 17070)
+}
+
 func (s *BootstrapSuite) TestBootstrapCloudConfigAndAdHoc(c *gc.C) {
 	s.patchVersionAndSeries(c, "raring")
 	_, err := cmdtesting.RunCommand(
@@ -6,6 +6,7 @@ package commands
 import (
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -22,6 +23,7 @@ import (
 	"github.com/juju/juju/api/common"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	corelogger "github.com/juju/juju/core/logger"
 )
 
 // defaultLineCount is the default number of lines to
@@ -50,13 +52,26 @@ The '--include-module' and '--exclude-module' options filter by (dotted)
 logging module name. The module name can be truncated such that all loggers
 with the prefix will match.
 
+The '--include-message' option filters by message, only showing the messages
+which match the given regular expression.
+
+The '--include-label' option filters by the key=value labels given to
+messages logged by charms with juju-log. The labels of a message are shown
+after it.
+
 The filtering options combine as follows:
 * All --include options are logically ORed together.
 * All --exclude options are logically ORed together.
 * All --include-module options are logically ORed together.
 * All --exclude-module options are logically ORed together.
-* The combined --include, --exclude, --include-module and --exclude-module
-  selections are logically ANDed to form the complete filter.
+* All --include-label options are logically ANDed together.
+* The combined --include, --exclude, --include-module, --exclude-module,
+  --include-message and --include-label selections are logically ANDed to
+  form the complete filter.
+
+The filtering is done by the controller, so only the matching messages are
+sent. The number of messages shown by --replay is bounded by the controller,
+so only the most recent 100000 matching messages of a model are replayed.
 
 Examples:
 
@@ -90,6 +105,14 @@ machine-3 or machine-4, and then stop:
         --exclude machine-3 \
         --exclude machine-4 
 
+Show the messages of the last 20 hook failures of unit mysql/0:
+
+    juju debug-log --include mysql/0 --include-message "hook failed" --lines 20
+
+Show the messages logged by charms with the label stage=dump:
+
+    juju debug-log --replay --include-label stage=dump
+
 To see all WARNING and ERROR messages and then continue showing any
 new WARNING and ERROR messages as they are logged:
 
@@ -121,6 +144,7 @@ type debugLogCommand struct {
 	modelcmd.ModelCommandBase
 
 	level  string
+	labels []string
 	params common.DebugLogParams
 
 	utc      bool
@@ -144,6 +168,8 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeEntity), "exclude", "Do not show log messages for these entities")
 	f.Var(cmd.NewAppendStringsValue(&c.params.IncludeModule), "include-module", "Only show log messages for these logging modules")
 	f.Var(cmd.NewAppendStringsValue(&c.params.ExcludeModule), "exclude-module", "Do not show log messages for these logging modules")
+	f.StringVar(&c.params.IncludeMessage, "include-message", "", "Only show log messages matching this regular expression")
+	f.Var(cmd.NewAppendStringsValue(&c.labels), "include-label", "Only show log messages with these key=value labels")
 
 	f.StringVar(&c.level, "l", "", "Log level to show, one of [TRACE, DEBUG, INFO, WARNING, ERROR]")
 	f.StringVar(&c.level, "level", "", "")
@@ -172,6 +198,24 @@ func (c *debugLogCommand) Init(args []string) error {
 		}
 		c.params.Level = level
 	}
+	if c.params.IncludeMessage != "" {
+		if _, err := regexp.Compile(c.params.IncludeMessage); err != nil {
+			return errors.Annotate(err, "invalid --include-message")
+		}
+	}
+	for _, label := range c.labels {
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) != 2 {
+			return errors.Errorf("expected key=value label, got %q", label)
+		}
+		if err := corelogger.ValidateLabel(parts[0], parts[1]); err != nil {
+			return errors.Trace(err)
+		}
+		if c.params.IncludeLabels == nil {
+			c.params.IncludeLabels = make(map[string]string)
+		}
+		c.params.IncludeLabels[parts[0]] = parts[1]
+	}
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
@@ -296,5 +340,5 @@ func (c *debugLogCommand) writeLogRecord(w *ansiterm.Writer, r common.LogMessage
 	if c.location {
 		loggocolor.LocationColor.Fprintf(w, "%s ", r.Location)
 	}
-	fmt.Fprintln(w, r.Message)
+	fmt.Fprintln(w, corelogger.FormatMessage(r.Message, r.Labels))
 }
@@ -89,6 +89,27 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				ExcludeModule: []string{"juju.foo", "unit"},
 				Backlog:       10,
 			},
+		}, {
+			args: []string{"--include-message", "hook failed"},
+			expected: common.DebugLogParams{
+				IncludeMessage: "hook failed",
+				Backlog:        10,
+			},
+		}, {
+			args:     []string{"--include-message", "fail(ed"},
+			errMatch: `invalid --include-message: error parsing regexp: .*`,
+		}, {
+			args: []string{"--include-label", "stage=dump", "--include-label", "db=main"},
+			expected: common.DebugLogParams{
+				IncludeLabels: map[string]string{"stage": "dump", "db": "main"},
+				Backlog:       10,
+			},
+		}, {
+			args:     []string{"--include-label", "stage"},
+			errMatch: `expected key=value label, got "stage"`,
+		}, {
+			args:     []string{"--include-label", "1stage=dump"},
+			errMatch: `label key "1stage" not valid`,
 		}, {
 			args: []string{"--replay"},
 			expected: common.DebugLogParams{
@@ -184,6 +205,25 @@ func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 		"machine-0: 14:15:23 INFO test.module somefile.go:123 this is the log output\n")
 }
 
+func (s *DebugLogSuite) TestLogOutputWithLabels(c *gc.C) {
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return &fakeDebugLogAPI{log: []common.LogMessage{
+			{
+				Entity:    "unit-mysql-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 23, 345000000, time.UTC),
+				Severity:  "INFO",
+				Module:    "unit.mysql/0.juju-log",
+				Message:   "backup started",
+				Labels:    map[string]string{"stage": "dump", "db": "main"},
+			},
+		}}, nil
+	})
+	ctx, err := cmdtesting.RunCommand(c, newDebugLogCommandTZ(jujuclienttesting.MinimalStore(), time.UTC))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals,
+		"unit-mysql-0: 08:15:23 INFO unit.mysql/0.juju-log backup started [labels: db=main stage=dump]\n")
+}
+
 type fakeDebugLogAPI struct {
 	log    []common.LogMessage
 	params common.DebugLogParams
@@ -38,6 +38,7 @@ import (
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/cmd/juju/metricsdebug"
 	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/cmd/juju/plugins"
 	"github.com/juju/juju/cmd/juju/resource"
 	rcmd "github.com/juju/juju/cmd/juju/romulus/commands"
 	"github.com/juju/juju/cmd/juju/setmeterstatus"
@@ -253,6 +254,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewConsumeCommand())
 	r.Register(application.NewSuspendRelationCommand())
 	r.Register(application.NewResumeRelationCommand())
+	r.Register(application.NewSetRelationDrainTimeoutCommand())
+	r.Register(application.NewShowRelationCommand())
 
 	// Firewall rule commands.
 	r.Register(firewall.NewSetFirewallRuleCommand())
@@ -268,6 +271,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(status.NewTimelineCommand())
+	r.Register(status.NewCrashReportsCommand())
 
 	// Error resolution and debugging commands.
 	r.Register(newDefaultRunCommand(nil))
@@ -295,6 +300,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	// Manage backups.
 	r.Register(backups.NewCreateCommand())
 	r.Register(backups.NewDownloadCommand())
+	r.Register(backups.NewInspectCommand())
 	r.Register(backups.NewShowCommand())
 	r.Register(backups.NewListCommand())
 	r.Register(backups.NewRemoveCommand())
@@ -334,6 +340,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewConfigCommand())
 	r.Register(model.NewDefaultsCommand())
 	r.Register(model.NewRetryProvisioningCommand())
+	r.Register(model.NewFindOrphansCommand())
+	r.Register(model.NewAdviseCommand())
 	r.Register(model.NewDestroyCommand())
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
@@ -349,6 +357,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	r.Register(newMigrateCommand())
 	r.Register(model.NewExportBundleCommand())
+	r.Register(model.NewExportModelCommand())
+	r.Register(model.NewImportModelCommand())
 
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
@@ -361,6 +371,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewShowOutputCommand())
 	r.Register(action.NewListCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewDebugAgentCommand())
 
 	// Manage controller availability
 	r.Register(newEnableHACommand())
@@ -375,6 +386,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewApplicationSetConstraintsCommand())
 	r.Register(application.NewBundleDiffCommand())
 	r.Register(application.NewShowApplicationCommand())
+	r.Register(application.NewRefreshPolicyCommand())
+	r.Register(application.NewAutoscaleCommand())
+	r.Register(application.NewPauseCommand())
+	r.Register(application.NewResumeCommand())
+	r.Register(application.NewBlueGreenCommand())
 
 	// Operation protection commands
 	r.Register(block.NewDisableCommand())
@@ -392,6 +408,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
+	r.Register(storage.NewRetryStorageCommandWithAPI())
 	r.Register(storage.NewImportFilesystemCommand(storage.NewStorageImporter, nil))
 
 	// Manage spaces
@@ -423,6 +440,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
+	r.Register(controller.NewSessionsCommand())
+	r.Register(controller.NewRevokeSessionCommand())
+	r.Register(controller.NewControllerSchemaCommand())
+	r.Register(controller.NewQuotasCommand())
+	r.Register(controller.NewSetQuotaCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -448,6 +470,11 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCloudCommand())
 	r.Register(model.NewRevokeCloudCommand())
 
+	// Manage plugins
+	r.Register(plugins.NewListPluginsCommand())
+	r.Register(plugins.NewInstallPluginCommand())
+	r.Register(plugins.NewRemovePluginCommand())
+
 	// CAAS commands
 	r.Register(caas.NewAddCAASCommand(&cloudToCommandAdapter{}))
 	r.Register(caas.NewRemoveCAASCommand(&cloudToCommandAdapter{}))
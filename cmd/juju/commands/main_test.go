@@ -424,13 +424,16 @@ var commandNames = []string{
 	"add-subnet",
 	"add-unit",
 	"add-user",
+	"advise",
 	"agree",
 	"agreements",
 	"attach",
 	"attach-resource",
 	"attach-storage",
 	"autoload-credentials",
+	"autoscale",
 	"backups",
+	"bluegreen",
 	"bootstrap",
 	"budget",
 	"cached-images",
@@ -443,11 +446,14 @@ var commandNames = []string{
 	"config",
 	"consume",
 	"controller-config",
+	"controller-schema",
 	"controllers",
+	"crash-reports",
 	"create-backup",
 	"create-storage-pool",
 	"create-wallet",
 	"credentials",
+	"debug-agent",
 	"debug-hooks",
 	"debug-log",
 	"deploy",
@@ -464,8 +470,10 @@ var commandNames = []string{
 	"enable-ha",
 	"enable-user",
 	"export-bundle",
+	"export-model",
 	"expose",
 	"find-offers",
+	"find-orphans",
 	"firewall-rules",
 	"get-constraints",
 	"get-model-constraints",
@@ -477,7 +485,10 @@ var commandNames = []string{
 	"hook-tool",
 	"hook-tools",
 	"import-filesystem",
+	"import-model",
 	"import-ssh-key",
+	"inspect-backup",
+	"install-plugin",
 	"kill-controller",
 	"list-actions",
 	"list-agreements",
@@ -494,6 +505,7 @@ var commandNames = []string{
 	"list-offers",
 	"list-payloads",
 	"list-plans",
+	"list-plugins",
 	"list-regions",
 	"list-resources",
 	"list-spaces",
@@ -514,9 +526,13 @@ var commandNames = []string{
 	"models",
 	"offer",
 	"offers",
+	"pause",
 	"payloads",
 	"plans",
+	"plugins",
+	"quotas",
 	"regions",
+	"refresh-policy",
 	"register",
 	"relate", //alias for add-relation
 	"reload-spaces",
@@ -529,6 +545,7 @@ var commandNames = []string{
 	"remove-k8s",
 	"remove-machine",
 	"remove-offer",
+	"remove-plugin",
 	"remove-relation",
 	"remove-saas",
 	"remove-ssh-key",
@@ -540,14 +557,18 @@ var commandNames = []string{
 	"resolve",
 	"resources",
 	"restore-backup",
+	"resume",
 	"resume-relation",
 	"retry-provisioning",
+	"retry-storage",
 	"revoke",
 	"revoke-cloud",
+	"revoke-session",
 	"run",
 	"run-action",
 	"scale-application",
 	"scp",
+	"sessions",
 	"set-credential",
 	"set-constraints",
 	"set-default-credential",
@@ -556,6 +577,8 @@ var commandNames = []string{
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
+	"set-quota",
+	"set-relation-drain-timeout",
 	"set-series",
 	"set-wallet",
 	"show-action-output",
@@ -569,6 +592,7 @@ var commandNames = []string{
 	"show-machine",
 	"show-model",
 	"show-offer",
+	"show-relation",
 	"show-status",
 	"show-status-log",
 	"show-storage",
@@ -586,6 +610,7 @@ var commandNames = []string{
 	"switch",
 	"sync-agent-binaries",
 	"sync-tools",
+	"timeline",
 	"trust",
 	"unexpose",
 	"unregister",
@@ -6,6 +6,7 @@ package commands
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 	"gopkg.in/macaroon.v2-unstable"
 
@@ -28,6 +29,8 @@ type migrateCommand struct {
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
 	targetController string
+	targetRegion     string
+	copy             bool
 }
 
 type migrateAPI interface {
@@ -57,6 +60,23 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+The --region option moves the model to another region of its cloud,
+which the target controller must also manage. The model's machines
+are provisioned again in that region from images of their instances,
+and the instances in the original region are stopped once the
+migration has succeeded. Models with volumes or manually provisioned
+machines can't be moved to another region.
+
+With --copy, the model is left running in its original region and
+controller, and a copy of it is made in the new region. A model can
+only be copied to another region.
+
+Examples:
+
+    juju migrate mymodel other-controller
+    juju migrate mymodel other-controller --region us-west-2
+    juju migrate mymodel other-controller --region us-west-2 --copy
+
 See also:
     login
     controllers
@@ -73,6 +93,13 @@ func (c *migrateCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.targetRegion, "region", "", "Move the model to another region of its cloud")
+	f.BoolVar(&c.copy, "copy", false, "Copy the model to another region, leaving the original running")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -85,6 +112,10 @@ func (c *migrateCommand) Init(args []string) error {
 		return errors.New("too many arguments specified")
 	}
 
+	if c.copy && c.targetRegion == "" {
+		return errors.New("--copy requires --region")
+	}
+
 	c.SetModelName(args[0], false)
 	c.targetController = args[1]
 	return nil
@@ -119,6 +150,8 @@ func (c *migrateCommand) getMigrationSpec() (*controller.MigrationSpec, error) {
 		TargetUser:           accountInfo.User,
 		TargetPassword:       accountInfo.Password,
 		TargetMacaroons:      macs,
+		TargetRegion:         c.targetRegion,
+		Copy:                 c.copy,
 	}, nil
 }
 
@@ -156,6 +156,27 @@ func (s *MigrateSuite) TestSuccess(c *gc.C) {
 	})
 }
 
+func (s *MigrateSuite) TestSuccessToRegion(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "target", "--region", "us-west-2", "--copy")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+		TargetRegion:         "us-west-2",
+		Copy:                 true,
+	})
+}
+
+func (s *MigrateSuite) TestCopyWithoutRegion(c *gc.C) {
+	_, err := s.makeAndRun(c, "model", "target", "--copy")
+	c.Assert(err, gc.ErrorMatches, "--copy requires --region")
+}
+
 func (s *MigrateSuite) TestSuccessMacaroons(c *gc.C) {
 	err := s.store.UpdateAccount("target", jujuclient.AccountDetails{
 		User:     "targetuser",
@@ -8,22 +8,21 @@ import (
 	"io/ioutil"
 	"os"
 	"os/exec"
-	"path/filepath"
-	"regexp"
-	"sort"
 	"strings"
 	"syscall"
 
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/utils"
 	"github.com/juju/utils/set"
 
+	"github.com/juju/juju/cmd/juju/plugins"
 	"github.com/juju/juju/juju/osenv"
+	jujuversion "github.com/juju/juju/version"
 )
 
-const JujuPluginPrefix = "juju-"
-const JujuPluginPattern = "^juju-[a-zA-Z]"
+const JujuPluginPrefix = plugins.Prefix
 
 var jujuArgNames = set.NewStrings("-m", "--model", "-c", "--controller")
 
@@ -35,6 +34,10 @@ func extractJujuArgs(args []string) []string {
 	for nextArg := 0; nextArg < nrArgs; {
 		arg := args[nextArg]
 		nextArg++
+		if i := strings.Index(arg, "="); i > 0 && jujuArgNames.Contains(arg[:i]) {
+			jujuArgs = append(jujuArgs, arg)
+			continue
+		}
 		if !jujuArgNames.Contains(arg) {
 			continue
 		}
@@ -48,8 +51,19 @@ func extractJujuArgs(args []string) []string {
 }
 
 func RunPlugin(ctx *cmd.Context, subcommand string, args []string) error {
+	found, err := plugins.Lookup(subcommand)
+	if errors.IsNotFound(err) {
+		return &cmd.UnrecognizedCommand{Name: subcommand}
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if found.Manifest != nil {
+		if err := found.Manifest.CheckCompatible(jujuversion.Current); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	cmdName := JujuPluginPrefix + subcommand
-	plugin := &PluginCommand{name: cmdName}
+	plugin := &PluginCommand{name: found.Path}
 
 	// We process common flags supported by Juju commands.
 	// To do this, we extract only those supported flags from the
@@ -64,7 +78,7 @@ func RunPlugin(ctx *cmd.Context, subcommand string, args []string) error {
 	if err := plugin.Init(args); err != nil {
 		return err
 	}
-	err := plugin.Run(ctx)
+	err = plugin.Run(ctx)
 	_, execError := err.(*exec.Error)
 	// exec.Error results are for when the executable isn't found, in
 	// those cases, drop through.
@@ -106,7 +120,10 @@ func (c *PluginCommand) SetFlags(f *gnuflag.FlagSet) {
 func (c *PluginCommand) Run(ctx *cmd.Context) error {
 	command := exec.Command(c.name, c.args...)
 
+	// Plugins share the client store with juju, wherever juju
+	// has found it.
 	env := os.Environ()
+	env = utils.Setenv(env, osenv.JujuXDGDataHomeEnvKey+"="+osenv.JujuXDGDataHomeDir())
 	if c.controllerName != "" {
 		env = utils.Setenv(env, osenv.JujuControllerEnvKey+"="+c.controllerName)
 	}
@@ -140,66 +157,53 @@ type PluginDescription struct {
 // the plugins are run in parallel, so the function should only take as long
 // as the longest call.
 func GetPluginDescriptions() []PluginDescription {
-	plugins := findPlugins()
+	found := plugins.Find()
 	results := []PluginDescription{}
-	if len(plugins) == 0 {
+	if len(found) == 0 {
 		return results
 	}
 	// create a channel with enough backing for each plugin
-	description := make(chan PluginDescription, len(plugins))
+	description := make(chan PluginDescription, len(found))
 
 	// exec the command, and wait only for the timeout before killing the process
-	for _, plugin := range plugins {
-		go func(plugin string) {
-			result := PluginDescription{name: plugin}
+	for _, plugin := range found {
+		go func(plugin plugins.Plugin) {
+			result := PluginDescription{name: plugin.Name}
 			defer func() {
 				description <- result
 			}()
-			desccmd := exec.Command(plugin, "--description")
+			desccmd := exec.Command(plugin.Path, "--description")
 			output, err := desccmd.CombinedOutput()
 
 			if err == nil {
 				// trim to only get the first line
 				result.description = strings.SplitN(string(output), "\n", 2)[0]
 			} else {
-				result.description = fmt.Sprintf("error occurred running '%s --description'", plugin)
-				logger.Errorf("'%s --description': %s", plugin, err)
+				result.description = fmt.Sprintf("error occurred running '%s%s --description'", JujuPluginPrefix, plugin.Name)
+				logger.Errorf("'%s --description': %s", plugin.Path, err)
 			}
 		}(plugin)
 	}
 	resultMap := map[string]PluginDescription{}
 	// gather the results at the end
-	for range plugins {
+	for range found {
 		result := <-description
 		resultMap[result.name] = result
 	}
-	// plugins array is already sorted, use this to get the results in order
-	for _, plugin := range plugins {
-		// Strip the 'juju-' off the start of the plugin name in the results
-		result := resultMap[plugin]
-		result.name = result.name[len(JujuPluginPrefix):]
-		results = append(results, result)
+	// found is already sorted, use this to get the results in order
+	for _, plugin := range found {
+		results = append(results, resultMap[plugin.Name])
 	}
 	return results
 }
 
-// findPlugins searches the current PATH for executable files that match
-// JujuPluginPattern.
+// findPlugins returns the names of the plugin executables found in
+// the plugin install directory, on the PATH and in snaps.
 func findPlugins() []string {
-	re := regexp.MustCompile(JujuPluginPattern)
-	path := os.Getenv("PATH")
-	plugins := []string{}
-	for _, name := range filepath.SplitList(path) {
-		entries, err := ioutil.ReadDir(name)
-		if err != nil {
-			continue
-		}
-		for _, entry := range entries {
-			if re.Match([]byte(entry.Name())) && (entry.Mode()&0111) != 0 {
-				plugins = append(plugins, entry.Name())
-			}
-		}
+	found := plugins.Find()
+	names := make([]string, len(found))
+	for i, plugin := range found {
+		names[i] = JujuPluginPrefix + plugin.Name
 	}
-	sort.Strings(plugins)
-	return plugins
+	return names
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"runtime"
 	"text/template"
 	"time"
@@ -18,6 +19,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/testing"
@@ -198,6 +200,39 @@ func (suite *PluginSuite) TestJujuControllerEnvVars(c *gc.C) {
 	c.Assert(output, gc.Matches, expectedDebug)
 }
 
+func (suite *PluginSuite) TestJujuModelEnvVarsWithEquals(c *gc.C) {
+	suite.setupClientStore(c)
+	suite.makeFullPlugin(PluginParams{Name: "foo"})
+	output := badrun(c, 0, "foo", "--model=mymodel", "-p", "pluginarg")
+	expectedDebug := "foo --model=mymodel -p pluginarg\nmodel is:  mymodel\n"
+	c.Assert(output, gc.Matches, expectedDebug)
+}
+
+func (suite *PluginSuite) TestRunInstalledPlugin(c *gc.C) {
+	dir := osenv.JujuXDGDataHomePath("plugins")
+	err := os.MkdirAll(dir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dir, "juju-foo"), []byte("#!/bin/bash --norc\necho installed $*"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	suite.makeWorkingPlugin("foo", 0755)
+
+	ctx := cmdtesting.Context(c)
+	err = RunPlugin(ctx, "foo", []string{"some params"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "installed some params\n")
+}
+
+func (suite *PluginSuite) TestRunPluginNeedsNewerJuju(c *gc.C) {
+	suite.makeWorkingPlugin("foo", 0755)
+	manifest := "name: foo\nversion: 1.0.0\nmin-juju-version: 99.0.0\n"
+	err := ioutil.WriteFile(gitjujutesting.HomePath("juju-foo.yaml"), []byte(manifest), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := cmdtesting.Context(c)
+	err = RunPlugin(ctx, "foo", nil)
+	c.Assert(err, gc.ErrorMatches, `plugin "foo" needs juju 99.0.0 or later, this is juju .*`)
+}
+
 func (suite *PluginSuite) makePlugin(fullName, script string, perm os.FileMode) {
 	filename := gitjujutesting.HomePath(fullName)
 	content := fmt.Sprintf("#!/bin/bash --norc\n%s", script)
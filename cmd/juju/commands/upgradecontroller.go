@@ -46,8 +46,13 @@ The command will abort if an upgrade is in progress. It will also abort if
 a previous upgrade was not fully completed (e.g.: if one of the
 controllers in a high availability model failed to upgrade).
 
+The '--precheck' option reports whether the controller is ready to
+upgrade, including checks of the controller database and of any model
+migrations in progress, without upgrading it.
+
 Examples:
     juju upgrade-controller --dry-run
+    juju upgrade-controller --precheck
     juju upgrade-controller --agent-version 2.0.1
     
 See also: 
@@ -205,6 +210,9 @@ func (c *upgradeControllerCommand) upgradeCAASController(ctx *cmd.Context) error
 	if warnCompat {
 		fmt.Fprintf(ctx.Stderr, "version %s incompatible with this client (%s)\n", context.chosen, jujuversion.Current)
 	}
+	if c.Precheck {
+		return c.reportUpgradePrecheck(ctx, client, context.chosen)
+	}
 	if c.DryRun {
 		fmt.Fprintf(ctx.Stderr, "%s\n", c.upgradeMessage)
 		return nil
@@ -281,6 +289,9 @@ func (c *upgradeControllerCommand) upgradeIAASController(ctx *cmd.Context) error
 	if c.DryRun {
 		args = append(args, "--dry-run")
 	}
+	if c.Precheck {
+		args = append(args, "--precheck")
+	}
 	if c.IgnoreAgentVersions {
 		args = append(args, "--ignore-agent-versions")
 	}
@@ -10,13 +10,16 @@ import (
 	"io"
 	"os"
 	"path"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/os/series"
 	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
 
 	apicontroller "github.com/juju/juju/api/controller"
 	"github.com/juju/juju/api/modelconfig"
@@ -24,6 +27,7 @@ import (
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/environs/config"
@@ -60,12 +64,29 @@ configured agent stream for that model. It's possible to overwrite this for
 the lifetime of this upgrade using --agent-stream
 If a failed upgrade has been resolved, '--reset-previous-upgrade' can be
 used to allow the upgrade to proceed.
+The '--precheck' option runs the checks the controller makes before an
+upgrade, such as whether the cloud is reachable and whether the new
+version has agent binaries for every machine's series, and reports the
+model's readiness without upgrading it.
+A model's agent version can be pinned with '--pin', so that the model is
+only ever upgraded to that version, until it is unpinned with '--unpin'.
+The '--schedule' option asks the controller to upgrade its hosted models
+in waves, to '--agent-version' or else to the controller's version.
+Waves are separated by ';' and the models in a wave by ','. Each wave
+must finish upgrading within '--wave-timeout', with no machines or units
+in error, before the next wave is started; otherwise the remaining waves
+are not upgraded. The controller model must be upgraded first. Use
+'--show-schedule' to follow the progress of a scheduled upgrade, and
+'--abort-schedule' to stop it.
 Backups are recommended prior to upgrading.
 
 Examples:
     juju upgrade-model --dry-run
+    juju upgrade-model --precheck
     juju upgrade-model --agent-version 2.0.1
     juju upgrade-model --agent-stream proposed
+    juju upgrade-model --pin
+    juju upgrade-model --schedule "staging;prod-a,prod-b" --wave-timeout 30m
     
 See also: 
     sync-agent-binaries`
@@ -106,6 +127,7 @@ type baseUpgradeCommand struct {
 	Version       version.Number
 	BuildAgent    bool
 	DryRun        bool
+	Precheck      bool
 	ResetPrevious bool
 	AssumeYes     bool
 	AgentStream   string
@@ -132,6 +154,7 @@ func (u *baseUpgradeCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&u.AgentStream, "agent-stream", "", "Check this agent stream for upgrades")
 	f.BoolVar(&u.BuildAgent, "build-agent", false, "Build a local version of the agent binary; for development use only")
 	f.BoolVar(&u.DryRun, "dry-run", false, "Don't change anything, just report what would be changed")
+	f.BoolVar(&u.Precheck, "precheck", false, "Don't change anything, just check whether the upgrade can go ahead")
 	f.BoolVar(&u.ResetPrevious, "reset-previous-upgrade", false, "Clear the previous (incomplete) upgrade status (use with care)")
 	f.BoolVar(&u.AssumeYes, "y", false, "Answer 'yes' to confirmation prompts")
 	f.BoolVar(&u.AssumeYes, "yes", false, "")
@@ -236,6 +259,18 @@ type upgradeJujuCommand struct {
 	modelcmd.ModelCommandBase
 	baseUpgradeCommand
 
+	// Pin and Unpin set and remove the model's pinned agent version.
+	Pin   bool
+	Unpin bool
+
+	// Schedule holds the waves of models to upgrade, as parsed by
+	// parseUpgradeSchedule.
+	Schedule      string
+	WaveTimeout   time.Duration
+	ShowSchedule  bool
+	AbortSchedule bool
+	waves         [][]string
+
 	jujuClientAPI jujuClientAPI
 }
 
@@ -251,6 +286,55 @@ func (c *upgradeJujuCommand) Info() *cmd.Info {
 func (c *upgradeJujuCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	c.baseUpgradeCommand.SetFlags(f)
+	f.BoolVar(&c.Pin, "pin", false, "Pin the model's agent version to --agent-version, or to its current version")
+	f.BoolVar(&c.Unpin, "unpin", false, "Remove the model's agent version pin")
+	f.StringVar(&c.Schedule, "schedule", "", "Upgrade the given models in waves separated by ';'")
+	f.DurationVar(&c.WaveTimeout, "wave-timeout", time.Hour, "How long each wave of a scheduled upgrade has to upgrade")
+	f.BoolVar(&c.ShowSchedule, "show-schedule", false, "Show the progress of the scheduled upgrade")
+	f.BoolVar(&c.AbortSchedule, "abort-schedule", false, "Stop the scheduled upgrade")
+}
+
+func (c *upgradeJujuCommand) Init(args []string) error {
+	// Pinning and scheduling don't upgrade the model itself, so they
+	// can't be combined with each other or with the upgrade options.
+	var modes, upgradeFlags []string
+	for flag, set := range map[string]bool{
+		"--pin":            c.Pin,
+		"--unpin":          c.Unpin,
+		"--schedule":       c.Schedule != "",
+		"--show-schedule":  c.ShowSchedule,
+		"--abort-schedule": c.AbortSchedule,
+	} {
+		if set {
+			modes = append(modes, flag)
+		}
+	}
+	for flag, set := range map[string]bool{
+		"--dry-run":                c.DryRun,
+		"--precheck":               c.Precheck,
+		"--build-agent":            c.BuildAgent,
+		"--reset-previous-upgrade": c.ResetPrevious,
+	} {
+		if set {
+			upgradeFlags = append(upgradeFlags, flag)
+		}
+	}
+	if len(modes) > 1 || len(modes) == 1 && len(upgradeFlags) > 0 {
+		conflicting := append(modes, upgradeFlags...)
+		sort.Strings(conflicting)
+		return errors.Errorf("cannot specify %s together", strings.Join(conflicting, " and "))
+	}
+	if c.Schedule != "" {
+		waves, err := parseUpgradeSchedule(c.Schedule)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if c.WaveTimeout <= 0 {
+			return errors.Errorf("--wave-timeout must be positive")
+		}
+		c.waves = waves
+	}
+	return c.baseUpgradeCommand.Init(args)
 }
 
 var (
@@ -304,6 +388,8 @@ type toolsAPI interface {
 type upgradeJujuAPI interface {
 	AbortCurrentUpgrade() error
 	SetModelAgentVersion(version version.Number, ignoreAgentVersion bool) error
+	UpgradePrecheck(version version.Number) ([]params.UpgradePrecheckResult, error)
+	PinModelAgentVersion(version version.Number) error
 	Close() error
 }
 
@@ -320,6 +406,9 @@ type modelConfigAPI interface {
 type controllerAPI interface {
 	ControllerConfig() (controller.Config, error)
 	ModelConfig() (map[string]interface{}, error)
+	StartStagedUpgrade(target version.Number, waves [][]names.ModelTag, waveTimeout time.Duration) error
+	StagedUpgradeStatus() (params.StagedUpgradeStatus, error)
+	AbortStagedUpgrade() error
 	Close() error
 }
 
@@ -357,6 +446,16 @@ func (c *upgradeJujuCommand) getControllerAPI() (controllerAPI, error) {
 
 // Run changes the version proposed for the juju envtools.
 func (c *upgradeJujuCommand) Run(ctx *cmd.Context) (err error) {
+	switch {
+	case c.Pin || c.Unpin:
+		return c.pinModelAgentVersion(ctx)
+	case c.Schedule != "":
+		return c.scheduleUpgrade(ctx)
+	case c.ShowSchedule:
+		return c.showScheduledUpgrade(ctx)
+	case c.AbortSchedule:
+		return c.abortScheduledUpgrade(ctx)
+	}
 	modelType, err := c.ModelType()
 	if err != nil {
 		return errors.Trace(err)
@@ -438,6 +537,9 @@ func (c *upgradeJujuCommand) upgradeCAASModel(ctx *cmd.Context) (err error) {
 	if warnCompat {
 		fmt.Fprintf(ctx.Stderr, "version %s incompatible with this client (%s)\n", context.chosen, jujuversion.Current)
 	}
+	if c.Precheck {
+		return c.reportUpgradePrecheck(ctx, client, context.chosen)
+	}
 	if c.DryRun {
 		fmt.Fprintf(ctx.Stderr, "%s\n", c.upgradeMessage)
 		return nil
@@ -519,7 +621,7 @@ func (c *upgradeJujuCommand) upgradeIAASModel(ctx *cmd.Context) (err error) {
 	// jujud binary if possible.
 	uploadLocalBinary := isControllerModel && packagedAgentErr != nil && tryImplicit
 	if !warnCompat && (uploadLocalBinary || c.BuildAgent) {
-		if err := context.uploadTools(client, c.BuildAgent, agentVersion, c.DryRun); err != nil {
+		if err := context.uploadTools(client, c.BuildAgent, agentVersion, c.DryRun || c.Precheck); err != nil {
 			return block.ProcessBlockedError(err, block.BlockChange)
 		}
 		builtMsg := ""
@@ -541,6 +643,9 @@ func (c *upgradeJujuCommand) upgradeIAASModel(ctx *cmd.Context) (err error) {
 	if warnCompat {
 		fmt.Fprintf(ctx.Stderr, "version %s incompatible with this client (%s)\n", context.chosen, jujuversion.Current)
 	}
+	if c.Precheck {
+		return c.reportUpgradePrecheck(ctx, client, context.chosen)
+	}
 	if c.DryRun {
 		if c.BuildAgent {
 			fmt.Fprintf(ctx.Stderr, "%s --build-agent\n", c.upgradeMessage)
@@ -581,6 +686,46 @@ func (c *baseUpgradeCommand) notifyControllerUpgrade(ctx *cmd.Context, client up
 	return nil
 }
 
+// reportUpgradePrecheck runs the controller's upgrade checks against
+// the chosen version and prints whether the model is ready to upgrade.
+func (c *baseUpgradeCommand) reportUpgradePrecheck(ctx *cmd.Context, client upgradeJujuAPI, chosen version.Number) error {
+	results, err := client.UpgradePrecheck(chosen)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ready := true
+	tw := output.TabWriter(ctx.Stdout)
+	fmt.Fprintf(tw, "Check\tResult\tDescription\n")
+	for _, result := range results {
+		status := "ok"
+		switch {
+		case result.Error != nil:
+			status = "error"
+			ready = false
+		case len(result.Problems) > 0 && result.Blocking:
+			status = "failed"
+			ready = false
+		case len(result.Problems) > 0:
+			status = "warning"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", result.Name, status, result.Description)
+		if result.Error != nil {
+			fmt.Fprintf(tw, "\t\t- %s\n", result.Error.Message)
+		}
+		for _, problem := range result.Problems {
+			fmt.Fprintf(tw, "\t\t- %s\n", problem)
+		}
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Trace(err)
+	}
+	if !ready {
+		return errors.Errorf("not ready to upgrade to %s", chosen)
+	}
+	fmt.Fprintf(ctx.Stdout, "ready to upgrade to %s\n", chosen)
+	return nil
+}
+
 func tryImplicitUpload(agentVersion version.Number) (bool, error) {
 	newerAgent := jujuversion.Current.Compare(agentVersion) > 0
 	if newerAgent || agentVersion.Build > 0 || jujuversion.Current.Build > 0 {
@@ -22,6 +22,7 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
@@ -863,6 +864,184 @@ func (s *UpgradeJujuSuite) TestUpgradeInProgress(c *gc.C) {
 	)
 }
 
+func (s *UpgradeJujuSuite) TestUpgradePrecheckReady(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.precheckResults = []params.UpgradePrecheckResult{{
+		Name:        "provider-api",
+		Description: "the cloud provider API is reachable",
+		Blocking:    true,
+	}, {
+		Name:        "deprecated-config",
+		Description: "the model config does not use deprecated settings",
+		Problems:    []string{`"ignore-machine-addresses" is deprecated`},
+	}}
+
+	cmd := s.upgradeJujuCommand(nil, fakeAPI, fakeAPI, fakeAPI)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--precheck")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, fmt.Sprintf(`
+Check              Result   Description
+provider-api       ok       the cloud provider API is reachable
+deprecated-config  warning  the model config does not use deprecated settings
+                            - "ignore-machine-addresses" is deprecated
+ready to upgrade to %s
+`[1:], fakeAPI.nextVersion.Number))
+	c.Assert(fakeAPI.precheckCalledWith, gc.Equals, fakeAPI.nextVersion.Number)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+}
+
+func (s *UpgradeJujuSuite) TestUpgradePrecheckNotReady(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.precheckResults = []params.UpgradePrecheckResult{{
+		Name:        "series-support",
+		Description: "the target version has agent binaries for every machine",
+		Blocking:    true,
+		Problems:    []string{"no agent binaries for juju 9.9.9 on precise/amd64, used by machines 0"},
+	}, {
+		Name:        "provider-api",
+		Description: "the cloud provider API is reachable",
+		Blocking:    true,
+		Error:       &params.Error{Message: "boom"},
+	}}
+
+	cmd := s.upgradeJujuCommand(nil, fakeAPI, fakeAPI, fakeAPI)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--precheck")
+	c.Assert(err, gc.ErrorMatches, "not ready to upgrade to .*")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Check           Result  Description
+series-support  failed  the target version has agent binaries for every machine
+                        - no agent binaries for juju 9.9.9 on precise/amd64, used by machines 0
+provider-api    error   the cloud provider API is reachable
+                        - boom
+`[1:])
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+}
+
+func (s *UpgradeJujuSuite) TestPinInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--pin", "--unpin"},
+		err:  "cannot specify --pin and --unpin together",
+	}, {
+		args: []string{"--pin", "--dry-run"},
+		err:  "cannot specify --dry-run and --pin together",
+	}, {
+		args: []string{"--schedule", "a;b", "--abort-schedule"},
+		err:  "cannot specify --abort-schedule and --schedule together",
+	}, {
+		args: []string{"--schedule", "a;;b"},
+		err:  "wave 2 of --schedule has no models",
+	}, {
+		args: []string{"--schedule", "a", "--wave-timeout", "0s"},
+		err:  "--wave-timeout must be positive",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+		cmd := s.upgradeJujuCommand(nil, fakeAPI, fakeAPI, fakeAPI)
+		err := cmdtesting.InitCommand(cmd, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *UpgradeJujuSuite) TestPinCurrentVersion(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	cmd := s.upgradeJujuCommand(nil, fakeAPI, fakeAPI, fakeAPI)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--pin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, fmt.Sprintf("model agent version pinned to %s\n", jujuversion.Current))
+	c.Assert(fakeAPI.pinCalledWith, gc.Equals, jujuversion.Current)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+}
+
+func (s *UpgradeJujuSuite) TestPinVersion(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	cmd := s.upgradeJujuCommand(nil, fakeAPI, fakeAPI, fakeAPI)
+	_, err := cmdtesting.RunCommand(c, cmd, "--pin", "--agent-version", "2.5.4")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.pinCalledWith, gc.Equals, version.MustParse("2.5.4"))
+}
+
+func (s *UpgradeJujuSuite) TestUnpin(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	cmd := s.upgradeJujuCommand(nil, fakeAPI, fakeAPI, fakeAPI)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--unpin")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "model agent version unpinned\n")
+	c.Assert(fakeAPI.pinCalled, jc.IsTrue)
+	c.Assert(fakeAPI.pinCalledWith, gc.Equals, version.Zero)
+}
+
+func (s *UpgradeJujuSuite) TestSchedule(c *gc.C) {
+	const otherUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	cmd := s.upgradeJujuCommand(nil, fakeAPI, fakeAPI, fakeAPI)
+	_, err := cmdtesting.RunCommand(c, cmd,
+		"--schedule", "dummy-model; "+otherUUID, "--wave-timeout", "30m")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.stagedTarget, gc.Equals, jujuversion.Current)
+	c.Assert(fakeAPI.stagedWaves, jc.DeepEquals, [][]names.ModelTag{
+		{coretesting.ModelTag},
+		{names.NewModelTag(otherUUID)},
+	})
+	c.Assert(fakeAPI.stagedWaveTimeout, gc.Equals, 30*time.Minute)
+	c.Assert(fakeAPI.setVersionCalledWith, gc.Equals, version.Number{})
+}
+
+func (s *UpgradeJujuSuite) TestScheduleUnknownModel(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	cmd := s.upgradeJujuCommand(nil, fakeAPI, fakeAPI, fakeAPI)
+	_, err := cmdtesting.RunCommand(c, cmd, "--schedule", "nope")
+	c.Assert(err, gc.ErrorMatches, `.*model .*nope not found`)
+	c.Assert(fakeAPI.stagedWaves, gc.IsNil)
+}
+
+func (s *UpgradeJujuSuite) TestShowSchedule(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.stagedStatus = params.StagedUpgradeStatus{
+		TargetVersion: version.MustParse("2.6.1"),
+		Waves: [][]string{
+			{coretesting.ModelTag.String()},
+			{"model-deadbeef-0bad-400d-8000-4b1d0d06f00d"},
+		},
+		Wave:        1,
+		WaveTimeout: time.Hour,
+		Status:      "failed",
+		Message:     "wave 2 timed out",
+	}
+	cmd := s.upgradeJujuCommand(nil, fakeAPI, fakeAPI, fakeAPI)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--show-schedule")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Target:        2.6.1
+Status:        failed
+Message:       wave 2 timed out
+Wave timeout:  1h0m0s
+
+Wave  State     Models
+1     upgraded  admin/dummy-model
+2     failed    deadbeef-0bad-400d-8000-4b1d0d06f00d
+`[1:])
+}
+
+func (s *UpgradeJujuSuite) TestShowScheduleNone(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	fakeAPI.stagedStatusErr = &params.Error{Code: params.CodeNotFound, Message: "staged upgrade not found"}
+	cmd := s.upgradeJujuCommand(nil, fakeAPI, fakeAPI, fakeAPI)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--show-schedule")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "no upgrade has been scheduled\n")
+}
+
+func (s *UpgradeJujuSuite) TestAbortSchedule(c *gc.C) {
+	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
+	cmd := s.upgradeJujuCommand(nil, fakeAPI, fakeAPI, fakeAPI)
+	_, err := cmdtesting.RunCommand(c, cmd, "--abort-schedule")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fakeAPI.abortStagedCalled, jc.IsTrue)
+}
+
 func (s *UpgradeJujuSuite) TestBlockUpgradeInProgress(c *gc.C) {
 	fakeAPI := NewFakeUpgradeJujuAPI(c, s.State)
 	fakeAPI.setVersionErr = common.OperationBlockedError("the operation has been blocked")
@@ -965,6 +1144,16 @@ type fakeUpgradeJujuAPI struct {
 	setIgnoreCalledWith       bool
 	tools                     []string
 	findToolsCalled           bool
+	precheckResults           []params.UpgradePrecheckResult
+	precheckCalledWith        version.Number
+	pinCalled                 bool
+	pinCalledWith             version.Number
+	stagedTarget              version.Number
+	stagedWaves               [][]names.ModelTag
+	stagedWaveTimeout         time.Duration
+	stagedStatus              params.StagedUpgradeStatus
+	stagedStatusErr           error
+	abortStagedCalled         bool
 }
 
 func (a *fakeUpgradeJujuAPI) reset() {
@@ -978,10 +1167,27 @@ func (a *fakeUpgradeJujuAPI) reset() {
 
 func (a *fakeUpgradeJujuAPI) ModelConfig() (map[string]interface{}, error) {
 	return map[string]interface{}{
-		"uuid": a.st.ControllerModelUUID(),
+		"uuid":          a.st.ControllerModelUUID(),
+		"agent-version": jujuversion.Current.String(),
 	}, nil
 }
 
+func (a *fakeUpgradeJujuAPI) StartStagedUpgrade(target version.Number, waves [][]names.ModelTag, waveTimeout time.Duration) error {
+	a.stagedTarget = target
+	a.stagedWaves = waves
+	a.stagedWaveTimeout = waveTimeout
+	return nil
+}
+
+func (a *fakeUpgradeJujuAPI) StagedUpgradeStatus() (params.StagedUpgradeStatus, error) {
+	return a.stagedStatus, a.stagedStatusErr
+}
+
+func (a *fakeUpgradeJujuAPI) AbortStagedUpgrade() error {
+	a.abortStagedCalled = true
+	return nil
+}
+
 func (a *fakeUpgradeJujuAPI) addTools(tools ...string) {
 	for _, tool := range tools {
 		a.tools = append(a.tools, tool)
@@ -1024,6 +1230,17 @@ func (a *fakeUpgradeJujuAPI) SetModelAgentVersion(v version.Number, ignoreAgentV
 	return a.setVersionErr
 }
 
+func (a *fakeUpgradeJujuAPI) UpgradePrecheck(v version.Number) ([]params.UpgradePrecheckResult, error) {
+	a.precheckCalledWith = v
+	return a.precheckResults, nil
+}
+
+func (a *fakeUpgradeJujuAPI) PinModelAgentVersion(v version.Number) error {
+	a.pinCalled = true
+	a.pinCalledWith = v
+	return nil
+}
+
 func (a *fakeUpgradeJujuAPI) Close() error {
 	return nil
 }
@@ -0,0 +1,227 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/environs/config"
+)
+
+// parseUpgradeSchedule splits a schedule such as "a,b;c" into waves of
+// model names: waves are separated by ';' and the models in a wave by
+// ','.
+func parseUpgradeSchedule(schedule string) ([][]string, error) {
+	var waves [][]string
+	for i, wave := range strings.Split(schedule, ";") {
+		var models []string
+		for _, model := range strings.Split(wave, ",") {
+			if model = strings.TrimSpace(model); model != "" {
+				models = append(models, model)
+			}
+		}
+		if len(models) == 0 {
+			return nil, errors.Errorf("wave %d of --schedule has no models", i+1)
+		}
+		waves = append(waves, models)
+	}
+	return waves, nil
+}
+
+// pinModelAgentVersion pins the model's agent version to the requested
+// version, or to its current one, or removes the pin.
+func (c *upgradeJujuCommand) pinModelAgentVersion(ctx *cmd.Context) error {
+	client, err := c.getJujuClientAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if c.Unpin {
+		if err := client.PinModelAgentVersion(version.Zero); err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		ctx.Infof("model agent version unpinned")
+		return nil
+	}
+
+	pin := c.Version
+	if pin == version.Zero {
+		modelConfigClient, err := c.getModelConfigAPI()
+		if err != nil {
+			return err
+		}
+		defer modelConfigClient.Close()
+		attrs, err := modelConfigClient.ModelGet()
+		if err != nil {
+			return err
+		}
+		cfg, err := config.New(config.NoDefaults, attrs)
+		if err != nil {
+			return err
+		}
+		var ok bool
+		if pin, ok = cfg.AgentVersion(); !ok {
+			return errors.New("incomplete model configuration")
+		}
+	}
+	if err := client.PinModelAgentVersion(pin); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("model agent version pinned to %s", pin)
+	return nil
+}
+
+// scheduleUpgrade asks the controller to upgrade the scheduled models
+// in waves.
+func (c *upgradeJujuCommand) scheduleUpgrade(ctx *cmd.Context) error {
+	controllerClient, err := c.getControllerAPI()
+	if err != nil {
+		return err
+	}
+	defer controllerClient.Close()
+
+	target := c.Version
+	if target == version.Zero {
+		// Upgrade the models to the version the controller is running.
+		controllerModelConfig, err := controllerClient.ModelConfig()
+		if err != nil {
+			return err
+		}
+		agentVersion, _ := controllerModelConfig[config.AgentVersionKey].(string)
+		if target, err = version.Parse(agentVersion); err != nil {
+			return errors.Annotate(err, "reading controller agent version")
+		}
+	}
+
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	store := c.ClientStore()
+	waves := make([][]names.ModelTag, len(c.waves))
+	for i, wave := range c.waves {
+		for _, modelName := range wave {
+			if names.IsValidModel(modelName) {
+				waves[i] = append(waves[i], names.NewModelTag(modelName))
+				continue
+			}
+			details, err := store.ModelByName(controllerName, modelName)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			waves[i] = append(waves[i], names.NewModelTag(details.ModelUUID))
+		}
+	}
+
+	if err := controllerClient.StartStagedUpgrade(target, waves, c.WaveTimeout); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("started upgrading %d waves of models to %s", len(waves), target)
+	ctx.Infof("use 'juju upgrade-model --show-schedule' to follow its progress")
+	return nil
+}
+
+// abortScheduledUpgrade stops the controller's running staged upgrade.
+func (c *upgradeJujuCommand) abortScheduledUpgrade(ctx *cmd.Context) error {
+	controllerClient, err := c.getControllerAPI()
+	if err != nil {
+		return err
+	}
+	defer controllerClient.Close()
+	if err := controllerClient.AbortStagedUpgrade(); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("scheduled upgrade aborted")
+	return nil
+}
+
+// showScheduledUpgrade prints the progress of the latest staged
+// upgrade.
+func (c *upgradeJujuCommand) showScheduledUpgrade(ctx *cmd.Context) error {
+	controllerClient, err := c.getControllerAPI()
+	if err != nil {
+		return err
+	}
+	defer controllerClient.Close()
+	status, err := controllerClient.StagedUpgradeStatus()
+	if params.IsCodeNotFound(err) {
+		ctx.Infof("no upgrade has been scheduled")
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+
+	modelNames, err := c.modelNamesByUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tw := output.TabWriter(ctx.Stdout)
+	fmt.Fprintf(tw, "Target:\t%s\n", status.TargetVersion)
+	fmt.Fprintf(tw, "Status:\t%s\n", status.Status)
+	if status.Message != "" {
+		fmt.Fprintf(tw, "Message:\t%s\n", status.Message)
+	}
+	fmt.Fprintf(tw, "Wave timeout:\t%v\n", status.WaveTimeout)
+	fmt.Fprintln(tw)
+	fmt.Fprintln(tw, "Wave\tState\tModels")
+	for i, wave := range status.Waves {
+		var models []string
+		for _, tagString := range wave {
+			tag, err := names.ParseModelTag(tagString)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			name, ok := modelNames[tag.Id()]
+			if !ok {
+				name = tag.Id()
+			}
+			models = append(models, name)
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", i+1, waveState(status, i), strings.Join(models, ", "))
+	}
+	return tw.Flush()
+}
+
+// waveState describes how far the staged upgrade got with a wave.
+func waveState(status params.StagedUpgradeStatus, wave int) string {
+	switch {
+	case wave < status.Wave || wave == status.Wave && status.Status == "completed":
+		return "upgraded"
+	case wave > status.Wave:
+		return "pending"
+	case status.Status == "running":
+		return "upgrading"
+	}
+	return status.Status
+}
+
+// modelNamesByUUID returns the names of the current controller's
+// models known to the client, keyed on model UUID.
+func (c *upgradeJujuCommand) modelNamesByUUID() (map[string]string, error) {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	models, err := c.ClientStore().AllModels(controllerName)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string)
+	for name, details := range models {
+		result[details.ModelUUID] = name
+	}
+	return result, nil
+}
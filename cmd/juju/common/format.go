@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
 )
 
 // LastConnection turns the *time.Time returned from the API server
@@ -124,3 +126,17 @@ func ConformYAML(input interface{}) (interface{}, error) {
 		return input, nil
 	}
 }
+
+// FormatCostEstimate describes the estimated cost of running the new
+// machines of a cost estimate.
+func FormatCostEstimate(estimate params.CostEstimateResult) string {
+	machines := "machines"
+	if estimate.Count == 1 {
+		machines = "machine"
+	}
+	return fmt.Sprintf("%d %s (%s at %.4f %s/hour each): %.2f %s/month",
+		estimate.Count, machines, estimate.InstanceType,
+		estimate.HourlyCost, estimate.Currency,
+		estimate.MonthlyCost, estimate.Currency,
+	)
+}
@@ -10,6 +10,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/common"
 )
 
@@ -262,3 +263,17 @@ func (*userFriendlyDurationSuite) TestFormat(c *gc.C) {
 		c.Check(obtained, gc.Equals, test.expected)
 	}
 }
+
+func (s *FormatTimeSuite) TestFormatCostEstimate(c *gc.C) {
+	estimate := params.CostEstimateResult{
+		InstanceType: "m5.large",
+		Count:        1,
+		HourlyCost:   0.096,
+		MonthlyCost:  70.08,
+		Currency:     "USD",
+	}
+	c.Check(common.FormatCostEstimate(estimate), gc.Equals, "1 machine (m5.large at 0.0960 USD/hour each): 70.08 USD/month")
+	estimate.Count = 3
+	estimate.MonthlyCost = 210.24
+	c.Check(common.FormatCostEstimate(estimate), gc.Equals, "3 machines (m5.large at 0.0960 USD/hour each): 210.24 USD/month")
+}
@@ -189,3 +189,54 @@ func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, er
 var (
 	NoModelsMessage = noModelsMessage
 )
+
+// NewSessionsCommandForTest returns a sessions command with the API
+// and clock mocked out.
+func NewSessionsCommandForTest(api SessionsAPI, clock clock.Clock, store jujuclient.ClientStore) cmd.Command {
+	c := &sessionsCommand{
+		api:   api,
+		clock: clock,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewControllerSchemaCommandForTest returns a controller-schema command
+// with the API mocked out.
+func NewControllerSchemaCommandForTest(api ControllerSchemaAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &controllerSchemaCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRevokeSessionCommandForTest returns a revoke-session command with
+// the API mocked out.
+func NewRevokeSessionCommandForTest(api SessionsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &revokeSessionCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewQuotasCommandForTest returns a quotas command with the API mocked
+// out.
+func NewQuotasCommandForTest(api QuotasAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &quotasCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewSetQuotaCommandForTest returns a set-quota command with the API
+// mocked out.
+func NewSetQuotaCommandForTest(api QuotasAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &setQuotaCommand{
+		api: api,
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -0,0 +1,358 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"github.com/juju/utils/keyvalues"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const quotasDoc = `
+Lists the quotas set on the controller's models and users, along with
+the resources each of them currently uses. A user's quota limits the
+resources used by all of the models the user owns.
+
+Examples:
+
+    juju quotas
+    juju quotas --format yaml
+
+See also:
+    set-quota
+`
+
+const setQuotaDoc = `
+Sets the quota of a model, or with --user, of all of the models owned by
+a user. Adding machines, units or storage that would take a model, or
+the user that owns it, over quota fails.
+
+The limits that can be set are:
+
+    machines    the number of machines, including containers
+    units       the number of units
+    storage     the total size of storage, with an optional M, G, T or P
+                suffix; the default is MiB
+
+Limits that aren't specified are left as they are. A limit of 0 removes
+it.
+
+Examples:
+
+    juju set-quota mymodel machines=10 units=20
+    juju set-quota --user bob storage=500G
+    juju set-quota mymodel machines=0
+
+See also:
+    quotas
+`
+
+// QuotasAPI defines the API methods used by the quotas and set-quota
+// commands.
+type QuotasAPI interface {
+	Close() error
+	ListQuotas() ([]params.EntityQuota, error)
+	SetQuotas([]params.SetQuotaArg) error
+}
+
+// NewQuotasCommand returns a command that lists the quotas set on the
+// controller's models and users.
+func NewQuotasCommand() cmd.Command {
+	return modelcmd.WrapController(&quotasCommand{})
+}
+
+// quotasCommand lists the quotas set on the controller's models and
+// users.
+type quotasCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+	api QuotasAPI
+}
+
+// Info implements Command.Info.
+func (c *quotasCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "quotas",
+		Purpose: "Lists the quotas set on models and users.",
+		Doc:     quotasDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *quotasCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatQuotasTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *quotasCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *quotasCommand) getAPI() (QuotasAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// quotaLimits holds the limits of a quota, or the resources used, for
+// display.
+type quotaLimits struct {
+	Machines int    `yaml:"machines" json:"machines"`
+	Units    int    `yaml:"units" json:"units"`
+	Storage  string `yaml:"storage" json:"storage"`
+}
+
+// entityQuota holds the quota of a model or user for display.
+type entityQuota struct {
+	Kind  string       `yaml:"kind" json:"kind"`
+	Name  string       `yaml:"name" json:"name"`
+	Quota quotaLimits  `yaml:"quota" json:"quota"`
+	Usage *quotaLimits `yaml:"usage,omitempty" json:"usage,omitempty"`
+	Error string       `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *quotasCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	quotas, err := client.ListQuotas()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(quotas) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No quotas have been set.")
+		return nil
+	}
+	result := make([]entityQuota, len(quotas))
+	for i, q := range quotas {
+		tag, err := names.ParseTag(q.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result[i] = entityQuota{
+			Kind: tag.Kind(),
+			Name: q.Name,
+			Quota: quotaLimits{
+				Machines: q.Quota.Machines,
+				Units:    q.Quota.Units,
+				Storage:  formatStorageMiB(q.Quota.StorageMiB),
+			},
+		}
+		if q.Usage != nil {
+			result[i].Usage = &quotaLimits{
+				Machines: q.Usage.Machines,
+				Units:    q.Usage.Units,
+				Storage:  formatStorageMiB(q.Usage.StorageMiB),
+			}
+		}
+		if q.Error != nil {
+			result[i].Error = q.Error.Error()
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatQuotasTabular(writer io.Writer, value interface{}) error {
+	quotas, ok := value.([]entityQuota)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", quotas, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Kind", "Name", "Machines", "Units", "Storage")
+	for _, q := range quotas {
+		usage := q.Usage
+		if usage == nil {
+			usage = &quotaLimits{}
+		}
+		w.Println(
+			q.Kind,
+			q.Name,
+			formatQuotaLimit(strconv.Itoa(usage.Machines), q.Quota.Machines == 0, strconv.Itoa(q.Quota.Machines)),
+			formatQuotaLimit(strconv.Itoa(usage.Units), q.Quota.Units == 0, strconv.Itoa(q.Quota.Units)),
+			formatQuotaLimit(usage.Storage, q.Quota.Storage == "-", q.Quota.Storage),
+		)
+	}
+	tw.Flush()
+	return nil
+}
+
+// formatQuotaLimit returns the usage of a resource against its limit,
+// or just the usage if the resource isn't limited.
+func formatQuotaLimit(used string, unlimited bool, limit string) string {
+	if unlimited {
+		return used
+	}
+	return used + "/" + limit
+}
+
+// formatStorageMiB returns a human readable storage size.
+func formatStorageMiB(size uint64) string {
+	if size == 0 {
+		return "-"
+	}
+	for _, unit := range []struct {
+		suffix string
+		size   uint64
+	}{{"P", 1 << 30}, {"T", 1 << 20}, {"G", 1 << 10}} {
+		if size%unit.size == 0 {
+			return fmt.Sprintf("%d%s", size/unit.size, unit.suffix)
+		}
+	}
+	return fmt.Sprintf("%dM", size)
+}
+
+// NewSetQuotaCommand returns a command that sets the quota of a model
+// or user.
+func NewSetQuotaCommand() cmd.Command {
+	return modelcmd.WrapController(&setQuotaCommand{})
+}
+
+// setQuotaCommand sets the quota of a model or user.
+type setQuotaCommand struct {
+	modelcmd.ControllerCommandBase
+	api QuotasAPI
+
+	user      string
+	modelName string
+	machines  *int
+	units     *int
+	storage   *uint64
+}
+
+// Info implements Command.Info.
+func (c *setQuotaCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-quota",
+		Args:    "[<model>] <key>=<value> ...",
+		Purpose: "Sets the quota of a model or user.",
+		Doc:     setQuotaDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *setQuotaCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Set the quota of the user's models")
+}
+
+// Init implements Command.Init.
+func (c *setQuotaCommand) Init(args []string) error {
+	if c.user != "" {
+		if !names.IsValidUser(c.user) {
+			return errors.NotValidf("user %q", c.user)
+		}
+	} else {
+		if len(args) == 0 || strings.Contains(args[0], "=") {
+			return errors.New("no model or --user specified")
+		}
+		c.modelName, args = args[0], args[1:]
+	}
+	if len(args) == 0 {
+		return errors.New("no limits specified")
+	}
+	limits, err := keyvalues.Parse(args, false)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for key, value := range limits {
+		switch key {
+		case "machines", "units":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return errors.NotValidf("%s limit %q", key, value)
+			}
+			if key == "machines" {
+				c.machines = &n
+			} else {
+				c.units = &n
+			}
+		case "storage":
+			size, err := utils.ParseSize(value)
+			if err != nil {
+				return errors.NotValidf("storage limit %q", value)
+			}
+			c.storage = &size
+		default:
+			return errors.NotValidf("quota %q", key)
+		}
+	}
+	return nil
+}
+
+func (c *setQuotaCommand) getAPI() (QuotasAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *setQuotaCommand) Run(ctx *cmd.Context) error {
+	var tag names.Tag
+	if c.user != "" {
+		tag = names.NewUserTag(c.user)
+	} else {
+		uuids, err := c.ModelUUIDs([]string{c.modelName})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tag = names.NewModelTag(uuids[0])
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	// Limits that aren't specified are left as they are.
+	quotas, err := client.ListQuotas()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var quota params.Quota
+	for _, q := range quotas {
+		if q.Tag == tag.String() {
+			quota = q.Quota
+			break
+		}
+	}
+	if c.machines != nil {
+		quota.Machines = *c.machines
+	}
+	if c.units != nil {
+		quota.Units = *c.units
+	}
+	if c.storage != nil {
+		quota.StorageMiB = *c.storage
+	}
+	return errors.Trace(client.SetQuotas([]params.SetQuotaArg{{
+		Tag:   tag.String(),
+		Quota: quota,
+	}}))
+}
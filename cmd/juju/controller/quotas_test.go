@@ -0,0 +1,171 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+const quotaModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+
+type quotasSuite struct {
+	baseControllerSuite
+	api   *fakeQuotasAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&quotasSuite{})
+
+func (s *quotasSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeQuotasAPI{
+		quotas: []params.EntityQuota{{
+			Tag:   "model-" + quotaModelUUID,
+			Name:  "admin/mymodel",
+			Quota: params.Quota{Machines: 10, StorageMiB: 2048},
+			Usage: &params.QuotaUsage{Machines: 3, Units: 4, StorageMiB: 1536},
+		}, {
+			Tag:   "user-bob",
+			Name:  "bob",
+			Quota: params.Quota{Units: 20},
+			Usage: &params.QuotaUsage{Units: 7},
+		}},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["fake"] = jujuclient.AccountDetails{User: "admin"}
+	s.store.Models["fake"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/mymodel": {ModelUUID: quotaModelUUID},
+		},
+	}
+}
+
+func (s *quotasSuite) TestQuotas(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, controller.NewQuotasCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Kind   Name           Machines  Units  Storage
+model  admin/mymodel  3/10      4      1536M/2G
+user   bob            0         7/20   -
+`[1:])
+}
+
+func (s *quotasSuite) TestQuotasYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, controller.NewQuotasCommandForTest(s.api, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- kind: model
+  name: admin/mymodel
+  quota:
+    machines: 10
+    units: 0
+    storage: 2G
+  usage:
+    machines: 3
+    units: 4
+    storage: 1536M
+- kind: user
+  name: bob
+  quota:
+    machines: 0
+    units: 20
+    storage: '-'
+  usage:
+    machines: 0
+    units: 7
+    storage: '-'
+`[1:])
+}
+
+func (s *quotasSuite) TestQuotasNone(c *gc.C) {
+	s.api.quotas = nil
+	ctx, err := cmdtesting.RunCommand(c, controller.NewQuotasCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No quotas have been set.\n")
+}
+
+func (s *quotasSuite) TestQuotasError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := cmdtesting.RunCommand(c, controller.NewQuotasCommandForTest(s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *quotasSuite) TestSetQuotaModel(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewSetQuotaCommandForTest(s.api, s.store), "mymodel", "units=5", "storage=0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.set, jc.DeepEquals, []params.SetQuotaArg{{
+		Tag:   "model-" + quotaModelUUID,
+		Quota: params.Quota{Machines: 10, Units: 5},
+	}})
+}
+
+func (s *quotasSuite) TestSetQuotaUser(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewSetQuotaCommandForTest(s.api, s.store), "--user", "mary", "storage=1G", "machines=3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.set, jc.DeepEquals, []params.SetQuotaArg{{
+		Tag:   "user-mary",
+		Quota: params.Quota{Machines: 3, StorageMiB: 1024},
+	}})
+}
+
+func (s *quotasSuite) TestSetQuotaInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no model or --user specified",
+	}, {
+		args: []string{"units=1"},
+		err:  "no model or --user specified",
+	}, {
+		args: []string{"mymodel"},
+		err:  "no limits specified",
+	}, {
+		args: []string{"--user", "!bob", "units=1"},
+		err:  `user "!bob" not valid`,
+	}, {
+		args: []string{"mymodel", "units=-1"},
+		err:  `units limit "-1" not valid`,
+	}, {
+		args: []string{"mymodel", "storage=lots"},
+		err:  `storage limit "lots" not valid`,
+	}, {
+		args: []string{"mymodel", "cores=4"},
+		err:  `quota "cores" not valid`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, controller.NewSetQuotaCommandForTest(s.api, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.api.set, gc.IsNil)
+}
+
+type fakeQuotasAPI struct {
+	quotas []params.EntityQuota
+	set    []params.SetQuotaArg
+	err    error
+}
+
+func (f *fakeQuotasAPI) Close() error {
+	return nil
+}
+
+func (f *fakeQuotasAPI) ListQuotas() ([]params.EntityQuota, error) {
+	return f.quotas, f.err
+}
+
+func (f *fakeQuotasAPI) SetQuotas(quotas []params.SetQuotaArg) error {
+	f.set = quotas
+	return f.err
+}
@@ -0,0 +1,114 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const controllerSchemaDoc = `
+Prints the JSON schema of the parameters and results of every method of
+every API facade served by the controller, as generated from the
+controller's facade registry. The output can be used to generate typed
+API clients, or compared with that of another controller to find the
+changes to the API between Juju versions.
+
+Examples:
+
+    juju controller-schema
+    juju controller-schema -o schema.json
+
+See also:
+    show-controller
+`
+
+// ControllerSchemaAPI defines the API methods used by the
+// controller-schema command.
+type ControllerSchemaAPI interface {
+	Close() error
+	FacadeSchemas() (json.RawMessage, error)
+}
+
+// NewControllerSchemaCommand returns a command that prints the schemas
+// of the controller's API facades.
+func NewControllerSchemaCommand() cmd.Command {
+	return modelcmd.WrapController(&controllerSchemaCommand{})
+}
+
+// controllerSchemaCommand prints the schemas of the controller's API
+// facades.
+type controllerSchemaCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+	api ControllerSchemaAPI
+}
+
+// Info implements Command.Info.
+func (c *controllerSchemaCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "controller-schema",
+		Purpose: "Prints the schemas of the controller's API facades.",
+		Doc:     controllerSchemaDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *controllerSchemaCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "json", map[string]cmd.Formatter{
+		"json": formatSchemaJSON,
+	})
+}
+
+// Init implements Command.Init.
+func (c *controllerSchemaCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *controllerSchemaCommand) getAPI() (ControllerSchemaAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *controllerSchemaCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	schemas, err := client.FacadeSchemas()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.out.Write(ctx, schemas)
+}
+
+// formatSchemaJSON writes the schemas as indented JSON, which is easier
+// to read and to compare between controllers than the compact form.
+func formatSchemaJSON(writer io.Writer, value interface{}) error {
+	schemas, ok := value.(json.RawMessage)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", schemas, value)
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, schemas, "", "  "); err != nil {
+		return errors.Trace(err)
+	}
+	buf.WriteString("\n")
+	_, err := buf.WriteTo(writer)
+	return errors.Trace(err)
+}
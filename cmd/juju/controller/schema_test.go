@@ -0,0 +1,77 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"encoding/json"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type controllerSchemaSuite struct {
+	baseControllerSuite
+	api   *fakeControllerSchemaAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&controllerSchemaSuite{})
+
+func (s *controllerSchemaSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.api = &fakeControllerSchemaAPI{
+		schemas: json.RawMessage(`[{"name":"Pinger","version":1,"methods":{"Ping":{}}}]`),
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *controllerSchemaSuite) TestControllerSchema(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, controller.NewControllerSchemaCommandForTest(s.api, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+[
+  {
+    "name": "Pinger",
+    "version": 1,
+    "methods": {
+      "Ping": {}
+    }
+  }
+]
+`[1:])
+	c.Assert(s.api.closed, jc.IsTrue)
+}
+
+func (s *controllerSchemaSuite) TestControllerSchemaError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := cmdtesting.RunCommand(c, controller.NewControllerSchemaCommandForTest(s.api, s.store))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSchemaSuite) TestControllerSchemaArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewControllerSchemaCommandForTest(s.api, s.store), "Client")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["Client"\]`)
+}
+
+type fakeControllerSchemaAPI struct {
+	schemas json.RawMessage
+	err     error
+	closed  bool
+}
+
+func (f *fakeControllerSchemaAPI) Close() error {
+	f.closed = true
+	return nil
+}
+
+func (f *fakeControllerSchemaAPI) FacadeSchemas() (json.RawMessage, error) {
+	return f.schemas, f.err
+}
@@ -0,0 +1,289 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const sessionsDoc = `
+Lists the API connections that have logged in to the controller's API
+servers, showing for each whether a user or an agent logged in, where the
+connection is from and how long ago it logged in. In a highly available
+controller, the connections to every API server are listed.
+
+A session is identified by the API server's machine ID and the
+connection ID, separated by a colon. Sessions can be closed with
+revoke-session.
+
+Examples:
+
+    juju sessions
+    juju sessions --format yaml
+
+See also:
+    revoke-session
+`
+
+const revokeSessionDoc = `
+Closes API connections to the controller. Either the given sessions are
+closed, or with --user, all of the user's connections to every API
+server.
+
+The login macaroons the controller has given the user are revoked too,
+so the user must authenticate again, with their password or identity
+provider, to reconnect. Agents log in with passwords, so an agent's
+connection can be closed but the agent can log in again. Use
+disable-user or change-user-password as well to lock a user out.
+
+Examples:
+
+    juju revoke-session 0:42
+    juju revoke-session --user bob
+
+See also:
+    sessions
+    disable-user
+`
+
+// SessionsAPI defines the API methods used by the sessions and
+// revoke-session commands.
+type SessionsAPI interface {
+	Close() error
+	ListSessions() ([]params.APISession, error)
+	RevokeSessions([]params.RevokeSession) error
+}
+
+// NewSessionsCommand returns a command that lists the logged in API
+// connections to the controller.
+func NewSessionsCommand() cmd.Command {
+	return modelcmd.WrapController(&sessionsCommand{clock: clock.WallClock})
+}
+
+// sessionsCommand lists the logged in API connections to the
+// controller.
+type sessionsCommand struct {
+	modelcmd.ControllerCommandBase
+	out   cmd.Output
+	api   SessionsAPI
+	clock clock.Clock
+}
+
+// Info implements Command.Info.
+func (c *sessionsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "sessions",
+		Purpose: "Lists the API connections logged in to the controller.",
+		Doc:     sessionsDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *sessionsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSessionsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *sessionsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *sessionsCommand) getAPI() (SessionsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// apiSession holds the details of an API connection for display.
+type apiSession struct {
+	Session    string    `yaml:"session" json:"session"`
+	Kind       string    `yaml:"kind" json:"kind"`
+	Entity     string    `yaml:"entity" json:"entity"`
+	Model      string    `yaml:"model,omitempty" json:"model,omitempty"`
+	RemoteAddr string    `yaml:"remote-address" json:"remote-address"`
+	LoggedIn   time.Time `yaml:"logged-in" json:"logged-in"`
+	Age        string    `yaml:"age" json:"age"`
+}
+
+// Run implements Command.Run.
+func (c *sessionsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	sessions, err := client.ListSessions()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(sessions) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No sessions found.")
+		return nil
+	}
+	now := c.clock.Now()
+	result := make([]apiSession, len(sessions))
+	for i, s := range sessions {
+		tag, err := names.ParseTag(s.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		kind, entity := "agent", tag.String()
+		if tag.Kind() == names.UserTagKind {
+			kind, entity = "user", tag.Id()
+		}
+		var model string
+		if s.ModelTag != "" {
+			modelTag, err := names.ParseModelTag(s.ModelTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			model = modelTag.Id()
+		}
+		result[i] = apiSession{
+			Session:    formatSessionID(s.Server, s.ConnectionID),
+			Kind:       kind,
+			Entity:     entity,
+			Model:      model,
+			RemoteAddr: s.RemoteAddr,
+			LoggedIn:   s.LoggedIn,
+			Age:        now.Sub(s.LoggedIn).Round(time.Second).String(),
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatSessionsTabular(writer io.Writer, value interface{}) error {
+	sessions, ok := value.([]apiSession)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", sessions, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Session", "Kind", "Entity", "Remote address", "Age", "Model")
+	for _, s := range sessions {
+		w.Println(s.Session, s.Kind, s.Entity, s.RemoteAddr, s.Age, s.Model)
+	}
+	tw.Flush()
+	return nil
+}
+
+// formatSessionID returns the identifier of a session used by the
+// sessions and revoke-session commands.
+func formatSessionID(server string, connectionID uint64) string {
+	return fmt.Sprintf("%s:%d", server, connectionID)
+}
+
+// parseSessionID parses a session identifier returned by
+// formatSessionID.
+func parseSessionID(id string) (params.RevokeSession, error) {
+	parts := strings.Split(id, ":")
+	if len(parts) != 2 || !names.IsValidMachine(parts[0]) {
+		return params.RevokeSession{}, errors.NotValidf("session %q", id)
+	}
+	connectionID, err := strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return params.RevokeSession{}, errors.NotValidf("session %q", id)
+	}
+	return params.RevokeSession{
+		Server:       parts[0],
+		ConnectionID: connectionID,
+	}, nil
+}
+
+// NewRevokeSessionCommand returns a command that closes API
+// connections to the controller.
+func NewRevokeSessionCommand() cmd.Command {
+	return modelcmd.WrapController(&revokeSessionCommand{})
+}
+
+// revokeSessionCommand closes API connections to the controller.
+type revokeSessionCommand struct {
+	modelcmd.ControllerCommandBase
+	api SessionsAPI
+
+	user     string
+	sessions []params.RevokeSession
+}
+
+// Info implements Command.Info.
+func (c *revokeSessionCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "revoke-session",
+		Args:    "[<session> ...]",
+		Purpose: "Closes API connections to the controller.",
+		Doc:     revokeSessionDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *revokeSessionCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Close all of the user's connections")
+}
+
+// Init implements Command.Init.
+func (c *revokeSessionCommand) Init(args []string) error {
+	if c.user != "" {
+		if len(args) > 0 {
+			return errors.New("cannot specify both sessions and --user")
+		}
+		if !names.IsValidUser(c.user) {
+			return errors.NotValidf("user %q", c.user)
+		}
+		c.sessions = []params.RevokeSession{{
+			UserTag: names.NewUserTag(c.user).String(),
+		}}
+		return nil
+	}
+	if len(args) == 0 {
+		return errors.New("no sessions specified")
+	}
+	for _, arg := range args {
+		session, err := parseSessionID(arg)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.sessions = append(c.sessions, session)
+	}
+	return nil
+}
+
+func (c *revokeSessionCommand) getAPI() (SessionsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.NewControllerAPIClient()
+}
+
+// Run implements Command.Run.
+func (c *revokeSessionCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	return errors.Trace(client.RevokeSessions(c.sessions))
+}
@@ -0,0 +1,160 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type sessionsSuite struct {
+	baseControllerSuite
+	api   *fakeSessionsAPI
+	clock *testclock.Clock
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&sessionsSuite{})
+
+func (s *sessionsSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	loggedIn := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.clock = testclock.NewClock(loggedIn.Add(90 * time.Minute))
+	s.api = &fakeSessionsAPI{
+		sessions: []params.APISession{{
+			Server:       "0",
+			ConnectionID: 42,
+			Tag:          "user-bob",
+			ModelTag:     "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			RemoteAddr:   "10.0.0.1:34567",
+			LoggedIn:     loggedIn,
+		}, {
+			Server:       "0",
+			ConnectionID: 43,
+			Tag:          "machine-1",
+			ModelTag:     "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			RemoteAddr:   "10.0.0.2:45678",
+			LoggedIn:     loggedIn.Add(time.Hour),
+		}},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *sessionsSuite) TestSessions(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, controller.NewSessionsCommandForTest(s.api, s.clock, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Session  Kind   Entity     Remote address  Age      Model
+0:42     user   bob        10.0.0.1:34567  1h30m0s  deadbeef-0bad-400d-8000-4b1d0d06f00d
+0:43     agent  machine-1  10.0.0.2:45678  30m0s    deadbeef-0bad-400d-8000-4b1d0d06f00d
+`[1:])
+}
+
+func (s *sessionsSuite) TestSessionsYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, controller.NewSessionsCommandForTest(s.api, s.clock, s.store), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- session: "0:42"
+  kind: user
+  entity: bob
+  model: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  remote-address: 10.0.0.1:34567
+  logged-in: 2019-05-01T12:00:00Z
+  age: 1h30m0s
+- session: "0:43"
+  kind: agent
+  entity: machine-1
+  model: deadbeef-0bad-400d-8000-4b1d0d06f00d
+  remote-address: 10.0.0.2:45678
+  logged-in: 2019-05-01T13:00:00Z
+  age: 30m0s
+`[1:])
+}
+
+func (s *sessionsSuite) TestSessionsNone(c *gc.C) {
+	s.api.sessions = nil
+	ctx, err := cmdtesting.RunCommand(c, controller.NewSessionsCommandForTest(s.api, s.clock, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No sessions found.\n")
+}
+
+func (s *sessionsSuite) TestSessionsError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := cmdtesting.RunCommand(c, controller.NewSessionsCommandForTest(s.api, s.clock, s.store))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *sessionsSuite) TestRevokeSessions(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewRevokeSessionCommandForTest(s.api, s.store), "0:42", "1:7")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.revoked, jc.DeepEquals, []params.RevokeSession{
+		{Server: "0", ConnectionID: 42},
+		{Server: "1", ConnectionID: 7},
+	})
+}
+
+func (s *sessionsSuite) TestRevokeSessionsUser(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, controller.NewRevokeSessionCommandForTest(s.api, s.store), "--user", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.api.revoked, jc.DeepEquals, []params.RevokeSession{
+		{UserTag: "user-bob"},
+	})
+}
+
+func (s *sessionsSuite) TestRevokeSessionsInitErrors(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no sessions specified",
+	}, {
+		args: []string{"--user", "bob", "0:42"},
+		err:  "cannot specify both sessions and --user",
+	}, {
+		args: []string{"--user", "!bob"},
+		err:  `user "!bob" not valid`,
+	}, {
+		args: []string{"42"},
+		err:  `session "42" not valid`,
+	}, {
+		args: []string{"0:bob"},
+		err:  `session "0:bob" not valid`,
+	}} {
+		_, err := cmdtesting.RunCommand(c, controller.NewRevokeSessionCommandForTest(s.api, s.store), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	c.Assert(s.api.revoked, gc.IsNil)
+}
+
+type fakeSessionsAPI struct {
+	sessions []params.APISession
+	revoked  []params.RevokeSession
+	err      error
+}
+
+func (f *fakeSessionsAPI) Close() error {
+	return nil
+}
+
+func (f *fakeSessionsAPI) ListSessions() ([]params.APISession, error) {
+	return f.sessions, f.err
+}
+
+func (f *fakeSessionsAPI) RevokeSessions(sessions []params.RevokeSession) error {
+	f.revoked = sessions
+	return f.err
+}
@@ -5,6 +5,7 @@ package machine
 
 import (
 	"fmt"
+	"io/ioutil"
 	"path/filepath"
 	"strings"
 	"time"
@@ -63,6 +64,10 @@ It is possible to override or augment constraints by passing provider-specific
 information about how to allocate the machine. For example, one can direct the
 MAAS provider to acquire a particular node by specifying its hostname.
 
+With --estimate, no machines are added. Instead, the estimated monthly cost
+of the machines that would be started is reported, on clouds that support
+cost estimation.
+
 Examples:
    juju add-machine                      (starts a new machine)
    juju add-machine -n 2                 (starts 2 new machines)
@@ -74,6 +79,10 @@ Examples:
    juju add-machine winrm:user@10.10.0.3 (manually provisions machine with winrm)
    juju add-machine zone=us-east-1a      (start a machine in zone us-east-1a on AWS)
    juju add-machine maas2.name           (acquire machine maas2.name on MAAS)
+   juju add-machine --cloudinit-userdata ./user-data.yaml
+                                         (starts a machine with extra cloud-init user data)
+   juju add-machine -n 3 --constraints mem=8G --estimate
+                                         (reports the estimated monthly cost of 3 machines)
 
 See also:
     remove-machine
@@ -115,6 +124,12 @@ type addCommand struct {
 	NumMachines int
 	// Disks describes disks that are to be attached to the machine.
 	Disks []storage.Constraints
+	// CloudInitUserDataFile is the path of a file holding cloud-init
+	// user data to merge with the model's cloudinit-userdata.
+	CloudInitUserDataFile string
+	// Estimate reports the estimated cost of the machines rather
+	// than adding them.
+	Estimate bool
 }
 
 func (c *addCommand) Info() *cmd.Info {
@@ -132,6 +147,8 @@ func (c *addCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.NumMachines, "n", 1, "The number of machines to add")
 	f.StringVar(&c.ConstraintsStr, "constraints", "", "Additional machine constraints")
 	f.Var(disksFlag{&c.Disks}, "disks", "Constraints for disks to attach to the machine")
+	f.StringVar(&c.CloudInitUserDataFile, "cloudinit-userdata", "", "Path to a YAML file of cloud-init user data to merge with the model's cloudinit-userdata")
+	f.BoolVar(&c.Estimate, "estimate", false, "Report the estimated monthly cost of the machines instead of adding them")
 }
 
 func (c *addCommand) Init(args []string) error {
@@ -153,6 +170,14 @@ func (c *addCommand) Init(args []string) error {
 	if c.NumMachines > 1 && c.Placement != nil && c.Placement.Directive != "" {
 		return errors.New("cannot use -n when specifying a placement directive")
 	}
+	if c.Estimate && c.Placement != nil {
+		if c.Placement.Scope == sshScope || c.Placement.Scope == winrmScope {
+			return errors.New("cannot use --estimate when manually provisioning a machine")
+		}
+		if _, err := instance.ParseContainerType(c.Placement.Scope); err == nil && c.Placement.Directive != "" {
+			return errors.New("cannot use --estimate when adding a container to an existing machine")
+		}
+	}
 	return nil
 }
 
@@ -172,6 +197,7 @@ type ModelConfigAPI interface {
 
 type MachineManagerAPI interface {
 	AddMachines([]params.AddMachineParams) ([]params.AddMachinesResult, error)
+	EstimateCosts([]params.CostEstimateArg) ([]params.CostEstimateResult, error)
 	BestAPIVersion() int
 	Close() error
 }
@@ -225,6 +251,21 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.Estimate {
+		return errors.Trace(c.estimate(ctx))
+	}
+
+	var userData string
+	if c.CloudInitUserDataFile != "" {
+		data, err := ioutil.ReadFile(ctx.AbsPath(c.CloudInitUserDataFile))
+		if err != nil {
+			return errors.Annotate(err, "reading cloudinit-userdata")
+		}
+		if _, err := config.ParseCloudInitUserData(string(data)); err != nil {
+			return errors.Annotate(err, "invalid cloudinit-userdata")
+		}
+		userData = string(data)
+	}
 	client, err := c.getClientAPI()
 	if err != nil {
 		return errors.Trace(err)
@@ -285,11 +326,12 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	jobs := []multiwatcher.MachineJob{multiwatcher.JobHostUnits}
 
 	machineParams := params.AddMachineParams{
-		Placement:   c.Placement,
-		Series:      c.Series,
-		Constraints: c.Constraints,
-		Jobs:        jobs,
-		Disks:       c.Disks,
+		Placement:         c.Placement,
+		Series:            c.Series,
+		Constraints:       c.Constraints,
+		Jobs:              jobs,
+		Disks:             c.Disks,
+		CloudInitUserData: userData,
 	}
 	machines := make([]params.AddMachineParams, c.NumMachines)
 	for i := 0; i < c.NumMachines; i++ {
@@ -339,6 +381,29 @@ func (c *addCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+// estimate reports the estimated cost of the machines that would be
+// added, without adding them.
+func (c *addCommand) estimate(ctx *cmd.Context) error {
+	machineManager, err := c.getMachineManagerAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer machineManager.Close()
+
+	results, err := machineManager.EstimateCosts([]params.CostEstimateArg{{
+		Constraints: c.Constraints,
+		Count:       c.NumMachines,
+	}})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	fmt.Fprintf(ctx.Stdout, "Estimated cost of %s\n", common.FormatCostEstimate(results[0]))
+	return nil
+}
+
 var (
 	sshProvisioner    = sshprovisioner.ProvisionMachine
 	winrmProvisioner  = winrmprovisioner.ProvisionMachine
@@ -4,6 +4,8 @@
 package machine_test
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/environs/manual"
 	"github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/state/multiwatcher"
@@ -111,6 +114,32 @@ func (s *AddMachineSuite) TestInit(c *gc.C) {
 	}
 }
 
+func (s *AddMachineSuite) TestInitEstimate(c *gc.C) {
+	for _, test := range []struct {
+		args        []string
+		errorString string
+	}{{
+		args: []string{"--estimate", "-n", "2"},
+	}, {
+		args: []string{"--estimate", "lxd"},
+	}, {
+		args:        []string{"--estimate", "lxd:4"},
+		errorString: "cannot use --estimate when adding a container to an existing machine",
+	}, {
+		args:        []string{"--estimate", "ssh:user@10.10.0.3"},
+		errorString: "cannot use --estimate when manually provisioning a machine",
+	}} {
+		c.Logf("args %q", test.args)
+		wrappedCommand, _ := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager)
+		err := cmdtesting.InitCommand(wrappedCommand, test.args)
+		if test.errorString == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.errorString)
+		}
+	}
+}
+
 func (s *AddMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
 	add, _ := machine.NewAddCommandForTest(s.fakeAddMachine, s.fakeAddMachine, s.fakeMachineManager)
 	return cmdtesting.RunCommand(c, add, args...)
@@ -211,6 +240,27 @@ func (s *AddMachineSuite) TestAddMachineWithDisksUnsupported(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cannot add machines with disks: not supported by the API server")
 }
 
+func (s *AddMachineSuite) TestAddMachineWithCloudInitUserData(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "user-data.yaml")
+	err := ioutil.WriteFile(path, []byte("packages: [htop]\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.run(c, "--cloudinit-userdata", path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 1)
+	c.Assert(s.fakeAddMachine.args[0].CloudInitUserData, gc.Equals, "packages: [htop]\n")
+}
+
+func (s *AddMachineSuite) TestAddMachineWithInvalidCloudInitUserData(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "user-data.yaml")
+	err := ioutil.WriteFile(path, []byte("bootcmd: [reboot]\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.run(c, "--cloudinit-userdata", path)
+	c.Assert(err, gc.ErrorMatches, "invalid cloudinit-userdata: bootcmd not allowed")
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+}
+
 type fakeAddMachineAPI struct {
 	successOrder     []bool
 	currentOp        int
@@ -279,8 +329,44 @@ func (f *fakeAddMachineAPI) ModelGet() (map[string]interface{}, error) {
 type fakeMachineManagerAPI struct {
 	apiVersion int
 	fakeAddMachineAPI
+
+	estimateArgs    []params.CostEstimateArg
+	estimateResults []params.CostEstimateResult
+	estimateErr     error
+}
+
+func (f *fakeMachineManagerAPI) EstimateCosts(args []params.CostEstimateArg) ([]params.CostEstimateResult, error) {
+	f.estimateArgs = args
+	return f.estimateResults, f.estimateErr
 }
 
 func (f *fakeMachineManagerAPI) BestAPIVersion() int {
 	return f.apiVersion
 }
+
+func (s *AddMachineSuite) TestAddMachineEstimate(c *gc.C) {
+	s.fakeMachineManager.estimateResults = []params.CostEstimateResult{{
+		InstanceType: "m5.large",
+		Count:        2,
+		HourlyCost:   0.096,
+		MonthlyCost:  140.16,
+		Currency:     "USD",
+	}}
+	context, err := s.run(c, "-n", "2", "--constraints", "mem=8G", "--estimate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, "Estimated cost of 2 machines (m5.large at 0.0960 USD/hour each): 140.16 USD/month\n")
+	c.Assert(s.fakeMachineManager.estimateArgs, jc.DeepEquals, []params.CostEstimateArg{{
+		Constraints: constraints.MustParse("mem=8G"),
+		Count:       2,
+	}})
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+}
+
+func (s *AddMachineSuite) TestAddMachineEstimateError(c *gc.C) {
+	s.fakeMachineManager.estimateResults = []params.CostEstimateResult{{
+		Error: &params.Error{Message: "no instance types matching"},
+	}}
+	_, err := s.run(c, "--estimate")
+	c.Assert(err, gc.ErrorMatches, "no instance types matching")
+	c.Assert(s.fakeAddMachine.args, gc.HasLen, 0)
+}
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/worker.v1/catacomb"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
 	"github.com/juju/juju/storage"
@@ -51,10 +52,11 @@ type RemoveCommand struct {
 }
 
 // NewRemoveCommand returns an RemoveCommand with the api provided as specified.
-func NewRemoveCommandForTest(apiRoot api.Connection, machineAPI RemoveMachineAPI) (cmd.Command, *RemoveCommand) {
+func NewRemoveCommandForTest(apiRoot api.Connection, machineAPI RemoveMachineAPI, modelConfigAPI block.ModelConfigAPI) (cmd.Command, *RemoveCommand) {
 	command := &removeCommand{
-		apiRoot:    apiRoot,
-		machineAPI: machineAPI,
+		apiRoot:        apiRoot,
+		machineAPI:     machineAPI,
+		modelConfigAPI: modelConfigAPI,
 	}
 	command.SetClientStore(jujuclienttesting.MinimalStore())
 	return modelcmd.Wrap(command), &RemoveCommand{command}
@@ -119,6 +119,7 @@ func (*fakeStatusAPI) Status(c []string) (*params.FullStatus, error) {
 						},
 					},
 				},
+				NetworkConfigDrift: []string{`device "eth1" not observed`},
 			},
 		},
 	}
@@ -209,7 +210,9 @@ func (s *MachineListCommandSuite) TestListMachineYaml(c *gc.C) {
 		"        devices:\n"+
 		"          tun:\n"+
 		"            path: /dev/net/tun\n"+
-		"            type: unix-char\n",
+		"            type: unix-char\n"+
+		"    network-config-drift:\n"+
+		"    - device \"eth1\" not observed\n",
 	)
 }
 
@@ -311,7 +314,10 @@ func (s *MachineListCommandSuite) TestListMachineJson(c *gc.C) {
 		"				   }" +
 		"				}" +
 		"			 }" +
-		"		  }" +
+		"		  }," +
+		"		  \"network-config-drift\":[" +
+		"			 \"device \\\"eth1\\\" not observed\"" +
+		"		  ]" +
 		"	   }" +
 		"	}" +
 		" }\n")
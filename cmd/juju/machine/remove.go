@@ -11,6 +11,7 @@ import (
 
 	"github.com/juju/juju/api"
 	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/api/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
@@ -25,11 +26,13 @@ func NewRemoveCommand() cmd.Command {
 // removeCommand causes an existing machine to be destroyed.
 type removeCommand struct {
 	baseMachinesCommand
-	apiRoot      api.Connection
-	machineAPI   RemoveMachineAPI
-	MachineIds   []string
-	Force        bool
-	KeepInstance bool
+	apiRoot        api.Connection
+	machineAPI     RemoveMachineAPI
+	modelConfigAPI block.ModelConfigAPI
+	MachineIds     []string
+	Force          bool
+	KeepInstance   bool
+	Unprotect      bool
 }
 
 const destroyMachineDoc = `
@@ -55,6 +58,12 @@ the corresponding cloud instance:
 
     juju remove-machine 7 --keep-instance
 
+Machines cannot be removed from a protected model (see the "protected"
+model configuration setting). Remove the model's protection and then
+remove machine 8:
+
+    juju remove-machine 8 --unprotect
+
 See also:
     add-machine
 `
@@ -74,6 +83,7 @@ func (c *removeCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.Force, "force", false, "Completely remove a machine and all its dependencies")
 	f.BoolVar(&c.KeepInstance, "keep-instance", false, "Do not stop the running cloud instance")
+	f.BoolVar(&c.Unprotect, "unprotect", false, "Remove the model's protection before removing the machines")
 }
 
 func (c *removeCommand) Init(args []string) error {
@@ -149,6 +159,26 @@ func (c *removeCommand) getRemoveMachineAPI() (RemoveMachineAPI, error) {
 	return removeMachineAdapter{root.Client()}, nil
 }
 
+func (c *removeCommand) getModelConfigAPI() (block.ModelConfigAPI, error) {
+	if c.modelConfigAPI != nil {
+		return c.modelConfigAPI, nil
+	}
+	root, err := c.getAPIRoot()
+	if err != nil {
+		return nil, err
+	}
+	return modelconfig.NewClient(root), nil
+}
+
+func (c *removeCommand) unprotectModel() error {
+	client, err := c.getModelConfigAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return block.UnprotectModel(client)
+}
+
 // Run implements Command.Run.
 func (c *removeCommand) Run(ctx *cmd.Context) error {
 	client, err := c.getRemoveMachineAPI()
@@ -157,6 +187,12 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
+	if c.Unprotect {
+		if err := c.unprotectModel(); err != nil {
+			return err
+		}
+	}
+
 	var results []params.DestroyMachineResult
 	if c.KeepInstance {
 		results, err = client.DestroyMachinesWithParams(c.Force, c.KeepInstance, c.MachineIds...)
@@ -167,7 +203,8 @@ func (c *removeCommand) Run(ctx *cmd.Context) error {
 		}
 		results, err = destroy(c.MachineIds...)
 	}
-	if err := block.ProcessBlockedError(err, block.BlockRemove); err != nil {
+	err = block.ProcessBlockedError(err, block.BlockRemove)
+	if err := block.ProcessProtectedError(err); err != nil {
 		return err
 	}
 
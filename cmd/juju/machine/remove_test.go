@@ -6,6 +6,7 @@ package machine_test
 import (
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -19,6 +20,7 @@ import (
 type RemoveMachineSuite struct {
 	testing.FakeJujuXDGDataHomeSuite
 	fake          *fakeRemoveMachineAPI
+	configAPI     *fakeUnprotectModelConfigAPI
 	apiConnection *mockAPIConnection
 }
 
@@ -27,13 +29,14 @@ var _ = gc.Suite(&RemoveMachineSuite{})
 func (s *RemoveMachineSuite) SetUpTest(c *gc.C) {
 	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
 	s.fake = &fakeRemoveMachineAPI{}
+	s.configAPI = &fakeUnprotectModelConfigAPI{}
 	s.apiConnection = &mockAPIConnection{
 		bestFacadeVersion: 4,
 	}
 }
 
 func (s *RemoveMachineSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
-	remove, _ := machine.NewRemoveCommandForTest(s.apiConnection, s.fake)
+	remove, _ := machine.NewRemoveCommandForTest(s.apiConnection, s.fake, s.configAPI)
 	return cmdtesting.RunCommand(c, remove, args...)
 }
 
@@ -74,7 +77,7 @@ func (s *RemoveMachineSuite) TestInit(c *gc.C) {
 		},
 	} {
 		c.Logf("test %d", i)
-		wrappedCommand, removeCmd := machine.NewRemoveCommandForTest(s.apiConnection, s.fake)
+		wrappedCommand, removeCmd := machine.NewRemoveCommandForTest(s.apiConnection, s.fake, s.configAPI)
 		err := cmdtesting.InitCommand(wrappedCommand, test.args)
 		if test.errorString == "" {
 			c.Check(err, jc.ErrorIsNil)
@@ -164,6 +167,26 @@ func (s *RemoveMachineSuite) TestOldFacadeRemoveKeep(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "this version of Juju doesn't support --keep-instance")
 }
 
+func (s *RemoveMachineSuite) TestRemoveUnprotect(c *gc.C) {
+	_, err := s.run(c, "--unprotect", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.configAPI.attrs, jc.DeepEquals, map[string]interface{}{"protected": false})
+	c.Assert(s.fake.machines, jc.DeepEquals, []string{"1"})
+}
+
+func (s *RemoveMachineSuite) TestRemoveUnprotectError(c *gc.C) {
+	s.configAPI.err = errors.New("permission denied")
+	_, err := s.run(c, "--unprotect", "1")
+	c.Assert(err, gc.ErrorMatches, "cannot unprotect model: permission denied")
+	c.Assert(s.fake.machines, gc.IsNil)
+}
+
+func (s *RemoveMachineSuite) TestProtectedError(c *gc.C) {
+	s.fake.removeError = common.ModelProtectedError("test")
+	_, err := s.run(c, "1")
+	c.Assert(err, gc.ErrorMatches, `(?s)model "test" is protected.*run the command again with --unprotect.*`)
+}
+
 type fakeRemoveMachineAPI struct {
 	forced      bool
 	keep        bool
@@ -212,3 +235,20 @@ type mockAPIConnection struct {
 func (m *mockAPIConnection) BestFacadeVersion(name string) int {
 	return m.bestFacadeVersion
 }
+
+type fakeUnprotectModelConfigAPI struct {
+	attrs map[string]interface{}
+	err   error
+}
+
+func (f *fakeUnprotectModelConfigAPI) Close() error {
+	return nil
+}
+
+func (f *fakeUnprotectModelConfigAPI) ModelSet(attrs map[string]interface{}) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.attrs = attrs
+	return nil
+}
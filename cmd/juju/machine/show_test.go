@@ -93,7 +93,9 @@ func (s *MachineShowCommandSuite) TestShowMachine(c *gc.C) {
 		"        devices:\n"+
 		"          tun:\n"+
 		"            path: /dev/net/tun\n"+
-		"            type: unix-char\n",
+		"            type: unix-char\n"+
+		"    network-config-drift:\n"+
+		"    - device \"eth1\" not observed\n",
 	)
 }
 
@@ -234,7 +236,10 @@ func (s *MachineShowCommandSuite) TestShowJsonMachine(c *gc.C) {
 		"				   }" +
 		"				}" +
 		"			 }" +
-		"		  }" +
+		"		  }," +
+		"		  \"network-config-drift\":[" +
+		"			 \"device \\\"eth1\\\" not observed\"" +
+		"		  ]" +
 		"	   }" +
 		"	}" +
 		" }\n")
@@ -0,0 +1,157 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/advisor"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const adviseDoc = `
+Lists the machines and units in the model that have been idle for at
+least the number of days given by the idle-resource-days model config,
+along with the command that would remove each of them. Juju never
+removes idle machines or units itself.
+
+Machines are idle when they host no units or containers. Units are idle
+when the values they record for the charm metric named by the
+idle-utilisation-metric model config stay below the
+idle-utilisation-threshold model config. Removing a unit is only
+suggested while its application would keep at least one unit, and no
+fewer than its minimum number of units.
+
+Examples:
+
+    juju advise
+    juju advise --format yaml
+
+See also:
+    model-config
+    remove-machine
+    remove-unit
+`
+
+// NewAdviseCommand returns a command that lists the model's idle
+// machines and units.
+func NewAdviseCommand() cmd.Command {
+	return modelcmd.Wrap(&adviseCommand{})
+}
+
+// adviseCommand lists the model's idle machines and units.
+type adviseCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	out cmd.Output
+	api AdviseAPI
+}
+
+// AdviseAPI defines the API methods used by the advise command.
+type AdviseAPI interface {
+	Close() error
+	Advice() ([]params.Advice, error)
+}
+
+// Info implements Command.Info.
+func (c *adviseCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "advise",
+		Purpose: "Lists idle machines and units that could be removed.",
+		Doc:     adviseDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *adviseCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAdviceTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *adviseCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *adviseCommand) getAPI() (AdviseAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return advisor.NewClient(root), nil
+}
+
+// idleResource holds the details of an idle machine or unit for
+// display.
+type idleResource struct {
+	Kind       string    `yaml:"kind" json:"kind"`
+	Id         string    `yaml:"id" json:"id"`
+	IdleSince  time.Time `yaml:"idle-since" json:"idle-since"`
+	Reason     string    `yaml:"reason" json:"reason"`
+	Suggestion string    `yaml:"suggestion,omitempty" json:"suggestion,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *adviseCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	advice, err := client.Advice()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(advice) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No idle machines or units found.")
+		return nil
+	}
+	result := make([]idleResource, len(advice))
+	for i, a := range advice {
+		tag, err := names.ParseTag(a.Tag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result[i] = idleResource{
+			Kind:       tag.Kind(),
+			Id:         tag.Id(),
+			IdleSince:  a.IdleSince,
+			Reason:     a.Reason,
+			Suggestion: a.Suggestion,
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatAdviceTabular(writer io.Writer, value interface{}) error {
+	resources, ok := value.([]idleResource)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", resources, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Kind", "Id", "Idle since", "Reason", "Suggestion")
+	for _, r := range resources {
+		w.Println(r.Kind, r.Id, r.IdleSince.Format("2006-01-02"), r.Reason, r.Suggestion)
+	}
+	tw.Flush()
+	return nil
+}
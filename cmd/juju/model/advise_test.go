@@ -0,0 +1,102 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type adviseSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeAdviseAPI
+}
+
+var _ = gc.Suite(&adviseSuite{})
+
+type fakeAdviseAPI struct {
+	advice []params.Advice
+	err    error
+}
+
+func (f *fakeAdviseAPI) Close() error {
+	return nil
+}
+
+func (f *fakeAdviseAPI) Advice() ([]params.Advice, error) {
+	return f.advice, f.err
+}
+
+func (s *adviseSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	since := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	s.api = &fakeAdviseAPI{
+		advice: []params.Advice{{
+			Tag:        "machine-2",
+			IdleSince:  since,
+			Reason:     "no units or containers",
+			Suggestion: "juju remove-machine 2",
+		}, {
+			Tag:       "unit-mysql-0",
+			IdleSince: since,
+			Reason:    "cpu below 5",
+		}},
+	}
+}
+
+func (s *adviseSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewAdviseCommandForTest(s.api), args...)
+}
+
+func (s *adviseSuite) TestInitRejectsArgs(c *gc.C) {
+	_, err := s.run(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *adviseSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Kind     Id       Idle since  Reason                  Suggestion\n"+
+		"machine  2        2019-05-01  no units or containers  juju remove-machine 2\n"+
+		"unit     mysql/0  2019-05-01  cpu below 5             \n")
+}
+
+func (s *adviseSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"- kind: machine\n"+
+		"  id: \"2\"\n"+
+		"  idle-since: 2019-05-01T12:00:00Z\n"+
+		"  reason: no units or containers\n"+
+		"  suggestion: juju remove-machine 2\n"+
+		"- kind: unit\n"+
+		"  id: mysql/0\n"+
+		"  idle-since: 2019-05-01T12:00:00Z\n"+
+		"  reason: cpu below 5\n")
+}
+
+func (s *adviseSuite) TestNoIdleResources(c *gc.C) {
+	s.api.advice = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No idle machines or units found.\n")
+}
+
+func (s *adviseSuite) TestError(c *gc.C) {
+	s.api.err = errors.New("boom")
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
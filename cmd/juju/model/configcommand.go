@@ -33,6 +33,11 @@ are displayed.
 Supplying one key name returns only the value for the key. Supplying key=value
 will set the supplied key to the supplied value, this can be repeated for
 multiple keys. You can also specify a yaml file containing key values.
+
+Use --trace with a key name to show the value each level of the
+inheritance chain (Juju defaults, the cloud, the cloud region and the
+model itself) holds for the key, and which level supplies the
+effective value. Cloud and region values are set with model-defaults.
 `
 	modelConfigHelpDocKeys = `
 The following keys are available:
@@ -46,6 +51,7 @@ Examples:
     juju model-config path/to/file.yaml
     juju model-config -m othercontroller:mymodel default-series=yakkety test-mode=false
     juju model-config --reset default-series test-mode
+    juju model-config --trace apt-mirror
 
 See also:
     models
@@ -74,6 +80,7 @@ type configCommand struct {
 	reset      []string // Holds the keys to be reset until parsed.
 	resetKeys  []string // Holds the keys to be reset once parsed.
 	setOptions common.ConfigFlag
+	trace      string // The key whose inheritance is to be shown.
 }
 
 // configCommandAPI defines an API interface to be used during testing.
@@ -83,6 +90,7 @@ type configCommandAPI interface {
 	ModelGetWithMetadata() (config.ConfigValues, error)
 	ModelSet(config map[string]interface{}) error
 	ModelUnset(keys ...string) error
+	ModelConfigTrace(key string) (config.ConfigTrace, error)
 }
 
 // Info implements part of the cmd.Command interface.
@@ -118,6 +126,7 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml":    cmd.FormatYaml,
 	})
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.StringVar(&c.trace, "trace", "", "Show which level supplies the value of the given key")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -130,6 +139,14 @@ func (c *configCommand) Init(args []string) error {
 		return errors.Trace(err)
 	}
 
+	if c.trace != "" {
+		if len(args) > 0 || len(c.reset) > 0 {
+			return errors.New("--trace cannot be combined with other keys or --reset")
+		}
+		c.action = c.traceConfig
+		return nil
+	}
+
 	switch len(args) {
 	case 0:
 		return c.handleZeroArgs()
@@ -353,6 +370,40 @@ func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) err
 	return c.out.Write(ctx, attrs)
 }
 
+// traceConfig writes the value each level of the model's config
+// inheritance chain holds for the traced key.
+func (c *configCommand) traceConfig(client configCommandAPI, ctx *cmd.Context) error {
+	trace, err := client.ModelConfigTrace(c.trace)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := configTrace{
+		Key:    c.trace,
+		Value:  trace.Effective.Value,
+		Source: trace.Effective.Source,
+	}
+	for _, level := range trace.Levels {
+		out.Levels = append(out.Levels, configTraceLevel{
+			Source: level.Source,
+			Value:  level.Value,
+		})
+	}
+	return c.out.Write(ctx, out)
+}
+
+// configTrace is the serialised form of a model config trace.
+type configTrace struct {
+	Key    string             `yaml:"key" json:"key"`
+	Value  interface{}        `yaml:"value" json:"value"`
+	Source string             `yaml:"source" json:"source"`
+	Levels []configTraceLevel `yaml:"levels,omitempty" json:"levels,omitempty"`
+}
+
+type configTraceLevel struct {
+	Source string      `yaml:"source" json:"source"`
+	Value  interface{} `yaml:"value" json:"value"`
+}
+
 // verifyKnownKeys is a helper to validate the keys we are operating with
 // against the set of known attributes from the model.
 func (c *configCommand) verifyKnownKeys(client configCommandAPI, keys []string) error {
@@ -385,6 +436,9 @@ func (c *configCommand) isModelAttribute(attr string) bool {
 
 // formatConfigTabular writes a tabular summary of config information.
 func formatConfigTabular(writer io.Writer, value interface{}) error {
+	if trace, ok := value.(configTrace); ok {
+		return formatTraceTabular(writer, trace)
+	}
 	configValues, ok := value.(config.ConfigValues)
 	if !ok {
 		return errors.Errorf("expected value of type %T, got %T", configValues, value)
@@ -417,6 +471,31 @@ func formatConfigTabular(writer io.Writer, value interface{}) error {
 	return nil
 }
 
+// formatTraceTabular writes a tabular summary of the levels of a
+// model config trace, marking the level the effective value is
+// attributed to.
+func formatTraceTabular(writer io.Writer, trace configTrace) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("Level", "Value", "")
+	for _, level := range trace.Levels {
+		out := &bytes.Buffer{}
+		if err := cmd.FormatYaml(out, level.Value); err != nil {
+			return errors.Annotatef(err, "formatting %s value for %q", level.Source, trace.Key)
+		}
+		valString := strings.TrimSuffix(out.String(), "\n")
+		effective := ""
+		if level.Source == trace.Source {
+			effective = "effective"
+		}
+		w.Println(level.Source, valString, effective)
+	}
+
+	tw.Flush()
+	return nil
+}
+
 // modelConfigDetails gets ModelDetails when a model is not available
 // to use.
 func (c *configCommand) modelConfigDetails() (map[string]interface{}, error) {
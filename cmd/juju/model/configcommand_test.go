@@ -64,6 +64,18 @@ func (s *ConfigCommandSuite) TestInit(c *gc.C) {
 			desc:       "get multiple fails",
 			args:       []string{"one", "two"},
 			errorMatch: "can only retrieve a single value, or all values",
+		}, {
+			desc:   "trace succeeds",
+			args:   []string{"--trace", "special"},
+			nilErr: true,
+		}, {
+			desc:       "trace with other keys fails",
+			args:       []string{"--trace", "special", "running"},
+			errorMatch: "--trace cannot be combined with other keys or --reset",
+		}, {
+			desc:       "trace with reset fails",
+			args:       []string{"--trace", "special", "--reset", "running"},
+			errorMatch: "--trace cannot be combined with other keys or --reset",
 		}, {
 			// test variations
 			desc:   "test reset interspersed",
@@ -171,6 +183,43 @@ func (s *ConfigCommandSuite) TestAllValuesTabular(c *gc.C) {
 	c.Assert(output, gc.Equals, expected)
 }
 
+func (s *ConfigCommandSuite) TestTraceTabular(c *gc.C) {
+	context, err := s.run(c, "--trace", "special")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := cmdtesting.Stdout(context)
+	expected := "" +
+		"Level       Value          \n" +
+		"default     default value  \n" +
+		"controller  cloud value    \n" +
+		"model       special value  effective\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigCommandSuite) TestTraceYAML(c *gc.C) {
+	context, err := s.run(c, "--trace", "special", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := cmdtesting.Stdout(context)
+	expected := "" +
+		"key: special\n" +
+		"value: special value\n" +
+		"source: model\n" +
+		"levels:\n" +
+		"- source: default\n" +
+		"  value: default value\n" +
+		"- source: controller\n" +
+		"  value: cloud value\n" +
+		"- source: model\n" +
+		"  value: special value\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigCommandSuite) TestTraceUnknownKey(c *gc.C) {
+	_, err := s.run(c, "--trace", "unknown")
+	c.Assert(err, gc.ErrorMatches, `model config attribute "unknown" not found`)
+}
+
 func (s *ConfigCommandSuite) TestSetAgentVersion(c *gc.C) {
 	_, err := s.run(c, "agent-version=2.0.0")
 	c.Assert(err, gc.ErrorMatches, `"agent-version"" must be set via "upgrade-model"`)
@@ -62,12 +62,14 @@ type destroyCommand struct {
 	timeout        time.Duration
 	destroyStorage bool
 	releaseStorage bool
+	unprotect      bool
 	api            DestroyModelAPI
 	configAPI      ModelConfigAPI
 	storageAPI     StorageAPI
 
 	Force  bool
 	NoWait bool
+	DryRun bool
 	fs     *gnuflag.FlagSet
 }
 
@@ -94,6 +96,13 @@ proceed to a next step until the current step finished.
 However, when using --force, users can also specify --no-wait to progress through steps 
 without delay waiting for each step to complete.
 
+A model that is protected (see the "protected" model configuration
+setting) cannot be destroyed. Use --unprotect to remove the protection
+before destroying the model; this requires admin access to the model.
+
+Use --dry-run to see which machines, applications, storage and offers would be
+removed, without destroying the model.
+
 Examples:
 
     juju destroy-model test
@@ -102,6 +111,8 @@ Examples:
     juju destroy-model -y mymodel --release-storage
     juju destroy-model -y mymodel --force
     juju destroy-model -y mymodel --force --no-wait
+    juju destroy-model -y mymodel --unprotect
+    juju destroy-model --dry-run --release-storage mymodel
 
 See also:
     destroy-controller
@@ -125,6 +136,7 @@ type DestroyModelAPI interface {
 	BestAPIVersion() int
 	DestroyModel(tag names.ModelTag, destroyStorage, force *bool, maxWait *time.Duration) error
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
+	PreviewDestroyModel(tag names.ModelTag, destroyStorage *bool) (params.DestroyModelImpact, error)
 }
 
 // ModelConfigAPI defines the methods on the modelconfig
@@ -132,6 +144,7 @@ type DestroyModelAPI interface {
 type ModelConfigAPI interface {
 	Close() error
 	SLALevel() (string, error)
+	ModelSet(attrs map[string]interface{}) error
 }
 
 // Info implements Command.Info.
@@ -155,6 +168,8 @@ func (c *destroyCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.releaseStorage, "release-storage", false, "Release all storage instances from the model, and management of the controller, without destroying them")
 	f.BoolVar(&c.Force, "force", false, "Force destroy model ignoring any errors")
 	f.BoolVar(&c.NoWait, "no-wait", false, "Rush through model destruction without waiting for each individual step to complete")
+	f.BoolVar(&c.DryRun, "dry-run", false, "Show what would be destroyed without destroying the model")
+	f.BoolVar(&c.unprotect, "unprotect", false, "Remove the model's protection before destroying it")
 	c.fs = f
 }
 
@@ -163,6 +178,9 @@ func (c *destroyCommand) Init(args []string) error {
 	if c.destroyStorage && c.releaseStorage {
 		return errors.New("--destroy-storage and --release-storage cannot both be specified")
 	}
+	if c.DryRun && c.unprotect {
+		return errors.New("--dry-run and --unprotect cannot both be specified")
+	}
 
 	switch len(args) {
 	case 0:
@@ -241,7 +259,35 @@ func (c *destroyCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("%q is a controller; use 'juju destroy-controller' to destroy it", modelName)
 	}
 
+	// Attempt to connect to the API.  If we can't, fail the destroy.
+	api, err := c.getAPI()
+	if err != nil {
+		return errors.Annotate(err, "cannot connect to API")
+	}
+	defer api.Close()
+
+	modelTag := names.NewModelTag(modelDetails.ModelUUID)
+	if c.DryRun {
+		if api.BestAPIVersion() < 8 {
+			return errors.New("--dry-run is not supported by this controller")
+		}
+		impact, err := api.PreviewDestroyModel(modelTag, c.storageDisposition())
+		if err != nil {
+			return errors.Annotate(err, "cannot determine what would be destroyed")
+		}
+		printDestroyModelImpact(ctx.Stdout, modelName, impact)
+		return nil
+	}
+
 	if !c.assumeYes {
+		if api.BestAPIVersion() >= 8 {
+			impact, err := api.PreviewDestroyModel(modelTag, c.storageDisposition())
+			if err == nil {
+				printDestroyModelImpact(ctx.Stdout, modelName, impact)
+			} else {
+				logger.Debugf("could not determine what would be destroyed: %v", err)
+			}
+		}
 		modelType, err := c.ModelType()
 		if err != nil {
 			return errors.Trace(err)
@@ -257,19 +303,18 @@ func (c *destroyCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
-	// Attempt to connect to the API.  If we can't, fail the destroy.
-	api, err := c.getAPI()
-	if err != nil {
-		return errors.Annotate(err, "cannot connect to API")
-	}
-	defer api.Close()
-
 	configAPI, err := c.getModelConfigAPI()
 	if err != nil {
 		return errors.Annotate(err, "cannot connect to API")
 	}
 	defer configAPI.Close()
 
+	if c.unprotect {
+		if err := block.UnprotectModel(configAPI); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	// Check if the model has an SLA set.
 	slaIsSet := false
 	slaLevel, err := configAPI.SLALevel()
@@ -318,10 +363,7 @@ upgrade the controller to version 2.3 or greater.
 
 	// Attempt to destroy the model.
 	ctx.Infof("Destroying model")
-	var destroyStorage *bool
-	if c.destroyStorage || c.releaseStorage {
-		destroyStorage = &c.destroyStorage
-	}
+	destroyStorage := c.storageDisposition()
 	var force *bool
 	var maxWait *time.Duration
 	if c.Force {
@@ -334,7 +376,6 @@ upgrade the controller to version 2.3 or greater.
 			maxWait = &oneMin
 		}
 	}
-	modelTag := names.NewModelTag(modelDetails.ModelUUID)
 	if err := api.DestroyModel(modelTag, destroyStorage, force, maxWait); err != nil {
 		return c.handleError(
 			modelTag, modelName, api,
@@ -364,6 +405,48 @@ upgrade the controller to version 2.3 or greater.
 	return nil
 }
 
+// storageDisposition returns whether the model's storage should be
+// destroyed, or nil if the user has not chosen to destroy or release it.
+func (c *destroyCommand) storageDisposition() *bool {
+	if c.destroyStorage || c.releaseStorage {
+		destroyStorage := c.destroyStorage
+		return &destroyStorage
+	}
+	return nil
+}
+
+// printDestroyModelImpact writes a description of what destroying the
+// named model would remove.
+func printDestroyModelImpact(w io.Writer, modelName string, impact params.DestroyModelImpact) {
+	fmt.Fprintf(w, "destroying model %q would:\n", modelName)
+	printEntities := func(action string, entities []params.Entity) {
+		for _, entity := range entities {
+			tag, err := names.ParseTag(entity.Tag)
+			if err != nil {
+				logger.Warningf("%s", err)
+				continue
+			}
+			fmt.Fprintf(w, "- %s %s\n", action, names.ReadableString(tag))
+		}
+	}
+	printEntities("remove", impact.Machines)
+	printEntities("remove", impact.Applications)
+	printEntities("destroy", impact.DestroyedStorage)
+	printEntities("release", impact.ReleasedStorage)
+	printEntities("destroy or release", impact.UndecidedStorage)
+	for _, offer := range impact.Offers {
+		suffix := "s"
+		if offer.Connections == 1 {
+			suffix = ""
+		}
+		fmt.Fprintf(w, "- remove offer %s (%d connection%s)\n", offer.OfferName, offer.Connections, suffix)
+	}
+	if len(impact.UndecidedStorage) > 0 {
+		fmt.Fprintln(w, "Use --destroy-storage or --release-storage to choose what happens to the storage.")
+	}
+	fmt.Fprintln(w)
+}
+
 func (c *destroyCommand) removeModelBudget(uuid string) error {
 	bakeryClient, err := c.BakeryClient()
 	if err != nil {
@@ -577,6 +660,9 @@ func (c *destroyCommand) handleError(
 	if params.IsCodeOperationBlocked(err) {
 		return block.ProcessBlockedError(err, block.BlockDestroy)
 	}
+	if params.IsCodeModelProtected(err) {
+		return block.ProcessProtectedError(err)
+	}
 	if params.IsCodeHasPersistentStorage(err) {
 		return handlePersistentStorageError(modelTag, modelName, api)
 	}
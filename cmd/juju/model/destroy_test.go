@@ -53,6 +53,7 @@ type fakeAPI struct {
 	bestAPIVersion     int
 	modelInfoErr       []*params.Error
 	modelStatusPayload []base.ModelStatus
+	impact             params.DestroyModelImpact
 }
 
 func (f *fakeAPI) Close() error { return nil }
@@ -66,6 +67,11 @@ func (f *fakeAPI) DestroyModel(tag names.ModelTag, destroyStorage *bool, force *
 	return f.NextErr()
 }
 
+func (f *fakeAPI) PreviewDestroyModel(tag names.ModelTag, destroyStorage *bool) (params.DestroyModelImpact, error) {
+	f.MethodCall(f, "PreviewDestroyModel", tag, destroyStorage)
+	return f.impact, f.NextErr()
+}
+
 func (f *fakeAPI) ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error) {
 	var err error
 	if f.statusCallCount < len(f.modelInfoErr) {
@@ -92,6 +98,7 @@ func (f *fakeAPI) ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, err
 
 // fakeConfigAPI mocks out the ModelConfigAPI.
 type fakeConfigAPI struct {
+	*jutesting.Stub
 	err      error
 	slaLevel string
 }
@@ -100,6 +107,11 @@ func (f *fakeConfigAPI) SLALevel() (string, error) {
 	return f.slaLevel, f.err
 }
 
+func (f *fakeConfigAPI) ModelSet(attrs map[string]interface{}) error {
+	f.MethodCall(f, "ModelSet", attrs)
+	return f.NextErr()
+}
+
 func (f *fakeConfigAPI) Close() error { return nil }
 
 func (s *DestroySuite) SetUpTest(c *gc.C) {
@@ -109,7 +121,7 @@ func (s *DestroySuite) SetUpTest(c *gc.C) {
 		Stub:           s.stub,
 		bestAPIVersion: 4,
 	}
-	s.configAPI = &fakeConfigAPI{}
+	s.configAPI = &fakeConfigAPI{Stub: s.stub}
 	s.storageAPI = &mockStorageAPI{Stub: s.stub}
 	s.clock = testclock.NewClock(time.Now())
 
@@ -350,6 +362,72 @@ func (s *DestroySuite) TestDestroyDestroyStorageFlagUnspecifiedOldControllerNoSt
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *DestroySuite) TestDestroyDryRun(c *gc.C) {
+	s.api.bestAPIVersion = 8
+	s.api.impact = params.DestroyModelImpact{
+		Machines:         []params.Entity{{Tag: "machine-0"}},
+		Applications:     []params.Entity{{Tag: "application-mysql"}},
+		ReleasedStorage:  []params.Entity{{Tag: "storage-data-0"}},
+		UndecidedStorage: []params.Entity{{Tag: "storage-logs-1"}},
+		Offers:           []params.DestroyedOffer{{OfferName: "db", Connections: 1}},
+	}
+	ctx, err := s.runDestroyCommand(c, "test2", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+destroying model "test2" would:
+- remove machine 0
+- remove application mysql
+- release storage data/0
+- destroy or release storage logs/1
+- remove offer db (1 connection)
+Use --destroy-storage or --release-storage to choose what happens to the storage.
+
+`[1:])
+	checkModelExistsInStore(c, "test1:admin/test2", s.store)
+	s.stub.CheckCalls(c, []jutesting.StubCall{
+		{"PreviewDestroyModel", []interface{}{names.NewModelTag("test2-uuid"), (*bool)(nil)}},
+	})
+}
+
+func (s *DestroySuite) TestDestroyDryRunReleaseStorage(c *gc.C) {
+	s.api.bestAPIVersion = 8
+	_, err := s.runDestroyCommand(c, "test2", "--dry-run", "--release-storage")
+	c.Assert(err, jc.ErrorIsNil)
+	destroyStorage := false
+	s.stub.CheckCalls(c, []jutesting.StubCall{
+		{"PreviewDestroyModel", []interface{}{names.NewModelTag("test2-uuid"), &destroyStorage}},
+	})
+}
+
+func (s *DestroySuite) TestDestroyDryRunNotSupported(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "--dry-run is not supported by this controller")
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *DestroySuite) TestDestroyShowsImpactBeforeConfirmation(c *gc.C) {
+	s.api.bestAPIVersion = 8
+	s.api.impact = params.DestroyModelImpact{
+		Machines: []params.Entity{{Tag: "machine-0"}},
+	}
+	var stdin, stdout bytes.Buffer
+	ctx, err := cmd.DefaultContext()
+	c.Assert(err, jc.ErrorIsNil)
+	ctx.Stdout = &stdout
+	ctx.Stdin = &stdin
+	stdin.WriteString("n")
+
+	_, errc := cmdtest.RunCommandWithDummyProvider(ctx, s.NewDestroyCommand(), "test2")
+	select {
+	case err := <-errc:
+		c.Check(err, gc.ErrorMatches, "model destruction: aborted")
+	case <-time.After(testing.LongWait):
+		c.Fatalf("command took too long")
+	}
+	c.Check(cmdtesting.Stdout(ctx), gc.Matches, "destroying model \"test2\" would:\n- remove machine 0\n\nWARNING!(.|\n)*")
+	s.stub.CheckCallNames(c, "PreviewDestroyModel")
+}
+
 func (s *DestroySuite) resetModel(c *gc.C) {
 	s.store.Models["test1"] = &jujuclient.ControllerModels{
 		Models: map[string]jujuclient.ModelDetails{
@@ -476,6 +554,31 @@ func (s *DestroySuite) TestBlockedDestroy(c *gc.C) {
 	testing.AssertOperationWasBlocked(c, err, ".*TestBlockedDestroy.*")
 }
 
+func (s *DestroySuite) TestDestroyUnprotect(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--unprotect")
+	c.Assert(err, jc.ErrorIsNil)
+	checkModelRemovedFromStore(c, "test1:admin/test2", s.store)
+	s.stub.CheckCalls(c, []jutesting.StubCall{
+		{"ModelSet", []interface{}{map[string]interface{}{"protected": false}}},
+		{"DestroyModel", []interface{}{names.NewModelTag("test2-uuid"), (*bool)(nil), (*bool)(nil), (*time.Duration)(nil)}},
+	})
+}
+
+func (s *DestroySuite) TestDestroyUnprotectDryRun(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "--dry-run", "--unprotect")
+	c.Assert(err, gc.ErrorMatches, "--dry-run and --unprotect cannot both be specified")
+}
+
+func (s *DestroySuite) TestDestroyProtected(c *gc.C) {
+	s.stub.SetErrors(&params.Error{
+		Code:    params.CodeModelProtected,
+		Message: `model "test2" is protected from destruction and from removal of its applications and machines`,
+	})
+	_, err := s.runDestroyCommand(c, "test2", "-y")
+	c.Assert(err, gc.ErrorMatches, `(?s)cannot destroy model: model "test2" is protected.*run the command again with --unprotect.*`)
+	checkModelExistsInStore(c, "test1:admin/test2", s.store)
+}
+
 // mockBudgetAPIClient implements the budgetAPIClient interface.
 type mockBudgetAPIClient struct {
 	*jutesting.Stub
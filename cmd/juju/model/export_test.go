@@ -44,6 +44,24 @@ func NewRetryProvisioningCommandForTest(api RetryProvisioningAPI) cmd.Command {
 	return modelcmd.Wrap(cmd)
 }
 
+// NewFindOrphansCommandForTest returns a findOrphansCommand with the api provided as specified.
+func NewFindOrphansCommandForTest(api FindOrphansAPI) cmd.Command {
+	cmd := &findOrphansCommand{
+		api: api,
+	}
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(cmd)
+}
+
+// NewAdviseCommandForTest returns an adviseCommand with the api provided as specified.
+func NewAdviseCommandForTest(api AdviseAPI) cmd.Command {
+	cmd := &adviseCommand{
+		api: api,
+	}
+	cmd.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(cmd)
+}
+
 // NewShowCommandForTest returns a ShowCommand with the api provided as specified.
 func NewShowCommandForTest(api ShowModelAPI, refreshFunc func(jujuclient.ClientStore, string) error, store jujuclient.ClientStore) cmd.Command {
 	cmd := &showModelCommand{api: api}
@@ -220,3 +238,24 @@ func NewDiffCommandForTest(api DiffCommandAPI, store jujuclient.ClientStore) cmd
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+// NewExportModelCommandForTest returns an ExportModelCommand with the api
+// and downloader provided as specified.
+func NewExportModelCommandForTest(api DumpModelAPI, downloader ModelDownloader, store jujuclient.ClientStore) cmd.Command {
+	cmd := &exportModelCommand{api: api, downloader: downloader}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewImportModelCommandForTest returns an ImportModelCommand with the api
+// provided as specified, which gives imported models the specified UUID.
+func NewImportModelCommandForTest(api ImportModelAPI, store jujuclient.ClientStore, uuid string) cmd.Command {
+	cmd := &importModelCommand{
+		api: api,
+		newUUID: func() (string, error) {
+			return uuid, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.WrapController(cmd)
+}
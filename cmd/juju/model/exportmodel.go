@@ -0,0 +1,180 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/migration"
+)
+
+// NewExportModelCommand returns a fully constructed export-model command.
+func NewExportModelCommand() cmd.Command {
+	return modelcmd.Wrap(&exportModelCommand{})
+}
+
+type exportModelCommand struct {
+	modelcmd.ModelCommandBase
+	api        DumpModelAPI
+	downloader ModelDownloader
+
+	filename string
+}
+
+const exportModelHelpDoc = `
+Writes an archive of the model to a file on the local machine. The
+archive holds the model's representation, as shown by dump-model, along
+with the charms and resources used by its applications. Agent binaries
+are not included.
+
+The archive can be imported into another controller with import-model.
+Exporting a model leaves it untouched; unlike migrate, the model stays
+under the control of the current controller.
+
+Examples:
+
+    juju export-model mymodel.tar.gz
+    juju export-model -m mymodel mymodel.tar.gz
+
+See also:
+    import-model
+    dump-model
+    migrate
+`
+
+// Info implements Command.
+func (c *exportModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "export-model",
+		Args:    "<file>",
+		Purpose: "Writes an archive of the model to a file.",
+		Doc:     exportModelHelpDoc,
+	})
+}
+
+// Init implements Command.
+func (c *exportModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing filename")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// ModelDownloader downloads the charms and resources used by a model.
+type ModelDownloader interface {
+	migration.CharmDownloader
+	migration.ResourceDownloader
+	Close() error
+}
+
+func (c *exportModelCommand) getAPI() (DumpModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	return c.ModelCommandBase.NewModelManagerAPIClient()
+}
+
+func (c *exportModelCommand) getDownloader() (ModelDownloader, error) {
+	if c.downloader != nil {
+		return c.downloader, nil
+	}
+	root, err := c.ModelCommandBase.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &modelDownloader{root}, nil
+}
+
+// Run implements Command.
+func (c *exportModelCommand) Run(ctx *cmd.Context) (err error) {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	_, modelDetails, err := c.ModelCommandBase.ModelDetails()
+	if err != nil {
+		return errors.Annotate(err, "getting model details")
+	}
+
+	modelTag := names.NewModelTag(modelDetails.ModelUUID)
+	model, err := client.DumpModel(modelTag, false)
+	if err != nil {
+		return err
+	}
+	serialized, err := yaml.Marshal(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	downloader, err := c.getDownloader()
+	if err != nil {
+		return err
+	}
+	defer downloader.Close()
+
+	filename := ctx.AbsPath(c.filename)
+	f, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if closeErr := f.Close(); err == nil {
+			err = errors.Trace(closeErr)
+		}
+		if err != nil {
+			os.Remove(filename)
+		}
+	}()
+
+	err = migration.WriteModelArchive(f, migration.ModelArchiveConfig{
+		Model:              serialized,
+		CharmDownloader:    downloader,
+		ResourceDownloader: downloader,
+	})
+	if err != nil {
+		return errors.Annotate(err, "exporting model")
+	}
+	ctx.Infof("Model %q exported to %s", modelDetails.ModelUUID, filename)
+	return nil
+}
+
+// modelDownloader downloads charms and resources over the model's API
+// connection.
+type modelDownloader struct {
+	api.Connection
+}
+
+// OpenCharm is part of the migration.CharmDownloader interface.
+func (d *modelDownloader) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return api.OpenCharm(d.Connection, curl)
+}
+
+// OpenResource is part of the migration.ResourceDownloader interface.
+func (d *modelDownloader) OpenResource(application, name string) (io.ReadCloser, error) {
+	httpClient, err := d.Connection.HTTPClient()
+	if err != nil {
+		return nil, errors.Annotate(err, "unable to create HTTP client")
+	}
+	uri := fmt.Sprintf("/applications/%s/resources/%s", application, name)
+	var resp *http.Response
+	if err := httpClient.Get(uri, &resp); err != nil {
+		return nil, errors.Annotate(err, "unable to retrieve resource")
+	}
+	return resp.Body, nil
+}
@@ -0,0 +1,177 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/cmd/juju/model"
+	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/testing"
+)
+
+type ExportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api        fakeExportModelClient
+	downloader fakeModelDownloader
+	store      *jujuclient.MemStore
+	filename   string
+}
+
+var _ = gc.Suite(&ExportModelCommandSuite{})
+
+// newDescriptionModel returns a serialized model with a single
+// application.
+func newDescriptionModel(c *gc.C) []byte {
+	m := description.NewModel(description.ModelArgs{
+		Owner: names.NewUserTag("admin"),
+		Config: map[string]interface{}{
+			"name": "mymodel",
+			"uuid": testing.ModelTag.Id(),
+		},
+	})
+	m.SetStatus(description.StatusArgs{Value: "available"})
+	app := m.AddApplication(description.ApplicationArgs{
+		Tag:                names.NewApplicationTag("mysql"),
+		CharmURL:           "cs:bionic/mysql-1",
+		Series:             "bionic",
+		CharmConfig:        map[string]interface{}{},
+		LeadershipSettings: map[string]interface{}{},
+	})
+	app.SetStatus(description.StatusArgs{Value: "running"})
+	serialized, err := description.Serialize(m)
+	c.Assert(err, jc.ErrorIsNil)
+	return serialized
+}
+
+type fakeExportModelClient struct {
+	gitjujutesting.Stub
+	model []byte
+}
+
+func (f *fakeExportModelClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportModelClient) DumpModel(model names.ModelTag, simplified bool) (map[string]interface{}, error) {
+	f.MethodCall(f, "DumpModel", model, simplified)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	var out map[string]interface{}
+	err := yaml.Unmarshal(f.model, &out)
+	return out, err
+}
+
+type fakeModelDownloader struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeModelDownloader) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeModelDownloader) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenCharm", curl)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader([]byte(curl.String() + " content"))), nil
+}
+
+func (f *fakeModelDownloader) OpenResource(application, name string) (io.ReadCloser, error) {
+	f.MethodCall(f, "OpenResource", application, name)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return ioutil.NopCloser(bytes.NewReader([]byte(name))), nil
+}
+
+func (s *ExportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = fakeExportModelClient{model: newDescriptionModel(c)}
+	s.downloader = fakeModelDownloader{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		ModelUUID: testing.ModelTag.Id(),
+		ModelType: coremodel.IAAS,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+	s.filename = filepath.Join(c.MkDir(), "mymodel.tar.gz")
+}
+
+func (s *ExportModelCommandSuite) run(c *gc.C, args ...string) error {
+	_, err := cmdtesting.RunCommand(c, model.NewExportModelCommandForTest(&s.api, &s.downloader, s.store), args...)
+	return err
+}
+
+func (s *ExportModelCommandSuite) TestExport(c *gc.C) {
+	err := s.run(c, s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []gitjujutesting.StubCall{
+		{"DumpModel", []interface{}{testing.ModelTag, false}},
+		{"Close", nil},
+	})
+	s.downloader.CheckCalls(c, []gitjujutesting.StubCall{
+		{"OpenCharm", []interface{}{charm.MustParseURL("cs:bionic/mysql-1")}},
+		{"Close", nil},
+	})
+
+	f, err := os.Open(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	defer f.Close()
+	archive, err := migration.ReadModelArchive(f)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	c.Check(archive.Model().Tag(), gc.Equals, testing.ModelTag)
+	c.Check(archive.Charms(), jc.DeepEquals, []string{"cs:bionic/mysql-1"})
+}
+
+func (s *ExportModelCommandSuite) TestExportFileExists(c *gc.C) {
+	err := ioutil.WriteFile(s.filename, []byte("precious"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, ".*file exists")
+
+	content, err := ioutil.ReadFile(s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "precious")
+}
+
+func (s *ExportModelCommandSuite) TestExportDownloadFails(c *gc.C) {
+	s.downloader.SetErrors(errors.New("boom"))
+	err := s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "exporting model: cannot open charm: boom")
+	_, err = os.Stat(s.filename)
+	c.Check(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *ExportModelCommandSuite) TestExportMissingFilename(c *gc.C) {
+	err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "missing filename")
+}
@@ -62,6 +62,24 @@ func (f *fakeEnvAPI) ModelGetWithMetadata() (config.ConfigValues, error) {
 	return result, nil
 }
 
+func (f *fakeEnvAPI) ModelConfigTrace(key string) (config.ConfigTrace, error) {
+	if f.err != nil {
+		return config.ConfigTrace{}, f.err
+	}
+	value, ok := f.values[key]
+	if !ok {
+		return config.ConfigTrace{}, errors.NotFoundf("model config attribute %q", key)
+	}
+	return config.ConfigTrace{
+		Effective: config.ConfigValue{Value: value, Source: "model"},
+		Levels: []config.ConfigValue{
+			{Value: "default value", Source: "default"},
+			{Value: "cloud value", Source: "controller"},
+			{Value: value, Source: "model"},
+		},
+	}, nil
+}
+
 func (f *fakeEnvAPI) ModelSet(config map[string]interface{}) error {
 	f.values = config
 	return f.err
@@ -0,0 +1,143 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/orphans"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const findOrphansDoc = `
+Lists the instances, volumes, security groups and load balancers that
+are tagged with the model in the cloud, but are unknown to Juju. Such
+resources are usually left behind when removing a machine or model with
+--force is interrupted, and continue to incur costs.
+
+Setting the remove-orphaned-resources model config has Juju remove
+orphaned resources once it has found them twice in a row.
+
+Examples:
+
+    juju find-orphans
+    juju find-orphans --format yaml
+
+See also:
+    model-config
+`
+
+// NewFindOrphansCommand returns a command that lists provider resources
+// tagged with the model but unknown to Juju.
+func NewFindOrphansCommand() cmd.Command {
+	return modelcmd.Wrap(&findOrphansCommand{})
+}
+
+// findOrphansCommand lists provider resources tagged with the model but
+// unknown to Juju.
+type findOrphansCommand struct {
+	modelcmd.ModelCommandBase
+	modelcmd.IAASOnlyCommand
+	out cmd.Output
+	api FindOrphansAPI
+}
+
+// FindOrphansAPI defines the API methods used by the find-orphans
+// command.
+type FindOrphansAPI interface {
+	Close() error
+	FindOrphans() ([]params.ModelResource, error)
+}
+
+// Info implements Command.Info.
+func (c *findOrphansCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "find-orphans",
+		Purpose: "Lists cloud resources tagged with the model but unknown to Juju.",
+		Doc:     findOrphansDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *findOrphansCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatOrphansTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *findOrphansCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *findOrphansCommand) getAPI() (FindOrphansAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return orphans.NewClient(root), nil
+}
+
+// orphanedResource holds the details of an orphaned resource for
+// display.
+type orphanedResource struct {
+	Kind  string `yaml:"kind" json:"kind"`
+	Id    string `yaml:"id" json:"id"`
+	Owner string `yaml:"owner,omitempty" json:"owner,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *findOrphansCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	resources, err := client.FindOrphans()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(resources) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No orphaned resources found.")
+		return nil
+	}
+	result := make([]orphanedResource, len(resources))
+	for i, r := range resources {
+		result[i] = orphanedResource{
+			Kind:  r.Kind,
+			Id:    r.Id,
+			Owner: r.Owner,
+		}
+	}
+	return c.out.Write(ctx, result)
+}
+
+func formatOrphansTabular(writer io.Writer, value interface{}) error {
+	resources, ok := value.([]orphanedResource)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", resources, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Kind", "Id", "Owner")
+	for _, r := range resources {
+		w.Println(r.Kind, r.Id, r.Owner)
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/testing"
+)
+
+type findOrphansSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeFindOrphansAPI
+}
+
+var _ = gc.Suite(&findOrphansSuite{})
+
+type fakeFindOrphansAPI struct {
+	resources []params.ModelResource
+	err       error
+}
+
+func (f *fakeFindOrphansAPI) Close() error {
+	return nil
+}
+
+func (f *fakeFindOrphansAPI) FindOrphans() ([]params.ModelResource, error) {
+	return f.resources, f.err
+}
+
+func (s *findOrphansSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeFindOrphansAPI{
+		resources: []params.ModelResource{
+			{Kind: "instance", Id: "i-1234"},
+			{Kind: "security-group", Id: "sg-5678", Owner: "machine-3"},
+		},
+	}
+}
+
+func (s *findOrphansSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, model.NewFindOrphansCommandForTest(s.api), args...)
+}
+
+func (s *findOrphansSuite) TestInitRejectsArgs(c *gc.C) {
+	_, err := s.run(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *findOrphansSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Kind            Id       Owner\n"+
+		"instance        i-1234   \n"+
+		"security-group  sg-5678  machine-3\n")
+}
+
+func (s *findOrphansSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"- kind: instance\n"+
+		"  id: i-1234\n"+
+		"- kind: security-group\n"+
+		"  id: sg-5678\n"+
+		"  owner: machine-3\n")
+}
+
+func (s *findOrphansSuite) TestNoOrphans(c *gc.C) {
+	s.api.resources = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No orphaned resources found.\n")
+}
+
+func (s *findOrphansSuite) TestError(c *gc.C) {
+	s.api.err = errors.NotSupportedf("finding orphaned resources on %q", "lxd")
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, `finding orphaned resources on "lxd" not supported`)
+}
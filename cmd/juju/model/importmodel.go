@@ -0,0 +1,215 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/api/migrationtarget"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/tools"
+)
+
+// NewImportModelCommand returns a fully constructed import-model command.
+func NewImportModelCommand() cmd.Command {
+	return modelcmd.WrapController(&importModelCommand{})
+}
+
+type importModelCommand struct {
+	modelcmd.ControllerCommandBase
+	api     ImportModelAPI
+	newUUID func() (string, error)
+
+	filename string
+}
+
+const importModelHelpDoc = `
+Imports a model from an archive written by export-model into the current
+controller. The model's state, charms and resources are restored from the
+archive; the model in the controller it was exported from is untouched.
+
+The imported model is a copy of the exported one: it is given a new UUID
+and none of the original model's cloud resources. Its machines are
+provisioned afresh, and its storage is created anew, by the controller it
+is imported into, so the original model may keep running alongside it.
+
+The user running import-model must be a controller superuser.
+
+Examples:
+
+    juju import-model mymodel.tar.gz
+    juju import-model -c othercontroller mymodel.tar.gz
+
+See also:
+    export-model
+    migrate
+`
+
+// Info implements Command.
+func (c *importModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "import-model",
+		Args:    "<file>",
+		Purpose: "Imports a model from an archive into the controller.",
+		Doc:     importModelHelpDoc,
+	})
+}
+
+// Init implements Command.
+func (c *importModelCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("missing filename")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// ImportModelAPI specifies the used function calls of the
+// MigrationTarget facade.
+type ImportModelAPI interface {
+	Close() error
+	Import([]byte) error
+	Activate(modelUUID string) error
+	Abort(modelUUID string) error
+	UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error)
+	UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error)
+	UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error
+	SetPlaceholderResource(modelUUID string, res resource.Resource) error
+	SetUnitResource(modelUUID, unit string, res resource.Resource) error
+}
+
+type importModelClient struct {
+	*migrationtarget.Client
+	closer io.Closer
+}
+
+// Close is part of ImportModelAPI.
+func (c *importModelClient) Close() error {
+	return c.closer.Close()
+}
+
+func (c *importModelCommand) getAPI() (ImportModelAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.ControllerCommandBase.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &importModelClient{
+		Client: migrationtarget.NewClient(root),
+		closer: root,
+	}, nil
+}
+
+// Run implements Command.
+func (c *importModelCommand) Run(ctx *cmd.Context) error {
+	f, err := os.Open(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer f.Close()
+	archive, err := migration.ReadModelArchive(f)
+	if err != nil {
+		return errors.Annotatef(err, "reading %q", c.filename)
+	}
+	defer archive.Close()
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// Import a copy of the model with its own UUID, so that the two
+	// controllers never manage the same cloud resources.
+	newUUID := c.newUUID
+	if newUUID == nil {
+		newUUID = func() (string, error) {
+			uuid, err := utils.NewUUID()
+			return uuid.String(), err
+		}
+	}
+	modelUUID, err := newUUID()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	serialized, model, err := archive.NewModelCopy(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := client.Import(serialized); err != nil {
+		return errors.Annotate(err, "importing model")
+	}
+	if err := c.uploadAndActivate(client, archive, modelUUID); err != nil {
+		if abortErr := client.Abort(modelUUID); abortErr != nil {
+			logger.Errorf("cannot abort import of model %q: %v", modelUUID, abortErr)
+		}
+		return errors.Trace(err)
+	}
+	ctx.Infof("Model %q imported with UUID %q", model.Config()["name"], modelUUID)
+	return nil
+}
+
+func (c *importModelCommand) uploadAndActivate(client ImportModelAPI, archive *migration.ModelArchive, modelUUID string) error {
+	uploader := &importUploader{client, modelUUID}
+	err := migration.UploadBinaries(migration.UploadBinariesConfig{
+		Charms:          archive.Charms(),
+		CharmDownloader: archive,
+		CharmUploader:   uploader,
+
+		ToolsDownloader: archive,
+		ToolsUploader:   uploader,
+
+		Resources:          archive.Resources(),
+		ResourceDownloader: archive,
+		ResourceUploader:   uploader,
+	})
+	if err != nil {
+		return errors.Annotate(err, "uploading binaries")
+	}
+	return errors.Annotate(client.Activate(modelUUID), "activating model")
+}
+
+// importUploader passes the imported model's UUID to the calls of the
+// ImportModelAPI used to upload its binaries.
+type importUploader struct {
+	client    ImportModelAPI
+	modelUUID string
+}
+
+// UploadTools is part of the migration.ToolsUploader interface.
+func (u *importUploader) UploadTools(r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	return u.client.UploadTools(u.modelUUID, r, vers, additionalSeries...)
+}
+
+// UploadCharm is part of the migration.CharmUploader interface.
+func (u *importUploader) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	return u.client.UploadCharm(u.modelUUID, curl, content)
+}
+
+// UploadResource is part of the migration.ResourceUploader interface.
+func (u *importUploader) UploadResource(res resource.Resource, content io.ReadSeeker) error {
+	return u.client.UploadResource(u.modelUUID, res, content)
+}
+
+// SetPlaceholderResource is part of the migration.ResourceUploader interface.
+func (u *importUploader) SetPlaceholderResource(res resource.Resource) error {
+	return u.client.SetPlaceholderResource(u.modelUUID, res)
+}
+
+// SetUnitResource is part of the migration.ResourceUploader interface.
+func (u *importUploader) SetUnitResource(unitName string, res resource.Resource) error {
+	return u.client.SetUnitResource(u.modelUUID, unitName, res)
+}
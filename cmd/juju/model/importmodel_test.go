@@ -0,0 +1,164 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
+)
+
+type ImportModelCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api      fakeImportModelClient
+	store    *jujuclient.MemStore
+	filename string
+}
+
+var _ = gc.Suite(&ImportModelCommandSuite{})
+
+type fakeImportModelClient struct {
+	gitjujutesting.Stub
+}
+
+func (f *fakeImportModelClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Import(bytes []byte) error {
+	f.MethodCall(f, "Import", bytes)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Activate(modelUUID string) error {
+	f.MethodCall(f, "Activate", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) Abort(modelUUID string) error {
+	f.MethodCall(f, "Abort", modelUUID)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) UploadCharm(modelUUID string, curl *charm.URL, content io.ReadSeeker) (*charm.URL, error) {
+	data, err := ioutil.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+	f.MethodCall(f, "UploadCharm", modelUUID, curl, string(data))
+	return curl, f.NextErr()
+}
+
+func (f *fakeImportModelClient) UploadTools(modelUUID string, r io.ReadSeeker, vers version.Binary, additionalSeries ...string) (tools.List, error) {
+	f.MethodCall(f, "UploadTools", modelUUID, vers)
+	return nil, f.NextErr()
+}
+
+func (f *fakeImportModelClient) UploadResource(modelUUID string, res resource.Resource, r io.ReadSeeker) error {
+	f.MethodCall(f, "UploadResource", modelUUID, res)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) SetPlaceholderResource(modelUUID string, res resource.Resource) error {
+	f.MethodCall(f, "SetPlaceholderResource", modelUUID, res)
+	return f.NextErr()
+}
+
+func (f *fakeImportModelClient) SetUnitResource(modelUUID, unit string, res resource.Resource) error {
+	f.MethodCall(f, "SetUnitResource", modelUUID, unit, res)
+	return f.NextErr()
+}
+
+func (s *ImportModelCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = fakeImportModelClient{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+
+	var buf bytes.Buffer
+	downloader := &fakeModelDownloader{}
+	err := migration.WriteModelArchive(&buf, migration.ModelArchiveConfig{
+		Model:              newDescriptionModel(c),
+		CharmDownloader:    downloader,
+		ResourceDownloader: downloader,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.filename = filepath.Join(c.MkDir(), "mymodel.tar.gz")
+	err = ioutil.WriteFile(s.filename, buf.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+const importedModelUUID = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+func (s *ImportModelCommandSuite) run(c *gc.C, args ...string) error {
+	_, err := cmdtesting.RunCommand(c, model.NewImportModelCommandForTest(&s.api, s.store, importedModelUUID), args...)
+	return err
+}
+
+func (s *ImportModelCommandSuite) TestImport(c *gc.C) {
+	err := s.run(c, s.filename)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Import", "UploadCharm", "Activate", "Close")
+
+	// A copy of the model is imported, with a UUID of its own.
+	imported, err := description.Deserialize(s.api.Calls()[0].Args[0].([]byte))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(imported.Tag().Id(), gc.Equals, importedModelUUID)
+	c.Check(imported.Config()["name"], gc.Equals, "mymodel")
+	c.Check(imported.Applications(), gc.HasLen, 1)
+
+	s.api.CheckCall(c, 1, "UploadCharm",
+		importedModelUUID, charm.MustParseURL("cs:bionic/mysql-1"), "cs:bionic/mysql-1 content")
+	s.api.CheckCall(c, 2, "Activate", importedModelUUID)
+}
+
+func (s *ImportModelCommandSuite) TestImportAbortsOnUploadFailure(c *gc.C) {
+	s.api.SetErrors(nil, errors.New("boom"))
+	err := s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "uploading binaries: cannot upload charm: boom")
+	s.api.CheckCallNames(c, "Import", "UploadCharm", "Abort", "Close")
+	s.api.CheckCall(c, 2, "Abort", importedModelUUID)
+}
+
+func (s *ImportModelCommandSuite) TestImportFails(c *gc.C) {
+	s.api.SetErrors(errors.New("model already exists"))
+	err := s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, "importing model: model already exists")
+	s.api.CheckCallNames(c, "Import", "Close")
+}
+
+func (s *ImportModelCommandSuite) TestImportNotAnArchive(c *gc.C) {
+	err := ioutil.WriteFile(s.filename, []byte("<not an archive>"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.run(c, s.filename)
+	c.Assert(err, gc.ErrorMatches, `reading ".*": while uncompressing model archive: .*`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *ImportModelCommandSuite) TestImportMissingFilename(c *gc.C) {
+	err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "missing filename")
+}
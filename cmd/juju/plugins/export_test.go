@@ -0,0 +1,35 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugins
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/version"
+)
+
+var (
+	SnapDir    = &snapDir
+	SnapBinDir = &snapBinDir
+)
+
+func NewListPluginsCommandForTest(current version.Number) cmd.Command {
+	return &listPluginsCommand{
+		find:    Find,
+		current: current,
+	}
+}
+
+func NewInstallPluginCommandForTest(installDir string, current version.Number) cmd.Command {
+	return &installPluginCommand{
+		installDir: installDir,
+		current:    current,
+	}
+}
+
+func NewRemovePluginCommandForTest(installDir string) cmd.Command {
+	return &removePluginCommand{
+		installDir: installDir,
+		lookup:     Lookup,
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+
+	jujucmd "github.com/juju/juju/cmd"
+	jujuversion "github.com/juju/juju/version"
+)
+
+var usageInstallPluginSummary = `
+Installs a plugin for the juju client.`[1:]
+
+var usageInstallPluginDetails = `
+The plugin executable must be named juju-<name>, and its manifest must be
+beside it, named juju-<name>.yaml. The manifest is YAML giving the
+plugin's name, its version and, optionally, the oldest version of juju
+it works with and a description:
+
+    name: <name>
+    version: 1.2.0
+    min-juju-version: 2.6.0
+    description: Does something useful.
+
+Both files are copied into the juju client's plugin directory. An
+installed plugin is used in preference to one of the same name on the
+PATH or installed as a snap.
+
+A plugin needing a newer juju, or one that is already installed, is only
+installed if --force is given.
+
+Examples:
+    juju install-plugin ./juju-wait
+    juju install-plugin --force ~/src/juju-wait/juju-wait
+
+See also:
+    plugins
+    remove-plugin`[1:]
+
+// NewInstallPluginCommand returns a command to install a plugin.
+func NewInstallPluginCommand() cmd.Command {
+	return &installPluginCommand{
+		installDir: InstallDir(),
+		current:    jujuversion.Current,
+	}
+}
+
+type installPluginCommand struct {
+	cmd.CommandBase
+
+	installDir string
+	current    version.Number
+
+	path  string
+	force bool
+}
+
+// Info implements cmd.Command.
+func (c *installPluginCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "install-plugin",
+		Args:    "<path to plugin>",
+		Purpose: usageInstallPluginSummary,
+		Doc:     usageInstallPluginDetails,
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *installPluginCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	f.BoolVar(&c.force, "force", false, "Install even if the plugin needs a newer juju or is already installed")
+}
+
+// Init implements cmd.Command.
+func (c *installPluginCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no plugin specified")
+	}
+	c.path = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *installPluginCommand) Run(ctx *cmd.Context) error {
+	path := ctx.AbsPath(c.path)
+	fullName := filepath.Base(path)
+	if !namePattern.MatchString(fullName) {
+		return errors.Errorf("plugin executable %q is not named %s<name>", fullName, Prefix)
+	}
+	name := fullName[len(Prefix):]
+	info, err := os.Stat(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if info.IsDir() || info.Mode()&0111 == 0 {
+		return errors.Errorf("plugin %q is not an executable file", path)
+	}
+
+	manifest, err := ReadManifest(path + ManifestSuffix)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := manifest.Validate(name); err != nil {
+		return errors.Trace(err)
+	}
+	if err := manifest.CheckCompatible(c.current); err != nil && !c.force {
+		return errors.Annotate(err, "use --force to install it anyway")
+	}
+
+	target := filepath.Join(c.installDir, fullName)
+	if _, err := os.Stat(target); err == nil && !c.force {
+		return errors.Errorf("plugin %q is already installed, use --force to replace it", name)
+	}
+	if err := os.MkdirAll(c.installDir, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	if err := utils.CopyFile(target+ManifestSuffix, path+ManifestSuffix); err != nil {
+		return errors.Annotatef(err, "cannot install manifest for plugin %q", name)
+	}
+	if err := utils.CopyFile(target, path); err != nil {
+		return errors.Annotatef(err, "cannot install plugin %q", name)
+	}
+	if err := os.Chmod(target, 0755); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Installed plugin %q version %s.", name, manifest.Version)
+	return nil
+}
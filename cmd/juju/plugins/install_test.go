@@ -0,0 +1,91 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugins_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/plugins"
+)
+
+type installSuite struct {
+	baseSuite
+
+	srcDir     string
+	installDir string
+}
+
+var _ = gc.Suite(&installSuite{})
+
+func (s *installSuite) SetUpTest(c *gc.C) {
+	s.baseSuite.SetUpTest(c)
+	s.srcDir = c.MkDir()
+	s.installDir = filepath.Join(c.MkDir(), "plugins")
+}
+
+func (s *installSuite) run(c *gc.C, args ...string) (string, error) {
+	command := plugins.NewInstallPluginCommandForTest(s.installDir, version.MustParse("2.6.0"))
+	ctx, err := cmdtesting.RunCommand(c, command, args...)
+	return cmdtesting.Stderr(ctx), err
+}
+
+func (s *installSuite) TestInstall(c *gc.C) {
+	path := s.makePlugin(c, s.srcDir, "foo", "name: foo\nversion: 1.0.0\n")
+
+	stderr, err := s.run(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderr, gc.Equals, "Installed plugin \"foo\" version 1.0.0.\n")
+
+	data, err := ioutil.ReadFile(filepath.Join(s.installDir, "juju-foo"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "#!/bin/sh\necho foo\n")
+	data, err = ioutil.ReadFile(filepath.Join(s.installDir, "juju-foo.yaml"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "name: foo\nversion: 1.0.0\n")
+}
+
+func (s *installSuite) TestInstallNoArgs(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "no plugin specified")
+}
+
+func (s *installSuite) TestInstallBadName(c *gc.C) {
+	path := filepath.Join(s.srcDir, "foo")
+	err := ioutil.WriteFile(path, nil, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.run(c, path)
+	c.Assert(err, gc.ErrorMatches, `plugin executable "foo" is not named juju-<name>`)
+}
+
+func (s *installSuite) TestInstallNoManifest(c *gc.C) {
+	path := s.makePlugin(c, s.srcDir, "foo", "")
+	_, err := s.run(c, path)
+	c.Assert(err, gc.ErrorMatches, `plugin manifest ".*/juju-foo.yaml" not found`)
+}
+
+func (s *installSuite) TestInstallNeedsNewerJuju(c *gc.C) {
+	path := s.makePlugin(c, s.srcDir, "foo", "name: foo\nversion: 1.0.0\nmin-juju-version: 2.7.0\n")
+	_, err := s.run(c, path)
+	c.Assert(err, gc.ErrorMatches, `use --force to install it anyway: plugin "foo" needs juju 2.7.0 or later, this is juju 2.6.0`)
+
+	_, err = s.run(c, "--force", path)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *installSuite) TestInstallAlreadyInstalled(c *gc.C) {
+	path := s.makePlugin(c, s.srcDir, "foo", "name: foo\nversion: 1.0.0\n")
+	_, err := s.run(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.run(c, path)
+	c.Assert(err, gc.ErrorMatches, `plugin "foo" is already installed, use --force to replace it`)
+	_, err = s.run(c, "--force", path)
+	c.Assert(err, jc.ErrorIsNil)
+}
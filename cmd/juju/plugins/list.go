@@ -0,0 +1,135 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugins
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/version"
+
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/output"
+	jujuversion "github.com/juju/juju/version"
+)
+
+var usageListPluginsSummary = `
+Lists the plugins available to the juju client.`[1:]
+
+var usageListPluginsDetails = `
+A plugin is an executable named juju-<name> which is run by
+"juju <name>". Plugins are found, in this order, among those installed
+with install-plugin, on the PATH, and installed as snaps.
+
+A plugin may come with a manifest giving its name, version and the
+oldest version of juju it works with. Plugins without a manifest are
+listed, but their version is unknown.
+
+Examples:
+    juju plugins
+    juju plugins --format yaml
+
+See also:
+    install-plugin
+    remove-plugin`[1:]
+
+// NewListPluginsCommand returns a command to list plugins.
+func NewListPluginsCommand() cmd.Command {
+	return &listPluginsCommand{
+		find:    Find,
+		current: jujuversion.Current,
+	}
+}
+
+type listPluginsCommand struct {
+	cmd.CommandBase
+	out cmd.Output
+
+	find    func() []Plugin
+	current version.Number
+}
+
+// PluginInfo holds the details of a plugin for output.
+type PluginInfo struct {
+	Name           string `yaml:"name" json:"name"`
+	Version        string `yaml:"version,omitempty" json:"version,omitempty"`
+	MinJujuVersion string `yaml:"min-juju-version,omitempty" json:"min-juju-version,omitempty"`
+	Description    string `yaml:"description,omitempty" json:"description,omitempty"`
+	Source         Source `yaml:"source" json:"source"`
+	Path           string `yaml:"path" json:"path"`
+	Problem        string `yaml:"problem,omitempty" json:"problem,omitempty"`
+}
+
+// Info implements cmd.Command.
+func (c *listPluginsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "plugins",
+		Purpose: usageListPluginsSummary,
+		Doc:     usageListPluginsDetails,
+		Aliases: []string{"list-plugins"},
+	})
+}
+
+// SetFlags implements cmd.Command.
+func (c *listPluginsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.CommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatPluginsTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *listPluginsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *listPluginsCommand) Run(ctx *cmd.Context) error {
+	plugins := c.find()
+	if len(plugins) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No plugins found.")
+		return nil
+	}
+	infos := make([]PluginInfo, len(plugins))
+	for i, plugin := range plugins {
+		infos[i] = PluginInfo{
+			Name:   plugin.Name,
+			Source: plugin.Source,
+			Path:   plugin.Path,
+		}
+		if plugin.Manifest == nil {
+			infos[i].Problem = "no manifest"
+			continue
+		}
+		infos[i].Version = plugin.Manifest.Version
+		infos[i].MinJujuVersion = plugin.Manifest.MinJujuVersion
+		infos[i].Description = plugin.Manifest.Description
+		if err := plugin.Manifest.CheckCompatible(c.current); err != nil {
+			infos[i].Problem = err.Error()
+		}
+	}
+	return c.out.Write(ctx, infos)
+}
+
+func formatPluginsTabular(writer io.Writer, value interface{}) error {
+	infos, ok := value.([]PluginInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	tw := output.TabWriter(writer)
+	fmt.Fprintln(tw, "Plugin\tVersion\tSource\tPath\tNotes")
+	for _, info := range infos {
+		vers := info.Version
+		if vers == "" {
+			vers = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", info.Name, vers, info.Source, info.Path, info.Problem)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugins_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/plugins"
+)
+
+type listSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&listSuite{})
+
+func (s *listSuite) run(c *gc.C, args ...string) (string, string, error) {
+	command := plugins.NewListPluginsCommandForTest(version.MustParse("2.6.0"))
+	ctx, err := cmdtesting.RunCommand(c, command, args...)
+	return cmdtesting.Stdout(ctx), cmdtesting.Stderr(ctx), err
+}
+
+func (s *listSuite) TestListNone(c *gc.C) {
+	stdout, stderr, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout, gc.Equals, "")
+	c.Assert(stderr, gc.Equals, "No plugins found.\n")
+}
+
+func (s *listSuite) TestListTabular(c *gc.C) {
+	s.makePlugin(c, s.pathDir, "bar", "")
+	s.makePlugin(c, plugins.InstallDir(), "foo", "name: foo\nversion: 1.0.0\nmin-juju-version: 2.7.0\n")
+
+	stdout, _, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout, gc.Matches, ""+
+		"Plugin +Version +Source +Path +Notes\n"+
+		"bar +- +path +.*/juju-bar +no manifest\n"+
+		`foo +1\.0\.0 +installed +.*/juju-foo +plugin "foo" needs juju 2\.7\.0 or later, this is juju 2\.6\.0\n`)
+}
+
+func (s *listSuite) TestListYAML(c *gc.C) {
+	path := s.makePlugin(c, s.pathDir, "foo", "name: foo\nversion: 1.0.0\ndescription: Does foo.\n")
+
+	stdout, _, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stdout, gc.Equals, ""+
+		"- name: foo\n"+
+		"  version: 1.0.0\n"+
+		"  description: Does foo.\n"+
+		"  source: path\n"+
+		"  path: "+path+"\n")
+}
@@ -0,0 +1,242 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package plugins finds and manages juju CLI plugins: executables named
+// juju-<name> that juju runs when asked for a command it doesn't know.
+package plugins
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/version"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/juju/osenv"
+)
+
+var logger = loggo.GetLogger("juju.cmd.juju.plugins")
+
+const (
+	// Prefix starts the name of every plugin executable.
+	Prefix = "juju-"
+
+	// ManifestSuffix is added to the name of a plugin executable to
+	// give the name of its manifest file.
+	ManifestSuffix = ".yaml"
+)
+
+var namePattern = regexp.MustCompile("^" + Prefix + "[a-zA-Z]")
+
+// Source says where a plugin was found.
+type Source string
+
+const (
+	// SourceInstalled is for plugins installed with install-plugin.
+	SourceInstalled Source = "installed"
+
+	// SourcePath is for plugins found on the PATH.
+	SourcePath Source = "path"
+
+	// SourceSnap is for plugins installed as snaps.
+	SourceSnap Source = "snap"
+)
+
+var (
+	// snapDir is where snaps are mounted, and snapBinDir is where
+	// their commands are linked.
+	snapDir    = "/snap"
+	snapBinDir = "/snap/bin"
+)
+
+// Manifest describes a plugin. It is read from a YAML file kept beside
+// the plugin executable, named after it with ManifestSuffix added. A
+// plugin installed as a snap keeps its manifest at the top of the snap.
+type Manifest struct {
+	Name           string `yaml:"name" json:"name"`
+	Version        string `yaml:"version" json:"version"`
+	MinJujuVersion string `yaml:"min-juju-version,omitempty" json:"min-juju-version,omitempty"`
+	Description    string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// Validate returns an error if the manifest isn't valid for the plugin
+// with the given name, without the prefix.
+func (m *Manifest) Validate(name string) error {
+	if m.Name != name {
+		return errors.NotValidf("manifest naming %q for plugin %q", m.Name, name)
+	}
+	if m.Version == "" {
+		return errors.NotValidf("manifest without version for plugin %q", name)
+	}
+	if m.MinJujuVersion != "" {
+		if _, err := version.Parse(m.MinJujuVersion); err != nil {
+			return errors.NotValidf("min-juju-version %q for plugin %q", m.MinJujuVersion, name)
+		}
+	}
+	return nil
+}
+
+// CheckCompatible returns an error if the plugin needs a newer juju
+// than the given version.
+func (m *Manifest) CheckCompatible(current version.Number) error {
+	if m.MinJujuVersion == "" {
+		return nil
+	}
+	min, err := version.Parse(m.MinJujuVersion)
+	if err != nil {
+		return errors.NotValidf("min-juju-version %q for plugin %q", m.MinJujuVersion, m.Name)
+	}
+	if current.Compare(min) < 0 {
+		return errors.Errorf("plugin %q needs juju %v or later, this is juju %v", m.Name, min, current)
+	}
+	return nil
+}
+
+// ReadManifest reads the plugin manifest at the given path. It returns
+// a NotFound error if there is no manifest there.
+func ReadManifest(path string) (*Manifest, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("plugin manifest %q", path)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse plugin manifest %q", path)
+	}
+	return &manifest, nil
+}
+
+// Plugin is a plugin found on this machine.
+type Plugin struct {
+	// Name is the name of the plugin, without the prefix.
+	Name string
+
+	// Path is the path of the plugin executable.
+	Path string
+
+	// Source says where the plugin was found.
+	Source Source
+
+	// Manifest describes the plugin. It is nil for plugins
+	// without a valid manifest.
+	Manifest *Manifest
+}
+
+// InstallDir returns the directory plugins are installed into.
+func InstallDir() string {
+	return osenv.JujuXDGDataHomePath("plugins")
+}
+
+type searchDir struct {
+	path   string
+	source Source
+}
+
+// searchDirs returns the directories plugins are looked for in, in
+// order of precedence.
+func searchDirs() []searchDir {
+	dirs := []searchDir{{InstallDir(), SourceInstalled}}
+	seenSnapBin := false
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		source := SourcePath
+		if dir == snapBinDir {
+			source = SourceSnap
+			seenSnapBin = true
+		}
+		dirs = append(dirs, searchDir{dir, source})
+	}
+	// A confined juju snap doesn't have the snap bin dir on its
+	// PATH, but plugins installed as snaps are found there.
+	if !seenSnapBin && os.Getenv("SNAP") != "" {
+		dirs = append(dirs, searchDir{snapBinDir, SourceSnap})
+	}
+	return dirs
+}
+
+// Find returns the plugins found on this machine, sorted by name. If a
+// plugin is found in more than one place, installed plugins take
+// precedence over those on the PATH, in PATH order, then snaps.
+func Find() []Plugin {
+	found := make(map[string]Plugin)
+	for _, dir := range searchDirs() {
+		entries, err := ioutil.ReadDir(dir.path)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			fullName := entry.Name()
+			if !namePattern.MatchString(fullName) || filepath.Ext(fullName) == ManifestSuffix {
+				continue
+			}
+			if entry.Mode()&0111 == 0 {
+				continue
+			}
+			name := fullName[len(Prefix):]
+			if _, ok := found[name]; ok {
+				continue
+			}
+			found[name] = newPlugin(name, filepath.Join(dir.path, fullName), dir.source)
+		}
+	}
+	plugins := make([]Plugin, 0, len(found))
+	for _, plugin := range found {
+		plugins = append(plugins, plugin)
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// Lookup returns the plugin with the given name, without the prefix,
+// from wherever Find would take it. It returns a NotFound error if
+// there is no such plugin.
+func Lookup(name string) (Plugin, error) {
+	fullName := Prefix + name
+	if !namePattern.MatchString(fullName) {
+		return Plugin{}, errors.NotFoundf("plugin %q", name)
+	}
+	for _, dir := range searchDirs() {
+		path := filepath.Join(dir.path, fullName)
+		info, err := os.Stat(path)
+		if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+			continue
+		}
+		return newPlugin(name, path, dir.source), nil
+	}
+	return Plugin{}, errors.NotFoundf("plugin %q", name)
+}
+
+func newPlugin(name, path string, source Source) Plugin {
+	plugin := Plugin{
+		Name:   name,
+		Path:   path,
+		Source: source,
+	}
+	manifestPath := path + ManifestSuffix
+	if source == SourceSnap {
+		fullName := Prefix + name
+		manifestPath = filepath.Join(snapDir, fullName, "current", fullName+ManifestSuffix)
+	}
+	manifest, err := ReadManifest(manifestPath)
+	if err == nil {
+		err = manifest.Validate(name)
+	}
+	if err == nil {
+		plugin.Manifest = manifest
+	} else if !errors.IsNotFound(err) {
+		logger.Warningf("ignoring manifest for plugin %q: %v", name, err)
+	}
+	return plugin
+}
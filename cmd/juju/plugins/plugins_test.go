@@ -0,0 +1,153 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugins_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/plugins"
+	"github.com/juju/juju/testing"
+)
+
+type baseSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+
+	pathDir    string
+	snapBinDir string
+}
+
+func (s *baseSuite) SetUpTest(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("plugins are found by their executable bit")
+	}
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.pathDir = c.MkDir()
+	s.PatchEnvironment("PATH", s.pathDir)
+	s.PatchEnvironment("SNAP", "")
+
+	snapDir := c.MkDir()
+	s.snapBinDir = filepath.Join(snapDir, "bin")
+	err := os.Mkdir(s.snapBinDir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(plugins.SnapDir, snapDir)
+	s.PatchValue(plugins.SnapBinDir, s.snapBinDir)
+}
+
+// makePlugin writes a plugin executable with the given name into dir,
+// and its manifest beside it if manifest isn't empty.
+func (s *baseSuite) makePlugin(c *gc.C, dir, name, manifest string) string {
+	err := os.MkdirAll(dir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	path := filepath.Join(dir, plugins.Prefix+name)
+	err = ioutil.WriteFile(path, []byte("#!/bin/sh\necho "+name+"\n"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	if manifest != "" {
+		err = ioutil.WriteFile(path+plugins.ManifestSuffix, []byte(manifest), 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	return path
+}
+
+type pluginsSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&pluginsSuite{})
+
+func (s *pluginsSuite) TestFindNone(c *gc.C) {
+	c.Assert(plugins.Find(), gc.HasLen, 0)
+}
+
+func (s *pluginsSuite) TestFind(c *gc.C) {
+	s.makePlugin(c, plugins.InstallDir(), "foo", "name: foo\nversion: 1.0.0\n")
+	s.makePlugin(c, s.pathDir, "foo", "")
+	bar := s.makePlugin(c, s.pathDir, "bar", "")
+	err := ioutil.WriteFile(filepath.Join(s.pathDir, "juju-noexec"), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	found := plugins.Find()
+	c.Assert(found, jc.DeepEquals, []plugins.Plugin{{
+		Name:   "bar",
+		Path:   bar,
+		Source: plugins.SourcePath,
+	}, {
+		Name:     "foo",
+		Path:     filepath.Join(plugins.InstallDir(), "juju-foo"),
+		Source:   plugins.SourceInstalled,
+		Manifest: &plugins.Manifest{Name: "foo", Version: "1.0.0"},
+	}})
+}
+
+func (s *pluginsSuite) TestFindInvalidManifest(c *gc.C) {
+	s.makePlugin(c, s.pathDir, "foo", "name: bar\nversion: 1.0.0\n")
+	found := plugins.Find()
+	c.Assert(found, gc.HasLen, 1)
+	c.Assert(found[0].Manifest, gc.IsNil)
+}
+
+func (s *pluginsSuite) TestFindSnap(c *gc.C) {
+	s.makePlugin(c, s.snapBinDir, "foo", "")
+	s.makePlugin(c, filepath.Join(*plugins.SnapDir, "juju-foo", "current"), "foo", "name: foo\nversion: 2.0.0\n")
+
+	// Snaps aren't looked for outside the juju snap unless the
+	// snap bin dir is on the PATH.
+	c.Assert(plugins.Find(), gc.HasLen, 0)
+
+	s.PatchEnvironment("SNAP", "/snap/juju/current")
+	found := plugins.Find()
+	c.Assert(found, jc.DeepEquals, []plugins.Plugin{{
+		Name:     "foo",
+		Path:     filepath.Join(s.snapBinDir, "juju-foo"),
+		Source:   plugins.SourceSnap,
+		Manifest: &plugins.Manifest{Name: "foo", Version: "2.0.0"},
+	}})
+}
+
+func (s *pluginsSuite) TestLookup(c *gc.C) {
+	s.makePlugin(c, s.pathDir, "foo", "")
+	installed := s.makePlugin(c, plugins.InstallDir(), "foo", "")
+
+	plugin, err := plugins.Lookup("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plugin.Path, gc.Equals, installed)
+	c.Assert(plugin.Source, gc.Equals, plugins.SourceInstalled)
+}
+
+func (s *pluginsSuite) TestLookupNotFound(c *gc.C) {
+	_, err := plugins.Lookup("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = plugins.Lookup("1foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *pluginsSuite) TestManifestValidate(c *gc.C) {
+	manifest := plugins.Manifest{Name: "foo", Version: "1.0", MinJujuVersion: "2.6.0"}
+	c.Assert(manifest.Validate("foo"), jc.ErrorIsNil)
+	c.Assert(manifest.Validate("bar"), gc.ErrorMatches, `manifest naming "foo" for plugin "bar" not valid`)
+
+	manifest.MinJujuVersion = "two"
+	c.Assert(manifest.Validate("foo"), gc.ErrorMatches, `min-juju-version "two" for plugin "foo" not valid`)
+
+	manifest = plugins.Manifest{Name: "foo"}
+	c.Assert(manifest.Validate("foo"), gc.ErrorMatches, `manifest without version for plugin "foo" not valid`)
+}
+
+func (s *pluginsSuite) TestManifestCheckCompatible(c *gc.C) {
+	manifest := plugins.Manifest{Name: "foo", Version: "1.0", MinJujuVersion: "2.6.0"}
+	c.Assert(manifest.CheckCompatible(version.MustParse("2.6.0")), jc.ErrorIsNil)
+	c.Assert(manifest.CheckCompatible(version.MustParse("2.7.1")), jc.ErrorIsNil)
+	c.Assert(manifest.CheckCompatible(version.MustParse("2.5.4")), gc.ErrorMatches,
+		`plugin "foo" needs juju 2.6.0 or later, this is juju 2.5.4`)
+
+	manifest.MinJujuVersion = ""
+	c.Assert(manifest.CheckCompatible(version.MustParse("2.5.4")), jc.ErrorIsNil)
+}
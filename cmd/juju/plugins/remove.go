@@ -0,0 +1,90 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	jujucmd "github.com/juju/juju/cmd"
+)
+
+var usageRemovePluginSummary = `
+Removes a plugin installed with install-plugin.`[1:]
+
+var usageRemovePluginDetails = `
+Only plugins installed with install-plugin can be removed. Plugins on
+the PATH or installed as snaps need to be removed the way they were
+installed.
+
+Examples:
+    juju remove-plugin wait
+
+See also:
+    install-plugin
+    plugins`[1:]
+
+// NewRemovePluginCommand returns a command to remove an installed plugin.
+func NewRemovePluginCommand() cmd.Command {
+	return &removePluginCommand{
+		installDir: InstallDir(),
+		lookup:     Lookup,
+	}
+}
+
+type removePluginCommand struct {
+	cmd.CommandBase
+
+	installDir string
+	lookup     func(name string) (Plugin, error)
+
+	name string
+}
+
+// Info implements cmd.Command.
+func (c *removePluginCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-plugin",
+		Args:    "<plugin name>",
+		Purpose: usageRemovePluginSummary,
+		Doc:     usageRemovePluginDetails,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *removePluginCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no plugin specified")
+	}
+	c.name = args[0]
+	if !namePattern.MatchString(Prefix+c.name) || strings.ContainsAny(c.name, `/\`) {
+		return errors.NotValidf("plugin name %q", c.name)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+// Run implements cmd.Command.
+func (c *removePluginCommand) Run(ctx *cmd.Context) error {
+	target := filepath.Join(c.installDir, Prefix+c.name)
+	if _, err := os.Stat(target); os.IsNotExist(err) {
+		if plugin, err := c.lookup(c.name); err == nil {
+			return errors.Errorf("plugin %q was not installed with install-plugin, it is at %s", c.name, plugin.Path)
+		}
+		return errors.NotFoundf("plugin %q", c.name)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if err := os.Remove(target); err != nil {
+		return errors.Annotatef(err, "cannot remove plugin %q", c.name)
+	}
+	if err := os.Remove(target + ManifestSuffix); err != nil && !os.IsNotExist(err) {
+		return errors.Annotatef(err, "cannot remove manifest for plugin %q", c.name)
+	}
+	ctx.Infof("Removed plugin %q.", c.name)
+	return nil
+}
@@ -0,0 +1,57 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugins_test
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/plugins"
+)
+
+type removeSuite struct {
+	baseSuite
+}
+
+var _ = gc.Suite(&removeSuite{})
+
+func (s *removeSuite) run(c *gc.C, args ...string) (string, error) {
+	command := plugins.NewRemovePluginCommandForTest(plugins.InstallDir())
+	ctx, err := cmdtesting.RunCommand(c, command, args...)
+	return cmdtesting.Stderr(ctx), err
+}
+
+func (s *removeSuite) TestRemove(c *gc.C) {
+	path := s.makePlugin(c, plugins.InstallDir(), "foo", "name: foo\nversion: 1.0.0\n")
+
+	stderr, err := s.run(c, "foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderr, gc.Equals, "Removed plugin \"foo\".\n")
+	_, err = os.Stat(path)
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+	_, err = os.Stat(path + plugins.ManifestSuffix)
+	c.Assert(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *removeSuite) TestRemoveNotInstalled(c *gc.C) {
+	path := s.makePlugin(c, s.pathDir, "foo", "")
+	_, err := s.run(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `plugin "foo" was not installed with install-plugin, it is at `+path)
+}
+
+func (s *removeSuite) TestRemoveNotFound(c *gc.C) {
+	_, err := s.run(c, "foo")
+	c.Assert(err, gc.ErrorMatches, `plugin "foo" not found`)
+}
+
+func (s *removeSuite) TestRemoveBadName(c *gc.C) {
+	_, err := s.run(c, "../foo")
+	c.Assert(err, gc.ErrorMatches, `plugin name "../foo" not valid`)
+	_, err = s.run(c, filepath.Join("foo", "bar"))
+	c.Assert(err, gc.ErrorMatches, `plugin name "foo/bar" not valid`)
+}
@@ -11,6 +11,7 @@ import (
 	charmresource "gopkg.in/juju/charm.v6/resource"
 
 	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/resource"
 )
 
 type stubCharmStore struct {
@@ -30,6 +31,17 @@ func (s *stubCharmStore) ListResources(charms []charmstore.CharmID) ([][]charmre
 
 type stubAPIClient struct {
 	stub *testing.Stub
+
+	ReturnListResources []resource.ApplicationResources
+}
+
+func (s *stubAPIClient) ListResources(applications []string) ([]resource.ApplicationResources, error) {
+	s.stub.AddCall("ListResources", applications)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnListResources, nil
 }
 
 func (s *stubAPIClient) Upload(application, name, filename string, resource io.ReadSeeker) error {
@@ -4,13 +4,17 @@
 package resource
 
 import (
+	"bytes"
+	"encoding/json"
 	"io"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	charmresource "gopkg.in/juju/charm.v6/resource"
 
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/resource"
 )
 
 // UploadClient has the API client methods needed by UploadCommand.
@@ -18,6 +22,9 @@ type UploadClient interface {
 	// Upload sends the resource to Juju.
 	Upload(application, name, filename string, resource io.ReadSeeker) error
 
+	// ListResources returns info about resources for applications in the model.
+	ListResources(applications []string) ([]resource.ApplicationResources, error)
+
 	// Close closes the client.
 	Close() error
 }
@@ -61,6 +68,11 @@ func (c *UploadCommand) Info() *cmd.Info {
 		Doc: `
 This command uploads a file from your local disk to the juju controller to be
 used as a resource for an application.
+
+For oci-image resources, the value may be a registry path or a YAML or JSON
+file holding the registry path of the image along with the username and
+password needed to pull it from a private registry. Attaching the resource
+again with new credentials replaces the stored ones.
 `,
 		Aliases: []string{"attach"},
 	})
@@ -125,6 +137,13 @@ func (c *UploadCommand) Run(*cmd.Context) error {
 // upload opens the given file and calls the apiclient to upload it to the given
 // application with the given name.
 func (c *UploadCommand) upload(rf resourceFile, client UploadClient) error {
+	resourceType, err := c.resourceType(rf, client)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if resourceType == charmresource.TypeContainerImage {
+		return errors.Trace(c.uploadDockerDetails(rf, client))
+	}
 	f, err := c.deps.OpenResource(rf.filename)
 	if err != nil {
 		return errors.Trace(err)
@@ -133,3 +152,36 @@ func (c *UploadCommand) upload(rf resourceFile, client UploadClient) error {
 	err = client.Upload(rf.application, rf.name, rf.filename, f)
 	return errors.Trace(err)
 }
+
+// resourceType returns the type of the application's resource that is
+// being uploaded. Resources the application does not define are
+// treated as files, leaving the controller to reject them.
+func (c *UploadCommand) resourceType(rf resourceFile, client UploadClient) (charmresource.Type, error) {
+	svcs, err := client.ListResources([]string{rf.application})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	for _, svc := range svcs {
+		for _, res := range svc.Resources {
+			if res.Name == rf.name {
+				return res.Type, nil
+			}
+		}
+	}
+	return charmresource.TypeFile, nil
+}
+
+// uploadDockerDetails sends the registry path and credentials for an
+// oci-image resource, read from the given file or registry path.
+func (c *UploadCommand) uploadDockerDetails(rf resourceFile, client UploadClient) error {
+	dockerDetails, err := getDockerDetailsData(rf.filename)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := json.Marshal(dockerDetails)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = client.Upload(rf.application, rf.name, rf.filename, bytes.NewReader(data))
+	return errors.Trace(err)
+}
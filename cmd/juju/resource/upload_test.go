@@ -4,13 +4,21 @@
 package resource_test
 
 import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+
 	jujucmd "github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	charmresource "gopkg.in/juju/charm.v6/resource"
 
 	resourcecmd "github.com/juju/juju/cmd/juju/resource"
+	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/resource"
 )
 
 var _ = gc.Suite(&UploadSuite{})
@@ -96,6 +104,11 @@ func (s *UploadSuite) TestInfo(c *gc.C) {
 		Doc: `
 This command uploads a file from your local disk to the juju controller to be
 used as a resource for an application.
+
+For oci-image resources, the value may be a registry path or a YAML or JSON
+file holding the registry path of the image along with the username and
+password needed to pull it from a private registry. Attaching the resource
+again with new credentials replaces the stored ones.
 `,
 		Aliases:        []string{"attach"},
 		FlagKnownAs:    "option",
@@ -119,13 +132,66 @@ func (s *UploadSuite) TestRun(c *gc.C) {
 
 	s.stub.CheckCallNames(c,
 		"NewClient",
+		"ListResources",
 		"OpenResource",
 		"Upload",
 		"FileClose",
 		"Close",
 	)
-	s.stub.CheckCall(c, 1, "OpenResource", "bar")
-	s.stub.CheckCall(c, 2, "Upload", "svc", "foo", "bar", file)
+	s.stub.CheckCall(c, 1, "ListResources", []string{"svc"})
+	s.stub.CheckCall(c, 2, "OpenResource", "bar")
+	s.stub.CheckCall(c, 3, "Upload", "svc", "foo", "bar", file)
+}
+
+func (s *UploadSuite) TestRunDockerImage(c *gc.C) {
+	client := &stubAPIClient{
+		stub: s.stub,
+		ReturnListResources: []resource.ApplicationResources{{
+			Resources: []resource.Resource{{
+				Resource: charmresource.Resource{
+					Meta: charmresource.Meta{
+						Name: "foo",
+						Type: charmresource.TypeContainerImage,
+					},
+				},
+			}},
+		}},
+	}
+	s.stubDeps.client = client
+	dir := c.MkDir()
+	path := filepath.Join(dir, "image.yaml")
+	err := ioutil.WriteFile(path, []byte(`
+registrypath: registry.example.com/team/mariadb:10.3
+username: docker-registry
+password: hunter2
+`), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	u := resourcecmd.NewUploadCommandForTest(resourcecmd.UploadDeps{
+		NewClient:    s.stubDeps.NewClient,
+		OpenResource: s.stubDeps.OpenResource,
+	})
+	err = u.Init([]string{"svc", "foo=" + path})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"ListResources",
+		"Upload",
+		"Close",
+	)
+	reader := s.stub.Calls()[2].Args[3].(io.Reader)
+	var details resources.DockerImageDetails
+	err = json.NewDecoder(reader).Decode(&details)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(details, jc.DeepEquals, resources.DockerImageDetails{
+		RegistryPath: "registry.example.com/team/mariadb:10.3",
+		Username:     "docker-registry",
+		Password:     "hunter2",
+	})
 }
 
 type stubUploadDeps struct {
@@ -0,0 +1,165 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/crashreports"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// NewCrashReportsCommand returns a command that lists the crash
+// reports uploaded by the agents of a model, or shows one of them.
+func NewCrashReportsCommand() cmd.Command {
+	return modelcmd.Wrap(&crashReportsCommand{})
+}
+
+// CrashReportsAPI is the API surface for the crash-reports command.
+type CrashReportsAPI interface {
+	BestAPIVersion() int
+	List() ([]params.CrashReportInfo, error)
+	Get(id string) (params.CrashReport, error)
+	Close() error
+}
+
+type crashReportsCommand struct {
+	modelcmd.ModelCommandBase
+	api     CrashReportsAPI
+	isoTime bool
+	id      string
+}
+
+const crashReportsDoc = `
+Lists the reports of the crashes of the machine and unit agents of a
+model, or shows the report with the given ID.
+
+When an agent panics, it records the panic, the stacks of its
+goroutines and the last lines it logged in a crash report, kept on
+its machine, and uploads the report to the controller once it is
+running again. The controller keeps the last 100 reports of a model.
+
+Examples:
+    juju crash-reports
+    juju crash-reports 20190503-101112-4f2a9c1e
+
+See also:
+    timeline
+    debug-log`
+
+func (c *crashReportsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "crash-reports",
+		Args:    "[<id>]",
+		Purpose: "Lists the crash reports of the agents of a model, or shows one of them.",
+		Doc:     crashReportsDoc,
+	})
+}
+
+func (c *crashReportsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+}
+
+func (c *crashReportsCommand) Init(args []string) error {
+	if len(args) > 1 {
+		return errors.Errorf("unexpected arguments after crash report ID.")
+	}
+	if len(args) == 1 {
+		c.id = args[0]
+	}
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		var err error
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *crashReportsCommand) getAPI() (CrashReportsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return crashreports.NewClient(root), nil
+}
+
+func (c *crashReportsCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiclient.Close()
+	if apiclient.BestAPIVersion() < 1 {
+		return errors.New("crash reports are not supported by this version of Juju")
+	}
+
+	if c.id != "" {
+		report, err := apiclient.Get(c.id)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.writeReport(ctx.Stdout, report)
+		return nil
+	}
+	infos, err := apiclient.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(infos) == 0 {
+		ctx.Infof("No crash reports to display.")
+		return nil
+	}
+	c.writeTabular(ctx.Stdout, infos)
+	return nil
+}
+
+func (c *crashReportsCommand) writeTabular(writer io.Writer, infos []params.CrashReportInfo) {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("ID", "Time", "Agent", "Version", "Panic")
+	for _, info := range infos {
+		when := info.Time
+		w.Print(info.ID, common.FormatTime(&when, c.isoTime), entityName(info.Agent), info.Version)
+		w.Println(info.Panic)
+	}
+	tw.Flush()
+}
+
+func (c *crashReportsCommand) writeReport(w io.Writer, report params.CrashReport) {
+	when := report.Time
+	fmt.Fprintf(w, "ID:       %s\n", report.ID)
+	fmt.Fprintf(w, "Time:     %s\n", common.FormatTime(&when, c.isoTime))
+	fmt.Fprintf(w, "Agent:    %s\n", entityName(report.Agent))
+	fmt.Fprintf(w, "Version:  %s\n", report.Version)
+	fmt.Fprintf(w, "\n%s\n", report.Panic)
+	if report.Goroutines != "" {
+		fmt.Fprintf(w, "\n%s\n", report.Goroutines)
+	}
+	if report.LogTail != "" {
+		fmt.Fprintf(w, "\nLog tail:\n%s", report.LogTail)
+	}
+}
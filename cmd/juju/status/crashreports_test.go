@@ -0,0 +1,150 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	statuscmd "github.com/juju/juju/cmd/juju/status"
+)
+
+type CrashReportsSuite struct {
+	testing.IsolationSuite
+	api *fakeCrashReportsAPI
+}
+
+var _ = gc.Suite(&CrashReportsSuite{})
+
+func (s *CrashReportsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	crashTime := time.Date(2019, 5, 3, 10, 11, 12, 0, time.UTC)
+	s.api = &fakeCrashReportsAPI{
+		Stub:    &testing.Stub{},
+		version: 1,
+		infos: []params.CrashReportInfo{{
+			ID:       "20190503-101112-deadbeef",
+			Agent:    "machine-0",
+			Version:  "2.6.1",
+			Time:     crashTime,
+			Panic:    "panic: runtime error: invalid memory address or nil pointer dereference",
+			Size:     2048,
+			Uploaded: crashTime.Add(time.Minute),
+		}, {
+			ID:       "20190503-111112-cafef00d",
+			Agent:    "unit-mysql-0",
+			Version:  "2.6.1",
+			Time:     crashTime.Add(time.Hour),
+			Panic:    "fatal error: concurrent map writes",
+			Size:     1024,
+			Uploaded: crashTime.Add(time.Hour + time.Minute),
+		}},
+		report: params.CrashReport{
+			ID:         "20190503-101112-deadbeef",
+			Agent:      "machine-0",
+			Version:    "2.6.1",
+			Time:       crashTime,
+			Panic:      "panic: boom",
+			Goroutines: "goroutine 1 [running]:\nmain.main()",
+			LogTail:    "2019-05-03 10:11:11 INFO juju.worker started\n",
+		},
+	}
+}
+
+func (s *CrashReportsSuite) runCrashReports(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, statuscmd.NewTestCrashReportsCommand(s.api), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *CrashReportsSuite) TestInvalidArguments(c *gc.C) {
+	_, err := s.runCrashReports(c, "one", "two")
+	c.Assert(err, gc.ErrorMatches, "unexpected arguments after crash report ID.")
+}
+
+func (s *CrashReportsSuite) TestList(c *gc.C) {
+	out, err := s.runCrashReports(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, ""+
+		"ID                        Time                  Agent    Version  Panic\n"+
+		"20190503-101112-deadbeef  2019-05-03 10:11:12Z  0        2.6.1    panic: runtime error: invalid memory address or nil pointer dereference\n"+
+		"20190503-111112-cafef00d  2019-05-03 11:11:12Z  mysql/0  2.6.1    fatal error: concurrent map writes\n")
+	s.api.CheckCallNames(c, "List", "Close")
+}
+
+func (s *CrashReportsSuite) TestListNone(c *gc.C) {
+	s.api.infos = nil
+	ctx, err := cmdtesting.RunCommand(c, statuscmd.NewTestCrashReportsCommand(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No crash reports to display.\n")
+}
+
+func (s *CrashReportsSuite) TestShow(c *gc.C) {
+	out, err := s.runCrashReports(c, "20190503-101112-deadbeef", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, ""+
+		"ID:       20190503-101112-deadbeef\n"+
+		"Time:     2019-05-03 10:11:12Z\n"+
+		"Agent:    0\n"+
+		"Version:  2.6.1\n"+
+		"\n"+
+		"panic: boom\n"+
+		"\n"+
+		"goroutine 1 [running]:\n"+
+		"main.main()\n"+
+		"\n"+
+		"Log tail:\n"+
+		"2019-05-03 10:11:11 INFO juju.worker started\n")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Get", []interface{}{"20190503-101112-deadbeef"}},
+		{"Close", nil},
+	})
+}
+
+func (s *CrashReportsSuite) TestOldServer(c *gc.C) {
+	s.api.version = 0
+	_, err := s.runCrashReports(c)
+	c.Assert(err, gc.ErrorMatches, "crash reports are not supported by this version of Juju")
+}
+
+func (s *CrashReportsSuite) TestFail(c *gc.C) {
+	s.api.SetErrors(errors.NotFoundf(`crash report "missing"`))
+	_, err := s.runCrashReports(c, "missing")
+	c.Assert(err, gc.ErrorMatches, `crash report "missing" not found`)
+}
+
+type fakeCrashReportsAPI struct {
+	*testing.Stub
+	version int
+	infos   []params.CrashReportInfo
+	report  params.CrashReport
+}
+
+func (f *fakeCrashReportsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeCrashReportsAPI) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeCrashReportsAPI) List() ([]params.CrashReportInfo, error) {
+	f.MethodCall(f, "List")
+	return f.infos, f.NextErr()
+}
+
+func (f *fakeCrashReportsAPI) Get(id string) (params.CrashReport, error) {
+	f.MethodCall(f, "Get", id)
+	return f.report, f.NextErr()
+}
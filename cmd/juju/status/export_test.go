@@ -18,3 +18,15 @@ func NewTestStatusCommand(statusapi statusAPI, storageapi storage.StorageListAPI
 	return modelcmd.Wrap(
 		&statusCommand{statusAPI: statusapi, storageAPI: storageapi, clock: clock})
 }
+
+func NewTestModelsStatusCommand(api modelsStatusAPI) cmd.Command {
+	return modelcmd.Wrap(&statusCommand{modelsStatusAPI: api})
+}
+
+func NewTestTimelineCommand(api TimelineAPI) cmd.Command {
+	return &timelineCommand{api: api}
+}
+
+func NewTestCrashReportsCommand(api CrashReportsAPI) cmd.Command {
+	return &crashReportsCommand{api: api}
+}
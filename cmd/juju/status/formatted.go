@@ -77,6 +77,8 @@ type machineStatus struct {
 	Hardware           string                        `json:"hardware,omitempty" yaml:"hardware,omitempty"`
 	HAStatus           string                        `json:"controller-member-status,omitempty" yaml:"controller-member-status,omitempty"`
 	LXDProfiles        map[string]lxdProfileContents `json:"lxd-profiles,omitempty" yaml:"lxd-profiles,omitempty"`
+	NetworkConfigDrift []string                      `json:"network-config-drift,omitempty" yaml:"network-config-drift,omitempty"`
+	Idle               string                        `json:"idle,omitempty" yaml:"idle,omitempty"`
 }
 
 // A goyaml bug means we can't declare these types
@@ -127,6 +129,7 @@ type applicationStatus struct {
 	ProviderId       string                `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
 	Address          string                `json:"address,omitempty" yaml:"address,omitempty"`
 	Exposed          bool                  `json:"exposed" yaml:"exposed"`
+	LoadBalancer     []string              `json:"load-balancer-addresses,omitempty" yaml:"load-balancer-addresses,omitempty"`
 	Life             string                `json:"life,omitempty" yaml:"life,omitempty"`
 	StatusInfo       statusInfoContents    `json:"application-status,omitempty" yaml:"application-status"`
 	Relations        map[string][]string   `json:"relations,omitempty" yaml:"relations,omitempty"`
@@ -228,6 +231,7 @@ type unitStatus struct {
 	Address       string                `json:"address,omitempty" yaml:"address,omitempty"`
 	ProviderId    string                `json:"provider-id,omitempty" yaml:"provider-id,omitempty"`
 	Subordinates  map[string]unitStatus `json:"subordinates,omitempty" yaml:"subordinates,omitempty"`
+	Idle          string                `json:"idle,omitempty" yaml:"idle,omitempty"`
 }
 
 func (s *formattedStatus) applicationScale(name string) (string, bool) {
@@ -163,6 +163,8 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		Constraints:        machine.Constraints,
 		Hardware:           machine.Hardware,
 		LXDProfiles:        make(map[string]lxdProfileContents),
+		NetworkConfigDrift: machine.NetworkConfigDrift,
+		Idle:               machine.Idle,
 	}
 
 	for k, d := range machine.NetworkInterfaces {
@@ -240,6 +242,7 @@ func (sf *statusFormatter) formatApplication(name string, application params.App
 		CharmVersion:     application.CharmVersion,
 		CharmProfile:     application.CharmProfile,
 		Exposed:          application.Exposed,
+		LoadBalancer:     application.LoadBalancerAddresses,
 		Life:             application.Life,
 		Scale:            application.Scale,
 		ProviderId:       application.ProviderId,
@@ -393,6 +396,7 @@ func (sf *statusFormatter) formatUnit(info unitFormatInfo) unitStatus {
 		Charm:              info.unit.Charm,
 		Subordinates:       make(map[string]unitStatus),
 		Leader:             info.unit.Leader,
+		Idle:               info.unit.Idle,
 	}
 
 	if ms, ok := info.meterStatuses[info.unitName]; ok {
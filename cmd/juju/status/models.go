@@ -0,0 +1,325 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	controllerapi "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+)
+
+// modelsStatusAPI is used to get the status of several models from the
+// controller.
+type modelsStatusAPI interface {
+	DashboardSummary(all bool) (params.DashboardSummaryResult, error)
+	ModelsFullStatus(models []names.ModelTag, patterns []string) ([]params.ModelFullStatusResult, error)
+	Close() error
+}
+
+var newAPIClientForModelsStatus = func(c *statusCommand) (modelsStatusAPI, error) {
+	if c.modelsStatusAPI == nil {
+		root, err := c.NewControllerAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		c.modelsStatusAPI = controllerapi.NewClient(root)
+	}
+	return c.modelsStatusAPI, nil
+}
+
+// statusModel identifies a model whose status is displayed.
+type statusModel struct {
+	name string
+	tag  names.ModelTag
+}
+
+// modelFormattedStatus holds the formatted status of one of several
+// models.
+type modelFormattedStatus struct {
+	name   string
+	status formattedStatus
+}
+
+// modelSummary is the summary of a model displayed by --summary.
+type modelSummary struct {
+	Status            string              `json:"status" yaml:"status"`
+	ApplicationStatus map[string]int      `json:"application-status,omitempty" yaml:"application-status,omitempty"`
+	UnitStatus        map[string]int      `json:"unit-status,omitempty" yaml:"unit-status,omitempty"`
+	RecentErrors      []modelSummaryError `json:"recent-errors,omitempty" yaml:"recent-errors,omitempty"`
+	Version           string              `json:"version,omitempty" yaml:"version,omitempty"`
+	AvailableVersion  string              `json:"available-version,omitempty" yaml:"available-version,omitempty"`
+}
+
+type modelSummaryError struct {
+	Entity  string `json:"entity" yaml:"entity"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// runModels displays the status of the named models, or of all the
+// models the user has access to if there are no names.
+func (c *statusCommand) runModels(ctx *cmd.Context, modelNames []string) error {
+	for _, name := range modelNames {
+		if name == "" {
+			return errors.New("empty model name")
+		}
+	}
+	api, err := newAPIClientForModelsStatus(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.summary {
+		return c.runModelsSummary(ctx, api, modelNames)
+	}
+
+	models, err := c.statusModels(api, modelNames)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tags := make([]names.ModelTag, len(models))
+	for i, model := range models {
+		tags[i] = model.tag
+	}
+	results, err := api.ModelsFullStatus(tags, c.patterns)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var statuses []modelFormattedStatus
+	failed := false
+	for i, result := range results {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.Stderr, "model %q: %v\n", models[i].name, result.Error)
+			failed = true
+			continue
+		}
+		formatted, err := newStatusFormatter(newStatusFormatterParams{
+			status:         result.Result,
+			controllerName: controllerName,
+			isoTime:        c.isoTime,
+			showRelations:  c.relations || c.out.Name() != "tabular",
+		}).format()
+		if err != nil {
+			return errors.Annotatef(err, "model %q", models[i].name)
+		}
+		statuses = append(statuses, modelFormattedStatus{
+			name:   models[i].name,
+			status: formatted,
+		})
+	}
+
+	switch c.out.Name() {
+	case "yaml", "json":
+		byName := make(map[string]formattedStatus)
+		for _, s := range statuses {
+			byName[s.name] = s.status
+		}
+		err = c.out.Write(ctx, byName)
+	default:
+		err = c.out.WriteFormatter(ctx, c.formatModels(c.formatters()[c.out.Name()]), statuses)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// statusModels returns the named models, or all the models the user
+// has access to if there are no names.
+func (c *statusCommand) statusModels(api modelsStatusAPI, modelNames []string) ([]statusModel, error) {
+	if len(modelNames) == 0 {
+		summary, err := api.DashboardSummary(false)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		models := make([]statusModel, len(summary.Models))
+		for i, model := range summary.Models {
+			tag, err := names.ParseModelTag(model.ModelTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			models[i] = statusModel{
+				name: qualifiedModelName(model),
+				tag:  tag,
+			}
+		}
+		sort.Slice(models, func(i, j int) bool {
+			return models[i].name < models[j].name
+		})
+		return models, nil
+	}
+	uuids, err := c.ModelUUIDs(modelNames)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	models := make([]statusModel, len(modelNames))
+	for i, name := range modelNames {
+		models[i] = statusModel{
+			name: name,
+			tag:  names.NewModelTag(uuids[i]),
+		}
+	}
+	return models, nil
+}
+
+// runModelsSummary displays a summary of each of the named models, or
+// of all the models the user has access to if there are no names. The
+// summaries are aggregated by the controller in a single call.
+func (c *statusCommand) runModelsSummary(ctx *cmd.Context, api modelsStatusAPI, modelNames []string) error {
+	// Superusers may name models they don't have access to themselves.
+	result, err := api.DashboardSummary(len(modelNames) > 0)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	byTag := make(map[string]params.ModelDashboardSummary)
+	for _, model := range result.Models {
+		byTag[model.ModelTag] = model
+	}
+	var summaries []params.ModelDashboardSummary
+	var displayNames []string
+	if len(modelNames) == 0 {
+		summaries = result.Models
+		for _, model := range summaries {
+			displayNames = append(displayNames, qualifiedModelName(model))
+		}
+	} else {
+		uuids, err := c.ModelUUIDs(modelNames)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for i, uuid := range uuids {
+			model, ok := byTag[names.NewModelTag(uuid).String()]
+			if !ok {
+				return errors.NotFoundf("model %q", modelNames[i])
+			}
+			summaries = append(summaries, model)
+			displayNames = append(displayNames, modelNames[i])
+		}
+	}
+
+	formatted := make(map[string]modelSummary)
+	for i, model := range summaries {
+		summary := modelSummary{
+			Status:            string(model.Status.Status),
+			ApplicationStatus: model.ApplicationStatus,
+			UnitStatus:        model.UnitStatus,
+			Version:           versionString(model.AgentVersion),
+			AvailableVersion:  versionString(model.AvailableVersion),
+		}
+		for _, statusErr := range model.RecentErrors {
+			summary.RecentErrors = append(summary.RecentErrors, modelSummaryError{
+				Entity:  entityName(statusErr.Tag),
+				Message: statusErr.Message,
+			})
+		}
+		formatted[displayNames[i]] = summary
+	}
+	if c.out.Name() == "tabular" {
+		return c.out.WriteFormatter(ctx, formatModelSummariesTabular, formatted)
+	}
+	return c.out.Write(ctx, formatted)
+}
+
+// formatModels returns a formatter that formats the status of each of
+// several models in turn with the given formatter.
+func (c *statusCommand) formatModels(formatter cmd.Formatter) cmd.Formatter {
+	return func(writer io.Writer, value interface{}) error {
+		statuses, ok := value.([]modelFormattedStatus)
+		if !ok {
+			return errors.Errorf("expected value of type %T, got %T", statuses, value)
+		}
+		for i, s := range statuses {
+			if i > 0 {
+				fmt.Fprintln(writer)
+			}
+			if c.out.Name() != "tabular" {
+				// Only the tabular format shows the model's name.
+				fmt.Fprintf(writer, "Model %s:\n", s.name)
+			}
+			if err := formatter(writer, s.status); err != nil {
+				return errors.Annotatef(err, "model %q", s.name)
+			}
+		}
+		return nil
+	}
+}
+
+// formatModelSummariesTabular writes a tabular summary of each model.
+func formatModelSummariesTabular(writer io.Writer, value interface{}) error {
+	summaries, ok := value.(map[string]modelSummary)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", summaries, value)
+	}
+	modelNames := make([]string, 0, len(summaries))
+	for name := range summaries {
+		modelNames = append(modelNames, name)
+	}
+	sort.Strings(modelNames)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Model", "Status", "Apps", "Units", "Errors", "Version", "Upgrade")
+	for _, name := range modelNames {
+		summary := summaries[name]
+		w.Println(
+			name,
+			summary.Status,
+			sumCounts(summary.ApplicationStatus),
+			sumCounts(summary.UnitStatus),
+			summary.UnitStatus["error"],
+			summary.Version,
+			summary.AvailableVersion,
+		)
+	}
+	return tw.Flush()
+}
+
+func qualifiedModelName(model params.ModelDashboardSummary) string {
+	owner, err := names.ParseUserTag(model.OwnerTag)
+	if err != nil {
+		return model.Name
+	}
+	return jujuclient.JoinOwnerModelName(owner, model.Name)
+}
+
+func sumCounts(counts map[string]int) int {
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	return total
+}
+
+func versionString(v *version.Number) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
+
+// entityName returns the id of the entity with the given tag, or the
+// tag itself if it isn't valid.
+func entityName(tagString string) string {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return tagString
+	}
+	return tag.Id()
+}
@@ -0,0 +1,191 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/status"
+	corestatus "github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+const (
+	stagingUUID    = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	productionUUID = "deadbeef-0bad-400d-8000-4b1d0d06f0ff"
+)
+
+type ModelsStatusSuite struct {
+	testing.BaseSuite
+
+	api *fakeModelsStatusAPI
+}
+
+var _ = gc.Suite(&ModelsStatusSuite{})
+
+func (s *ModelsStatusSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.SetModelAndController(c, "test", "admin/test")
+	store := jujuclient.NewFileClientStore()
+	for name, uuid := range map[string]string{
+		"admin/staging":    stagingUUID,
+		"admin/production": productionUUID,
+	} {
+		err := store.UpdateModel("test", name, jujuclient.ModelDetails{ModelUUID: uuid})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	current := version.MustParse("2.7.0")
+	available := version.MustParse("2.7.1")
+	s.api = &fakeModelsStatusAPI{
+		statuses: map[string]params.ModelFullStatusResult{
+			stagingUUID: {Result: &params.FullStatus{
+				Model: params.ModelStatusInfo{Name: "staging", CloudTag: "cloud-foo"},
+			}},
+			productionUUID: {Result: &params.FullStatus{
+				Model: params.ModelStatusInfo{Name: "production", CloudTag: "cloud-foo"},
+			}},
+		},
+		summary: params.DashboardSummaryResult{
+			Models: []params.ModelDashboardSummary{{
+				ModelTag:          names.NewModelTag(stagingUUID).String(),
+				Name:              "staging",
+				OwnerTag:          "user-admin",
+				Status:            params.EntityStatus{Status: corestatus.Available},
+				ApplicationStatus: map[string]int{"active": 2},
+				UnitStatus:        map[string]int{"active": 2, "error": 1},
+				RecentErrors: []params.EntityStatusError{{
+					Tag:     "unit-mysql-0",
+					Message: "hook failed",
+				}},
+				AgentVersion:     &current,
+				AvailableVersion: &available,
+			}, {
+				ModelTag:          names.NewModelTag(productionUUID).String(),
+				Name:              "production",
+				OwnerTag:          "user-admin",
+				Status:            params.EntityStatus{Status: corestatus.Available},
+				ApplicationStatus: map[string]int{"active": 1},
+				UnitStatus:        map[string]int{"active": 3},
+				AgentVersion:      &current,
+			}},
+		},
+	}
+}
+
+func (s *ModelsStatusSuite) runStatus(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, status.NewTestModelsStatusCommand(s.api), args...)
+}
+
+func (s *ModelsStatusSuite) TestStatusOfModels(c *gc.C) {
+	ctx, err := s.runStatus(c, "-m", "admin/staging,admin/production", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.models, jc.DeepEquals, []names.ModelTag{
+		names.NewModelTag(stagingUUID),
+		names.NewModelTag(productionUUID),
+	})
+	c.Check(s.api.patterns, jc.DeepEquals, []string{"mysql"})
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model    Controller  Cloud/Region  Version
+staging  test        foo           
+
+Model       Controller  Cloud/Region  Version
+production  test        foo           
+
+`[1:])
+}
+
+func (s *ModelsStatusSuite) TestStatusOfAllModels(c *gc.C) {
+	ctx, err := s.runStatus(c, "--all-models", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.summaryAll, jc.IsFalse)
+	c.Check(s.api.models, jc.DeepEquals, []names.ModelTag{
+		names.NewModelTag(productionUUID),
+		names.NewModelTag(stagingUUID),
+	})
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "admin/production:\n  model:\n    name: production\n")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "admin/staging:\n  model:\n    name: staging\n")
+}
+
+func (s *ModelsStatusSuite) TestStatusOfModelsError(c *gc.C) {
+	s.api.statuses[stagingUUID] = params.ModelFullStatusResult{
+		Error: &params.Error{Message: "permission denied"},
+	}
+	ctx, err := s.runStatus(c, "-m", "admin/staging,admin/production")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "model \"admin/staging\": permission denied\n")
+	c.Check(cmdtesting.Stdout(ctx), jc.Contains, "production")
+}
+
+func (s *ModelsStatusSuite) TestSummary(c *gc.C) {
+	ctx, err := s.runStatus(c, "--all-models", "--summary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.models, gc.IsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+Model             Status     Apps  Units  Errors  Version  Upgrade
+admin/production  available  1     3      0       2.7.0    
+admin/staging     available  2     3      1       2.7.0    2.7.1
+
+`[1:])
+}
+
+func (s *ModelsStatusSuite) TestSummaryOfModels(c *gc.C) {
+	ctx, err := s.runStatus(c, "-m", "admin/staging", "--summary", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.api.summaryAll, jc.IsTrue)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+admin/staging:
+  status: available
+  application-status:
+    active: 2
+  unit-status:
+    active: 2
+    error: 1
+  recent-errors:
+  - entity: mysql/0
+    message: hook failed
+  version: 2.7.0
+  available-version: 2.7.1
+`[1:])
+}
+
+func (s *ModelsStatusSuite) TestSummaryUnsupportedFormat(c *gc.C) {
+	_, err := s.runStatus(c, "--summary", "--format", "oneline")
+	c.Assert(err, gc.ErrorMatches, "--summary does not support the oneline format")
+}
+
+type fakeModelsStatusAPI struct {
+	statuses   map[string]params.ModelFullStatusResult
+	summary    params.DashboardSummaryResult
+	summaryAll bool
+	models     []names.ModelTag
+	patterns   []string
+}
+
+func (f *fakeModelsStatusAPI) DashboardSummary(all bool) (params.DashboardSummaryResult, error) {
+	f.summaryAll = all
+	return f.summary, nil
+}
+
+func (f *fakeModelsStatusAPI) ModelsFullStatus(models []names.ModelTag, patterns []string) ([]params.ModelFullStatusResult, error) {
+	f.models = models
+	f.patterns = patterns
+	results := make([]params.ModelFullStatusResult, len(models))
+	for i, tag := range models {
+		results[i] = f.statuses[tag.Id()]
+		results[i].ModelTag = tag.String()
+	}
+	return results, nil
+}
+
+func (*fakeModelsStatusAPI) Close() error {
+	return nil
+}
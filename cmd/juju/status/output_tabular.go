@@ -148,14 +148,17 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 			version = version[:truncatedWidth] + ellipsis
 		}
 		// Notes may well contain other things later.
-		notes := ""
+		var notes []string
 		if app.Exposed {
-			notes = "exposed"
+			notes = append(notes, "exposed")
+		}
+		if app.CanUpgradeTo != "" {
+			notes = append(notes, "upgrade available")
 		}
 		// Expose any operator messages.
 		if fs.Model.Type == caasModelType {
 			if app.StatusInfo.Message != "" {
-				notes = app.StatusInfo.Message
+				notes = []string{app.StatusInfo.Message}
 			}
 		}
 		w.Print(appName, version)
@@ -175,7 +178,7 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 			w.Print(app.Address)
 		}
 
-		w.Println(notes)
+		w.Println(strings.Join(notes, ", "))
 		for un, u := range app.Units {
 			units[un] = u
 			if u.MeterStatus != nil {
@@ -68,6 +68,19 @@ type statusCommand struct {
 
 	// storage indicates if 'storage' section is displayed
 	storage bool
+
+	// allModels indicates if the status of all the models the user
+	// can access is displayed.
+	allModels bool
+
+	// summary indicates if only a summary of each model is displayed.
+	summary bool
+
+	// readOnly indicates if the status is read from the controller's
+	// secondary replicas.
+	readOnly bool
+
+	modelsStatusAPI modelsStatusAPI
 }
 
 var usageSummary = `
@@ -110,12 +123,31 @@ In tabular format, 'Relations' section is not displayed by default.
 Use --relations option to see this section. This option is ignored in all other
 formats.
 
+The status of several models can be displayed at once, by giving a comma
+separated list of models to the -m option, or by using the --all-models option
+to display the status of every model you have access to. The status of the
+models is gathered by the controller, and each model's status is displayed in
+turn. Filter patterns apply to each model. The 'storage' section is not
+displayed for several models.
+
+The --summary option displays a one line summary of each model instead: the
+number of applications and units, the number of units in error, and whether a
+newer agent version is available. It supports the tabular, yaml and json
+formats.
+
+The --read-only option asks the controller to read the status from the
+secondary members of its replica set, taking load off the primary. The
+status may then lag slightly behind the latest changes.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
     juju show-status --relations
     juju show-status --storage
+    juju show-status -m staging,production
+    juju show-status --all-models --summary
+    juju show-status --read-only
 
 See also:
     machines
@@ -142,6 +174,10 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.relations, "relations", false, "Show 'relations' section")
 	f.BoolVar(&c.storage, "storage", false, "Show 'storage' section")
 
+	f.BoolVar(&c.allModels, "all-models", false, "Show the status of all the models you have access to")
+	f.BoolVar(&c.summary, "summary", false, "Show a one line summary of each model")
+	f.BoolVar(&c.readOnly, "read-only", false, "Read the status from the controller's secondary replicas")
+
 	f.IntVar(&c.retryCount, "retry-count", 3, "Number of times to retry API failures")
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
 
@@ -162,7 +198,11 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	defaultFormat := "tabular"
 
-	c.out.AddFlags(f, defaultFormat, map[string]cmd.Formatter{
+	c.out.AddFlags(f, defaultFormat, c.formatters())
+}
+
+func (c *statusCommand) formatters() map[string]cmd.Formatter {
+	return map[string]cmd.Formatter{
 		"yaml":    cmd.FormatYaml,
 		"json":    cmd.FormatJson,
 		"short":   FormatOneline,
@@ -170,7 +210,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 		"line":    FormatOneline,
 		"tabular": c.FormatTabular,
 		"summary": FormatSummary,
-	})
+	}
 }
 
 func (c *statusCommand) Init(args []string) error {
@@ -186,6 +226,14 @@ func (c *statusCommand) Init(args []string) error {
 			}
 		}
 	}
+	if c.summary {
+		switch c.out.Name() {
+		case "tabular", "yaml", "json":
+		default:
+			return errors.Errorf("--summary does not support the %s format", c.out.Name())
+		}
+	}
+	c.SetReadOnlyAPI(c.readOnly)
 	if c.clock == nil {
 		c.clock = clock.WallClock
 	}
@@ -223,6 +271,9 @@ func (c *statusCommand) close() {
 	if c.storageAPI != nil {
 		c.storageAPI.Close()
 	}
+	if c.modelsStatusAPI != nil {
+		c.modelsStatusAPI.Close()
+	}
 	return
 }
 
@@ -253,6 +304,17 @@ func (c *statusCommand) getStorageInfo(ctx *cmd.Context) (*storage.CombinedStora
 func (c *statusCommand) Run(ctx *cmd.Context) error {
 	defer c.close()
 
+	if c.allModels {
+		return c.runModels(ctx, nil)
+	}
+	modelName, err := c.ModelName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if modelNames := strings.Split(modelName, ","); len(modelNames) > 1 || c.summary {
+		return c.runModels(ctx, modelNames)
+	}
+
 	// Always attempt to get the status at least once, and retry if it fails.
 	status, err := c.getStatus()
 	if err != nil {
@@ -0,0 +1,223 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/timeline"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// NewTimelineCommand returns a command that reports the timeline of
+// events of a model or one of its entities.
+func NewTimelineCommand() cmd.Command {
+	return modelcmd.Wrap(&timelineCommand{})
+}
+
+// TimelineAPI is the API surface for the timeline command.
+type TimelineAPI interface {
+	BestAPIVersion() int
+	Timeline(args params.TimelineArgs) ([]params.TimelineEvent, error)
+	Close() error
+}
+
+type timelineCommand struct {
+	modelcmd.ModelCommandBase
+	api         TimelineAPI
+	kinds       string
+	backlogSize int
+	backlogDate string
+	isoTime     bool
+	archived    bool
+	entity      names.Tag
+	date        time.Time
+}
+
+const timelineDoc = `
+Shows the events of a model, or of one of its applications, units or
+machines, merged into a single timeline ordered by time. This saves
+piecing together what happened from the output of show-status-log,
+show-action-status and debug-log.
+
+The events are of the following kinds:
+    status:   changes of the status of the model, applications, unit
+              workloads and machine instances
+    agent:    changes of the status of unit and machine agents
+    hook:     hooks run by unit agents
+    action:   actions being enqueued, started and completed
+    cleanup:  pending cleanups, such as the removal of the units of a
+              dying application
+
+The timeline of an application includes the events of its units.
+
+Status history is pruned by the controller once it is older than the
+max-status-history-age model setting, or the history is larger than
+max-status-history-size; the pruned history is archived. Include the
+archived history in the timeline with --archived.
+
+Examples:
+    juju timeline
+    juju timeline mysql/0
+    juju timeline mysql --kind hook,action -n 50
+    juju timeline 0 --from-date 2019-05-01
+    juju timeline mysql/0 --archived --from-date 2019-01-01
+
+See also:
+    show-status-log
+    show-action-status
+    debug-log`
+
+func (c *timelineCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "timeline",
+		Args:    "[<application>|<unit>|<machine>]",
+		Purpose: "Output the timeline of events of a model or one of its entities.",
+		Doc:     timelineDoc,
+	})
+}
+
+func (c *timelineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.kinds, "kind", "", "Comma separated kinds of events to display [status|agent|hook|action|cleanup]")
+	f.IntVar(&c.backlogSize, "n", 0, "Returns the last N events (cannot be combined with --from-date)")
+	f.StringVar(&c.backlogDate, "from-date", "", "Returns events after the passed date, the expected date format is YYYY-MM-DD (cannot be combined with -n)")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	f.BoolVar(&c.archived, "archived", false, "Include the archived status history")
+}
+
+func (c *timelineCommand) Init(args []string) error {
+	if len(args) > 1 {
+		return errors.Errorf("unexpected arguments after entity name.")
+	}
+	if len(args) == 1 {
+		name := args[0]
+		switch {
+		case names.IsValidUnit(name):
+			c.entity = names.NewUnitTag(name)
+		case names.IsValidMachine(name):
+			c.entity = names.NewMachineTag(name)
+		case names.IsValidApplication(name):
+			c.entity = names.NewApplicationTag(name)
+		default:
+			return errors.Errorf("%q is not a valid application, unit or machine name", name)
+		}
+	}
+	// If use of ISO time not specified on command line,
+	// check env var.
+	if !c.isoTime {
+		var err error
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	if c.backlogSize < 0 {
+		return errors.Errorf("backlog size cannot be negative")
+	}
+	if c.backlogSize != 0 && c.backlogDate != "" {
+		return errors.Errorf("backlog size and backlog date cannot be specified together")
+	}
+	if c.backlogDate != "" {
+		var err error
+		c.date, err = time.Parse("2006-01-02", c.backlogDate)
+		if err != nil {
+			return errors.Annotate(err, "parsing backlog date")
+		}
+	}
+	return nil
+}
+
+func (c *timelineCommand) getAPI() (TimelineAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return timeline.NewClient(root), nil
+}
+
+func (c *timelineCommand) Run(ctx *cmd.Context) error {
+	apiclient, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer apiclient.Close()
+	if apiclient.BestAPIVersion() < 1 {
+		return errors.New("showing a timeline is not supported by this version of Juju")
+	}
+	if c.archived && apiclient.BestAPIVersion() < 2 {
+		return errors.New("showing archived status history is not supported by this version of Juju")
+	}
+
+	args := params.TimelineArgs{
+		Size:     c.backlogSize,
+		Archived: c.archived,
+	}
+	if c.entity != nil {
+		args.Entity = c.entity.String()
+	}
+	if c.kinds != "" {
+		for _, kind := range strings.Split(c.kinds, ",") {
+			args.Kinds = append(args.Kinds, strings.TrimSpace(kind))
+		}
+	}
+	if !c.date.IsZero() {
+		args.Since = &c.date
+	}
+	events, err := apiclient.Timeline(args)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(events) == 0 {
+		ctx.Infof("No events to display.")
+		return nil
+	}
+	c.writeTabular(ctx.Stdout, events)
+	return nil
+}
+
+func (c *timelineCommand) writeTabular(writer io.Writer, events []params.TimelineEvent) {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	w.Println("Time", "Kind", "Entity", "Status", "Message")
+	for _, event := range events {
+		when := event.Time
+		w.Print(common.FormatTime(&when, c.isoTime), event.Kind, entityName(event.Entity), event.Status)
+		w.Println(event.Message)
+	}
+	tw.Flush()
+}
+
+// entityName returns the name of the entity with the given tag, as
+// given on the command line.
+func entityName(tagString string) string {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return tagString
+	}
+	if tag.Kind() == names.ModelTagKind {
+		return "model"
+	}
+	return tag.Id()
+}
@@ -0,0 +1,158 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	statuscmd "github.com/juju/juju/cmd/juju/status"
+)
+
+type TimelineSuite struct {
+	testing.IsolationSuite
+	api *fakeTimelineAPI
+}
+
+var _ = gc.Suite(&TimelineSuite{})
+
+func (s *TimelineSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	now := time.Date(2019, 5, 1, 12, 34, 56, 0, time.UTC)
+	s.api = &fakeTimelineAPI{
+		Stub:    &testing.Stub{},
+		version: 2,
+		events: []params.TimelineEvent{{
+			Time:    now,
+			Kind:    "hook",
+			Entity:  "unit-mysql-0",
+			Status:  "executing",
+			Message: "running install hook",
+		}, {
+			Time:    now.Add(time.Minute),
+			Kind:    "status",
+			Entity:  "unit-mysql-0",
+			Status:  "active",
+			Message: "ready",
+		}, {
+			Time:    now.Add(2 * time.Minute),
+			Kind:    "cleanup",
+			Entity:  "application-mysql",
+			Status:  "pending",
+			Message: "units cleanup scheduled",
+		}},
+	}
+}
+
+func (s *TimelineSuite) runTimeline(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, statuscmd.NewTestTimelineCommand(s.api), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *TimelineSuite) TestInvalidArguments(c *gc.C) {
+	_, err := s.runTimeline(c, "mysql/0", "mysql/1")
+	c.Assert(err, gc.ErrorMatches, "unexpected arguments after entity name.")
+
+	_, err = s.runTimeline(c, "mysql/x")
+	c.Assert(err, gc.ErrorMatches, `"mysql/x" is not a valid application, unit or machine name`)
+
+	_, err = s.runTimeline(c, "-n", "5", "--from-date", "2019-05-01")
+	c.Assert(err, gc.ErrorMatches, "backlog size and backlog date cannot be specified together")
+
+	_, err = s.runTimeline(c, "--from-date", "yesterday")
+	c.Assert(err, gc.ErrorMatches, "parsing backlog date: .*")
+}
+
+func (s *TimelineSuite) TestTimeline(c *gc.C) {
+	out, err := s.runTimeline(c, "mysql", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, ""+
+		"Time                  Kind     Entity   Status     Message\n"+
+		"2019-05-01 12:34:56Z  hook     mysql/0  executing  running install hook\n"+
+		"2019-05-01 12:35:56Z  status   mysql/0  active     ready\n"+
+		"2019-05-01 12:36:56Z  cleanup  mysql    pending    units cleanup scheduled\n")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"Timeline", []interface{}{params.TimelineArgs{Entity: "application-mysql"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *TimelineSuite) TestTimelineFilters(c *gc.C) {
+	_, err := s.runTimeline(c, "0", "--kind", "agent, action", "--from-date", "2019-05-01")
+	c.Assert(err, jc.ErrorIsNil)
+	since := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	s.api.CheckCall(c, 0, "Timeline", params.TimelineArgs{
+		Entity: "machine-0",
+		Kinds:  []string{"agent", "action"},
+		Since:  &since,
+	})
+
+	_, err = s.runTimeline(c, "-n", "5")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 2, "Timeline", params.TimelineArgs{Size: 5})
+}
+
+func (s *TimelineSuite) TestNoEvents(c *gc.C) {
+	s.api.events = nil
+	ctx, err := cmdtesting.RunCommand(c, statuscmd.NewTestTimelineCommand(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "No events to display.\n")
+}
+
+func (s *TimelineSuite) TestOldServer(c *gc.C) {
+	s.api.version = 0
+	_, err := s.runTimeline(c)
+	c.Assert(err, gc.ErrorMatches, "showing a timeline is not supported by this version of Juju")
+}
+
+func (s *TimelineSuite) TestTimelineArchived(c *gc.C) {
+	_, err := s.runTimeline(c, "mysql/0", "--archived")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Timeline", params.TimelineArgs{
+		Entity:   "unit-mysql-0",
+		Archived: true,
+	})
+}
+
+func (s *TimelineSuite) TestTimelineArchivedOldServer(c *gc.C) {
+	s.api.version = 1
+	_, err := s.runTimeline(c, "--archived")
+	c.Assert(err, gc.ErrorMatches, "showing archived status history is not supported by this version of Juju")
+}
+
+func (s *TimelineSuite) TestFail(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runTimeline(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeTimelineAPI struct {
+	*testing.Stub
+	version int
+	events  []params.TimelineEvent
+}
+
+func (f *fakeTimelineAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeTimelineAPI) BestAPIVersion() int {
+	return f.version
+}
+
+func (f *fakeTimelineAPI) Timeline(args params.TimelineArgs) ([]params.TimelineEvent, error) {
+	f.MethodCall(f, "Timeline", args)
+	return f.events, f.NextErr()
+}
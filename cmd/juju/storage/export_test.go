@@ -91,3 +91,10 @@ func NewDetachStorageCommandForTest(new NewEntityDetacherCloserFunc, store jujuc
 	cmd.newEntityDetacherCloser = new
 	return modelcmd.Wrap(cmd)
 }
+
+func NewRetryStorageCommandForTest(new NewStorageRetrierCloserFunc, store jujuclient.ClientStore) cmd.Command {
+	cmd := &retryStorageCommand{}
+	cmd.SetClientStore(store)
+	cmd.newStorageRetrierCloser = new
+	return modelcmd.Wrap(cmd)
+}
@@ -11,7 +11,6 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/cmd/juju/common"
 )
 
 // FilesystemCommandBase is a helper base structure for filesystem commands.
@@ -107,12 +106,7 @@ func createFilesystemInfo(details params.FilesystemDetails) (names.FilesystemTag
 	info.Pool = details.Info.Pool
 	info.Size = details.Info.Size
 	info.Life = string(details.Life)
-	info.Status = EntityStatus{
-		details.Status.Status,
-		details.Status.Info,
-		// TODO(axw) we should support formatting as ISO time
-		common.FormatTime(details.Status.Since, false),
-	}
+	info.Status = entityStatusFromParams(details.Status)
 
 	if details.VolumeTag != "" {
 		volumeId, err := idFromTag(details.VolumeTag)
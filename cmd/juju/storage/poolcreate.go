@@ -45,9 +45,40 @@ configuration as space-separated pairs, e.g. tags, size, path, etc.
 For Kubernetes models, the provider type defaults to "kubernetes"
 unless otherwise specified.
 
+Filesystems that Juju creates on volumes from a pool can be configured
+with the following attributes, supported by all providers:
+
+    filesystem-type  the type of filesystem to create: ext4 (default) or xfs
+    mount-options    comma-separated options to mount the filesystem with
+    uid, gid         the user and group IDs to own the filesystem
+    mode             the octal permission mode of the filesystem
+
+Storage can also be drawn from the machines themselves. Pools of the "lvm"
+provider create logical volumes in the LVM volume group given by the
+volume-group attribute, and pools of the "zfs" provider create datasets in
+the ZFS pool given by the zfs-pool attribute. The volume group or ZFS pool
+must already exist on the machines.
+
+Pools of the "rbd" provider create RBD images in a Ceph cluster, and map
+them on the machines with the kernel RBD driver. The cluster is given by the
+mon-hosts attribute, a comma-separated list of monitor addresses, and the
+keyring attribute, the cephx key of the Ceph user given by the user attribute
+("admin" by default). Images are created in the Ceph pool given by the
+rbd-pool attribute ("rbd" by default).
+
+Pools of the "nfs" provider mount the directory exported by the NFS server
+given by the nfs-server attribute, at the path given by the nfs-export
+attribute. Every filesystem from such a pool is the export itself, so the
+units of an application on different machines share its data.
+
 Examples:
 
     juju create-storage-pool ebsrotary ebs volume-type=standard
+    juju create-storage-pool ebsdata ebs filesystem-type=xfs mount-options=noatime,nodev uid=1000 mode=0750
+    juju create-storage-pool fast lvm volume-group=vg0
+    juju create-storage-pool tank zfs zfs-pool=tank/juju uid=1000
+    juju create-storage-pool ceph rbd mon-hosts=10.0.0.1,10.0.0.2 user=juju keyring=AQBdHWBc...
+    juju create-storage-pool shared nfs nfs-server=10.0.0.5 nfs-export=/srv/share mount-options=noatime
     juju create-storage-pool gcepd storage-provisioner=kubernetes.io/gce-pd parameters.type=pd-standard
 
 See also:
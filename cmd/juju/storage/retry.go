@@ -0,0 +1,126 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRetryStorageCommandWithAPI returns a command used to retry
+// the failed operations on storage.
+func NewRetryStorageCommandWithAPI() cmd.Command {
+	cmd := &retryStorageCommand{}
+	cmd.newStorageRetrierCloser = func() (StorageRetrierCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRetryStorageCommand returns a command used to retry the
+// failed operations on storage.
+func NewRetryStorageCommand(new NewStorageRetrierCloserFunc) cmd.Command {
+	cmd := &retryStorageCommand{}
+	cmd.newStorageRetrierCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	retryStorageCommandDoc = `
+Retries the failed operations on storage. Specify one or more storage IDs,
+as output by "juju storage".
+
+Juju retries failed storage operations, such as creating or attaching
+a volume, with an increasing delay between attempts. The number of
+attempts made, and the time of the next attempt, are shown in the status
+of the storage by "juju storage --format yaml". This command makes Juju
+retry the operations now, rather than wait for their next attempt.
+
+Examples:
+    juju retry-storage pgdata/0
+`
+
+	retryStorageCommandArgs = `<storage> [<storage> ...]`
+)
+
+// retryStorageCommand retries the failed operations on storage
+// instances.
+type retryStorageCommand struct {
+	StorageCommandBase
+	newStorageRetrierCloser NewStorageRetrierCloserFunc
+	storageIds              []string
+}
+
+// Init implements Command.Init.
+func (c *retryStorageCommand) Init(args []string) error {
+	if len(args) < 1 {
+		return errors.New("retry-storage requires at least one storage ID")
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Info implements Command.Info.
+func (c *retryStorageCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "retry-storage",
+		Purpose: "Retries the failed operations on storage.",
+		Doc:     retryStorageCommandDoc,
+		Args:    retryStorageCommandArgs,
+	})
+}
+
+// Run implements Command.Run.
+func (c *retryStorageCommand) Run(ctx *cmd.Context) error {
+	retrier, err := c.newStorageRetrierCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer retrier.Close()
+
+	results, err := retrier.RetryStorage(c.storageIds)
+	if err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "retry storage")
+		}
+		return err
+	}
+	for i, result := range results {
+		if result.Error == nil {
+			ctx.Infof("retrying %s", c.storageIds[i])
+		}
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to retry %s: %s", c.storageIds[i], result.Error)
+			anyFailed = true
+		}
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// NewStorageRetrierCloserFunc is the type of a function that returns
+// a StorageRetrierCloser.
+type NewStorageRetrierCloserFunc func() (StorageRetrierCloser, error)
+
+// StorageRetrierCloser extends StorageRetrier with a Closer method.
+type StorageRetrierCloser interface {
+	StorageRetrier
+	Close() error
+}
+
+// StorageRetrier defines an interface for retrying the failed
+// operations on storage with the specified IDs.
+type StorageRetrier interface {
+	RetryStorage([]string) ([]params.ErrorResult, error)
+}
@@ -0,0 +1,95 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type RetryStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RetryStorageSuite{})
+
+func (s *RetryStorageSuite) TestRetryStorage(c *gc.C) {
+	fake := fakeStorageRetrier{results: []params.ErrorResult{
+		{},
+		{},
+	}}
+	cmd := storage.NewRetryStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0", "bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageRetrierCloser", "RetryStorage", "Close")
+	fake.CheckCall(c, 1, "RetryStorage", []string{"foo/0", "bar/1"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+retrying foo/0
+retrying bar/1
+`[1:])
+}
+
+func (s *RetryStorageSuite) TestRetryError(c *gc.C) {
+	fake := fakeStorageRetrier{results: []params.ErrorResult{
+		{Error: &params.Error{Message: "foo"}},
+		{Error: &params.Error{Message: "bar"}},
+	}}
+	retryCmd := storage.NewRetryStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, retryCmd, "baz/0", "qux/1")
+	stderr := cmdtesting.Stderr(ctx)
+	c.Assert(stderr, gc.Equals, `failed to retry baz/0: foo
+failed to retry qux/1: bar
+`)
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+}
+
+func (s *RetryStorageSuite) TestRetryUnauthorizedError(c *gc.C) {
+	var fake fakeStorageRetrier
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewRetryStorageCommandForTest(fake.new, jujuclienttesting.MinimalStore())
+	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to retry storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *RetryStorageSuite) TestRetryInitErrors(c *gc.C) {
+	s.testRetryInitError(c, []string{}, "retry-storage requires at least one storage ID")
+}
+
+func (s *RetryStorageSuite) testRetryInitError(c *gc.C, args []string, expect string) {
+	cmd := storage.NewRetryStorageCommandForTest(nil, jujuclienttesting.MinimalStore())
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type fakeStorageRetrier struct {
+	testing.Stub
+	results []params.ErrorResult
+}
+
+func (f *fakeStorageRetrier) new() (storage.StorageRetrierCloser, error) {
+	f.MethodCall(f, "NewStorageRetrierCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageRetrier) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageRetrier) RetryStorage(ids []string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "RetryStorage", ids)
+	return f.results, f.NextErr()
+}
@@ -74,6 +74,26 @@ shared-fs/0:
 	)
 }
 
+func (s *ShowSuite) TestShowRetries(c *gc.C) {
+	now := time.Now()
+	s.mockAPI.time = now
+	s.assertValidShow(
+		c,
+		[]string{"retry-db/0"},
+		fmt.Sprintf(`
+retry-db/0:
+  kind: block
+  status:
+    current: error
+    message: 'creating volume: boom'
+    since: %s
+    attempts: 3
+    next-retry: "2019-01-01T00:02:00Z"
+  persistent: false
+`[1:], common.FormatTime(&now, false)),
+	)
+}
+
 func (s *ShowSuite) TestShowInvalidId(c *gc.C) {
 	_, err := s.runShow(c, []string{"foo"})
 	c.Assert(err, gc.ErrorMatches, ".*invalid storage id foo.*")
@@ -170,6 +190,20 @@ func (s mockShowAPI) StorageDetails(tags []names.StorageTag) ([]params.StorageDe
 					},
 				},
 			}
+		} else if strings.Contains(tag.String(), "retry") {
+			all[i].Result = &params.StorageDetails{
+				StorageTag: tag.String(),
+				Kind:       params.StorageKindBlock,
+				Status: params.EntityStatus{
+					Status: "error",
+					Info:   "creating volume: boom",
+					Since:  &s.time,
+					Data: map[string]interface{}{
+						"attempts":   float64(3),
+						"next-retry": "2019-01-01T00:02:00Z",
+					},
+				},
+			}
 		} else {
 			all[i].Result = &params.StorageDetails{
 				StorageTag: tag.String(),
@@ -12,7 +12,6 @@ import (
 
 	"github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/params"
-	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 )
 
@@ -89,14 +88,9 @@ func createStorageInfo(details params.StorageDetails) (names.StorageTag, Storage
 	}
 
 	info := StorageInfo{
-		Kind: details.Kind.String(),
-		Life: string(details.Life),
-		Status: EntityStatus{
-			details.Status.Status,
-			details.Status.Info,
-			// TODO(axw) we should support formatting as ISO time
-			common.FormatTime(details.Status.Since, false),
-		},
+		Kind:       details.Kind.String(),
+		Life:       string(details.Life),
+		Status:     entityStatusFromParams(details.Status),
 		Persistent: details.Persistent,
 	}
 
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/storage"
 )
 
 // VolumeInfo defines the serialization behaviour for storage volume.
@@ -53,6 +54,34 @@ type EntityStatus struct {
 	Current status.Status `json:"current,omitempty" yaml:"current,omitempty"`
 	Message string        `json:"message,omitempty" yaml:"message,omitempty"`
 	Since   string        `json:"since,omitempty" yaml:"since,omitempty"`
+
+	// Attempts and NextRetry are the number of failed attempts at
+	// the operation on the entity, and when it will next be retried.
+	Attempts  int    `json:"attempts,omitempty" yaml:"attempts,omitempty"`
+	NextRetry string `json:"next-retry,omitempty" yaml:"next-retry,omitempty"`
+}
+
+// entityStatusFromParams returns the EntityStatus formatted from the
+// given params.EntityStatus, including the retries of any operation
+// on the entity recorded by the storage provisioner.
+func entityStatusFromParams(in params.EntityStatus) EntityStatus {
+	out := EntityStatus{
+		Current: in.Status,
+		Message: in.Info,
+		// TODO(axw) we should support formatting as ISO time
+		Since: common.FormatTime(in.Since, false),
+	}
+	// The attempts are decoded from JSON as a float64.
+	switch attempts := in.Data[storage.StatusDataAttempts].(type) {
+	case int:
+		out.Attempts = attempts
+	case float64:
+		out.Attempts = int(attempts)
+	}
+	if nextRetry, ok := in.Data[storage.StatusDataNextRetry].(string); ok {
+		out.NextRetry = nextRetry
+	}
+	return out
 }
 
 type VolumeAttachments struct {
@@ -128,12 +157,7 @@ func createVolumeInfo(details params.VolumeDetails) (names.VolumeTag, VolumeInfo
 	info.Size = details.Info.Size
 	info.Persistent = details.Info.Persistent
 	info.Life = string(details.Life)
-	info.Status = EntityStatus{
-		details.Status.Status,
-		details.Status.Info,
-		// TODO(axw) we should support formatting as ISO time
-		common.FormatTime(details.Status.Since, false),
-	}
+	info.Status = entityStatusFromParams(details.Status)
 
 	attachmentsFromDetails := func(
 		in map[string]params.VolumeAttachmentDetails,
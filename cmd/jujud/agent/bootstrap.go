@@ -486,7 +486,7 @@ func (c *BootstrapCommand) startMongo(isCAAS bool, addrs []network.Address, agen
 
 	if !isCAAS {
 		logger.Debugf("calling ensureMongoServer")
-		ensureServerParams, err := cmdutil.NewEnsureServerParams(agentConfig)
+		ensureServerParams, err := agent.NewEnsureServerParams(agentConfig)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,113 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/core/crashreport"
+	jujuversion "github.com/juju/juju/version"
+)
+
+const (
+	crashReportWriterName = "crash-report"
+
+	// crashReportLogLines is the number of lines logged by an agent
+	// included in the reports of its panics.
+	crashReportLogLines = 500
+)
+
+// crashReporter holds what's needed to report the panics of the
+// running agent; it's set up by setupCrashReporting.
+var crashReporter struct {
+	mu    sync.Mutex
+	agent string
+	store *crashreport.Store
+	logs  *crashreport.LogBuffer
+}
+
+// setupCrashReporting sets up the reporting of the crashes of an agent.
+// A report of the last crash reported by the Go runtime in the agent's
+// log file, which holds the agent's stderr, is collected if it hasn't
+// been already, and the last lines logged are kept to be reported with
+// any panic recorded by RecordPanic. Reports are kept in the agent's
+// crash report directory until they're uploaded to the controller.
+func setupCrashReporting(config agent.Config) error {
+	// Report the stacks of all goroutines when the runtime crashes
+	// the agent, not only the goroutine that panicked.
+	debug.SetTraceback("all")
+
+	tag := config.Tag().String()
+	store := crashreport.NewStore(agent.CrashReportDir(config))
+	output, err := crashreport.ReadTail(agent.LogFilename(config), crashreport.MaxSize)
+	if err != nil {
+		logger.Warningf("cannot read agent log for crash reports: %v", err)
+	} else {
+		collected, err := store.CollectRuntimeCrash(tag, jujuversion.Current.String(), time.Now(), output)
+		if err != nil {
+			logger.Warningf("cannot collect crash report: %v", err)
+		} else if collected {
+			logger.Warningf("collected report of previous crash of %s", tag)
+		}
+	}
+
+	logs := crashreport.NewLogBuffer(crashReportLogLines)
+	loggo.RemoveWriter(crashReportWriterName)
+	if err := loggo.RegisterWriter(crashReportWriterName, logs); err != nil {
+		return errors.Trace(err)
+	}
+
+	crashReporter.mu.Lock()
+	defer crashReporter.mu.Unlock()
+	crashReporter.agent = tag
+	crashReporter.store = store
+	crashReporter.logs = logs
+	return nil
+}
+
+// RecordPanic writes a report of a panic recovered in the running
+// agent, with the stacks of its goroutines and the last lines it
+// logged, to be uploaded to the controller when the agent restarts.
+// It does nothing if crash reporting hasn't been set up.
+func RecordPanic(value interface{}) {
+	crashReporter.mu.Lock()
+	defer crashReporter.mu.Unlock()
+	if crashReporter.store == nil {
+		return
+	}
+	r := crashreport.New(
+		crashReporter.agent,
+		jujuversion.Current.String(),
+		time.Now(),
+		fmt.Sprintf("panic: %v", value),
+		goroutineStacks(),
+		crashReporter.logs.Tail(),
+	)
+	if err := crashReporter.store.Write(r); err != nil {
+		logger.Errorf("cannot write crash report: %v", err)
+		return
+	}
+	logger.Criticalf("wrote crash report %s", r.ID)
+}
+
+// goroutineStacks returns the stacks of all goroutines, starting with
+// the calling goroutine, up to the maximum size of a crash report.
+func goroutineStacks() string {
+	buf := make([]byte, 64*1024)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) || len(buf) >= crashreport.MaxSize {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/core/crashreport"
+	jujuversion "github.com/juju/juju/version"
+)
+
+type crashSuite struct {
+	testing.IsolationSuite
+
+	config crashConfig
+}
+
+var _ = gc.Suite(&crashSuite{})
+
+// crashConfig is an agent config with log and data directories that
+// can be written to.
+type crashConfig struct {
+	FakeConfig
+	dir string
+}
+
+func (c crashConfig) LogDir() string {
+	return filepath.Join(c.dir, "log")
+}
+
+func (c crashConfig) DataDir() string {
+	return filepath.Join(c.dir, "data")
+}
+
+func (s *crashSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = crashConfig{dir: c.MkDir()}
+	err := os.MkdirAll(s.config.LogDir(), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.PatchValue(&crashReporter.agent, "")
+	s.PatchValue(&crashReporter.store, (*crashreport.Store)(nil))
+	s.PatchValue(&crashReporter.logs, (*crashreport.LogBuffer)(nil))
+	s.AddCleanup(func(*gc.C) { loggo.RemoveWriter(crashReportWriterName) })
+}
+
+func (s *crashSuite) pending(c *gc.C) []crashreport.Report {
+	reports, err := crashreport.NewStore(agent.CrashReportDir(s.config)).Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	return reports
+}
+
+func (s *crashSuite) TestCollectsRuntimeCrash(c *gc.C) {
+	output := "2019-05-03 10:11:12 INFO juju.worker started\n" +
+		"panic: boom\n\ngoroutine 7 [running]:\nmain.main()\n"
+	err := ioutil.WriteFile(agent.LogFilename(s.config), []byte(output), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = setupCrashReporting(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports := s.pending(c)
+	c.Assert(reports, gc.HasLen, 1)
+	c.Check(reports[0].Agent, gc.Equals, "machine-42")
+	c.Check(reports[0].Version, gc.Equals, jujuversion.Current.String())
+	c.Check(reports[0].Panic, gc.Equals, "panic: boom")
+	c.Check(reports[0].Goroutines, gc.Equals, "goroutine 7 [running]:\nmain.main()")
+	c.Check(reports[0].LogTail, gc.Equals, "2019-05-03 10:11:12 INFO juju.worker started\n")
+
+	// The same crash isn't collected again.
+	err = setupCrashReporting(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.pending(c), gc.HasLen, 1)
+}
+
+func (s *crashSuite) TestNoCrash(c *gc.C) {
+	err := setupCrashReporting(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.pending(c), gc.HasLen, 0)
+}
+
+func (s *crashSuite) TestRecordPanic(c *gc.C) {
+	err := setupCrashReporting(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	loggo.GetLogger("juju.test").Warningf("about to panic")
+
+	RecordPanic("boom")
+
+	reports := s.pending(c)
+	c.Assert(reports, gc.HasLen, 1)
+	c.Check(reports[0].Agent, gc.Equals, "machine-42")
+	c.Check(reports[0].Panic, gc.Equals, "panic: boom")
+	c.Check(reports[0].Goroutines, gc.Matches, `(?s)goroutine \d+ \[running\]:.*TestRecordPanic.*`)
+	c.Check(reports[0].LogTail, gc.Matches, `(?s).*WARNING juju.test crash_test.go:\d+ about to panic\n`)
+}
+
+func (s *crashSuite) TestRecordPanicNotSetUp(c *gc.C) {
+	RecordPanic("boom")
+	_, err := os.Stat(agent.CrashReportDir(s.config))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
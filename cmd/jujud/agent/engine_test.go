@@ -34,10 +34,13 @@ var (
 	requireValidCredentialModelWorkers = []string{
 		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
 		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
+		"autoscaler",             // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
+		"dns-publisher", // tertiary dependency: will be inactive because migration workers will be inactive
 		"environ-tracker",
 		"firewaller",
+		"idle-advisor", // tertiary dependency: will be inactive because migration workers will be inactive
 		"instance-poller",
 		"machine-undertaker",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"metric-worker",           // tertiary dependency: will be inactive because migration workers will be inactive
@@ -46,6 +49,7 @@ var (
 		"migration-master",        // secondary dependency: will be inactive because depends on environ-upgrader
 		"environ-upgrader",
 		"remote-relations",      // tertiary dependency: will be inactive because migration workers will be inactive
+		"spare-machines",        // tertiary dependency: will be inactive because migration workers will be inactive
 		"state-cleaner",         // tertiary dependency: will be inactive because migration workers will be inactive
 		"status-history-pruner", // tertiary dependency: will be inactive because migration workers will be inactive
 		"storage-provisioner",   // tertiary dependency: will be inactive because migration workers will be inactive
@@ -55,10 +59,13 @@ var (
 	aliveModelWorkers = []string{
 		"action-pruner",
 		"application-scaler",
+		"autoscaler",
 		"charm-revision-updater",
 		"compute-provisioner",
+		"dns-publisher",
 		"environ-tracker",
 		"firewaller",
+		"idle-advisor",
 		"instance-poller",
 		"log-forwarder",
 		"machine-undertaker",
@@ -67,6 +74,7 @@ var (
 		"migration-inactive-flag",
 		"migration-master",
 		"remote-relations",
+		"spare-machines",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
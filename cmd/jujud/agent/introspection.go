@@ -24,7 +24,7 @@ import (
 // abstract domain socket that the introspection worker serves requests
 // over.
 func DefaultIntrospectionSocketName(entityTag names.Tag) string {
-	return "jujud-" + entityTag.String()
+	return introspection.SocketName(entityTag)
 }
 
 // introspectionConfig defines the various components that the introspection
@@ -0,0 +1,137 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/go-systemd/journal"
+	"github.com/juju/cmd"
+	"github.com/juju/loggo"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
+	corelogger "github.com/juju/juju/core/logger"
+)
+
+const journalWriterName = "journal"
+
+// journalEnabled and journalSend are overridden in tests.
+var (
+	journalEnabled = journal.Enabled
+	journalSend    = journal.Send
+)
+
+// setupAgentLogWriters sets up the writers of the logs of an agent, as
+// configured by the logging values in its agent config. By default the
+// logs are written to the agent's log file, rotated as configured. The
+// logs may also, or instead, be written to the local journald with
+// structured fields, if journald is available.
+func setupAgentLogWriters(ctx *cmd.Context, config agent.Config) error {
+	// Remove any journal writer registered before the agent was
+	// restarted for new logging values.
+	loggo.RemoveWriter(journalWriterName)
+
+	target := config.Value(agent.AgentLogTarget)
+	if target == "" {
+		target = controller.DefaultAgentLogTarget
+	}
+	if target != controller.AgentLogTargetFile && !journalEnabled() {
+		logger.Warningf("journald not available, logging to file only")
+		target = controller.AgentLogTargetFile
+	}
+
+	if target != controller.AgentLogTargetFile {
+		writer := &journalWriter{
+			identifier: "jujud-" + config.Tag().String(),
+			entity:     config.Tag().String(),
+		}
+		if err := loggo.RegisterWriter(journalWriterName, writer); err != nil {
+			return err
+		}
+	}
+	if target == controller.AgentLogTargetJournald {
+		// the context's stderr is set as the loggo writer in github.com/juju/cmd/logging.go
+		ctx.Stderr = ioutil.Discard
+		return nil
+	}
+	ctx.Stderr = newLogFileWriter(config)
+	return nil
+}
+
+// newLogFileWriter returns a writer of the agent's log file, rotated as
+// configured by the logging values in its agent config.
+func newLogFileWriter(config agent.Config) io.Writer {
+	maxSize := controller.DefaultAgentLogfileMaxSizeMB
+	if value := config.Value(agent.AgentLogfileMaxSizeMB); value != "" {
+		if size, err := strconv.Atoi(value); err == nil && size > 0 {
+			maxSize = size
+		}
+	}
+	maxBackups := controller.DefaultAgentLogfileMaxBackups
+	if value := config.Value(agent.AgentLogfileMaxBackups); value != "" {
+		if backups, err := strconv.Atoi(value); err == nil && backups >= 0 {
+			maxBackups = backups
+		}
+	}
+	return &lumberjack.Logger{
+		Filename:   agent.LogFilename(config),
+		MaxSize:    maxSize, // megabytes
+		MaxBackups: maxBackups,
+		Compress:   true,
+	}
+}
+
+// journalWriter is a loggo.Writer that sends log entries to journald,
+// with the module, source location and labels of the entries as
+// structured fields.
+type journalWriter struct {
+	identifier string
+	entity     string
+}
+
+// Write is part of the loggo.Writer interface.
+func (w *journalWriter) Write(entry loggo.Entry) {
+	message, labels := corelogger.ParseMessage(entry.Message)
+	vars := map[string]string{
+		"SYSLOG_IDENTIFIER": w.identifier,
+		"JUJU_ENTITY":       w.entity,
+		"JUJU_MODULE":       entry.Module,
+		"CODE_FILE":         entry.Filename,
+		"CODE_LINE":         fmt.Sprint(entry.Line),
+	}
+	for key, value := range labels {
+		vars[journalLabelField(key)] = value
+	}
+	// Failing to send to the journal must not stop the agent logging.
+	_ = journalSend(message, journalPriority(entry.Level), vars)
+}
+
+// journalLabelField returns the name of the journal field holding the
+// value of the label with the given key. Journal field names may only
+// contain upper case letters, digits and underscores.
+func journalLabelField(key string) string {
+	return "JUJU_LABEL_" + strings.ToUpper(strings.Replace(key, "-", "_", -1))
+}
+
+// journalPriority returns the journal priority of the given log level.
+func journalPriority(level loggo.Level) journal.Priority {
+	switch level {
+	case loggo.CRITICAL:
+		return journal.PriCrit
+	case loggo.ERROR:
+		return journal.PriErr
+	case loggo.WARNING:
+		return journal.PriWarning
+	case loggo.INFO:
+		return journal.PriInfo
+	default:
+		return journal.PriDebug
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"io/ioutil"
+
+	"github.com/coreos/go-systemd/journal"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	"github.com/juju/juju/agent"
+)
+
+type loggingSuite struct {
+	testing.IsolationSuite
+
+	enabled bool
+	sent    []sentEntry
+}
+
+type sentEntry struct {
+	message  string
+	priority journal.Priority
+	vars     map[string]string
+}
+
+var _ = gc.Suite(&loggingSuite{})
+
+func (s *loggingSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.enabled = true
+	s.sent = nil
+	s.PatchValue(&journalEnabled, func() bool { return s.enabled })
+	s.PatchValue(&journalSend, func(message string, priority journal.Priority, vars map[string]string) error {
+		s.sent = append(s.sent, sentEntry{message, priority, vars})
+		return nil
+	})
+	s.AddCleanup(func(*gc.C) { loggo.RemoveWriter(journalWriterName) })
+}
+
+func (s *loggingSuite) setup(c *gc.C, values map[string]string) *lumberjack.Logger {
+	ctx := cmdtesting.Context(c)
+	err := setupAgentLogWriters(ctx, FakeConfig{values: values})
+	c.Assert(err, jc.ErrorIsNil)
+	if ctx.Stderr == ioutil.Discard {
+		return nil
+	}
+	l, ok := ctx.Stderr.(*lumberjack.Logger)
+	c.Assert(ok, jc.IsTrue)
+	return l
+}
+
+func (s *loggingSuite) TestDefaults(c *gc.C) {
+	l := s.setup(c, nil)
+	c.Assert(l, gc.NotNil)
+	c.Check(l.MaxSize, gc.Equals, 300)
+	c.Check(l.MaxBackups, gc.Equals, 2)
+
+	loggo.GetLogger("juju.test").Warningf("hello")
+	c.Check(s.sent, gc.HasLen, 0)
+}
+
+func (s *loggingSuite) TestRotationValues(c *gc.C) {
+	l := s.setup(c, map[string]string{
+		agent.AgentLogfileMaxSizeMB:  "50",
+		agent.AgentLogfileMaxBackups: "0",
+	})
+	c.Assert(l, gc.NotNil)
+	c.Check(l.MaxSize, gc.Equals, 50)
+	c.Check(l.MaxBackups, gc.Equals, 0)
+}
+
+func (s *loggingSuite) TestJournald(c *gc.C) {
+	l := s.setup(c, map[string]string{
+		agent.AgentLogTarget: "journald",
+	})
+	c.Assert(l, gc.IsNil)
+
+	loggo.GetLogger("juju.test").Warningf("hello [labels: deploy-id=42]")
+	c.Assert(s.sent, gc.HasLen, 1)
+	c.Check(s.sent[0].message, gc.Equals, "hello")
+	c.Check(s.sent[0].priority, gc.Equals, journal.PriWarning)
+	vars := s.sent[0].vars
+	c.Check(vars["SYSLOG_IDENTIFIER"], gc.Equals, "jujud-machine-42")
+	c.Check(vars["JUJU_ENTITY"], gc.Equals, "machine-42")
+	c.Check(vars["JUJU_MODULE"], gc.Equals, "juju.test")
+	c.Check(vars["JUJU_LABEL_DEPLOY_ID"], gc.Equals, "42")
+}
+
+func (s *loggingSuite) TestFileAndJournald(c *gc.C) {
+	l := s.setup(c, map[string]string{
+		agent.AgentLogTarget: "file+journald",
+	})
+	c.Assert(l, gc.NotNil)
+
+	loggo.GetLogger("juju.test").Errorf("boom")
+	c.Assert(s.sent, gc.HasLen, 1)
+	c.Check(s.sent[0].priority, gc.Equals, journal.PriErr)
+}
+
+func (s *loggingSuite) TestJournaldNotAvailable(c *gc.C) {
+	s.enabled = false
+	l := s.setup(c, map[string]string{
+		agent.AgentLogTarget: "journald",
+	})
+	c.Assert(l, gc.NotNil)
+
+	loggo.GetLogger("juju.test").Warningf("hello")
+	c.Check(s.sent, gc.HasLen, 0)
+}
@@ -32,7 +32,7 @@ import (
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 	"gopkg.in/mgo.v2"
-	"gopkg.in/natefinch/lumberjack.v2"
+	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/agent/tools"
@@ -63,6 +63,7 @@ import (
 	"github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/mongometrics"
+	"github.com/juju/juju/provider/ec2"
 	"github.com/juju/juju/pubsub/centralhub"
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
@@ -80,6 +81,7 @@ import (
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/logsender/logsendermetrics"
 	"github.com/juju/juju/worker/migrationmaster"
+	"github.com/juju/juju/worker/modelcache"
 	"github.com/juju/juju/worker/provisioner"
 	psworker "github.com/juju/juju/worker/pubsub"
 	"github.com/juju/juju/worker/upgradesteps"
@@ -209,14 +211,10 @@ func (a *machineAgentCmd) Init(args []string) error {
 		return errors.Errorf("cannot read agent configuration: %v", err)
 	}
 	config := a.currentConfig.CurrentConfig()
-	// the context's stderr is set as the loggo writer in github.com/juju/cmd/logging.go
-	a.ctx.Stderr = &lumberjack.Logger{
-		Filename:   agent.LogFilename(config),
-		MaxSize:    300, // megabytes
-		MaxBackups: 2,
-		Compress:   true,
+	if err := setupAgentLogWriters(a.ctx, config); err != nil {
+		return err
 	}
-	return nil
+	return setupCrashReporting(config)
 }
 
 func (a *machineAgentCmd) tag() names.Tag {
@@ -306,6 +304,7 @@ func NewMachineAgent(
 		newIntrospectionSocketName:  newIntrospectionSocketName,
 		prometheusRegistry:          prometheusRegistry,
 		mongoTxnCollector:           mongometrics.NewTxnCollector(),
+		modelCacheInvalidator:       modelcache.NewInvalidator(),
 		mongoDialCollector:          mongometrics.NewDialCollector(),
 		preUpgradeSteps:             preUpgradeSteps,
 		isCaasMachineAgent:          isCaasMachineAgent,
@@ -335,6 +334,9 @@ func (a *MachineAgent) registerPrometheusCollectors() error {
 	if err := a.prometheusRegistry.Register(a.mongoDialCollector); err != nil {
 		return errors.Annotate(err, "registering mongo dial collector")
 	}
+	if err := a.prometheusRegistry.Register(ec2.RetryCollector()); err != nil {
+		return errors.Annotate(err, "registering ec2 retry collector")
+	}
 	return nil
 }
 
@@ -367,6 +369,7 @@ type MachineAgent struct {
 	prometheusRegistry         *prometheus.Registry
 	mongoTxnCollector          *mongometrics.TxnCollector
 	mongoDialCollector         *mongometrics.DialCollector
+	modelCacheInvalidator      *modelcache.Invalidator
 	preUpgradeSteps            upgrades.PreUpgradeStepsFunc
 
 	// Only API servers have hubs. This is temporary until the apiserver and
@@ -567,6 +570,7 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 			ValidateMigration:       a.validateMigration,
 			PrometheusRegisterer:    a.prometheusRegistry,
 			CentralHub:              a.centralHub,
+			ModelCacheInvalidator:   a.modelCacheInvalidator,
 			PubSubReporter:          pubsubReporter,
 			PresenceRecorder:        presenceRecorder,
 			UpdateLoggerConfig:      updateAgentConfLogging,
@@ -577,6 +581,7 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 			ControllerLeaseDuration:           time.Minute,
 			LogPruneInterval:                  5 * time.Minute,
 			TransactionPruneInterval:          time.Hour,
+			StagedUpgradePollInterval:         30 * time.Second,
 			MachineLock:                       a.machineLock,
 			SetStatePool:                      statePoolReporter.set,
 			RegisterIntrospectionHTTPHandlers: registerIntrospectionHandlers,
@@ -798,7 +803,7 @@ func (a *MachineAgent) openStateForUpgrade() (*state.StatePool, error) {
 		// point in reading existing controller config from state in order
 		// to pass in the max-txn-log-size value.
 		InitDatabaseFunc:       state.InitDatabase,
-		RunTransactionObserver: a.mongoTxnCollector.AfterRunTransaction,
+		RunTransactionObserver: a.afterRunTransaction,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -948,11 +953,19 @@ func (a *MachineAgent) initController(agentConfig agent.Config) (*state.Controll
 		ControllerModelTag:     agentConfig.Model(),
 		MongoSession:           session,
 		NewPolicy:              stateenvirons.GetNewPolicyFunc(),
-		RunTransactionObserver: a.mongoTxnCollector.AfterRunTransaction,
+		RunTransactionObserver: a.afterRunTransaction,
 	})
 	return ctrl, nil
 }
 
+// afterRunTransaction is called after each transaction is run on the
+// controller's database. It records transaction metrics, and invalidates
+// memoized reads of the changed model in the model cache.
+func (a *MachineAgent) afterRunTransaction(dbName, modelUUID string, ops []txn.Op, err error) {
+	a.mongoTxnCollector.AfterRunTransaction(dbName, modelUUID, ops, err)
+	a.modelCacheInvalidator.AfterRunTransaction(dbName, modelUUID, ops, err)
+}
+
 func (a *MachineAgent) initState(agentConfig agent.Config) (*state.StatePool, error) {
 	// Start MongoDB server and dial.
 	if err := a.ensureMongoServer(agentConfig); err != nil {
@@ -970,7 +983,7 @@ func (a *MachineAgent) initState(agentConfig agent.Config) (*state.StatePool, er
 	pool, _, err := openStatePool(
 		agentConfig,
 		dialOpts,
-		a.mongoTxnCollector.AfterRunTransaction,
+		a.afterRunTransaction,
 	)
 	if err != nil {
 		return nil, err
@@ -1052,7 +1065,7 @@ func (a *MachineAgent) ensureMongoServer(agentConfig agent.Config) (err error) {
 		return nil
 	}
 	// EnsureMongoServer installs/upgrades the init config as necessary.
-	ensureServerParams, err := cmdutil.NewEnsureServerParams(agentConfig)
+	ensureServerParams, err := agent.NewEnsureServerParams(agentConfig)
 	if err != nil {
 		return err
 	}
@@ -52,6 +52,7 @@ import (
 	"github.com/juju/juju/worker/common"
 	lxdbroker "github.com/juju/juju/worker/containerbroker"
 	"github.com/juju/juju/worker/controllerport"
+	"github.com/juju/juju/worker/crashreporter"
 	"github.com/juju/juju/worker/credentialvalidator"
 	"github.com/juju/juju/worker/dblogpruner"
 	"github.com/juju/juju/worker/deployer"
@@ -76,6 +77,8 @@ import (
 	"github.com/juju/juju/worker/migrationminion"
 	"github.com/juju/juju/worker/modelcache"
 	"github.com/juju/juju/worker/modelworkermanager"
+	"github.com/juju/juju/worker/mongoupgrader"
+	"github.com/juju/juju/worker/networkconfigdrift"
 	"github.com/juju/juju/worker/peergrouper"
 	prworker "github.com/juju/juju/worker/presence"
 	"github.com/juju/juju/worker/proxyupdater"
@@ -85,11 +88,13 @@ import (
 	"github.com/juju/juju/worker/raft/raftclusterer"
 	"github.com/juju/juju/worker/raft/raftflag"
 	"github.com/juju/juju/worker/raft/raftforwarder"
+	"github.com/juju/juju/worker/raft/raftsnapshotter"
 	"github.com/juju/juju/worker/raft/rafttransport"
 	"github.com/juju/juju/worker/reboot"
 	"github.com/juju/juju/worker/restorewatcher"
 	"github.com/juju/juju/worker/resumer"
 	"github.com/juju/juju/worker/singular"
+	"github.com/juju/juju/worker/stagedupgrader"
 	workerstate "github.com/juju/juju/worker/state"
 	"github.com/juju/juju/worker/stateconfigwatcher"
 	"github.com/juju/juju/worker/storageprovisioner"
@@ -113,6 +118,14 @@ const (
 	// leaseRequestTopic is the pubsub topic that lease FSM updates
 	// will be published on.
 	leaseRequestTopic = "lease.request"
+
+	// mongoUpgradePollInterval is how often the mongo upgrader checks
+	// on the replica set while a controller resyncs its database.
+	mongoUpgradePollInterval = 10 * time.Second
+
+	// mongoResyncTimeout is how long a controller's database may take
+	// to resync before its migration is recorded as failed.
+	mongoResyncTimeout = time.Hour
 )
 
 // ManifoldsConfig allows specialisation of the result of Manifolds.
@@ -199,6 +212,10 @@ type ManifoldsConfig struct {
 	// CentralHub is the primary hub that exists in the apiserver.
 	CentralHub *pubsub.StructuredHub
 
+	// ModelCacheInvalidator is used to pass the transactions run by
+	// the controller to the model cache.
+	ModelCacheInvalidator *modelcache.Invalidator
+
 	// PubSubReporter is the introspection reporter for the pubsub forwarding
 	// worker.
 	PubSubReporter psworker.Reporter
@@ -229,6 +246,10 @@ type ManifoldsConfig struct {
 	// are pruned from the database.
 	TransactionPruneInterval time.Duration
 
+	// StagedUpgradePollInterval defines how frequently the health of
+	// the models in a staged upgrade wave is checked.
+	StagedUpgradePollInterval time.Duration
+
 	// SetStatePool is used by the state worker for informing the agent of
 	// the StatePool that it creates, so we can pass it to the introspection
 	// worker running outside of the dependency engine.
@@ -405,6 +426,7 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			StateName:            stateName,
 			Logger:               loggo.GetLogger("juju.worker.modelcache"),
 			PrometheusRegisterer: config.PrometheusRegisterer,
+			Invalidator:          config.ModelCacheInvalidator,
 			NewWorker:            modelcache.NewWorker,
 		}),
 
@@ -631,6 +653,13 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:     machineactions.NewMachineActionsWorker,
 		})),
 
+		// The crash reporter uploads the reports of the agent's
+		// crashes, kept locally, to the controller.
+		crashReporterName: ifNotMigrating(crashreporter.Manifold(crashreporter.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+		})),
+
 		externalControllerUpdaterName: ifNotMigrating(ifPrimaryController(externalcontrollerupdater.Manifold(
 			externalcontrollerupdater.ManifoldConfig{
 				APICallerName:                      apiCallerName,
@@ -656,6 +685,16 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			},
 		))),
 
+		stagedUpgraderName: ifNotMigrating(ifPrimaryController(stagedupgrader.Manifold(
+			stagedupgrader.ManifoldConfig{
+				ClockName:    clockName,
+				StateName:    stateName,
+				PollInterval: config.StagedUpgradePollInterval,
+				NewBackend:   stagedupgrader.NewBackend,
+				NewWorker:    stagedupgrader.NewWorker,
+			},
+		))),
+
 		httpServerArgsName: httpserverargs.Manifold(httpserverargs.ManifoldConfig{
 			ClockName:             clockName,
 			ControllerPortName:    controllerPortName,
@@ -787,6 +826,16 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:      raftbackstop.NewWorker,
 		}),
 
+		// The raft snapshotter snapshots the local raft node when
+		// asked to over the hub, discarding old log entries.
+		raftSnapshotterName: raftsnapshotter.Manifold(raftsnapshotter.ManifoldConfig{
+			RaftName:       raftName,
+			CentralHubName: centralHubName,
+			AgentName:      agentName,
+			Logger:         loggo.GetLogger("juju.worker.raft.raftsnapshotter"),
+			NewWorker:      raftsnapshotter.NewWorker,
+		}),
+
 		// The raft forwarder accepts FSM commands from the hub and
 		// applies them to the raft leader.
 		raftForwarderName: ifRaftLeader(raftforwarder.Manifold(raftforwarder.ManifoldConfig{
@@ -862,6 +911,14 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 		})),
 
+		// The network config drift worker periodically compares the
+		// network config observed on the machine with the one recorded
+		// for it, and records any drift found.
+		networkConfigDriftName: ifNotMigrating(networkconfigdrift.Manifold(networkconfigdrift.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+		})),
+
 		// The proxy config updater is a leaf worker that sets http/https/apt/etc
 		// proxy settings.
 		proxyConfigUpdater: ifNotMigrating(proxyupdater.Manifold(proxyupdater.ManifoldConfig{
@@ -925,6 +982,19 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			Clock:         config.Clock,
 		})),
 
+		// The mongo upgrader migrates the controller's database to a
+		// new storage engine when asked to through the API, taking
+		// turns with the other controllers.
+		mongoUpgraderName: ifController(ifFullyUpgraded(mongoupgrader.Manifold(mongoupgrader.ManifoldConfig{
+			AgentName:     agentName,
+			ClockName:     clockName,
+			StateName:     stateName,
+			PollInterval:  mongoUpgradePollInterval,
+			ResyncTimeout: mongoResyncTimeout,
+			NewBackend:    mongoupgrader.NewBackend,
+			NewWorker:     mongoupgrader.NewWorker,
+		}))),
+
 		// The storageProvisioner worker manages provisioning
 		// (deprovisioning), and attachment (detachment) of first-class
 		// volumes and filesystems.
@@ -1065,6 +1135,7 @@ const (
 	proxyConfigUpdater            = "proxy-config-updater"
 	apiAddressUpdaterName         = "api-address-updater"
 	machinerName                  = "machiner"
+	networkConfigDriftName        = "network-config-drift"
 	logSenderName                 = "log-sender"
 	deployerName                  = "unit-agent-deployer"
 	authenticationWorkerName      = "ssh-authkeys-updater"
@@ -1073,6 +1144,7 @@ const (
 	identityFileWriterName        = "ssh-identity-writer"
 	toolsVersionCheckerName       = "tools-version-checker"
 	machineActionName             = "machine-action-runner"
+	crashReporterName             = "crash-reporter"
 	hostKeyReporterName           = "host-key-reporter"
 	fanConfigurerName             = "fan-configurer"
 	externalControllerUpdaterName = "external-controller-updater"
@@ -1083,6 +1155,8 @@ const (
 	instanceMutaterName           = "instance-mutater"
 	logPrunerName                 = "log-pruner"
 	txnPrunerName                 = "transaction-pruner"
+	stagedUpgraderName            = "staged-upgrader"
+	mongoUpgraderName             = "mongo-upgrader"
 	certificateWatcherName        = "certificate-watcher"
 	modelCacheName                = "model-cache"
 	modelWorkerManagerName        = "model-worker-manager"
@@ -1099,12 +1173,13 @@ const (
 	httpServerArgsName = "http-server-args"
 	apiServerName      = "api-server"
 
-	raftTransportName = "raft-transport"
-	raftName          = "raft"
-	raftClustererName = "raft-clusterer"
-	raftFlagName      = "raft-leader-flag"
-	raftBackstopName  = "raft-backstop"
-	raftForwarderName = "raft-forwarder"
+	raftTransportName   = "raft-transport"
+	raftName            = "raft"
+	raftClustererName   = "raft-clusterer"
+	raftFlagName        = "raft-leader-flag"
+	raftBackstopName    = "raft-backstop"
+	raftForwarderName   = "raft-forwarder"
+	raftSnapshotterName = "raft-snapshotter"
 
 	validCredentialFlagName = "valid-credential-flag"
 
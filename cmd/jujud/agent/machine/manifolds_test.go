@@ -71,6 +71,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"certificate-watcher",
 			"clock",
 			"controller-port",
+			"crash-reporter",
 			"disk-manager",
 			"external-controller-updater",
 			"fan-configurer",
@@ -95,6 +96,8 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"migration-inactive-flag",
 			"model-cache",
 			"model-worker-manager",
+			"mongo-upgrader",
+			"network-config-drift",
 			"peer-grouper",
 			"presence",
 			"proxy-config-updater",
@@ -104,11 +107,13 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"raft-clusterer",
 			"raft-forwarder",
 			"raft-leader-flag",
+			"raft-snapshotter",
 			"raft-transport",
 			"reboot-executor",
 			"restore-watcher",
 			"ssh-authkeys-updater",
 			"ssh-identity-writer",
+			"staged-upgrader",
 			"state",
 			"state-config-watcher",
 			"storage-provisioner",
@@ -147,6 +152,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"certificate-watcher",
 			"clock",
 			"controller-port",
+			"crash-reporter",
 			"disk-manager",
 			"external-controller-updater",
 			"fan-configurer",
@@ -177,9 +183,11 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"raft-clusterer",
 			"raft-forwarder",
 			"raft-leader-flag",
+			"raft-snapshotter",
 			"raft-transport",
 			"restore-watcher",
 			"ssh-identity-writer",
+			"staged-upgrader",
 			"state",
 			"state-config-watcher",
 			"termination-signal-handler",
@@ -240,6 +248,7 @@ func (ms *ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"log-forwarder",
 		"model-cache",
 		"model-worker-manager",
+		"mongo-upgrader",
 		"peer-grouper",
 		"presence",
 		"pubsub-forwarder",
@@ -263,6 +272,7 @@ func (ms *ManifoldsSuite) TestMigrationGuardsUsed(c *gc.C) {
 		"raft-clusterer",
 		"raft-forwarder",
 		"raft-leader-flag",
+		"raft-snapshotter",
 		"raft-transport",
 		"valid-credential-flag",
 	)
@@ -288,11 +298,13 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 		"is-primary-controller-flag",
 		"lease-manager",
 		"legacy-leases-flag",
+		"mongo-upgrader",
 		"raft-transport",
 	)
 	primaryControllerWorkers := set.NewStrings(
 		"external-controller-updater",
 		"log-pruner",
+		"staged-upgrader",
 		"transaction-pruner",
 	)
 	for name, manifold := range manifolds {
@@ -461,6 +473,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"crash-reporter": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"disk-manager": {
 		"agent",
 		"api-caller",
@@ -690,6 +714,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"network-config-drift": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"mgo-txn-resumer": {
 		"agent",
 		"api-caller",
@@ -742,6 +778,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"mongo-upgrader": {
+		"agent",
+		"clock",
+		"is-controller-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"peer-grouper": {
 		"agent",
 		"central-hub",
@@ -808,6 +856,23 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"raft-snapshotter": {
+		"agent",
+		"central-hub",
+		"clock",
+		"controller-port",
+		"http-server-args",
+		"is-controller-flag",
+		"raft",
+		"raft-transport",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"raft-clusterer": {
 		"agent",
 		"central-hub",
@@ -910,6 +975,23 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-gate",
 	},
 
+	"staged-upgrader": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"state": {"agent", "state-config-watcher"},
 
 	"state-config-watcher": {"agent"},
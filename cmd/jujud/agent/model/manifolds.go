@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/applicationscaler"
+	"github.com/juju/juju/worker/autoscaler"
 	"github.com/juju/juju/worker/caasbroker"
 	"github.com/juju/juju/worker/caasenvironupgrader"
 	"github.com/juju/juju/worker/caasfirewaller"
@@ -40,10 +41,12 @@ import (
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/common"
 	"github.com/juju/juju/worker/credentialvalidator"
+	"github.com/juju/juju/worker/dnspublisher"
 	"github.com/juju/juju/worker/environ"
 	"github.com/juju/juju/worker/firewaller"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
+	"github.com/juju/juju/worker/idleadvisor"
 	"github.com/juju/juju/worker/instancemutater"
 	"github.com/juju/juju/worker/instancepoller"
 	"github.com/juju/juju/worker/lifeflag"
@@ -54,12 +57,15 @@ import (
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationmaster"
 	"github.com/juju/juju/worker/modelupgrader"
+	"github.com/juju/juju/worker/orphanfinder"
 	"github.com/juju/juju/worker/provisioner"
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
 	"github.com/juju/juju/worker/singular"
+	"github.com/juju/juju/worker/sparemachines"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
+	"github.com/juju/juju/worker/tagreconciler"
 	"github.com/juju/juju/worker/undertaker"
 	"github.com/juju/juju/worker/unitassigner"
 )
@@ -371,6 +377,23 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     applicationscaler.NewFacade,
 			NewWorker:     applicationscaler.New,
 		})),
+		idleAdvisorName: ifNotMigrating(idleadvisor.Manifold(idleadvisor.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			NewFacade:     idleadvisor.NewFacade,
+			NewWorker:     idleadvisor.NewWorker,
+		})),
+		autoscalerName: ifNotMigrating(autoscaler.Manifold(autoscaler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			NewFacade:     autoscaler.NewFacade,
+			NewWorker:     autoscaler.NewWorker,
+		})),
+		spareMachinesName: ifNotMigrating(sparemachines.Manifold(sparemachines.ManifoldConfig{
+			APICallerName: apiCallerName,
+			NewFacade:     sparemachines.NewFacade,
+			NewWorker:     sparemachines.NewWorker,
+		})),
 		instancePollerName: ifNotMigrating(ifCredentialValid(instancepoller.Manifold(instancepoller.ManifoldConfig{
 			APICallerName:                apiCallerName,
 			EnvironName:                  environTrackerName,
@@ -387,6 +410,28 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:                    machineundertaker.NewWorker,
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
 		}))),
+		dnsPublisherName: ifNotMigrating(ifCredentialValid(dnspublisher.Manifold(dnspublisher.ManifoldConfig{
+			APICallerName:                apiCallerName,
+			EnvironName:                  environTrackerName,
+			ClockName:                    clockName,
+			NewFacade:                    dnspublisher.NewFacade,
+			NewWorker:                    dnspublisher.NewWorker,
+			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+		}))),
+		orphanFinderName: ifNotMigrating(ifCredentialValid(orphanfinder.Manifold(orphanfinder.ManifoldConfig{
+			APICallerName:                apiCallerName,
+			EnvironName:                  environTrackerName,
+			NewFacade:                    orphanfinder.NewFacade,
+			NewWorker:                    orphanfinder.NewWorker,
+			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+		}))),
+		tagReconcilerName: ifNotMigrating(ifCredentialValid(tagreconciler.Manifold(tagreconciler.ManifoldConfig{
+			APICallerName:                apiCallerName,
+			EnvironName:                  environTrackerName,
+			NewFacade:                    tagreconciler.NewFacade,
+			NewWorker:                    tagreconciler.NewWorker,
+			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+		}))),
 		environUpgraderName: ifCredentialValid(modelupgrader.Manifold(modelupgrader.ManifoldConfig{
 			APICallerName:                apiCallerName,
 			EnvironName:                  environTrackerName,
@@ -602,6 +647,12 @@ const (
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
 	instanceMutaterName      = "instance-mutater"
+	dnsPublisherName         = "dns-publisher"
+	orphanFinderName         = "orphan-finder"
+	tagReconcilerName        = "tag-reconciler"
+	idleAdvisorName          = "idle-advisor"
+	autoscalerName           = "autoscaler"
+	spareMachinesName        = "spare-machines"
 
 	caasFirewallerName          = "caas-firewaller"
 	caasOperatorProvisionerName = "caas-operator-provisioner"
@@ -37,14 +37,17 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"application-scaler",
+		"autoscaler",
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
+		"dns-publisher",
 		"environ-tracker",
 		"environ-upgrade-gate",
 		"environ-upgraded-flag",
 		"environ-upgrader",
 		"firewaller",
+		"idle-advisor",
 		"instance-poller",
 		"is-responsible-flag",
 		"log-forwarder",
@@ -55,10 +58,13 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"migration-master",
 		"not-alive-flag",
 		"not-dead-flag",
+		"orphan-finder",
 		"remote-relations",
+		"spare-machines",
 		"state-cleaner",
 		"status-history-pruner",
 		"storage-provisioner",
+		"tag-reconciler",
 		"undertaker",
 		"unit-assigner",
 		"valid-credential-flag",
@@ -399,6 +405,39 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"environ-upgraded-flag",
 		"not-dead-flag"},
 
+	"idle-advisor": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag"},
+
+	"autoscaler": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag"},
+
+	"spare-machines": {
+		"agent",
+		"api-caller",
+		"clock",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag"},
+
 	"charm-revision-updater": {
 		"agent",
 		"api-caller",
@@ -426,6 +465,20 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"valid-credential-flag",
 	},
 
+	"dns-publisher": {
+		"agent",
+		"api-caller",
+		"clock",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"environ-tracker": {
 		"agent",
 		"api-caller",
@@ -542,6 +595,20 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 
 	"not-dead-flag": {"agent", "api-caller"},
 
+	"orphan-finder": {
+		"agent",
+		"api-caller",
+		"clock",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"remote-relations": {
 		"agent",
 		"api-caller",
@@ -592,6 +659,20 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"valid-credential-flag",
 	},
 
+	"tag-reconciler": {
+		"agent",
+		"api-caller",
+		"clock",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"environ-upgrade-gate",
+		"environ-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
 	"undertaker": {
 		"agent",
 		"api-caller",
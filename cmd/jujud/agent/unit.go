@@ -16,7 +16,6 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
-	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/api/base"
@@ -114,19 +113,15 @@ func (a *UnitAgent) Init(args []string) error {
 	if err := a.ReadConfig(a.Tag().String()); err != nil {
 		return err
 	}
-	agentConfig := a.CurrentConfig()
 
-	if !a.logToStdErr {
-
-		// the writer in ctx.stderr gets set as the loggo writer in github.com/juju/cmd/logging.go
-		a.ctx.Stderr = &lumberjack.Logger{
-			Filename:   agent.LogFilename(agentConfig),
-			MaxSize:    300, // megabytes
-			MaxBackups: 2,
-			Compress:   true,
-		}
+	if a.logToStdErr {
+		return nil
 	}
-	return nil
+	config := a.CurrentConfig()
+	if err := setupAgentLogWriters(a.ctx, config); err != nil {
+		return err
+	}
+	return setupCrashReporting(config)
 }
 
 // Stop stops the unit agent.
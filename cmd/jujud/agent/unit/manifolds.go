@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
+	"github.com/juju/juju/worker/crashreporter"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/leadership"
@@ -257,6 +258,13 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			APICallerName: apiCallerName,
 		})),
 
+		// The crash reporter uploads the reports of the agent's
+		// crashes, kept locally, to the controller.
+		crashReporterName: ifNotMigrating(crashreporter.Manifold(crashreporter.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+		})),
+
 		// The proxy config updater is a leaf worker that sets http/https/apt/etc
 		// proxy settings.
 		// TODO(fwereade): timing of this is suspicious. There was superstitious
@@ -381,6 +389,7 @@ const (
 	loggingConfigUpdaterName = "logging-config-updater"
 	proxyConfigUpdaterName   = "proxy-config-updater"
 	apiAddressUpdaterName    = "api-address-updater"
+	crashReporterName        = "crash-reporter"
 
 	charmDirName          = "charm-dir"
 	leadershipTrackerName = "leadership-tracker"
@@ -364,6 +364,10 @@ func (FakeConfig) LogDir() string {
 	return filepath.FromSlash("/var/log/juju/")
 }
 
+func (FakeConfig) DataDir() string {
+	return filepath.FromSlash("/var/lib/juju/")
+}
+
 func (FakeConfig) Tag() names.Tag {
 	return names.NewMachineTag("42")
 }
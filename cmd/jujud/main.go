@@ -215,6 +215,7 @@ func Main(args []string) int {
 			buf := make([]byte, 4096)
 			buf = buf[:runtime.Stack(buf, false)]
 			logger.Criticalf("Unhandled panic: \n%v\n%s", r, buf)
+			agentcmd.RecordPanic(r)
 			os.Exit(exit_panic)
 		}
 	}()
@@ -5,13 +5,11 @@ package util
 
 import (
 	"fmt"
-	"strconv"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/os/series"
 
-	"github.com/juju/juju/agent"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/juju/paths"
 	"github.com/juju/juju/mongo"
@@ -184,54 +182,6 @@ var PingerIsDead = func(logger loggo.Logger, conn Pinger) bool {
 	return false
 }
 
-// NewEnsureServerParams creates an EnsureServerParams from an agent
-// configuration.
-func NewEnsureServerParams(agentConfig agent.Config) (mongo.EnsureServerParams, error) {
-	// If oplog size is specified in the agent configuration, use that.
-	// Otherwise leave the default zero value to indicate to EnsureServer
-	// that it should calculate the size.
-	var oplogSize int
-	if oplogSizeString := agentConfig.Value(agent.MongoOplogSize); oplogSizeString != "" {
-		var err error
-		if oplogSize, err = strconv.Atoi(oplogSizeString); err != nil {
-			return mongo.EnsureServerParams{}, fmt.Errorf("invalid oplog size: %q", oplogSizeString)
-		}
-	}
-
-	// If numa ctl preference is specified in the agent configuration, use that.
-	// Otherwise leave the default false value to indicate to EnsureServer
-	// that numactl should not be used.
-	var numaCtlPolicy bool
-	if numaCtlString := agentConfig.Value(agent.NUMACtlPreference); numaCtlString != "" {
-		var err error
-		if numaCtlPolicy, err = strconv.ParseBool(numaCtlString); err != nil {
-			return mongo.EnsureServerParams{}, fmt.Errorf("invalid numactl preference: %q", numaCtlString)
-		}
-	}
-
-	si, ok := agentConfig.StateServingInfo()
-	if !ok {
-		return mongo.EnsureServerParams{}, fmt.Errorf("agent config has no state serving info")
-	}
-
-	params := mongo.EnsureServerParams{
-		APIPort:        si.APIPort,
-		StatePort:      si.StatePort,
-		Cert:           si.Cert,
-		PrivateKey:     si.PrivateKey,
-		CAPrivateKey:   si.CAPrivateKey,
-		SharedSecret:   si.SharedSecret,
-		SystemIdentity: si.SystemIdentity,
-
-		DataDir:              agentConfig.DataDir(),
-		OplogSize:            oplogSize,
-		SetNUMAControlPolicy: numaCtlPolicy,
-
-		MemoryProfile: agentConfig.MongoMemoryProfile(),
-	}
-	return params, nil
-}
-
 // ParamsStateServingInfoToStateStateServingInfo converts a
 // params.StateServingInfo to a state.StateServingInfo.
 func ParamsStateServingInfoToStateStateServingInfo(i params.StateServingInfo) state.StateServingInfo {
@@ -0,0 +1,166 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcmd
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/retry"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// APIRetryPolicy describes how read-only API calls that fail with a
+// transient error, such as a busy or upgrading controller, are retried.
+type APIRetryPolicy struct {
+	// Attempts is the maximum number of times each call is made.
+	// A value of 1 disables retries.
+	Attempts int
+
+	// Delay is the delay before the first retry. It doubles for
+	// each subsequent retry, up to MaxDelay.
+	Delay time.Duration
+
+	// MaxDelay is the longest delay between retries.
+	MaxDelay time.Duration
+}
+
+// DefaultAPIRetryPolicy is the retry policy used unless it is
+// overridden by the JUJU_API_RETRY_ATTEMPTS and JUJU_API_RETRY_DELAY
+// environment variables.
+var DefaultAPIRetryPolicy = APIRetryPolicy{
+	Attempts: 3,
+	Delay:    time.Second,
+	MaxDelay: 10 * time.Second,
+}
+
+// apiRetryPolicy returns the default retry policy, updated with any
+// values set in the environment.
+func apiRetryPolicy() (APIRetryPolicy, error) {
+	policy := DefaultAPIRetryPolicy
+	if v := os.Getenv(osenv.JujuAPIRetryAttemptsEnvKey); v != "" {
+		attempts, err := strconv.Atoi(v)
+		if err != nil || attempts < 1 {
+			return APIRetryPolicy{}, errors.Errorf("invalid %s env var, expected a positive integer, got %q", osenv.JujuAPIRetryAttemptsEnvKey, v)
+		}
+		policy.Attempts = attempts
+	}
+	if v := os.Getenv(osenv.JujuAPIRetryDelayEnvKey); v != "" {
+		delay, err := time.ParseDuration(v)
+		if err != nil || delay <= 0 {
+			return APIRetryPolicy{}, errors.Errorf("invalid %s env var, expected a positive duration, got %q", osenv.JujuAPIRetryDelayEnvKey, v)
+		}
+		policy.Delay = delay
+		if policy.MaxDelay < delay {
+			policy.MaxDelay = delay
+		}
+	}
+	return policy, nil
+}
+
+// transientErrorCodes holds the codes of errors returned by a
+// controller that is temporarily unable to handle a call.
+var transientErrorCodes = set.NewStrings(
+	params.CodeTryAgain,
+	params.CodeRetry,
+	params.CodeUpgradeInProgress,
+)
+
+// idempotentMethods holds read-only API methods whose names don't
+// start with one of the prefixes in idempotentMethodPrefixes.
+var idempotentMethods = set.NewStrings(
+	"AllModels",
+	"ControllerConfig",
+	"FullStatus",
+	"Info",
+	"ModelConfig",
+	"ModelGet",
+	"ModelInfo",
+	"ModelStatus",
+	"Ping",
+	"StatusHistory",
+	"UserInfo",
+)
+
+var idempotentMethodPrefixes = []string{"Get", "List", "Show", "Find"}
+
+// isIdempotentMethod reports whether calling the API method more than
+// once has the same effect as calling it once, so that it can safely
+// be retried.
+func isIdempotentMethod(method string) bool {
+	if idempotentMethods.Contains(method) {
+		return true
+	}
+	for _, prefix := range idempotentMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// retryingConnection is an api.Connection that retries idempotent
+// API calls that fail with a transient error.
+type retryingConnection struct {
+	api.Connection
+	policy APIRetryPolicy
+	clock  clock.Clock
+}
+
+// APICall is part of the base.APICaller interface.
+func (c *retryingConnection) APICall(facade string, version int, id, method string, args, response interface{}) error {
+	if c.policy.Attempts <= 1 || !isIdempotentMethod(method) {
+		return c.Connection.APICall(facade, version, id, method, args, response)
+	}
+	var callErr error
+	err := retry.Call(retry.CallArgs{
+		Attempts:    c.policy.Attempts,
+		Delay:       c.policy.Delay,
+		MaxDelay:    c.policy.MaxDelay,
+		BackoffFunc: retry.DoubleDelay,
+		Clock:       c.clock,
+		Func: func() error {
+			callErr = c.Connection.APICall(facade, version, id, method, args, response)
+			return callErr
+		},
+		IsFatalError: func(err error) bool {
+			return !transientErrorCodes.Contains(params.ErrCode(err))
+		},
+		NotifyFunc: func(err error, attempt int) {
+			logger.Debugf("%s.%s failed on attempt %d: %v", facade, method, attempt, err)
+		},
+	})
+	if err != nil {
+		return callErr
+	}
+	return nil
+}
+
+// sharedConnection is an API connection that is shared by all the
+// callers in a command that need a connection to the same model or
+// controller as the same user. Closing it leaves the underlying
+// connection, which keeps itself alive with periodic pings, open for
+// the next caller; it is closed when the command finishes.
+type sharedConnection struct {
+	api.Connection
+}
+
+// Close is part of the api.Connection interface.
+func (c *sharedConnection) Close() error {
+	return nil
+}
+
+// apiConnectionKey returns the key under which connections to the
+// given model or controller, as the given user, are shared.
+func apiConnectionKey(controllerName, modelName, user, password string) string {
+	return strings.Join([]string{controllerName, modelName, user, password}, "\x00")
+}
@@ -0,0 +1,205 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcmd_test
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/juju/osenv"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/network"
+)
+
+type APIConnectionSuite struct {
+	testing.IsolationSuite
+	store *jujuclient.MemStore
+	conns []*fakeConnection
+}
+
+var _ = gc.Suite(&APIConnectionSuite{})
+
+func (s *APIConnectionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchEnvironment(osenv.JujuAPIRetryDelayEnvKey, "1ms")
+
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "foo"
+	s.store.Controllers["foo"] = jujuclient.ControllerDetails{
+		APIEndpoints: []string{"testing.invalid:1234"},
+	}
+	s.store.Models["foo"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/model": {ModelUUID: "deadbeef", ModelType: model.IAAS},
+		},
+		CurrentModel: "admin/model",
+	}
+	s.store.Accounts["foo"] = jujuclient.AccountDetails{
+		User: "bar", Password: "hunter2",
+	}
+	s.conns = nil
+}
+
+func (s *APIConnectionSuite) newCommand(c *gc.C) *modelcmd.ModelCommandBase {
+	baseCmd := new(modelcmd.ModelCommandBase)
+	baseCmd.SetClientStore(s.store)
+	baseCmd.SetAPIOpen(func(*api.Info, api.DialOpts) (api.Connection, error) {
+		conn := &fakeConnection{}
+		s.conns = append(s.conns, conn)
+		return conn, nil
+	})
+	modelcmd.InitContexts(&cmd.Context{Stderr: ioutil.Discard}, baseCmd)
+	modelcmd.SetRunStarted(baseCmd)
+	err := baseCmd.SetModelName("foo:admin/model", false)
+	c.Assert(err, jc.ErrorIsNil)
+	return baseCmd
+}
+
+func (s *APIConnectionSuite) TestConnectionShared(c *gc.C) {
+	baseCmd := s.newCommand(c)
+
+	conn1, err := baseCmd.NewAPIRoot()
+	c.Assert(err, jc.ErrorIsNil)
+	err = conn1.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	conn2, err := baseCmd.NewAPIRoot()
+	c.Assert(err, jc.ErrorIsNil)
+	defer conn2.Close()
+
+	c.Assert(s.conns, gc.HasLen, 1)
+	c.Check(s.conns[0].closed, jc.IsFalse)
+
+	modelcmd.CloseAPIContexts(baseCmd)
+	c.Check(s.conns[0].closed, jc.IsTrue)
+}
+
+func (s *APIConnectionSuite) TestBrokenConnectionReplaced(c *gc.C) {
+	baseCmd := s.newCommand(c)
+
+	_, err := baseCmd.NewAPIRoot()
+	c.Assert(err, jc.ErrorIsNil)
+	s.conns[0].broken = true
+	_, err = baseCmd.NewAPIRoot()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.conns, gc.HasLen, 2)
+	c.Check(s.conns[0].closed, jc.IsTrue)
+	c.Check(s.conns[1].closed, jc.IsFalse)
+}
+
+func (s *APIConnectionSuite) TestIdempotentCallRetried(c *gc.C) {
+	baseCmd := s.newCommand(c)
+	conn, err := baseCmd.NewAPIRoot()
+	c.Assert(err, jc.ErrorIsNil)
+	s.conns[0].SetErrors(&params.Error{Code: params.CodeTryAgain, Message: "busy"})
+
+	err = conn.APICall("Client", 1, "", "FullStatus", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.conns[0].CheckCallNames(c, "APICall", "APICall")
+}
+
+func (s *APIConnectionSuite) TestIdempotentCallRetriesExhausted(c *gc.C) {
+	s.PatchEnvironment(osenv.JujuAPIRetryAttemptsEnvKey, "2")
+	baseCmd := s.newCommand(c)
+	conn, err := baseCmd.NewAPIRoot()
+	c.Assert(err, jc.ErrorIsNil)
+	s.conns[0].SetErrors(
+		&params.Error{Code: params.CodeTryAgain, Message: "busy"},
+		&params.Error{Code: params.CodeUpgradeInProgress, Message: "upgrading"},
+	)
+
+	err = conn.APICall("Client", 1, "", "GetModelConstraints", nil, nil)
+	c.Assert(err, gc.ErrorMatches, "upgrading")
+	s.conns[0].CheckCallNames(c, "APICall", "APICall")
+}
+
+func (s *APIConnectionSuite) TestNonIdempotentCallNotRetried(c *gc.C) {
+	baseCmd := s.newCommand(c)
+	conn, err := baseCmd.NewAPIRoot()
+	c.Assert(err, jc.ErrorIsNil)
+	s.conns[0].SetErrors(&params.Error{Code: params.CodeTryAgain, Message: "busy"})
+
+	err = conn.APICall("Application", 1, "", "Deploy", nil, nil)
+	c.Assert(err, gc.ErrorMatches, "busy")
+	s.conns[0].CheckCallNames(c, "APICall")
+}
+
+func (s *APIConnectionSuite) TestPermanentErrorNotRetried(c *gc.C) {
+	baseCmd := s.newCommand(c)
+	conn, err := baseCmd.NewAPIRoot()
+	c.Assert(err, jc.ErrorIsNil)
+	s.conns[0].SetErrors(&params.Error{Code: params.CodeUnauthorized, Message: "denied"})
+
+	err = conn.APICall("Client", 1, "", "FullStatus", nil, nil)
+	c.Assert(err, gc.ErrorMatches, "denied")
+	s.conns[0].CheckCallNames(c, "APICall")
+}
+
+func (s *APIConnectionSuite) TestInvalidRetryEnvironment(c *gc.C) {
+	s.PatchEnvironment(osenv.JujuAPIRetryAttemptsEnvKey, "many")
+	baseCmd := s.newCommand(c)
+	_, err := baseCmd.NewAPIRoot()
+	c.Assert(err, gc.ErrorMatches, `invalid JUJU_API_RETRY_ATTEMPTS env var, expected a positive integer, got "many"`)
+	c.Assert(s.conns, gc.HasLen, 0)
+}
+
+type fakeConnection struct {
+	api.Connection
+	testing.Stub
+
+	closed bool
+	broken bool
+}
+
+func (c *fakeConnection) APICall(facade string, version int, id, method string, args, response interface{}) error {
+	c.MethodCall(c, "APICall", facade, version, id, method, args, response)
+	return c.NextErr()
+}
+
+func (c *fakeConnection) Close() error {
+	c.closed = true
+	return nil
+}
+
+func (c *fakeConnection) IsBroken() bool {
+	return c.broken
+}
+
+func (c *fakeConnection) APIHostPorts() [][]network.HostPort {
+	return nil
+}
+
+func (c *fakeConnection) ServerVersion() (version.Number, bool) {
+	return version.Number{}, false
+}
+
+func (c *fakeConnection) Addr() string {
+	return "testing.invalid:1234"
+}
+
+func (c *fakeConnection) IPAddr() string {
+	return "0.1.2.3:1234"
+}
+
+func (c *fakeConnection) PublicDNSName() string {
+	return ""
+}
+
+func (c *fakeConnection) AuthTag() names.Tag {
+	return names.NewUserTag("bar")
+}
+
+func (c *fakeConnection) ControllerAccess() string {
+	return "superuser"
+}
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 
+	"github.com/juju/clock"
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -60,6 +61,7 @@ type CommandBase struct {
 	cmd.CommandBase
 	cmdContext    *cmd.Context
 	apiContexts   map[string]*apiContext
+	apiConns      map[string]api.Connection
 	modelAPI_     ModelAPI
 	apiOpenFunc   api.OpenFunc
 	authOpts      AuthOpts
@@ -68,6 +70,10 @@ type CommandBase struct {
 
 	// CanClearCurrentModel indicates that this command can reset current model in local cache, aka client store.
 	CanClearCurrentModel bool
+
+	// readOnlyAPI indicates that API connections ask the controller
+	// for read-only access.
+	readOnlyAPI bool
 }
 
 func (c *CommandBase) assertRunStarted() {
@@ -80,9 +86,15 @@ func (c *CommandBase) setRunStarted() {
 	c.runStarted = true
 }
 
-// closeAPIContexts closes any API contexts that have
-// been created.
+// closeAPIContexts closes any API contexts and shared API
+// connections that have been created.
 func (c *CommandBase) closeAPIContexts() {
+	for key, conn := range c.apiConns {
+		if err := conn.Close(); err != nil {
+			logger.Errorf("%v", err)
+		}
+		delete(c.apiConns, key)
+	}
 	for name, ctx := range c.apiContexts {
 		if err := ctx.Close(); err != nil {
 			logger.Errorf("%v", err)
@@ -106,6 +118,14 @@ func (c *CommandBase) SetAPIOpen(apiOpen api.OpenFunc) {
 	c.apiOpenFunc = apiOpen
 }
 
+// SetReadOnlyAPI sets whether the command's API connections ask the
+// controller for read-only access, which is served from secondary
+// members of the controller's replica set and so may lag slightly
+// behind. Only read-only calls may be made on such connections.
+func (c *CommandBase) SetReadOnlyAPI(readOnly bool) {
+	c.readOnlyAPI = readOnly
+}
+
 // SetModelRefresh sets the function used for refreshing models.
 func (c *CommandBase) SetModelRefresh(refresh func(jujuclient.ClientStore, string) error) {
 	c.refreshModels = refresh
@@ -124,8 +144,10 @@ func (c *CommandBase) modelAPI(store jujuclient.ClientStore, controllerName stri
 	return c.modelAPI_, nil
 }
 
-// NewAPIRoot returns a new connection to the API server for the given
-// model or controller.
+// NewAPIRoot returns a connection to the API server for the given
+// model or controller. Connections are shared between the callers in
+// a command, and read-only calls made on them are retried if they fail
+// with a transient error, according to the APIRetryPolicy.
 func (c *CommandBase) NewAPIRoot(
 	store jujuclient.ClientStore,
 	controllerName, modelName string,
@@ -146,17 +168,42 @@ func (c *CommandBase) NewAPIRoot(
 			accountDetails = &jujuclient.AccountDetails{}
 		}
 	}
+	key := apiConnectionKey(controllerName, modelName, accountDetails.User, accountDetails.Password)
+	if conn, ok := c.apiConns[key]; ok {
+		if !conn.IsBroken() {
+			return &sharedConnection{conn}, nil
+		}
+		conn.Close()
+		delete(c.apiConns, key)
+	}
+	policy, err := apiRetryPolicy()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	param, err := c.NewAPIConnectionParams(
 		store, controllerName, modelName, accountDetails,
 	)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	param.ReadOnly = c.readOnlyAPI
 	conn, err := juju.NewAPIConnection(param)
 	if modelName != "" && params.ErrCode(err) == params.CodeModelNotFound {
 		return nil, c.missingModelError(store, controllerName, modelName)
 	}
-	return conn, err
+	if err != nil {
+		return nil, err
+	}
+	conn = &retryingConnection{
+		Connection: conn,
+		policy:     policy,
+		clock:      clock.WallClock,
+	}
+	if c.apiConns == nil {
+		c.apiConns = make(map[string]api.Connection)
+	}
+	c.apiConns[key] = conn
+	return &sharedConnection{conn}, nil
 }
 
 // RemoveModelFromClientStore removes given model from client cache, store,
@@ -99,6 +99,24 @@ func (s *BaseCommandSuite) TestUnknownModelNotCurrentCanRemoveCachedCurrent(c *g
 	s.assertUnknownModel(c, baseCmd, "admin/goodmodel", "admin/goodmodel")
 }
 
+func (s *BaseCommandSuite) TestReadOnlyAPI(c *gc.C) {
+	var readOnly bool
+	apiOpen := func(info *api.Info, _ api.DialOpts) (api.Connection, error) {
+		readOnly = info.ReadOnly
+		return nil, errors.New("no connection")
+	}
+	baseCmd := new(modelcmd.ModelCommandBase)
+	baseCmd.SetClientStore(s.store)
+	baseCmd.SetAPIOpen(apiOpen)
+	baseCmd.SetReadOnlyAPI(true)
+	modelcmd.InitContexts(&cmd.Context{Stderr: ioutil.Discard}, baseCmd)
+	modelcmd.SetRunStarted(baseCmd)
+	baseCmd.SetModelName("foo:admin/goodmodel", false)
+	_, err := baseCmd.NewAPIRoot()
+	c.Assert(err, gc.ErrorMatches, "no connection")
+	c.Assert(readOnly, jc.IsTrue)
+}
+
 type NewGetBootstrapConfigParamsFuncSuite struct {
 	testing.IsolationSuite
 }
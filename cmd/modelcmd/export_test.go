@@ -28,3 +28,9 @@ func SetModelRefresh(refresh func(jujuclient.ClientStore, string) error, b inter
 }) {
 	b.SetModelRefresh(refresh)
 }
+
+func CloseAPIContexts(b interface {
+	closeAPIContexts()
+}) {
+	b.closeAPIContexts()
+}
@@ -132,6 +132,7 @@ func (broker *kvmBroker) StartInstance(ctx context.ProviderCallContext, args env
 		config.LegacyProxy,
 		config.JujuProxy,
 		config.AptProxy,
+		config.SnapProxy,
 		config.AptMirror,
 		config.EnableOSRefreshUpdate,
 		config.EnableOSUpgrade,
@@ -131,6 +131,7 @@ func (broker *lxdBroker) StartInstance(ctx context.ProviderCallContext, args env
 		config.LegacyProxy,
 		config.JujuProxy,
 		config.AptProxy,
+		config.SnapProxy,
 		config.AptMirror,
 		config.EnableOSRefreshUpdate,
 		config.EnableOSUpgrade,
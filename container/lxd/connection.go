@@ -93,6 +93,16 @@ func MakeSimpleStreamsServerSpec(name, host string) ServerSpec {
 	}
 }
 
+// MakeLXDServerSpec creates a ServerSpec for a public LXD image server,
+// ensuring that the host is HTTPS
+func MakeLXDServerSpec(name, host string) ServerSpec {
+	return ServerSpec{
+		Name:     name,
+		Host:     EnsureHTTPS(host),
+		Protocol: LXDProtocol,
+	}
+}
+
 // Validate ensures that the ServerSpec is valid.
 func (s *ServerSpec) Validate() error {
 	return nil
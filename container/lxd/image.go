@@ -6,6 +6,7 @@ package lxd
 import (
 	"fmt"
 	"path"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/core/status"
@@ -115,6 +116,104 @@ func (s *Server) FindImage(
 	return sourced, nil
 }
 
+// FindCustomImage searches for a custom image, identified by alias or
+// fingerprint, rather than an OS image for a series.
+// The input sources are searched in supplied order. An image found
+// remotely is copied to the local cache unless the cache already holds
+// the same image, and the local alias is pointed at it. This means that
+// an image republished under the same alias replaces the cached one the
+// next time it is used.
+// If none of the sources has the image, an image already on the server
+// is used.
+// The callback argument is used to report copy progress.
+func (s *Server) FindCustomImage(
+	name string,
+	sources []ServerSpec,
+	callback environs.StatusCallbackFunc,
+) (SourcedImage, error) {
+	if callback != nil {
+		callback(status.Provisioning, fmt.Sprintf("acquiring LXD image %q", name), nil)
+	}
+
+	for _, remote := range sources {
+		source, err := ConnectImageRemote(remote)
+		if err != nil {
+			logger.Infof("failed to connect to %q: %s", remote.Host, err)
+			continue
+		}
+		image, err := findImageByName(source, name)
+		if err != nil {
+			logger.Debugf("image %q not found on %q: %s", name, remote.Name, err)
+			continue
+		}
+
+		cached, _, err := s.GetImage(image.Fingerprint)
+		if err != nil {
+			logger.Debugf("Copying image %q %q from %q", name, image.Fingerprint, remote.Name)
+			sourced := SourcedImage{Image: image, LXDServer: source}
+			if err := s.CopyRemoteImage(sourced, nil, callback); err != nil {
+				return SourcedImage{}, errors.Trace(err)
+			}
+			if cached, _, err = s.GetImage(image.Fingerprint); err != nil {
+				return SourcedImage{}, errors.Trace(err)
+			}
+		}
+		if err := s.ensureImageAlias(name, cached.Fingerprint); err != nil {
+			return SourcedImage{}, errors.Trace(err)
+		}
+		return SourcedImage{
+			Image:     cached,
+			LXDServer: s.ContainerServer,
+		}, nil
+	}
+
+	image, err := findImageByName(s.ContainerServer, name)
+	if err != nil {
+		return SourcedImage{}, errors.Annotatef(err, "finding image %q", name)
+	}
+	logger.Debugf("Found image locally - %q %q", name, image.Fingerprint)
+	return SourcedImage{
+		Image:     image,
+		LXDServer: s.ContainerServer,
+	}, nil
+}
+
+// findImageByName returns the image on the server with the given alias
+// or, failing that, fingerprint.
+func findImageByName(server lxd.ImageServer, name string) (*api.Image, error) {
+	target := name
+	if entry, _, err := server.GetImageAlias(name); err == nil && entry != nil && entry.Target != "" {
+		target = entry.Target
+	}
+	image, _, err := server.GetImage(target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return image, nil
+}
+
+// ensureImageAlias points the local alias with the given name at the
+// image with the given fingerprint, unless the name is the fingerprint.
+func (s *Server) ensureImageAlias(name, fingerprint string) error {
+	if strings.HasPrefix(fingerprint, name) {
+		return nil
+	}
+	entry, _, err := s.GetImageAlias(name)
+	if err != nil || entry == nil {
+		return errors.Trace(s.CreateImageAlias(api.ImageAliasesPost{
+			ImageAliasesEntry: api.ImageAliasesEntry{
+				Name:                 name,
+				ImageAliasesEntryPut: api.ImageAliasesEntryPut{Target: fingerprint},
+			},
+		}))
+	}
+	if entry.Target == fingerprint {
+		return nil
+	}
+	logger.Infof("refreshing image alias %q from %q to %q", name, entry.Target, fingerprint)
+	return errors.Trace(s.UpdateImageAlias(name, api.ImageAliasesEntryPut{Target: fingerprint}, ""))
+}
+
 // CopyRemoteImage accepts an image sourced from a remote server and copies it
 // to the local cache
 func (s *Server) CopyRemoteImage(
@@ -184,6 +184,118 @@ func (s *imageSuite) TestFindImageRemoteServersNotFound(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, ".*failed to retrieve image.*")
 }
 
+func (s *imageSuite) TestFindCustomImageRemoteCopiesImage(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	rSvr := lxdtesting.NewMockImageServer(ctrl)
+	s.patch(map[string]lxdclient.ImageServer{
+		"server-that-has-image": rSvr,
+	})
+
+	copyOp := lxdtesting.NewMockRemoteOperation(ctrl)
+	copyOp.EXPECT().Wait().Return(nil).AnyTimes()
+	copyOp.EXPECT().GetTarget().Return(&lxdapi.Operation{StatusCode: lxdapi.Success}, nil)
+
+	image := lxdapi.Image{Fingerprint: "fingerprint-1"}
+	alias := lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "fingerprint-1"}}
+	copyReq := &lxdclient.ImageCopyArgs{Aliases: []lxdapi.ImageAlias{}}
+	createReq := lxdapi.ImageAliasesPost{ImageAliasesEntry: lxdapi.ImageAliasesEntry{
+		Name:                 "custom",
+		ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "fingerprint-1"},
+	}}
+	gomock.InOrder(
+		rSvr.EXPECT().GetImageAlias("custom").Return(&alias, lxdtesting.ETag, nil),
+		rSvr.EXPECT().GetImage("fingerprint-1").Return(&image, lxdtesting.ETag, nil),
+		iSvr.EXPECT().GetImage("fingerprint-1").Return(nil, lxdtesting.ETag, errors.New("not found")),
+		iSvr.EXPECT().CopyImage(rSvr, image, copyReq).Return(copyOp, nil),
+		iSvr.EXPECT().GetImage("fingerprint-1").Return(&image, lxdtesting.ETag, nil),
+		iSvr.EXPECT().GetImageAlias("custom").Return(nil, lxdtesting.ETag, errors.New("not found")),
+		iSvr.EXPECT().CreateImageAlias(createReq).Return(nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	remotes := []lxd.ServerSpec{{Name: "server-that-has-image", Protocol: lxd.LXDProtocol}}
+	found, err := jujuSvr.FindCustomImage("custom", remotes, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(found.LXDServer, gc.Equals, iSvr)
+	c.Check(*found.Image, gc.DeepEquals, image)
+}
+
+func (s *imageSuite) TestFindCustomImageRemoteRefreshesAlias(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	rSvr := lxdtesting.NewMockImageServer(ctrl)
+	s.patch(map[string]lxdclient.ImageServer{
+		"server-that-has-image": rSvr,
+	})
+
+	// The cache already holds the republished image, but the local
+	// alias still points at the previous one.
+	image := lxdapi.Image{Fingerprint: "fingerprint-2"}
+	remoteAlias := lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "fingerprint-2"}}
+	localAlias := lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "fingerprint-1"}}
+	gomock.InOrder(
+		rSvr.EXPECT().GetImageAlias("custom").Return(&remoteAlias, lxdtesting.ETag, nil),
+		rSvr.EXPECT().GetImage("fingerprint-2").Return(&image, lxdtesting.ETag, nil),
+		iSvr.EXPECT().GetImage("fingerprint-2").Return(&image, lxdtesting.ETag, nil),
+		iSvr.EXPECT().GetImageAlias("custom").Return(&localAlias, lxdtesting.ETag, nil),
+		iSvr.EXPECT().UpdateImageAlias("custom", lxdapi.ImageAliasesEntryPut{Target: "fingerprint-2"}, "").Return(nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	remotes := []lxd.ServerSpec{{Name: "server-that-has-image", Protocol: lxd.LXDProtocol}}
+	found, err := jujuSvr.FindCustomImage("custom", remotes, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(found.LXDServer, gc.Equals, iSvr)
+	c.Check(*found.Image, gc.DeepEquals, image)
+}
+
+func (s *imageSuite) TestFindCustomImageLocalServer(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	image := lxdapi.Image{Fingerprint: "fingerprint-1"}
+	alias := lxdapi.ImageAliasesEntry{ImageAliasesEntryPut: lxdapi.ImageAliasesEntryPut{Target: "fingerprint-1"}}
+	gomock.InOrder(
+		iSvr.EXPECT().GetImageAlias("custom").Return(&alias, lxdtesting.ETag, nil),
+		iSvr.EXPECT().GetImage("fingerprint-1").Return(&image, lxdtesting.ETag, nil),
+	)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	found, err := jujuSvr.FindCustomImage("custom", nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(found.LXDServer, gc.Equals, iSvr)
+	c.Check(*found.Image, gc.DeepEquals, image)
+}
+
+func (s *imageSuite) TestFindCustomImageNotFound(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+	iSvr := s.NewMockServer(ctrl)
+
+	gomock.InOrder(
+		iSvr.EXPECT().GetImageAlias("custom").Return(nil, lxdtesting.ETag, errors.New("not found")),
+		iSvr.EXPECT().GetImage("custom").Return(nil, lxdtesting.ETag, errors.New("not found")),
+	)
+
+	jujuSvr, err := lxd.NewServer(iSvr)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = jujuSvr.FindCustomImage("custom", nil, nil)
+	c.Assert(err, gc.ErrorMatches, `finding image "custom": not found`)
+}
+
 func (s *imageSuite) TestSeriesRemoteAliasesNotSupported(c *gc.C) {
 	_, err := lxd.SeriesRemoteAliases("centos7", "arm64")
 	c.Assert(err, gc.ErrorMatches, `series "centos7" not supported`)
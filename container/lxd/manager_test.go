@@ -102,6 +102,7 @@ func prepInstanceConfig(c *gc.C) *instancecfg.InstanceConfig {
 		proxy.Settings{},
 		proxy.Settings{},
 		proxy.Settings{},
+		proxy.Settings{},
 		"",
 		false,
 		false,
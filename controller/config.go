@@ -6,7 +6,9 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/juju/collections/set"
@@ -21,6 +23,7 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/core/resources"
+	"github.com/juju/juju/permission"
 )
 
 const (
@@ -30,6 +33,15 @@ const (
 	MongoProfDefault = "default"
 )
 
+const (
+	// AutocertChallengeTLSALPN01 is the ACME challenge type answered by
+	// the API server itself, on the API port.
+	AutocertChallengeTLSALPN01 = "tls-alpn-01"
+	// AutocertChallengeHTTP01 is the ACME challenge type answered over
+	// plain HTTP on port 80.
+	AutocertChallengeHTTP01 = "http-01"
+)
+
 const (
 	// APIPort is the port used for api connections.
 	APIPort = "api-port"
@@ -97,6 +109,36 @@ const (
 	// IdentityPublicKey sets the public key of the identity manager.
 	IdentityPublicKey = "identity-public-key"
 
+	// OIDCIssuerURL sets the URL of an OpenID Connect identity provider
+	// whose ID tokens users may log in with. Users authenticated this
+	// way are external users.
+	OIDCIssuerURL = "oidc-issuer-url"
+
+	// OIDCClientID sets the client ID that OpenID Connect ID tokens
+	// must have been issued for.
+	OIDCClientID = "oidc-client-id"
+
+	// OIDCGroupsClaim sets the name of the ID token claim that lists
+	// the groups a user belongs to. It defaults to "groups".
+	OIDCGroupsClaim = "oidc-groups-claim"
+
+	// OIDCGroupAccess maps identity provider groups to the controller
+	// access granted to their members, as a list of "<group>=<access>"
+	// entries, e.g. "juju-admins=superuser".
+	OIDCGroupAccess = "oidc-group-access"
+
+	// APIPolicyURL sets the URL of an external policy endpoint that is
+	// consulted before each API method call made by a user is
+	// dispatched. Agent calls are not subject to the policy. If it is
+	// not set, all authenticated calls are allowed through to the
+	// facades' own permission checks.
+	APIPolicyURL = "api-policy-url"
+
+	// APIPolicyFailOpen sets whether API calls are allowed when the
+	// policy endpoint set by APIPolicyURL cannot be consulted. By
+	// default such calls are refused.
+	APIPolicyFailOpen = "api-policy-fail-open"
+
 	// SetNUMAControlPolicyKey stores the value for this setting
 	SetNUMAControlPolicyKey = "set-numa-control-policy"
 
@@ -113,6 +155,13 @@ const (
 	// "https://acme-staging.api.letsencrypt.org/directory".
 	AutocertURLKey = "autocert-url"
 
+	// AutocertChallengeKey sets the ACME challenge type used to prove
+	// ownership of the autocert DNS name when requesting or renewing a
+	// certificate. It is one of "tls-alpn-01" (the default), which
+	// requires the API to be reachable on port 443, or "http-01", which
+	// requires port 80 to be reachable.
+	AutocertChallengeKey = "autocert-challenge"
+
 	// AllowModelAccessKey sets whether the controller will allow users to
 	// connect to models they have been authorized for even when
 	// they don't have any access rights to the controller itself.
@@ -156,6 +205,64 @@ const (
 	// to not sleep at all.
 	PruneTxnSleepTime = "prune-txn-sleep-time"
 
+	// PruneTxnInterval is how often the controller prunes completed
+	// transactions, eg "1h". If it is not set, the agent's built-in
+	// interval is used. Changes take effect without a restart.
+	PruneTxnInterval = "prune-txn-interval"
+
+	// PruneLogsInterval is how often the controller prunes the log
+	// collections, eg "5m". If it is not set, the agent's built-in
+	// interval is used. Changes take effect without a restart.
+	PruneLogsInterval = "prune-logs-interval"
+
+	// RaftSnapshotThreshold is the number of raft log entries written
+	// between snapshots. After each snapshot the older log entries are
+	// discarded. Changes take effect when the controller agents restart.
+	RaftSnapshotThreshold = "raft-snapshot-threshold"
+
+	// RaftTrailingLogs is the number of raft log entries kept after a
+	// snapshot, so that slow followers can catch up without needing
+	// the whole snapshot. Changes take effect when the controller
+	// agents restart.
+	RaftTrailingLogs = "raft-trailing-logs"
+
+	// AgentBinaryBandwidthLimit is the maximum rate, in KiB per second,
+	// at which each controller sends agent binaries to agents. Zero,
+	// the default, means no limit. Changes take effect immediately.
+	AgentBinaryBandwidthLimit = "agent-binary-bandwidth-limit"
+
+	// ResourceBandwidthLimit is the maximum rate, in KiB per second,
+	// at which each controller sends resources to units, summed over
+	// all models. Zero, the default, means no limit. Changes take
+	// effect immediately.
+	ResourceBandwidthLimit = "resource-bandwidth-limit"
+
+	// BackupBandwidthLimit is the maximum rate, in KiB per second, at
+	// which each controller streams backups to clients. Zero, the
+	// default, means no limit. Changes take effect immediately.
+	BackupBandwidthLimit = "backup-bandwidth-limit"
+
+	// MaxRelationSettingsSize is the maximum size, in KiB, of a unit's
+	// settings in a relation. Writes that would take the settings over
+	// the limit fail. Zero means no limit. Changes take effect
+	// immediately.
+	MaxRelationSettingsSize = "max-relation-settings-size"
+
+	// AgentLogTarget is where machine and unit agents write their
+	// logs: to a file in the log directory, to the local journald
+	// with structured fields, or to both. Machine agents restart to
+	// apply changes, and unit agents deployed afterwards use the new
+	// value.
+	AgentLogTarget = "agent-log-target"
+
+	// AgentLogfileMaxSize is the size at which agent log files are
+	// rotated.
+	AgentLogfileMaxSize = "agent-logfile-max-size"
+
+	// AgentLogfileMaxBackups is the number of rotated agent log files
+	// kept.
+	AgentLogfileMaxBackups = "agent-logfile-max-backups"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -178,6 +285,10 @@ const (
 	// Only use numactl if user specifically requests it
 	DefaultNUMAControlPolicy = false
 
+	// DefaultOIDCGroupsClaim is the default ID token claim listing the
+	// groups of users logging in with OpenID Connect.
+	DefaultOIDCGroupsClaim = "groups"
+
 	// DefaultStatePort is the default port the controller is listening on.
 	DefaultStatePort int = 37017
 
@@ -217,6 +328,36 @@ const (
 	// other systems to operate concurrently.
 	DefaultPruneTxnSleepTime = "10ms"
 
+	// DefaultRaftSnapshotThreshold is the default number of raft log
+	// entries written between snapshots.
+	DefaultRaftSnapshotThreshold = 8192
+
+	// DefaultRaftTrailingLogs is the default number of raft log
+	// entries kept after a snapshot.
+	DefaultRaftTrailingLogs = 10240
+
+	// DefaultMaxRelationSettingsSize is the default maximum size, in
+	// KiB, of a unit's settings in a relation.
+	DefaultMaxRelationSettingsSize = 1024
+
+	// AgentLogTargetFile, AgentLogTargetJournald and
+	// AgentLogTargetFileAndJournald are the valid values of
+	// AgentLogTarget.
+	AgentLogTargetFile            = "file"
+	AgentLogTargetJournald        = "journald"
+	AgentLogTargetFileAndJournald = "file+journald"
+
+	// DefaultAgentLogTarget is the default target of agent logs.
+	DefaultAgentLogTarget = AgentLogTargetFile
+
+	// DefaultAgentLogfileMaxSizeMB is the default size in MB at which
+	// agent log files are rotated.
+	DefaultAgentLogfileMaxSizeMB = 300
+
+	// DefaultAgentLogfileMaxBackups is the default number of rotated
+	// agent log files kept.
+	DefaultAgentLogfileMaxBackups = 2
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -239,6 +380,19 @@ const (
 
 	// MeteringURL is the key for the url to use for metrics
 	MeteringURL = "metering-url"
+
+	// LocalCharmRepository is the key for the path, on each controller
+	// machine, of a directory of charms used to resolve and fetch charm
+	// store charms when the charm store cannot be reached.
+	LocalCharmRepository = "local-charm-repository"
+
+	// ExternalProviders is the key for the list of out-of-tree cloud
+	// providers, each given as "<type>=unix:<path>", where path is the
+	// unix socket the provider's gRPC service listens on. Only local
+	// sockets are supported, as cloud credentials are passed to the
+	// providers. This is experimental, and changes take effect when the
+	// controller agents restart.
+	ExternalProviders = "external-providers"
 )
 
 var (
@@ -248,6 +402,7 @@ var (
 		AllowModelAccessKey,
 		APIPort,
 		APIPortOpenDelay,
+		AutocertChallengeKey,
 		AutocertDNSNameKey,
 		AutocertURLKey,
 		CACertKey,
@@ -256,6 +411,12 @@ var (
 		ControllerUUIDKey,
 		IdentityPublicKey,
 		IdentityURL,
+		OIDCClientID,
+		OIDCGroupAccess,
+		OIDCGroupsClaim,
+		OIDCIssuerURL,
+		APIPolicyURL,
+		APIPolicyFailOpen,
 		SetNUMAControlPolicyKey,
 		StatePort,
 		MongoMemoryProfile,
@@ -266,6 +427,17 @@ var (
 		MaxPruneTxnPasses,
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
+		PruneTxnInterval,
+		PruneLogsInterval,
+		RaftSnapshotThreshold,
+		RaftTrailingLogs,
+		AgentBinaryBandwidthLimit,
+		ResourceBandwidthLimit,
+		BackupBandwidthLimit,
+		MaxRelationSettingsSize,
+		AgentLogTarget,
+		AgentLogfileMaxSize,
+		AgentLogfileMaxBackups,
 		JujuHASpace,
 		JujuManagementSpace,
 		AuditingEnabled,
@@ -277,6 +449,8 @@ var (
 		CAASImageRepo,
 		Features,
 		MeteringURL,
+		LocalCharmRepository,
+		ExternalProviders,
 	}
 
 	// AllowedUpdateConfigAttributes contains all of the controller
@@ -287,6 +461,12 @@ var (
 		AuditingEnabled,
 		AuditLogCaptureArgs,
 		AuditLogExcludeMethods,
+		APIPolicyURL,
+		APIPolicyFailOpen,
+		OIDCClientID,
+		OIDCGroupAccess,
+		OIDCGroupsClaim,
+		OIDCIssuerURL,
 		// TODO Juju 3.0: ControllerAPIPort should be required and treated
 		// more like api-port.
 		ControllerAPIPort,
@@ -297,11 +477,24 @@ var (
 		MongoMemoryProfile,
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
+		PruneTxnInterval,
+		PruneLogsInterval,
+		RaftSnapshotThreshold,
+		RaftTrailingLogs,
+		AgentBinaryBandwidthLimit,
+		ResourceBandwidthLimit,
+		BackupBandwidthLimit,
+		MaxRelationSettingsSize,
+		AgentLogTarget,
+		AgentLogfileMaxSize,
+		AgentLogfileMaxBackups,
 		JujuHASpace,
 		JujuManagementSpace,
 		CAASOperatorImagePath,
 		CAASImageRepo,
 		Features,
+		LocalCharmRepository,
+		ExternalProviders,
 	)
 
 	// DefaultAuditLogExcludeMethods is the default list of methods to
@@ -494,6 +687,47 @@ func (c Config) CharmStoreURL() string {
 	return url
 }
 
+// LocalCharmRepository returns the path of the directory of charms to
+// fall back to when the charm store cannot be reached, or "" if there
+// is no such directory.
+func (c Config) LocalCharmRepository() string {
+	return c.asString(LocalCharmRepository)
+}
+
+// ExternalProviders returns the addresses of the out-of-tree cloud
+// providers, keyed by provider type.
+func (c Config) ExternalProviders() map[string]string {
+	providers := make(map[string]string)
+	if value, ok := c[ExternalProviders]; ok {
+		for _, item := range value.([]interface{}) {
+			providerType, address, err := parseExternalProvider(item.(string))
+			if err != nil {
+				// Validate rejects bad entries, so this can only
+				// happen for config that was never validated.
+				continue
+			}
+			providers[providerType] = address
+		}
+	}
+	return providers
+}
+
+// parseExternalProvider parses an external-providers entry.
+func parseExternalProvider(item string) (providerType, address string, err error) {
+	parts := strings.SplitN(item, "=", 2)
+	if len(parts) != 2 || parts[1] == "" || !validExternalProviderType.MatchString(parts[0]) {
+		return "", "", errors.NotValidf("external provider %q (expected <type>=unix:<path>)", item)
+	}
+	if !strings.HasPrefix(parts[1], "unix:/") {
+		// Credentials are sent to the provider unencrypted, so
+		// it must be on the same machine.
+		return "", "", errors.NotValidf("external provider %q address %q (expected unix:<absolute path>)", parts[0], parts[1])
+	}
+	return parts[0], parts[1], nil
+}
+
+var validExternalProviderType = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
 // ControllerUUID returns the uuid for the controller.
 func (c Config) ControllerUUID() string {
 	return c.mustString(ControllerUUIDKey)
@@ -529,6 +763,91 @@ func (c Config) AutocertDNSName() string {
 	return c.asString(AutocertDNSNameKey)
 }
 
+// AutocertChallenge returns the ACME challenge type used to obtain
+// official TLS certificates. See AutocertChallengeKey for more details.
+func (c Config) AutocertChallenge() string {
+	if v := c.asString(AutocertChallengeKey); v != "" {
+		return v
+	}
+	return AutocertChallengeTLSALPN01
+}
+
+// APIPolicyURL returns the URL of the external API policy endpoint,
+// if any.
+func (c Config) APIPolicyURL() string {
+	return c.asString(APIPolicyURL)
+}
+
+// APIPolicyFailOpen returns whether API calls are allowed when the
+// external API policy endpoint cannot be consulted.
+func (c Config) APIPolicyFailOpen() bool {
+	if v, ok := c[APIPolicyFailOpen]; ok {
+		return v.(bool)
+	}
+	return false
+}
+
+// OIDCIssuerURL returns the URL of the OpenID Connect identity provider,
+// if any. See OIDCIssuerURL for more details.
+func (c Config) OIDCIssuerURL() string {
+	return c.asString(OIDCIssuerURL)
+}
+
+// OIDCClientID returns the client ID that OpenID Connect ID tokens must
+// have been issued for.
+func (c Config) OIDCClientID() string {
+	return c.asString(OIDCClientID)
+}
+
+// OIDCGroupsClaim returns the name of the ID token claim that lists the
+// groups a user belongs to.
+func (c Config) OIDCGroupsClaim() string {
+	if v := c.asString(OIDCGroupsClaim); v != "" {
+		return v
+	}
+	return DefaultOIDCGroupsClaim
+}
+
+// OIDCGroupAccess returns the controller access granted to members of
+// each identity provider group.
+func (c Config) OIDCGroupAccess() map[string]permission.Access {
+	groupAccess, _ := parseOIDCGroupAccess(c[OIDCGroupAccess])
+	return groupAccess
+}
+
+// parseOIDCGroupAccess parses a list of "<group>=<access>" entries.
+func parseOIDCGroupAccess(value interface{}) (map[string]permission.Access, error) {
+	var entries []string
+	switch value := value.(type) {
+	case nil:
+	case []string:
+		entries = value
+	case []interface{}:
+		for _, item := range value {
+			entry, ok := item.(string)
+			if !ok {
+				return nil, errors.Errorf("expected string, got %T(%v)", item, item)
+			}
+			entries = append(entries, entry)
+		}
+	default:
+		return nil, errors.Errorf("expected list of strings, got %T(%v)", value, value)
+	}
+	groupAccess := make(map[string]permission.Access)
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, errors.Errorf("expected <group>=<access>, got %q", entry)
+		}
+		access := permission.Access(parts[1])
+		if err := permission.ValidateControllerAccess(access); err != nil {
+			return nil, errors.Annotatef(err, "group %q", parts[0])
+		}
+		groupAccess[parts[0]] = access
+	}
+	return groupAccess, nil
+}
+
 // IdentityPublicKey returns the public key of the identity manager.
 func (c Config) IdentityPublicKey() *bakery.PublicKey {
 	key := c.asString(IdentityPublicKey)
@@ -620,6 +939,88 @@ func (c Config) PruneTxnSleepTime() time.Duration {
 	return val
 }
 
+// PruneTxnInterval returns how often completed transactions are
+// pruned, or zero if the agent's default should be used.
+func (c Config) PruneTxnInterval() time.Duration {
+	return c.durationOrZero(PruneTxnInterval)
+}
+
+// PruneLogsInterval returns how often the log collections are
+// pruned, or zero if the agent's default should be used.
+func (c Config) PruneLogsInterval() time.Duration {
+	return c.durationOrZero(PruneLogsInterval)
+}
+
+// RaftSnapshotThreshold returns the number of raft log entries written
+// between snapshots.
+func (c Config) RaftSnapshotThreshold() int {
+	return c.intOrDefault(RaftSnapshotThreshold, DefaultRaftSnapshotThreshold)
+}
+
+// RaftTrailingLogs returns the number of raft log entries kept after
+// a snapshot.
+func (c Config) RaftTrailingLogs() int {
+	return c.intOrDefault(RaftTrailingLogs, DefaultRaftTrailingLogs)
+}
+
+// AgentBinaryBandwidthLimit returns the maximum rate, in KiB per
+// second, at which agent binaries are sent, or zero if there is no
+// limit.
+func (c Config) AgentBinaryBandwidthLimit() int {
+	return c.intOrDefault(AgentBinaryBandwidthLimit, 0)
+}
+
+// ResourceBandwidthLimit returns the maximum rate, in KiB per second,
+// at which resources are sent to units, or zero if there is no limit.
+func (c Config) ResourceBandwidthLimit() int {
+	return c.intOrDefault(ResourceBandwidthLimit, 0)
+}
+
+// BackupBandwidthLimit returns the maximum rate, in KiB per second, at
+// which backups are streamed, or zero if there is no limit.
+func (c Config) BackupBandwidthLimit() int {
+	return c.intOrDefault(BackupBandwidthLimit, 0)
+}
+
+// MaxRelationSettingsSize returns the maximum size, in KiB, of a unit's
+// settings in a relation, or zero if there is no limit.
+func (c Config) MaxRelationSettingsSize() int {
+	return c.intOrDefault(MaxRelationSettingsSize, DefaultMaxRelationSettingsSize)
+}
+
+// AgentLogTarget returns where machine and unit agents write their
+// logs, one of AgentLogTargetFile, AgentLogTargetJournald and
+// AgentLogTargetFileAndJournald.
+func (c Config) AgentLogTarget() string {
+	if v := c.asString(AgentLogTarget); v != "" {
+		return v
+	}
+	return DefaultAgentLogTarget
+}
+
+// AgentLogfileMaxSizeMB returns the size in MB at which agent log
+// files are rotated.
+func (c Config) AgentLogfileMaxSizeMB() int {
+	if v := c.asString(AgentLogfileMaxSize); v != "" {
+		// Value has already been validated.
+		value, _ := utils.ParseSize(v)
+		return int(value)
+	}
+	return DefaultAgentLogfileMaxSizeMB
+}
+
+// AgentLogfileMaxBackups returns the number of rotated agent log files
+// kept.
+func (c Config) AgentLogfileMaxBackups() int {
+	return c.intOrDefault(AgentLogfileMaxBackups, DefaultAgentLogfileMaxBackups)
+}
+
+func (c Config) durationOrZero(name string) time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.asString(name))
+	return val
+}
+
 // JujuHASpace is the network space within which the MongoDB replica-set
 // should communicate.
 func (c Config) JujuHASpace() string {
@@ -676,6 +1077,33 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[OIDCIssuerURL].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid OIDC issuer URL")
+		}
+		if u.Scheme != "https" {
+			return errors.Errorf("%s must be an https URL", OIDCIssuerURL)
+		}
+		if c.OIDCClientID() == "" {
+			return errors.Errorf("%s is required when %s is set", OIDCClientID, OIDCIssuerURL)
+		}
+	}
+
+	if _, err := parseOIDCGroupAccess(c[OIDCGroupAccess]); err != nil {
+		return errors.Annotatef(err, "invalid %s", OIDCGroupAccess)
+	}
+
+	if v, ok := c[APIPolicyURL].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid API policy URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return errors.Errorf("%s must be an http or https URL", APIPolicyURL)
+		}
+	}
+
 	caCert, caCertOK := c.CACert()
 	if !caCertOK {
 		return errors.Errorf("missing CA certificate")
@@ -694,6 +1122,12 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[AutocertChallengeKey].(string); ok && v != "" {
+		if v != AutocertChallengeTLSALPN01 && v != AutocertChallengeHTTP01 {
+			return errors.Errorf("%s: expected one of %q or %q got string(%q)", AutocertChallengeKey, AutocertChallengeTLSALPN01, AutocertChallengeHTTP01, v)
+		}
+	}
+
 	if v, ok := c[MaxLogsAge].(string); ok {
 		if _, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid logs prune interval in configuration")
@@ -718,6 +1152,53 @@ func Validate(c Config) error {
 		}
 	}
 
+	for _, name := range []string{PruneTxnInterval, PruneLogsInterval} {
+		v, ok := c[name].(string)
+		if !ok || v == "" {
+			continue
+		}
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, `%s must be a valid duration (eg "1h")`, name)
+		}
+		if d <= 0 {
+			return errors.Errorf("%s must be positive, got %q", name, v)
+		}
+	}
+
+	for _, name := range []string{RaftSnapshotThreshold, RaftTrailingLogs} {
+		if v, ok := c[name].(int); ok && v <= 0 {
+			return errors.Errorf("%s must be positive, got %d", name, v)
+		}
+	}
+
+	for _, name := range []string{AgentBinaryBandwidthLimit, ResourceBandwidthLimit, BackupBandwidthLimit, MaxRelationSettingsSize} {
+		if v, ok := c[name].(int); ok && v < 0 {
+			return errors.Errorf("%s must not be negative, got %d", name, v)
+		}
+	}
+
+	if v, ok := c[AgentLogTarget].(string); ok {
+		switch v {
+		case AgentLogTargetFile, AgentLogTargetJournald, AgentLogTargetFileAndJournald:
+		default:
+			return errors.Errorf("%s must be one of %q, %q or %q, got %q", AgentLogTarget,
+				AgentLogTargetFile, AgentLogTargetJournald, AgentLogTargetFileAndJournald, v)
+		}
+	}
+
+	if v, ok := c[AgentLogfileMaxSize].(string); ok {
+		if size, err := utils.ParseSize(v); err != nil {
+			return errors.Annotatef(err, "invalid %s in configuration", AgentLogfileMaxSize)
+		} else if size == 0 {
+			return errors.Errorf("%s must be positive, got %q", AgentLogfileMaxSize, v)
+		}
+	}
+
+	if v, ok := c[AgentLogfileMaxBackups].(int); ok && v < 0 {
+		return errors.Errorf("%s must not be negative, got %d", AgentLogfileMaxBackups, v)
+	}
+
 	if err := c.validateSpaceConfig(JujuHASpace, "juju HA"); err != nil {
 		return errors.Trace(err)
 	}
@@ -738,6 +1219,26 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[ExternalProviders].([]interface{}); ok {
+		seen := set.NewStrings()
+		for _, item := range v {
+			providerType, _, err := parseExternalProvider(fmt.Sprint(item))
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if seen.Contains(providerType) {
+				return errors.Errorf("%s has more than one address for %q", ExternalProviders, providerType)
+			}
+			seen.Add(providerType)
+		}
+	}
+
+	if v, ok := c[LocalCharmRepository].(string); ok && v != "" {
+		if !filepath.IsAbs(v) {
+			return errors.Errorf("%s must be an absolute path, got %q", LocalCharmRepository, v)
+		}
+	}
+
 	var auditLogMaxSize int
 	if v, ok := c[AuditLogMaxSize].(string); ok {
 		if size, err := utils.ParseSize(v); err != nil {
@@ -847,65 +1348,105 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	AuditLogCaptureArgs:     schema.Bool(),
-	AuditLogMaxSize:         schema.String(),
-	AuditLogMaxBackups:      schema.ForceInt(),
-	AuditLogExcludeMethods:  schema.List(schema.String()),
-	APIPort:                 schema.ForceInt(),
-	APIPortOpenDelay:        schema.String(),
-	ControllerAPIPort:       schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
-	MaxLogsAge:              schema.String(),
-	MaxLogsSize:             schema.String(),
-	MaxTxnLogSize:           schema.String(),
-	MaxPruneTxnBatchSize:    schema.ForceInt(),
-	MaxPruneTxnPasses:       schema.ForceInt(),
-	PruneTxnQueryCount:      schema.ForceInt(),
-	PruneTxnSleepTime:       schema.String(),
-	JujuHASpace:             schema.String(),
-	JujuManagementSpace:     schema.String(),
-	CAASOperatorImagePath:   schema.String(),
-	CAASImageRepo:           schema.String(),
-	Features:                schema.List(schema.String()),
-	CharmStoreURL:           schema.String(),
-	MeteringURL:             schema.String(),
+	AuditingEnabled:           schema.Bool(),
+	AuditLogCaptureArgs:       schema.Bool(),
+	AuditLogMaxSize:           schema.String(),
+	AuditLogMaxBackups:        schema.ForceInt(),
+	AuditLogExcludeMethods:    schema.List(schema.String()),
+	APIPort:                   schema.ForceInt(),
+	APIPortOpenDelay:          schema.String(),
+	ControllerAPIPort:         schema.ForceInt(),
+	StatePort:                 schema.ForceInt(),
+	IdentityURL:               schema.String(),
+	IdentityPublicKey:         schema.String(),
+	OIDCIssuerURL:             schema.String(),
+	OIDCClientID:              schema.String(),
+	OIDCGroupsClaim:           schema.String(),
+	OIDCGroupAccess:           schema.List(schema.String()),
+	APIPolicyURL:              schema.String(),
+	APIPolicyFailOpen:         schema.Bool(),
+	SetNUMAControlPolicyKey:   schema.Bool(),
+	AutocertURLKey:            schema.String(),
+	AutocertDNSNameKey:        schema.String(),
+	AutocertChallengeKey:      schema.String(),
+	AllowModelAccessKey:       schema.Bool(),
+	MongoMemoryProfile:        schema.String(),
+	MaxLogsAge:                schema.String(),
+	MaxLogsSize:               schema.String(),
+	MaxTxnLogSize:             schema.String(),
+	MaxPruneTxnBatchSize:      schema.ForceInt(),
+	MaxPruneTxnPasses:         schema.ForceInt(),
+	PruneTxnQueryCount:        schema.ForceInt(),
+	PruneTxnSleepTime:         schema.String(),
+	PruneTxnInterval:          schema.String(),
+	PruneLogsInterval:         schema.String(),
+	RaftSnapshotThreshold:     schema.ForceInt(),
+	RaftTrailingLogs:          schema.ForceInt(),
+	AgentBinaryBandwidthLimit: schema.ForceInt(),
+	ResourceBandwidthLimit:    schema.ForceInt(),
+	BackupBandwidthLimit:      schema.ForceInt(),
+	MaxRelationSettingsSize:   schema.ForceInt(),
+	AgentLogTarget:            schema.String(),
+	AgentLogfileMaxSize:       schema.String(),
+	AgentLogfileMaxBackups:    schema.ForceInt(),
+	JujuHASpace:               schema.String(),
+	JujuManagementSpace:       schema.String(),
+	CAASOperatorImagePath:     schema.String(),
+	CAASImageRepo:             schema.String(),
+	Features:                  schema.List(schema.String()),
+	CharmStoreURL:             schema.String(),
+	MeteringURL:               schema.String(),
+	LocalCharmRepository:      schema.String(),
+	ExternalProviders:         schema.List(schema.String()),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	APIPortOpenDelay:        DefaultAPIPortOpenDelay,
-	ControllerAPIPort:       schema.Omit,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	AuditLogCaptureArgs:     DefaultAuditLogCaptureArgs,
-	AuditLogMaxSize:         fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
-	AuditLogMaxBackups:      DefaultAuditLogMaxBackups,
-	AuditLogExcludeMethods:  DefaultAuditLogExcludeMethods,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      DefaultMongoMemoryProfile,
-	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
-	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
-	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
-	MaxPruneTxnBatchSize:    DefaultMaxPruneTxnBatchSize,
-	MaxPruneTxnPasses:       DefaultMaxPruneTxnPasses,
-	PruneTxnQueryCount:      DefaultPruneTxnQueryCount,
-	PruneTxnSleepTime:       DefaultPruneTxnSleepTime,
-	JujuHASpace:             schema.Omit,
-	JujuManagementSpace:     schema.Omit,
-	CAASOperatorImagePath:   schema.Omit,
-	CAASImageRepo:           schema.Omit,
-	Features:                schema.Omit,
-	CharmStoreURL:           csclient.ServerURL,
-	MeteringURL:             romulus.DefaultAPIRoot,
+	APIPort:                   DefaultAPIPort,
+	APIPortOpenDelay:          DefaultAPIPortOpenDelay,
+	ControllerAPIPort:         schema.Omit,
+	AuditingEnabled:           DefaultAuditingEnabled,
+	AuditLogCaptureArgs:       DefaultAuditLogCaptureArgs,
+	AuditLogMaxSize:           fmt.Sprintf("%vM", DefaultAuditLogMaxSizeMB),
+	AuditLogMaxBackups:        DefaultAuditLogMaxBackups,
+	AuditLogExcludeMethods:    DefaultAuditLogExcludeMethods,
+	StatePort:                 DefaultStatePort,
+	IdentityURL:               schema.Omit,
+	IdentityPublicKey:         schema.Omit,
+	OIDCIssuerURL:             schema.Omit,
+	OIDCClientID:              schema.Omit,
+	OIDCGroupsClaim:           schema.Omit,
+	OIDCGroupAccess:           schema.Omit,
+	APIPolicyURL:              schema.Omit,
+	APIPolicyFailOpen:         schema.Omit,
+	SetNUMAControlPolicyKey:   DefaultNUMAControlPolicy,
+	AutocertURLKey:            schema.Omit,
+	AutocertDNSNameKey:        schema.Omit,
+	AutocertChallengeKey:      schema.Omit,
+	AllowModelAccessKey:       schema.Omit,
+	MongoMemoryProfile:        DefaultMongoMemoryProfile,
+	MaxLogsAge:                fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
+	MaxLogsSize:               fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
+	MaxTxnLogSize:             fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	MaxPruneTxnBatchSize:      DefaultMaxPruneTxnBatchSize,
+	MaxPruneTxnPasses:         DefaultMaxPruneTxnPasses,
+	PruneTxnQueryCount:        DefaultPruneTxnQueryCount,
+	PruneTxnSleepTime:         DefaultPruneTxnSleepTime,
+	PruneTxnInterval:          schema.Omit,
+	PruneLogsInterval:         schema.Omit,
+	RaftSnapshotThreshold:     schema.Omit,
+	RaftTrailingLogs:          schema.Omit,
+	AgentBinaryBandwidthLimit: schema.Omit,
+	ResourceBandwidthLimit:    schema.Omit,
+	BackupBandwidthLimit:      schema.Omit,
+	MaxRelationSettingsSize:   schema.Omit,
+	AgentLogTarget:            schema.Omit,
+	AgentLogfileMaxSize:       schema.Omit,
+	AgentLogfileMaxBackups:    schema.Omit,
+	JujuHASpace:               schema.Omit,
+	JujuManagementSpace:       schema.Omit,
+	CAASOperatorImagePath:     schema.Omit,
+	CAASImageRepo:             schema.Omit,
+	Features:                  schema.Omit,
+	CharmStoreURL:             csclient.ServerURL,
+	MeteringURL:               romulus.DefaultAPIRoot,
+	LocalCharmRepository:      schema.Omit,
+	ExternalProviders:         schema.Omit,
 })
@@ -18,6 +18,7 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/testing"
 )
 
@@ -230,6 +231,34 @@ var validateTests = []struct {
 		controller.CAASImageRepo: "foo//bar",
 	},
 	expectError: `docker image path "foo//bar" not valid`,
+}, {
+	about: "relative local charm repository",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.LocalCharmRepository: "charms",
+	},
+	expectError: `local-charm-repository must be an absolute path, got "charms"`,
+}, {
+	about: "external provider without address",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.ExternalProviders: []interface{}{"hyperoo"},
+	},
+	expectError: `external provider "hyperoo" \(expected <type>=unix:<path>\) not valid`,
+}, {
+	about: "external provider on tcp",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.ExternalProviders: []interface{}{"hyperoo=10.0.0.1:17080"},
+	},
+	expectError: `external provider "hyperoo" address "10.0.0.1:17080" \(expected unix:<absolute path>\) not valid`,
+}, {
+	about: "duplicate external provider",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.ExternalProviders: []interface{}{"hyperoo=unix:/var/run/a.sock", "hyperoo=unix:/var/run/b.sock"},
+	},
+	expectError: `external-providers has more than one address for "hyperoo"`,
 }, {
 	about: "negative controller-api-port",
 	config: controller.Config{
@@ -268,6 +297,69 @@ var validateTests = []struct {
 		controller.PruneTxnSleepTime: "15",
 	},
 	expectError: `prune-txn-sleep-time must be a valid duration \(eg "10ms"\): time: missing unit in duration 15`,
+}, {
+	about: "prune-txn-interval not a duration",
+	config: controller.Config{
+		controller.CACertKey:        testing.CACert,
+		controller.PruneTxnInterval: "hourly",
+	},
+	expectError: `prune-txn-interval must be a valid duration \(eg "1h"\): .*`,
+}, {
+	about: "prune-logs-interval not positive",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.PruneLogsInterval: "0s",
+	},
+	expectError: `prune-logs-interval must be positive, got "0s"`,
+}, {
+	about: "raft-snapshot-threshold not positive",
+	config: controller.Config{
+		controller.CACertKey:             testing.CACert,
+		controller.RaftSnapshotThreshold: 0,
+	},
+	expectError: `raft-snapshot-threshold must be positive, got 0`,
+}, {
+	about: "raft-trailing-logs not positive",
+	config: controller.Config{
+		controller.CACertKey:        testing.CACert,
+		controller.RaftTrailingLogs: -1,
+	},
+	expectError: `raft-trailing-logs must be positive, got -1`,
+}, {
+	about: "backup-bandwidth-limit negative",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.BackupBandwidthLimit: -1,
+	},
+	expectError: `backup-bandwidth-limit must not be negative, got -1`,
+}, {
+	about: "max-relation-settings-size negative",
+	config: controller.Config{
+		controller.CACertKey:               testing.CACert,
+		controller.MaxRelationSettingsSize: -1,
+	},
+	expectError: `max-relation-settings-size must not be negative, got -1`,
+}, {
+	about: "agent-log-target not valid",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.AgentLogTarget: "syslog",
+	},
+	expectError: `agent-log-target must be one of "file", "journald" or "file\+journald", got "syslog"`,
+}, {
+	about: "agent-logfile-max-size not valid",
+	config: controller.Config{
+		controller.CACertKey:           testing.CACert,
+		controller.AgentLogfileMaxSize: "0M",
+	},
+	expectError: `agent-logfile-max-size must be positive, got "0M"`,
+}, {
+	about: "agent-logfile-max-backups negative",
+	config: controller.Config{
+		controller.CACertKey:              testing.CACert,
+		controller.AgentLogfileMaxBackups: -1,
+	},
+	expectError: `agent-logfile-max-backups must not be negative, got -1`,
 }, {
 	about: "mongo-memory-profile not valid",
 	config: controller.Config{
@@ -275,6 +367,49 @@ var validateTests = []struct {
 		controller.MongoMemoryProfile: "not-valid",
 	},
 	expectError: `mongo-memory-profile: expected one of "low" or "default" got string\("not-valid"\)`,
+}, {
+	about: "autocert-challenge not valid",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.AutocertChallengeKey: "dns-01",
+	},
+	expectError: `autocert-challenge: expected one of "tls-alpn-01" or "http-01" got string\("dns-01"\)`,
+}, {
+	about: "oidc-issuer-url not https",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.OIDCIssuerURL: "http://idp.example.com",
+		controller.OIDCClientID:  "juju",
+	},
+	expectError: `oidc-issuer-url must be an https URL`,
+}, {
+	about: "oidc-issuer-url without oidc-client-id",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.OIDCIssuerURL: "https://idp.example.com",
+	},
+	expectError: `oidc-client-id is required when oidc-issuer-url is set`,
+}, {
+	about: "oidc-group-access entry not valid",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.OIDCGroupAccess: []interface{}{"admins"},
+	},
+	expectError: `invalid oidc-group-access: expected <group>=<access>, got "admins"`,
+}, {
+	about: "oidc-group-access access not valid",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.OIDCGroupAccess: []interface{}{"admins=admin"},
+	},
+	expectError: `invalid oidc-group-access: group "admins": "admin" controller access not valid`,
+}, {
+	about: "api-policy-url not http",
+	config: controller.Config{
+		controller.CACertKey:    testing.CACert,
+		controller.APIPolicyURL: "unix:///var/run/opa.sock",
+	},
+	expectError: `api-policy-url must be an http or https URL`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -372,6 +507,117 @@ func (s *ConfigSuite) TestPruneTxnQueryCount(c *gc.C) {
 	c.Check(cfg.PruneTxnSleepTime(), gc.Equals, 5*time.Millisecond)
 }
 
+func (s *ConfigSuite) TestPruneIntervals(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.PruneTxnInterval(), gc.Equals, time.Duration(0))
+	c.Check(cfg.PruneLogsInterval(), gc.Equals, time.Duration(0))
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"prune-txn-interval":  "30m",
+			"prune-logs-interval": "1m",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.PruneTxnInterval(), gc.Equals, 30*time.Minute)
+	c.Check(cfg.PruneLogsInterval(), gc.Equals, time.Minute)
+	c.Check(controller.AllowedUpdateConfigAttributes.Contains(controller.PruneTxnInterval), jc.IsTrue)
+	c.Check(controller.AllowedUpdateConfigAttributes.Contains(controller.PruneLogsInterval), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestRaftLogThresholds(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.RaftSnapshotThreshold(), gc.Equals, controller.DefaultRaftSnapshotThreshold)
+	c.Check(cfg.RaftTrailingLogs(), gc.Equals, controller.DefaultRaftTrailingLogs)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"raft-snapshot-threshold": "1024",
+			"raft-trailing-logs":      512,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.RaftSnapshotThreshold(), gc.Equals, 1024)
+	c.Check(cfg.RaftTrailingLogs(), gc.Equals, 512)
+	c.Check(controller.AllowedUpdateConfigAttributes.Contains(controller.RaftSnapshotThreshold), jc.IsTrue)
+	c.Check(controller.AllowedUpdateConfigAttributes.Contains(controller.RaftTrailingLogs), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestBandwidthLimits(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.AgentBinaryBandwidthLimit(), gc.Equals, 0)
+	c.Check(cfg.ResourceBandwidthLimit(), gc.Equals, 0)
+	c.Check(cfg.BackupBandwidthLimit(), gc.Equals, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"agent-binary-bandwidth-limit": "2048",
+			"resource-bandwidth-limit":     4096,
+			"backup-bandwidth-limit":       1024,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.AgentBinaryBandwidthLimit(), gc.Equals, 2048)
+	c.Check(cfg.ResourceBandwidthLimit(), gc.Equals, 4096)
+	c.Check(cfg.BackupBandwidthLimit(), gc.Equals, 1024)
+	for _, name := range []string{
+		controller.AgentBinaryBandwidthLimit,
+		controller.ResourceBandwidthLimit,
+		controller.BackupBandwidthLimit,
+	} {
+		c.Check(controller.AllowedUpdateConfigAttributes.Contains(name), jc.IsTrue)
+	}
+}
+
+func (s *ConfigSuite) TestMaxRelationSettingsSize(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MaxRelationSettingsSize(), gc.Equals, controller.DefaultMaxRelationSettingsSize)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"max-relation-settings-size": "0",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MaxRelationSettingsSize(), gc.Equals, 0)
+	c.Check(controller.AllowedUpdateConfigAttributes.Contains(controller.MaxRelationSettingsSize), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestAgentLogging(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.AgentLogTarget(), gc.Equals, controller.AgentLogTargetFile)
+	c.Check(cfg.AgentLogfileMaxSizeMB(), gc.Equals, controller.DefaultAgentLogfileMaxSizeMB)
+	c.Check(cfg.AgentLogfileMaxBackups(), gc.Equals, controller.DefaultAgentLogfileMaxBackups)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"agent-log-target":          "file+journald",
+			"agent-logfile-max-size":    "1G",
+			"agent-logfile-max-backups": "5",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.AgentLogTarget(), gc.Equals, controller.AgentLogTargetFileAndJournald)
+	c.Check(cfg.AgentLogfileMaxSizeMB(), gc.Equals, 1024)
+	c.Check(cfg.AgentLogfileMaxBackups(), gc.Equals, 5)
+	c.Check(controller.AllowedUpdateConfigAttributes.Contains(controller.AgentLogTarget), jc.IsTrue)
+}
+
 func (s *ConfigSuite) TestNetworkSpaceConfigValues(c *gc.C) {
 	haSpace := "space1"
 	managementSpace := "space2"
@@ -569,3 +815,121 @@ func (s *ConfigSuite) TestMeteringURLSettingValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.MeteringURL(), gc.Equals, mURL)
 }
+
+func (s *ConfigSuite) TestLocalCharmRepository(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.LocalCharmRepository(), gc.Equals, "")
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.LocalCharmRepository: "/srv/charms",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.LocalCharmRepository(), gc.Equals, "/srv/charms")
+}
+
+func (s *ConfigSuite) TestExternalProviders(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.ExternalProviders(), gc.HasLen, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.ExternalProviders: []interface{}{
+				"hyperoo=unix:/var/run/hyperoo.sock",
+				"tiny-cloud=unix:/var/run/tiny-cloud.sock",
+			},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.ExternalProviders(), jc.DeepEquals, map[string]string{
+		"hyperoo":    "unix:/var/run/hyperoo.sock",
+		"tiny-cloud": "unix:/var/run/tiny-cloud.sock",
+	})
+}
+
+func (s *ConfigSuite) TestAutocertChallengeDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.AutocertChallenge(), gc.Equals, controller.AutocertChallengeTLSALPN01)
+}
+
+func (s *ConfigSuite) TestAutocertChallengeSettingValue(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.AutocertChallengeKey: controller.AutocertChallengeHTTP01,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.AutocertChallenge(), gc.Equals, controller.AutocertChallengeHTTP01)
+}
+
+func (s *ConfigSuite) TestOIDCConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.OIDCIssuerURL(), gc.Equals, "")
+	c.Check(cfg.OIDCClientID(), gc.Equals, "")
+	c.Check(cfg.OIDCGroupsClaim(), gc.Equals, "groups")
+	c.Check(cfg.OIDCGroupAccess(), gc.HasLen, 0)
+}
+
+func (s *ConfigSuite) TestOIDCConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.OIDCIssuerURL:   "https://idp.example.com",
+			controller.OIDCClientID:    "juju",
+			controller.OIDCGroupsClaim: "roles",
+			controller.OIDCGroupAccess: []interface{}{"juju-admins=superuser", "developers=login"},
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.OIDCIssuerURL(), gc.Equals, "https://idp.example.com")
+	c.Check(cfg.OIDCClientID(), gc.Equals, "juju")
+	c.Check(cfg.OIDCGroupsClaim(), gc.Equals, "roles")
+	c.Check(cfg.OIDCGroupAccess(), jc.DeepEquals, map[string]permission.Access{
+		"juju-admins": permission.SuperuserAccess,
+		"developers":  permission.LoginAccess,
+	})
+}
+
+func (s *ConfigSuite) TestAPIPolicyURL(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.APIPolicyURL: "http://localhost:8181/v1/data/juju/allow",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.APIPolicyURL(), gc.Equals, "http://localhost:8181/v1/data/juju/allow")
+	c.Assert(cfg.APIPolicyFailOpen(), jc.IsFalse)
+
+	cfg[controller.APIPolicyFailOpen] = true
+	c.Assert(cfg.APIPolicyFailOpen(), jc.IsTrue)
+}
@@ -11,6 +11,14 @@ import (
 // JujuRunActionName defines the action name used by juju-run.
 const JujuRunActionName = "juju-run"
 
+// JujuIntrospectActionName defines the action name used to fetch a
+// report from the introspection worker of an agent.
+const JujuIntrospectActionName = "juju-introspect"
+
+// IntrospectQueries holds the reports that a juju-introspect action
+// can fetch.
+var IntrospectQueries = []string{"engine-report", "goroutines", "metrics"}
+
 // PredefinedActionsSpec defines a spec for each predefined action.
 var PredefinedActionsSpec = map[string]charm.ActionSpec{
 	JujuRunActionName: {
@@ -32,4 +40,24 @@ var PredefinedActionsSpec = map[string]charm.ActionSpec{
 			},
 		},
 	},
+	JujuIntrospectActionName: {
+		Description: "predefined juju-introspect action",
+		Params: map[string]interface{}{
+			"type":        "object",
+			"title":       JujuIntrospectActionName,
+			"description": "predefined juju-introspect action params",
+			"required":    []interface{}{"agent", "query"},
+			"properties": map[string]interface{}{
+				"agent": map[string]interface{}{
+					"type":        "string",
+					"description": "tag of the agent to introspect",
+				},
+				"query": map[string]interface{}{
+					"type":        "string",
+					"description": "report to fetch from the agent",
+					"enum":        []interface{}{"engine-report", "goroutines", "metrics"},
+				},
+			},
+		},
+	},
 }
@@ -0,0 +1,6 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package autoscale defines the policies used to scale the number of
+// units of an application, and how the policies decide when to scale.
+package autoscale
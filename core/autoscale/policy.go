@@ -0,0 +1,164 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscale
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// Policy describes how the number of units of an application is scaled
+// according to the value of a metric expression.
+type Policy struct {
+	// MinUnits and MaxUnits bound the number of units.
+	MinUnits int
+	MaxUnits int
+
+	// Metric is the metric expression evaluated over the values most
+	// recently recorded by the application's units.
+	Metric string
+
+	// A unit is added when the metric expression is above ScaleUpAbove,
+	// and removed when it is below ScaleDownBelow.
+	ScaleUpAbove   float64
+	ScaleDownBelow float64
+
+	// Cooldown is the minimum time between scaling the application
+	// because of the metric expression.
+	Cooldown time.Duration
+}
+
+// Validate returns an error if the policy is not valid.
+func (p Policy) Validate() error {
+	if p.MinUnits < 0 {
+		return errors.NotValidf("negative minimum units %d", p.MinUnits)
+	}
+	if p.MaxUnits < 1 || p.MaxUnits < p.MinUnits {
+		return errors.NotValidf("maximum units %d with minimum units %d", p.MaxUnits, p.MinUnits)
+	}
+	if _, err := ParseExpression(p.Metric); err != nil {
+		return errors.Trace(err)
+	}
+	if p.ScaleDownBelow >= p.ScaleUpAbove {
+		return errors.NotValidf("scale down threshold %v not below scale up threshold %v",
+			p.ScaleDownBelow, p.ScaleUpAbove)
+	}
+	if p.Cooldown < 0 {
+		return errors.NotValidf("negative cooldown %v", p.Cooldown)
+	}
+	return nil
+}
+
+// Target returns the number of units an application with the given
+// number of units should have, according to the policy and the values
+// recorded by its units, along with the reason for the change. If no
+// change is needed, Target returns units and an empty reason.
+func (p Policy) Target(units int, values []float64) (int, string) {
+	if units < p.MinUnits {
+		return p.MinUnits, fmt.Sprintf("below minimum of %d units", p.MinUnits)
+	}
+	if units > p.MaxUnits {
+		return p.MaxUnits, fmt.Sprintf("above maximum of %d units", p.MaxUnits)
+	}
+	expr, err := ParseExpression(p.Metric)
+	if err != nil {
+		return units, ""
+	}
+	value, ok := expr.Evaluate(values)
+	if !ok {
+		return units, ""
+	}
+	switch {
+	case value > p.ScaleUpAbove && units < p.MaxUnits:
+		return units + 1, fmt.Sprintf("%s %s above %s", expr, formatFloat(value), formatFloat(p.ScaleUpAbove))
+	case value < p.ScaleDownBelow && units > p.MinUnits:
+		return units - 1, fmt.Sprintf("%s %s below %s", expr, formatFloat(value), formatFloat(p.ScaleDownBelow))
+	}
+	return units, ""
+}
+
+// Event records a change to the number of units of an application made
+// by the autoscaler.
+type Event struct {
+	Time   time.Time
+	From   int
+	To     int
+	Reason string
+}
+
+// Functions holds the names of the functions that can be used in a
+// metric expression.
+var Functions = []string{"avg", "max", "min", "sum"}
+
+// Expression is a metric expression, applying a function to the values
+// of a charm metric recorded by an application's units.
+type Expression struct {
+	Function string
+	Metric   string
+}
+
+var (
+	expressionRE = regexp.MustCompile(`^([a-z]+)\(([^()\s]+)\)$`)
+	metricRE     = regexp.MustCompile(`^[a-z][a-z0-9._-]*$`)
+)
+
+// ParseExpression parses a metric expression. An expression is either
+// the name of a metric, whose values are averaged, or one of Functions
+// applied to the name of a metric, such as "max(cpu)".
+func ParseExpression(s string) (Expression, error) {
+	if metricRE.MatchString(s) {
+		return Expression{Function: "avg", Metric: s}, nil
+	}
+	parts := expressionRE.FindStringSubmatch(s)
+	if parts == nil || !metricRE.MatchString(parts[2]) {
+		return Expression{}, errors.NotValidf("metric expression %q", s)
+	}
+	for _, f := range Functions {
+		if parts[1] == f {
+			return Expression{Function: f, Metric: parts[2]}, nil
+		}
+	}
+	return Expression{}, errors.NotValidf("function %q in metric expression %q", parts[1], s)
+}
+
+// String returns the expression in the form accepted by
+// ParseExpression.
+func (e Expression) String() string {
+	return fmt.Sprintf("%s(%s)", e.Function, e.Metric)
+}
+
+// Evaluate applies the expression's function to the given values. It
+// returns false if there are no values.
+func (e Expression) Evaluate(values []float64) (float64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+	result := values[0]
+	for _, v := range values[1:] {
+		switch e.Function {
+		case "max":
+			if v > result {
+				result = v
+			}
+		case "min":
+			if v < result {
+				result = v
+			}
+		default:
+			result += v
+		}
+	}
+	if e.Function == "avg" {
+		result /= float64(len(values))
+	}
+	return result, true
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,160 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscale_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/autoscale"
+)
+
+type policySuite struct{}
+
+var _ = gc.Suite(&policySuite{})
+
+func validPolicy() autoscale.Policy {
+	return autoscale.Policy{
+		MinUnits:       1,
+		MaxUnits:       4,
+		Metric:         "cpu",
+		ScaleUpAbove:   80,
+		ScaleDownBelow: 20,
+		Cooldown:       10 * time.Minute,
+	}
+}
+
+func (*policySuite) TestValidate(c *gc.C) {
+	c.Assert(validPolicy().Validate(), jc.ErrorIsNil)
+
+	for i, test := range []struct {
+		modify func(*autoscale.Policy)
+		err    string
+	}{{
+		modify: func(p *autoscale.Policy) { p.MinUnits = -1 },
+		err:    "negative minimum units -1 not valid",
+	}, {
+		modify: func(p *autoscale.Policy) { p.MaxUnits = 0; p.MinUnits = 0 },
+		err:    "maximum units 0 with minimum units 0 not valid",
+	}, {
+		modify: func(p *autoscale.Policy) { p.MinUnits = 5 },
+		err:    "maximum units 4 with minimum units 5 not valid",
+	}, {
+		modify: func(p *autoscale.Policy) { p.Metric = "" },
+		err:    `metric expression "" not valid`,
+	}, {
+		modify: func(p *autoscale.Policy) { p.ScaleDownBelow = 80 },
+		err:    "scale down threshold 80 not below scale up threshold 80 not valid",
+	}, {
+		modify: func(p *autoscale.Policy) { p.Cooldown = -time.Second },
+		err:    "negative cooldown -1s not valid",
+	}} {
+		c.Logf("test %d", i)
+		p := validPolicy()
+		test.modify(&p)
+		c.Check(p.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (*policySuite) TestTarget(c *gc.C) {
+	p := validPolicy()
+	for i, test := range []struct {
+		units  int
+		values []float64
+		target int
+		reason string
+	}{{
+		units:  0,
+		target: 1,
+		reason: "below minimum of 1 units",
+	}, {
+		units:  6,
+		values: []float64{90},
+		target: 4,
+		reason: "above maximum of 4 units",
+	}, {
+		units:  2,
+		values: []float64{90, 80},
+		target: 3,
+		reason: "avg(cpu) 85 above 80",
+	}, {
+		units:  4,
+		values: []float64{90, 80},
+		target: 4,
+	}, {
+		units:  2,
+		values: []float64{10, 20},
+		target: 1,
+		reason: "avg(cpu) 15 below 20",
+	}, {
+		units:  1,
+		values: []float64{10},
+		target: 1,
+	}, {
+		units:  2,
+		values: []float64{50},
+		target: 2,
+	}, {
+		units:  2,
+		target: 2,
+	}} {
+		c.Logf("test %d", i)
+		target, reason := p.Target(test.units, test.values)
+		c.Check(target, gc.Equals, test.target)
+		c.Check(reason, gc.Equals, test.reason)
+	}
+}
+
+func (*policySuite) TestParseExpression(c *gc.C) {
+	for i, test := range []struct {
+		expr   string
+		result autoscale.Expression
+		err    string
+	}{{
+		expr:   "cpu",
+		result: autoscale.Expression{Function: "avg", Metric: "cpu"},
+	}, {
+		expr:   "max(requests-per-second)",
+		result: autoscale.Expression{Function: "max", Metric: "requests-per-second"},
+	}, {
+		expr: "median(cpu)",
+		err:  `function "median" in metric expression "median\(cpu\)" not valid`,
+	}, {
+		expr: "avg(cpu",
+		err:  `metric expression "avg\(cpu" not valid`,
+	}, {
+		expr: "avg(Cpu)",
+		err:  `metric expression "avg\(Cpu\)" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.expr)
+		result, err := autoscale.ParseExpression(test.expr)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(result, jc.DeepEquals, test.result)
+	}
+}
+
+func (*policySuite) TestEvaluate(c *gc.C) {
+	values := []float64{4, 1, 7}
+	for _, test := range []struct {
+		function string
+		result   float64
+	}{
+		{"avg", 4},
+		{"max", 7},
+		{"min", 1},
+		{"sum", 12},
+	} {
+		result, ok := autoscale.Expression{Function: test.function, Metric: "cpu"}.Evaluate(values)
+		c.Check(ok, jc.IsTrue)
+		c.Check(result, gc.Equals, test.result, gc.Commentf("%s", test.function))
+	}
+	_, ok := autoscale.Expression{Function: "avg", Metric: "cpu"}.Evaluate(nil)
+	c.Check(ok, jc.IsFalse)
+}
@@ -0,0 +1,108 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package binaryarch
+
+import (
+	"archive/zip"
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
+)
+
+// headerSize is the number of bytes at the start of an ELF binary that
+// identify its architecture.
+const headerSize = 20
+
+type machine struct {
+	data elf.Data
+	elf.Machine
+}
+
+// architectures maps the byte order and machine type of ELF binaries
+// to the architectures they run on.
+var architectures = map[machine]string{
+	{elf.ELFDATA2LSB, elf.EM_X86_64}:  arch.AMD64,
+	{elf.ELFDATA2LSB, elf.EM_386}:     arch.I386,
+	{elf.ELFDATA2LSB, elf.EM_AARCH64}: arch.ARM64,
+	{elf.ELFDATA2LSB, elf.EM_ARM}:     arch.ARM,
+	{elf.ELFDATA2LSB, elf.EM_PPC64}:   arch.PPC64EL,
+	{elf.ELFDATA2MSB, elf.EM_S390}:    arch.S390X,
+}
+
+// Architecture returns the architecture of the binary that starts with
+// the given bytes, or "" if they don't start an ELF binary built for
+// an architecture Juju supports.
+func Architecture(header []byte) string {
+	if len(header) < headerSize || !bytes.HasPrefix(header, []byte(elf.ELFMAG)) {
+		return ""
+	}
+	data := elf.Data(header[elf.EI_DATA])
+	var order binary.ByteOrder
+	switch data {
+	case elf.ELFDATA2LSB:
+		order = binary.LittleEndian
+	case elf.ELFDATA2MSB:
+		order = binary.BigEndian
+	default:
+		return ""
+	}
+	m := elf.Machine(order.Uint16(header[18:20]))
+	return architectures[machine{data, m}]
+}
+
+// Read returns the architecture of the binary read from r, or "" if it
+// isn't an ELF binary built for an architecture Juju supports. Only
+// the start of the binary is read.
+func Read(r io.Reader) (string, error) {
+	header := make([]byte, headerSize)
+	n, err := io.ReadFull(r, header)
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return Architecture(header[:n]), nil
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return Architecture(header), nil
+}
+
+// ArchiveArchitectures returns the architectures of the binaries in the
+// zip archive read from r, such as a charm archive, in sorted order.
+// It returns no architectures if the archive contains no binaries.
+func ArchiveArchitectures(r io.ReaderAt, size int64) ([]string, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading archive")
+	}
+	found := set.NewStrings()
+	for _, f := range archive.File {
+		if !f.Mode().IsRegular() || f.UncompressedSize64 < headerSize {
+			continue
+		}
+		fileArch, err := readArchiveFile(f)
+		if err != nil {
+			return nil, errors.Annotatef(err, "reading %q", f.Name)
+		}
+		if fileArch != "" {
+			found.Add(fileArch)
+		}
+	}
+	arches := found.Values()
+	sort.Strings(arches)
+	return arches, nil
+}
+
+func readArchiveFile(f *zip.File) (string, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer rc.Close()
+	return Read(rc)
+}
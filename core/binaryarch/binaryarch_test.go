@@ -0,0 +1,126 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package binaryarch_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/binaryarch"
+)
+
+type binaryArchSuite struct{}
+
+var _ = gc.Suite(&binaryArchSuite{})
+
+// elfHeader returns the start of an ELF binary with the given byte
+// order and machine type, followed by some padding.
+func elfHeader(data elf.Data, m elf.Machine) []byte {
+	header := make([]byte, 64)
+	copy(header, elf.ELFMAG)
+	header[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	header[elf.EI_DATA] = byte(data)
+	var order binary.ByteOrder = binary.LittleEndian
+	if data == elf.ELFDATA2MSB {
+		order = binary.BigEndian
+	}
+	order.PutUint16(header[18:20], uint16(m))
+	return header
+}
+
+func (*binaryArchSuite) TestArchitecture(c *gc.C) {
+	for i, test := range []struct {
+		header []byte
+		arch   string
+	}{{
+		header: elfHeader(elf.ELFDATA2LSB, elf.EM_X86_64),
+		arch:   arch.AMD64,
+	}, {
+		header: elfHeader(elf.ELFDATA2LSB, elf.EM_AARCH64),
+		arch:   arch.ARM64,
+	}, {
+		header: elfHeader(elf.ELFDATA2LSB, elf.EM_386),
+		arch:   arch.I386,
+	}, {
+		header: elfHeader(elf.ELFDATA2LSB, elf.EM_ARM),
+		arch:   arch.ARM,
+	}, {
+		header: elfHeader(elf.ELFDATA2LSB, elf.EM_PPC64),
+		arch:   arch.PPC64EL,
+	}, {
+		header: elfHeader(elf.ELFDATA2MSB, elf.EM_S390),
+		arch:   arch.S390X,
+	}, {
+		// Big-endian ppc64 isn't supported.
+		header: elfHeader(elf.ELFDATA2MSB, elf.EM_PPC64),
+	}, {
+		header: elfHeader(elf.ELFDATA2LSB, elf.EM_MIPS),
+	}, {
+		header: elfHeader(elf.ELFDATA2LSB, elf.EM_X86_64)[:10],
+	}, {
+		header: []byte("#!/bin/sh\necho hello world\n"),
+	}} {
+		c.Logf("test %d", i)
+		c.Check(binaryarch.Architecture(test.header), gc.Equals, test.arch)
+	}
+}
+
+func (*binaryArchSuite) TestRead(c *gc.C) {
+	a, err := binaryarch.Read(bytes.NewReader(elfHeader(elf.ELFDATA2LSB, elf.EM_AARCH64)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a, gc.Equals, arch.ARM64)
+
+	a, err = binaryarch.Read(bytes.NewReader([]byte("short")))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(a, gc.Equals, "")
+}
+
+func (*binaryArchSuite) TestArchiveArchitectures(c *gc.C) {
+	archive := makeArchive(c, map[string][]byte{
+		"metadata.yaml":      []byte("name: foo\n"),
+		"hooks/install":      []byte("#!/bin/sh\n./bin/foo install\n"),
+		"bin/foo":            elfHeader(elf.ELFDATA2LSB, elf.EM_X86_64),
+		"bin/foo-arm64":      elfHeader(elf.ELFDATA2LSB, elf.EM_AARCH64),
+		"lib/libfoo.so":      elfHeader(elf.ELFDATA2LSB, elf.EM_X86_64),
+		"wheelhouse/bar.tgz": []byte("not a binary"),
+	})
+	arches, err := binaryarch.ArchiveArchitectures(bytes.NewReader(archive), int64(len(archive)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(arches, jc.DeepEquals, []string{arch.AMD64, arch.ARM64})
+}
+
+func (*binaryArchSuite) TestArchiveArchitecturesNoBinaries(c *gc.C) {
+	archive := makeArchive(c, map[string][]byte{
+		"metadata.yaml": []byte("name: foo\n"),
+		"hooks/install": []byte("#!/bin/sh\necho hello world\n"),
+	})
+	arches, err := binaryarch.ArchiveArchitectures(bytes.NewReader(archive), int64(len(archive)))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(arches, gc.HasLen, 0)
+}
+
+func (*binaryArchSuite) TestArchiveArchitecturesNotAnArchive(c *gc.C) {
+	data := []byte("not an archive")
+	_, err := binaryarch.ArchiveArchitectures(bytes.NewReader(data), int64(len(data)))
+	c.Assert(err, gc.ErrorMatches, "reading archive: .*")
+}
+
+func makeArchive(c *gc.C, files map[string][]byte) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for name, content := range files {
+		f, err := w.Create(name)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = f.Write(content)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return buf.Bytes()
+}
@@ -0,0 +1,9 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package binaryarch finds the architectures that the binaries in
+// charms and resources are built for, so that they can be checked
+// against the architectures of the machines they are deployed to.
+// Only ELF binaries are recognised; anything else is taken to run on
+// any architecture.
+package binaryarch
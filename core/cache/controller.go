@@ -6,6 +6,7 @@ package cache
 import (
 	"sync"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/pubsub"
@@ -26,6 +27,10 @@ type ControllerConfig struct {
 	// called by the controller main processing loop after processing a change.
 	// The change processed is passed in as the arg to notify.
 	Notify func(interface{})
+
+	// Clock is used to determine the age of memoized model reads.
+	// If it is nil, the wall clock is used.
+	Clock clock.Clock
 }
 
 // Validate ensures the controller has the right values to be created.
@@ -64,6 +69,9 @@ func newController(config ControllerConfig, manager *residentManager) (*Controll
 	if err := config.Validate(); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if config.Clock == nil {
+		config.Clock = clock.WallClock
+	}
 	c := &Controller{
 		manager: manager,
 		config:  config,
@@ -168,6 +176,18 @@ func (c *Controller) Model(uuid string) (*Model, error) {
 	return model, nil
 }
 
+// Invalidate discards any memoized reads of the model with the input
+// UUID. It is called when a transaction has been applied to the model,
+// so that reads are not served from memory until the cache has caught
+// up with the change.
+func (c *Controller) Invalidate(modelUUID string) {
+	c.mu.Lock()
+	if model, found := c.models[modelUUID]; found {
+		model.invalidate()
+	}
+	c.mu.Unlock()
+}
+
 // updateModel will add or update the model details as
 // described in the ModelChange.
 func (c *Controller) updateModel(ch ModelChange) {
@@ -263,7 +283,7 @@ func (c *Controller) removeResident(modelUUID string, removeFrom func(m *Model)
 func (c *Controller) ensureModel(modelUUID string) *Model {
 	model, found := c.models[modelUUID]
 	if !found {
-		model = newModel(c.metrics, c.hub, c.manager.new())
+		model = newModel(c.metrics, c.hub, c.config.Clock, c.manager.new())
 		c.models[modelUUID] = model
 	}
 	return model
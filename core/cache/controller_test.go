@@ -90,6 +90,29 @@ func (s *ControllerSuite) TestRemoveModel(c *gc.C) {
 	s.AssertResident(c, mod.CacheId(), false)
 }
 
+func (s *ControllerSuite) TestInvalidate(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, modelChange, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	reads := 0
+	read := func() (interface{}, error) {
+		reads++
+		return reads, nil
+	}
+	_, err = mod.Memo("status", time.Minute, read)
+	c.Assert(err, jc.ErrorIsNil)
+
+	controller.Invalidate(modelChange.ModelUUID)
+	value, err := mod.Memo("status", time.Minute, read)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, gc.Equals, 2)
+
+	// Invalidating an unknown model is a no-op.
+	controller.Invalidate("unknown-uuid")
+}
+
 func (s *ControllerSuite) TestAddApplication(c *gc.C) {
 	controller, events := s.new(c)
 	s.processChange(c, appChange, events)
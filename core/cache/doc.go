@@ -9,4 +9,9 @@
 // Instances in the cache package also provide watchers. These watchers are
 // checking for changes in the in-memory representation and can be used to avoid
 // excess database reads.
+//
+// Cached models can also memoize the results of expensive reads, such as the
+// full status of the model. The memoized results are discarded whenever the
+// model changes, or the controller is told that a transaction has been applied
+// to the model.
 package cache
@@ -57,6 +57,9 @@ type ControllerGauges struct {
 	LXDProfileChangeError prometheus.Gauge
 	LXDProfileChangeHit   prometheus.Gauge
 	LXDProfileChangeMiss  prometheus.Gauge
+
+	ModelMemoHit  prometheus.Gauge
+	ModelMemoMiss prometheus.Gauge
 }
 
 func createControllerGauges() *ControllerGauges {
@@ -124,6 +127,20 @@ func createControllerGauges() *ControllerGauges {
 				Help:      "The number of times an LXD Profile change was not found.",
 			},
 		),
+		ModelMemoHit: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "model_memo_hit",
+				Help:      "The number of times a model read was served from memory.",
+			},
+		),
+		ModelMemoMiss: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "model_memo_miss",
+				Help:      "The number of times a model read could not be served from memory.",
+			},
+		),
 	}
 }
 
@@ -140,6 +157,9 @@ func (c *ControllerGauges) Collect(ch chan<- prometheus.Metric) {
 	c.LXDProfileChangeError.Collect(ch)
 	c.LXDProfileChangeHit.Collect(ch)
 	c.LXDProfileChangeMiss.Collect(ch)
+
+	c.ModelMemoHit.Collect(ch)
+	c.ModelMemoMiss.Collect(ch)
 }
 
 // Collector is a prometheus.Collector that collects metrics about
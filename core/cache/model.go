@@ -7,7 +7,9 @@ import (
 	"fmt"
 	"regexp"
 	"sync"
+	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/pubsub"
 	"gopkg.in/juju/names.v2"
@@ -26,10 +28,11 @@ const (
 	modelUnitLXDProfileRemove = "model-unit-remove"
 )
 
-func newModel(metrics *ControllerGauges, hub *pubsub.SimpleHub, res *Resident) *Model {
+func newModel(metrics *ControllerGauges, hub *pubsub.SimpleHub, clock clock.Clock, res *Resident) *Model {
 	m := &Model{
 		Resident: res,
 		metrics:  metrics,
+		clock:    clock,
 		// TODO: consider a separate hub per model for better scalability
 		// when many models.
 		hub:          hub,
@@ -37,6 +40,7 @@ func newModel(metrics *ControllerGauges, hub *pubsub.SimpleHub, res *Resident) *
 		charms:       make(map[string]*Charm),
 		machines:     make(map[string]*Machine),
 		units:        make(map[string]*Unit),
+		memos:        make(map[string]memo),
 	}
 	return m
 }
@@ -50,6 +54,7 @@ type Model struct {
 
 	metrics *ControllerGauges
 	hub     *pubsub.SimpleHub
+	clock   clock.Clock
 	mu      sync.Mutex
 
 	details      ModelChange
@@ -59,6 +64,17 @@ type Model struct {
 	charms       map[string]*Charm
 	machines     map[string]*Machine
 	units        map[string]*Unit
+
+	// generation is incremented whenever the model changes, so that
+	// reads made concurrently with a change are not memoized.
+	generation uint64
+	memos      map[string]memo
+}
+
+// memo is the memoized result of a read of the model.
+type memo struct {
+	value   interface{}
+	created time.Time
 }
 
 // Config returns the current model config.
@@ -78,6 +94,54 @@ func (m *Model) Name() string {
 	return m.details.Name
 }
 
+// Memo returns the result of calling read, reusing the result of an
+// earlier call with the same key if the model has not changed since,
+// and the result is no older than maxAge. This allows expensive reads,
+// such as the full status of the model, to be served from memory on
+// busy controllers. Errors are not memoized.
+func (m *Model) Memo(key string, maxAge time.Duration, read func() (interface{}, error)) (interface{}, error) {
+	m.mu.Lock()
+	now := m.clock.Now()
+	if entry, found := m.memos[key]; found && now.Sub(entry.created) < maxAge {
+		m.mu.Unlock()
+		m.metrics.ModelMemoHit.Inc()
+		return entry.value, nil
+	}
+	generation := m.generation
+	m.mu.Unlock()
+	m.metrics.ModelMemoMiss.Inc()
+
+	value, err := read()
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	// The result may not reflect a change made while it was being
+	// read, in which case it must not be served again.
+	if m.generation == generation {
+		m.memos[key] = memo{value: value, created: now}
+	}
+	m.mu.Unlock()
+	return value, nil
+}
+
+// invalidate discards the memoized reads of the model.
+func (m *Model) invalidate() {
+	m.mu.Lock()
+	m.changed()
+	m.mu.Unlock()
+}
+
+// changed records a change to the model, discarding its memoized reads.
+// The model's lock must be held.
+func (m *Model) changed() {
+	m.generation++
+	if len(m.memos) > 0 {
+		m.memos = make(map[string]memo)
+	}
+}
+
 // WatchConfig creates a watcher for the model config.
 func (m *Model) WatchConfig(keys ...string) *ConfigWatcher {
 	return newConfigWatcher(keys, m.hashCache, m.hub, m.topic(modelConfigChange), m.Resident)
@@ -196,6 +260,7 @@ func (m *Model) Unit(unitName string) (*Unit, error) {
 // updateApplication adds or updates the application in the model.
 func (m *Model) updateApplication(ch ApplicationChange, rm *residentManager) {
 	m.mu.Lock()
+	m.changed()
 
 	app, found := m.applications[ch.Name]
 	if !found {
@@ -210,6 +275,7 @@ func (m *Model) updateApplication(ch ApplicationChange, rm *residentManager) {
 // removeApplication removes the application from the model.
 func (m *Model) removeApplication(ch RemoveApplication) error {
 	defer m.doLocked()()
+	m.changed()
 
 	app, ok := m.applications[ch.Name]
 	if ok {
@@ -224,6 +290,7 @@ func (m *Model) removeApplication(ch RemoveApplication) error {
 // updateCharm adds or updates the charm in the model.
 func (m *Model) updateCharm(ch CharmChange, rm *residentManager) {
 	m.mu.Lock()
+	m.changed()
 
 	charm, found := m.charms[ch.CharmURL]
 	if !found {
@@ -238,6 +305,7 @@ func (m *Model) updateCharm(ch CharmChange, rm *residentManager) {
 // removeCharm removes the charm from the model.
 func (m *Model) removeCharm(ch RemoveCharm) error {
 	defer m.doLocked()()
+	m.changed()
 
 	charm, ok := m.charms[ch.CharmURL]
 	if ok {
@@ -252,6 +320,7 @@ func (m *Model) removeCharm(ch RemoveCharm) error {
 // updateUnit adds or updates the unit in the model.
 func (m *Model) updateUnit(ch UnitChange, rm *residentManager) {
 	m.mu.Lock()
+	m.changed()
 
 	unit, found := m.units[ch.Name]
 	if !found {
@@ -273,6 +342,7 @@ type unitLXDProfileRemove struct {
 // removeUnit removes the unit from the model.
 func (m *Model) removeUnit(ch RemoveUnit) error {
 	defer m.doLocked()()
+	m.changed()
 
 	unit, ok := m.units[ch.Name]
 	if ok {
@@ -288,6 +358,7 @@ func (m *Model) removeUnit(ch RemoveUnit) error {
 // updateMachine adds or updates the machine in the model.
 func (m *Model) updateMachine(ch MachineChange, rm *residentManager) {
 	m.mu.Lock()
+	m.changed()
 
 	machine, found := m.machines[ch.Id]
 	if !found {
@@ -303,6 +374,7 @@ func (m *Model) updateMachine(ch MachineChange, rm *residentManager) {
 // removeMachine removes the machine from the model.
 func (m *Model) removeMachine(ch RemoveMachine) error {
 	defer m.doLocked()()
+	m.changed()
 
 	machine, ok := m.machines[ch.Id]
 	if ok {
@@ -326,6 +398,7 @@ func modelTopic(modelUUID, suffix string) string {
 
 func (m *Model) setDetails(details ModelChange) {
 	m.mu.Lock()
+	m.changed()
 
 	m.details = details
 	hashCache, configHash := newHashCache(details.Config, m.metrics.ModelHashCacheHit, m.metrics.ModelHashCacheMiss)
@@ -4,6 +4,8 @@
 package cache_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -59,6 +61,65 @@ func (s *ModelSuite) TestModelConfigIncrementsReadCount(c *gc.C) {
 	c.Check(testutil.ToFloat64(s.Gauges.ModelConfigReads), gc.Equals, float64(2))
 }
 
+func (s *ModelSuite) TestMemo(c *gc.C) {
+	m := s.NewModel(modelChange)
+	reads := 0
+	read := func() (interface{}, error) {
+		reads++
+		return reads, nil
+	}
+
+	value, err := m.Memo("status", time.Minute, read)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, gc.Equals, 1)
+	value, err = m.Memo("status", time.Minute, read)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, gc.Equals, 1)
+	c.Check(testutil.ToFloat64(s.Gauges.ModelMemoHit), gc.Equals, float64(1))
+	c.Check(testutil.ToFloat64(s.Gauges.ModelMemoMiss), gc.Equals, float64(1))
+
+	// A change to the model discards the memoized read.
+	m.UpdateMachine(cache.MachineChange{ModelUUID: modelChange.ModelUUID, Id: "0"}, s.Manager)
+	value, err = m.Memo("status", time.Minute, read)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, gc.Equals, 2)
+
+	// As does age.
+	s.Clock.Advance(time.Minute)
+	value, err = m.Memo("status", time.Minute, read)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, gc.Equals, 3)
+}
+
+func (s *ModelSuite) TestMemoErrorNotMemoized(c *gc.C) {
+	m := s.NewModel(modelChange)
+	_, err := m.Memo("status", time.Minute, func() (interface{}, error) {
+		return nil, errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+
+	value, err := m.Memo("status", time.Minute, func() (interface{}, error) {
+		return "ok", nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, gc.Equals, "ok")
+}
+
+func (s *ModelSuite) TestMemoNotKeptIfModelChangesDuringRead(c *gc.C) {
+	m := s.NewModel(modelChange)
+	_, err := m.Memo("status", time.Minute, func() (interface{}, error) {
+		m.SetDetails(modelChange)
+		return "stale", nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	value, err := m.Memo("status", time.Minute, func() (interface{}, error) {
+		return "fresh", nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, gc.Equals, "fresh")
+}
+
 // Some of the tested behaviour in the following methods is specific to the
 // watcher, but using a cached model avoids the need to put scaffolding code in
 // export_test.go to create a watcher in isolation.
@@ -5,7 +5,9 @@ package cache
 
 import (
 	"testing"
+	"time"
 
+	"github.com/juju/clock/testclock"
 	"github.com/juju/loggo"
 	"github.com/juju/pubsub"
 	jujutesting "github.com/juju/testing"
@@ -52,6 +54,7 @@ type EntitySuite struct {
 
 	Gauges *ControllerGauges
 	Hub    *pubsub.SimpleHub
+	Clock  *testclock.Clock
 }
 
 func (s *EntitySuite) SetUpTest(c *gc.C) {
@@ -64,10 +67,11 @@ func (s *EntitySuite) SetUpTest(c *gc.C) {
 	})
 
 	s.Gauges = createControllerGauges()
+	s.Clock = testclock.NewClock(time.Time{})
 }
 
 func (s *EntitySuite) NewModel(details ModelChange) *Model {
-	m := newModel(s.Gauges, s.Hub, s.Manager.new())
+	m := newModel(s.Gauges, s.Hub, s.Clock, s.Manager.new())
 	m.setDetails(details)
 	return m
 }
@@ -25,8 +25,12 @@ const (
 	// cpuCores is an alias for Cores.
 	cpuCores       = "cpu-cores"
 	Cores          = "cores"
+	CpuFamily      = "cpu-family"
 	CpuPower       = "cpu-power"
+	DiskIOPS       = "disk-iops"
+	ImageID        = "image-id"
 	Mem            = "mem"
+	NetBandwidth   = "net-bandwidth"
 	RootDisk       = "root-disk"
 	RootDiskSource = "root-disk-source"
 	Tags           = "tags"
@@ -36,6 +40,15 @@ const (
 	Zones          = "zones"
 )
 
+// ProviderSpecific lists the constraint attributes that only some
+// providers are able to honour. A Validator reports them as
+// unsupported unless the provider registers them as supported.
+var ProviderSpecific = []string{
+	CpuFamily,
+	DiskIOPS,
+	NetBandwidth,
+}
+
 // Value describes a user's requirements of the hardware on which units
 // of an application will run. Constraints are used to choose an existing machine
 // onto which a unit will be deployed, or to provision a new machine if no
@@ -58,10 +71,29 @@ type Value struct {
 	// equivalent to 1 Amazon ECU (or, roughly, a single 2007-era Xeon).
 	CpuPower *uint64 `json:"cpu-power,omitempty" yaml:"cpu-power,omitempty"`
 
+	// CpuFamily, if not nil or empty, indicates that a machine must have
+	// a processor of the named family or generation, such as "skylake".
+	// The names recognised are provider specific.
+	CpuFamily *string `json:"cpu-family,omitempty" yaml:"cpu-family,omitempty"`
+
+	// DiskIOPS, if not nil, indicates that a machine's root disk must
+	// sustain at least that many I/O operations per second.
+	DiskIOPS *uint64 `json:"disk-iops,omitempty" yaml:"disk-iops,omitempty"`
+
+	// ImageID, if not nil or empty, indicates that a machine must be
+	// provisioned from the identified image rather than the provider's
+	// default image for the machine's series. Only valid for clouds which
+	// support custom images.
+	ImageID *string `json:"image-id,omitempty" yaml:"image-id,omitempty"`
+
 	// Mem, if not nil, indicates that a machine must have at least that many
 	// megabytes of RAM.
 	Mem *uint64 `json:"mem,omitempty" yaml:"mem,omitempty"`
 
+	// NetBandwidth, if not nil, indicates that a machine must have at
+	// least that many megabits per second of network bandwidth.
+	NetBandwidth *uint64 `json:"net-bandwidth,omitempty" yaml:"net-bandwidth,omitempty"`
+
 	// RootDisk, if not nil, indicates that a machine must have at least
 	// that many megabytes of disk space available in the root disk. In
 	// providers where the root disk is configurable at instance startup
@@ -140,12 +172,35 @@ func (v *Value) HasCpuCores() bool {
 	return v.CpuCores != nil && *v.CpuCores > 0
 }
 
+// HasCpuFamily returns true if the constraints.Value specifies a CPU
+// family.
+func (v *Value) HasCpuFamily() bool {
+	return v.CpuFamily != nil && *v.CpuFamily != ""
+}
+
+// HasDiskIOPS returns true if the constraints.Value specifies a minimum
+// number of root disk IOPS.
+func (v *Value) HasDiskIOPS() bool {
+	return v.DiskIOPS != nil && *v.DiskIOPS > 0
+}
+
+// HasNetBandwidth returns true if the constraints.Value specifies a
+// minimum network bandwidth.
+func (v *Value) HasNetBandwidth() bool {
+	return v.NetBandwidth != nil && *v.NetBandwidth > 0
+}
+
 // HasRootDiskSource returns true if the constraints.Value specifies a
 // source for its root disk.
 func (v *Value) HasRootDiskSource() bool {
 	return v.RootDiskSource != nil && *v.RootDiskSource != ""
 }
 
+// HasImageID returns true if the constraints.Value specifies an image.
+func (v *Value) HasImageID() bool {
+	return v.ImageID != nil && *v.ImageID != ""
+}
+
 // HasInstanceType returns true if the constraints.Value specifies an instance type.
 func (v *Value) HasInstanceType() bool {
 	return v.InstanceType != nil && *v.InstanceType != ""
@@ -215,9 +270,18 @@ func (v Value) String() string {
 	if v.CpuCores != nil {
 		strs = append(strs, "cores="+uintStr(*v.CpuCores))
 	}
+	if v.CpuFamily != nil {
+		strs = append(strs, "cpu-family="+(*v.CpuFamily))
+	}
 	if v.CpuPower != nil {
 		strs = append(strs, "cpu-power="+uintStr(*v.CpuPower))
 	}
+	if v.DiskIOPS != nil {
+		strs = append(strs, "disk-iops="+uintStr(*v.DiskIOPS))
+	}
+	if v.ImageID != nil {
+		strs = append(strs, "image-id="+(*v.ImageID))
+	}
 	if v.InstanceType != nil {
 		strs = append(strs, "instance-type="+(*v.InstanceType))
 	}
@@ -228,6 +292,13 @@ func (v Value) String() string {
 		}
 		strs = append(strs, "mem="+s)
 	}
+	if v.NetBandwidth != nil {
+		s := uintStr(*v.NetBandwidth)
+		if s != "" {
+			s += "M"
+		}
+		strs = append(strs, "net-bandwidth="+s)
+	}
 	if v.RootDisk != nil {
 		s := uintStr(*v.RootDisk)
 		if s != "" {
@@ -270,12 +341,24 @@ func (v Value) GoString() string {
 	if v.CpuPower != nil {
 		values = append(values, fmt.Sprintf("CpuPower: %v", *v.CpuPower))
 	}
+	if v.CpuFamily != nil {
+		values = append(values, fmt.Sprintf("CpuFamily: %q", *v.CpuFamily))
+	}
 	if v.Mem != nil {
 		values = append(values, fmt.Sprintf("Mem: %v", *v.Mem))
 	}
 	if v.RootDisk != nil {
 		values = append(values, fmt.Sprintf("RootDisk: %v", *v.RootDisk))
 	}
+	if v.DiskIOPS != nil {
+		values = append(values, fmt.Sprintf("DiskIOPS: %v", *v.DiskIOPS))
+	}
+	if v.NetBandwidth != nil {
+		values = append(values, fmt.Sprintf("NetBandwidth: %v", *v.NetBandwidth))
+	}
+	if v.ImageID != nil {
+		values = append(values, fmt.Sprintf("ImageID: %q", *v.ImageID))
+	}
 	if v.InstanceType != nil {
 		values = append(values, fmt.Sprintf("InstanceType: %q", *v.InstanceType))
 	}
@@ -444,8 +527,16 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setCpuCores(str)
 	case CpuPower:
 		err = v.setCpuPower(str)
+	case CpuFamily:
+		err = v.setCpuFamily(str)
+	case DiskIOPS:
+		err = v.setDiskIOPS(str)
+	case ImageID:
+		err = v.setImageID(str)
 	case Mem:
 		err = v.setMem(str)
+	case NetBandwidth:
+		err = v.setNetBandwidth(str)
 	case RootDisk:
 		err = v.setRootDisk(str)
 	case RootDiskSource:
@@ -499,14 +590,22 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 		case Container:
 			ctype := instance.ContainerType(vstr)
 			v.Container = &ctype
+		case ImageID:
+			v.ImageID = &vstr
 		case InstanceType:
 			v.InstanceType = &vstr
 		case Cores:
 			v.CpuCores, err = parseUint64(vstr)
 		case CpuPower:
 			v.CpuPower, err = parseUint64(vstr)
+		case CpuFamily:
+			v.CpuFamily = &vstr
+		case DiskIOPS:
+			v.DiskIOPS, err = parseUint64(vstr)
 		case Mem:
 			v.Mem, err = parseUint64(vstr)
+		case NetBandwidth:
+			v.NetBandwidth, err = parseUint64(vstr)
 		case RootDisk:
 			v.RootDisk, err = parseUint64(vstr)
 		case RootDiskSource:
@@ -586,6 +685,30 @@ func (v *Value) setCpuPower(str string) (err error) {
 	return
 }
 
+func (v *Value) setCpuFamily(str string) error {
+	if v.CpuFamily != nil {
+		return errors.Errorf("already set")
+	}
+	v.CpuFamily = &str
+	return nil
+}
+
+func (v *Value) setDiskIOPS(str string) (err error) {
+	if v.DiskIOPS != nil {
+		return errors.Errorf("already set")
+	}
+	v.DiskIOPS, err = parseUint64(str)
+	return
+}
+
+func (v *Value) setImageID(str string) error {
+	if v.ImageID != nil {
+		return errors.Errorf("already set")
+	}
+	v.ImageID = &str
+	return nil
+}
+
 func (v *Value) setInstanceType(str string) error {
 	if v.InstanceType != nil {
 		return errors.Errorf("already set")
@@ -602,6 +725,14 @@ func (v *Value) setMem(str string) (err error) {
 	return
 }
 
+func (v *Value) setNetBandwidth(str string) (err error) {
+	if v.NetBandwidth != nil {
+		return errors.Errorf("already set")
+	}
+	v.NetBandwidth, err = parseBandwidth(str)
+	return
+}
+
 func (v *Value) setRootDisk(str string) (err error) {
 	if v.RootDisk != nil {
 		return errors.Errorf("already set")
@@ -697,6 +828,26 @@ func parseSize(str string) (*uint64, error) {
 	return &value, nil
 }
 
+// parseBandwidth parses a bandwidth in megabits per second, with an
+// optional M (megabit) or G (gigabit) suffix.
+func parseBandwidth(str string) (*uint64, error) {
+	var value uint64
+	if str != "" {
+		mult := 1.0
+		if m, ok := bandwidthSuffixes[str[len(str)-1:]]; ok {
+			str = str[:len(str)-1]
+			mult = m
+		}
+		val, err := strconv.ParseFloat(str, 64)
+		if err != nil || val < 0 {
+			return nil, errors.Errorf("must be a non-negative float with optional M/G suffix")
+		}
+		val *= mult
+		value = uint64(math.Ceil(val))
+	}
+	return &value, nil
+}
+
 // parseCommaDelimited returns the items in the value s. We expect the
 // items to be comma delimited strings.
 func parseCommaDelimited(s string) *[]string {
@@ -729,3 +880,8 @@ var mbSuffixes = map[string]float64{
 	"T": 1024 * 1024,
 	"P": 1024 * 1024 * 1024,
 }
+
+var bandwidthSuffixes = map[string]float64{
+	"M": 1,
+	"G": 1000,
+}
@@ -166,6 +166,56 @@ var parseConstraintsTests = []struct {
 		err:     `bad "cpu-power" constraint: already set`,
 	},
 
+	// "cpu-family" in detail.
+	{
+		summary: "set cpu-family empty",
+		args:    []string{"cpu-family="},
+	}, {
+		summary: "set cpu-family",
+		args:    []string{"cpu-family=skylake"},
+	}, {
+		summary: "double set cpu-family",
+		args:    []string{"cpu-family=skylake cpu-family=haswell"},
+		err:     `bad "cpu-family" constraint: already set`,
+	},
+
+	// "disk-iops" in detail.
+	{
+		summary: "set disk-iops empty",
+		args:    []string{"disk-iops="},
+	}, {
+		summary: "set disk-iops",
+		args:    []string{"disk-iops=3000"},
+	}, {
+		summary: "set nonsense disk-iops",
+		args:    []string{"disk-iops=fast"},
+		err:     `bad "disk-iops" constraint: must be a non-negative integer`,
+	}, {
+		summary: "double set disk-iops",
+		args:    []string{"disk-iops=3000", "disk-iops=4000"},
+		err:     `bad "disk-iops" constraint: already set`,
+	},
+
+	// "net-bandwidth" in detail.
+	{
+		summary: "set net-bandwidth empty",
+		args:    []string{"net-bandwidth="},
+	}, {
+		summary: "set net-bandwidth",
+		args:    []string{"net-bandwidth=500"},
+	}, {
+		summary: "set net-bandwidth with suffix",
+		args:    []string{"net-bandwidth=2.5G"},
+	}, {
+		summary: "set nonsense net-bandwidth",
+		args:    []string{"net-bandwidth=-1"},
+		err:     `bad "net-bandwidth" constraint: must be a non-negative float with optional M/G suffix`,
+	}, {
+		summary: "double set net-bandwidth",
+		args:    []string{"net-bandwidth=1G net-bandwidth=2G"},
+		err:     `bad "net-bandwidth" constraint: already set`,
+	},
+
 	// "mem" in detail.
 	{
 		summary: "set mem empty",
@@ -301,6 +351,19 @@ var parseConstraintsTests = []struct {
 		args:    []string{"spaces="},
 	},
 
+	// image id
+	{
+		summary: "set image id",
+		args:    []string{"image-id=bionic-custom"},
+	}, {
+		summary: "image id empty",
+		args:    []string{"image-id="},
+	}, {
+		summary: "double set image id",
+		args:    []string{"image-id=foo", "image-id=bar"},
+		err:     `bad "image-id" constraint: already set`,
+	},
+
 	// instance type
 	{
 		summary: "set instance type",
@@ -389,6 +452,20 @@ func (s *ConstraintsSuite) TestParseAliases(c *gc.C) {
 	})
 }
 
+func (s *ConstraintsSuite) TestParseProviderSpecific(c *gc.C) {
+	v, err := constraints.Parse("cpu-family=skylake disk-iops=3000 net-bandwidth=2.5G")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(v, gc.DeepEquals, constraints.Value{
+		CpuFamily:    strp("skylake"),
+		DiskIOPS:     uint64p(3000),
+		NetBandwidth: uint64p(2500),
+	})
+	c.Assert(v.String(), gc.Equals, "cpu-family=skylake disk-iops=3000 net-bandwidth=2500M")
+	c.Assert(v.HasCpuFamily(), jc.IsTrue)
+	c.Assert(v.HasDiskIOPS(), jc.IsTrue)
+	c.Assert(v.HasNetBandwidth(), jc.IsTrue)
+}
+
 func (s *ConstraintsSuite) TestMerge(c *gc.C) {
 	con1 := constraints.MustParse("arch=amd64 mem=4G")
 	con2 := constraints.MustParse("cores=42")
@@ -563,6 +640,8 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces1", constraints.Value{Spaces: nil}},
 	{"Spaces2", constraints.Value{Spaces: &[]string{}}},
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
+	{"ImageID1", constraints.Value{ImageID: strp("")}},
+	{"ImageID2", constraints.Value{ImageID: strp("bionic-custom")}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
 	{"Zones1", constraints.Value{Zones: nil}},
@@ -578,6 +657,7 @@ var constraintsRoundtripTests = []roundTrip{
 		RootDiskSource: strp("cave"),
 		Tags:           &[]string{"foo", "bar"},
 		Spaces:         &[]string{"space1", "^space2"},
+		ImageID:        strp("bionic-custom"),
 		InstanceType:   strp("foo"),
 		Zones:          &[]string{"az1", "az2"},
 	}},
@@ -657,6 +737,13 @@ func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	c.Check(cons.HasInstanceType(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestHasImageID(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64 image-id=")
+	c.Check(cons.HasImageID(), jc.IsFalse)
+	cons = constraints.MustParse("arch=amd64 image-id=bionic-custom")
+	c.Check(cons.HasImageID(), jc.IsTrue)
+}
+
 const initialWithoutCons = "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cores=4 spaces=space1,^space2 tags=foo " +
 	"container=lxd instance-type=bar zones=az1,az2"
 
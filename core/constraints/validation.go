@@ -27,6 +27,12 @@ type Validator interface {
 	// RegisterUnsupported records attributes which are not supported by a constraints Value.
 	RegisterUnsupported(unsupported []string)
 
+	// RegisterSupported records provider specific attributes (see
+	// ProviderSpecific) which are supported by a constraints Value.
+	// Provider specific attributes which are not registered are
+	// reported as unsupported.
+	RegisterSupported(supported []string)
+
 	// RegisterVocabulary records allowed values for the specified constraint attribute.
 	// allowedValues is expected to be a slice/array but is declared as interface{} so
 	// that vocabs of different types can be passed in.
@@ -51,15 +57,17 @@ type Validator interface {
 // NewValidator returns a new constraints Validator instance.
 func NewValidator() Validator {
 	return &validator{
-		conflicts: make(map[string]set.Strings),
-		vocab:     make(map[string][]interface{}),
+		conflicts:        make(map[string]set.Strings),
+		vocab:            make(map[string][]interface{}),
+		providerSpecific: set.NewStrings(ProviderSpecific...),
 	}
 }
 
 type validator struct {
-	unsupported set.Strings
-	conflicts   map[string]set.Strings
-	vocab       map[string][]interface{}
+	unsupported      set.Strings
+	conflicts        map[string]set.Strings
+	vocab            map[string][]interface{}
+	providerSpecific set.Strings
 }
 
 // RegisterConflicts is defined on Validator.
@@ -77,6 +85,13 @@ func (v *validator) RegisterUnsupported(unsupported []string) {
 	v.unsupported = set.NewStrings(unsupported...)
 }
 
+// RegisterSupported is defined on Validator.
+func (v *validator) RegisterSupported(supported []string) {
+	for _, attr := range supported {
+		v.providerSpecific.Remove(resolveAlias(attr))
+	}
+}
+
 // RegisterVocabulary is defined on Validator.
 func (v *validator) RegisterVocabulary(attributeName string, allowedValues interface{}) {
 	v.vocab[resolveAlias(attributeName)] = convertToSlice(allowedValues)
@@ -158,7 +173,7 @@ func (v *validator) checkConflicts(cons Value) error {
 
 // checkUnsupported returns any unsupported attributes.
 func (v *validator) checkUnsupported(cons Value) []string {
-	return cons.hasAny(v.unsupported.Values()...)
+	return cons.hasAny(v.unsupported.Union(v.providerSpecific).SortedValues()...)
 }
 
 // checkValidValues returns an error if the constraints value contains an
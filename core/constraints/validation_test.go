@@ -156,6 +156,20 @@ func (s *validationSuite) TestValidation(c *gc.C) {
 	}
 }
 
+func (s *validationSuite) TestProviderSpecificUnsupportedByDefault(c *gc.C) {
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported([]string{"tags"})
+	cons := constraints.MustParse("mem=4G tags=foo disk-iops=3000 net-bandwidth=10G cpu-family=skylake")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"tags", "cpu-family", "disk-iops", "net-bandwidth"})
+
+	validator.RegisterSupported([]string{"disk-iops", "net-bandwidth"})
+	unsupported, err = validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.SameContents, []string{"tags", "cpu-family"})
+}
+
 var mergeTests = []struct {
 	desc         string
 	consFallback string
@@ -0,0 +1,57 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreport
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/juju/loggo"
+)
+
+// LogBuffer is a loggo.Writer holding the last lines logged, to be
+// included in crash reports.
+type LogBuffer struct {
+	mu    sync.Mutex
+	lines []string
+	next  int
+	full  bool
+}
+
+// NewLogBuffer returns a LogBuffer holding up to the given number of
+// lines.
+func NewLogBuffer(size int) *LogBuffer {
+	return &LogBuffer{lines: make([]string, size)}
+}
+
+// Write is part of the loggo.Writer interface.
+func (b *LogBuffer) Write(entry loggo.Entry) {
+	line := loggo.DefaultFormatter(entry)
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if len(b.lines) == 0 {
+		return
+	}
+	b.lines[b.next] = line
+	b.next++
+	if b.next == len(b.lines) {
+		b.next = 0
+		b.full = true
+	}
+}
+
+// Tail returns the lines held by the buffer, oldest first, one per
+// line.
+func (b *LogBuffer) Tail() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	lines := b.lines[:b.next]
+	if b.full {
+		lines = append(append([]string(nil), b.lines[b.next:]...), lines...)
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
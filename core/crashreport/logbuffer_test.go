@@ -0,0 +1,61 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreport_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crashreport"
+)
+
+type logBufferSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&logBufferSuite{})
+
+func (s *logBufferSuite) write(b *crashreport.LogBuffer, messages ...string) {
+	for _, message := range messages {
+		b.Write(loggo.Entry{
+			Level:     loggo.INFO,
+			Module:    "juju.test",
+			Filename:  "test.go",
+			Line:      1,
+			Timestamp: time.Date(2019, 5, 3, 10, 11, 12, 0, time.UTC),
+			Message:   message,
+		})
+	}
+}
+
+func (s *logBufferSuite) line(message string) string {
+	return fmt.Sprintf("2019-05-03 10:11:12 INFO juju.test test.go:1 %s\n", message)
+}
+
+func (s *logBufferSuite) TestTailEmpty(c *gc.C) {
+	b := crashreport.NewLogBuffer(3)
+	c.Assert(b.Tail(), gc.Equals, "")
+}
+
+func (s *logBufferSuite) TestTail(c *gc.C) {
+	b := crashreport.NewLogBuffer(3)
+	s.write(b, "one", "two")
+	c.Assert(b.Tail(), gc.Equals, s.line("one")+s.line("two"))
+}
+
+func (s *logBufferSuite) TestTailWraps(c *gc.C) {
+	b := crashreport.NewLogBuffer(3)
+	s.write(b, "one", "two", "three", "four", "five")
+	c.Assert(b.Tail(), gc.Equals, s.line("three")+s.line("four")+s.line("five"))
+}
+
+func (s *logBufferSuite) TestZeroSize(c *gc.C) {
+	b := crashreport.NewLogBuffer(0)
+	s.write(b, "one")
+	c.Assert(b.Tail(), gc.Equals, "")
+}
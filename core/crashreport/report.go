@@ -0,0 +1,127 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crashreport captures reports of the crashes of agents: the
+// panic that crashed an agent, the stacks of its goroutines, and the
+// last lines it logged.
+package crashreport
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+)
+
+// MaxSize is the maximum size, in bytes, of the content of a crash
+// report. Larger reports are truncated.
+const MaxSize = 1 << 20
+
+// truncatedMarker marks where the content of a report was truncated.
+const truncatedMarker = "\n... truncated ...\n"
+
+// Report describes a crash of an agent.
+type Report struct {
+	// ID identifies the report.
+	ID string `json:"id"`
+
+	// Agent is the tag of the agent that crashed.
+	Agent string `json:"agent"`
+
+	// Version is the version of the agent.
+	Version string `json:"version"`
+
+	// Time is the time the crash was captured.
+	Time time.Time `json:"time"`
+
+	// Panic describes the panic, or fatal error, that crashed
+	// the agent.
+	Panic string `json:"panic"`
+
+	// Goroutines holds the stacks of the goroutines of the agent,
+	// starting with the goroutine that panicked.
+	Goroutines string `json:"goroutines,omitempty"`
+
+	// LogTail holds the last lines logged by the agent.
+	LogTail string `json:"log-tail,omitempty"`
+}
+
+// New returns a report of the crash of the given agent, captured at
+// the given time, truncated to MaxSize.
+func New(agent, version string, t time.Time, panicMessage, goroutines, logTail string) Report {
+	r := Report{
+		ID:         reportID(agent, t, panicMessage, goroutines),
+		Agent:      agent,
+		Version:    version,
+		Time:       t.UTC(),
+		Panic:      panicMessage,
+		Goroutines: goroutines,
+		LogTail:    logTail,
+	}
+	r.Truncate(MaxSize)
+	return r
+}
+
+// reportID returns the ID of a report. IDs sort by the time of the
+// crash.
+func reportID(agent string, t time.Time, panicMessage, goroutines string) string {
+	digest := crashDigest(agent, panicMessage, goroutines)
+	return fmt.Sprintf("%s-%s", t.UTC().Format("20060102-150405"), digest[:8])
+}
+
+// crashDigest returns a digest identifying a crash, whenever it is
+// captured.
+func crashDigest(agent, panicMessage, goroutines string) string {
+	hash := sha256.Sum256([]byte(agent + "\n" + panicMessage + "\n" + goroutines))
+	return fmt.Sprintf("%x", hash)
+}
+
+// Size returns the size, in bytes, of the content of the report.
+func (r Report) Size() int {
+	return len(r.Panic) + len(r.Goroutines) + len(r.LogTail)
+}
+
+// Truncate truncates the content of the report so its size is no more
+// than max. The goroutine stacks and log tail share what is left after
+// the panic; the start of the goroutine stacks, which holds the stack
+// of the goroutine that panicked, and the end of the log tail, which
+// holds the lines logged just before the crash, are kept.
+func (r *Report) Truncate(max int) {
+	if r.Size() <= max {
+		return
+	}
+	if len(r.Panic) >= max {
+		r.Panic = r.Panic[:max]
+		r.Goroutines = ""
+		r.LogTail = ""
+		return
+	}
+	budget := max - len(r.Panic)
+	logBudget := budget / 2
+	if len(r.Goroutines) < budget-logBudget {
+		logBudget = budget - len(r.Goroutines)
+	}
+	r.Goroutines = truncateEnd(r.Goroutines, budget-logBudget)
+	r.LogTail = truncateStart(r.LogTail, budget-len(r.Goroutines))
+}
+
+// truncateEnd returns the start of s, no longer than max.
+func truncateEnd(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	if max < len(truncatedMarker) {
+		return s[:max]
+	}
+	return s[:max-len(truncatedMarker)] + truncatedMarker
+}
+
+// truncateStart returns the end of s, no longer than max.
+func truncateStart(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	if max < len(truncatedMarker) {
+		return s[len(s)-max:]
+	}
+	return truncatedMarker + s[len(s)-max+len(truncatedMarker):]
+}
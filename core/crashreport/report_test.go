@@ -0,0 +1,93 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreport_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crashreport"
+)
+
+type reportSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&reportSuite{})
+
+var crashTime = time.Date(2019, 5, 3, 10, 11, 12, 0, time.UTC)
+
+func (s *reportSuite) TestNew(c *gc.C) {
+	r := crashreport.New("machine-0", "2.6.1", crashTime.In(time.FixedZone("x", 3600)), "panic: boom", "goroutine 1 [running]:", "log\n")
+	c.Assert(r.ID, gc.Matches, `20190503-101112-[0-9a-f]{8}`)
+	c.Assert(r, jc.DeepEquals, crashreport.Report{
+		ID:         r.ID,
+		Agent:      "machine-0",
+		Version:    "2.6.1",
+		Time:       crashTime,
+		Panic:      "panic: boom",
+		Goroutines: "goroutine 1 [running]:",
+		LogTail:    "log\n",
+	})
+}
+
+func (s *reportSuite) TestNewSameCrashSameID(c *gc.C) {
+	r0 := crashreport.New("machine-0", "2.6.1", crashTime, "panic: boom", "goroutine 1", "")
+	r1 := crashreport.New("machine-0", "2.6.1", crashTime, "panic: boom", "goroutine 1", "more log")
+	r2 := crashreport.New("unit-mysql-0", "2.6.1", crashTime, "panic: boom", "goroutine 1", "")
+	c.Assert(r0.ID, gc.Equals, r1.ID)
+	c.Assert(r0.ID, gc.Not(gc.Equals), r2.ID)
+}
+
+func (s *reportSuite) TestNewTruncates(c *gc.C) {
+	goroutines := strings.Repeat("g", crashreport.MaxSize)
+	logTail := strings.Repeat("l", crashreport.MaxSize)
+	r := crashreport.New("machine-0", "2.6.1", crashTime, "panic: boom", goroutines, logTail)
+	c.Assert(r.Size(), gc.Equals, crashreport.MaxSize)
+	c.Assert(r.Panic, gc.Equals, "panic: boom")
+}
+
+func (s *reportSuite) TestTruncate(c *gc.C) {
+	r := crashreport.Report{
+		Panic:      "panic",
+		Goroutines: "start" + strings.Repeat("g", 100),
+		LogTail:    strings.Repeat("l", 100) + "end",
+	}
+	r.Truncate(105)
+	c.Assert(r.Size(), gc.Equals, 105)
+	c.Assert(r.Panic, gc.Equals, "panic")
+	c.Assert(r.Goroutines, jc.HasPrefix, "start")
+	c.Assert(r.Goroutines, jc.HasSuffix, "\n... truncated ...\n")
+	c.Assert(r.LogTail, jc.HasPrefix, "\n... truncated ...\n")
+	c.Assert(r.LogTail, jc.HasSuffix, "end")
+	c.Assert(len(r.Goroutines), gc.Equals, 50)
+	c.Assert(len(r.LogTail), gc.Equals, 50)
+}
+
+func (s *reportSuite) TestTruncateShortGoroutines(c *gc.C) {
+	r := crashreport.Report{
+		Panic:      "panic",
+		Goroutines: "goroutines",
+		LogTail:    strings.Repeat("l", 100),
+	}
+	r.Truncate(55)
+	c.Assert(r.Goroutines, gc.Equals, "goroutines")
+	c.Assert(len(r.LogTail), gc.Equals, 40)
+}
+
+func (s *reportSuite) TestTruncateNotNeeded(c *gc.C) {
+	r := crashreport.Report{Panic: "panic", Goroutines: "goroutines", LogTail: "log"}
+	r.Truncate(100)
+	c.Assert(r, jc.DeepEquals, crashreport.Report{Panic: "panic", Goroutines: "goroutines", LogTail: "log"})
+}
+
+func (s *reportSuite) TestTruncateLongPanic(c *gc.C) {
+	r := crashreport.Report{Panic: "panic: boom", Goroutines: "goroutines", LogTail: "log"}
+	r.Truncate(5)
+	c.Assert(r, jc.DeepEquals, crashreport.Report{Panic: "panic"})
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreport
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// crashPrefixes start the lines with which the Go runtime reports an
+// unrecovered panic, or a fatal error, before it crashes a process.
+var crashPrefixes = []string{"panic: ", "fatal error: "}
+
+// ParseRuntimeOutput finds the report of a crash in output written to
+// stderr by the Go runtime, and whether a crash was found. The report
+// holds the panic, or fatal error, that crashed the process, the stacks
+// of its goroutines, and the output before the crash as its log tail;
+// it isn't identified. If the output reports more than one crash, the
+// last is returned.
+func ParseRuntimeOutput(output string) (Report, bool) {
+	// A panic repanicked while it was being recovered is reported
+	// as the original panic, followed by the indented repanic, so
+	// only unindented lines start a crash.
+	start := -1
+	for _, prefix := range crashPrefixes {
+		// The index of the line in the output with a newline
+		// prepended is the index of the line in the output.
+		if i := strings.LastIndex("\n"+output, "\n"+prefix); i > start {
+			start = i
+		}
+	}
+	if start < 0 {
+		return Report{}, false
+	}
+	r := Report{LogTail: output[:start]}
+	crash := output[start:]
+	end := strings.Index(crash, "\ngoroutine ")
+	if end < 0 {
+		r.Panic = strings.TrimSpace(crash)
+		return r, true
+	}
+	r.Panic = strings.TrimSpace(crash[:end])
+	r.Goroutines = strings.TrimSpace(crash[end:])
+	return r, true
+}
+
+// ReadTail returns up to the last size bytes of the file at the given
+// path. It returns an empty string if the file doesn't exist.
+func ReadTail(path string, size int64) (string, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if info.Size() > size {
+		if _, err := f.Seek(-size, io.SeekEnd); err != nil {
+			return "", errors.Trace(err)
+		}
+	}
+	data, err := ioutil.ReadAll(f)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(data), nil
+}
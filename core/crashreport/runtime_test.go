@@ -0,0 +1,97 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreport_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crashreport"
+)
+
+type runtimeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&runtimeSuite{})
+
+const runtimePanic = `panic: runtime error: invalid memory address or nil pointer dereference
+[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4a1b2c]
+
+goroutine 42 [running]:
+github.com/juju/juju/worker/uniter.(*Uniter).loop(0x0)
+	/build/src/github.com/juju/juju/worker/uniter/uniter.go:300 +0x2c
+
+goroutine 1 [chan receive]:
+main.main()
+	/build/src/github.com/juju/juju/cmd/jujud/main.go:200 +0x40
+`
+
+func (s *runtimeSuite) TestParsePanic(c *gc.C) {
+	r, ok := crashreport.ParseRuntimeOutput("log line\n" + runtimePanic)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(r.Panic, gc.Equals, `panic: runtime error: invalid memory address or nil pointer dereference
+[signal SIGSEGV: segmentation violation code=0x1 addr=0x0 pc=0x4a1b2c]`)
+	c.Assert(r.Goroutines, jc.HasPrefix, "goroutine 42 [running]:\n")
+	c.Assert(r.Goroutines, jc.HasSuffix, "main.go:200 +0x40")
+	c.Assert(r.LogTail, gc.Equals, "log line\n")
+}
+
+func (s *runtimeSuite) TestParseLastCrash(c *gc.C) {
+	first := "panic: first\n\ngoroutine 1 [running]:\nmain.main()\n" +
+		"some other output\n"
+	output := first + "fatal error: concurrent map writes\n\ngoroutine 7 [running]:\nruntime.throw()\n"
+	r, ok := crashreport.ParseRuntimeOutput(output)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(r, jc.DeepEquals, crashreport.Report{
+		Panic:      "fatal error: concurrent map writes",
+		Goroutines: "goroutine 7 [running]:\nruntime.throw()",
+		LogTail:    first,
+	})
+}
+
+func (s *runtimeSuite) TestParseRepanicked(c *gc.C) {
+	output := "panic: first [recovered]\n\tpanic: second\n\ngoroutine 1 [running]:\nmain.main()\n"
+	r, ok := crashreport.ParseRuntimeOutput(output)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(r.Panic, gc.Equals, "panic: first [recovered]\n\tpanic: second")
+	c.Assert(r.Goroutines, gc.Equals, "goroutine 1 [running]:\nmain.main()")
+}
+
+func (s *runtimeSuite) TestParseNoGoroutines(c *gc.C) {
+	r, ok := crashreport.ParseRuntimeOutput("panic: boom\n")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(r, jc.DeepEquals, crashreport.Report{Panic: "panic: boom"})
+}
+
+func (s *runtimeSuite) TestParseNoCrash(c *gc.C) {
+	for _, output := range []string{"", "some output\n", "a panic: in the middle\n"} {
+		_, ok := crashreport.ParseRuntimeOutput(output)
+		c.Check(ok, jc.IsFalse, gc.Commentf("%q", output))
+	}
+}
+
+func (s *runtimeSuite) TestReadTail(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "machine-0.log")
+	err := ioutil.WriteFile(path, []byte("0123456789"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	tail, err := crashreport.ReadTail(path, 4)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tail, gc.Equals, "6789")
+
+	tail, err = crashreport.ReadTail(path, 100)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tail, gc.Equals, "0123456789")
+}
+
+func (s *runtimeSuite) TestReadTailNoFile(c *gc.C) {
+	tail, err := crashreport.ReadTail(filepath.Join(c.MkDir(), "missing.log"), 4)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tail, gc.Equals, "")
+}
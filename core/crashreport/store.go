@@ -0,0 +1,170 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreport
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+)
+
+const (
+	// maxPending is the maximum number of reports kept in a store
+	// waiting to be uploaded; the oldest are removed first.
+	maxPending = 10
+
+	// maxUploaded is the maximum number of uploaded reports kept
+	// in a store; the oldest are removed first.
+	maxUploaded = 10
+
+	uploadedDir  = "uploaded"
+	reportSuffix = ".json"
+
+	// runtimeCrashFile holds the digest of the last crash collected
+	// from the output of the Go runtime.
+	runtimeCrashFile = "runtime-crash"
+)
+
+// Store holds the crash reports of an agent in a directory until they
+// are uploaded to the controller.
+type Store struct {
+	dir string
+}
+
+// NewStore returns a Store holding reports in the given directory.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Write writes the report to the store, to be uploaded.
+func (s *Store) Write(r Report) error {
+	if err := os.MkdirAll(s.dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	data, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := utils.AtomicWriteFile(s.reportPath(r.ID), data, 0600); err != nil {
+		return errors.Annotatef(err, "writing crash report %q", r.ID)
+	}
+	return errors.Trace(removeOldest(s.dir, maxPending))
+}
+
+// Pending returns the reports waiting to be uploaded, oldest first.
+func (s *Store) Pending() ([]Report, error) {
+	ids, err := reportIDs(s.dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	reports := make([]Report, 0, len(ids))
+	for _, id := range ids {
+		data, err := ioutil.ReadFile(s.reportPath(id))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var r Report
+		if err := json.Unmarshal(data, &r); err != nil {
+			return nil, errors.Annotatef(err, "reading crash report %q", id)
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+// MarkUploaded records that the report with the given ID has been
+// uploaded, so it's no longer pending. The last uploaded reports are
+// kept for reference.
+func (s *Store) MarkUploaded(id string) error {
+	dir := filepath.Join(s.dir, uploadedDir)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return errors.Trace(err)
+	}
+	err := os.Rename(s.reportPath(id), filepath.Join(dir, id+reportSuffix))
+	if os.IsNotExist(err) {
+		return errors.NotFoundf("crash report %q", id)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(removeOldest(dir, maxUploaded))
+}
+
+// CollectRuntimeCrash writes a report of the last crash of the given
+// agent found in output written by the Go runtime, captured at the
+// given time, unless that crash has been collected already. It returns
+// whether a report was written.
+func (s *Store) CollectRuntimeCrash(agent, version string, t time.Time, output string) (bool, error) {
+	crash, ok := ParseRuntimeOutput(output)
+	if !ok {
+		return false, nil
+	}
+	// The output of the runtime is kept until it's rotated away, so
+	// the same crash is found every time the agent restarts.
+	digest := crashDigest(agent, crash.Panic, crash.Goroutines)
+	path := filepath.Join(s.dir, runtimeCrashFile)
+	last, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, errors.Trace(err)
+	}
+	if string(last) == digest {
+		return false, nil
+	}
+	r := New(agent, version, t, crash.Panic, crash.Goroutines, crash.LogTail)
+	if err := s.Write(r); err != nil {
+		return false, errors.Trace(err)
+	}
+	if err := utils.AtomicWriteFile(path, []byte(digest), 0600); err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+func (s *Store) reportPath(id string) string {
+	return filepath.Join(s.dir, id+reportSuffix)
+}
+
+// reportIDs returns the IDs of the reports in dir, oldest first.
+func reportIDs(dir string) ([]string, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []string
+	for _, info := range infos {
+		name := info.Name()
+		if info.Mode().IsRegular() && strings.HasSuffix(name, reportSuffix) {
+			ids = append(ids, strings.TrimSuffix(name, reportSuffix))
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// removeOldest removes the oldest reports in dir, leaving no more
+// than max.
+func removeOldest(dir string, max int) error {
+	ids, err := reportIDs(dir)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for len(ids) > max {
+		err := os.Remove(filepath.Join(dir, ids[0]+reportSuffix))
+		if err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+		ids = ids[1:]
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreport_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crashreport"
+)
+
+type storeSuite struct {
+	testing.IsolationSuite
+	dir   string
+	store *crashreport.Store
+}
+
+var _ = gc.Suite(&storeSuite{})
+
+func (s *storeSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = filepath.Join(c.MkDir(), "crash-reports")
+	s.store = crashreport.NewStore(s.dir)
+}
+
+func (s *storeSuite) report(i int) crashreport.Report {
+	return crashreport.New("machine-0", "2.6.1", crashTime.AddDate(0, 0, i), fmt.Sprintf("panic: %d", i), "", "")
+}
+
+func (s *storeSuite) TestPendingNoDir(c *gc.C) {
+	reports, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, gc.HasLen, 0)
+}
+
+func (s *storeSuite) TestWritePending(c *gc.C) {
+	r0, r1 := s.report(0), s.report(1)
+	c.Assert(s.store.Write(r1), jc.ErrorIsNil)
+	c.Assert(s.store.Write(r0), jc.ErrorIsNil)
+
+	info, err := os.Stat(filepath.Join(s.dir, r0.ID+".json"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+
+	reports, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, []crashreport.Report{r0, r1})
+}
+
+func (s *storeSuite) TestWriteRemovesOldest(c *gc.C) {
+	for i := 0; i < 12; i++ {
+		c.Assert(s.store.Write(s.report(i)), jc.ErrorIsNil)
+	}
+	reports, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, gc.HasLen, 10)
+	c.Assert(reports[0], jc.DeepEquals, s.report(2))
+}
+
+func (s *storeSuite) TestMarkUploaded(c *gc.C) {
+	r0, r1 := s.report(0), s.report(1)
+	c.Assert(s.store.Write(r0), jc.ErrorIsNil)
+	c.Assert(s.store.Write(r1), jc.ErrorIsNil)
+
+	err := s.store.MarkUploaded(r0.ID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	reports, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, jc.DeepEquals, []crashreport.Report{r1})
+	_, err = os.Stat(filepath.Join(s.dir, "uploaded", r0.ID+".json"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *storeSuite) TestMarkUploadedRemovesOldest(c *gc.C) {
+	for i := 0; i < 12; i++ {
+		r := s.report(i)
+		c.Assert(s.store.Write(r), jc.ErrorIsNil)
+		c.Assert(s.store.MarkUploaded(r.ID), jc.ErrorIsNil)
+	}
+	uploaded, err := filepath.Glob(filepath.Join(s.dir, "uploaded", "*.json"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(uploaded, gc.HasLen, 10)
+	c.Assert(filepath.Base(uploaded[0]), gc.Equals, s.report(2).ID+".json")
+}
+
+func (s *storeSuite) TestMarkUploadedNotFound(c *gc.C) {
+	err := s.store.MarkUploaded("20190503-101112-deadbeef")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *storeSuite) TestCollectRuntimeCrash(c *gc.C) {
+	output := "last log line\n" + runtimePanic
+	collected, err := s.store.CollectRuntimeCrash("machine-0", "2.6.1", crashTime, output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(collected, jc.IsTrue)
+
+	reports, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reports, gc.HasLen, 1)
+	c.Assert(reports[0].Agent, gc.Equals, "machine-0")
+	c.Assert(reports[0].Time, gc.Equals, crashTime)
+	c.Assert(reports[0].Panic, jc.HasPrefix, "panic: runtime error")
+	c.Assert(reports[0].Goroutines, jc.HasPrefix, "goroutine 42 [running]:")
+	c.Assert(reports[0].LogTail, gc.Equals, "last log line\n")
+}
+
+func (s *storeSuite) TestCollectRuntimeCrashOnce(c *gc.C) {
+	collected, err := s.store.CollectRuntimeCrash("machine-0", "2.6.1", crashTime, runtimePanic)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(collected, jc.IsTrue)
+	err = s.store.MarkUploaded(s.pendingIDs(c)[0])
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The agent restarted, and logged more, without crashing again.
+	output := runtimePanic + "more logging\n"
+	collected, err = s.store.CollectRuntimeCrash("machine-0", "2.6.1", crashTime.Add(time.Hour), output)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(collected, jc.IsFalse)
+	c.Assert(s.pendingIDs(c), gc.HasLen, 0)
+}
+
+func (s *storeSuite) TestCollectRuntimeCrashNoCrash(c *gc.C) {
+	collected, err := s.store.CollectRuntimeCrash("machine-0", "2.6.1", crashTime, "logging\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(collected, jc.IsFalse)
+	c.Assert(s.pendingIDs(c), gc.HasLen, 0)
+}
+
+func (s *storeSuite) pendingIDs(c *gc.C) []string {
+	reports, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, r := range reports {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
@@ -0,0 +1,86 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package logger holds the conventions shared by the agents and the
+// controller for structured log records.
+package logger
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+)
+
+// Labels are the key=value pairs attached to a log record, such as
+// those given to juju-log by charms. They are carried from the agents
+// to the controller at the end of the log message, so that they reach
+// the agent's log file as well as the logs collection.
+type Labels map[string]string
+
+const labelsPrefix = " [labels: "
+
+var (
+	validLabelKey   = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9_-]*$`)
+	validLabelValue = regexp.MustCompile(`^[^\s\]=]+$`)
+	labelsSuffix    = regexp.MustCompile(`^(?s)(.*) \[labels: ([^\]]+)\]$`)
+)
+
+// ValidateLabel returns an error if the given key or value can't be
+// used for a label.
+func ValidateLabel(key, value string) error {
+	if !validLabelKey.MatchString(key) {
+		return errors.NotValidf("label key %q", key)
+	}
+	if !validLabelValue.MatchString(value) {
+		return errors.NotValidf("value %q of label %q", value, key)
+	}
+	return nil
+}
+
+// String returns the labels as space separated key=value pairs,
+// ordered by key.
+func (l Labels) String() string {
+	keys := make([]string, 0, len(l))
+	for key := range l {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + l[key]
+	}
+	return strings.Join(pairs, " ")
+}
+
+// FormatMessage returns the message with the labels appended, in the
+// form read by ParseMessage.
+func FormatMessage(message string, labels Labels) string {
+	if len(labels) == 0 {
+		return message
+	}
+	return message + labelsPrefix + labels.String() + "]"
+}
+
+// ParseMessage splits the labels appended to a message by
+// FormatMessage from the message. If the message has no valid labels
+// appended, it is returned unchanged with nil labels.
+func ParseMessage(message string) (string, Labels) {
+	if !strings.HasSuffix(message, "]") || !strings.Contains(message, labelsPrefix) {
+		return message, nil
+	}
+	match := labelsSuffix.FindStringSubmatch(message)
+	if match == nil {
+		return message, nil
+	}
+	labels := make(Labels)
+	for _, pair := range strings.Split(match[2], " ") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || ValidateLabel(parts[0], parts[1]) != nil {
+			return message, nil
+		}
+		labels[parts[0]] = parts[1]
+	}
+	return match[1], labels
+}
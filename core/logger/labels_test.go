@@ -0,0 +1,57 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logger_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/logger"
+)
+
+type labelsSuite struct{}
+
+var _ = gc.Suite(&labelsSuite{})
+
+func (*labelsSuite) TestValidateLabel(c *gc.C) {
+	c.Check(logger.ValidateLabel("stage", "dump"), jc.ErrorIsNil)
+	c.Check(logger.ValidateLabel("db_name", "main.1"), jc.ErrorIsNil)
+	c.Check(logger.ValidateLabel("db.name", "main"), gc.ErrorMatches, `label key "db.name" not valid`)
+	c.Check(logger.ValidateLabel("1stage", "dump"), gc.ErrorMatches, `label key "1stage" not valid`)
+	c.Check(logger.ValidateLabel("stage", "two words"), gc.ErrorMatches, `value "two words" of label "stage" not valid`)
+	c.Check(logger.ValidateLabel("stage", ""), gc.ErrorMatches, `value "" of label "stage" not valid`)
+	c.Check(logger.ValidateLabel("stage", "a]"), gc.ErrorMatches, `value "a\]" of label "stage" not valid`)
+}
+
+func (*labelsSuite) TestFormatMessage(c *gc.C) {
+	message := logger.FormatMessage("backup started", logger.Labels{
+		"stage": "dump",
+		"db":    "main",
+	})
+	c.Assert(message, gc.Equals, "backup started [labels: db=main stage=dump]")
+	c.Assert(logger.FormatMessage("backup started", nil), gc.Equals, "backup started")
+}
+
+func (*labelsSuite) TestParseMessage(c *gc.C) {
+	message, labels := logger.ParseMessage("backup\nstarted [labels: db=main stage=dump]")
+	c.Assert(message, gc.Equals, "backup\nstarted")
+	c.Assert(labels, jc.DeepEquals, logger.Labels{
+		"stage": "dump",
+		"db":    "main",
+	})
+}
+
+func (*labelsSuite) TestParseMessageWithoutLabels(c *gc.C) {
+	for _, message := range []string{
+		"backup started",
+		"backup started [done]",
+		"backup started [labels: ]",
+		"backup started [labels: db]",
+		"backup started [labels: db=main stage]",
+	} {
+		parsed, labels := logger.ParseMessage(message)
+		c.Check(parsed, gc.Equals, message)
+		c.Check(labels, gc.IsNil)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package maintenance defines the maintenance windows during which
+// automatic operations, such as charm refreshes and agent upgrades, may
+// take place.
+package maintenance
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+const day = 24 * time.Hour
+
+// Window is a daily period of time, in UTC, within which automatic
+// operations are allowed. The zero Window allows them at any time.
+type Window struct {
+	// Start and End are the offsets from midnight UTC at which the
+	// window opens and closes. A window that ends before it starts
+	// spans midnight.
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseWindow parses a window of the form HH:MM-HH:MM. The empty
+// string and "any" both denote the window that is always open.
+func ParseWindow(s string) (Window, error) {
+	if s == "" || s == "any" {
+		return Window{}, nil
+	}
+	parts := strings.Split(s, "-")
+	if len(parts) != 2 {
+		return Window{}, errors.NotValidf("maintenance window %q (expected HH:MM-HH:MM)", s)
+	}
+	start, err := parseTimeOfDay(parts[0])
+	if err != nil {
+		return Window{}, errors.Annotatef(err, "maintenance window %q", s)
+	}
+	end, err := parseTimeOfDay(parts[1])
+	if err != nil {
+		return Window{}, errors.Annotatef(err, "maintenance window %q", s)
+	}
+	if start == end {
+		return Window{}, errors.NotValidf("empty maintenance window %q", s)
+	}
+	return Window{Start: start, End: end}, nil
+}
+
+func parseTimeOfDay(s string) (time.Duration, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, errors.NotValidf("time of day %q", s)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+// Validate returns an error if the window's times are not within a day.
+func (w Window) Validate() error {
+	for _, d := range []time.Duration{w.Start, w.End} {
+		if d < 0 || d >= day {
+			return errors.NotValidf("maintenance window time %v (must be within a day)", d)
+		}
+	}
+	return nil
+}
+
+// IsZero reports whether the window is always open.
+func (w Window) IsZero() bool {
+	return w.Start == w.End
+}
+
+// String returns the window in the form accepted by ParseWindow.
+func (w Window) String() string {
+	if w.IsZero() {
+		return "any"
+	}
+	return formatTimeOfDay(w.Start) + "-" + formatTimeOfDay(w.End)
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+}
+
+// Contains reports whether the given time falls within the window.
+func (w Window) Contains(t time.Time) bool {
+	return w.Until(t) == 0
+}
+
+// Until returns how long after the given time the window next opens,
+// or zero if the window is open at that time.
+func (w Window) Until(t time.Time) time.Duration {
+	if w.IsZero() {
+		return 0
+	}
+	t = t.UTC()
+	midnight := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := t.Sub(midnight)
+	if w.Start < w.End {
+		if offset >= w.Start && offset < w.End {
+			return 0
+		}
+	} else if offset >= w.Start || offset < w.End {
+		return 0
+	}
+	if offset < w.Start {
+		return w.Start - offset
+	}
+	return day - offset + w.Start
+}
@@ -0,0 +1,99 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package maintenance_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/maintenance"
+)
+
+type WindowSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WindowSuite{})
+
+func at(hour, minute int) time.Time {
+	return time.Date(2019, 6, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func (*WindowSuite) TestParseWindow(c *gc.C) {
+	for i, test := range []struct {
+		in     string
+		expect maintenance.Window
+		err    string
+	}{{
+		in: "",
+	}, {
+		in: "any",
+	}, {
+		in:     "02:00-04:30",
+		expect: maintenance.Window{Start: 2 * time.Hour, End: 4*time.Hour + 30*time.Minute},
+	}, {
+		in:     "22:00-02:00",
+		expect: maintenance.Window{Start: 22 * time.Hour, End: 2 * time.Hour},
+	}, {
+		in:  "02:00",
+		err: `maintenance window "02:00" \(expected HH:MM-HH:MM\) not valid`,
+	}, {
+		in:  "02:00-25:00",
+		err: `maintenance window "02:00-25:00": time of day "25:00" not valid`,
+	}, {
+		in:  "02:00-02:00",
+		err: `empty maintenance window "02:00-02:00" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.in)
+		w, err := maintenance.ParseWindow(test.in)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(w, gc.Equals, test.expect)
+	}
+}
+
+func (*WindowSuite) TestString(c *gc.C) {
+	c.Check(maintenance.Window{}.String(), gc.Equals, "any")
+	c.Check(maintenance.Window{Start: 22 * time.Hour, End: 2*time.Hour + 5*time.Minute}.String(), gc.Equals, "22:00-02:05")
+}
+
+func (*WindowSuite) TestValidate(c *gc.C) {
+	c.Check(maintenance.Window{Start: time.Hour, End: 2 * time.Hour}.Validate(), jc.ErrorIsNil)
+	c.Check(maintenance.Window{Start: time.Hour, End: 24 * time.Hour}.Validate(), gc.ErrorMatches,
+		`maintenance window time 24h0m0s \(must be within a day\) not valid`)
+}
+
+func (*WindowSuite) TestUntil(c *gc.C) {
+	for i, test := range []struct {
+		start, end time.Duration
+		t          time.Time
+		expect     time.Duration
+	}{
+		{0, 0, at(13, 0), 0},
+		{2 * time.Hour, 4 * time.Hour, at(1, 30), 30 * time.Minute},
+		{2 * time.Hour, 4 * time.Hour, at(2, 0), 0},
+		{2 * time.Hour, 4 * time.Hour, at(3, 59), 0},
+		{2 * time.Hour, 4 * time.Hour, at(4, 0), 22 * time.Hour},
+		{22 * time.Hour, 2 * time.Hour, at(23, 0), 0},
+		{22 * time.Hour, 2 * time.Hour, at(1, 0), 0},
+		{22 * time.Hour, 2 * time.Hour, at(12, 0), 10 * time.Hour},
+	} {
+		c.Logf("test %d: %v-%v at %v", i, test.start, test.end, test.t)
+		w := maintenance.Window{Start: test.start, End: test.end}
+		c.Check(w.Until(test.t), gc.Equals, test.expect)
+		c.Check(w.Contains(test.t), gc.Equals, test.expect == 0)
+	}
+}
+
+func (*WindowSuite) TestContainsLocalTime(c *gc.C) {
+	w := maintenance.Window{Start: 2 * time.Hour, End: 4 * time.Hour}
+	t := time.Date(2019, 6, 1, 5, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	c.Assert(w.Contains(t), jc.IsTrue)
+}
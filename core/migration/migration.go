@@ -13,6 +13,13 @@ import (
 	"github.com/juju/juju/resource"
 )
 
+// PinnedAgentVersionAnnotation is the model annotation that carries a
+// model's pinned agent version through a migration, as the model
+// description has no field for it. Users can't set annotations with
+// dots in their keys, so it can't clash with theirs. Controllers whose
+// MigrationTarget facade is older than v4 reject it.
+const PinnedAgentVersionAnnotation = "juju.pinned-agent-version"
+
 // MigrationStatus returns the details for a migration as needed by
 // the migrationmaster worker.
 type MigrationStatus struct {
@@ -32,6 +39,41 @@ type MigrationStatus struct {
 	// TargetInfo contains the details of how to connect to the target
 	// controller.
 	TargetInfo TargetInfo
+
+	// TargetRegion holds the cloud region the model is moving to, if
+	// it is moving to another region of its cloud.
+	TargetRegion string
+
+	// Mode indicates whether the model is moved or copied.
+	Mode Mode
+}
+
+// Mode describes what becomes of a model in the source controller
+// once it has been migrated.
+type Mode string
+
+const (
+	// ModeMove removes the model from the source controller once it
+	// has been migrated. This is the default.
+	ModeMove Mode = "move"
+
+	// ModeCopy leaves the model running in the source controller,
+	// alongside the copy made in the target controller. Machines
+	// can't be shared by both, so a model can only be copied to
+	// another region.
+	ModeCopy Mode = "copy"
+)
+
+// ParseMode converts a string to a migration mode. An empty string
+// is the default, ModeMove.
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case "", ModeMove:
+		return ModeMove, nil
+	case ModeCopy:
+		return ModeCopy, nil
+	}
+	return "", errors.NotValidf("migration mode %q", s)
 }
 
 // SerializedModel wraps a buffer contain a serialised Juju model as
@@ -85,3 +127,55 @@ func (i *ModelInfo) Validate() error {
 	}
 	return nil
 }
+
+// VolumeTranslation describes a volume of a migrating model that the
+// target controller cannot use as it is, because the storage provider
+// that created it is not available there. The volume must be exported
+// by the source controller and recreated in another storage pool.
+type VolumeTranslation struct {
+	// Tag identifies the volume in the model.
+	Tag names.VolumeTag
+
+	// VolumeId is the provider ID of the volume in the source cloud.
+	VolumeId string
+
+	// Pool is the storage pool the volume was created in.
+	Pool string
+
+	// TargetPool is the storage pool the volume will be recreated in.
+	TargetPool string
+
+	// Size is the size of the volume in MiB.
+	Size uint64
+}
+
+// VolumeExport describes a portable copy of a volume, such as an image
+// exported to object storage, made by the source controller so that
+// the volume can be recreated by the target controller.
+type VolumeExport struct {
+	// Tag identifies the exported volume in the model.
+	Tag names.VolumeTag
+
+	// Location is the URL of the copy.
+	Location string
+
+	// Format is the disk format of the copy, e.g. "raw" or "qcow2".
+	Format string
+
+	// Size is the size of the volume in MiB.
+	Size uint64
+}
+
+// MachineSnapshot describes an image of a machine's instance, made in
+// the region that a migrating model is moving to. The machine is
+// provisioned again from it there.
+type MachineSnapshot struct {
+	// Tag identifies the machine in the model.
+	Tag names.MachineTag
+
+	// Region is the region of the cloud that holds the image.
+	Region string
+
+	// ImageId is the provider ID of the image.
+	ImageId string
+}
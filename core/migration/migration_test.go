@@ -0,0 +1,38 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/migration"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ModeSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(new(ModeSuite))
+
+func (s *ModeSuite) TestParseMode(c *gc.C) {
+	for _, t := range []struct {
+		in  string
+		out migration.Mode
+	}{
+		{"", migration.ModeMove},
+		{"move", migration.ModeMove},
+		{"copy", migration.ModeCopy},
+	} {
+		mode, err := migration.ParseMode(t.in)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(mode, gc.Equals, t.out)
+	}
+}
+
+func (s *ModeSuite) TestParseModeInvalid(c *gc.C) {
+	_, err := migration.ParseMode("clone")
+	c.Assert(err, gc.ErrorMatches, `migration mode "clone" not valid`)
+}
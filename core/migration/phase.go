@@ -12,8 +12,10 @@ const (
 	NONE
 	QUIESCE
 	IMPORT
+	STORAGETRANSLATION
 	VALIDATION
 	SUCCESS
+	REPROVISION
 	LOGTRANSFER
 	REAP
 	REAPFAILED
@@ -27,8 +29,10 @@ var phaseNames = []string{
 	"NONE",    // For watchers to indicate there's never been a migration attempt.
 	"QUIESCE",
 	"IMPORT",
+	"STORAGETRANSLATION",
 	"VALIDATION",
 	"SUCCESS",
+	"REPROVISION",
 	"LOGTRANSFER",
 	"REAP",
 	"REAPFAILED",
@@ -81,7 +85,7 @@ func (p Phase) IsRunning() bool {
 		return false
 	}
 	switch p {
-	case QUIESCE, IMPORT, VALIDATION, SUCCESS:
+	case QUIESCE, IMPORT, STORAGETRANSLATION, VALIDATION, SUCCESS:
 		return true
 	default:
 		return false
@@ -93,13 +97,15 @@ func (p Phase) IsRunning() bool {
 // The keys are the "from" states and the values enumerate the
 // possible "to" states.
 var validTransitions = map[Phase][]Phase{
-	QUIESCE:     {IMPORT, ABORT},
-	IMPORT:      {VALIDATION, ABORT},
-	VALIDATION:  {SUCCESS, ABORT},
-	SUCCESS:     {LOGTRANSFER},
-	LOGTRANSFER: {REAP},
-	REAP:        {DONE, REAPFAILED},
-	ABORT:       {ABORTDONE},
+	QUIESCE:            {IMPORT, ABORT},
+	IMPORT:             {STORAGETRANSLATION, VALIDATION, ABORT},
+	STORAGETRANSLATION: {VALIDATION, ABORT},
+	VALIDATION:         {SUCCESS, ABORT},
+	SUCCESS:            {REPROVISION, LOGTRANSFER},
+	REPROVISION:        {LOGTRANSFER},
+	LOGTRANSFER:        {REAP},
+	REAP:               {DONE, REAPFAILED},
+	ABORT:              {ABORTDONE},
 }
 
 var terminalPhases []Phase
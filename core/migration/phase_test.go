@@ -61,8 +61,10 @@ func (s *PhaseSuite) TestIsRunning(c *gc.C) {
 
 	c.Check(migration.QUIESCE.IsRunning(), jc.IsTrue)
 	c.Check(migration.IMPORT.IsRunning(), jc.IsTrue)
+	c.Check(migration.STORAGETRANSLATION.IsRunning(), jc.IsTrue)
 	c.Check(migration.SUCCESS.IsRunning(), jc.IsTrue)
 
+	c.Check(migration.REPROVISION.IsRunning(), jc.IsFalse)
 	c.Check(migration.LOGTRANSFER.IsRunning(), jc.IsFalse)
 	c.Check(migration.REAP.IsRunning(), jc.IsFalse)
 	c.Check(migration.REAPFAILED.IsRunning(), jc.IsFalse)
@@ -78,3 +80,23 @@ func (s *PhaseSuite) TestCanTransitionTo(c *gc.C) {
 	c.Check(migration.QUIESCE.CanTransitionTo(migration.Phase(-1)), jc.IsFalse)
 	c.Check(migration.ABORT.CanTransitionTo(migration.QUIESCE), jc.IsFalse)
 }
+
+func (s *PhaseSuite) TestStorageTranslationTransitions(c *gc.C) {
+	// Storage translation is skipped unless the target controller
+	// needs volumes recreated.
+	c.Check(migration.IMPORT.CanTransitionTo(migration.STORAGETRANSLATION), jc.IsTrue)
+	c.Check(migration.IMPORT.CanTransitionTo(migration.VALIDATION), jc.IsTrue)
+	c.Check(migration.STORAGETRANSLATION.CanTransitionTo(migration.VALIDATION), jc.IsTrue)
+	c.Check(migration.STORAGETRANSLATION.CanTransitionTo(migration.ABORT), jc.IsTrue)
+	c.Check(migration.STORAGETRANSLATION.CanTransitionTo(migration.SUCCESS), jc.IsFalse)
+}
+
+func (s *PhaseSuite) TestReprovisionTransitions(c *gc.C) {
+	// Machines are only re-provisioned when a model moves to another
+	// region, once the target controller is in charge of it.
+	c.Check(migration.SUCCESS.CanTransitionTo(migration.REPROVISION), jc.IsTrue)
+	c.Check(migration.SUCCESS.CanTransitionTo(migration.LOGTRANSFER), jc.IsTrue)
+	c.Check(migration.REPROVISION.CanTransitionTo(migration.LOGTRANSFER), jc.IsTrue)
+	c.Check(migration.REPROVISION.CanTransitionTo(migration.ABORT), jc.IsFalse)
+	c.Check(migration.IMPORT.CanTransitionTo(migration.REPROVISION), jc.IsFalse)
+}
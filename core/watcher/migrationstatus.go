@@ -15,6 +15,7 @@ type MigrationStatus struct {
 	SourceCACert   string
 	TargetAPIAddrs []string
 	TargetCACert   string
+	Copy           bool
 }
 
 // MigrationStatusWatcher describes a watcher that reports the latest
@@ -168,6 +168,32 @@ type InstanceBroker interface {
 	MaintainInstance(ctx context.ProviderCallContext, args StartInstanceParams) error
 }
 
+// InstanceBatchBroker is implemented by brokers that can start several
+// instances with a single provider request, such as a request for a
+// number of identical instances.
+type InstanceBatchBroker interface {
+	InstanceBroker
+
+	// StartInstances asks for an instance to be created for each of the
+	// given parameters. Callers only batch parameters that differ in
+	// their InstanceConfig, so every instance shares the same series,
+	// constraints, availability zone and placement. A result is
+	// returned for each of the parameters, in order; a failure to start
+	// one instance does not prevent the others from starting.
+	StartInstances(ctx context.ProviderCallContext, args []StartInstanceParams) []BatchStartInstanceResult
+}
+
+// BatchStartInstanceResult holds the outcome of starting one of the
+// instances requested in an InstanceBatchBroker.StartInstances call.
+type BatchStartInstanceResult struct {
+	// Result holds details of the started instance, if Error is nil.
+	Result *StartInstanceResult
+
+	// Error holds the reason the instance could not be started, and
+	// may be classified as described for StartInstance.
+	Error error
+}
+
 // LXDProfiler defines an interface for dealing with lxd profiles used to
 // deploy juju machines and containers.
 type LXDProfiler interface {
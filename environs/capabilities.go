@@ -0,0 +1,108 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+)
+
+// Capability names an optional feature that a provider's environs may
+// support.
+type Capability string
+
+const (
+	// CapabilitySpaces means that the provider supports network spaces.
+	CapabilitySpaces Capability = "spaces"
+
+	// CapabilityAvailabilityZones means that the provider distributes
+	// instances across availability zones, which may be chosen with
+	// "zone=" placement directives and zones constraints.
+	CapabilityAvailabilityZones Capability = "availability-zones"
+
+	// CapabilityLoadBalancers means that the provider can provision
+	// load balancers for exposed applications.
+	CapabilityLoadBalancers Capability = "load-balancers"
+
+	// CapabilityDNSZones means that the provider can publish records
+	// in a DNS service hosted by the cloud.
+	CapabilityDNSZones Capability = "dns-zones"
+
+	// CapabilityBlockStorage means that the provider has a native
+	// storage provider for block devices.
+	CapabilityBlockStorage Capability = "block-storage"
+
+	// CapabilityFilesystemStorage means that the provider has a native
+	// storage provider for filesystems.
+	CapabilityFilesystemStorage Capability = "filesystem-storage"
+
+	// CapabilitySpotInstances means that the provider can start
+	// instances on the cloud's spot or preemptible capacity.
+	CapabilitySpotInstances Capability = "spot-instances"
+)
+
+// capabilityDescriptions holds the descriptions of the capabilities
+// used in errors.
+var capabilityDescriptions = map[Capability]string{
+	CapabilitySpaces:            "spaces",
+	CapabilityAvailabilityZones: "availability zones",
+	CapabilityLoadBalancers:     "load balancers",
+	CapabilityDNSZones:          "DNS zones",
+	CapabilityBlockStorage:      "native block storage",
+	CapabilityFilesystemStorage: "native filesystem storage",
+	CapabilitySpotInstances:     "spot instances",
+}
+
+// CapabilityReporter is implemented by providers that report which of
+// the optional features their environs may support. Whether a given
+// environ supports a feature can still depend on the cloud's region
+// and configuration; the reported capabilities are those the provider
+// implements at all.
+type CapabilityReporter interface {
+	// Capabilities returns the features the provider implements.
+	Capabilities() []Capability
+}
+
+// ProviderCapabilities returns the capabilities reported by the named
+// provider type, in name order, and whether the provider reports them.
+func ProviderCapabilities(providerType string) ([]Capability, bool, error) {
+	provider, err := Provider(providerType)
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	reporter, ok := provider.(CapabilityReporter)
+	if !ok {
+		return nil, false, nil
+	}
+	capabilities := append([]Capability(nil), reporter.Capabilities()...)
+	sort.Slice(capabilities, func(i, j int) bool {
+		return capabilities[i] < capabilities[j]
+	})
+	return capabilities, true, nil
+}
+
+// CheckProviderCapability returns an error satisfying
+// errors.IsNotSupported if the named provider type reports that it
+// does not support the capability. Providers that do not report their
+// capabilities are assumed to support it.
+func CheckProviderCapability(providerType string, capability Capability) error {
+	capabilities, ok, err := ProviderCapabilities(providerType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !ok {
+		return nil
+	}
+	for _, c := range capabilities {
+		if c == capability {
+			return nil
+		}
+	}
+	description, ok := capabilityDescriptions[capability]
+	if !ok {
+		description = string(capability)
+	}
+	return errors.NotSupportedf("%s on %q clouds", description, providerType)
+}
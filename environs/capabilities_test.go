@@ -0,0 +1,67 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/testing"
+)
+
+type capabilitiesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&capabilitiesSuite{})
+
+type capableProvider struct {
+	environs.CloudEnvironProvider
+	capabilities []environs.Capability
+}
+
+func (p *capableProvider) Capabilities() []environs.Capability {
+	return p.capabilities
+}
+
+func (s *capabilitiesSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.AddCleanup(environs.RegisterProvider("capable", &capableProvider{
+		capabilities: []environs.Capability{
+			environs.CapabilitySpaces,
+			environs.CapabilityAvailabilityZones,
+		},
+	}))
+	s.AddCleanup(environs.RegisterProvider("unreported", &dummyProvider{}))
+}
+
+func (s *capabilitiesSuite) TestProviderCapabilities(c *gc.C) {
+	capabilities, reported, err := environs.ProviderCapabilities("capable")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reported, jc.IsTrue)
+	c.Assert(capabilities, jc.DeepEquals, []environs.Capability{
+		environs.CapabilityAvailabilityZones,
+		environs.CapabilitySpaces,
+	})
+
+	capabilities, reported, err = environs.ProviderCapabilities("unreported")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(reported, jc.IsFalse)
+	c.Assert(capabilities, gc.HasLen, 0)
+
+	_, _, err = environs.ProviderCapabilities("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *capabilitiesSuite) TestCheckProviderCapability(c *gc.C) {
+	err := environs.CheckProviderCapability("capable", environs.CapabilitySpaces)
+	c.Assert(err, jc.ErrorIsNil)
+	err = environs.CheckProviderCapability("capable", environs.CapabilityLoadBalancers)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `load balancers on "capable" clouds not supported`)
+	err = environs.CheckProviderCapability("unreported", environs.CapabilityLoadBalancers)
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -5,6 +5,7 @@ package environs
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -37,6 +38,9 @@ func GlobalProviderRegistry() ProviderRegistry {
 }
 
 type globalProviderRegistry struct {
+	// mu guards the maps, as external providers are registered
+	// while the controller is running.
+	mu sync.RWMutex
 	// providers maps from provider type to EnvironProvider for
 	// each registered provider type.
 	providers map[string]EnvironProvider
@@ -50,6 +54,8 @@ var globalProviders = &globalProviderRegistry{
 }
 
 func (r *globalProviderRegistry) RegisterProvider(p EnvironProvider, providerType string, providerTypeAliases ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	if r.providers[providerType] != nil || r.aliases[providerType] != "" {
 		return errors.Errorf("duplicate provider name %q", providerType)
 	}
@@ -65,6 +71,8 @@ func (r *globalProviderRegistry) RegisterProvider(p EnvironProvider, providerTyp
 
 // UnregisterProvider removes the named provider from the list of available providers.
 func (r *globalProviderRegistry) UnregisterProvider(providerType string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	delete(r.providers, providerType)
 	for a, p := range r.aliases {
 		if p == providerType {
@@ -74,6 +82,8 @@ func (r *globalProviderRegistry) UnregisterProvider(providerType string) {
 }
 
 func (r *globalProviderRegistry) RegisteredProviders() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	var p []string
 	for k := range r.providers {
 		p = append(p, k)
@@ -82,6 +92,8 @@ func (r *globalProviderRegistry) RegisteredProviders() []string {
 }
 
 func (r *globalProviderRegistry) Provider(providerType string) (EnvironProvider, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
 	if alias, ok := r.aliases[providerType]; ok {
 		providerType = alias
 	}
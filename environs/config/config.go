@@ -4,8 +4,11 @@
 package config
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -23,6 +26,8 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/maintenance"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	jujuversion "github.com/juju/juju/juju/version"
@@ -226,6 +231,16 @@ const (
 	// FanConfig defines the configuration for FAN network running in the model.
 	FanConfig = "fan-config"
 
+	// PreferredAddressFamilyKey is the address family, "ipv4" or "ipv6",
+	// preferred when selecting machine and API server addresses in
+	// dual-stack deployments.
+	PreferredAddressFamilyKey = "preferred-address-family"
+
+	// ReapplyNetworkConfigOnDriftKey is the key for whether machine agents
+	// re-apply the machine's netplan configuration when the observed
+	// network configuration has drifted from the one recorded.
+	ReapplyNetworkConfigOnDriftKey = "reapply-network-config-on-drift"
+
 	// CloudInitUserDataKey is the key to specify cloud-init yaml the user
 	// wants to add into the cloud-config data produced by Juju when
 	// provisioning machines.
@@ -234,6 +249,96 @@ const (
 	// BackupDirKey specifies the backup working directory.
 	BackupDirKey = "backup-dir"
 
+	// ProvisionerRetryDelayKey is the key for the delay before the
+	// provisioner first retries a failed attempt to start an instance.
+	ProvisionerRetryDelayKey = "provisioner-retry-delay"
+
+	// ProvisionerRetryMaxDelayKey is the key for the longest delay the
+	// provisioner will back off to between attempts to start an instance.
+	ProvisionerRetryMaxDelayKey = "provisioner-retry-max-delay"
+
+	// ProvisionerRetryCountKey is the key for the number of times the
+	// provisioner retries a failed attempt to start an instance.
+	ProvisionerRetryCountKey = "provisioner-retry-count"
+
+	// ProtectedKey is the key for whether the model is protected from
+	// accidental destruction. While it is set, the model cannot be
+	// destroyed and its applications and machines cannot be removed.
+	ProtectedKey = "protected"
+
+	// MaintenanceWindowKey is the key for the daily window, in UTC,
+	// within which automatic charm refreshes, agent upgrades and
+	// series upgrades may take place.
+	MaintenanceWindowKey = "maintenance-window"
+
+	// DNSProviderKey is the key for the external DNS service, one of
+	// "route53", "designate" or "coredns", in which application and
+	// unit addresses are published. If empty, no records are published.
+	DNSProviderKey = "dns-provider"
+
+	// DNSZoneKey is the key for the DNS zone in which application and
+	// unit records are published.
+	DNSZoneKey = "dns-zone"
+
+	// DNSEndpointKey is the key for the https endpoint of the etcd
+	// cluster backing CoreDNS, used when the DNS provider is "coredns".
+	DNSEndpointKey = "dns-endpoint"
+
+	// DNSCACertKey is the key for the certificate of the CA that
+	// signed the certificate of the etcd cluster backing CoreDNS. If
+	// empty, the system's CAs are trusted.
+	DNSCACertKey = "dns-ca-cert"
+
+	// DNSClientCertKey is the key for the client certificate used to
+	// authenticate to the etcd cluster backing CoreDNS.
+	DNSClientCertKey = "dns-client-cert"
+
+	// DNSClientKeyKey is the key for the client key used to
+	// authenticate to the etcd cluster backing CoreDNS.
+	DNSClientKeyKey = "dns-client-key"
+
+	// RemoveOrphanedResourcesKey is the key for whether provider
+	// resources tagged with the model, but unknown to Juju, are
+	// removed once found.
+	RemoveOrphanedResourcesKey = "remove-orphaned-resources"
+
+	// TagReconciliationKey is the key for whether the tags of the
+	// model's instances and volumes are corrected when they drift from
+	// those Juju set, or only reported.
+	TagReconciliationKey = "tag-reconciliation"
+
+	// IdleResourceDaysKey is the key for the number of days a machine
+	// or unit must be idle before it is reported by "juju advise". Zero
+	// disables the reporting of idle resources.
+	IdleResourceDaysKey = "idle-resource-days"
+
+	// IdleUtilisationMetricKey is the key for the name of the charm
+	// metric used to decide whether a unit is idle.
+	IdleUtilisationMetricKey = "idle-utilisation-metric"
+
+	// IdleUtilisationThresholdKey is the key for the value of the idle
+	// utilisation metric below which a unit is idle.
+	IdleUtilisationThresholdKey = "idle-utilisation-threshold"
+
+	// SpareMachinesKey is the key for the number of provisioned, empty
+	// machines kept in the model's pool of spare machines, ready for
+	// units to be assigned to them.
+	SpareMachinesKey = "spare-machines"
+
+	// SpareMachineConstraintsKey is the key for the constraints used
+	// when adding machines to the pool of spare machines.
+	SpareMachineConstraintsKey = "spare-machine-constraints"
+
+	// ResourceDownloadRateLimitKey is the key for the maximum rate, in
+	// KiB per second, at which the controller sends resources to the
+	// units of the model.
+	ResourceDownloadRateLimitKey = "resource-download-rate-limit"
+
+	// ColocationPolicyKey is the key for the policy that decides whether
+	// a unit may be placed on a machine already hosting units of other
+	// applications.
+	ColocationPolicyKey = "colocation-policy"
+
 	// ContainerInheritProperiesKey is the key to specify a list of properties
 	// to be copied from a machine to a container during provisioning. The
 	// list will be comma separated.
@@ -402,6 +507,38 @@ const (
 	DefaultActionResultsAge = "336h" // 2 weeks
 
 	DefaultActionResultsSize = "5G"
+
+	// DefaultIdleResourceDays is the default value for IdleResourceDaysKey.
+	DefaultIdleResourceDays = 7
+
+	// DefaultIdleUtilisationThreshold is the default value for
+	// IdleUtilisationThresholdKey.
+	DefaultIdleUtilisationThreshold = 5
+)
+
+const (
+	// ColocationPermissive allows units to be placed on machines
+	// hosting units of other applications, unless an anti-affinity
+	// rule forbids it.
+	ColocationPermissive = "permissive"
+
+	// ColocationStrict only allows units to be placed on machines
+	// hosting units of other applications if an affinity rule allows
+	// it.
+	ColocationStrict = "strict"
+)
+
+const (
+	// TagReconciliationEnabled corrects the tags of instances and
+	// volumes that have drifted.
+	TagReconciliationEnabled = "enabled"
+
+	// TagReconciliationDryRun logs the corrections that would be made,
+	// without making them.
+	TagReconciliationDryRun = "dry-run"
+
+	// TagReconciliationDisabled neither checks nor corrects tags.
+	TagReconciliationDisabled = "disabled"
 )
 
 var defaultConfigValues = map[string]interface{}{
@@ -432,22 +569,25 @@ var defaultConfigValues = map[string]interface{}{
 	NetBondReconfigureDelayKey: 17,
 	ContainerNetworkingMethod:  "",
 
-	"default-series":             jujuversion.SupportedLTS(),
-	ProvisionerHarvestModeKey:    HarvestDestroyed.String(),
-	ResourceTagsKey:              "",
-	"logging-config":             "",
-	AutomaticallyRetryHooks:      true,
-	"enable-os-refresh-update":   true,
-	"enable-os-upgrade":          true,
-	"development":                false,
-	"test-mode":                  false,
-	TransmitVendorMetricsKey:     true,
-	UpdateStatusHookInterval:     DefaultUpdateStatusHookInterval,
-	EgressSubnets:                "",
-	FanConfig:                    "",
-	CloudInitUserDataKey:         "",
-	ContainerInheritProperiesKey: "",
-	BackupDirKey:                 "",
+	"default-series":               jujuversion.SupportedLTS(),
+	ProvisionerHarvestModeKey:      HarvestDestroyed.String(),
+	ResourceTagsKey:                "",
+	"logging-config":               "",
+	AutomaticallyRetryHooks:        true,
+	"enable-os-refresh-update":     true,
+	"enable-os-upgrade":            true,
+	"development":                  false,
+	"test-mode":                    false,
+	TransmitVendorMetricsKey:       true,
+	UpdateStatusHookInterval:       DefaultUpdateStatusHookInterval,
+	EgressSubnets:                  "",
+	FanConfig:                      "",
+	PreferredAddressFamilyKey:      "",
+	ReapplyNetworkConfigOnDriftKey: false,
+	RemoveOrphanedResourcesKey:     false,
+	CloudInitUserDataKey:           "",
+	ContainerInheritProperiesKey:   "",
+	BackupDirKey:                   "",
 
 	// Image and agent streams and URLs.
 	"image-stream":               "released",
@@ -518,6 +658,18 @@ func (c *Config) ensureUnitLogging() error {
 	return nil
 }
 
+// deprecatedAttributes holds the names of attributes that are still
+// accepted but will be removed in a future release.
+var deprecatedAttributes = []string{
+	IgnoreMachineAddresses,
+}
+
+// DeprecatedAttributes returns the names of the deprecated model
+// config attributes.
+func DeprecatedAttributes() []string {
+	return append([]string(nil), deprecatedAttributes...)
+}
+
 // ProcessDeprecatedAttributes gathers any deprecated attributes in attrs and adds or replaces
 // them with new name value pairs for the replacement attrs.
 // Ths ensures that older versions of Juju which require that deprecated
@@ -616,6 +768,28 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[MaintenanceWindowKey].(string); ok {
+		if _, err := maintenance.ParseWindow(v); err != nil {
+			return errors.Annotate(err, "invalid maintenance window in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[DNSProviderKey].(string); ok && v != "" {
+		switch v {
+		case DNSProviderRoute53, DNSProviderDesignate, DNSProviderCoreDNS:
+		default:
+			return errors.NotValidf("%s %q", DNSProviderKey, v)
+		}
+		if cfg.asString(DNSZoneKey) == "" {
+			return errors.Errorf("%s must be set when %s is %q", DNSZoneKey, DNSProviderKey, v)
+		}
+		if v == DNSProviderCoreDNS {
+			if err := cfg.validateCoreDNS(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
 	if v, ok := cfg.defined[MaxActionResultsAge].(string); ok {
 		if _, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid max action age in model configuration")
@@ -641,6 +815,51 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	for _, key := range []string{ProvisionerRetryDelayKey, ProvisionerRetryMaxDelayKey} {
+		if v, ok := cfg.defined[key].(string); ok {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return errors.Annotatef(err, "invalid %s in model configuration", key)
+			}
+			if d <= 0 {
+				return errors.Errorf("%s must be positive, got %q", key, v)
+			}
+		}
+	}
+
+	if v, ok := cfg.defined[ProvisionerRetryCountKey].(int); ok && v < 0 {
+		return errors.Errorf("%s cannot be negative, got %d", ProvisionerRetryCountKey, v)
+	}
+
+	for _, key := range []string{IdleResourceDaysKey, IdleUtilisationThresholdKey, SpareMachinesKey, ResourceDownloadRateLimitKey} {
+		if v, ok := cfg.defined[key].(int); ok && v < 0 {
+			return errors.Errorf("%s cannot be negative, got %d", key, v)
+		}
+	}
+
+	if v, ok := cfg.defined[SpareMachineConstraintsKey].(string); ok {
+		if _, err := constraints.Parse(v); err != nil {
+			return errors.Annotate(err, SpareMachineConstraintsKey)
+		}
+	}
+
+	if v, ok := cfg.defined[ColocationPolicyKey].(string); ok {
+		switch v {
+		case "", ColocationPermissive, ColocationStrict:
+		default:
+			return errors.Errorf("%s must be %q or %q, got %q", ColocationPolicyKey, ColocationPermissive, ColocationStrict, v)
+		}
+	}
+
+	if v, ok := cfg.defined[TagReconciliationKey].(string); ok {
+		switch v {
+		case "", TagReconciliationEnabled, TagReconciliationDryRun, TagReconciliationDisabled:
+		default:
+			return errors.Errorf("%s must be %q, %q or %q, got %q", TagReconciliationKey,
+				TagReconciliationEnabled, TagReconciliationDryRun, TagReconciliationDisabled, v)
+		}
+	}
+
 	if v, ok := cfg.defined[EgressSubnets].(string); ok && v != "" {
 		cidrs := strings.Split(v, ",")
 		for _, cidr := range cidrs {
@@ -653,6 +872,12 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[PreferredAddressFamilyKey].(string); ok {
+		if _, err := network.ParseAddressSelectionPolicy(v); err != nil {
+			return errors.Annotate(err, PreferredAddressFamilyKey)
+		}
+	}
+
 	if v, ok := cfg.defined[FanConfig].(string); ok && v != "" {
 		_, err := network.ParseFanConfig(v)
 		if err != nil {
@@ -675,35 +900,9 @@ func Validate(cfg, old *Config) error {
 	}
 
 	if raw, ok := cfg.defined[CloudInitUserDataKey].(string); ok && raw != "" {
-		userDataMap, err := ensureStringMaps(raw)
-		if err != nil {
+		if _, err := ParseCloudInitUserData(raw); err != nil {
 			return errors.Annotate(err, "cloudinit-userdata")
 		}
-
-		// if there packages, ensure they are strings
-		if packages, ok := userDataMap["packages"].([]interface{}); ok {
-			for _, v := range packages {
-				checker := schema.String()
-				if _, err := checker.Coerce(v, nil); err != nil {
-					return errors.Annotate(err, "cloudinit-userdata: packages must be a list of strings")
-				}
-			}
-		}
-
-		// error if users is specified
-		if _, ok := userDataMap["users"]; ok {
-			return errors.New("cloudinit-userdata: users not allowed")
-		}
-
-		// error if runcmd is specified
-		if _, ok := userDataMap["runcmd"]; ok {
-			return errors.New("cloudinit-userdata: runcmd not allowed, use preruncmd or postruncmd instead")
-		}
-
-		// error if bootcmd is specified
-		if _, ok := userDataMap["bootcmd"]; ok {
-			return errors.New("cloudinit-userdata: bootcmd not allowed")
-		}
 	}
 
 	if raw, ok := cfg.defined[ContainerInheritProperiesKey].(string); ok && raw != "" {
@@ -1332,6 +1531,118 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// ProvisionerRetryDelay returns the delay before the provisioner first
+// retries a failed attempt to start an instance, and whether it was set.
+func (c *Config) ProvisionerRetryDelay() (time.Duration, bool) {
+	return c.optionalDuration(ProvisionerRetryDelayKey)
+}
+
+// ProvisionerRetryMaxDelay returns the longest delay the provisioner
+// will back off to between attempts to start an instance, and whether
+// it was set.
+func (c *Config) ProvisionerRetryMaxDelay() (time.Duration, bool) {
+	return c.optionalDuration(ProvisionerRetryMaxDelayKey)
+}
+
+// ProvisionerRetryCount returns the number of times the provisioner
+// retries a failed attempt to start an instance, and whether it was set.
+func (c *Config) ProvisionerRetryCount() (int, bool) {
+	v, ok := c.defined[ProvisionerRetryCountKey].(int)
+	return v, ok
+}
+
+// Protected returns whether the model is protected from destruction
+// and from the removal of its applications and machines.
+func (c *Config) Protected() bool {
+	v, _ := c.defined[ProtectedKey].(bool)
+	return v
+}
+
+// MaintenanceWindow returns the daily window within which automatic
+// charm refreshes, agent upgrades and series upgrades may take place.
+// If none is set, the window is always open.
+func (c *Config) MaintenanceWindow() maintenance.Window {
+	// Value has already been validated.
+	w, _ := maintenance.ParseWindow(c.asString(MaintenanceWindowKey))
+	return w
+}
+
+// The DNS providers in which application and unit addresses may be
+// published.
+const (
+	DNSProviderRoute53   = "route53"
+	DNSProviderDesignate = "designate"
+	DNSProviderCoreDNS   = "coredns"
+)
+
+// DNSProvider returns the external DNS service in which application and
+// unit addresses are published, or "" if they are not published.
+func (c *Config) DNSProvider() string {
+	return c.asString(DNSProviderKey)
+}
+
+// DNSZone returns the DNS zone in which application and unit addresses
+// are published.
+func (c *Config) DNSZone() string {
+	return c.asString(DNSZoneKey)
+}
+
+// DNSEndpoint returns the endpoint of the etcd cluster backing CoreDNS.
+func (c *Config) DNSEndpoint() string {
+	return c.asString(DNSEndpointKey)
+}
+
+// DNSCACert returns the certificate of the CA that signed the
+// certificate of the etcd cluster backing CoreDNS, or "" if the
+// system's CAs are trusted.
+func (c *Config) DNSCACert() string {
+	return c.asString(DNSCACertKey)
+}
+
+// DNSClientCert returns the client certificate used to authenticate to
+// the etcd cluster backing CoreDNS.
+func (c *Config) DNSClientCert() string {
+	return c.asString(DNSClientCertKey)
+}
+
+// DNSClientKey returns the client key used to authenticate to the etcd
+// cluster backing CoreDNS.
+func (c *Config) DNSClientKey() string {
+	return c.asString(DNSClientKeyKey)
+}
+
+// validateCoreDNS checks that the etcd cluster backing CoreDNS is
+// reached over TLS, authenticating with a client certificate.
+func (c *Config) validateCoreDNS() error {
+	endpoint := c.DNSEndpoint()
+	if endpoint == "" {
+		return errors.Errorf("%s must be set when %s is %q", DNSEndpointKey, DNSProviderKey, DNSProviderCoreDNS)
+	}
+	if u, err := url.Parse(endpoint); err != nil || u.Scheme != "https" || u.Host == "" {
+		return errors.Errorf("%s %q is not an https URL", DNSEndpointKey, endpoint)
+	}
+	if c.DNSClientCert() == "" || c.DNSClientKey() == "" {
+		return errors.Errorf("%s and %s must be set when %s is %q", DNSClientCertKey, DNSClientKeyKey, DNSProviderKey, DNSProviderCoreDNS)
+	}
+	if _, err := tls.X509KeyPair([]byte(c.DNSClientCert()), []byte(c.DNSClientKey())); err != nil {
+		return errors.Annotatef(err, "invalid %s or %s", DNSClientCertKey, DNSClientKeyKey)
+	}
+	if caCert := c.DNSCACert(); caCert != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(caCert)) {
+		return errors.Errorf("%s contains no PEM certificates", DNSCACertKey)
+	}
+	return nil
+}
+
+func (c *Config) optionalDuration(key string) (time.Duration, bool) {
+	raw, ok := c.defined[key].(string)
+	if !ok || raw == "" {
+		return 0, false
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val, true
+}
+
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
@@ -1348,6 +1659,93 @@ func (c *Config) EgressSubnets() []string {
 	return result
 }
 
+// AddressSelectionPolicy returns the policy for selecting between
+// IPv4 and IPv6 addresses, according to the preferred address family.
+func (c *Config) AddressSelectionPolicy() network.AddressSelectionPolicy {
+	// Value has already been validated.
+	policy, _ := network.ParseAddressSelectionPolicy(c.asString(PreferredAddressFamilyKey))
+	return policy
+}
+
+// ReapplyNetworkConfigOnDrift reports whether machine agents re-apply
+// the machine's netplan configuration when drift is detected.
+func (c *Config) ReapplyNetworkConfigOnDrift() bool {
+	v, _ := c.defined[ReapplyNetworkConfigOnDriftKey].(bool)
+	return v
+}
+
+// RemoveOrphanedResources reports whether provider resources tagged with
+// the model, but unknown to Juju, are removed once found.
+func (c *Config) RemoveOrphanedResources() bool {
+	v, _ := c.defined[RemoveOrphanedResourcesKey].(bool)
+	return v
+}
+
+// IdleResourceDays returns the number of days a machine or unit must be
+// idle before it is reported. Zero means idle resources aren't reported.
+func (c *Config) IdleResourceDays() int {
+	if v, ok := c.defined[IdleResourceDaysKey].(int); ok {
+		return v
+	}
+	return DefaultIdleResourceDays
+}
+
+// IdleUtilisationMetric returns the name of the charm metric used to
+// decide whether a unit is idle. If empty, units are never idle.
+func (c *Config) IdleUtilisationMetric() string {
+	v, _ := c.defined[IdleUtilisationMetricKey].(string)
+	return v
+}
+
+// IdleUtilisationThreshold returns the value of the idle utilisation
+// metric below which a unit is idle.
+func (c *Config) IdleUtilisationThreshold() int {
+	if v, ok := c.defined[IdleUtilisationThresholdKey].(int); ok {
+		return v
+	}
+	return DefaultIdleUtilisationThreshold
+}
+
+// SpareMachines returns the number of empty machines kept in the
+// model's pool of spare machines. Zero means no pool is kept.
+func (c *Config) SpareMachines() int {
+	v, _ := c.defined[SpareMachinesKey].(int)
+	return v
+}
+
+// SpareMachineConstraints returns the constraints used when adding
+// machines to the pool of spare machines.
+func (c *Config) SpareMachineConstraints() (constraints.Value, error) {
+	return constraints.Parse(c.asString(SpareMachineConstraintsKey))
+}
+
+// ResourceDownloadRateLimit returns the maximum rate, in KiB per second,
+// at which the controller sends resources to the units of the model.
+// Zero means downloads are not limited.
+func (c *Config) ResourceDownloadRateLimit() int {
+	v, _ := c.defined[ResourceDownloadRateLimitKey].(int)
+	return v
+}
+
+// ColocationPolicy returns the policy that decides whether a unit may
+// be placed on a machine already hosting units of other applications.
+func (c *Config) ColocationPolicy() string {
+	if v := c.asString(ColocationPolicyKey); v != "" {
+		return v
+	}
+	return ColocationPermissive
+}
+
+// TagReconciliation returns whether the tags of the model's instances
+// and volumes are corrected when they drift, only reported, or not
+// checked at all.
+func (c *Config) TagReconciliation() string {
+	if v := c.asString(TagReconciliationKey); v != "" {
+		return v
+	}
+	return TagReconciliationDryRun
+}
+
 // FanConfig is the configuration of FAN network running in the model.
 func (c *Config) FanConfig() (network.FanConfig, error) {
 	// At this point we are sure that the line is valid.
@@ -1366,6 +1764,74 @@ func (c *Config) CloudInitUserData() map[string]interface{} {
 	return conformingUserDataMap
 }
 
+// ParseCloudInitUserData parses cloud-init user data in the form accepted
+// by the cloudinit-userdata model config, returning an error if it holds
+// attributes which may not be customised.
+func ParseCloudInitUserData(raw string) (map[string]interface{}, error) {
+	userDataMap, err := ensureStringMaps(raw)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// if there packages, ensure they are strings
+	if packages, ok := userDataMap["packages"].([]interface{}); ok {
+		for _, v := range packages {
+			checker := schema.String()
+			if _, err := checker.Coerce(v, nil); err != nil {
+				return nil, errors.Annotate(err, "packages must be a list of strings")
+			}
+		}
+	}
+
+	// error if users is specified
+	if _, ok := userDataMap["users"]; ok {
+		return nil, errors.New("users not allowed")
+	}
+
+	// error if runcmd is specified
+	if _, ok := userDataMap["runcmd"]; ok {
+		return nil, errors.New("runcmd not allowed, use preruncmd or postruncmd instead")
+	}
+
+	// error if bootcmd is specified
+	if _, ok := userDataMap["bootcmd"]; ok {
+		return nil, errors.New("bootcmd not allowed")
+	}
+	return userDataMap, nil
+}
+
+// appendedUserDataKeys holds the cloud-init user data attributes whose
+// values are appended to, rather than replaced, when merging user data.
+var appendedUserDataKeys = []string{"packages", "preruncmd", "postruncmd"}
+
+// MergeCloudInitUserData returns the machine specific user data merged
+// over that of the model. List attributes which Juju appends to its own
+// (packages, preruncmd and postruncmd) are concatenated, model values
+// first; any other machine attributes replace those of the model.
+func MergeCloudInitUserData(modelData, machineData map[string]interface{}) map[string]interface{} {
+	if len(machineData) == 0 {
+		return modelData
+	}
+	result := make(map[string]interface{}, len(modelData)+len(machineData))
+	for k, v := range modelData {
+		result[k] = v
+	}
+	for k, v := range machineData {
+		result[k] = v
+	}
+	for _, key := range appendedUserDataKeys {
+		modelValues, _ := modelData[key].([]interface{})
+		machineValues, ok := machineData[key].([]interface{})
+		if !ok || len(modelValues) == 0 {
+			continue
+		}
+		merged := make([]interface{}, 0, len(modelValues)+len(machineValues))
+		merged = append(merged, modelValues...)
+		result[key] = append(merged, machineValues...)
+	}
+	return result
+}
+
 // ContainerInheritProperies returns a copy of the raw user data keys
 // that were specified by the user.
 func (c *Config) ContainerInheritProperies() string {
@@ -1448,57 +1914,79 @@ var alwaysOptional = schema.Defaults{
 	StorageDefaultBlockSourceKey:      schema.Omit,
 	StorageDefaultFilesystemSourceKey: schema.Omit,
 
-	"firewall-mode":              schema.Omit,
-	"logging-config":             schema.Omit,
-	ProvisionerHarvestModeKey:    schema.Omit,
-	HTTPProxyKey:                 schema.Omit,
-	HTTPSProxyKey:                schema.Omit,
-	FTPProxyKey:                  schema.Omit,
-	NoProxyKey:                   schema.Omit,
-	JujuHTTPProxyKey:             schema.Omit,
-	JujuHTTPSProxyKey:            schema.Omit,
-	JujuFTPProxyKey:              schema.Omit,
-	JujuNoProxyKey:               schema.Omit,
-	AptHTTPProxyKey:              schema.Omit,
-	AptHTTPSProxyKey:             schema.Omit,
-	AptFTPProxyKey:               schema.Omit,
-	AptNoProxyKey:                schema.Omit,
-	SnapHTTPProxyKey:             schema.Omit,
-	SnapHTTPSProxyKey:            schema.Omit,
-	SnapStoreProxyKey:            schema.Omit,
-	SnapStoreAssertionsKey:       schema.Omit,
-	"apt-mirror":                 schema.Omit,
-	AgentStreamKey:               schema.Omit,
-	ResourceTagsKey:              schema.Omit,
-	"cloudimg-base-url":          schema.Omit,
-	"enable-os-refresh-update":   schema.Omit,
-	"enable-os-upgrade":          schema.Omit,
-	"image-stream":               schema.Omit,
-	"image-metadata-url":         schema.Omit,
-	AgentMetadataURLKey:          schema.Omit,
-	ContainerImageStreamKey:      schema.Omit,
-	ContainerImageMetadataURLKey: schema.Omit,
-	"default-series":             schema.Omit,
-	"development":                schema.Omit,
-	"ssl-hostname-verification":  schema.Omit,
-	"proxy-ssh":                  schema.Omit,
-	"disable-network-management": schema.Omit,
-	IgnoreMachineAddresses:       schema.Omit,
-	AutomaticallyRetryHooks:      schema.Omit,
-	"test-mode":                  schema.Omit,
-	TransmitVendorMetricsKey:     schema.Omit,
-	NetBondReconfigureDelayKey:   schema.Omit,
-	ContainerNetworkingMethod:    schema.Omit,
-	MaxStatusHistoryAge:          schema.Omit,
-	MaxStatusHistorySize:         schema.Omit,
-	MaxActionResultsAge:          schema.Omit,
-	MaxActionResultsSize:         schema.Omit,
-	UpdateStatusHookInterval:     schema.Omit,
-	EgressSubnets:                schema.Omit,
-	FanConfig:                    schema.Omit,
-	CloudInitUserDataKey:         schema.Omit,
-	ContainerInheritProperiesKey: schema.Omit,
-	BackupDirKey:                 schema.Omit,
+	"firewall-mode":                schema.Omit,
+	"logging-config":               schema.Omit,
+	ProvisionerHarvestModeKey:      schema.Omit,
+	HTTPProxyKey:                   schema.Omit,
+	HTTPSProxyKey:                  schema.Omit,
+	FTPProxyKey:                    schema.Omit,
+	NoProxyKey:                     schema.Omit,
+	JujuHTTPProxyKey:               schema.Omit,
+	JujuHTTPSProxyKey:              schema.Omit,
+	JujuFTPProxyKey:                schema.Omit,
+	JujuNoProxyKey:                 schema.Omit,
+	AptHTTPProxyKey:                schema.Omit,
+	AptHTTPSProxyKey:               schema.Omit,
+	AptFTPProxyKey:                 schema.Omit,
+	AptNoProxyKey:                  schema.Omit,
+	SnapHTTPProxyKey:               schema.Omit,
+	SnapHTTPSProxyKey:              schema.Omit,
+	SnapStoreProxyKey:              schema.Omit,
+	SnapStoreAssertionsKey:         schema.Omit,
+	"apt-mirror":                   schema.Omit,
+	AgentStreamKey:                 schema.Omit,
+	ResourceTagsKey:                schema.Omit,
+	"cloudimg-base-url":            schema.Omit,
+	"enable-os-refresh-update":     schema.Omit,
+	"enable-os-upgrade":            schema.Omit,
+	"image-stream":                 schema.Omit,
+	"image-metadata-url":           schema.Omit,
+	AgentMetadataURLKey:            schema.Omit,
+	ContainerImageStreamKey:        schema.Omit,
+	ContainerImageMetadataURLKey:   schema.Omit,
+	"default-series":               schema.Omit,
+	"development":                  schema.Omit,
+	"ssl-hostname-verification":    schema.Omit,
+	"proxy-ssh":                    schema.Omit,
+	"disable-network-management":   schema.Omit,
+	IgnoreMachineAddresses:         schema.Omit,
+	AutomaticallyRetryHooks:        schema.Omit,
+	"test-mode":                    schema.Omit,
+	TransmitVendorMetricsKey:       schema.Omit,
+	NetBondReconfigureDelayKey:     schema.Omit,
+	ContainerNetworkingMethod:      schema.Omit,
+	MaxStatusHistoryAge:            schema.Omit,
+	MaxStatusHistorySize:           schema.Omit,
+	MaxActionResultsAge:            schema.Omit,
+	MaxActionResultsSize:           schema.Omit,
+	UpdateStatusHookInterval:       schema.Omit,
+	EgressSubnets:                  schema.Omit,
+	FanConfig:                      schema.Omit,
+	PreferredAddressFamilyKey:      schema.Omit,
+	ReapplyNetworkConfigOnDriftKey: schema.Omit,
+	RemoveOrphanedResourcesKey:     schema.Omit,
+	TagReconciliationKey:           schema.Omit,
+	IdleResourceDaysKey:            schema.Omit,
+	IdleUtilisationMetricKey:       schema.Omit,
+	IdleUtilisationThresholdKey:    schema.Omit,
+	SpareMachinesKey:               schema.Omit,
+	SpareMachineConstraintsKey:     schema.Omit,
+	ResourceDownloadRateLimitKey:   schema.Omit,
+	ColocationPolicyKey:            schema.Omit,
+	CloudInitUserDataKey:           schema.Omit,
+	ContainerInheritProperiesKey:   schema.Omit,
+	BackupDirKey:                   schema.Omit,
+	ProvisionerRetryDelayKey:       schema.Omit,
+	ProvisionerRetryMaxDelayKey:    schema.Omit,
+	ProvisionerRetryCountKey:       schema.Omit,
+	ProtectedKey:                   schema.Omit,
+	MaintenanceWindowKey:           schema.Omit,
+	DNSProviderKey:                 schema.Omit,
+	DNSZoneKey:                     schema.Omit,
+	DNSEndpointKey:                 schema.Omit,
+	DNSCACertKey:                   schema.Omit,
+	DNSClientCertKey:               schema.Omit,
+	DNSClientKeyKey:                schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1978,6 +2466,62 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	ProvisionerRetryDelayKey: {
+		Description: "The delay before retrying a failed attempt to start an instance, doubling after each retry (default 10s)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ProvisionerRetryMaxDelayKey: {
+		Description: "The longest delay between attempts to start an instance (default 5m)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ProvisionerRetryCountKey: {
+		Description: "The number of times a failed attempt to start an instance is retried, for errors that may be transient (default 10)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	ProtectedKey: {
+		Description: "Whether the model is protected from being destroyed and from having applications or machines removed (may only be changed by model admins)",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	MaintenanceWindowKey: {
+		Description: `The daily window (HH:MM-HH:MM, in UTC) within which automatic charm refreshes, agent upgrades and series upgrades may take place, or "any"`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	DNSProviderKey: {
+		Description: "The external DNS service in which application and unit addresses are published",
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"", DNSProviderRoute53, DNSProviderDesignate, DNSProviderCoreDNS},
+		Group:       environschema.EnvironGroup,
+	},
+	DNSZoneKey: {
+		Description: "The DNS zone in which application and unit addresses are published",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	DNSEndpointKey: {
+		Description: "The https endpoint of the etcd cluster backing CoreDNS, when dns-provider is coredns",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	DNSCACertKey: {
+		Description: "The certificate of the CA that signed the certificate of the etcd cluster backing CoreDNS, in PEM format",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	DNSClientCertKey: {
+		Description: "The client certificate used to authenticate to the etcd cluster backing CoreDNS, in PEM format",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	DNSClientKeyKey: {
+		Description: "The client key used to authenticate to the etcd cluster backing CoreDNS, in PEM format. Anyone who can read the model config can read the key, so it should belong to an etcd user that can only write the CoreDNS keys for the zone",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	EgressSubnets: {
 		Description: "Source address(es) for traffic originating from this model",
 		Type:        environschema.Tstring,
@@ -1988,6 +2532,63 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	PreferredAddressFamilyKey: {
+		Description: "The address family preferred when selecting addresses in dual-stack deployments",
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"", "ipv4", "ipv6"},
+		Group:       environschema.EnvironGroup,
+	},
+	ReapplyNetworkConfigOnDriftKey: {
+		Description: "Whether machine agents re-apply the machine's netplan configuration when the observed network configuration drifts from the recorded one",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	RemoveOrphanedResourcesKey: {
+		Description: "Whether provider resources tagged with the model, but unknown to Juju, are removed once found",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
+	TagReconciliationKey: {
+		Description: `Whether the tags of the model's instances and volumes are corrected when they drift from those Juju set ("enabled"), only logged ("dry-run") or not checked ("disabled") (default "dry-run")`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{"", TagReconciliationEnabled, TagReconciliationDryRun, TagReconciliationDisabled},
+		Group:       environschema.EnvironGroup,
+	},
+	IdleResourceDaysKey: {
+		Description: "The number of days a machine or unit must be idle before it is reported by juju advise, or 0 to not report idle resources (default 7)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	IdleUtilisationMetricKey: {
+		Description: "The charm metric used to decide whether a unit is idle; if empty, only machines without units or containers are reported",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	IdleUtilisationThresholdKey: {
+		Description: "The value of idle-utilisation-metric below which a unit is idle (default 5)",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	SpareMachinesKey: {
+		Description: "The number of provisioned, empty machines kept ready for new units, or 0 to not keep spare machines",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	SpareMachineConstraintsKey: {
+		Description: "The constraints used when adding spare machines",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ResourceDownloadRateLimitKey: {
+		Description: "The maximum rate, in KiB per second, at which the controller sends resources to the units of the model, or 0 for no limit",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	ColocationPolicyKey: {
+		Description: `Whether units may be placed on machines hosting other applications: "permissive" unless an anti-affinity rule forbids it, or "strict" only if an affinity rule allows it (default "permissive")`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	CloudInitUserDataKey: {
 		Description: "Cloud-init user-data (in yaml format) to be added to userdata for new machines created in this model",
 		Type:        environschema.Tstring,
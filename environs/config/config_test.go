@@ -20,9 +20,12 @@ import (
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/maintenance"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
 	jujuversion "github.com/juju/juju/juju/version"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/testing"
 )
 
@@ -199,6 +202,13 @@ var configTests = []configTest{
 			"agent-version": "2",
 		}),
 		err: `invalid agent version in model configuration: "2"`,
+	}, {
+		about:       "Invalid maintenance window",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"maintenance-window": "02:00-26:00",
+		}),
+		err: `invalid maintenance window in model configuration: maintenance window "02:00-26:00": time of day "26:00" not valid`,
 	}, {
 		about:       "Missing type",
 		useDefaults: config.UseDefaults,
@@ -571,6 +581,77 @@ var configTests = []configTest{
 		attrs: minimalConfigAttrs.Merge(testing.Attrs{
 			"backup-dir": "/foo/bar",
 		}),
+	}, {
+		about:       "Valid provisioner retry settings",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioner-retry-delay":     "30s",
+			"provisioner-retry-max-delay": "10m",
+			"provisioner-retry-count":     3,
+		}),
+	}, {
+		about:       "Invalid provisioner retry delay",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioner-retry-delay": "soon",
+		}),
+		err: `invalid provisioner-retry-delay in model configuration: time: invalid duration "?soon"?`,
+	}, {
+		about:       "Non-positive provisioner retry max delay",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioner-retry-max-delay": "0s",
+		}),
+		err: `provisioner-retry-max-delay must be positive, got "0s"`,
+	}, {
+		about:       "Negative provisioner retry count",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"provisioner-retry-count": -1,
+		}),
+		err: `provisioner-retry-count cannot be negative, got -1`,
+	}, {
+		about:       "Negative idle resource days",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"idle-resource-days": -1,
+		}),
+		err: `idle-resource-days cannot be negative, got -1`,
+	}, {
+		about:       "Negative spare machines",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"spare-machines": -1,
+		}),
+		err: `spare-machines cannot be negative, got -1`,
+	}, {
+		about:       "Negative resource download rate limit",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"resource-download-rate-limit": -1,
+		}),
+		err: `resource-download-rate-limit cannot be negative, got -1`,
+	}, {
+		about:       "Invalid spare machine constraints",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"spare-machine-constraints": "mem=lots",
+		}),
+		err: `spare-machine-constraints: bad "mem" constraint: .*`,
+	}, {
+		about:       "Invalid colocation policy",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"colocation-policy": "sometimes",
+		}),
+		err: `colocation-policy must be "permissive" or "strict", got "sometimes"`,
+	}, {
+		about:       "Invalid tag reconciliation",
+		useDefaults: config.UseDefaults,
+		attrs: minimalConfigAttrs.Merge(testing.Attrs{
+			"tag-reconciliation": "sometimes",
+		}),
+		err: `tag-reconciliation must be "enabled", "dry-run" or "disabled", got "sometimes"`,
 	},
 }
 
@@ -1097,6 +1178,120 @@ func (s *ConfigSuite) TestBackupDir(c *gc.C) {
 	c.Assert(config.BackupDir(), gc.Equals, testDir)
 }
 
+func (s *ConfigSuite) TestProtected(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.Protected(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{"protected": true})
+	c.Assert(cfg.Protected(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestMaintenanceWindow(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MaintenanceWindow(), gc.Equals, maintenance.Window{})
+
+	cfg = newTestConfig(c, testing.Attrs{"maintenance-window": "22:00-02:00"})
+	c.Assert(cfg.MaintenanceWindow(), gc.Equals, maintenance.Window{Start: 22 * time.Hour, End: 2 * time.Hour})
+}
+
+func (s *ConfigSuite) TestProvisionerRetry(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.ProvisionerRetryDelay()
+	c.Assert(ok, jc.IsFalse)
+	_, ok = cfg.ProvisionerRetryMaxDelay()
+	c.Assert(ok, jc.IsFalse)
+	_, ok = cfg.ProvisionerRetryCount()
+	c.Assert(ok, jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"provisioner-retry-delay":     "30s",
+		"provisioner-retry-max-delay": "10m",
+		"provisioner-retry-count":     0,
+	})
+	delay, ok := cfg.ProvisionerRetryDelay()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(delay, gc.Equals, 30*time.Second)
+	maxDelay, ok := cfg.ProvisionerRetryMaxDelay()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(maxDelay, gc.Equals, 10*time.Minute)
+	count, ok := cfg.ProvisionerRetryCount()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(count, gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestDNS(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.DNSProvider(), gc.Equals, "")
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"dns-provider":    "coredns",
+		"dns-zone":        "example.com",
+		"dns-endpoint":    "https://10.0.0.1:2379",
+		"dns-ca-cert":     testing.CACert,
+		"dns-client-cert": testing.ServerCert,
+		"dns-client-key":  testing.ServerKey,
+	})
+	c.Assert(cfg.DNSProvider(), gc.Equals, "coredns")
+	c.Assert(cfg.DNSZone(), gc.Equals, "example.com")
+	c.Assert(cfg.DNSEndpoint(), gc.Equals, "https://10.0.0.1:2379")
+	c.Assert(cfg.DNSCACert(), gc.Equals, testing.CACert)
+	c.Assert(cfg.DNSClientCert(), gc.Equals, testing.ServerCert)
+	c.Assert(cfg.DNSClientKey(), gc.Equals, testing.ServerKey)
+}
+
+func (s *ConfigSuite) TestDNSInvalid(c *gc.C) {
+	for i, test := range []struct {
+		attrs testing.Attrs
+		err   string
+	}{{
+		attrs: testing.Attrs{"dns-provider": "bind", "dns-zone": "example.com"},
+		err:   `.*dns-provider.*`,
+	}, {
+		attrs: testing.Attrs{"dns-provider": "route53"},
+		err:   `dns-zone must be set when dns-provider is "route53"`,
+	}, {
+		attrs: testing.Attrs{"dns-provider": "coredns", "dns-zone": "example.com"},
+		err:   `dns-endpoint must be set when dns-provider is "coredns"`,
+	}, {
+		attrs: testing.Attrs{
+			"dns-provider": "coredns", "dns-zone": "example.com",
+			"dns-endpoint": "http://10.0.0.1:2379",
+		},
+		err: `dns-endpoint "http://10.0.0.1:2379" is not an https URL`,
+	}, {
+		attrs: testing.Attrs{
+			"dns-provider": "coredns", "dns-zone": "example.com",
+			"dns-endpoint": "https://10.0.0.1:2379",
+		},
+		err: `dns-client-cert and dns-client-key must be set when dns-provider is "coredns"`,
+	}, {
+		attrs: testing.Attrs{
+			"dns-provider": "coredns", "dns-zone": "example.com",
+			"dns-endpoint":    "https://10.0.0.1:2379",
+			"dns-client-cert": testing.ServerCert,
+			"dns-client-key":  testing.CAKey,
+		},
+		err: `invalid dns-client-cert or dns-client-key: .*`,
+	}, {
+		attrs: testing.Attrs{
+			"dns-provider": "coredns", "dns-zone": "example.com",
+			"dns-endpoint":    "https://10.0.0.1:2379",
+			"dns-ca-cert":     "not a certificate",
+			"dns-client-cert": testing.ServerCert,
+			"dns-client-key":  testing.ServerKey,
+		},
+		err: `dns-ca-cert contains no PEM certificates`,
+	}} {
+		c.Logf("test %d", i)
+		attrs := testing.Attrs{
+			"type": "my-type", "name": "my-name",
+			"uuid": testing.ModelTag.Id(),
+		}.Merge(test.attrs)
+		_, err := config.New(config.UseDefaults, attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 func (s *ConfigSuite) TestAutoHookRetryDefault(c *gc.C) {
 	config := newTestConfig(c, testing.Attrs{})
 	c.Assert(config.AutomaticallyRetryHooks(), gc.Equals, true)
@@ -1325,6 +1520,104 @@ func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestAddressSelectionPolicy(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.AddressSelectionPolicy(), gc.Equals, network.PreferIPv4)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.PreferredAddressFamilyKey: "ipv6",
+	})
+	c.Assert(cfg.AddressSelectionPolicy(), gc.Equals, network.PreferIPv6)
+
+	_, err := config.New(config.UseDefaults, testing.FakeConfig().Merge(testing.Attrs{
+		config.PreferredAddressFamilyKey: "ipx",
+	}))
+	c.Assert(err, gc.ErrorMatches, `preferred-address-family: address family "ipx" not valid`)
+}
+
+func (s *ConfigSuite) TestReapplyNetworkConfigOnDrift(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ReapplyNetworkConfigOnDrift(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.ReapplyNetworkConfigOnDriftKey: true,
+	})
+	c.Assert(cfg.ReapplyNetworkConfigOnDrift(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestRemoveOrphanedResources(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.RemoveOrphanedResources(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.RemoveOrphanedResourcesKey: true,
+	})
+	c.Assert(cfg.RemoveOrphanedResources(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestIdleResources(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.IdleResourceDays(), gc.Equals, config.DefaultIdleResourceDays)
+	c.Assert(cfg.IdleUtilisationMetric(), gc.Equals, "")
+	c.Assert(cfg.IdleUtilisationThreshold(), gc.Equals, config.DefaultIdleUtilisationThreshold)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.IdleResourceDaysKey:         0,
+		config.IdleUtilisationMetricKey:    "cpu",
+		config.IdleUtilisationThresholdKey: 10,
+	})
+	c.Assert(cfg.IdleResourceDays(), gc.Equals, 0)
+	c.Assert(cfg.IdleUtilisationMetric(), gc.Equals, "cpu")
+	c.Assert(cfg.IdleUtilisationThreshold(), gc.Equals, 10)
+}
+
+func (s *ConfigSuite) TestSpareMachines(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.SpareMachines(), gc.Equals, 0)
+	cons, err := cfg.SpareMachineConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.Value{})
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.SpareMachinesKey:           3,
+		config.SpareMachineConstraintsKey: "mem=4G",
+	})
+	c.Assert(cfg.SpareMachines(), gc.Equals, 3)
+	cons, err = cfg.SpareMachineConstraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cons, jc.DeepEquals, constraints.MustParse("mem=4G"))
+}
+
+func (s *ConfigSuite) TestColocationPolicy(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ColocationPolicy(), gc.Equals, config.ColocationPermissive)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.ColocationPolicyKey: config.ColocationStrict,
+	})
+	c.Assert(cfg.ColocationPolicy(), gc.Equals, config.ColocationStrict)
+}
+
+func (s *ConfigSuite) TestTagReconciliation(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.TagReconciliation(), gc.Equals, config.TagReconciliationDryRun)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.TagReconciliationKey: config.TagReconciliationEnabled,
+	})
+	c.Assert(cfg.TagReconciliation(), gc.Equals, config.TagReconciliationEnabled)
+}
+
+func (s *ConfigSuite) TestResourceDownloadRateLimit(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ResourceDownloadRateLimit(), gc.Equals, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.ResourceDownloadRateLimitKey: 1024,
+	})
+	c.Assert(cfg.ResourceDownloadRateLimit(), gc.Equals, 1024)
+}
+
 func (s *ConfigSuite) TestCloudInitUserDataFromEnvironment(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		config.CloudInitUserDataKey: validCloudInitUserData,
@@ -1337,6 +1630,40 @@ func (s *ConfigSuite) TestCloudInitUserDataFromEnvironment(c *gc.C) {
 	)
 }
 
+func (s *ConfigSuite) TestParseCloudInitUserData(c *gc.C) {
+	userData, err := config.ParseCloudInitUserData("packages: [htop]\npackage_upgrade: true\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(userData, gc.DeepEquals, map[string]interface{}{
+		"packages":        []interface{}{"htop"},
+		"package_upgrade": true,
+	})
+
+	_, err = config.ParseCloudInitUserData("runcmd: [reboot]\n")
+	c.Assert(err, gc.ErrorMatches, "runcmd not allowed, use preruncmd or postruncmd instead")
+}
+
+func (s *ConfigSuite) TestMergeCloudInitUserData(c *gc.C) {
+	modelData := map[string]interface{}{
+		"packages":        []interface{}{"python-keystoneclient"},
+		"preruncmd":       []interface{}{"mkdir /tmp/model"},
+		"package_upgrade": false,
+	}
+	machineData := map[string]interface{}{
+		"packages":        []interface{}{"htop"},
+		"postruncmd":      []interface{}{"mkdir /tmp/machine"},
+		"package_upgrade": true,
+	}
+	c.Assert(config.MergeCloudInitUserData(modelData, machineData), gc.DeepEquals, map[string]interface{}{
+		"packages":        []interface{}{"python-keystoneclient", "htop"},
+		"preruncmd":       []interface{}{"mkdir /tmp/model"},
+		"postruncmd":      []interface{}{"mkdir /tmp/machine"},
+		"package_upgrade": true,
+	})
+	// The model data is not modified.
+	c.Assert(modelData["packages"], gc.DeepEquals, []interface{}{"python-keystoneclient"})
+	c.Assert(config.MergeCloudInitUserData(modelData, nil), gc.DeepEquals, modelData)
+}
+
 func (s *ConfigSuite) TestContainerInheritProperies(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"container-inherit-properties": "ca-certs,apt-primary",
@@ -52,6 +52,20 @@ func (c ConfigValues) AllAttrs() map[string]interface{} {
 	return result
 }
 
+// ConfigTrace describes how the effective value of a model config
+// attribute was arrived at.
+type ConfigTrace struct {
+	// Effective is the attribute's value, and the source
+	// it is attributed to.
+	Effective ConfigValue
+
+	// Levels holds the value set at each level of the inheritance
+	// chain that sets the attribute, from lowest to highest
+	// precedence. The model level is only included if the model's
+	// value is not inherited.
+	Levels []ConfigValue
+}
+
 // ConfigSchemaSource instances provide information on config attributes
 // and the default attribute values.
 type ConfigSchemaSource interface {
@@ -0,0 +1,156 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/context"
+)
+
+// DNSRecordTTL is the time to live, in seconds, of the DNS records
+// published for applications and units. It is kept short so that
+// clients notice units coming and going.
+const DNSRecordTTL = 60
+
+// DNSRecord holds the addresses published under a single name in a DNS
+// zone. IPv4 addresses are published as A records, and IPv6 addresses
+// as AAAA records.
+type DNSRecord struct {
+	// Name is the name of the record, relative to the zone.
+	Name string
+
+	// Addresses holds the addresses published under the name. A
+	// record with no addresses is removed from the zone.
+	Addresses []string
+}
+
+// DNSZones is implemented by environs that can publish records in a DNS
+// service hosted by the cloud, such as Route53 or Designate.
+//
+// Each name published is marked as owned by its publisher with a TXT
+// record holding DNSOwnerText(owner). Names marked as owned by another
+// publisher, and names with address records but no marker, are never
+// changed, so records created by others in the zone are left alone.
+type DNSZones interface {
+	// OwnedDNSRecords returns the records in the named zone whose
+	// names are marked as owned by the given owner.
+	OwnedDNSRecords(ctx context.ProviderCallContext, zone, owner string) ([]DNSRecord, error)
+
+	// SetDNSRecords creates, replaces or removes the given records in
+	// the named zone, marking their names as owned by the given owner
+	// while they have addresses. Removing a record that does not exist
+	// is not an error. Records whose names are owned by others are
+	// skipped, and reported in an error once the rest have been set.
+	SetDNSRecords(ctx context.ProviderCallContext, zone, owner string, records []DNSRecord) error
+}
+
+// SupportsDNSZones returns the environ's DNSZones implementation, and
+// whether it has one.
+func SupportsDNSZones(env BootstrapEnviron) (DNSZones, bool) {
+	zones, ok := env.(DNSZones)
+	return zones, ok
+}
+
+// dnsOwnerPrefix starts the text of every ownership marker.
+const dnsOwnerPrefix = "heritage=juju,juju-owner="
+
+// DNSOwnerText returns the text of the TXT record that marks a name as
+// owned by the given owner.
+func DNSOwnerText(owner string) string {
+	return dnsOwnerPrefix + owner
+}
+
+// DNSRecordSet holds the values of a record set read from a DNS zone.
+// It is used by DNSZones implementations to work out who owns the
+// names in the zone.
+type DNSRecordSet struct {
+	// Name is the name of the record set, relative to the zone.
+	Name string
+
+	// Type is the record type, such as "A" or "TXT".
+	Type string
+
+	// Values holds the values of the records. The values of TXT
+	// records may be quoted.
+	Values []string
+}
+
+// DNSNameOwnership returns whether each name in the record sets that
+// has address records or an ownership marker is owned by the given
+// owner. Names not in the result are free to be published.
+func DNSNameOwnership(recordSets []DNSRecordSet, owner string) map[string]bool {
+	marker := DNSOwnerText(owner)
+	result := make(map[string]bool)
+	for _, recordSet := range recordSets {
+		switch recordSet.Type {
+		case "A", "AAAA":
+			if _, ok := result[recordSet.Name]; !ok {
+				result[recordSet.Name] = false
+			}
+		case "TXT":
+			for _, value := range recordSet.Values {
+				value = strings.Trim(value, `"`)
+				if value == marker {
+					result[recordSet.Name] = true
+				} else if strings.HasPrefix(value, dnsOwnerPrefix) && !result[recordSet.Name] {
+					result[recordSet.Name] = false
+				}
+			}
+		}
+	}
+	return result
+}
+
+// OwnedDNSRecords returns the address records of the names in the
+// record sets that are owned by the given owner, ordered by name.
+func OwnedDNSRecords(recordSets []DNSRecordSet, owner string) []DNSRecord {
+	ownership := DNSNameOwnership(recordSets, owner)
+	addresses := make(map[string][]string)
+	for _, recordSet := range recordSets {
+		if !ownership[recordSet.Name] {
+			continue
+		}
+		if recordSet.Type == "A" || recordSet.Type == "AAAA" {
+			addresses[recordSet.Name] = append(addresses[recordSet.Name], recordSet.Values...)
+		}
+	}
+	result := make([]DNSRecord, 0, len(addresses))
+	for name, addrs := range addresses {
+		sort.Strings(addrs)
+		result = append(result, DNSRecord{Name: name, Addresses: addrs})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result
+}
+
+// SplitDNSAddresses splits the addresses into those published as A
+// records and those published as AAAA records. Addresses that are not
+// IP addresses are dropped.
+func SplitDNSAddresses(addrs []string) (ipv4, ipv6 []string) {
+	for _, addr := range addrs {
+		ip := net.ParseIP(addr)
+		switch {
+		case ip == nil:
+		case ip.To4() != nil:
+			ipv4 = append(ipv4, addr)
+		default:
+			ipv6 = append(ipv6, addr)
+		}
+	}
+	return ipv4, ipv6
+}
+
+// DNSNamesNotOwnedError returns the error reported by SetDNSRecords when
+// the records of the given names were not set because they are owned
+// by others.
+func DNSNamesNotOwnedError(names []string) error {
+	return errors.Errorf("not publishing %s: names in use by others", strings.Join(names, ", "))
+}
@@ -0,0 +1,50 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type dnsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&dnsSuite{})
+
+var dnsRecordSets = []environs.DNSRecordSet{
+	{Name: "", Type: "NS", Values: []string{"ns1.example.com."}},
+	{Name: "wordpress.prod", Type: "A", Values: []string{"203.0.113.11", "203.0.113.10"}},
+	{Name: "wordpress.prod", Type: "TXT", Values: []string{`"` + environs.DNSOwnerText("deadbeef") + `"`}},
+	{Name: "wordpress.prod", Type: "AAAA", Values: []string{"2001:db8::10"}},
+	{Name: "www", Type: "A", Values: []string{"203.0.113.20"}},
+	{Name: "mysql.prod", Type: "TXT", Values: []string{environs.DNSOwnerText("cafebabe")}},
+	{Name: "mail", Type: "TXT", Values: []string{`"v=spf1 -all"`}},
+}
+
+func (s *dnsSuite) TestDNSNameOwnership(c *gc.C) {
+	c.Check(environs.DNSNameOwnership(dnsRecordSets, "deadbeef"), jc.DeepEquals, map[string]bool{
+		"wordpress.prod": true,
+		"www":            false,
+		"mysql.prod":     false,
+	})
+}
+
+func (s *dnsSuite) TestOwnedDNSRecords(c *gc.C) {
+	c.Check(environs.OwnedDNSRecords(dnsRecordSets, "deadbeef"), jc.DeepEquals, []environs.DNSRecord{{
+		Name:      "wordpress.prod",
+		Addresses: []string{"2001:db8::10", "203.0.113.10", "203.0.113.11"},
+	}})
+	c.Check(environs.OwnedDNSRecords(dnsRecordSets, "cafebabe"), gc.HasLen, 0)
+}
+
+func (s *dnsSuite) TestSplitDNSAddresses(c *gc.C) {
+	ipv4, ipv6 := environs.SplitDNSAddresses([]string{"203.0.113.10", "2001:db8::10", "wordpress.example.com"})
+	c.Check(ipv4, jc.DeepEquals, []string{"203.0.113.10"})
+	c.Check(ipv6, jc.DeepEquals, []string{"2001:db8::10"})
+}
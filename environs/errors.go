@@ -41,3 +41,47 @@ func IsAvailabilityZoneIndependent(err error) bool {
 	}
 	return false
 }
+
+// ProvisioningErrorKind classifies a failure to start an instance by how
+// the provisioner should respond to it.
+type ProvisioningErrorKind string
+
+const (
+	// ProvisioningErrorRetryable indicates a failure that may succeed if
+	// attempted again later, such as a temporary lack of capacity.
+	ProvisioningErrorRetryable ProvisioningErrorKind = "retryable"
+
+	// ProvisioningErrorFatal indicates a failure that will not succeed
+	// however many times it is attempted, such as an unknown image.
+	ProvisioningErrorFatal ProvisioningErrorKind = "fatal"
+
+	// ProvisioningErrorNeedsUserAction indicates a failure that will not
+	// succeed until the user intervenes, for example by raising a quota
+	// or updating an invalid credential.
+	ProvisioningErrorNeedsUserAction ProvisioningErrorKind = "needs-user-action"
+)
+
+// ProvisioningError provides an interface for compute providers to
+// classify a failure to start an instance, so that the provisioner
+// knows whether the attempt is worth retrying.
+type ProvisioningError interface {
+	error
+
+	// ProvisioningErrorKind reports how the provisioner should respond
+	// to the error.
+	ProvisioningErrorKind() ProvisioningErrorKind
+
+	// ProvisioningErrorCode returns a short machine-readable code
+	// identifying the failure, such as "quota-exceeded".
+	ProvisioningErrorCode() string
+}
+
+// ClassifyProvisioningError returns the kind and code of the given
+// error, or its cause. Errors that do not implement ProvisioningError
+// are assumed to be retryable, and have no code.
+func ClassifyProvisioningError(err error) (ProvisioningErrorKind, string) {
+	if err, ok := errors.Cause(err).(ProvisioningError); ok {
+		return err.ProvisioningErrorKind(), err.ProvisioningErrorCode()
+	}
+	return ProvisioningErrorRetryable, ""
+}
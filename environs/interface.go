@@ -342,6 +342,17 @@ type CloudDestroyer interface {
 	Destroy(ctx context.ProviderCallContext) error
 }
 
+// VolumePreservingDestroyer is an optional interface that an Environ
+// may implement if it can destroy the model's cloud resources while
+// leaving its volumes in place, so that released storage survives the
+// model.
+type VolumePreservingDestroyer interface {
+	// DestroyExceptVolumes shuts down all known machines and
+	// destroys every other resource tagged with the model, as
+	// Destroy does, except for the model's volumes.
+	DestroyExceptVolumes(ctx context.ProviderCallContext) error
+}
+
 // An Environ represents a Juju environment.
 //
 // Due to the limitations of some providers (for example ec2), the
@@ -498,12 +509,48 @@ type InstanceTagger interface {
 	TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error
 }
 
+// InstanceSnapshotter is an interface that may be implemented by an
+// Environ that can capture images of its instances in other regions of
+// the same cloud. It is used to provision a model's machines again when
+// the model is migrated to another region.
+type InstanceSnapshotter interface {
+	// SnapshotInstance creates an image of the instance's disks in
+	// the specified region, and returns the ID of the image there.
+	SnapshotInstance(ctx context.ProviderCallContext, id instance.Id, region string) (string, error)
+
+	// RemoveSnapshot removes an image created by SnapshotInstance
+	// from the specified region.
+	RemoveSnapshot(ctx context.ProviderCallContext, region, imageId string) error
+}
+
 // InstanceTypesFetcher is an interface that allows for instance information from
 // a provider to be obtained.
 type InstanceTypesFetcher interface {
 	InstanceTypes(context.ProviderCallContext, constraints.Value) (instances.InstanceTypesWithCostMetadata, error)
 }
 
+// InstancePricer is an interface that may be implemented by an Environ
+// that can estimate the cost of the instances it starts.
+type InstancePricer interface {
+	// InstancePricing returns the on-demand pricing of the instance type
+	// that would be started to satisfy the given constraints.
+	InstancePricing(context.ProviderCallContext, constraints.Value) (InstancePricing, error)
+}
+
+// InstancePricing holds the on-demand price of an instance type.
+type InstancePricing struct {
+	// InstanceType is the name of the instance type.
+	InstanceType string
+
+	// HourlyCost is the cost of running an instance for an hour,
+	// expressed in Currency.
+	HourlyCost float64
+
+	// Currency is the currency in which HourlyCost is expressed,
+	// e.g. "USD".
+	Currency string
+}
+
 // Upgrader is an interface that can be used for upgrading Environs. If an
 // Environ implements this interface, its UpgradeOperations method will be
 // invoked to identify operations that should be run on upgrade.
@@ -0,0 +1,52 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
+)
+
+// LoadBalancers is implemented by environs that can provision load
+// balancers, such as an EC2 ELB, an OpenStack Octavia load balancer or
+// a GCE forwarding rule, in front of the instances running the units of
+// an exposed application.
+type LoadBalancers interface {
+	// EnsureLoadBalancer creates the load balancer for the application
+	// named in the spec, or updates an existing one so that it forwards
+	// exactly the spec's port ranges to exactly the spec's instances.
+	// It returns the addresses at which the load balancer is reachable.
+	EnsureLoadBalancer(ctx context.ProviderCallContext, spec LoadBalancerSpec) ([]network.Address, error)
+
+	// DeleteLoadBalancer removes the load balancer for the named
+	// application. Deleting a load balancer that does not exist is not
+	// an error.
+	DeleteLoadBalancer(ctx context.ProviderCallContext, applicationName string) error
+}
+
+// LoadBalancerSpec holds the details of a load balancer for an
+// application.
+type LoadBalancerSpec struct {
+	// ApplicationName is the name of the application. Providers name
+	// load balancers after the application and the model, so there is
+	// at most one load balancer for each application.
+	ApplicationName string
+
+	// PortRanges holds the port ranges opened by the application's
+	// units, which the load balancer forwards to the instances.
+	PortRanges []corenetwork.PortRange
+
+	// Instances holds the ids of the instances running the
+	// application's units.
+	Instances []instance.Id
+}
+
+// SupportsLoadBalancers returns the environ's LoadBalancers
+// implementation, and whether it has one.
+func SupportsLoadBalancers(env BootstrapEnviron) (LoadBalancers, bool) {
+	lb, ok := env.(LoadBalancers)
+	return lb, ok
+}
@@ -0,0 +1,101 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"sort"
+
+	"github.com/juju/collections/set"
+
+	"github.com/juju/juju/environs/context"
+)
+
+// ModelResourceKind identifies the kind of a provider resource created
+// for a model.
+type ModelResourceKind string
+
+const (
+	InstanceResource      ModelResourceKind = "instance"
+	VolumeResource        ModelResourceKind = "volume"
+	SecurityGroupResource ModelResourceKind = "security-group"
+	LoadBalancerResource  ModelResourceKind = "load-balancer"
+)
+
+// ModelResource identifies a provider resource created for a model.
+type ModelResource struct {
+	// Kind is the kind of the resource.
+	Kind ModelResourceKind
+
+	// Id is the provider's id for the resource.
+	Id string
+
+	// Owner is the tag of the machine or application the resource was
+	// created for, if the provider records it. It is empty for
+	// resources belonging to the model as a whole.
+	Owner string
+}
+
+// ModelResources is implemented by environs that can list and remove
+// the provider resources they create for a model, so that resources
+// leaked by interrupted operations, such as force-destroying a machine,
+// can be found.
+type ModelResources interface {
+	// AllModelResources returns the instances, volumes, security groups
+	// and load balancers tagged with the model's UUID.
+	AllModelResources(ctx context.ProviderCallContext) ([]ModelResource, error)
+
+	// DestroyModelResources removes the given resources. Removing a
+	// resource that no longer exists is not an error.
+	DestroyModelResources(ctx context.ProviderCallContext, resources []ModelResource) error
+}
+
+// SupportsModelResources returns the environ's ModelResources
+// implementation, and whether it has one.
+func SupportsModelResources(env BootstrapEnviron) (ModelResources, bool) {
+	mr, ok := env.(ModelResources)
+	return mr, ok
+}
+
+// KnownModelResources holds the ids of the provider resources, and the
+// tags of the entities, that Juju knows about for a model.
+type KnownModelResources struct {
+	InstanceIds  []string
+	VolumeIds    []string
+	Machines     []string
+	Applications []string
+}
+
+// OrphanedModelResources returns the resources that Juju does not know
+// about. Instances and volumes are orphaned if their ids are unknown;
+// other resources are orphaned if they are owned by a machine or
+// application that no longer exists. The result is sorted by kind and
+// id.
+func OrphanedModelResources(resources []ModelResource, known KnownModelResources) []ModelResource {
+	instanceIds := set.NewStrings(known.InstanceIds...)
+	volumeIds := set.NewStrings(known.VolumeIds...)
+	owners := set.NewStrings(known.Machines...).Union(set.NewStrings(known.Applications...))
+
+	var orphans []ModelResource
+	for _, resource := range resources {
+		var orphaned bool
+		switch resource.Kind {
+		case InstanceResource:
+			orphaned = !instanceIds.Contains(resource.Id)
+		case VolumeResource:
+			orphaned = !volumeIds.Contains(resource.Id)
+		default:
+			orphaned = resource.Owner != "" && !owners.Contains(resource.Owner)
+		}
+		if orphaned {
+			orphans = append(orphans, resource)
+		}
+	}
+	sort.Slice(orphans, func(i, j int) bool {
+		if orphans[i].Kind != orphans[j].Kind {
+			return orphans[i].Kind < orphans[j].Kind
+		}
+		return orphans[i].Id < orphans[j].Id
+	})
+	return orphans
+}
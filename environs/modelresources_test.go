@@ -0,0 +1,51 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type modelResourcesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&modelResourcesSuite{})
+
+func (s *modelResourcesSuite) TestOrphanedModelResources(c *gc.C) {
+	resources := []environs.ModelResource{
+		{Kind: environs.InstanceResource, Id: "i-1"},
+		{Kind: environs.InstanceResource, Id: "i-2"},
+		{Kind: environs.VolumeResource, Id: "vol-2"},
+		{Kind: environs.VolumeResource, Id: "vol-1"},
+		{Kind: environs.SecurityGroupResource, Id: "sg-model"},
+		{Kind: environs.SecurityGroupResource, Id: "sg-0", Owner: "machine-0"},
+		{Kind: environs.SecurityGroupResource, Id: "sg-1", Owner: "machine-1"},
+		{Kind: environs.LoadBalancerResource, Id: "lb-wordpress", Owner: "application-wordpress"},
+		{Kind: environs.LoadBalancerResource, Id: "lb-mysql", Owner: "application-mysql"},
+	}
+	orphans := environs.OrphanedModelResources(resources, environs.KnownModelResources{
+		InstanceIds:  []string{"i-1"},
+		VolumeIds:    []string{"vol-1"},
+		Machines:     []string{"machine-0"},
+		Applications: []string{"application-wordpress"},
+	})
+	c.Assert(orphans, jc.DeepEquals, []environs.ModelResource{
+		{Kind: environs.InstanceResource, Id: "i-2"},
+		{Kind: environs.LoadBalancerResource, Id: "lb-mysql", Owner: "application-mysql"},
+		{Kind: environs.SecurityGroupResource, Id: "sg-1", Owner: "machine-1"},
+		{Kind: environs.VolumeResource, Id: "vol-2"},
+	})
+}
+
+func (s *modelResourcesSuite) TestOrphanedModelResourcesNone(c *gc.C) {
+	orphans := environs.OrphanedModelResources([]environs.ModelResource{
+		{Kind: environs.InstanceResource, Id: "i-1"},
+	}, environs.KnownModelResources{InstanceIds: []string{"i-1"}})
+	c.Assert(orphans, gc.HasLen, 0)
+}
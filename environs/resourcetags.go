@@ -0,0 +1,63 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs
+
+import (
+	"github.com/juju/juju/environs/context"
+)
+
+// TaggedResource is an instance or volume created for a model, and the
+// tags Juju expects it to have.
+type TaggedResource struct {
+	// ModelResource identifies the resource. Its Owner is the tag of
+	// the machine or volume the resource was created for.
+	ModelResource
+
+	// Tags holds the tags Juju expects the resource to have.
+	Tags map[string]string
+}
+
+// ResourceTagger is implemented by environs that can read and update
+// the tags of the instances and volumes they create for a model, so
+// that tags changed by users or left stale by model migration can be
+// corrected.
+type ResourceTagger interface {
+	// ResourceTags returns the tags of each of the resources, in the
+	// same order. The tags of resources that no longer exist are nil.
+	ResourceTags(ctx context.ProviderCallContext, resources []ModelResource) ([]map[string]string, error)
+
+	// ProviderResourceTags returns the tags, such as display names,
+	// that the provider itself sets on the resource, in addition to
+	// those Juju expects.
+	ProviderResourceTags(resource ModelResource) map[string]string
+
+	// TagResource sets the tags on the resource. Existing tags with the
+	// same keys are replaced, and others are left alone.
+	TagResource(ctx context.ProviderCallContext, resource ModelResource, tags map[string]string) error
+}
+
+// SupportsResourceTagger returns the environ's ResourceTagger
+// implementation, and whether it has one.
+func SupportsResourceTagger(env BootstrapEnviron) (ResourceTagger, bool) {
+	rt, ok := env.(ResourceTagger)
+	return rt, ok
+}
+
+// ResourceTagDrift returns the expected tags that are missing from the
+// actual tags, or have different values there, or nil if there are
+// none. Tags that are not expected are ignored, as users may add tags
+// of their own.
+func ResourceTagDrift(expected, actual map[string]string) map[string]string {
+	var drift map[string]string
+	for k, v := range expected {
+		if current, ok := actual[k]; ok && current == v {
+			continue
+		}
+		if drift == nil {
+			drift = make(map[string]string)
+		}
+		drift[k] = v
+	}
+	return drift
+}
@@ -0,0 +1,44 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package environs_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+)
+
+type resourceTagsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&resourceTagsSuite{})
+
+func (s *resourceTagsSuite) TestResourceTagDrift(c *gc.C) {
+	expected := map[string]string{
+		"juju-model-uuid":      "model-uuid",
+		"juju-controller-uuid": "controller-uuid",
+		"owner":                "finance",
+	}
+	drift := environs.ResourceTagDrift(expected, map[string]string{
+		"juju-model-uuid":      "model-uuid",
+		"juju-controller-uuid": "old-controller-uuid",
+		"cost-centre":          "42",
+	})
+	c.Assert(drift, jc.DeepEquals, map[string]string{
+		"juju-controller-uuid": "controller-uuid",
+		"owner":                "finance",
+	})
+}
+
+func (s *resourceTagsSuite) TestResourceTagDriftNone(c *gc.C) {
+	expected := map[string]string{"juju-model-uuid": "model-uuid"}
+	drift := environs.ResourceTagDrift(expected, map[string]string{
+		"juju-model-uuid": "model-uuid",
+		"cost-centre":     "42",
+	})
+	c.Assert(drift, gc.IsNil)
+}
@@ -40,6 +40,11 @@ const (
 	// the model and machine id corresponding to the
 	// provisioned machine instance.
 	JujuMachine = JujuTagPrefix + "machine-id"
+
+	// JujuApplication is the tag name used for identifying
+	// the application that an infrastructure resource, such
+	// as a load balancer, was created for.
+	JujuApplication = JujuTagPrefix + "application"
 )
 
 // ResourceTagger is an interface that can provide resource tags.
@@ -61,3 +61,14 @@ const MongoDbSSTXN = "mongodb-sstxn"
 // InstanceMutater tells juju to use the InstanceMutater watcher for managing profiles
 // on existing machines.
 const InstanceMutater = "instance-mutater"
+
+// GRPCAPI enables the experimental gRPC transport for the API, served
+// alongside the websocket API on the controller's API port.
+// This value is only checked using the controller config "features" attribute.
+const GRPCAPI = "grpc-api"
+
+// CachedStatus allows the full status of a model to be served for a
+// few seconds from the controller's in-memory model cache, rather than
+// being read from the database for every request.
+// This value is only checked using the controller config "features" attribute.
+const CachedStatus = "cached-status"
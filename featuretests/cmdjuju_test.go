@@ -112,6 +112,10 @@ func (s *cmdJujuSuite) TestApplicationUnset(c *gc.C) {
 func (s *cmdJujuSuite) TestApplicationGetIAASModel(c *gc.C) {
 	expected := `application: dummy-application
 application-config:
+  maintenance-window:
+    description: Overrides the model maintenance-window for this application
+    source: unset
+    type: string
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -190,6 +194,21 @@ application-config:
     source: default
     type: bool
     value: false
+  kubernetes-ingress-tls-secret:
+    description: the name of the secret holding the TLS certificate used by the ingress
+      resource
+    source: unset
+    type: string
+  kubernetes-pod-anti-affinity:
+    description: spread the application's pods across nodes or zones
+    source: unset
+    type: string
+  kubernetes-pod-anti-affinity-required:
+    default: false
+    description: whether pods must be spread, rather than spread where possible
+    source: default
+    type: bool
+    value: false
   kubernetes-service-annotations:
     description: a space separated set of annotations to add to the service
     source: unset
@@ -224,6 +243,10 @@ application-config:
     source: default
     type: string
     value: ClusterIP
+  maintenance-window:
+    description: Overrides the model maintenance-window for this application
+    source: unset
+    type: string
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -280,6 +303,10 @@ settings:
 func (s *cmdJujuSuite) TestApplicationGetWeirdYAML(c *gc.C) {
 	expected := `application: yaml-config
 application-config:
+  maintenance-window:
+    description: Overrides the model maintenance-window for this application
+    source: unset
+    type: string
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -0,0 +1,25 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/juju/juju/apiserver"
+	"github.com/juju/juju/apiserver/apischema"
+)
+
+// This generator writes the JSON schemas of all the API server's
+// facades to stdout, for use in generating clients in other languages.
+func main() {
+	schemas := apischema.Generate(apiserver.AllFacades())
+	data, err := json.MarshalIndent(schemas, "", "  ")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	fmt.Println(string(data))
+}
@@ -44,6 +44,10 @@ type NewAPIConnectionParams struct {
 	// will be scoped to the model with that UUID; otherwise it will be
 	// scoped to the controller.
 	ModelUUID string
+
+	// ReadOnly, if true, asks the controller for a read-only
+	// connection, served from secondary members of its replica set.
+	ReadOnly bool
 }
 
 // NewAPIConnection returns an api.Connection to the specified Juju controller,
@@ -82,6 +86,7 @@ func NewAPIConnection(args NewAPIConnectionParams) (_ api.Connection, err error)
 			ModelTag: apiInfo.ModelTag,
 			Addrs:    network.HostPortsToStrings(usableHostPorts(redirErr.Servers)),
 			CACert:   redirErr.CACert,
+			ReadOnly: apiInfo.ReadOnly,
 		}
 		st, err = args.OpenAPI(apiInfo, args.DialOpts)
 		if err != nil {
@@ -179,6 +184,7 @@ func connectionInfo(args NewAPIConnectionParams) (*api.Info, *jujuclient.Control
 		apiInfo.SkipLogin = true
 		return apiInfo, controller, nil
 	}
+	apiInfo.ReadOnly = args.ReadOnly
 	account := args.AccountDetails
 	if account.User != "" {
 		userTag := names.NewUserTag(account.User)
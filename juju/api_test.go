@@ -138,6 +138,26 @@ func (s *NewAPIClientSuite) TestUpdatesLastKnownAccess(c *gc.C) {
 	)
 }
 
+func (s *NewAPIClientSuite) TestReadOnly(c *gc.C) {
+	store := newClientStore(c, "noconfig")
+	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
+		c.Check(apiInfo.ReadOnly, jc.IsTrue)
+		return mockedAPIState(mockedHostPort | mockedModelTag), nil
+	}
+
+	accountDetails, err := store.AccountDetails("noconfig")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = juju.NewAPIConnection(juju.NewAPIConnectionParams{
+		Store:          store,
+		ControllerName: "noconfig",
+		DialOpts:       api.DefaultDialOpts(),
+		OpenAPI:        apiOpen,
+		AccountDetails: accountDetails,
+		ReadOnly:       true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *NewAPIClientSuite) TestUpdatesPublicDNSName(c *gc.C) {
 	apiOpen := func(apiInfo *api.Info, opts api.DialOpts) (api.Connection, error) {
 		conn := mockedAPIState(noFlags)
@@ -44,6 +44,16 @@ const (
 	// timestamps to be written in RFC3339 format.
 	JujuStatusIsoTimeEnvKey = "JUJU_STATUS_ISO_TIME"
 
+	// JujuAPIRetryAttemptsEnvKey is the env var holding the number of
+	// times the client makes a read-only API call that fails with a
+	// transient error, eg "5". A value of 1 disables retries.
+	JujuAPIRetryAttemptsEnvKey = "JUJU_API_RETRY_ATTEMPTS"
+
+	// JujuAPIRetryDelayEnvKey is the env var holding the delay before
+	// the client first retries a failed read-only API call, eg "2s".
+	// The delay doubles for each subsequent retry.
+	JujuAPIRetryDelayEnvKey = "JUJU_API_RETRY_DELAY"
+
 	// XDGDataHome is a path where data for the running user
 	// should be stored according to the xdg standard.
 	XDGDataHome = "XDG_DATA_HOME"
@@ -0,0 +1,450 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	utilstar "github.com/juju/utils/tar"
+	"gopkg.in/juju/charm.v6"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/resource"
+)
+
+// A model archive is a gzipped tar file holding everything needed to
+// recreate a model in another controller: the serialized model
+// description, and the charm and resource blobs used by the model.
+// Unlike a migration, nothing is handed over; the model in the source
+// controller is left as it is.
+const (
+	archiveVersion      = 1
+	archiveVersionFile  = "version"
+	archiveModelFile    = "model.yaml"
+	archiveCharmsDir    = "charms"
+	archiveResourcesDir = "resources"
+)
+
+// ModelArchiveConfig holds what WriteModelArchive needs to write an
+// archive of a model.
+type ModelArchiveConfig struct {
+	// Model is the serialized model description.
+	Model []byte
+
+	CharmDownloader    CharmDownloader
+	ResourceDownloader ResourceDownloader
+}
+
+// Validate makes sure that all the config values are set.
+func (c *ModelArchiveConfig) Validate() error {
+	if len(c.Model) == 0 {
+		return errors.NotValidf("missing Model")
+	}
+	if c.CharmDownloader == nil {
+		return errors.NotValidf("missing CharmDownloader")
+	}
+	if c.ResourceDownloader == nil {
+		return errors.NotValidf("missing ResourceDownloader")
+	}
+	return nil
+}
+
+// WriteModelArchive writes an archive of the model described in the
+// config to w, downloading the charms and resources it uses.
+func WriteModelArchive(w io.Writer, config ModelArchiveConfig) error {
+	if err := config.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	model, err := description.Deserialize(config.Model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resources, err := modelResources(model)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	gzw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gzw)
+	version := []byte(strconv.Itoa(archiveVersion))
+	if err := writeArchiveFile(tw, archiveVersionFile, int64(len(version)), bytes.NewReader(version)); err != nil {
+		return errors.Trace(err)
+	}
+	if err := writeArchiveFile(tw, archiveModelFile, int64(len(config.Model)), bytes.NewReader(config.Model)); err != nil {
+		return errors.Trace(err)
+	}
+	for _, charmURL := range modelCharms(model) {
+		logger.Debugf("archiving charm %s", charmURL)
+		curl, err := charm.ParseURL(charmURL)
+		if err != nil {
+			return errors.Annotate(err, "bad charm URL")
+		}
+		reader, err := config.CharmDownloader.OpenCharm(curl)
+		if err != nil {
+			return errors.Annotate(err, "cannot open charm")
+		}
+		err = writeArchiveBlob(tw, path.Join(archiveCharmsDir, url.PathEscape(charmURL)), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive charm %s", charmURL)
+		}
+	}
+	for _, res := range resources {
+		rev := res.ApplicationRevision
+		if rev.IsPlaceholder() {
+			continue
+		}
+		logger.Debugf("archiving application resource for %s: %s", rev.ApplicationID, rev.Name)
+		reader, err := config.ResourceDownloader.OpenResource(rev.ApplicationID, rev.Name)
+		if err != nil {
+			return errors.Annotate(err, "cannot open resource")
+		}
+		err = writeArchiveBlob(tw, path.Join(archiveResourcesDir, rev.ApplicationID, rev.Name), reader)
+		reader.Close()
+		if err != nil {
+			return errors.Annotatef(err, "cannot archive resource %s for %s", rev.Name, rev.ApplicationID)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(gzw.Close())
+}
+
+// writeArchiveBlob writes the content of r to the archive. The tar
+// header needs the size up front, so the content goes through a
+// temporary file first.
+func writeArchiveBlob(tw *tar.Writer, name string, r io.Reader) error {
+	content, cleanup, err := streamThroughTempFile(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer cleanup()
+	size, err := content.Seek(0, io.SeekEnd)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(writeArchiveFile(tw, name, size, content))
+}
+
+func writeArchiveFile(tw *tar.Writer, name string, size int64, r io.Reader) error {
+	hdr := &tar.Header{
+		Name:     name,
+		Mode:     0644,
+		Size:     size,
+		Typeflag: tar.TypeReg,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := io.Copy(tw, r)
+	return errors.Trace(err)
+}
+
+// ModelArchive is a model archive unpacked and ready to be imported.
+// It provides the charms and resources in the archive through the
+// interfaces UploadBinaries uses to download them.
+type ModelArchive struct {
+	dir       string
+	bytes     []byte
+	model     description.Model
+	resources []migration.SerializedModelResource
+}
+
+// ReadModelArchive unpacks the model archive read from r into a
+// temporary directory, which is removed when the archive is closed.
+func ReadModelArchive(r io.Reader) (_ *ModelArchive, err error) {
+	dir, err := ioutil.TempDir("", "juju-model-archive")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			os.RemoveAll(dir)
+		}
+	}()
+
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "while uncompressing model archive")
+	}
+	defer gzr.Close()
+	if err := utilstar.UntarFiles(gzr, dir); err != nil {
+		return nil, errors.Annotate(err, "while unpacking model archive")
+	}
+
+	version, err := ioutil.ReadFile(filepath.Join(dir, archiveVersionFile))
+	if err != nil {
+		return nil, errors.Annotate(err, "not a model archive")
+	}
+	if v, err := strconv.Atoi(strings.TrimSpace(string(version))); err != nil || v != archiveVersion {
+		return nil, errors.NotSupportedf("model archive version %q", version)
+	}
+	serialized, err := ioutil.ReadFile(filepath.Join(dir, archiveModelFile))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	model, err := description.Deserialize(serialized)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resources, err := modelResources(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelArchive{
+		dir:       dir,
+		bytes:     serialized,
+		model:     model,
+		resources: resources,
+	}, nil
+}
+
+// Close removes the unpacked archive.
+func (a *ModelArchive) Close() error {
+	return errors.Trace(os.RemoveAll(a.dir))
+}
+
+// Bytes returns the serialized model description.
+func (a *ModelArchive) Bytes() []byte {
+	return a.bytes
+}
+
+// Model returns the model description.
+func (a *ModelArchive) Model() description.Model {
+	return a.model
+}
+
+// NewModelCopy returns the description, serialized and not, of a copy
+// of the archived model that can be imported while the original model
+// is still running. The copy has the given UUID, and forgets the cloud
+// resources of the original: the instances of its machines, the
+// network devices and addresses of those instances, the provider IDs
+// of its volumes and filesystems, and the cloud containers and
+// services of its applications. The controller the copy is imported
+// into provisions its own resources instead of managing those of the
+// original model, which are tagged with the original's UUID.
+//
+// Subnets and spaces are kept as they are; they belong to the cloud
+// rather than to the model.
+func (a *ModelArchive) NewModelCopy(uuid string) ([]byte, description.Model, error) {
+	var doc map[interface{}]interface{}
+	if err := yaml.Unmarshal(a.bytes, &doc); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	config, ok := doc["config"].(map[interface{}]interface{})
+	if !ok {
+		return nil, nil, errors.NotValidf("model description without config")
+	}
+	config["uuid"] = uuid
+	delete(doc, "link-layer-devices")
+	delete(doc, "ip-addresses")
+
+	var forgetInstances func(machines []interface{})
+	forgetInstances = func(machines []interface{}) {
+		for _, machine := range mappings(machines) {
+			delete(machine, "instance")
+			containers, _ := machine["containers"].([]interface{})
+			forgetInstances(containers)
+		}
+	}
+	forgetInstances(collection(doc, "machines"))
+
+	for _, volume := range mappings(collection(doc, "volumes")) {
+		volume["provisioned"] = false
+		for _, key := range []string{"volume-id", "hardware-id", "wwn"} {
+			delete(volume, key)
+		}
+		for _, attachment := range mappings(collection(volume, "attachments")) {
+			attachment["provisioned"] = false
+			for _, key := range []string{"device-name", "device-link", "bus-address"} {
+				delete(attachment, key)
+			}
+		}
+	}
+	for _, filesystem := range mappings(collection(doc, "filesystems")) {
+		filesystem["provisioned"] = false
+		delete(filesystem, "filesystem-id")
+		for _, attachment := range mappings(collection(filesystem, "attachments")) {
+			attachment["provisioned"] = false
+			delete(attachment, "mount-point")
+		}
+	}
+	for _, application := range mappings(collection(doc, "applications")) {
+		delete(application, "cloud-service")
+		for _, unit := range mappings(collection(application, "units")) {
+			delete(unit, "cloud-container")
+		}
+	}
+
+	serialized, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	model, err := description.Deserialize(serialized)
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "copying model description")
+	}
+	return serialized, model, nil
+}
+
+// collection returns the entries of a versioned collection in a
+// serialized model description, which are held as
+// "<name>: {version: N, <name>: [...]}".
+func collection(doc map[interface{}]interface{}, name string) []interface{} {
+	outer, _ := doc[name].(map[interface{}]interface{})
+	entries, _ := outer[name].([]interface{})
+	return entries
+}
+
+// mappings returns the entries that are themselves mappings.
+func mappings(entries []interface{}) []map[interface{}]interface{} {
+	var result []map[interface{}]interface{}
+	for _, entry := range entries {
+		if m, ok := entry.(map[interface{}]interface{}); ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+// Charms returns the URLs of the charms used by the model.
+func (a *ModelArchive) Charms() []string {
+	return modelCharms(a.model)
+}
+
+// Resources returns the resources used by the model.
+func (a *ModelArchive) Resources() []migration.SerializedModelResource {
+	return a.resources
+}
+
+// OpenCharm is part of the CharmDownloader interface.
+func (a *ModelArchive) OpenCharm(curl *charm.URL) (io.ReadCloser, error) {
+	return a.open(archiveCharmsDir, url.PathEscape(curl.String()))
+}
+
+// OpenResource is part of the ResourceDownloader interface.
+func (a *ModelArchive) OpenResource(application, name string) (io.ReadCloser, error) {
+	return a.open(archiveResourcesDir, application, name)
+}
+
+// OpenURI is part of the ToolsDownloader interface. Agent binaries
+// are not model specific, so they aren't kept in model archives.
+func (a *ModelArchive) OpenURI(uri string, query url.Values) (io.ReadCloser, error) {
+	return nil, errors.NotSupportedf("agent binaries in model archives")
+}
+
+func (a *ModelArchive) open(elem ...string) (io.ReadCloser, error) {
+	for _, e := range elem {
+		if e == "" || e == "." || e == ".." || strings.ContainsAny(e, `/\`) {
+			return nil, errors.NotValidf("archive path %q", path.Join(elem...))
+		}
+	}
+	f, err := os.Open(filepath.Join(append([]string{a.dir}, elem...)...))
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("%q in model archive", path.Join(elem...))
+	}
+	return f, errors.Trace(err)
+}
+
+// modelCharms returns the URLs of the charms used by the model.
+func modelCharms(model description.Model) []string {
+	result := set.NewStrings()
+	for _, application := range model.Applications() {
+		result.Add(application.CharmURL())
+	}
+	return result.SortedValues()
+}
+
+// modelResources returns the resources used by the model, along with
+// the revisions used by each unit.
+func modelResources(model description.Model) ([]migration.SerializedModelResource, error) {
+	var out []migration.SerializedModelResource
+	for _, app := range model.Applications() {
+		for _, res := range app.Resources() {
+			appRev, err := descriptionResource(app.Name(), res.Name(), res.ApplicationRevision())
+			if err != nil {
+				return nil, errors.Annotate(err, "application revision")
+			}
+			csRev, err := descriptionResource(app.Name(), res.Name(), res.CharmStoreRevision())
+			if err != nil {
+				return nil, errors.Annotate(err, "charmstore revision")
+			}
+			unitRevs := make(map[string]resource.Resource)
+			for _, unit := range app.Units() {
+				for _, unitRes := range unit.Resources() {
+					if unitRes.Name() != res.Name() {
+						continue
+					}
+					unitRev, err := descriptionResource(app.Name(), res.Name(), unitRes.Revision())
+					if err != nil {
+						return nil, errors.Annotate(err, "unit revision")
+					}
+					unitRevs[unit.Name()] = unitRev
+				}
+			}
+			out = append(out, migration.SerializedModelResource{
+				ApplicationRevision: appRev,
+				CharmStoreRevision:  csRev,
+				UnitRevisions:       unitRevs,
+			})
+		}
+	}
+	return out, nil
+}
+
+func descriptionResource(app, name string, rev description.ResourceRevision) (resource.Resource, error) {
+	if rev == nil {
+		return resource.Resource{}, nil
+	}
+	type_, err := charmresource.ParseType(rev.Type())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	origin, err := charmresource.ParseOrigin(rev.Origin())
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	var fp charmresource.Fingerprint
+	if rev.FingerprintHex() != "" {
+		if fp, err = charmresource.ParseFingerprint(rev.FingerprintHex()); err != nil {
+			return resource.Resource{}, errors.Annotate(err, "invalid fingerprint")
+		}
+	}
+	return resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name:        name,
+				Type:        type_,
+				Path:        rev.Path(),
+				Description: rev.Description(),
+			},
+			Origin:      origin,
+			Revision:    rev.Revision(),
+			Size:        rev.Size(),
+			Fingerprint: fp,
+		},
+		ApplicationID: app,
+		Username:      rev.Username(),
+		Timestamp:     rev.Timestamp(),
+	}, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/migration"
+	"github.com/juju/juju/provider/dummy"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ArchiveSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&ArchiveSuite{})
+
+func (s *ArchiveSuite) SetUpTest(c *gc.C) {
+	s.InitialConfig = coretesting.CustomModelConfig(c, dummy.SampleConfig())
+	s.StateSuite.SetUpTest(c)
+}
+
+func (s *ArchiveSuite) TestModelArchiveConfigValidate(c *gc.C) {
+	downloader := &fakeDownloader{}
+	config := migration.ModelArchiveConfig{
+		Model:              []byte("model"),
+		CharmDownloader:    downloader,
+		ResourceDownloader: downloader,
+	}
+	c.Assert(config.Validate(), jc.ErrorIsNil)
+
+	check := func(modify func(*migration.ModelArchiveConfig), missing string) {
+		config := config
+		modify(&config)
+		err := config.Validate()
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, "missing "+missing+" not valid")
+	}
+	check(func(c *migration.ModelArchiveConfig) { c.Model = nil }, "Model")
+	check(func(c *migration.ModelArchiveConfig) { c.CharmDownloader = nil }, "CharmDownloader")
+	check(func(c *migration.ModelArchiveConfig) { c.ResourceDownloader = nil }, "ResourceDownloader")
+}
+
+func (s *ArchiveSuite) TestWriteReadModelArchive(c *gc.C) {
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "mysql"}),
+	})
+	serialized, err := migration.ExportModel(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+
+	downloader := &fakeDownloader{}
+	var buf bytes.Buffer
+	err = migration.WriteModelArchive(&buf, migration.ModelArchiveConfig{
+		Model:              serialized,
+		CharmDownloader:    downloader,
+		ResourceDownloader: downloader,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(downloader.charms, gc.HasLen, 1)
+
+	archive, err := migration.ReadModelArchive(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+
+	c.Check(archive.Bytes(), jc.DeepEquals, serialized)
+	c.Check(archive.Model().Tag(), gc.Equals, s.Model.ModelTag())
+	c.Check(archive.Charms(), jc.DeepEquals, downloader.charms)
+	c.Check(archive.Resources(), gc.HasLen, 0)
+
+	reader, err := archive.OpenCharm(charm.MustParseURL(downloader.charms[0]))
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	content, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, downloader.charms[0]+" content")
+
+	_, err = archive.OpenCharm(charm.MustParseURL("cs:trusty/missing-1"))
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	_, err = archive.OpenURI("/tools/2.6.0-bionic-amd64", nil)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ArchiveSuite) TestNewModelCopy(c *gc.C) {
+	s.Factory.MakeMachine(c, &factory.MachineParams{InstanceId: "inst-0"})
+	serialized, err := migration.ExportModel(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+
+	downloader := &fakeDownloader{}
+	var buf bytes.Buffer
+	err = migration.WriteModelArchive(&buf, migration.ModelArchiveConfig{
+		Model:              serialized,
+		CharmDownloader:    downloader,
+		ResourceDownloader: downloader,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	archive, err := migration.ReadModelArchive(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	defer archive.Close()
+	c.Assert(archive.Model().Machines()[0].Instance(), gc.NotNil)
+
+	const uuid = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+	copied, model, err := archive.NewModelCopy(uuid)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(model.Tag().Id(), gc.Equals, uuid)
+	c.Check(model.Config()["name"], gc.Equals, archive.Model().Config()["name"])
+	c.Assert(model.Machines(), gc.HasLen, 1)
+	c.Check(model.Machines()[0].Instance(), gc.IsNil)
+
+	reread, err := description.Deserialize(copied)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(reread.Tag().Id(), gc.Equals, uuid)
+
+	// The archive itself is unchanged.
+	c.Check(archive.Model().Tag(), gc.Equals, s.Model.ModelTag())
+}
+
+func (s *ArchiveSuite) TestReadModelArchiveNotAnArchive(c *gc.C) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	_, err := gzw.Write(make([]byte, 1024))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gzw.Close(), jc.ErrorIsNil)
+
+	_, err = migration.ReadModelArchive(&buf)
+	c.Assert(err, gc.ErrorMatches, "not a model archive: .*")
+}
@@ -4,6 +4,7 @@
 package migration
 
 import (
+	"bytes"
 	"io"
 	"io/ioutil"
 	"net/url"
@@ -49,6 +50,33 @@ func ExportModel(st StateExporter) ([]byte, error) {
 	return bytes, nil
 }
 
+// DropPinnedAgentVersion removes the annotation carrying the pinned
+// agent version from the serialized model, for target controllers that
+// would reject it. The model arrives at such a controller unpinned.
+func DropPinnedAgentVersion(modelBytes []byte) ([]byte, error) {
+	if !bytes.Contains(modelBytes, []byte(migration.PinnedAgentVersionAnnotation)) {
+		return modelBytes, nil
+	}
+	model, err := description.Deserialize(modelBytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pinned, isPinned := model.Annotations()[migration.PinnedAgentVersionAnnotation]
+	if !isPinned {
+		return modelBytes, nil
+	}
+	logger.Warningf("target controller can't import pinned agent versions; unpinning model from %s", pinned)
+	annotations := make(map[string]string)
+	for key, value := range model.Annotations() {
+		if key != migration.PinnedAgentVersionAnnotation {
+			annotations[key] = value
+		}
+	}
+	model.SetAnnotations(annotations)
+	modelBytes, err = description.Serialize(model)
+	return modelBytes, errors.Trace(err)
+}
+
 // StateImporter describes the method needed to import a model
 // into the database.
 type StateImporter interface {
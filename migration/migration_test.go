@@ -384,6 +384,33 @@ func (s *ExportSuite) TestExportModel(c *gc.C) {
 	c.Assert(modelDesc.Validate(), jc.ErrorIsNil)
 }
 
+func (s *ExportSuite) TestDropPinnedAgentVersion(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetAnnotations(model, map[string]string{"owner": "bob"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.SetPinnedAgentVersion(version.MustParse("2.6.8"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	modelBytes, err := migration.ExportModel(st)
+	c.Assert(err, jc.ErrorIsNil)
+	modelBytes, err = migration.DropPinnedAgentVersion(modelBytes)
+	c.Assert(err, jc.ErrorIsNil)
+	modelDesc, err := description.Deserialize(modelBytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(modelDesc.Annotations(), jc.DeepEquals, map[string]string{"owner": "bob"})
+}
+
+func (s *ExportSuite) TestDropPinnedAgentVersionNotPinned(c *gc.C) {
+	modelBytes, err := migration.ExportModel(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	dropped, err := migration.DropPinnedAgentVersion(modelBytes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dropped, gc.DeepEquals, modelBytes)
+}
+
 func fakeGetClaimer(string) (leadership.Claimer, error) {
 	return &fakeClaimer{}, nil
 }
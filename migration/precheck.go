@@ -5,6 +5,7 @@ package migration
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/version"
@@ -12,9 +13,11 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/autoscale"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/tools"
@@ -32,6 +35,7 @@ type PrecheckBackend interface {
 	AllMachines() ([]PrecheckMachine, error)
 	AllApplications() ([]PrecheckApplication, error)
 	AllRelations() ([]PrecheckRelation, error)
+	ColocationRules() ([]state.ColocationRule, error)
 	ControllerBackend() (PrecheckBackend, error)
 	CloudCredential(tag names.CloudCredentialTag) (state.Credential, error)
 	ListPendingResources(string) ([]resource.Resource, error)
@@ -75,6 +79,10 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	IsLoadBalanced() bool
+	LoadBalancerAddresses() []network.Address
+	IsPaused() bool
+	AutoscalePolicy() (autoscale.Policy, error)
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -88,6 +96,7 @@ type PrecheckUnit interface {
 	Status() (status.StatusInfo, error)
 	AgentPresence() (bool, error)
 	ShouldBeAssigned() bool
+	Paused() bool
 }
 
 // PrecheckRelation describes the state interface for relations needed
@@ -96,6 +105,7 @@ type PrecheckRelation interface {
 	String() string
 	IsCrossModel() (bool, error)
 	Endpoints() []state.Endpoint
+	DrainTimeout() time.Duration
 	Unit(PrecheckUnit) (PrecheckRelationUnit, error)
 }
 
@@ -138,6 +148,10 @@ func SourcePrecheck(
 		return errors.Trace(err)
 	}
 
+	if err := ctx.checkColocationRules(); err != nil {
+		return errors.Trace(err)
+	}
+
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
 		return errors.Annotate(err, "checking cleanups")
 	} else if cleanupNeeded {
@@ -347,6 +361,26 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 		if app.Life() != state.Alive {
 			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
 		}
+		// The model description has no load balancers, so the
+		// target would not know about the application's load
+		// balancer, and nothing would remove it.
+		if app.IsLoadBalanced() {
+			return nil, errors.Errorf("application %s is exposed through a load balancer", app.Name())
+		}
+		if len(app.LoadBalancerAddresses()) > 0 {
+			return nil, errors.Errorf("load balancer for application %s is still being removed", app.Name())
+		}
+		// Pausing isn't migrated, so the units would run hooks
+		// again on the target while the operator expects them not to.
+		if app.IsPaused() {
+			return nil, errors.Errorf("application %s is paused", app.Name())
+		}
+		// Nor are autoscale policies and their history.
+		if _, err := app.AutoscalePolicy(); err == nil {
+			return nil, errors.Errorf("application %s is autoscaled", app.Name())
+		} else if !errors.IsNotFound(err) {
+			return nil, errors.Annotatef(err, "retrieving autoscale policy for %s", app.Name())
+		}
 		units, err := app.AllUnits()
 		if err != nil {
 			return nil, errors.Annotatef(err, "retrieving units for %s", app.Name())
@@ -371,6 +405,9 @@ func (ctx *precheckContext) checkUnits(app PrecheckApplication, units []Precheck
 		if unit.Life() != state.Alive {
 			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
 		}
+		if unit.Paused() {
+			return errors.Errorf("unit %s is paused", unit.Name())
+		}
 
 		if err := ctx.checkUnitAgentStatus(unit); err != nil {
 			return errors.Trace(err)
@@ -431,12 +468,32 @@ func newStatusError(format, id string, s status.Status) error {
 	return errors.New(msg)
 }
 
+// checkColocationRules refuses to migrate models with co-location
+// rules, which are not part of the model description; the target would
+// place units without them.
+func (ctx *precheckContext) checkColocationRules() error {
+	rules, err := ctx.backend.ColocationRules()
+	if err != nil {
+		return errors.Annotate(err, "retrieving co-location rules")
+	}
+	if len(rules) > 0 {
+		apps := rules[0].Applications
+		return errors.Errorf("applications %s and %s have a co-location rule", apps[0], apps[1])
+	}
+	return nil
+}
+
 func (ctx *precheckContext) checkRelations(appUnits map[string][]PrecheckUnit) error {
 	relations, err := ctx.backend.AllRelations()
 	if err != nil {
 		return errors.Annotate(err, "retrieving model relations")
 	}
 	for _, rel := range relations {
+		// Drain timeouts are not part of the model description; the
+		// target would remove departing units from scope at once.
+		if rel.DrainTimeout() > 0 {
+			return errors.Errorf("relation %s has a drain timeout", rel)
+		}
 		// We expect a relationScope and settings for each of the
 		// units of the specified application, unless it is a
 		// remote application.
@@ -4,6 +4,8 @@
 package migration_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
@@ -11,10 +13,12 @@ import (
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/core/autoscale"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/migration"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
@@ -86,6 +90,15 @@ func (*SourcePrecheckSuite) TestPendingResources(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (*SourcePrecheckSuite) TestColocationRules(c *gc.C) {
+	backend := newHappyBackend()
+	backend.colocationRules = []state.ColocationRule{{
+		Applications: [2]string{"bar", "foo"},
+	}}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "applications bar and foo have a co-location rule")
+}
+
 func (*SourcePrecheckSuite) TestImportingModel(c *gc.C) {
 	backend := newFakeBackend()
 	backend.model.migrationMode = state.MigrationModeImporting
@@ -176,6 +189,32 @@ func (s *SourcePrecheckSuite) TestDyingApplication(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "application foo is dying")
 }
 
+func (s *SourcePrecheckSuite) TestLoadBalancedApplication(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:         "foo",
+				loadBalanced: true,
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo is exposed through a load balancer")
+}
+
+func (s *SourcePrecheckSuite) TestLoadBalancerBeingRemoved(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:        "foo",
+				lbAddresses: network.NewAddresses("10.0.0.1"),
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "load balancer for application foo is still being removed")
+}
+
 func (s *SourcePrecheckSuite) TestWithPendingMinUnits(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -240,6 +279,49 @@ func (s *SourcePrecheckSuite) TestDeadUnit(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "unit foo/0 is dead")
 }
 
+func (s *SourcePrecheckSuite) TestPausedApplication(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:   "foo",
+				paused: true,
+				units:  []migration.PrecheckUnit{&fakeUnit{name: "foo/0"}},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo is paused")
+}
+
+func (s *SourcePrecheckSuite) TestAutoscaledApplication(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:       "foo",
+				autoscaled: true,
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "application foo is autoscaled")
+}
+
+func (s *SourcePrecheckSuite) TestPausedUnit(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name: "foo",
+				units: []migration.PrecheckUnit{
+					&fakeUnit{name: "foo/0"},
+					&fakeUnit{name: "foo/1", paused: true},
+				},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err.Error(), gc.Equals, "unit foo/1 is paused")
+}
+
 func (s *SourcePrecheckSuite) TestUnitExecuting(c *gc.C) {
 	backend := &fakeBackend{
 		apps: []migration.PrecheckApplication{
@@ -363,6 +445,25 @@ func (s *SourcePrecheckSuite) TestUnitsAllInScope(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *SourcePrecheckSuite) TestRelationDrainTimeout(c *gc.C) {
+	backend := newHappyBackend()
+	backend.relations = []migration.PrecheckRelation{&fakeRelation{
+		key: "foo:db bar:db",
+		endpoints: []state.Endpoint{
+			{ApplicationName: "foo"},
+			{ApplicationName: "bar"},
+		},
+		relUnits: map[string]*fakeRelationUnit{
+			"foo/0": {valid: true, inScope: true},
+			"bar/0": {valid: true, inScope: true},
+			"bar/1": {valid: true, inScope: true},
+		},
+		drainTimeout: time.Minute,
+	}}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "relation foo:db bar:db has a drain timeout")
+}
+
 func (s *SourcePrecheckSuite) TestSubordinatesNotYetInScope(c *gc.C) {
 	backend := newHappyBackend()
 	backend.relations = []migration.PrecheckRelation{&fakeRelation{
@@ -778,6 +879,8 @@ type fakeBackend struct {
 	relations  []migration.PrecheckRelation
 	allRelsErr error
 
+	colocationRules []state.ColocationRule
+
 	credentials    state.Credential
 	credentialsErr error
 
@@ -827,6 +930,10 @@ func (b *fakeBackend) AllRelations() ([]migration.PrecheckRelation, error) {
 	return b.relations, b.allRelsErr
 }
 
+func (b *fakeBackend) ColocationRules() ([]state.ColocationRule, error) {
+	return b.colocationRules, nil
+}
+
 func (b *fakeBackend) ListPendingResources(app string) ([]resource.Resource, error) {
 	return b.pendingResources, b.pendingResourcesErr
 }
@@ -960,11 +1067,15 @@ func (m *fakeMachine) ShouldRebootOrShutdown() (state.RebootAction, error) {
 }
 
 type fakeApp struct {
-	name     string
-	life     state.Life
-	charmURL string
-	units    []migration.PrecheckUnit
-	minunits int
+	name         string
+	life         state.Life
+	charmURL     string
+	units        []migration.PrecheckUnit
+	minunits     int
+	loadBalanced bool
+	lbAddresses  []network.Address
+	paused       bool
+	autoscaled   bool
 }
 
 func (a *fakeApp) Name() string {
@@ -991,6 +1102,25 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) IsLoadBalanced() bool {
+	return a.loadBalanced
+}
+
+func (a *fakeApp) LoadBalancerAddresses() []network.Address {
+	return a.lbAddresses
+}
+
+func (a *fakeApp) IsPaused() bool {
+	return a.paused
+}
+
+func (a *fakeApp) AutoscalePolicy() (autoscale.Policy, error) {
+	if !a.autoscaled {
+		return autoscale.Policy{}, errors.NotFoundf("autoscale policy for application %q", a.name)
+	}
+	return autoscale.Policy{MinUnits: 1, MaxUnits: 3}, nil
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
@@ -999,6 +1129,7 @@ type fakeUnit struct {
 	charmURL    string
 	agentStatus status.Status
 	lost        bool
+	paused      bool
 }
 
 func (u *fakeUnit) Name() string {
@@ -1053,6 +1184,10 @@ func (u *fakeUnit) AgentPresence() (bool, error) {
 	return !u.lost, nil
 }
 
+func (u *fakeUnit) Paused() bool {
+	return u.paused
+}
+
 type fakeRelation struct {
 	key           string
 	crossModel    bool
@@ -1060,6 +1195,7 @@ type fakeRelation struct {
 	endpoints     []state.Endpoint
 	relUnits      map[string]*fakeRelationUnit
 	unitErr       error
+	drainTimeout  time.Duration
 }
 
 func (r *fakeRelation) String() string {
@@ -1074,6 +1210,10 @@ func (r *fakeRelation) Endpoints() []state.Endpoint {
 	return r.endpoints
 }
 
+func (r *fakeRelation) DrainTimeout() time.Duration {
+	return r.drainTimeout
+}
+
 func (r *fakeRelation) Unit(u migration.PrecheckUnit) (migration.PrecheckRelationUnit, error) {
 	return r.relUnits[u.Name()], r.unitErr
 }
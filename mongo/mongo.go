@@ -453,6 +453,12 @@ type EnsureServerParams struct {
 	// MemoryProfile determines which value is going to be used by
 	// the cache and future memory tweaks.
 	MemoryProfile MemoryProfile
+
+	// Version, if set, is the version of mongod to run instead of the
+	// best one installed. It is used to go back to the mongod that
+	// wrote the data files when migrating them to a new storage
+	// engine fails.
+	Version Version
 }
 
 // EnsureServer ensures that the MongoDB server is installed,
@@ -517,8 +523,16 @@ func ensureServer(args EnsureServerParams, mongoKernelTweaks map[string]string)
 		// (LP #1441904)
 		logger.Errorf("cannot install/upgrade mongod (will proceed anyway): %v", err)
 	}
-	finder := NewMongodFinder()
-	mongoPath, mongodVersion, err := finder.FindBest()
+	var mongoPath string
+	var mongodVersion Version
+	var err error
+	if args.Version != zeroVersion {
+		mongodVersion = args.Version
+		mongoPath, err = Path(args.Version)
+	} else {
+		finder := NewMongodFinder()
+		mongoPath, mongodVersion, err = finder.FindBest()
+	}
 	if err != nil {
 		return zeroVersion, errors.Trace(err)
 	}
@@ -0,0 +1,139 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+)
+
+// A controller's database is migrated to a new storage engine one
+// replica set member at a time: the member's data files are moved
+// aside, and mongod is restarted with the new storage engine so that
+// the member resyncs its data from its peers. The figures below are
+// deliberately conservative, and are only used to give the operator
+// an idea of how long the migration will take.
+const (
+	// resyncBytesPerSecond is the rate at which a member is expected
+	// to copy data and build indexes during an initial sync.
+	resyncBytesPerSecond = 20 * humanize.MiByte
+
+	// memberRestartTime is the time taken to stop mongod, move the
+	// data files aside and start it again.
+	memberRestartTime = time.Minute
+
+	// electionTime is the time the replica set is expected to be
+	// without a primary after the primary steps down.
+	electionTime = 15 * time.Second
+)
+
+// ValidateStorageEngineMigration returns an error if the database
+// can't be migrated from the current to the target version.
+func ValidateStorageEngineMigration(current, target Version) error {
+	if target.StorageEngine != WiredTiger {
+		return errors.NotSupportedf("migrating to storage engine %q", target.StorageEngine)
+	}
+	if target.NewerThan(Mongo32wt) < 0 {
+		return errors.NotSupportedf("storage engine %q with mongo %s", target.StorageEngine, target)
+	}
+	if target.NewerThan(current) < 0 {
+		return errors.NotValidf("migrating from mongo %s to older mongo %s", current, target)
+	}
+	if current.StorageEngine == target.StorageEngine && current.NewerThan(target) == 0 {
+		return errors.AlreadyExistsf("mongo %s", target)
+	}
+	return nil
+}
+
+// StorageEngineMigrationEstimate holds the estimated time taken to
+// migrate a controller's database to a new storage engine.
+type StorageEngineMigrationEstimate struct {
+	// Duration is the time taken to migrate all the members of the
+	// replica set.
+	Duration time.Duration
+
+	// Downtime is the time during which the database is expected to
+	// be unavailable for writes.
+	Downtime time.Duration
+}
+
+// EstimateStorageEngineMigration estimates the time taken to migrate
+// a replica set with the given number of members, holding dataSize
+// bytes of data and indexes.
+func EstimateStorageEngineMigration(dataSize int64, members int) StorageEngineMigrationEstimate {
+	resync := time.Duration(dataSize/resyncBytesPerSecond) * time.Second
+	return StorageEngineMigrationEstimate{
+		Duration: time.Duration(members) * (memberRestartTime + resync),
+		// Members resync one at a time, and at most one election
+		// happens for each member, when it steps down as primary.
+		Downtime: time.Duration(members) * electionTime,
+	}
+}
+
+// DataDirStorageEngine returns the storage engine used by the data
+// files in the database directory. It returns an error satisfying
+// errors.IsNotFound if the directory holds no data files.
+func DataDirStorageEngine(dbDir string) (StorageEngine, error) {
+	exists := func(name string) (bool, error) {
+		_, err := os.Stat(filepath.Join(dbDir, name))
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return err == nil, errors.Trace(err)
+	}
+	// WiredTiger keeps its metadata in a file called WiredTiger, and
+	// MMAPv1 keeps a namespace file for each database.
+	for _, probe := range []struct {
+		name   string
+		engine StorageEngine
+	}{
+		{"WiredTiger", WiredTiger},
+		{"local.ns", MMAPV1},
+	} {
+		found, err := exists(probe.name)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		if found {
+			return probe.engine, nil
+		}
+	}
+	return "", errors.NotFoundf("data files in %q", dbDir)
+}
+
+// MoveDataDirAside renames the database directory so that mongod
+// starts with an empty one, and returns the new name of the directory.
+// The old data files are kept until the operator removes them, in case
+// the migration has to be undone.
+func MoveDataDirAside(dbDir string, engine StorageEngine, now time.Time) (string, error) {
+	aside := fmt.Sprintf("%s.%s.%s", dbDir, engine, now.UTC().Format("20060102-150405"))
+	if err := os.Rename(dbDir, aside); err != nil {
+		return "", errors.Annotate(err, "cannot move database directory aside")
+	}
+	if err := os.MkdirAll(dbDir, 0700); err != nil {
+		return "", errors.Annotate(err, "cannot create database directory")
+	}
+	return aside, nil
+}
+
+// RestoreDataDir undoes MoveDataDirAside: it removes the data files
+// written since the database directory was moved aside, and puts the
+// old directory back in its place.
+func RestoreDataDir(dbDir, aside string) error {
+	if _, err := os.Stat(aside); err != nil {
+		return errors.Annotate(err, "cannot find old database directory")
+	}
+	if err := os.RemoveAll(dbDir); err != nil {
+		return errors.Annotate(err, "cannot remove database directory")
+	}
+	if err := os.Rename(aside, dbDir); err != nil {
+		return errors.Annotate(err, "cannot restore database directory")
+	}
+	return nil
+}
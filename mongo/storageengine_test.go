@@ -0,0 +1,151 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongo_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/mongo"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type storageEngineSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&storageEngineSuite{})
+
+func (s *storageEngineSuite) TestValidateStorageEngineMigration(c *gc.C) {
+	mongo34mmap := mongo.Version{Major: 3, Minor: 4, StorageEngine: mongo.MMAPV1}
+	for i, test := range []struct {
+		current mongo.Version
+		target  mongo.Version
+		check   func(error) bool
+	}{{
+		current: mongo.Mongo24,
+		target:  mongo.Mongo36wt,
+	}, {
+		current: mongo34mmap,
+		target:  mongo.Mongo36wt,
+	}, {
+		current: mongo.Mongo32wt,
+		target:  mongo.Mongo40wt,
+	}, {
+		current: mongo.Mongo24,
+		target:  mongo.Mongo26,
+		check:   errors.IsNotSupported,
+	}, {
+		current: mongo.Mongo24,
+		target:  mongo.Version{Major: 3, Minor: 0, StorageEngine: mongo.WiredTiger},
+		check:   errors.IsNotSupported,
+	}, {
+		current: mongo.Mongo40wt,
+		target:  mongo.Mongo36wt,
+		check:   errors.IsNotValid,
+	}, {
+		current: mongo.Mongo36wt,
+		target:  mongo.Mongo36wt,
+		check:   errors.IsAlreadyExists,
+	}} {
+		c.Logf("test %d: %s -> %s", i, test.current, test.target)
+		err := mongo.ValidateStorageEngineMigration(test.current, test.target)
+		if test.check == nil {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, jc.Satisfies, test.check)
+		}
+	}
+}
+
+func (s *storageEngineSuite) TestEstimateStorageEngineMigration(c *gc.C) {
+	estimate := mongo.EstimateStorageEngineMigration(1200*humanize.MiByte, 3)
+	c.Assert(estimate, jc.DeepEquals, mongo.StorageEngineMigrationEstimate{
+		Duration: 3 * (time.Minute + time.Minute),
+		Downtime: 3 * 15 * time.Second,
+	})
+}
+
+func (s *storageEngineSuite) TestDataDirStorageEngine(c *gc.C) {
+	dir := c.MkDir()
+	_, err := mongo.DataDirStorageEngine(dir)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "local.ns"), nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	engine, err := mongo.DataDirStorageEngine(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(engine, gc.Equals, mongo.MMAPV1)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "WiredTiger"), nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	engine, err = mongo.DataDirStorageEngine(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(engine, gc.Equals, mongo.WiredTiger)
+}
+
+func (s *storageEngineSuite) TestMoveDataDirAside(c *gc.C) {
+	dbDir := filepath.Join(c.MkDir(), "db")
+	err := os.Mkdir(dbDir, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dbDir, "local.ns"), []byte("data"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Date(2019, 5, 6, 9, 35, 12, 0, time.UTC)
+	aside, err := mongo.MoveDataDirAside(dbDir, mongo.MMAPV1, now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(aside, gc.Equals, dbDir+".mmapv1.20190506-093512")
+
+	content, err := ioutil.ReadFile(filepath.Join(aside, "local.ns"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "data")
+	entries, err := ioutil.ReadDir(dbDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 0)
+}
+
+func (s *storageEngineSuite) TestRestoreDataDir(c *gc.C) {
+	dbDir := filepath.Join(c.MkDir(), "db")
+	err := os.Mkdir(dbDir, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dbDir, "local.ns"), []byte("data"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	aside, err := mongo.MoveDataDirAside(dbDir, mongo.MMAPV1, time.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dbDir, "WiredTiger"), nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mongo.RestoreDataDir(dbDir, aside)
+	c.Assert(err, jc.ErrorIsNil)
+	engine, err := mongo.DataDirStorageEngine(dbDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(engine, gc.Equals, mongo.MMAPV1)
+	content, err := ioutil.ReadFile(filepath.Join(dbDir, "local.ns"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(content), gc.Equals, "data")
+	_, err = os.Stat(aside)
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *storageEngineSuite) TestRestoreDataDirMissing(c *gc.C) {
+	dbDir := filepath.Join(c.MkDir(), "db")
+	err := os.Mkdir(dbDir, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dbDir, "WiredTiger"), nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = mongo.RestoreDataDir(dbDir, dbDir+".mmapv1.20190506-093512")
+	c.Assert(err, gc.ErrorMatches, "cannot find old database directory: .*")
+	// The new data files are left alone.
+	engine, err := mongo.DataDirStorageEngine(dbDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(engine, gc.Equals, mongo.WiredTiger)
+}
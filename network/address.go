@@ -337,20 +337,120 @@ func SelectControllerAddress(addresses []Address, machineLocal bool) (Address, b
 	return internalAddress, ok
 }
 
+// AddressSelectionPolicy determines which address family is preferred
+// when selecting between addresses of equally suitable scope.
+type AddressSelectionPolicy int
+
+const (
+	// PreferIPv4 prefers IPv4 addresses over IPv6 addresses and
+	// hostnames. This is the policy used by the package functions.
+	PreferIPv4 AddressSelectionPolicy = iota
+
+	// PreferIPv6 prefers IPv6 addresses over IPv4 addresses and
+	// hostnames, for IPv6-only and dual-stack deployments.
+	PreferIPv6
+)
+
+// String is part of the fmt.Stringer interface.
+func (p AddressSelectionPolicy) String() string {
+	if p == PreferIPv6 {
+		return "ipv6"
+	}
+	return "ipv4"
+}
+
+// ParseAddressSelectionPolicy returns the policy preferring the named
+// address family, "ipv4" or "ipv6". The empty string means "ipv4".
+func ParseAddressSelectionPolicy(family string) (AddressSelectionPolicy, error) {
+	switch family {
+	case "", "ipv4":
+		return PreferIPv4, nil
+	case "ipv6":
+		return PreferIPv6, nil
+	}
+	return PreferIPv4, errors.NotValidf("address family %q", family)
+}
+
+// matcher returns the scope match function adjusted for the policy.
+// The match functions rank IPv4 addresses first within each scope;
+// when preferring IPv6 the ranking within each scope is swapped.
+func (p AddressSelectionPolicy) matcher(matchFunc scopeMatchFunc) scopeMatchFunc {
+	if p != PreferIPv6 {
+		return matchFunc
+	}
+	return func(addr Address) scopeMatch {
+		match := matchFunc(addr)
+		preferred := addr.Type == IPv6Address
+		switch match {
+		case exactScopeIPv4, exactScope:
+			if preferred {
+				return exactScopeIPv4
+			}
+			return exactScope
+		case firstFallbackScopeIPv4, firstFallbackScope:
+			if preferred {
+				return firstFallbackScopeIPv4
+			}
+			return firstFallbackScope
+		case secondFallbackScopeIPv4, secondFallbackScope:
+			if preferred {
+				return secondFallbackScopeIPv4
+			}
+			return secondFallbackScope
+		}
+		return match
+	}
+}
+
 // SelectPublicAddress picks one address from a slice that would be
-// appropriate to display as a publicly accessible endpoint. If there
-// are no suitable addresses, then ok is false (and an empty address is
-// returned). If a suitable address is then ok is true.
-func SelectPublicAddress(addresses []Address) (Address, bool) {
+// appropriate to display as a publicly accessible endpoint, preferring
+// addresses of the policy's address family.
+func (p AddressSelectionPolicy) SelectPublicAddress(addresses []Address) (Address, bool) {
 	index := bestAddressIndex(len(addresses), func(i int) Address {
 		return addresses[i]
-	}, publicMatch)
+	}, p.matcher(publicMatch))
 	if index < 0 {
 		return Address{}, false
 	}
 	return addresses[index], true
 }
 
+// SelectInternalAddress picks one address from a slice that can be
+// used as an endpoint for juju internal communication, preferring
+// addresses of the policy's address family.
+func (p AddressSelectionPolicy) SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
+	index := bestAddressIndex(len(addresses), func(i int) Address {
+		return addresses[i]
+	}, p.matcher(internalAddressMatcher(machineLocal)))
+	if index < 0 {
+		return Address{}, false
+	}
+	return addresses[index], true
+}
+
+// PrioritizeInternalHostPorts orders the provided addresses by best
+// match for use as an endpoint for juju internal communication,
+// preferring addresses of the policy's address family.
+func (p AddressSelectionPolicy) PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
+	indexes := prioritizedAddressIndexes(len(hps), func(i int) Address {
+		return hps[i].Address
+	}, p.matcher(internalAddressMatcher(machineLocal)))
+
+	out := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		out = append(out, hps[index].NetAddr())
+	}
+	return out
+}
+
+// SelectPublicAddress picks one address from a slice that would be
+// appropriate to display as a publicly accessible endpoint. If there
+// are no suitable addresses, then ok is false (and an empty address is
+// returned). If a suitable address is then ok is true.
+func SelectPublicAddress(addresses []Address) (Address, bool) {
+	return PreferIPv4.SelectPublicAddress(addresses)
+}
+
 // SelectPublicHostPort picks one HostPort from a slice that would be
 // appropriate to display as a publicly accessible endpoint. If there
 // are no suitable candidates, the empty string is returned.
@@ -369,13 +469,7 @@ func SelectPublicHostPort(hps []HostPort) string {
 // no suitable addresses, then ok is false (and an empty address is
 // returned). If a suitable address was found then ok is true.
 func SelectInternalAddress(addresses []Address, machineLocal bool) (Address, bool) {
-	index := bestAddressIndex(len(addresses), func(i int) Address {
-		return addresses[i]
-	}, internalAddressMatcher(machineLocal))
-	if index < 0 {
-		return Address{}, false
-	}
-	return addresses[index], true
+	return PreferIPv4.SelectInternalAddress(addresses, machineLocal)
 }
 
 // SelectInternalAddresses picks the best addresses from a slice that can be
@@ -431,15 +525,7 @@ func SelectInternalHostPorts(hps []HostPort, machineLocal bool) []string {
 // returns them in NetAddr form. If there are no suitable addresses
 // then an empty slice is returned.
 func PrioritizeInternalHostPorts(hps []HostPort, machineLocal bool) []string {
-	indexes := prioritizedAddressIndexes(len(hps), func(i int) Address {
-		return hps[i].Address
-	}, internalAddressMatcher(machineLocal))
-
-	out := make([]string, 0, len(indexes))
-	for _, index := range indexes {
-		out = append(out, hps[index].NetAddr())
-	}
-	return out
+	return PreferIPv4.PrioritizeInternalHostPorts(hps, machineLocal)
 }
 
 func publicMatch(addr Address) scopeMatch {
@@ -695,6 +695,52 @@ func (s *AddressSuite) TestPrioritizeInternalHostPorts(c *gc.C) {
 	}
 }
 
+func (s *AddressSuite) TestParseAddressSelectionPolicy(c *gc.C) {
+	for _, family := range []string{"", "ipv4"} {
+		policy, err := network.ParseAddressSelectionPolicy(family)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(policy, gc.Equals, network.PreferIPv4)
+	}
+	policy, err := network.ParseAddressSelectionPolicy("ipv6")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, gc.Equals, network.PreferIPv6)
+	c.Assert(policy.String(), gc.Equals, "ipv6")
+
+	_, err = network.ParseAddressSelectionPolicy("appletalk")
+	c.Assert(err, gc.ErrorMatches, `address family "appletalk" not valid`)
+}
+
+func (s *AddressSuite) TestSelectAddressPreferringIPv6(c *gc.C) {
+	addresses := []network.Address{
+		network.NewScopedAddress("8.8.8.8", network.ScopePublic),
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+		network.NewScopedAddress("fc00::1", network.ScopeCloudLocal),
+		network.NewScopedAddress("2001:db8::1", network.ScopePublic),
+	}
+	public, ok := network.PreferIPv6.SelectPublicAddress(addresses)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(public.Value, gc.Equals, "2001:db8::1")
+	internal, ok := network.PreferIPv6.SelectInternalAddress(addresses, false)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(internal.Value, gc.Equals, "fc00::1")
+
+	// Scope still takes precedence over the address family.
+	public, ok = network.PreferIPv6.SelectPublicAddress(addresses[:3])
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(public.Value, gc.Equals, "8.8.8.8")
+}
+
+func (s *AddressSuite) TestPrioritizeInternalHostPortsPreferringIPv6(c *gc.C) {
+	hps := []network.HostPort{
+		{network.NewScopedAddress("2001:db8::1", network.ScopePublic), 123},
+		{network.NewScopedAddress("fc00::1", network.ScopeCloudLocal), 123},
+		{network.NewScopedAddress("8.8.8.8", network.ScopePublic), 123},
+		{network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal), 4444},
+	}
+	prioritized := network.PreferIPv6.PrioritizeInternalHostPorts(hps, false)
+	c.Assert(prioritized, gc.DeepEquals, []string{"[fc00::1]:123", "10.0.0.1:4444", "[2001:db8::1]:123", "8.8.8.8:123"})
+}
+
 var stringTests = []struct {
 	addr network.Address
 	str  string
@@ -379,6 +379,7 @@ func (env *azureEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (
 	validator := constraints.NewValidator()
 	validator.RegisterUnsupported([]string{
 		constraints.CpuPower,
+		constraints.ImageID,
 		constraints.Tags,
 		constraints.VirtType,
 	})
@@ -112,6 +112,7 @@ type environSuite struct {
 	storageAccount     *storage.Account
 	storageAccountKeys *storage.AccountListKeysResult
 	ubuntuServerSKUs   []compute.VirtualMachineImageResource
+	centOSSKUs         []compute.VirtualMachineImageResource
 	commonDeployment   *resources.DeploymentExtended
 	deployment         *resources.Deployment
 	sshPublicKeys      []compute.SSHPublicKey
@@ -366,6 +367,9 @@ func (s *environSuite) startInstanceSenders(bootstrap bool) azuretesting.Senders
 	if s.ubuntuServerSKUs != nil {
 		senders = append(senders, s.makeSender(".*/Canonical/.*/UbuntuServer/skus", s.ubuntuServerSKUs))
 	}
+	if s.centOSSKUs != nil {
+		senders = append(senders, s.makeSender(".*/OpenLogic/.*/CentOS/skus", s.centOSSKUs))
+	}
 	if !bootstrap {
 		// When starting an instance, we must wait for the common
 		// deployment to complete.
@@ -691,8 +695,14 @@ func (s *environSuite) testStartInstanceWindows(
 }
 
 func (s *environSuite) TestStartInstanceCentOS(c *gc.C) {
-	// Starting a CentOS VM, we should not expect an image query.
+	// Starting a CentOS VM, we should expect a query for the
+	// CentOS SKUs only.
 	s.PatchValue(&s.ubuntuServerSKUs, nil)
+	s.PatchValue(&s.centOSSKUs, []compute.VirtualMachineImageResource{
+		{Name: to.StringPtr("6.10")},
+		{Name: to.StringPtr("7.2")},
+		{Name: to.StringPtr("7.3")},
+	})
 
 	env := s.openEnviron(c)
 	s.sender = s.startInstanceSenders(false)
@@ -1121,9 +1131,9 @@ func (s *environSuite) assertStartInstanceRequests(
 
 	// Validate HTTP request bodies.
 	var startInstanceRequests startInstanceRequests
-	if args.vmExtension != nil {
-		// It must be Windows or CentOS, so
-		// there should be no image query.
+	if args.vmExtension != nil && args.osProfile == &windowsOsProfile {
+		// It must be Windows, so there
+		// should be no image query.
 		c.Assert(requests, gc.HasLen, numExpectedStartInstanceRequests-1)
 		c.Assert(requests[nexti()].Method, gc.Equals, "GET") // vmSizes
 		startInstanceRequests.vmSizes = requests[0]
@@ -115,6 +115,13 @@ func (prov *azureEnvironProvider) Version() int {
 	return currentProviderVersion
 }
 
+// Capabilities is part of the CapabilityReporter interface.
+func (*azureEnvironProvider) Capabilities() []environs.Capability {
+	return []environs.Capability{
+		environs.CapabilityBlockStorage,
+	}
+}
+
 // Open is part of the EnvironProvider interface.
 func (prov *azureEnvironProvider) Open(args environs.OpenParams) (environs.Environ, error) {
 	logger.Debugf("opening model %q", args.Config.Name())
@@ -136,12 +136,7 @@ func findInstanceSpec(
 	imageStream string,
 ) (*instances.InstanceSpec, error) {
 
-	if !constraintHasArch(constraint, arch.AMD64) {
-		// Azure only supports AMD64.
-		return nil, errors.NotFoundf("%s in arch constraints", arch.AMD64)
-	}
-
-	image, err := imageutils.SeriesImage(ctx, constraint.Series, imageStream, constraint.Region, client)
+	image, err := imageutils.SeriesImage(ctx, constraint.Series, imageStream, constraint.Region, constraint.Arches, client)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -155,15 +150,6 @@ func findInstanceSpec(
 	return instances.FindInstanceSpec(images, constraint, instanceTypes)
 }
 
-func constraintHasArch(constraint *instances.InstanceConstraint, arch string) bool {
-	for _, constraintArch := range constraint.Arches {
-		if constraintArch == arch {
-			return true
-		}
-	}
-	return false
-}
-
 // If you specify no constraints at all, you're going to get the smallest
 // instance type available. In practice that one's a bit small, so unless
 // the constraints are deliberately set lower, this gives you a set of
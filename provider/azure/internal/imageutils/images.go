@@ -42,21 +42,31 @@ const (
 	dailyStream = "daily"
 )
 
+// marketplaceArches holds the architectures of the images published
+// to the Azure marketplace by the publishers we use.
+var marketplaceArches = []string{arch.AMD64}
+
 // SeriesImage gets an instances.Image for the specified series, image stream
-// and location. The resulting Image's ID is in the URN format expected by
-// Azure Resource Manager.
+// and location, built for one of the given architectures. If no
+// architectures are given, any will do. The resulting Image's ID is in the
+// URN format expected by Azure Resource Manager.
 //
 // For Ubuntu, we query the SKUs to determine the most recent point release
 // for a series.
 func SeriesImage(
 	ctx context.ProviderCallContext,
 	series, stream, location string,
+	arches []string,
 	client compute.VirtualMachineImagesClient,
 ) (*instances.Image, error) {
 	seriesOS, err := jujuseries.GetOSFromSeries(series)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	imageArch, err := selectArch(series, arches)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	var publisher, offering, sku string
 	switch seriesOS {
@@ -86,6 +96,14 @@ func SeriesImage(
 			publisher = windowsServerPublisher
 			offering = windowsServerOffering
 			sku = "2012-R2-Datacenter"
+		case "win2016":
+			publisher = windowsServerPublisher
+			offering = windowsServerOffering
+			sku = "2016-Datacenter"
+		case "win2019":
+			publisher = windowsServerPublisher
+			offering = windowsServerOffering
+			sku = "2019-Datacenter"
 		default:
 			return nil, errors.NotSupportedf("deploying %s", series)
 		}
@@ -93,25 +111,38 @@ func SeriesImage(
 	case os.CentOS:
 		publisher = centOSPublisher
 		offering = centOSOffering
-		switch series {
-		case "centos7":
-			sku = "7.3"
-		default:
-			return nil, errors.NotSupportedf("deploying %s", series)
+		sku, err = centOSSKU(ctx, series, location, client)
+		if err != nil {
+			return nil, errors.Annotatef(err, "selecting SKU for %s", series)
 		}
 
 	default:
-		// TODO(axw) CentOS
 		return nil, errors.NotSupportedf("deploying %s", seriesOS)
 	}
 
 	return &instances.Image{
 		Id:       fmt.Sprintf("%s:%s:%s:latest", publisher, offering, sku),
-		Arch:     arch.AMD64,
+		Arch:     imageArch,
 		VirtType: "Hyper-V",
 	}, nil
 }
 
+// selectArch returns the first of the given architectures that images
+// are published for.
+func selectArch(series string, arches []string) (string, error) {
+	if len(arches) == 0 {
+		return marketplaceArches[0], nil
+	}
+	for _, a := range arches {
+		for _, published := range marketplaceArches {
+			if a == published {
+				return a, nil
+			}
+		}
+	}
+	return "", errors.NotSupportedf("deploying %s on %s", series, strings.Join(arches, ", "))
+}
+
 // ubuntuSKU returns the best SKU for the Canonical:UbuntuServer offering,
 // matching the given series.
 func ubuntuSKU(ctx context.ProviderCallContext, series, stream, location string, client compute.VirtualMachineImagesClient) (string, error) {
@@ -163,6 +194,49 @@ func ubuntuSKU(ctx context.ProviderCallContext, series, stream, location string,
 	return skuNamesByVersion[bestVersion], nil
 }
 
+// centOSSKU returns the most recent point release SKU for the
+// OpenLogic:CentOS offering, matching the major version of the given
+// series.
+func centOSSKU(ctx context.ProviderCallContext, series, location string, client compute.VirtualMachineImagesClient) (string, error) {
+	if !strings.HasPrefix(series, "centos") {
+		return "", errors.NotSupportedf("deploying %s", series)
+	}
+	major, err := strconv.Atoi(strings.TrimPrefix(series, "centos"))
+	if err != nil {
+		return "", errors.NotSupportedf("deploying %s", series)
+	}
+	logger.Debugf("listing SKUs: Location=%s, Publisher=%s, Offer=%s", location, centOSPublisher, centOSOffering)
+	sdkCtx := stdcontext.Background()
+	result, err := client.ListSkus(sdkCtx, location, centOSPublisher, centOSOffering)
+	if err != nil {
+		return "", errorutils.HandleCredentialError(errors.Annotate(err, "listing CentOS SKUs"), ctx)
+	}
+	var bestSKU string
+	bestMinor := -1
+	if result.Value != nil {
+		for _, result := range *result.Value {
+			skuName := to.String(result.Name)
+			parts := strings.SplitN(skuName, ".", 2)
+			if len(parts) != 2 || parts[0] != strconv.Itoa(major) {
+				logger.Debugf("ignoring SKU %q (does not match series %q)", skuName, series)
+				continue
+			}
+			minor, err := strconv.Atoi(parts[1])
+			if err != nil {
+				logger.Debugf("ignoring SKU %q (not a point release)", skuName)
+				continue
+			}
+			if minor > bestMinor {
+				bestSKU, bestMinor = skuName, minor
+			}
+		}
+	}
+	if bestSKU == "" {
+		return "", errors.NotFoundf("CentOS SKUs")
+	}
+	return bestSKU, nil
+}
+
 type ubuntuVersion struct {
 	Year  int
 	Month int
@@ -40,7 +40,7 @@ func (s *imageutilsSuite) TestSeriesImage(c *gc.C) {
 	s.mockSender.AppendResponse(mocks.NewResponseWithContent(
 		`[{"name": "14.04.3"}, {"name": "14.04.1-LTS"}, {"name": "12.04.5"}]`,
 	))
-	image, err := imageutils.SeriesImage(s.callCtx, "trusty", "released", "westus", s.client)
+	image, err := imageutils.SeriesImage(s.callCtx, "trusty", "released", "westus", nil, s.client)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(image, gc.NotNil)
 	c.Assert(image, jc.DeepEquals, &instances.Image{
@@ -50,11 +50,23 @@ func (s *imageutilsSuite) TestSeriesImage(c *gc.C) {
 	})
 }
 
+func (s *imageutilsSuite) TestSeriesImageArches(c *gc.C) {
+	s.mockSender.AppendResponse(mocks.NewResponseWithContent(`[{"name": "14.04.3"}]`))
+	image, err := imageutils.SeriesImage(s.callCtx, "trusty", "released", "westus", []string{arch.ARM64, arch.AMD64}, s.client)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(image.Arch, gc.Equals, arch.AMD64)
+}
+
+func (s *imageutilsSuite) TestSeriesImageArchNotSupported(c *gc.C) {
+	_, err := imageutils.SeriesImage(s.callCtx, "trusty", "released", "westus", []string{arch.ARM64}, s.client)
+	c.Assert(err, gc.ErrorMatches, "deploying trusty on arm64 not supported")
+}
+
 func (s *imageutilsSuite) TestSeriesImageInvalidSKU(c *gc.C) {
 	s.mockSender.AppendResponse(mocks.NewResponseWithContent(
 		`[{"name": "12.04.invalid"}, {"name": "12.04.5-LTS"}]`,
 	))
-	image, err := imageutils.SeriesImage(s.callCtx, "precise", "released", "westus", s.client)
+	image, err := imageutils.SeriesImage(s.callCtx, "precise", "released", "westus", nil, s.client)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(image, gc.NotNil)
 	c.Assert(image, jc.DeepEquals, &instances.Image{
@@ -67,16 +79,27 @@ func (s *imageutilsSuite) TestSeriesImageInvalidSKU(c *gc.C) {
 func (s *imageutilsSuite) TestSeriesImageWindows(c *gc.C) {
 	s.assertImageId(c, "win2012r2", "daily", "MicrosoftWindowsServer:WindowsServer:2012-R2-Datacenter:latest")
 	s.assertImageId(c, "win2012", "daily", "MicrosoftWindowsServer:WindowsServer:2012-Datacenter:latest")
+	s.assertImageId(c, "win2016", "daily", "MicrosoftWindowsServer:WindowsServer:2016-Datacenter:latest")
+	s.assertImageId(c, "win2019", "daily", "MicrosoftWindowsServer:WindowsServer:2019-Datacenter:latest")
 	s.assertImageId(c, "win81", "daily", "MicrosoftVisualStudio:Windows:8.1-Enterprise-N:latest")
 	s.assertImageId(c, "win10", "daily", "MicrosoftVisualStudio:Windows:10-Enterprise:latest")
 }
 
 func (s *imageutilsSuite) TestSeriesImageCentOS(c *gc.C) {
-	s.assertImageId(c, "centos7", "released", "OpenLogic:CentOS:7.3:latest")
+	s.mockSender.AppendResponse(mocks.NewResponseWithContent(
+		`[{"name": "6.10"}, {"name": "7.3"}, {"name": "7.10"}, {"name": "7-CI"}, {"name": "7.6"}]`,
+	))
+	s.assertImageId(c, "centos7", "released", "OpenLogic:CentOS:7.10:latest")
+}
+
+func (s *imageutilsSuite) TestSeriesImageCentOSNotFound(c *gc.C) {
+	s.mockSender.AppendResponse(mocks.NewResponseWithContent(`[{"name": "6.10"}]`))
+	_, err := imageutils.SeriesImage(s.callCtx, "centos7", "released", "westus", nil, s.client)
+	c.Assert(err, gc.ErrorMatches, "selecting SKU for centos7: CentOS SKUs not found")
 }
 
 func (s *imageutilsSuite) TestSeriesImageGenericLinux(c *gc.C) {
-	_, err := imageutils.SeriesImage(s.callCtx, "genericlinux", "released", "westus", s.client)
+	_, err := imageutils.SeriesImage(s.callCtx, "genericlinux", "released", "westus", nil, s.client)
 	c.Assert(err, gc.ErrorMatches, "deploying GenericLinux not supported")
 }
 
@@ -89,14 +112,14 @@ func (s *imageutilsSuite) TestSeriesImageStream(c *gc.C) {
 
 func (s *imageutilsSuite) TestSeriesImageNotFound(c *gc.C) {
 	s.mockSender.AppendResponse(mocks.NewResponseWithContent(`[]`))
-	image, err := imageutils.SeriesImage(s.callCtx, "trusty", "released", "westus", s.client)
+	image, err := imageutils.SeriesImage(s.callCtx, "trusty", "released", "westus", nil, s.client)
 	c.Assert(err, gc.ErrorMatches, "selecting SKU for trusty: Ubuntu SKUs not found")
 	c.Assert(image, gc.IsNil)
 }
 
 func (s *imageutilsSuite) TestSeriesImageStreamNotFound(c *gc.C) {
 	s.mockSender.AppendResponse(mocks.NewResponseWithContent(`[{"name": "14.04-beta1"}]`))
-	_, err := imageutils.SeriesImage(s.callCtx, "trusty", "whatever", "westus", s.client)
+	_, err := imageutils.SeriesImage(s.callCtx, "trusty", "whatever", "westus", nil, s.client)
 	c.Assert(err, gc.ErrorMatches, "selecting SKU for trusty: Ubuntu SKUs for whatever stream not found")
 }
 
@@ -108,7 +131,7 @@ func (s *imageutilsSuite) TestSeriesImageStreamThrewCredentialError(c *gc.C) {
 		return nil
 	}
 
-	_, err := imageutils.SeriesImage(s.callCtx, "trusty", "whatever", "westus", s.client)
+	_, err := imageutils.SeriesImage(s.callCtx, "trusty", "whatever", "westus", nil, s.client)
 	c.Assert(err.Error(), jc.Contains, "StatusCode=401")
 	c.Assert(called, jc.IsTrue)
 }
@@ -121,13 +144,13 @@ func (s *imageutilsSuite) TestSeriesImageStreamThrewNonCredentialError(c *gc.C)
 		return nil
 	}
 
-	_, err := imageutils.SeriesImage(s.callCtx, "trusty", "whatever", "westus", s.client)
+	_, err := imageutils.SeriesImage(s.callCtx, "trusty", "whatever", "westus", nil, s.client)
 	c.Assert(err.Error(), jc.Contains, "StatusCode=308")
 	c.Assert(called, jc.IsFalse)
 }
 
 func (s *imageutilsSuite) assertImageId(c *gc.C, series, stream, id string) {
-	image, err := imageutils.SeriesImage(s.callCtx, series, stream, "westus", s.client)
+	image, err := imageutils.SeriesImage(s.callCtx, series, stream, "westus", nil, s.client)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(image.Id, gc.Equals, id)
 }
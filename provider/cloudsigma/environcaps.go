@@ -10,6 +10,7 @@ import (
 
 var unsupportedConstraints = []string{
 	constraints.Container,
+	constraints.ImageID,
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
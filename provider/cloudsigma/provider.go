@@ -63,6 +63,12 @@ func (environProvider) Version() int {
 	return 0
 }
 
+// Capabilities is part of the CapabilityReporter interface. The
+// provider implements none of the optional features.
+func (environProvider) Capabilities() []environs.Capability {
+	return nil
+}
+
 // Open opens the environment and returns it.
 // The configuration must have come from a previously
 // prepared environment.
@@ -28,6 +28,17 @@ func Destroy(env environs.Environ, ctx context.ProviderCallContext) error {
 	return nil
 }
 
+// DestroyExceptVolumes is a common implementation of the
+// DestroyExceptVolumes method defined on environs.VolumePreservingDestroyer.
+// It destroys the environ's instances, and leaves its storage alone.
+func DestroyExceptVolumes(env environs.Environ, ctx context.ProviderCallContext) error {
+	logger.Infof("destroying model %q except for its volumes", env.Config().Name())
+	if err := destroyInstances(env, ctx); err != nil {
+		return errors.Annotate(err, "destroying instances")
+	}
+	return nil
+}
+
 func destroyInstances(env environs.Environ, ctx context.ProviderCallContext) error {
 	logger.Infof("destroying instances")
 	instances, err := env.AllInstances(ctx)
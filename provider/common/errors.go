@@ -9,6 +9,7 @@ import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/context"
 )
 
@@ -33,6 +34,33 @@ func (zoneIndependentError) AvailabilityZoneIndependent() bool {
 	return true
 }
 
+// ProvisioningError wraps the given error such that it satisfies
+// environs.ProvisioningError with the given kind and code.
+func ProvisioningError(err error, kind environs.ProvisioningErrorKind, code string) error {
+	if err == nil {
+		return nil
+	}
+	wrapped := errors.Wrap(err, provisioningError{err, kind, code})
+	wrapped.(*errors.Err).SetLocation(1)
+	return wrapped
+}
+
+type provisioningError struct {
+	error
+	kind environs.ProvisioningErrorKind
+	code string
+}
+
+// ProvisioningErrorKind is part of the environs.ProvisioningError interface.
+func (e provisioningError) ProvisioningErrorKind() environs.ProvisioningErrorKind {
+	return e.kind
+}
+
+// ProvisioningErrorCode is part of the environs.ProvisioningError interface.
+func (e provisioningError) ProvisioningErrorCode() string {
+	return e.code
+}
+
 // credentialNotValid represents an error when a provider credential is not valid.
 // Realistically, this is not a transient error. Without a valid credential we
 // cannot do much on the provider. This is fatal.
@@ -40,6 +68,17 @@ type credentialNotValid struct {
 	error
 }
 
+// ProvisioningErrorKind is part of the environs.ProvisioningError
+// interface. Provisioning cannot succeed until the credential is updated.
+func (*credentialNotValid) ProvisioningErrorKind() environs.ProvisioningErrorKind {
+	return environs.ProvisioningErrorNeedsUserAction
+}
+
+// ProvisioningErrorCode is part of the environs.ProvisioningError interface.
+func (*credentialNotValid) ProvisioningErrorCode() string {
+	return "credential-not-valid"
+}
+
 // CredentialNotValid returns an error which wraps err and satisfies
 // IsCredentialNotValid().
 func CredentialNotValid(err error) error {
@@ -34,6 +34,28 @@ github.com/juju/juju/provider/common/errors_test.go:.*: bar
 github.com/juju/juju/provider/common/errors_test.go:.*: bar: foo`[1:])
 }
 
+func (*ErrorsSuite) TestWrapProvisioningError(c *gc.C) {
+	err1 := errors.New("foo")
+	err2 := errors.Annotate(err1, "bar")
+	wrapped := common.ProvisioningError(err2, environs.ProvisioningErrorNeedsUserAction, "quota-exceeded")
+	c.Assert(wrapped, gc.ErrorMatches, "bar: foo")
+
+	kind, code := environs.ClassifyProvisioningError(errors.Annotate(wrapped, "starting instance"))
+	c.Assert(kind, gc.Equals, environs.ProvisioningErrorNeedsUserAction)
+	c.Assert(code, gc.Equals, "quota-exceeded")
+
+	kind, code = environs.ClassifyProvisioningError(err2)
+	c.Assert(kind, gc.Equals, environs.ProvisioningErrorRetryable)
+	c.Assert(code, gc.Equals, "")
+}
+
+func (*ErrorsSuite) TestInvalidCredentialClassified(c *gc.C) {
+	err := common.CredentialNotValid(errors.New("foo"))
+	kind, code := environs.ClassifyProvisioningError(err)
+	c.Assert(kind, gc.Equals, environs.ProvisioningErrorNeedsUserAction)
+	c.Assert(code, gc.Equals, "credential-not-valid")
+}
+
 func (s *ErrorsSuite) TestInvalidCredentialWrapped(c *gc.C) {
 	err1 := errors.New("foo")
 	err2 := errors.Annotate(err1, "bar")
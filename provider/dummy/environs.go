@@ -32,6 +32,7 @@ import (
 	"time"
 
 	"github.com/juju/clock"
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/jsonschema"
 	"github.com/juju/loggo"
@@ -238,6 +239,7 @@ type environProvider struct {
 	newStatePolicy         state.NewPolicyFunc
 	supportsSpaces         bool
 	supportsSpaceDiscovery bool
+	network                *Network
 	apiPort                int
 	controllerState        *environState
 	state                  map[string]*environState
@@ -259,6 +261,8 @@ type environState struct {
 	maxId          int // maximum instance id allocated so far.
 	maxAddr        int // maximum allocated address last byte
 	insts          map[instance.Id]*dummyInstance
+	maxVolumeId    int // maximum volume id allocated so far.
+	volumes        map[string]*dummyVolume
 	globalRules    network.IngressRuleSlice
 	bootstrapped   bool
 	mux            *apiserverhttp.Mux
@@ -289,6 +293,7 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.Networking = (*environ)(nil)
+var _ environs.InstanceBatchBroker = (*environ)(nil)
 
 // discardOperations discards all Operations written to it.
 var discardOperations = make(chan Operation)
@@ -326,6 +331,7 @@ func Reset(c *gc.C) {
 	dummy.newStatePolicy = stateenvirons.GetNewPolicyFunc()
 	dummy.supportsSpaces = true
 	dummy.supportsSpaceDiscovery = false
+	dummy.network = nil
 	dummy.mu.Unlock()
 
 	// NOTE(axw) we must destroy the old states without holding
@@ -487,6 +493,7 @@ func newState(name string, ops chan<- Operation, newStatePolicy state.NewPolicyF
 		ops:            ops,
 		newStatePolicy: newStatePolicy,
 		insts:          make(map[instance.Id]*dummyInstance),
+		volumes:        make(map[string]*dummyVolume),
 		creator:        string(buf),
 	}
 	return s
@@ -682,11 +689,18 @@ func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, erro
 		return nil, err
 	}
 	env := &environ{
-		ProviderRegistry: StorageProviders(),
-		name:             ecfg.Name(),
-		modelUUID:        args.Config.UUID(),
-		cloud:            args.Cloud,
-		ecfgUnlocked:     ecfg,
+		name:         ecfg.Name(),
+		modelUUID:    args.Config.UUID(),
+		cloud:        args.Cloud,
+		ecfgUnlocked: ecfg,
+	}
+	env.ProviderRegistry = storage.ChainedProviderRegistry{
+		StorageProviders(),
+		storage.StaticProviderRegistry{
+			Providers: map[storage.ProviderType]storage.Provider{
+				VolumeProviderType: volumeProvider{env},
+			},
+		},
 	}
 	if err := env.checkBroken("Open"); err != nil {
 		return nil, err
@@ -1135,6 +1149,7 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	if err != nil {
 		return nil, err
 	}
+	simulated := simulatedNetwork()
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
 
@@ -1179,16 +1194,25 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	if state.ParentId(machineId) == "" {
 		// Assume that the provided Availability Zone won't fail,
 		// though one is required.
-		var zone string
-		if args.Placement != "" {
-			split := strings.Split(args.Placement, "=")
-			if len(split) == 2 && split[0] == "zone" {
-				zone = split[1]
-			}
-		}
+		zone := placementZone(args.Placement)
 		if zone == "" && args.AvailabilityZone != "" {
 			zone = args.AvailabilityZone
 		}
+		if simulated != nil {
+			var subnets []network.SubnetInfo
+			zone, subnets, err = simulated.placeInstance(zone, args.Constraints.IncludeSpaces(), estate.insts)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			i.zone = zone
+			i.interfaces, err = instanceInterfaces(estate.maxId, subnets)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, nic := range i.interfaces {
+				i.addresses = append(i.addresses, nic.Address)
+			}
+		}
 
 		// We will just assume the instance hardware characteristics exactly matches
 		// the supplied constraints (if specified).
@@ -1222,6 +1246,13 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	// Simulate subnetsToZones gets populated when spaces given in constraints.
 	spaces := args.Constraints.IncludeSpaces()
 	var subnetsToZones map[network.Id][]string
+	if simulated != nil && len(spaces) > 0 {
+		subnetsToZones = make(map[network.Id][]string)
+		for _, nic := range i.interfaces {
+			subnetsToZones[nic.ProviderSubnetId] = []string{i.zone}
+		}
+		spaces = nil
+	}
 	for isp := range spaces {
 		// Simulate 2 subnets per space.
 		if subnetsToZones == nil {
@@ -1285,6 +1316,16 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	}, nil
 }
 
+// StartInstances is specified in the InstanceBatchBroker interface.
+// The dummy provider starts each instance in turn.
+func (e *environ) StartInstances(ctx context.ProviderCallContext, args []environs.StartInstanceParams) []environs.BatchStartInstanceResult {
+	results := make([]environs.BatchStartInstanceResult, len(args))
+	for i, arg := range args {
+		results[i].Result, results[i].Error = e.StartInstance(ctx, arg)
+	}
+	return results
+}
+
 func (e *environ) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
 	defer delay()
 	if err := e.checkBroken("StopInstance"); err != nil {
@@ -1298,6 +1339,10 @@ func (e *environ) StopInstances(ctx context.ProviderCallContext, ids ...instance
 	defer estate.mu.Unlock()
 	for _, id := range ids {
 		delete(estate.insts, id)
+		// Volumes are detached from the instances they are attached to.
+		for _, v := range estate.volumes {
+			delete(v.attachments, id)
+		}
 	}
 	estate.ops <- OpStopInstances{
 		Env: e.name,
@@ -1370,6 +1415,9 @@ func (env *environ) Spaces(ctx context.ProviderCallContext) ([]network.SpaceInfo
 	if err := env.checkBroken("Spaces"); err != nil {
 		return []network.SpaceInfo{}, err
 	}
+	if n := simulatedNetwork(); n != nil {
+		return append([]network.SpaceInfo{}, n.Spaces...), nil
+	}
 	return []network.SpaceInfo{{
 		Name:       "foo",
 		ProviderId: network.Id("0"),
@@ -1410,9 +1458,24 @@ func (env *environ) NetworkInterfaces(ctx context.ProviderCallContext, instId in
 	if err != nil {
 		return nil, err
 	}
+	n := simulatedNetwork()
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
 
+	if n != nil {
+		inst, ok := estate.insts[instId]
+		if !ok {
+			return nil, errors.NotFoundf("instance %q", instId)
+		}
+		info := append([]network.InterfaceInfo{}, inst.interfaces...)
+		estate.ops <- OpNetworkInterfaces{
+			Env:        env.name,
+			InstanceId: instId,
+			Info:       info,
+		}
+		return info, nil
+	}
+
 	// Simulate 3 NICs - primary and secondary enabled plus a disabled NIC.
 	// all configured using DHCP and having fake DNS servers and gateway.
 	info := make([]network.InterfaceInfo, 3)
@@ -1463,6 +1526,9 @@ func (az azShim) Available() bool {
 
 // AvailabilityZones implements environs.ZonedEnviron.
 func (env *environ) AvailabilityZones(ctx context.ProviderCallContext) ([]common.AvailabilityZone, error) {
+	if n := simulatedNetwork(); n != nil {
+		return n.availabilityZones(), nil
+	}
 	// TODO(dimitern): Fix this properly.
 	return []common.AvailabilityZone{
 		azShim{"zone1", true},
@@ -1477,6 +1543,9 @@ func (env *environ) InstanceAvailabilityZoneNames(ctx context.ProviderCallContex
 	if err := env.checkBroken("InstanceAvailabilityZoneNames"); err != nil {
 		return nil, errors.NotSupportedf("instance availability zones")
 	}
+	if simulatedNetwork() != nil {
+		return env.simulatedInstanceZones(ids)
+	}
 	availabilityZones, err := env.AvailabilityZones(ctx)
 	if err != nil {
 		return nil, err
@@ -1503,9 +1572,45 @@ func (env *environ) InstanceAvailabilityZoneNames(ctx context.ProviderCallContex
 
 // DeriveAvailabilityZones is part of the common.ZonedEnviron interface.
 func (env *environ) DeriveAvailabilityZones(ctx context.ProviderCallContext, args environs.StartInstanceParams) ([]string, error) {
+	if simulatedNetwork() == nil {
+		return nil, nil
+	}
+	if zone := placementZone(args.Placement); zone != "" {
+		return []string{zone}, nil
+	}
 	return nil, nil
 }
 
+// simulatedInstanceZones returns the availability zones of the
+// instances with the given ids, when simulating a network.
+func (env *environ) simulatedInstanceZones(ids []instance.Id) ([]string, error) {
+	estate, err := env.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	zones := make([]string, len(ids))
+	for i, id := range ids {
+		inst, ok := estate.insts[id]
+		if !ok {
+			return nil, errors.NotFoundf("instance %q", id)
+		}
+		zones[i] = inst.zone
+	}
+	return zones, nil
+}
+
+// placementZone returns the availability zone of the given
+// placement directive, if it is of the form "zone=<name>".
+func placementZone(placement string) string {
+	split := strings.Split(placement, "=")
+	if len(split) == 2 && split[0] == "zone" {
+		return split[1]
+	}
+	return ""
+}
+
 // Subnets implements environs.Environ.Subnets.
 func (env *environ) Subnets(ctx context.ProviderCallContext, instId instance.Id, subnetIds []network.Id) ([]network.SubnetInfo, error) {
 	if err := env.checkBroken("Subnets"); err != nil {
@@ -1516,9 +1621,13 @@ func (env *environ) Subnets(ctx context.ProviderCallContext, instId instance.Id,
 	if err != nil {
 		return nil, err
 	}
+	n := simulatedNetwork()
 	estate.mu.Lock()
 	defer estate.mu.Unlock()
 
+	if n != nil {
+		return env.simulatedSubnets(estate, n, instId, subnetIds)
+	}
 	if ok, _ := env.SupportsSpaceDiscovery(ctx); ok {
 		// Space discovery needs more subnets to work with.
 		return env.subnetsForSpaceDiscovery(estate)
@@ -1566,6 +1675,51 @@ func (env *environ) Subnets(ctx context.ProviderCallContext, instId instance.Id,
 	return result, nil
 }
 
+// simulatedSubnets returns the subnets of the simulated network, or
+// those of the instance with the given id, filtered by the given
+// subnet ids if any.
+func (env *environ) simulatedSubnets(estate *environState, n *Network, instId instance.Id, subnetIds []network.Id) ([]network.SubnetInfo, error) {
+	allSubnets := n.subnets()
+	if instId != "" && instId != instance.UnknownId {
+		inst, ok := estate.insts[instId]
+		if !ok {
+			return nil, errors.NotFoundf("instance %q", instId)
+		}
+		instSubnets := set.NewStrings()
+		for _, nic := range inst.interfaces {
+			instSubnets.Add(string(nic.ProviderSubnetId))
+		}
+		var subnets []network.SubnetInfo
+		for _, subnet := range allSubnets {
+			if instSubnets.Contains(string(subnet.ProviderId)) {
+				subnets = append(subnets, subnet)
+			}
+		}
+		allSubnets = subnets
+	}
+
+	result := allSubnets
+	if len(subnetIds) > 0 {
+		wanted := set.NewStrings()
+		for _, id := range subnetIds {
+			wanted.Add(string(id))
+		}
+		result = nil
+		for _, subnet := range allSubnets {
+			if wanted.Contains(string(subnet.ProviderId)) {
+				result = append(result, subnet)
+			}
+		}
+	}
+	estate.ops <- OpSubnets{
+		Env:        env.name,
+		InstanceId: instId,
+		SubnetIds:  subnetIds,
+		Info:       result,
+	}
+	return result, nil
+}
+
 func (env *environ) subnetsForSpaceDiscovery(estate *environState) ([]network.SubnetInfo, error) {
 	result := []network.SubnetInfo{{
 		ProviderId:        network.Id("1"),
@@ -1695,6 +1849,10 @@ type dummyInstance struct {
 	firewallMode string
 	controller   bool
 
+	// zone and interfaces are set when simulating a network.
+	zone       string
+	interfaces []network.InterfaceInfo
+
 	mu        sync.Mutex
 	addresses []network.Address
 	broken    []string
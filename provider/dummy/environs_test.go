@@ -12,8 +12,10 @@ import (
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/bootstrap"
@@ -24,7 +26,9 @@ import (
 	"github.com/juju/juju/juju/keys"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/dummy"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/testing"
 	jujuversion "github.com/juju/juju/version"
 )
@@ -341,6 +345,174 @@ func (s *suite) TestSubnets(c *gc.C) {
 	c.Assert(netInfo, gc.HasLen, 0)
 }
 
+func (s *suite) TestSimulatedNetwork(c *gc.C) {
+	e := s.bootstrapTestEnviron(c)
+	defer func() {
+		err := e.Destroy(s.callCtx)
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+
+	spaces := []network.SpaceInfo{{
+		Name:       "db",
+		ProviderId: "space-db",
+		Subnets: []network.SubnetInfo{{
+			ProviderId:        "subnet-db-1",
+			CIDR:              "10.1.1.0/24",
+			AvailabilityZones: []string{"az1"},
+		}, {
+			ProviderId:        "subnet-db-2",
+			CIDR:              "10.1.2.0/24",
+			AvailabilityZones: []string{"az2"},
+		}},
+	}, {
+		Name:       "public",
+		ProviderId: "space-public",
+		Subnets: []network.SubnetInfo{{
+			ProviderId:        "subnet-public",
+			CIDR:              "10.2.0.0/24",
+			AvailabilityZones: []string{"az1"},
+		}},
+	}}
+	previous := dummy.SetNetwork(&dummy.Network{
+		Zones:            []string{"az1", "az2"},
+		UnavailableZones: []string{"az3"},
+		Spaces:           spaces,
+	})
+	c.Assert(previous, gc.IsNil)
+
+	result, err := e.Spaces(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, spaces)
+
+	zonedEnv := e.(common.ZonedEnviron)
+	zones, err := zonedEnv.AvailabilityZones(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(zones, gc.HasLen, 3)
+	c.Check(zones[0].Name(), gc.Equals, "az1")
+	c.Check(zones[0].Available(), jc.IsTrue)
+	c.Check(zones[2].Name(), gc.Equals, "az3")
+	c.Check(zones[2].Available(), jc.IsFalse)
+
+	// An instance constrained to a space is placed in its subnets.
+	inst1, hwc := jujutesting.AssertStartInstanceWithConstraints(
+		c, e, s.callCtx, s.ControllerUUID, "1", constraints.MustParse("spaces=public"))
+	c.Check(*hwc.AvailabilityZone, gc.Equals, "az1")
+	nics, err := e.NetworkInterfaces(s.callCtx, inst1.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(nics, gc.HasLen, 1)
+	c.Check(nics[0].ProviderSubnetId, gc.Equals, network.Id("subnet-public"))
+	c.Check(nics[0].Address.Value, gc.Matches, `10\.2\.0\.\d+`)
+
+	// Other instances are spread over the zones.
+	inst2, hwc := jujutesting.AssertStartInstance(c, e, s.callCtx, s.ControllerUUID, "2")
+	c.Check(*hwc.AvailabilityZone, gc.Equals, "az2")
+	subnets, err := e.Subnets(s.callCtx, inst2.Id(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(subnets, gc.HasLen, 1)
+	c.Check(subnets[0].ProviderId, gc.Equals, network.Id("subnet-db-2"))
+	c.Check(subnets[0].SpaceProviderId, gc.Equals, network.Id("space-db"))
+
+	zoneNames, err := zonedEnv.InstanceAvailabilityZoneNames(s.callCtx, []instance.Id{inst1.Id(), inst2.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(zoneNames, jc.DeepEquals, []string{"az1", "az2"})
+
+	// Instances cannot be started in unavailable zones.
+	_, err = jujutesting.StartInstanceWithParams(e, s.callCtx, "3", environs.StartInstanceParams{
+		ControllerUUID: s.ControllerUUID,
+		Placement:      "zone=az3",
+	})
+	c.Assert(err, gc.ErrorMatches, `availability zone "az3" not available`)
+
+	// Nor in spaces without subnets in the requested zone.
+	_, err = jujutesting.StartInstanceWithParams(e, s.callCtx, "3", environs.StartInstanceParams{
+		ControllerUUID: s.ControllerUUID,
+		Placement:      "zone=az2",
+		Constraints:    constraints.MustParse("spaces=public"),
+	})
+	c.Assert(err, gc.ErrorMatches, `no subnets of spaces \[public\] in availability zone "az2"`)
+}
+
+func (s *suite) TestVolumeSource(c *gc.C) {
+	e := s.bootstrapTestEnviron(c)
+	defer func() {
+		err := e.Destroy(s.callCtx)
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+
+	provider, err := e.StorageProvider(dummy.VolumeProviderType)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(provider.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Check(provider.Scope(), gc.Equals, storage.ScopeEnviron)
+	source, err := provider.VolumeSource(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	inst, _ := jujutesting.AssertStartInstance(c, e, s.callCtx, s.ControllerUUID, "1")
+	volumeTag := names.NewVolumeTag("0")
+	machineTag := names.NewMachineTag("1")
+	created, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:      volumeTag,
+		Size:     1024,
+		Provider: dummy.VolumeProviderType,
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Provider:   dummy.VolumeProviderType,
+				Machine:    machineTag,
+				InstanceId: inst.Id(),
+			},
+			Volume: volumeTag,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(created, gc.HasLen, 1)
+	c.Assert(created[0].Error, jc.ErrorIsNil)
+	volumeId := created[0].Volume.VolumeId
+	c.Check(created[0].Volume.Size, gc.Equals, uint64(1024))
+	c.Check(created[0].VolumeAttachment.DeviceName, gc.Equals, "xvdb")
+
+	ids, err := source.ListVolumes(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ids, jc.DeepEquals, []string{volumeId})
+
+	// Attached volumes cannot be destroyed.
+	errs, err := source.DestroyVolumes(s.callCtx, []string{volumeId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(errs[0], gc.ErrorMatches, `volume ".*" is attached`)
+
+	attachment := storage.VolumeAttachmentParams{
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    machineTag,
+			InstanceId: inst.Id(),
+		},
+		Volume:   volumeTag,
+		VolumeId: volumeId,
+	}
+	errs, err = source.DetachVolumes(s.callCtx, []storage.VolumeAttachmentParams{attachment})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(errs[0], jc.ErrorIsNil)
+	errs, err = source.DestroyVolumes(s.callCtx, []string{volumeId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(errs[0], jc.ErrorIsNil)
+
+	described, err := source.DescribeVolumes(s.callCtx, []string{volumeId})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(described[0].Error, jc.Satisfies, errors.IsNotFound)
+
+	// Volumes can only be attached to instances of the environment.
+	created, err = source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		Size:     1024,
+		Provider: dummy.VolumeProviderType,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(created[0].Error, jc.ErrorIsNil)
+	attachment.Volume = names.NewVolumeTag("1")
+	attachment.VolumeId = created[0].Volume.VolumeId
+	attachment.InstanceId = "i-unknown"
+	attached, err := source.AttachVolumes(s.callCtx, []storage.VolumeAttachmentParams{attachment})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(attached[0].Error, jc.Satisfies, errors.IsNotFound)
+}
+
 func assertInterfaces(c *gc.C, e environs.Environ, opc chan dummy.Operation, expectInstId instance.Id, expectInfo []network.InterfaceInfo) {
 	select {
 	case op := <-opc:
@@ -0,0 +1,179 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dummy
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
+)
+
+// Network describes the spaces, subnets and availability zones of a
+// simulated cloud. When set with SetNetwork, dummy environments report
+// them instead of their fixed networking data, distribute the instances
+// they start over the availability zones, and place the instances in the
+// subnets of the spaces required by their constraints.
+type Network struct {
+	// Zones holds the names of the available availability zones.
+	Zones []string
+
+	// UnavailableZones holds the names of the availability zones
+	// that are known but not available to start instances in.
+	UnavailableZones []string
+
+	// Spaces holds the spaces of the cloud. Their subnets must have
+	// a provider ID, a CIDR and the availability zones they span.
+	Spaces []network.SpaceInfo
+}
+
+// SetNetwork sets the network simulated by dummy environments, and
+// returns the previous one. Setting a nil network restores the fixed
+// networking data of the dummy provider.
+func SetNetwork(n *Network) *Network {
+	dummy.mu.Lock()
+	defer dummy.mu.Unlock()
+	current := dummy.network
+	dummy.network = n
+	return current
+}
+
+// simulatedNetwork returns the network simulated by dummy environments,
+// or nil if none is set.
+func simulatedNetwork() *Network {
+	dummy.mu.Lock()
+	defer dummy.mu.Unlock()
+	return dummy.network
+}
+
+// subnets returns the subnets of all the spaces of the network.
+func (n *Network) subnets() []network.SubnetInfo {
+	var subnets []network.SubnetInfo
+	for _, space := range n.Spaces {
+		for _, subnet := range space.Subnets {
+			subnet.SpaceProviderId = space.ProviderId
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets
+}
+
+// availabilityZones returns the availability zones of the network.
+func (n *Network) availabilityZones() []common.AvailabilityZone {
+	var zones []common.AvailabilityZone
+	for _, name := range n.Zones {
+		zones = append(zones, azShim{name, true})
+	}
+	for _, name := range n.UnavailableZones {
+		zones = append(zones, azShim{name, false})
+	}
+	return zones
+}
+
+// zoneSubnets returns the subnets spanning the given zone, restricted to
+// the subnets of the given spaces if any are given.
+func (n *Network) zoneSubnets(zone string, spaces []string) []network.SubnetInfo {
+	spaceNames := set.NewStrings(spaces...)
+	var subnets []network.SubnetInfo
+	for _, space := range n.Spaces {
+		if !spaceNames.IsEmpty() && !spaceNames.Contains(space.Name) {
+			continue
+		}
+		for _, subnet := range space.Subnets {
+			if !set.NewStrings(subnet.AvailabilityZones...).Contains(zone) {
+				continue
+			}
+			subnet.SpaceProviderId = space.ProviderId
+			subnets = append(subnets, subnet)
+		}
+	}
+	return subnets
+}
+
+// placeInstance returns the availability zone and subnets of a new
+// instance. The instance is placed in the given zone if there is one,
+// otherwise in the available zone with the fewest instances. When spaces
+// are given, the instance is placed in subnets of those spaces only.
+func (n *Network) placeInstance(zone string, spaces []string, insts map[instance.Id]*dummyInstance) (string, []network.SubnetInfo, error) {
+	if zone != "" {
+		if !set.NewStrings(n.Zones...).Contains(zone) {
+			return "", nil, errors.Errorf("availability zone %q not available", zone)
+		}
+		subnets := n.zoneSubnets(zone, spaces)
+		if len(spaces) > 0 && len(subnets) == 0 {
+			return "", nil, errors.Errorf("no subnets of spaces %v in availability zone %q", spaces, zone)
+		}
+		return zone, subnets, nil
+	}
+
+	population := make(map[string]int)
+	for _, inst := range insts {
+		population[inst.zone]++
+	}
+	var best string
+	var bestSubnets []network.SubnetInfo
+	for _, candidate := range n.Zones {
+		subnets := n.zoneSubnets(candidate, spaces)
+		if len(spaces) > 0 && len(subnets) == 0 {
+			continue
+		}
+		if best == "" || population[candidate] < population[best] {
+			best, bestSubnets = candidate, subnets
+		}
+	}
+	if best == "" {
+		return "", nil, errors.Errorf("no availability zone with subnets of spaces %v", spaces)
+	}
+	return best, bestSubnets, nil
+}
+
+// instanceInterfaces returns the network interfaces of the instance with
+// the given index, one for each of the given subnets.
+func instanceInterfaces(index int, subnets []network.SubnetInfo) ([]network.InterfaceInfo, error) {
+	info := make([]network.InterfaceInfo, len(subnets))
+	for i, subnet := range subnets {
+		_, ipNet, err := net.ParseCIDR(subnet.CIDR)
+		if err != nil {
+			return nil, errors.Annotatef(err, "subnet %q", subnet.ProviderId)
+		}
+		info[i] = network.InterfaceInfo{
+			DeviceIndex:      i,
+			ProviderId:       network.Id(fmt.Sprintf("dummy-eth%d-%d", index, i)),
+			ProviderSubnetId: subnet.ProviderId,
+			InterfaceType:    network.EthernetInterface,
+			CIDR:             subnet.CIDR,
+			InterfaceName:    fmt.Sprintf("eth%d", i),
+			VLANTag:          subnet.VLANTag,
+			MACAddress:       fmt.Sprintf("aa:bb:cc:%02x:%02x:%02x", index>>8&0xff, index&0xff, i),
+			ConfigType:       network.ConfigDHCP,
+			Address:          network.NewAddress(hostAddress(ipNet, index+2)),
+			DNSServers:       network.NewAddresses("ns1.dummy", "ns2.dummy"),
+			GatewayAddress:   network.NewAddress(hostAddress(ipNet, 1)),
+		}
+	}
+	return info, nil
+}
+
+// hostAddress returns the address of the host with the given number in
+// the given IPv4 network, wrapping around within the network.
+func hostAddress(ipNet *net.IPNet, host int) string {
+	ip := ipNet.IP.To4()
+	if ip == nil {
+		return ipNet.IP.String()
+	}
+	ones, bits := ipNet.Mask.Size()
+	size := uint32(1) << uint(bits-ones)
+	if size > 2 {
+		host = 1 + (host-1)%int(size-2)
+	}
+	result := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(result, binary.BigEndian.Uint32(ip)+uint32(host))
+	return result.String()
+}
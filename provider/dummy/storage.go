@@ -4,10 +4,315 @@
 package dummy
 
 import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/storage"
 	dummystorage "github.com/juju/juju/storage/provider/dummy"
 )
 
+// VolumeProviderType is the type of the storage provider of dummy
+// environments whose volume source simulates the volumes of a cloud.
+// The volumes are kept in the state of the environment, and can only
+// be attached to its instances.
+const VolumeProviderType storage.ProviderType = "dummy-volume"
+
 func StorageProviders() storage.ProviderRegistry {
 	return dummystorage.StorageProviders()
 }
+
+// dummyVolume is a volume of a dummy environment.
+type dummyVolume struct {
+	tag         names.VolumeTag
+	info        storage.VolumeInfo
+	attachments map[instance.Id]storage.VolumeAttachment
+}
+
+// volumeProvider is the storage provider of VolumeProviderType.
+type volumeProvider struct {
+	env *environ
+}
+
+// VolumeSource is defined on storage.Provider.
+func (p volumeProvider) VolumeSource(*storage.Config) (storage.VolumeSource, error) {
+	return &volumeSource{p.env}, nil
+}
+
+// FilesystemSource is defined on storage.Provider.
+func (volumeProvider) FilesystemSource(*storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on storage.Provider.
+func (volumeProvider) Supports(kind storage.StorageKind) bool {
+	return kind == storage.StorageKindBlock
+}
+
+// Scope is defined on storage.Provider.
+func (volumeProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on storage.Provider.
+func (volumeProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on storage.Provider.
+func (volumeProvider) Releasable() bool {
+	return true
+}
+
+// DefaultPools is defined on storage.Provider.
+func (volumeProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// ValidateConfig is defined on storage.Provider.
+func (volumeProvider) ValidateConfig(*storage.Config) error {
+	return nil
+}
+
+// volumeSource is the storage.VolumeSource of dummy environments.
+type volumeSource struct {
+	env *environ
+}
+
+// CreateVolumes is defined on storage.VolumeSource.
+func (s *volumeSource) CreateVolumes(ctx context.ProviderCallContext, params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	if err := s.env.checkBroken("CreateVolumes"); err != nil {
+		return nil, err
+	}
+	estate, err := s.env.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+
+	results := make([]storage.CreateVolumesResult, len(params))
+	for i, p := range params {
+		id := fmt.Sprintf("vol-%d", estate.maxVolumeId)
+		estate.maxVolumeId++
+		v := &dummyVolume{
+			tag: p.Tag,
+			info: storage.VolumeInfo{
+				VolumeId:   id,
+				HardwareId: "dummy-" + id,
+				Size:       p.Size,
+				Persistent: true,
+			},
+			attachments: make(map[instance.Id]storage.VolumeAttachment),
+		}
+		estate.volumes[id] = v
+		results[i].Volume = &storage.Volume{
+			Tag:        p.Tag,
+			VolumeInfo: v.info,
+		}
+		if p.Attachment != nil && p.Attachment.InstanceId != "" {
+			attachment, err := estate.attachVolume(v, p.Attachment)
+			if err != nil {
+				results[i].Error = errors.Trace(err)
+				continue
+			}
+			results[i].VolumeAttachment = attachment
+		}
+	}
+	return results, nil
+}
+
+// ListVolumes is defined on storage.VolumeSource.
+func (s *volumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, error) {
+	if err := s.env.checkBroken("ListVolumes"); err != nil {
+		return nil, err
+	}
+	estate, err := s.env.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+
+	ids := make([]string, 0, len(estate.volumes))
+	for id := range estate.volumes {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// DescribeVolumes is defined on storage.VolumeSource.
+func (s *volumeSource) DescribeVolumes(ctx context.ProviderCallContext, volIds []string) ([]storage.DescribeVolumesResult, error) {
+	if err := s.env.checkBroken("DescribeVolumes"); err != nil {
+		return nil, err
+	}
+	estate, err := s.env.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+
+	results := make([]storage.DescribeVolumesResult, len(volIds))
+	for i, id := range volIds {
+		v, ok := estate.volumes[id]
+		if !ok {
+			results[i].Error = errors.NotFoundf("volume %q", id)
+			continue
+		}
+		info := v.info
+		results[i].VolumeInfo = &info
+	}
+	return results, nil
+}
+
+// DestroyVolumes is defined on storage.VolumeSource.
+func (s *volumeSource) DestroyVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
+	if err := s.env.checkBroken("DestroyVolumes"); err != nil {
+		return nil, err
+	}
+	return s.removeVolumes(volIds)
+}
+
+// ReleaseVolumes is defined on storage.VolumeSource.
+func (s *volumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
+	if err := s.env.checkBroken("ReleaseVolumes"); err != nil {
+		return nil, err
+	}
+	return s.removeVolumes(volIds)
+}
+
+// removeVolumes removes the volumes with the given ids from the
+// environment. Like the volumes of a cloud, volumes that are attached
+// to instances cannot be removed. Volumes that are already removed
+// are ignored.
+func (s *volumeSource) removeVolumes(volIds []string) ([]error, error) {
+	estate, err := s.env.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+
+	results := make([]error, len(volIds))
+	for i, id := range volIds {
+		v, ok := estate.volumes[id]
+		if !ok {
+			continue
+		}
+		if len(v.attachments) > 0 {
+			results[i] = errors.Errorf("volume %q is attached", id)
+			continue
+		}
+		delete(estate.volumes, id)
+	}
+	return results, nil
+}
+
+// ValidateVolumeParams is defined on storage.VolumeSource.
+func (s *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	if params.Size == 0 {
+		return errors.NotValidf("volume size 0")
+	}
+	return nil
+}
+
+// AttachVolumes is defined on storage.VolumeSource.
+func (s *volumeSource) AttachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	if err := s.env.checkBroken("AttachVolumes"); err != nil {
+		return nil, err
+	}
+	estate, err := s.env.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+
+	results := make([]storage.AttachVolumesResult, len(params))
+	for i, p := range params {
+		v, ok := estate.volumes[p.VolumeId]
+		if !ok {
+			results[i].Error = errors.NotFoundf("volume %q", p.VolumeId)
+			continue
+		}
+		attachment, err := estate.attachVolume(v, &p)
+		if err != nil {
+			results[i].Error = errors.Trace(err)
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+// DetachVolumes is defined on storage.VolumeSource.
+func (s *volumeSource) DetachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) ([]error, error) {
+	if err := s.env.checkBroken("DetachVolumes"); err != nil {
+		return nil, err
+	}
+	estate, err := s.env.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+
+	// Volumes that are not attached are already detached.
+	for _, p := range params {
+		if v, ok := estate.volumes[p.VolumeId]; ok {
+			delete(v.attachments, p.InstanceId)
+		}
+	}
+	return make([]error, len(params)), nil
+}
+
+// attachVolume attaches the volume to the instance of the given
+// attachment parameters. The instance must exist. Attaching a volume
+// that is already attached to the instance returns the existing
+// attachment. The caller must hold the state lock.
+func (estate *environState) attachVolume(v *dummyVolume, p *storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	if _, ok := estate.insts[p.InstanceId]; !ok {
+		return nil, errors.NotFoundf("instance %q", p.InstanceId)
+	}
+	if attachment, ok := v.attachments[p.InstanceId]; ok {
+		return &attachment, nil
+	}
+	if len(v.attachments) > 0 {
+		return nil, errors.Errorf("volume %q is attached to another instance", v.info.VolumeId)
+	}
+
+	// Volumes are exposed as the next free device of the instance.
+	inUse := make(map[string]bool)
+	for _, other := range estate.volumes {
+		if attachment, ok := other.attachments[p.InstanceId]; ok {
+			inUse[attachment.DeviceName] = true
+		}
+	}
+	var deviceName string
+	for c := 'b'; c <= 'z'; c++ {
+		if name := fmt.Sprintf("xvd%c", c); !inUse[name] {
+			deviceName = name
+			break
+		}
+	}
+	if deviceName == "" {
+		return nil, errors.Errorf("no free devices on instance %q", p.InstanceId)
+	}
+
+	attachment := storage.VolumeAttachment{
+		Volume:  v.tag,
+		Machine: p.Machine,
+		VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+			DeviceName: deviceName,
+			ReadOnly:   p.ReadOnly,
+		},
+	}
+	v.attachments[p.InstanceId] = attachment
+	return &attachment, nil
+}
@@ -0,0 +1,214 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"net"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
+)
+
+// maxContainerAddressAttempts is the number of free addresses tried
+// when assigning a container address, in case another allocation
+// claims the same address concurrently.
+const maxContainerAddressAttempts = 5
+
+// AllocateContainerAddresses is specified on environs.Networking. Each
+// container interface bridged to a host interface is given a secondary
+// private IP address of the host's network interface in the same subnet,
+// making the container directly routable within the VPC without an
+// overlay network.
+func (e *environ) AllocateContainerAddresses(
+	ctx context.ProviderCallContext,
+	hostInstanceID instance.Id,
+	containerTag names.MachineTag,
+	preparedInfo []network.InterfaceInfo,
+) ([]network.InterfaceInfo, error) {
+	if len(preparedInfo) == 0 {
+		return nil, errors.Errorf("no prepared info to allocate")
+	}
+	logger.Debugf("using prepared container info: %+v", preparedInfo)
+
+	filter := ec2.NewFilter()
+	filter.Add("attachment.instance-id", string(hostInstanceID))
+	resp, err := e.ec2.NetworkInterfaces(nil, filter)
+	if err != nil {
+		return nil, errors.Annotatef(maybeConvertCredentialError(err, ctx),
+			"cannot get instance %q network interfaces", hostInstanceID)
+	}
+
+	result := make([]network.InterfaceInfo, len(preparedInfo))
+	for i, info := range preparedInfo {
+		result[i] = info
+		if info.ProviderSubnetId == "" {
+			// Not bridged to a provider subnet, leave it to DHCP.
+			continue
+		}
+		hostInterface, ok := interfaceInSubnet(resp.Interfaces, string(info.ProviderSubnetId))
+		if !ok {
+			return nil, errors.NotFoundf(
+				"network interface of instance %q in subnet %q", hostInstanceID, info.ProviderSubnetId)
+		}
+		address, err := e.assignContainerAddress(ctx, hostInterface, info.CIDR)
+		if err != nil {
+			return nil, errors.Annotatef(err, "allocating address for container %q", containerTag.Id())
+		}
+		logger.Debugf("assigned address %q of %q to container %q", address, hostInterface.Id, containerTag.Id())
+
+		result[i].ConfigType = network.ConfigStatic
+		result[i].ProviderId = containerInterfaceProviderId(hostInterface.Id, address)
+		result[i].Address = network.NewScopedAddress(address, network.ScopeCloudLocal)
+		// The VPC router and DNS resolver are always at the
+		// first and second addresses of the subnet.
+		if gateway, err := subnetReservedAddress(info.CIDR, 1); err == nil && info.IsDefaultGateway {
+			result[i].GatewayAddress = network.NewScopedAddress(gateway, network.ScopeCloudLocal)
+		}
+		if resolver, err := subnetReservedAddress(info.CIDR, 2); err == nil {
+			result[i].DNSServers = network.NewAddresses(resolver)
+		}
+	}
+	return result, nil
+}
+
+// ReleaseContainerAddresses is specified on environs.Networking. The
+// secondary private IP addresses assigned to the containers' interfaces
+// are unassigned from the host network interfaces.
+func (e *environ) ReleaseContainerAddresses(ctx context.ProviderCallContext, interfaces []network.ProviderInterfaceInfo) error {
+	for _, iface := range interfaces {
+		interfaceId, address, ok := parseContainerInterfaceProviderId(iface.ProviderId)
+		if !ok {
+			logger.Debugf("skipping container interface %q without an allocated address", iface.InterfaceName)
+			continue
+		}
+		_, err := e.ec2.UnassignPrivateIPAddresses(interfaceId, []string{address})
+		switch ec2ErrCode(err) {
+		case "":
+		case "InvalidNetworkInterfaceID.NotFound":
+			// The host interface, and so its addresses, are gone.
+		default:
+			return errors.Annotatef(maybeConvertCredentialError(err, ctx),
+				"releasing address %q of %q", address, interfaceId)
+		}
+	}
+	return nil
+}
+
+// assignContainerAddress assigns a free address of the subnet as a
+// secondary private IP address of the network interface.
+func (e *environ) assignContainerAddress(ctx context.ProviderCallContext, iface ec2.NetworkInterface, cidr string) (string, error) {
+	filter := ec2.NewFilter()
+	filter.Add("subnet-id", iface.SubnetId)
+	resp, err := e.ec2.NetworkInterfaces(nil, filter)
+	if err != nil {
+		return "", errors.Annotatef(maybeConvertCredentialError(err, ctx),
+			"cannot get network interfaces in subnet %q", iface.SubnetId)
+	}
+	used := set.NewStrings()
+	for _, subnetInterface := range resp.Interfaces {
+		for _, ip := range subnetInterface.PrivateIPs {
+			used.Add(ip.Address)
+		}
+	}
+	for attempt := 0; attempt < maxContainerAddressAttempts; attempt++ {
+		address, err := nextFreeSubnetAddress(cidr, used)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		_, err = e.ec2.AssignPrivateIPAddresses(iface.Id, []string{address}, 0, false)
+		switch ec2ErrCode(err) {
+		case "":
+			return address, nil
+		case "InvalidParameterValue", "InvalidIPAddress.InUse":
+			// Claimed by someone else in the meantime.
+			logger.Debugf("address %q of subnet %q in use, retrying", address, iface.SubnetId)
+			used.Add(address)
+		default:
+			return "", errors.Trace(maybeConvertCredentialError(err, ctx))
+		}
+	}
+	return "", errors.Errorf("no free address found in subnet %q", iface.SubnetId)
+}
+
+// interfaceInSubnet returns the network interface attached to the subnet.
+func interfaceInSubnet(interfaces []ec2.NetworkInterface, subnetId string) (ec2.NetworkInterface, bool) {
+	for _, iface := range interfaces {
+		if iface.SubnetId == subnetId {
+			return iface, true
+		}
+	}
+	return ec2.NetworkInterface{}, false
+}
+
+// subnetReservedAddress returns the address at the given offset from the
+// base of the IPv4 subnet. AWS reserves the first four addresses and the
+// last address of every subnet.
+func subnetReservedAddress(cidr string, offset int) (string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	base := ipNet.IP.To4()
+	if base == nil {
+		return "", errors.NotSupportedf("IPv6 subnet %q", cidr)
+	}
+	return addressAtOffset(base, offset).String(), nil
+}
+
+// nextFreeSubnetAddress returns the lowest address of the IPv4 subnet
+// which is neither reserved by AWS nor in the used set.
+func nextFreeSubnetAddress(cidr string, used set.Strings) (string, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	base := ipNet.IP.To4()
+	if base == nil {
+		return "", errors.NotSupportedf("IPv6 subnet %q", cidr)
+	}
+	ones, bits := ipNet.Mask.Size()
+	size := 1 << uint(bits-ones)
+	// Skip the four reserved addresses at the start of the
+	// subnet and the broadcast address at the end.
+	for offset := 4; offset < size-1; offset++ {
+		address := addressAtOffset(base, offset).String()
+		if !used.Contains(address) {
+			return address, nil
+		}
+	}
+	return "", errors.Errorf("no free address found in subnet %q", cidr)
+}
+
+func addressAtOffset(base net.IP, offset int) net.IP {
+	ip := make(net.IP, len(base))
+	copy(ip, base)
+	for i := len(ip) - 1; i >= 0 && offset > 0; i-- {
+		sum := int(ip[i]) + offset
+		ip[i] = byte(sum)
+		offset = sum >> 8
+	}
+	return ip
+}
+
+// containerInterfaceProviderId returns the provider id recorded for a
+// container interface, identifying both the host network interface and
+// the secondary address assigned to it, so the address can be released.
+func containerInterfaceProviderId(interfaceId, address string) network.Id {
+	return network.Id(interfaceId + ":" + address)
+}
+
+func parseContainerInterfaceProviderId(id network.Id) (interfaceId, address string, ok bool) {
+	parts := strings.SplitN(string(id), ":", 2)
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "eni-") || net.ParseIP(parts[1]) == nil {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
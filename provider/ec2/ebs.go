@@ -423,7 +423,11 @@ func (v *ebsVolumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string
 }
 
 func listVolumes(client *ec2.EC2, ctx context.ProviderCallContext, filter *ec2.Filter, includeRootDisks bool) ([]string, error) {
-	resp, err := client.Volumes(nil, filter)
+	var resp *ec2.VolumesResp
+	err := callEC2("DescribeVolumes", func() (err error) {
+		resp, err = client.Volumes(nil, filter)
+		return err
+	})
 	if err != nil {
 		return nil, maybeConvertCredentialError(err, ctx)
 	}
@@ -454,7 +458,11 @@ func (v *ebsVolumeSource) DescribeVolumes(ctx context.ProviderCallContext, volId
 	// operation to fail. If we get an invalid volume ID response,
 	// fall back to querying each volume individually. That should
 	// be rare.
-	resp, err := v.env.ec2.Volumes(volIds, nil)
+	var resp *ec2.VolumesResp
+	err := callEC2("DescribeVolumes", func() (err error) {
+		resp, err = v.env.ec2.Volumes(volIds, nil)
+		return err
+	})
 	if err != nil {
 		return nil, maybeConvertCredentialError(err, ctx)
 	}
@@ -880,7 +888,11 @@ func waitVolume(
 }
 
 func describeVolume(client *ec2.EC2, ctx context.ProviderCallContext, volumeId string) (*ec2.Volume, error) {
-	resp, err := client.Volumes([]string{volumeId}, nil)
+	var resp *ec2.VolumesResp
+	err := callEC2("DescribeVolumes", func() (err error) {
+		resp, err = client.Volumes([]string{volumeId}, nil)
+		return err
+	})
 	if err != nil {
 		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "querying volume")
 	}
@@ -902,7 +914,11 @@ func (c instanceCache) update(ec2client *ec2.EC2, ctx context.ProviderCallContex
 	}
 	filter := ec2.NewFilter()
 	filter.Add("instance-state-name", "running")
-	resp, err := ec2client.Instances(ids, filter)
+	var resp *ec2.InstancesResp
+	err := callEC2("DescribeInstances", func() (err error) {
+		resp, err = ec2client.Instances(ids, filter)
+		return err
+	})
 	if err != nil {
 		return errors.Annotate(maybeConvertCredentialError(err, ctx), "querying instance details")
 	}
@@ -959,7 +975,11 @@ func detachVolumes(client *ec2.EC2, ctx context.ProviderCallContext, attachParam
 
 // ImportVolume is specified on the storage.VolumeImporter interface.
 func (v *ebsVolumeSource) ImportVolume(ctx context.ProviderCallContext, volumeId string, tags map[string]string) (storage.VolumeInfo, error) {
-	resp, err := v.env.ec2.Volumes([]string{volumeId}, nil)
+	var resp *ec2.VolumesResp
+	err := callEC2("DescribeVolumes", func() (err error) {
+		resp, err = v.env.ec2.Volumes([]string{volumeId}, nil)
+		return err
+	})
 	if err != nil {
 		// TODO(axw) check for "not found" response, massage error message?
 		return storage.VolumeInfo{}, maybeConvertCredentialError(err, ctx)
@@ -1049,10 +1069,28 @@ func getBlockDeviceMappings(
 		}
 	}
 	// The first block device is for the root disk.
-	blockDeviceMappings := []ec2.BlockDeviceMapping{{
+	rootDisk := ec2.BlockDeviceMapping{
 		DeviceName: rootDiskDeviceName,
 		VolumeSize: int64(mibToGib(rootDiskSizeMiB)),
-	}}
+	}
+	if cons.HasDiskIOPS() {
+		// Provisioned IOPS volumes must be large enough for the
+		// requested rate, so grow the root disk if necessary.
+		iops := *cons.DiskIOPS
+		if iops > maxProvisionedIops {
+			iops = maxProvisionedIops
+		}
+		minSizeGiB := (iops + maxProvisionedIopsSizeRatio - 1) / maxProvisionedIopsSizeRatio
+		if minSizeGiB < minProvisionedIopsVolumeSizeGiB {
+			minSizeGiB = minProvisionedIopsVolumeSizeGiB
+		}
+		if rootDisk.VolumeSize < int64(minSizeGiB) {
+			rootDisk.VolumeSize = int64(minSizeGiB)
+		}
+		rootDisk.VolumeType = volumeTypeIO1
+		rootDisk.IOPS = int64(iops)
+	}
+	blockDeviceMappings := []ec2.BlockDeviceMapping{rootDisk}
 
 	// Not all machines have this many instance stores.
 	// Instances will be started with as many of the
@@ -4,11 +4,7 @@
 package ec2_test
 
 import (
-	"bytes"
-	"encoding/xml"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"sort"
 	"strconv"
 	"time"
@@ -422,12 +418,7 @@ func (s *ebsSuite) TestDestroyVolumesCredentialError(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.setupAttachVolumesTest(c, vs, ec2test.Running)
 
-	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
-		resp.StatusCode = http.StatusBadRequest
-		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
-			Code: "Blocked",
-		}}})
-	}
+	s.srv.injectFaults(errorFault("", "Blocked"))
 	in := []string{"vol-0"}
 	results, err := vs.DestroyVolumes(s.cloudCallCtx, in)
 	c.Assert(err, jc.ErrorIsNil)
@@ -497,12 +488,7 @@ func (s *ebsSuite) TestReleaseVolumesCredentialError(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	s.setupAttachVolumesTest(c, vs, ec2test.Running)
 
-	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
-		resp.StatusCode = http.StatusBadRequest
-		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
-			Code: "Blocked",
-		}}})
-	}
+	s.srv.injectFaults(errorFault("", "Blocked"))
 	in := []string{"vol-0"}
 	results, err := vs.ReleaseVolumes(s.cloudCallCtx, in)
 	c.Assert(err, jc.ErrorIsNil)
@@ -537,12 +523,7 @@ func (s *ebsSuite) TestAttachVolumesCredentialError(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
 
-	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
-		resp.StatusCode = http.StatusBadRequest
-		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
-			Code: "Blocked",
-		}}})
-	}
+	s.srv.injectFaults(errorFault("", "Blocked"))
 	results, err := vs.AttachVolumes(s.cloudCallCtx, params)
 	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
 	c.Assert(results, gc.IsNil)
@@ -587,12 +568,7 @@ func (s *ebsSuite) TestDescribeVolumesNotFound(c *gc.C) {
 
 func (s *ebsSuite) TestDescribeVolumesCredentialError(c *gc.C) {
 	vs := s.volumeSource(c, nil)
-	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
-		resp.StatusCode = http.StatusBadRequest
-		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
-			Code: "Blocked",
-		}}})
-	}
+	s.srv.injectFaults(errorFault("", "Blocked"))
 	results, err := vs.DescribeVolumes(s.cloudCallCtx, []string{"vol-42"})
 	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
 	c.Assert(results, gc.IsNil)
@@ -611,12 +587,7 @@ func (s *ebsSuite) TestListVolumes(c *gc.C) {
 
 func (s *ebsSuite) TestListVolumesCredentialError(c *gc.C) {
 	vs := s.volumeSource(c, nil)
-	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
-		resp.StatusCode = http.StatusBadRequest
-		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
-			Code: "Blocked",
-		}}})
-	}
+	s.srv.injectFaults(errorFault("", "Blocked"))
 	results, err := vs.ListVolumes(s.cloudCallCtx)
 	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
 	c.Assert(results, gc.IsNil)
@@ -830,12 +801,7 @@ func (s *ebsSuite) TestCreateVolumesErrors(c *gc.C) {
 func (s *ebsSuite) TestCreateVolumesCredentialError(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	params := s.createVolumesParams("")
-	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
-		resp.StatusCode = http.StatusBadRequest
-		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
-			Code: "Blocked",
-		}}})
-	}
+	s.srv.injectFaults(errorFault("", "Blocked"))
 	results, err := vs.CreateVolumes(s.cloudCallCtx, params)
 	c.Assert(err, jc.ErrorIsNil)
 	for i, result := range results {
@@ -925,7 +891,7 @@ func (s *ebsSuite) TestAttachVolumesCreating(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
 	var calls int
-	s.srv.proxy.ModifyResponse = makeDescribeVolumesResponseModifier(func(resp *awsec2.VolumesResp) error {
+	s.srv.injectFaults(describeVolumesFault(func(resp *awsec2.VolumesResp) error {
 		if len(resp.Volumes) != 1 {
 			return errors.New("expected one volume")
 		}
@@ -936,7 +902,7 @@ func (s *ebsSuite) TestAttachVolumesCreating(c *gc.C) {
 			resp.Volumes[0].Status = "available"
 		}
 		return nil
-	})
+	}))
 	result, err := vs.AttachVolumes(s.cloudCallCtx, params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.HasLen, 1)
@@ -947,7 +913,7 @@ func (s *ebsSuite) TestAttachVolumesCreating(c *gc.C) {
 func (s *ebsSuite) TestAttachVolumesDetaching(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
-	s.srv.proxy.ModifyResponse = makeDescribeVolumesResponseModifier(func(resp *awsec2.VolumesResp) error {
+	s.srv.injectFaults(describeVolumesFault(func(resp *awsec2.VolumesResp) error {
 		if len(resp.Volumes) != 1 {
 			return errors.New("expected one volume")
 		}
@@ -956,7 +922,7 @@ func (s *ebsSuite) TestAttachVolumesDetaching(c *gc.C) {
 			InstanceId: "something else",
 		})
 		return nil
-	})
+	}))
 	result, err := vs.AttachVolumes(s.cloudCallCtx, params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(result, gc.HasLen, 1)
@@ -999,12 +965,7 @@ func (s *ebsSuite) testDetachVolumesDetachedState(c *gc.C, errorCode string) {
 	_, err := vs.AttachVolumes(s.cloudCallCtx, params)
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
-		resp.StatusCode = http.StatusBadRequest
-		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
-			Code: errorCode,
-		}}})
-	}
+	s.srv.injectFaults(errorFault("", errorCode))
 	errs, err := vs.DetachVolumes(s.cloudCallCtx, params)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(errs, jc.DeepEquals, []error{nil})
@@ -1049,18 +1010,123 @@ func (s *ebsSuite) TestImportVolumeCredentialError(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
-		resp.StatusCode = http.StatusBadRequest
-		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
-			Code: "Blocked",
-		}}})
-	}
+	s.srv.injectFaults(errorFault("", "Blocked"))
 	_, err = vs.(storage.VolumeImporter).ImportVolume(s.cloudCallCtx, resp.Id, map[string]string{
 		"foo": "bar",
 	})
 	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
 }
 
+func (s *ebsSuite) TestImportVolumeRetriesTagging(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	resp, err := s.srv.client.CreateVolume(awsec2.CreateVolume{
+		VolumeSize: 1,
+		VolumeType: "gp2",
+		AvailZone:  "us-east-1a",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A new volume may not be visible to CreateTags immediately,
+	// so tagging it is retried while it is not found.
+	faults := s.srv.injectFaults(&fault{
+		Action:    "CreateTags",
+		Times:     2,
+		ErrorCode: "InvalidVolume.NotFound",
+	})
+	_, err = vs.(storage.VolumeImporter).ImportVolume(s.cloudCallCtx, resp.Id, map[string]string{
+		"foo": "bar",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(faults.requestCount("CreateTags"), gc.Equals, 3)
+}
+
+func (s *ebsSuite) TestImportVolumeTaggingNotFound(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	resp, err := s.srv.client.CreateVolume(awsec2.CreateVolume{
+		VolumeSize: 1,
+		VolumeType: "gp2",
+		AvailZone:  "us-east-1a",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	faults := s.srv.injectFaults(errorFault("CreateTags", "InvalidVolume.NotFound"))
+	_, err = vs.(storage.VolumeImporter).ImportVolume(s.cloudCallCtx, resp.Id, map[string]string{
+		"foo": "bar",
+	})
+	c.Assert(err, gc.ErrorMatches, "tagging volume: .*InvalidVolume.NotFound.*")
+	c.Assert(faults.requestCount("CreateTags"), gc.Equals, ec2.APIRetryStrategy.Attempts)
+}
+
+func (s *ebsSuite) TestImportVolumeRetriesThrottling(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	resp, err := s.srv.client.CreateVolume(awsec2.CreateVolume{
+		VolumeSize: 1,
+		VolumeType: "gp2",
+		AvailZone:  "us-east-1a",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	faults := s.srv.injectFaults(throttleFault("DescribeVolumes", 2))
+	_, err = vs.(storage.VolumeImporter).ImportVolume(s.cloudCallCtx, resp.Id, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(faults.requestCount("DescribeVolumes"), gc.Equals, 3)
+}
+
+func (s *ebsSuite) TestImportVolumeThrottled(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	resp, err := s.srv.client.CreateVolume(awsec2.CreateVolume{
+		VolumeSize: 1,
+		VolumeType: "gp2",
+		AvailZone:  "us-east-1a",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Throttling is not a credential error; the import fails once
+	// all the attempts have been throttled.
+	faults := s.srv.injectFaults(throttleFault("DescribeVolumes", 0))
+	_, err = vs.(storage.VolumeImporter).ImportVolume(s.cloudCallCtx, resp.Id, nil)
+	c.Assert(err, gc.ErrorMatches, ".*RequestLimitExceeded.*")
+	c.Assert(err, gc.Not(jc.Satisfies), common.IsCredentialNotValid)
+	c.Assert(faults.requestCount("DescribeVolumes"), gc.Equals, ec2.APIRetryStrategy.Attempts)
+}
+
+func (s *ebsSuite) TestListVolumesNotFoundNotRetried(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	faults := s.srv.injectFaults(errorFault("DescribeVolumes", "InvalidVolume.NotFound"))
+	_, err := vs.ListVolumes(s.cloudCallCtx)
+	c.Assert(err, gc.ErrorMatches, ".*InvalidVolume.NotFound.*")
+	c.Assert(faults.requestCount("DescribeVolumes"), gc.Equals, 1)
+}
+
+func (s *ebsSuite) TestAttachVolumesLatency(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
+	s.srv.injectFaults(latencyFault("DescribeVolumes", 50*time.Millisecond))
+	start := time.Now()
+	result, err := vs.AttachVolumes(s.cloudCallCtx, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 1)
+	c.Assert(result[0].Error, jc.ErrorIsNil)
+	c.Assert(time.Since(start) >= 50*time.Millisecond, jc.IsTrue)
+}
+
+func (s *ebsSuite) TestFaultsTruncateResults(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.assertCreateVolumes(c, vs, "")
+
+	faults := s.srv.injectFaults(truncateFault("DescribeVolumes", 2))
+	resp, err := s.srv.client.Volumes(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Volumes, gc.HasLen, 2)
+
+	// Other actions are not faulted.
+	instances, err := s.srv.client.Instances(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(instances.Reservations, gc.Not(gc.HasLen), 0)
+	c.Assert(faults.requestCount("DescribeVolumes"), gc.Equals, 1)
+	c.Assert(faults.requestCount("DescribeInstances"), gc.Equals, 1)
+}
+
 func (s *ebsSuite) TestImportVolumeInUse(c *gc.C) {
 	vs := s.volumeSource(c, nil)
 	c.Assert(vs, gc.Implements, new(storage.VolumeImporter))
@@ -1176,37 +1242,3 @@ func (*blockDeviceMappingSuite) TestGetBlockDeviceMappingsController(c *gc.C) {
 		DeviceName:  "/dev/sde",
 	}})
 }
-
-func makeDescribeVolumesResponseModifier(modify func(*awsec2.VolumesResp) error) func(*http.Response) error {
-	return func(resp *http.Response) error {
-		if resp.Request.URL.Query().Get("Action") != "DescribeVolumes" {
-			return nil
-		}
-		var respDecoded struct {
-			XMLName xml.Name
-			awsec2.VolumesResp
-		}
-		if err := xml.NewDecoder(resp.Body).Decode(&respDecoded); err != nil {
-			return err
-		}
-		resp.Body.Close()
-
-		if err := modify(&respDecoded.VolumesResp); err != nil {
-			return err
-		}
-		return replaceResponseBody(resp, &respDecoded)
-	}
-}
-
-func replaceResponseBody(resp *http.Response, value interface{}) error {
-	var buf bytes.Buffer
-	if err := xml.NewEncoder(&buf).Encode(value); err != nil {
-		return err
-	}
-	resp.Body = ioutil.NopCloser(&buf)
-	return nil
-}
-
-type ec2Errors struct {
-	Errors []awsec2.Error `xml:"Errors>Error"`
-}
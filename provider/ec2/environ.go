@@ -81,6 +81,7 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.Networking = (*environ)(nil)
+var _ environs.VolumePreservingDestroyer = (*environ)(nil)
 
 func (e *environ) Config() *config.Config {
 	return e.ecfg().Config
@@ -152,8 +153,14 @@ func (e *environ) SupportsSpaces(ctx context.ProviderCallContext) (bool, error)
 }
 
 // SupportsContainerAddresses is specified on environs.Networking.
+// Container addresses are allocated from the subnets of the host
+// machine's network interfaces when the model is configured with
+// container-networking-method=provider.
 func (e *environ) SupportsContainerAddresses(ctx context.ProviderCallContext) (bool, error) {
-	return false, errors.NotSupportedf("container address allocation")
+	if e.Config().ContainerNetworkingMethod() != "provider" {
+		return false, errors.NotSupportedf("container address allocation without container-networking-method=provider")
+	}
+	return true, nil
 }
 
 // SupportsSpaceDiscovery is specified on environs.Networking.
@@ -162,6 +169,7 @@ func (e *environ) SupportsSpaceDiscovery(ctx context.ProviderCallContext) (bool,
 }
 
 var unsupportedConstraints = []string{
+	constraints.ImageID,
 	constraints.Tags,
 	// TODO(anastasiamac 2016-03-16) LP#1557874
 	// use virt-type in StartInstances
@@ -175,15 +183,24 @@ func (e *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constra
 		[]string{constraints.InstanceType},
 		[]string{constraints.Mem, constraints.Cores, constraints.CpuPower})
 	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterSupported([]string{
+		constraints.CpuFamily,
+		constraints.DiskIOPS,
+		constraints.NetBandwidth,
+	})
+	validator.RegisterVocabulary(constraints.CpuFamily, cpuFamilies())
 	instanceTypes, err := e.supportedInstanceTypes(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	instTypeNames := make([]string, len(instanceTypes))
+	instTypeArches := set.NewStrings()
 	for i, itype := range instanceTypes {
 		instTypeNames[i] = itype.Name
+		instTypeArches = instTypeArches.Union(set.NewStrings(itype.Arches...))
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
+	validator.RegisterVocabulary(constraints.Arch, instTypeArches.SortedValues())
 	return validator, nil
 }
 
@@ -331,6 +348,9 @@ func (e *environ) PrecheckInstance(ctx context.ProviderCallContext, args environ
 	); err != nil {
 		return errors.Trace(err)
 	}
+	if args.Constraints.HasDiskIOPS() && *args.Constraints.DiskIOPS > maxProvisionedIops {
+		return errors.NotValidf("disk-iops %d greater than maximum %d", *args.Constraints.DiskIOPS, maxProvisionedIops)
+	}
 	if !args.Constraints.HasInstanceType() {
 		return nil
 	}
@@ -424,18 +444,7 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	callback(status.Allocating, "Verifying availability zone", nil)
 
 	annotateWrapError := func(received error, annotation string) error {
-		if received == nil {
-			return nil
-		}
-		// If there is a problem with authentication/authorisation,
-		// we want a correctly typed error.
-		annotatedErr := errors.Annotate(
-			maybeConvertCredentialError(received, ctx),
-			annotation)
-		if common.IsCredentialNotValid(annotatedErr) {
-			return annotatedErr
-		}
-		return common.ZoneIndependentError(annotatedErr)
+		return annotateStartInstanceError(ctx, received, annotation)
 	}
 
 	wrapError := func(received error) error {
@@ -458,44 +467,12 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 		return nil, err
 	}
 
-	arches := args.Tools.Arches()
-
-	instanceTypes, err := e.supportedInstanceTypes(ctx)
-	if err != nil {
-		return nil, wrapError(err)
-	}
-
-	spec, err := findInstanceSpec(
-		args.InstanceConfig.Controller != nil,
-		args.ImageMetadata,
-		instanceTypes,
-		&instances.InstanceConstraint{
-			Region:      e.cloud.Region,
-			Series:      args.InstanceConfig.Series,
-			Arches:      arches,
-			Constraints: args.Constraints,
-			Storage:     []string{ssdStorage, ebsStorage},
-		},
-	)
+	spec, err := e.findStartInstanceSpec(ctx, args)
 	if err != nil {
 		return nil, wrapError(err)
 	}
-	tools, err := args.Tools.Match(tools.Filter{Arch: spec.Image.Arch})
-	if err != nil {
-		return nil, common.ZoneIndependentError(
-			errors.Errorf("chosen architecture %v not present in %v", spec.Image.Arch, arches),
-		)
-	}
-
-	if spec.InstanceType.Deprecated {
-		logger.Infof("deprecated instance type specified: %s", spec.InstanceType.Name)
-	}
-
-	if err := args.InstanceConfig.SetTools(tools); err != nil {
-		return nil, common.ZoneIndependentError(err)
-	}
-	if err := instancecfg.FinishInstanceConfig(args.InstanceConfig, e.Config()); err != nil {
-		return nil, common.ZoneIndependentError(err)
+	if err := e.finishStartInstanceConfig(args, spec); err != nil {
+		return nil, err
 	}
 
 	callback(status.Allocating, "Making user data", nil)
@@ -557,9 +534,131 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	runArgs.AvailZone = availabilityZone
 
 	haveVPCID := isVPCIDSet(e.ecfg().vpcID())
+	if runArgs.SubnetId, err = e.selectSubnetID(ctx, args, availabilityZone, placementSubnetID); err != nil {
+		return nil, err
+	}
+
+	callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), nil)
+	instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
+	if err != nil {
+		return nil, runInstancesError(ctx, err)
+	}
+	if len(instResp.Instances) != 1 {
+		return nil, errors.Errorf("expected 1 started instance, got %d", len(instResp.Instances))
+	}
+
+	inst = &ec2Instance{
+		e:        e,
+		Instance: &instResp.Instances[0],
+	}
+	instAZ := inst.Instance.AvailZone
+	if haveVPCID {
+		instVPC := e.ecfg().vpcID()
+		instSubnet := inst.Instance.SubnetId
+		logger.Infof("started instance %q in AZ %q, subnet %q, VPC %q", inst.Id(), instAZ, instSubnet, instVPC)
+	} else {
+		logger.Infof("started instance %q in AZ %q", inst.Id(), instAZ)
+	}
+
+	if err := e.tagStartedInstance(ctx, args, inst); err != nil {
+		return nil, err
+	}
+	return &environs.StartInstanceResult{
+		Instance: inst,
+		Hardware: startedInstanceHardware(spec, inst, rootDiskSize),
+	}, nil
+}
+
+// annotateStartInstanceError annotates an error met while starting an
+// instance. Unless it is a problem with the credential, the error is
+// marked as not specific to the availability zone.
+func annotateStartInstanceError(ctx context.ProviderCallContext, received error, annotation string) error {
+	if received == nil {
+		return nil
+	}
+	// If there is a problem with authentication/authorisation,
+	// we want a correctly typed error.
+	annotatedErr := errors.Annotate(
+		maybeConvertCredentialError(received, ctx),
+		annotation)
+	if common.IsCredentialNotValid(annotatedErr) {
+		return annotatedErr
+	}
+	return common.ZoneIndependentError(annotatedErr)
+}
+
+// runInstancesError returns the error to report when RunInstances
+// fails, classified for the provisioner.
+func runInstancesError(ctx context.ProviderCallContext, err error) error {
+	kind, code := runInstancesErrorKind(err)
+	if !isZoneOrSubnetConstrainedError(err) {
+		err = annotateStartInstanceError(ctx, err, "cannot run instances")
+	}
+	if code != "" {
+		err = common.ProvisioningError(err, kind, code)
+	}
+	return err
+}
+
+// findStartInstanceSpec returns the instance type and image with which
+// to start an instance with the given parameters.
+func (e *environ) findStartInstanceSpec(ctx context.ProviderCallContext, args environs.StartInstanceParams) (*instances.InstanceSpec, error) {
+	instanceTypes, err := e.supportedInstanceTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	instanceTypes = filterProviderSpecific(instanceTypes, args.Constraints)
+
+	spec, err := findInstanceSpec(
+		args.InstanceConfig.Controller != nil,
+		args.ImageMetadata,
+		instanceTypes,
+		&instances.InstanceConstraint{
+			Region:      e.cloud.Region,
+			Series:      args.InstanceConfig.Series,
+			Arches:      args.Tools.Arches(),
+			Constraints: args.Constraints,
+			Storage:     []string{ssdStorage, ebsStorage},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if spec.InstanceType.Deprecated {
+		logger.Infof("deprecated instance type specified: %s", spec.InstanceType.Name)
+	}
+	return spec, nil
+}
+
+// finishStartInstanceConfig completes the instance config with the
+// agent binaries matching the architecture of the chosen image.
+func (e *environ) finishStartInstanceConfig(args environs.StartInstanceParams, spec *instances.InstanceSpec) error {
+	arches := args.Tools.Arches()
+	tools, err := args.Tools.Match(tools.Filter{Arch: spec.Image.Arch})
+	if err != nil {
+		return common.ZoneIndependentError(
+			errors.Errorf("chosen architecture %v not present in %v", spec.Image.Arch, arches),
+		)
+	}
+	if err := args.InstanceConfig.SetTools(tools); err != nil {
+		return common.ZoneIndependentError(err)
+	}
+	if err := instancecfg.FinishInstanceConfig(args.InstanceConfig, e.Config()); err != nil {
+		return common.ZoneIndependentError(err)
+	}
+	return nil
+}
+
+// selectSubnetID returns the subnet in the availability zone to start
+// an instance in, or "" if EC2 can choose.
+func (e *environ) selectSubnetID(
+	ctx context.ProviderCallContext,
+	args environs.StartInstanceParams,
+	availabilityZone, placementSubnetID string,
+) (string, error) {
 	var subnetIDsForZone []string
 	var subnetErr error
-	if haveVPCID {
+	if isVPCIDSet(e.ecfg().vpcID()) {
 		var allowedSubnetIDs []string
 		if placementSubnetID != "" {
 			allowedSubnetIDs = []string{placementSubnetID}
@@ -584,54 +683,34 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 
 	switch {
 	case subnetErr != nil && errors.IsNotFound(subnetErr):
-		return nil, errors.Trace(subnetErr)
+		return "", errors.Trace(subnetErr)
 	case subnetErr != nil:
-		return nil, errors.Annotatef(maybeConvertCredentialError(subnetErr, ctx), "getting subnets for zone %q", availabilityZone)
+		return "", errors.Annotatef(maybeConvertCredentialError(subnetErr, ctx), "getting subnets for zone %q", availabilityZone)
 	case len(subnetIDsForZone) > 1:
 		// With multiple equally suitable subnets, picking one at random
 		// will allow for better instance spread within the same zone, and
 		// still work correctly if we happen to pick a constrained subnet
 		// (we'll just treat this the same way we treat constrained zones
 		// and retry).
-		runArgs.SubnetId = subnetIDsForZone[rand.Intn(len(subnetIDsForZone))]
-		logger.Debugf("selected random subnet %q from all matching in zone %q", runArgs.SubnetId, availabilityZone)
+		subnetID := subnetIDsForZone[rand.Intn(len(subnetIDsForZone))]
+		logger.Debugf("selected random subnet %q from all matching in zone %q", subnetID, availabilityZone)
+		return subnetID, nil
 	case len(subnetIDsForZone) == 1:
-		runArgs.SubnetId = subnetIDsForZone[0]
-		logger.Debugf("selected subnet %q in zone %q", runArgs.SubnetId, availabilityZone)
-	}
-
-	callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), nil)
-	instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
-	if err != nil {
-		if !isZoneOrSubnetConstrainedError(err) {
-			err = annotateWrapError(err, "cannot run instances")
-		}
-		return nil, err
-	}
-	if len(instResp.Instances) != 1 {
-		return nil, errors.Errorf("expected 1 started instance, got %d", len(instResp.Instances))
-	}
-
-	inst = &ec2Instance{
-		e:        e,
-		Instance: &instResp.Instances[0],
-	}
-	instAZ := inst.Instance.AvailZone
-	if haveVPCID {
-		instVPC := e.ecfg().vpcID()
-		instSubnet := inst.Instance.SubnetId
-		logger.Infof("started instance %q in AZ %q, subnet %q, VPC %q", inst.Id(), instAZ, instSubnet, instVPC)
-	} else {
-		logger.Infof("started instance %q in AZ %q", inst.Id(), instAZ)
+		logger.Debugf("selected subnet %q in zone %q", subnetIDsForZone[0], availabilityZone)
+		return subnetIDsForZone[0], nil
 	}
+	return "", nil
+}
 
-	// Tag instance, for accounting and identification.
+// tagStartedInstance tags the instance started for a machine, and its
+// root EBS volume if it has one, for accounting and identification.
+func (e *environ) tagStartedInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams, inst *ec2Instance) error {
 	instanceName := resourceName(
 		names.NewMachineTag(args.InstanceConfig.MachineId), e.Config().Name(),
 	)
 	args.InstanceConfig.Tags[tagName] = instanceName
 	if err := tagResources(e.ec2, ctx, args.InstanceConfig.Tags, string(inst.Id())); err != nil {
-		return nil, annotateWrapError(err, "tagging instance")
+		return annotateStartInstanceError(ctx, err, "tagging instance")
 	}
 
 	// Tag the machine's root EBS volume, if it has one.
@@ -644,11 +723,16 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 		)
 		tags[tagName] = instanceName + "-root"
 		if err := tagRootDisk(e.ec2, ctx, tags, inst.Instance); err != nil {
-			return nil, annotateWrapError(err, "tagging root disk")
+			return annotateStartInstanceError(ctx, err, "tagging root disk")
 		}
 	}
+	return nil
+}
 
-	hc := instance.HardwareCharacteristics{
+// startedInstanceHardware returns the hardware characteristics of an
+// instance started with the given spec.
+func startedInstanceHardware(spec *instances.InstanceSpec, inst *ec2Instance, rootDiskSize uint64) *instance.HardwareCharacteristics {
+	return &instance.HardwareCharacteristics{
 		Arch:     &spec.Image.Arch,
 		Mem:      &spec.InstanceType.Mem,
 		CpuCores: &spec.InstanceType.CpuCores,
@@ -657,10 +741,6 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 		// Tags currently not supported by EC2
 		AvailabilityZone: &inst.Instance.AvailZone,
 	}
-	return &environs.StartInstanceResult{
-		Instance: inst,
-		Hardware: &hc,
-	}, nil
 }
 
 func (e *environ) deriveAvailabilityZone(ctx context.ProviderCallContext, args environs.StartInstanceParams) (string, error) {
@@ -745,7 +825,7 @@ func (e *environ) instancePlacementZone(ctx context.ProviderCallContext, placeme
 // volumeAttachmentsZone determines the availability zone for each volume
 // identified in the volume attachment parameters, checking that they are
 // all the same, and returns the availability zone name.
-func volumeAttachmentsZone(ec2 *ec2.EC2, ctx context.ProviderCallContext, attachments []storage.VolumeAttachmentParams) (string, error) {
+func volumeAttachmentsZone(client *ec2.EC2, ctx context.ProviderCallContext, attachments []storage.VolumeAttachmentParams) (string, error) {
 	volumeIds := make([]string, 0, len(attachments))
 	for _, a := range attachments {
 		if a.Provider != EBS_ProviderType {
@@ -756,7 +836,11 @@ func volumeAttachmentsZone(ec2 *ec2.EC2, ctx context.ProviderCallContext, attach
 	if len(volumeIds) == 0 {
 		return "", nil
 	}
-	resp, err := ec2.Volumes(volumeIds, nil)
+	var resp *ec2.VolumesResp
+	err := callEC2("DescribeVolumes", func() (err error) {
+		resp, err = client.Volumes(volumeIds, nil)
+		return err
+	})
 	if err != nil {
 		return "", errors.Annotatef(maybeConvertCredentialError(err, ctx), "getting volume details (%s)", volumeIds)
 	}
@@ -775,8 +859,8 @@ func volumeAttachmentsZone(ec2 *ec2.EC2, ctx context.ProviderCallContext, attach
 }
 
 // tagResources calls ec2.CreateTags, tagging each of the specified resources
-// with the given tags. tagResources will retry for a short period of time
-// if it receives a *.NotFound error response from EC2.
+// with the given tags. tagResources will retry with backoff if it receives
+// a *.NotFound error response from EC2, or is throttled.
 func tagResources(e *ec2.EC2, ctx context.ProviderCallContext, tags map[string]string, resourceIds ...string) error {
 	if len(tags) == 0 {
 		return nil
@@ -785,13 +869,10 @@ func tagResources(e *ec2.EC2, ctx context.ProviderCallContext, tags map[string]s
 	for k, v := range tags {
 		ec2Tags = append(ec2Tags, ec2.Tag{k, v})
 	}
-	var err error
-	for a := shortAttempt.Start(); a.Next(); {
-		_, err = e.CreateTags(resourceIds, ec2Tags)
-		if err == nil || !strings.HasSuffix(ec2ErrCode(err), ".NotFound") {
-			return err
-		}
-	}
+	err := callEC2Eventually("CreateTags", func() error {
+		_, err := e.CreateTags(resourceIds, ec2Tags)
+		return err
+	})
 	return maybeConvertCredentialError(err, ctx)
 }
 
@@ -941,7 +1022,11 @@ func (e *environ) gatherInstances(
 	insts []instances.Instance,
 	filter *ec2.Filter,
 ) error {
-	resp, err := e.ec2.Instances(nil, filter)
+	var resp *ec2.InstancesResp
+	err := callEC2("DescribeInstances", func() (err error) {
+		resp, err = e.ec2.Instances(nil, filter)
+		return err
+	})
 	if err != nil {
 		return maybeConvertCredentialError(err, ctx)
 	}
@@ -1263,7 +1348,11 @@ func (e *environ) allInstanceIDs(ctx context.ProviderCallContext, filter *ec2.Fi
 }
 
 func (e *environ) allInstances(ctx context.ProviderCallContext, filter *ec2.Filter) ([]instances.Instance, error) {
-	resp, err := e.ec2.Instances(nil, filter)
+	var resp *ec2.InstancesResp
+	err := callEC2("DescribeInstances", func() (err error) {
+		resp, err = e.ec2.Instances(nil, filter)
+		return err
+	})
 	if err != nil {
 		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing instances")
 	}
@@ -1280,7 +1369,24 @@ func (e *environ) allInstances(ctx context.ProviderCallContext, filter *ec2.Filt
 
 // Destroy is part of the environs.Environ interface.
 func (e *environ) Destroy(ctx context.ProviderCallContext) error {
-	if err := common.Destroy(e, ctx); err != nil {
+	return e.destroy(ctx, common.Destroy)
+}
+
+// DestroyExceptVolumes is part of the environs.VolumePreservingDestroyer
+// interface.
+func (e *environ) DestroyExceptVolumes(ctx context.ProviderCallContext) error {
+	return e.destroy(ctx, common.DestroyExceptVolumes)
+}
+
+// destroy destroys the model's load balancers and security groups,
+// and everything else with the given function.
+func (e *environ) destroy(ctx context.ProviderCallContext, destroyRest func(environs.Environ, context.ProviderCallContext) error) error {
+	// Load balancers use the model's security groups, so they must go
+	// before the groups are cleaned up.
+	if err := e.deleteModelLoadBalancers(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	if err := destroyRest(e, ctx); err != nil {
 		return errors.Trace(maybeConvertCredentialError(err, ctx))
 	}
 	if err := e.cleanEnvironmentSecurityGroups(ctx); err != nil {
@@ -1981,6 +2087,21 @@ func isSubnetConstrainedError(err error) bool {
 	return false
 }
 
+// runInstancesErrorKind classifies errors from RunInstances that are
+// due to an account limit being reached, or to EC2 not having enough
+// capacity for the request. Limits will not be raised without the
+// user asking for it, whereas capacity may become available later.
+// An empty code is returned for all other errors.
+func runInstancesErrorKind(err error) (environs.ProvisioningErrorKind, string) {
+	switch ec2ErrCode(err) {
+	case "InstanceLimitExceeded", "VcpuLimitExceeded", "MaxSpotInstanceCountExceeded", "VolumeLimitExceeded":
+		return environs.ProvisioningErrorNeedsUserAction, "quota-exceeded"
+	case "InsufficientInstanceCapacity", "InsufficientHostCapacity", "InsufficientCapacity":
+		return environs.ProvisioningErrorRetryable, "insufficient-capacity"
+	}
+	return environs.ProvisioningErrorRetryable, ""
+}
+
 // If the err is of type *ec2.Error, ec2ErrCode returns
 // its code, otherwise it returns the empty string.
 func ec2ErrCode(err error) string {
@@ -1991,14 +2112,6 @@ func ec2ErrCode(err error) string {
 	return ec2err.Code
 }
 
-func (e *environ) AllocateContainerAddresses(ctx context.ProviderCallContext, hostInstanceID instance.Id, containerTag names.MachineTag, preparedInfo []network.InterfaceInfo) ([]network.InterfaceInfo, error) {
-	return nil, errors.NotSupportedf("container address allocation")
-}
-
-func (e *environ) ReleaseContainerAddresses(ctx context.ProviderCallContext, interfaces []network.ProviderInterfaceInfo) error {
-	return errors.NotSupportedf("container address allocation")
-}
-
 func (e *environ) supportedInstanceTypes(ctx context.ProviderCallContext) ([]instances.InstanceType, error) {
 	allInstanceTypes := ec2instancetypes.RegionInstanceTypes(e.cloud.Region)
 	if isVPCIDSet(e.ecfg().vpcID()) {
@@ -4,6 +4,7 @@
 package ec2
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	amzec2 "gopkg.in/amz.v3/ec2"
@@ -13,8 +14,10 @@ import (
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
 )
 
 // Ensure EC2 provider supports the expected interfaces,
@@ -102,6 +105,53 @@ func (*Suite) TestRootDiskBlockDeviceMapping(c *gc.C) {
 	}
 }
 
+func (*Suite) TestRootDiskIOPS(c *gc.C) {
+	for _, t := range []struct {
+		iops     uint64
+		expected amzec2.BlockDeviceMapping
+	}{{
+		iops: 3000,
+		expected: amzec2.BlockDeviceMapping{
+			VolumeSize: 100, DeviceName: "/dev/sda1", VolumeType: "io1", IOPS: 3000,
+		},
+	}, {
+		iops: 100,
+		expected: amzec2.BlockDeviceMapping{
+			VolumeSize: 8, DeviceName: "/dev/sda1", VolumeType: "io1", IOPS: 100,
+		},
+	}, {
+		iops: 50000,
+		expected: amzec2.BlockDeviceMapping{
+			VolumeSize: 667, DeviceName: "/dev/sda1", VolumeType: "io1", IOPS: 20000,
+		},
+	}} {
+		c.Logf("disk-iops=%d", t.iops)
+		cons := constraints.Value{DiskIOPS: pInt(t.iops)}
+		mappings := getBlockDeviceMappings(cons, "trusty", false)
+		expected := append([]amzec2.BlockDeviceMapping{t.expected}, commonInstanceStoreDisks...)
+		c.Assert(mappings, gc.DeepEquals, expected)
+	}
+}
+
+func (*Suite) TestFilterProviderSpecific(c *gc.C) {
+	var all []instances.InstanceType
+	for _, name := range []string{"t2.micro", "c4.large", "c5.large", "c5.18xlarge", "m5a.24xlarge"} {
+		all = append(all, instances.InstanceType{Name: name})
+	}
+	names := func(cons string) []string {
+		var result []string
+		for _, itype := range filterProviderSpecific(all, constraints.MustParse(cons)) {
+			result = append(result, itype.Name)
+		}
+		return result
+	}
+	c.Check(names(""), jc.DeepEquals, []string{"t2.micro", "c4.large", "c5.large", "c5.18xlarge", "m5a.24xlarge"})
+	c.Check(names("cpu-family=skylake"), jc.DeepEquals, []string{"c5.large", "c5.18xlarge"})
+	c.Check(names("disk-iops=1000"), jc.DeepEquals, []string{"c4.large", "c5.large", "c5.18xlarge", "m5a.24xlarge"})
+	c.Check(names("net-bandwidth=20G"), jc.DeepEquals, []string{"c5.18xlarge", "m5a.24xlarge"})
+	c.Check(names("net-bandwidth=20G cpu-family=epyc"), jc.DeepEquals, []string{"m5a.24xlarge"})
+}
+
 func pInt(i uint64) *uint64 {
 	return &i
 }
@@ -194,9 +244,55 @@ func (*Suite) TestSupportsSpaceDiscovery(c *gc.C) {
 
 func (*Suite) TestSupportsContainerAddresses(c *gc.C) {
 	callCtx := context.NewCloudCallContext()
-	var env *environ
+	env := &environ{ecfgUnlocked: &environConfig{Config: coretesting.ModelConfig(c)}}
 	supported, err := env.SupportsContainerAddresses(callCtx)
 	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
 	c.Assert(supported, jc.IsFalse)
 	c.Check(environs.SupportsContainerAddresses(callCtx, env), jc.IsFalse)
 }
+
+func (*Suite) TestSupportsContainerAddressesProviderNetworking(c *gc.C) {
+	callCtx := context.NewCloudCallContext()
+	cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{
+		config.ContainerNetworkingMethod: "provider",
+	})
+	env := &environ{ecfgUnlocked: &environConfig{Config: cfg}}
+	supported, err := env.SupportsContainerAddresses(callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(supported, jc.IsTrue)
+	c.Check(environs.SupportsContainerAddresses(callCtx, env), jc.IsTrue)
+}
+
+func (*Suite) TestNextFreeSubnetAddress(c *gc.C) {
+	address, err := nextFreeSubnetAddress("10.0.1.0/24", set.NewStrings("10.0.1.4", "10.0.1.5", "10.0.1.7"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(address, gc.Equals, "10.0.1.6")
+
+	address, err = nextFreeSubnetAddress("10.0.0.0/23", set.NewStrings())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(address, gc.Equals, "10.0.0.4")
+
+	_, err = nextFreeSubnetAddress("10.0.1.0/29", set.NewStrings("10.0.1.4", "10.0.1.5", "10.0.1.6"))
+	c.Assert(err, gc.ErrorMatches, `no free address found in subnet "10.0.1.0/29"`)
+}
+
+func (*Suite) TestSubnetReservedAddress(c *gc.C) {
+	gateway, err := subnetReservedAddress("172.31.16.0/20", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gateway, gc.Equals, "172.31.16.1")
+	resolver, err := subnetReservedAddress("10.0.0.255/23", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resolver, gc.Equals, "10.0.0.2")
+}
+
+func (*Suite) TestContainerInterfaceProviderId(c *gc.C) {
+	id := containerInterfaceProviderId("eni-0123abcd", "10.0.1.6")
+	c.Assert(id, gc.Equals, network.Id("eni-0123abcd:10.0.1.6"))
+	interfaceId, address, ok := parseContainerInterfaceProviderId(id)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(interfaceId, gc.Equals, "eni-0123abcd")
+	c.Assert(address, gc.Equals, "10.0.1.6")
+
+	_, _, ok = parseContainerInterfaceProviderId("eni-0123abcd")
+	c.Assert(ok, jc.IsFalse)
+}
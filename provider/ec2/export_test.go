@@ -57,6 +57,7 @@ var (
 	IsVPCNotUsableError            = isVPCNotUsableError
 	IsVPCNotRecommendedError       = isVPCNotRecommendedError
 	ShortAttempt                   = &shortAttempt
+	APIRetryStrategy               = &apiRetryStrategy
 	DestroyVolumeAttempt           = &destroyVolumeAttempt
 	DeleteSecurityGroupInsistently = &deleteSecurityGroupInsistently
 	TerminateInstancesById         = &terminateInstancesById
@@ -68,3 +69,24 @@ const VPCIDNone = vpcIDNone
 func VerifyCredentials(env environs.Environ, ctx context.ProviderCallContext) error {
 	return verifyCredentials(env.(*environ), ctx)
 }
+
+type patcher interface {
+	PatchValue(destination, source interface{})
+}
+
+// PatchNoLoadBalancers replaces the Elastic Load Balancing client with
+// one that finds no load balancers, as the test server does not
+// implement the API.
+func PatchNoLoadBalancers(p patcher) {
+	p.PatchValue(&newELBClient, func(environs.CloudSpec) elbAPI {
+		return noLoadBalancers{}
+	})
+}
+
+type noLoadBalancers struct {
+	elbAPI
+}
+
+func (noLoadBalancers) AllLoadBalancerTags() (map[string]map[string]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,267 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	awsec2 "gopkg.in/amz.v3/ec2"
+)
+
+// fault describes a fault injected by a faultInjector into the
+// responses of the fake EC2 server, as if they came from a misbehaving
+// or overloaded cloud.
+type fault struct {
+	// Action is the EC2 API action whose responses are faulted,
+	// e.g. "DescribeVolumes". The responses to all actions are
+	// faulted if it is empty.
+	Action string
+
+	// Times is the number of responses faulted. All responses are
+	// faulted if it is zero.
+	Times int
+
+	// Latency delays the faulted responses.
+	Latency time.Duration
+
+	// ErrorCode, if set, replaces the faulted responses with an EC2
+	// error with the code, e.g. "InvalidVolume.NotFound", returned
+	// with the HTTP status Status, or 400 if Status is zero.
+	ErrorCode string
+	Status    int
+
+	// Truncate, if positive, truncates the result sets of the faulted
+	// responses to this many items, as if the results were paginated.
+	Truncate int
+
+	// Modify, if set, is called to change the faulted responses.
+	Modify func(*http.Response) error
+
+	count int
+}
+
+// errorFault returns a fault replacing all responses to the action
+// with an EC2 error with the given code.
+func errorFault(action, code string) *fault {
+	return &fault{Action: action, ErrorCode: code}
+}
+
+// throttleFault returns a fault throttling the given number of
+// requests for the action, as EC2 does when its request rate limit
+// is exceeded.
+func throttleFault(action string, times int) *fault {
+	return &fault{
+		Action:    action,
+		Times:     times,
+		ErrorCode: "RequestLimitExceeded",
+		Status:    http.StatusServiceUnavailable,
+	}
+}
+
+// latencyFault returns a fault delaying all responses to the action.
+func latencyFault(action string, latency time.Duration) *fault {
+	return &fault{Action: action, Latency: latency}
+}
+
+// truncateFault returns a fault truncating the result sets of all
+// responses to the action to the given number of items.
+func truncateFault(action string, items int) *fault {
+	return &fault{Action: action, Truncate: items}
+}
+
+// describeVolumesFault returns a fault changing the volumes of all
+// DescribeVolumes responses with the given function.
+func describeVolumesFault(modify func(*awsec2.VolumesResp) error) *fault {
+	return &fault{
+		Action: "DescribeVolumes",
+		Modify: func(resp *http.Response) error {
+			var respDecoded struct {
+				XMLName xml.Name
+				awsec2.VolumesResp
+			}
+			if err := xml.NewDecoder(resp.Body).Decode(&respDecoded); err != nil {
+				return err
+			}
+			resp.Body.Close()
+
+			if err := modify(&respDecoded.VolumesResp); err != nil {
+				return err
+			}
+			return replaceResponseBody(resp, &respDecoded)
+		},
+	}
+}
+
+// faultInjector injects faults into the responses of the fake EC2
+// server, and counts the requests made for each action so that tests
+// can check how often the provider retried them.
+type faultInjector struct {
+	mu       sync.Mutex
+	faults   []*fault
+	requests map[string]int
+}
+
+// requestCount returns the number of requests made for the action.
+func (f *faultInjector) requestCount(action string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[action]
+}
+
+// modifyResponse is used as the ModifyResponse function of the proxy
+// in front of the fake EC2 server. The faults matching the request are
+// applied in order; a fault replacing the response with an error stops
+// the faults after it from being applied.
+func (f *faultInjector) modifyResponse(resp *http.Response) error {
+	action := resp.Request.URL.Query().Get("Action")
+
+	f.mu.Lock()
+	f.requests[action]++
+	var faults []fault
+	for _, flt := range f.faults {
+		if flt.Action != "" && flt.Action != action {
+			continue
+		}
+		if flt.Times > 0 && flt.count >= flt.Times {
+			continue
+		}
+		flt.count++
+		faults = append(faults, *flt)
+		if flt.ErrorCode != "" {
+			break
+		}
+	}
+	f.mu.Unlock()
+
+	for _, flt := range faults {
+		if flt.Latency > 0 {
+			time.Sleep(flt.Latency)
+		}
+		if flt.ErrorCode != "" {
+			resp.Body.Close()
+			resp.StatusCode = flt.Status
+			if resp.StatusCode == 0 {
+				resp.StatusCode = http.StatusBadRequest
+			}
+			return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
+				Code: flt.ErrorCode,
+			}}})
+		}
+		if flt.Truncate > 0 {
+			if err := truncateResultSets(resp, flt.Truncate); err != nil {
+				return err
+			}
+		}
+		if flt.Modify != nil {
+			if err := flt.Modify(resp); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// injectFaults makes the proxy in front of the fake EC2 server inject
+// the given faults into its responses, replacing any faults injected
+// before, and returns the injector.
+func (srv *localServer) injectFaults(faults ...*fault) *faultInjector {
+	injector := &faultInjector{
+		faults:   faults,
+		requests: make(map[string]int),
+	}
+	srv.proxy.ModifyResponse = injector.modifyResponse
+	return injector
+}
+
+// truncateResultSets truncates the result sets of the response, the
+// "...Set" elements directly within the response element, to the
+// given number of items.
+func truncateResultSets(resp *http.Response, items int) error {
+	var buf bytes.Buffer
+	decoder := xml.NewDecoder(resp.Body)
+	encoder := xml.NewEncoder(&buf)
+	var depth, kept int
+	var inSet bool
+	for {
+		token, err := decoder.RawToken()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			depth++
+			switch {
+			case depth == 2 && strings.HasSuffix(token.Name.Local, "Set"):
+				inSet, kept = true, 0
+			case depth == 3 && inSet && token.Name.Local == "item":
+				if kept >= items {
+					if err := skipElement(decoder); err != nil {
+						return err
+					}
+					depth--
+					continue
+				}
+				kept++
+			}
+		case xml.EndElement:
+			if depth == 2 {
+				inSet = false
+			}
+			depth--
+		}
+		if err := encoder.EncodeToken(token); err != nil {
+			return err
+		}
+	}
+	if err := encoder.Flush(); err != nil {
+		return err
+	}
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(&buf)
+	resp.ContentLength = int64(buf.Len())
+	resp.Header.Del("Content-Length")
+	return nil
+}
+
+// skipElement skips the rest of the element whose start was last read
+// from the decoder.
+func skipElement(decoder *xml.Decoder) error {
+	for depth := 1; depth > 0; {
+		token, err := decoder.RawToken()
+		if err != nil {
+			return err
+		}
+		switch token.(type) {
+		case xml.StartElement:
+			depth++
+		case xml.EndElement:
+			depth--
+		}
+	}
+	return nil
+}
+
+func replaceResponseBody(resp *http.Response, value interface{}) error {
+	var buf bytes.Buffer
+	if err := xml.NewEncoder(&buf).Encode(value); err != nil {
+		return err
+	}
+	resp.Body = ioutil.NopCloser(&buf)
+	resp.ContentLength = int64(buf.Len())
+	resp.Header.Del("Content-Length")
+	return nil
+}
+
+type ec2Errors struct {
+	Errors []awsec2.Error `xml:"Errors>Error"`
+}
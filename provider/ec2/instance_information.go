@@ -30,3 +30,21 @@ func (e *environ) InstanceTypes(ctx context.ProviderCallContext, c constraints.V
 		CostDivisor:   1000,
 		CostCurrency:  "USD"}, nil
 }
+
+var _ environs.InstancePricer = (*environ)(nil)
+
+// InstancePricing implements InstancePricer.
+func (e *environ) InstancePricing(ctx context.ProviderCallContext, c constraints.Value) (environs.InstancePricing, error) {
+	iTypes, err := e.InstanceTypes(ctx, c)
+	if err != nil {
+		return environs.InstancePricing{}, errors.Trace(err)
+	}
+	// Matching instance types are sorted by cost, and the cheapest
+	// is the one the provisioner chooses.
+	iType := iTypes.InstanceTypes[0]
+	return environs.InstancePricing{
+		InstanceType: iType.Name,
+		HourlyCost:   float64(iType.Cost) / float64(iTypes.CostDivisor),
+		Currency:     iTypes.CostCurrency,
+	}, nil
+}
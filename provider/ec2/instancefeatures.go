@@ -0,0 +1,206 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/instances"
+)
+
+// instanceFamily describes the features shared by the instance types of
+// an EC2 instance family, which the pricing data the instance types are
+// generated from does not record.
+type instanceFamily struct {
+	// cpuFamily is the processor generation of the family's
+	// instance types.
+	cpuFamily string
+
+	// ebsOptimized is true if the family's instance types are EBS
+	// optimised by default, giving them dedicated bandwidth for
+	// provisioned IOPS volumes.
+	ebsOptimized bool
+
+	// netBandwidth is the sustained network bandwidth, in Mbit/s, of
+	// the family's instance types that are not in netBandwidthBySize.
+	// Smaller instance types can burst above this, but are not
+	// guaranteed to.
+	netBandwidth uint64
+
+	// netBandwidthBySize holds the network bandwidth, in Mbit/s, of
+	// the family's larger instance types, keyed by size.
+	netBandwidthBySize map[string]uint64
+}
+
+// instanceFamilies holds the features of the current generation
+// instance families. The network bandwidths above 10Gbit/s require
+// the Elastic Network Adapter (ENA), which all supported Ubuntu
+// images enable.
+var instanceFamilies = map[string]instanceFamily{
+	"a1": {
+		cpuFamily:          "graviton",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"4xlarge": 10000},
+	},
+	"c4": {
+		cpuFamily:          "haswell",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"8xlarge": 10000},
+	},
+	"c5": {
+		cpuFamily:          "skylake",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"9xlarge": 10000, "18xlarge": 25000},
+	},
+	"c5d": {
+		cpuFamily:          "skylake",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"9xlarge": 10000, "18xlarge": 25000},
+	},
+	"c5n": {
+		cpuFamily:          "skylake",
+		ebsOptimized:       true,
+		netBandwidth:       3000,
+		netBandwidthBySize: map[string]uint64{"9xlarge": 50000, "18xlarge": 100000},
+	},
+	"i3": {
+		cpuFamily:          "broadwell",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"8xlarge": 10000, "16xlarge": 25000, "metal": 25000},
+	},
+	"m4": {
+		cpuFamily:          "broadwell",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"10xlarge": 10000, "16xlarge": 25000},
+	},
+	"m5": {
+		cpuFamily:          "skylake",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"12xlarge": 10000, "24xlarge": 25000},
+	},
+	"m5a": {
+		cpuFamily:          "epyc",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"12xlarge": 10000, "24xlarge": 20000},
+	},
+	"m5d": {
+		cpuFamily:          "skylake",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"12xlarge": 10000, "24xlarge": 25000},
+	},
+	"p3": {
+		cpuFamily:          "broadwell",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"8xlarge": 10000, "16xlarge": 25000},
+	},
+	"r4": {
+		cpuFamily:          "broadwell",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"8xlarge": 10000, "16xlarge": 25000},
+	},
+	"r5": {
+		cpuFamily:          "skylake",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"12xlarge": 10000, "24xlarge": 25000},
+	},
+	"r5a": {
+		cpuFamily:          "epyc",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"12xlarge": 10000, "24xlarge": 20000},
+	},
+	"r5d": {
+		cpuFamily:          "skylake",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"12xlarge": 10000, "24xlarge": 25000},
+	},
+	"t3": {
+		cpuFamily:    "skylake",
+		ebsOptimized: true,
+		netBandwidth: 100,
+	},
+	"x1": {
+		cpuFamily:          "haswell",
+		ebsOptimized:       true,
+		netBandwidth:       10000,
+		netBandwidthBySize: map[string]uint64{"32xlarge": 25000},
+	},
+	"z1d": {
+		cpuFamily:          "skylake",
+		ebsOptimized:       true,
+		netBandwidth:       1000,
+		netBandwidthBySize: map[string]uint64{"6xlarge": 10000, "12xlarge": 25000},
+	},
+}
+
+// cpuFamilies returns the CPU families of the known instance families,
+// which make up the cpu-family constraint vocabulary.
+func cpuFamilies() []string {
+	result := set.NewStrings()
+	for _, family := range instanceFamilies {
+		result.Add(family.cpuFamily)
+	}
+	return result.SortedValues()
+}
+
+// matchesProviderSpecific reports whether the instance type satisfies
+// the cpu-family, disk-iops and net-bandwidth constraints. Instance
+// types of unknown families satisfy none of them.
+func matchesProviderSpecific(itype instances.InstanceType, cons constraints.Value) bool {
+	if !cons.HasCpuFamily() && !cons.HasDiskIOPS() && !cons.HasNetBandwidth() {
+		return true
+	}
+	parts := strings.SplitN(itype.Name, ".", 2)
+	if len(parts) != 2 {
+		return false
+	}
+	family, ok := instanceFamilies[parts[0]]
+	if !ok {
+		return false
+	}
+	if cons.HasCpuFamily() && family.cpuFamily != *cons.CpuFamily {
+		return false
+	}
+	if cons.HasDiskIOPS() && !family.ebsOptimized {
+		return false
+	}
+	if cons.HasNetBandwidth() {
+		bandwidth, ok := family.netBandwidthBySize[parts[1]]
+		if !ok {
+			bandwidth = family.netBandwidth
+		}
+		if bandwidth < *cons.NetBandwidth {
+			return false
+		}
+	}
+	return true
+}
+
+// filterProviderSpecific returns the instance types that satisfy the
+// cpu-family, disk-iops and net-bandwidth constraints.
+func filterProviderSpecific(instanceTypes []instances.InstanceType, cons constraints.Value) []instances.InstanceType {
+	var result []instances.InstanceType
+	for _, itype := range instanceTypes {
+		if matchesProviderSpecific(itype, cons) {
+			result = append(result, itype)
+		}
+	}
+	return result
+}
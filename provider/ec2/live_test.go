@@ -79,7 +79,7 @@ type LiveTests struct {
 func (t *LiveTests) SetUpSuite(c *gc.C) {
 	// Upload arches that ec2 supports; add to this
 	// as ec2 coverage expands.
-	t.UploadArches = []string{arch.AMD64, arch.I386}
+	t.UploadArches = []string{arch.AMD64, arch.ARM64, arch.I386}
 	t.BaseSuite.SetUpSuite(c)
 	t.LiveTests.SetUpSuite(c)
 	t.BaseSuite.PatchValue(&jujuversion.Current, coretesting.FakeVersionNumber)
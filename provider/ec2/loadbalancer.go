@@ -0,0 +1,529 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"crypto/sha256"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/network"
+)
+
+var _ environs.LoadBalancers = (*environ)(nil)
+
+// maxELBListeners is the maximum number of listeners that may be
+// configured on a classic load balancer. Each opened port needs its own
+// listener, so applications with wide port ranges cannot be load
+// balanced.
+const maxELBListeners = 100
+
+// elbListener describes a classic load balancer listener forwarding a
+// port on the load balancer to the same port on the instances.
+type elbListener struct {
+	Protocol         string `xml:"Listener>Protocol"`
+	LoadBalancerPort int    `xml:"Listener>LoadBalancerPort"`
+	InstanceProtocol string `xml:"Listener>InstanceProtocol"`
+	InstancePort     int    `xml:"Listener>InstancePort"`
+}
+
+// elbLoadBalancer holds the details of a classic load balancer.
+type elbLoadBalancer struct {
+	Name              string        `xml:"LoadBalancerName"`
+	DNSName           string        `xml:"DNSName"`
+	Listeners         []elbListener `xml:"ListenerDescriptions>member"`
+	Instances         []string      `xml:"Instances>member>InstanceId"`
+	AvailabilityZones []string      `xml:"AvailabilityZones>member"`
+	Subnets           []string      `xml:"Subnets>member"`
+}
+
+// elbAPI is the subset of the Elastic Load Balancing (classic) API
+// used to manage application load balancers.
+type elbAPI interface {
+	// LoadBalancer returns the named load balancer. If it does not
+	// exist then an error satisfying errors.IsNotFound is returned.
+	LoadBalancer(name string) (*elbLoadBalancer, error)
+	// CreateLoadBalancer creates the named load balancer in either the
+	// given VPC subnets or EC2-Classic availability zones, tagged with
+	// the given tags, and returns its DNS name.
+	CreateLoadBalancer(name string, listeners []elbListener, subnets, zones, groups []string, lbTags map[string]string) (string, error)
+	// AddZones enables the load balancer in the given VPC subnets or
+	// EC2-Classic availability zones.
+	AddZones(name string, subnets, zones []string) error
+	CreateListeners(name string, listeners []elbListener) error
+	DeleteListeners(name string, ports []int) error
+	RegisterInstances(name string, ids []string) error
+	DeregisterInstances(name string, ids []string) error
+	// DeleteLoadBalancer deletes the named load balancer. Deleting a
+	// load balancer that does not exist is not an error.
+	DeleteLoadBalancer(name string) error
+	// AllLoadBalancerTags returns the tags of every load balancer in
+	// the region, keyed by load balancer name.
+	AllLoadBalancerTags() (map[string]map[string]string, error)
+}
+
+// newELBClient returns an elbAPI for the given cloud. It is a variable
+// so tests can replace it.
+var newELBClient = func(cloud environs.CloudSpec) elbAPI {
+	credentialAttrs := cloud.Credential.Attributes()
+	endpoint := fmt.Sprintf("https://elasticloadbalancing.%s.amazonaws.com", cloud.Region)
+	if strings.HasPrefix(cloud.Region, "cn-") {
+		endpoint += ".cn"
+	}
+	return &elbClient{
+		auth: aws.Auth{
+			AccessKey: credentialAttrs["access-key"],
+			SecretKey: credentialAttrs["secret-key"],
+		},
+		endpoint:   endpoint,
+		sign:       aws.SignV4Factory(cloud.Region, "elasticloadbalancing"),
+		httpClient: &http.Client{Timeout: elbRequestTimeout},
+	}
+}
+
+// elbRequestTimeout is how long a request to the Elastic Load
+// Balancing API may take, so that an unresponsive endpoint can't
+// block the firewaller indefinitely.
+const elbRequestTimeout = time.Minute
+
+// loadBalancerName returns the name of the load balancer for the named
+// application. Load balancer names are limited to 32 characters, so the
+// name is derived from a hash of the model UUID and application name.
+func (e *environ) loadBalancerName(applicationName string) string {
+	sum := sha256.Sum256([]byte(e.uuid() + ":" + applicationName))
+	return fmt.Sprintf("juju-%x", sum)[:32]
+}
+
+// EnsureLoadBalancer is part of the environs.LoadBalancers interface.
+//
+// Classic load balancers only support TCP, and need a listener for
+// each port, so UDP port ranges are ignored.
+func (e *environ) EnsureLoadBalancer(ctx context.ProviderCallContext, spec environs.LoadBalancerSpec) ([]network.Address, error) {
+	listeners, err := elbListeners(spec.PortRanges)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ids := make([]string, len(spec.Instances))
+	for i, id := range spec.Instances {
+		ids[i] = string(id)
+	}
+	resp, err := e.ec2.Instances(ids, nil)
+	if err != nil {
+		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "getting load balancer instances")
+	}
+	subnetsByZone := make(map[string]string)
+	zones := set.NewStrings()
+	groups := set.NewStrings()
+	for _, reservation := range resp.Reservations {
+		for _, inst := range reservation.Instances {
+			if inst.SubnetId == "" {
+				zones.Add(inst.AvailZone)
+				continue
+			}
+			// A VPC load balancer may only be attached to one
+			// subnet in each availability zone.
+			if _, ok := subnetsByZone[inst.AvailZone]; !ok {
+				subnetsByZone[inst.AvailZone] = inst.SubnetId
+			}
+			// The instances' security groups allow ingress to the
+			// ports opened on them, so reusing them for the load
+			// balancer admits the same traffic.
+			for _, group := range inst.SecurityGroups {
+				groups.Add(group.Id)
+			}
+		}
+	}
+	subnets := set.NewStrings()
+	for _, subnet := range subnetsByZone {
+		subnets.Add(subnet)
+	}
+
+	client := newELBClient(e.cloud)
+	name := e.loadBalancerName(spec.ApplicationName)
+	lb, err := client.LoadBalancer(name)
+	if errors.IsNotFound(err) {
+		dnsName, err := client.CreateLoadBalancer(
+			name, listeners, subnets.SortedValues(), zones.SortedValues(), groups.SortedValues(),
+			map[string]string{
+				tags.JujuModel:       e.uuid(),
+				tags.JujuApplication: spec.ApplicationName,
+			},
+		)
+		if err != nil {
+			return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "creating load balancer")
+		}
+		lb = &elbLoadBalancer{Name: name, DNSName: dnsName}
+	} else if err != nil {
+		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "getting load balancer")
+	} else {
+		if err := updateELBListeners(client, lb, listeners); err != nil {
+			return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "updating load balancer listeners")
+		}
+		newSubnets := subnets.Difference(set.NewStrings(lb.Subnets...))
+		newZones := zones.Difference(set.NewStrings(lb.AvailabilityZones...))
+		if !newSubnets.IsEmpty() || !newZones.IsEmpty() {
+			if err := client.AddZones(name, newSubnets.SortedValues(), newZones.SortedValues()); err != nil {
+				return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "updating load balancer zones")
+			}
+		}
+	}
+
+	wanted := set.NewStrings(ids...)
+	existing := set.NewStrings(lb.Instances...)
+	if added := wanted.Difference(existing); !added.IsEmpty() {
+		if err := client.RegisterInstances(name, added.SortedValues()); err != nil {
+			return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "registering load balancer instances")
+		}
+	}
+	if removed := existing.Difference(wanted); !removed.IsEmpty() {
+		if err := client.DeregisterInstances(name, removed.SortedValues()); err != nil {
+			return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "deregistering load balancer instances")
+		}
+	}
+	return []network.Address{network.NewScopedAddress(lb.DNSName, network.ScopePublic)}, nil
+}
+
+// DeleteLoadBalancer is part of the environs.LoadBalancers interface.
+func (e *environ) DeleteLoadBalancer(ctx context.ProviderCallContext, applicationName string) error {
+	err := newELBClient(e.cloud).DeleteLoadBalancer(e.loadBalancerName(applicationName))
+	return errors.Trace(maybeConvertCredentialError(err, ctx))
+}
+
+// deleteModelLoadBalancers deletes all of the load balancers tagged
+// with the model's UUID, whether or not the firewaller still knows
+// about them.
+func (e *environ) deleteModelLoadBalancers(ctx context.ProviderCallContext) error {
+	client := newELBClient(e.cloud)
+	lbTags, err := client.AllLoadBalancerTags()
+	if err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "listing load balancers")
+	}
+	for name, t := range lbTags {
+		if t[tags.JujuModel] != e.uuid() {
+			continue
+		}
+		if err := client.DeleteLoadBalancer(name); err != nil {
+			return errors.Annotatef(maybeConvertCredentialError(err, ctx), "deleting load balancer %q", name)
+		}
+	}
+	return nil
+}
+
+// elbListeners returns the listeners needed to forward the TCP ports in
+// the port ranges, sorted by port.
+func elbListeners(portRanges []corenetwork.PortRange) ([]elbListener, error) {
+	ports := set.NewInts()
+	for _, portRange := range portRanges {
+		if strings.ToLower(portRange.Protocol) != "tcp" {
+			logger.Warningf("load balancers do not support %v, ignoring", portRange)
+			continue
+		}
+		for port := portRange.FromPort; port <= portRange.ToPort; port++ {
+			ports.Add(port)
+		}
+	}
+	if ports.Size() == 0 {
+		return nil, errors.NotSupportedf("load balancing without TCP ports")
+	}
+	if ports.Size() > maxELBListeners {
+		return nil, errors.NotSupportedf("load balancing more than %d ports", maxELBListeners)
+	}
+	var listeners []elbListener
+	for _, port := range ports.SortedValues() {
+		listeners = append(listeners, elbListener{
+			Protocol:         "TCP",
+			LoadBalancerPort: port,
+			InstanceProtocol: "TCP",
+			InstancePort:     port,
+		})
+	}
+	return listeners, nil
+}
+
+// updateELBListeners replaces the load balancer's listeners with the
+// ones passed in, leaving listeners that are already correct alone.
+func updateELBListeners(client elbAPI, lb *elbLoadBalancer, listeners []elbListener) error {
+	existing := make(map[int]elbListener)
+	for _, listener := range lb.Listeners {
+		existing[listener.LoadBalancerPort] = listener
+	}
+	var added []elbListener
+	var removed []int
+	for _, listener := range listeners {
+		current, ok := existing[listener.LoadBalancerPort]
+		delete(existing, listener.LoadBalancerPort)
+		if ok && current == listener {
+			continue
+		}
+		if ok {
+			removed = append(removed, listener.LoadBalancerPort)
+		}
+		added = append(added, listener)
+	}
+	for port := range existing {
+		removed = append(removed, port)
+	}
+	sort.Ints(removed)
+	if len(removed) > 0 {
+		if err := client.DeleteListeners(lb.Name, removed); err != nil {
+			return err
+		}
+	}
+	if len(added) > 0 {
+		return client.CreateListeners(lb.Name, added)
+	}
+	return nil
+}
+
+// elbClient implements elbAPI by making requests to the Elastic Load
+// Balancing query API.
+type elbClient struct {
+	auth       aws.Auth
+	endpoint   string
+	sign       func(*http.Request, aws.Auth) error
+	httpClient *http.Client
+}
+
+const elbAPIVersion = "2012-06-01"
+
+func (c *elbClient) LoadBalancer(name string) (*elbLoadBalancer, error) {
+	params := url.Values{}
+	params.Set("LoadBalancerNames.member.1", name)
+	var resp struct {
+		LoadBalancers []elbLoadBalancer `xml:"DescribeLoadBalancersResult>LoadBalancerDescriptions>member"`
+	}
+	err := c.query("DescribeLoadBalancers", params, &resp)
+	if ec2ErrCode(err) == "LoadBalancerNotFound" || (err == nil && len(resp.LoadBalancers) == 0) {
+		return nil, errors.NotFoundf("load balancer %q", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resp.LoadBalancers[0], nil
+}
+
+func (c *elbClient) CreateLoadBalancer(name string, listeners []elbListener, subnets, zones, groups []string, lbTags map[string]string) (string, error) {
+	params := url.Values{}
+	params.Set("LoadBalancerName", name)
+	addListenerParams(params, listeners)
+	addMemberParams(params, "Subnets", subnets)
+	addMemberParams(params, "AvailabilityZones", zones)
+	addMemberParams(params, "SecurityGroups", groups)
+	addTagParams(params, lbTags)
+	var resp struct {
+		DNSName string `xml:"CreateLoadBalancerResult>DNSName"`
+	}
+	if err := c.query("CreateLoadBalancer", params, &resp); err != nil {
+		return "", err
+	}
+	return resp.DNSName, nil
+}
+
+func (c *elbClient) AddZones(name string, subnets, zones []string) error {
+	if len(subnets) > 0 {
+		params := url.Values{}
+		params.Set("LoadBalancerName", name)
+		addMemberParams(params, "Subnets", subnets)
+		if err := c.query("AttachLoadBalancerToSubnets", params, nil); err != nil {
+			return err
+		}
+	}
+	if len(zones) > 0 {
+		params := url.Values{}
+		params.Set("LoadBalancerName", name)
+		addMemberParams(params, "AvailabilityZones", zones)
+		if err := c.query("EnableAvailabilityZonesForLoadBalancer", params, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *elbClient) CreateListeners(name string, listeners []elbListener) error {
+	params := url.Values{}
+	params.Set("LoadBalancerName", name)
+	addListenerParams(params, listeners)
+	return c.query("CreateLoadBalancerListeners", params, nil)
+}
+
+func (c *elbClient) DeleteListeners(name string, ports []int) error {
+	params := url.Values{}
+	params.Set("LoadBalancerName", name)
+	for i, port := range ports {
+		params.Set(fmt.Sprintf("LoadBalancerPorts.member.%d", i+1), strconv.Itoa(port))
+	}
+	return c.query("DeleteLoadBalancerListeners", params, nil)
+}
+
+func (c *elbClient) RegisterInstances(name string, ids []string) error {
+	params := url.Values{}
+	params.Set("LoadBalancerName", name)
+	addInstanceParams(params, ids)
+	return c.query("RegisterInstancesWithLoadBalancer", params, nil)
+}
+
+func (c *elbClient) DeregisterInstances(name string, ids []string) error {
+	params := url.Values{}
+	params.Set("LoadBalancerName", name)
+	addInstanceParams(params, ids)
+	return c.query("DeregisterInstancesFromLoadBalancer", params, nil)
+}
+
+func (c *elbClient) DeleteLoadBalancer(name string) error {
+	params := url.Values{}
+	params.Set("LoadBalancerName", name)
+	// DeleteLoadBalancer succeeds if the load balancer does not exist.
+	return c.query("DeleteLoadBalancer", params, nil)
+}
+
+// maxELBDescribeTags is the maximum number of load balancers whose
+// tags may be described in a single request.
+const maxELBDescribeTags = 20
+
+func (c *elbClient) AllLoadBalancerTags() (map[string]map[string]string, error) {
+	var lbNames []string
+	marker := ""
+	for {
+		params := url.Values{}
+		if marker != "" {
+			params.Set("Marker", marker)
+		}
+		var resp struct {
+			Names      []string `xml:"DescribeLoadBalancersResult>LoadBalancerDescriptions>member>LoadBalancerName"`
+			NextMarker string   `xml:"DescribeLoadBalancersResult>NextMarker"`
+		}
+		if err := c.query("DescribeLoadBalancers", params, &resp); err != nil {
+			return nil, err
+		}
+		lbNames = append(lbNames, resp.Names...)
+		if resp.NextMarker == "" {
+			break
+		}
+		marker = resp.NextMarker
+	}
+
+	result := make(map[string]map[string]string)
+	for len(lbNames) > 0 {
+		batch := lbNames
+		if len(batch) > maxELBDescribeTags {
+			batch = batch[:maxELBDescribeTags]
+		}
+		lbNames = lbNames[len(batch):]
+
+		params := url.Values{}
+		addMemberParams(params, "LoadBalancerNames", batch)
+		var resp struct {
+			Descriptions []struct {
+				Name string `xml:"LoadBalancerName"`
+				Tags []struct {
+					Key   string `xml:"Key"`
+					Value string `xml:"Value"`
+				} `xml:"Tags>member"`
+			} `xml:"DescribeTagsResult>TagDescriptions>member"`
+		}
+		if err := c.query("DescribeTags", params, &resp); err != nil {
+			return nil, err
+		}
+		for _, d := range resp.Descriptions {
+			lbTags := make(map[string]string)
+			for _, tag := range d.Tags {
+				lbTags[tag.Key] = tag.Value
+			}
+			result[d.Name] = lbTags
+		}
+	}
+	return result, nil
+}
+
+func addMemberParams(params url.Values, prefix string, values []string) {
+	for i, value := range values {
+		params.Set(fmt.Sprintf("%s.member.%d", prefix, i+1), value)
+	}
+}
+
+func addListenerParams(params url.Values, listeners []elbListener) {
+	for i, listener := range listeners {
+		prefix := fmt.Sprintf("Listeners.member.%d.", i+1)
+		params.Set(prefix+"Protocol", listener.Protocol)
+		params.Set(prefix+"LoadBalancerPort", strconv.Itoa(listener.LoadBalancerPort))
+		params.Set(prefix+"InstanceProtocol", listener.InstanceProtocol)
+		params.Set(prefix+"InstancePort", strconv.Itoa(listener.InstancePort))
+	}
+}
+
+func addTagParams(params url.Values, lbTags map[string]string) {
+	keys := make([]string, 0, len(lbTags))
+	for key := range lbTags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		prefix := fmt.Sprintf("Tags.member.%d.", i+1)
+		params.Set(prefix+"Key", key)
+		params.Set(prefix+"Value", lbTags[key])
+	}
+}
+
+func addInstanceParams(params url.Values, ids []string) {
+	for i, id := range ids {
+		params.Set(fmt.Sprintf("Instances.member.%d.InstanceId", i+1), id)
+	}
+}
+
+// query makes a signed request for the action to the Elastic Load
+// Balancing API, decoding the response into resp if it is not nil.
+// Errors reported by the API are returned unwrapped as *ec2.Error, so
+// they can be inspected in the same way as errors from the EC2 API.
+func (c *elbClient) query(action string, params url.Values, resp interface{}) error {
+	params.Set("Action", action)
+	params.Set("Version", elbAPIVersion)
+	req, err := http.NewRequest("GET", c.endpoint+"/?"+params.Encode(), nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := c.sign(req, c.auth); err != nil {
+		return errors.Trace(err)
+	}
+	r, err := c.httpClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		var errResp struct {
+			Code      string `xml:"Error>Code"`
+			Message   string `xml:"Error>Message"`
+			RequestId string `xml:"RequestId"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&errResp); err != nil {
+			return errors.Errorf("%s failed with status %q", action, r.Status)
+		}
+		return &ec2.Error{
+			StatusCode: r.StatusCode,
+			Code:       errResp.Code,
+			Message:    errResp.Message,
+			RequestId:  errResp.RequestId,
+		}
+	}
+	if resp == nil {
+		return nil
+	}
+	return errors.Trace(xml.NewDecoder(r.Body).Decode(resp))
+}
@@ -0,0 +1,114 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"net/url"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/testing"
+)
+
+type loadBalancerSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&loadBalancerSuite{})
+
+func tcpListener(port int) elbListener {
+	return elbListener{
+		Protocol:         "TCP",
+		LoadBalancerPort: port,
+		InstanceProtocol: "TCP",
+		InstancePort:     port,
+	}
+}
+
+func (s *loadBalancerSuite) TestELBListeners(c *gc.C) {
+	listeners, err := elbListeners([]corenetwork.PortRange{
+		{FromPort: 8080, ToPort: 8081, Protocol: "tcp"},
+		{FromPort: 53, ToPort: 53, Protocol: "udp"},
+		{FromPort: 80, ToPort: 80, Protocol: "TCP"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(listeners, jc.DeepEquals, []elbListener{
+		tcpListener(80), tcpListener(8080), tcpListener(8081),
+	})
+}
+
+func (s *loadBalancerSuite) TestELBListenersNoTCP(c *gc.C) {
+	_, err := elbListeners([]corenetwork.PortRange{
+		{FromPort: 53, ToPort: 53, Protocol: "udp"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *loadBalancerSuite) TestELBListenersTooMany(c *gc.C) {
+	_, err := elbListeners([]corenetwork.PortRange{
+		{FromPort: 8000, ToPort: 8000 + maxELBListeners, Protocol: "tcp"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+type fakeELB struct {
+	elbAPI
+	created []elbListener
+	deleted []int
+}
+
+func (f *fakeELB) CreateListeners(name string, listeners []elbListener) error {
+	f.created = listeners
+	return nil
+}
+
+func (f *fakeELB) DeleteListeners(name string, ports []int) error {
+	f.deleted = ports
+	return nil
+}
+
+func (s *loadBalancerSuite) TestUpdateELBListeners(c *gc.C) {
+	changed := tcpListener(443)
+	changed.InstancePort = 8443
+	lb := &elbLoadBalancer{
+		Name:      "lb",
+		Listeners: []elbListener{tcpListener(80), changed, tcpListener(22)},
+	}
+	client := &fakeELB{}
+	err := updateELBListeners(client, lb, []elbListener{
+		tcpListener(80), tcpListener(443), tcpListener(8080),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.deleted, jc.DeepEquals, []int{22, 443})
+	c.Check(client.created, jc.DeepEquals, []elbListener{tcpListener(443), tcpListener(8080)})
+}
+
+func (s *loadBalancerSuite) TestUpdateELBListenersUnchanged(c *gc.C) {
+	lb := &elbLoadBalancer{
+		Name:      "lb",
+		Listeners: []elbListener{tcpListener(80)},
+	}
+	client := &fakeELB{}
+	err := updateELBListeners(client, lb, []elbListener{tcpListener(80)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(client.deleted, gc.HasLen, 0)
+	c.Check(client.created, gc.HasLen, 0)
+}
+
+func (s *loadBalancerSuite) TestAddTagParams(c *gc.C) {
+	params := url.Values{}
+	addTagParams(params, map[string]string{
+		"juju-model-uuid":  "deadbeef",
+		"juju-application": "wordpress",
+	})
+	c.Check(params, jc.DeepEquals, url.Values{
+		"Tags.member.1.Key":   {"juju-application"},
+		"Tags.member.1.Value": {"wordpress"},
+		"Tags.member.2.Key":   {"juju-model-uuid"},
+		"Tags.member.2.Value": {"deadbeef"},
+	})
+}
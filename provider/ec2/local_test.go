@@ -12,6 +12,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/clock"
 	"github.com/juju/collections/set"
@@ -97,12 +98,13 @@ func (t *localLiveSuite) SetUpSuite(c *gc.C) {
 
 	// Upload arches that ec2 supports; add to this
 	// as ec2 coverage expands.
-	t.UploadArches = []string{arch.AMD64, arch.I386}
+	t.UploadArches = []string{arch.AMD64, arch.ARM64, arch.I386}
 	t.TestConfig = localConfigAttrs
 	imagetesting.PatchOfficialDataSources(&t.BaseSuite.CleanupSuite, "test:")
 	t.BaseSuite.PatchValue(&imagemetadata.SimplestreamsImagesPublicKey, sstesting.SignedMetadataPublicKey)
 	t.BaseSuite.PatchValue(&keys.JujuPublicKey, sstesting.SignedMetadataPublicKey)
 	t.BaseSuite.PatchValue(ec2.DeleteSecurityGroupInsistently, deleteSecurityGroupForTestFunc)
+	ec2.PatchNoLoadBalancers(&t.BaseSuite)
 	t.srv.createRootDisks = true
 	t.srv.startServer(c)
 
@@ -232,7 +234,7 @@ func (t *localServerSuite) SetUpSuite(c *gc.C) {
 
 	// Upload arches that ec2 supports; add to this
 	// as ec2 coverage expands.
-	t.UploadArches = []string{arch.AMD64, arch.I386}
+	t.UploadArches = []string{arch.AMD64, arch.ARM64, arch.I386}
 	t.TestConfig = localConfigAttrs
 	imagetesting.PatchOfficialDataSources(&t.BaseSuite.CleanupSuite, "test:")
 	t.BaseSuite.PatchValue(&imagemetadata.SimplestreamsImagesPublicKey, sstesting.SignedMetadataPublicKey)
@@ -241,6 +243,7 @@ func (t *localServerSuite) SetUpSuite(c *gc.C) {
 	t.BaseSuite.PatchValue(&arch.HostArch, func() string { return arch.AMD64 })
 	t.BaseSuite.PatchValue(&series.MustHostSeries, func() string { return supportedversion.SupportedLTS() })
 	t.BaseSuite.PatchValue(ec2.DeleteSecurityGroupInsistently, deleteSecurityGroupForTestFunc)
+	ec2.PatchNoLoadBalancers(&t.BaseSuite)
 	t.srv.createRootDisks = true
 	t.srv.startServer(c)
 	// TODO(jam) I don't understand why we shouldn't do this.
@@ -719,6 +722,56 @@ func (t *localServerSuite) TestDestroyControllerDestroysHostedModelResources(c *
 	assertGroups("default")
 }
 
+func (t *localServerSuite) TestDestroyExceptVolumes(c *gc.C) {
+	controllerEnv := t.prepareAndBootstrap(c)
+
+	// Create a hosted model environment with an instance and a volume.
+	hostedModelUUID := "7e386e08-cba7-44a4-a76e-7c1633584210"
+	t.srv.ec2srv.SetInitialInstanceState(ec2test.Running)
+	cfg, err := controllerEnv.Config().Apply(map[string]interface{}{
+		"uuid":          hostedModelUUID,
+		"firewall-mode": "global",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	env, err := environs.New(environs.OpenParams{
+		Cloud:  t.CloudSpec(),
+		Config: cfg,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "0")
+	ebsProvider, err := env.StorageProvider(ec2.EBS_ProviderType)
+	c.Assert(err, jc.ErrorIsNil)
+	vs, err := ebsProvider.VolumeSource(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	volumeResults, err := vs.CreateVolumes(t.callCtx, []storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     1024,
+		Provider: ec2.EBS_ProviderType,
+		ResourceTags: map[string]string{
+			tags.JujuController: t.ControllerUUID,
+			tags.JujuModel:      hostedModelUUID,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeResults, gc.HasLen, 1)
+	c.Assert(volumeResults[0].Error, jc.ErrorIsNil)
+
+	err = env.(environs.VolumePreservingDestroyer).DestroyExceptVolumes(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	insts, err := env.AllInstances(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 0)
+	volIds, err := vs.ListVolumes(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volIds, jc.SameContents, []string{volumeResults[0].Volume.VolumeId})
+	groupsResp, err := t.client.SecurityGroups(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, group := range groupsResp.Groups {
+		c.Check(group.Name, gc.Not(jc.HasPrefix), "juju-"+hostedModelUUID)
+	}
+}
+
 func (t *localServerSuite) TestInstanceStatus(c *gc.C) {
 	env := t.Prepare(c)
 	err := bootstrap.Bootstrap(envtesting.BootstrapContext(c), env,
@@ -742,6 +795,36 @@ func (t *localServerSuite) TestStartInstanceHardwareCharacteristics(c *gc.C) {
 	c.Check(*hc.CpuCores, gc.Equals, uint64(2))
 }
 
+func (t *localServerSuite) TestStartInstanceArchArm64(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	inst, hc := testing.AssertStartInstanceWithConstraints(
+		c, env, t.callCtx, t.ControllerUUID, "1", constraints.MustParse("arch=arm64"),
+	)
+	c.Check(*hc.Arch, gc.Equals, "arm64")
+	ec2inst := ec2.InstanceEC2(inst)
+	c.Check(ec2inst.ImageId, gc.Equals, "ami-00002133")
+	c.Check(ec2inst.InstanceType, gc.Matches, `a1\..*`)
+}
+
+func (t *localServerSuite) TestStartInstanceArm64InstanceType(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	inst, hc := testing.AssertStartInstanceWithConstraints(
+		c, env, t.callCtx, t.ControllerUUID, "1", constraints.MustParse("instance-type=a1.large"),
+	)
+	c.Check(*hc.Arch, gc.Equals, "arm64")
+	ec2inst := ec2.InstanceEC2(inst)
+	c.Check(ec2inst.ImageId, gc.Equals, "ami-00002133")
+	c.Check(ec2inst.InstanceType, gc.Equals, "a1.large")
+}
+
+func (t *localServerSuite) TestStartInstanceArchArm64NoImage(c *gc.C) {
+	env := t.prepareAndBootstrapWithConfig(c, coretesting.Attrs{"default-series": "xenial"})
+	_, _, _, err := testing.StartInstanceWithConstraints(
+		env, t.callCtx, t.ControllerUUID, "1", constraints.MustParse("arch=arm64"),
+	)
+	c.Assert(err, gc.ErrorMatches, `.*no "xenial" images in test with arches \[arm64\]`)
+}
+
 func (t *localServerSuite) TestStartInstanceAvailZone(c *gc.C) {
 	inst, err := t.testStartInstanceAvailZone(c, "test-available")
 	c.Assert(err, jc.ErrorIsNil)
@@ -1128,6 +1211,47 @@ func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, run
 	c.Assert(errors.Details(err), jc.Contains, runInstancesError.Message)
 }
 
+func (t *localServerSuite) TestStartInstanceInsufficientCapacityClassified(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ctx context.ProviderCallContext, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		return nil, azInsufficientInstanceCapacityErr
+	})
+
+	params := environs.StartInstanceParams{
+		ControllerUUID:   t.ControllerUUID,
+		StatusCallback:   fakeCallback,
+		AvailabilityZone: "test-available",
+	}
+	_, err := testing.StartInstanceWithParams(env, t.callCtx, "1", params)
+	c.Assert(err, gc.Not(jc.Satisfies), environs.IsAvailabilityZoneIndependent)
+	kind, code := environs.ClassifyProvisioningError(err)
+	c.Check(kind, gc.Equals, environs.ProvisioningErrorRetryable)
+	c.Check(code, gc.Equals, "insufficient-capacity")
+}
+
+func (t *localServerSuite) TestStartInstanceQuotaExceeded(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ctx context.ProviderCallContext, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		return nil, &amzec2.Error{
+			Code:    "InstanceLimitExceeded",
+			Message: "You have requested more instances (21) than your current instance limit of 20 allows.",
+		}
+	})
+
+	params := environs.StartInstanceParams{
+		ControllerUUID:   t.ControllerUUID,
+		StatusCallback:   fakeCallback,
+		AvailabilityZone: "test-available",
+	}
+	_, err := testing.StartInstanceWithParams(env, t.callCtx, "1", params)
+	c.Assert(err, gc.ErrorMatches, `cannot run instances: You have requested more instances .*`)
+	kind, code := environs.ClassifyProvisioningError(err)
+	c.Check(kind, gc.Equals, environs.ProvisioningErrorNeedsUserAction)
+	c.Check(code, gc.Equals, "quota-exceeded")
+}
+
 // addTestingSubnets adds a testing default VPC with 3 subnets in the EC2 test
 // server: 2 of the subnets are in the "test-available" AZ, the remaining - in
 // "test-unavailable". Returns a slice with the IDs of the created subnets and
@@ -1344,6 +1468,59 @@ func (t *localServerSuite) testStartInstanceAvailZoneOneConstrained(c *gc.C, run
 	c.Assert(azArgs, gc.DeepEquals, []string{"test-available", "test-available2"})
 }
 
+func (t *localServerSuite) TestStartInstancesWithOneRequest(c *gc.C) {
+	env := t.prepareAndBootstrapWithConfig(c, coretesting.Attrs{"firewall-mode": "global"})
+
+	var counts []int
+	var userData []byte
+	realRunInstances := *ec2.RunInstances
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ctx context.ProviderCallContext, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		counts = append(counts, ri.MinCount, ri.MaxCount)
+		userData = ri.UserData
+		resp, err := realRunInstances(e, ctx, ri, fakeCallback)
+		if err != nil {
+			return nil, err
+		}
+		// EC2 does not return the instances in launch index order.
+		for i := range resp.Instances {
+			resp.Instances[i].AMILaunchIndex = i
+		}
+		for i, j := 0, len(resp.Instances)-1; i < j; i, j = i+1, j-1 {
+			resp.Instances[i], resp.Instances[j] = resp.Instances[j], resp.Instances[i]
+		}
+		return resp, nil
+	})
+
+	var args []environs.StartInstanceParams
+	for _, machineId := range []string{"1", "2", "3"} {
+		params := environs.StartInstanceParams{ControllerUUID: t.ControllerUUID}
+		err := testing.FillInStartInstanceParams(env, machineId, false, &params)
+		c.Assert(err, jc.ErrorIsNil)
+		args = append(args, params)
+	}
+	results := env.(environs.InstanceBatchBroker).StartInstances(t.callCtx, args)
+	c.Assert(counts, jc.DeepEquals, []int{3, 3})
+	c.Assert(results, gc.HasLen, 3)
+
+	ids := make(map[instance.Id]bool)
+	for i, result := range results {
+		c.Assert(result.Error, jc.ErrorIsNil)
+		ids[result.Result.Instance.Id()] = true
+		inst := ec2.InstanceEC2(result.Result.Instance)
+		c.Check(inst.AMILaunchIndex, gc.Equals, i)
+		c.Check(args[i].InstanceConfig.Tags["Name"], gc.Equals, fmt.Sprintf("juju-sample-machine-%d", i+1))
+	}
+	c.Assert(ids, gc.HasLen, 3)
+
+	// Each machine's config is in the part for its launch index.
+	data, err := utils.Gunzip(userData)
+	c.Assert(err, jc.ErrorIsNil)
+	for i := 0; i < 3; i++ {
+		c.Check(string(data), jc.Contains, fmt.Sprintf("Launch-Index: %d", i))
+		c.Check(string(data), jc.Contains, fmt.Sprintf("machine-%d", i+1))
+	}
+}
+
 func (t *localServerSuite) TestAddresses(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 	inst, _ := testing.AssertStartInstance(c, env, t.callCtx, t.ControllerUUID, "1")
@@ -1387,6 +1564,16 @@ func (t *localServerSuite) TestConstraintsValidatorVocab(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "invalid constraint value: instance-type=foo\nvalid values are:.*")
 }
 
+func (t *localServerSuite) TestConstraintsValidatorVocabArch(c *gc.C) {
+	env := t.Prepare(c)
+	validator, err := env.ConstraintsValidator(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("arch=arm64"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = validator.Validate(constraints.MustParse("arch=ppc64el"))
+	c.Assert(err, gc.ErrorMatches, "invalid constraint value: arch=ppc64el\nvalid values are:.*")
+}
+
 func (t *localServerSuite) TestConstraintsValidatorVocabNoDefaultOrSpecifiedVPC(c *gc.C) {
 	t.srv.defaultVPC.IsDefault = false
 	err := t.srv.ec2srv.UpdateVPC(*t.srv.defaultVPC)
@@ -1691,6 +1878,23 @@ func (t *localServerSuite) TestInstanceInformation(c *gc.C) {
 	c.Assert(types.InstanceTypes, gc.HasLen, 48)
 }
 
+func (t *localServerSuite) TestInstancePricing(c *gc.C) {
+	env := t.prepareEnviron(c)
+	cons := constraints.MustParse("mem=4G")
+	pricing, err := env.(environs.InstancePricer).InstancePricing(t.callCtx, cons)
+	c.Assert(err, jc.ErrorIsNil)
+
+	types, err := env.InstanceTypes(t.callCtx, cons)
+	c.Assert(err, jc.ErrorIsNil)
+	cheapest := types.InstanceTypes[0]
+	c.Assert(cheapest.Cost, gc.Not(gc.Equals), uint64(0))
+	c.Assert(pricing, jc.DeepEquals, environs.InstancePricing{
+		InstanceType: cheapest.Name,
+		HourlyCost:   float64(cheapest.Cost) / 1000,
+		Currency:     "USD",
+	})
+}
+
 func validateSubnets(c *gc.C, subnets []network.SubnetInfo, vpcId network.Id) {
 	// These are defined in the test server for the testing default
 	// VPC.
@@ -1797,6 +2001,41 @@ func (t *localServerSuite) TestRootDiskTags(c *gc.C) {
 	})
 }
 
+func (t *localServerSuite) TestResourceTags(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	insts, err := env.AllInstances(t.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 1)
+
+	tagger, ok := environs.SupportsResourceTagger(env)
+	c.Assert(ok, jc.IsTrue)
+	resources := []environs.ModelResource{{
+		Kind:  environs.InstanceResource,
+		Id:    string(insts[0].Id()),
+		Owner: "machine-0",
+	}, {
+		Kind:  environs.InstanceResource,
+		Id:    "i-gone",
+		Owner: "machine-1",
+	}}
+	c.Assert(tagger.ProviderResourceTags(resources[0]), jc.DeepEquals, map[string]string{
+		"Name": "juju-sample-machine-0",
+	})
+
+	err = tagger.TagResource(t.callCtx, resources[0], map[string]string{
+		"juju-model-uuid": "new-model-uuid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	tags, err := tagger.ResourceTags(t.callCtx, resources)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(tags, jc.DeepEquals, []map[string]string{{
+		"Name":                 "juju-sample-machine-0",
+		"juju-model-uuid":      "new-model-uuid",
+		"juju-controller-uuid": t.ControllerUUID,
+		"juju-is-controller":   "true",
+	}, nil})
+}
+
 func (s *localServerSuite) TestBootstrapInstanceConstraints(c *gc.C) {
 	env := s.prepareAndBootstrap(c)
 	inst, err := env.AllInstances(s.callCtx)
@@ -1944,6 +2183,7 @@ func (t *localNonUSEastSuite) SetUpSuite(c *gc.C) {
 	t.PatchValue(&imagemetadata.SimplestreamsImagesPublicKey, sstesting.SignedMetadataPublicKey)
 	t.PatchValue(&keys.JujuPublicKey, sstesting.SignedMetadataPublicKey)
 	t.BaseSuite.PatchValue(ec2.DeleteSecurityGroupInsistently, deleteSecurityGroupForTestFunc)
+	ec2.PatchNoLoadBalancers(&t.BaseSuite)
 }
 
 func (t *localNonUSEastSuite) TearDownSuite(c *gc.C) {
@@ -1996,7 +2236,11 @@ func patchEC2ForTesting(c *gc.C, region aws.Region) func() {
 	ec2.UseTestImageData(c, ec2.MakeTestImageStreamsData(region))
 	restoreTimeouts := envtesting.PatchAttemptStrategies(ec2.ShortAttempt)
 	restoreFinishBootstrap := envtesting.DisableFinishBootstrap()
+	retryStrategy := *ec2.APIRetryStrategy
+	ec2.APIRetryStrategy.Delay = time.Nanosecond
+	ec2.APIRetryStrategy.MaxDelay = time.Nanosecond
 	return func() {
+		*ec2.APIRetryStrategy = retryStrategy
 		restoreFinishBootstrap()
 		restoreTimeouts()
 		ec2.UseTestImageData(c, nil)
@@ -0,0 +1,139 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"strings"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/tags"
+)
+
+var _ environs.ModelResources = (*environ)(nil)
+
+// AllModelResources is part of the environs.ModelResources interface.
+func (e *environ) AllModelResources(ctx context.ProviderCallContext) ([]environs.ModelResource, error) {
+	var resources []environs.ModelResource
+
+	insts, err := e.AllInstances(ctx)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing instances")
+	}
+	for _, inst := range insts {
+		resources = append(resources, environs.ModelResource{
+			Kind: environs.InstanceResource,
+			Id:   string(inst.Id()),
+		})
+	}
+
+	volIds, err := e.allModelVolumes(ctx, false)
+	if err != nil {
+		return nil, errors.Annotate(err, "listing volumes")
+	}
+	for _, id := range volIds {
+		resources = append(resources, environs.ModelResource{
+			Kind: environs.VolumeResource,
+			Id:   id,
+		})
+	}
+
+	filter := ec2.NewFilter()
+	e.addModelFilter(filter)
+	resp, err := e.ec2.SecurityGroups(nil, filter)
+	if err != nil {
+		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing security groups")
+	}
+	for _, group := range resp.Groups {
+		resources = append(resources, environs.ModelResource{
+			Kind:  environs.SecurityGroupResource,
+			Id:    group.Id,
+			Owner: securityGroupOwner(e.jujuGroupName(), group.Name),
+		})
+	}
+
+	lbTags, err := newELBClient(e.cloud).AllLoadBalancerTags()
+	if err != nil {
+		return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing load balancers")
+	}
+	for name, t := range lbTags {
+		if t[tags.JujuModel] != e.uuid() {
+			continue
+		}
+		resource := environs.ModelResource{
+			Kind: environs.LoadBalancerResource,
+			Id:   name,
+		}
+		if app := t[tags.JujuApplication]; names.IsValidApplication(app) {
+			resource.Owner = names.NewApplicationTag(app).String()
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// securityGroupOwner returns the tag of the machine that the named
+// security group was created for, or "" if it is one of the groups
+// shared by the whole model.
+func securityGroupOwner(jujuGroupName, groupName string) string {
+	machineId := strings.TrimPrefix(groupName, jujuGroupName+"-")
+	if machineId == groupName || !names.IsValidMachine(machineId) {
+		return ""
+	}
+	return names.NewMachineTag(machineId).String()
+}
+
+// DestroyModelResources is part of the environs.ModelResources interface.
+//
+// Load balancers and instances are removed first, so that the volumes
+// and security groups they use can be removed after them.
+func (e *environ) DestroyModelResources(ctx context.ProviderCallContext, resources []environs.ModelResource) error {
+	var (
+		lbNames  []string
+		instIds  []instance.Id
+		volIds   []string
+		groupIds []string
+	)
+	for _, resource := range resources {
+		switch resource.Kind {
+		case environs.LoadBalancerResource:
+			lbNames = append(lbNames, resource.Id)
+		case environs.InstanceResource:
+			instIds = append(instIds, instance.Id(resource.Id))
+		case environs.VolumeResource:
+			volIds = append(volIds, resource.Id)
+		case environs.SecurityGroupResource:
+			groupIds = append(groupIds, resource.Id)
+		default:
+			return errors.NotValidf("resource kind %q", resource.Kind)
+		}
+	}
+
+	client := newELBClient(e.cloud)
+	for _, name := range lbNames {
+		if err := client.DeleteLoadBalancer(name); err != nil {
+			return errors.Annotatef(maybeConvertCredentialError(err, ctx), "deleting load balancer %q", name)
+		}
+	}
+	if err := e.terminateInstances(ctx, instIds); err != nil {
+		return errors.Annotate(err, "terminating instances")
+	}
+	for i, err := range foreachVolume(e.ec2, ctx, volIds, destroyVolume) {
+		if err != nil {
+			return errors.Annotatef(err, "destroying volume %q", volIds[i])
+		}
+	}
+	for _, id := range groupIds {
+		if err := deleteSecurityGroupInsistently(e.ec2, ctx, ec2.SecurityGroup{Id: id}, clock.WallClock); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
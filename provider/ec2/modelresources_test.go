@@ -0,0 +1,33 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+)
+
+type modelResourcesSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&modelResourcesSuite{})
+
+func (s *modelResourcesSuite) TestSecurityGroupOwner(c *gc.C) {
+	const jujuGroup = "juju-deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	for _, t := range []struct {
+		group string
+		owner string
+	}{
+		{jujuGroup, ""},
+		{jujuGroup + "-global", ""},
+		{jujuGroup + "-0", "machine-0"},
+		{jujuGroup + "-42", "machine-42"},
+		{"juju-other-model-3", ""},
+		{"default", ""},
+	} {
+		c.Check(securityGroupOwner(jujuGroup, t.group), gc.Equals, t.owner, gc.Commentf("group %q", t.group))
+	}
+}
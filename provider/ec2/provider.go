@@ -34,6 +34,17 @@ func (environProvider) Version() int {
 	return 0
 }
 
+// Capabilities is part of the CapabilityReporter interface.
+func (environProvider) Capabilities() []environs.Capability {
+	return []environs.Capability{
+		environs.CapabilitySpaces,
+		environs.CapabilityAvailabilityZones,
+		environs.CapabilityLoadBalancers,
+		environs.CapabilityDNSZones,
+		environs.CapabilityBlockStorage,
+	}
+}
+
 // Open is specified in the EnvironProvider interface.
 func (p environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
 	logger.Infof("opening model %q", args.Config.Name())
@@ -0,0 +1,92 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+var _ environs.ResourceTagger = (*environ)(nil)
+
+// ResourceTags is part of the environs.ResourceTagger interface.
+//
+// The resources are looked up by id alone, and not by the model tag,
+// as that is one of the tags that may have drifted.
+func (e *environ) ResourceTags(ctx context.ProviderCallContext, resources []environs.ModelResource) ([]map[string]string, error) {
+	var instIds, volIds []string
+	for _, resource := range resources {
+		switch resource.Kind {
+		case environs.InstanceResource:
+			instIds = append(instIds, resource.Id)
+		case environs.VolumeResource:
+			volIds = append(volIds, resource.Id)
+		default:
+			return nil, errors.NotValidf("resource kind %q", resource.Kind)
+		}
+	}
+
+	found := make(map[string]map[string]string)
+	if len(instIds) > 0 {
+		// Instances are looked up with a filter rather than by id,
+		// so that ids of instances that have gone are not an error.
+		filter := ec2.NewFilter()
+		filter.Add("instance-state-name", aliveInstanceStates...)
+		filter.Add("instance-id", instIds...)
+		resp, err := e.ec2.Instances(nil, filter)
+		if err != nil {
+			return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing instances")
+		}
+		for _, r := range resp.Reservations {
+			for _, inst := range r.Instances {
+				found[inst.InstanceId] = tagsMap(inst.Tags)
+			}
+		}
+	}
+	if len(volIds) > 0 {
+		filter := ec2.NewFilter()
+		filter.Add("volume-id", volIds...)
+		resp, err := e.ec2.Volumes(nil, filter)
+		if err != nil {
+			return nil, errors.Annotate(maybeConvertCredentialError(err, ctx), "listing volumes")
+		}
+		for _, vol := range resp.Volumes {
+			found[vol.Id] = tagsMap(vol.Tags)
+		}
+	}
+
+	result := make([]map[string]string, len(resources))
+	for i, resource := range resources {
+		result[i] = found[resource.Id]
+	}
+	return result, nil
+}
+
+func tagsMap(ec2Tags []ec2.Tag) map[string]string {
+	result := make(map[string]string, len(ec2Tags))
+	for _, tag := range ec2Tags {
+		result[tag.Key] = tag.Value
+	}
+	return result
+}
+
+// ProviderResourceTags is part of the environs.ResourceTagger interface.
+// Instances and volumes are given a Name tag, which the AWS console
+// displays.
+func (e *environ) ProviderResourceTags(resource environs.ModelResource) map[string]string {
+	owner, err := names.ParseTag(resource.Owner)
+	if err != nil {
+		return nil
+	}
+	return map[string]string{tagName: resourceName(owner, e.Config().Name())}
+}
+
+// TagResource is part of the environs.ResourceTagger interface.
+func (e *environ) TagResource(ctx context.ProviderCallContext, resource environs.ModelResource, tags map[string]string) error {
+	return errors.Trace(tagResources(e.ec2, ctx, tags, resource.Id))
+}
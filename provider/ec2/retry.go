@@ -0,0 +1,197 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
+	"github.com/juju/retry"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	actionLabel = "action"
+	reasonLabel = "reason"
+
+	retryReasonThrottled = "throttled"
+	retryReasonNotFound  = "not-found"
+)
+
+// throttlingErrorCodes are the codes of the errors EC2 returns when it
+// throttles requests, or is too busy to serve them. The requests were
+// not acted on, and may be retried after backing off.
+var throttlingErrorCodes = set.NewStrings(
+	"RequestLimitExceeded",
+	"Throttling",
+	"ThrottlingException",
+	"RequestThrottled",
+	"ServiceUnavailable",
+	"Unavailable",
+	"InternalError",
+)
+
+// retryStrategy describes how EC2 API calls are retried: up to Attempts
+// times, waiting between attempts for an exponentially increasing delay,
+// starting at Delay and capped at MaxDelay, with random jitter so that
+// the clients throttled together don't retry together.
+type retryStrategy struct {
+	Attempts int
+	Delay    time.Duration
+	MaxDelay time.Duration
+	Clock    clock.Clock
+}
+
+// apiRetryStrategy is the strategy used to retry EC2 API calls.
+var apiRetryStrategy = retryStrategy{
+	Attempts: 8,
+	Delay:    250 * time.Millisecond,
+	MaxDelay: 10 * time.Second,
+	Clock:    clock.WallClock,
+}
+
+// callEC2 makes the named EC2 API call, e.g. "DescribeInstances",
+// retrying it with jittered exponential backoff while EC2 throttles it.
+// If the call still fails, the error of its last attempt is returned.
+//
+// Only calls that are safe to repeat should be made with callEC2.
+func callEC2(action string, call func() error) error {
+	return apiRetryStrategy.call(action, call, false)
+}
+
+// callEC2Eventually is like callEC2, but also retries the call while
+// the resources it refers to are not found. It is used for calls on
+// resources that were only just created, as EC2 is eventually
+// consistent and may not know about them yet.
+func callEC2Eventually(action string, call func() error) error {
+	return apiRetryStrategy.call(action, call, true)
+}
+
+func (s retryStrategy) call(action string, call func() error, retryNotFound bool) error {
+	var lastErr error
+	err := retry.Call(retry.CallArgs{
+		Func: func() error {
+			if lastErr != nil {
+				retryMetrics.retried(action, retryReason(lastErr))
+			}
+			lastErr = call()
+			return lastErr
+		},
+		IsFatalError: func(err error) bool {
+			switch retryReason(err) {
+			case retryReasonThrottled:
+				return false
+			case retryReasonNotFound:
+				return !retryNotFound
+			}
+			return true
+		},
+		NotifyFunc: func(err error, attempt int) {
+			logger.Debugf("%s attempt %d failed: %v", action, attempt, err)
+		},
+		Attempts:    s.Attempts,
+		Delay:       s.Delay,
+		MaxDelay:    s.MaxDelay,
+		BackoffFunc: s.backoff,
+		Clock:       s.Clock,
+	})
+	if retry.IsAttemptsExceeded(err) {
+		logger.Warningf("%s failed after %d attempts: %v", action, s.Attempts, lastErr)
+		retryMetrics.exhausted(action, retryReason(lastErr))
+		// Return the error of the call itself, so that callers
+		// can check its code.
+		return lastErr
+	}
+	return err
+}
+
+// backoff is a retry.BackoffFunc returning the delay before the attempt
+// after the given one: the initial delay doubled for each attempt made,
+// less a random jitter of up to half of it.
+func (s retryStrategy) backoff(_ time.Duration, attempt int) time.Duration {
+	delay := s.Delay
+	for i := 0; i < attempt && (s.MaxDelay <= 0 || delay < s.MaxDelay); i++ {
+		delay *= 2
+	}
+	if s.MaxDelay > 0 && delay > s.MaxDelay {
+		delay = s.MaxDelay
+	}
+	half := int64(delay / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+// retryReason returns the reason an EC2 API call that failed with the
+// given error may be retried, or "" if it may not.
+func retryReason(err error) string {
+	code := ec2ErrCode(err)
+	switch {
+	case throttlingErrorCodes.Contains(code):
+		return retryReasonThrottled
+	case strings.HasSuffix(code, ".NotFound"):
+		return retryReasonNotFound
+	}
+	return ""
+}
+
+var retryMetrics = newRetryCollector()
+
+// RetryCollector returns the prometheus.Collector of the metrics about
+// the EC2 API calls retried by the provider.
+func RetryCollector() prometheus.Collector {
+	return retryMetrics
+}
+
+// retryCollector is a prometheus.Collector counting the EC2 API calls
+// retried, and those that failed after all their attempts.
+type retryCollector struct {
+	retriesTotal   *prometheus.CounterVec
+	exhaustedTotal *prometheus.CounterVec
+}
+
+func newRetryCollector() *retryCollector {
+	labels := []string{actionLabel, reasonLabel}
+	return &retryCollector{
+		retriesTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "juju",
+			Subsystem: "provider_ec2",
+			Name:      "api_retries_total",
+			Help:      "Total number of EC2 API calls retried.",
+		}, labels),
+		exhaustedTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "juju",
+			Subsystem: "provider_ec2",
+			Name:      "api_retries_exhausted_total",
+			Help:      "Total number of EC2 API calls that failed after all their attempts.",
+		}, labels),
+	}
+}
+
+func (c *retryCollector) retried(action, reason string) {
+	c.retriesTotal.With(prometheus.Labels{
+		actionLabel: action,
+		reasonLabel: reason,
+	}).Inc()
+}
+
+func (c *retryCollector) exhausted(action, reason string) {
+	c.exhaustedTotal.With(prometheus.Labels{
+		actionLabel: action,
+		reasonLabel: reason,
+	}).Inc()
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *retryCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.retriesTotal.Describe(ch)
+	c.exhaustedTotal.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *retryCollector) Collect(ch chan<- prometheus.Metric) {
+	c.retriesTotal.Collect(ch)
+	c.exhaustedTotal.Collect(ch)
+}
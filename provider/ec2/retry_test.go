@@ -0,0 +1,145 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	amzec2 "gopkg.in/amz.v3/ec2"
+	gc "gopkg.in/check.v1"
+)
+
+type retrySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&retrySuite{})
+
+func (s *retrySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.PatchValue(&apiRetryStrategy, retryStrategy{
+		Attempts: 3,
+		Delay:    time.Nanosecond,
+		MaxDelay: time.Nanosecond,
+		Clock:    clock.WallClock,
+	})
+	s.PatchValue(&retryMetrics, newRetryCollector())
+}
+
+// failingCall returns a call failing with the given errors in turn,
+// then succeeding, and a pointer to the number of calls made.
+func failingCall(errs ...error) (func() error, *int) {
+	var calls int
+	return func() error {
+		calls++
+		if calls <= len(errs) {
+			return errs[calls-1]
+		}
+		return nil
+	}, &calls
+}
+
+func (s *retrySuite) TestRetriesThrottling(c *gc.C) {
+	call, calls := failingCall(
+		&amzec2.Error{Code: "RequestLimitExceeded"},
+		&amzec2.Error{Code: "Throttling"},
+	)
+	err := callEC2("DescribeInstances", call)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*calls, gc.Equals, 3)
+	c.Assert(counts(c, retryMetrics.retriesTotal), jc.DeepEquals, map[string]float64{
+		"DescribeInstances throttled": 2,
+	})
+	c.Assert(counts(c, retryMetrics.exhaustedTotal), gc.HasLen, 0)
+}
+
+func (s *retrySuite) TestAttemptsExhausted(c *gc.C) {
+	throttled := &amzec2.Error{Code: "RequestLimitExceeded"}
+	call, calls := failingCall(throttled, throttled, throttled)
+	err := callEC2("DescribeVolumes", call)
+	c.Assert(err, gc.Equals, throttled)
+	c.Assert(*calls, gc.Equals, 3)
+	c.Assert(counts(c, retryMetrics.retriesTotal), jc.DeepEquals, map[string]float64{
+		"DescribeVolumes throttled": 2,
+	})
+	c.Assert(counts(c, retryMetrics.exhaustedTotal), jc.DeepEquals, map[string]float64{
+		"DescribeVolumes throttled": 1,
+	})
+}
+
+func (s *retrySuite) TestOtherErrorsNotRetried(c *gc.C) {
+	for _, callErr := range []error{
+		errors.New("boom"),
+		&amzec2.Error{Code: "AuthFailure"},
+		&amzec2.Error{Code: "InvalidInstanceID.NotFound"},
+	} {
+		call, calls := failingCall(callErr)
+		err := callEC2("DescribeInstances", call)
+		c.Check(err, gc.Equals, callErr)
+		c.Check(*calls, gc.Equals, 1)
+	}
+	c.Assert(counts(c, retryMetrics.retriesTotal), gc.HasLen, 0)
+}
+
+func (s *retrySuite) TestEventuallyRetriesNotFound(c *gc.C) {
+	call, calls := failingCall(
+		&amzec2.Error{Code: "InvalidInstanceID.NotFound"},
+		&amzec2.Error{Code: "RequestLimitExceeded"},
+	)
+	err := callEC2Eventually("CreateTags", call)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*calls, gc.Equals, 3)
+	c.Assert(counts(c, retryMetrics.retriesTotal), jc.DeepEquals, map[string]float64{
+		"CreateTags not-found": 1,
+		"CreateTags throttled": 1,
+	})
+}
+
+func (s *retrySuite) TestBackoff(c *gc.C) {
+	strategy := retryStrategy{
+		Delay:    time.Second,
+		MaxDelay: 10 * time.Second,
+	}
+	for attempt, max := range map[int]time.Duration{
+		1:  2 * time.Second,
+		2:  4 * time.Second,
+		3:  8 * time.Second,
+		4:  10 * time.Second,
+		20: 10 * time.Second,
+	} {
+		for i := 0; i < 10; i++ {
+			delay := strategy.backoff(0, attempt)
+			c.Check(delay >= max/2 && delay <= max, jc.IsTrue, gc.Commentf("attempt %d: %v", attempt, delay))
+		}
+	}
+}
+
+// counts returns the counts of the given counters, keyed by their
+// action and reason labels.
+func counts(c *gc.C, counters *prometheus.CounterVec) map[string]float64 {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		defer close(ch)
+		counters.Collect(ch)
+	}()
+	result := make(map[string]float64)
+	for metric := range ch {
+		var m dto.Metric
+		err := metric.Write(&m)
+		c.Assert(err, jc.ErrorIsNil)
+		var labels []string
+		for _, label := range m.Label {
+			labels = append(labels, label.GetValue())
+		}
+		result[strings.Join(labels, " ")] = m.Counter.GetValue()
+	}
+	return result
+}
@@ -0,0 +1,320 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+var _ environs.DNSZones = (*environ)(nil)
+
+// maxRoute53Changes is the number of record set changes sent to Route53
+// in each request, well within its limit on the size of a change batch.
+const maxRoute53Changes = 100
+
+const route53APIVersion = "2013-04-01"
+
+// route53RecordSet holds a Route53 resource record set.
+type route53RecordSet struct {
+	Name   string   `xml:"Name"`
+	Type   string   `xml:"Type"`
+	TTL    int      `xml:"TTL"`
+	Values []string `xml:"ResourceRecords>ResourceRecord>Value"`
+}
+
+// route53Change holds a change to a Route53 resource record set.
+type route53Change struct {
+	Action    string           `xml:"Action"`
+	RecordSet route53RecordSet `xml:"ResourceRecordSet"`
+}
+
+// route53API is the subset of the Route53 API used to publish
+// application and unit addresses.
+type route53API interface {
+	// HostedZoneId returns the id of the named public hosted zone. If
+	// there is no such zone then an error satisfying errors.IsNotFound
+	// is returned.
+	HostedZoneId(name string) (string, error)
+	// RecordSets returns all the record sets in the hosted zone.
+	RecordSets(zoneId string) ([]route53RecordSet, error)
+	// ChangeRecordSets applies the changes to the hosted zone as a
+	// single transaction.
+	ChangeRecordSets(zoneId string, changes []route53Change) error
+}
+
+// newRoute53Client returns a route53API for the given cloud. It is a
+// variable so tests can replace it.
+var newRoute53Client = func(cloud environs.CloudSpec) route53API {
+	credentialAttrs := cloud.Credential.Attributes()
+	// Route53 is a global service, with a single endpoint in each
+	// partition.
+	endpoint, region := "https://route53.amazonaws.com", "us-east-1"
+	if strings.HasPrefix(cloud.Region, "cn-") {
+		endpoint, region = "https://route53.amazonaws.com.cn", "cn-northwest-1"
+	}
+	return &route53Client{
+		auth: aws.Auth{
+			AccessKey: credentialAttrs["access-key"],
+			SecretKey: credentialAttrs["secret-key"],
+		},
+		endpoint: endpoint,
+		sign:     aws.SignV4Factory(region, "route53"),
+	}
+}
+
+// OwnedDNSRecords is part of the environs.DNSZones interface.
+func (e *environ) OwnedDNSRecords(ctx context.ProviderCallContext, zone, owner string) ([]environs.DNSRecord, error) {
+	client := newRoute53Client(e.cloud)
+	_, zoneName, recordSets, err := route53Zone(client, zone)
+	if err != nil {
+		return nil, errors.Annotatef(maybeConvertCredentialError(err, ctx), "reading hosted zone %q", zone)
+	}
+	return environs.OwnedDNSRecords(route53DNSRecordSets(zoneName, recordSets), owner), nil
+}
+
+// SetDNSRecords is part of the environs.DNSZones interface. The zone
+// must be a public hosted zone in Route53 in the model's AWS account.
+func (e *environ) SetDNSRecords(ctx context.ProviderCallContext, zone, owner string, records []environs.DNSRecord) error {
+	client := newRoute53Client(e.cloud)
+	zoneId, zoneName, recordSets, err := route53Zone(client, zone)
+	if err != nil {
+		return errors.Annotatef(maybeConvertCredentialError(err, ctx), "reading hosted zone %q", zone)
+	}
+	changes, notOwned := route53Changes(zoneName, owner, recordSets, records)
+	for len(changes) > 0 {
+		n := len(changes)
+		if n > maxRoute53Changes {
+			n = maxRoute53Changes
+		}
+		if err := client.ChangeRecordSets(zoneId, changes[:n]); err != nil {
+			return errors.Annotatef(maybeConvertCredentialError(err, ctx), "updating hosted zone %q", zone)
+		}
+		changes = changes[n:]
+	}
+	if len(notOwned) > 0 {
+		return environs.DNSNamesNotOwnedError(notOwned)
+	}
+	return nil
+}
+
+// route53Zone returns the id, fully qualified name and record sets of
+// the named hosted zone.
+func route53Zone(client route53API, zone string) (string, string, []route53RecordSet, error) {
+	zoneName := strings.TrimSuffix(zone, ".") + "."
+	zoneId, err := client.HostedZoneId(zoneName)
+	if err != nil {
+		return "", "", nil, err
+	}
+	recordSets, err := client.RecordSets(zoneId)
+	if err != nil {
+		return "", "", nil, err
+	}
+	return zoneId, zoneName, recordSets, nil
+}
+
+// route53DNSRecordSets converts the record sets of the named hosted
+// zone, naming them relative to the zone.
+func route53DNSRecordSets(zoneName string, recordSets []route53RecordSet) []environs.DNSRecordSet {
+	result := make([]environs.DNSRecordSet, len(recordSets))
+	for i, recordSet := range recordSets {
+		name := ""
+		if recordSet.Name != zoneName {
+			name = strings.TrimSuffix(recordSet.Name, "."+zoneName)
+		}
+		result[i] = environs.DNSRecordSet{
+			Name:   name,
+			Type:   recordSet.Type,
+			Values: recordSet.Values,
+		}
+	}
+	return result
+}
+
+// route53Changes returns the changes needed to set the records in the
+// hosted zone with the given existing record sets, and the names of the
+// records not set because they are owned by others. Record sets with
+// values are upserted. Route53 only deletes a record set if given its
+// current contents, which are taken from the existing record sets.
+// Other TXT records at a name are kept alongside the ownership marker.
+func route53Changes(zoneName, owner string, existing []route53RecordSet, records []environs.DNSRecord) ([]route53Change, []string) {
+	ownership := environs.DNSNameOwnership(route53DNSRecordSets(zoneName, existing), owner)
+	current := make(map[string]route53RecordSet)
+	for _, recordSet := range existing {
+		current[recordSet.Name+" "+recordSet.Type] = recordSet
+	}
+	marker := environs.DNSOwnerText(owner)
+
+	var changes []route53Change
+	var notOwned []string
+	for _, record := range records {
+		if owned, ok := ownership[record.Name]; ok && !owned {
+			notOwned = append(notOwned, record.Name)
+			continue
+		}
+		name := record.Name + "." + zoneName
+		ipv4, ipv6 := environs.SplitDNSAddresses(record.Addresses)
+		a := route53RecordSet{Name: name, Type: "A", Values: ipv4}
+		aaaa := route53RecordSet{Name: name, Type: "AAAA", Values: ipv6}
+		txt := route53RecordSet{Name: name, Type: "TXT"}
+		for _, value := range current[name+" TXT"].Values {
+			if strings.Trim(value, `"`) != marker {
+				txt.Values = append(txt.Values, value)
+			}
+		}
+		recordSets := []route53RecordSet{a, aaaa, txt}
+		if len(ipv4)+len(ipv6) > 0 {
+			// Changes may be split across requests, so the name is
+			// marked as owned before its addresses are published.
+			txt.Values = append(txt.Values, strconv.Quote(marker))
+			recordSets = []route53RecordSet{txt, a, aaaa}
+		}
+		for _, recordSet := range recordSets {
+			if len(recordSet.Values) > 0 {
+				recordSet.TTL = environs.DNSRecordTTL
+				changes = append(changes, route53Change{
+					Action:    "UPSERT",
+					RecordSet: recordSet,
+				})
+			} else if existing, ok := current[name+" "+recordSet.Type]; ok {
+				changes = append(changes, route53Change{
+					Action:    "DELETE",
+					RecordSet: existing,
+				})
+			}
+		}
+	}
+	return changes, notOwned
+}
+
+type route53Client struct {
+	auth     aws.Auth
+	endpoint string
+	sign     func(*http.Request, aws.Auth) error
+}
+
+func (c *route53Client) HostedZoneId(name string) (string, error) {
+	params := url.Values{}
+	params.Set("dnsname", name)
+	params.Set("maxitems", "1")
+	var resp struct {
+		HostedZones []struct {
+			Id      string `xml:"Id"`
+			Name    string `xml:"Name"`
+			Private bool   `xml:"Config>PrivateZone"`
+		} `xml:"HostedZones>HostedZone"`
+	}
+	if err := c.request("GET", "/hostedzonesbyname?"+params.Encode(), nil, &resp); err != nil {
+		return "", err
+	}
+	// Zones are listed in order starting from the requested name, so
+	// the first zone is only the one wanted if the name matches.
+	if len(resp.HostedZones) == 0 || resp.HostedZones[0].Name != name || resp.HostedZones[0].Private {
+		return "", errors.NotFoundf("public hosted zone %q", name)
+	}
+	return strings.TrimPrefix(resp.HostedZones[0].Id, "/hostedzone/"), nil
+}
+
+func (c *route53Client) RecordSets(zoneId string) ([]route53RecordSet, error) {
+	var result []route53RecordSet
+	params := url.Values{}
+	for {
+		var resp struct {
+			RecordSets           []route53RecordSet `xml:"ResourceRecordSets>ResourceRecordSet"`
+			IsTruncated          bool               `xml:"IsTruncated"`
+			NextRecordName       string             `xml:"NextRecordName"`
+			NextRecordType       string             `xml:"NextRecordType"`
+			NextRecordIdentifier string             `xml:"NextRecordIdentifier"`
+		}
+		path := "/hostedzone/" + zoneId + "/rrset"
+		if len(params) > 0 {
+			path += "?" + params.Encode()
+		}
+		if err := c.request("GET", path, nil, &resp); err != nil {
+			return nil, err
+		}
+		result = append(result, resp.RecordSets...)
+		if !resp.IsTruncated {
+			return result, nil
+		}
+		params.Set("name", resp.NextRecordName)
+		params.Set("type", resp.NextRecordType)
+		if resp.NextRecordIdentifier != "" {
+			params.Set("identifier", resp.NextRecordIdentifier)
+		} else {
+			params.Del("identifier")
+		}
+	}
+}
+
+type route53ChangeRequest struct {
+	XMLName xml.Name        `xml:"https://route53.amazonaws.com/doc/2013-04-01/ ChangeResourceRecordSetsRequest"`
+	Changes []route53Change `xml:"ChangeBatch>Changes>Change"`
+}
+
+func (c *route53Client) ChangeRecordSets(zoneId string, changes []route53Change) error {
+	body, err := xml.Marshal(route53ChangeRequest{Changes: changes})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return c.request("POST", "/hostedzone/"+zoneId+"/rrset/", body, nil)
+}
+
+// request makes a signed request to the Route53 API, decoding the
+// response into resp if it is not nil. As with the load balancer API,
+// errors reported by Route53 are returned unwrapped as *ec2.Error.
+func (c *route53Client) request(method, path string, body []byte, resp interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		reqBody = bytes.NewReader(body)
+	}
+	req, err := http.NewRequest(method, c.endpoint+"/"+route53APIVersion+path, reqBody)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/xml")
+	}
+	if err := c.sign(req, c.auth); err != nil {
+		return errors.Trace(err)
+	}
+	r, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Body.Close()
+
+	if r.StatusCode != http.StatusOK {
+		var errResp struct {
+			Code      string `xml:"Error>Code"`
+			Message   string `xml:"Error>Message"`
+			RequestId string `xml:"RequestId"`
+		}
+		if err := xml.NewDecoder(r.Body).Decode(&errResp); err != nil {
+			return errors.Errorf("%s %s failed with status %q", method, path, r.Status)
+		}
+		return &ec2.Error{
+			StatusCode: r.StatusCode,
+			Code:       errResp.Code,
+			Message:    errResp.Message,
+			RequestId:  errResp.RequestId,
+		}
+	}
+	if resp == nil {
+		return nil
+	}
+	return errors.Trace(xml.NewDecoder(r.Body).Decode(resp))
+}
@@ -0,0 +1,128 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/xml"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/testing"
+)
+
+type route53Suite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&route53Suite{})
+
+func (s *route53Suite) TestRoute53Changes(c *gc.C) {
+	owner := environs.DNSOwnerText("deadbeef")
+	existing := []route53RecordSet{{
+		Name:   "mysql.example.com.",
+		Type:   "A",
+		TTL:    60,
+		Values: []string{"203.0.113.20"},
+	}, {
+		Name:   "mysql.example.com.",
+		Type:   "TXT",
+		TTL:    60,
+		Values: []string{`"v=spf1 -all"`, `"` + owner + `"`},
+	}, {
+		Name:   "www.example.com.",
+		Type:   "A",
+		TTL:    300,
+		Values: []string{"203.0.113.30"},
+	}, {
+		Name:   "mediawiki.example.com.",
+		Type:   "TXT",
+		TTL:    60,
+		Values: []string{`"` + environs.DNSOwnerText("cafebabe") + `"`},
+	}}
+	changes, notOwned := route53Changes("example.com.", "deadbeef", existing, []environs.DNSRecord{
+		{Name: "wordpress", Addresses: []string{"203.0.113.10", "2001:db8::10"}},
+		{Name: "mysql"},
+		{Name: "www", Addresses: []string{"203.0.113.11"}},
+		{Name: "mediawiki", Addresses: []string{"203.0.113.12"}},
+	})
+	c.Check(notOwned, jc.DeepEquals, []string{"www", "mediawiki"})
+	c.Check(changes, jc.DeepEquals, []route53Change{{
+		Action: "UPSERT",
+		RecordSet: route53RecordSet{
+			Name:   "wordpress.example.com.",
+			Type:   "TXT",
+			TTL:    environs.DNSRecordTTL,
+			Values: []string{`"` + owner + `"`},
+		},
+	}, {
+		Action: "UPSERT",
+		RecordSet: route53RecordSet{
+			Name:   "wordpress.example.com.",
+			Type:   "A",
+			TTL:    environs.DNSRecordTTL,
+			Values: []string{"203.0.113.10"},
+		},
+	}, {
+		Action: "UPSERT",
+		RecordSet: route53RecordSet{
+			Name:   "wordpress.example.com.",
+			Type:   "AAAA",
+			TTL:    environs.DNSRecordTTL,
+			Values: []string{"2001:db8::10"},
+		},
+	}, {
+		Action:    "DELETE",
+		RecordSet: existing[0],
+	}, {
+		Action: "UPSERT",
+		RecordSet: route53RecordSet{
+			Name:   "mysql.example.com.",
+			Type:   "TXT",
+			TTL:    environs.DNSRecordTTL,
+			Values: []string{`"v=spf1 -all"`},
+		},
+	}})
+}
+
+func (s *route53Suite) TestRoute53OwnedRecords(c *gc.C) {
+	recordSets := route53DNSRecordSets("example.com.", []route53RecordSet{{
+		Name:   "example.com.",
+		Type:   "A",
+		Values: []string{"203.0.113.1"},
+	}, {
+		Name:   "wordpress.example.com.",
+		Type:   "A",
+		Values: []string{"203.0.113.10"},
+	}, {
+		Name:   "wordpress.example.com.",
+		Type:   "TXT",
+		Values: []string{`"` + environs.DNSOwnerText("deadbeef") + `"`},
+	}})
+	c.Check(recordSets[0].Name, gc.Equals, "")
+	c.Check(environs.OwnedDNSRecords(recordSets, "deadbeef"), jc.DeepEquals, []environs.DNSRecord{
+		{Name: "wordpress", Addresses: []string{"203.0.113.10"}},
+	})
+}
+
+func (s *route53Suite) TestChangeRequestXML(c *gc.C) {
+	body, err := xml.Marshal(route53ChangeRequest{Changes: []route53Change{{
+		Action: "UPSERT",
+		RecordSet: route53RecordSet{
+			Name:   "wordpress.example.com.",
+			Type:   "A",
+			TTL:    60,
+			Values: []string{"203.0.113.10"},
+		},
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(body), gc.Equals, ``+
+		`<ChangeResourceRecordSetsRequest xmlns="https://route53.amazonaws.com/doc/2013-04-01/">`+
+		`<ChangeBatch><Changes><Change><Action>UPSERT</Action><ResourceRecordSet>`+
+		`<Name>wordpress.example.com.</Name><Type>A</Type><TTL>60</TTL>`+
+		`<ResourceRecords><ResourceRecord><Value>203.0.113.10</Value></ResourceRecord></ResourceRecords>`+
+		`</ResourceRecordSet></Change></Changes></ChangeBatch>`+
+		`</ChangeResourceRecordSetsRequest>`)
+}
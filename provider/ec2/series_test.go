@@ -111,6 +111,7 @@ const testImageMetadataIndex = `
    "format": "products:1.0",
    "products": [
     "com.ubuntu.cloud:server:18.04:amd64",
+    "com.ubuntu.cloud:server:18.04:arm64",
     "com.ubuntu.cloud:server:16.04:amd64",
     "com.ubuntu.cloud:server:14.04:amd64",
     "com.ubuntu.cloud:server:14.04:i386",
@@ -183,6 +184,25 @@ const testImageMetadataProduct = `
         }
       }
     },
+    "com.ubuntu.cloud:server:18.04:arm64": {
+      "release": "bionic",
+      "version": "18.04",
+      "arch": "arm64",
+      "versions": {
+        "20121218": {
+          "items": {
+            "test1he": {
+              "root_store": "ssd",
+              "virt": "hvm",
+              "region": "test",
+              "id": "ami-00002133"
+            }
+          },
+          "pubname": "ubuntu-bionic-18.04-arm64-server-20121218",
+          "label": "release"
+        }
+      }
+    },
    "com.ubuntu.cloud:server:16.04:amd64": {
      "release": "trusty",
      "version": "16.04",
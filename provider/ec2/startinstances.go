@@ -0,0 +1,223 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os"
+	"github.com/juju/os/series"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
+)
+
+var _ environs.InstanceBatchBroker = (*environ)(nil)
+
+// StartInstances is specified in the InstanceBatchBroker interface.
+//
+// The instances are started with as few RunInstances requests as the
+// EC2 user data size limit allows. Each instance picks its own
+// cloud-init config out of the shared user data by its launch index.
+// Instances that each need their own security group, controllers,
+// machines with volumes to attach and non-Linux machines are started
+// one at a time.
+func (e *environ) StartInstances(ctx context.ProviderCallContext, args []environs.StartInstanceParams) []environs.BatchStartInstanceResult {
+	if !e.canStartInstancesTogether(args) {
+		results := make([]environs.BatchStartInstanceResult, len(args))
+		for i, arg := range args {
+			results[i].Result, results[i].Error = e.StartInstance(ctx, arg)
+		}
+		return results
+	}
+	return e.startInstancesTogether(ctx, args)
+}
+
+// canStartInstancesTogether reports whether the instances for the
+// given parameters can be started by a single RunInstances request.
+func (e *environ) canStartInstancesTogether(args []environs.StartInstanceParams) bool {
+	if len(args) < 2 || e.Config().FirewallMode() == config.FwInstance {
+		return false
+	}
+	for _, arg := range args {
+		if arg.InstanceConfig.Controller != nil || len(arg.VolumeAttachments) > 0 {
+			return false
+		}
+		os, err := series.GetOSFromSeries(arg.InstanceConfig.Series)
+		if err != nil || (os != jujuos.Ubuntu && os != jujuos.CentOS) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e *environ) startInstancesTogether(ctx context.ProviderCallContext, args []environs.StartInstanceParams) []environs.BatchStartInstanceResult {
+	results := make([]environs.BatchStartInstanceResult, len(args))
+	failAll := func(indices []int, err error) {
+		for _, i := range indices {
+			results[i].Error = err
+		}
+	}
+	callback := func(st status.Status, info string, data map[string]interface{}) error {
+		for _, arg := range args {
+			arg.StatusCallback(st, info, data)
+		}
+		return nil
+	}
+	all := make([]int, len(args))
+	for i := range args {
+		all[i] = i
+	}
+
+	callback(status.Allocating, "Verifying availability zone", nil)
+	availabilityZone, placementSubnetID, err := e.deriveAvailabilityZoneAndSubnetID(ctx, args[0])
+	if err != nil {
+		if !errors.IsNotValid(err) {
+			err = annotateStartInstanceError(ctx, err, "")
+		}
+		failAll(all, err)
+		return results
+	}
+	spec, err := e.findStartInstanceSpec(ctx, args[0])
+	if err != nil {
+		failAll(all, annotateStartInstanceError(ctx, err, ""))
+		return results
+	}
+
+	// Render each instance's config; an instance whose config cannot
+	// be completed fails on its own.
+	callback(status.Allocating, "Making user data", nil)
+	var pending []int
+	var parts [][]byte
+	for i, arg := range args {
+		if err := e.finishStartInstanceConfig(arg, spec); err != nil {
+			results[i].Error = err
+			continue
+		}
+		part, err := providerinit.ComposeUserData(arg.InstanceConfig, nil, SharedPartRenderer{})
+		if err != nil {
+			results[i].Error = common.ZoneIndependentError(
+				errors.Annotate(err, "cannot make user data"),
+			)
+			continue
+		}
+		pending = append(pending, i)
+		parts = append(parts, part)
+	}
+	if len(pending) == 0 {
+		return results
+	}
+
+	callback(status.Allocating, "Setting up groups", nil)
+	apiPorts := []int{args[pending[0]].InstanceConfig.APIInfo.Ports()[0]}
+	groups, err := e.setUpGroups(ctx, args[0].ControllerUUID, args[pending[0]].InstanceConfig.MachineId, apiPorts)
+	if err != nil {
+		failAll(pending, annotateStartInstanceError(ctx, err, "cannot set up groups"))
+		return results
+	}
+
+	blockDeviceMappings := getBlockDeviceMappings(args[0].Constraints, args[0].InstanceConfig.Series, false)
+	rootDiskSize := uint64(blockDeviceMappings[0].VolumeSize) * 1024
+	subnetID, err := e.selectSubnetID(ctx, args[0], availabilityZone, placementSubnetID)
+	if err != nil {
+		failAll(pending, err)
+		return results
+	}
+
+	for len(pending) > 0 {
+		count, userData, err := fitSharedUserData(parts)
+		if err != nil {
+			// Not even one config fits; start the next
+			// instance on its own with compressed user data.
+			i := pending[0]
+			results[i].Result, results[i].Error = e.StartInstance(ctx, args[i])
+			pending, parts = pending[1:], parts[1:]
+			continue
+		}
+		logger.Debugf("ec2 shared user data for %d instances; %d bytes", count, len(userData))
+		batch := pending[:count]
+		pending, parts = pending[count:], parts[count:]
+
+		callback(status.Allocating, fmt.Sprintf(
+			"Trying to start %d instances in availability zone %q", count, availabilityZone,
+		), nil)
+		instResp, err := runInstances(e.ec2, ctx, &ec2.RunInstances{
+			MinCount:            count,
+			MaxCount:            count,
+			UserData:            userData,
+			InstanceType:        spec.InstanceType.Name,
+			SecurityGroups:      groups,
+			BlockDeviceMappings: blockDeviceMappings,
+			ImageId:             spec.Image.Id,
+			AvailZone:           availabilityZone,
+			SubnetId:            subnetID,
+		}, callback)
+		if err != nil {
+			failAll(batch, runInstancesError(ctx, err))
+			continue
+		}
+
+		byIndex, err := e.instancesByLaunchIndex(instResp.Instances, count)
+		if err != nil {
+			var ids []instance.Id
+			for _, inst := range instResp.Instances {
+				ids = append(ids, instance.Id(inst.InstanceId))
+			}
+			e.stopFailedInstances(ctx, callback, ids...)
+			failAll(batch, err)
+			continue
+		}
+		for n, i := range batch {
+			inst := byIndex[n]
+			logger.Infof("started instance %q in AZ %q for machine %q", inst.Id(), inst.Instance.AvailZone, args[i].InstanceConfig.MachineId)
+			if err := e.tagStartedInstance(ctx, args[i], inst); err != nil {
+				e.stopFailedInstances(ctx, callback, inst.Id())
+				results[i].Error = err
+				continue
+			}
+			results[i].Result = &environs.StartInstanceResult{
+				Instance: inst,
+				Hardware: startedInstanceHardware(spec, inst, rootDiskSize),
+			}
+		}
+	}
+	return results
+}
+
+// instancesByLaunchIndex orders the instances started by a single
+// RunInstances request by their launch index, which selects the
+// part of the shared user data each instance applies.
+func (e *environ) instancesByLaunchIndex(started []ec2.Instance, count int) ([]*ec2Instance, error) {
+	if len(started) != count {
+		return nil, errors.Errorf("expected %d started instances, got %d", count, len(started))
+	}
+	byIndex := make([]*ec2Instance, count)
+	for i := range started {
+		index := started[i].AMILaunchIndex
+		if index < 0 || index >= count || byIndex[index] != nil {
+			return nil, errors.Errorf("unexpected launch index %d for instance %q", index, started[i].InstanceId)
+		}
+		byIndex[index] = &ec2Instance{e: e, Instance: &started[i]}
+	}
+	return byIndex, nil
+}
+
+// stopFailedInstances stops instances that were started but cannot be
+// handed to the provisioner.
+func (e *environ) stopFailedInstances(ctx context.ProviderCallContext, callback environs.StatusCallbackFunc, ids ...instance.Id) {
+	if len(ids) == 0 {
+		return
+	}
+	if err := e.terminateInstances(ctx, ids); err != nil {
+		callback(status.Error, fmt.Sprintf("error stopping failed instances: %v", err), nil)
+		logger.Errorf("error stopping failed instances: %v", err)
+	}
+}
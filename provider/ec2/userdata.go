@@ -5,6 +5,12 @@
 package ec2
 
 import (
+	"bytes"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"strconv"
+
 	"github.com/juju/errors"
 	jujuos "github.com/juju/os"
 	"github.com/juju/utils"
@@ -13,6 +19,10 @@ import (
 	"github.com/juju/juju/cloudconfig/providerinit/renderers"
 )
 
+// maxUserDataSize is the largest user data, before it is base64
+// encoded, that EC2 accepts for an instance.
+const maxUserDataSize = 16 * 1024
+
 type AmazonRenderer struct{}
 
 func (AmazonRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byte, error) {
@@ -25,3 +35,68 @@ func (AmazonRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byt
 		return nil, errors.Errorf("Cannot encode userdata for OS: %s", os.String())
 	}
 }
+
+// SharedPartRenderer renders the cloud-init config of one of several
+// instances sharing their user data. The config is not compressed, as
+// the shared user data is compressed as a whole.
+type SharedPartRenderer struct{}
+
+func (SharedPartRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byte, error) {
+	switch os {
+	case jujuos.Ubuntu, jujuos.CentOS:
+		return renderers.RenderYAML(cfg)
+	default:
+		return nil, errors.Errorf("Cannot share userdata for OS: %s", os.String())
+	}
+}
+
+// sharedUserData returns the user data for instances started with a
+// single RunInstances request. It is a multipart MIME message holding
+// each instance's cloud-init config, rendered by SharedPartRenderer,
+// in order. Each part has the launch index EC2 gives the instance it
+// is for, and cloud-init only applies the part matching the launch
+// index of the instance it runs on.
+func sharedUserData(parts [][]byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\nMIME-Version: 1.0\r\n\r\n", w.Boundary())
+	for i, part := range parts {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Type", "text/cloud-config")
+		header.Set("Launch-Index", strconv.Itoa(i))
+		pw, err := w.CreatePart(header)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := pw.Write(part); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return utils.Gzip(buf.Bytes()), nil
+}
+
+// fitSharedUserData returns how many of the parts, from the first,
+// fit in the shared user data of a single RunInstances request, along
+// with that user data.
+func fitSharedUserData(parts [][]byte) (int, []byte, error) {
+	var fitted []byte
+	n := 0
+	for n < len(parts) {
+		userData, err := sharedUserData(parts[:n+1])
+		if err != nil {
+			return 0, nil, errors.Trace(err)
+		}
+		if len(userData) > maxUserDataSize {
+			break
+		}
+		fitted = userData
+		n++
+	}
+	if n == 0 {
+		return 0, nil, errors.Errorf("user data is larger than %d bytes", maxUserDataSize)
+	}
+	return n, fitted, nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	stdcontext "context"
+	"encoding/json"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
+)
+
+// callTimeout is how long an external provider may take to answer a
+// call.
+const callTimeout = 10 * time.Minute
+
+// jsonCodec encodes the gRPC messages of the Environ service as JSON.
+type jsonCodec struct{}
+
+// Marshal is part of the grpc.Codec interface.
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+// Unmarshal is part of the grpc.Codec interface.
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	if len(data) == 0 {
+		// Methods without results may send empty messages.
+		return nil
+	}
+	return json.Unmarshal(data, v)
+}
+
+// String is part of the grpc.Codec interface.
+func (jsonCodec) String() string {
+	return "json"
+}
+
+// client calls the Environ service of an external provider.
+type client struct {
+	providerType string
+	conn         *grpc.ClientConn
+}
+
+// dial returns a client for the external provider at the address,
+// which must be "unix:<path>". Cloud credentials are sent to providers,
+// and the connection is not encrypted, so only local providers are
+// supported. The connection is made in the background, and remade
+// whenever it is lost.
+func dial(providerType, address string) (*client, error) {
+	if !strings.HasPrefix(address, "unix:") {
+		return nil, errors.NotValidf("external provider %q address %q (expected unix:<path>)", providerType, address)
+	}
+	conn, err := grpc.Dial(
+		address,
+		grpc.WithInsecure(),
+		grpc.WithDialer(dialAddress),
+		grpc.WithDefaultCallOptions(grpc.CallCustomCodec(jsonCodec{})),
+	)
+	if err != nil {
+		return nil, errors.Annotatef(err, "connecting to external provider %q", providerType)
+	}
+	return &client{providerType: providerType, conn: conn}, nil
+}
+
+func dialAddress(address string, timeout time.Duration) (net.Conn, error) {
+	return net.DialTimeout("unix", strings.TrimPrefix(address, "unix:"), timeout)
+}
+
+// Close closes the connection to the provider.
+func (c *client) Close() error {
+	return c.conn.Close()
+}
+
+// call calls the method, decoding its result into result, which may be
+// nil if the method has none. The call is abandoned if ctx, which may
+// be nil, starts dying. If the provider reports that the credential was
+// rejected, the credential is invalidated through ctx.
+func (c *client) call(ctx context.ProviderCallContext, method string, args, result interface{}) error {
+	callCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), callTimeout)
+	defer cancel()
+	if ctx != nil {
+		go func() {
+			select {
+			case <-ctx.Dying():
+				cancel()
+			case <-callCtx.Done():
+			}
+		}()
+	}
+	if args == nil {
+		args = struct{}{}
+	}
+	if result == nil {
+		result = &json.RawMessage{}
+	}
+	err := c.conn.Invoke(callCtx, "/"+serviceName+"/"+method, args, result)
+	if err == nil {
+		return nil
+	}
+	err = fromStatus(err)
+	if common.MaybeHandleCredentialError(common.IsCredentialNotValid, err, ctx) {
+		return errors.Trace(err)
+	}
+	return errors.Annotatef(err, "external provider %q %s", c.providerType, method)
+}
+
+// fromStatus returns the Juju error corresponding to a gRPC status
+// error returned by a provider.
+func fromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	switch st.Code() {
+	case codes.NotFound:
+		return errors.NewNotFound(nil, st.Message())
+	case codes.Unimplemented:
+		return errors.NewNotSupported(nil, st.Message())
+	case codes.InvalidArgument:
+		return errors.NewNotValid(nil, st.Message())
+	case codes.AlreadyExists:
+		return errors.NewAlreadyExists(nil, st.Message())
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return common.NewCredentialNotValid(st.Message())
+	}
+	return errors.New(st.Message())
+}
+
+// toStatus returns the gRPC status error corresponding to an error
+// returned by a Cloud.
+func toStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	code := codes.Unknown
+	switch {
+	case errors.IsNotFound(err):
+		code = codes.NotFound
+	case errors.IsNotSupported(err), errors.IsNotImplemented(err):
+		code = codes.Unimplemented
+	case errors.IsNotValid(err):
+		code = codes.InvalidArgument
+	case errors.IsAlreadyExists(err):
+		code = codes.AlreadyExists
+	case common.IsCredentialNotValid(err), errors.IsUnauthorized(err):
+		code = codes.Unauthenticated
+	}
+	return status.Error(code, err.Error())
+}
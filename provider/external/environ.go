@@ -0,0 +1,370 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	"sync"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	jujuos "github.com/juju/os"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/cloudconfig/providerinit"
+	"github.com/juju/juju/cloudconfig/providerinit/renderers"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/tools"
+)
+
+// environ is an environs.Environ that passes the cloud-specific work
+// to an external provider.
+type environ struct {
+	provider       *environProvider
+	controllerUUID string
+	cloud          environs.CloudSpec
+
+	mu  sync.Mutex
+	cfg *config.Config
+}
+
+var _ environs.Environ = (*environ)(nil)
+
+// Provider is part of the Environ interface.
+func (e *environ) Provider() environs.EnvironProvider {
+	return e.provider
+}
+
+// Config is part of the Environ interface.
+func (e *environ) Config() *config.Config {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.cfg
+}
+
+// SetConfig is part of the Environ interface.
+func (e *environ) SetConfig(cfg *config.Config) error {
+	e.mu.Lock()
+	old := e.cfg
+	e.mu.Unlock()
+	valid, err := e.provider.Validate(cfg, old)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	e.mu.Lock()
+	e.cfg = valid
+	e.mu.Unlock()
+	return nil
+}
+
+// modelArgs returns the arguments identifying the environ's model and
+// cloud to the external provider.
+func (e *environ) modelArgs() ModelArgs {
+	cfg := e.Config()
+	args := ModelArgs{
+		ControllerUUID: e.controllerUUID,
+		ModelUUID:      cfg.UUID(),
+		ModelName:      cfg.Name(),
+		Cloud: CloudSpec{
+			Name:             e.cloud.Name,
+			Region:           e.cloud.Region,
+			Endpoint:         e.cloud.Endpoint,
+			IdentityEndpoint: e.cloud.IdentityEndpoint,
+			StorageEndpoint:  e.cloud.StorageEndpoint,
+			CACertificates:   e.cloud.CACertificates,
+		},
+		Config: cfg.UnknownAttrs(),
+	}
+	if e.cloud.Credential != nil {
+		args.Cloud.AuthType = string(e.cloud.Credential.AuthType())
+		args.Cloud.Credential = e.cloud.Credential.Attributes()
+	}
+	return args
+}
+
+// PrepareForBootstrap is part of the Environ interface.
+func (e *environ) PrepareForBootstrap(ctx environs.BootstrapContext, controllerName string) error {
+	return errors.NotSupportedf("bootstrapping with external provider %q", e.provider.providerType)
+}
+
+// Bootstrap is part of the Environ interface.
+func (e *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.ProviderCallContext, args environs.BootstrapParams) (*environs.BootstrapResult, error) {
+	return nil, errors.NotSupportedf("bootstrapping with external provider %q", e.provider.providerType)
+}
+
+// Create is part of the Environ interface.
+func (e *environ) Create(ctx context.ProviderCallContext, args environs.CreateParams) error {
+	return errors.Trace(e.provider.client.call(ctx, methodCreateModel, e.modelArgs(), nil))
+}
+
+// Destroy is part of the Environ interface.
+func (e *environ) Destroy(ctx context.ProviderCallContext) error {
+	if err := common.Destroy(e, ctx); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(e.provider.client.call(ctx, methodDestroyModel, e.modelArgs(), nil))
+}
+
+// DestroyController is part of the Environ interface.
+func (e *environ) DestroyController(ctx context.ProviderCallContext, controllerUUID string) error {
+	if err := e.Destroy(ctx); err != nil {
+		return errors.Trace(err)
+	}
+	args := DestroyControllerArgs{ModelArgs: e.modelArgs()}
+	args.ControllerUUID = controllerUUID
+	return errors.Trace(e.provider.client.call(ctx, methodDestroyController, args, nil))
+}
+
+// AdoptResources is part of the Environ interface.
+func (e *environ) AdoptResources(ctx context.ProviderCallContext, controllerUUID string, fromVersion version.Number) error {
+	args := AdoptResourcesArgs{
+		ModelArgs:   e.modelArgs(),
+		FromVersion: fromVersion.String(),
+	}
+	args.ControllerUUID = controllerUUID
+	return errors.Trace(e.provider.client.call(ctx, methodAdoptResources, args, nil))
+}
+
+// StorageProviderTypes is part of the storage.ProviderRegistry
+// interface. External providers have no storage providers of their
+// own.
+func (e *environ) StorageProviderTypes() ([]storage.ProviderType, error) {
+	return nil, nil
+}
+
+// StorageProvider is part of the storage.ProviderRegistry interface.
+func (e *environ) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	return nil, errors.NotFoundf("storage provider %q", t)
+}
+
+// arches returns the architectures of the instances the external
+// provider can start.
+func (e *environ) arches() ([]string, error) {
+	info, err := e.provider.getInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(info.Arches) == 0 {
+		return []string{"amd64"}, nil
+	}
+	return info.Arches, nil
+}
+
+// ConstraintsValidator is part of the Environ interface.
+func (e *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
+	info, err := e.provider.getInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	arches, err := e.arches()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	validator := constraints.NewValidator()
+	validator.RegisterUnsupported(info.UnsupportedConstraints)
+	validator.RegisterVocabulary(constraints.Arch, arches)
+	return validator, nil
+}
+
+// PrecheckInstance is part of the Environ interface.
+func (e *environ) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
+	return errors.Trace(e.provider.client.call(ctx, methodPrecheckInstance, PrecheckInstanceArgs{
+		ModelArgs:   e.modelArgs(),
+		Series:      args.Series,
+		Constraints: args.Constraints.String(),
+		Placement:   args.Placement,
+	}, nil))
+}
+
+// InstanceTypes is part of the Environ interface.
+func (e *environ) InstanceTypes(ctx context.ProviderCallContext, c constraints.Value) (instances.InstanceTypesWithCostMetadata, error) {
+	return instances.InstanceTypesWithCostMetadata{}, errors.NotSupportedf("InstanceTypes")
+}
+
+// chooseArch returns the architecture of the instance to start: the
+// one in the constraints if there is one, or otherwise the first the
+// external provider supports for which there are agent binaries.
+func (e *environ) chooseArch(args environs.StartInstanceParams) (string, error) {
+	arches, err := e.arches()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	available := set.NewStrings(args.Tools.Arches()...)
+	if args.Constraints.HasArch() {
+		arch := *args.Constraints.Arch
+		if !set.NewStrings(arches...).Contains(arch) {
+			return "", errors.NotSupportedf("architecture %q", arch)
+		}
+		return arch, nil
+	}
+	for _, arch := range arches {
+		if available.Contains(arch) {
+			return arch, nil
+		}
+	}
+	return "", errors.Errorf("no agent binaries for architectures %q", arches)
+}
+
+// StartInstance is part of the InstanceBroker interface.
+func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) (*environs.StartInstanceResult, error) {
+	if args.InstanceConfig == nil {
+		return nil, errors.New("instance configuration is nil")
+	}
+	arch, err := e.chooseArch(args)
+	if err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+	agentBinaries, err := args.Tools.Match(tools.Filter{Arch: arch})
+	if err != nil {
+		return nil, common.ZoneIndependentError(errors.Errorf(
+			"chosen architecture %v not present in %v", arch, args.Tools.Arches(),
+		))
+	}
+	if err := args.InstanceConfig.SetTools(agentBinaries); err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+	if err := instancecfg.FinishInstanceConfig(args.InstanceConfig, e.Config()); err != nil {
+		return nil, common.ZoneIndependentError(err)
+	}
+	userData, err := providerinit.ComposeUserData(args.InstanceConfig, nil, userDataRenderer{})
+	if err != nil {
+		return nil, common.ZoneIndependentError(errors.Annotate(err, "cannot make user data"))
+	}
+
+	var result StartInstanceResult
+	if err := e.provider.client.call(ctx, methodStartInstance, StartInstanceArgs{
+		ModelArgs:        e.modelArgs(),
+		MachineId:        args.InstanceConfig.MachineId,
+		Series:           args.InstanceConfig.Series,
+		Arch:             arch,
+		Constraints:      args.Constraints.String(),
+		Placement:        args.Placement,
+		AvailabilityZone: args.AvailabilityZone,
+		UserData:         userData,
+		Tags:             args.InstanceConfig.Tags,
+	}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Hardware.Arch == "" {
+		result.Hardware.Arch = arch
+	}
+	return &environs.StartInstanceResult{
+		DisplayName: result.DisplayName,
+		Instance:    newInstance(result.Instance),
+		Hardware:    hardwareCharacteristics(result.Hardware),
+	}, nil
+}
+
+// MaintainInstance is part of the InstanceBroker interface.
+func (e *environ) MaintainInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) error {
+	return nil
+}
+
+// StopInstances is part of the InstanceBroker interface.
+func (e *environ) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return errors.Trace(e.provider.client.call(ctx, methodStopInstances, StopInstancesArgs{
+		ModelArgs: e.modelArgs(),
+		Ids:       instanceIds(ids),
+	}, nil))
+}
+
+// AllInstances is part of the InstanceBroker interface.
+func (e *environ) AllInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
+	found, err := e.instances(ctx, InstancesArgs{ModelArgs: e.modelArgs()})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]instances.Instance, len(found))
+	for i, inst := range found {
+		result[i] = newInstance(inst)
+	}
+	return result, nil
+}
+
+// Instances is part of the InstanceLister interface.
+func (e *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
+	if len(ids) == 0 {
+		return nil, environs.ErrNoInstances
+	}
+	found, err := e.instances(ctx, InstancesArgs{
+		ModelArgs: e.modelArgs(),
+		Ids:       instanceIds(ids),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byId := make(map[instance.Id]Instance)
+	for _, inst := range found {
+		byId[instance.Id(inst.Id)] = inst
+	}
+	result := make([]instances.Instance, len(ids))
+	n := 0
+	for i, id := range ids {
+		if inst, ok := byId[id]; ok {
+			result[i] = newInstance(inst)
+			n++
+		}
+	}
+	switch n {
+	case 0:
+		return nil, environs.ErrNoInstances
+	case len(ids):
+		return result, nil
+	}
+	return result, environs.ErrPartialInstances
+}
+
+// ControllerInstances is part of the Environ interface.
+func (e *environ) ControllerInstances(ctx context.ProviderCallContext, controllerUUID string) ([]instance.Id, error) {
+	args := InstancesArgs{
+		ModelArgs:      e.modelArgs(),
+		ControllerOnly: true,
+	}
+	args.ControllerUUID = controllerUUID
+	found, err := e.instances(ctx, args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(found) == 0 {
+		return nil, environs.ErrNotBootstrapped
+	}
+	ids := make([]instance.Id, len(found))
+	for i, inst := range found {
+		ids[i] = instance.Id(inst.Id)
+	}
+	return ids, nil
+}
+
+func (e *environ) instances(ctx context.ProviderCallContext, args InstancesArgs) ([]Instance, error) {
+	var result InstancesResult
+	if err := e.provider.client.call(ctx, methodInstances, args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Instances, nil
+}
+
+// userDataRenderer renders user data as plain YAML, which the external
+// provider encodes as its cloud requires.
+type userDataRenderer struct{}
+
+// Render is part of the renderers.ProviderRenderer interface.
+func (userDataRenderer) Render(cfg cloudinit.CloudConfig, os jujuos.OSType) ([]byte, error) {
+	switch os {
+	case jujuos.Ubuntu, jujuos.CentOS:
+		return renderers.RenderYAML(cfg)
+	default:
+		return nil, errors.Errorf("cannot encode userdata for OS: %s", os.String())
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external_test
+
+import (
+	stdcontext "context"
+	"net"
+	"path/filepath"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/external"
+	coretesting "github.com/juju/juju/testing"
+)
+
+// fakeCloud is an external provider with a fixed set of instances.
+type fakeCloud struct {
+	mu        sync.Mutex
+	calls     []string
+	instances []external.Instance
+	err       error
+}
+
+func (f *fakeCloud) record(call string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+	return f.err
+}
+
+func (f *fakeCloud) Info(ctx stdcontext.Context) (external.InfoResult, error) {
+	return external.InfoResult{
+		ProtocolVersion: external.ProtocolVersion,
+		Version:         3,
+		AuthTypes: map[string][]external.CredentialAttr{
+			"userpass": {
+				{Name: "username"},
+				{Name: "password", Hidden: true},
+			},
+		},
+		UnsupportedConstraints: []string{"tags"},
+	}, nil
+}
+
+func (f *fakeCloud) Ping(ctx stdcontext.Context, args external.PingArgs) error {
+	return f.record("Ping " + args.Endpoint)
+}
+
+func (f *fakeCloud) ValidateConfig(ctx stdcontext.Context, args external.ValidateConfigArgs) (external.ValidateConfigResult, error) {
+	if err := f.record("ValidateConfig"); err != nil {
+		return external.ValidateConfigResult{}, err
+	}
+	if args.Config["network"] == "bad" {
+		return external.ValidateConfigResult{}, errors.NotValidf("network %q", "bad")
+	}
+	return external.ValidateConfigResult{
+		Config: map[string]interface{}{"network": "default"},
+	}, nil
+}
+
+func (f *fakeCloud) CreateModel(ctx stdcontext.Context, args external.ModelArgs) error {
+	return f.record("CreateModel " + args.ModelName)
+}
+
+func (f *fakeCloud) DestroyModel(ctx stdcontext.Context, args external.ModelArgs) error {
+	return f.record("DestroyModel " + args.ModelName)
+}
+
+func (f *fakeCloud) DestroyController(ctx stdcontext.Context, args external.DestroyControllerArgs) error {
+	return f.record("DestroyController " + args.ControllerUUID)
+}
+
+func (f *fakeCloud) AdoptResources(ctx stdcontext.Context, args external.AdoptResourcesArgs) error {
+	return f.record("AdoptResources " + args.FromVersion)
+}
+
+func (f *fakeCloud) PrecheckInstance(ctx stdcontext.Context, args external.PrecheckInstanceArgs) error {
+	return f.record("PrecheckInstance " + args.Series)
+}
+
+func (f *fakeCloud) StartInstance(ctx stdcontext.Context, args external.StartInstanceArgs) (external.StartInstanceResult, error) {
+	return external.StartInstanceResult{}, errors.NotImplementedf("StartInstance")
+}
+
+func (f *fakeCloud) StopInstances(ctx stdcontext.Context, args external.StopInstancesArgs) error {
+	return f.record("StopInstances")
+}
+
+func (f *fakeCloud) Instances(ctx stdcontext.Context, args external.InstancesArgs) (external.InstancesResult, error) {
+	if err := f.record("Instances"); err != nil {
+		return external.InstancesResult{}, err
+	}
+	if len(args.Ids) == 0 {
+		return external.InstancesResult{Instances: f.instances}, nil
+	}
+	var result external.InstancesResult
+	for _, id := range args.Ids {
+		for _, inst := range f.instances {
+			if inst.Id == id {
+				result.Instances = append(result.Instances, inst)
+			}
+		}
+	}
+	return result, nil
+}
+
+type externalSuite struct {
+	testing.IsolationSuite
+	cloud      *fakeCloud
+	address    string
+	provider   environs.CloudEnvironProvider
+	unregister func()
+}
+
+var _ = gc.Suite(&externalSuite{})
+
+func (s *externalSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.cloud = &fakeCloud{
+		instances: []external.Instance{{
+			Id:        "inst-0",
+			Status:    "running",
+			Addresses: []external.Address{{Value: "10.0.0.1", Scope: "local-cloud"}},
+		}},
+	}
+	socket := filepath.Join(c.MkDir(), "fake.sock")
+	listener, err := net.Listen("unix", socket)
+	c.Assert(err, jc.ErrorIsNil)
+	server := external.NewServer(s.cloud)
+	go server.Serve(listener)
+	s.AddCleanup(func(*gc.C) { server.Stop() })
+
+	s.address = "unix:" + socket
+	s.unregister = external.RegisterProviders(map[string]string{"fake": s.address})
+	s.AddCleanup(func(*gc.C) { s.unregister() })
+	provider, err := environs.Provider("fake")
+	c.Assert(err, jc.ErrorIsNil)
+	s.provider = provider.(environs.CloudEnvironProvider)
+}
+
+func (s *externalSuite) open(c *gc.C) environs.Environ {
+	credential := cloud.NewCredential("userpass", map[string]string{
+		"username": "admin",
+		"password": "secret",
+	})
+	env, err := s.provider.Open(environs.OpenParams{
+		Cloud: environs.CloudSpec{
+			Type:       "fake",
+			Name:       "private",
+			Credential: &credential,
+		},
+		Config: coretesting.ModelConfig(c),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return env
+}
+
+func (s *externalSuite) TestRegisterProviders(c *gc.C) {
+	c.Assert(environs.RegisteredProviders(), jc.Contains, "fake")
+
+	// Clashing providers are skipped, and not unregistered.
+	unregister := external.RegisterProviders(map[string]string{"fake": s.address})
+	unregister()
+	provider, err := environs.Provider("fake")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(provider, gc.Equals, s.provider)
+
+	s.unregister()
+	_, err = environs.Provider("fake")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *externalSuite) TestVersion(c *gc.C) {
+	c.Assert(s.provider.Version(), gc.Equals, 3)
+}
+
+func (s *externalSuite) TestCredentialSchemas(c *gc.C) {
+	c.Assert(s.provider.CredentialSchemas(), jc.DeepEquals, map[cloud.AuthType]cloud.CredentialSchema{
+		"userpass": {
+			{Name: "username"},
+			{Name: "password", CredentialAttr: cloud.CredentialAttr{Hidden: true}},
+		},
+	})
+}
+
+func (s *externalSuite) TestPing(c *gc.C) {
+	err := s.provider.Ping(context.NewCloudCallContext(), "https://cloud.example.com")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.cloud.calls, jc.DeepEquals, []string{"Ping https://cloud.example.com"})
+}
+
+func (s *externalSuite) TestOpenUnknownAuthType(c *gc.C) {
+	credential := cloud.NewCredential("oauth2", nil)
+	_, err := s.provider.Open(environs.OpenParams{
+		Cloud: environs.CloudSpec{
+			Type:       "fake",
+			Name:       "private",
+			Credential: &credential,
+		},
+		Config: coretesting.ModelConfig(c),
+	})
+	c.Assert(err, gc.ErrorMatches, `validating cloud spec: "oauth2" auth-type not supported`)
+}
+
+func (s *externalSuite) TestValidateAppliesDefaults(c *gc.C) {
+	cfg, err := s.provider.Validate(coretesting.ModelConfig(c), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.UnknownAttrs()["network"], gc.Equals, "default")
+}
+
+func (s *externalSuite) TestValidateNotValid(c *gc.C) {
+	cfg := coretesting.CustomModelConfig(c, coretesting.Attrs{"network": "bad"})
+	_, err := s.provider.Validate(cfg, nil)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `external provider "fake" ValidateConfig: network "bad" not valid`)
+}
+
+func (s *externalSuite) TestConstraintsValidator(c *gc.C) {
+	validator, err := s.open(c).ConstraintsValidator(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+	unsupported, err := validator.Validate(constraints.MustParse("tags=foo arch=amd64"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unsupported, jc.DeepEquals, []string{"tags"})
+	_, err = validator.Validate(constraints.MustParse("arch=arm64"))
+	c.Assert(err, gc.ErrorMatches, `invalid constraint value: arch=arm64\nvalid values are: \[amd64\]`)
+}
+
+func (s *externalSuite) TestInstances(c *gc.C) {
+	ctx := context.NewCloudCallContext()
+	insts, err := s.open(c).Instances(ctx, []instance.Id{"inst-0", "inst-1"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(insts, gc.HasLen, 2)
+	c.Assert(insts[1], gc.IsNil)
+	c.Assert(insts[0].Id(), gc.Equals, instance.Id("inst-0"))
+	c.Assert(insts[0].Status(ctx), jc.DeepEquals, instance.Status{Status: status.Running})
+	addrs, err := insts[0].Addresses(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, jc.DeepEquals, []network.Address{
+		network.NewScopedAddress("10.0.0.1", network.ScopeCloudLocal),
+	})
+}
+
+func (s *externalSuite) TestInstancesNoneFound(c *gc.C) {
+	_, err := s.open(c).Instances(context.NewCloudCallContext(), []instance.Id{"inst-1"})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+}
+
+func (s *externalSuite) TestControllerInstancesNotBootstrapped(c *gc.C) {
+	s.cloud.instances = nil
+	_, err := s.open(c).ControllerInstances(context.NewCloudCallContext(), coretesting.ControllerTag.Id())
+	c.Assert(err, gc.Equals, environs.ErrNotBootstrapped)
+}
+
+func (s *externalSuite) TestBootstrapNotSupported(c *gc.C) {
+	err := s.open(c).PrepareForBootstrap(nil, "controller")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *externalSuite) TestInvalidCredential(c *gc.C) {
+	env := s.open(c)
+	s.cloud.err = common.NewCredentialNotValid("token expired")
+
+	var invalidated string
+	ctx := context.NewCloudCallContext()
+	ctx.InvalidateCredentialFunc = func(reason string) error {
+		invalidated = reason
+		return nil
+	}
+	_, err := env.AllInstances(ctx)
+	c.Assert(err, jc.Satisfies, common.IsCredentialNotValid)
+	c.Assert(invalidated, gc.Matches, ".*token expired.*")
+}
@@ -0,0 +1,67 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/network"
+)
+
+// externalInstance is an instance described by an external provider.
+type externalInstance struct {
+	inst Instance
+}
+
+var _ instances.Instance = (*externalInstance)(nil)
+
+func newInstance(inst Instance) *externalInstance {
+	return &externalInstance{inst: inst}
+}
+
+// Id is part of the instances.Instance interface.
+func (i *externalInstance) Id() instance.Id {
+	return instance.Id(i.inst.Id)
+}
+
+// Status is part of the instances.Instance interface.
+func (i *externalInstance) Status(ctx context.ProviderCallContext) instance.Status {
+	return instance.Status{
+		Status:  status.Status(i.inst.Status),
+		Message: i.inst.Message,
+	}
+}
+
+// Addresses is part of the instances.Instance interface.
+func (i *externalInstance) Addresses(ctx context.ProviderCallContext) ([]network.Address, error) {
+	addresses := make([]network.Address, len(i.inst.Addresses))
+	for j, addr := range i.inst.Addresses {
+		addresses[j] = network.NewScopedAddress(addr.Value, network.Scope(addr.Scope))
+	}
+	return addresses, nil
+}
+
+// hardwareCharacteristics converts the hardware reported by an external
+// provider, omitting the characteristics it did not report.
+func hardwareCharacteristics(hw Hardware) *instance.HardwareCharacteristics {
+	var hc instance.HardwareCharacteristics
+	if hw.Arch != "" {
+		hc.Arch = &hw.Arch
+	}
+	if hw.Mem != 0 {
+		hc.Mem = &hw.Mem
+	}
+	if hw.RootDisk != 0 {
+		hc.RootDisk = &hw.RootDisk
+	}
+	if hw.CpuCores != 0 {
+		hc.CpuCores = &hw.CpuCores
+	}
+	if hw.AvailabilityZone != "" {
+		hc.AvailabilityZone = &hw.AvailabilityZone
+	}
+	return &hc
+}
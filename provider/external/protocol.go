@@ -0,0 +1,256 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package external implements an experimental provider for clouds whose
+// providers are not part of Juju. An out-of-tree provider is a separate
+// process serving the Environ gRPC service described here, usually with
+// Serve on a unix socket, and is registered with the controller by
+// adding "<type>=unix:<path>" to the external-providers controller
+// config. Providers must run on the controller machines, as the socket
+// is not encrypted and requests carry cloud credentials.
+//
+// Juju does the work common to all providers, such as rendering the
+// cloud-init user data of new machines and validating constraints, and
+// the external provider does only the work specific to its cloud. The
+// gRPC messages are encoded as JSON rather than protocol buffers, as
+// the API's gRPC transport does, so that providers may be written in
+// any language with a gRPC implementation. Every request embeds
+// ModelArgs, identifying the model and the cloud credential to use.
+//
+// External providers cannot be used to bootstrap a controller, and do
+// not provide storage; storage plugins may be used for that.
+package external
+
+import (
+	"github.com/juju/juju/core/instance"
+)
+
+// ProtocolVersion is the version of the protocol implemented by this
+// package, which external providers report in their InfoResult.
+const ProtocolVersion = 1
+
+// serviceName is the name of the gRPC service implemented by external
+// providers.
+const serviceName = "juju.provider.Environ"
+
+// The methods of the Environ service.
+const (
+	methodInfo              = "Info"
+	methodPing              = "Ping"
+	methodValidateConfig    = "ValidateConfig"
+	methodCreateModel       = "CreateModel"
+	methodDestroyModel      = "DestroyModel"
+	methodDestroyController = "DestroyController"
+	methodAdoptResources    = "AdoptResources"
+	methodPrecheckInstance  = "PrecheckInstance"
+	methodStartInstance     = "StartInstance"
+	methodStopInstances     = "StopInstances"
+	methodInstances         = "Instances"
+)
+
+// InfoResult describes an external provider.
+type InfoResult struct {
+	// ProtocolVersion is the version of the protocol the provider
+	// implements.
+	ProtocolVersion int `json:"protocol-version"`
+
+	// Version is the version of the provider, recorded as the environ
+	// version of each model using it.
+	Version int `json:"version"`
+
+	// AuthTypes holds the credential attributes of each of the
+	// authentication types the provider supports.
+	AuthTypes map[string][]CredentialAttr `json:"auth-types"`
+
+	// UnsupportedConstraints holds the names of the constraints the
+	// provider ignores.
+	UnsupportedConstraints []string `json:"unsupported-constraints,omitempty"`
+
+	// Arches holds the architectures of the instances the provider
+	// can start. If it is empty, only amd64 instances are supported.
+	Arches []string `json:"arches,omitempty"`
+}
+
+// CredentialAttr describes an attribute of a credential.
+type CredentialAttr struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Hidden      bool   `json:"hidden,omitempty"`
+	Optional    bool   `json:"optional,omitempty"`
+}
+
+// CloudSpec describes the cloud, region and credential to use.
+type CloudSpec struct {
+	Name             string            `json:"name"`
+	Region           string            `json:"region,omitempty"`
+	Endpoint         string            `json:"endpoint,omitempty"`
+	IdentityEndpoint string            `json:"identity-endpoint,omitempty"`
+	StorageEndpoint  string            `json:"storage-endpoint,omitempty"`
+	CACertificates   []string          `json:"ca-certificates,omitempty"`
+	AuthType         string            `json:"auth-type,omitempty"`
+	Credential       map[string]string `json:"credential,omitempty"`
+}
+
+// ModelArgs identifies the model and cloud an operation is for.
+type ModelArgs struct {
+	ControllerUUID string `json:"controller-uuid"`
+	ModelUUID      string `json:"model-uuid"`
+	ModelName      string `json:"model-name"`
+
+	// Cloud describes the model's cloud.
+	Cloud CloudSpec `json:"cloud"`
+
+	// Config holds the model config attributes specific to the
+	// provider.
+	Config map[string]interface{} `json:"config,omitempty"`
+}
+
+// PingArgs is the request for Ping, which checks that a cloud
+// endpoint is valid. It has no result.
+type PingArgs struct {
+	Endpoint string `json:"endpoint"`
+}
+
+// ValidateConfigArgs is the request for ValidateConfig.
+type ValidateConfigArgs struct {
+	ModelArgs
+
+	// OldConfig holds the model's previous provider-specific config,
+	// if the config is being changed.
+	OldConfig map[string]interface{} `json:"old-config,omitempty"`
+}
+
+// ValidateConfigResult holds the validated provider-specific config,
+// with any defaults filled in.
+type ValidateConfigResult struct {
+	Config map[string]interface{} `json:"config"`
+}
+
+// DestroyControllerArgs is the request for DestroyController, which
+// destroys the resources of all the controller's models.
+type DestroyControllerArgs struct {
+	ModelArgs
+}
+
+// AdoptResourcesArgs is the request for AdoptResources, which
+// transfers the model's resources to the controller in ModelArgs.
+type AdoptResourcesArgs struct {
+	ModelArgs
+	FromVersion string `json:"from-version"`
+}
+
+// PrecheckInstanceArgs is the request for PrecheckInstance, which
+// checks that an instance could be started, before a machine is added.
+type PrecheckInstanceArgs struct {
+	ModelArgs
+	Series      string `json:"series"`
+	Constraints string `json:"constraints,omitempty"`
+	Placement   string `json:"placement,omitempty"`
+}
+
+// StartInstanceArgs is the request for StartInstance.
+type StartInstanceArgs struct {
+	ModelArgs
+
+	// MachineId is the id of the Juju machine.
+	MachineId string `json:"machine-id"`
+
+	// Series is the series the instance must run.
+	Series string `json:"series"`
+
+	// Arch is the architecture the instance must have, which the
+	// agent binaries in UserData are built for.
+	Arch string `json:"arch"`
+
+	// Constraints holds the machine's constraints.
+	Constraints string `json:"constraints,omitempty"`
+
+	// Placement holds the machine's placement directive, if any.
+	Placement string `json:"placement,omitempty"`
+
+	// AvailabilityZone is the zone to start the instance in, if any.
+	AvailabilityZone string `json:"availability-zone,omitempty"`
+
+	// UserData is the cloud-init user data the instance must be
+	// started with, which installs and starts the Juju agent.
+	UserData []byte `json:"user-data"`
+
+	// Tags holds the tags to set on the instance, if the cloud
+	// supports tags.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// StartInstanceResult describes the instance started.
+type StartInstanceResult struct {
+	Instance    Instance `json:"instance"`
+	DisplayName string   `json:"display-name,omitempty"`
+
+	// Hardware describes the instance's hardware.
+	Hardware Hardware `json:"hardware"`
+}
+
+// Hardware describes the hardware of an instance.
+type Hardware struct {
+	Arch             string `json:"arch,omitempty"`
+	Mem              uint64 `json:"mem,omitempty"`
+	RootDisk         uint64 `json:"root-disk,omitempty"`
+	CpuCores         uint64 `json:"cpu-cores,omitempty"`
+	AvailabilityZone string `json:"availability-zone,omitempty"`
+}
+
+// StopInstancesArgs is the request for StopInstances, which must not
+// fail for instances that no longer exist.
+type StopInstancesArgs struct {
+	ModelArgs
+	Ids []string `json:"ids"`
+}
+
+// InstancesArgs is the request for Instances.
+type InstancesArgs struct {
+	ModelArgs
+
+	// Ids holds the ids of the instances to describe. If it is empty,
+	// all the model's instances are described.
+	Ids []string `json:"ids,omitempty"`
+
+	// ControllerOnly is true if only the instances of controller
+	// machines are to be described.
+	ControllerOnly bool `json:"controller-only,omitempty"`
+}
+
+// InstancesResult describes the instances found, in any order.
+// Instances that were not found are omitted.
+type InstancesResult struct {
+	Instances []Instance `json:"instances"`
+}
+
+// Instance describes an instance.
+type Instance struct {
+	Id string `json:"id"`
+
+	// Status is one of the statuses of the core/status package, such
+	// as "running", and Message is the cloud's description of it.
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+
+	// Addresses holds the instance's IP addresses and hostnames.
+	Addresses []Address `json:"addresses,omitempty"`
+}
+
+// Address is an address of an instance.
+type Address struct {
+	Value string `json:"value"`
+
+	// Scope is "public", "local-cloud" or "local-machine", or empty
+	// if it is unknown.
+	Scope string `json:"scope,omitempty"`
+}
+
+// instanceIds converts instance ids to their protocol form.
+func instanceIds(ids []instance.Id) []string {
+	result := make([]string, len(ids))
+	for i, id := range ids {
+		result[i] = string(id)
+	}
+	return result
+}
@@ -0,0 +1,168 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/jsonschema"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+)
+
+var logger = loggo.GetLogger("juju.provider.external")
+
+// environProvider is an environs.CloudEnvironProvider that passes the
+// cloud-specific work to an external provider.
+type environProvider struct {
+	providerType string
+	client       *client
+
+	mu   sync.Mutex
+	info *InfoResult
+}
+
+var _ environs.CloudEnvironProvider = (*environProvider)(nil)
+
+// getInfo returns the description of the external provider, asking it
+// for one the first time it is needed.
+func (p *environProvider) getInfo() (InfoResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.info != nil {
+		return *p.info, nil
+	}
+	var info InfoResult
+	if err := p.client.call(nil, methodInfo, nil, &info); err != nil {
+		return InfoResult{}, errors.Trace(err)
+	}
+	if info.ProtocolVersion != ProtocolVersion {
+		return InfoResult{}, errors.NotSupportedf(
+			"external provider %q protocol version %d (expected %d)",
+			p.providerType, info.ProtocolVersion, ProtocolVersion,
+		)
+	}
+	p.info = &info
+	return info, nil
+}
+
+// Version is part of the EnvironProvider interface.
+func (p *environProvider) Version() int {
+	info, err := p.getInfo()
+	if err != nil {
+		logger.Warningf("cannot get version of external provider %q: %v", p.providerType, err)
+		return 0
+	}
+	return info.Version
+}
+
+// CloudSchema is part of the EnvironProvider interface. Clouds of
+// external providers cannot be added interactively.
+func (p *environProvider) CloudSchema() *jsonschema.Schema {
+	return nil
+}
+
+// Ping is part of the EnvironProvider interface.
+func (p *environProvider) Ping(ctx context.ProviderCallContext, endpoint string) error {
+	return errors.Trace(p.client.call(ctx, methodPing, PingArgs{Endpoint: endpoint}, nil))
+}
+
+// PrepareConfig is part of the EnvironProvider interface.
+func (p *environProvider) PrepareConfig(args environs.PrepareConfigParams) (*config.Config, error) {
+	if err := p.validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	return args.Config, nil
+}
+
+// Validate is part of the EnvironProvider interface.
+func (p *environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	if err := config.Validate(cfg, old); err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := ValidateConfigArgs{
+		ModelArgs: ModelArgs{
+			ModelUUID: cfg.UUID(),
+			ModelName: cfg.Name(),
+			Config:    cfg.UnknownAttrs(),
+		},
+	}
+	if old != nil {
+		args.OldConfig = old.UnknownAttrs()
+	}
+	var result ValidateConfigResult
+	if err := p.client.call(nil, methodValidateConfig, args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cfg.Apply(result.Config)
+}
+
+// Open is part of the CloudEnvironProvider interface.
+func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
+	if err := p.validateCloudSpec(args.Cloud); err != nil {
+		return nil, errors.Annotate(err, "validating cloud spec")
+	}
+	env := &environ{
+		provider:       p,
+		controllerUUID: args.ControllerUUID,
+		cloud:          args.Cloud,
+	}
+	if err := env.SetConfig(args.Config); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return env, nil
+}
+
+func (p *environProvider) validateCloudSpec(spec environs.CloudSpec) error {
+	if err := spec.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Credential == nil {
+		return errors.NotValidf("missing credential")
+	}
+	if _, ok := p.CredentialSchemas()[spec.Credential.AuthType()]; !ok {
+		return errors.NotSupportedf("%q auth-type", spec.Credential.AuthType())
+	}
+	return nil
+}
+
+// CredentialSchemas is part of the ProviderCredentials interface.
+func (p *environProvider) CredentialSchemas() map[cloud.AuthType]cloud.CredentialSchema {
+	info, err := p.getInfo()
+	if err != nil {
+		logger.Warningf("cannot get credential schemas of external provider %q: %v", p.providerType, err)
+		return nil
+	}
+	schemas := make(map[cloud.AuthType]cloud.CredentialSchema)
+	for authType, attrs := range info.AuthTypes {
+		schema := make(cloud.CredentialSchema, len(attrs))
+		for i, attr := range attrs {
+			schema[i] = cloud.NamedCredentialAttr{
+				Name: attr.Name,
+				CredentialAttr: cloud.CredentialAttr{
+					Description: attr.Description,
+					Hidden:      attr.Hidden,
+					Optional:    attr.Optional,
+				},
+			}
+		}
+		schemas[cloud.AuthType(authType)] = schema
+	}
+	return schemas
+}
+
+// DetectCredentials is part of the ProviderCredentials interface.
+func (p *environProvider) DetectCredentials() (*cloud.CloudCredential, error) {
+	return nil, errors.NotFoundf("credentials")
+}
+
+// FinalizeCredential is part of the ProviderCredentials interface.
+func (p *environProvider) FinalizeCredential(_ environs.FinalizeCredentialContext, args environs.FinalizeCredentialParams) (*cloud.Credential, error) {
+	return &args.Credential, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	"sort"
+
+	"github.com/juju/juju/environs"
+)
+
+// RegisterProviders registers an environ provider for each of the
+// external providers, which map provider types to the addresses they
+// are served at, as returned by the controller config's
+// ExternalProviders method. Providers that cannot be registered, such
+// as those whose types clash with a built-in provider, are logged and
+// skipped. The returned function unregisters the providers and closes
+// the connections to them.
+func RegisterProviders(providers map[string]string) (unregister func()) {
+	registry := environs.GlobalProviderRegistry()
+	providerTypes := make([]string, 0, len(providers))
+	for providerType := range providers {
+		providerTypes = append(providerTypes, providerType)
+	}
+	sort.Strings(providerTypes)
+
+	var registered []*environProvider
+	for _, providerType := range providerTypes {
+		address := providers[providerType]
+		client, err := dial(providerType, address)
+		if err != nil {
+			logger.Errorf("cannot register external provider: %v", err)
+			continue
+		}
+		provider := &environProvider{
+			providerType: providerType,
+			client:       client,
+		}
+		if err := registry.RegisterProvider(provider, providerType); err != nil {
+			logger.Errorf("cannot register external provider %q: %v", providerType, err)
+			client.Close()
+			continue
+		}
+		logger.Infof("registered external provider %q at %s", providerType, address)
+		registered = append(registered, provider)
+	}
+	return func() {
+		for _, provider := range registered {
+			registry.UnregisterProvider(provider.providerType)
+			if err := provider.client.Close(); err != nil {
+				logger.Warningf("closing connection to external provider %q: %v", provider.providerType, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package external
+
+import (
+	stdcontext "context"
+	"net"
+
+	"google.golang.org/grpc"
+)
+
+// Cloud is implemented by out-of-tree providers, to be served to Juju
+// by Serve. Errors satisfying errors.IsNotFound, errors.IsNotSupported,
+// errors.IsNotValid and errors.IsAlreadyExists are passed to Juju as
+// such; errors satisfying common.IsCredentialNotValid cause Juju to
+// invalidate the model's credential.
+type Cloud interface {
+	// Info describes the provider.
+	Info(ctx stdcontext.Context) (InfoResult, error)
+
+	// Ping checks that the endpoint is one of the provider's clouds.
+	Ping(ctx stdcontext.Context, args PingArgs) error
+
+	// ValidateConfig validates the provider-specific model config.
+	ValidateConfig(ctx stdcontext.Context, args ValidateConfigArgs) (ValidateConfigResult, error)
+
+	// CreateModel creates any cloud resources shared by the model's
+	// instances.
+	CreateModel(ctx stdcontext.Context, args ModelArgs) error
+
+	// DestroyModel destroys the cloud resources of the model, after
+	// Juju has stopped its instances.
+	DestroyModel(ctx stdcontext.Context, args ModelArgs) error
+
+	// DestroyController destroys the cloud resources of all the
+	// controller's models.
+	DestroyController(ctx stdcontext.Context, args DestroyControllerArgs) error
+
+	// AdoptResources transfers the model's cloud resources to the
+	// controller in args, when the model is migrated to it.
+	AdoptResources(ctx stdcontext.Context, args AdoptResourcesArgs) error
+
+	// PrecheckInstance checks that an instance could be started with
+	// the series, constraints and placement.
+	PrecheckInstance(ctx stdcontext.Context, args PrecheckInstanceArgs) error
+
+	// StartInstance starts an instance.
+	StartInstance(ctx stdcontext.Context, args StartInstanceArgs) (StartInstanceResult, error)
+
+	// StopInstances stops instances.
+	StopInstances(ctx stdcontext.Context, args StopInstancesArgs) error
+
+	// Instances describes the model's instances.
+	Instances(ctx stdcontext.Context, args InstancesArgs) (InstancesResult, error)
+}
+
+// NewServer returns a gRPC server serving the Environ service from the
+// cloud.
+func NewServer(cloud Cloud) *grpc.Server {
+	server := grpc.NewServer(grpc.CustomCodec(jsonCodec{}))
+	server.RegisterService(&environServiceDesc, cloud)
+	return server
+}
+
+// Serve serves the Environ service from the cloud, accepting
+// connections from Juju on the listener until it is closed.
+func Serve(listener net.Listener, cloud Cloud) error {
+	return NewServer(cloud).Serve(listener)
+}
+
+// noResult is returned for methods that have no result.
+type noResult struct{}
+
+var environServiceDesc = grpc.ServiceDesc{
+	ServiceName: serviceName,
+	HandlerType: (*Cloud)(nil),
+	Methods: []grpc.MethodDesc{
+		unaryMethod(methodInfo, func(ctx stdcontext.Context, cloud Cloud, dec func(interface{}) error) (interface{}, error) {
+			return cloud.Info(ctx)
+		}),
+		unaryMethod(methodPing, func(ctx stdcontext.Context, cloud Cloud, dec func(interface{}) error) (interface{}, error) {
+			var args PingArgs
+			if err := dec(&args); err != nil {
+				return nil, err
+			}
+			return noResult{}, cloud.Ping(ctx, args)
+		}),
+		unaryMethod(methodValidateConfig, func(ctx stdcontext.Context, cloud Cloud, dec func(interface{}) error) (interface{}, error) {
+			var args ValidateConfigArgs
+			if err := dec(&args); err != nil {
+				return nil, err
+			}
+			return cloud.ValidateConfig(ctx, args)
+		}),
+		unaryMethod(methodCreateModel, func(ctx stdcontext.Context, cloud Cloud, dec func(interface{}) error) (interface{}, error) {
+			var args ModelArgs
+			if err := dec(&args); err != nil {
+				return nil, err
+			}
+			return noResult{}, cloud.CreateModel(ctx, args)
+		}),
+		unaryMethod(methodDestroyModel, func(ctx stdcontext.Context, cloud Cloud, dec func(interface{}) error) (interface{}, error) {
+			var args ModelArgs
+			if err := dec(&args); err != nil {
+				return nil, err
+			}
+			return noResult{}, cloud.DestroyModel(ctx, args)
+		}),
+		unaryMethod(methodDestroyController, func(ctx stdcontext.Context, cloud Cloud, dec func(interface{}) error) (interface{}, error) {
+			var args DestroyControllerArgs
+			if err := dec(&args); err != nil {
+				return nil, err
+			}
+			return noResult{}, cloud.DestroyController(ctx, args)
+		}),
+		unaryMethod(methodAdoptResources, func(ctx stdcontext.Context, cloud Cloud, dec func(interface{}) error) (interface{}, error) {
+			var args AdoptResourcesArgs
+			if err := dec(&args); err != nil {
+				return nil, err
+			}
+			return noResult{}, cloud.AdoptResources(ctx, args)
+		}),
+		unaryMethod(methodPrecheckInstance, func(ctx stdcontext.Context, cloud Cloud, dec func(interface{}) error) (interface{}, error) {
+			var args PrecheckInstanceArgs
+			if err := dec(&args); err != nil {
+				return nil, err
+			}
+			return noResult{}, cloud.PrecheckInstance(ctx, args)
+		}),
+		unaryMethod(methodStartInstance, func(ctx stdcontext.Context, cloud Cloud, dec func(interface{}) error) (interface{}, error) {
+			var args StartInstanceArgs
+			if err := dec(&args); err != nil {
+				return nil, err
+			}
+			return cloud.StartInstance(ctx, args)
+		}),
+		unaryMethod(methodStopInstances, func(ctx stdcontext.Context, cloud Cloud, dec func(interface{}) error) (interface{}, error) {
+			var args StopInstancesArgs
+			if err := dec(&args); err != nil {
+				return nil, err
+			}
+			return noResult{}, cloud.StopInstances(ctx, args)
+		}),
+		unaryMethod(methodInstances, func(ctx stdcontext.Context, cloud Cloud, dec func(interface{}) error) (interface{}, error) {
+			var args InstancesArgs
+			if err := dec(&args); err != nil {
+				return nil, err
+			}
+			return cloud.Instances(ctx, args)
+		}),
+	},
+}
+
+// unaryMethod returns the description of a method of the Environ
+// service, which calls the Cloud and converts any error it returns to
+// a gRPC status error.
+func unaryMethod(
+	name string,
+	call func(ctx stdcontext.Context, cloud Cloud, dec func(interface{}) error) (interface{}, error),
+) grpc.MethodDesc {
+	return grpc.MethodDesc{
+		MethodName: name,
+		Handler: func(srv interface{}, ctx stdcontext.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+			result, err := call(ctx, srv.(Cloud), dec)
+			if err != nil {
+				return nil, toStatus(err)
+			}
+			return result, nil
+		},
+	}
+}
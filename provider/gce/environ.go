@@ -12,6 +12,7 @@ import (
 
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -69,6 +70,16 @@ type gceConnection interface {
 	InstanceDisks(zone, instanceId string) ([]*google.AttachedDisk, error)
 	// ListMachineTypes returns a list of machines available in the project and zone provided.
 	ListMachineTypes(zone string) ([]google.MachineType, error)
+
+	// EnsureLoadBalancer creates or updates the named load balancer so
+	// that it forwards the port ranges to the instances, and returns
+	// the IP address at which it is reachable.
+	EnsureLoadBalancer(name string, insts []google.Instance, portRanges []corenetwork.PortRange) (string, error)
+	// RemoveLoadBalancer removes the named load balancer, if it exists.
+	RemoveLoadBalancer(name string) error
+	// RemoveLoadBalancers removes all of the load balancers whose
+	// names have the given prefix.
+	RemoveLoadBalancers(prefix string) error
 }
 
 type environ struct {
@@ -86,6 +97,7 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.NetworkingEnviron = (*environ)(nil)
+var _ environs.VolumePreservingDestroyer = (*environ)(nil)
 
 // Function entry points defined as variables so they can be overridden
 // for testing purposes.
@@ -229,6 +241,24 @@ func (env *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.Pro
 // Destroy shuts down all known machines and destroys the rest of the
 // known environment.
 func (env *environ) Destroy(ctx context.ProviderCallContext) error {
+	return env.destroy(ctx, destroyEnv)
+}
+
+// DestroyExceptVolumes implements the environs.VolumePreservingDestroyer
+// interface.
+func (env *environ) DestroyExceptVolumes(ctx context.ProviderCallContext) error {
+	return env.destroy(ctx, common.DestroyExceptVolumes)
+}
+
+// destroy removes the model's load balancers and firewall rules, and
+// destroys everything else with the given function.
+func (env *environ) destroy(ctx context.ProviderCallContext, destroyRest func(environs.Environ, context.ProviderCallContext) error) error {
+	// Load balancers forward to the model's instances, and hold
+	// addresses that are not released with them.
+	if err := env.gce.RemoveLoadBalancers(env.loadBalancerName("")); err != nil {
+		return google.HandleCredentialError(errors.Annotate(err, "removing load balancers"), ctx)
+	}
+
 	ports, err := env.IngressRules(ctx)
 	if err != nil {
 		return errors.Trace(err)
@@ -240,7 +270,7 @@ func (env *environ) Destroy(ctx context.ProviderCallContext) error {
 		}
 	}
 
-	return destroyEnv(env, ctx)
+	return destroyRest(env, ctx)
 }
 
 // DestroyController implements the Environ interface.
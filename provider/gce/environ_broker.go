@@ -10,6 +10,7 @@ import (
 	jujuos "github.com/juju/os"
 	"github.com/juju/os/series"
 	"github.com/juju/utils"
+	"github.com/juju/utils/arch"
 
 	"github.com/juju/juju/cloudconfig/instancecfg"
 	"github.com/juju/juju/cloudconfig/providerinit"
@@ -99,6 +100,10 @@ func (env *environ) finishInstanceConfig(args environs.StartInstanceParams, spec
 func (env *environ) buildInstanceSpec(args environs.StartInstanceParams) (*instances.InstanceSpec, error) {
 	arches := args.Tools.Arches()
 	series := args.Tools.OneSeries()
+	imageMetadata := args.ImageMetadata
+	if len(imageMetadata) == 0 {
+		imageMetadata = centOSImageMetadata(series, env.cloud.Region)
+	}
 	spec, err := findInstanceSpec(
 		env, &instances.InstanceConstraint{
 			Region:      env.cloud.Region,
@@ -106,11 +111,35 @@ func (env *environ) buildInstanceSpec(args environs.StartInstanceParams) (*insta
 			Arches:      arches,
 			Constraints: args.Constraints,
 		},
-		args.ImageMetadata,
+		imageMetadata,
 	)
 	return spec, errors.Trace(err)
 }
 
+// centOSImageFamilies maps CentOS series to the families of the
+// public CentOS images on GCE.
+var centOSImageFamilies = map[string]string{
+	"centos7": "centos-7",
+}
+
+// centOSImageMetadata returns the metadata of the latest image in
+// the public GCE image family for the given CentOS series, as
+// simplestreams has no CentOS images for GCE. It returns nil for
+// other series.
+func centOSImageMetadata(series, region string) []*imagemetadata.ImageMetadata {
+	family, ok := centOSImageFamilies[series]
+	if !ok {
+		return nil
+	}
+	return []*imagemetadata.ImageMetadata{{
+		Id:         "family/" + family,
+		Arch:       arch.AMD64,
+		Version:    series,
+		RegionName: region,
+		VirtType:   vtype,
+	}}
+}
+
 var findInstanceSpec = func(
 	env *environ,
 	ic *instances.InstanceConstraint,
@@ -127,7 +156,8 @@ func (env *environ) findInstanceSpec(
 	imageMetadata []*imagemetadata.ImageMetadata,
 ) (*instances.InstanceSpec, error) {
 	images := instances.ImageMetadataToImages(imageMetadata)
-	spec, err := instances.FindInstanceSpec(images, ic, allInstanceTypes)
+	instanceTypes := instanceTypesWithNetBandwidth(ic.Constraints)
+	spec, err := instances.FindInstanceSpec(images, ic, instanceTypes)
 	return spec, errors.Trace(err)
 }
 
@@ -146,6 +176,8 @@ func (env *environ) imageURLBase(os jujuos.OSType) (string, error) {
 		}
 	case jujuos.Windows:
 		base = windowsImageBasePath
+	case jujuos.CentOS:
+		base = centOSImageBasePath
 	default:
 		return "", errors.Errorf("os %s is not supported on the gce provider", os.String())
 	}
@@ -210,7 +242,14 @@ func (env *environ) newRawInstance(ctx context.ProviderCallContext, args environ
 		// We currently treat all AddInstance failures
 		// as being zone-specific, so we'll retry in
 		// another zone.
-		return nil, google.HandleCredentialError(errors.Trace(err), ctx)
+		err = google.HandleCredentialError(errors.Trace(err), ctx)
+		switch {
+		case google.IsQuotaExceededError(err):
+			return nil, common.ProvisioningError(err, environs.ProvisioningErrorNeedsUserAction, "quota-exceeded")
+		case google.IsResourceExhaustedError(err):
+			return nil, common.ProvisioningError(err, environs.ProvisioningErrorRetryable, "insufficient-capacity")
+		}
+		return nil, err
 	}
 	return inst, nil
 }
@@ -229,7 +268,7 @@ func getMetadata(args environs.StartInstanceParams, os jujuos.OSType) (map[strin
 		metadata[tag] = value
 	}
 	switch os {
-	case jujuos.Ubuntu:
+	case jujuos.Ubuntu, jujuos.CentOS:
 		// We store a gz snapshop of information that is used by
 		// cloud-init and unpacked in to the /var/lib/cloud/instances folder
 		// for the instance. Due to a limitation with GCE and binary blobs
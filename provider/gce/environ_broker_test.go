@@ -11,8 +11,13 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	"github.com/juju/version"
+	"google.golang.org/api/googleapi"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
@@ -20,7 +25,10 @@ import (
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/gce"
+	"github.com/juju/juju/provider/gce/google"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/tools"
 )
 
 type environBrokerSuite struct {
@@ -122,6 +130,66 @@ func (s *environBrokerSuite) TestStartInstanceVolumeAvailabilityZone(c *gc.C) {
 	c.Assert(*result.Hardware.AvailabilityZone, gc.Equals, derivedZones[0])
 }
 
+func (s *environBrokerSuite) TestStartInstanceCentOS(c *gc.C) {
+	s.UnpatchInstanceCreation()
+	s.FakeConn.Inst = s.BaseInstance
+	s.FakeCommon.AZInstances = []common.AvailabilityZoneInstances{{
+		ZoneName:  "home-zone",
+		Instances: []instance.Id{s.Instance.Id()},
+	}}
+	icfg, err := instancecfg.NewInstanceConfig(
+		names.NewControllerTag(s.ControllerUUID), "42", "nonce",
+		imagemetadata.ReleasedStream, "centos7", &api.Info{
+			Addrs:    []string{"localhost:17070"},
+			CACert:   testing.CACert,
+			Password: "secret",
+			Tag:      names.NewMachineTag("42"),
+			ModelTag: testing.ModelTag,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	s.StartInstArgs.InstanceConfig = icfg
+	s.StartInstArgs.Tools = tools.List{{
+		Version: version.MustParseBinary("2.6.0-centos7-amd64"),
+		URL:     "https://example.org",
+	}}
+
+	result, err := s.Env.StartInstance(s.CallCtx, s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Instance, jc.DeepEquals, s.Instance)
+
+	var added []google.InstanceSpec
+	for _, call := range s.FakeConn.Calls {
+		if call.FuncName == "AddInstance" {
+			added = append(added, call.InstanceSpec)
+		}
+	}
+	c.Assert(added, gc.HasLen, 1)
+	c.Assert(added[0].Disks, gc.HasLen, 1)
+	c.Check(added[0].Disks[0].ImageURL, gc.Equals, gce.CentOSImageBasePath+"family/centos-7")
+	c.Check(added[0].Metadata["user-data"], gc.Not(gc.Equals), "")
+}
+
+func (s *environBrokerSuite) TestBuildInstanceSpecNoCentOSImages(c *gc.C) {
+	s.UnpatchInstanceCreation()
+	s.StartInstArgs.Tools = tools.List{{
+		Version: version.MustParseBinary("2.6.0-centos7-amd64"),
+		URL:     "https://example.org",
+	}}
+
+	spec, err := gce.BuildInstanceSpec(s.Env, s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.Image.Id, gc.Equals, "family/centos-7")
+	c.Check(spec.Image.Arch, gc.Equals, arch.AMD64)
+}
+
+func (s *environBrokerSuite) TestBuildInstanceSpecNoImages(c *gc.C) {
+	s.UnpatchInstanceCreation()
+
+	_, err := gce.BuildInstanceSpec(s.Env, s.StartInstArgs)
+	c.Assert(err, gc.ErrorMatches, `no "trusty" images in .* with arches \[amd64\]`)
+}
+
 func (s *environBrokerSuite) TestFinishInstanceConfig(c *gc.C) {
 	err := gce.FinishInstanceConfig(s.Env, s.StartInstArgs, s.spec)
 
@@ -145,6 +213,14 @@ func (s *environBrokerSuite) TestFindInstanceSpec(c *gc.C) {
 	c.Check(spec, jc.DeepEquals, s.spec)
 }
 
+func (s *environBrokerSuite) TestFindInstanceSpecNetBandwidth(c *gc.C) {
+	s.ic.Constraints = constraints.MustParse("net-bandwidth=10G")
+	spec, err := gce.FindInstanceSpec(s.Env, s.ic, s.imageMetadata)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(spec.InstanceType.Name, gc.Equals, "n1-highcpu-8")
+}
+
 func (s *environBrokerSuite) TestNewRawInstance(c *gc.C) {
 	s.FakeConn.Inst = s.BaseInstance
 	s.FakeCommon.AZInstances = []common.AvailabilityZoneInstances{{
@@ -175,6 +251,34 @@ func (s *environBrokerSuite) TestNewRawInstanceZoneSpecificError(c *gc.C) {
 	c.Assert(err, gc.Not(jc.Satisfies), environs.IsAvailabilityZoneIndependent)
 }
 
+func (s *environBrokerSuite) TestNewRawInstanceQuotaExceededError(c *gc.C) {
+	s.FakeConn.Err = &googleapi.Error{
+		Code:    403,
+		Message: "Quota 'CPUS' exceeded. Limit: 24.0 in region us-east1.",
+		Errors:  []googleapi.ErrorItem{{Reason: "quotaExceeded"}},
+	}
+
+	_, err := gce.NewRawInstance(s.Env, s.CallCtx, s.StartInstArgs, s.spec)
+	c.Assert(err, gc.ErrorMatches, ".*Quota 'CPUS' exceeded.*")
+	kind, code := environs.ClassifyProvisioningError(err)
+	c.Check(kind, gc.Equals, environs.ProvisioningErrorNeedsUserAction)
+	c.Check(code, gc.Equals, "quota-exceeded")
+}
+
+func (s *environBrokerSuite) TestNewRawInstanceResourceExhaustedError(c *gc.C) {
+	s.FakeConn.Err = &googleapi.Error{
+		Code:    503,
+		Message: "The zone does not have enough resources available to fulfill the request.",
+		Errors:  []googleapi.ErrorItem{{Reason: "resourcePoolExhausted"}},
+	}
+
+	_, err := gce.NewRawInstance(s.Env, s.CallCtx, s.StartInstArgs, s.spec)
+	c.Assert(err, gc.Not(jc.Satisfies), environs.IsAvailabilityZoneIndependent)
+	kind, code := environs.ClassifyProvisioningError(err)
+	c.Check(kind, gc.Equals, environs.ProvisioningErrorRetryable)
+	c.Check(code, gc.Equals, "insufficient-capacity")
+}
+
 func (s *environBrokerSuite) TestGetMetadataUbuntu(c *gc.C) {
 	metadata, err := gce.GetMetadata(s.StartInstArgs, jujuos.Ubuntu)
 
@@ -191,6 +295,13 @@ func (s *environBrokerSuite) TestGetMetadataWindows(c *gc.C) {
 	c.Check(metadata["sysprep-specialize-script-ps1"], gc.Matches, s.WindowsMetadata["sysprep-specialize-script-ps1"])
 }
 
+func (s *environBrokerSuite) TestGetMetadataCentOS(c *gc.C) {
+	metadata, err := gce.GetMetadata(s.StartInstArgs, jujuos.CentOS)
+
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(metadata, jc.DeepEquals, s.UbuntuMetadata)
+}
+
 func (s *environBrokerSuite) TestGetMetadataOSNotSupported(c *gc.C) {
 	metadata, err := gce.GetMetadata(s.StartInstArgs, jujuos.GenericLinux)
 
@@ -206,6 +317,7 @@ var getDisksTests = []struct {
 	{"trusty", gce.UbuntuImageBasePath, nil},
 	{"bionic", "/tmp/", nil}, // --config base-image-path=/tmp/
 	{"win2012r2", gce.WindowsImageBasePath, nil},
+	{"centos7", gce.CentOSImageBasePath, nil},
 	{"arch", "", errors.New("os Arch is not supported on the gce provider")},
 }
 
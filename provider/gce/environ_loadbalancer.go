@@ -0,0 +1,51 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+var _ environs.LoadBalancers = (*environ)(nil)
+
+// loadBalancerName returns the name used for the GCE resources making
+// up the load balancer for the named application.
+func (env *environ) loadBalancerName(applicationName string) string {
+	return env.namespace.Prefix() + "lb-" + applicationName
+}
+
+// EnsureLoadBalancer is part of the environs.LoadBalancers interface.
+func (env *environ) EnsureLoadBalancer(ctx context.ProviderCallContext, spec environs.LoadBalancerSpec) ([]network.Address, error) {
+	all, err := env.gceInstances(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	wanted := make(map[string]bool)
+	for _, id := range spec.Instances {
+		wanted[string(id)] = true
+	}
+	var insts []google.Instance
+	for _, inst := range all {
+		if wanted[inst.ID] {
+			insts = append(insts, inst)
+		}
+	}
+
+	addr, err := env.gce.EnsureLoadBalancer(env.loadBalancerName(spec.ApplicationName), insts, spec.PortRanges)
+	if err != nil {
+		return nil, google.HandleCredentialError(errors.Trace(err), ctx)
+	}
+	return []network.Address{network.NewScopedAddress(addr, network.ScopePublic)}, nil
+}
+
+// DeleteLoadBalancer is part of the environs.LoadBalancers interface.
+func (env *environ) DeleteLoadBalancer(ctx context.ProviderCallContext, applicationName string) error {
+	err := env.gce.RemoveLoadBalancer(env.loadBalancerName(applicationName))
+	return google.HandleCredentialError(errors.Trace(err), ctx)
+}
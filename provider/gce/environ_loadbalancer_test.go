@@ -0,0 +1,64 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/gce"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+type environLoadBalancerSuite struct {
+	gce.BaseSuite
+}
+
+var _ = gc.Suite(&environLoadBalancerSuite{})
+
+func (s *environLoadBalancerSuite) TestEnsureLoadBalancer(c *gc.C) {
+	spam := s.NewBaseInstance(c, "spam")
+	ham := s.NewBaseInstance(c, "ham")
+	s.FakeConn.Insts = []google.Instance{*spam, *ham}
+
+	portRanges := []corenetwork.PortRange{{FromPort: 80, ToPort: 80, Protocol: "tcp"}}
+	addrs, err := s.Env.EnsureLoadBalancer(s.CallCtx, environs.LoadBalancerSpec{
+		ApplicationName: "wordpress",
+		PortRanges:      portRanges,
+		Instances:       []instance.Id{"ham"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addrs, jc.DeepEquals, []network.Address{
+		network.NewScopedAddress("203.0.113.10", network.ScopePublic),
+	})
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Instances")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "EnsureLoadBalancer")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, s.Prefix()+"lb-wordpress")
+	c.Check(s.FakeConn.Calls[1].Insts, jc.DeepEquals, []google.Instance{*ham})
+	c.Check(s.FakeConn.Calls[1].PortRanges, jc.DeepEquals, portRanges)
+}
+
+func (s *environLoadBalancerSuite) TestEnsureLoadBalancerInvalidCredentialError(c *gc.C) {
+	s.FakeConn.Err = gce.InvalidCredentialError
+	s.FakeConn.FailOnCall = 1
+	c.Assert(s.InvalidatedCredentials, jc.IsFalse)
+	_, err := s.Env.EnsureLoadBalancer(s.CallCtx, environs.LoadBalancerSpec{ApplicationName: "wordpress"})
+	c.Check(err, gc.NotNil)
+	c.Assert(s.InvalidatedCredentials, jc.IsTrue)
+}
+
+func (s *environLoadBalancerSuite) TestDeleteLoadBalancer(c *gc.C) {
+	err := s.Env.DeleteLoadBalancer(s.CallCtx, "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "RemoveLoadBalancer")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, s.Prefix()+"lb-wordpress")
+}
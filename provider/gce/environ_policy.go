@@ -32,6 +32,7 @@ func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args envir
 }
 
 var unsupportedConstraints = []string{
+	constraints.ImageID,
 	constraints.Tags,
 	constraints.VirtType,
 }
@@ -62,6 +63,7 @@ func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (const
 	// unsupported
 
 	validator.RegisterUnsupported(unsupportedConstraints)
+	validator.RegisterSupported([]string{constraints.NetBandwidth})
 
 	// vocab
 
@@ -71,6 +73,8 @@ func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (const
 	}
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
 
+	validator.RegisterVocabulary(constraints.Arch, arches)
+
 	validator.RegisterVocabulary(constraints.Container, []string{vtype})
 
 	return validator, nil
@@ -176,6 +176,17 @@ func (s *environPolSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	c.Check(unsupported, jc.SameContents, []string{"tags", "virt-type"})
 }
 
+func (s *environPolSuite) TestConstraintsValidatorProviderSpecific(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("net-bandwidth=4G disk-iops=3000 cpu-family=skylake")
+	unsupported, err := validator.Validate(cons)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(unsupported, jc.SameContents, []string{"disk-iops", "cpu-family"})
+}
+
 func (s *environPolSuite) TestConstraintsValidatorVocabInstType(c *gc.C) {
 	validator, err := s.Env.ConstraintsValidator(s.CallCtx)
 	c.Assert(err, jc.ErrorIsNil)
@@ -196,6 +207,16 @@ func (s *environPolSuite) TestConstraintsValidatorVocabContainer(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "invalid constraint value: container=lxd\nvalid values are:.*")
 }
 
+func (s *environPolSuite) TestConstraintsValidatorVocabArch(c *gc.C) {
+	validator, err := s.Env.ConstraintsValidator(s.CallCtx)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("arch=arm64")
+	_, err = validator.Validate(cons)
+
+	c.Check(err, gc.ErrorMatches, "invalid constraint value: arch=arm64\nvalid values are:.*")
+}
+
 func (s *environPolSuite) TestConstraintsValidatorConflicts(c *gc.C) {
 	validator, err := s.Env.ConstraintsValidator(s.CallCtx)
 	c.Assert(err, jc.ErrorIsNil)
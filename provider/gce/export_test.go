@@ -21,6 +21,7 @@ var (
 	UbuntuImageBasePath                               = ubuntuImageBasePath
 	UbuntuDailyImageBasePath                          = ubuntuDailyImageBasePath
 	WindowsImageBasePath                              = windowsImageBasePath
+	CentOSImageBasePath                               = centOSImageBasePath
 )
 
 func ExposeInstBase(inst instances.Instance) *google.Instance {
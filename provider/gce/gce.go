@@ -26,6 +26,7 @@ const (
 	ubuntuImageBasePath      = "projects/ubuntu-os-cloud/global/images/"
 	ubuntuDailyImageBasePath = "projects/ubuntu-os-cloud-devel/global/images/"
 	windowsImageBasePath     = "projects/windows-cloud/global/images/"
+	centOSImageBasePath      = "projects/centos-cloud/global/images/"
 )
 
 var (
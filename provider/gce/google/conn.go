@@ -104,6 +104,63 @@ type rawConnectionWrapper interface {
 
 	// ListNetworks returns a list of Networks available in the given project.
 	ListNetworks(projectID string) ([]*compute.Network, error)
+
+	// GetTargetPool returns the named target pool in the given project
+	// and region. If the target pool does not exist then an error
+	// satisfying errors.IsNotFound will be returned.
+	GetTargetPool(projectID, region, name string) (*compute.TargetPool, error)
+
+	// AddTargetPool requests GCE to add the target pool to the given
+	// project and region. The call blocks until the target pool is added
+	// or the request fails.
+	AddTargetPool(projectID, region string, pool *compute.TargetPool) error
+
+	// RemoveTargetPool removes the named target pool from the given
+	// project and region. The call blocks until the target pool is
+	// removed or the request fails.
+	RemoveTargetPool(projectID, region, name string) error
+
+	// AddTargetPoolInstances adds the instances identified by the given
+	// URLs to the named target pool.
+	AddTargetPoolInstances(projectID, region, name string, instanceURLs []string) error
+
+	// RemoveTargetPoolInstances removes the instances identified by the
+	// given URLs from the named target pool.
+	RemoveTargetPoolInstances(projectID, region, name string, instanceURLs []string) error
+
+	// ListForwardingRules returns the forwarding rules in the given
+	// project and region for which the name starts with the provided
+	// prefix.
+	ListForwardingRules(projectID, region, prefix string) ([]*compute.ForwardingRule, error)
+
+	// AddForwardingRule requests GCE to add the forwarding rule to the
+	// given project and region. The call blocks until the forwarding
+	// rule is added or the request fails.
+	AddForwardingRule(projectID, region string, rule *compute.ForwardingRule) error
+
+	// RemoveForwardingRule removes the named forwarding rule from the
+	// given project and region. The call blocks until the forwarding
+	// rule is removed or the request fails.
+	RemoveForwardingRule(projectID, region, name string) error
+
+	// GetAddress returns the named static address in the given project
+	// and region. If the address does not exist then an error
+	// satisfying errors.IsNotFound will be returned.
+	GetAddress(projectID, region, name string) (*compute.Address, error)
+
+	// AddAddress requests GCE to reserve the static address in the
+	// given project and region. The call blocks until the address is
+	// reserved or the request fails.
+	AddAddress(projectID, region string, addr *compute.Address) error
+
+	// RemoveAddress releases the named static address in the given
+	// project and region. The call blocks until the address is released
+	// or the request fails.
+	RemoveAddress(projectID, region, name string) error
+
+	// ListAddresses returns the static addresses in the given project
+	// and region whose names have the given prefix.
+	ListAddresses(projectID, region, prefix string) ([]*compute.Address, error)
 }
 
 // TODO(ericsnow) Add specific error types for common failures
@@ -0,0 +1,199 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package google
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"google.golang.org/api/compute/v1"
+
+	corenetwork "github.com/juju/juju/core/network"
+)
+
+const instanceURLBase = "https://www.googleapis.com/compute/v1/projects/%s/zones/%s/instances/%s"
+
+// instanceURL returns the fully qualified URL of the instance, as
+// required when referring to it from a target pool.
+func (gce Connection) instanceURL(inst Instance) string {
+	return fmt.Sprintf(instanceURLBase, gce.projectID, inst.ZoneName, inst.ID)
+}
+
+// forwardingRuleName returns the name of the load balancer's
+// forwarding rule for the port range.
+func forwardingRuleName(name string, portRange corenetwork.PortRange) string {
+	return fmt.Sprintf("%s-%s-%d-%d", name, strings.ToLower(portRange.Protocol), portRange.FromPort, portRange.ToPort)
+}
+
+// EnsureLoadBalancer creates or updates the named network load
+// balancer so that it forwards exactly the given port ranges to exactly
+// the given instances, and returns the IP address at which it is
+// reachable. A load balancer is made up of a reserved static address, a
+// target pool holding the instances and a forwarding rule for each port
+// range, all of which are named after the load balancer.
+func (gce Connection) EnsureLoadBalancer(name string, insts []Instance, portRanges []corenetwork.PortRange) (string, error) {
+	addr, err := gce.ensureLoadBalancerAddress(name)
+	if err != nil {
+		return "", errors.Annotate(err, "reserving load balancer address")
+	}
+	pool, err := gce.ensureTargetPool(name, insts)
+	if err != nil {
+		return "", errors.Annotate(err, "updating load balancer target pool")
+	}
+
+	rules, err := gce.forwardingRules(name, pool)
+	if err != nil {
+		return "", errors.Annotate(err, "listing load balancer forwarding rules")
+	}
+	existing := set.NewStrings()
+	for _, rule := range rules {
+		existing.Add(rule.Name)
+	}
+	wanted := set.NewStrings()
+	for _, portRange := range portRanges {
+		ruleName := forwardingRuleName(name, portRange)
+		wanted.Add(ruleName)
+		if existing.Contains(ruleName) {
+			continue
+		}
+		rule := &compute.ForwardingRule{
+			Name:       ruleName,
+			IPAddress:  addr,
+			IPProtocol: strings.ToUpper(portRange.Protocol),
+			PortRange:  fmt.Sprintf("%d-%d", portRange.FromPort, portRange.ToPort),
+			Target:     pool.SelfLink,
+		}
+		if err := gce.raw.AddForwardingRule(gce.projectID, gce.region, rule); err != nil {
+			return "", errors.Annotatef(err, "adding forwarding rule %q", ruleName)
+		}
+	}
+	for _, ruleName := range existing.Difference(wanted).SortedValues() {
+		err := gce.raw.RemoveForwardingRule(gce.projectID, gce.region, ruleName)
+		if err != nil && !errors.IsNotFound(err) {
+			return "", errors.Annotatef(err, "removing forwarding rule %q", ruleName)
+		}
+	}
+	return addr, nil
+}
+
+// forwardingRules returns the forwarding rules targeting the load
+// balancer's target pool. Rules are matched on their target as well as
+// their name, since the name of one load balancer may be a prefix of
+// another's.
+func (gce Connection) forwardingRules(name string, pool *compute.TargetPool) ([]*compute.ForwardingRule, error) {
+	rules, err := gce.raw.ListForwardingRules(gce.projectID, gce.region, name+"-")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []*compute.ForwardingRule
+	for _, rule := range rules {
+		if rule.Target == pool.SelfLink {
+			result = append(result, rule)
+		}
+	}
+	return result, nil
+}
+
+func (gce Connection) ensureLoadBalancerAddress(name string) (string, error) {
+	addr, err := gce.raw.GetAddress(gce.projectID, gce.region, name)
+	if errors.IsNotFound(err) {
+		if err := gce.raw.AddAddress(gce.projectID, gce.region, &compute.Address{Name: name}); err != nil {
+			return "", errors.Trace(err)
+		}
+		addr, err = gce.raw.GetAddress(gce.projectID, gce.region, name)
+	}
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return addr.Address, nil
+}
+
+func (gce Connection) ensureTargetPool(name string, insts []Instance) (*compute.TargetPool, error) {
+	wanted := set.NewStrings()
+	for _, inst := range insts {
+		wanted.Add(gce.instanceURL(inst))
+	}
+
+	pool, err := gce.raw.GetTargetPool(gce.projectID, gce.region, name)
+	if errors.IsNotFound(err) {
+		pool = &compute.TargetPool{
+			Name:      name,
+			Instances: wanted.SortedValues(),
+		}
+		if err := gce.raw.AddTargetPool(gce.projectID, gce.region, pool); err != nil {
+			return nil, errors.Trace(err)
+		}
+		pool, err = gce.raw.GetTargetPool(gce.projectID, gce.region, name)
+		return pool, errors.Trace(err)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	existing := set.NewStrings(pool.Instances...)
+	if added := wanted.Difference(existing); !added.IsEmpty() {
+		err := gce.raw.AddTargetPoolInstances(gce.projectID, gce.region, name, added.SortedValues())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if removed := existing.Difference(wanted); !removed.IsEmpty() {
+		err := gce.raw.RemoveTargetPoolInstances(gce.projectID, gce.region, name, removed.SortedValues())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return pool, nil
+}
+
+// RemoveLoadBalancer removes the forwarding rules, target pool and
+// reserved address making up the named load balancer. Parts of the load
+// balancer that do not exist are ignored.
+func (gce Connection) RemoveLoadBalancer(name string) error {
+	pool, err := gce.raw.GetTargetPool(gce.projectID, gce.region, name)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "getting load balancer target pool")
+	}
+	if err == nil {
+		// Forwarding rules are only ever added once the target pool
+		// exists, so if there is no pool there are no rules either.
+		rules, err := gce.forwardingRules(name, pool)
+		if err != nil {
+			return errors.Annotate(err, "listing load balancer forwarding rules")
+		}
+		for _, rule := range rules {
+			err := gce.raw.RemoveForwardingRule(gce.projectID, gce.region, rule.Name)
+			if err != nil && !errors.IsNotFound(err) {
+				return errors.Annotatef(err, "removing forwarding rule %q", rule.Name)
+			}
+		}
+		err = gce.raw.RemoveTargetPool(gce.projectID, gce.region, name)
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotate(err, "removing load balancer target pool")
+		}
+	}
+	err = gce.raw.RemoveAddress(gce.projectID, gce.region, name)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "releasing load balancer address")
+	}
+	return nil
+}
+
+// RemoveLoadBalancers removes all of the load balancers whose names
+// have the given prefix. A load balancer's address is reserved before
+// and released after the rest of it, so the addresses find them all.
+func (gce Connection) RemoveLoadBalancers(prefix string) error {
+	addrs, err := gce.raw.ListAddresses(gce.projectID, gce.region, prefix)
+	if err != nil {
+		return errors.Annotate(err, "listing load balancer addresses")
+	}
+	for _, addr := range addrs {
+		if err := gce.RemoveLoadBalancer(addr.Name); err != nil {
+			return errors.Annotatef(err, "removing load balancer %q", addr.Name)
+		}
+	}
+	return nil
+}
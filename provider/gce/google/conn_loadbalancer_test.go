@@ -0,0 +1,124 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package google_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"google.golang.org/api/compute/v1"
+	gc "gopkg.in/check.v1"
+
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/provider/gce/google"
+)
+
+const instanceURL = "https://www.googleapis.com/compute/v1/projects/spam/zones/a-zone/instances/"
+
+func (s *connSuite) TestEnsureLoadBalancerCreates(c *gc.C) {
+	inst := google.NewInstance(google.InstanceSummary{ID: "inst-0", ZoneName: "a-zone"}, nil)
+	addr, err := s.Conn.EnsureLoadBalancer("lb", []google.Instance{*inst}, []corenetwork.PortRange{
+		{FromPort: 80, ToPort: 81, Protocol: "tcp"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr, gc.Equals, "203.0.113.10")
+
+	var names []string
+	for _, call := range s.FakeConn.Calls {
+		names = append(names, call.FuncName)
+	}
+	c.Check(names, jc.DeepEquals, []string{
+		"GetAddress", "AddAddress", "GetAddress",
+		"GetTargetPool", "AddTargetPool", "GetTargetPool",
+		"ListForwardingRules", "AddForwardingRule",
+	})
+	c.Check(s.FakeConn.Calls[4].TargetPool.Instances, jc.DeepEquals, []string{instanceURL + "inst-0"})
+	rule := s.FakeConn.Calls[7].ForwardingRule
+	c.Check(rule.Name, gc.Equals, "lb-tcp-80-81")
+	c.Check(rule.IPAddress, gc.Equals, "203.0.113.10")
+	c.Check(rule.IPProtocol, gc.Equals, "TCP")
+	c.Check(rule.PortRange, gc.Equals, "80-81")
+}
+
+func (s *connSuite) TestEnsureLoadBalancerUpdates(c *gc.C) {
+	s.FakeConn.Address = &compute.Address{Name: "lb", Address: "203.0.113.10"}
+	s.FakeConn.TargetPool = &compute.TargetPool{
+		Name:      "lb",
+		SelfLink:  "pool-link",
+		Instances: []string{instanceURL + "inst-0"},
+	}
+	s.FakeConn.ForwardingRules = []*compute.ForwardingRule{
+		{Name: "lb-tcp-80-80", Target: "pool-link"},
+		{Name: "lb-tcp-443-443", Target: "pool-link"},
+		// A rule belonging to another load balancer sharing the prefix.
+		{Name: "lb-tcp-22-22", Target: "other-pool-link"},
+	}
+
+	inst := google.NewInstance(google.InstanceSummary{ID: "inst-1", ZoneName: "a-zone"}, nil)
+	addr, err := s.Conn.EnsureLoadBalancer("lb", []google.Instance{*inst}, []corenetwork.PortRange{
+		{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+		{FromPort: 53, ToPort: 53, Protocol: "udp"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(addr, gc.Equals, "203.0.113.10")
+
+	var names []string
+	for _, call := range s.FakeConn.Calls {
+		names = append(names, call.FuncName)
+	}
+	c.Check(names, jc.DeepEquals, []string{
+		"GetAddress", "GetTargetPool",
+		"AddTargetPoolInstances", "RemoveTargetPoolInstances",
+		"ListForwardingRules", "AddForwardingRule", "RemoveForwardingRule",
+	})
+	c.Check(s.FakeConn.Calls[2].InstanceURLs, jc.DeepEquals, []string{instanceURL + "inst-1"})
+	c.Check(s.FakeConn.Calls[3].InstanceURLs, jc.DeepEquals, []string{instanceURL + "inst-0"})
+	c.Check(s.FakeConn.Calls[5].ForwardingRule.Name, gc.Equals, "lb-udp-53-53")
+	c.Check(s.FakeConn.Calls[6].Name, gc.Equals, "lb-tcp-443-443")
+}
+
+func (s *connSuite) TestRemoveLoadBalancer(c *gc.C) {
+	s.FakeConn.TargetPool = &compute.TargetPool{Name: "lb", SelfLink: "pool-link"}
+	s.FakeConn.ForwardingRules = []*compute.ForwardingRule{
+		{Name: "lb-tcp-80-80", Target: "pool-link"},
+		{Name: "lb-tcp-22-22", Target: "other-pool-link"},
+	}
+
+	err := s.Conn.RemoveLoadBalancer("lb")
+	c.Assert(err, jc.ErrorIsNil)
+
+	var names []string
+	for _, call := range s.FakeConn.Calls {
+		names = append(names, call.FuncName)
+	}
+	c.Check(names, jc.DeepEquals, []string{
+		"GetTargetPool", "ListForwardingRules", "RemoveForwardingRule",
+		"RemoveTargetPool", "RemoveAddress",
+	})
+	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "lb-tcp-80-80")
+}
+
+func (s *connSuite) TestRemoveLoadBalancers(c *gc.C) {
+	s.FakeConn.Addresses = []*compute.Address{{Name: "juju-lb-a"}, {Name: "juju-lb-b"}}
+
+	err := s.Conn.RemoveLoadBalancers("juju-lb-")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ListAddresses")
+	c.Check(s.FakeConn.Calls[0].Prefix, gc.Equals, "juju-lb-")
+	var removed []string
+	for _, call := range s.FakeConn.Calls {
+		if call.FuncName == "RemoveAddress" {
+			removed = append(removed, call.Name)
+		}
+	}
+	c.Check(removed, jc.DeepEquals, []string{"juju-lb-a", "juju-lb-b"})
+}
+
+func (s *connSuite) TestRemoveLoadBalancerNoTargetPool(c *gc.C) {
+	err := s.Conn.RemoveLoadBalancer("lb")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetTargetPool")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveAddress")
+}
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	"google.golang.org/api/googleapi"
 
 	"github.com/juju/juju/environs/context"
 )
@@ -114,3 +115,42 @@ var AuthorisationFailureStatusCodes = map[int]string{
 	// https://tools.ietf.org/html/rfc6749#section-5.2
 	http.StatusBadRequest: "Bad Request",
 }
+
+// IsQuotaExceededError returns whether the error was caused by one of
+// the project's quotas, such as the number of CPUs in a region, being
+// reached.
+func IsQuotaExceededError(err error) bool {
+	return hasErrorCode(err, "QUOTA_EXCEEDED", "quotaExceeded")
+}
+
+// IsResourceExhaustedError returns whether the error was caused by a
+// zone not having enough resources available to satisfy the request.
+func IsResourceExhaustedError(err error) bool {
+	return hasErrorCode(err, "ZONE_RESOURCE_POOL_EXHAUSTED", "ZONE_RESOURCE_POOL_EXHAUSTED_WITH_DETAILS", "resourcePoolExhausted")
+}
+
+// hasErrorCode returns whether the cause of the error is a failed GCE
+// operation, or a GCE API error, reporting any of the given codes.
+func hasErrorCode(err error, codes ...string) bool {
+	var found []string
+	switch cause := errors.Cause(err).(type) {
+	case waitError:
+		if cause.op.Error != nil {
+			for _, opErr := range cause.op.Error.Errors {
+				found = append(found, opErr.Code)
+			}
+		}
+	case *googleapi.Error:
+		for _, item := range cause.Errors {
+			found = append(found, item.Reason)
+		}
+	}
+	for _, code := range found {
+		for _, want := range codes {
+			if code == want {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -10,6 +10,8 @@ import (
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/environs/context"
@@ -95,6 +97,36 @@ func (*ErrorSuite) TestAnyOtherError(c *gc.C) {
 	c.Assert(returnedErr, gc.DeepEquals, notinterestingErr)
 }
 
+func (*ErrorSuite) TestIsQuotaExceededError(c *gc.C) {
+	op := &compute.Operation{
+		Name: "insert-1",
+		Error: &compute.OperationError{
+			Errors: []*compute.OperationErrorErrors{{Code: "QUOTA_EXCEEDED"}},
+		},
+	}
+	err := errors.Annotate(google.NewWaitError(op, nil), "sending new instance request")
+	c.Check(google.IsQuotaExceededError(err), jc.IsTrue)
+	c.Check(google.IsResourceExhaustedError(err), jc.IsFalse)
+
+	apiErr := &googleapi.Error{Code: 403, Errors: []googleapi.ErrorItem{{Reason: "quotaExceeded"}}}
+	c.Check(google.IsQuotaExceededError(errors.Trace(apiErr)), jc.IsTrue)
+	c.Check(google.IsQuotaExceededError(errors.New("kaboom")), jc.IsFalse)
+	c.Check(google.IsQuotaExceededError(nil), jc.IsFalse)
+}
+
+func (*ErrorSuite) TestIsResourceExhaustedError(c *gc.C) {
+	op := &compute.Operation{
+		Name: "insert-1",
+		Error: &compute.OperationError{
+			Errors: []*compute.OperationErrorErrors{{Code: "ZONE_RESOURCE_POOL_EXHAUSTED"}},
+		},
+	}
+	err := errors.Trace(google.NewWaitError(op, nil))
+	c.Check(google.IsResourceExhaustedError(err), jc.IsTrue)
+	c.Check(google.IsQuotaExceededError(err), jc.IsFalse)
+	c.Check(google.IsResourceExhaustedError(google.NewWaitError(&compute.Operation{}, errors.New("timed out"))), jc.IsFalse)
+}
+
 type googlyError struct {
 	msg string
 }
@@ -20,6 +20,10 @@ var (
 	NewRuleSetFromRules = newRuleSetFromRules
 )
 
+func NewWaitError(op *compute.Operation, cause error) error {
+	return waitError{op, cause}
+}
+
 func SetRawConn(conn *Connection, raw rawConnectionWrapper) {
 	conn.raw = raw
 }
@@ -421,3 +421,145 @@ func (rc *rawConn) ListNetworks(projectID string) ([]*compute.Network, error) {
 	}
 	return results, nil
 }
+
+func (rc *rawConn) GetTargetPool(projectID, region, name string) (*compute.TargetPool, error) {
+	call := rc.TargetPools.Get(projectID, region, name)
+	pool, err := call.Do()
+	return pool, errors.Trace(convertRawAPIError(err))
+}
+
+func (rc *rawConn) AddTargetPool(projectID, region string, pool *compute.TargetPool) error {
+	call := rc.TargetPools.Insert(projectID, region, pool)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) RemoveTargetPool(projectID, region, name string) error {
+	call := rc.TargetPools.Delete(projectID, region, name)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(convertRawAPIError(err))
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(convertRawAPIError(err))
+}
+
+func instanceReferences(instanceURLs []string) []*compute.InstanceReference {
+	refs := make([]*compute.InstanceReference, len(instanceURLs))
+	for i, url := range instanceURLs {
+		refs[i] = &compute.InstanceReference{Instance: url}
+	}
+	return refs
+}
+
+func (rc *rawConn) AddTargetPoolInstances(projectID, region, name string, instanceURLs []string) error {
+	req := &compute.TargetPoolsAddInstanceRequest{
+		Instances: instanceReferences(instanceURLs),
+	}
+	call := rc.TargetPools.AddInstance(projectID, region, name, req)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) RemoveTargetPoolInstances(projectID, region, name string, instanceURLs []string) error {
+	req := &compute.TargetPoolsRemoveInstanceRequest{
+		Instances: instanceReferences(instanceURLs),
+	}
+	call := rc.TargetPools.RemoveInstance(projectID, region, name, req)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) ListForwardingRules(projectID, region, prefix string) ([]*compute.ForwardingRule, error) {
+	ctx := context.Background()
+	call := rc.ForwardingRules.List(projectID, region)
+	var results []*compute.ForwardingRule
+	err := call.Pages(ctx, func(page *compute.ForwardingRuleList) error {
+		for _, rule := range page.Items {
+			if strings.HasPrefix(rule.Name, prefix) {
+				results = append(results, rule)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+func (rc *rawConn) AddForwardingRule(projectID, region string, rule *compute.ForwardingRule) error {
+	call := rc.ForwardingRules.Insert(projectID, region, rule)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) RemoveForwardingRule(projectID, region, name string) error {
+	call := rc.ForwardingRules.Delete(projectID, region, name)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(convertRawAPIError(err))
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(convertRawAPIError(err))
+}
+
+func (rc *rawConn) GetAddress(projectID, region, name string) (*compute.Address, error) {
+	call := rc.Addresses.Get(projectID, region, name)
+	addr, err := call.Do()
+	return addr, errors.Trace(convertRawAPIError(err))
+}
+
+func (rc *rawConn) AddAddress(projectID, region string, addr *compute.Address) error {
+	call := rc.Addresses.Insert(projectID, region, addr)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) ListAddresses(projectID, region, prefix string) ([]*compute.Address, error) {
+	ctx := context.Background()
+	call := rc.Addresses.List(projectID, region)
+	var results []*compute.Address
+	err := call.Pages(ctx, func(page *compute.AddressList) error {
+		for _, addr := range page.Items {
+			if strings.HasPrefix(addr.Name, prefix) {
+				results = append(results, addr)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results, nil
+}
+
+func (rc *rawConn) RemoveAddress(projectID, region, name string) error {
+	call := rc.Addresses.Delete(projectID, region, name)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(convertRawAPIError(err))
+	}
+	err = rc.waitOperation(projectID, operation, attemptsLong)
+	return errors.Trace(convertRawAPIError(err))
+}
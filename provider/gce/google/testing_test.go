@@ -4,6 +4,7 @@
 package google
 
 import (
+	"github.com/juju/errors"
 	"google.golang.org/api/compute/v1"
 	gc "gopkg.in/check.v1"
 
@@ -170,6 +171,10 @@ type fakeCall struct {
 	Metadata         *compute.Metadata
 	LabelFingerprint string
 	Labels           map[string]string
+	TargetPool       *compute.TargetPool
+	InstanceURLs     []string
+	ForwardingRule   *compute.ForwardingRule
+	Address          *compute.Address
 }
 
 type fakeConn struct {
@@ -187,6 +192,11 @@ type fakeConn struct {
 	AttachedDisks []*compute.AttachedDisk
 	Networks      []*compute.Network
 	Subnetworks   []*compute.Subnetwork
+
+	TargetPool      *compute.TargetPool
+	ForwardingRules []*compute.ForwardingRule
+	Address         *compute.Address
+	Addresses       []*compute.Address
 }
 
 func (rc *fakeConn) GetProject(projectID string) (*compute.Project, error) {
@@ -542,3 +552,221 @@ func (rc *fakeConn) ListSubnetworks(projectID, region string) ([]*compute.Subnet
 	}
 	return rc.Subnetworks, nil
 }
+
+func (rc *fakeConn) GetTargetPool(projectID, region, name string) (*compute.TargetPool, error) {
+	call := fakeCall{
+		FuncName:  "GetTargetPool",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rc.TargetPool == nil {
+		return nil, errors.NotFoundf("target pool %q", name)
+	}
+	return rc.TargetPool, nil
+}
+
+func (rc *fakeConn) AddTargetPool(projectID, region string, pool *compute.TargetPool) error {
+	call := fakeCall{
+		FuncName:   "AddTargetPool",
+		ProjectID:  projectID,
+		Region:     region,
+		TargetPool: pool,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err == nil {
+		rc.TargetPool = pool
+	}
+	return err
+}
+
+func (rc *fakeConn) RemoveTargetPool(projectID, region, name string) error {
+	call := fakeCall{
+		FuncName:  "RemoveTargetPool",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) AddTargetPoolInstances(projectID, region, name string, instanceURLs []string) error {
+	call := fakeCall{
+		FuncName:     "AddTargetPoolInstances",
+		ProjectID:    projectID,
+		Region:       region,
+		Name:         name,
+		InstanceURLs: instanceURLs,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) RemoveTargetPoolInstances(projectID, region, name string, instanceURLs []string) error {
+	call := fakeCall{
+		FuncName:     "RemoveTargetPoolInstances",
+		ProjectID:    projectID,
+		Region:       region,
+		Name:         name,
+		InstanceURLs: instanceURLs,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) ListForwardingRules(projectID, region, prefix string) ([]*compute.ForwardingRule, error) {
+	call := fakeCall{
+		FuncName:  "ListForwardingRules",
+		ProjectID: projectID,
+		Region:    region,
+		Prefix:    prefix,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rc.ForwardingRules, nil
+}
+
+func (rc *fakeConn) AddForwardingRule(projectID, region string, rule *compute.ForwardingRule) error {
+	call := fakeCall{
+		FuncName:       "AddForwardingRule",
+		ProjectID:      projectID,
+		Region:         region,
+		ForwardingRule: rule,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) RemoveForwardingRule(projectID, region, name string) error {
+	call := fakeCall{
+		FuncName:  "RemoveForwardingRule",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) ListAddresses(projectID, region, prefix string) ([]*compute.Address, error) {
+	call := fakeCall{
+		FuncName:  "ListAddresses",
+		ProjectID: projectID,
+		Region:    region,
+		Prefix:    prefix,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return rc.Addresses, nil
+}
+
+func (rc *fakeConn) GetAddress(projectID, region, name string) (*compute.Address, error) {
+	call := fakeCall{
+		FuncName:  "GetAddress",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rc.Address == nil {
+		return nil, errors.NotFoundf("address %q", name)
+	}
+	return rc.Address, nil
+}
+
+func (rc *fakeConn) AddAddress(projectID, region string, addr *compute.Address) error {
+	call := fakeCall{
+		FuncName:  "AddAddress",
+		ProjectID: projectID,
+		Region:    region,
+		Address:   addr,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	if err == nil {
+		rc.Address = &compute.Address{Name: addr.Name, Address: "203.0.113.10"}
+	}
+	return err
+}
+
+func (rc *fakeConn) RemoveAddress(projectID, region, name string) error {
+	call := fakeCall{
+		FuncName:  "RemoveAddress",
+		ProjectID: projectID,
+		Region:    region,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
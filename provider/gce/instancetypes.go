@@ -6,6 +6,7 @@ package gce
 import (
 	"github.com/juju/utils/arch"
 
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/environs/instances"
 )
 
@@ -155,3 +156,46 @@ var allInstanceTypes = []instances.InstanceType{
 		VirtType: &vtype,
 	},
 }
+
+const (
+	// perCoreNetBandwidth is the egress bandwidth, in Mbit/s, that GCE
+	// allows for each vCPU of a machine type.
+	perCoreNetBandwidth = 2000
+
+	// maxNetBandwidth is the maximum egress bandwidth, in Mbit/s, of
+	// any machine type.
+	maxNetBandwidth = 16000
+
+	// sharedCoreNetBandwidth is the egress bandwidth, in Mbit/s, of
+	// the shared-core machine types.
+	sharedCoreNetBandwidth = 1000
+)
+
+// netBandwidth returns the egress bandwidth cap, in Mbit/s, of the
+// machine type.
+func netBandwidth(itype instances.InstanceType) uint64 {
+	switch itype.Name {
+	case "f1-micro", "g1-small":
+		return sharedCoreNetBandwidth
+	}
+	bandwidth := itype.CpuCores * perCoreNetBandwidth
+	if bandwidth > maxNetBandwidth {
+		bandwidth = maxNetBandwidth
+	}
+	return bandwidth
+}
+
+// instanceTypesWithNetBandwidth returns the machine types that satisfy
+// the net-bandwidth constraint, if there is one.
+func instanceTypesWithNetBandwidth(cons constraints.Value) []instances.InstanceType {
+	if !cons.HasNetBandwidth() {
+		return allInstanceTypes
+	}
+	var result []instances.InstanceType
+	for _, itype := range allInstanceTypes {
+		if netBandwidth(itype) >= *cons.NetBandwidth {
+			result = append(result, itype)
+		}
+	}
+	return result
+}
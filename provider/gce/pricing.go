@@ -0,0 +1,67 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+)
+
+var _ environs.InstancePricer = (*environ)(nil)
+
+// machineTypeCosts holds the on-demand cost of the machine types in
+// allInstanceTypes, in ten-thousandths of a US dollar per hour. The
+// costs are those of the us-central1 region, and so are an estimate
+// elsewhere.
+var machineTypeCosts = map[string]uint64{
+	"n1-standard-1":  475,
+	"n1-standard-2":  950,
+	"n1-standard-4":  1900,
+	"n1-standard-8":  3800,
+	"n1-standard-16": 7600,
+	"n1-standard-32": 15200,
+	"n1-highmem-2":   1184,
+	"n1-highmem-4":   2368,
+	"n1-highmem-8":   4736,
+	"n1-highmem-16":  9472,
+	"n1-highmem-32":  18944,
+	"n1-highcpu-2":   709,
+	"n1-highcpu-4":   1418,
+	"n1-highcpu-8":   2836,
+	"n1-highcpu-16":  5672,
+	"n1-highcpu-32":  11344,
+	"f1-micro":       76,
+	"g1-small":       257,
+}
+
+// machineTypeCostDivisor converts the costs in machineTypeCosts to US
+// dollars.
+const machineTypeCostDivisor = 10000
+
+// InstancePricing implements environs.InstancePricer.
+func (env *environ) InstancePricing(ctx context.ProviderCallContext, cons constraints.Value) (environs.InstancePricing, error) {
+	itypes := make([]instances.InstanceType, 0, len(allInstanceTypes))
+	for _, itype := range allInstanceTypes {
+		cost, ok := machineTypeCosts[itype.Name]
+		if !ok {
+			continue
+		}
+		itype.Cost = cost
+		itypes = append(itypes, itype)
+	}
+	// Matching instance types are sorted by cost.
+	itypes, err := instances.MatchingInstanceTypes(itypes, env.cloud.Region, cons)
+	if err != nil {
+		return environs.InstancePricing{}, errors.Trace(err)
+	}
+	return environs.InstancePricing{
+		InstanceType: itypes[0].Name,
+		HourlyCost:   float64(itypes[0].Cost) / machineTypeCostDivisor,
+		Currency:     "USD",
+	}, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package gce_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/gce"
+)
+
+type pricingSuite struct {
+	gce.BaseSuite
+}
+
+var _ = gc.Suite(&pricingSuite{})
+
+func (s *pricingSuite) TestInstancePricing(c *gc.C) {
+	for _, test := range []struct {
+		cons     string
+		expected environs.InstancePricing
+	}{{
+		cons:     "",
+		expected: environs.InstancePricing{InstanceType: "g1-small", HourlyCost: 0.0257, Currency: "USD"},
+	}, {
+		cons:     "mem=4G",
+		expected: environs.InstancePricing{InstanceType: "n1-standard-2", HourlyCost: 0.095, Currency: "USD"},
+	}, {
+		cons:     "instance-type=f1-micro",
+		expected: environs.InstancePricing{InstanceType: "f1-micro", HourlyCost: 0.0076, Currency: "USD"},
+	}} {
+		c.Logf("constraints %q", test.cons)
+		pricing, err := s.Env.InstancePricing(s.CallCtx, constraints.MustParse(test.cons))
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(pricing, jc.DeepEquals, test.expected)
+	}
+}
+
+func (s *pricingSuite) TestInstancePricingNoMatch(c *gc.C) {
+	_, err := s.Env.InstancePricing(s.CallCtx, constraints.MustParse("cores=64"))
+	c.Assert(err, gc.ErrorMatches, `no instance types in .* matching constraints "cores=64"`)
+}
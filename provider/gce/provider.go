@@ -34,6 +34,15 @@ func (environProvider) Version() int {
 	return currentProviderVersion
 }
 
+// Capabilities is part of the CapabilityReporter interface.
+func (environProvider) Capabilities() []environs.Capability {
+	return []environs.Capability{
+		environs.CapabilityAvailabilityZones,
+		environs.CapabilityLoadBalancers,
+		environs.CapabilityBlockStorage,
+	}
+}
+
 // Open implements environs.EnvironProvider.
 func (environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
 	if err := validateCloudSpec(args.Cloud); err != nil {
@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/cloudconfig/providerinit"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -344,6 +345,14 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 	}
 }
 
+// UnpatchInstanceCreation restores the real instance spec lookup and
+// instance creation, so that StartInstance only fakes the connection.
+func (s *BaseSuite) UnpatchInstanceCreation() {
+	s.PatchValue(&buildInstanceSpec, BuildInstanceSpec)
+	s.PatchValue(&findInstanceSpec, FindInstanceSpec)
+	s.PatchValue(&newRawInstance, NewRawInstance)
+}
+
 func (s *BaseSuite) TearDownTest(c *gc.C) {
 	s.BaseSuiteUnpatched.TearDownTest(c)
 	s.InvalidatedCredentials = false
@@ -503,6 +512,9 @@ type fakeConnCall struct {
 	Value            string
 	LabelFingerprint string
 	Labels           map[string]string
+	Name             string
+	Insts            []google.Instance
+	PortRanges       []corenetwork.PortRange
 }
 
 type fakeConn struct {
@@ -732,4 +744,33 @@ func (fc *fakeConn) ListMachineTypes(zone string) ([]google.MachineType, error)
 	}, nil
 }
 
+func (fc *fakeConn) EnsureLoadBalancer(name string, insts []google.Instance, portRanges []corenetwork.PortRange) (string, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:   "EnsureLoadBalancer",
+		Name:       name,
+		Insts:      insts,
+		PortRanges: portRanges,
+	})
+	if err := fc.err(); err != nil {
+		return "", err
+	}
+	return "203.0.113.10", nil
+}
+
+func (fc *fakeConn) RemoveLoadBalancer(name string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RemoveLoadBalancer",
+		Name:     name,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) RemoveLoadBalancers(prefix string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RemoveLoadBalancers",
+		Name:     prefix,
+	})
+	return fc.err()
+}
+
 var InvalidCredentialError = &url.Error{"Get", "testbad.com", errors.New("400 Bad Request")}
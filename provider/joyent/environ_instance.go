@@ -47,6 +47,7 @@ func newCompute(cloud environs.CloudSpec) (*joyentCompute, error) {
 
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
+	constraints.ImageID,
 	constraints.Tags,
 	constraints.VirtType,
 }
@@ -120,6 +120,12 @@ func (joyentProvider) Version() int {
 	return 0
 }
 
+// Capabilities is part of the CapabilityReporter interface. The
+// provider implements none of the optional features.
+func (joyentProvider) Capabilities() []environs.Capability {
+	return nil
+}
+
 func (joyentProvider) Open(args environs.OpenParams) (environs.Environ, error) {
 	if err := validateCloudSpec(args.Cloud); err != nil {
 		return nil, errors.Annotate(err, "validating cloud spec")
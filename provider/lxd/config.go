@@ -4,6 +4,8 @@
 package lxd
 
 import (
+	"net/url"
+
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
@@ -11,8 +13,22 @@ import (
 	"github.com/juju/juju/environs/config"
 )
 
+const (
+	cfgImageAlias  = "image-alias"
+	cfgImageServer = "image-server"
+)
+
 var (
-	configSchema                 = environschema.Fields{}
+	configSchema = environschema.Fields{
+		cfgImageAlias: {
+			Description: "The alias or fingerprint of a custom image used to provision machines that have no image-id constraint.",
+			Type:        environschema.Tstring,
+		},
+		cfgImageServer: {
+			Description: "The URL of an LXD image server from which custom images are fetched and refreshed.",
+			Type:        environschema.Tstring,
+		},
+	}
 	configFields, configDefaults = func() (schema.Fields, schema.Defaults) {
 		fields, defaults, err := configSchema.ValidationSchema()
 		if err != nil {
@@ -62,6 +78,24 @@ func (c *environConfig) validate() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	// There are currently no known extra fields for LXD
+	if server := c.imageServer(); server != "" {
+		if _, err := url.Parse(server); err != nil {
+			return errors.Annotatef(err, "invalid %s", cfgImageServer)
+		}
+	}
 	return nil
 }
+
+// imageAlias returns the alias or fingerprint of the custom image to
+// provision machines from, or "" if the standard images are used.
+func (c *environConfig) imageAlias() string {
+	alias, _ := c.attrs[cfgImageAlias].(string)
+	return alias
+}
+
+// imageServer returns the URL of the LXD image server hosting custom
+// images, or "" if they are only found on the target server.
+func (c *environConfig) imageServer() string {
+	server, _ := c.attrs[cfgImageServer].(string)
+	return server
+}
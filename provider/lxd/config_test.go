@@ -130,6 +130,14 @@ var newConfigTests = []configTestSpec{{
 	info:   "unknown field is not touched",
 	insert: testing.Attrs{"unknown-field": 12345},
 	expect: testing.Attrs{"unknown-field": 12345},
+}, {
+	info:   "image-alias is passed through",
+	insert: testing.Attrs{"image-alias": "my-image"},
+	expect: testing.Attrs{"image-alias": "my-image"},
+}, {
+	info:   "image-server is passed through",
+	insert: testing.Attrs{"image-server": "https://images.example.com:8443"},
+	expect: testing.Attrs{"image-server": "https://images.example.com:8443"},
 }}
 
 func (s *configSuite) TestNewModelConfig(c *gc.C) {
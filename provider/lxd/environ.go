@@ -157,6 +157,13 @@ func (env *environ) Config() *config.Config {
 	return cfg
 }
 
+func (env *environ) ecfg() *environConfig {
+	env.lock.Lock()
+	defer env.lock.Unlock()
+
+	return env.ecfgUnlocked
+}
+
 // PrepareForBootstrap implements environs.Environ.
 func (env *environ) PrepareForBootstrap(ctx environs.BootstrapContext, controllerName string) error {
 	return nil
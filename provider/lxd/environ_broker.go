@@ -120,7 +120,7 @@ func (env *environ) newContainer(
 		return nil, errors.Trace(err)
 	}
 
-	image, err := target.FindImage(args.InstanceConfig.Series, arch, imageSources, true, statusCallback)
+	image, err := env.findImage(target, args, arch, imageSources, statusCallback)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -140,6 +140,31 @@ func (env *environ) newContainer(
 	return container, nil
 }
 
+// findImage locates the image to create the container from. A custom
+// image, named by the image-id constraint or the image-alias model
+// config, takes precedence over the standard image for the series.
+func (env *environ) findImage(
+	target Server,
+	args environs.StartInstanceParams,
+	arch string,
+	imageSources []lxd.ServerSpec,
+	statusCallback environs.StatusCallbackFunc,
+) (lxd.SourcedImage, error) {
+	ecfg := env.ecfg()
+	name := ecfg.imageAlias()
+	if args.Constraints.HasImageID() {
+		name = *args.Constraints.ImageID
+	}
+	if name == "" {
+		return target.FindImage(args.InstanceConfig.Series, arch, imageSources, true, statusCallback)
+	}
+	var sources []lxd.ServerSpec
+	if server := ecfg.imageServer(); server != "" {
+		sources = append(sources, lxd.MakeLXDServerSpec(cfgImageServer, server))
+	}
+	return target.FindCustomImage(name, sources, statusCallback)
+}
+
 func (env *environ) getImageSources() ([]lxd.ServerSpec, error) {
 	metadataSources, err := environs.ImageMetadataSources(env)
 	if err != nil {
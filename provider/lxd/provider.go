@@ -136,6 +136,14 @@ func (*environProvider) Version() int {
 	return 0
 }
 
+// Capabilities is part of the CapabilityReporter interface.
+func (*environProvider) Capabilities() []environs.Capability {
+	return []environs.Capability{
+		environs.CapabilityAvailabilityZones,
+		environs.CapabilityFilesystemStorage,
+	}
+}
+
 // Open implements environs.EnvironProvider.
 func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
 	if err := p.validateCloudSpec(args.Cloud); err != nil {
@@ -226,6 +226,19 @@ func (mr *MockServerMockRecorder) FilterContainers(arg0 interface{}, arg1 ...int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FilterContainers", reflect.TypeOf((*MockServer)(nil).FilterContainers), varargs...)
 }
 
+// FindCustomImage mocks base method
+func (m *MockServer) FindCustomImage(arg0 string, arg1 []lxd.ServerSpec, arg2 environs.StatusCallbackFunc) (lxd.SourcedImage, error) {
+	ret := m.ctrl.Call(m, "FindCustomImage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(lxd.SourcedImage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCustomImage indicates an expected call of FindCustomImage
+func (mr *MockServerMockRecorder) FindCustomImage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCustomImage", reflect.TypeOf((*MockServer)(nil).FindCustomImage), arg0, arg1, arg2)
+}
+
 // FindImage mocks base method
 func (m *MockServer) FindImage(arg0, arg1 string, arg2 []lxd.ServerSpec, arg3 bool, arg4 environs.StatusCallbackFunc) (lxd.SourcedImage, error) {
 	ret := m.ctrl.Call(m, "FindImage", arg0, arg1, arg2, arg3, arg4)
@@ -468,6 +468,17 @@ func (conn *StubClient) FindImage(
 	return lxd.SourcedImage{}, nil
 }
 
+func (conn *StubClient) FindCustomImage(
+	name string, sources []lxd.ServerSpec, callback environs.StatusCallbackFunc,
+) (lxd.SourcedImage, error) {
+	conn.AddCall("FindCustomImage", name)
+	if err := conn.NextErr(); err != nil {
+		return lxd.SourcedImage{}, errors.Trace(err)
+	}
+
+	return lxd.SourcedImage{}, nil
+}
+
 func (conn *StubClient) CreateCertificate(cert api.CertificatesPost) error {
 	conn.AddCall("CreateCertificate", cert)
 	return conn.NextErr()
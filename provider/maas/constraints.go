@@ -19,6 +19,7 @@ import (
 
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
+	constraints.ImageID,
 	constraints.InstanceType,
 	constraints.VirtType,
 }
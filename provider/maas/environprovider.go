@@ -58,6 +58,15 @@ func (MaasEnvironProvider) Version() int {
 	return 0
 }
 
+// Capabilities is part of the CapabilityReporter interface.
+func (MaasEnvironProvider) Capabilities() []environs.Capability {
+	return []environs.Capability{
+		environs.CapabilitySpaces,
+		environs.CapabilityAvailabilityZones,
+		environs.CapabilityBlockStorage,
+	}
+}
+
 func (MaasEnvironProvider) Open(args environs.OpenParams) (environs.Environ, error) {
 	logger.Debugf("opening model %q.", args.Config.Name())
 	if err := validateCloudSpec(args.Cloud); err != nil {
@@ -323,6 +323,7 @@ func (*manualEnviron) PrecheckInstance(context.ProviderCallContext, environs.Pre
 
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
+	constraints.ImageID,
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
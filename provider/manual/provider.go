@@ -106,6 +106,13 @@ func (ManualProvider) Version() int {
 	return 0
 }
 
+// Capabilities is part of the CapabilityReporter interface. Manual
+// machines are provisioned by the user, so none of the optional
+// features are available.
+func (ManualProvider) Capabilities() []environs.Capability {
+	return nil
+}
+
 func (p ManualProvider) Open(args environs.OpenParams) (environs.Environ, error) {
 	if err := validateCloudSpec(args.Cloud); err != nil {
 		return nil, errors.Trace(err)
@@ -329,6 +329,7 @@ func (e *Environ) ConstraintsValidator(ctx envcontext.ProviderCallContext) (cons
 	// list of unsupported OCI provider constraints
 	unsupportedConstraints := []string{
 		constraints.Container,
+		constraints.ImageID,
 		constraints.VirtType,
 		constraints.Tags,
 	}
@@ -186,6 +186,14 @@ func (e EnvironProvider) Version() int {
 	return 1
 }
 
+// Capabilities implements environs.CapabilityReporter.
+func (EnvironProvider) Capabilities() []environs.Capability {
+	return []environs.Capability{
+		environs.CapabilityAvailabilityZones,
+		environs.CapabilityBlockStorage,
+	}
+}
+
 // CloudSchema implements environs.EnvironProvider.
 func (e EnvironProvider) CloudSchema() *jsonschema.Schema {
 	return nil
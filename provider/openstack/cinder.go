@@ -28,7 +28,8 @@ import (
 const (
 	CinderProviderType = storage.ProviderType("cinder")
 
-	cinderVolumeType = "volume-type"
+	cinderVolumeType   = "volume-type"
+	cinderAttachMethod = "attach-method"
 
 	// autoAssignedMountPoint specifies the value to pass in when
 	// you'd like Cinder to automatically assign a mount point.
@@ -42,17 +43,23 @@ const (
 
 var cinderConfigFields = schema.Fields{
 	cinderVolumeType: schema.String(),
+	cinderAttachMethod: schema.OneOf(
+		schema.Const(attachMethodNova),
+		schema.Const(attachMethodISCSI),
+	),
 }
 
 var cinderConfigChecker = schema.FieldMap(
 	cinderConfigFields,
 	schema.Defaults{
-		cinderVolumeType: schema.Omit,
+		cinderVolumeType:   schema.Omit,
+		cinderAttachMethod: attachMethodNova,
 	},
 )
 
 type cinderConfig struct {
-	volumeType string
+	volumeType   string
+	attachMethod string
 }
 
 func newCinderConfig(attrs map[string]interface{}) (*cinderConfig, error) {
@@ -62,8 +69,10 @@ func newCinderConfig(attrs map[string]interface{}) (*cinderConfig, error) {
 	}
 	coerced := out.(map[string]interface{})
 	volumeType, _ := coerced[cinderVolumeType].(string)
+	attachMethod, _ := coerced[cinderAttachMethod].(string)
 	cinderConfig := &cinderConfig{
-		volumeType: volumeType,
+		volumeType:   volumeType,
+		attachMethod: attachMethod,
 	}
 	return cinderConfig, nil
 }
@@ -129,9 +138,17 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 		}
 	}
 
+	// The volume actions are requested of the endpoint of
+	// the same service as the other Cinder requests.
+	volumeService := "volumev2"
+	if _, ok := client.EndpointsForRegion(env.cloudUnlocked.Region)[volumeService]; !ok {
+		volumeService = "volume"
+	}
+
 	return &openstackStorageAdapter{
 		cinderCl,
 		novaClient{env.novaUnlocked},
+		cinderActions{client, volumeService},
 	}, nil
 }
 
@@ -151,14 +168,16 @@ var cinderAttempt = utils.AttemptStrategy{
 
 // VolumeSource implements storage.Provider.
 func (p *cinderProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
-	if err := p.ValidateConfig(providerConfig); err != nil {
-		return nil, err
+	cinderConfig, err := newCinderConfig(providerConfig.Attrs())
+	if err != nil {
+		return nil, errors.Trace(err)
 	}
 	source := &cinderVolumeSource{
 		storageAdapter: p.storageAdapter,
 		envName:        p.envName,
 		modelUUID:      p.modelUUID,
 		namespace:      p.namespace,
+		attachMethod:   cinderConfig.attachMethod,
 	}
 	return source, nil
 }
@@ -210,6 +229,7 @@ type cinderVolumeSource struct {
 	envName        string // non unique, informational only
 	modelUUID      string
 	namespace      instance.Namespace
+	attachMethod   string
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
@@ -479,6 +499,9 @@ func (s *cinderVolumeSource) AttachVolumes(ctx context.ProviderCallContext, args
 }
 
 func (s *cinderVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	if s.attachMethod == attachMethodISCSI {
+		return s.attachVolumeISCSI(arg)
+	}
 	// Check to see if the volume is already attached.
 	existingAttachments, err := s.storageAdapter.ListVolumeAttachments(string(arg.InstanceId))
 	if err != nil {
@@ -555,6 +578,19 @@ func waitVolume(
 
 // DetachVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) DetachVolumes(ctx context.ProviderCallContext, args []storage.VolumeAttachmentParams) ([]error, error) {
+	if s.attachMethod == attachMethodISCSI {
+		results := make([]error, len(args))
+		for i, arg := range args {
+			if err := detachVolumeISCSI(string(arg.InstanceId), arg.VolumeId, s.storageAdapter); err != nil {
+				common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+				results[i] = errors.Annotatef(
+					err, "detaching volume %s from server %s",
+					arg.VolumeId, arg.InstanceId,
+				)
+			}
+		}
+		return results, nil
+	}
 	return detachVolumes(ctx, s.storageAdapter, args), nil
 }
 
@@ -621,6 +657,10 @@ type OpenstackStorage interface {
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	InitializeVolumeConnection(serverId, volumeId string) (*VolumeConnectionInfo, error)
+	TerminateVolumeConnection(serverId, volumeId string) error
+	SetVolumeAttached(serverId, volumeId, mountPoint string) error
+	SetVolumeDetached(volumeId, attachmentId string) error
 }
 
 type endpointResolver interface {
@@ -651,6 +691,7 @@ func getVolumeEndpointURL(client endpointResolver, region string) (*url.URL, err
 type openstackStorageAdapter struct {
 	cinderClient
 	novaClient
+	cinderActions
 }
 
 type cinderClient struct {
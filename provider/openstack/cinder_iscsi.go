@@ -0,0 +1,246 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/goose.v2/cinder"
+	"gopkg.in/goose.v2/client"
+	gooseerrors "gopkg.in/goose.v2/errors"
+	goosehttp "gopkg.in/goose.v2/http"
+
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// attachMethodNova attaches Cinder volumes through Nova, which
+	// connects them to the hypervisor and exposes them to the server
+	// as local block devices.
+	attachMethodNova = "nova"
+
+	// attachMethodISCSI attaches Cinder volumes by connecting the
+	// server to their iSCSI targets directly, as os-brick does. The
+	// machine agent logs in to the targets following the attachment
+	// plans of the volumes.
+	attachMethodISCSI = "iscsi"
+
+	// driverVolumeTypeISCSI is the driver volume type of the
+	// connections to volumes exported over iSCSI.
+	driverVolumeTypeISCSI = "iscsi"
+)
+
+// VolumeConnectionInfo describes how a server connects to a Cinder
+// volume, as returned by the os-initialize_connection volume action.
+type VolumeConnectionInfo struct {
+	DriverVolumeType string               `json:"driver_volume_type"`
+	Data             VolumeConnectionData `json:"data"`
+}
+
+// VolumeConnectionData holds the driver specific connection details
+// of a VolumeConnectionInfo. Only those of iSCSI connections are
+// decoded.
+type VolumeConnectionData struct {
+	TargetIQN    string `json:"target_iqn"`
+	TargetPortal string `json:"target_portal"`
+	TargetLUN    int    `json:"target_lun"`
+	AuthMethod   string `json:"auth_method"`
+	AuthUsername string `json:"auth_username"`
+	AuthPassword string `json:"auth_password"`
+}
+
+// devicePath returns the path of the device the connection appears
+// as on the server, once logged in to its target.
+func (info *VolumeConnectionInfo) devicePath() string {
+	return fmt.Sprintf(
+		"/dev/disk/by-path/ip-%s-iscsi-%s-lun-%d",
+		info.Data.TargetPortal, info.Data.TargetIQN, info.Data.TargetLUN,
+	)
+}
+
+// planInfo returns the attachment plan the machine agent follows to
+// log in to the iSCSI target of the connection.
+func (info *VolumeConnectionInfo) planInfo() (*storage.VolumeAttachmentPlanInfo, error) {
+	if info.DriverVolumeType != driverVolumeTypeISCSI {
+		return nil, errors.NotSupportedf("%q volume connections", info.DriverVolumeType)
+	}
+	if info.Data.TargetIQN == "" || info.Data.TargetPortal == "" {
+		return nil, errors.New("iSCSI target not specified")
+	}
+	address, port, err := net.SplitHostPort(info.Data.TargetPortal)
+	if err != nil {
+		return nil, errors.Annotatef(err, "parsing iSCSI target portal %q", info.Data.TargetPortal)
+	}
+	planInfo := &storage.VolumeAttachmentPlanInfo{
+		DeviceType: storage.DeviceTypeISCSI,
+		DeviceAttributes: map[string]string{
+			"iqn":     info.Data.TargetIQN,
+			"address": address,
+			"port":    port,
+		},
+	}
+	if strings.EqualFold(info.Data.AuthMethod, "CHAP") {
+		planInfo.DeviceAttributes["chap-user"] = info.Data.AuthUsername
+		planInfo.DeviceAttributes["chap-secret"] = info.Data.AuthPassword
+	}
+	return planInfo, nil
+}
+
+// volumeConnector describes the server connecting to a volume, in
+// the volume actions managing its connections.
+type volumeConnector struct {
+	Host      string `json:"host"`
+	Multipath bool   `json:"multipath"`
+}
+
+// cinderActions makes the Cinder volume action requests that the goose
+// Cinder client does not support.
+type cinderActions struct {
+	client  client.Client
+	service string
+}
+
+func (a cinderActions) volumeAction(volumeId string, req, resp interface{}, expected ...int) error {
+	requestData := goosehttp.RequestData{
+		ReqValue:       req,
+		RespValue:      resp,
+		ExpectedStatus: expected,
+	}
+	path := fmt.Sprintf("volumes/%s/action", volumeId)
+	err := a.client.SendRequest(client.POST, a.service, "v2", path, &requestData)
+	if gooseerrors.IsNotFound(err) {
+		return errors.NotFoundf("volume %q", volumeId)
+	}
+	return err
+}
+
+// InitializeVolumeConnection is part of the OpenstackStorage interface.
+func (a cinderActions) InitializeVolumeConnection(serverId, volumeId string) (*VolumeConnectionInfo, error) {
+	req := map[string]interface{}{
+		"os-initialize_connection": map[string]interface{}{
+			"connector": volumeConnector{Host: serverId},
+		},
+	}
+	var resp struct {
+		ConnectionInfo VolumeConnectionInfo `json:"connection_info"`
+	}
+	if err := a.volumeAction(volumeId, req, &resp, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &resp.ConnectionInfo, nil
+}
+
+// TerminateVolumeConnection is part of the OpenstackStorage interface.
+func (a cinderActions) TerminateVolumeConnection(serverId, volumeId string) error {
+	req := map[string]interface{}{
+		"os-terminate_connection": map[string]interface{}{
+			"connector": volumeConnector{Host: serverId},
+		},
+	}
+	return a.volumeAction(volumeId, req, nil, http.StatusAccepted)
+}
+
+// SetVolumeAttached is part of the OpenstackStorage interface.
+func (a cinderActions) SetVolumeAttached(serverId, volumeId, mountPoint string) error {
+	req := map[string]interface{}{
+		"os-attach": map[string]interface{}{
+			"instance_uuid": serverId,
+			"mountpoint":    mountPoint,
+		},
+	}
+	return a.volumeAction(volumeId, req, nil, http.StatusAccepted)
+}
+
+// SetVolumeDetached is part of the OpenstackStorage interface.
+func (a cinderActions) SetVolumeDetached(volumeId, attachmentId string) error {
+	req := map[string]interface{}{
+		"os-detach": map[string]interface{}{
+			"attachment_id": attachmentId,
+		},
+	}
+	return a.volumeAction(volumeId, req, nil, http.StatusAccepted)
+}
+
+// attachVolumeISCSI connects the server of the attachment to the iSCSI
+// target of the volume, and records the volume as attached to it in
+// Cinder. The returned attachment holds the plan the machine agent
+// follows to log in to the target.
+func (s *cinderVolumeSource) attachVolumeISCSI(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	serverId := string(arg.InstanceId)
+	var attached bool
+	if _, err := waitVolume(s.storageAdapter, arg.VolumeId, func(v *cinder.Volume) (bool, error) {
+		switch v.Status {
+		case volumeStatusAvailable:
+			return true, nil
+		case volumeStatusInUse:
+			if findCinderAttachment(serverId, v.Attachments) == nil {
+				return false, errors.New("volume is attached to another server")
+			}
+			attached = true
+			return true, nil
+		}
+		return false, nil
+	}); err != nil {
+		return nil, errors.Annotate(err, "waiting for volume to become available")
+	}
+
+	info, err := s.storageAdapter.InitializeVolumeConnection(serverId, arg.VolumeId)
+	if err != nil {
+		return nil, errors.Annotate(err, "initializing volume connection")
+	}
+	planInfo, err := info.planInfo()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !attached {
+		if err := s.storageAdapter.SetVolumeAttached(serverId, arg.VolumeId, info.devicePath()); err != nil {
+			return nil, errors.Annotate(err, "recording volume attachment")
+		}
+	}
+	return &storage.VolumeAttachment{
+		arg.Volume,
+		arg.Machine,
+		storage.VolumeAttachmentInfo{
+			ReadOnly: arg.ReadOnly,
+			PlanInfo: planInfo,
+		},
+	}, nil
+}
+
+// detachVolumeISCSI terminates the connection of the server to the
+// iSCSI target of the volume, once the machine agent has logged out of
+// it, and records the volume as detached from the server in Cinder.
+func detachVolumeISCSI(serverId, volumeId string, storageAdapter OpenstackStorage) error {
+	volume, err := storageAdapter.GetVolume(volumeId)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	attachment := findCinderAttachment(serverId, volume.Attachments)
+	if attachment == nil {
+		// The volume is already detached.
+		return nil
+	}
+	if err := storageAdapter.TerminateVolumeConnection(serverId, volumeId); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "terminating volume connection")
+	}
+	if err := storageAdapter.SetVolumeDetached(volumeId, attachment.Id); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "recording volume detachment")
+	}
+	return nil
+}
+
+func findCinderAttachment(serverId string, attachments []cinder.VolumeAttachment) *cinder.VolumeAttachment {
+	for _, attachment := range attachments {
+		if attachment.ServerId == serverId {
+			return &attachment
+		}
+	}
+	return nil
+}
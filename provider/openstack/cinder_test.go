@@ -608,6 +608,154 @@ func (s *cinderVolumeSourceSuite) TestDetachVolumes(c *gc.C) {
 	})
 }
 
+func iscsiConnectionInfo(serverId, volId string) (*openstack.VolumeConnectionInfo, error) {
+	return &openstack.VolumeConnectionInfo{
+		DriverVolumeType: "iscsi",
+		Data: openstack.VolumeConnectionData{
+			TargetIQN:    "iqn.2010-10.org.openstack:volume-" + volId,
+			TargetPortal: "10.0.0.1:3260",
+			TargetLUN:    1,
+			AuthMethod:   "CHAP",
+			AuthUsername: "user",
+			AuthPassword: "secret",
+		},
+	}, nil
+}
+
+func (s *cinderVolumeSourceSuite) TestAttachVolumesISCSI(c *gc.C) {
+	mockAdapter := &mockAdapter{initializeConnection: iscsiConnectionInfo}
+
+	volSource := openstack.NewISCSICinderVolumeSource(mockAdapter)
+	results, err := volSource.AttachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+		AttachmentParams: storage.AttachmentParams{
+			Provider:   openstack.CinderProviderType,
+			Machine:    mockMachineTag,
+			InstanceId: instance.Id(mockServerId),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			mockVolumeTag,
+			mockMachineTag,
+			storage.VolumeAttachmentInfo{
+				PlanInfo: &storage.VolumeAttachmentPlanInfo{
+					DeviceType: storage.DeviceTypeISCSI,
+					DeviceAttributes: map[string]string{
+						"iqn":         "iqn.2010-10.org.openstack:volume-0",
+						"address":     "10.0.0.1",
+						"port":        "3260",
+						"chap-user":   "user",
+						"chap-secret": "secret",
+					},
+				},
+			},
+		},
+	}})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+		{"InitializeVolumeConnection", []interface{}{mockServerId, mockVolId}},
+		{"SetVolumeAttached", []interface{}{
+			mockServerId, mockVolId,
+			"/dev/disk/by-path/ip-10.0.0.1:3260-iscsi-iqn.2010-10.org.openstack:volume-0-lun-1",
+		}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestAttachVolumesISCSIAlreadyAttached(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volId string) (*cinder.Volume, error) {
+			return &cinder.Volume{
+				ID:          volId,
+				Status:      "in-use",
+				Attachments: []cinder.VolumeAttachment{{ServerId: mockServerId}},
+			}, nil
+		},
+		initializeConnection: iscsiConnectionInfo,
+	}
+
+	volSource := openstack.NewISCSICinderVolumeSource(mockAdapter)
+	results, err := volSource.AttachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    mockMachineTag,
+			InstanceId: instance.Id(mockServerId),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeAttachment.PlanInfo, gc.NotNil)
+	mockAdapter.CheckCallNames(c, "GetVolume", "InitializeVolumeConnection")
+}
+
+func (s *cinderVolumeSourceSuite) TestAttachVolumesISCSIUnsupportedConnection(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		initializeConnection: func(serverId, volId string) (*openstack.VolumeConnectionInfo, error) {
+			return &openstack.VolumeConnectionInfo{DriverVolumeType: "rbd"}, nil
+		},
+	}
+
+	volSource := openstack.NewISCSICinderVolumeSource(mockAdapter)
+	results, err := volSource.AttachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    mockMachineTag,
+			InstanceId: instance.Id(mockServerId),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `"rbd" volume connections not supported`)
+	mockAdapter.CheckCallNames(c, "GetVolume", "InitializeVolumeConnection")
+}
+
+func (s *cinderVolumeSourceSuite) TestDetachVolumesISCSI(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volId string) (*cinder.Volume, error) {
+			if volId == "42" {
+				// Already detached.
+				return &cinder.Volume{ID: volId, Status: "available"}, nil
+			}
+			return &cinder.Volume{
+				ID:     volId,
+				Status: "in-use",
+				Attachments: []cinder.VolumeAttachment{{
+					Id:       "attachment-id",
+					ServerId: mockServerId,
+				}},
+			}, nil
+		},
+	}
+
+	volSource := openstack.NewISCSICinderVolumeSource(mockAdapter)
+	errs, err := volSource.DetachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("123"),
+		VolumeId: mockVolId,
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: mockServerId,
+		},
+	}, {
+		Volume:   names.NewVolumeTag("42"),
+		VolumeId: "42",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: mockServerId,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil, nil})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+		{"TerminateVolumeConnection", []interface{}{mockServerId, mockVolId}},
+		{"SetVolumeDetached", []interface{}{mockVolId, "attachment-id"}},
+		{"GetVolume", []interface{}{"42"}},
+	})
+}
+
 func (s *cinderVolumeSourceSuite) TestCreateVolumeCleanupDestroys(c *gc.C) {
 	var numCreateCalls, numDestroyCalls, numGetCalls int
 	mockAdapter := &mockAdapter{
@@ -759,6 +907,7 @@ type mockAdapter struct {
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
+	initializeConnection  func(string, string) (*openstack.VolumeConnectionInfo, error)
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -828,6 +977,29 @@ func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]st
 	return nil, nil
 }
 
+func (ma *mockAdapter) InitializeVolumeConnection(serverId, volumeId string) (*openstack.VolumeConnectionInfo, error) {
+	ma.MethodCall(ma, "InitializeVolumeConnection", serverId, volumeId)
+	if ma.initializeConnection != nil {
+		return ma.initializeConnection(serverId, volumeId)
+	}
+	return nil, errors.NotImplementedf("InitializeVolumeConnection")
+}
+
+func (ma *mockAdapter) TerminateVolumeConnection(serverId, volumeId string) error {
+	ma.MethodCall(ma, "TerminateVolumeConnection", serverId, volumeId)
+	return ma.NextErr()
+}
+
+func (ma *mockAdapter) SetVolumeAttached(serverId, volumeId, mountPoint string) error {
+	ma.MethodCall(ma, "SetVolumeAttached", serverId, volumeId, mountPoint)
+	return ma.NextErr()
+}
+
+func (ma *mockAdapter) SetVolumeDetached(volumeId, attachmentId string) error {
+	ma.MethodCall(ma, "SetVolumeDetached", volumeId, attachmentId)
+	return ma.NextErr()
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
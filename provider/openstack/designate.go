@@ -0,0 +1,246 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/goose.v2/client"
+	goosehttp "gopkg.in/goose.v2/http"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/provider/common"
+)
+
+var _ environs.DNSZones = (*Environ)(nil)
+
+const (
+	designateService    = "dns"
+	designateAPIVersion = "v2"
+)
+
+type designateRecordSet struct {
+	ID      string   `json:"id,omitempty"`
+	Name    string   `json:"name,omitempty"`
+	Type    string   `json:"type,omitempty"`
+	TTL     int      `json:"ttl"`
+	Records []string `json:"records"`
+}
+
+// designateClient is the part of the goose client used to make requests
+// to the OpenStack DNS API.
+type designateClient interface {
+	SendRequest(method, svcType, apiVersion, url string, requestData *goosehttp.RequestData) error
+}
+
+// designate makes requests to the OpenStack DNS API.
+type designate struct {
+	client designateClient
+}
+
+func (d designate) send(method, path string, params *url.Values, req, resp interface{}, expected ...int) error {
+	requestData := goosehttp.RequestData{
+		Params:         params,
+		ReqValue:       req,
+		RespValue:      resp,
+		ExpectedStatus: expected,
+	}
+	return d.client.SendRequest(method, designateService, designateAPIVersion, path, &requestData)
+}
+
+// zoneID returns the id of the named zone.
+func (d designate) zoneID(name string) (string, error) {
+	params := &url.Values{}
+	params.Set("name", name)
+	var resp struct {
+		Zones []struct {
+			ID string `json:"id"`
+		} `json:"zones"`
+	}
+	if err := d.send(client.GET, "zones", params, nil, &resp); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(resp.Zones) == 0 {
+		return "", errors.NotFoundf("DNS zone %q", name)
+	}
+	return resp.Zones[0].ID, nil
+}
+
+// recordSets returns all the record sets in the zone, following the
+// links to each page of results.
+func (d designate) recordSets(zoneID string) ([]designateRecordSet, error) {
+	var result []designateRecordSet
+	params := &url.Values{}
+	for {
+		var resp struct {
+			RecordSets []designateRecordSet `json:"recordsets"`
+			Links      struct {
+				Next string `json:"next"`
+			} `json:"links"`
+		}
+		if err := d.send(client.GET, "zones/"+zoneID+"/recordsets", params, nil, &resp); err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, resp.RecordSets...)
+		if resp.Links.Next == "" {
+			return result, nil
+		}
+		next, err := url.Parse(resp.Links.Next)
+		if err != nil {
+			return nil, errors.Annotate(err, "parsing link to next page of record sets")
+		}
+		marker := next.Query().Get("marker")
+		if marker == "" {
+			return result, nil
+		}
+		params.Set("marker", marker)
+	}
+}
+
+// zone returns the id, fully qualified name and record sets of the
+// named zone.
+func (d designate) zone(zone string) (string, string, []designateRecordSet, error) {
+	zoneName := strings.TrimSuffix(zone, ".") + "."
+	zoneID, err := d.zoneID(zoneName)
+	if err != nil {
+		return "", "", nil, errors.Trace(err)
+	}
+	recordSets, err := d.recordSets(zoneID)
+	if err != nil {
+		return "", "", nil, errors.Trace(err)
+	}
+	return zoneID, zoneName, recordSets, nil
+}
+
+// setRecordSet creates, updates or deletes the record set so that it
+// holds exactly the given records. The existing record set is nil if
+// there is none.
+func (d designate) setRecordSet(zoneID, name, recordType string, existing *designateRecordSet, records []string) error {
+	path := "zones/" + zoneID + "/recordsets"
+	switch {
+	case existing == nil && len(records) == 0:
+		return nil
+	case existing == nil:
+		req := designateRecordSet{
+			Name:    name,
+			Type:    recordType,
+			TTL:     environs.DNSRecordTTL,
+			Records: records,
+		}
+		return d.send(client.POST, path, nil, req, nil, http.StatusCreated, http.StatusAccepted)
+	case len(records) == 0:
+		return d.send(client.DELETE, path+"/"+existing.ID, nil, nil, nil, http.StatusAccepted, http.StatusNoContent)
+	default:
+		req := designateRecordSet{
+			TTL:     environs.DNSRecordTTL,
+			Records: records,
+		}
+		return d.send(client.PUT, path+"/"+existing.ID, nil, req, nil, http.StatusOK, http.StatusAccepted)
+	}
+}
+
+// ownedRecords returns the records in the named zone owned by owner.
+func (d designate) ownedRecords(zone, owner string) ([]environs.DNSRecord, error) {
+	_, zoneName, recordSets, err := d.zone(zone)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading DNS zone %q", zone)
+	}
+	return environs.OwnedDNSRecords(designateDNSRecordSets(zoneName, recordSets), owner), nil
+}
+
+// setRecords sets the records in the named zone, skipping those whose
+// names are owned by others. Other TXT records at a name are kept
+// alongside the ownership marker.
+func (d designate) setRecords(zone, owner string, records []environs.DNSRecord) error {
+	zoneID, zoneName, existing, err := d.zone(zone)
+	if err != nil {
+		return errors.Annotatef(err, "reading DNS zone %q", zone)
+	}
+	ownership := environs.DNSNameOwnership(designateDNSRecordSets(zoneName, existing), owner)
+	current := make(map[string]*designateRecordSet)
+	for i, recordSet := range existing {
+		current[recordSet.Name+" "+recordSet.Type] = &existing[i]
+	}
+	marker := environs.DNSOwnerText(owner)
+
+	var notOwned []string
+	for _, record := range records {
+		if owned, ok := ownership[record.Name]; ok && !owned {
+			notOwned = append(notOwned, record.Name)
+			continue
+		}
+		name := record.Name + "." + zoneName
+		ipv4, ipv6 := environs.SplitDNSAddresses(record.Addresses)
+		a := designateRecordSet{Type: "A", Records: ipv4}
+		aaaa := designateRecordSet{Type: "AAAA", Records: ipv6}
+		txt := designateRecordSet{Type: "TXT"}
+		if existing := current[name+" TXT"]; existing != nil {
+			for _, value := range existing.Records {
+				if strings.Trim(value, `"`) != marker {
+					txt.Records = append(txt.Records, value)
+				}
+			}
+		}
+		recordSets := []designateRecordSet{a, aaaa, txt}
+		if len(ipv4)+len(ipv6) > 0 {
+			// The name is marked as owned before its addresses are
+			// published, so they're never left without an owner.
+			txt.Records = append(txt.Records, strconv.Quote(marker))
+			recordSets = []designateRecordSet{txt, a, aaaa}
+		}
+		for _, recordSet := range recordSets {
+			existing := current[name+" "+recordSet.Type]
+			if err := d.setRecordSet(zoneID, name, recordSet.Type, existing, recordSet.Records); err != nil {
+				return errors.Annotatef(err, "setting %s records for %q", recordSet.Type, name)
+			}
+		}
+	}
+	if len(notOwned) > 0 {
+		return environs.DNSNamesNotOwnedError(notOwned)
+	}
+	return nil
+}
+
+// designateDNSRecordSets converts the record sets of the named zone,
+// naming them relative to the zone.
+func designateDNSRecordSets(zoneName string, recordSets []designateRecordSet) []environs.DNSRecordSet {
+	result := make([]environs.DNSRecordSet, len(recordSets))
+	for i, recordSet := range recordSets {
+		name := ""
+		if recordSet.Name != zoneName {
+			name = strings.TrimSuffix(recordSet.Name, "."+zoneName)
+		}
+		result[i] = environs.DNSRecordSet{
+			Name:   name,
+			Type:   recordSet.Type,
+			Values: recordSet.Records,
+		}
+	}
+	return result
+}
+
+// OwnedDNSRecords is part of the environs.DNSZones interface.
+func (e *Environ) OwnedDNSRecords(ctx context.ProviderCallContext, zone, owner string) ([]environs.DNSRecord, error) {
+	records, err := designate{e.client()}.ownedRecords(zone, owner)
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return nil, errors.Trace(err)
+	}
+	return records, nil
+}
+
+// SetDNSRecords is part of the environs.DNSZones interface. The zone
+// must be a Designate zone in the model's project.
+func (e *Environ) SetDNSRecords(ctx context.ProviderCallContext, zone, owner string, records []environs.DNSRecord) error {
+	if err := (designate{e.client()}).setRecords(zone, owner, records); err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return errors.Trace(err)
+	}
+	return nil
+}
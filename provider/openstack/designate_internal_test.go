@@ -0,0 +1,170 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	goosehttp "gopkg.in/goose.v2/http"
+
+	"github.com/juju/juju/environs"
+)
+
+type designateInternalSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&designateInternalSuite{})
+
+// fakeDesignate holds the record sets of the "example.com." zone, and
+// serves them a page at a time.
+type fakeDesignate struct {
+	recordSets []designateRecordSet
+	pageSize   int
+	nextID     int
+	calls      []string
+}
+
+func (f *fakeDesignate) SendRequest(method, svcType, apiVersion, url string, requestData *goosehttp.RequestData) error {
+	f.calls = append(f.calls, method+" "+url)
+	const recordSetsPath = "zones/zone-id/recordsets"
+	switch {
+	case method == "GET" && url == "zones":
+		var zones []map[string]string
+		if requestData.Params.Get("name") == "example.com." {
+			zones = append(zones, map[string]string{"id": "zone-id"})
+		}
+		return f.respond(requestData, map[string]interface{}{"zones": zones})
+	case method == "GET" && url == recordSetsPath:
+		start := 0
+		if marker := requestData.Params.Get("marker"); marker != "" {
+			for i, recordSet := range f.recordSets {
+				if recordSet.ID == marker {
+					start = i + 1
+				}
+			}
+		}
+		end := len(f.recordSets)
+		links := map[string]string{}
+		if start+f.pageSize < end {
+			end = start + f.pageSize
+			links["next"] = "https://dns.example.com/v2/" + recordSetsPath + "?marker=" + f.recordSets[end-1].ID
+		}
+		return f.respond(requestData, map[string]interface{}{
+			"recordsets": f.recordSets[start:end],
+			"links":      links,
+		})
+	case method == "POST" && url == recordSetsPath:
+		recordSet := requestData.ReqValue.(designateRecordSet)
+		f.nextID++
+		recordSet.ID = fmt.Sprintf("new-%d", f.nextID)
+		f.recordSets = append(f.recordSets, recordSet)
+		return nil
+	case method == "PUT" || method == "DELETE":
+		id := strings.TrimPrefix(url, recordSetsPath+"/")
+		for i, recordSet := range f.recordSets {
+			if recordSet.ID != id {
+				continue
+			}
+			if method == "DELETE" {
+				f.recordSets = append(f.recordSets[:i], f.recordSets[i+1:]...)
+			} else {
+				f.recordSets[i].Records = requestData.ReqValue.(designateRecordSet).Records
+			}
+			return nil
+		}
+		return errors.NotFoundf("record set %q", id)
+	}
+	return errors.Errorf("unexpected request %s %s", method, url)
+}
+
+func (f *fakeDesignate) respond(requestData *goosehttp.RequestData, resp interface{}) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, requestData.RespValue)
+}
+
+func ownerText(owner string) string {
+	return `"` + environs.DNSOwnerText(owner) + `"`
+}
+
+func (s *designateInternalSuite) TestOwnedRecords(c *gc.C) {
+	fake := &fakeDesignate{
+		pageSize: 2,
+		recordSets: []designateRecordSet{
+			{ID: "1", Name: "example.com.", Type: "NS", Records: []string{"ns1.example.com."}},
+			{ID: "2", Name: "wordpress.mymodel.example.com.", Type: "A", Records: []string{"203.0.113.10"}},
+			{ID: "3", Name: "wordpress.mymodel.example.com.", Type: "AAAA", Records: []string{"2001:db8::10"}},
+			{ID: "4", Name: "wordpress.mymodel.example.com.", Type: "TXT", Records: []string{ownerText("deadbeef")}},
+			{ID: "5", Name: "www.example.com.", Type: "A", Records: []string{"203.0.113.30"}},
+			{ID: "6", Name: "mysql.other.example.com.", Type: "A", Records: []string{"203.0.113.20"}},
+			{ID: "7", Name: "mysql.other.example.com.", Type: "TXT", Records: []string{ownerText("cafebabe")}},
+		},
+	}
+	records, err := designate{fake}.ownedRecords("example.com", "deadbeef")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(records, jc.DeepEquals, []environs.DNSRecord{{
+		Name:      "wordpress.mymodel",
+		Addresses: []string{"2001:db8::10", "203.0.113.10"},
+	}})
+	c.Check(fake.calls, jc.DeepEquals, []string{
+		"GET zones",
+		"GET zones/zone-id/recordsets",
+		"GET zones/zone-id/recordsets",
+		"GET zones/zone-id/recordsets",
+		"GET zones/zone-id/recordsets",
+	})
+}
+
+func (s *designateInternalSuite) TestOwnedRecordsZoneNotFound(c *gc.C) {
+	_, err := designate{&fakeDesignate{}}.ownedRecords("example.org", "deadbeef")
+	c.Assert(err, gc.ErrorMatches, `reading DNS zone "example.org": DNS zone "example.org." not found`)
+}
+
+func (s *designateInternalSuite) TestSetRecords(c *gc.C) {
+	fake := &fakeDesignate{
+		pageSize: 100,
+		recordSets: []designateRecordSet{
+			{ID: "1", Name: "mysql.mymodel.example.com.", Type: "A", Records: []string{"203.0.113.20"}},
+			{ID: "2", Name: "mysql.mymodel.example.com.", Type: "TXT", Records: []string{`"v=spf1 -all"`, ownerText("deadbeef")}},
+			{ID: "3", Name: "mediawiki.mymodel.example.com.", Type: "A", Records: []string{"203.0.113.30"}},
+			{ID: "4", Name: "mediawiki.mymodel.example.com.", Type: "TXT", Records: []string{ownerText("deadbeef")}},
+			{ID: "5", Name: "www.mymodel.example.com.", Type: "A", Records: []string{"203.0.113.40"}},
+		},
+	}
+	err := designate{fake}.setRecords("example.com", "deadbeef", []environs.DNSRecord{
+		{Name: "wordpress.mymodel", Addresses: []string{"203.0.113.10"}},
+		{Name: "mysql.mymodel"},
+		{Name: "mediawiki.mymodel", Addresses: []string{"203.0.113.31"}},
+		{Name: "www.mymodel", Addresses: []string{"203.0.113.11"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `not publishing www.mymodel: names in use by others`)
+
+	c.Check(fake.recordSets, jc.DeepEquals, []designateRecordSet{
+		{ID: "2", Name: "mysql.mymodel.example.com.", Type: "TXT", Records: []string{`"v=spf1 -all"`}},
+		{ID: "3", Name: "mediawiki.mymodel.example.com.", Type: "A", Records: []string{"203.0.113.31"}},
+		{ID: "4", Name: "mediawiki.mymodel.example.com.", Type: "TXT", Records: []string{ownerText("deadbeef")}},
+		{ID: "5", Name: "www.mymodel.example.com.", Type: "A", Records: []string{"203.0.113.40"}},
+		{ID: "new-1", Name: "wordpress.mymodel.example.com.", Type: "TXT", TTL: environs.DNSRecordTTL, Records: []string{ownerText("deadbeef")}},
+		{ID: "new-2", Name: "wordpress.mymodel.example.com.", Type: "A", TTL: environs.DNSRecordTTL, Records: []string{"203.0.113.10"}},
+	})
+	c.Check(fake.calls, jc.DeepEquals, []string{
+		"GET zones",
+		"GET zones/zone-id/recordsets",
+		"POST zones/zone-id/recordsets",
+		"POST zones/zone-id/recordsets",
+		"DELETE zones/zone-id/recordsets/1",
+		"PUT zones/zone-id/recordsets/2",
+		"PUT zones/zone-id/recordsets/4",
+		"PUT zones/zone-id/recordsets/3",
+	})
+}
@@ -54,6 +54,12 @@ func NewCinderVolumeSourceForModel(s OpenstackStorage, modelUUID string) storage
 	}
 }
 
+func NewISCSICinderVolumeSource(s OpenstackStorage) storage.VolumeSource {
+	source := NewCinderVolumeSource(s).(*cinderVolumeSource)
+	source.attachMethod = attachMethodISCSI
+	return source
+}
+
 type fakeNamespace struct {
 	instance.Namespace
 }
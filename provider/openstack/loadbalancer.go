@@ -0,0 +1,440 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/goose.v2/client"
+	goosehttp "gopkg.in/goose.v2/http"
+
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/provider/common"
+)
+
+var _ environs.LoadBalancers = (*Environ)(nil)
+
+// maxLoadBalancerListeners limits the number of listeners created for
+// a load balancer. Octavia needs a listener for each port, so
+// applications with wide port ranges cannot be load balanced.
+const maxLoadBalancerListeners = 100
+
+// octaviaAttempt is used when waiting for changes to a load balancer to
+// be provisioned. Octavia rejects changes to a load balancer while a
+// previous change is pending.
+var octaviaAttempt = utils.AttemptStrategy{
+	Total: 5 * time.Minute,
+	Delay: 5 * time.Second,
+}
+
+// octaviaListener is the port and protocol of a load balancer listener.
+// Each listener forwards to a pool of members listening on the same
+// port.
+type octaviaListener struct {
+	Protocol string
+	Port     int
+}
+
+// octaviaLoadBalancer holds the details of an Octavia load balancer
+// that are needed to manage it.
+type octaviaLoadBalancer struct {
+	ID                 string `json:"id"`
+	Name               string `json:"name"`
+	VIPAddress         string `json:"vip_address"`
+	ProvisioningStatus string `json:"provisioning_status"`
+}
+
+type octaviaPool struct {
+	ID       string `json:"id"`
+	Protocol string `json:"protocol"`
+}
+
+type octaviaListenerDetails struct {
+	ID            string `json:"id"`
+	Protocol      string `json:"protocol"`
+	ProtocolPort  int    `json:"protocol_port"`
+	DefaultPoolID string `json:"default_pool_id"`
+}
+
+type octaviaMember struct {
+	Address      string `json:"address"`
+	ProtocolPort int    `json:"protocol_port"`
+}
+
+// octavia makes requests to the OpenStack load balancing API.
+type octavia struct {
+	client client.Client
+}
+
+const (
+	octaviaService    = "load-balancer"
+	octaviaAPIVersion = "v2.0"
+)
+
+func (o octavia) send(method, path string, params *url.Values, req, resp interface{}, expected ...int) error {
+	requestData := goosehttp.RequestData{
+		Params:         params,
+		ReqValue:       req,
+		RespValue:      resp,
+		ExpectedStatus: expected,
+	}
+	return o.client.SendRequest(method, octaviaService, octaviaAPIVersion, path, &requestData)
+}
+
+// loadBalancer returns the named load balancer, or nil if there is none.
+func (o octavia) loadBalancer(name string) (*octaviaLoadBalancer, error) {
+	params := &url.Values{}
+	params.Set("name", name)
+	var resp struct {
+		LoadBalancers []octaviaLoadBalancer `json:"loadbalancers"`
+	}
+	if err := o.send(client.GET, "lbaas/loadbalancers", params, nil, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(resp.LoadBalancers) == 0 {
+		return nil, nil
+	}
+	return &resp.LoadBalancers[0], nil
+}
+
+// allLoadBalancers returns all of the load balancers in the project.
+func (o octavia) allLoadBalancers() ([]octaviaLoadBalancer, error) {
+	var resp struct {
+		LoadBalancers []octaviaLoadBalancer `json:"loadbalancers"`
+	}
+	if err := o.send(client.GET, "lbaas/loadbalancers", nil, nil, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.LoadBalancers, nil
+}
+
+func (o octavia) createLoadBalancer(name, networkID string) (*octaviaLoadBalancer, error) {
+	req := map[string]interface{}{
+		"loadbalancer": map[string]interface{}{
+			"name":           name,
+			"vip_network_id": networkID,
+		},
+	}
+	var resp struct {
+		LoadBalancer octaviaLoadBalancer `json:"loadbalancer"`
+	}
+	if err := o.send(client.POST, "lbaas/loadbalancers", nil, req, &resp, http.StatusCreated); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &resp.LoadBalancer, nil
+}
+
+// waitActive waits for pending changes to the load balancer to be
+// provisioned.
+func (o octavia) waitActive(id string) error {
+	for a := octaviaAttempt.Start(); a.Next(); {
+		var resp struct {
+			LoadBalancer octaviaLoadBalancer `json:"loadbalancer"`
+		}
+		if err := o.send(client.GET, "lbaas/loadbalancers/"+id, nil, nil, &resp); err != nil {
+			return errors.Trace(err)
+		}
+		switch resp.LoadBalancer.ProvisioningStatus {
+		case "ACTIVE":
+			return nil
+		case "ERROR":
+			return errors.Errorf("load balancer %q failed to provision", id)
+		}
+	}
+	return errors.Errorf("timed out waiting for load balancer %q to provision", id)
+}
+
+func (o octavia) listeners(lbID string) ([]octaviaListenerDetails, error) {
+	params := &url.Values{}
+	params.Set("loadbalancer_id", lbID)
+	var resp struct {
+		Listeners []octaviaListenerDetails `json:"listeners"`
+	}
+	if err := o.send(client.GET, "lbaas/listeners", params, nil, &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return resp.Listeners, nil
+}
+
+// addListener adds a listener for the port to the load balancer,
+// forwarding to a new pool with no members, and returns the pool's ID.
+func (o octavia) addListener(lbID string, listener octaviaListener) (string, error) {
+	listenerReq := map[string]interface{}{
+		"listener": map[string]interface{}{
+			"loadbalancer_id": lbID,
+			"protocol":        listener.Protocol,
+			"protocol_port":   listener.Port,
+		},
+	}
+	var listenerResp struct {
+		Listener octaviaListenerDetails `json:"listener"`
+	}
+	err := o.send(client.POST, "lbaas/listeners", nil, listenerReq, &listenerResp, http.StatusCreated)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := o.waitActive(lbID); err != nil {
+		return "", errors.Trace(err)
+	}
+	poolReq := map[string]interface{}{
+		"pool": map[string]interface{}{
+			"listener_id":  listenerResp.Listener.ID,
+			"protocol":     listener.Protocol,
+			"lb_algorithm": "ROUND_ROBIN",
+		},
+	}
+	var poolResp struct {
+		Pool octaviaPool `json:"pool"`
+	}
+	err = o.send(client.POST, "lbaas/pools", nil, poolReq, &poolResp, http.StatusCreated)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return poolResp.Pool.ID, errors.Trace(o.waitActive(lbID))
+}
+
+// removeListener removes the listener and its pool from the load
+// balancer.
+func (o octavia) removeListener(lbID string, listener octaviaListenerDetails) error {
+	if listener.DefaultPoolID != "" {
+		err := o.send(client.DELETE, "lbaas/pools/"+listener.DefaultPoolID, nil, nil, nil, http.StatusNoContent)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := o.waitActive(lbID); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	err := o.send(client.DELETE, "lbaas/listeners/"+listener.ID, nil, nil, nil, http.StatusNoContent)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(o.waitActive(lbID))
+}
+
+// setMembers replaces the members of the pool with the given
+// addresses.
+func (o octavia) setMembers(lbID, poolID string, port int, addresses []string) error {
+	members := make([]octaviaMember, len(addresses))
+	for i, addr := range addresses {
+		members[i] = octaviaMember{Address: addr, ProtocolPort: port}
+	}
+	req := map[string]interface{}{"members": members}
+	err := o.send(client.PUT, "lbaas/pools/"+poolID+"/members", nil, req, nil, http.StatusAccepted)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(o.waitActive(lbID))
+}
+
+// deleteLoadBalancer deletes the load balancer along with its
+// listeners, pools and members.
+func (o octavia) deleteLoadBalancer(id string) error {
+	params := &url.Values{}
+	params.Set("cascade", "true")
+	return errors.Trace(o.send(client.DELETE, "lbaas/loadbalancers/"+id, params, nil, nil, http.StatusNoContent))
+}
+
+// loadBalancerName returns the name of the load balancer for the named
+// application.
+func (e *Environ) loadBalancerName(applicationName string) string {
+	return e.namespace.Prefix() + "lb-" + applicationName
+}
+
+// EnsureLoadBalancer is part of the environs.LoadBalancers interface.
+//
+// The load balancer's virtual IP is allocated on the network configured
+// for the model, and it forwards each opened port to the same port on
+// the instances' addresses on that network.
+func (e *Environ) EnsureLoadBalancer(ctx context.ProviderCallContext, spec environs.LoadBalancerSpec) ([]network.Address, error) {
+	addrs, err := e.ensureLoadBalancer(ctx, spec)
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return nil, errors.Trace(err)
+	}
+	return addrs, nil
+}
+
+func (e *Environ) ensureLoadBalancer(ctx context.ProviderCallContext, spec environs.LoadBalancerSpec) ([]network.Address, error) {
+	usingNetwork := e.ecfg().network()
+	if usingNetwork == "" {
+		return nil, errors.NotSupportedf("load balancers without the %q model config", "network")
+	}
+	networkID, err := e.networking.ResolveNetwork(usingNetwork, false)
+	if err != nil {
+		return nil, errors.Annotate(err, "resolving load balancer network")
+	}
+	listeners, err := octaviaListeners(spec.PortRanges)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	members, err := e.loadBalancerMembers(ctx, spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	o := octavia{e.client()}
+	name := e.loadBalancerName(spec.ApplicationName)
+	lb, err := o.loadBalancer(name)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting load balancer")
+	}
+	if lb == nil {
+		if lb, err = o.createLoadBalancer(name, networkID); err != nil {
+			return nil, errors.Annotate(err, "creating load balancer")
+		}
+	}
+	if err := o.waitActive(lb.ID); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	existing, err := o.listeners(lb.ID)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting load balancer listeners")
+	}
+	pools := make(map[octaviaListener]string)
+	wanted := make(map[octaviaListener]bool)
+	for _, listener := range listeners {
+		wanted[listener] = true
+	}
+	for _, details := range existing {
+		listener := octaviaListener{Protocol: details.Protocol, Port: details.ProtocolPort}
+		if wanted[listener] && details.DefaultPoolID != "" {
+			pools[listener] = details.DefaultPoolID
+			continue
+		}
+		if err := o.removeListener(lb.ID, details); err != nil {
+			return nil, errors.Annotatef(err, "removing listener for %v", listener)
+		}
+	}
+	for _, listener := range listeners {
+		poolID, ok := pools[listener]
+		if !ok {
+			if poolID, err = o.addListener(lb.ID, listener); err != nil {
+				return nil, errors.Annotatef(err, "adding listener for %v", listener)
+			}
+		}
+		if err := o.setMembers(lb.ID, poolID, listener.Port, members); err != nil {
+			return nil, errors.Annotatef(err, "updating members for %v", listener)
+		}
+	}
+	return []network.Address{network.NewAddress(lb.VIPAddress)}, nil
+}
+
+// loadBalancerMembers returns the internal addresses of the instances
+// in the spec.
+func (e *Environ) loadBalancerMembers(ctx context.ProviderCallContext, spec environs.LoadBalancerSpec) ([]string, error) {
+	insts, err := e.Instances(ctx, spec.Instances)
+	if err != nil && err != environs.ErrPartialInstances {
+		return nil, errors.Annotate(err, "getting load balancer instances")
+	}
+	var members []string
+	for _, inst := range insts {
+		if inst == nil {
+			continue
+		}
+		addrs, err := inst.Addresses(ctx)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting addresses of instance %q", inst.Id())
+		}
+		if addr, ok := network.SelectInternalAddress(addrs, false); ok {
+			members = append(members, addr.Value)
+		}
+	}
+	sort.Strings(members)
+	return members, nil
+}
+
+// DeleteLoadBalancer is part of the environs.LoadBalancers interface.
+func (e *Environ) DeleteLoadBalancer(ctx context.ProviderCallContext, applicationName string) error {
+	o := octavia{e.client()}
+	lb, err := o.loadBalancer(e.loadBalancerName(applicationName))
+	if err == nil && lb != nil {
+		err = o.deleteLoadBalancer(lb.ID)
+	}
+	if err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return errors.Annotate(err, "removing load balancer")
+	}
+	return nil
+}
+
+// deleteModelLoadBalancers deletes all of the load balancers named for
+// the model's applications, whether or not the firewaller still knows
+// about them. Clouds without Octavia have none to delete.
+func (e *Environ) deleteModelLoadBalancers(ctx context.ProviderCallContext) error {
+	cl := e.client()
+	if !cl.IsAuthenticated() {
+		if err := authenticateClient(cl); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if _, ok := cl.EndpointsForRegion(e.cloud().Region)[octaviaService]; !ok {
+		return nil
+	}
+	o := octavia{cl}
+	lbs, err := o.allLoadBalancers()
+	if err != nil {
+		return errors.Annotate(err, "listing load balancers")
+	}
+	prefix := e.loadBalancerName("")
+	for _, lb := range lbs {
+		if !strings.HasPrefix(lb.Name, prefix) {
+			continue
+		}
+		if err := o.deleteLoadBalancer(lb.ID); err != nil {
+			return errors.Annotatef(err, "deleting load balancer %q", lb.Name)
+		}
+	}
+	return nil
+}
+
+// octaviaListeners returns the listeners needed to forward the ports in
+// the port ranges, sorted by protocol and port.
+func octaviaListeners(portRanges []corenetwork.PortRange) ([]octaviaListener, error) {
+	seen := make(map[octaviaListener]bool)
+	var listeners []octaviaListener
+	for _, portRange := range portRanges {
+		protocol := strings.ToUpper(portRange.Protocol)
+		if protocol != "TCP" && protocol != "UDP" {
+			logger.Warningf("load balancers do not support %v, ignoring", portRange)
+			continue
+		}
+		for port := portRange.FromPort; port <= portRange.ToPort; port++ {
+			listener := octaviaListener{Protocol: protocol, Port: port}
+			if seen[listener] {
+				continue
+			}
+			seen[listener] = true
+			listeners = append(listeners, listener)
+		}
+	}
+	if len(listeners) == 0 {
+		return nil, errors.NotSupportedf("load balancing without TCP or UDP ports")
+	}
+	if len(listeners) > maxLoadBalancerListeners {
+		return nil, errors.NotSupportedf("load balancing more than %d ports", maxLoadBalancerListeners)
+	}
+	sort.Slice(listeners, func(i, j int) bool {
+		if listeners[i].Protocol != listeners[j].Protocol {
+			return listeners[i].Protocol < listeners[j].Protocol
+		}
+		return listeners[i].Port < listeners[j].Port
+	})
+	return listeners, nil
+}
+
+func (l octaviaListener) String() string {
+	return fmt.Sprintf("%d/%s", l.Port, strings.ToLower(l.Protocol))
+}
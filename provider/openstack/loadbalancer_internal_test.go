@@ -0,0 +1,50 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package openstack
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	corenetwork "github.com/juju/juju/core/network"
+)
+
+type loadBalancerInternalSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&loadBalancerInternalSuite{})
+
+func (s *loadBalancerInternalSuite) TestOctaviaListeners(c *gc.C) {
+	listeners, err := octaviaListeners([]corenetwork.PortRange{
+		{FromPort: 8080, ToPort: 8081, Protocol: "tcp"},
+		{FromPort: 53, ToPort: 53, Protocol: "udp"},
+		{FromPort: 80, ToPort: 80, Protocol: "tcp"},
+		{FromPort: 8081, ToPort: 8081, Protocol: "tcp"},
+		{FromPort: -1, ToPort: -1, Protocol: "icmp"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(listeners, jc.DeepEquals, []octaviaListener{
+		{Protocol: "TCP", Port: 80},
+		{Protocol: "TCP", Port: 8080},
+		{Protocol: "TCP", Port: 8081},
+		{Protocol: "UDP", Port: 53},
+	})
+}
+
+func (s *loadBalancerInternalSuite) TestOctaviaListenersNone(c *gc.C) {
+	_, err := octaviaListeners([]corenetwork.PortRange{
+		{FromPort: -1, ToPort: -1, Protocol: "icmp"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *loadBalancerInternalSuite) TestOctaviaListenersTooMany(c *gc.C) {
+	_, err := octaviaListeners([]corenetwork.PortRange{
+		{FromPort: 8000, ToPort: 8000 + maxLoadBalancerListeners, Protocol: "tcp"},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
@@ -2260,6 +2260,47 @@ func (t *localServerSuite) TestStartInstanceWithUnknownAZError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "(?s).*Some unknown error.*")
 }
 
+func (t *localServerSuite) TestStartInstanceQuotaExceeded(c *gc.C) {
+	err := bootstrapEnv(c, t.env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cleanup := t.srv.Nova.RegisterControlPoint(
+		"addServer",
+		func(sc hook.ServiceControl, args ...interface{}) error {
+			return fmt.Errorf("Quota exceeded for instances: Requested 1, but already used 10 of 10 instances")
+		},
+	)
+	defer cleanup()
+	_, err = testing.StartInstanceWithParams(t.env, t.callCtx, "1", environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+	})
+	c.Assert(err, gc.ErrorMatches, "(?s)cannot run instance: .*Quota exceeded for instances.*")
+	kind, code := environs.ClassifyProvisioningError(err)
+	c.Check(kind, gc.Equals, environs.ProvisioningErrorNeedsUserAction)
+	c.Check(code, gc.Equals, "quota-exceeded")
+}
+
+func (t *localServerSuite) TestStartInstanceNoValidHost(c *gc.C) {
+	err := bootstrapEnv(c, t.env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	cleanup := t.srv.Nova.RegisterControlPoint(
+		"addServer",
+		func(sc hook.ServiceControl, args ...interface{}) error {
+			return fmt.Errorf("No valid host was found. There are not enough hosts available.")
+		},
+	)
+	defer cleanup()
+	_, err = testing.StartInstanceWithParams(t.env, t.callCtx, "1", environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+	})
+	c.Assert(err, gc.ErrorMatches, "(?s)cannot run instance: .*No valid host was found.*")
+	c.Assert(err, gc.Not(jc.Satisfies), environs.IsAvailabilityZoneIndependent)
+	kind, code := environs.ClassifyProvisioningError(err)
+	c.Check(kind, gc.Equals, environs.ProvisioningErrorRetryable)
+	c.Check(code, gc.Equals, "insufficient-capacity")
+}
+
 func (t *localServerSuite) testStartInstanceWithParamsDeriveAZ(
 	machineId string,
 	params environs.StartInstanceParams,
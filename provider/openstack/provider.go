@@ -158,6 +158,16 @@ func (EnvironProvider) Version() int {
 	return 0
 }
 
+// Capabilities is part of the CapabilityReporter interface.
+func (EnvironProvider) Capabilities() []environs.Capability {
+	return []environs.Capability{
+		environs.CapabilityAvailabilityZones,
+		environs.CapabilityLoadBalancers,
+		environs.CapabilityDNSZones,
+		environs.CapabilityBlockStorage,
+	}
+}
+
 func (p EnvironProvider) Open(args environs.OpenParams) (environs.Environ, error) {
 	logger.Infof("opening model %q", args.Config.Name())
 	if err := validateCloudSpec(args.Cloud); err != nil {
@@ -317,6 +327,7 @@ var _ environs.NetworkingEnviron = (*Environ)(nil)
 var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ context.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.VolumePreservingDestroyer = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -520,6 +531,7 @@ func (e *Environ) neutron() *neutron.Client {
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.CpuPower,
+	constraints.ImageID,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -1278,12 +1290,18 @@ func (e *Environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 	server, err := tryStartNovaInstance(shortAttempt, e.nova(), opts)
 	if err != nil || server == nil {
 		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
-		// 'No valid host available' is typically a resource error,
-		// let the provisioner know it is a good idea to try another
-		// AZ if available.
 		err := errors.Annotate(err, "cannot run instance")
-		zoneSpecific := isNoValidHostsError(err)
-		if !zoneSpecific {
+		switch {
+		case isQuotaExceededError(err):
+			// The project's quota will not be raised without the
+			// user asking for it, so there's no point retrying.
+			err = common.ProvisioningError(err, environs.ProvisioningErrorNeedsUserAction, "quota-exceeded")
+		case isNoValidHostsError(err):
+			// 'No valid host available' is typically a resource error,
+			// let the provisioner know it is a good idea to try another
+			// AZ if available.
+			err = common.ProvisioningError(err, environs.ProvisioningErrorRetryable, "insufficient-capacity")
+		default:
 			err = common.ZoneIndependentError(err)
 		}
 		return nil, err
@@ -1417,6 +1435,15 @@ func isNoValidHostsError(err error) bool {
 	return false
 }
 
+// isQuotaExceededError reports whether the error is due to the
+// project's compute quota being exceeded.
+func isQuotaExceededError(err error) bool {
+	if cause := errors.Cause(err); cause != nil {
+		return strings.Contains(cause.Error(), "Quota exceeded")
+	}
+	return false
+}
+
 func (e *Environ) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
 	// If in instance firewall mode, gather the security group names.
 	securityGroupNames, err := e.firewaller.GetSecurityGroups(ctx, ids...)
@@ -1711,7 +1738,25 @@ func (e *Environ) allInstances(ctx context.ProviderCallContext, tagFilter tagVal
 }
 
 func (e *Environ) Destroy(ctx context.ProviderCallContext) error {
-	err := common.Destroy(e, ctx)
+	return e.destroy(ctx, common.Destroy)
+}
+
+// DestroyExceptVolumes implements the environs.VolumePreservingDestroyer
+// interface.
+func (e *Environ) DestroyExceptVolumes(ctx context.ProviderCallContext) error {
+	return e.destroy(ctx, common.DestroyExceptVolumes)
+}
+
+// destroy destroys the model's load balancers and security groups,
+// and everything else with the given function.
+func (e *Environ) destroy(ctx context.ProviderCallContext, destroyRest func(environs.Environ, context.ProviderCallContext) error) error {
+	// Load balancers have ports on the model's networks, so they must
+	// go before the security groups.
+	if err := e.deleteModelLoadBalancers(ctx); err != nil {
+		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
+		return errors.Trace(err)
+	}
+	err := destroyRest(e, ctx)
 	if err != nil {
 		common.HandleCredentialError(IsAuthorisationFailure, err, ctx)
 		return errors.Trace(err)
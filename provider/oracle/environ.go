@@ -650,6 +650,7 @@ func (o *OracleEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (c
 	unsupportedConstraints := []string{
 		constraints.Container,
 		constraints.CpuPower,
+		constraints.ImageID,
 		constraints.RootDisk,
 		constraints.VirtType,
 	}
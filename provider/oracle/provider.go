@@ -80,6 +80,15 @@ func (EnvironProvider) Version() int {
 	return 0
 }
 
+// Capabilities is part of the CapabilityReporter interface.
+func (EnvironProvider) Capabilities() []environs.Capability {
+	return []environs.Capability{
+		environs.CapabilitySpaces,
+		environs.CapabilityAvailabilityZones,
+		environs.CapabilityBlockStorage,
+	}
+}
+
 // Open is defined on the environs.EnvironProvider interface.
 func (e *EnvironProvider) Open(params environs.OpenParams) (environs.Environ, error) {
 	logger.Debugf("opening model %q", params.Config.Name())
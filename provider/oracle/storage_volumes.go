@@ -505,6 +505,23 @@ func (s *oracleVolumeSource) getDeviceNameForIndex(idx int) string {
 	return fmt.Sprintf("%s%s", blockDevicePrefix, string([]byte{blockDeviceStartIndex + byte(idx)}))
 }
 
+// volumeAttachmentInfo returns the information of the volume attached
+// with the given index. The volume appears as a local block device,
+// whose name is also passed to the machine agent in the attachment
+// plan, so that it can wait for the device and report it.
+func (s *oracleVolumeSource) volumeAttachmentInfo(idx int) storage.VolumeAttachmentInfo {
+	deviceName := s.getDeviceNameForIndex(idx)
+	return storage.VolumeAttachmentInfo{
+		DeviceName: deviceName,
+		PlanInfo: &storage.VolumeAttachmentPlanInfo{
+			DeviceType: storage.DeviceTypeLocal,
+			DeviceAttributes: map[string]string{
+				"device-name": deviceName,
+			},
+		},
+	}
+}
+
 func (s *oracleVolumeSource) attachVolume(
 	instance *oracleInstance,
 	currentAttachments map[string][]ociResponse.StorageAttachment,
@@ -535,9 +552,7 @@ func (s *oracleVolumeSource) attachVolume(
 				VolumeAttachment: &storage.VolumeAttachment{
 					params.Volume,
 					params.Machine,
-					storage.VolumeAttachmentInfo{
-						DeviceName: s.getDeviceNameForIndex(int(val.Index)),
-					},
+					s.volumeAttachmentInfo(int(val.Index)),
 				},
 			}, nil
 		}
@@ -585,9 +600,7 @@ func (s *oracleVolumeSource) attachVolume(
 		VolumeAttachment: &storage.VolumeAttachment{
 			params.Volume,
 			params.Machine,
-			storage.VolumeAttachmentInfo{
-				DeviceName: s.getDeviceNameForIndex(idx),
-			},
+			s.volumeAttachmentInfo(idx),
 		},
 	}
 	return result, nil
@@ -72,6 +72,7 @@ func (env *sessionEnviron) checkDatastore(ctx context.ProviderCallContext, datas
 }
 
 var unsupportedConstraints = []string{
+	constraints.ImageID,
 	constraints.Tags,
 	constraints.VirtType,
 }
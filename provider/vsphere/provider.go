@@ -50,6 +50,13 @@ func (p *environProvider) Version() int {
 	return currentProviderVersion
 }
 
+// Capabilities implements environs.CapabilityReporter.
+func (*environProvider) Capabilities() []environs.Capability {
+	return []environs.Capability{
+		environs.CapabilityAvailabilityZones,
+	}
+}
+
 // Open implements environs.EnvironProvider.
 func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
 	if err := validateCloudSpec(args.Cloud); err != nil {
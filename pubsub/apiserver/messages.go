@@ -98,3 +98,22 @@ const RestartTopic = "apiserver.restart"
 // Restart message only contains the local-only indicator as the restart
 // is only ever for the same agent.
 type Restart common.LocalOnly
+
+// CloseSessionsTopic is used to ask the API servers to close logged in
+// API connections.
+// data: `CloseSessions`
+const CloseSessionsTopic = "apiserver.close-sessions"
+
+// CloseSessions identifies the API connections to close. Connections
+// are identified either by the API server they are connected to and
+// their connection IDs, or by the user that logged in, in which case
+// the user's connections to every API server are closed.
+type CloseSessions struct {
+	// Server is the machine ID of the API server that the connections
+	// in ConnectionIDs are connected to.
+	Server        string   `yaml:"server,omitempty"`
+	ConnectionIDs []uint64 `yaml:"connection-ids,omitempty"`
+
+	// User is the tag of the user whose connections are closed.
+	User string `yaml:"user,omitempty"`
+}
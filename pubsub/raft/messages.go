@@ -0,0 +1,27 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft
+
+// SnapshotRequestTopic is the topic on which requests for the
+// controllers to snapshot their raft logs are published. Each
+// controller takes a snapshot, discarding the log entries it covers,
+// and publishes a SnapshotResponse on the request's response topic.
+// data: `SnapshotRequest`
+const SnapshotRequestTopic = "raft.snapshot-request"
+
+// SnapshotRequest is the message published to ask the controllers to
+// snapshot their raft logs.
+type SnapshotRequest struct {
+	ResponseTopic string `yaml:"response-topic"`
+}
+
+// SnapshotResponse is sent back by each controller once it has taken
+// a snapshot, describing the disk space used by its raft node.
+type SnapshotResponse struct {
+	MachineID     string `yaml:"machine-id"`
+	LogBytes      int64  `yaml:"log-bytes"`
+	LogEntries    uint64 `yaml:"log-entries"`
+	SnapshotBytes int64  `yaml:"snapshot-bytes"`
+	Error         string `yaml:"error,omitempty"`
+}
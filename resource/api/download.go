@@ -3,7 +3,14 @@
 
 package api
 
-import "net/http"
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+)
 
 // NewHTTPDownloadRequest creates a new HTTP download request
 // for the given resource.
@@ -12,3 +19,53 @@ import "net/http"
 func NewHTTPDownloadRequest(resourceName string) (*http.Request, error) {
 	return http.NewRequest("GET", "/resources/"+resourceName, nil)
 }
+
+// NewHTTPRangeDownloadRequest creates a new HTTP download request
+// for the bytes of the given resource from start to end, inclusive.
+//
+// Intended for use on the client side.
+func NewHTTPRangeDownloadRequest(resourceName string, start, end int64) (*http.Request, error) {
+	req, err := NewHTTPDownloadRequest(resourceName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	req.Header.Set(HeaderRange, fmt.Sprintf("bytes=%d-%d", start, end))
+	return req, nil
+}
+
+// ExtractDownloadRange returns the inclusive byte range requested by
+// the "Range" header of a download request, for a resource of the
+// given size. Only a single range is supported. If the header is not
+// set, ok is false.
+//
+// Intended for use on the server side.
+func ExtractDownloadRange(req *http.Request, size int64) (start, end int64, ok bool, err error) {
+	value := req.Header.Get(HeaderRange)
+	if value == "" {
+		return 0, 0, false, nil
+	}
+	if !strings.HasPrefix(value, "bytes=") || strings.Contains(value, ",") {
+		return 0, 0, false, errors.BadRequestf("range %q not valid", value)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(value, "bytes="), "-", 2)
+	if len(parts) != 2 {
+		return 0, 0, false, errors.BadRequestf("range %q not valid", value)
+	}
+	start, err = strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, 0, false, errors.BadRequestf("range %q not valid", value)
+	}
+	end = size - 1
+	if parts[1] != "" {
+		if end, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+			return 0, 0, false, errors.BadRequestf("range %q not valid", value)
+		}
+		if end >= size {
+			end = size - 1
+		}
+	}
+	if start < 0 || start > end {
+		return 0, 0, false, errors.BadRequestf("range %q for %d bytes not valid", value, size)
+	}
+	return start, end, true, nil
+}
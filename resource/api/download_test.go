@@ -0,0 +1,82 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package api_test
+
+import (
+	"net/http"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/resource/api"
+)
+
+type DownloadSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&DownloadSuite{})
+
+func (DownloadSuite) TestNewHTTPRangeDownloadRequest(c *gc.C) {
+	req, err := api.NewHTTPRangeDownloadRequest("spam", 10, 19)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(req.Method, gc.Equals, "GET")
+	c.Check(req.URL.Path, gc.Equals, "/resources/spam")
+	c.Check(req.Header.Get("Range"), gc.Equals, "bytes=10-19")
+}
+
+func (DownloadSuite) TestExtractDownloadRange(c *gc.C) {
+	for i, test := range []struct {
+		header     string
+		start, end int64
+		ok         bool
+		err        string
+	}{{
+		header: "",
+	}, {
+		header: "bytes=10-19",
+		start:  10,
+		end:    19,
+		ok:     true,
+	}, {
+		header: "bytes=90-",
+		start:  90,
+		end:    99,
+		ok:     true,
+	}, {
+		header: "bytes=90-200",
+		start:  90,
+		end:    99,
+		ok:     true,
+	}, {
+		header: "bytes=100-110",
+		err:    `range "bytes=100-110" for 100 bytes not valid`,
+	}, {
+		header: "bytes=0-9,20-29",
+		err:    `range "bytes=0-9,20-29" not valid`,
+	}, {
+		header: "lines=1-2",
+		err:    `range "lines=1-2" not valid`,
+	}, {
+		header: "bytes=-10",
+		err:    `range "bytes=-10" not valid`,
+	}} {
+		c.Logf("test %d: %q", i, test.header)
+		req, err := http.NewRequest("GET", "/resources/spam", nil)
+		c.Assert(err, jc.ErrorIsNil)
+		if test.header != "" {
+			req.Header.Set("Range", test.header)
+		}
+		start, end, ok, err := api.ExtractDownloadRange(req, 100)
+		if test.err != "" {
+			c.Check(err, gc.ErrorMatches, test.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(ok, gc.Equals, test.ok)
+		c.Check(start, gc.Equals, test.start)
+		c.Check(end, gc.Equals, test.end)
+	}
+}
@@ -45,6 +45,15 @@ const (
 	// The params are formatted according to  RFC 2045 and RFC 2616 (see
 	// mime.ParseMediaType and mime.FormatMediaType).
 	HeaderContentDisposition = "Content-Disposition"
+	// HeaderRange is the header name for the byte range of a resource
+	// download being requested.
+	HeaderRange = "Range"
+	// HeaderContentRange is the header name for the byte range of the
+	// resource sent in response to a ranged download.
+	HeaderContentRange = "Content-Range"
+	// HeaderChunkSha384 is the trailer name for the sha hash of the bytes
+	// sent in response to a ranged download.
+	HeaderChunkSha384 = "Chunk-Sha384"
 )
 
 const (
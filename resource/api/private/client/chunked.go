@@ -0,0 +1,166 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/resource/api"
+)
+
+var logger = loggo.GetLogger("juju.resource.api.private.client")
+
+const (
+	// DefaultChunkSize is the size of the chunks in which large
+	// resources are downloaded.
+	DefaultChunkSize = 32 * 1024 * 1024
+
+	// DefaultParallelChunks is the number of chunks of a large
+	// resource that are downloaded at once.
+	DefaultParallelChunks = 4
+
+	// chunkAttempts is the number of times each chunk is requested
+	// before the download is abandoned.
+	chunkAttempts = 3
+)
+
+type chunkResult struct {
+	data []byte
+	err  error
+}
+
+// chunkedReader reads a resource as a sequence of byte ranges, each
+// of which is verified against the hash sent by the controller and
+// retried if it fails. Up to the given number of chunks are fetched
+// in parallel, ahead of the reader.
+type chunkedReader struct {
+	client       HTTPClient
+	resourceName string
+
+	futures  chan chan chunkResult
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	current *bytes.Reader
+	err     error
+}
+
+func newChunkedReader(client HTTPClient, resourceName string, size, chunkSize int64, parallel int) *chunkedReader {
+	if parallel < 1 {
+		parallel = 1
+	}
+	r := &chunkedReader{
+		client:       client,
+		resourceName: resourceName,
+		futures:      make(chan chan chunkResult, parallel-1),
+		stop:         make(chan struct{}),
+		current:      bytes.NewReader(nil),
+	}
+	go r.loop(size, chunkSize)
+	return r
+}
+
+func (r *chunkedReader) loop(size, chunkSize int64) {
+	defer close(r.futures)
+	for start := int64(0); start < size; start += chunkSize {
+		end := start + chunkSize - 1
+		if end >= size {
+			end = size - 1
+		}
+		future := make(chan chunkResult, 1)
+		select {
+		case r.futures <- future:
+		case <-r.stop:
+			return
+		}
+		go func(start, end int64) {
+			data, err := r.fetch(start, end)
+			future <- chunkResult{data: data, err: err}
+		}(start, end)
+	}
+}
+
+// fetch returns the bytes of the resource from start to end,
+// inclusive, retrying failed or corrupt requests.
+func (r *chunkedReader) fetch(start, end int64) ([]byte, error) {
+	var err error
+	for attempt := 0; attempt < chunkAttempts; attempt++ {
+		select {
+		case <-r.stop:
+			return nil, errors.New("download stopped")
+		default:
+		}
+		var data []byte
+		data, err = r.fetchOnce(start, end)
+		if err == nil {
+			return data, nil
+		}
+		logger.Debugf("fetching bytes %d-%d of resource %q: %v", start, end, r.resourceName, err)
+	}
+	return nil, errors.Annotatef(err, "fetching bytes %d-%d", start, end)
+}
+
+func (r *chunkedReader) fetchOnce(start, end int64) ([]byte, error) {
+	req, err := api.NewHTTPRangeDownloadRequest(r.resourceName, start, end)
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to build API request")
+	}
+	var response *http.Response
+	if err := r.client.Do(req, nil, &response); err != nil {
+		return nil, errors.Annotate(err, "HTTP request failed")
+	}
+	defer response.Body.Close()
+
+	data, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if int64(len(data)) != end-start+1 {
+		return nil, errors.Errorf("expected %d bytes, got %d", end-start+1, len(data))
+	}
+	// The trailer is only populated once the body has been read.
+	expected := response.Trailer.Get(api.HeaderChunkSha384)
+	sum := sha512.Sum384(data)
+	if actual := hex.EncodeToString(sum[:]); actual != expected {
+		return nil, errors.Errorf("chunk hash mismatch: expected %q, got %q", expected, actual)
+	}
+	return data, nil
+}
+
+// Read is part of the io.Reader interface.
+func (r *chunkedReader) Read(p []byte) (int, error) {
+	for r.current.Len() == 0 {
+		if r.err != nil {
+			return 0, r.err
+		}
+		future, ok := <-r.futures
+		if !ok {
+			r.err = io.EOF
+			continue
+		}
+		result := <-future
+		if result.err != nil {
+			r.err = result.err
+			r.Close()
+			continue
+		}
+		r.current = bytes.NewReader(result.data)
+	}
+	return r.current.Read(p)
+}
+
+// Close is part of the io.Closer interface.
+func (r *chunkedReader) Close() error {
+	r.stopOnce.Do(func() { close(r.stop) })
+	return nil
+}
@@ -42,8 +42,10 @@ type UnitHTTPClient interface {
 // portion of the uniter facade.
 func NewUnitFacadeClient(facadeCaller FacadeCaller, httpClient UnitHTTPClient) *UnitFacadeClient {
 	return &UnitFacadeClient{
-		FacadeCaller: facadeCaller,
-		HTTPClient:   httpClient,
+		FacadeCaller:   facadeCaller,
+		HTTPClient:     httpClient,
+		ChunkSize:      DefaultChunkSize,
+		ParallelChunks: DefaultParallelChunks,
 	}
 }
 
@@ -52,12 +54,32 @@ func NewUnitFacadeClient(facadeCaller FacadeCaller, httpClient UnitHTTPClient) *
 type UnitFacadeClient struct {
 	FacadeCaller
 	HTTPClient
+
+	// ChunkSize is the size above which resources are downloaded
+	// in chunks, and the size of those chunks.
+	ChunkSize int64
+
+	// ParallelChunks is the number of chunks of a resource that
+	// are downloaded at once.
+	ParallelChunks int
 }
 
 // GetResource opens the resource (metadata/blob), if it exists, via
 // the HTTP API and returns it. If it does not exist or hasn't been
-// uploaded yet then errors.NotFound is returned.
+// uploaded yet then errors.NotFound is returned. Resources larger
+// than the client's chunk size are downloaded in verified chunks,
+// each of which is retried if it fails.
 func (c *UnitFacadeClient) GetResource(resourceName string) (resource.Resource, io.ReadCloser, error) {
+	// HACK(katco): Combine this into one request?
+	resourceInfo, err := c.getResourceInfo(resourceName)
+	if err != nil {
+		return resource.Resource{}, nil, errors.Trace(err)
+	}
+	if c.ChunkSize > 0 && resourceInfo.Size > c.ChunkSize {
+		reader := newChunkedReader(c.HTTPClient, resourceName, resourceInfo.Size, c.ChunkSize, c.ParallelChunks)
+		return resourceInfo, reader, nil
+	}
+
 	var response *http.Response
 	req, err := api.NewHTTPDownloadRequest(resourceName)
 	if err != nil {
@@ -67,12 +89,6 @@ func (c *UnitFacadeClient) GetResource(resourceName string) (resource.Resource,
 		return resource.Resource{}, nil, errors.Annotate(err, "HTTP request failed")
 	}
 
-	// HACK(katco): Combine this into one request?
-	resourceInfo, err := c.getResourceInfo(resourceName)
-	if err != nil {
-		return resource.Resource{}, nil, errors.Trace(err)
-	}
-
 	// TODO(katco): Check headers against resource info
 	// TODO(katco): Check in on all the response headers
 	return resourceInfo, response.Body, nil
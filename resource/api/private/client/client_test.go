@@ -4,8 +4,14 @@
 package client_test
 
 import (
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/juju/errors"
 	"github.com/juju/testing"
@@ -45,6 +51,8 @@ func (s *UnitFacadeClientSuite) TestNewUnitFacadeClient(c *gc.C) {
 	s.stub.CheckNoCalls(c)
 	c.Check(cl.FacadeCaller, gc.Equals, caller)
 	c.Check(cl.HTTPClient, gc.Equals, doer)
+	c.Check(cl.ChunkSize, gc.Equals, int64(client.DefaultChunkSize))
+	c.Check(cl.ParallelChunks, gc.Equals, client.DefaultParallelChunks)
 }
 
 func (s *UnitFacadeClientSuite) TestGetResource(c *gc.C) {
@@ -55,11 +63,48 @@ func (s *UnitFacadeClientSuite) TestGetResource(c *gc.C) {
 	info, content, err := cl.GetResource("spam")
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Do", "FacadeCall")
+	s.stub.CheckCallNames(c, "FacadeCall", "Do")
 	c.Check(info, jc.DeepEquals, opened.Resource)
 	c.Check(content, jc.DeepEquals, opened)
 }
 
+func (s *UnitFacadeClientSuite) TestGetResourceChunked(c *gc.C) {
+	data := "some data that spans several chunks"
+	opened := resourcetesting.NewResource(c, s.stub, "spam", "a-application", data)
+	s.api.setResource(opened.Resource, opened)
+	doer := &rangeDoer{data: data, corrupt: map[string]bool{"bytes=8-15": true}}
+	cl := client.NewUnitFacadeClient(s.api, doer)
+	cl.ChunkSize = 8
+	cl.ParallelChunks = 2
+
+	info, content, err := cl.GetResource("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer content.Close()
+	c.Check(info, jc.DeepEquals, opened.Resource)
+
+	received, err := ioutil.ReadAll(content)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(received), gc.Equals, data)
+	// The corrupt chunk was requested again.
+	c.Check(doer.requests(), gc.Equals, 6)
+}
+
+func (s *UnitFacadeClientSuite) TestGetResourceChunkedFailure(c *gc.C) {
+	data := "some data that spans several chunks"
+	opened := resourcetesting.NewResource(c, s.stub, "spam", "a-application", data)
+	s.api.setResource(opened.Resource, opened)
+	doer := &rangeDoer{data: data, corrupt: map[string]bool{"bytes=16-23": true}, always: true}
+	cl := client.NewUnitFacadeClient(s.api, doer)
+	cl.ChunkSize = 8
+
+	_, content, err := cl.GetResource("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer content.Close()
+
+	_, err = ioutil.ReadAll(content)
+	c.Assert(err, gc.ErrorMatches, `fetching bytes 16-23: chunk hash mismatch: .*`)
+}
+
 func (s *UnitFacadeClientSuite) TestUnitDoer(c *gc.C) {
 	req, err := http.NewRequest("GET", "/resources/eggs", nil)
 	c.Assert(err, jc.ErrorIsNil)
@@ -122,3 +167,47 @@ func (s *stubAPI) Do(req *http.Request, body io.ReadSeeker, response interface{}
 	*resp = s.ReturnDo
 	return nil
 }
+
+// rangeDoer serves byte ranges of data as the controller does,
+// corrupting the requested ranges once, or always if so configured.
+type rangeDoer struct {
+	mu      sync.Mutex
+	data    string
+	corrupt map[string]bool
+	always  bool
+	count   int
+}
+
+func (d *rangeDoer) requests() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.count
+}
+
+func (d *rangeDoer) Do(req *http.Request, body io.ReadSeeker, response interface{}) error {
+	value := req.Header.Get(api.HeaderRange)
+	var start, end int
+	if _, err := fmt.Sscanf(value, "bytes=%d-%d", &start, &end); err != nil {
+		return errors.Trace(err)
+	}
+	chunk := d.data[start : end+1]
+	sum := sha512.Sum384([]byte(chunk))
+
+	d.mu.Lock()
+	d.count++
+	if d.corrupt[value] {
+		chunk = strings.ToUpper(chunk)
+		d.corrupt[value] = d.always
+	}
+	d.mu.Unlock()
+
+	resp := response.(**http.Response)
+	*resp = &http.Response{
+		StatusCode: http.StatusPartialContent,
+		Body:       ioutil.NopCloser(strings.NewReader(chunk)),
+		Trailer: http.Header{
+			api.HeaderChunkSha384: []string{hex.EncodeToString(sum[:])},
+		},
+	}
+	return nil
+}
@@ -485,6 +485,6 @@ func (st *State) matchingActionsByReceiverAndStatus(tag names.Tag, statusConditi
 // that the collection is smaller than <maxLogsMB> after the
 // deletion.
 func PruneActions(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
-	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, actionsC, "completed", GoTime)
+	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, actionsC, "completed", GoTime, nil)
 	return errors.Trace(err)
 }
@@ -5,9 +5,12 @@ package state
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
 
 	"github.com/juju/errors"
+	jujuos "github.com/juju/os"
+	"github.com/juju/os/series"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/txn"
 
@@ -85,10 +88,19 @@ type MachineTemplate struct {
 	// as unclean for unit-assignment purposes.
 	Dirty bool
 
+	// Spare signifies whether the new machine is added to the model's
+	// pool of spare machines, which are preferred when assigning units
+	// to clean, empty machines.
+	Spare bool
+
 	// Placement holds the placement directive that will be associated
 	// with the machine.
 	Placement string
 
+	// CloudInitUserData holds cloud-init user data specific to the
+	// machine, merged with that of the model when it is provisioned.
+	CloudInitUserData string
+
 	// principals holds the principal units that will
 	// associated with the machine.
 	principals []string
@@ -116,7 +128,11 @@ func (st *State) AddMachineInsideNewMachine(template, parentTemplate MachineTemp
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot add a new machine")
 	}
-	return st.addMachine(mdoc, ops)
+	add := QuotaUsage{
+		Machines:   2,
+		StorageMiB: volumesQuotaMiB(template) + volumesQuotaMiB(parentTemplate),
+	}
+	return st.addMachine(mdoc, ops, add)
 }
 
 // AddMachineInsideMachine adds a machine inside a container of the
@@ -126,7 +142,8 @@ func (st *State) AddMachineInsideMachine(template MachineTemplate, parentId stri
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot add a new machine")
 	}
-	return st.addMachine(mdoc, ops)
+	add := QuotaUsage{Machines: 1, StorageMiB: volumesQuotaMiB(template)}
+	return st.addMachine(mdoc, ops, add)
 }
 
 // AddMachine adds a machine with the given series and jobs.
@@ -159,6 +176,7 @@ func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err er
 	var ms []*Machine
 	var ops []txn.Op
 	var mdocs []*machineDoc
+	add := QuotaUsage{Machines: len(templates)}
 	for _, template := range templates {
 		mdoc, addOps, err := st.addMachineOps(template)
 		if err != nil {
@@ -167,6 +185,7 @@ func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err er
 		mdocs = append(mdocs, mdoc)
 		ms = append(ms, newMachine(st, mdoc))
 		ops = append(ops, addOps...)
+		add.StorageMiB += volumesQuotaMiB(template)
 	}
 	ssOps, err := st.maintainControllersOps(mdocs, nil)
 	if err != nil {
@@ -174,28 +193,43 @@ func (st *State) AddMachines(templates ...MachineTemplate) (_ []*Machine, err er
 	}
 	ops = append(ops, ssOps...)
 	ops = append(ops, assertModelActiveOp(st.ModelUUID()))
-	if err := st.db().RunTransaction(ops); err != nil {
-		if errors.Cause(err) == txn.ErrAborted {
-			if err := checkModelActive(st); err != nil {
-				return nil, errors.Trace(err)
-			}
-		}
+	if err := st.runAddMachinesTxn(ops, add); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return ms, nil
 }
 
-func (st *State) addMachine(mdoc *machineDoc, ops []txn.Op) (*Machine, error) {
+func (st *State) addMachine(mdoc *machineDoc, ops []txn.Op, add QuotaUsage) (*Machine, error) {
 	ops = append([]txn.Op{assertModelActiveOp(st.ModelUUID())}, ops...)
-	if err := st.db().RunTransaction(ops); err != nil {
-		if errors.Cause(err) == txn.ErrAborted {
+	if err := st.runAddMachinesTxn(ops, add); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return newMachine(st, mdoc), nil
+}
+
+// runAddMachinesTxn runs the operations adding machines, along with
+// the operations checking that the machines and their volumes, as
+// described by add, don't take the model over quota. The transaction
+// is only retried if the quotas or their usage changed.
+func (st *State) runAddMachinesTxn(ops []txn.Op, add QuotaUsage) error {
+	var quotaOps []txn.Op
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
 			if err := checkModelActive(st); err != nil {
 				return nil, errors.Trace(err)
 			}
 		}
-		return nil, errors.Trace(err)
+		newQuotaOps, err := st.quotaOps(st.ModelUUID(), add)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if attempt > 0 && reflect.DeepEqual(newQuotaOps, quotaOps) {
+			return nil, txn.ErrAborted
+		}
+		quotaOps = newQuotaOps
+		return append(ops[:len(ops):len(ops)], quotaOps...), nil
 	}
-	return newMachine(st, mdoc), nil
+	return st.db().Run(buildTxn)
 }
 
 func (st *State) resolveMachineConstraints(cons constraints.Value) (constraints.Value, error) {
@@ -251,10 +285,20 @@ func (st *State) effectiveMachineTemplate(p MachineTemplate, allowController boo
 		if !allowController {
 			return tmpl, errControllerNotAllowed
 		}
+		if isWindowsSeries(p.Series) {
+			return tmpl, errors.NotSupportedf("controller machine with series %q", p.Series)
+		}
 	}
 	return p, nil
 }
 
+// isWindowsSeries reports whether the given series is known to be a
+// Windows series.
+func isWindowsSeries(s string) bool {
+	seriesOS, err := series.GetOSFromSeries(s)
+	return err == nil && seriesOS == jujuos.Windows
+}
+
 // addMachineOps returns operations to add a new top level machine
 // based on the given template. It also returns the machine document
 // that will be inserted.
@@ -355,6 +399,10 @@ func (st *State) addMachineInsideMachineOps(template MachineTemplate, parentId s
 	if !parent.supportsContainerType(containerType) {
 		return nil, nil, errors.Errorf("machine %s cannot host %s containers", parentId, containerType)
 	}
+	// Windows machines can neither host containers nor run in them.
+	if isWindowsSeries(parent.Series()) || isWindowsSeries(template.Series) {
+		return nil, nil, errors.NotSupportedf("%s containers with Windows series", containerType)
+	}
 
 	// Ensure that the machine is not locked for series-upgrade.
 	locked, err := parent.IsLockedForSeriesUpgrade()
@@ -413,6 +461,9 @@ func (st *State) addMachineInsideNewMachineOps(template, parentTemplate MachineT
 	if containerType == "" {
 		return nil, nil, errors.New("no container type specified")
 	}
+	if isWindowsSeries(parentTemplate.Series) || isWindowsSeries(template.Series) {
+		return nil, nil, errors.NotSupportedf("%s containers with Windows series", containerType)
+	}
 	if parentTemplate.InstanceId == "" {
 		volumeAttachments, err := st.machineTemplateVolumeAttachmentParams(parentTemplate)
 		if err != nil {
@@ -506,6 +557,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		Series:                  template.Series,
 		Jobs:                    template.Jobs,
 		Clean:                   !template.Dirty,
+		Spare:                   template.Spare && !template.Dirty,
 		Principals:              template.principals,
 		Life:                    Alive,
 		Nonce:                   template.Nonce,
@@ -514,6 +566,7 @@ func (st *State) machineDocForTemplate(template MachineTemplate, id string) *mac
 		PreferredPublicAddress:  fromNetworkAddress(publicAddr, OriginMachine),
 		NoVote:                  template.NoVote,
 		Placement:               template.Placement,
+		CloudInitUserData:       template.CloudInitUserData,
 	}
 }
 
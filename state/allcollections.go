@@ -206,6 +206,23 @@ func allCollections() CollectionSchema {
 			rawAccess: true,
 		},
 
+		// This collection holds the API connections that have logged in
+		// to each of the controller's API servers.
+		apiSessionsC: {
+			global:    true,
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"server"},
+			}},
+		},
+
+		// This collection holds the last time that each user's logins
+		// were revoked.
+		loginRevocationsC: {
+			global:    true,
+			rawAccess: true,
+		},
+
 		// This collection tracks who holds which lease when the store
 		// is managed by raft - so that transactions can still make
 		// assertions about holding the lease.
@@ -465,6 +482,24 @@ func allCollections() CollectionSchema {
 			}},
 		},
 
+		// This collection records the archives of pruned status
+		// history and logs, which are stored in the blobstore.
+		archivesC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "kind", "from"},
+			}},
+		},
+
+		// This collection records the crash reports uploaded by
+		// agents, which are stored in the blobstore.
+		crashReportsC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "time"},
+			}},
+		},
+
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {
 			global:  true,
@@ -514,6 +549,26 @@ func allCollections() CollectionSchema {
 		// eg addresses.
 		cloudServicesC: {},
 
+		// idleResourcesC holds the machines and units that the idle
+		// advisor has found to be idle.
+		idleResourcesC: {},
+
+		// autoscalePoliciesC holds the autoscaling policies of
+		// applications, and the changes the autoscaler has made.
+		autoscalePoliciesC: {},
+
+		// colocationRulesC holds the rules deciding whether the units
+		// of pairs of applications may share machines.
+		colocationRulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "applications"},
+			}},
+		},
+
+		// modelPlansC holds the model plans that have been computed
+		// but not yet applied.
+		modelPlansC: {},
+
 		// ----------------------
 
 		// Raw-access collections
@@ -534,7 +589,10 @@ const (
 	actionresultsC             = "actionresults"
 	actionsC                   = "actions"
 	annotationsC               = "annotations"
+	apiSessionsC               = "apiSessions"
+	archivesC                  = "archives"
 	autocertCacheC             = "autocertCache"
+	autoscalePoliciesC         = "autoscalepolicies"
 	assignUnitC                = "assignUnits"
 	bakeryStorageItemsC        = "bakeryStorageItems"
 	blockDevicesC              = "blockdevices"
@@ -545,11 +603,13 @@ const (
 	cloudsC                    = "clouds"
 	cloudContainersC           = "cloudcontainers"
 	cloudServicesC             = "cloudservices"
+	colocationRulesC           = "colocationrules"
 	cloudCredentialsC          = "cloudCredentials"
 	constraintsC               = "constraints"
 	containerRefsC             = "containerRefs"
 	controllersC               = "controllers"
 	controllerUsersC           = "controllerusers"
+	crashReportsC              = "crashreports"
 	dockerResourcesC           = "dockerResources"
 	filesystemAttachmentsC     = "filesystemAttachments"
 	filesystemsC               = "filesystems"
@@ -558,10 +618,12 @@ const (
 	globalSettingsC            = "globalSettings"
 	guimetadataC               = "guimetadata"
 	guisettingsC               = "guisettings"
+	idleResourcesC             = "idleresources"
 	instanceDataC              = "instanceData"
 	instanceCharmProfileDataC  = "instanceCharmProfileData"
 	leasesC                    = "leases"
 	leaseHoldersC              = "leaseholders"
+	loginRevocationsC          = "loginRevocations"
 	machinesC                  = "machines"
 	machineRemovalsC           = "machineremovals"
 	machineUpgradeSeriesLocksC = "machineUpgradeSeriesLocks"
@@ -577,6 +639,7 @@ const (
 	modelUsersC                = "modelusers"
 	modelsC                    = "models"
 	modelEntityRefsC           = "modelEntityRefs"
+	modelPlansC                = "modelplans"
 	openedPortsC               = "openedPorts"
 	payloadsC                  = "payloads"
 	permissionsC               = "permissions"
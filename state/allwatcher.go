@@ -1368,7 +1368,7 @@ func newAllWatcherStateBacking(st *State, params WatchParams) Backing {
 	collections := makeAllWatcherCollectionInfo(collectionNames...)
 	return &allWatcherStateBacking{
 		st:               st,
-		watcher:          st.workers.txnLogWatcher(),
+		watcher:          st.txnLogWatcher(),
 		collectionByName: collections,
 	}
 }
@@ -1450,7 +1450,7 @@ func NewAllModelWatcherStateBacking(st *State, pool *StatePool) Backing {
 	)
 	return &allModelWatcherStateBacking{
 		st:               st,
-		watcher:          st.workers.txnLogWatcher(),
+		watcher:          st.txnLogWatcher(),
 		stPool:           pool,
 		collectionByName: collections,
 	}
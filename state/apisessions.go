@@ -0,0 +1,185 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// APISession describes an API connection that has logged in to one of
+// the controller's API servers.
+type APISession struct {
+	// Server is the machine ID of the API server.
+	Server string
+
+	// ConnectionID is the identifier given to the connection by the
+	// API server. It is unique to that API server.
+	ConnectionID uint64
+
+	// Tag is the tag of the user or agent that logged in.
+	Tag string
+
+	// ModelUUID is the UUID of the model the connection is for, or
+	// empty for a connection to the controller only.
+	ModelUUID string
+
+	// RemoteAddr is the address the connection is from.
+	RemoteAddr string
+
+	// LoggedIn is when the connection logged in.
+	LoggedIn time.Time
+}
+
+// apiSessionDoc records an API connection that has logged in. The
+// documents are not written using mgo/txn, and they must never appear
+// in any transaction.
+type apiSessionDoc struct {
+	DocID        string    `bson:"_id"`
+	Server       string    `bson:"server"`
+	ConnectionID int64     `bson:"connection-id"`
+	Tag          string    `bson:"tag"`
+	ModelUUID    string    `bson:"model-uuid,omitempty"`
+	RemoteAddr   string    `bson:"remote-addr"`
+	LoggedIn     time.Time `bson:"logged-in"`
+}
+
+func apiSessionDocID(server string, connectionID uint64) string {
+	return fmt.Sprintf("%s#%d", server, connectionID)
+}
+
+func (doc apiSessionDoc) session() APISession {
+	return APISession{
+		Server:       doc.Server,
+		ConnectionID: uint64(doc.ConnectionID),
+		Tag:          doc.Tag,
+		ModelUUID:    doc.ModelUUID,
+		RemoteAddr:   doc.RemoteAddr,
+		LoggedIn:     doc.LoggedIn.UTC(),
+	}
+}
+
+// AddAPISession records an API connection that has logged in.
+func (st *State) AddAPISession(session APISession) error {
+	sessions, closer := st.db().GetCollection(apiSessionsC)
+	defer closer()
+
+	sessionsW := sessions.Writeable()
+	// Sessions are recorded for every login, so as for the last login
+	// times, don't wait for the write to reach a majority or the disk.
+	sessionsW.Underlying().Database.Session.SetSafe(&mgo.Safe{})
+
+	doc := apiSessionDoc{
+		DocID:        apiSessionDocID(session.Server, session.ConnectionID),
+		Server:       session.Server,
+		ConnectionID: int64(session.ConnectionID),
+		Tag:          session.Tag,
+		ModelUUID:    session.ModelUUID,
+		RemoteAddr:   session.RemoteAddr,
+		LoggedIn:     session.LoggedIn.UTC(),
+	}
+	_, err := sessionsW.UpsertId(doc.DocID, doc)
+	return errors.Trace(err)
+}
+
+// RemoveAPISession forgets the API connection with the given ID to
+// the given API server, if it is recorded.
+func (st *State) RemoveAPISession(server string, connectionID uint64) error {
+	sessions, closer := st.db().GetCollection(apiSessionsC)
+	defer closer()
+
+	sessionsW := sessions.Writeable()
+	sessionsW.Underlying().Database.Session.SetSafe(&mgo.Safe{})
+	err := sessionsW.RemoveId(apiSessionDocID(server, connectionID))
+	if err != nil && err != mgo.ErrNotFound {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// RemoveAPISessions forgets all the API connections to the given API
+// server. It is called when the API server starts, to remove the
+// connections left behind when it last stopped.
+func (st *State) RemoveAPISessions(server string) error {
+	sessions, closer := st.db().GetCollection(apiSessionsC)
+	defer closer()
+
+	_, err := sessions.Writeable().RemoveAll(bson.D{{"server", server}})
+	return errors.Trace(err)
+}
+
+// APISessions returns the API connections that have logged in to any
+// of the controller's API servers, ordered by server and connection ID.
+func (st *State) APISessions() ([]APISession, error) {
+	sessions, closer := st.db().GetRawCollection(apiSessionsC)
+	defer closer()
+
+	var docs []apiSessionDoc
+	if err := sessions.Find(nil).Sort("server", "connection-id").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]APISession, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.session()
+	}
+	return result, nil
+}
+
+// APISession returns the API connection with the given ID to the given
+// API server.
+func (st *State) APISession(server string, connectionID uint64) (APISession, error) {
+	sessions, closer := st.db().GetRawCollection(apiSessionsC)
+	defer closer()
+
+	var doc apiSessionDoc
+	err := sessions.FindId(apiSessionDocID(server, connectionID)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return APISession{}, errors.NotFoundf("API connection %d to server %q", connectionID, server)
+	} else if err != nil {
+		return APISession{}, errors.Trace(err)
+	}
+	return doc.session(), nil
+}
+
+// loginRevocationDoc records when a user's logins were last revoked.
+type loginRevocationDoc struct {
+	User      string    `bson:"_id"`
+	RevokedAt time.Time `bson:"revoked-at"`
+}
+
+// RevokeLogins revokes the credentials that the user has been given
+// by the controller to log in without a password. The user must
+// authenticate again to log in.
+func (st *State) RevokeLogins(user names.UserTag) error {
+	revocations, closer := st.db().GetCollection(loginRevocationsC)
+	defer closer()
+
+	doc := loginRevocationDoc{
+		User:      user.Id(),
+		RevokedAt: st.clock().Now().UTC(),
+	}
+	_, err := revocations.Writeable().UpsertId(doc.User, doc)
+	return errors.Annotatef(err, "cannot revoke logins for %q", user.Id())
+}
+
+// LoginsRevokedAt returns when the user's logins were last revoked,
+// or the zero time if they never have been.
+func (st *State) LoginsRevokedAt(user names.UserTag) (time.Time, error) {
+	revocations, closer := st.db().GetRawCollection(loginRevocationsC)
+	defer closer()
+
+	var doc loginRevocationDoc
+	err := revocations.FindId(user.Id()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return time.Time{}, nil
+	} else if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return doc.RevokedAt.UTC(), nil
+}
@@ -0,0 +1,96 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type APISessionsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&APISessionsSuite{})
+
+func (s *APISessionsSuite) session(server string, id uint64) state.APISession {
+	return state.APISession{
+		Server:       server,
+		ConnectionID: id,
+		Tag:          "user-bob",
+		ModelUUID:    s.State.ModelUUID(),
+		RemoteAddr:   "10.0.0.1:54321",
+		LoggedIn:     time.Date(2019, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func (s *APISessionsSuite) TestAddAndRemove(c *gc.C) {
+	for _, session := range []state.APISession{
+		s.session("1", 7),
+		s.session("0", 3),
+		s.session("0", 1),
+	} {
+		c.Assert(s.State.AddAPISession(session), jc.ErrorIsNil)
+	}
+	err := s.State.RemoveAPISession("0", 3)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveAPISession("0", 42)
+	c.Assert(err, jc.ErrorIsNil)
+
+	sessions, err := s.State.APISessions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sessions, jc.DeepEquals, []state.APISession{
+		s.session("0", 1),
+		s.session("1", 7),
+	})
+
+	session, err := s.State.APISession("1", 7)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(session, jc.DeepEquals, s.session("1", 7))
+	_, err = s.State.APISession("0", 3)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *APISessionsSuite) TestRemoveAPISessions(c *gc.C) {
+	c.Assert(s.State.AddAPISession(s.session("0", 1)), jc.ErrorIsNil)
+	c.Assert(s.State.AddAPISession(s.session("0", 2)), jc.ErrorIsNil)
+	c.Assert(s.State.AddAPISession(s.session("1", 1)), jc.ErrorIsNil)
+
+	err := s.State.RemoveAPISessions("0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	sessions, err := s.State.APISessions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sessions, jc.DeepEquals, []state.APISession{s.session("1", 1)})
+}
+
+func (s *APISessionsSuite) TestRevokeLogins(c *gc.C) {
+	bob := names.NewUserTag("bob")
+	revokedAt, err := s.State.LoginsRevokedAt(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revokedAt.IsZero(), jc.IsTrue)
+
+	err = s.State.RevokeLogins(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	first, err := s.State.LoginsRevokedAt(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(first.IsZero(), jc.IsFalse)
+
+	s.Clock.Advance(time.Hour)
+	err = s.State.RevokeLogins(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	second, err := s.State.LoginsRevokedAt(bob)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(second.After(first), jc.IsTrue)
+
+	other, err := s.State.LoginsRevokedAt(names.NewUserTag("mary@external"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(other.IsZero(), jc.IsTrue)
+}
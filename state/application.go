@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"reflect"
 	"sort"
 	"strconv"
 	"strings"
@@ -69,6 +70,22 @@ type applicationDoc struct {
 	PasswordHash string `bson:"passwordhash"`
 	// Placement is the placement directive that should be used allocating units/pods.
 	Placement string `bson:"placement,omitempty"`
+
+	// RefreshPolicy determines how the application follows new
+	// revisions of its charm; see RefreshPolicy.
+	RefreshPolicy *refreshPolicyDoc `bson:"refresh-policy,omitempty"`
+
+	// Paused is true if hook execution is paused for all of the
+	// application's units.
+	Paused bool `bson:"paused,omitempty"`
+
+	// LoadBalanced is true if exposing the application also provisions
+	// a provider load balancer in front of its units.
+	LoadBalanced bool `bson:"load-balanced,omitempty"`
+
+	// LoadBalancerAddresses holds the addresses of the application's
+	// load balancer, once the provider has provisioned it.
+	LoadBalancerAddresses []address `bson:"load-balancer-addresses,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -232,6 +249,11 @@ type DestroyApplicationOperation struct {
 	// then detachable storage will be detached and left in the model.
 	DestroyStorage bool
 
+	// StorageDisposition maps the IDs of storage instances attached
+	// to units of the application to what should happen to them,
+	// overriding DestroyStorage for those instances.
+	StorageDisposition map[string]StorageDisposition
+
 	// RemoveOffers controls whether or not application offers
 	// are removed. If this is false, then the operation will
 	// fail if there are any offers remaining.
@@ -403,8 +425,7 @@ func (op *DestroyApplicationOperation) destroyOps() ([]txn.Op, error) {
 		cleanupOp := newCleanupOp(
 			cleanupUnitsForDyingApplication,
 			op.app.doc.Name,
-			op.DestroyStorage,
-			op.Force,
+			op.DestroyStorage, op.Force, op.StorageDisposition, op.MaxWait,
 		)
 		ops = append(ops, cleanupOp)
 		notLastRefs = append(notLastRefs, bson.D{{"unitcount", bson.D{{"$gt", 0}}}}...)
@@ -467,6 +488,16 @@ func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op,
 	}
 	ops = append(ops, removeOfferOps...)
 
+	// Remove co-location rules.
+	removeColocationOps, err := removeColocationRulesOps(a.st, a.doc.Name)
+	if err != nil {
+		if !op.Force {
+			return nil, errors.Trace(err)
+		}
+		op.AddError(err)
+	}
+	ops = append(ops, removeColocationOps...)
+
 	// Note that appCharmDecRefOps might not catch the final decref
 	// when run in a transaction that decrefs more than once. So we
 	// avoid attempting to do the final cleanup in the ref dec ops and
@@ -500,6 +531,7 @@ func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op,
 		removeSettingsOp(settingsC, a.applicationConfigKey()),
 		removeModelApplicationRefOp(a.st, name),
 		removePodSpecOp(a.ApplicationTag()),
+		removeAutoscalePolicyOp(a.st, name),
 	)
 	return ops, nil
 }
@@ -514,26 +546,77 @@ func (a *Application) IsExposed() bool {
 // SetExposed marks the application as exposed.
 // See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
-	return a.setExposed(true)
+	return a.setExposed(true, bson.D{{"exposed", true}})
 }
 
-// ClearExposed removes the exposed flag from the application.
+// SetExposedWithLoadBalancer marks the application as exposed through a
+// provider load balancer, which is provisioned in front of its units.
+// See ClearExposed, IsExposed and IsLoadBalanced.
+func (a *Application) SetExposedWithLoadBalancer() error {
+	return a.setExposed(true, bson.D{{"exposed", true}, {"load-balanced", true}})
+}
+
+// ClearExposed removes the exposed flag from the application, along
+// with any request for a load balancer.
 // See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
-	return a.setExposed(false)
+	return a.setExposed(false, bson.D{{"exposed", false}, {"load-balanced", false}})
 }
 
-func (a *Application) setExposed(exposed bool) (err error) {
+func (a *Application) setExposed(exposed bool, fields bson.D) (err error) {
 	ops := []txn.Op{{
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: bson.D{{"$set", fields}},
 	}}
 	if err := a.st.db().RunTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, applicationNotAliveErr))
 	}
 	a.doc.Exposed = exposed
+	for _, field := range fields {
+		if field.Name == "load-balanced" {
+			a.doc.LoadBalanced = field.Value.(bool)
+		}
+	}
+	return nil
+}
+
+// IsLoadBalanced returns whether exposing the application provisions a
+// provider load balancer in front of its units. See
+// SetExposedWithLoadBalancer.
+func (a *Application) IsLoadBalanced() bool {
+	return a.doc.LoadBalanced
+}
+
+// LoadBalancerAddresses returns the addresses of the application's
+// load balancer, if one has been provisioned.
+func (a *Application) LoadBalancerAddresses() []network.Address {
+	return networkAddresses(a.doc.LoadBalancerAddresses)
+}
+
+// SetLoadBalancerAddresses records the addresses of the application's
+// load balancer. The firewaller records them once the provider has
+// provisioned the load balancer, and clears them once it has been
+// removed, so they may be set while the application is dying.
+func (a *Application) SetLoadBalancerAddresses(addrs []network.Address) error {
+	stateAddrs := fromNetworkAddresses(addrs, OriginProvider)
+	var update bson.D
+	if len(stateAddrs) == 0 {
+		update = bson.D{{"$unset", bson.D{{"load-balancer-addresses", nil}}}}
+	} else {
+		update = bson.D{{"$set", bson.D{{"load-balancer-addresses", stateAddrs}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Annotatef(onAbort(err, errors.NotFoundf("application %q", a)), "cannot set load balancer addresses of application %q", a)
+	}
+	a.doc.LoadBalancerAddresses = stateAddrs
 	return nil
 }
 
@@ -1177,6 +1260,37 @@ type SetCharmConfig struct {
 	StorageConstraints map[string]StorageConstraints
 }
 
+// CheckCharmCompatibility returns an error if the application's charm
+// cannot be changed to the given charm without changing its subordinacy,
+// breaking its relations or losing its storage. SetCharm makes the same
+// checks; CheckCharmCompatibility allows them to be made before anything
+// else, such as the application's resources, is changed.
+func (a *Application) CheckCharmCompatibility(ch *Charm) (err error) {
+	defer errors.DeferredAnnotatef(
+		&err, "cannot upgrade application %q to charm %q", a, ch,
+	)
+	if ch.Meta().Subordinate != a.doc.Subordinate {
+		return errors.Errorf("cannot change an application's subordinacy")
+	}
+	oldCharm, _, err := a.Charm()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := a.checkStorageUpgrade(ch.Meta(), oldCharm.Meta(), units); err != nil {
+		return errors.Trace(err)
+	}
+	relations, err := a.Relations()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = a.checkRelationsOps(ch, relations)
+	return errors.Trace(err)
+}
+
 // SetCharm changes the charm for the application.
 func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	defer errors.DeferredAnnotatef(
@@ -1478,7 +1592,11 @@ func (a *Application) ChangeScale(scaleChange int) (int, error) {
 				return nil, errors.NotValidf("cannot remove more units than currently exist")
 			}
 		}
-		return []txn.Op{{
+		quotaOps, err := a.scaleQuotaOps(newScale)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append([]txn.Op{{
 			C:  applicationsC,
 			Id: a.doc.DocID,
 			Assert: bson.D{{"life", Alive},
@@ -1486,10 +1604,10 @@ func (a *Application) ChangeScale(scaleChange int) (int, error) {
 				{"unitcount", a.doc.UnitCount},
 				{"scale", a.doc.DesiredScale}},
 			Update: bson.D{{"$set", bson.D{{"scale", newScale}}}},
-		}}, nil
+		}}, quotaOps...), nil
 	}
 	if err := a.st.db().Run(buildTxn); err != nil {
-		return a.doc.DesiredScale, errors.Errorf("cannot set scale for application %q to %v: %v", a, newScale, onAbort(err, applicationNotAliveErr))
+		return a.doc.DesiredScale, errors.Annotatef(onAbort(err, applicationNotAliveErr), "cannot set scale for application %q to %v", a, newScale)
 	}
 	a.doc.DesiredScale = newScale
 	return newScale, nil
@@ -1513,22 +1631,39 @@ func (a *Application) SetScale(scale int) error {
 				return nil, errors.Trace(err)
 			}
 		}
-		return []txn.Op{{
+		quotaOps, err := a.scaleQuotaOps(scale)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append([]txn.Op{{
 			C:  applicationsC,
 			Id: a.doc.DocID,
 			Assert: bson.D{{"life", Alive},
 				{"charmurl", a.doc.CharmURL},
-				{"unitcount", a.doc.UnitCount}},
+				{"unitcount", a.doc.UnitCount},
+				{"scale", a.doc.DesiredScale}},
 			Update: bson.D{{"$set", bson.D{{"scale", scale}}}},
-		}}, nil
+		}}, quotaOps...), nil
 	}
 	if err := a.st.db().Run(buildTxn); err != nil {
-		return errors.Errorf("cannot set scale for application %q to %v: %v", a, scale, onAbort(err, applicationNotAliveErr))
+		return errors.Annotatef(onAbort(err, applicationNotAliveErr), "cannot set scale for application %q to %v", a, scale)
 	}
 	a.doc.DesiredScale = scale
 	return nil
 }
 
+// scaleQuotaOps returns the operations checking that scaling the
+// application doesn't take the model over its units quota. Units are
+// counted against quotas by the greater of the application's scale and
+// its unit count, so only scaling beyond both adds to the usage.
+func (a *Application) scaleQuotaOps(scale int) ([]txn.Op, error) {
+	counted := applicationQuotaUnits(a.doc.UnitCount, a.doc.DesiredScale)
+	if scale <= counted {
+		return nil, nil
+	}
+	return a.st.quotaOps(a.st.ModelUUID(), QuotaUsage{Units: scale - counted})
+}
+
 // newUnitName returns the next unit name.
 func (a *Application) newUnitName() (string, error) {
 	unitSeq, err := sequence(a.st, a.Tag().String())
@@ -1927,14 +2062,40 @@ func (a *Application) AddUnit(args AddUnitParams) (unit *Unit, err error) {
 		return nil, err
 	}
 
-	if err := a.st.db().RunTransaction(ops); err == txn.ErrAborted {
-		if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
+	// The unit, and its storage, are counted against quotas on IAAS
+	// models. CAAS units are counted when the application is scaled.
+	m, err := a.st.Model()
+	if err != nil {
+		return nil, err
+	}
+	var add QuotaUsage
+	if m.Type() == ModelTypeIAAS {
+		cons, err := a.StorageConstraints()
+		if err != nil {
 			return nil, err
-		} else if !alive {
-			return nil, applicationNotAliveErr
 		}
-		return nil, errors.New("inconsistent state")
-	} else if err != nil {
+		add = QuotaUsage{Units: 1, StorageMiB: storageQuotaMiB(cons)}
+	}
+	var quotaOps []txn.Op
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		newQuotaOps, err := a.st.quotaOps(a.st.ModelUUID(), add)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if attempt > 0 {
+			if alive, err := isAlive(a.st, applicationsC, a.doc.DocID); err != nil {
+				return nil, err
+			} else if !alive {
+				return nil, applicationNotAliveErr
+			}
+			if reflect.DeepEqual(newQuotaOps, quotaOps) {
+				return nil, errors.New("inconsistent state")
+			}
+		}
+		quotaOps = newQuotaOps
+		return append(ops[:len(ops):len(ops)], quotaOps...), nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
 		return nil, err
 	}
 	return a.st.Unit(name)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
 	"gopkg.in/juju/environschema.v1"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/mgo.v2/bson"
@@ -537,6 +539,43 @@ func (s *ApplicationSuite) TestSetCharmChecksEndpointsWithRelations(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "myrequirer" to charm "local:quantal/quantal-mysql-4": would break relation "myrequirer:kludge myprovider:kludge"`)
 }
 
+func (s *ApplicationSuite) TestCheckCharmCompatibility(c *gc.C) {
+	providerCharm := s.AddMetaCharm(c, "mysql", metaDifferentProvider, 2)
+	providerApp := s.AddTestingApplication(c, "myprovider", providerCharm)
+	requirerCharm := s.AddMetaCharm(c, "mysql", metaDifferentRequirer, 3)
+	s.AddTestingApplication(c, "myrequirer", requirerCharm)
+	eps, err := s.State.InferEndpoints("myprovider:kludge", "myrequirer:kludge")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = providerApp.CheckCharmCompatibility(providerCharm)
+	c.Assert(err, jc.ErrorIsNil)
+
+	baseCharm := s.AddMetaCharm(c, "mysql", metaBase, 4)
+	err = providerApp.CheckCharmCompatibility(baseCharm)
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "myprovider" to charm "local:quantal/quantal-mysql-4": would break relation "myrequirer:kludge myprovider:kludge"`)
+
+	logging := s.AddTestingCharm(c, "logging")
+	err = providerApp.CheckCharmCompatibility(logging)
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "myprovider" to charm "local:quantal/quantal-logging-1": cannot change an application's subordinacy`)
+
+	// Nothing has changed.
+	err = providerApp.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := providerApp.CharmURL()
+	c.Assert(curl, gc.DeepEquals, providerCharm.URL())
+}
+
+func (s *ApplicationSuite) TestCheckCharmCompatibilityRequiredStorageRemoved(c *gc.C) {
+	oldCh := s.AddMetaCharm(c, "mysql", mysqlBaseMeta+oneRequiredStorageMeta, 2)
+	newCh := s.AddMetaCharm(c, "mysql", mysqlBaseMeta, 3)
+	app := s.AddTestingApplication(c, "test", oldCh)
+
+	err := app.CheckCharmCompatibility(newCh)
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "test" to charm "local:quantal/quantal-mysql-3": required storage "data0" removed`)
+}
+
 var stringConfig = `
 options:
   key: {default: My Key, description: Desc, type: string}
@@ -1981,6 +2020,81 @@ func (s *ApplicationSuite) TestApplicationExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestApplicationExposedWithLoadBalancer(c *gc.C) {
+	c.Assert(s.mysql.IsLoadBalanced(), jc.IsFalse)
+
+	err := s.mysql.SetExposedWithLoadBalancer()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.IsLoadBalanced(), jc.IsTrue)
+
+	// Exposing again without a load balancer keeps the load balancer.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsLoadBalanced(), jc.IsTrue)
+
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+	c.Assert(s.mysql.IsLoadBalanced(), jc.IsFalse)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsLoadBalanced(), jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestSetLoadBalancerAddresses(c *gc.C) {
+	c.Assert(s.mysql.LoadBalancerAddresses(), gc.HasLen, 0)
+
+	addrs := network.NewAddresses("203.0.113.10")
+	err := s.mysql.SetLoadBalancerAddresses(addrs)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.LoadBalancerAddresses(), jc.DeepEquals, addrs)
+
+	err = s.mysql.SetLoadBalancerAddresses(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.LoadBalancerAddresses(), gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestRefreshPolicy(c *gc.C) {
+	c.Assert(s.mysql.RefreshPolicy(), jc.DeepEquals, state.RefreshPolicy{})
+	c.Assert(s.mysql.TrackedChannel(), gc.Equals, s.mysql.Channel())
+
+	policy := state.RefreshPolicy{
+		Channel:     "candidate",
+		Auto:        true,
+		WindowStart: 22 * time.Hour,
+		WindowEnd:   2 * time.Hour,
+	}
+	err := s.mysql.SetRefreshPolicy(policy)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.RefreshPolicy(), jc.DeepEquals, policy)
+	c.Assert(s.mysql.TrackedChannel(), gc.Equals, csparams.Channel("candidate"))
+
+	app, err := s.State.Application(s.mysql.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.RefreshPolicy(), jc.DeepEquals, policy)
+}
+
+func (s *ApplicationSuite) TestSetRefreshPolicyInvalidWindow(c *gc.C) {
+	err := s.mysql.SetRefreshPolicy(state.RefreshPolicy{WindowEnd: 25 * time.Hour})
+	c.Assert(err, gc.ErrorMatches, `cannot set refresh policy for application "mysql": refresh window time 25h0m0s \(must be within a day\) not valid`)
+}
+
+func (s *ApplicationSuite) TestSetRefreshPolicyNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetRefreshPolicy(state.RefreshPolicy{Hold: true})
+	c.Assert(err, gc.ErrorMatches, notAliveErr)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	c.Assert(s.mysql.UnitCount(), gc.Equals, 0)
@@ -0,0 +1,490 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state/storage"
+)
+
+// ArchiveKind identifies the kind of records held by an archive.
+type ArchiveKind string
+
+const (
+	// ArchiveStatusHistory is the kind of the archives holding
+	// pruned status history.
+	ArchiveStatusHistory ArchiveKind = "status-history"
+
+	// ArchiveLogs is the kind of the archives holding pruned logs.
+	ArchiveLogs ArchiveKind = "logs"
+)
+
+// archiveBatchSize is the maximum number of records written to a
+// single archive.
+const archiveBatchSize = 10000
+
+// archiveDoc records an archive of pruned records. The records are
+// stored in the blobstore bucket of the model, as gzip-compressed
+// JSON, one record per line.
+type archiveDoc struct {
+	DocID     string      `bson:"_id"`
+	ModelUUID string      `bson:"model-uuid"`
+	Kind      ArchiveKind `bson:"kind"`
+	Path      string      `bson:"path"`
+
+	// From and To are the times, in unix nanoseconds, of the
+	// oldest and newest records in the archive.
+	From int64 `bson:"from"`
+	To   int64 `bson:"to"`
+
+	Count   int   `bson:"count"`
+	Size    int64 `bson:"size"`
+	Created int64 `bson:"created"`
+}
+
+// ArchiveInfo describes an archive of pruned records.
+type ArchiveInfo struct {
+	Kind ArchiveKind
+
+	// From and To are the times of the oldest and newest
+	// records in the archive.
+	From time.Time
+	To   time.Time
+
+	// Count is the number of records in the archive.
+	Count int
+
+	// Size is the compressed size of the archive, in bytes.
+	Size int64
+
+	// Created is the time the archive was written.
+	Created time.Time
+}
+
+// archivedStatus is the form of a status history entry in an archive.
+type archivedStatus struct {
+	GlobalKey string                 `json:"globalkey"`
+	Status    status.Status          `json:"status"`
+	Message   string                 `json:"message,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Updated   int64                  `json:"updated"`
+}
+
+// archivedLog is the form of a log record in an archive.
+type archivedLog struct {
+	Time     int64             `json:"t"`
+	Entity   string            `json:"entity"`
+	Version  string            `json:"version,omitempty"`
+	Module   string            `json:"module"`
+	Location string            `json:"location"`
+	Level    int               `json:"level"`
+	Message  string            `json:"message"`
+	Labels   map[string]string `json:"labels,omitempty"`
+}
+
+// archiveRecord is a record to be added to an archive of the model
+// with the given UUID; time is the time of the record, in unix
+// nanoseconds.
+type archiveRecord struct {
+	modelUUID string
+	time      int64
+	value     interface{}
+}
+
+// archiveRecordFunc converts a document being pruned into the record
+// to archive.
+type archiveRecordFunc func(doc bson.Raw) (archiveRecord, error)
+
+// statusHistoryArchiveRecord converts a status history document
+// into an archive record.
+func statusHistoryArchiveRecord(raw bson.Raw) (archiveRecord, error) {
+	var doc historicalStatusDoc
+	if err := raw.Unmarshal(&doc); err != nil {
+		return archiveRecord{}, errors.Trace(err)
+	}
+	return archiveRecord{
+		modelUUID: doc.ModelUUID,
+		time:      doc.Updated,
+		value: archivedStatus{
+			GlobalKey: doc.GlobalKey,
+			Status:    doc.Status,
+			Message:   doc.StatusInfo,
+			Data:      utils.UnescapeKeys(doc.StatusData),
+			Updated:   doc.Updated,
+		},
+	}, nil
+}
+
+// logArchiveRecord returns a function converting the log documents of
+// the model with the given UUID into archive records.
+func logArchiveRecord(modelUUID string) archiveRecordFunc {
+	return func(raw bson.Raw) (archiveRecord, error) {
+		var doc logDoc
+		if err := raw.Unmarshal(&doc); err != nil {
+			return archiveRecord{}, errors.Trace(err)
+		}
+		return archiveRecord{
+			modelUUID: modelUUID,
+			time:      doc.Time,
+			value: archivedLog{
+				Time:     doc.Time,
+				Entity:   doc.Entity,
+				Version:  doc.Version,
+				Module:   doc.Module,
+				Location: doc.Location,
+				Level:    doc.Level,
+				Message:  doc.Message,
+				Labels:   doc.Labels,
+			},
+		}, nil
+	}
+}
+
+// historyArchiver accumulates records as they are pruned, and writes
+// them to archives in the blobstore buckets of their models.
+type historyArchiver struct {
+	session  *mgo.Session
+	archives *mgo.Collection
+	clock    clock.Clock
+	kind     ArchiveKind
+	record   archiveRecordFunc
+	pending  map[string]*pendingArchive
+}
+
+// pendingArchive holds the records of an archive being written.
+type pendingArchive struct {
+	buf   bytes.Buffer
+	gzw   *gzip.Writer
+	enc   *json.Encoder
+	from  int64
+	to    int64
+	count int
+}
+
+// newHistoryArchiver returns a historyArchiver which writes archives
+// of the given kind, recording them in the given raw archives
+// collection.
+func newHistoryArchiver(
+	session *mgo.Session,
+	archives *mgo.Collection,
+	clock clock.Clock,
+	kind ArchiveKind,
+	record archiveRecordFunc,
+) *historyArchiver {
+	return &historyArchiver{
+		session:  session,
+		archives: archives,
+		clock:    clock,
+		kind:     kind,
+		record:   record,
+		pending:  make(map[string]*pendingArchive),
+	}
+}
+
+// add adds the given document to the archive of its model.
+func (a *historyArchiver) add(doc bson.Raw) error {
+	rec, err := a.record(doc)
+	if err != nil {
+		return errors.Annotate(err, "reading record to archive")
+	}
+	p, ok := a.pending[rec.modelUUID]
+	if !ok {
+		p = &pendingArchive{from: rec.time, to: rec.time}
+		p.gzw = gzip.NewWriter(&p.buf)
+		p.enc = json.NewEncoder(p.gzw)
+		a.pending[rec.modelUUID] = p
+	}
+	if err := p.enc.Encode(rec.value); err != nil {
+		return errors.Annotate(err, "encoding record to archive")
+	}
+	if rec.time < p.from {
+		p.from = rec.time
+	}
+	if rec.time > p.to {
+		p.to = rec.time
+	}
+	p.count++
+	return nil
+}
+
+// flush writes the records added since the last flush to archives,
+// one for each model.
+func (a *historyArchiver) flush() error {
+	modelUUIDs := make([]string, 0, len(a.pending))
+	for modelUUID := range a.pending {
+		modelUUIDs = append(modelUUIDs, modelUUID)
+	}
+	sort.Strings(modelUUIDs)
+	for _, modelUUID := range modelUUIDs {
+		if err := a.write(modelUUID, a.pending[modelUUID]); err != nil {
+			return errors.Trace(err)
+		}
+		delete(a.pending, modelUUID)
+	}
+	return nil
+}
+
+func (a *historyArchiver) write(modelUUID string, p *pendingArchive) error {
+	if err := p.gzw.Close(); err != nil {
+		return errors.Annotate(err, "compressing archive")
+	}
+	id := bson.NewObjectId().Hex()
+	path := fmt.Sprintf("archives/%s/%s.jsonl.gz", a.kind, id)
+	size := int64(p.buf.Len())
+	stor := storage.NewStorage(modelUUID, a.session)
+	if err := stor.Put(path, &p.buf, size); err != nil {
+		return errors.Annotatef(err, "storing archive %q", path)
+	}
+	err := a.archives.Insert(&archiveDoc{
+		DocID:     ensureModelUUID(modelUUID, id),
+		ModelUUID: modelUUID,
+		Kind:      a.kind,
+		Path:      path,
+		From:      p.from,
+		To:        p.to,
+		Count:     p.count,
+		Size:      size,
+		Created:   a.clock.Now().UnixNano(),
+	})
+	if err != nil {
+		// Without its metadata the archive can never be found,
+		// so don't leave it behind.
+		if err := stor.Remove(path); err != nil {
+			logger.Warningf("cannot remove unrecorded archive %q: %v", path, err)
+		}
+		return errors.Annotatef(err, "recording archive %q", path)
+	}
+	logger.Debugf("archived %d %s records of model %s to %q", p.count, a.kind, modelUUID, path)
+	return nil
+}
+
+// Archives returns information about the model's archives of the given
+// kind which hold records from between from and to, oldest first. Zero
+// times leave the range unbounded.
+func (st *State) Archives(kind ArchiveKind, from, to time.Time) ([]ArchiveInfo, error) {
+	docs, err := st.findArchives(kind, from, to)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	infos := make([]ArchiveInfo, len(docs))
+	for i, doc := range docs {
+		infos[i] = ArchiveInfo{
+			Kind:    doc.Kind,
+			From:    time.Unix(0, doc.From).UTC(),
+			To:      time.Unix(0, doc.To).UTC(),
+			Count:   doc.Count,
+			Size:    doc.Size,
+			Created: time.Unix(0, doc.Created).UTC(),
+		}
+	}
+	return infos, nil
+}
+
+// ArchivedStatusHistory returns the status history of the entity with
+// the given tag which was archived when it was pruned, recorded between
+// from and to, oldest first. Zero times leave the range unbounded. The
+// kind selects the history of units and machines, as for the status
+// history still held by the model; models and applications have only
+// one status history, and ignore it.
+func (st *State) ArchivedStatusHistory(kind status.HistoryKind, tag names.Tag, from, to time.Time) ([]status.StatusInfo, error) {
+	globalKeys, err := archivedStatusGlobalKeys(kind, tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	docs, err := st.findArchives(ArchiveStatusHistory, from, to)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var history []status.StatusInfo
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	for _, doc := range docs {
+		err := readArchive(stor, doc.Path, func(dec *json.Decoder) error {
+			var entry archivedStatus
+			if err := dec.Decode(&entry); err != nil {
+				return errors.Trace(err)
+			}
+			if !globalKeys.Contains(entry.GlobalKey) || !inTimeRange(entry.Updated, from, to) {
+				return nil
+			}
+			history = append(history, status.StatusInfo{
+				Status:  entry.Status,
+				Message: entry.Message,
+				Data:    entry.Data,
+				Since:   unixNanoToTime(entry.Updated),
+			})
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	// Archives may overlap, if pruning by size and
+	// age archived records of the same time.
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Since.Before(*history[j].Since)
+	})
+	return history, nil
+}
+
+// ArchivedLogs returns the log records of the model which were archived
+// when they were pruned, recorded between from and to, oldest first.
+// Zero times leave the range unbounded.
+func (st *State) ArchivedLogs(from, to time.Time) ([]*LogRecord, error) {
+	docs, err := st.findArchives(ArchiveLogs, from, to)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var records []*LogRecord
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	for _, doc := range docs {
+		err := readArchive(stor, doc.Path, func(dec *json.Decoder) error {
+			var entry archivedLog
+			if err := dec.Decode(&entry); err != nil {
+				return errors.Trace(err)
+			}
+			if !inTimeRange(entry.Time, from, to) {
+				return nil
+			}
+			rec, err := logDocToRecord(st.ModelUUID(), &logDoc{
+				Time:     entry.Time,
+				Entity:   entry.Entity,
+				Version:  entry.Version,
+				Module:   entry.Module,
+				Location: entry.Location,
+				Level:    entry.Level,
+				Message:  entry.Message,
+				Labels:   entry.Labels,
+			})
+			if err != nil {
+				return errors.Trace(err)
+			}
+			records = append(records, rec)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+	return records, nil
+}
+
+// findArchives returns the model's archives of the given kind which
+// hold records from between from and to, oldest first.
+func (st *State) findArchives(kind ArchiveKind, from, to time.Time) ([]archiveDoc, error) {
+	archives, closer := st.db().GetCollection(archivesC)
+	defer closer()
+
+	query := bson.D{{"kind", kind}}
+	if !from.IsZero() {
+		query = append(query, bson.DocElem{"to", bson.M{"$gte": from.UnixNano()}})
+	}
+	if !to.IsZero() {
+		query = append(query, bson.DocElem{"from", bson.M{"$lte": to.UnixNano()}})
+	}
+	var docs []archiveDoc
+	if err := archives.Find(query).Sort("from").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get %s archives", kind)
+	}
+	return docs, nil
+}
+
+// removeArchives removes the model's archives from its blobstore
+// bucket. The archive documents are removed with the model's other
+// documents.
+func (st *State) removeArchives() error {
+	archives, closer := st.db().GetCollection(archivesC)
+	defer closer()
+
+	var docs []archiveDoc
+	if err := archives.Find(nil).Select(bson.M{"path": 1}).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get archives")
+	}
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	for _, doc := range docs {
+		if err := stor.Remove(doc.Path); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing archive %q", doc.Path)
+		}
+	}
+	return nil
+}
+
+// readArchive calls add with a decoder positioned at each record of
+// the archive at the given path, in turn.
+func readArchive(stor storage.Storage, path string, add func(*json.Decoder) error) error {
+	r, _, err := stor.Get(path)
+	if err != nil {
+		return errors.Annotatef(err, "reading archive %q", path)
+	}
+	defer r.Close()
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return errors.Annotatef(err, "reading archive %q", path)
+	}
+	defer gzr.Close()
+	dec := json.NewDecoder(gzr)
+	for dec.More() {
+		if err := add(dec); err != nil {
+			return errors.Annotatef(err, "reading archive %q", path)
+		}
+	}
+	return nil
+}
+
+// inTimeRange reports whether the time t, in unix nanoseconds, is
+// between from and to. Zero times leave the range unbounded.
+func inTimeRange(t int64, from, to time.Time) bool {
+	if !from.IsZero() && t < from.UnixNano() {
+		return false
+	}
+	if !to.IsZero() && t > to.UnixNano() {
+		return false
+	}
+	return true
+}
+
+// archivedStatusGlobalKeys returns the global keys of the status
+// history of the given kind of the entity with the given tag.
+func archivedStatusGlobalKeys(kind status.HistoryKind, tag names.Tag) (set.Strings, error) {
+	switch tag := tag.(type) {
+	case names.ModelTag:
+		return set.NewStrings(modelGlobalKey), nil
+	case names.ApplicationTag:
+		return set.NewStrings(applicationGlobalKey(tag.Id())), nil
+	case names.UnitTag:
+		switch kind {
+		case status.KindUnit:
+			return set.NewStrings(unitGlobalKey(tag.Id()), unitAgentGlobalKey(tag.Id())), nil
+		case status.KindWorkload:
+			return set.NewStrings(unitGlobalKey(tag.Id())), nil
+		case status.KindUnitAgent:
+			return set.NewStrings(unitAgentGlobalKey(tag.Id())), nil
+		}
+	case names.MachineTag:
+		switch kind {
+		case status.KindMachine, status.KindContainer:
+			return set.NewStrings(machineGlobalKey(tag.Id())), nil
+		case status.KindMachineInstance, status.KindContainerInstance:
+			return set.NewStrings(machineGlobalInstanceKey(tag.Id())), nil
+		}
+	}
+	return nil, errors.NotValidf("%s status history of %q", kind, tag)
+}
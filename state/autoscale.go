@@ -0,0 +1,232 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/autoscale"
+)
+
+// maxAutoscaleEvents is the number of autoscale events kept for each
+// application.
+const maxAutoscaleEvents = 20
+
+// autoscalePolicyDoc holds an application's autoscaling policy, and
+// the most recent changes the autoscaler has made to the application.
+// The document is removed when the policy is removed, or when the
+// application is removed.
+type autoscalePolicyDoc struct {
+	DocID          string              `bson:"_id"`
+	ModelUUID      string              `bson:"model-uuid"`
+	Application    string              `bson:"application"`
+	MinUnits       int                 `bson:"min-units"`
+	MaxUnits       int                 `bson:"max-units"`
+	Metric         string              `bson:"metric"`
+	ScaleUpAbove   float64             `bson:"scale-up-above"`
+	ScaleDownBelow float64             `bson:"scale-down-below"`
+	Cooldown       int64               `bson:"cooldown"`
+	Events         []autoscaleEventDoc `bson:"events,omitempty"`
+}
+
+type autoscaleEventDoc struct {
+	Time   int64  `bson:"time"`
+	From   int    `bson:"from"`
+	To     int    `bson:"to"`
+	Reason string `bson:"reason"`
+}
+
+func (doc autoscalePolicyDoc) policy() autoscale.Policy {
+	return autoscale.Policy{
+		MinUnits:       doc.MinUnits,
+		MaxUnits:       doc.MaxUnits,
+		Metric:         doc.Metric,
+		ScaleUpAbove:   doc.ScaleUpAbove,
+		ScaleDownBelow: doc.ScaleDownBelow,
+		Cooldown:       time.Duration(doc.Cooldown),
+	}
+}
+
+func (doc autoscalePolicyDoc) events() []autoscale.Event {
+	events := make([]autoscale.Event, len(doc.Events))
+	for i, e := range doc.Events {
+		events[i] = autoscale.Event{
+			Time:   time.Unix(0, e.Time).UTC(),
+			From:   e.From,
+			To:     e.To,
+			Reason: e.Reason,
+		}
+	}
+	return events
+}
+
+func (a *Application) autoscalePolicyDoc() (autoscalePolicyDoc, error) {
+	coll, closer := a.st.db().GetCollection(autoscalePoliciesC)
+	defer closer()
+
+	var doc autoscalePolicyDoc
+	err := coll.FindId(a.doc.Name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return doc, errors.NotFoundf("autoscale policy for application %q", a.doc.Name)
+	} else if err != nil {
+		return doc, errors.Annotatef(err, "cannot read autoscale policy for application %q", a.doc.Name)
+	}
+	return doc, nil
+}
+
+// AutoscalePolicy returns the application's autoscaling policy. It
+// returns a NotFound error if the application isn't autoscaled.
+func (a *Application) AutoscalePolicy() (autoscale.Policy, error) {
+	doc, err := a.autoscalePolicyDoc()
+	if err != nil {
+		return autoscale.Policy{}, errors.Trace(err)
+	}
+	return doc.policy(), nil
+}
+
+// AutoscaleEvents returns the most recent changes the autoscaler has
+// made to the application, oldest first.
+func (a *Application) AutoscaleEvents() ([]autoscale.Event, error) {
+	doc, err := a.autoscalePolicyDoc()
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.events(), nil
+}
+
+// SetAutoscalePolicy sets the application's autoscaling policy. The
+// history of changes made by the autoscaler is kept when an existing
+// policy is replaced.
+func (a *Application) SetAutoscalePolicy(policy autoscale.Policy) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set autoscale policy for application %q", a)
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	// The autoscaler adds units on new machines, which container
+	// models don't have.
+	m, err := a.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if m.Type() == ModelTypeCAAS {
+		return errors.NotSupportedf("autoscaling applications on a container model")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errors.New("application is no longer alive")
+		}
+		if a.doc.Subordinate {
+			return nil, errors.New("subordinate applications cannot be autoscaled")
+		}
+		ops := []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+		}}
+		_, err := a.autoscalePolicyDoc()
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      autoscalePoliciesC,
+				Id:     a.st.docID(a.doc.Name),
+				Assert: txn.DocMissing,
+				Insert: &autoscalePolicyDoc{
+					Application:    a.doc.Name,
+					MinUnits:       policy.MinUnits,
+					MaxUnits:       policy.MaxUnits,
+					Metric:         policy.Metric,
+					ScaleUpAbove:   policy.ScaleUpAbove,
+					ScaleDownBelow: policy.ScaleDownBelow,
+					Cooldown:       int64(policy.Cooldown),
+				},
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      autoscalePoliciesC,
+			Id:     a.st.docID(a.doc.Name),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"min-units", policy.MinUnits},
+				{"max-units", policy.MaxUnits},
+				{"metric", policy.Metric},
+				{"scale-up-above", policy.ScaleUpAbove},
+				{"scale-down-below", policy.ScaleDownBelow},
+				{"cooldown", int64(policy.Cooldown)},
+			}}},
+		}), nil
+	}
+	return a.st.db().Run(buildTxn)
+}
+
+// RemoveAutoscalePolicy stops the application from being autoscaled,
+// and discards the history of changes made by the autoscaler. It is
+// not an error to remove a policy that doesn't exist.
+func (a *Application) RemoveAutoscalePolicy() error {
+	err := a.st.db().RunTransaction([]txn.Op{removeAutoscalePolicyOp(a.st, a.doc.Name)})
+	return errors.Annotatef(err, "cannot remove autoscale policy for application %q", a)
+}
+
+// RecordAutoscaleEvent records a change the autoscaler has made to the
+// application. Only the most recent changes are kept.
+func (a *Application) RecordAutoscaleEvent(event autoscale.Event) error {
+	doc := autoscaleEventDoc{
+		Time:   event.Time.UnixNano(),
+		From:   event.From,
+		To:     event.To,
+		Reason: event.Reason,
+	}
+	err := a.st.db().RunTransaction([]txn.Op{{
+		C:      autoscalePoliciesC,
+		Id:     a.st.docID(a.doc.Name),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$push", bson.D{{"events", bson.D{
+			{"$each", []autoscaleEventDoc{doc}},
+			{"$slice", -maxAutoscaleEvents},
+		}}}}},
+	}})
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("autoscale policy for application %q", a.doc.Name)
+	}
+	return errors.Annotatef(err, "cannot record autoscale event for application %q", a)
+}
+
+// removeAutoscalePolicyOp returns the operation required to remove the
+// autoscale policy document of the application.
+func removeAutoscalePolicyOp(st *State, applicationName string) txn.Op {
+	return txn.Op{
+		C:      autoscalePoliciesC,
+		Id:     st.docID(applicationName),
+		Remove: true,
+	}
+}
+
+// AutoscalePolicies returns the autoscaling policies of the model's
+// applications, keyed by application name.
+func (st *State) AutoscalePolicies() (map[string]autoscale.Policy, error) {
+	coll, closer := st.db().GetCollection(autoscalePoliciesC)
+	defer closer()
+
+	var docs []autoscalePolicyDoc
+	if err := coll.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read autoscale policies")
+	}
+	result := make(map[string]autoscale.Policy)
+	for _, doc := range docs {
+		result[doc.Application] = doc.policy()
+	}
+	return result, nil
+}
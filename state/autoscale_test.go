@@ -0,0 +1,160 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/autoscale"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type AutoscaleSuite struct {
+	ConnSuite
+	application *state.Application
+}
+
+var _ = gc.Suite(&AutoscaleSuite{})
+
+func (s *AutoscaleSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+}
+
+var testAutoscalePolicy = autoscale.Policy{
+	MinUnits:       1,
+	MaxUnits:       3,
+	Metric:         "max(cpu)",
+	ScaleUpAbove:   80,
+	ScaleDownBelow: 20,
+	Cooldown:       5 * time.Minute,
+}
+
+func (s *AutoscaleSuite) TestAutoscalePolicyNotSet(c *gc.C) {
+	_, err := s.application.AutoscalePolicy()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	events, err := s.application.AutoscaleEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 0)
+	policies, err := s.State.AutoscalePolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policies, gc.HasLen, 0)
+}
+
+func (s *AutoscaleSuite) TestSetAutoscalePolicy(c *gc.C) {
+	err := s.application.SetAutoscalePolicy(testAutoscalePolicy)
+	c.Assert(err, jc.ErrorIsNil)
+	policy, err := s.application.AutoscalePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, testAutoscalePolicy)
+
+	updated := testAutoscalePolicy
+	updated.MaxUnits = 5
+	err = s.application.SetAutoscalePolicy(updated)
+	c.Assert(err, jc.ErrorIsNil)
+	policies, err := s.State.AutoscalePolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policies, jc.DeepEquals, map[string]autoscale.Policy{
+		"dummy-application": updated,
+	})
+}
+
+func (s *AutoscaleSuite) TestSetAutoscalePolicyInvalid(c *gc.C) {
+	policy := testAutoscalePolicy
+	policy.MaxUnits = 0
+	err := s.application.SetAutoscalePolicy(policy)
+	c.Assert(err, gc.ErrorMatches, `cannot set autoscale policy for application "dummy-application": maximum units 0 with minimum units 1 not valid`)
+}
+
+func (s *AutoscaleSuite) TestSetAutoscalePolicyNotAlive(c *gc.C) {
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.application})
+	err := s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetAutoscalePolicy(testAutoscalePolicy)
+	c.Assert(err, gc.ErrorMatches, `cannot set autoscale policy for application "dummy-application": application is no longer alive`)
+}
+
+func (s *AutoscaleSuite) TestSetAutoscalePolicySubordinate(c *gc.C) {
+	logging := s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	err := logging.SetAutoscalePolicy(testAutoscalePolicy)
+	c.Assert(err, gc.ErrorMatches, `cannot set autoscale policy for application "logging": subordinate applications cannot be autoscaled`)
+}
+
+func (s *AutoscaleSuite) TestSetAutoscalePolicyCAAS(c *gc.C) {
+	st := s.Factory.MakeCAASModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+	app := f.MakeApplication(c, &factory.ApplicationParams{Name: "gitlab", Charm: ch})
+	err := app.SetAutoscalePolicy(testAutoscalePolicy)
+	c.Assert(err, gc.ErrorMatches, `cannot set autoscale policy for application "gitlab": autoscaling applications on a container model not supported`)
+	c.Assert(errors.Cause(err), jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *AutoscaleSuite) TestRemoveAutoscalePolicy(c *gc.C) {
+	err := s.application.SetAutoscalePolicy(testAutoscalePolicy)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.RemoveAutoscalePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.application.AutoscalePolicy()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// Removing it again is fine.
+	err = s.application.RemoveAutoscalePolicy()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AutoscaleSuite) TestRecordAutoscaleEvent(c *gc.C) {
+	err := s.application.SetAutoscalePolicy(testAutoscalePolicy)
+	c.Assert(err, jc.ErrorIsNil)
+
+	start := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < 25; i++ {
+		err := s.application.RecordAutoscaleEvent(autoscale.Event{
+			Time:   start.Add(time.Duration(i) * time.Minute),
+			From:   i,
+			To:     i + 1,
+			Reason: fmt.Sprintf("event %d", i),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	events, err := s.application.AutoscaleEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 20)
+	c.Assert(events[0], jc.DeepEquals, autoscale.Event{
+		Time:   start.Add(5 * time.Minute),
+		From:   5,
+		To:     6,
+		Reason: "event 5",
+	})
+	c.Assert(events[19].Reason, gc.Equals, "event 24")
+
+	// Replacing the policy keeps the events.
+	err = s.application.SetAutoscalePolicy(testAutoscalePolicy)
+	c.Assert(err, jc.ErrorIsNil)
+	events, err = s.application.AutoscaleEvents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 20)
+}
+
+func (s *AutoscaleSuite) TestRecordAutoscaleEventNoPolicy(c *gc.C) {
+	err := s.application.RecordAutoscaleEvent(autoscale.Event{Time: time.Now()})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *AutoscaleSuite) TestApplicationRemovalRemovesPolicy(c *gc.C) {
+	err := s.application.SetAutoscalePolicy(testAutoscalePolicy)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	policies, err := s.State.AutoscalePolicies()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policies, gc.HasLen, 0)
+}
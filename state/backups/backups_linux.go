@@ -6,7 +6,10 @@
 package backups
 
 import (
+	"io/ioutil"
 	"net"
+	"os"
+	"path/filepath"
 	"strconv"
 
 	"github.com/juju/errors"
@@ -82,16 +85,15 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 	}
 	defer workspace.Close()
 
-	// This might actually work, but we don't have a guarantee so we don't allow it.
-	if meta.Origin.Series != args.NewInstSeries {
-		return nil, errors.Errorf("cannot restore a backup made in a machine with series %q into a machine with series %q, %#v", meta.Origin.Series, args.NewInstSeries, meta)
+	// Archives read straight from disk, such as the safety snapshot
+	// taken before a restore, only know where they came from through
+	// the metadata bundled inside them.
+	if meta.Origin.Model == UnknownString {
+		if meta, err = workspace.Metadata(); err != nil {
+			return nil, errors.Annotate(err, "cannot read backup metadata")
+		}
 	}
 
-	// TODO(perrito666) Create a compatibility table of sorts.
-	vers := meta.Origin.Version
-	if vers.Major != 2 {
-		return nil, errors.Errorf("Juju version %v cannot restore backups made using Juju version %v", version.Current.Minor, vers)
-	}
 	backupMachine := names.NewMachineTag(meta.Origin.Machine)
 
 	// The path for the config file might change if the tag changed
@@ -103,10 +105,29 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 
 	var oldAgentConfig agent.ConfigSetterWriter
 	oldAgentConfigFile := agent.ConfigPath(oldDatadir, args.NewInstTag)
+	if args.Rollback {
+		// The failed restore may already have replaced this machine's
+		// agent config, but the safety snapshot holds the original.
+		rollbackRoot, err := ioutil.TempDir("", "juju-rollback")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		defer os.RemoveAll(rollbackRoot)
+		if err := workspace.UnpackFilesBundle(rollbackRoot); err != nil {
+			return nil, errors.Annotate(err, "cannot obtain system files from safety snapshot")
+		}
+		oldAgentConfigFile = agent.ConfigPath(filepath.Join(rollbackRoot, oldDatadir), args.NewInstTag)
+	}
 	if oldAgentConfig, err = agent.ReadConfig(oldAgentConfigFile); err != nil {
 		return nil, errors.Annotate(err, "cannot load old agent config from disk")
 	}
 
+	// Nothing has been changed yet, so bail out now if the backup
+	// doesn't belong to this controller.
+	if err := ValidateRestore(meta, oldAgentConfig.Model().Id(), args.NewInstSeries, version.Current); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	logger.Infof("stopping juju-db")
 	if err = mongo.StopService(); err != nil {
 		return nil, errors.Annotate(err, "failed to stop mongo")
@@ -315,8 +336,14 @@ func (b *backups) Restore(backupId string, args RestoreArgs) (names.Tag, error)
 	if err := info.PurgeTxn(); err != nil {
 		return nil, errors.Annotate(err, "cannot purge stale transactions")
 	}
-	if err = info.SetStatus(state.RestoreFinished); err != nil {
-		return nil, errors.Annotate(err, "failed to set status to finished")
+	// A rollback puts the controller back as it was, but the restore
+	// that was asked for still failed, so FinishRestore must say so.
+	status := state.RestoreFinished
+	if args.Rollback {
+		status = state.RestoreFailed
+	}
+	if err = info.SetStatus(status); err != nil {
+		return nil, errors.Annotatef(err, "failed to set status to %q", status)
 	}
 
 	return backupMachine, nil
@@ -0,0 +1,224 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"io/ioutil"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+const (
+	// maxDocumentSize is the largest document mongo will write to a
+	// dump, including the allowance it makes for internal fields.
+	maxDocumentSize = 16*1024*1024 + 16*1024
+
+	// jujuDBName and modelsCollection locate the models in a dump.
+	jujuDBName       = "juju"
+	modelsCollection = "models"
+)
+
+// ArchiveInfo describes the contents of a backup archive.
+type ArchiveInfo struct {
+	// Metadata is the metadata bundled in the archive. It is nil for
+	// archives made before the metadata was bundled.
+	Metadata *Metadata
+
+	// Size is the size of the compressed archive.
+	Size int64
+
+	// Checksum is the checksum of the compressed archive, in the same
+	// format as the checksum of stored backups.
+	Checksum string
+
+	// ChecksumFormat describes how the checksum was computed.
+	ChecksumFormat string
+
+	// FilesSize is the size of the bundle of state-related files.
+	FilesSize int64
+
+	// Models holds the models found in the dumped juju database.
+	Models []ArchiveModel
+
+	// Collections holds the collections dumped from the database.
+	Collections []ArchiveCollection
+}
+
+// ArchiveModel describes a model found in a backup archive.
+type ArchiveModel struct {
+	UUID  string `bson:"_id"`
+	Name  string `bson:"name"`
+	Owner string `bson:"owner"`
+}
+
+// ArchiveCollection describes a collection dumped into a backup archive.
+type ArchiveCollection struct {
+	// Database is the name of the database the collection is in.
+	// It is empty for the oplog.
+	Database string
+
+	// Name is the name of the collection.
+	Name string
+
+	// Size is the size of the dumped collection.
+	Size int64
+
+	// Documents is the number of documents in the collection.
+	Documents int
+}
+
+// countingWriter counts the bytes written to it.
+type countingWriter int64
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	*w += countingWriter(len(p))
+	return len(p), nil
+}
+
+// InspectArchive reads the given compressed backup archive and
+// describes what is in it, without unpacking it to disk. As the whole
+// archive is read, the checksum of its compressed content is verified,
+// as is the structure of the files bundle and of the database dump.
+func InspectArchive(archive io.Reader) (*ArchiveInfo, error) {
+	hasher := sha1.New()
+	var size countingWriter
+	archive = io.TeeReader(archive, io.MultiWriter(hasher, &size))
+
+	gzr, err := gzip.NewReader(archive)
+	if err != nil {
+		return nil, errors.Annotate(err, "while uncompressing archive file")
+	}
+	defer gzr.Close()
+
+	var info ArchiveInfo
+	paths := NewCanonicalArchivePaths()
+	dumpPrefix := paths.DBDumpDir + "/"
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Annotate(err, "while reading archive file")
+		}
+		name := path.Clean(hdr.Name)
+		switch {
+		case name == paths.MetadataFile:
+			if info.Metadata, err = NewMetadataJSONReader(tr); err != nil {
+				return nil, errors.Annotate(err, "while reading metadata")
+			}
+		case name == paths.FilesBundle:
+			if err := checkFilesBundle(tr); err != nil {
+				return nil, errors.Annotate(err, "while reading files bundle")
+			}
+			info.FilesSize = hdr.Size
+		case strings.HasPrefix(name, dumpPrefix) && strings.HasSuffix(name, ".bson"):
+			dbName, collName := path.Split(strings.TrimPrefix(name, dumpPrefix))
+			coll := ArchiveCollection{
+				Database: strings.TrimSuffix(dbName, "/"),
+				Name:     strings.TrimSuffix(collName, ".bson"),
+				Size:     hdr.Size,
+			}
+			isModels := coll.Database == jujuDBName && coll.Name == modelsCollection
+			coll.Documents, err = readDocuments(tr, func(doc []byte) error {
+				if !isModels {
+					return nil
+				}
+				var model ArchiveModel
+				if err := bson.Unmarshal(doc, &model); err != nil {
+					return errors.Trace(err)
+				}
+				info.Models = append(info.Models, model)
+				return nil
+			})
+			if err != nil {
+				return nil, errors.Annotatef(err, "while reading dump of %q", name)
+			}
+			info.Collections = append(info.Collections, coll)
+		}
+	}
+
+	// Read to the end, so that the checksum of the compressed
+	// content is verified and the whole archive is hashed.
+	if _, err := io.Copy(ioutil.Discard, gzr); err != nil {
+		return nil, errors.Annotate(err, "while verifying archive file")
+	}
+	if _, err := io.Copy(ioutil.Discard, archive); err != nil {
+		return nil, errors.Trace(err)
+	}
+	info.Size = int64(size)
+	info.Checksum = base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+	info.ChecksumFormat = checksumFormat
+
+	sort.Slice(info.Models, func(i, j int) bool {
+		return info.Models[i].Name < info.Models[j].Name
+	})
+	sort.Slice(info.Collections, func(i, j int) bool {
+		a, b := info.Collections[i], info.Collections[j]
+		if a.Database != b.Database {
+			return a.Database < b.Database
+		}
+		return a.Name < b.Name
+	})
+	return &info, nil
+}
+
+// checkFilesBundle reads through the files bundle, returning an error
+// if it isn't a complete tar file.
+func checkFilesBundle(r io.Reader) error {
+	tr := tar.NewReader(r)
+	for {
+		_, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := io.Copy(ioutil.Discard, tr); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
+
+// readDocuments calls f with each document in a mongo dump file,
+// returning the number of documents read.
+func readDocuments(r io.Reader, f func(doc []byte) error) (int, error) {
+	var count int
+	var header [4]byte
+	for {
+		if _, err := io.ReadFull(r, header[:]); err == io.EOF {
+			return count, nil
+		} else if err != nil {
+			return count, errors.Annotate(err, "truncated document")
+		}
+		size := int64(binary.LittleEndian.Uint32(header[:]))
+		if size < 5 || size > maxDocumentSize {
+			return count, errors.Errorf("invalid document size %d", size)
+		}
+		doc := make([]byte, size)
+		copy(doc, header[:])
+		if _, err := io.ReadFull(r, doc[4:]); err != nil {
+			return count, errors.Annotate(err, "truncated document")
+		}
+		if doc[size-1] != 0 {
+			return count, errors.New("invalid document terminator")
+		}
+		if err := f(doc); err != nil {
+			return count, errors.Trace(err)
+		}
+		count++
+	}
+}
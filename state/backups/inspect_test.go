@@ -0,0 +1,112 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/base64"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/backups"
+	bt "github.com/juju/juju/state/backups/testing"
+)
+
+type inspectSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&inspectSuite{})
+
+func (s *inspectSuite) bsonDump(c *gc.C, docs ...interface{}) string {
+	var buf bytes.Buffer
+	for _, doc := range docs {
+		data, err := bson.Marshal(doc)
+		c.Assert(err, jc.ErrorIsNil)
+		buf.Write(data)
+	}
+	return buf.String()
+}
+
+func (s *inspectSuite) newArchive(c *gc.C, meta *backups.Metadata, machines string) []byte {
+	files := []bt.File{{
+		Name:    "var/lib/juju/system-identity",
+		Content: "<an ssh key goes here>",
+	}}
+	dump := []bt.File{{
+		Name:  "juju",
+		IsDir: true,
+	}, {
+		Name: "juju/models.bson",
+		Content: s.bsonDump(c,
+			bson.M{"_id": "deadbeef-0bad-400d-8000-4b1d0d06f00d", "name": "default", "owner": "admin"},
+			bson.M{"_id": "49db53ac-a42f-4ab2-86e1-0c6fa0fec762", "name": "controller", "owner": "admin"},
+		),
+	}, {
+		Name:    "juju/machines.bson",
+		Content: machines,
+	}, {
+		Name:    "oplog.bson",
+		Content: s.bsonDump(c, bson.M{"op": "n"}),
+	}}
+	archive, err := bt.NewArchive(meta, files, dump)
+	c.Assert(err, jc.ErrorIsNil)
+	return archive.Bytes()
+}
+
+func (s *inspectSuite) TestInspectArchive(c *gc.C) {
+	meta := bt.NewMetadataStarted()
+	machines := s.bsonDump(c, bson.M{"_id": "0"}, bson.M{"_id": "1"}, bson.M{"_id": "2"})
+	data := s.newArchive(c, meta, machines)
+
+	info, err := backups.InspectArchive(bytes.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+
+	sum := sha1.Sum(data)
+	c.Check(info.Size, gc.Equals, int64(len(data)))
+	c.Check(info.Checksum, gc.Equals, base64.StdEncoding.EncodeToString(sum[:]))
+	c.Check(info.FilesSize, jc.GreaterThan, int64(0))
+	c.Assert(info.Metadata, gc.NotNil)
+	c.Check(info.Metadata.Origin.Model, gc.Equals, meta.Origin.Model)
+	c.Check(info.Models, jc.DeepEquals, []backups.ArchiveModel{{
+		UUID:  "49db53ac-a42f-4ab2-86e1-0c6fa0fec762",
+		Name:  "controller",
+		Owner: "admin",
+	}, {
+		UUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Name:  "default",
+		Owner: "admin",
+	}})
+	c.Assert(info.Collections, gc.HasLen, 3)
+	c.Check(info.Collections[0].Database, gc.Equals, "")
+	c.Check(info.Collections[0].Name, gc.Equals, "oplog")
+	c.Check(info.Collections[0].Documents, gc.Equals, 1)
+	c.Check(info.Collections[1].Name, gc.Equals, "machines")
+	c.Check(info.Collections[1].Size, gc.Equals, int64(len(machines)))
+	c.Check(info.Collections[1].Documents, gc.Equals, 3)
+	c.Check(info.Collections[2].Database, gc.Equals, "juju")
+	c.Check(info.Collections[2].Name, gc.Equals, "models")
+	c.Check(info.Collections[2].Documents, gc.Equals, 2)
+}
+
+func (s *inspectSuite) TestInspectArchiveTruncatedDump(c *gc.C) {
+	machines := s.bsonDump(c, bson.M{"_id": "0"}, bson.M{"_id": "1"})
+	data := s.newArchive(c, bt.NewMetadataStarted(), machines[:len(machines)-3])
+
+	_, err := backups.InspectArchive(bytes.NewReader(data))
+	c.Assert(err, gc.ErrorMatches, `while reading dump of "juju-backup/dump/juju/machines.bson": truncated document: unexpected EOF`)
+}
+
+func (s *inspectSuite) TestInspectArchiveCorrupted(c *gc.C) {
+	data := s.newArchive(c, bt.NewMetadataStarted(), "")
+	// Flip a bit in the gzip trailer's checksum of the content.
+	data[len(data)-8] ^= 1
+
+	_, err := backups.InspectArchive(bytes.NewReader(data))
+	c.Assert(err, gc.ErrorMatches, `.*gzip: invalid checksum`)
+}
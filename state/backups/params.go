@@ -16,4 +16,8 @@ type RestoreArgs struct {
 	NewInstId      instance.Id
 	NewInstTag     names.Tag
 	NewInstSeries  string
+
+	// Rollback is set when restoring the safety snapshot taken
+	// before a failed restore.
+	Rollback bool
 }
@@ -0,0 +1,58 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/version"
+)
+
+// ValidateRestore returns an error if the backup described by meta
+// can't be restored into the controller with the given controller
+// model UUID, running on a machine with the given series and with the
+// given juju version. It is checked before anything on the controller
+// is changed.
+func ValidateRestore(meta *Metadata, modelUUID, series string, current version.Number) error {
+	if err := ValidateRestoreModel(meta, modelUUID); err != nil {
+		return errors.Trace(err)
+	}
+	if err := ValidateRestoreSeries(meta, series); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(ValidateRestoreVersion(meta, current))
+}
+
+// ValidateRestoreModel returns an error if the backup described by
+// meta was not made of the controller with the given controller model
+// UUID.
+func ValidateRestoreModel(meta *Metadata, modelUUID string) error {
+	if meta.Origin.Model != modelUUID {
+		return errors.Errorf("cannot restore a backup of controller model %q into controller model %q", meta.Origin.Model, modelUUID)
+	}
+	return nil
+}
+
+// ValidateRestoreSeries returns an error if the backup described by
+// meta was made on a machine with a different series.
+func ValidateRestoreSeries(meta *Metadata, series string) error {
+	// This might actually work, but we don't have a guarantee so we don't allow it.
+	if meta.Origin.Series != series {
+		return errors.Errorf("cannot restore a backup made in a machine with series %q into a machine with series %q", meta.Origin.Series, series)
+	}
+	return nil
+}
+
+// ValidateRestoreVersion returns an error if the backup described by
+// meta was made using a juju version the current one can't restore.
+func ValidateRestoreVersion(meta *Metadata, current version.Number) error {
+	// TODO(perrito666) Create a compatibility table of sorts.
+	vers := meta.Origin.Version
+	if vers.Major != current.Major {
+		return errors.Errorf("Juju version %v cannot restore backups made using Juju version %v", current, vers)
+	}
+	if vers.Compare(current) > 0 {
+		return errors.Errorf("cannot restore a backup made using Juju version %v into a controller running older version %v", vers, current)
+	}
+	return nil
+}
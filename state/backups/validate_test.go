@@ -0,0 +1,58 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+	"github.com/juju/juju/testing"
+)
+
+type validateSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&validateSuite{})
+
+func (s *validateSuite) metadata() *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.Origin = backups.Origin{
+		Model:    "asdf-zxcv-qwe",
+		Machine:  "0",
+		Hostname: "myhost",
+		Version:  version.MustParse("2.6.1"),
+		Series:   "bionic",
+	}
+	return meta
+}
+
+func (s *validateSuite) TestValidateRestore(c *gc.C) {
+	err := backups.ValidateRestore(s.metadata(), "asdf-zxcv-qwe", "bionic", version.MustParse("2.6.1"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = backups.ValidateRestore(s.metadata(), "asdf-zxcv-qwe", "bionic", version.MustParse("2.6.5"))
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *validateSuite) TestValidateRestoreWrongModel(c *gc.C) {
+	err := backups.ValidateRestore(s.metadata(), "other-model", "bionic", version.MustParse("2.6.1"))
+	c.Assert(err, gc.ErrorMatches, `cannot restore a backup of controller model "asdf-zxcv-qwe" into controller model "other-model"`)
+}
+
+func (s *validateSuite) TestValidateRestoreWrongSeries(c *gc.C) {
+	err := backups.ValidateRestore(s.metadata(), "asdf-zxcv-qwe", "xenial", version.MustParse("2.6.1"))
+	c.Assert(err, gc.ErrorMatches, `cannot restore a backup made in a machine with series "bionic" into a machine with series "xenial"`)
+}
+
+func (s *validateSuite) TestValidateRestoreWrongMajorVersion(c *gc.C) {
+	err := backups.ValidateRestore(s.metadata(), "asdf-zxcv-qwe", "bionic", version.MustParse("3.0.0"))
+	c.Assert(err, gc.ErrorMatches, `Juju version 3.0.0 cannot restore backups made using Juju version 2.6.1`)
+}
+
+func (s *validateSuite) TestValidateRestoreNewerVersion(c *gc.C) {
+	err := backups.ValidateRestore(s.metadata(), "asdf-zxcv-qwe", "bionic", version.MustParse("2.5.4"))
+	c.Assert(err, gc.ErrorMatches, `cannot restore a backup made using Juju version 2.6.1 into a controller running older version 2.5.4`)
+}
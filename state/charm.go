@@ -4,6 +4,8 @@
 package state
 
 import (
+	"bytes"
+	"io/ioutil"
 	"regexp"
 	"strings"
 
@@ -17,6 +19,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/binaryarch"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/mongo"
 	mongoutils "github.com/juju/juju/mongo/utils"
@@ -552,6 +555,27 @@ func (c *Charm) StoragePath() string {
 	return c.doc.StoragePath
 }
 
+// Architectures returns the architectures of the binaries in the
+// charm's archive, in sorted order. A charm without binaries, or whose
+// archive hasn't been uploaded yet, has no architectures.
+func (c *Charm) Architectures() ([]string, error) {
+	if c.doc.StoragePath == "" {
+		return nil, nil
+	}
+	stor := storage.NewStorage(c.st.ModelUUID(), c.st.MongoSession())
+	r, size, err := stor.Get(c.doc.StoragePath)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading charm archive")
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading charm archive")
+	}
+	arches, err := binaryarch.ArchiveArchitectures(bytes.NewReader(data), size)
+	return arches, errors.Annotatef(err, "charm %q", c)
+}
+
 // BundleSha256 returns the SHA256 digest of the charm bundle bytes.
 func (c *Charm) BundleSha256() string {
 	return c.doc.BundleSha256
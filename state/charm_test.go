@@ -4,7 +4,10 @@
 package state_test
 
 import (
+	"archive/zip"
 	"bytes"
+	"debug/elf"
+	"encoding/binary"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -12,6 +15,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/macaroon.v2-unstable"
@@ -174,6 +178,36 @@ func (s *CharmSuite) TestRemoveDeletesStorage(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *CharmSuite) TestArchitectures(c *gc.C) {
+	// We normally don't actually set up charm storage in state
+	// tests, but we need it here.
+	header := make([]byte, 64)
+	copy(header, elf.ELFMAG)
+	header[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	binary.LittleEndian.PutUint16(header[18:20], uint16(elf.EM_AARCH64))
+
+	var archive bytes.Buffer
+	w := zip.NewWriter(&archive)
+	for name, content := range map[string][]byte{
+		"metadata.yaml": []byte("name: dummy\n"),
+		"bin/dummy":     header,
+	} {
+		f, err := w.Create(name)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = f.Write(content)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	c.Assert(w.Close(), jc.ErrorIsNil)
+
+	stor := storage.NewStorage(s.State.ModelUUID(), s.State.MongoSession())
+	err := stor.Put(s.charm.StoragePath(), &archive, int64(archive.Len()))
+	c.Assert(err, jc.ErrorIsNil)
+
+	arches, err := s.charm.Architectures()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(arches, jc.DeepEquals, []string{arch.ARM64})
+}
+
 func (s *CharmSuite) TestReferenceDyingCharm(c *gc.C) {
 
 	s.destroy(c)
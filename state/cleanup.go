@@ -4,6 +4,8 @@
 package state
 
 import (
+	"fmt"
+	"sort"
 	"time"
 
 	"github.com/juju/errors"
@@ -13,18 +15,22 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/mongo"
 )
 
 type cleanupKind string
 
 const (
-	// forceTimeout is how far in the future the backstop force
-	// cleanup will be scheduled. This will be hard-coded to 0 for now
-	// until the no-wait flag is wired through, then we can change it
-	// to time.Minute.
-	forceTimeout = time.Duration(0)
+	// modelResourcesBackstopTimeout is how long the backstop cleanup
+	// waits, after the forced steps of destroying a model are due and
+	// then between checks, for a dying model to make progress before
+	// asking the provider to reclaim the model's resources.
+	modelResourcesBackstopTimeout = 30 * time.Minute
 )
 
 var (
@@ -52,6 +58,7 @@ const (
 	cleanupAttachmentsForDyingVolume     cleanupKind = "volumeAttachments"
 	cleanupAttachmentsForDyingFilesystem cleanupKind = "filesystemAttachments"
 	cleanupModelsForDyingController      cleanupKind = "models"
+	cleanupDrainedRelationUnit           cleanupKind = "drainedRelationUnit"
 
 	// IAAS models require machines to be cleaned up.
 	cleanupMachinesForDyingModel cleanupKind = "modelMachines"
@@ -61,6 +68,10 @@ const (
 
 	cleanupResourceBlob         cleanupKind = "resourceBlob"
 	cleanupStorageForDyingModel cleanupKind = "modelStorage"
+
+	// Force-destroyed IAAS models have their provider resources
+	// destroyed if the model is stuck dying.
+	cleanupForceDestroyedModelResources cleanupKind = "forceDestroyModelResources"
 )
 
 // cleanupDoc originally represented a set of documents that should be
@@ -128,6 +139,51 @@ func (st *State) NeedsCleanup() (bool, error) {
 	return count > 0, nil
 }
 
+// CleanupInfo describes a cleanup that has yet to be run.
+type CleanupInfo struct {
+	// Kind is the kind of cleanup.
+	Kind string
+
+	// Prefix usually names the entity being cleaned up.
+	Prefix string
+
+	// Scheduled is when the cleanup was scheduled.
+	Scheduled time.Time
+
+	// When is the earliest time at which the cleanup may run, or the
+	// zero time if it may run as soon as possible.
+	When time.Time
+}
+
+// PendingCleanups returns the cleanups that have yet to be run, in the
+// order they were scheduled.
+func (st *State) PendingCleanups() ([]CleanupInfo, error) {
+	cleanups, closer := st.db().GetCollection(cleanupsC)
+	defer closer()
+
+	var docs []cleanupDoc
+	if err := cleanups.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read cleanups")
+	}
+	result := make([]CleanupInfo, len(docs))
+	for i, doc := range docs {
+		result[i] = CleanupInfo{
+			Kind:   string(doc.Kind),
+			Prefix: doc.Prefix,
+			When:   doc.When,
+		}
+		// The ids of cleanup documents are object ids, which
+		// record when they were created.
+		if id := st.localID(doc.DocID); bson.IsObjectIdHex(id) {
+			result[i].Scheduled = bson.ObjectIdHex(id).Time()
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Scheduled.Before(result[j].Scheduled)
+	})
+	return result, nil
+}
+
 // Cleanup removes all documents that were previously marked for removal, if
 // any such exist. It should be called periodically by at least one element
 // of the system.
@@ -163,7 +219,7 @@ func (st *State) Cleanup() (err error) {
 		case cleanupDyingUnit:
 			err = st.cleanupDyingUnit(doc.Prefix, args)
 		case cleanupForceDestroyedUnit:
-			err = st.cleanupForceDestroyedUnit(doc.Prefix)
+			err = st.cleanupForceDestroyedUnit(doc.Prefix, args)
 		case cleanupForceRemoveUnit:
 			err = st.cleanupForceRemoveUnit(doc.Prefix)
 		case cleanupDyingUnitResources:
@@ -171,11 +227,11 @@ func (st *State) Cleanup() (err error) {
 		case cleanupRemovedUnit:
 			err = st.cleanupRemovedUnit(doc.Prefix, args)
 		case cleanupApplicationsForDyingModel:
-			err = st.cleanupApplicationsForDyingModel()
+			err = st.cleanupApplicationsForDyingModel(args)
 		case cleanupDyingMachine:
 			err = st.cleanupDyingMachine(doc.Prefix, args)
 		case cleanupForceDestroyedMachine:
-			err = st.cleanupForceDestroyedMachine(doc.Prefix)
+			err = st.cleanupForceDestroyedMachine(doc.Prefix, args)
 		case cleanupAttachmentsForDyingStorage:
 			err = st.cleanupAttachmentsForDyingStorage(doc.Prefix, args)
 		case cleanupAttachmentsForDyingVolume:
@@ -184,12 +240,16 @@ func (st *State) Cleanup() (err error) {
 			err = st.cleanupAttachmentsForDyingFilesystem(doc.Prefix)
 		case cleanupModelsForDyingController:
 			err = st.cleanupModelsForDyingController(args)
+		case cleanupDrainedRelationUnit:
+			err = st.cleanupDrainedRelationUnit(doc.Prefix, args)
 		case cleanupMachinesForDyingModel: // IAAS models only
-			err = st.cleanupMachinesForDyingModel()
+			err = st.cleanupMachinesForDyingModel(args)
 		case cleanupResourceBlob:
 			err = st.cleanupResourceBlob(doc.Prefix)
 		case cleanupStorageForDyingModel:
 			err = st.cleanupStorageForDyingModel(args)
+		case cleanupForceDestroyedModelResources:
+			err = st.cleanupForceDestroyedModelResources(args)
 		default:
 			err = errors.Errorf("unknown cleanup kind %q", doc.Kind)
 		}
@@ -227,6 +287,57 @@ func (st *State) cleanupResourceBlob(storagePath string) error {
 	return errors.Trace(err)
 }
 
+// cleanupDrainedRelationUnit completes the departure of a unit from a
+// relation scope once its drain deadline has passed.
+func (st *State) cleanupDrainedRelationUnit(relationKey string, cleanupArgs []bson.Raw) error {
+	if n := len(cleanupArgs); n != 2 {
+		return errors.Errorf("expected 2 arguments, got %d", n)
+	}
+	var unitName, scope string
+	if err := cleanupArgs[0].Unmarshal(&unitName); err != nil {
+		return errors.Annotate(err, "unmarshalling cleanup arg 'unitName'")
+	}
+	if err := cleanupArgs[1].Unmarshal(&scope); err != nil {
+		return errors.Annotate(err, "unmarshalling cleanup arg 'scope'")
+	}
+	rel, err := st.KeyRelation(relationKey)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	appName, err := names.UnitApplication(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ep, err := rel.Endpoint(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ru := &RelationUnit{
+		st:          st,
+		relation:    rel,
+		unitName:    unitName,
+		isPrincipal: true,
+		endpoint:    ep,
+		scope:       scope,
+		isLocalUnit: true,
+	}
+	deadline, err := ru.DrainDeadline()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if deadline.IsZero() || deadline.After(st.clock().Now()) {
+		// The unit has left the scope and entered it
+		// again since the cleanup was scheduled.
+		return nil
+	}
+	logger.Infof("unit %q drained from relation %q, leaving scope", unitName, rel)
+	return errors.Trace(ru.LeaveScope())
+}
+
 func (st *State) cleanupRelationSettings(prefix string) error {
 	change := relationSettingsCleanupChange{Prefix: st.docID(prefix)}
 	if err := Apply(st.database, change); err != nil {
@@ -284,7 +395,13 @@ func (st *State) cleanupModelsForDyingController(cleanupArgs []bson.Raw) (err er
 // cleanupMachinesForDyingModel sets all non-manager machines to Dying,
 // if they are not already Dying or Dead. It's expected to be used when
 // a model is destroyed.
-func (st *State) cleanupMachinesForDyingModel() (err error) {
+func (st *State) cleanupMachinesForDyingModel(cleanupArgs []bson.Raw) (err error) {
+	args, err := destroyModelParamsFromArgs(cleanupArgs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	force := args.Force != nil && *args.Force
+
 	// This won't miss machines, because a Dying model cannot have
 	// machines added to it. But we do have to remove the machines themselves
 	// via individual transactions, because they could be in any state at all.
@@ -303,14 +420,16 @@ func (st *State) cleanupMachinesForDyingModel() (err error) {
 		if err != nil {
 			return errors.Trace(err)
 		}
-		destroy := m.ForceDestroy
-		if manual {
+		destroy := func() error {
+			return m.ForceDestroy(args.MaxWait)
+		}
+		if manual && !force {
 			// Manually added machines should never be force-
 			// destroyed automatically. That should be a user-
 			// driven decision, since it may leak applications
 			// and resources on the machine. If something is
 			// stuck, then the user can still force-destroy
-			// the manual machines.
+			// the manual machines, or the whole model.
 			destroy = m.Destroy
 		}
 		if err := destroy(); err != nil {
@@ -320,42 +439,188 @@ func (st *State) cleanupMachinesForDyingModel() (err error) {
 	return nil
 }
 
-// cleanupStorageForDyingModel sets all storage to Dying, if they are not
-// already Dying or Dead. It's expected to be used when a model is destroyed.
-func (st *State) cleanupStorageForDyingModel(cleanupArgs []bson.Raw) (err error) {
-	sb, err := NewStorageBackend(st)
+// cleanupForceDestroyedModelResources is the backstop for force-destroying
+// a model. If the model is still dying, and has made no progress since the
+// backstop last looked at it, the provider is asked to reclaim the
+// resources tagged with the model, so that cloud resources are not leaked
+// when state is too inconsistent for the model to be removed.
+//
+// If the user asked for the model's storage to be released rather than
+// destroyed, the provider is asked to destroy everything but the model's
+// volumes. Providers that cannot do that only have the model's instances
+// stopped, as destroying the environ would destroy the volumes too.
+func (st *State) cleanupForceDestroyedModelResources(cleanupArgs []bson.Raw) error {
+	// Old cleanups have no args; err on the side of keeping
+	// the storage.
+	var destroyStorage bool
+	var lastProgress string
+	n := len(cleanupArgs)
+	if n > 2 {
+		return errors.Errorf("expected 0-2 arguments, got %d", n)
+	}
+	if n >= 1 {
+		if err := cleanupArgs[0].Unmarshal(&destroyStorage); err != nil {
+			return errors.Annotate(err, "unmarshalling cleanup arg 'destroyStorage'")
+		}
+	}
+	if n >= 2 {
+		if err := cleanupArgs[1].Unmarshal(&lastProgress); err != nil {
+			return errors.Annotate(err, "unmarshalling cleanup arg 'progress'")
+		}
+	}
+
+	model, err := st.Model()
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if model.Life() != Dying {
+		// Dead models have their provider resources destroyed
+		// by the undertaker.
+		return nil
+	}
+
+	// Only reclaim the resources of a model that is stuck: if the
+	// model's entities are still being removed, check again later.
+	progress, err := st.modelDestructionProgress()
 	if err != nil {
 		return errors.Trace(err)
 	}
-	destroyStorage := sb.DestroyStorageInstance
-	destroyStorageFromArg := func() error {
-		var destroyStorageFlag bool
-		if err := cleanupArgs[0].Unmarshal(&destroyStorageFlag); err != nil {
-			return errors.Annotate(err, "unmarshalling cleanup arg 'destroyStorage'")
+	if progress != lastProgress {
+		logger.Debugf("model %v is still being destroyed (%s), checking again later", model.UUID(), progress)
+		deadline := st.stateClock.Now().Add(modelResourcesBackstopTimeout)
+		op := newCleanupAtOp(
+			deadline, cleanupForceDestroyedModelResources, model.UUID(),
+			destroyStorage, progress,
+		)
+		return errors.Trace(st.db().RunTransaction([]txn.Op{op}))
+	}
+
+	if st.policy == nil {
+		logger.Warningf("model %v is stuck dying, but has no policy to destroy its provider resources", model.UUID())
+		return nil
+	}
+	destroyer, err := st.policy.CloudDestroyer()
+	if errors.IsNotImplemented(err) {
+		logger.Warningf("model %v is stuck dying, but its provider resources cannot be destroyed", model.UUID())
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	ctx := CallContext(st)
+	if destroyStorage {
+		logger.Warningf("model %v is stuck dying after being force-destroyed, destroying its provider resources", model.UUID())
+		if err := destroyer.Destroy(ctx); err != nil {
+			return errors.Annotate(err, "destroying provider resources")
 		}
-		if !destroyStorageFlag {
-			destroyStorage = sb.ReleaseStorageInstance
+		return nil
+	}
+	if destroyer, ok := destroyer.(environs.VolumePreservingDestroyer); ok {
+		logger.Warningf("model %v is stuck dying after being force-destroyed, destroying its provider resources except volumes", model.UUID())
+		if err := destroyer.DestroyExceptVolumes(ctx); err != nil {
+			return errors.Annotate(err, "destroying provider resources")
 		}
 		return nil
 	}
+	broker, ok := destroyer.(modelInstanceStopper)
+	if !ok {
+		logger.Warningf("model %v is stuck dying, but its instances cannot be stopped", model.UUID())
+		return nil
+	}
+	logger.Warningf("model %v is stuck dying after being force-destroyed, stopping its instances", model.UUID())
+	insts, err := broker.AllInstances(ctx)
+	if err != nil {
+		return errors.Annotate(err, "listing instances")
+	}
+	if len(insts) == 0 {
+		return nil
+	}
+	ids := make([]instance.Id, len(insts))
+	for i, inst := range insts {
+		ids[i] = inst.Id()
+	}
+	if err := broker.StopInstances(ctx, ids...); err != nil {
+		return errors.Annotate(err, "stopping instances")
+	}
+	return nil
+}
 
-	var force bool
+// modelInstanceStopper is the part of an environ used to stop the
+// instances of a stuck model without touching its storage.
+type modelInstanceStopper interface {
+	AllInstances(context.ProviderCallContext) ([]instances.Instance, error)
+	StopInstances(context.ProviderCallContext, ...instance.Id) error
+}
+
+// modelDestructionProgress returns a summary of the entities remaining
+// in a dying model. The summary changes as long as the model's entities
+// are still being removed.
+func (st *State) modelDestructionProgress() (string, error) {
+	model, err := st.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	refs, err := model.getEntityRefs()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	units, closer := st.db().GetCollection(unitsC)
+	defer closer()
+	unitCount, err := units.Count()
+	if err != nil {
+		return "", errors.Annotate(err, "counting units")
+	}
+	return fmt.Sprintf(
+		"machines=%d applications=%d units=%d volumes=%d filesystems=%d",
+		len(refs.Machines), len(refs.Applications), unitCount,
+		len(refs.Volumes), len(refs.Filesystems),
+	), nil
+}
+
+// cleanupStorageForDyingModel sets all storage to Dying, if they are not
+// already Dying or Dead. It's expected to be used when a model is destroyed.
+func (st *State) cleanupStorageForDyingModel(cleanupArgs []bson.Raw) (err error) {
 	// It's valid to have no args: old cleanups have no args, so follow the old behaviour.
-	if n := len(cleanupArgs); n > 0 {
-		if n > 2 {
-			return errors.Errorf("expected 0-2 arguments, got %d", n)
+	destroyStorageFlag := true
+	var force bool
+	var maxWait time.Duration
+	n := len(cleanupArgs)
+	if n > 3 {
+		return errors.Errorf("expected 0-3 arguments, got %d", n)
+	}
+	if n >= 1 {
+		if err := cleanupArgs[0].Unmarshal(&destroyStorageFlag); err != nil {
+			return errors.Annotate(err, "unmarshalling cleanup arg 'destroyStorage'")
 		}
-		if n >= 1 {
-			if err := destroyStorageFromArg(); err != nil {
-				return err
-			}
+	}
+	if n >= 2 {
+		if err := cleanupArgs[1].Unmarshal(&force); err != nil {
+			return errors.Annotate(err, "unmarshalling cleanup arg 'force'")
 		}
-		if n >= 2 {
-			if err := cleanupArgs[1].Unmarshal(&force); err != nil {
-				return errors.Annotate(err, "unmarshalling cleanup arg 'force'")
-			}
+	}
+	if n >= 3 {
+		if err := cleanupArgs[2].Unmarshal(&maxWait); err != nil {
+			return errors.Annotate(err, "unmarshalling cleanup arg 'maxWait'")
 		}
 	}
+
+	sb, err := NewStorageBackend(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	destroyStorage := sb.DestroyStorageInstance
+	if !destroyStorageFlag {
+		destroyStorage = sb.ReleaseStorageInstance
+	}
+
+	// If we're forcing, give the storage a chance to be destroyed
+	// gracefully first, and only force the destruction of whatever
+	// remains once maxWait has passed.
+	graceful := force && maxWait > 0
+	if graceful {
+		force = false
+	}
 	storage, err := sb.AllStorageInstances()
 	if err != nil {
 		return errors.Trace(err)
@@ -366,23 +631,54 @@ func (st *State) cleanupStorageForDyingModel(cleanupArgs []bson.Raw) (err error)
 		if errors.IsNotFound(err) {
 			continue
 		} else if err != nil {
+			if graceful {
+				// The forced cleanup will deal with it.
+				logger.Warningf("could not destroy storage %v, will force in %v: %v", s.StorageTag().Id(), maxWait, err)
+				continue
+			}
 			return errors.Trace(err)
 		}
 	}
+	if graceful {
+		st.scheduleForceCleanup(
+			cleanupStorageForDyingModel, st.ModelUUID(), maxWait,
+			destroyStorageFlag, true, time.Duration(0),
+		)
+	}
 	return nil
 }
 
 // cleanupApplicationsForDyingModel sets all applications to Dying, if they are
 // not already Dying or Dead. It's expected to be used when a model is
 // destroyed.
-func (st *State) cleanupApplicationsForDyingModel() (err error) {
+func (st *State) cleanupApplicationsForDyingModel(cleanupArgs []bson.Raw) (err error) {
+	args, err := destroyModelParamsFromArgs(cleanupArgs)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	if err := st.removeRemoteApplicationsForDyingModel(); err != nil {
 		return err
 	}
-	return st.removeApplicationsForDyingModel()
+	return st.removeApplicationsForDyingModel(args)
 }
 
-func (st *State) removeApplicationsForDyingModel() (err error) {
+// destroyModelParamsFromArgs returns the DestroyModelParams passed to a
+// model cleanup. Old cleanups have no args, and so are not forced.
+func destroyModelParamsFromArgs(cleanupArgs []bson.Raw) (DestroyModelParams, error) {
+	var args DestroyModelParams
+	switch n := len(cleanupArgs); n {
+	case 0:
+	case 1:
+		if err := cleanupArgs[0].Unmarshal(&args); err != nil {
+			return args, errors.Annotate(err, "unmarshalling cleanup args")
+		}
+	default:
+		return args, errors.Errorf("expected 0-1 arguments, got %d", n)
+	}
+	return args, nil
+}
+
+func (st *State) removeApplicationsForDyingModel(args DestroyModelParams) (err error) {
 	// This won't miss applications, because a Dying model cannot have
 	// applications added to it. But we do have to remove the applications
 	// themselves via individual transactions, because they could be in any
@@ -396,6 +692,8 @@ func (st *State) removeApplicationsForDyingModel() (err error) {
 	for iter.Next(&application.doc) {
 		op := application.DestroyOperation()
 		op.RemoveOffers = true
+		op.Force = args.Force != nil && *args.Force
+		op.MaxWait = args.MaxWait
 		if err := st.ApplyOperation(op); err != nil {
 			return errors.Trace(err)
 		}
@@ -425,23 +723,9 @@ func (st *State) removeRemoteApplicationsForDyingModel() (err error) {
 // if they are not already Dying or Dead. It's expected to be used when a
 // application is destroyed.
 func (st *State) cleanupUnitsForDyingApplication(applicationname string, cleanupArgs []bson.Raw) (err error) {
-	var destroyStorage bool
-	var force bool
-	// It's valid to have no args: old cleanups have no args, so follow the old behaviour.
-	if n := len(cleanupArgs); n > 0 {
-		if n > 2 {
-			return errors.Errorf("expected 0-2 arguments, got %d", n)
-		}
-		if n >= 1 {
-			if err := cleanupArgs[0].Unmarshal(&destroyStorage); err != nil {
-				return errors.Annotate(err, "unmarshalling cleanup args")
-			}
-		}
-		if n >= 2 {
-			if err := cleanupArgs[1].Unmarshal(&force); err != nil {
-				return errors.Annotate(err, "unmarshalling cleanup arg 'force'")
-			}
-		}
+	destroyStorage, force, disposition, maxWait, err := unitCleanupArgs(cleanupArgs)
+	if err != nil {
+		return errors.Trace(err)
 	}
 
 	// This won't miss units, because a Dying application cannot have units
@@ -457,7 +741,9 @@ func (st *State) cleanupUnitsForDyingApplication(applicationname string, cleanup
 	for iter.Next(&unit.doc) {
 		op := unit.DestroyOperation()
 		op.DestroyStorage = destroyStorage
+		op.StorageDisposition = disposition
 		op.Force = force
+		op.MaxWait = maxWait
 		if err := st.ApplyOperation(op); err != nil {
 			return errors.Trace(err)
 		}
@@ -501,23 +787,9 @@ func (st *State) cleanupCharm(charmURL string) error {
 // cleanupDyingUnit marks resources owned by the unit as dying, to ensure
 // they are cleaned up as well.
 func (st *State) cleanupDyingUnit(name string, cleanupArgs []bson.Raw) error {
-	var destroyStorage bool
-	var force bool
-	// It's valid to have no args: old cleanups have no args, so follow the old behaviour.
-	if n := len(cleanupArgs); n > 0 {
-		if n > 2 {
-			return errors.Errorf("expected 0-2 arguments, got %d", n)
-		}
-		if n >= 1 {
-			if err := cleanupArgs[0].Unmarshal(&destroyStorage); err != nil {
-				return errors.Annotate(err, "unmarshalling cleanup args")
-			}
-		}
-		if n >= 2 {
-			if err := cleanupArgs[1].Unmarshal(&force); err != nil {
-				return errors.Annotate(err, "unmarshalling cleanup arg 'force'")
-			}
-		}
+	destroyStorage, force, disposition, maxWait, err := unitCleanupArgs(cleanupArgs)
+	if err != nil {
+		return errors.Trace(err)
 	}
 
 	unit, err := st.Unit(name)
@@ -558,11 +830,16 @@ func (st *State) cleanupDyingUnit(name string, cleanupArgs []bson.Raw) error {
 
 	// If we're forcing, set up a backstop cleanup to really remove
 	// the unit in the case that the unit and machine agents don't for
-	// some reason.
+	// some reason within maxWait.
 	if force {
-		st.scheduleForceCleanup(cleanupForceDestroyedUnit, name)
+		st.scheduleForceCleanup(cleanupForceDestroyedUnit, name, maxWait, maxWait)
 	}
 
+	if len(disposition) > 0 {
+		// Dispose of each storage instance as requested, allowing
+		// the unit to terminate.
+		return st.cleanupUnitStorage(unit.UnitTag(), destroyStorage, disposition, force)
+	}
 	if destroyStorage {
 		// Detach and mark storage instances as dying, allowing the
 		// unit to terminate.
@@ -574,9 +851,65 @@ func (st *State) cleanupDyingUnit(name string, cleanupArgs []bson.Raw) error {
 	}
 }
 
-func (st *State) scheduleForceCleanup(kind cleanupKind, name string) {
-	deadline := st.stateClock.Now().Add(forceTimeout)
-	op := newCleanupAtOp(deadline, kind, name)
+// unitCleanupArgs returns the arguments passed to the cleanups for
+// dying applications and units. It's valid to have fewer arguments,
+// as old cleanups have fewer.
+func unitCleanupArgs(cleanupArgs []bson.Raw) (
+	destroyStorage, force bool,
+	disposition map[string]StorageDisposition,
+	maxWait time.Duration,
+	err error,
+) {
+	n := len(cleanupArgs)
+	if n > 4 {
+		return false, false, nil, 0, errors.Errorf("expected 0-4 arguments, got %d", n)
+	}
+	if n >= 1 {
+		if err := cleanupArgs[0].Unmarshal(&destroyStorage); err != nil {
+			return false, false, nil, 0, errors.Annotate(err, "unmarshalling cleanup args")
+		}
+	}
+	if n >= 2 {
+		if err := cleanupArgs[1].Unmarshal(&force); err != nil {
+			return false, false, nil, 0, errors.Annotate(err, "unmarshalling cleanup arg 'force'")
+		}
+	}
+	if n >= 3 {
+		if err := cleanupArgs[2].Unmarshal(&disposition); err != nil {
+			return false, false, nil, 0, errors.Annotate(err, "unmarshalling cleanup arg 'storageDisposition'")
+		}
+	}
+	if n >= 4 {
+		if err := cleanupArgs[3].Unmarshal(&maxWait); err != nil {
+			return false, false, nil, 0, errors.Annotate(err, "unmarshalling cleanup arg 'maxWait'")
+		}
+	}
+	return destroyStorage, force, disposition, maxWait, nil
+}
+
+// maxWaitFromArgs returns the maxWait argument passed to a forced
+// cleanup. Old cleanups have no args, and so run the next step
+// immediately.
+func maxWaitFromArgs(cleanupArgs []bson.Raw) (time.Duration, error) {
+	var maxWait time.Duration
+	switch n := len(cleanupArgs); n {
+	case 0:
+	case 1:
+		if err := cleanupArgs[0].Unmarshal(&maxWait); err != nil {
+			return 0, errors.Annotate(err, "unmarshalling cleanup arg 'maxWait'")
+		}
+	default:
+		return 0, errors.Errorf("expected 0-1 arguments, got %d", n)
+	}
+	return maxWait, nil
+}
+
+// scheduleForceCleanup schedules a cleanup of the given kind to run
+// once maxWait has passed, giving the entity a chance to be dealt
+// with gracefully before it is forced.
+func (st *State) scheduleForceCleanup(kind cleanupKind, name string, maxWait time.Duration, args ...interface{}) {
+	deadline := st.stateClock.Now().Add(maxWait)
+	op := newCleanupAtOp(deadline, kind, name, args...)
 	err := st.db().Run(func(int) ([]txn.Op, error) {
 		return []txn.Op{op}, nil
 	})
@@ -585,7 +918,11 @@ func (st *State) scheduleForceCleanup(kind cleanupKind, name string) {
 	}
 }
 
-func (st *State) cleanupForceDestroyedUnit(unitId string) error {
+func (st *State) cleanupForceDestroyedUnit(unitId string, cleanupArgs []bson.Raw) error {
+	maxWait, err := maxWaitFromArgs(cleanupArgs)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	unit, err := st.Unit(unitId)
 	if errors.IsNotFound(err) {
 		logger.Debugf("no need to force unit to dead %q", unitId)
@@ -606,7 +943,7 @@ func (st *State) cleanupForceDestroyedUnit(unitId string) error {
 		} else if err != nil {
 			logger.Warningf("couldn't get subordinate %q to force destroy: %v", subName, err)
 		}
-		opErrs, err := subUnit.DestroyWithForce(true)
+		opErrs, err := subUnit.DestroyWithForce(true, maxWait)
 		if len(opErrs) != 0 || err != nil {
 			logger.Warningf("errors while destroying subordinate %q: %v, %v", subName, err, opErrs)
 		}
@@ -647,9 +984,9 @@ func (st *State) cleanupForceDestroyedUnit(unitId string) error {
 		logger.Warningf("couldn't set unit %q dead: %v", unitId, err)
 	}
 
-	// Set up another cleanup to remove the unit in a minute if the
-	// deployer doesn't do it.
-	st.scheduleForceCleanup(cleanupForceRemoveUnit, unitId)
+	// Set up another cleanup to remove the unit once maxWait has
+	// passed if the deployer doesn't do it.
+	st.scheduleForceCleanup(cleanupForceRemoveUnit, unitId, maxWait)
 	return nil
 }
 
@@ -785,6 +1122,53 @@ func (st *State) cleanupUnitStorageInstances(unitTag names.UnitTag, force bool)
 	return nil
 }
 
+// cleanupUnitStorage destroys, releases or detaches each storage instance
+// attached to the unit according to disposition. Storage instances with no
+// recorded disposition are destroyed if destroyStorage is true, and
+// detached otherwise.
+func (st *State) cleanupUnitStorage(
+	unitTag names.UnitTag,
+	destroyStorage bool,
+	disposition map[string]StorageDisposition,
+	force bool,
+) error {
+	sb, err := NewStorageBackend(st)
+	if err != nil {
+		return err
+	}
+	storageAttachments, err := sb.UnitStorageAttachments(unitTag)
+	if err != nil {
+		return err
+	}
+	for _, storageAttachment := range storageAttachments {
+		storageTag := storageAttachment.StorageInstance()
+		what, ok := disposition[storageTag.Id()]
+		if !ok {
+			what = StorageDetach
+			if destroyStorage {
+				what = StorageDestroy
+			}
+		}
+		switch what {
+		case StorageDestroy:
+			err = sb.DestroyStorageInstance(storageTag, true, force)
+		case StorageRelease:
+			err = sb.ReleaseStorageInstance(storageTag, true, force)
+		default:
+			err = sb.DetachStorage(storageTag, unitTag, force)
+		}
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			if !force {
+				return err
+			}
+			logger.Warningf("could not %s storage %v for unit %v: %v", what, storageTag.Id(), unitTag.Id(), err)
+		}
+	}
+	return nil
+}
+
 // cleanupRemovedUnit takes care of all the final cleanup required when
 // a unit is removed.
 func (st *State) cleanupRemovedUnit(unitId string, cleanupArgs []bson.Raw) error {
@@ -863,7 +1247,18 @@ func (st *State) cleanupDyingMachine(machineId string, cleanupArgs []bson.Raw) e
 // cleanupForceDestroyedMachine systematically destroys and removes all entities
 // that depend upon the supplied machine, and removes the machine from state. It's
 // expected to be used in response to destroy-machine --force.
-func (st *State) cleanupForceDestroyedMachine(machineId string) error {
+func (st *State) cleanupForceDestroyedMachine(machineId string, cleanupArgs []bson.Raw) error {
+	maxWait, err := maxWaitFromArgs(cleanupArgs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return st.forceDestroyMachine(machineId, maxWait)
+}
+
+// forceDestroyMachine does the work of cleanupForceDestroyedMachine. Units
+// on the machine are forcibly destroyed, but their own forced cleanups wait
+// for maxWait.
+func (st *State) forceDestroyMachine(machineId string, maxWait time.Duration) error {
 	machine, err := st.Machine(machineId)
 	if errors.IsNotFound(err) {
 		return nil
@@ -882,11 +1277,11 @@ func (st *State) cleanupForceDestroyedMachine(machineId string) error {
 	// But machine destruction is unsophisticated, and doesn't allow for
 	// destruction while dependencies exist; so we just have to deal with that
 	// possibility below.
-	if err := st.cleanupContainers(machine); err != nil {
+	if err := st.cleanupContainers(machine, maxWait); err != nil {
 		return errors.Trace(err)
 	}
 	for _, unitName := range machine.doc.Principals {
-		opErrs, err := st.obliterateUnit(unitName, true)
+		opErrs, err := st.obliterateUnit(unitName, true, maxWait)
 		if len(opErrs) != 0 {
 			logger.Warningf("while obliterating unit %v: %v", unitName, opErrs)
 		}
@@ -956,9 +1351,9 @@ func (st *State) cleanupForceDestroyedMachine(machineId string) error {
 	// instance that would otherwise be ignored when in provisioner-safe-mode.
 }
 
-// cleanupContainers recursively calls cleanupForceDestroyedMachine on the supplied
+// cleanupContainers recursively calls forceDestroyMachine on the supplied
 // machine's containers, and removes them from state entirely.
-func (st *State) cleanupContainers(machine *Machine) error {
+func (st *State) cleanupContainers(machine *Machine, maxWait time.Duration) error {
 	containerIds, err := machine.Containers()
 	if errors.IsNotFound(err) {
 		return nil
@@ -966,7 +1361,7 @@ func (st *State) cleanupContainers(machine *Machine) error {
 		return err
 	}
 	for _, containerId := range containerIds {
-		if err := st.cleanupForceDestroyedMachine(containerId); err != nil {
+		if err := st.forceDestroyMachine(containerId, maxWait); err != nil {
 			return err
 		}
 		container, err := st.Machine(containerId)
@@ -1159,7 +1554,7 @@ func cleanupDyingEntityStorage(sb *storageBackend, hostTag names.Tag, manual boo
 // sane to obliterate any unit in isolation; its only reasonable use is in
 // the context of machine obliteration, in which we can be sure that unclean
 // shutdown of units is not going to leave a machine in a difficult state.
-func (st *State) obliterateUnit(unitName string, force bool) ([]error, error) {
+func (st *State) obliterateUnit(unitName string, force bool, maxWait time.Duration) ([]error, error) {
 	var opErrs []error
 	unit, err := st.Unit(unitName)
 	if errors.IsNotFound(err) {
@@ -1170,7 +1565,7 @@ func (st *State) obliterateUnit(unitName string, force bool) ([]error, error) {
 	// Unlike the machine, we *can* always destroy the unit, and (at least)
 	// prevent further dependencies being added. If we're really lucky, the
 	// unit will be removed immediately.
-	errs, err := unit.DestroyWithForce(force)
+	errs, err := unit.DestroyWithForce(force, maxWait)
 	opErrs = append(opErrs, errs...)
 	if err != nil {
 		if !force {
@@ -1195,7 +1590,7 @@ func (st *State) obliterateUnit(unitName string, force bool) ([]error, error) {
 		opErrs = append(opErrs, err)
 	}
 	for _, subName := range unit.SubordinateNames() {
-		errs, err := st.obliterateUnit(subName, force)
+		errs, err := st.obliterateUnit(subName, force, maxWait)
 		opErrs = append(opErrs, errs...)
 		if err != nil {
 			if !force {
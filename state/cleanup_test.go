@@ -18,6 +18,9 @@ import (
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
@@ -34,6 +37,10 @@ type CleanupSuite struct {
 
 var _ = gc.Suite(&CleanupSuite{})
 
+// dontWait is the maxWait used for forced operations whose forced
+// cleanups should run straight away.
+const dontWait = time.Duration(0)
+
 func (s *CleanupSuite) SetUpTest(c *gc.C) {
 	s.ConnSuite.SetUpTest(c)
 	s.assertDoesNotNeedCleanup(c)
@@ -80,6 +87,28 @@ func (s *CleanupSuite) TestCleanupDyingApplicationUnits(c *gc.C) {
 	s.assertCleanupCount(c, 1)
 }
 
+func (s *CleanupSuite) TestPendingCleanups(c *gc.C) {
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	_, err := mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	cleanups, err := s.State.PendingCleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanups, gc.HasLen, 0)
+
+	before := time.Now().Add(-time.Second)
+	err = mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	cleanups, err = s.State.PendingCleanups()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cleanups, gc.HasLen, 1)
+	c.Check(cleanups[0].Kind, gc.Equals, "units")
+	c.Check(cleanups[0].Prefix, gc.Equals, "mysql")
+	c.Check(cleanups[0].When.IsZero(), jc.IsTrue)
+	c.Check(cleanups[0].Scheduled.After(before), jc.IsTrue)
+
+	s.assertCleanupRuns(c)
+}
+
 func (s *CleanupSuite) TestCleanupDyingApplicationCharm(c *gc.C) {
 	// Create a application and a charm.
 	ch := s.AddTestingCharm(c, "mysql")
@@ -231,6 +260,209 @@ func (s *CleanupSuite) TestCleanupModelMachines(c *gc.C) {
 	assertLife(c, stateMachine, state.Alive)
 }
 
+type fakeCloudDestroyer struct {
+	calls   int
+	err     error
+	stopped []instance.Id
+}
+
+func (d *fakeCloudDestroyer) Destroy(context.ProviderCallContext) error {
+	d.calls++
+	return d.err
+}
+
+func (d *fakeCloudDestroyer) AllInstances(context.ProviderCallContext) ([]instances.Instance, error) {
+	return []instances.Instance{fakeInstance{id: "inst-0"}}, nil
+}
+
+func (d *fakeCloudDestroyer) StopInstances(_ context.ProviderCallContext, ids ...instance.Id) error {
+	d.stopped = append(d.stopped, ids...)
+	return nil
+}
+
+// fakeVolumePreservingDestroyer is a fakeCloudDestroyer that can
+// destroy everything but the model's volumes.
+type fakeVolumePreservingDestroyer struct {
+	fakeCloudDestroyer
+	exceptVolumesCalls int
+}
+
+func (d *fakeVolumePreservingDestroyer) DestroyExceptVolumes(context.ProviderCallContext) error {
+	d.exceptVolumesCalls++
+	return d.err
+}
+
+type fakeInstance struct {
+	instances.Instance
+	id instance.Id
+}
+
+func (i fakeInstance) Id() instance.Id {
+	return i.id
+}
+
+func (s *CleanupSuite) forceDestroyStuckModel(c *gc.C, force, destroyStorage bool) *state.State {
+	otherSt := s.Factory.MakeModel(c, nil)
+	_, err := otherSt.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := otherSt.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = model.Destroy(state.DestroyModelParams{
+		Force:          &force,
+		DestroyStorage: &destroyStorage,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The machine is never removed by a provisioner, so the
+	// model is stuck dying.
+	for i := 0; i < 3; i++ {
+		err = otherSt.Cleanup()
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = model.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Life(), gc.Equals, state.Dying)
+	return otherSt
+}
+
+// waitForStuckModel runs the backstop cleanup's first check, which
+// records the model's progress, and moves on to its second check.
+func (s *CleanupSuite) waitForStuckModel(c *gc.C, st *state.State) {
+	s.Clock.Advance(30 * time.Minute)
+	err := st.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(30 * time.Minute)
+}
+
+func (s *CleanupSuite) TestCleanupForceDestroyedModelResources(c *gc.C) {
+	destroyer := &fakeCloudDestroyer{}
+	s.policy.GetCloudDestroyer = func() (environs.CloudDestroyer, error) {
+		return destroyer, nil
+	}
+	otherSt := s.forceDestroyStuckModel(c, true, true)
+	defer otherSt.Close()
+	c.Assert(destroyer.calls, gc.Equals, 0)
+
+	s.waitForStuckModel(c, otherSt)
+	c.Assert(destroyer.calls, gc.Equals, 0)
+	err := otherSt.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(destroyer.calls, gc.Equals, 1)
+	c.Assert(destroyer.stopped, gc.HasLen, 0)
+
+	// The backstop cleanup is done.
+	s.Clock.Advance(30 * time.Minute)
+	err = otherSt.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(destroyer.calls, gc.Equals, 1)
+}
+
+func (s *CleanupSuite) TestCleanupForceDestroyedModelResourcesReleasesStorage(c *gc.C) {
+	destroyer := &fakeCloudDestroyer{}
+	s.policy.GetCloudDestroyer = func() (environs.CloudDestroyer, error) {
+		return destroyer, nil
+	}
+	otherSt := s.forceDestroyStuckModel(c, true, false)
+	defer otherSt.Close()
+
+	s.waitForStuckModel(c, otherSt)
+	err := otherSt.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Destroying the environ would destroy the volumes the user
+	// asked to keep, so only the instances are stopped.
+	c.Assert(destroyer.calls, gc.Equals, 0)
+	c.Assert(destroyer.stopped, jc.DeepEquals, []instance.Id{"inst-0"})
+}
+
+func (s *CleanupSuite) TestCleanupForceDestroyedModelResourcesReleasesStorageKeepingVolumes(c *gc.C) {
+	destroyer := &fakeVolumePreservingDestroyer{}
+	s.policy.GetCloudDestroyer = func() (environs.CloudDestroyer, error) {
+		return destroyer, nil
+	}
+	otherSt := s.forceDestroyStuckModel(c, true, false)
+	defer otherSt.Close()
+
+	s.waitForStuckModel(c, otherSt)
+	err := otherSt.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The provider destroys everything but the volumes the user
+	// asked to keep.
+	c.Assert(destroyer.exceptVolumesCalls, gc.Equals, 1)
+	c.Assert(destroyer.calls, gc.Equals, 0)
+	c.Assert(destroyer.stopped, gc.HasLen, 0)
+}
+
+func (s *CleanupSuite) TestCleanupForceDestroyedModelResourcesWaitsForProgress(c *gc.C) {
+	destroyer := &fakeCloudDestroyer{}
+	s.policy.GetCloudDestroyer = func() (environs.CloudDestroyer, error) {
+		return destroyer, nil
+	}
+	otherSt := s.forceDestroyStuckModel(c, true, true)
+	defer otherSt.Close()
+
+	s.Clock.Advance(30 * time.Minute)
+	err := otherSt.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The model is still making progress, so its resources
+	// are left alone.
+	m, err := otherSt.Machine("0")
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(30 * time.Minute)
+	err = otherSt.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(destroyer.calls, gc.Equals, 0)
+
+	s.Clock.Advance(30 * time.Minute)
+	err = otherSt.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(destroyer.calls, gc.Equals, 1)
+}
+
+func (s *CleanupSuite) TestCleanupForceDestroyedModelResourcesRetriesOnError(c *gc.C) {
+	destroyer := &fakeCloudDestroyer{err: errors.New("boom")}
+	s.policy.GetCloudDestroyer = func() (environs.CloudDestroyer, error) {
+		return destroyer, nil
+	}
+	otherSt := s.forceDestroyStuckModel(c, true, true)
+	defer otherSt.Close()
+
+	s.waitForStuckModel(c, otherSt)
+	err := otherSt.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(destroyer.calls, gc.Equals, 1)
+
+	destroyer.err = nil
+	err = otherSt.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(destroyer.calls, gc.Equals, 2)
+
+	err = otherSt.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(destroyer.calls, gc.Equals, 2)
+}
+
+func (s *CleanupSuite) TestCleanupModelResourcesNotDestroyedWithoutForce(c *gc.C) {
+	destroyer := &fakeCloudDestroyer{}
+	s.policy.GetCloudDestroyer = func() (environs.CloudDestroyer, error) {
+		return destroyer, nil
+	}
+	otherSt := s.forceDestroyStuckModel(c, false, true)
+	defer otherSt.Close()
+
+	s.waitForStuckModel(c, otherSt)
+	err := otherSt.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(destroyer.calls, gc.Equals, 0)
+}
+
 func (s *CleanupSuite) TestCleanupModelApplications(c *gc.C) {
 	s.assertDoesNotNeedCleanup(c)
 
@@ -336,7 +568,7 @@ func (s *CleanupSuite) TestCleanupForceDestroyedMachineUnit(c *gc.C) {
 	s.assertDoesNotNeedCleanup(c)
 
 	// Force machine destruction, check cleanup queued.
-	err = machine.ForceDestroy()
+	err = machine.ForceDestroy(dontWait)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertNeedsCleanup(c)
 
@@ -371,7 +603,7 @@ func (s *CleanupSuite) TestCleanupForceDestroyedControllerMachine(c *gc.C) {
 		m.SetHasVote(true)
 	}
 	s.assertDoesNotNeedCleanup(c)
-	err = machine.ForceDestroy()
+	err = machine.ForceDestroy(dontWait)
 	c.Assert(err, jc.ErrorIsNil)
 	// The machine should no longer want the vote, should be forced to not have the vote, and forced to not be a
 	// controller member anymore
@@ -431,7 +663,7 @@ func (s *CleanupSuite) TestCleanupForceDestroyMachineCleansStorageAttachments(c
 	c.Assert(sa.Life(), gc.Equals, state.Alive)
 
 	// destroy machine and run cleanups
-	err = machine.ForceDestroy()
+	err = machine.ForceDestroy(dontWait)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertCleanupCount(c, 2)
 
@@ -479,13 +711,13 @@ func (s *CleanupSuite) TestCleanupForceDestroyedMachineWithContainer(c *gc.C) {
 	s.assertDoesNotNeedCleanup(c)
 
 	// Force removal of the top-level machine.
-	err = machine.ForceDestroy()
+	err = machine.ForceDestroy(dontWait)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertNeedsCleanup(c)
 
 	// And do it again, just to check that the second cleanup doc for the same
 	// machine doesn't cause problems down the line.
-	err = machine.ForceDestroy()
+	err = machine.ForceDestroy(dontWait)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertNeedsCleanup(c)
 
@@ -511,6 +743,51 @@ func (s *CleanupSuite) TestCleanupForceDestroyedMachineWithContainer(c *gc.C) {
 	assertLife(c, machine, state.Dead)
 }
 
+func (s *CleanupSuite) TestCleanupDrainedRelationUnit(c *gc.C) {
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	eps, err := s.State.InferEndpoints("mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.SetDrainTimeout(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var relUnits []*state.RelationUnit
+	for _, app := range []*state.Application{mysql, wordpress} {
+		unit, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		ru, err := rel.Unit(unit)
+		c.Assert(err, jc.ErrorIsNil)
+		err = ru.EnterScope(nil)
+		c.Assert(err, jc.ErrorIsNil)
+		relUnits = append(relUnits, ru)
+	}
+	s.assertDoesNotNeedCleanup(c)
+
+	err = relUnits[1].DrainScope()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertNeedsCleanup(c)
+
+	// The unit stays in scope until its drain deadline...
+	s.assertCleanupRuns(c)
+	s.assertNeedsCleanup(c)
+	inScope, err := relUnits[1].InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.IsTrue)
+
+	// ...when its departure is completed.
+	s.Clock.Advance(time.Minute)
+	s.assertCleanupRuns(c)
+	s.assertDoesNotNeedCleanup(c)
+	inScope, err = relUnits[1].InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.IsFalse)
+	inScope, err = relUnits[0].InScope()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(inScope, jc.IsTrue)
+}
+
 func (s *CleanupSuite) TestCleanupDyingUnit(c *gc.C) {
 	// Create active unit, in a relation.
 	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
@@ -719,6 +996,44 @@ func (s *CleanupSuite) TestCleanupStorageInstances(c *gc.C) {
 	s.assertDoesNotNeedCleanup(c)
 }
 
+func (s *CleanupSuite) TestCleanupApplicationStorageDisposition(c *gc.C) {
+	ch := s.AddTestingCharm(c, "storage-block")
+	storage := map[string]state.StorageConstraints{
+		"allecto": makeStorageCons("modelscoped-block", 1024, 1),
+	}
+	application := s.AddTestingApplicationWithStorage(c, "storage-block", ch, storage)
+	for i := 0; i < 2; i++ {
+		_, err := application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.assertDoesNotNeedCleanup(c)
+
+	// Destroy allecto/0, and leave allecto/1 in the model.
+	op := application.DestroyOperation()
+	op.StorageDisposition = map[string]state.StorageDisposition{
+		"allecto/0": state.StorageDestroy,
+	}
+	err := s.State.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// First cleanup marks all units of the application as dying.
+	s.assertCleanupRuns(c)
+	// Second cleanup disposes of each unit's storage, and the
+	// third removes the destroyed storage's attachment.
+	s.assertCleanupRuns(c)
+	s.assertCleanupRuns(c)
+
+	si, err := s.storageBackend.StorageInstance(names.NewStorageTag("allecto/0"))
+	if err == nil {
+		c.Assert(si.Life(), gc.Not(gc.Equals), state.Alive)
+	} else {
+		c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	}
+	si, err = s.storageBackend.StorageInstance(names.NewStorageTag("allecto/1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(si.Life(), gc.Equals, state.Alive)
+}
+
 func (s *CleanupSuite) TestCleanupMachineStorage(c *gc.C) {
 	ch := s.AddTestingCharm(c, "storage-block")
 	storage := map[string]state.StorageConstraints{
@@ -952,7 +1267,7 @@ func (s *CleanupSuite) TestDyingUnitWithForceSchedulesForceFallback(c *gc.C) {
 	})
 	c.Assert(err, jc.ErrorIsNil)
 
-	opErrs, err := unit.DestroyWithForce(true)
+	opErrs, err := unit.DestroyWithForce(true, dontWait)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(opErrs, gc.IsNil)
 
@@ -980,6 +1295,45 @@ func (s *CleanupSuite) TestDyingUnitWithForceSchedulesForceFallback(c *gc.C) {
 	s.assertCleanupCount(c, 2)
 }
 
+func (s *CleanupSuite) TestDyingUnitWithForceWaitsMaxWait(c *gc.C) {
+	ch := s.AddTestingCharm(c, "mysql")
+	application := s.AddTestingApplication(c, "mysql", ch)
+	unit, err := application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(unit, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	maxWait := 5 * time.Minute
+	opErrs, err := unit.DestroyWithForce(true, maxWait)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opErrs, gc.IsNil)
+	assertUnitLife(c, unit, state.Dying)
+
+	// dyingUnit
+	s.assertCleanupRuns(c)
+
+	// The forced cleanup is not due until maxWait has passed.
+	s.Clock.Advance(maxWait - time.Second)
+	s.assertCleanupRuns(c)
+	assertUnitLife(c, unit, state.Dying)
+
+	s.Clock.Advance(time.Second)
+	// forceDestroyedUnit
+	s.assertCleanupRuns(c)
+	assertUnitLife(c, unit, state.Dead)
+
+	// forceRemoveUnit waits for maxWait too.
+	s.assertCleanupRuns(c)
+	assertUnitLife(c, unit, state.Dead)
+	s.Clock.Advance(maxWait)
+	s.assertCleanupRuns(c)
+	assertUnitRemoved(c, unit)
+}
+
 func (s *CleanupSuite) TestForceDestroyUnitDestroysSubordinates(c *gc.C) {
 	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeContainer)
 	prr.allEnterScope(c)
@@ -997,7 +1351,7 @@ func (s *CleanupSuite) TestForceDestroyUnitDestroysSubordinates(c *gc.C) {
 	unit := prr.pu0
 	subordinate := prr.ru0
 
-	opErrs, err := unit.DestroyWithForce(true)
+	opErrs, err := unit.DestroyWithForce(true, dontWait)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(opErrs, gc.IsNil)
 
@@ -1056,7 +1410,7 @@ func (s *CleanupSuite) TestForceDestroyUnitLeavesRelations(c *gc.C) {
 	}
 
 	unit := prr.pu0
-	opErrs, err := unit.DestroyWithForce(true)
+	opErrs, err := unit.DestroyWithForce(true, dontWait)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(opErrs, gc.IsNil)
 
@@ -1124,7 +1478,7 @@ func (s *CleanupSuite) TestForceDestroyUnitRemovesStorageAttachments(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	// destroy unit and run cleanups
-	opErrs, err := u.DestroyWithForce(true)
+	opErrs, err := u.DestroyWithForce(true, dontWait)
 	c.Assert(opErrs, gc.IsNil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.assertCleanupRuns(c)
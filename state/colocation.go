@@ -0,0 +1,238 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// ColocationRule records whether the units of two applications may be
+// placed on the same machines.
+type ColocationRule struct {
+	// Applications holds the names of the two applications, in
+	// lexical order.
+	Applications [2]string
+
+	// Affinity is true if the applications' units may share machines,
+	// and false if they must not.
+	Affinity bool
+}
+
+// colocationRuleDoc holds a co-location rule for a pair of
+// applications. The document is removed when the rule is removed, or
+// when either application is removed.
+type colocationRuleDoc struct {
+	DocID        string   `bson:"_id"`
+	ModelUUID    string   `bson:"model-uuid"`
+	Applications []string `bson:"applications"`
+	Affinity     bool     `bson:"affinity"`
+}
+
+// colocationRuleId returns the local id of the co-location rule for
+// the two applications, which is the same whichever order they are
+// given in.
+func colocationRuleId(app1, app2 string) string {
+	if app2 < app1 {
+		app1, app2 = app2, app1
+	}
+	return app1 + "#" + app2
+}
+
+func validateColocationPair(app1, app2 string) error {
+	for _, name := range []string{app1, app2} {
+		if !names.IsValidApplication(name) {
+			return errors.NotValidf("application name %q", name)
+		}
+	}
+	if app1 == app2 {
+		return errors.Errorf("application %q cannot have a co-location rule with itself", app1)
+	}
+	return nil
+}
+
+// SetColocationRule sets whether the units of the two applications may
+// be placed on the same machines, replacing any existing rule for them.
+// The rule is enforced when a unit is assigned to a machine; units
+// already sharing machines are not affected.
+func (st *State) SetColocationRule(app1, app2 string, affinity bool) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set co-location rule for %q and %q", app1, app2)
+	if err := validateColocationPair(app1, app2); err != nil {
+		return errors.Trace(err)
+	}
+	id := colocationRuleId(app1, app2)
+	buildTxn := func(int) ([]txn.Op, error) {
+		var ops []txn.Op
+		for _, name := range []string{app1, app2} {
+			app, err := st.Application(name)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if app.Life() != Alive {
+				return nil, errors.Errorf("application %q is not alive", name)
+			}
+			ops = append(ops, txn.Op{
+				C:      applicationsC,
+				Id:     app.doc.DocID,
+				Assert: isAliveDoc,
+			})
+		}
+		coll, closer := st.db().GetCollection(colocationRulesC)
+		defer closer()
+		n, err := coll.FindId(id).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			pair := []string{app1, app2}
+			sort.Strings(pair)
+			return append(ops, txn.Op{
+				C:      colocationRulesC,
+				Id:     st.docID(id),
+				Assert: txn.DocMissing,
+				Insert: &colocationRuleDoc{
+					Applications: pair,
+					Affinity:     affinity,
+				},
+			}), nil
+		}
+		return append(ops, txn.Op{
+			C:      colocationRulesC,
+			Id:     st.docID(id),
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"affinity", affinity}}}},
+		}), nil
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// RemoveColocationRule removes the co-location rule for the two
+// applications, if there is one.
+func (st *State) RemoveColocationRule(app1, app2 string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot remove co-location rule for %q and %q", app1, app2)
+	if err := validateColocationPair(app1, app2); err != nil {
+		return errors.Trace(err)
+	}
+	err = st.db().RunTransaction([]txn.Op{{
+		C:      colocationRulesC,
+		Id:     st.docID(colocationRuleId(app1, app2)),
+		Assert: txn.DocExists,
+		Remove: true,
+	}})
+	if err == txn.ErrAborted {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// ColocationRules returns the model's co-location rules, ordered by
+// application names.
+func (st *State) ColocationRules() ([]ColocationRule, error) {
+	coll, closer := st.db().GetCollection(colocationRulesC)
+	defer closer()
+
+	var docs []colocationRuleDoc
+	if err := coll.Find(nil).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read co-location rules")
+	}
+	rules := make([]ColocationRule, len(docs))
+	for i, doc := range docs {
+		rules[i].Applications[0] = doc.Applications[0]
+		rules[i].Applications[1] = doc.Applications[1]
+		rules[i].Affinity = doc.Affinity
+	}
+	return rules, nil
+}
+
+// removeColocationRulesOps returns the operations to remove the
+// co-location rules involving the application.
+func removeColocationRulesOps(st *State, application string) ([]txn.Op, error) {
+	coll, closer := st.db().GetCollection(colocationRulesC)
+	defer closer()
+
+	var docs []colocationRuleDoc
+	if err := coll.Find(bson.D{{"applications", application}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "reading application %q co-location rules", application)
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      colocationRulesC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return ops, nil
+}
+
+// colocationOps checks that the model's co-location policy and rules
+// allow the unit to be assigned to the machine, alongside the units of
+// other applications already there. It returns operations asserting
+// that the rules it relied on are unchanged.
+func (u *Unit) colocationOps(m *Machine) ([]txn.Op, error) {
+	others := set.NewStrings()
+	for _, principal := range m.doc.Principals {
+		app, err := names.UnitApplication(principal)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if app != u.doc.Application {
+			others.Add(app)
+		}
+	}
+	if others.IsEmpty() {
+		return nil, nil
+	}
+	cfg, err := u.st.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	strict := cfg.ColocationPolicy() == config.ColocationStrict
+
+	coll, closer := u.st.db().GetCollection(colocationRulesC)
+	defer closer()
+
+	var ops []txn.Op
+	for _, other := range others.SortedValues() {
+		id := colocationRuleId(u.doc.Application, other)
+		var doc colocationRuleDoc
+		err := coll.FindId(id).One(&doc)
+		switch {
+		case err == mgo.ErrNotFound:
+			if strict {
+				return nil, errors.Errorf(
+					"no affinity rule allows applications %q and %q to share machines",
+					u.doc.Application, other,
+				)
+			}
+			ops = append(ops, txn.Op{
+				C:      colocationRulesC,
+				Id:     u.st.docID(id),
+				Assert: txn.DocMissing,
+			})
+		case err != nil:
+			return nil, errors.Trace(err)
+		case !doc.Affinity:
+			return nil, errors.Errorf(
+				"anti-affinity rule prevents applications %q and %q sharing machines",
+				u.doc.Application, other,
+			)
+		default:
+			ops = append(ops, txn.Op{
+				C:      colocationRulesC,
+				Id:     u.st.docID(id),
+				Assert: bson.D{{"affinity", true}},
+			})
+		}
+	}
+	return ops, nil
+}
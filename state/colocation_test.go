@@ -0,0 +1,133 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ColocationSuite struct {
+	ConnSuite
+	wordpress *state.Application
+	mysql     *state.Application
+	machine   *state.Machine
+}
+
+var _ = gc.Suite(&ColocationSuite{})
+
+func (s *ColocationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.mysql = s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+
+	// The machine already hosts a mysql unit.
+	var err error
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	u, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ColocationSuite) assignWordpressUnit(c *gc.C) error {
+	u, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	return u.AssignToMachine(s.machine)
+}
+
+func (s *ColocationSuite) TestSetColocationRule(c *gc.C) {
+	err := s.State.SetColocationRule("wordpress", "mysql", false)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := s.State.ColocationRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []state.ColocationRule{{
+		Applications: [2]string{"mysql", "wordpress"},
+	}})
+
+	// Setting the rule for the pair in either order replaces it.
+	err = s.State.SetColocationRule("mysql", "wordpress", true)
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.State.ColocationRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []state.ColocationRule{{
+		Applications: [2]string{"mysql", "wordpress"},
+		Affinity:     true,
+	}})
+}
+
+func (s *ColocationSuite) TestSetColocationRuleInvalid(c *gc.C) {
+	err := s.State.SetColocationRule("wordpress", "wordpress", true)
+	c.Assert(err, gc.ErrorMatches, `cannot set co-location rule for "wordpress" and "wordpress": application "wordpress" cannot have a co-location rule with itself`)
+	err = s.State.SetColocationRule("wordpress", "Bad", true)
+	c.Assert(err, gc.ErrorMatches, `cannot set co-location rule for "wordpress" and "Bad": application name "Bad" not valid`)
+	err = s.State.SetColocationRule("wordpress", "varnish", true)
+	c.Assert(err, gc.ErrorMatches, `cannot set co-location rule for "wordpress" and "varnish": application "varnish" not found`)
+}
+
+func (s *ColocationSuite) TestRemoveColocationRule(c *gc.C) {
+	err := s.State.SetColocationRule("wordpress", "mysql", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveColocationRule("mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := s.State.ColocationRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	// Removing a missing rule is not an error.
+	err = s.State.RemoveColocationRule("mysql", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ColocationSuite) TestRemoveApplicationRemovesRules(c *gc.C) {
+	err := s.State.SetColocationRule("wordpress", "mysql", true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.wordpress.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := s.State.ColocationRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+}
+
+func (s *ColocationSuite) TestAssignPermissive(c *gc.C) {
+	err := s.assignWordpressUnit(c)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ColocationSuite) TestAssignAntiAffinity(c *gc.C) {
+	err := s.State.SetColocationRule("wordpress", "mysql", false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.assignWordpressUnit(c)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/0" to machine 0: anti-affinity rule prevents applications "wordpress" and "mysql" sharing machines`)
+}
+
+func (s *ColocationSuite) TestAssignStrict(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"colocation-policy": "strict",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.assignWordpressUnit(c)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "wordpress/0" to machine 0: no affinity rule allows applications "wordpress" and "mysql" to share machines`)
+
+	err = s.State.SetColocationRule("wordpress", "mysql", true)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.assignWordpressUnit(c)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ColocationSuite) TestAssignSameApplication(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"colocation-policy": "strict",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	u, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
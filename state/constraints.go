@@ -21,10 +21,14 @@ type constraintsDoc struct {
 	Arch           *string
 	CpuCores       *uint64
 	CpuPower       *uint64
+	CpuFamily      *string
+	DiskIOPS       *uint64
 	Mem            *uint64
+	NetBandwidth   *uint64
 	RootDisk       *uint64
 	RootDiskSource *string
 	InstanceType   *string
+	ImageID        *string
 	Container      *instance.ContainerType
 	Tags           *[]string
 	Spaces         *[]string
@@ -37,10 +41,14 @@ func (doc constraintsDoc) value() constraints.Value {
 		Arch:           doc.Arch,
 		CpuCores:       doc.CpuCores,
 		CpuPower:       doc.CpuPower,
+		CpuFamily:      doc.CpuFamily,
+		DiskIOPS:       doc.DiskIOPS,
 		Mem:            doc.Mem,
+		NetBandwidth:   doc.NetBandwidth,
 		RootDisk:       doc.RootDisk,
 		RootDiskSource: doc.RootDiskSource,
 		InstanceType:   doc.InstanceType,
+		ImageID:        doc.ImageID,
 		Container:      doc.Container,
 		Tags:           doc.Tags,
 		Spaces:         doc.Spaces,
@@ -55,10 +63,14 @@ func newConstraintsDoc(cons constraints.Value) constraintsDoc {
 		Arch:           cons.Arch,
 		CpuCores:       cons.CpuCores,
 		CpuPower:       cons.CpuPower,
+		CpuFamily:      cons.CpuFamily,
+		DiskIOPS:       cons.DiskIOPS,
 		Mem:            cons.Mem,
+		NetBandwidth:   cons.NetBandwidth,
 		RootDisk:       cons.RootDisk,
 		RootDiskSource: cons.RootDiskSource,
 		InstanceType:   cons.InstanceType,
+		ImageID:        cons.ImageID,
 		Container:      cons.Container,
 		Tags:           cons.Tags,
 		Spaces:         cons.Spaces,
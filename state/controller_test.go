@@ -29,8 +29,15 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 	optional := set.NewStrings(
 		controller.IdentityURL,
 		controller.IdentityPublicKey,
+		controller.OIDCIssuerURL,
+		controller.OIDCClientID,
+		controller.OIDCGroupsClaim,
+		controller.OIDCGroupAccess,
+		controller.APIPolicyURL,
+		controller.APIPolicyFailOpen,
 		controller.AutocertURLKey,
 		controller.AutocertDNSNameKey,
+		controller.AutocertChallengeKey,
 		controller.AllowModelAccessKey,
 		controller.MongoMemoryProfile,
 		controller.JujuHASpace,
@@ -40,6 +47,17 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.MaxPruneTxnPasses,
 		controller.PruneTxnQueryCount,
 		controller.PruneTxnSleepTime,
+		controller.PruneTxnInterval,
+		controller.PruneLogsInterval,
+		controller.RaftSnapshotThreshold,
+		controller.RaftTrailingLogs,
+		controller.AgentBinaryBandwidthLimit,
+		controller.ResourceBandwidthLimit,
+		controller.BackupBandwidthLimit,
+		controller.MaxRelationSettingsSize,
+		controller.AgentLogTarget,
+		controller.AgentLogfileMaxSize,
+		controller.AgentLogfileMaxBackups,
 		controller.MaxLogsSize,
 		controller.MaxLogsAge,
 		controller.CAASOperatorImagePath,
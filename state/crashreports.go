@@ -0,0 +1,231 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/crashreport"
+	"github.com/juju/juju/state/storage"
+)
+
+// maxCrashReports is the maximum number of crash reports kept for a
+// model; the oldest are removed first.
+const maxCrashReports = 100
+
+// crashReportDoc records a crash report uploaded by an agent. The
+// report is stored in the blobstore bucket of the model, as JSON.
+type crashReportDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	ReportID  string `bson:"report-id"`
+	Agent     string `bson:"agent"`
+	Version   string `bson:"version"`
+	Panic     string `bson:"panic"`
+	Path      string `bson:"path"`
+	Size      int64  `bson:"size"`
+
+	// Time is the time, in unix nanoseconds, the crash was
+	// captured.
+	Time int64 `bson:"time"`
+
+	// Uploaded is the time, in unix nanoseconds, the report was
+	// uploaded.
+	Uploaded int64 `bson:"uploaded"`
+}
+
+// CrashReportInfo describes a crash report uploaded by an agent.
+type CrashReportInfo struct {
+	ID      string
+	Agent   string
+	Version string
+
+	// Panic is the first line of the panic, or fatal error, that
+	// crashed the agent.
+	Panic string
+
+	// Time is the time the crash was captured.
+	Time time.Time
+
+	// Size is the size of the report, in bytes.
+	Size int64
+
+	// Uploaded is the time the report was uploaded.
+	Uploaded time.Time
+}
+
+// AddCrashReport records the given crash report of an agent of the
+// model, storing it in the blobstore. Adding a report which has
+// already been added does nothing, so agents can safely retry uploads.
+// Only the last reports of the model are kept.
+func (st *State) AddCrashReport(r crashreport.Report) error {
+	if r.ID == "" {
+		return errors.NotValidf("crash report without ID")
+	}
+	if strings.ContainsAny(r.ID, "/\\") {
+		return errors.NotValidf("crash report ID %q", r.ID)
+	}
+	if r.Size() > crashreport.MaxSize {
+		return errors.NotValidf("crash report of %d bytes (maximum %d)", r.Size(), crashreport.MaxSize)
+	}
+
+	reports, closer := st.db().GetRawCollection(crashReportsC)
+	defer closer()
+
+	docID := st.docID(r.ID)
+	if n, err := reports.FindId(docID).Count(); err != nil {
+		return errors.Annotatef(err, "cannot get crash report %q", r.ID)
+	} else if n > 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	path := crashReportPath(r.ID)
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	if err := stor.Put(path, bytes.NewReader(data), int64(len(data))); err != nil {
+		return errors.Annotatef(err, "storing crash report %q", r.ID)
+	}
+	err = reports.Insert(&crashReportDoc{
+		DocID:     docID,
+		ModelUUID: st.ModelUUID(),
+		ReportID:  r.ID,
+		Agent:     r.Agent,
+		Version:   r.Version,
+		Panic:     firstLine(r.Panic),
+		Path:      path,
+		Size:      int64(len(data)),
+		Time:      r.Time.UnixNano(),
+		Uploaded:  st.clock().Now().UnixNano(),
+	})
+	if mgo.IsDup(err) {
+		// Another upload of the same report won the race.
+		return nil
+	} else if err != nil {
+		// Without its document the report can never be found,
+		// so don't leave it behind.
+		if err := stor.Remove(path); err != nil {
+			logger.Warningf("cannot remove unrecorded crash report %q: %v", r.ID, err)
+		}
+		return errors.Annotatef(err, "recording crash report %q", r.ID)
+	}
+	return errors.Trace(st.removeOldCrashReports(reports, stor))
+}
+
+// CrashReports returns information about the crash reports uploaded by
+// the agents of the model, oldest first.
+func (st *State) CrashReports() ([]CrashReportInfo, error) {
+	reports, closer := st.db().GetCollection(crashReportsC)
+	defer closer()
+
+	var docs []crashReportDoc
+	if err := reports.Find(nil).Sort("time", "report-id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get crash reports")
+	}
+	infos := make([]CrashReportInfo, len(docs))
+	for i, doc := range docs {
+		infos[i] = CrashReportInfo{
+			ID:       doc.ReportID,
+			Agent:    doc.Agent,
+			Version:  doc.Version,
+			Panic:    doc.Panic,
+			Time:     time.Unix(0, doc.Time).UTC(),
+			Size:     doc.Size,
+			Uploaded: time.Unix(0, doc.Uploaded).UTC(),
+		}
+	}
+	return infos, nil
+}
+
+// CrashReport returns the crash report with the given ID.
+func (st *State) CrashReport(id string) (crashreport.Report, error) {
+	reports, closer := st.db().GetCollection(crashReportsC)
+	defer closer()
+
+	var doc crashReportDoc
+	err := reports.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return crashreport.Report{}, errors.NotFoundf("crash report %q", id)
+	} else if err != nil {
+		return crashreport.Report{}, errors.Annotatef(err, "cannot get crash report %q", id)
+	}
+
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	rc, _, err := stor.Get(doc.Path)
+	if err != nil {
+		return crashreport.Report{}, errors.Annotatef(err, "reading crash report %q", id)
+	}
+	defer rc.Close()
+	var r crashreport.Report
+	if err := json.NewDecoder(rc).Decode(&r); err != nil {
+		return crashreport.Report{}, errors.Annotatef(err, "reading crash report %q", id)
+	}
+	return r, nil
+}
+
+// removeOldCrashReports removes the model's oldest crash reports,
+// leaving no more than maxCrashReports.
+func (st *State) removeOldCrashReports(reports *mgo.Collection, stor storage.Storage) error {
+	var docs []crashReportDoc
+	err := reports.Find(bson.D{{"model-uuid", st.ModelUUID()}}).
+		Sort("-time", "-report-id").
+		Skip(maxCrashReports).
+		Select(bson.M{"path": 1}).
+		All(&docs)
+	if err != nil {
+		return errors.Annotate(err, "cannot get old crash reports")
+	}
+	for _, doc := range docs {
+		if err := stor.Remove(doc.Path); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing crash report %q", doc.Path)
+		}
+		if err := reports.RemoveId(doc.DocID); err != nil && err != mgo.ErrNotFound {
+			return errors.Annotatef(err, "removing crash report %q", doc.Path)
+		}
+	}
+	return nil
+}
+
+// removeCrashReports removes the model's crash reports from its
+// blobstore bucket. The crash report documents are removed with the
+// model's other documents.
+func (st *State) removeCrashReports() error {
+	reports, closer := st.db().GetCollection(crashReportsC)
+	defer closer()
+
+	var docs []crashReportDoc
+	if err := reports.Find(nil).Select(bson.M{"path": 1}).All(&docs); err != nil {
+		return errors.Annotate(err, "cannot get crash reports")
+	}
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	for _, doc := range docs {
+		if err := stor.Remove(doc.Path); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing crash report %q", doc.Path)
+		}
+	}
+	return nil
+}
+
+// crashReportPath returns the path of the crash report with the given
+// ID in the blobstore bucket of its model.
+func crashReportPath(id string) string {
+	return "crash-reports/" + id + ".json"
+}
+
+// firstLine returns the first line of s.
+func firstLine(s string) string {
+	if i := strings.Index(s, "\n"); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
@@ -0,0 +1,86 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/crashreport"
+)
+
+type CrashReportsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CrashReportsSuite{})
+
+var crashTime = time.Date(2019, 5, 3, 10, 11, 12, 0, time.UTC)
+
+func newCrashReport(agent string, t time.Time) crashreport.Report {
+	return crashreport.New(agent, "2.6.1", t, "panic: boom\n[signal SIGSEGV]", "goroutine 1 [running]:", "log\n")
+}
+
+func (s *CrashReportsSuite) TestAddCrashReport(c *gc.C) {
+	r0 := newCrashReport("unit-mysql-0", crashTime.Add(time.Minute))
+	r1 := newCrashReport("machine-0", crashTime)
+	c.Assert(s.State.AddCrashReport(r0), jc.ErrorIsNil)
+	c.Assert(s.State.AddCrashReport(r1), jc.ErrorIsNil)
+
+	infos, err := s.State.CrashReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, gc.HasLen, 2)
+	c.Check(infos[0].ID, gc.Equals, r1.ID)
+	c.Check(infos[0].Agent, gc.Equals, "machine-0")
+	c.Check(infos[0].Version, gc.Equals, "2.6.1")
+	c.Check(infos[0].Panic, gc.Equals, "panic: boom")
+	c.Check(infos[0].Time, gc.Equals, crashTime)
+	c.Check(infos[0].Size > 0, jc.IsTrue)
+	c.Check(infos[1].ID, gc.Equals, r0.ID)
+
+	r, err := s.State.CrashReport(r0.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r, jc.DeepEquals, r0)
+}
+
+func (s *CrashReportsSuite) TestAddCrashReportTwice(c *gc.C) {
+	r := newCrashReport("machine-0", crashTime)
+	c.Assert(s.State.AddCrashReport(r), jc.ErrorIsNil)
+	c.Assert(s.State.AddCrashReport(r), jc.ErrorIsNil)
+
+	infos, err := s.State.CrashReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, gc.HasLen, 1)
+}
+
+func (s *CrashReportsSuite) TestAddCrashReportInvalid(c *gc.C) {
+	r := newCrashReport("machine-0", crashTime)
+	r.ID = "../machine-0"
+	err := s.State.AddCrashReport(r)
+	c.Assert(err, gc.ErrorMatches, `crash report ID "../machine-0" not valid`)
+
+	r = newCrashReport("machine-0", crashTime)
+	r.Goroutines = strings.Repeat("g", crashreport.MaxSize)
+	err = s.State.AddCrashReport(r)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *CrashReportsSuite) TestCrashReportNotFound(c *gc.C) {
+	_, err := s.State.CrashReport("20190503-101112-deadbeef")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CrashReportsSuite) TestCrashReportsOtherModel(c *gc.C) {
+	c.Assert(s.State.AddCrashReport(newCrashReport("machine-0", crashTime)), jc.ErrorIsNil)
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	infos, err := st.CrashReports()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, gc.HasLen, 0)
+}
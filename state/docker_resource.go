@@ -5,10 +5,14 @@ package state
 
 import (
 	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
 	"encoding/json"
 	"io"
 
 	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
@@ -31,8 +35,29 @@ type dockerMetadataDoc struct {
 	// Username holds the password string for a non-private image.
 	Username string `bson:"username"`
 
-	// Password holds the password string for a non-private image.
+	// Password holds the password string for a non-private image. It is
+	// only set for resources saved before passwords were encrypted.
 	Password string `bson:"password"`
+
+	// EncryptedPassword holds the password for a non-private image,
+	// encrypted with the controller's docker resource key.
+	EncryptedPassword []byte `bson:"encrypted-password,omitempty"`
+}
+
+const (
+	// dockerResourceKeyDocId is the id of the document in the
+	// controllers collection holding the key used to encrypt docker
+	// resource passwords.
+	dockerResourceKeyDocId = "dockerResourceKey"
+
+	dockerResourceKeySize = 32
+)
+
+// dockerResourceKeyDoc holds the key used to encrypt docker resource
+// passwords for all models on the controller.
+type dockerResourceKeyDoc struct {
+	Id  string `bson:"_id"`
+	Key []byte `bson:"key"`
 }
 
 // DockerMetadataStorage provides the interface for storing Docker resource-type data
@@ -55,7 +80,13 @@ func (dr *dockerMetadataStorage) Save(resourceID string, drInfo resources.Docker
 		Id:           resourceID,
 		RegistryPath: drInfo.RegistryPath,
 		Username:     drInfo.Username,
-		Password:     drInfo.Password,
+	}
+	if drInfo.Password != "" {
+		encrypted, err := dr.encryptPassword(drInfo.Password)
+		if err != nil {
+			return errors.Annotate(err, "encrypting Docker resource password")
+		}
+		doc.EncryptedPassword = encrypted
 	}
 
 	buildTxn := func(int) ([]txn.Op, error) {
@@ -75,6 +106,7 @@ func (dr *dockerMetadataStorage) Save(resourceID string, drInfo resources.Docker
 							{"registry-path", doc.RegistryPath},
 							{"username", doc.Username},
 							{"password", doc.Password},
+							{"encrypted-password", doc.EncryptedPassword},
 						},
 					},
 				},
@@ -110,11 +142,17 @@ func (dr *dockerMetadataStorage) Get(resourceID string) (io.ReadCloser, int64, e
 	if err != nil {
 		return nil, -1, errors.Trace(err)
 	}
+	password := doc.Password
+	if len(doc.EncryptedPassword) > 0 {
+		if password, err = dr.decryptPassword(doc.EncryptedPassword); err != nil {
+			return nil, -1, errors.Annotate(err, "decrypting Docker resource password")
+		}
+	}
 	data, err := json.Marshal(
 		resources.DockerImageDetails{
 			RegistryPath: doc.RegistryPath,
 			Username:     doc.Username,
-			Password:     doc.Password,
+			Password:     password,
 		})
 	if err != nil {
 		return nil, -1, errors.Trace(err)
@@ -139,6 +177,83 @@ func (dr *dockerMetadataStorage) get(resourceID string) (*dockerMetadataDoc, err
 	return &doc, nil
 }
 
+// encryptPassword encrypts the password with the controller's docker
+// resource key, returning the nonce followed by the ciphertext.
+func (dr *dockerMetadataStorage) encryptPassword(password string) ([]byte, error) {
+	gcm, err := dr.cipher()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return gcm.Seal(nonce, nonce, []byte(password), nil), nil
+}
+
+// decryptPassword reverses encryptPassword.
+func (dr *dockerMetadataStorage) decryptPassword(encrypted []byte) (string, error) {
+	gcm, err := dr.cipher()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(encrypted) < gcm.NonceSize() {
+		return "", errors.New("encrypted password too short")
+	}
+	nonce, ciphertext := encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():]
+	password, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return string(password), nil
+}
+
+func (dr *dockerMetadataStorage) cipher() (cipher.AEAD, error) {
+	key, err := dr.key()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting Docker resource key")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// key returns the controller's docker resource key, creating it if it
+// doesn't exist yet.
+func (dr *dockerMetadataStorage) key() ([]byte, error) {
+	controllers, closer := dr.st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc dockerResourceKeyDoc
+	buildTxn := func(int) ([]txn.Op, error) {
+		err := controllers.FindId(dockerResourceKeyDocId).One(&doc)
+		if err == nil {
+			return nil, jujutxn.ErrNoOperations
+		} else if err != mgo.ErrNotFound {
+			return nil, errors.Trace(err)
+		}
+		doc = dockerResourceKeyDoc{
+			Id:  dockerResourceKeyDocId,
+			Key: make([]byte, dockerResourceKeySize),
+		}
+		if _, err := rand.Read(doc.Key); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     dockerResourceKeyDocId,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		}}, nil
+	}
+	if err := dr.st.db().Run(buildTxn); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.Key, nil
+}
+
 type dockerResourceReadCloser struct {
 	io.ReadSeeker
 }
@@ -106,6 +106,52 @@ func (s *dockerMetadataStorageSuite) TestGet(c *gc.C) {
 
 }
 
+func (s *dockerMetadataStorageSuite) TestSaveEncryptsPassword(c *gc.C) {
+	id := "test-123"
+	resource := resources.DockerImageDetails{
+		RegistryPath: "url@sha256:abc123",
+		Username:     "testuser",
+		Password:     "hunter2",
+	}
+	err := s.metadataStorage.Save(id, resource)
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll, closer := state.GetCollection(s.State, "dockerResources")
+	defer closer()
+	var raw bson.M
+	err = coll.FindId(id).One(&raw)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(raw["password"], gc.Equals, "")
+	encrypted, ok := raw["encrypted-password"].([]byte)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(bytes.Contains(encrypted, []byte("hunter2")), jc.IsFalse)
+
+	// Rotating the credentials replaces the stored password.
+	resource.Password = "correct-horse"
+	err = s.metadataStorage.Save(id, resource)
+	c.Assert(err, jc.ErrorIsNil)
+	retrieved, _, err := s.metadataStorage.Get(id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readerToDockerDetails(c, retrieved).Password, gc.Equals, "correct-horse")
+}
+
+func (s *dockerMetadataStorageSuite) TestGetUnencryptedPassword(c *gc.C) {
+	coll, closer := state.GetRawCollection(s.State, "dockerResources")
+	defer closer()
+	err := coll.Insert(bson.M{
+		"_id":           s.State.ModelUUID() + ":test-123",
+		"model-uuid":    s.State.ModelUUID(),
+		"registry-path": "url@sha256:abc123",
+		"username":      "testuser",
+		"password":      "hunter2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	retrieved, _, err := s.metadataStorage.Get("test-123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readerToDockerDetails(c, retrieved).Password, gc.Equals, "hunter2")
+}
+
 func (s *dockerMetadataStorageSuite) TestRemove(c *gc.C) {
 	id := "test-123"
 	resource := resources.DockerImageDetails{
@@ -540,7 +540,7 @@ func SpaceDoc(s *Space) spaceDoc {
 }
 
 func ForceDestroyMachineOps(m *Machine) ([]txn.Op, error) {
-	return m.forceDestroyOps()
+	return m.forceDestroyOps(0)
 }
 
 func MakeActionIdConverter(st *State) func(string) (string, error) {
@@ -930,3 +930,9 @@ func (s ModelBackendShim) isController() bool {
 func (s ModelBackendShim) txnLogWatcher() watcher.BaseWatcher {
 	return s.Watcher
 }
+
+// StartMongoUpgradeForMachines starts a mongo upgrade for the given
+// controller machines without checking whether it can go ahead.
+func StartMongoUpgradeForMachines(st *State, check MongoUpgradeCheck, machineIds []string) error {
+	return st.startMongoUpgrade(check, machineIds)
+}
@@ -0,0 +1,156 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// IdleResource records that a machine or unit has been idle since a
+// given time. Idle resources are found by the idle advisor worker, and
+// are only ever reported to users; Juju doesn't act on them.
+type IdleResource struct {
+	// Entity is the tag of the idle machine or unit.
+	Entity names.Tag
+
+	// Since is the time the entity was first found to be idle.
+	Since time.Time
+
+	// Reason describes why the entity is considered idle.
+	Reason string
+}
+
+// IdleFor reports whether the resource had been idle for at least the
+// given duration at the given time.
+func (r IdleResource) IdleFor(d time.Duration, now time.Time) bool {
+	return !now.Before(r.Since.Add(d))
+}
+
+type idleResourceDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+	Entity    string `bson:"entity"`
+	Since     int64  `bson:"since"`
+	Reason    string `bson:"reason"`
+}
+
+func checkIdleResourceEntity(tag names.Tag) error {
+	switch tag.(type) {
+	case names.MachineTag, names.UnitTag:
+		return nil
+	}
+	return errors.NotValidf("idle resource %q", tag)
+}
+
+// IdleResources returns the model's idle machines and units, sorted by
+// entity.
+func (st *State) IdleResources() ([]IdleResource, error) {
+	docs, err := st.idleResourceDocs()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]IdleResource, len(docs))
+	for i, doc := range docs {
+		tag, err := names.ParseTag(doc.Entity)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = IdleResource{
+			Entity: tag,
+			Since:  time.Unix(0, doc.Since).UTC(),
+			Reason: doc.Reason,
+		}
+	}
+	return result, nil
+}
+
+func (st *State) idleResourceDocs() ([]idleResourceDoc, error) {
+	coll, closer := st.db().GetCollection(idleResourcesC)
+	defer closer()
+
+	var docs []idleResourceDoc
+	if err := coll.Find(nil).Sort("entity").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read idle resources")
+	}
+	return docs, nil
+}
+
+// SetIdleResources replaces the model's idle machines and units with
+// the given ones.
+func (st *State) SetIdleResources(resources []IdleResource) error {
+	wanted := make(map[string]idleResourceDoc)
+	for _, r := range resources {
+		if err := checkIdleResourceEntity(r.Entity); err != nil {
+			return errors.Trace(err)
+		}
+		id := st.docID(r.Entity.String())
+		wanted[id] = idleResourceDoc{
+			DocID:     id,
+			ModelUUID: st.ModelUUID(),
+			Entity:    r.Entity.String(),
+			Since:     r.Since.UnixNano(),
+			Reason:    r.Reason,
+		}
+	}
+
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		existing, err := st.idleResourceDocs()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		seen := make(map[string]bool)
+		for _, doc := range existing {
+			seen[doc.DocID] = true
+			w, ok := wanted[doc.DocID]
+			switch {
+			case !ok:
+				ops = append(ops, txn.Op{
+					C:      idleResourcesC,
+					Id:     doc.DocID,
+					Assert: txn.DocExists,
+					Remove: true,
+				})
+			case w.Since != doc.Since || w.Reason != doc.Reason:
+				ops = append(ops, txn.Op{
+					C:      idleResourcesC,
+					Id:     doc.DocID,
+					Assert: txn.DocExists,
+					Update: bson.D{{"$set", bson.D{
+						{"since", w.Since},
+						{"reason", w.Reason},
+					}}},
+				})
+			}
+		}
+		var ids []string
+		for id := range wanted {
+			if !seen[id] {
+				ids = append(ids, id)
+			}
+		}
+		sort.Strings(ids)
+		for _, id := range ids {
+			doc := wanted[id]
+			ops = append(ops, txn.Op{
+				C:      idleResourcesC,
+				Id:     id,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			})
+		}
+		if len(ops) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return ops, nil
+	}
+	return errors.Annotate(st.db().Run(buildTxn), "cannot set idle resources")
+}
@@ -0,0 +1,75 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type IdleResourcesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&IdleResourcesSuite{})
+
+func (s *IdleResourcesSuite) TestIdleResourcesNoneSet(c *gc.C) {
+	resources, err := s.State.IdleResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 0)
+}
+
+func (s *IdleResourcesSuite) TestSetIdleResources(c *gc.C) {
+	since := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.SetIdleResources([]state.IdleResource{
+		{Entity: names.NewUnitTag("mysql/1"), Since: since, Reason: "cpu below 5"},
+		{Entity: names.NewMachineTag("2"), Since: since.Add(time.Hour), Reason: "no units or containers"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	resources, err := s.State.IdleResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, []state.IdleResource{
+		{Entity: names.NewMachineTag("2"), Since: since.Add(time.Hour), Reason: "no units or containers"},
+		{Entity: names.NewUnitTag("mysql/1"), Since: since, Reason: "cpu below 5"},
+	})
+
+	// Resources not given are removed, and those given are updated.
+	err = s.State.SetIdleResources([]state.IdleResource{
+		{Entity: names.NewMachineTag("2"), Since: since, Reason: "no units or containers"},
+		{Entity: names.NewMachineTag("3"), Since: since, Reason: "no units or containers"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	resources, err = s.State.IdleResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, []state.IdleResource{
+		{Entity: names.NewMachineTag("2"), Since: since, Reason: "no units or containers"},
+		{Entity: names.NewMachineTag("3"), Since: since, Reason: "no units or containers"},
+	})
+
+	err = s.State.SetIdleResources(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	resources, err = s.State.IdleResources()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 0)
+}
+
+func (s *IdleResourcesSuite) TestSetIdleResourcesInvalidEntity(c *gc.C) {
+	err := s.State.SetIdleResources([]state.IdleResource{
+		{Entity: names.NewApplicationTag("mysql"), Since: time.Now()},
+	})
+	c.Assert(err, gc.ErrorMatches, `idle resource "application-mysql" not valid`)
+}
+
+func (s *IdleResourcesSuite) TestIdleFor(c *gc.C) {
+	since := time.Date(2019, 5, 1, 12, 0, 0, 0, time.UTC)
+	r := state.IdleResource{Since: since}
+	c.Check(r.IdleFor(48*time.Hour, since.Add(47*time.Hour)), jc.IsFalse)
+	c.Check(r.IdleFor(48*time.Hour, since.Add(48*time.Hour)), jc.IsTrue)
+}
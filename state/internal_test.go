@@ -149,6 +149,10 @@ func (internalStatePolicy) StorageProviderRegistry() (storage.ProviderRegistry,
 	}, nil
 }
 
+func (internalStatePolicy) CloudDestroyer() (environs.CloudDestroyer, error) {
+	return nil, errors.NotImplementedf("CloudDestroyer")
+}
+
 func (internalStatePolicy) ProviderConfigSchemaSource(cloudName string) (config.ConfigSchemaSource, error) {
 	return nil, errors.NotImplementedf("ConfigSchemaSource")
 }
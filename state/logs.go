@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"math"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -196,14 +197,15 @@ func (logger *LastSentLogTracker) Get() (int64, int64, error) {
 // for increased precision.
 // TODO: remove version from this structure: https://pad.lv/1643743
 type logDoc struct {
-	Id       bson.ObjectId `bson:"_id"`
-	Time     int64         `bson:"t"` // unix nano UTC
-	Entity   string        `bson:"n"` // e.g. "machine-0"
-	Version  string        `bson:"r"`
-	Module   string        `bson:"m"` // e.g. "juju.worker.firewaller"
-	Location string        `bson:"l"` // "filename:lineno"
-	Level    int           `bson:"v"`
-	Message  string        `bson:"x"`
+	Id       bson.ObjectId     `bson:"_id"`
+	Time     int64             `bson:"t"` // unix nano UTC
+	Entity   string            `bson:"n"` // e.g. "machine-0"
+	Version  string            `bson:"r"`
+	Module   string            `bson:"m"` // e.g. "juju.worker.firewaller"
+	Location string            `bson:"l"` // "filename:lineno"
+	Level    int               `bson:"v"`
+	Message  string            `bson:"x"`
+	Labels   map[string]string `bson:"lb,omitempty"`
 }
 
 type DbLogger struct {
@@ -251,6 +253,7 @@ func (logger *DbLogger) Log(records []LogRecord) error {
 			Location: r.Location,
 			Level:    int(r.Level),
 			Message:  r.Message,
+			Labels:   r.Labels,
 		})
 	}
 	_, err := bulk.Run()
@@ -310,6 +313,7 @@ type LogRecord struct {
 	Module   string
 	Location string
 	Message  string
+	Labels   map[string]string
 }
 
 // LogTailerParams specifies the filtering a LogTailer should apply to
@@ -324,7 +328,18 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+	// IncludeMessage is a regular expression which the messages of
+	// the log records must match. If empty, all messages match.
+	IncludeMessage string
+	// IncludeLabels holds the labels the log records must have. If
+	// empty, all log records are considered included.
+	IncludeLabels map[string]string
+	// MaxReplayLines bounds the number of existing log records sent
+	// when InitialLines is zero. If more records match, only the most
+	// recent MaxReplayLines are sent. If zero, all matching records
+	// are sent.
+	MaxReplayLines int
+	Oplog          *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -494,6 +509,25 @@ func (t *logTailer) processCollection() error {
 	if t.params.InitialLines > 0 {
 		return t.processReversed(query)
 	}
+	if t.params.MaxReplayLines > 0 {
+		// Find the oldest of the records to replay, and only replay
+		// from it onwards. Skipping over the index is cheap, and
+		// unlike processReversed it doesn't load all the records
+		// into memory.
+		err := t.logsColl.Find(sel).Sort("-t", "-_id").Skip(t.params.MaxReplayLines - 1).Select(bson.M{"t": 1}).One(&doc)
+		switch err {
+		case nil:
+			sel = append(sel, bson.DocElem{"$or", []bson.M{
+				{"t": bson.M{"$gt": doc.Time}},
+				{"t": doc.Time, "_id": bson.M{"$gte": doc.Id}},
+			}})
+			query = t.logsColl.Find(sel)
+		case mgo.ErrNotFound:
+			// There are fewer records than the bound.
+		default:
+			return errors.Trace(err)
+		}
+	}
 	// In tests, sorting by time can leave the result ordering
 	// underconstrained. Since object ids are (timestamp, machine id,
 	// process id, counter)
@@ -643,6 +677,17 @@ func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson
 		sel = append(sel,
 			bson.DocElem{"m", bson.M{"$not": bson.RegEx{Pattern: makeModulePattern(params.ExcludeModule)}}})
 	}
+	if params.IncludeMessage != "" {
+		sel = append(sel, bson.DocElem{"x", bson.RegEx{Pattern: params.IncludeMessage}})
+	}
+	labelKeys := make([]string, 0, len(params.IncludeLabels))
+	for key := range params.IncludeLabels {
+		labelKeys = append(labelKeys, key)
+	}
+	sort.Strings(labelKeys)
+	for _, key := range labelKeys {
+		sel = append(sel, bson.DocElem{"lb." + key, params.IncludeLabels[key]})
+	}
 	if prefix != "" {
 		for i, elem := range sel {
 			sel[i].Name = prefix + elem.Name
@@ -749,6 +794,7 @@ func logDocToRecord(modelUUID string, doc *logDoc) (*LogRecord, error) {
 		Module:   doc.Module,
 		Location: doc.Location,
 		Message:  doc.Message,
+		Labels:   doc.Labels,
 	}
 	return rec, nil
 }
@@ -761,7 +807,8 @@ type DebugLogger interface {
 // PruneLogs removes old log documents in order to control the size of
 // logs collection. All logs older than minLogTime are
 // removed. Further removal is also performed if the logs collection
-// size is greater than maxLogsMB.
+// size is greater than maxLogsMB. The removed logs are archived, and
+// may still be queried with ArchivedLogs.
 func PruneLogs(st ControllerSessioner, minLogTime time.Time, maxLogsMB int, logger DebugLogger) (string, error) {
 	if !st.IsController() {
 		return "", errors.Errorf("pruning logs requires a controller state")
@@ -780,13 +827,11 @@ func PruneLogs(st ControllerSessioner, minLogTime time.Time, maxLogsMB int, logg
 
 	// Remove old log entries for each model.
 	for modelUUID, logColl := range logColls {
-		removeInfo, err := logColl.RemoveAll(bson.M{
-			"t": bson.M{"$lt": minLogTime.UnixNano()},
-		})
+		removed, err := archiveAndRemoveLogs(st, modelUUID, logColl, minLogTime.UnixNano())
 		if err != nil {
 			return "", errors.Annotate(err, "failed to prune logs by time")
 		}
-		pruneCounts[modelUUID] = removeInfo.Removed
+		pruneCounts[modelUUID] = removed
 	}
 
 	// Do further pruning if the total size of the log collections is
@@ -829,13 +874,11 @@ func PruneLogs(st ControllerSessioner, minLogTime time.Time, maxLogsMB int, logg
 		thresholdTs := doc["t"]
 
 		// Remove old records.
-		removeInfo, err := logColl.RemoveAll(bson.M{
-			"t": bson.M{"$lt": thresholdTs},
-		})
+		removed, err := archiveAndRemoveLogs(st, modelUUID, logColl, thresholdTs)
 		if err != nil {
 			return "", errors.Annotate(err, "log pruning failed")
 		}
-		pruneCounts[modelUUID] += removeInfo.Removed
+		pruneCounts[modelUUID] += removed
 	}
 
 	totalRemoved := 0
@@ -864,6 +907,26 @@ func PruneLogs(st ControllerSessioner, minLogTime time.Time, maxLogsMB int, logg
 	return message, nil
 }
 
+// archiveAndRemoveLogs removes the model's log records older than the
+// given timestamp, archiving them first in the model's blobstore bucket
+// so they can still be queried with ArchivedLogs. It returns the number
+// of records removed.
+func archiveAndRemoveLogs(st ControllerSessioner, modelUUID string, logColl *mgo.Collection, before interface{}) (int, error) {
+	session := st.MongoSession()
+	archiver := newHistoryArchiver(
+		session, session.DB(jujuDB).C(archivesC), st.clock(),
+		ArchiveLogs, logArchiveRecord(modelUUID),
+	)
+	iter := logColl.Find(bson.M{
+		"t": bson.M{"$lt": before},
+	}).Sort("t", "_id").Iter()
+	defer iter.Close()
+
+	logTemplate := fmt.Sprintf("log pruning (%s): %%d rows deleted", modelUUID)
+	removed, err := archiveInBatches(logColl, iter, archiver, logTemplate, loggo.DEBUG, noEarlyFinish)
+	return removed, errors.Trace(err)
+}
+
 func initLogsSessionDB(st MongoSessioner) (*mgo.Session, *mgo.Database) {
 	// To improve throughput, only wait for the logs to be written to
 	// the primary. For some reason, this makes a huge difference even
@@ -161,6 +161,7 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 		Location: "bar.go:42",
 		Level:    loggo.ERROR,
 		Message:  "oh noes",
+		Labels:   map[string]string{"stage": "dump"},
 	}})
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -182,6 +183,8 @@ func (s *LogsSuite) TestDbLogger(c *gc.C) {
 	c.Assert(docs[1]["l"], gc.Equals, "bar.go:42")
 	c.Assert(docs[1]["v"], gc.Equals, int(loggo.ERROR))
 	c.Assert(docs[1]["x"], gc.Equals, "oh noes")
+	c.Assert(docs[1]["lb"], jc.DeepEquals, bson.M{"stage": "dump"})
+	c.Assert(docs[0]["lb"], gc.IsNil)
 }
 
 func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
@@ -223,6 +226,58 @@ func (s *LogsSuite) TestPruneLogsByTime(c *gc.C) {
 	}
 }
 
+func (s *LogsSuite) TestPruneLogsArchives(c *gc.C) {
+	dbLogger := state.NewDbLogger(s.State)
+	defer dbLogger.Close()
+	log := func(t time.Time, msg string) {
+		err := dbLogger.Log([]state.LogRecord{{
+			Time:     t,
+			Entity:   names.NewMachineTag("22"),
+			Version:  jujuversion.Current,
+			Module:   "module",
+			Location: "loc",
+			Level:    loggo.INFO,
+			Message:  msg,
+			Labels:   map[string]string{"foo": "bar"},
+		}})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	now := truncateDBTime(coretesting.NonZeroTime())
+	maxLogTime := now.Add(-time.Minute)
+	log(now, "keep")
+	log(maxLogTime.Add(-time.Second), "second")
+	log(maxLogTime.Add(-(2 * time.Second)), "first")
+
+	_, err := state.PruneLogs(s.State, maxLogTime, 100, s.logger)
+	c.Assert(err, jc.ErrorIsNil)
+
+	archives, err := s.State.Archives(state.ArchiveLogs, time.Time{}, time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archives, gc.HasLen, 1)
+	c.Check(archives[0].Count, gc.Equals, 2)
+	c.Check(archives[0].From.Equal(maxLogTime.Add(-(2 * time.Second))), jc.IsTrue)
+	c.Check(archives[0].To.Equal(maxLogTime.Add(-time.Second)), jc.IsTrue)
+
+	// The pruned logs are returned oldest first.
+	records, err := s.State.ArchivedLogs(time.Time{}, time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 2)
+	c.Check(records[0].Message, gc.Equals, "first")
+	c.Check(records[0].Time.Equal(maxLogTime.Add(-(2 * time.Second))), jc.IsTrue)
+	c.Check(records[0].ModelUUID, gc.Equals, s.State.ModelUUID())
+	c.Check(records[0].Entity, gc.Equals, names.NewMachineTag("22"))
+	c.Check(records[0].Version, gc.Equals, jujuversion.Current)
+	c.Check(records[0].Level, gc.Equals, loggo.INFO)
+	c.Check(records[0].Labels, jc.DeepEquals, map[string]string{"foo": "bar"})
+	c.Check(records[1].Message, gc.Equals, "second")
+
+	records, err = s.State.ArchivedLogs(maxLogTime.Add(-time.Second), now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0].Message, gc.Equals, "second")
+}
+
 func (s *LogsSuite) TestPruneLogsBySize(c *gc.C) {
 	// Set up 3 models and generate different amounts of logs
 	// for them.
@@ -555,6 +610,42 @@ func (s *LogTailerSuite) TestInitialLines(c *gc.C) {
 	s.assertTailer(c, tailer, 5, expected)
 }
 
+func (s *LogTailerSuite) TestMaxReplayLines(c *gc.C) {
+	expected := logTemplate{Message: "want"}
+	s.writeLogs(c, s.otherUUID, 3, logTemplate{Message: "dont want"})
+	s.writeLogs(c, s.otherUUID, 5, expected)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		MaxReplayLines: 5,
+		NoTail:         true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// Should see just the last 5 lines, and then the end of the logs.
+	s.assertTailer(c, tailer, 5, expected)
+	select {
+	case _, ok := <-tailer.Logs():
+		c.Assert(ok, jc.IsFalse)
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs to finish")
+	}
+}
+
+func (s *LogTailerSuite) TestMaxReplayLinesWithNotEnoughLines(c *gc.C) {
+	expected := logTemplate{Message: "want"}
+	s.writeLogs(c, s.otherUUID, 2, expected)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		MaxReplayLines: 5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// Should see all the lines, even though fewer than the bound.
+	s.assertTailer(c, tailer, 2, expected)
+}
+
 func (s *LogTailerSuite) TestRecordsAddedOutOfTimeOrder(c *gc.C) {
 	format := "2006-01-02 03:04"
 	t1, err := time.Parse(format, "2016-11-25 09:10")
@@ -785,6 +876,45 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestIncludeMessage(c *gc.C) {
+	started := logTemplate{Message: "worker started"}
+	failed := logTemplate{Message: "worker failed: boom"}
+	stopped := logTemplate{Message: "worker stopped"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, started)
+		s.writeLogs(c, s.otherUUID, 1, failed)
+		s.writeLogs(c, s.otherUUID, 1, stopped)
+	}
+	params := state.LogTailerParams{
+		IncludeMessage: "(failed|stopped)",
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, failed)
+		s.assertTailer(c, tailer, 1, stopped)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestIncludeLabels(c *gc.C) {
+	dump := logTemplate{Message: "backup", Labels: map[string]string{"db": "main", "stage": "dump"}}
+	upload := logTemplate{Message: "backup", Labels: map[string]string{"db": "main", "stage": "upload"}}
+	other := logTemplate{Message: "backup", Labels: map[string]string{"db": "other", "stage": "dump"}}
+	unlabelled := logTemplate{Message: "backup"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 1, dump)
+		s.writeLogs(c, s.otherUUID, 1, upload)
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 1, unlabelled)
+	}
+	params := state.LogTailerParams{
+		IncludeLabels: map[string]string{"db": "main", "stage": "dump"},
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, dump)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -814,6 +944,7 @@ type logTemplate struct {
 	Location string
 	Level    loggo.Level
 	Message  string
+	Labels   map[string]string
 }
 
 // emptyTag gives us an explicit way to specify an empty tag for the
@@ -895,7 +1026,7 @@ func (s *LogTailerSuite) normaliseLogTemplate(lt *logTemplate) {
 
 func (s *LogTailerSuite) logTemplateToDoc(lt logTemplate, t time.Time) interface{} {
 	s.normaliseLogTemplate(&lt)
-	return state.MakeLogDoc(
+	doc := state.MakeLogDoc(
 		lt.Entity,
 		t,
 		lt.Module,
@@ -903,6 +1034,8 @@ func (s *LogTailerSuite) logTemplateToDoc(lt logTemplate, t time.Time) interface
 		lt.Level,
 		lt.Message,
 	)
+	doc.Labels = lt.Labels
+	return doc
 }
 
 func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedCount int, lt logTemplate) {
@@ -922,6 +1055,7 @@ func (s *LogTailerSuite) assertTailer(c *gc.C, tailer state.LogTailer, expectedC
 			c.Assert(log.Location, gc.Equals, lt.Location)
 			c.Assert(log.Level, gc.Equals, lt.Level)
 			c.Assert(log.Message, gc.Equals, lt.Message)
+			c.Assert(log.Labels, jc.DeepEquals, lt.Labels)
 			count++
 			if count == expectedCount {
 				return
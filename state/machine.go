@@ -111,6 +111,10 @@ type machineDoc struct {
 	PasswordHash  string
 	Clean         bool
 
+	// Spare is true if the machine was added to the model's pool of
+	// spare machines, and has not yet had a unit assigned to it.
+	Spare bool `bson:"spare,omitempty"`
+
 	// Volumes contains the names of volumes attached to the machine.
 	Volumes []string `bson:"volumes,omitempty"`
 	// Filesystems contains the names of filesystems attached to the machine.
@@ -140,6 +144,10 @@ type machineDoc struct {
 	// an instance for the machine.
 	Placement string `bson:",omitempty"`
 
+	// CloudInitUserData holds cloud-init user data specific to the
+	// machine, in the form accepted by the cloudinit-userdata model config.
+	CloudInitUserData string `bson:"cloudinit-userdata,omitempty"`
+
 	// StopMongoUntilVersion holds the version that must be checked to
 	// know if mongo must be stopped.
 	StopMongoUntilVersion string `bson:",omitempty"`
@@ -241,6 +249,11 @@ type instanceData struct {
 	// CharmProfiles contains the names of LXD profiles used by this machine.
 	// Profiles would have been defined in the charm deployed to this machine.
 	CharmProfiles []string `bson:"charm-profiles,omitempty"`
+
+	// NetworkConfigDrift describes the differences last found between
+	// the network configuration observed on the machine and the one
+	// recorded for it.
+	NetworkConfigDrift []string `bson:"network-config-drift,omitempty"`
 }
 
 func hardwareCharacteristics(instData instanceData) *instance.HardwareCharacteristics {
@@ -380,6 +393,47 @@ func (m *Machine) SetCharmProfiles(profiles []string) error {
 	return errors.Annotatef(err, "cannot update profiles for %q to %s", m, strings.Join(profiles, ", "))
 }
 
+// NetworkConfigDrift returns the differences last found between the
+// network configuration observed on the machine and the link-layer
+// devices and addresses recorded for it.
+func (m *Machine) NetworkConfigDrift() ([]string, error) {
+	instData, err := getInstanceData(m.st, m.Id())
+	if errors.IsNotFound(err) {
+		err = errors.NotProvisionedf("machine %v", m.Id())
+	}
+	if err != nil {
+		return nil, err
+	}
+	return instData.NetworkConfigDrift, nil
+}
+
+// SetNetworkConfigDrift records the differences found between the
+// network configuration observed on the machine and the one recorded
+// for it in its instanceData. An empty drift clears any recorded one.
+func (m *Machine) SetNetworkConfigDrift(drift []string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		current, err := m.NetworkConfigDrift()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if strings.Join(current, "\n") == strings.Join(drift, "\n") {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update := bson.D{{"$set", bson.D{{"network-config-drift", drift}}}}
+		if len(drift) == 0 {
+			update = bson.D{{"$unset", bson.D{{"network-config-drift", nil}}}}
+		}
+		return []txn.Op{{
+			C:      instanceDataC,
+			Id:     m.doc.DocID,
+			Assert: txn.DocExists,
+			Update: update,
+		}}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot set network config drift for %q", m)
+}
+
 // WantsVote reports whether the machine is a controller
 // that wants to take part in peer voting.
 func (m *Machine) WantsVote() bool {
@@ -445,6 +499,12 @@ func (m *Machine) IsManager() bool {
 	return hasJob(m.doc.Jobs, JobManageModel)
 }
 
+// IsSpare returns true if the machine is in the model's pool of spare
+// machines, waiting for a unit to be assigned to it.
+func (m *Machine) IsSpare() bool {
+	return m.doc.Spare
+}
+
 // IsManual returns true if the machine was manually provisioned.
 func (m *Machine) IsManual() (bool, error) {
 	// Apart from the bootstrap machine, manually provisioned
@@ -579,9 +639,11 @@ func (m *Machine) Destroy() error {
 }
 
 // ForceDestroy queues the machine for complete removal, including the
-// destruction of all units and containers on the machine.
-func (m *Machine) ForceDestroy() error {
-	ops, err := m.forceDestroyOps()
+// destruction of all units and containers on the machine. The forced
+// cleanups of those units wait for maxWait, giving their agents a
+// chance to remove them gracefully.
+func (m *Machine) ForceDestroy(maxWait time.Duration) error {
+	ops, err := m.forceDestroyOps(maxWait)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -591,7 +653,7 @@ func (m *Machine) ForceDestroy() error {
 	return nil
 }
 
-func (m *Machine) forceDestroyOps() ([]txn.Op, error) {
+func (m *Machine) forceDestroyOps(maxWait time.Duration) ([]txn.Op, error) {
 	if m.IsManager() {
 		controllerInfo, err := m.st.ControllerInfo()
 		if err != nil {
@@ -628,7 +690,7 @@ func (m *Machine) forceDestroyOps() ([]txn.Op, error) {
 		return []txn.Op{
 			machineOp,
 			controllerOp,
-			newCleanupOp(cleanupForceDestroyedMachine, m.doc.Id),
+			newCleanupOp(cleanupForceDestroyedMachine, m.doc.Id, maxWait),
 		}, nil
 	} else {
 		// Make sure the machine doesn't become a manager while we're destroying it
@@ -636,7 +698,7 @@ func (m *Machine) forceDestroyOps() ([]txn.Op, error) {
 			C:      machinesC,
 			Id:     m.doc.DocID,
 			Assert: bson.D{{"jobs", bson.D{{"$nin", []MachineJob{JobManageModel}}}}},
-		}, newCleanupOp(cleanupForceDestroyedMachine, m.doc.Id),
+		}, newCleanupOp(cleanupForceDestroyedMachine, m.doc.Id, maxWait),
 		}, nil
 	}
 }
@@ -1652,7 +1714,9 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
+func (m *Machine) setPublicAddressOps(
+	policy network.AddressSelectionPolicy, providerAddresses []address, machineAddresses []address,
+) ([]txn.Op, *address) {
 	publicAddress := m.doc.PreferredPublicAddress
 	logger.Tracef(
 		"machine %v: current public address: %#v \nprovider addresses: %#v \nmachine addresses: %#v",
@@ -1664,7 +1728,7 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectPublicAddress(networkAddresses(addresses))
+		addr, _ := policy.SelectPublicAddress(networkAddresses(addresses))
 		return addr
 	}
 
@@ -1678,7 +1742,9 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	return ops, &newAddr
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
+func (m *Machine) setPrivateAddressOps(
+	policy network.AddressSelectionPolicy, providerAddresses []address, machineAddresses []address,
+) ([]txn.Op, *address) {
 	privateAddress := m.doc.PreferredPrivateAddress
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
@@ -1687,7 +1753,7 @@ func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddre
 	}
 	// Without an exact match, prefer a fallback match.
 	getAddr := func(addresses []address) network.Address {
-		addr, _ := network.SelectInternalAddress(networkAddresses(addresses), false)
+		addr, _ := policy.SelectInternalAddress(networkAddresses(addresses), false)
 		return addr
 	}
 
@@ -1790,6 +1856,15 @@ func (m *Machine) setAddressesOps(
 	if m.doc.Life == Dead {
 		return nil, nil, nil, nil, nil, ErrDead
 	}
+	model, err := m.st.Model()
+	if err != nil {
+		return nil, nil, nil, nil, nil, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return nil, nil, nil, nil, nil, errors.Trace(err)
+	}
+	policy := cfg.AddressSelectionPolicy()
 
 	fromNetwork := func(in []network.Address, origin Origin) []address {
 		sorted := make([]network.Address, len(in))
@@ -1817,8 +1892,8 @@ func (m *Machine) setAddressesOps(
 		Update: bson.D{{"$set", set}},
 	}}
 
-	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses)
-	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses)
+	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(policy, providerStateAddresses, machineStateAddresses)
+	setPublicAddressOps, newPublic := m.setPublicAddressOps(policy, providerStateAddresses, machineStateAddresses)
 	ops = append(ops, setPrivateAddressOps...)
 	ops = append(ops, setPublicAddressOps...)
 	return ops, machineStateAddresses, providerStateAddresses, newPrivate, newPublic, nil
@@ -1840,6 +1915,12 @@ func (m *Machine) Placement() string {
 	return m.doc.Placement
 }
 
+// CloudInitUserData returns the cloud-init user data specific to the
+// machine, to be merged with that of the model when it is provisioned.
+func (m *Machine) CloudInitUserData() string {
+	return m.doc.CloudInitUserData
+}
+
 // Constraints returns the exact constraints that should apply when provisioning
 // an instance for the machine.
 func (m *Machine) Constraints() (constraints.Value, error) {
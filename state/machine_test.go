@@ -148,6 +148,29 @@ func (s *MachineSuite) TestSetCharmProfile(c *gc.C) {
 	c.Assert(expectedProfiles, jc.SameContents, obtainedProfiles)
 }
 
+func (s *MachineSuite) TestSetNetworkConfigDrift(c *gc.C) {
+	_, err := s.machine.NetworkConfigDrift()
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+
+	err = s.machine.SetProvisioned("1234", "", "nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	drift := []string{`device "eth1" not observed`}
+	err = s.machine.SetNetworkConfigDrift(drift)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m, err := s.State.Machine(s.machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	obtained, err := m.NetworkConfigDrift()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained, jc.DeepEquals, drift)
+
+	err = m.SetNetworkConfigDrift(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	obtained, err = m.NetworkConfigDrift()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained, gc.HasLen, 0)
+}
+
 func (s *MachineSuite) TestSetUpgradeCharmProfileWithoutLXDProfileHasExisting(c *gc.C) {
 	s.testSetUpgradeCharmProfileWithoutLXDProfile(c, []string{"juju-default-appname-0"})
 	s.assertUpgradeCharmProfileNotRequired(c, "lxd-profile/0")
@@ -508,7 +531,7 @@ func (s *MachineSuite) TestLifeJobManageModel(c *gc.C) {
 	err = m.EnsureDead()
 	c.Assert(err, gc.ErrorMatches, "machine 0 is still a voting controller member")
 	// Since this is the only controller machine, we cannot even force destroy it
-	err = m.ForceDestroy()
+	err = m.ForceDestroy(dontWait)
 	c.Assert(err, gc.ErrorMatches, "machine 0 is the only controller machine")
 	err = m.EnsureDead()
 	c.Assert(err, gc.ErrorMatches, "machine 0 is still a voting controller member")
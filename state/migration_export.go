@@ -16,6 +16,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
 
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/payload"
 	"github.com/juju/juju/resource"
@@ -120,7 +121,7 @@ func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 		})
 	}
 	modelKey := dbModel.globalKey()
-	export.model.SetAnnotations(export.getAnnotations(modelKey))
+	export.model.SetAnnotations(export.modelAnnotations(modelKey))
 	if err := export.sequences(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -493,7 +494,16 @@ func (e *exporter) openedPortsArgsForMachine(machineId string, portsData []ports
 		// Don't bother including a subnet if there are no ports open on it.
 		if doc.MachineID == machineId && len(doc.Ports) > 0 {
 			args := description.OpenedPortsArgs{SubnetID: doc.SubnetID}
+			seen := make(map[PortRange]bool)
 			for _, p := range doc.Ports {
+				// The model description has no notion of endpoints,
+				// so ranges opened for specific endpoints are exported
+				// once, as opened for all of the unit's endpoints.
+				p = p.withoutEndpoint()
+				if seen[p] {
+					continue
+				}
+				seen[p] = true
 				args.OpenedPorts = append(args.OpenedPorts, description.PortRangeArgs{
 					UnitName: p.UnitName,
 					FromPort: p.FromPort,
@@ -1455,6 +1465,21 @@ func (e *exporter) readAllConstraints() error {
 	return nil
 }
 
+// modelAnnotations returns the model's annotations, along with the
+// annotation carrying its pinned agent version, if it is pinned.
+func (e *exporter) modelAnnotations(key string) map[string]string {
+	annotations := e.getAnnotations(key)
+	pinned, isPinned := e.dbModel.PinnedAgentVersion()
+	if !isPinned {
+		return annotations
+	}
+	result := map[string]string{migration.PinnedAgentVersionAnnotation: pinned.String()}
+	for k, v := range annotations {
+		result[k] = v
+	}
+	return result
+}
+
 // getAnnotations doesn't really care if there are any there or not
 // for the key, but if they were there, they are removed so we can
 // check at the end of the export for anything we have forgotten.
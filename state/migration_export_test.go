@@ -215,6 +215,26 @@ func (s *MigrationExportSuite) TestModelInfo(c *gc.C) {
 	})
 }
 
+func (s *MigrationExportSuite) TestModelPinnedAgentVersion(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	dbModel, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = dbModel.SetAnnotations(dbModel, testAnnotations)
+	c.Assert(err, jc.ErrorIsNil)
+	err = dbModel.SetPinnedAgentVersion(version.MustParse("2.6.8"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := st.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := map[string]string{"juju.pinned-agent-version": "2.6.8"}
+	for key, value := range testAnnotations {
+		expected[key] = value
+	}
+	c.Assert(model.Annotations(), jc.DeepEquals, expected)
+}
+
 func (s *MigrationExportSuite) TestModelUsers(c *gc.C) {
 	// Make sure we have some last connection times for the admin user,
 	// and create a few other users.
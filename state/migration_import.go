@@ -21,6 +21,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
@@ -236,7 +237,21 @@ func (i *importer) modelExtras() error {
 		}
 	}
 
-	if annotations := i.model.Annotations(); len(annotations) > 0 {
+	annotations := make(map[string]string)
+	for key, value := range i.model.Annotations() {
+		if key != migration.PinnedAgentVersionAnnotation {
+			annotations[key] = value
+			continue
+		}
+		pinned, err := version.Parse(value)
+		if err != nil {
+			return errors.Annotate(err, "pinned agent version")
+		}
+		if err := i.dbModel.SetPinnedAgentVersion(pinned); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(annotations) > 0 {
 		if err := i.dbModel.SetAnnotations(i.dbModel, annotations); err != nil {
 			return errors.Trace(err)
 		}
@@ -226,6 +226,25 @@ func (s *MigrationImportSuite) AssertUserEqual(c *gc.C, newUser, oldUser permiss
 	}
 }
 
+func (s *MigrationImportSuite) TestModelPinnedAgentVersion(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	original, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = original.SetAnnotations(original, testAnnotations)
+	c.Assert(err, jc.ErrorIsNil)
+	err = original.SetPinnedAgentVersion(version.MustParse("2.6.8"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModel, _ := s.importModel(c, st)
+
+	pinned, ok := newModel.PinnedAgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(pinned, gc.Equals, version.MustParse("2.6.8"))
+	// The annotation carrying the version isn't imported.
+	s.assertAnnotations(c, newModel, newModel)
+}
+
 func (s *MigrationImportSuite) TestModelUsers(c *gc.C) {
 	// To be sure with this test, we create three env users, and remove
 	// the owner.
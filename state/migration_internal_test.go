@@ -106,6 +106,10 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
+		// API sessions and login revocations belong to the
+		// controller's users and API servers.
+		apiSessionsC,
+		loginRevocationsC,
 		// Controller users contain extra data about users therefore
 		// are not migrated either.
 		controllerUsersC,
@@ -199,6 +203,32 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// Resources are transferred separately
 		"storedResources",
+
+		// Idle resources are found again by the idle advisor once
+		// the model has been migrated.
+		idleResourcesC,
+
+		// Autoscale policies are not part of the model description, so
+		// the migration precheck refuses to migrate autoscaled
+		// applications.
+		autoscalePoliciesC,
+
+		// Co-location rules are not part of the model description, so
+		// the migration precheck refuses to migrate models that have
+		// them.
+		colocationRulesC,
+
+		// Model plans are only valid for the model they were
+		// computed against, so they are not migrated.
+		modelPlansC,
+
+		// Archives of pruned status history and logs are kept
+		// by the controller the model was archived on.
+		archivesC,
+
+		// Crash reports are kept by the controller they were
+		// uploaded to.
+		crashReportsC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -259,6 +289,11 @@ func (s *MigrationSuite) TestModelDocFields(c *gc.C) {
 		"SLA",
 		"MeterStatus",
 		"EnvironVersion",
+		// PinnedAgentVersion is exported as a model annotation.
+		"PinnedAgentVersion",
+		// QuotaSerial only guards transactions against quotas
+		// being set concurrently; quotas are controller-wide.
+		"QuotaSerial",
 	)
 	s.AssertExportedFields(c, modelDoc{}, fields)
 }
@@ -321,6 +356,12 @@ func (s *MigrationSuite) TestMachineDocFields(c *gc.C) {
 		// Ignored at this stage, could be an issue if mongo 3.0 isn't
 		// available.
 		"StopMongoUntilVersion",
+		// Machine cloud-init user data is only used when provisioning,
+		// and the machines of a migrated model are already provisioned.
+		"CloudInitUserData",
+		// Spare machines become ordinary clean machines in the
+		// target model, which replenishes its own pool.
+		"Spare",
 	)
 	migrated := set.NewStrings(
 		"Addresses",
@@ -349,6 +390,9 @@ func (s *MigrationSuite) TestInstanceDataFields(c *gc.C) {
 		// KeepInstance is only set when a machine is
 		// dying/dead (to be removed).
 		"KeepInstance",
+		// NetworkConfigDrift is detected again by the
+		// machine agent once the model has been migrated.
+		"NetworkConfigDrift",
 	)
 	migrated := set.NewStrings(
 		// DocID is the model + machine id
@@ -403,6 +447,18 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// RefreshPolicy is not yet part of the model description; migrated
+		// applications go back to only being notified of new revisions.
+		"RefreshPolicy",
+		// Pausing is a temporary measure taken by operators, and is not
+		// migrated; the migration precheck refuses paused applications
+		// and units.
+		"Paused",
+		// Load balancers are not part of the model description, so
+		// the migration precheck refuses to migrate applications
+		// that have one.
+		"LoadBalanced",
+		"LoadBalancerAddresses",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -436,6 +492,10 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Series",
 		"CharmURL",
 		"TxnRevno",
+		// Pausing is a temporary measure taken by operators, and is not
+		// migrated; the migration precheck refuses paused applications
+		// and units.
+		"Paused",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -496,6 +556,9 @@ func (s *MigrationSuite) TestRelationDocFields(c *gc.C) {
 		// UnitCount isn't explicitly exported, but defined by the stored
 		// unit settings data for the relation endpoint.
 		"UnitCount",
+		// DrainTimeout is not part of the model description, so the
+		// migration precheck refuses to migrate relations that have one.
+		"DrainTimeout",
 	)
 	s.AssertExportedFields(c, relationDoc{}, fields)
 	// We also need to check the Endpoint and nested charm.Relation field.
@@ -522,6 +585,8 @@ func (s *MigrationSuite) TestRelationScopeDocFields(c *gc.C) {
 		"Key",
 		// Departing isn't exported as we only deal with live, stable systems.
 		"Departing",
+		// DrainDeadline isn't exported for the same reason.
+		"DrainDeadline",
 	)
 	s.AssertExportedFields(c, relationScopeDoc{}, fields)
 }
@@ -587,6 +652,15 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Spaces",
 		"VirtType",
 		"Zones",
+		// TODO(image-id): the description package doesn't yet
+		// support image-id constraints, so they aren't migrated.
+		"ImageID",
+		// TODO(provider-constraints): the description package doesn't
+		// yet support the provider specific constraints, so they
+		// aren't migrated.
+		"CpuFamily",
+		"DiskIOPS",
+		"NetBandwidth",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
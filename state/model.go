@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
@@ -112,6 +113,14 @@ type modelDoc struct {
 
 	// MeterStatus is the current meter status of the model.
 	MeterStatus modelMeterStatusdoc `bson:"meter-status"`
+
+	// PinnedAgentVersion, if set, is the only agent version the model
+	// can be upgraded to.
+	PinnedAgentVersion string `bson:"pinned-agent-version,omitempty"`
+
+	// QuotaSerial is incremented whenever a quota is set on the
+	// model, or on its owner, where there was none before.
+	QuotaSerial int64 `bson:"quota-serial,omitempty"`
 }
 
 // slaLevel enumerates the support levels available to a model.
@@ -621,6 +630,38 @@ func (m *Model) SetMigrationMode(mode MigrationMode) error {
 	return m.Refresh()
 }
 
+// PinnedAgentVersion returns the agent version the model is pinned to,
+// and whether it is pinned at all.
+func (m *Model) PinnedAgentVersion() (version.Number, bool) {
+	if m.doc.PinnedAgentVersion == "" {
+		return version.Zero, false
+	}
+	return version.MustParse(m.doc.PinnedAgentVersion), true
+}
+
+// SetPinnedAgentVersion pins the model's agent version, so that it
+// can't be upgraded to any other version until it is unpinned. Passing
+// version.Zero unpins the model. The controller model can't be pinned.
+func (m *Model) SetPinnedAgentVersion(v version.Number) error {
+	update := bson.D{{"$unset", bson.D{{"pinned-agent-version", 1}}}}
+	if v != version.Zero {
+		if m.IsControllerModel() {
+			return errors.New("cannot pin the agent version of the controller model")
+		}
+		update = bson.D{{"$set", bson.D{{"pinned-agent-version", v.String()}}}}
+	}
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     m.doc.UUID,
+		Assert: txn.DocExists,
+		Update: update,
+	}}
+	if err := m.st.db().RunTransaction(ops); err != nil {
+		return errors.Trace(err)
+	}
+	return m.Refresh()
+}
+
 // Life returns whether the model is Alive, Dying or Dead.
 func (m *Model) Life() Life {
 	return m.doc.Life
@@ -969,6 +1010,19 @@ type DestroyModelParams struct {
 	// models), an error satisfying IsHasPersistentStorageError
 	// will be returned.
 	DestroyStorage *bool
+
+	// Force specifies whether model destruction will be forced,
+	// i.e. keep going despite operational errors. Forcing the
+	// destruction of a hosted IAAS model also schedules a backstop
+	// cleanup that reclaims the model's provider resources if the
+	// model gets stuck dying.
+	Force *bool
+
+	// MaxWait specifies the amount of time that each step in the
+	// model destruction process will wait before forcing the next
+	// step to kick-off. This parameter only makes sense in
+	// combination with Force set to true.
+	MaxWait time.Duration
 }
 
 func (m *Model) uniqueIndexID() string {
@@ -1120,6 +1174,7 @@ func (m *Model) destroyOps(
 	isEmpty := true
 	modelUUID := m.UUID()
 	nextLife := Dying
+	force := args.Force != nil && *args.Force
 
 	prereqOps, err := checkModelEntityRefsEmpty(modelEntityRefs)
 	if err != nil {
@@ -1288,10 +1343,10 @@ func (m *Model) destroyOps(
 		// that case we'll get errors if we try to enqueue hosted-model
 		// cleanups, because the cleanups collection is non-global.
 		ops = append(ops,
-			newCleanupOp(cleanupApplicationsForDyingModel, modelUUID),
+			newCleanupOp(cleanupApplicationsForDyingModel, modelUUID, args),
 		)
 		if m.Type() == ModelTypeIAAS {
-			ops = append(ops, newCleanupOp(cleanupMachinesForDyingModel, modelUUID))
+			ops = append(ops, newCleanupOp(cleanupMachinesForDyingModel, modelUUID, args))
 		}
 		if args.DestroyStorage != nil {
 			// The user has specified that the storage should be destroyed
@@ -1304,10 +1359,21 @@ func (m *Model) destroyOps(
 				// cleanup, so the storage can be destroyed/released
 				// according to the parameters.
 				*args.DestroyStorage,
-				// TODO (anastasiamac 2019-04-04) Once we implement
-				// 'destroy-model --force' this will be passed in from the user.
-				// For now, hardcode.
-				false,
+				force,
+				args.MaxWait,
+			))
+		}
+		if force && m.Type() == ModelTypeIAAS && !m.IsControllerModel() {
+			// Destroying the controller model's provider resources
+			// would take the controller down with it, so only hosted
+			// models get the backstop. The model's volumes are only
+			// destroyed along with its other provider resources if
+			// the user asked for the storage to be destroyed.
+			destroyStorage := args.DestroyStorage != nil && *args.DestroyStorage
+			deadline := m.st.stateClock.Now().Add(args.MaxWait + modelResourcesBackstopTimeout)
+			ops = append(ops, newCleanupAtOp(
+				deadline, cleanupForceDestroyedModelResources, modelUUID,
+				destroyStorage,
 			))
 		}
 	}
@@ -12,6 +12,7 @@ import (
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
+	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/mgo.v2/bson"
@@ -345,6 +346,32 @@ func (s *ModelSuite) TestSetMigrationMode(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeExporting)
 }
 
+func (s *ModelSuite) TestSetPinnedAgentVersion(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, pinned := model.PinnedAgentVersion()
+	c.Assert(pinned, jc.IsFalse)
+
+	err = model.SetPinnedAgentVersion(version.MustParse("2.6.8"))
+	c.Assert(err, jc.ErrorIsNil)
+	v, pinned := model.PinnedAgentVersion()
+	c.Assert(pinned, jc.IsTrue)
+	c.Assert(v, gc.Equals, version.MustParse("2.6.8"))
+
+	err = model.SetPinnedAgentVersion(version.Zero)
+	c.Assert(err, jc.ErrorIsNil)
+	_, pinned = model.PinnedAgentVersion()
+	c.Assert(pinned, jc.IsFalse)
+}
+
+func (s *ModelSuite) TestSetPinnedAgentVersionControllerModel(c *gc.C) {
+	err := s.Model.SetPinnedAgentVersion(version.MustParse("2.6.8"))
+	c.Assert(err, gc.ErrorMatches, "cannot pin the agent version of the controller model")
+}
+
 func (s *ModelSuite) TestModelExists(c *gc.C) {
 	modelExists, err := s.State.ModelExists(s.State.ModelUUID())
 	c.Assert(err, jc.ErrorIsNil)
@@ -206,6 +206,44 @@ func (model *Model) ModelConfigValues() (config.ConfigValues, error) {
 	return model.modelConfigValues(cfg.AllAttrs())
 }
 
+// ModelConfigTrace returns the value of the named model config attribute
+// at each level of the model's config inheritance chain, along with the
+// effective value and its source.
+func (model *Model) ModelConfigTrace(attr string) (config.ConfigTrace, error) {
+	values, err := model.ModelConfigValues()
+	if err != nil {
+		return config.ConfigTrace{}, errors.Trace(err)
+	}
+	effective, ok := values[attr]
+	if !ok {
+		return config.ConfigTrace{}, errors.NotFoundf("model config attribute %q", attr)
+	}
+	rspec, err := model.st.regionSpec()
+	if err != nil {
+		return config.ConfigTrace{}, errors.Trace(err)
+	}
+	trace := config.ConfigTrace{Effective: effective}
+	for _, src := range modelConfigSources(model.st, rspec) {
+		cfg, err := src.sourceFunc()
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return config.ConfigTrace{}, errors.Annotatef(err, "reading %s settings", src.name)
+		}
+		if val, ok := cfg[attr]; ok {
+			trace.Levels = append(trace.Levels, config.ConfigValue{
+				Value:  val,
+				Source: src.name,
+			})
+		}
+	}
+	if effective.Source == config.JujuModelConfigSource {
+		trace.Levels = append(trace.Levels, effective)
+	}
+	return trace, nil
+}
+
 // ModelConfigDefaultValues returns the default config values to be used
 // when creating a new model, and the origin of those values.
 func (st *State) ModelConfigDefaultValues(cloudName string) (config.ModelDefaultAttributes, error) {
@@ -417,6 +417,39 @@ func (s *ModelConfigSourceSuite) TestModelConfigUpdateSource(c *gc.C) {
 	s.assertModelConfigValues(c, modelCfg, modelAttributes, set.NewStrings("apt-mirror"))
 }
 
+func (s *ModelConfigSourceSuite) TestModelConfigTraceInherited(c *gc.C) {
+	trace, err := s.Model.ModelConfigTrace("http-proxy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(trace, jc.DeepEquals, config.ConfigTrace{
+		Effective: config.ConfigValue{Value: "http://proxy", Source: "controller"},
+		Levels: []config.ConfigValue{
+			{Value: "", Source: "default"},
+			{Value: "http://proxy", Source: "controller"},
+		},
+	})
+}
+
+func (s *ModelConfigSourceSuite) TestModelConfigTraceModelValue(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"apt-mirror": "http://anothermirror",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	trace, err := s.Model.ModelConfigTrace("apt-mirror")
+	c.Assert(err, jc.ErrorIsNil)
+	effective := config.ConfigValue{Value: "http://anothermirror", Source: "model"}
+	c.Assert(trace.Effective, jc.DeepEquals, effective)
+	c.Assert(trace.Levels[0], jc.DeepEquals, config.ConfigValue{Value: "", Source: "default"})
+	c.Assert(trace.Levels[1], jc.DeepEquals, config.ConfigValue{Value: "http://mirror", Source: "controller"})
+	c.Assert(trace.Levels[len(trace.Levels)-1], jc.DeepEquals, effective)
+}
+
+func (s *ModelConfigSourceSuite) TestModelConfigTraceNotFound(c *gc.C) {
+	_, err := s.Model.ModelConfigTrace("no-such-attr")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `model config attribute "no-such-attr" not found`)
+}
+
 func (s *ModelConfigSourceSuite) TestModelConfigDefaults(c *gc.C) {
 	expectedValues := make(config.ModelDefaultAttributes)
 	for attr, val := range config.ConfigDefaults() {
@@ -0,0 +1,202 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/status"
+)
+
+// maxDashboardErrors is the number of most recent errors reported for
+// each model in a dashboard summary.
+const maxDashboardErrors = 5
+
+// ModelDashboardSummary holds the aggregated status of a model, as
+// shown by dashboards giving an overview of many models.
+type ModelDashboardSummary struct {
+	ModelSummary
+
+	// ApplicationStatus counts the model's applications by status.
+	// Applications whose charms have never set a status are counted
+	// as unknown.
+	ApplicationStatus map[status.Status]int
+
+	// UnitStatus counts the model's units by workload status.
+	UnitStatus map[status.Status]int
+
+	// RecentErrors holds the most recent errors of the model's
+	// machines, applications and units, newest first.
+	RecentErrors []EntityStatusError
+
+	// AvailableVersion is the newest agent version the model can be
+	// upgraded to, if it is newer than the model's agent version.
+	AvailableVersion *version.Number
+}
+
+// EntityStatusError describes an entity in an error state.
+type EntityStatusError struct {
+	Entity  names.Tag
+	Message string
+	Since   *time.Time
+}
+
+// ModelDashboardSummariesForUser returns the dashboard summaries of
+// the models the user has access to. The statuses of all the models
+// are aggregated with a few queries, rather than model by model.
+func (st *State) ModelDashboardSummariesForUser(user names.UserTag, all bool) ([]ModelDashboardSummary, error) {
+	summaries, err := st.ModelSummariesForUser(user, all)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]ModelDashboardSummary, len(summaries))
+	indexByUUID := make(map[string]int, len(summaries))
+	modelUUIDs := make([]string, len(summaries))
+	for i, summary := range summaries {
+		result[i] = ModelDashboardSummary{
+			ModelSummary:      summary,
+			ApplicationStatus: make(map[status.Status]int),
+			UnitStatus:        make(map[status.Status]int),
+		}
+		indexByUUID[summary.UUID] = i
+		modelUUIDs[i] = summary.UUID
+	}
+	if len(modelUUIDs) == 0 {
+		return result, nil
+	}
+
+	// We use the raw statuses because otherwise it filters by model-uuid.
+	rawStatus, closer := st.db().GetRawCollection(statusesC)
+	defer closer()
+
+	// Application status docs are keyed "<model-uuid>:a#<name>", and unit
+	// workload status docs "<model-uuid>:u#<name>#charm".
+	counts := []struct {
+		idPattern string
+		count     func(summary *ModelDashboardSummary, s status.Status, n int)
+	}{{
+		idPattern: "^[^:]+:a#[^#]+$",
+		count: func(summary *ModelDashboardSummary, s status.Status, n int) {
+			summary.ApplicationStatus[s] += n
+		},
+	}, {
+		idPattern: "^[^:]+:u#[^#]+#charm$",
+		count: func(summary *ModelDashboardSummary, s status.Status, n int) {
+			summary.UnitStatus[s] += n
+		},
+	}}
+	for _, c := range counts {
+		pipe := rawStatus.Pipe([]bson.M{
+			{"$match": bson.M{
+				"model-uuid": bson.M{"$in": modelUUIDs},
+				"_id":        bson.M{"$regex": c.idPattern},
+			}},
+			{"$group": bson.M{
+				"_id": bson.M{
+					"model-uuid": "$model-uuid",
+					"status":     "$status",
+					"neverset":   "$neverset",
+				},
+				"count": bson.M{"$sum": 1},
+			}},
+		})
+		var doc struct {
+			Id struct {
+				ModelUUID string        `bson:"model-uuid"`
+				Status    status.Status `bson:"status"`
+				NeverSet  bool          `bson:"neverset"`
+			} `bson:"_id"`
+			Count int `bson:"count"`
+		}
+		iter := pipe.Iter()
+		for iter.Next(&doc) {
+			idx, ok := indexByUUID[doc.Id.ModelUUID]
+			if !ok {
+				continue
+			}
+			s := doc.Id.Status
+			if doc.Id.NeverSet {
+				s = status.Unknown
+			}
+			c.count(&result[idx], s, doc.Count)
+		}
+		if err := iter.Close(); err != nil {
+			return nil, errors.Annotate(err, "counting statuses")
+		}
+	}
+
+	query := rawStatus.Find(bson.M{
+		"model-uuid": bson.M{"$in": modelUUIDs},
+		"status":     status.Error,
+	})
+	query.Select(bson.M{"_id": 1, "model-uuid": 1, "statusinfo": 1, "updated": 1})
+	query.Sort("-updated")
+	iter := query.Iter()
+	var doc statusDocWithID
+	for iter.Next(&doc) {
+		idx, ok := indexByUUID[doc.ModelUUID]
+		if !ok || len(result[idx].RecentErrors) >= maxDashboardErrors {
+			continue
+		}
+		tag, err := statusKeyToEntityTag(strings.TrimPrefix(doc.ID, doc.ModelUUID+":"))
+		if err != nil {
+			// Not the status of an entity shown on a dashboard.
+			continue
+		}
+		result[idx].RecentErrors = append(result[idx].RecentErrors, EntityStatusError{
+			Entity:  tag,
+			Message: doc.StatusInfo,
+			Since:   unixNanoToTime(doc.Updated),
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "reading errors")
+	}
+
+	models, closer := st.db().GetCollection(modelsC)
+	defer closer()
+	var modelDocs []modelDoc
+	if err := models.Find(bson.M{
+		"_id":             bson.M{"$in": modelUUIDs},
+		"available-tools": bson.M{"$exists": true},
+	}).Select(bson.M{"_id": 1, "available-tools": 1}).All(&modelDocs); err != nil {
+		return nil, errors.Annotate(err, "reading available agent versions")
+	}
+	for _, doc := range modelDocs {
+		available, err := version.Parse(doc.LatestAvailableTools)
+		if err != nil {
+			continue
+		}
+		summary := &result[indexByUUID[doc.UUID]]
+		if summary.AgentVersion != nil && summary.AgentVersion.Compare(available) < 0 {
+			summary.AvailableVersion = &available
+		}
+	}
+	return result, nil
+}
+
+// statusKeyToEntityTag returns the tag of the machine, application or
+// unit the status global key is for. Both a unit's agent and workload
+// statuses are for the unit; other status keys, such as those of
+// cloud containers, are not valid.
+func statusKeyToEntityTag(key string) (names.Tag, error) {
+	key = strings.TrimSuffix(key, "#charm")
+	if strings.Count(key, "#") != 1 {
+		return nil, errors.NotValidf("status key %q", key)
+	}
+	id := key[strings.Index(key, "#")+1:]
+	switch {
+	case strings.HasPrefix(key, "m#") && names.IsValidMachine(id),
+		strings.HasPrefix(key, "u#") && names.IsValidUnit(id),
+		strings.HasPrefix(key, "a#") && names.IsValidApplication(id):
+		return globalKeyToAgentTag(key)
+	}
+	return nil, errors.NotValidf("status key %q", key)
+}
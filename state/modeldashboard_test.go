@@ -0,0 +1,95 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ModelDashboardSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelDashboardSuite{})
+
+func (s *ModelDashboardSuite) summaryForModel(c *gc.C, summaries []state.ModelDashboardSummary, name string) state.ModelDashboardSummary {
+	for _, summary := range summaries {
+		if summary.Name == name {
+			return summary
+		}
+	}
+	c.Fatalf("no summary for model %q", name)
+	return state.ModelDashboardSummary{}
+}
+
+func (s *ModelDashboardSuite) TestModelDashboardSummaries(c *gc.C) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Name: "busy"})
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	app := f.MakeApplication(c, nil)
+	f.MakeUnit(c, &factory.UnitParams{Application: app})
+	unit := f.MakeUnit(c, &factory.UnitParams{Application: app})
+	now := time.Now()
+	err := unit.SetStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "hook failed",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	summaries, err := s.State.ModelDashboardSummariesForUser(s.Model.Owner(), false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(summaries, gc.HasLen, 2)
+
+	summary := s.summaryForModel(c, summaries, "busy")
+	c.Check(summary.ApplicationStatus, jc.DeepEquals, map[status.Status]int{
+		status.Unknown: 1,
+	})
+	c.Check(summary.UnitStatus, jc.DeepEquals, map[status.Status]int{
+		status.Waiting: 1,
+		status.Error:   1,
+	})
+	c.Assert(summary.RecentErrors, gc.HasLen, 1)
+	c.Check(summary.RecentErrors[0].Entity, gc.Equals, unit.Tag())
+	c.Check(summary.RecentErrors[0].Message, gc.Equals, "hook failed")
+	c.Check(summary.AvailableVersion, gc.IsNil)
+
+	summary = s.summaryForModel(c, summaries, "testmodel")
+	c.Check(summary.ApplicationStatus, gc.HasLen, 0)
+	c.Check(summary.UnitStatus, gc.HasLen, 0)
+	c.Check(summary.RecentErrors, gc.HasLen, 0)
+}
+
+func (s *ModelDashboardSuite) TestModelDashboardSummariesAvailableVersion(c *gc.C) {
+	cfg, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	current, ok := cfg.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	available := current
+	available.Minor++
+	err = s.Model.UpdateLatestToolsVersion(available)
+	c.Assert(err, jc.ErrorIsNil)
+
+	summaries, err := s.State.ModelDashboardSummariesForUser(s.Model.Owner(), false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(summaries, gc.HasLen, 1)
+	c.Check(summaries[0].AvailableVersion, jc.DeepEquals, &available)
+}
+
+func (s *ModelDashboardSuite) TestModelDashboardSummariesNoModels(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Name:        "nomodels",
+		NoModelUser: true,
+	})
+	summaries, err := s.State.ModelDashboardSummariesForUser(user.UserTag(), false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(summaries, gc.HasLen, 0)
+}
@@ -62,6 +62,14 @@ type ModelMigration interface {
 	// InitiatedBy returns username the initiated the migration.
 	InitiatedBy() string
 
+	// TargetRegion returns the cloud region the model is moving to,
+	// or "" if it stays in its region.
+	TargetRegion() string
+
+	// Mode returns whether the model is moved or copied to the
+	// target controller.
+	Mode() migration.Mode
+
 	// TargetInfo returns the details required to connect to the
 	// migration's target controller.
 	TargetInfo() (*migration.TargetInfo, error)
@@ -151,6 +159,14 @@ type modelMigDoc struct {
 	// TargetMacaroons holds the macaroons to use with TargetAuthTag
 	// when authenticating.
 	TargetMacaroons string `bson:"target-macaroons,omitempty"`
+
+	// TargetRegion holds the cloud region the model is moving to. The
+	// model's machines are provisioned again there.
+	TargetRegion string `bson:"target-region,omitempty"`
+
+	// Mode holds whether the model is moved or copied. It is empty
+	// for a move.
+	Mode string `bson:"mode,omitempty"`
 }
 
 // modelMigStatusDoc tracks the progress of a migration attempt for a
@@ -254,6 +270,19 @@ func (mig *modelMigration) InitiatedBy() string {
 	return mig.doc.InitiatedBy
 }
 
+// TargetRegion implements ModelMigration.
+func (mig *modelMigration) TargetRegion() string {
+	return mig.doc.TargetRegion
+}
+
+// Mode implements ModelMigration.
+func (mig *modelMigration) Mode() migration.Mode {
+	if mig.doc.Mode == "" {
+		return migration.ModeMove
+	}
+	return migration.Mode(mig.doc.Mode)
+}
+
 // TargetInfo implements ModelMigration.
 func (mig *modelMigration) TargetInfo() (*migration.TargetInfo, error) {
 	authTag, err := names.ParseUserTag(mig.doc.TargetAuthTag)
@@ -307,8 +336,11 @@ func (mig *modelMigration) SetPhase(nextPhase migration.Phase) error {
 		return errors.Trace(err)
 	}
 
-	// If the migration aborted, make the model active again.
-	if nextPhase == migration.ABORTDONE {
+	// If the migration aborted, make the model active again. A model
+	// outlives being copied, so it is made active again however that
+	// ends.
+	copied := mig.Mode() == migration.ModeCopy
+	if nextPhase == migration.ABORTDONE || (copied && nextPhase.IsTerminal()) {
 		ops = append(ops, txn.Op{
 			C:      modelsC,
 			Id:     mig.doc.ModelUUID,
@@ -609,6 +641,14 @@ func (mig *modelMigration) Refresh() error {
 type MigrationSpec struct {
 	InitiatedBy names.UserTag
 	TargetInfo  migration.TargetInfo
+
+	// TargetRegion, if set, requests that the model moves to another
+	// region of its cloud, with its machines provisioned again there.
+	TargetRegion string
+
+	// Mode requests that the model is moved or copied to the target
+	// controller. The default is to move it.
+	Mode migration.Mode
 }
 
 // Validate returns an error if the MigrationSpec contains bad
@@ -617,6 +657,16 @@ func (spec *MigrationSpec) Validate() error {
 	if !names.IsValidUser(spec.InitiatedBy.Id()) {
 		return errors.NotValidf("InitiatedBy")
 	}
+	if _, err := migration.ParseMode(string(spec.Mode)); err != nil {
+		return errors.Trace(err)
+	}
+	if spec.Mode == migration.ModeCopy {
+		// The copy can't share the model's machines, so they
+		// must be provisioned again elsewhere.
+		if spec.TargetRegion == "" {
+			return errors.NotValidf("copy without TargetRegion")
+		}
+	}
 	return spec.TargetInfo.Validate()
 }
 
@@ -633,6 +683,15 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 	if err := checkTargetController(st, spec.TargetInfo.ControllerTag); err != nil {
 		return nil, errors.Trace(err)
 	}
+	if spec.TargetRegion != "" {
+		if err := st.CheckRelocation(spec.TargetRegion); err != nil {
+			return nil, errors.Annotatef(err, "cannot move model to region %q", spec.TargetRegion)
+		}
+	}
+	mode := spec.Mode
+	if mode == migration.ModeMove {
+		mode = ""
+	}
 
 	now := st.clock().Now().UnixNano()
 	modelUUID := st.ModelUUID()
@@ -682,6 +741,8 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			TargetAuthTag:    spec.TargetInfo.AuthTag.String(),
 			TargetPassword:   spec.TargetInfo.Password,
 			TargetMacaroons:  macsJSON,
+			TargetRegion:     spec.TargetRegion,
+			Mode:             string(mode),
 		}
 
 		statusDoc = modelMigStatusDoc{
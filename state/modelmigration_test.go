@@ -204,6 +204,84 @@ func (s *MigrationSuite) TestCreateWithControllerModel(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "controllers can't be migrated")
 }
 
+func (s *MigrationSuite) TestCreateRelocation(c *gc.C) {
+	s.stdSpec.TargetRegion = "nether-region"
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.TargetRegion(), gc.Equals, "nether-region")
+	c.Check(mig.Mode(), gc.Equals, migration.ModeMove)
+
+	mig2, err := s.State2.LatestMigration()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig2.TargetRegion(), gc.Equals, "nether-region")
+}
+
+func (s *MigrationSuite) TestCreateRelocationSameRegion(c *gc.C) {
+	s.stdSpec.TargetRegion = "dummy-region"
+	_, err := s.State2.CreateMigration(s.stdSpec)
+	c.Check(err, gc.ErrorMatches, `cannot move model to region "dummy-region": model is already in region "dummy-region"`)
+}
+
+func (s *MigrationSuite) TestCreateRelocationUnknownRegion(c *gc.C) {
+	s.stdSpec.TargetRegion = "outer-region"
+	_, err := s.State2.CreateMigration(s.stdSpec)
+	c.Check(err, gc.ErrorMatches, `cannot move model to region "outer-region": region "outer-region" not found .*`)
+}
+
+func (s *MigrationSuite) TestCreateRelocationManualMachine(c *gc.C) {
+	factory2 := factory.NewFactory(s.State2, s.StatePool)
+	m := factory2.MakeMachine(c, &factory.MachineParams{
+		Nonce: "manual:",
+	})
+
+	s.stdSpec.TargetRegion = "nether-region"
+	_, err := s.State2.CreateMigration(s.stdSpec)
+	c.Check(err, gc.ErrorMatches, fmt.Sprintf(
+		`cannot move model to region "nether-region": machine %s is manually provisioned`, m.Id(),
+	))
+}
+
+func (s *MigrationSuite) TestCreateCopy(c *gc.C) {
+	s.stdSpec.TargetRegion = "nether-region"
+	s.stdSpec.Mode = migration.ModeCopy
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mig.Mode(), gc.Equals, migration.ModeCopy)
+}
+
+func (s *MigrationSuite) TestCopyWithoutRegion(c *gc.C) {
+	s.stdSpec.Mode = migration.ModeCopy
+	_, err := s.State2.CreateMigration(s.stdSpec)
+	c.Check(errors.IsNotValid(err), jc.IsTrue)
+	c.Check(err, gc.ErrorMatches, "copy without TargetRegion not valid")
+}
+
+func (s *MigrationSuite) TestCopyEndReactivatesModel(c *gc.C) {
+	s.stdSpec.TargetRegion = "nether-region"
+	s.stdSpec.Mode = migration.ModeCopy
+	mig, err := s.State2.CreateMigration(s.stdSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	phases := []migration.Phase{
+		migration.IMPORT,
+		migration.VALIDATION,
+		migration.SUCCESS,
+		migration.REPROVISION,
+		migration.LOGTRANSFER,
+		migration.REAP,
+		migration.DONE,
+	}
+	for _, phase := range phases {
+		s.Clock.Advance(time.Millisecond)
+		c.Assert(mig.SetPhase(phase), jc.ErrorIsNil)
+	}
+
+	assertMigrationNotActive(c, s.State2)
+	model, err := s.State2.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeNone)
+}
+
 func (s *MigrationSuite) TestCreateMigrationInProgress(c *gc.C) {
 	mig, err := s.State2.CreateMigration(s.stdSpec)
 	c.Assert(mig, gc.Not(gc.IsNil))
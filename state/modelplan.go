@@ -0,0 +1,203 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ModelPlanOperationKind identifies the change made to a model by an
+// operation of a model plan.
+type ModelPlanOperationKind string
+
+const (
+	// ModelPlanDeploy deploys an application from a charm that has
+	// already been added to the model.
+	ModelPlanDeploy ModelPlanOperationKind = "deploy"
+
+	// ModelPlanSetConfig changes the charm config of an application.
+	ModelPlanSetConfig ModelPlanOperationKind = "set-config"
+
+	// ModelPlanAddRelation relates two application endpoints.
+	ModelPlanAddRelation ModelPlanOperationKind = "add-relation"
+)
+
+// ModelPlanOperation is a single change to the model, made when the
+// plan it belongs to is applied.
+type ModelPlanOperation struct {
+	// ID identifies the operation within its plan.
+	ID string
+
+	Kind        ModelPlanOperationKind
+	Application string
+
+	// CharmURL and NumUnits describe the application deployed by a
+	// deploy operation.
+	CharmURL string
+	NumUnits int
+
+	// Config holds the charm config of the application deployed by a
+	// deploy operation, or the values set by a set-config operation.
+	Config map[string]interface{}
+
+	// PreviousConfig holds the values of the config changed by a
+	// set-config operation when the plan was made. The operation is
+	// only applied if they have not changed since.
+	PreviousConfig map[string]interface{}
+
+	// Endpoints holds the endpoints related by an add-relation
+	// operation.
+	Endpoints []string
+}
+
+// ModelPlan is a sequence of changes to a model, computed from a
+// desired change set, that can be reviewed before it is applied. A
+// plan can only be applied once.
+type ModelPlan struct {
+	ID         string
+	Created    time.Time
+	Operations []ModelPlanOperation
+}
+
+// modelPlanDoc holds a model plan that has not yet been applied.
+type modelPlanDoc struct {
+	DocID      string                  `bson:"_id"`
+	ModelUUID  string                  `bson:"model-uuid"`
+	Created    int64                   `bson:"created"`
+	Operations []modelPlanOperationDoc `bson:"operations"`
+}
+
+type modelPlanOperationDoc struct {
+	ID             string                 `bson:"id"`
+	Kind           string                 `bson:"kind"`
+	Application    string                 `bson:"application,omitempty"`
+	CharmURL       string                 `bson:"charm-url,omitempty"`
+	NumUnits       int                    `bson:"num-units,omitempty"`
+	Config         map[string]interface{} `bson:"config,omitempty"`
+	PreviousConfig map[string]interface{} `bson:"previous-config,omitempty"`
+	Endpoints      []string               `bson:"endpoints,omitempty"`
+}
+
+func (doc modelPlanDoc) plan(st *State) *ModelPlan {
+	plan := &ModelPlan{
+		ID:         st.localID(doc.DocID),
+		Created:    time.Unix(0, doc.Created).UTC(),
+		Operations: make([]ModelPlanOperation, len(doc.Operations)),
+	}
+	for i, op := range doc.Operations {
+		plan.Operations[i] = ModelPlanOperation{
+			ID:             op.ID,
+			Kind:           ModelPlanOperationKind(op.Kind),
+			Application:    op.Application,
+			CharmURL:       op.CharmURL,
+			NumUnits:       op.NumUnits,
+			Config:         op.Config,
+			PreviousConfig: op.PreviousConfig,
+			Endpoints:      op.Endpoints,
+		}
+	}
+	return plan
+}
+
+// AddModelPlan records a plan made up of the given operations, and
+// returns it with its new ID.
+func (st *State) AddModelPlan(operations []ModelPlanOperation) (*ModelPlan, error) {
+	uuid, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := modelPlanDoc{
+		DocID:      st.docID(uuid.String()),
+		ModelUUID:  st.ModelUUID(),
+		Created:    st.clock().Now().UnixNano(),
+		Operations: make([]modelPlanOperationDoc, len(operations)),
+	}
+	for i, op := range operations {
+		doc.Operations[i] = modelPlanOperationDoc{
+			ID:             op.ID,
+			Kind:           string(op.Kind),
+			Application:    op.Application,
+			CharmURL:       op.CharmURL,
+			NumUnits:       op.NumUnits,
+			Config:         op.Config,
+			PreviousConfig: op.PreviousConfig,
+			Endpoints:      op.Endpoints,
+		}
+	}
+	err = st.db().RunTransaction([]txn.Op{{
+		C:      modelPlansC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot add model plan")
+	}
+	return doc.plan(st), nil
+}
+
+// ModelPlan returns the model plan with the given ID, or a NotFound
+// error if it doesn't exist or has already been applied.
+func (st *State) ModelPlan(id string) (*ModelPlan, error) {
+	coll, closer := st.db().GetCollection(modelPlansC)
+	defer closer()
+
+	var doc modelPlanDoc
+	err := coll.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("model plan %q", id)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot read model plan %q", id)
+	}
+	return doc.plan(st), nil
+}
+
+// RemoveModelPlan removes the model plan with the given ID. It returns
+// a NotFound error if the plan has already been removed, so that a
+// plan that is removed before it is applied is applied at most once.
+func (st *State) RemoveModelPlan(id string) error {
+	err := st.db().RunTransaction([]txn.Op{{
+		C:      modelPlansC,
+		Id:     st.docID(id),
+		Assert: txn.DocExists,
+		Remove: true,
+	}})
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("model plan %q", id)
+	}
+	return errors.Annotatef(err, "cannot remove model plan %q", id)
+}
+
+// RemoveModelPlansCreatedBefore removes the model plans created before
+// the given time, which have not been applied.
+func (st *State) RemoveModelPlansCreatedBefore(t time.Time) error {
+	coll, closer := st.db().GetCollection(modelPlansC)
+	defer closer()
+
+	var docs []struct {
+		DocID string `bson:"_id"`
+	}
+	err := coll.Find(bson.D{{"created", bson.D{{"$lt", t.UnixNano()}}}}).Select(bson.D{{"_id", 1}}).All(&docs)
+	if err != nil {
+		return errors.Annotate(err, "cannot read model plans")
+	}
+	if len(docs) == 0 {
+		return nil
+	}
+	ops := make([]txn.Op, len(docs))
+	for i, doc := range docs {
+		ops[i] = txn.Op{
+			C:      modelPlansC,
+			Id:     doc.DocID,
+			Remove: true,
+		}
+	}
+	return errors.Annotate(st.db().RunTransaction(ops), "cannot remove model plans")
+}
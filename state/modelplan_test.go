@@ -0,0 +1,86 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type ModelPlanSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelPlanSuite{})
+
+var testModelPlanOperations = []state.ModelPlanOperation{{
+	ID:          "1",
+	Kind:        state.ModelPlanDeploy,
+	Application: "mysql",
+	CharmURL:    "cs:quantal/mysql-1",
+	NumUnits:    2,
+	Config:      map[string]interface{}{"dataset-size": "80%"},
+}, {
+	ID:             "2",
+	Kind:           state.ModelPlanSetConfig,
+	Application:    "wordpress",
+	Config:         map[string]interface{}{"blog-title": "planned"},
+	PreviousConfig: map[string]interface{}{"blog-title": "My Title"},
+}, {
+	ID:        "3",
+	Kind:      state.ModelPlanAddRelation,
+	Endpoints: []string{"wordpress:db", "mysql:server"},
+}}
+
+func (s *ModelPlanSuite) TestAddModelPlan(c *gc.C) {
+	plan, err := s.State.AddModelPlan(testModelPlanOperations)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plan.ID, gc.Not(gc.Equals), "")
+	c.Assert(plan.Created, gc.Equals, s.Clock.Now().UTC())
+	c.Assert(plan.Operations, jc.DeepEquals, testModelPlanOperations)
+
+	read, err := s.State.ModelPlan(plan.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(read, jc.DeepEquals, plan)
+}
+
+func (s *ModelPlanSuite) TestModelPlanNotFound(c *gc.C) {
+	_, err := s.State.ModelPlan("missing")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `model plan "missing" not found`)
+}
+
+func (s *ModelPlanSuite) TestRemoveModelPlan(c *gc.C) {
+	plan, err := s.State.AddModelPlan(testModelPlanOperations)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveModelPlan(plan.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ModelPlan(plan.ID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	// A plan can only be removed once.
+	err = s.State.RemoveModelPlan(plan.ID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelPlanSuite) TestRemoveModelPlansCreatedBefore(c *gc.C) {
+	old, err := s.State.AddModelPlan(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Hour)
+	current, err := s.State.AddModelPlan(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveModelPlansCreatedBefore(current.Created)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ModelPlan(old.ID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.ModelPlan(current.ID)
+	c.Assert(err, jc.ErrorIsNil)
+}
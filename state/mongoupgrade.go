@@ -0,0 +1,478 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	stderrors "errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/mongo"
+)
+
+// mongoUpgradeKey is the key of the document in the controllers
+// collection that records the progress of a migration of the
+// controller's database to a new storage engine.
+const mongoUpgradeKey = "mongoUpgrade"
+
+// MongoUpgradePhase describes how far a controller has got with
+// migrating its database.
+type MongoUpgradePhase string
+
+const (
+	// MongoUpgradePending indicates that the controller has not started
+	// migrating its database.
+	MongoUpgradePending MongoUpgradePhase = "pending"
+
+	// MongoUpgradeResyncing indicates that the controller has moved its
+	// data files aside and is resyncing from its peers. Only one
+	// controller resyncs at a time.
+	MongoUpgradeResyncing MongoUpgradePhase = "resyncing"
+
+	// MongoUpgradeDone indicates that the controller's database uses
+	// the target storage engine.
+	MongoUpgradeDone MongoUpgradePhase = "done"
+
+	// MongoUpgradeFailed indicates that the controller could not
+	// migrate its database.
+	MongoUpgradeFailed MongoUpgradePhase = "failed"
+)
+
+// ErrMongoResyncInProgress is returned by SetMongoUpgradeMember when
+// another controller is already resyncing its database.
+var ErrMongoResyncInProgress = stderrors.New("another controller is resyncing its database")
+
+// MongoUpgradeCheck holds the result of checking whether the
+// controller's database can be migrated to a new storage engine.
+type MongoUpgradeCheck struct {
+	// Current is the version and storage engine currently in use.
+	Current mongo.Version
+
+	// Target is the version and storage engine to migrate to.
+	Target mongo.Version
+
+	// DataSize is the size in bytes of the data and indexes that
+	// have to be resynced by each member of the replica set.
+	DataSize int64
+
+	// Members is the number of members in the replica set.
+	Members int
+
+	// Estimate is the estimated time taken by the migration.
+	Estimate mongo.StorageEngineMigrationEstimate
+
+	// Problems holds the reasons why the migration can't go ahead. It
+	// is empty if the migration can be started.
+	Problems []string
+}
+
+// CheckMongoUpgrade checks whether the controller's database can be
+// migrated to the target version and storage engine, and estimates
+// how long the migration would take.
+func (st *State) CheckMongoUpgrade(target mongo.Version) (MongoUpgradeCheck, error) {
+	result := MongoUpgradeCheck{Target: target}
+	current, err := st.currentMongoVersion()
+	if err != nil {
+		return MongoUpgradeCheck{}, errors.Trace(err)
+	}
+	result.Current = current
+	if err := mongo.ValidateStorageEngineMigration(current, target); err != nil {
+		result.Problems = append(result.Problems, err.Error())
+	}
+
+	if result.DataSize, err = st.mongoDataSize(); err != nil {
+		return MongoUpgradeCheck{}, errors.Trace(err)
+	}
+
+	status, err := replicaset.CurrentStatus(st.session)
+	if err != nil {
+		return MongoUpgradeCheck{}, errors.Annotate(err, "cannot get replica set status")
+	}
+	result.Members = len(status.Members)
+	if result.Members < 2 {
+		// Members are migrated by resyncing them from their peers,
+		// so there has to be at least one other member to sync from.
+		result.Problems = append(result.Problems,
+			`the controller has a single database member; run "juju enable-ha" before migrating`)
+	}
+	for _, member := range status.Members {
+		if !member.Healthy {
+			result.Problems = append(result.Problems,
+				fmt.Sprintf("replica set member %q is not healthy", member.Address))
+			continue
+		}
+		if member.State != replicaset.PrimaryState && member.State != replicaset.SecondaryState {
+			result.Problems = append(result.Problems,
+				fmt.Sprintf("replica set member %q is %s", member.Address, member.State))
+		}
+	}
+
+	doc, err := st.mongoUpgradeDoc()
+	if err != nil && !errors.IsNotFound(err) {
+		return MongoUpgradeCheck{}, errors.Trace(err)
+	}
+	if doc != nil && !doc.finished() {
+		result.Problems = append(result.Problems,
+			fmt.Sprintf("a migration to mongo %s is already in progress", doc.Target))
+	}
+
+	result.Estimate = mongo.EstimateStorageEngineMigration(result.DataSize, result.Members)
+	return result, nil
+}
+
+// currentMongoVersion returns the version and storage engine of the
+// mongod the state is connected to.
+func (st *State) currentMongoVersion() (mongo.Version, error) {
+	binfo, err := st.session.BuildInfo()
+	if err != nil {
+		return mongo.Version{}, errors.Annotate(err, "cannot obtain mongo build info")
+	}
+	version, err := mongo.NewVersion(binfo.Version)
+	if err != nil {
+		return mongo.Version{}, errors.Trace(err)
+	}
+	var serverStatus struct {
+		StorageEngine struct {
+			Name string `bson:"name"`
+		} `bson:"storageEngine"`
+	}
+	if err := st.session.Run(bson.D{{"serverStatus", 1}}, &serverStatus); err != nil {
+		return mongo.Version{}, errors.Annotate(err, "cannot obtain mongo server status")
+	}
+	// Versions of mongo before 3.0 don't report a storage engine,
+	// and only support MMAPv1.
+	version.StorageEngine = mongo.MMAPV1
+	if serverStatus.StorageEngine.Name != "" {
+		version.StorageEngine = mongo.StorageEngine(serverStatus.StorageEngine.Name)
+	}
+	return version, nil
+}
+
+// mongoDataSize returns the size in bytes of the data and indexes in
+// all the replicated databases.
+func (st *State) mongoDataSize() (int64, error) {
+	names, err := st.session.DatabaseNames()
+	if err != nil {
+		return 0, errors.Annotate(err, "cannot list databases")
+	}
+	var total int64
+	for _, name := range names {
+		if name == "local" {
+			// The local database isn't replicated.
+			continue
+		}
+		var stats struct {
+			DataSize  int64 `bson:"dataSize"`
+			IndexSize int64 `bson:"indexSize"`
+		}
+		if err := st.session.DB(name).Run(bson.D{{"dbStats", 1}}, &stats); err != nil {
+			return 0, errors.Annotatef(err, "cannot get stats for database %q", name)
+		}
+		total += stats.DataSize + stats.IndexSize
+	}
+	return total, nil
+}
+
+type mongoUpgradeMemberDoc struct {
+	Phase   MongoUpgradePhase `bson:"phase"`
+	Message string            `bson:"message,omitempty"`
+	Updated time.Time         `bson:"updated"`
+}
+
+type mongoUpgradeDoc struct {
+	Target            string                           `bson:"target"`
+	Started           time.Time                        `bson:"started"`
+	DataSize          int64                            `bson:"data-size"`
+	EstimatedDuration time.Duration                    `bson:"estimated-duration"`
+	EstimatedDowntime time.Duration                    `bson:"estimated-downtime"`
+	Members           map[string]mongoUpgradeMemberDoc `bson:"members"`
+}
+
+// finished returns whether every controller has either migrated its
+// database or given up.
+func (doc *mongoUpgradeDoc) finished() bool {
+	for _, member := range doc.Members {
+		if member.Phase != MongoUpgradeDone && member.Phase != MongoUpgradeFailed {
+			return false
+		}
+	}
+	return true
+}
+
+func (st *State) mongoUpgradeDoc() (*mongoUpgradeDoc, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc mongoUpgradeDoc
+	err := controllers.FindId(mongoUpgradeKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("mongo upgrade")
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read mongo upgrade")
+	}
+	return &doc, nil
+}
+
+// StartMongoUpgrade requests that the controller's database is
+// migrated to the target version and storage engine. The mongo upgrader
+// worker on each controller notices the request, and migrates its own
+// database once no other controller is resyncing.
+func (st *State) StartMongoUpgrade(target mongo.Version) error {
+	check, err := st.CheckMongoUpgrade(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(check.Problems) > 0 {
+		return errors.Errorf("cannot migrate to mongo %s: %s", target, check.Problems[0])
+	}
+	info, err := st.ControllerInfo()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.startMongoUpgrade(check, info.MachineIds))
+}
+
+func (st *State) startMongoUpgrade(check MongoUpgradeCheck, machineIds []string) error {
+	now := st.clock().Now()
+	doc := mongoUpgradeDoc{
+		Target:            check.Target.String(),
+		Started:           now,
+		DataSize:          check.DataSize,
+		EstimatedDuration: check.Estimate.Duration,
+		EstimatedDowntime: check.Estimate.Downtime,
+		Members:           make(map[string]mongoUpgradeMemberDoc),
+	}
+	for _, id := range machineIds {
+		doc.Members[id] = mongoUpgradeMemberDoc{
+			Phase:   MongoUpgradePending,
+			Updated: now,
+		}
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		existing, err := st.mongoUpgradeDoc()
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     mongoUpgradeKey,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}}, nil
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !existing.finished() {
+			return nil, errors.AlreadyExistsf("migration to mongo %s", existing.Target)
+		}
+		// Replace the record of the previous migration, making sure
+		// it hasn't been updated in the meantime.
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     mongoUpgradeKey,
+			Assert: bson.D{{"started", existing.Started}},
+			Update: bson.D{{"$set", bson.D{
+				{"target", doc.Target},
+				{"started", doc.Started},
+				{"data-size", doc.DataSize},
+				{"estimated-duration", doc.EstimatedDuration},
+				{"estimated-downtime", doc.EstimatedDowntime},
+				{"members", doc.Members},
+			}}},
+		}}, nil
+	}
+	return errors.Annotate(st.db().Run(buildTxn), "cannot start mongo upgrade")
+}
+
+// WatchMongoUpgrade returns a NotifyWatcher that fires when a migration
+// of the controller's database is started, or its progress changes.
+func (st *State) WatchMongoUpgrade() NotifyWatcher {
+	return newEntityWatcher(st, controllersC, mongoUpgradeKey)
+}
+
+// MongoUpgradeMember holds the progress of a controller migrating its
+// database.
+type MongoUpgradeMember struct {
+	MachineId string
+	Phase     MongoUpgradePhase
+
+	// Message describes what the controller is doing while it
+	// resyncs, or why it failed.
+	Message string
+
+	// Updated is when the controller entered its current phase.
+	Updated time.Time
+}
+
+// MongoUpgradeStatus holds the progress of a migration of the
+// controller's database to a new storage engine.
+type MongoUpgradeStatus struct {
+	Target  mongo.Version
+	Started time.Time
+
+	// DataSize and Estimate are the size of the data and the
+	// estimated time taken when the migration was started.
+	DataSize int64
+	Estimate mongo.StorageEngineMigrationEstimate
+
+	// Members holds the progress of each controller, sorted by
+	// machine id.
+	Members []MongoUpgradeMember
+}
+
+// Remaining estimates how much longer the migration will take, given
+// the controllers that have yet to finish and how long the one that is
+// resyncing has been at it.
+func (s *MongoUpgradeStatus) Remaining(now time.Time) time.Duration {
+	if len(s.Members) == 0 {
+		return 0
+	}
+	perMember := s.Estimate.Duration / time.Duration(len(s.Members))
+	var remaining time.Duration
+	for _, member := range s.Members {
+		switch member.Phase {
+		case MongoUpgradePending:
+			remaining += perMember
+		case MongoUpgradeResyncing:
+			if left := perMember - now.Sub(member.Updated); left > 0 {
+				remaining += left
+			}
+		}
+	}
+	return remaining
+}
+
+// Member returns the progress of the controller with the given
+// machine id.
+func (s *MongoUpgradeStatus) Member(machineId string) (MongoUpgradeMember, bool) {
+	for _, member := range s.Members {
+		if member.MachineId == machineId {
+			return member, true
+		}
+	}
+	return MongoUpgradeMember{}, false
+}
+
+// MongoUpgradeStatus returns the progress of the latest migration of
+// the controller's database. It returns an error satisfying
+// errors.IsNotFound if no migration has been requested.
+func (st *State) MongoUpgradeStatus() (*MongoUpgradeStatus, error) {
+	doc, err := st.mongoUpgradeDoc()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	target, err := mongo.NewVersion(doc.Target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := &MongoUpgradeStatus{
+		Target:   target,
+		Started:  doc.Started,
+		DataSize: doc.DataSize,
+		Estimate: mongo.StorageEngineMigrationEstimate{
+			Duration: doc.EstimatedDuration,
+			Downtime: doc.EstimatedDowntime,
+		},
+	}
+	for id, member := range doc.Members {
+		result.Members = append(result.Members, MongoUpgradeMember{
+			MachineId: id,
+			Phase:     member.Phase,
+			Message:   member.Message,
+			Updated:   member.Updated,
+		})
+	}
+	sort.Slice(result.Members, func(i, j int) bool {
+		return result.Members[i].MachineId < result.Members[j].MachineId
+	})
+	return result, nil
+}
+
+// SetMongoUpgradeMember records the progress of the controller with
+// the given machine id. Controllers resync one at a time: moving to
+// MongoUpgradeResyncing fails with ErrMongoResyncInProgress while
+// another controller is resyncing.
+func (st *State) SetMongoUpgradeMember(machineId string, phase MongoUpgradePhase, message string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := st.mongoUpgradeDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, ok := doc.Members[machineId]; !ok {
+			return nil, errors.NotFoundf("controller %q in mongo upgrade", machineId)
+		}
+		assert := bson.D{
+			{"started", doc.Started},
+			{"members." + machineId, bson.D{{"$exists", true}}},
+		}
+		if phase == MongoUpgradeResyncing {
+			for id, member := range doc.Members {
+				if id == machineId {
+					continue
+				}
+				if member.Phase == MongoUpgradeResyncing {
+					return nil, ErrMongoResyncInProgress
+				}
+				assert = append(assert, bson.DocElem{
+					"members." + id + ".phase", bson.D{{"$ne", MongoUpgradeResyncing}},
+				})
+			}
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     mongoUpgradeKey,
+			Assert: assert,
+			Update: bson.D{{"$set", bson.D{{"members." + machineId, mongoUpgradeMemberDoc{
+				Phase:   phase,
+				Message: message,
+				Updated: st.clock().Now(),
+			}}}}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); errors.Cause(err) == ErrMongoResyncInProgress {
+		return ErrMongoResyncInProgress
+	} else if err != nil {
+		return errors.Annotate(err, "cannot set mongo upgrade progress")
+	}
+	return nil
+}
+
+// SetMongoUpgradeProgress records what the controller with the given
+// machine id is doing while it resyncs its database. Unlike
+// SetMongoUpgradeMember, it doesn't change when the controller entered
+// its current phase.
+func (st *State) SetMongoUpgradeProgress(machineId string, message string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		doc, err := st.mongoUpgradeDoc()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		member, ok := doc.Members[machineId]
+		if !ok {
+			return nil, errors.NotFoundf("controller %q in mongo upgrade", machineId)
+		}
+		if member.Phase != MongoUpgradeResyncing {
+			return nil, errors.Errorf("controller %q is not resyncing", machineId)
+		}
+		return []txn.Op{{
+			C:  controllersC,
+			Id: mongoUpgradeKey,
+			Assert: bson.D{
+				{"started", doc.Started},
+				{"members." + machineId + ".phase", MongoUpgradeResyncing},
+			},
+			Update: bson.D{{"$set", bson.D{
+				{"members." + machineId + ".message", message},
+			}}},
+		}}, nil
+	}
+	return errors.Annotate(st.db().Run(buildTxn), "cannot set mongo upgrade progress")
+}
@@ -0,0 +1,184 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+)
+
+type MongoUpgradeSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&MongoUpgradeSuite{})
+
+func (s *MongoUpgradeSuite) start(c *gc.C) {
+	err := state.StartMongoUpgradeForMachines(s.State, state.MongoUpgradeCheck{
+		Target:   mongo.Mongo36wt,
+		DataSize: 1 << 30,
+		Estimate: mongo.StorageEngineMigrationEstimate{
+			Duration: 30 * time.Minute,
+			Downtime: 45 * time.Second,
+		},
+	}, []string{"0", "1", "2"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MongoUpgradeSuite) phases(c *gc.C) map[string]state.MongoUpgradePhase {
+	status, err := s.State.MongoUpgradeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	result := make(map[string]state.MongoUpgradePhase)
+	for _, member := range status.Members {
+		result[member.MachineId] = member.Phase
+	}
+	return result
+}
+
+func (s *MongoUpgradeSuite) TestMongoUpgradeStatusNotFound(c *gc.C) {
+	_, err := s.State.MongoUpgradeStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MongoUpgradeSuite) TestStartMongoUpgrade(c *gc.C) {
+	s.start(c)
+
+	status, err := s.State.MongoUpgradeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Target, gc.Equals, mongo.Mongo36wt)
+	c.Check(status.Started.IsZero(), jc.IsFalse)
+	c.Check(status.DataSize, gc.Equals, int64(1<<30))
+	c.Check(status.Estimate, jc.DeepEquals, mongo.StorageEngineMigrationEstimate{
+		Duration: 30 * time.Minute,
+		Downtime: 45 * time.Second,
+	})
+	c.Check(status.Remaining(status.Started), gc.Equals, 30*time.Minute)
+	c.Assert(status.Members, gc.HasLen, 3)
+	for i, id := range []string{"0", "1", "2"} {
+		c.Check(status.Members[i].MachineId, gc.Equals, id)
+		c.Check(status.Members[i].Phase, gc.Equals, state.MongoUpgradePending)
+	}
+	member, ok := status.Member("1")
+	c.Check(ok, jc.IsTrue)
+	c.Check(member.MachineId, gc.Equals, "1")
+	_, ok = status.Member("3")
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *MongoUpgradeSuite) TestStartMongoUpgradeInProgress(c *gc.C) {
+	s.start(c)
+	err := state.StartMongoUpgradeForMachines(s.State, state.MongoUpgradeCheck{Target: mongo.Mongo40wt}, []string{"0"})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *MongoUpgradeSuite) TestStartMongoUpgradeReplacesFinished(c *gc.C) {
+	s.start(c)
+	for _, id := range []string{"0", "1"} {
+		err := s.State.SetMongoUpgradeMember(id, state.MongoUpgradeDone, "")
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.State.SetMongoUpgradeMember("2", state.MongoUpgradeFailed, "boom")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.StartMongoUpgradeForMachines(s.State, state.MongoUpgradeCheck{Target: mongo.Mongo40wt}, []string{"0"})
+	c.Assert(err, jc.ErrorIsNil)
+	status, err := s.State.MongoUpgradeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(status.Target, gc.Equals, mongo.Mongo40wt)
+	c.Check(s.phases(c), jc.DeepEquals, map[string]state.MongoUpgradePhase{
+		"0": state.MongoUpgradePending,
+	})
+}
+
+func (s *MongoUpgradeSuite) TestSetMongoUpgradeMember(c *gc.C) {
+	s.start(c)
+	err := s.State.SetMongoUpgradeMember("1", state.MongoUpgradeFailed, "no space left")
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.State.MongoUpgradeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	member, ok := status.Member("1")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(member.Phase, gc.Equals, state.MongoUpgradeFailed)
+	c.Check(member.Message, gc.Equals, "no space left")
+}
+
+func (s *MongoUpgradeSuite) TestSetMongoUpgradeMemberUnknown(c *gc.C) {
+	s.start(c)
+	err := s.State.SetMongoUpgradeMember("42", state.MongoUpgradeDone, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MongoUpgradeSuite) TestResyncOneAtATime(c *gc.C) {
+	s.start(c)
+	err := s.State.SetMongoUpgradeMember("0", state.MongoUpgradeResyncing, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetMongoUpgradeMember("1", state.MongoUpgradeResyncing, "")
+	c.Assert(err, gc.Equals, state.ErrMongoResyncInProgress)
+
+	err = s.State.SetMongoUpgradeMember("0", state.MongoUpgradeDone, "")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetMongoUpgradeMember("1", state.MongoUpgradeResyncing, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.phases(c), jc.DeepEquals, map[string]state.MongoUpgradePhase{
+		"0": state.MongoUpgradeDone,
+		"1": state.MongoUpgradeResyncing,
+		"2": state.MongoUpgradePending,
+	})
+}
+
+func (s *MongoUpgradeSuite) TestResyncRaceLosesToOtherController(c *gc.C) {
+	s.start(c)
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.SetMongoUpgradeMember("0", state.MongoUpgradeResyncing, "")
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err := s.State.SetMongoUpgradeMember("1", state.MongoUpgradeResyncing, "")
+	c.Assert(err, gc.Equals, state.ErrMongoResyncInProgress)
+}
+
+func (s *MongoUpgradeSuite) TestSetMongoUpgradeProgress(c *gc.C) {
+	s.start(c)
+	err := s.State.SetMongoUpgradeMember("1", state.MongoUpgradeResyncing, "")
+	c.Assert(err, jc.ErrorIsNil)
+	status, err := s.State.MongoUpgradeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	started, _ := status.Member("1")
+
+	err = s.State.SetMongoUpgradeProgress("1", "resyncing from peers")
+	c.Assert(err, jc.ErrorIsNil)
+	status, err = s.State.MongoUpgradeStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	member, ok := status.Member("1")
+	c.Assert(ok, jc.IsTrue)
+	c.Check(member.Phase, gc.Equals, state.MongoUpgradeResyncing)
+	c.Check(member.Message, gc.Equals, "resyncing from peers")
+	c.Check(member.Updated, gc.Equals, started.Updated)
+}
+
+func (s *MongoUpgradeSuite) TestSetMongoUpgradeProgressNotResyncing(c *gc.C) {
+	s.start(c)
+	err := s.State.SetMongoUpgradeProgress("1", "resyncing from peers")
+	c.Assert(err, gc.ErrorMatches, `cannot set mongo upgrade progress: controller "1" is not resyncing`)
+}
+
+func (s *MongoUpgradeSuite) TestRemaining(c *gc.C) {
+	status := state.MongoUpgradeStatus{
+		Estimate: mongo.StorageEngineMigrationEstimate{Duration: 30 * time.Minute},
+		Members: []state.MongoUpgradeMember{
+			{MachineId: "0", Phase: state.MongoUpgradeDone},
+			{MachineId: "1", Phase: state.MongoUpgradeResyncing, Updated: time.Unix(0, 0)},
+			{MachineId: "2", Phase: state.MongoUpgradePending},
+		},
+	}
+	c.Check(status.Remaining(time.Unix(4*60, 0)), gc.Equals, 16*time.Minute)
+	c.Check(status.Remaining(time.Unix(20*60, 0)), gc.Equals, 10*time.Minute)
+}
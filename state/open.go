@@ -212,8 +212,10 @@ func newState(
 // Close the connection to the database.
 func (st *State) Close() (err error) {
 	defer errors.DeferredAnnotatef(&err, "closing state failed")
-	if err := st.stopWorkers(); err != nil {
-		return errors.Trace(err)
+	if st.replica == nil {
+		if err := st.stopWorkers(); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	st.session.Close()
 	logger.Debugf("closed state without error")
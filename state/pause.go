@@ -0,0 +1,70 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// IsPaused reports whether hook execution is paused for all of the
+// application's units.
+func (a *Application) IsPaused() bool {
+	return a.doc.Paused
+}
+
+// SetPaused pauses or resumes hook execution for all of the
+// application's units, so that operators can carry out maintenance
+// without the unit agents reacting to it.
+func (a *Application) SetPaused(paused bool) error {
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"paused", paused}}}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot set paused flag for application %q to %v: %v", a, paused, onAbort(err, applicationNotAliveErr))
+	}
+	a.doc.Paused = paused
+	return nil
+}
+
+// Paused reports whether hook execution is paused for the unit itself.
+// Use IsPaused to also take into account whether the unit's application
+// is paused.
+func (u *Unit) Paused() bool {
+	return u.doc.Paused
+}
+
+// IsPaused reports whether hook execution is paused for the unit,
+// either because the unit itself or its application is paused.
+func (u *Unit) IsPaused() (bool, error) {
+	if u.doc.Paused {
+		return true, nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return app.IsPaused(), nil
+}
+
+// SetPaused pauses or resumes hook execution for the unit, so that
+// operators can carry out maintenance without the unit agent reacting
+// to it.
+func (u *Unit) SetPaused(paused bool) error {
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"paused", paused}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot set paused flag for unit %q to %v: %v", u, paused, onAbort(err, unitNotAliveErr))
+	}
+	u.doc.Paused = paused
+	return nil
+}
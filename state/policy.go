@@ -46,6 +46,9 @@ type Policy interface {
 
 	// StorageProviderRegistry returns a storage.ProviderRegistry or an error.
 	StorageProviderRegistry() (storage.ProviderRegistry, error)
+
+	// CloudDestroyer returns an environs.CloudDestroyer or an error.
+	CloudDestroyer() (environs.CloudDestroyer, error)
 }
 
 // precheckInstance calls the state's assigned policy, if non-nil, to obtain
@@ -38,6 +38,10 @@ type PooledState struct {
 	isSystemState bool
 	released      bool
 	itemKey       uint64
+
+	// replica is set when the State is a read-only replica of the
+	// pooled State, which is closed on release.
+	replica bool
 }
 
 var _ PoolHelper = (*PooledState)(nil)
@@ -59,6 +63,14 @@ func newPooledState(st *State, pool *StatePool, modelUUID string, isSystemState
 // from the pool - items marked for removal are only removed when released
 // by all other reference holders.
 func (ps *PooledState) Release() bool {
+	if ps.replica && !ps.released {
+		if err := ps.State.Close(); err != nil {
+			logger.Errorf("closing read-only replica: %v", err)
+		}
+		if ps.isSystemState {
+			ps.released = true
+		}
+	}
 	if ps.isSystemState || ps.released {
 		return false
 	}
@@ -115,6 +127,10 @@ type StatePool struct {
 
 	// watcherRunner makes sure the TxnWatcher stays running.
 	watcherRunner *worker.Runner
+
+	// replicaOf is set for a view of a pool whose States read from
+	// secondaries; see ReadOnlyReplica.
+	replicaOf *StatePool
 }
 
 // OpenStatePool returns a new StatePool instance.
@@ -189,6 +205,9 @@ func OpenStatePool(args OpenParams) (*StatePool, error) {
 // if required.
 // If the State has been marked for removal, an error is returned.
 func (p *StatePool) Get(modelUUID string) (*PooledState, error) {
+	if p.replicaOf != nil {
+		return p.replicaOf.getReplica(modelUUID)
+	}
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -242,6 +261,34 @@ func (p *StatePool) Get(modelUUID string) (*PooledState, error) {
 	return ps, nil
 }
 
+// ReadOnlyReplica returns a view of the pool whose Get method returns
+// read-only replicas of the pooled States; see State.ReadOnlyReplica.
+// Each replica is closed when it is released. The view's other
+// methods act on the pool itself, and SystemState returns the
+// pool's system State, which reads from the primary.
+func (p *StatePool) ReadOnlyReplica() *StatePool {
+	if p.replicaOf != nil {
+		return p
+	}
+	return &StatePool{replicaOf: p}
+}
+
+func (p *StatePool) getReplica(modelUUID string) (*PooledState, error) {
+	ps, err := p.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	replica, err := ps.State.ReadOnlyReplica()
+	if err != nil {
+		ps.Release()
+		return nil, errors.Trace(err)
+	}
+	rps := newPooledState(replica, p, modelUUID, ps.isSystemState)
+	rps.itemKey = ps.itemKey
+	rps.replica = true
+	return rps, nil
+}
+
 func (p *StatePool) openState(modelUUID string) (*State, error) {
 	modelTag := names.NewModelTag(modelUUID)
 	session := p.systemState.session.Copy()
@@ -309,6 +356,9 @@ func (p *StatePool) release(modelUUID string, key uint64) (bool, error) {
 // corresponding Releases). The boolean result indicates whether or
 // not the state was removed.
 func (p *StatePool) Remove(modelUUID string) (bool, error) {
+	if p.replicaOf != nil {
+		return p.replicaOf.Remove(modelUUID)
+	}
 	if modelUUID == p.systemState.ModelUUID() {
 		// We do not monitor usage of the controller's state.
 		return false, nil
@@ -341,6 +391,9 @@ func (p *StatePool) maybeRemoveItem(item *PoolItem) (bool, error) {
 
 // SystemState returns the State passed in to NewStatePool.
 func (p *StatePool) SystemState() *State {
+	if p.replicaOf != nil {
+		return p.replicaOf.SystemState()
+	}
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	// State pool is closed, no more access to the system state.
@@ -352,6 +405,10 @@ func (p *StatePool) SystemState() *State {
 
 // Close closes all State instances in the pool.
 func (p *StatePool) Close() error {
+	if p.replicaOf != nil {
+		// The view doesn't own the pool.
+		return nil
+	}
 	p.mu.Lock()
 	// A nil pool map indicates that the pool has already been closed.
 	if p.pool == nil {
@@ -418,6 +475,9 @@ func (p *StatePool) Close() error {
 // IntrospectionReport produces the output for the introspection worker
 // in order to look inside the state pool.
 func (p *StatePool) IntrospectionReport() string {
+	if p.replicaOf != nil {
+		return p.replicaOf.IntrospectionReport()
+	}
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
@@ -448,6 +508,9 @@ func (p *StatePool) IntrospectionReport() string {
 // Report conforms to the Dependency Engine Report() interface, giving an opportunity to introspect
 // what is going on at runtime.
 func (p *StatePool) Report() map[string]interface{} {
+	if p.replicaOf != nil {
+		return p.replicaOf.Report()
+	}
 	p.mu.Lock()
 	report := make(map[string]interface{})
 	report["txn-watcher"] = p.watcherRunner.Report()
@@ -189,6 +189,42 @@ func (s *statePoolSuite) TestRemoveWithRefsClosesOnLastRelease(c *gc.C) {
 	assertClosed(c, st.State)
 }
 
+func (s *statePoolSuite) TestReadOnlyReplica(c *gc.C) {
+	replicas := s.StatePool.ReadOnlyReplica()
+	replica, err := replicas.Get(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replica.ModelUUID(), gc.Equals, s.ModelUUID1)
+
+	// The replica is distinct from the pooled State, but holds a
+	// reference to it until released.
+	st, err := s.StatePool.Get(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replica.State, gc.Not(gc.Equals), st.State)
+	removed := st.Release()
+	c.Assert(removed, jc.IsFalse)
+
+	removed, err = s.StatePool.Remove(s.ModelUUID1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed, jc.IsFalse)
+	assertNotClosed(c, st.State)
+
+	removed = replica.Release()
+	c.Assert(removed, jc.IsTrue)
+	assertClosed(c, st.State)
+}
+
+func (s *statePoolSuite) TestReadOnlyReplicaControllerModel(c *gc.C) {
+	replicas := s.StatePool.ReadOnlyReplica()
+	replica, err := replicas.Get(s.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replica.State, gc.Not(gc.Equals), s.State)
+	c.Assert(replicas.SystemState(), gc.Equals, s.State)
+
+	removed := replica.Release()
+	c.Assert(removed, jc.IsFalse)
+	assertNotClosed(c, s.State)
+}
+
 func (s *statePoolSuite) TestGetRemovedNotAllowed(c *gc.C) {
 	_, err := s.StatePool.Get(s.ModelUUID1)
 	c.Assert(err, jc.ErrorIsNil)
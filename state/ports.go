@@ -37,6 +37,11 @@ type PortRange struct {
 	FromPort int
 	ToPort   int
 	Protocol string
+
+	// Endpoint is the name of the charm endpoint the range is
+	// opened for. An empty endpoint means all of the unit's
+	// endpoints.
+	Endpoint string `bson:"endpoint,omitempty"`
 }
 
 // NewPortRange create a new port range and validate it.
@@ -121,8 +126,9 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 
 	// An exact port range match (including the associated unit name) is not
 	// considered a conflict due to the fact that many charms issue commands
-	// to open the same port multiple times.
-	if prA == prB {
+	// to open the same port multiple times. The same unit may also open the
+	// same range for several of its endpoints.
+	if prA.withoutEndpoint() == prB.withoutEndpoint() {
 		return nil
 	}
 	if prA.Protocol != prB.Protocol {
@@ -134,13 +140,23 @@ func (prA PortRange) CheckConflicts(prB PortRange) error {
 	return nil
 }
 
+// withoutEndpoint returns a copy of the port range with no endpoint set.
+func (p PortRange) withoutEndpoint() PortRange {
+	p.Endpoint = ""
+	return p
+}
+
 // Strings returns the port range as a string.
 func (p PortRange) String() string {
+	owner := fmt.Sprintf("%q", p.UnitName)
+	if p.Endpoint != "" {
+		owner = fmt.Sprintf("%q, endpoint %q", p.UnitName, p.Endpoint)
+	}
 	proto := strings.ToLower(p.Protocol)
 	if proto == "icmp" {
-		return fmt.Sprintf("%s (%q)", proto, p.UnitName)
+		return fmt.Sprintf("%s (%s)", proto, owner)
 	}
-	return fmt.Sprintf("%d-%d/%s (%q)", p.FromPort, p.ToPort, proto, p.UnitName)
+	return fmt.Sprintf("%d-%d/%s (%s)", p.FromPort, p.ToPort, proto, owner)
 }
 
 // portsDoc represents the state of ports opened on machines for networks
@@ -335,6 +351,14 @@ func (p *Ports) PortsForUnit(unitName string) []PortRange {
 	return ports
 }
 
+// PortRanges returns all the port ranges maintained on this document,
+// including the endpoints they are opened for.
+func (p *Ports) PortRanges() []PortRange {
+	ports := make([]PortRange, len(p.doc.Ports))
+	copy(ports, p.doc.Ports)
+	return ports
+}
+
 // Refresh refreshes the port document from state.
 func (p *Ports) Refresh() error {
 	openedPorts, closer := p.st.db().GetCollection(openedPortsC)
@@ -508,17 +532,10 @@ func removePortsForUnitOps(st *State, unit *Unit) ([]txn.Op, error) {
 	}
 	var ops []txn.Op
 	for _, ports := range allPorts {
-		allRanges := ports.AllPortRanges()
 		var keepPorts []PortRange
-		for portRange, unitName := range allRanges {
-			if unitName != unit.Name() {
-				unitRange := PortRange{
-					UnitName: unitName,
-					FromPort: portRange.FromPort,
-					ToPort:   portRange.ToPort,
-					Protocol: portRange.Protocol,
-				}
-				keepPorts = append(keepPorts, unitRange)
+		for _, portRange := range ports.PortRanges() {
+			if portRange.UnitName != unit.Name() {
+				keepPorts = append(keepPorts, portRange)
 			}
 		}
 		if len(keepPorts) > 0 {
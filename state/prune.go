@@ -17,8 +17,9 @@ import (
 // pruneCollection removes collection entries until
 // only entries newer than <maxLogTime> remain and also ensures
 // that the collection is smaller than <maxLogsMB> after the
-// deletion.
-func pruneCollection(mb modelBackend, maxHistoryTime time.Duration, maxHistoryMB int, collectionName string, ageField string, timeUnit TimeUnit) error {
+// deletion. If an archiver is given, the entries are archived
+// before they are removed.
+func pruneCollection(mb modelBackend, maxHistoryTime time.Duration, maxHistoryMB int, collectionName string, ageField string, timeUnit TimeUnit, archiver *historyArchiver) error {
 
 	// NOTE(axw) we require a raw collection to obtain the size of the
 	// collection. Take care to include model-uuid in queries where
@@ -33,6 +34,7 @@ func pruneCollection(mb modelBackend, maxHistoryTime time.Duration, maxHistoryMB
 		maxSize:  maxHistoryMB,
 		ageField: ageField,
 		timeUnit: timeUnit,
+		archiver: archiver,
 	}
 	if err := p.validate(); err != nil {
 		return errors.Trace(err)
@@ -64,6 +66,9 @@ type collectionPruner struct {
 
 	ageField string
 	timeUnit TimeUnit
+
+	// archiver, if not nil, archives the entries being pruned.
+	archiver *historyArchiver
 }
 
 func (p *collectionPruner) validate() error {
@@ -96,18 +101,17 @@ func (p *collectionPruner) pruneByAge() error {
 		notSet = time.Time{}
 	}
 
-	iter := p.coll.Find(bson.D{
+	query := p.coll.Find(bson.D{
 		{"model-uuid", p.st.modelUUID()},
 		{p.ageField, bson.M{"$gt": notSet, "$lt": age}},
-	}).Select(bson.M{"_id": 1}).Iter()
-	defer iter.Close()
+	})
 
 	modelName, err := p.st.modelName()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	logTemplate := fmt.Sprintf("%s age pruning (%s): %%d rows deleted", p.coll.Name, modelName)
-	deleted, err := deleteInBatches(p.coll, iter, logTemplate, loggo.INFO, noEarlyFinish)
+	deleted, err := p.deleteInBatches(query, logTemplate, noEarlyFinish)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -155,11 +159,10 @@ func (p *collectionPruner) pruneBySize() error {
 	}
 	toDelete := int(float64(collMB-p.maxSize) / sizePerStatus)
 
-	iter := p.coll.Find(nil).Sort(p.ageField).Limit(toDelete).Select(bson.M{"_id": 1}).Iter()
-	defer iter.Close()
+	query := p.coll.Find(nil).Sort(p.ageField).Limit(toDelete)
 
 	template := fmt.Sprintf("%s size pruning: deleted %%d of %d (estimated)", p.coll.Name, toDelete)
-	deleted, err := deleteInBatches(p.coll, iter, template, loggo.INFO, func() (bool, error) {
+	deleted, err := p.deleteInBatches(query, template, func() (bool, error) {
 		// Check that we still need to delete more
 		collMB, err := getCollectionMB(p.coll)
 		if err != nil {
@@ -180,6 +183,19 @@ func (p *collectionPruner) pruneBySize() error {
 	return nil
 }
 
+// deleteInBatches deletes the entries matching the query, archiving
+// them first if the pruner has an archiver.
+func (p *collectionPruner) deleteInBatches(query *mgo.Query, logTemplate string, shouldStop doneCheck) (int, error) {
+	if p.archiver == nil {
+		iter := query.Select(bson.M{"_id": 1}).Iter()
+		defer iter.Close()
+		return deleteInBatches(p.coll, iter, logTemplate, loggo.INFO, shouldStop)
+	}
+	iter := query.Iter()
+	defer iter.Close()
+	return archiveInBatches(p.coll, iter, p.archiver, logTemplate, loggo.INFO, shouldStop)
+}
+
 func deleteInBatches(
 	coll *mgo.Collection,
 	iter mongo.Iterator,
@@ -237,6 +253,84 @@ func deleteInBatches(
 	return deleted + chunkSize, nil
 }
 
+// archiveInBatches is like deleteInBatches, but adds the documents to
+// the archiver before deleting them. Each batch is archived before it
+// is deleted, so no document is deleted without having been archived;
+// a document may be archived twice, though, if deleting it fails.
+func archiveInBatches(
+	coll *mgo.Collection,
+	iter mongo.Iterator,
+	archiver *historyArchiver,
+	logTemplate string,
+	logLevel loggo.Level,
+	shouldStop doneCheck,
+) (int, error) {
+	var ids []interface{}
+	deleted := 0
+	deleteBatch := func() error {
+		if err := archiver.flush(); err != nil {
+			return errors.Annotate(err, "archiving batch")
+		}
+		chunk := coll.Bulk()
+		for _, id := range ids {
+			chunk.Remove(bson.D{{"_id", id}})
+		}
+		_, err := chunk.Run()
+		// NotFound indicates that records were already deleted.
+		if err != nil && err != mgo.ErrNotFound {
+			return errors.Annotate(err, "removing batch")
+		}
+		deleted += len(ids)
+		ids = ids[:0]
+		return nil
+	}
+
+	lastUpdate := time.Now()
+	var doc bson.Raw
+	for iter.Next(&doc) {
+		var idDoc struct {
+			Id interface{} `bson:"_id"`
+		}
+		if err := doc.Unmarshal(&idDoc); err != nil {
+			return 0, errors.Annotate(err, "reading document id")
+		}
+		if err := archiver.add(doc); err != nil {
+			return 0, errors.Trace(err)
+		}
+		ids = append(ids, idDoc.Id)
+		if len(ids) == archiveBatchSize {
+			if err := deleteBatch(); err != nil {
+				return 0, errors.Trace(err)
+			}
+
+			// Check that we still need to delete more
+			done, err := shouldStop()
+			if err != nil {
+				return 0, errors.Annotate(err, "checking whether to stop")
+			}
+			if done {
+				return deleted, nil
+			}
+
+			now := time.Now()
+			if now.Sub(lastUpdate) >= historyPruneProgressSeconds*time.Second {
+				logger.Logf(logLevel, logTemplate, deleted)
+				lastUpdate = now
+			}
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return 0, errors.Annotate(err, "closing iterator")
+	}
+
+	if len(ids) > 0 {
+		if err := deleteBatch(); err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+	return deleted, nil
+}
+
 func noEarlyFinish() (bool, error) {
 	return false, nil
 }
@@ -0,0 +1,502 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// quotaKeyPrefix prefixes the keys of the documents in the controllers
+// collection that hold the quotas of models and users.
+const quotaKeyPrefix = "quota#"
+
+// Quota limits the resources that a model, or all of the models owned
+// by a user, may consume. A zero limit means there is no limit.
+type Quota struct {
+	// Machines limits the number of machines, including containers.
+	Machines int
+
+	// Units limits the number of units.
+	Units int
+
+	// StorageMiB limits the total size of the volumes and filesystems,
+	// in MiB.
+	StorageMiB uint64
+}
+
+// IsZero returns whether the quota doesn't limit anything.
+func (q Quota) IsZero() bool {
+	return q == Quota{}
+}
+
+// QuotaUsage holds the resources that a model, or all of the models
+// owned by a user, consume.
+type QuotaUsage struct {
+	Machines   int
+	Units      int
+	StorageMiB uint64
+}
+
+// quotaDoc holds the quota of a model or user. UsageSerial is
+// incremented whenever resources are added under the quota, so that
+// transactions adding resources can assert that the usage they checked
+// against the quota hasn't changed since.
+type quotaDoc struct {
+	DocID       string `bson:"_id"`
+	Entity      string `bson:"entity"`
+	Machines    int    `bson:"machines,omitempty"`
+	Units       int    `bson:"units,omitempty"`
+	StorageMiB  uint64 `bson:"storage-mib,omitempty"`
+	UsageSerial int64  `bson:"usage-serial"`
+}
+
+func (doc quotaDoc) quota() Quota {
+	return Quota{
+		Machines:   doc.Machines,
+		Units:      doc.Units,
+		StorageMiB: doc.StorageMiB,
+	}
+}
+
+func quotaKey(tag names.Tag) string {
+	return quotaKeyPrefix + tag.String()
+}
+
+func checkQuotaEntity(tag names.Tag) error {
+	switch tag.(type) {
+	case names.ModelTag, names.UserTag:
+		return nil
+	}
+	return errors.NotValidf("quota for %q", tag)
+}
+
+// QuotaExceededError is returned when adding resources to a model
+// would take it, or the user that owns it, over quota.
+type QuotaExceededError struct {
+	// Entity describes the model or user whose quota is exceeded.
+	Entity string
+
+	// Resource is the kind of resource that is over quota.
+	Resource string
+
+	Limit     uint64
+	Used      uint64
+	Requested uint64
+}
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf(
+		"quota exceeded: %s is limited to %d %s (using %d, requested %d)",
+		e.Entity, e.Limit, e.Resource, e.Used, e.Requested,
+	)
+}
+
+// IsQuotaExceededError reports whether or not the error is a
+// QuotaExceededError.
+func IsQuotaExceededError(err error) bool {
+	_, ok := errors.Cause(err).(*QuotaExceededError)
+	return ok
+}
+
+// Quota returns the quota of the given model or user. The quota is
+// zero if none has been set.
+func (st *State) Quota(tag names.Tag) (Quota, error) {
+	if err := checkQuotaEntity(tag); err != nil {
+		return Quota{}, errors.Trace(err)
+	}
+	doc, err := st.readQuotaDoc(tag)
+	if err != nil || doc == nil {
+		return Quota{}, errors.Trace(err)
+	}
+	return doc.quota(), nil
+}
+
+// readQuotaDoc returns the quota document of the given model or user,
+// or nil if no quota has been set.
+func (st *State) readQuotaDoc(tag names.Tag) (*quotaDoc, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc quotaDoc
+	err := controllers.FindId(quotaKey(tag)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read quota for %s", names.ReadableString(tag))
+	}
+	return &doc, nil
+}
+
+// AllQuotas returns the quotas that have been set, keyed by the tag of
+// the model or user.
+func (st *State) AllQuotas() (map[names.Tag]Quota, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var docs []quotaDoc
+	query := bson.D{{"_id", bson.D{{"$regex", "^" + quotaKeyPrefix}}}}
+	if err := controllers.Find(query).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read quotas")
+	}
+	result := make(map[names.Tag]Quota, len(docs))
+	for _, doc := range docs {
+		tag, err := names.ParseTag(doc.Entity)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[tag] = doc.quota()
+	}
+	return result, nil
+}
+
+// SetQuota sets the quota of the given model or user. Setting a zero
+// quota removes any limits.
+func (st *State) SetQuota(tag names.Tag, quota Quota) error {
+	if err := checkQuotaEntity(tag); err != nil {
+		return errors.Trace(err)
+	}
+	if quota.Machines < 0 || quota.Units < 0 {
+		return errors.NotValidf("negative quota")
+	}
+	key := quotaKey(tag)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		existing, err := st.Quota(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing == quota {
+			return nil, jujutxn.ErrNoOperations
+		}
+		if quota.IsZero() {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     key,
+				Assert: txn.DocExists,
+				Remove: true,
+			}}, nil
+		}
+		doc := quotaDoc{
+			DocID:      key,
+			Entity:     tag.String(),
+			Machines:   quota.Machines,
+			Units:      quota.Units,
+			StorageMiB: quota.StorageMiB,
+		}
+		if existing.IsZero() {
+			ops := []txn.Op{{
+				C:      controllersC,
+				Id:     key,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}}
+			modelOps, err := st.newQuotaModelOps(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return append(ops, modelOps...), nil
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     key,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"machines", doc.Machines},
+				{"units", doc.Units},
+				{"storage-mib", doc.StorageMiB},
+			}}, {"$inc", bson.D{{"usage-serial", 1}}}},
+		}}, nil
+	}
+	return errors.Annotatef(st.db().Run(buildTxn), "cannot set quota for %s", names.ReadableString(tag))
+}
+
+// newQuotaModelOps returns the operations that bump the quota serial
+// of each model the new quota of the given model or user applies to.
+// Transactions adding resources to a model without quotas assert the
+// model's quota serial rather than the absence of the quota documents,
+// so they don't contend with every other model owned by the same user.
+func (st *State) newQuotaModelOps(tag names.Tag) ([]txn.Op, error) {
+	modelUUIDs, err := st.quotaModelUUIDs(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops := make([]txn.Op, len(modelUUIDs))
+	for i, modelUUID := range modelUUIDs {
+		ops[i] = txn.Op{
+			C:      modelsC,
+			Id:     modelUUID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$inc", bson.D{{"quota-serial", 1}}}},
+		}
+	}
+	return ops, nil
+}
+
+// QuotaUsage returns the resources consumed by the given model, or by
+// all of the models owned by the given user.
+func (st *State) QuotaUsage(tag names.Tag) (QuotaUsage, error) {
+	modelUUIDs, err := st.quotaModelUUIDs(tag)
+	if err != nil {
+		return QuotaUsage{}, errors.Trace(err)
+	}
+	return st.quotaUsage(modelUUIDs, QuotaUsage{Machines: 1, Units: 1, StorageMiB: 1})
+}
+
+func (st *State) quotaModelUUIDs(tag names.Tag) ([]string, error) {
+	switch tag := tag.(type) {
+	case names.ModelTag:
+		return []string{tag.Id()}, nil
+	case names.UserTag:
+		models, closer := st.db().GetCollection(modelsC)
+		defer closer()
+		var docs []modelDoc
+		err := models.Find(bson.D{{"owner", tag.Id()}}).Select(bson.D{{"_id", 1}}).All(&docs)
+		if err != nil {
+			return nil, errors.Annotatef(err, "cannot read models owned by %q", tag.Id())
+		}
+		uuids := make([]string, len(docs))
+		for i, doc := range docs {
+			uuids[i] = doc.UUID
+		}
+		return uuids, nil
+	}
+	return nil, errors.NotValidf("quota for %q", tag)
+}
+
+// quotaUsage returns the resources consumed by the given models. Only
+// the resources that are non-zero in counted are counted; the others
+// are left zero.
+func (st *State) quotaUsage(modelUUIDs []string, counted QuotaUsage) (QuotaUsage, error) {
+	var usage QuotaUsage
+	if len(modelUUIDs) == 0 {
+		return usage, nil
+	}
+	alive := bson.D{
+		{"model-uuid", bson.D{{"$in", modelUUIDs}}},
+		{"life", bson.D{{"$ne", Dead}}},
+	}
+	if counted.Machines > 0 {
+		machines, closer := st.db().GetRawCollection(machinesC)
+		defer closer()
+		n, err := machines.Find(alive).Count()
+		if err != nil {
+			return QuotaUsage{}, errors.Annotate(err, "cannot count machines")
+		}
+		usage.Machines = n
+	}
+
+	// Units are counted by application, so that the scale requested
+	// for CAAS applications is counted before their pods appear.
+	if counted.Units > 0 {
+		applications, closer := st.db().GetRawCollection(applicationsC)
+		defer closer()
+		var applicationDocs []applicationDoc
+		err := applications.Find(alive).Select(bson.D{{"unitcount", 1}, {"scale", 1}}).All(&applicationDocs)
+		if err != nil {
+			return QuotaUsage{}, errors.Annotate(err, "cannot read applications")
+		}
+		for _, doc := range applicationDocs {
+			usage.Units += applicationQuotaUnits(doc.UnitCount, doc.DesiredScale)
+		}
+	}
+
+	if counted.StorageMiB > 0 {
+		volumesMiB, err := st.storageQuotaUsage(volumesC, alive)
+		if err != nil {
+			return QuotaUsage{}, errors.Trace(err)
+		}
+		// Filesystems backed by volumes are already counted.
+		filesystemsMiB, err := st.storageQuotaUsage(filesystemsC, append(alive,
+			bson.DocElem{"volumeid", bson.D{{"$in", []interface{}{"", nil}}}},
+		))
+		if err != nil {
+			return QuotaUsage{}, errors.Trace(err)
+		}
+		usage.StorageMiB = volumesMiB + filesystemsMiB
+	}
+	return usage, nil
+}
+
+// storageQuotaUsage returns the total size of the volumes or
+// filesystems matching the query. The sizes are summed by the database
+// so that the documents aren't read.
+func (st *State) storageQuotaUsage(collection string, query bson.D) (uint64, error) {
+	coll, closer := st.db().GetRawCollection(collection)
+	defer closer()
+
+	var result struct {
+		Size int64 `bson:"size"`
+	}
+	err := coll.Pipe([]bson.M{
+		{"$match": query},
+		{"$group": bson.M{
+			"_id":  nil,
+			"size": bson.M{"$sum": bson.M{"$ifNull": []interface{}{"$info.size", "$params.size"}}},
+		}},
+	}).One(&result)
+	if err == mgo.ErrNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, errors.Annotatef(err, "cannot sum sizes of %s", collection)
+	}
+	return uint64(result.Size), nil
+}
+
+// applicationQuotaUnits returns the number of units counted against
+// quotas for an application with the given number of units and scale.
+func applicationQuotaUnits(unitCount, scale int) int {
+	if scale > unitCount {
+		return scale
+	}
+	return unitCount
+}
+
+// CheckQuotas returns a QuotaExceededError if adding the given
+// resources to the model would exceed the quota of the model, or of
+// the user that owns it.
+func (st *State) CheckQuotas(modelUUID string, add QuotaUsage) error {
+	_, err := st.quotaOps(modelUUID, add)
+	return errors.Trace(err)
+}
+
+// quotaOps returns a QuotaExceededError if adding the given resources
+// to the model would exceed the quota of the model, or of the user that
+// owns it. Otherwise it returns the operations to include in the
+// transaction adding the resources. These assert that no quota has
+// been set on the model or its owner since, and bump the usage serial
+// of each existing quota so that concurrent transactions adding
+// resources under the same quota abort and are checked again. Usage is
+// only counted for the resources that are both limited and added.
+//
+// Machines created to host units when they are assigned are admitted
+// along with the units, by the API server, rather than here.
+func (st *State) quotaOps(modelUUID string, add QuotaUsage) ([]txn.Op, error) {
+	if add == (QuotaUsage{}) {
+		return nil, nil
+	}
+	models, closer := st.db().GetCollection(modelsC)
+	defer closer()
+	var doc modelDoc
+	if err := models.FindId(modelUUID).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("model %q", modelUUID)
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot read model %q", modelUUID)
+	}
+
+	quotaSerial := bson.D{{"quota-serial", doc.QuotaSerial}}
+	if doc.QuotaSerial == 0 {
+		quotaSerial = bson.D{{"quota-serial", bson.D{{"$exists", false}}}}
+	}
+	ops := []txn.Op{{
+		C:      modelsC,
+		Id:     modelUUID,
+		Assert: quotaSerial,
+	}}
+	for _, tag := range []names.Tag{
+		names.NewModelTag(modelUUID),
+		names.NewUserTag(doc.Owner),
+	} {
+		qdoc, err := st.readQuotaDoc(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if qdoc == nil {
+			continue
+		}
+		counted := limitedQuotaUsage(qdoc.quota(), add)
+		var usage QuotaUsage
+		if counted != (QuotaUsage{}) {
+			modelUUIDs, err := st.quotaModelUUIDs(tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if usage, err = st.quotaUsage(modelUUIDs, counted); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		entity := fmt.Sprintf("model %q", doc.Name)
+		if tag.Kind() == names.UserTagKind {
+			entity = fmt.Sprintf("user %q", tag.Id())
+		}
+		if err := checkQuota(entity, qdoc.quota(), usage, add); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, txn.Op{
+			C:      controllersC,
+			Id:     qdoc.DocID,
+			Assert: bson.D{{"usage-serial", qdoc.UsageSerial}},
+			Update: bson.D{{"$inc", bson.D{{"usage-serial", 1}}}},
+		})
+	}
+	return ops, nil
+}
+
+// limitedQuotaUsage returns the resources in add that are limited by
+// the quota.
+func limitedQuotaUsage(quota Quota, add QuotaUsage) QuotaUsage {
+	var limited QuotaUsage
+	if quota.Machines > 0 {
+		limited.Machines = add.Machines
+	}
+	if quota.Units > 0 {
+		limited.Units = add.Units
+	}
+	if quota.StorageMiB > 0 {
+		limited.StorageMiB = add.StorageMiB
+	}
+	return limited
+}
+
+// storageQuotaMiB returns the size of the storage created for a unit
+// with the given storage constraints, as counted against quotas.
+func storageQuotaMiB(cons map[string]StorageConstraints) uint64 {
+	var total uint64
+	for _, c := range cons {
+		total += c.Size * c.Count
+	}
+	return total
+}
+
+// volumesQuotaMiB returns the size of the volumes created with a
+// machine from the given template, as counted against quotas.
+func volumesQuotaMiB(template MachineTemplate) uint64 {
+	var total uint64
+	for _, v := range template.Volumes {
+		total += v.Volume.Size
+	}
+	return total
+}
+
+func checkQuota(entity string, quota Quota, usage, add QuotaUsage) error {
+	for _, check := range []struct {
+		resource         string
+		limit, used, add uint64
+	}{
+		{"machines", uint64(quota.Machines), uint64(usage.Machines), uint64(add.Machines)},
+		{"units", uint64(quota.Units), uint64(usage.Units), uint64(add.Units)},
+		{"MiB of storage", quota.StorageMiB, usage.StorageMiB, add.StorageMiB},
+	} {
+		if check.limit == 0 || check.add == 0 {
+			continue
+		}
+		if check.used+check.add > check.limit {
+			return &QuotaExceededError{
+				Entity:    entity,
+				Resource:  check.resource,
+				Limit:     check.limit,
+				Used:      check.used,
+				Requested: check.add,
+			}
+		}
+	}
+	return nil
+}
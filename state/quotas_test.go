@@ -0,0 +1,232 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type QuotaSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&QuotaSuite{})
+
+func (s *QuotaSuite) TestQuotaNotSet(c *gc.C) {
+	quota, err := s.State.Quota(s.Model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota.IsZero(), jc.IsTrue)
+}
+
+func (s *QuotaSuite) TestSetQuota(c *gc.C) {
+	modelTag := s.Model.ModelTag()
+	userTag := names.NewUserTag("bob")
+	err := s.State.SetQuota(modelTag, state.Quota{Machines: 3, StorageMiB: 2048})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetQuota(userTag, state.Quota{Units: 10})
+	c.Assert(err, jc.ErrorIsNil)
+
+	quota, err := s.State.Quota(modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quota, gc.Equals, state.Quota{Machines: 3, StorageMiB: 2048})
+
+	err = s.State.SetQuota(modelTag, state.Quota{Units: 5})
+	c.Assert(err, jc.ErrorIsNil)
+	quotas, err := s.State.AllQuotas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quotas, jc.DeepEquals, map[names.Tag]state.Quota{
+		modelTag: {Units: 5},
+		userTag:  {Units: 10},
+	})
+
+	err = s.State.SetQuota(modelTag, state.Quota{})
+	c.Assert(err, jc.ErrorIsNil)
+	quotas, err = s.State.AllQuotas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(quotas, jc.DeepEquals, map[names.Tag]state.Quota{
+		userTag: {Units: 10},
+	})
+}
+
+func (s *QuotaSuite) TestSetQuotaInvalid(c *gc.C) {
+	err := s.State.SetQuota(names.NewMachineTag("0"), state.Quota{Units: 1})
+	c.Assert(err, gc.ErrorMatches, `quota for "machine-0" not valid`)
+	err = s.State.SetQuota(s.Model.ModelTag(), state.Quota{Units: -1})
+	c.Assert(err, gc.ErrorMatches, `.*negative quota not valid`)
+}
+
+func (s *QuotaSuite) TestQuotaUsage(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	s.Factory.MakeUnit(c, nil)
+
+	usage, err := s.State.QuotaUsage(s.Model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.Equals, state.QuotaUsage{Machines: 2, Units: 1})
+
+	owner := s.Model.Owner()
+	st := s.Factory.MakeModel(c, &factory.ModelParams{Owner: owner})
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	f.MakeMachine(c, nil)
+
+	usage, err = s.State.QuotaUsage(owner)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.Equals, state.QuotaUsage{Machines: 3, Units: 1})
+}
+
+func (s *QuotaSuite) TestQuotaUsageStorage(c *gc.C) {
+	_, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Volumes: []state.HostVolumeParams{{
+			Volume: state.VolumeParams{Pool: "loop", Size: 1024},
+		}, {
+			Volume: state.VolumeParams{Pool: "loop", Size: 512},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	usage, err := s.State.QuotaUsage(s.Model.ModelTag())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage, gc.Equals, state.QuotaUsage{Machines: 1, StorageMiB: 1536})
+}
+
+func (s *QuotaSuite) TestAddMachineWithoutQuotas(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing is asserted about the quota documents, so no stash
+	// documents are created for them.
+	n, err := s.Session.DB("juju").C("txns.stash").Find(bson.D{{"_id.c", "controllers"}}).Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 0)
+}
+
+func (s *QuotaSuite) TestCheckQuotasModel(c *gc.C) {
+	s.Factory.MakeMachine(c, nil)
+	err := s.State.SetQuota(s.Model.ModelTag(), state.Quota{Machines: 2})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CheckQuotas(s.State.ModelUUID(), state.QuotaUsage{Machines: 1, Units: 5})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CheckQuotas(s.State.ModelUUID(), state.QuotaUsage{Machines: 2})
+	c.Assert(err, gc.ErrorMatches, `quota exceeded: model "testmodel" is limited to 2 machines \(using 1, requested 2\)`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+}
+
+func (s *QuotaSuite) TestCheckQuotasOwner(c *gc.C) {
+	s.Factory.MakeUnit(c, nil)
+	err := s.State.SetQuota(s.Model.Owner(), state.Quota{Units: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CheckQuotas(s.State.ModelUUID(), state.QuotaUsage{Units: 1})
+	c.Assert(err, gc.ErrorMatches, `quota exceeded: user "test-admin" is limited to 1 units \(using 1, requested 1\)`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+}
+
+func (s *QuotaSuite) TestAddMachinesEnforcesQuota(c *gc.C) {
+	err := s.State.SetQuota(s.Model.ModelTag(), state.Quota{Machines: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddMachines(
+		state.MachineTemplate{Series: "quantal", Jobs: []state.MachineJob{state.JobHostUnits}},
+		state.MachineTemplate{Series: "quantal", Jobs: []state.MachineJob{state.JobHostUnits}},
+	)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: quota exceeded: model "testmodel" is limited to 2 machines \(using 1, requested 2\)`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *QuotaSuite) TestAddMachineQuotaUsageChangedConcurrently(c *gc.C) {
+	err := s.State.SetQuota(s.Model.ModelTag(), state.Quota{Machines: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: quota exceeded: model "testmodel" is limited to 1 machines \(using 1, requested 1\)`)
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+}
+
+func (s *QuotaSuite) TestAddMachineQuotaSetConcurrently(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.SetQuota(s.Model.ModelTag(), state.Quota{Machines: 1})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: quota exceeded: model "testmodel" is limited to 1 machines \(using 1, requested 1\)`)
+	machines, err := s.State.AllMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+}
+
+func (s *QuotaSuite) TestAddUnitOwnerQuotaSetConcurrently(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := s.State.SetQuota(s.Model.Owner(), state.Quota{Units: 1})
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "mysql": quota exceeded: user "test-admin" is limited to 1 units \(using 1, requested 1\)`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+}
+
+func (s *QuotaSuite) TestAddUnitEnforcesQuota(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetQuota(s.Model.Owner(), state.Quota{Units: 1})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "mysql": quota exceeded: user "test-admin" is limited to 1 units \(using 1, requested 1\)`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+}
+
+func (s *QuotaSuite) TestSetScaleEnforcesQuota(c *gc.C) {
+	st := s.Factory.MakeCAASModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+	app := f.MakeApplication(c, &factory.ApplicationParams{Name: "gitlab", Charm: ch})
+	err := st.SetQuota(names.NewModelTag(st.ModelUUID()), state.Quota{Units: 3})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.SetScale(3)
+	c.Assert(err, jc.ErrorIsNil)
+	usage, err := st.QuotaUsage(names.NewModelTag(st.ModelUUID()))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(usage.Units, gc.Equals, 3)
+
+	_, err = app.ChangeScale(1)
+	c.Assert(err, gc.ErrorMatches, `cannot set scale for application "gitlab" to 4: quota exceeded: .* is limited to 3 units \(using 3, requested 1\)`)
+	c.Assert(state.IsQuotaExceededError(err), jc.IsTrue)
+
+	err = app.SetScale(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.GetScale(), gc.Equals, 2)
+}
@@ -0,0 +1,125 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/maintenance"
+)
+
+// RefreshPolicy determines how an application follows new revisions of
+// its charm in the charm store. New revisions are always recorded, so
+// that they are reported as available in the application's status;
+// the policy decides whether the controller also refreshes the
+// application to them.
+type RefreshPolicy struct {
+	// Channel is the charm store channel tracked for new revisions.
+	// If empty, the channel the application was deployed from is
+	// tracked.
+	Channel csparams.Channel
+
+	// Auto is true if the application should be refreshed to new
+	// revisions automatically, rather than only being notified of
+	// them.
+	Auto bool
+
+	// WindowStart and WindowEnd delimit the time of day, as offsets
+	// from midnight UTC, within which automatic refreshes may take
+	// place. A window that ends before it starts spans midnight. If
+	// both are zero, refreshes may take place at any time.
+	WindowStart time.Duration
+	WindowEnd   time.Duration
+
+	// Hold suspends automatic refreshes without discarding the rest
+	// of the policy.
+	Hold bool
+}
+
+// Validate returns an error if the policy is not valid.
+func (p RefreshPolicy) Validate() error {
+	for _, d := range []time.Duration{p.WindowStart, p.WindowEnd} {
+		if d < 0 || d >= 24*time.Hour {
+			return errors.NotValidf("refresh window time %v (must be within a day)", d)
+		}
+	}
+	return nil
+}
+
+// InWindow reports whether the given time falls within the policy's
+// refresh window.
+func (p RefreshPolicy) InWindow(t time.Time) bool {
+	return maintenance.Window{Start: p.WindowStart, End: p.WindowEnd}.Contains(t)
+}
+
+// ShouldRefresh reports whether an application with this policy should
+// be refreshed automatically at the given time.
+func (p RefreshPolicy) ShouldRefresh(t time.Time) bool {
+	return p.Auto && !p.Hold && p.InWindow(t)
+}
+
+// refreshPolicyDoc is the persistent representation of a RefreshPolicy.
+type refreshPolicyDoc struct {
+	Channel     string `bson:"channel,omitempty"`
+	Auto        bool   `bson:"auto"`
+	WindowStart int64  `bson:"window-start"`
+	WindowEnd   int64  `bson:"window-end"`
+	Hold        bool   `bson:"hold"`
+}
+
+// RefreshPolicy returns the application's refresh policy. Applications
+// without a policy of their own track the channel they were deployed
+// from and are only notified of new revisions.
+func (a *Application) RefreshPolicy() RefreshPolicy {
+	doc := a.doc.RefreshPolicy
+	if doc == nil {
+		return RefreshPolicy{}
+	}
+	return RefreshPolicy{
+		Channel:     csparams.Channel(doc.Channel),
+		Auto:        doc.Auto,
+		WindowStart: time.Duration(doc.WindowStart),
+		WindowEnd:   time.Duration(doc.WindowEnd),
+		Hold:        doc.Hold,
+	}
+}
+
+// TrackedChannel returns the charm store channel checked for new
+// revisions of the application's charm.
+func (a *Application) TrackedChannel() csparams.Channel {
+	if channel := a.RefreshPolicy().Channel; channel != csparams.NoChannel {
+		return channel
+	}
+	return a.Channel()
+}
+
+// SetRefreshPolicy sets the application's refresh policy.
+func (a *Application) SetRefreshPolicy(policy RefreshPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Annotatef(err, "cannot set refresh policy for application %q", a)
+	}
+	doc := &refreshPolicyDoc{
+		Channel:     string(policy.Channel),
+		Auto:        policy.Auto,
+		WindowStart: int64(policy.WindowStart),
+		WindowEnd:   int64(policy.WindowEnd),
+		Hold:        policy.Hold,
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"refresh-policy", doc}}}},
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return errors.Errorf("cannot set refresh policy for application %q: %v", a, onAbort(err, applicationNotAliveErr))
+	}
+	a.doc.RefreshPolicy = doc
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type RefreshPolicySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RefreshPolicySuite{})
+
+func (s *RefreshPolicySuite) TestInWindow(c *gc.C) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2019, 6, 1, hour, minute, 0, 0, time.UTC)
+	}
+	for i, test := range []struct {
+		start, end time.Duration
+		t          time.Time
+		expect     bool
+	}{
+		{0, 0, at(13, 0), true},
+		{2 * time.Hour, 4 * time.Hour, at(1, 59), false},
+		{2 * time.Hour, 4 * time.Hour, at(2, 0), true},
+		{2 * time.Hour, 4 * time.Hour, at(3, 59), true},
+		{2 * time.Hour, 4 * time.Hour, at(4, 0), false},
+		{22 * time.Hour, 2 * time.Hour, at(23, 0), true},
+		{22 * time.Hour, 2 * time.Hour, at(1, 0), true},
+		{22 * time.Hour, 2 * time.Hour, at(12, 0), false},
+	} {
+		c.Logf("test %d: %v-%v at %v", i, test.start, test.end, test.t)
+		policy := state.RefreshPolicy{WindowStart: test.start, WindowEnd: test.end}
+		c.Check(policy.InWindow(test.t), gc.Equals, test.expect)
+	}
+}
+
+func (s *RefreshPolicySuite) TestInWindowLocalTime(c *gc.C) {
+	policy := state.RefreshPolicy{WindowStart: 2 * time.Hour, WindowEnd: 4 * time.Hour}
+	t := time.Date(2019, 6, 1, 5, 0, 0, 0, time.FixedZone("UTC+2", 2*60*60))
+	c.Assert(policy.InWindow(t), jc.IsTrue)
+}
+
+func (s *RefreshPolicySuite) TestShouldRefresh(c *gc.C) {
+	t := time.Date(2019, 6, 1, 3, 0, 0, 0, time.UTC)
+	c.Check(state.RefreshPolicy{}.ShouldRefresh(t), jc.IsFalse)
+	c.Check(state.RefreshPolicy{Auto: true}.ShouldRefresh(t), jc.IsTrue)
+	c.Check(state.RefreshPolicy{Auto: true, Hold: true}.ShouldRefresh(t), jc.IsFalse)
+	c.Check(state.RefreshPolicy{
+		Auto:        true,
+		WindowStart: 4 * time.Hour,
+		WindowEnd:   5 * time.Hour,
+	}.ShouldRefresh(t), jc.IsFalse)
+}
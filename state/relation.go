@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
@@ -47,6 +48,10 @@ type relationDoc struct {
 	UnitCount       int        `bson:"unitcount"`
 	Suspended       bool       `bson:"suspended"`
 	SuspendedReason string     `bson:"suspended-reason"`
+
+	// DrainTimeout is how long a departing unit is kept in scope, so
+	// that its counterparts can run their cleanup hooks.
+	DrainTimeout time.Duration `bson:"drain-timeout,omitempty"`
 }
 
 // Relation represents a relation between one or two application endpoints.
@@ -81,6 +86,34 @@ func (r *Relation) SuspendedReason() string {
 	return r.doc.SuspendedReason
 }
 
+// DrainTimeout returns how long a unit departing the relation is kept in
+// its scope, while its counterpart units run their relation-departed
+// hooks, before its departure is completed regardless.
+func (r *Relation) DrainTimeout() time.Duration {
+	return r.doc.DrainTimeout
+}
+
+// SetDrainTimeout sets how long units departing the relation are kept in
+// their scopes. A zero timeout means departing units leave immediately.
+func (r *Relation) SetDrainTimeout(timeout time.Duration) error {
+	if timeout < 0 {
+		return errors.NotValidf("negative drain timeout %v", timeout)
+	}
+	ops := []txn.Op{{
+		C:      relationsC,
+		Id:     r.doc.DocID,
+		Assert: notDeadDoc,
+		Update: bson.D{{"$set", bson.D{{"drain-timeout", timeout}}}},
+	}}
+	if err := r.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.Errorf("cannot set drain timeout of relation %q: relation is dead or removed", r)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set drain timeout of relation %q", r)
+	}
+	r.doc.DrainTimeout = timeout
+	return nil
+}
+
 // Refresh refreshes the contents of the relation from the underlying
 // state. It returns an error that satisfies errors.IsNotFound if the
 // relation has been removed.
@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -761,6 +763,23 @@ func (s *RelationSuite) TestSetSuspendFalse(c *gc.C) {
 	c.Assert(rel.Suspended(), jc.IsFalse)
 }
 
+func (s *RelationSuite) TestSetDrainTimeout(c *gc.C) {
+	rel := s.setupRelationStatus(c)
+	c.Assert(rel.DrainTimeout(), gc.Equals, time.Duration(0))
+	err := rel.SetDrainTimeout(5 * time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.DrainTimeout(), gc.Equals, 5*time.Minute)
+	rel, err = s.State.Relation(rel.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.DrainTimeout(), gc.Equals, 5*time.Minute)
+}
+
+func (s *RelationSuite) TestSetDrainTimeoutNegative(c *gc.C) {
+	rel := s.setupRelationStatus(c)
+	err := rel.SetDrainTimeout(-time.Second)
+	c.Assert(err, gc.ErrorMatches, `negative drain timeout -1s not valid`)
+}
+
 func (s *RelationSuite) TestResumeRelationNoConsumeAccess(c *gc.C) {
 	rel := s.setupRelationStatus(c)
 	err := rel.SetSuspended(true, "reason")
@@ -0,0 +1,89 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// RelationSettingsSize returns the size, in bytes, that a unit's settings
+// in a relation count against the controller's limit. It is the total
+// length of the keys and values.
+func RelationSettingsSize(settings map[string]interface{}) int {
+	var size int
+	for k, v := range settings {
+		size += len(k)
+		if s, ok := v.(string); ok {
+			size += len(s)
+		} else {
+			size += len(fmt.Sprint(v))
+		}
+	}
+	return size
+}
+
+// RelationSettingsSizes returns the total size of the settings of the
+// units of each application in all of the model's relations, keyed by
+// application name.
+func (st *State) RelationSettingsSizes() (map[string]int, error) {
+	settings, closer := st.db().GetCollection(settingsC)
+	defer closer()
+
+	sel := bson.D{{"_id", bson.D{{"$regex", "^" + st.docID("r#")}}}}
+	iter := settings.Find(sel).Iter()
+	defer iter.Close()
+
+	sizes := make(map[string]int)
+	var doc settingsDoc
+	for iter.Next(&doc) {
+		unitName := unitNameFromScopeKey(st.localID(doc.DocID))
+		if !names.IsValidUnit(unitName) {
+			continue
+		}
+		appName, err := names.UnitApplication(unitName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		sizes[appName] += RelationSettingsSize(doc.Settings)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "cannot read relation settings")
+	}
+	return sizes, nil
+}
+
+// UnitSettings returns the settings of the units in the relation's
+// scope, keyed by unit name.
+func (r *Relation) UnitSettings() (map[string]map[string]interface{}, error) {
+	relationScopes, closer := r.st.db().GetCollection(relationScopesC)
+	defer closer()
+
+	sel := bson.D{{"key", bson.D{{"$regex", "^" + r.globalScope() + "#"}}}}
+	var scopeDocs []relationScopeDoc
+	if err := relationScopes.Find(sel).All(&scopeDocs); err != nil {
+		return nil, errors.Annotatef(err, "cannot read scope of relation %q", r)
+	}
+	keys := make([]string, len(scopeDocs))
+	for i, doc := range scopeDocs {
+		keys[i] = r.st.docID(doc.Key)
+	}
+
+	settings, closer := r.st.db().GetCollection(settingsC)
+	defer closer()
+
+	var docs []settingsDoc
+	if err := settings.Find(bson.D{{"_id", bson.D{{"$in", keys}}}}).All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot read settings of relation %q", r)
+	}
+	result := make(map[string]map[string]interface{})
+	for _, doc := range docs {
+		unitName := unitNameFromScopeKey(r.st.localID(doc.DocID))
+		result[unitName] = doc.Settings
+	}
+	return result, nil
+}
@@ -358,6 +358,77 @@ func (ru *RelationUnit) LeaveScope() error {
 	return err
 }
 
+// DrainScope signals that the unit is done with the relation, but that its
+// counterpart units may still be running their relation-departed hooks.
+// If the relation has a drain timeout and counterpart units remain in the
+// scope, the unit is reported as departed but kept in the scope until the
+// timeout expires, when its departure is completed by a cleanup; otherwise
+// it leaves the scope immediately, as with LeaveScope.
+func (ru *RelationUnit) DrainScope() error {
+	timeout := ru.relation.DrainTimeout()
+	if timeout == 0 {
+		return ru.LeaveScope()
+	}
+	if counterparts, err := ru.counterpartsInScope(); err != nil {
+		return errors.Trace(err)
+	} else if counterparts == 0 {
+		return ru.LeaveScope()
+	}
+
+	key := ru.key()
+	deadline := ru.st.clock().Now().Add(timeout)
+	ops := []txn.Op{{
+		C:      relationScopesC,
+		Id:     key,
+		Assert: bson.D{{"drain-deadline", bson.D{{"$exists", false}}}},
+		Update: bson.D{{"$set", bson.D{
+			{"departing", true},
+			{"drain-deadline", deadline},
+		}}},
+	}, newCleanupAtOp(deadline, cleanupDrainedRelationUnit, ru.relation.doc.Key, ru.unitName, ru.scope)}
+	if err := ru.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		// The unit has either already left the scope,
+		// or is already draining.
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot drain scope of unit %q in relation %q", ru.unitName, ru.relation)
+	}
+	logger.Debugf("unit %q draining scope of relation %q until %v", ru.unitName, ru.relation, deadline)
+	return nil
+}
+
+// DrainDeadline returns the time at which the unit's departure from the
+// relation will be completed, if it is draining its scope. It returns the
+// zero time if the unit is not draining, and an error satisfying
+// errors.IsNotFound if the unit is not in the scope.
+func (ru *RelationUnit) DrainDeadline() (time.Time, error) {
+	relationScopes, closer := ru.st.db().GetCollection(relationScopesC)
+	defer closer()
+
+	var doc relationScopeDoc
+	if err := relationScopes.FindId(ru.key()).One(&doc); err == mgo.ErrNotFound {
+		return time.Time{}, errors.NotFoundf("unit %q in scope of relation %q", ru.unitName, ru.relation)
+	} else if err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+	return doc.DrainDeadline, nil
+}
+
+// counterpartsInScope returns the number of counterpart units that are
+// in the unit's scope and not departing.
+func (ru *RelationUnit) counterpartsInScope() (int, error) {
+	relationScopes, closer := ru.st.db().GetCollection(relationScopesC)
+	defer closer()
+
+	prefix := ru._key(string(counterpartRole(ru.endpoint.Role)), "")
+	sel := bson.D{
+		{"key", bson.D{{"$regex", "^" + prefix}, {"$ne", ru.key()}}},
+		{"departing", bson.D{{"$ne", true}}},
+	}
+	count, err := relationScopes.Find(sel).Count()
+	return count, errors.Trace(err)
+}
+
 // leaveScopeForcedOps is an internal method used by other state objects when they just want
 // to get database operations that are involved in leaving scop without
 // the actual immeiate act of leaving scope.
@@ -721,6 +792,10 @@ type relationScopeDoc struct {
 	Key       string `bson:"key"`
 	ModelUUID string `bson:"model-uuid"`
 	Departing bool   `bson:"departing"`
+
+	// DrainDeadline, if set, is the time at which the departure of a
+	// unit draining its scope is completed.
+	DrainDeadline time.Time `bson:"drain-deadline,omitempty"`
 }
 
 func (d *relationScopeDoc) unitName() string {
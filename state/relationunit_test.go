@@ -315,6 +315,50 @@ func (s *RelationUnitSuite) TestContainerSettings(c *gc.C) {
 	}
 }
 
+func (s *RelationUnitSuite) TestRelationSettingsSizes(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeContainer)
+	err := prr.pru0.EnterScope(map[string]interface{}{"gene": "hackman"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pru1.EnterScope(map[string]interface{}{"meme": "foul-bachelor-frog"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru0.EnterScope(map[string]interface{}{"level": "debug"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sizes, err := s.State.RelationSettingsSizes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sizes, jc.DeepEquals, map[string]int{
+		"mysql":   len("gene") + len("hackman") + len("meme") + len("foul-bachelor-frog"),
+		"logging": len("level") + len("debug"),
+	})
+}
+
+func (s *RelationUnitSuite) TestUnitSettings(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	err := prr.pru0.EnterScope(map[string]interface{}{"gene": "simmons"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru0.EnterScope(map[string]interface{}{"meme": "foul-bachelor-frog"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pru1.EnterScope(map[string]interface{}{"gone": "away"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.pru1.LeaveScope()
+	c.Assert(err, jc.ErrorIsNil)
+
+	settings, err := prr.rel.UnitSettings()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(settings, jc.DeepEquals, map[string]map[string]interface{}{
+		"mysql/0":     {"gene": "simmons"},
+		"wordpress/0": {"meme": "foul-bachelor-frog"},
+	})
+}
+
+func (s *RelationUnitSuite) TestRelationSettingsSize(c *gc.C) {
+	c.Assert(state.RelationSettingsSize(nil), gc.Equals, 0)
+	c.Assert(state.RelationSettingsSize(map[string]interface{}{
+		"gene": "hackman",
+		"port": 3306,
+	}), gc.Equals, len("gene")+len("hackman")+len("port")+len("3306"))
+}
+
 func (s *RelationUnitSuite) TestContainerCreateSubordinate(c *gc.C) {
 	papp := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
 	rapp := s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
@@ -834,6 +878,66 @@ func (s *RelationUnitSuite) testPrepareLeaveScope(c *gc.C, rel *state.Relation,
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *RelationUnitSuite) TestDrainScope(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	err := prr.rel.SetDrainTimeout(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	w0 := prr.pru0.WatchScope()
+	defer testing.AssertStop(c, w0)
+	s.assertScopeChange(c, w0, nil, nil)
+
+	err = prr.pru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertScopeChange(c, w0, []string{"wordpress/0"}, nil)
+
+	// rru0 drains; it's reported as departed, but stays in scope
+	// until its drain deadline.
+	err = prr.rru0.DrainScope()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertScopeChange(c, w0, nil, []string{"wordpress/0"})
+	assertInScope(c, prr.rru0)
+	assertNotJoined(c, prr.rru0)
+	deadline, err := prr.rru0.DrainDeadline()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deadline.IsZero(), jc.IsFalse)
+
+	// Draining again changes nothing.
+	err = prr.rru0.DrainScope()
+	c.Assert(err, jc.ErrorIsNil)
+	again, err := prr.rru0.DrainDeadline()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(again, jc.DeepEquals, deadline)
+}
+
+func (s *RelationUnitSuite) TestDrainScopeWithoutTimeout(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	err := prr.pru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = prr.rru0.DrainScope()
+	c.Assert(err, jc.ErrorIsNil)
+	assertNotInScope(c, prr.rru0)
+	_, err = prr.rru0.DrainDeadline()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RelationUnitSuite) TestDrainScopeWithoutCounterparts(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	err := prr.rel.SetDrainTimeout(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = prr.rru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// No provider units are in scope, so there's nobody to drain for.
+	err = prr.rru0.DrainScope()
+	c.Assert(err, jc.ErrorIsNil)
+	assertNotInScope(c, prr.rru0)
+}
+
 func (s *RelationUnitSuite) assertScopeChange(c *gc.C, w *state.RelationScopeWatcher, entered, left []string) {
 	s.State.StartSync()
 	select {
@@ -0,0 +1,205 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// This file contains the state side of moving a model to another region
+// of its cloud during a migration. The instances of the model's machines
+// can't follow it there, so the machines are provisioned again in the
+// new region, from images of their instances.
+
+// CheckRelocation returns an error if the model can't be moved to the
+// specified region of its cloud.
+func (st *State) CheckRelocation(region string) error {
+	m, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if m.Type() != ModelTypeIAAS {
+		return errors.NotSupportedf("moving %s models to another region", m.Type())
+	}
+	if region == m.CloudRegion() {
+		return errors.Errorf("model is already in region %q", region)
+	}
+	cloud, err := st.Cloud(m.Cloud())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, err := validateCloudRegion(cloud, region); err != nil {
+		return errors.Trace(err)
+	}
+
+	machines, err := st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, machine := range machines {
+		if machine.IsContainer() {
+			continue
+		}
+		if manual, err := machine.IsManual(); err != nil {
+			return errors.Trace(err)
+		} else if manual {
+			return errors.Errorf("machine %s is manually provisioned", machine.Id())
+		}
+	}
+
+	// Volumes belong to the region they were created in, and there
+	// is no way to move them to another yet.
+	sb, err := NewStorageBackend(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	volumes, err := sb.AllVolumes()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(volumes) > 0 {
+		return errors.New("model has volumes")
+	}
+	return nil
+}
+
+// ReprovisionMachines moves a model that has just been migrated to the
+// specified region of its cloud. The machines with images, keyed by
+// machine ID, forget their instances and are constrained to be
+// provisioned again from those images. It does nothing if the model is
+// already in the region, so that an interrupted migration can retry it.
+//
+// Subnets belong to the region the model is leaving, so they are
+// removed. The model's spaces are kept, and are populated again by the
+// subnets discovered in the new region.
+func (st *State) ReprovisionMachines(region string, images map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot re-provision machines in region %q", region)
+
+	m, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if m.CloudRegion() == region {
+		return nil
+	}
+	if m.MigrationMode() != MigrationModeNone {
+		return errors.New("model is being migrated")
+	}
+	cloud, err := st.Cloud(m.Cloud())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			if m.CloudRegion() == region {
+				return nil, jujutxn.ErrNoOperations
+			}
+			if m.MigrationMode() != MigrationModeNone {
+				return nil, errors.New("model is being migrated")
+			}
+		}
+		assertRegionOp, err := validateCloudRegion(cloud, region)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{assertRegionOp, {
+			C:  modelsC,
+			Id: st.ModelUUID(),
+			Assert: append(isAliveDoc,
+				bson.DocElem{"migration-mode", MigrationModeNone},
+				bson.DocElem{"cloud-region", m.CloudRegion()},
+			),
+			Update: bson.D{{"$set", bson.D{{"cloud-region", region}}}},
+		}}
+		for id, imageId := range images {
+			machine, err := st.Machine(id)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if machine.IsContainer() {
+				return nil, errors.Errorf("machine %s is a container", id)
+			}
+			machineOps, err := machine.reprovisionOps(imageId)
+			if err != nil {
+				return nil, errors.Annotatef(err, "machine %s", id)
+			}
+			ops = append(ops, machineOps...)
+		}
+		return ops, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+
+	subnets, err := st.AllSubnets()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, subnet := range subnets {
+		if err := subnet.EnsureDead(); err != nil {
+			return errors.Trace(err)
+		}
+		if err := subnet.Remove(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// reprovisionOps returns the operations that make the machine forget its
+// instance, and provision a new one from the specified image. Addresses
+// and link-layer devices are reported again by the new instance.
+func (m *Machine) reprovisionOps(imageId string) ([]txn.Op, error) {
+	if m.doc.Life != Alive {
+		return nil, machineNotAliveErr
+	}
+	ops := []txn.Op{{
+		C:      machinesC,
+		Id:     m.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{
+			{"$set", bson.D{{"nonce", ""}}},
+			{"$unset", bson.D{
+				{"addresses", nil},
+				{"machineaddresses", nil},
+				{"preferredpublicaddress", nil},
+				{"preferredprivateaddress", nil},
+			}},
+		},
+	}}
+
+	if _, err := m.InstanceId(); err == nil {
+		ops = append(ops, txn.Op{
+			C:      instanceDataC,
+			Id:     m.doc.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		})
+	} else if !errors.IsNotProvisioned(err) {
+		return nil, errors.Trace(err)
+	}
+
+	cons, err := m.Constraints()
+	if errors.IsNotFound(err) {
+		cons.ImageID = &imageId
+		ops = append(ops, createConstraintsOp(m.globalKey(), cons))
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else {
+		cons.ImageID = &imageId
+		ops = append(ops, setConstraintsOp(m.globalKey(), cons))
+	}
+
+	deviceOps, err := m.removeAllLinkLayerDevicesOps()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return append(ops, deviceOps...), nil
+}
@@ -0,0 +1,94 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type RelocationSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&RelocationSuite{})
+
+func (s *RelocationSuite) TestReprovisionMachines(c *gc.C) {
+	m0 := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Addresses: network.NewAddresses("10.0.0.2"),
+	})
+	m1 := s.Factory.MakeMachine(c, nil)
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24", ProviderId: "subnet-0"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ReprovisionMachines("nether-region", map[string]string{
+		m0.Id(): "ami-0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.Model.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.Model.CloudRegion(), gc.Equals, "nether-region")
+
+	err = m0.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = m0.InstanceId()
+	c.Check(err, jc.Satisfies, errors.IsNotProvisioned)
+	c.Check(m0.Addresses(), gc.HasLen, 0)
+	cons, err := m0.Constraints()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(*cons.ImageID, gc.Equals, "ami-0")
+
+	// Machines without images are left alone.
+	err = m1.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = m1.InstanceId()
+	c.Check(err, jc.ErrorIsNil)
+
+	subnets, err := s.State.AllSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnets, gc.HasLen, 0)
+
+	// Once the model is in the region, doing it again changes nothing.
+	err = s.State.ReprovisionMachines("nether-region", map[string]string{
+		m1.Id(): "ami-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = m1.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = m1.InstanceId()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *RelocationSuite) TestReprovisionMachinesImporting(c *gc.C) {
+	m := s.Factory.MakeMachine(c, nil)
+	err := s.Model.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.ReprovisionMachines("nether-region", map[string]string{
+		m.Id(): "ami-0",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot re-provision machines in region "nether-region": model is being migrated`)
+}
+
+func (s *RelocationSuite) TestReprovisionMachinesUnknownRegion(c *gc.C) {
+	err := s.State.ReprovisionMachines("outer-region", nil)
+	c.Assert(err, gc.ErrorMatches, `cannot re-provision machines in region "outer-region": region "outer-region" not found .*`)
+}
+
+func (s *RelocationSuite) TestReprovisionMachinesContainer(c *gc.C) {
+	host := s.Factory.MakeMachine(c, nil)
+	container := s.Factory.MakeMachineNested(c, host.Id(), nil)
+
+	err := s.State.ReprovisionMachines("nether-region", map[string]string{
+		container.Id(): "ami-0",
+	})
+	c.Assert(err, gc.ErrorMatches, fmt.Sprintf(".*machine %s is a container", container.Id()))
+}
@@ -0,0 +1,346 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/watcher"
+)
+
+const (
+	// replicaCatchUpTimeout is how long a read-only replica waits for
+	// the secondaries to catch up with the primary before it gives up
+	// and reads from the primary instead.
+	replicaCatchUpTimeout = 5 * time.Second
+
+	// replicaCatchUpPoll is how often the progress of the secondaries
+	// is checked while waiting for them.
+	replicaCatchUpPoll = 50 * time.Millisecond
+)
+
+// WaitForSecondaries blocks until the secondaries a read-only replica
+// reads from have applied every change committed on the primary when
+// it was called, so that reads made through the replica afterwards see
+// those changes. If the secondaries don't catch up in time, or their
+// progress cannot be determined, the replica reads from the primary
+// from then on. It does nothing for a State made by other means than
+// ReadOnlyReplica.
+func (st *State) WaitForSecondaries() {
+	if st.replica != nil {
+		st.replica.wait()
+	}
+}
+
+// replicaGuard keeps the reads made through a read-only replica
+// consistent with the primary.
+type replicaGuard struct {
+	clock clock.Clock
+
+	// optimes returns the optime of the primary, and those of the
+	// healthy secondaries as seen by the primary.
+	optimes func() (bson.MongoTimestamp, []bson.MongoTimestamp, error)
+
+	// usePrimary makes the replica read from the primary.
+	usePrimary func()
+
+	mu          sync.Mutex
+	primaryOnly bool
+}
+
+// newReplicaGuard returns a guard for a replica reading through the
+// replica session, which checks the secondaries' progress through the
+// primary session.
+func newReplicaGuard(primary, replica *mgo.Session, clock clock.Clock) *replicaGuard {
+	return &replicaGuard{
+		clock: clock,
+		optimes: func() (bson.MongoTimestamp, []bson.MongoTimestamp, error) {
+			return replicaSetOptimes(primary)
+		},
+		usePrimary: func() {
+			replica.SetMode(mgo.Primary, true)
+		},
+	}
+}
+
+func (g *replicaGuard) wait() {
+	g.mu.Lock()
+	primaryOnly := g.primaryOnly
+	g.mu.Unlock()
+	if primaryOnly {
+		return
+	}
+	target, secondaries, err := g.optimes()
+	timeout := g.clock.After(replicaCatchUpTimeout)
+	for {
+		if err != nil {
+			g.fallBack(errors.Annotate(err, "cannot get replica set status"))
+			return
+		}
+		if caughtUp(target, secondaries) {
+			return
+		}
+		select {
+		case <-timeout:
+			g.fallBack(errors.Errorf("secondaries still behind the primary after %v", replicaCatchUpTimeout))
+			return
+		case <-g.clock.After(replicaCatchUpPoll):
+		}
+		_, secondaries, err = g.optimes()
+	}
+}
+
+func (g *replicaGuard) fallBack(err error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.primaryOnly {
+		return
+	}
+	logger.Warningf("read-only replica reading from the primary: %v", err)
+	g.usePrimary()
+	g.primaryOnly = true
+}
+
+func caughtUp(target bson.MongoTimestamp, secondaries []bson.MongoTimestamp) bool {
+	for _, optime := range secondaries {
+		if optime < target {
+			return false
+		}
+	}
+	return true
+}
+
+const (
+	replicaSetPrimary   = 1
+	replicaSetSecondary = 2
+)
+
+// replicaSetOptimes returns the optime of the primary and of the
+// healthy secondaries, as reported by the primary.
+func replicaSetOptimes(session *mgo.Session) (bson.MongoTimestamp, []bson.MongoTimestamp, error) {
+	var status struct {
+		Members []struct {
+			State  int      `bson:"state"`
+			Health float64  `bson:"health"`
+			Optime bson.Raw `bson:"optime"`
+		} `bson:"members"`
+	}
+	if err := session.Run(bson.D{{"replSetGetStatus", 1}}, &status); err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	var primary bson.MongoTimestamp
+	var secondaries []bson.MongoTimestamp
+	for _, member := range status.Members {
+		if member.State != replicaSetPrimary && (member.State != replicaSetSecondary || member.Health != 1) {
+			continue
+		}
+		optime, err := memberOptime(member.Optime)
+		if err != nil {
+			return 0, nil, errors.Trace(err)
+		}
+		if member.State == replicaSetPrimary {
+			primary = optime
+		} else {
+			secondaries = append(secondaries, optime)
+		}
+	}
+	if primary == 0 {
+		return 0, nil, errors.New("no primary in replica set status")
+	}
+	return primary, secondaries, nil
+}
+
+// memberOptime returns the timestamp of a member's optime, which is
+// a bare timestamp with protocol version 0, and a document holding
+// the timestamp and the election term with protocol version 1.
+func memberOptime(raw bson.Raw) (bson.MongoTimestamp, error) {
+	if raw.Kind == bsonTimestampKind {
+		var optime bson.MongoTimestamp
+		if err := raw.Unmarshal(&optime); err != nil {
+			return 0, errors.Annotate(err, "cannot read optime")
+		}
+		return optime, nil
+	}
+	var optime struct {
+		Timestamp bson.MongoTimestamp `bson:"ts"`
+	}
+	if err := raw.Unmarshal(&optime); err != nil {
+		return 0, errors.Annotate(err, "cannot read optime")
+	}
+	return optime.Timestamp, nil
+}
+
+const bsonTimestampKind = 0x11
+
+// replicaWatcher is the txn log watcher of a read-only replica.
+// Watchers read the documents they are told about through the
+// replica, which must not happen before the secondaries have applied
+// the changes; so changes are relayed to a watcher only once the
+// secondaries have caught up with the primary. A watcher reads its
+// initial state as soon as it is watching, so the Watch methods also
+// wait for the secondaries.
+type replicaWatcher struct {
+	watcher.BaseWatcher
+	guard *replicaGuard
+
+	mu     sync.Mutex
+	relays map[chan<- watcher.Change]*changeRelay
+}
+
+func newReplicaWatcher(w watcher.BaseWatcher, guard *replicaGuard) *replicaWatcher {
+	return &replicaWatcher{
+		BaseWatcher: w,
+		guard:       guard,
+		relays:      make(map[chan<- watcher.Change]*changeRelay),
+	}
+}
+
+// Watch is part of the watcher.BaseWatcher interface.
+func (w *replicaWatcher) Watch(collection string, id interface{}, ch chan<- watcher.Change) {
+	w.BaseWatcher.Watch(collection, id, w.relay(ch, 1))
+	w.guard.wait()
+}
+
+// WatchMulti is part of the watcher.BaseWatcher interface.
+func (w *replicaWatcher) WatchMulti(collection string, ids []interface{}, ch chan<- watcher.Change) error {
+	if len(ids) == 0 {
+		return errors.Trace(w.BaseWatcher.WatchMulti(collection, ids, ch))
+	}
+	if err := w.BaseWatcher.WatchMulti(collection, ids, w.relay(ch, len(ids))); err != nil {
+		w.release(ch, len(ids))
+		return errors.Trace(err)
+	}
+	w.guard.wait()
+	return nil
+}
+
+// WatchCollection is part of the watcher.BaseWatcher interface.
+func (w *replicaWatcher) WatchCollection(collection string, ch chan<- watcher.Change) {
+	w.BaseWatcher.WatchCollection(collection, w.relay(ch, 1))
+	w.guard.wait()
+}
+
+// WatchCollectionWithFilter is part of the watcher.BaseWatcher interface.
+func (w *replicaWatcher) WatchCollectionWithFilter(collection string, ch chan<- watcher.Change, filter func(interface{}) bool) {
+	w.BaseWatcher.WatchCollectionWithFilter(collection, w.relay(ch, 1), filter)
+	w.guard.wait()
+}
+
+// Unwatch is part of the watcher.BaseWatcher interface.
+func (w *replicaWatcher) Unwatch(collection string, id interface{}, ch chan<- watcher.Change) {
+	w.BaseWatcher.Unwatch(collection, id, w.relayed(ch))
+	w.release(ch, 1)
+}
+
+// UnwatchCollection is part of the watcher.BaseWatcher interface.
+func (w *replicaWatcher) UnwatchCollection(collection string, ch chan<- watcher.Change) {
+	w.BaseWatcher.UnwatchCollection(collection, w.relayed(ch))
+	w.release(ch, 1)
+}
+
+// relay returns the channel on which changes for ch are received,
+// registering n more watches relayed to ch.
+func (w *replicaWatcher) relay(ch chan<- watcher.Change, n int) chan<- watcher.Change {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	r, ok := w.relays[ch]
+	if !ok {
+		r = newChangeRelay(ch, w.guard)
+		w.relays[ch] = r
+	}
+	r.watches += n
+	return r.in
+}
+
+// relayed returns the channel on which changes for ch are received.
+func (w *replicaWatcher) relayed(ch chan<- watcher.Change) chan<- watcher.Change {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if r, ok := w.relays[ch]; ok {
+		return r.in
+	}
+	return ch
+}
+
+// release drops n watches relayed to ch, stopping the relay when
+// there are none left. It must only be called once the underlying
+// watcher no longer sends to the relay.
+func (w *replicaWatcher) release(ch chan<- watcher.Change, n int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	r, ok := w.relays[ch]
+	if !ok {
+		return
+	}
+	r.watches -= n
+	if r.watches <= 0 {
+		close(r.stop)
+		delete(w.relays, ch)
+	}
+}
+
+// changeRelay passes changes on to a watcher once the secondaries
+// have caught up with them. It always accepts changes, so that a
+// slow replica never holds up the underlying watcher.
+type changeRelay struct {
+	in      chan watcher.Change
+	out     chan<- watcher.Change
+	stop    chan struct{}
+	guard   *replicaGuard
+	watches int
+}
+
+func newChangeRelay(out chan<- watcher.Change, guard *replicaGuard) *changeRelay {
+	r := &changeRelay{
+		in:    make(chan watcher.Change),
+		out:   out,
+		stop:  make(chan struct{}),
+		guard: guard,
+	}
+	go r.loop()
+	return r
+}
+
+func (r *changeRelay) loop() {
+	var pending []watcher.Change
+	// received and sent count the changes received and sent, and
+	// caughtUp the changes the secondaries are known to have.
+	var received, sent, caughtUp int
+	var waiting bool
+	done := make(chan int, 1)
+	for {
+		if !waiting && caughtUp < received {
+			waiting = true
+			upTo := received
+			go func() {
+				r.guard.wait()
+				done <- upTo
+			}()
+		}
+		var out chan<- watcher.Change
+		var next watcher.Change
+		if sent < caughtUp {
+			out, next = r.out, pending[0]
+		}
+		select {
+		case <-r.stop:
+			return
+		case change := <-r.in:
+			pending = append(pending, change)
+			received++
+		case upTo := <-done:
+			waiting = false
+			caughtUp = upTo
+		case out <- next:
+			pending = pending[1:]
+			sent++
+		}
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/watcher"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type replicaGuardSuite struct {
+	testing.IsolationSuite
+
+	clock     *testclock.Clock
+	stub      testing.Stub
+	secondary chan bson.MongoTimestamp
+	guard     *replicaGuard
+}
+
+var _ = gc.Suite(&replicaGuardSuite{})
+
+func (s *replicaGuardSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(coretesting.NonZeroTime())
+	s.stub.ResetCalls()
+	s.secondary = make(chan bson.MongoTimestamp, 10)
+	s.guard = &replicaGuard{
+		clock: s.clock,
+		optimes: func() (bson.MongoTimestamp, []bson.MongoTimestamp, error) {
+			s.stub.AddCall("optimes")
+			if err := s.stub.NextErr(); err != nil {
+				return 0, nil, err
+			}
+			return 10, []bson.MongoTimestamp{<-s.secondary}, nil
+		},
+		usePrimary: func() {
+			s.stub.AddCall("usePrimary")
+		},
+	}
+}
+
+func (s *replicaGuardSuite) wait(c *gc.C) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.guard.wait()
+	}()
+	return done
+}
+
+func (s *replicaGuardSuite) assertDone(c *gc.C, done <-chan struct{}) {
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the secondaries")
+	}
+}
+
+func (s *replicaGuardSuite) TestWaitCaughtUp(c *gc.C) {
+	s.secondary <- 12
+	s.assertDone(c, s.wait(c))
+	s.stub.CheckCallNames(c, "optimes")
+}
+
+func (s *replicaGuardSuite) TestWaitForSecondary(c *gc.C) {
+	s.secondary <- 8
+	s.secondary <- 10
+	done := s.wait(c)
+	err := s.clock.WaitAdvance(replicaCatchUpPoll, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDone(c, done)
+	s.stub.CheckCallNames(c, "optimes", "optimes")
+}
+
+func (s *replicaGuardSuite) TestWaitTimesOut(c *gc.C) {
+	for i := 0; i < 10; i++ {
+		s.secondary <- 8
+	}
+	done := s.wait(c)
+	err := s.clock.WaitAdvance(replicaCatchUpTimeout, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDone(c, done)
+	calls := s.stub.Calls()
+	c.Assert(calls[len(calls)-1].FuncName, gc.Equals, "usePrimary")
+
+	// Once reading from the primary, there's nothing to wait for.
+	s.assertDone(c, s.wait(c))
+	c.Assert(s.stub.Calls(), gc.HasLen, len(calls))
+}
+
+func (s *replicaGuardSuite) TestWaitError(c *gc.C) {
+	s.stub.SetErrors(errors.New("not running with --replSet"))
+	s.assertDone(c, s.wait(c))
+	s.stub.CheckCallNames(c, "optimes", "usePrimary")
+}
+
+func (s *replicaGuardSuite) TestWatcherRelaysChangesOnceCaughtUp(c *gc.C) {
+	base := &recordingWatcher{}
+	w := newReplicaWatcher(base, s.guard)
+	ch := make(chan watcher.Change)
+
+	s.secondary <- 10
+	w.Watch("machines", "0", ch)
+	c.Assert(base.calls, gc.HasLen, 1)
+	c.Assert(base.calls[0].ch, gc.Not(gc.Equals), chan<- watcher.Change(ch))
+
+	change := watcher.Change{C: "machines", Id: "0", Revno: 2}
+	select {
+	case base.calls[0].ch <- change:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("change not accepted")
+	}
+	select {
+	case <-ch:
+		c.Fatalf("change relayed before the secondaries caught up")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.secondary <- 10
+	select {
+	case got := <-ch:
+		c.Assert(got, jc.DeepEquals, change)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("change not relayed")
+	}
+
+	w.Unwatch("machines", "0", ch)
+	c.Assert(base.calls, gc.HasLen, 2)
+	c.Assert(base.calls[1].method, gc.Equals, "Unwatch")
+	c.Assert(base.calls[1].ch, gc.Equals, base.calls[0].ch)
+	c.Assert(w.relays, gc.HasLen, 0)
+}
+
+func (s *replicaGuardSuite) TestMemberOptime(c *gc.C) {
+	for i, optime := range []interface{}{
+		bson.MongoTimestamp(42),
+		bson.M{"ts": bson.MongoTimestamp(42), "t": int64(3)},
+	} {
+		c.Logf("test %d", i)
+		data, err := bson.Marshal(bson.M{"optime": optime})
+		c.Assert(err, jc.ErrorIsNil)
+		var doc struct {
+			Optime bson.Raw `bson:"optime"`
+		}
+		err = bson.Unmarshal(data, &doc)
+		c.Assert(err, jc.ErrorIsNil)
+		got, err := memberOptime(doc.Optime)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(got, gc.Equals, bson.MongoTimestamp(42))
+	}
+}
+
+type watchCall struct {
+	method string
+	ch     chan<- watcher.Change
+}
+
+type recordingWatcher struct {
+	watcher.BaseWatcher
+	calls []watchCall
+}
+
+func (w *recordingWatcher) Watch(collection string, id interface{}, ch chan<- watcher.Change) {
+	w.calls = append(w.calls, watchCall{"Watch", ch})
+}
+
+func (w *recordingWatcher) Unwatch(collection string, id interface{}, ch chan<- watcher.Change) {
+	w.calls = append(w.calls, watchCall{"Unwatch", ch})
+}
@@ -46,6 +46,10 @@ type Resources interface {
 	// OpenResource returns the metadata for a resource and a reader for the resource.
 	OpenResource(applicationID, name string) (resource.Resource, io.ReadCloser, error)
 
+	// OpenPendingResource returns the metadata for a pending resource
+	// and a reader for the resource.
+	OpenPendingResource(applicationID, name, pendingID string) (resource.Resource, io.ReadCloser, error)
+
 	// OpenResourceForUniter returns the metadata for a resource and a reader for the resource.
 	OpenResourceForUniter(unit resource.Unit, name string) (resource.Resource, io.ReadCloser, error)
 
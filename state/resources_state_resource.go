@@ -364,6 +364,27 @@ func (st resourceState) OpenResource(applicationID, name string) (resource.Resou
 	return resourceInfo, resourceReader, nil
 }
 
+// OpenPendingResource returns metadata about the pending resource, and
+// a reader for the resource if it has been uploaded. Only file
+// resources can be opened.
+func (st resourceState) OpenPendingResource(applicationID, name, pendingID string) (resource.Resource, io.ReadCloser, error) {
+	res, err := st.GetPendingResource(applicationID, name, pendingID)
+	if err != nil {
+		return resource.Resource{}, nil, errors.Trace(err)
+	}
+	if res.IsPlaceholder() {
+		return resource.Resource{}, nil, errors.NotFoundf("resource %q", name)
+	}
+	if res.Type != charmresource.TypeFile {
+		return resource.Resource{}, nil, errors.NotSupportedf("opening pending %s resource %q", res.Type, name)
+	}
+	r, _, err := st.storage.Get(storagePath(name, applicationID, pendingID))
+	if err != nil {
+		return resource.Resource{}, nil, errors.Annotate(err, "while retrieving resource data")
+	}
+	return res, r, nil
+}
+
 // OpenResourceForUniter returns metadata about the resource and
 // a reader for the resource. The resource is associated with
 // the unit once the reader is completely exhausted.
@@ -5,8 +5,10 @@ package state_test
 
 import (
 	"bytes"
+	"io/ioutil"
 	"time" // Only using time func.
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	charmresource "gopkg.in/juju/charm.v6/resource"
@@ -64,6 +66,30 @@ func (s *ResourcesSuite) TestFunctional(c *gc.C) {
 	// TODO(ericsnow) Add more as state.Resources grows more functionality.
 }
 
+func (s *ResourcesSuite) TestOpenPendingResource(c *gc.C) {
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+
+	data := "spamspamspam"
+	res := newResource(c, "spam", data)
+	pendingID, err := st.AddPendingResource("a-application", res.Username, res.Resource)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing has been uploaded yet.
+	_, _, err = st.OpenPendingResource("a-application", "spam", pendingID)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	_, err = st.UpdatePendingResource("a-application", pendingID, res.Username, res.Resource, bytes.NewBufferString(data))
+	c.Assert(err, jc.ErrorIsNil)
+	opened, r, err := st.OpenPendingResource("a-application", "spam", pendingID)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	c.Check(opened.PendingID, gc.Equals, pendingID)
+	content, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, data)
+}
+
 func newResource(c *gc.C, name, data string) resource.Resource {
 	opened := resourcetesting.NewResource(c, nil, name, "a-application", data)
 	res := opened.Resource
@@ -0,0 +1,35 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// SpareMachines returns the alive machines in the model's pool of spare
+// machines, ordered by id. Spare machines are added with
+// MachineTemplate.Spare set, and leave the pool when a unit is
+// assigned to them.
+func (st *State) SpareMachines() ([]*Machine, error) {
+	machinesCollection, closer := st.db().GetCollection(machinesC)
+	defer closer()
+
+	mdocs := machineDocSlice{}
+	err := machinesCollection.Find(bson.D{
+		{"spare", true},
+		{"life", Alive},
+	}).All(&mdocs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get spare machines")
+	}
+	sort.Sort(mdocs)
+	machines := make([]*Machine, len(mdocs))
+	for i, doc := range mdocs {
+		machines[i] = newMachine(st, &doc)
+	}
+	return machines, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type SpareMachinesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&SpareMachinesSuite{})
+
+func (s *SpareMachinesSuite) addSpareMachine(c *gc.C) *state.Machine {
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+		Spare:  true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return m
+}
+
+func (s *SpareMachinesSuite) TestSpareMachines(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	spare1 := s.addSpareMachine(c)
+	spare2 := s.addSpareMachine(c)
+	c.Assert(spare1.IsSpare(), jc.IsTrue)
+
+	err = spare2.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	machines, err := s.State.SpareMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 1)
+	c.Assert(machines[0].Id(), gc.Equals, spare1.Id())
+}
+
+func (s *SpareMachinesSuite) TestAssignToCleanEmptyMachinePrefersSpare(c *gc.C) {
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	spare := s.addSpareMachine(c)
+
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := unit.AssignToCleanEmptyMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Id(), gc.Equals, spare.Id())
+	c.Assert(m.IsSpare(), jc.IsFalse)
+
+	// The machine has left the pool.
+	err = spare.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(spare.IsSpare(), jc.IsFalse)
+	machines, err := s.State.SpareMachines()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 0)
+}
@@ -0,0 +1,395 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// stagedUpgradeKey is the key of the document in the controllers
+// collection that records the progress of a staged upgrade of the
+// hosted models.
+const stagedUpgradeKey = "stagedUpgrade"
+
+// StagedUpgradeStatus describes the state of a staged upgrade.
+type StagedUpgradeStatus string
+
+const (
+	// StagedUpgradeRunning indicates that the models in the current
+	// wave are being upgraded.
+	StagedUpgradeRunning StagedUpgradeStatus = "running"
+
+	// StagedUpgradeCompleted indicates that every wave was upgraded.
+	StagedUpgradeCompleted StagedUpgradeStatus = "completed"
+
+	// StagedUpgradeFailed indicates that a wave didn't pass its health
+	// gate, and the remaining waves were not upgraded.
+	StagedUpgradeFailed StagedUpgradeStatus = "failed"
+
+	// StagedUpgradeAborted indicates that the upgrade was stopped by
+	// the user.
+	StagedUpgradeAborted StagedUpgradeStatus = "aborted"
+)
+
+// StagedUpgrade holds the progress of an upgrade of the hosted models
+// in waves.
+type StagedUpgrade struct {
+	// Target is the agent version the models are upgraded to.
+	Target version.Number
+
+	// Waves holds the UUIDs of the models upgraded in each wave.
+	Waves [][]string
+
+	// Wave is the index of the wave being upgraded.
+	Wave int
+
+	// WaveTimeout is how long the agents in a wave have to upgrade
+	// before the upgrade fails.
+	WaveTimeout time.Duration
+
+	Status  StagedUpgradeStatus
+	Message string
+
+	Started     time.Time
+	WaveStarted time.Time
+}
+
+// Finished returns whether the staged upgrade is no longer running.
+func (u *StagedUpgrade) Finished() bool {
+	return u.Status != StagedUpgradeRunning
+}
+
+type stagedUpgradeDoc struct {
+	Target      string              `bson:"target"`
+	Waves       [][]string          `bson:"waves"`
+	Wave        int                 `bson:"wave"`
+	WaveTimeout time.Duration       `bson:"wave-timeout"`
+	Status      StagedUpgradeStatus `bson:"status"`
+	Message     string              `bson:"message,omitempty"`
+	Started     time.Time           `bson:"started"`
+	WaveStarted time.Time           `bson:"wave-started"`
+}
+
+func (st *State) stagedUpgradeDoc() (*stagedUpgradeDoc, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc stagedUpgradeDoc
+	err := controllers.FindId(stagedUpgradeKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("staged upgrade")
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read staged upgrade")
+	}
+	return &doc, nil
+}
+
+// StagedUpgrade returns the progress of the latest staged upgrade. It
+// returns an error satisfying errors.IsNotFound if none was started.
+func (st *State) StagedUpgrade() (*StagedUpgrade, error) {
+	doc, err := st.stagedUpgradeDoc()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	target, err := version.Parse(doc.Target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &StagedUpgrade{
+		Target:      target,
+		Waves:       doc.Waves,
+		Wave:        doc.Wave,
+		WaveTimeout: doc.WaveTimeout,
+		Status:      doc.Status,
+		Message:     doc.Message,
+		Started:     doc.Started,
+		WaveStarted: doc.WaveStarted,
+	}, nil
+}
+
+// WatchStagedUpgrade returns a NotifyWatcher that fires when the
+// staged upgrade changes.
+func (st *State) WatchStagedUpgrade() NotifyWatcher {
+	return newEntityWatcher(st, controllersC, stagedUpgradeKey)
+}
+
+// StartStagedUpgrade starts upgrading the given hosted models to the
+// target agent version, one wave at a time. The controller model must
+// already be running the target version, and none of the models can be
+// pinned to another version.
+func (st *State) StartStagedUpgrade(target version.Number, waves [][]string, waveTimeout time.Duration) error {
+	if len(waves) == 0 {
+		return errors.NotValidf("staged upgrade with no waves")
+	}
+	if waveTimeout <= 0 {
+		return errors.NotValidf("wave timeout %v", waveTimeout)
+	}
+	controllerModel, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerCfg, err := controllerModel.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerVersion, _ := controllerCfg.AgentVersion()
+	if controllerVersion.Compare(target) < 0 {
+		return errors.Errorf("controller is running %s: upgrade the controller model to %s first", controllerVersion, target)
+	}
+
+	seen := make(map[string]bool)
+	for i, wave := range waves {
+		if len(wave) == 0 {
+			return errors.NotValidf("empty wave %d", i+1)
+		}
+		for _, uuid := range wave {
+			if seen[uuid] {
+				return errors.NotValidf("model %q in more than one wave", uuid)
+			}
+			seen[uuid] = true
+			if err := st.checkStagedUpgradeModel(uuid, target); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
+	now := st.clock().Now()
+	doc := stagedUpgradeDoc{
+		Target:      target.String(),
+		Waves:       waves,
+		WaveTimeout: waveTimeout,
+		Status:      StagedUpgradeRunning,
+		Started:     now,
+		WaveStarted: now,
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		existing, err := st.stagedUpgradeDoc()
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     stagedUpgradeKey,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}}, nil
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if existing.Status == StagedUpgradeRunning {
+			return nil, errors.AlreadyExistsf("staged upgrade to %s", existing.Target)
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     stagedUpgradeKey,
+			Assert: bson.D{{"started", existing.Started}},
+			Update: bson.D{
+				{"$set", bson.D{
+					{"target", doc.Target},
+					{"waves", doc.Waves},
+					{"wave", doc.Wave},
+					{"wave-timeout", doc.WaveTimeout},
+					{"status", doc.Status},
+					{"started", doc.Started},
+					{"wave-started", doc.WaveStarted},
+				}},
+				{"$unset", bson.D{{"message", 1}}},
+			},
+		}}, nil
+	}
+	return errors.Annotate(st.db().Run(buildTxn), "cannot start staged upgrade")
+}
+
+func (st *State) checkStagedUpgradeModel(uuid string, target version.Number) error {
+	if uuid == st.ControllerModelUUID() {
+		return errors.NotValidf("staged upgrade of the controller model")
+	}
+	models, closer := st.db().GetCollection(modelsC)
+	defer closer()
+	var doc modelDoc
+	if err := models.FindId(uuid).One(&doc); err == mgo.ErrNotFound {
+		return errors.NotFoundf("model %q", uuid)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot read model %q", uuid)
+	}
+	if doc.Life != Alive {
+		return errors.Errorf("model %q is %s", doc.Name, doc.Life)
+	}
+	if doc.PinnedAgentVersion != "" && doc.PinnedAgentVersion != target.String() {
+		return errors.Errorf("model %q is pinned to %s", doc.Name, doc.PinnedAgentVersion)
+	}
+	return nil
+}
+
+// AdvanceStagedUpgrade moves the staged upgrade on from the given wave
+// to the next one, or marks it completed if that was the last wave.
+func (st *State) AdvanceStagedUpgrade(wave int) error {
+	doc, err := st.stagedUpgradeDoc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	set := bson.D{{"wave", wave + 1}, {"wave-started", st.clock().Now()}}
+	if wave+1 >= len(doc.Waves) {
+		set = bson.D{{"status", StagedUpgradeCompleted}}
+	}
+	return errors.Trace(st.updateStagedUpgrade(wave, set))
+}
+
+// FailStagedUpgrade stops the staged upgrade at the given wave,
+// recording why.
+func (st *State) FailStagedUpgrade(wave int, message string) error {
+	return errors.Trace(st.updateStagedUpgrade(wave, bson.D{
+		{"status", StagedUpgradeFailed},
+		{"message", message},
+	}))
+}
+
+// AbortStagedUpgrade stops the running staged upgrade. Models that
+// have already been upgraded are left as they are.
+func (st *State) AbortStagedUpgrade() error {
+	doc, err := st.stagedUpgradeDoc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.updateStagedUpgrade(doc.Wave, bson.D{
+		{"status", StagedUpgradeAborted},
+	}))
+}
+
+func (st *State) updateStagedUpgrade(wave int, set bson.D) error {
+	ops := []txn.Op{{
+		C:  controllersC,
+		Id: stagedUpgradeKey,
+		Assert: bson.D{
+			{"status", StagedUpgradeRunning},
+			{"wave", wave},
+		},
+		Update: bson.D{{"$set", set}},
+	}}
+	err := st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.Errorf("staged upgrade is not running wave %d", wave+1)
+	}
+	return errors.Annotate(err, "cannot update staged upgrade")
+}
+
+// ModelUpgradeHealth describes the agents in a model that stop a
+// staged upgrade from moving on to its next wave.
+type ModelUpgradeHealth struct {
+	// NotUpgraded holds the tags of the machine and unit agents that
+	// are not yet running the target version.
+	NotUpgraded []string
+
+	// InError holds the tags of the machines and units that are in
+	// an error state.
+	InError []string
+}
+
+// Healthy returns whether every agent in the model has upgraded and
+// none are in error.
+func (h ModelUpgradeHealth) Healthy() bool {
+	return len(h.NotUpgraded) == 0 && len(h.InError) == 0
+}
+
+// ModelUpgradeHealth reports which agents in the model have not yet
+// upgraded to the target version, and which are in error.
+func (st *State) ModelUpgradeHealth(target version.Number) (ModelUpgradeHealth, error) {
+	var health ModelUpgradeHealth
+	matchTarget := "^" + regexp.QuoteMeta(target.String()) + "-"
+	sel := bson.D{{"$or", []bson.D{
+		{{"tools", bson.D{{"$exists", false}}}},
+		{{"tools.version", bson.D{{"$not", bson.RegEx{Pattern: matchTarget}}}}},
+	}}}
+	for _, name := range []string{machinesC, unitsC} {
+		tags, err := st.agentTagsMatching(name, sel)
+		if err != nil {
+			return ModelUpgradeHealth{}, errors.Trace(err)
+		}
+		health.NotUpgraded = append(health.NotUpgraded, tags...)
+	}
+
+	statuses, closer := st.db().GetCollection(statusesC)
+	defer closer()
+	var doc struct {
+		DocID string `bson:"_id"`
+	}
+	iter := statuses.Find(bson.D{{"status", status.Error}}).Select(bson.D{{"_id", 1}}).Iter()
+	defer iter.Close()
+	for iter.Next(&doc) {
+		key, err := st.strictLocalID(doc.DocID)
+		if err != nil {
+			return ModelUpgradeHealth{}, errors.Trace(err)
+		}
+		if tag := statusKeyAgentTag(key); tag != "" {
+			health.InError = append(health.InError, tag)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return ModelUpgradeHealth{}, errors.Trace(err)
+	}
+	return health, nil
+}
+
+func (st *State) agentTagsMatching(collName string, sel bson.D) ([]string, error) {
+	collection, closer := st.db().GetCollection(collName)
+	defer closer()
+	var doc struct {
+		DocID string `bson:"_id"`
+	}
+	var tags []string
+	iter := collection.Find(sel).Select(bson.D{{"_id", 1}}).Iter()
+	defer iter.Close()
+	for iter.Next(&doc) {
+		localID, err := st.strictLocalID(doc.DocID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch collName {
+		case machinesC:
+			tags = append(tags, names.NewMachineTag(localID).String())
+		case unitsC:
+			tags = append(tags, names.NewUnitTag(localID).String())
+		}
+	}
+	return tags, errors.Trace(iter.Close())
+}
+
+var (
+	machineStatusKey = regexp.MustCompile(`^m#([^#]+)(#instance)?$`)
+	unitStatusKey    = regexp.MustCompile(`^u#([^#]+)#charm$`)
+)
+
+// statusKeyAgentTag returns the tag of the machine or unit whose status
+// is stored under the given global key, or "" for other entities.
+func statusKeyAgentTag(key string) string {
+	if m := machineStatusKey.FindStringSubmatch(key); m != nil {
+		return names.NewMachineTag(m[1]).String()
+	}
+	if m := unitStatusKey.FindStringSubmatch(key); m != nil {
+		return names.NewUnitTag(m[1]).String()
+	}
+	return ""
+}
+
+// String describes the health, for use in status messages.
+func (h ModelUpgradeHealth) String() string {
+	switch {
+	case len(h.InError) > 0:
+		return fmt.Sprintf("%d agents in error: %v", len(h.InError), h.InError)
+	case len(h.NotUpgraded) > 0:
+		return fmt.Sprintf("%d agents not upgraded: %v", len(h.NotUpgraded), h.NotUpgraded)
+	}
+	return "healthy"
+}
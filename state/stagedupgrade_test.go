@@ -0,0 +1,231 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type StagedUpgradeSuite struct {
+	ConnSuite
+
+	target version.Number
+	models []string
+}
+
+var _ = gc.Suite(&StagedUpgradeSuite{})
+
+func (s *StagedUpgradeSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	cfg, err := s.Model.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	s.target, _ = cfg.AgentVersion()
+
+	s.models = nil
+	for i := 0; i < 3; i++ {
+		st := s.Factory.MakeModel(c, nil)
+		s.models = append(s.models, st.ModelUUID())
+		st.Close()
+	}
+}
+
+func (s *StagedUpgradeSuite) start(c *gc.C) {
+	err := s.State.StartStagedUpgrade(s.target, [][]string{
+		{s.models[0]},
+		{s.models[1], s.models[2]},
+	}, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StagedUpgradeSuite) TestStagedUpgradeNotFound(c *gc.C) {
+	_, err := s.State.StagedUpgrade()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *StagedUpgradeSuite) TestStartStagedUpgrade(c *gc.C) {
+	s.start(c)
+
+	upgrade, err := s.State.StagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(upgrade.Target, gc.Equals, s.target)
+	c.Check(upgrade.Waves, jc.DeepEquals, [][]string{{s.models[0]}, {s.models[1], s.models[2]}})
+	c.Check(upgrade.Wave, gc.Equals, 0)
+	c.Check(upgrade.WaveTimeout, gc.Equals, time.Hour)
+	c.Check(upgrade.Status, gc.Equals, state.StagedUpgradeRunning)
+	c.Check(upgrade.Finished(), jc.IsFalse)
+	c.Check(upgrade.Started.IsZero(), jc.IsFalse)
+}
+
+func (s *StagedUpgradeSuite) TestStartStagedUpgradeAlreadyRunning(c *gc.C) {
+	s.start(c)
+	err := s.State.StartStagedUpgrade(s.target, [][]string{{s.models[0]}}, time.Hour)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+}
+
+func (s *StagedUpgradeSuite) TestStartStagedUpgradeAfterFinished(c *gc.C) {
+	s.start(c)
+	err := s.State.AbortStagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.StartStagedUpgrade(s.target, [][]string{{s.models[2]}}, time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err := s.State.StagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(upgrade.Waves, jc.DeepEquals, [][]string{{s.models[2]}})
+	c.Check(upgrade.Status, gc.Equals, state.StagedUpgradeRunning)
+}
+
+func (s *StagedUpgradeSuite) TestStartStagedUpgradeValidation(c *gc.C) {
+	newer := s.target
+	newer.Minor++
+	for i, test := range []struct {
+		target version.Number
+		waves  [][]string
+		err    string
+	}{{
+		target: s.target,
+		err:    "staged upgrade with no waves not valid",
+	}, {
+		target: s.target,
+		waves:  [][]string{{s.models[0]}, {}},
+		err:    "empty wave 2 not valid",
+	}, {
+		target: s.target,
+		waves:  [][]string{{s.models[0]}, {s.models[0]}},
+		err:    `model "` + s.models[0] + `" in more than one wave not valid`,
+	}, {
+		target: s.target,
+		waves:  [][]string{{s.State.ModelUUID()}},
+		err:    "staged upgrade of the controller model not valid",
+	}, {
+		target: s.target,
+		waves:  [][]string{{"deadbeef-0bad-400d-8000-4b1d0d06f00d"}},
+		err:    `model "deadbeef-0bad-400d-8000-4b1d0d06f00d" not found`,
+	}, {
+		target: newer,
+		waves:  [][]string{{s.models[0]}},
+		err:    "controller is running .*: upgrade the controller model to .* first",
+	}} {
+		c.Logf("test %d", i)
+		err := s.State.StartStagedUpgrade(test.target, test.waves, time.Hour)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *StagedUpgradeSuite) TestStartStagedUpgradePinnedModel(c *gc.C) {
+	model, release, err := s.StatePool.GetModel(s.models[1])
+	c.Assert(err, jc.ErrorIsNil)
+	defer release()
+	err = model.SetPinnedAgentVersion(version.MustParse("2.3.4"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.StartStagedUpgrade(s.target, [][]string{{s.models[0], s.models[1]}}, time.Hour)
+	c.Assert(err, gc.ErrorMatches, `model ".*" is pinned to 2.3.4`)
+}
+
+func (s *StagedUpgradeSuite) TestAdvanceStagedUpgrade(c *gc.C) {
+	s.start(c)
+
+	err := s.State.AdvanceStagedUpgrade(0)
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err := s.State.StagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(upgrade.Wave, gc.Equals, 1)
+	c.Check(upgrade.Status, gc.Equals, state.StagedUpgradeRunning)
+
+	// Advancing from a wave that isn't current fails.
+	err = s.State.AdvanceStagedUpgrade(0)
+	c.Assert(err, gc.ErrorMatches, "staged upgrade is not running wave 1")
+
+	err = s.State.AdvanceStagedUpgrade(1)
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err = s.State.StagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(upgrade.Wave, gc.Equals, 1)
+	c.Check(upgrade.Status, gc.Equals, state.StagedUpgradeCompleted)
+	c.Check(upgrade.Finished(), jc.IsTrue)
+}
+
+func (s *StagedUpgradeSuite) TestFailStagedUpgrade(c *gc.C) {
+	s.start(c)
+
+	err := s.State.FailStagedUpgrade(0, "wave timed out")
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err := s.State.StagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(upgrade.Status, gc.Equals, state.StagedUpgradeFailed)
+	c.Check(upgrade.Message, gc.Equals, "wave timed out")
+
+	err = s.State.AdvanceStagedUpgrade(0)
+	c.Assert(err, gc.ErrorMatches, "staged upgrade is not running wave 1")
+}
+
+func (s *StagedUpgradeSuite) TestAbortStagedUpgrade(c *gc.C) {
+	s.start(c)
+
+	err := s.State.AbortStagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	upgrade, err := s.State.StagedUpgrade()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(upgrade.Status, gc.Equals, state.StagedUpgradeAborted)
+}
+
+func (s *StagedUpgradeSuite) TestWatchStagedUpgrade(c *gc.C) {
+	w := s.State.WatchStagedUpgrade()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	s.start(c)
+	wc.AssertOneChange()
+
+	err := s.State.AdvanceStagedUpgrade(0)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *StagedUpgradeSuite) TestModelUpgradeHealth(c *gc.C) {
+	target := version.MustParseBinary("2.7.0-bionic-amd64")
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	machine := f.MakeMachine(c, nil)
+	unit := f.MakeUnit(c, &factory.UnitParams{Machine: machine})
+
+	health, err := st.ModelUpgradeHealth(target.Number)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(health.Healthy(), jc.IsFalse)
+	c.Check(health.NotUpgraded, jc.SameContents, []string{machine.Tag().String(), unit.Tag().String()})
+	c.Check(health.InError, gc.HasLen, 0)
+
+	err = machine.SetAgentVersion(target)
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetAgentVersion(target)
+	c.Assert(err, jc.ErrorIsNil)
+	health, err = st.ModelUpgradeHealth(target.Number)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(health.Healthy(), jc.IsTrue)
+
+	now := time.Now()
+	err = unit.SetStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: "hook failed",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	health, err = st.ModelUpgradeHealth(target.Number)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(health.Healthy(), jc.IsFalse)
+	c.Check(health.InError, jc.DeepEquals, []string{unit.Tag().String()})
+}
@@ -104,6 +104,11 @@ type State struct {
 	// first step.
 	workers *workers
 
+	// replica is set if the State reads from secondary members of
+	// the replica set, and shares the workers of the State it was
+	// made from rather than owning its own.
+	replica *replicaGuard
+
 	// TODO(anastasiamac 2015-07-16) As state gets broken up, remove this.
 	CloudImageMetadataStorage cloudimagemetadata.Storage
 }
@@ -146,6 +151,39 @@ func (st *State) newStateNoWorkers(modelUUID string) (*State, error) {
 	return newSt, nil
 }
 
+// ReadOnlyReplica returns a State for the same model which prefers to
+// read from secondary members of the controller's replica set, falling
+// back to the primary if there are none. Reads made through it may lag
+// slightly behind the primary unless WaitForSecondaries is called
+// first, so it must only be used for read-only queries such as status
+// reporting. Watchers made from it are notified of a change only once
+// the secondaries have it, so that they never read older state than
+// they were notified of.
+// The returned State shares the workers of st, which remain owned by
+// st; it must be closed independently, before st is closed.
+func (st *State) ReadOnlyReplica() (*State, error) {
+	session := st.session.Copy()
+	session.SetMode(mgo.SecondaryPreferred, true)
+	replica, err := newState(
+		st.modelTag,
+		st.controllerModelTag,
+		session,
+		st.newPolicy,
+		st.stateClock,
+		st.runTransactionObserver,
+	)
+	if err != nil {
+		session.Close()
+		return nil, errors.Trace(err)
+	}
+	replica.controllerTag = st.controllerTag
+	replica.leaseStoreId = st.leaseStoreId
+	replica.workers = st.workers
+	replica.replica = newReplicaGuard(st.session, session, st.clock())
+	replica.CloudImageMetadataStorage = st.CloudImageMetadataStorage
+	return replica, nil
+}
+
 // IsController returns true if this state instance has the bootstrap
 // model UUID.
 func (st *State) IsController() bool {
@@ -309,6 +347,15 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 		}
 	}
 
+	// The archives and crash reports are stored in the blobstore,
+	// so remove them before their documents are removed below.
+	if err := st.removeArchives(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := st.removeCrashReports(); err != nil {
+		return errors.Trace(err)
+	}
+
 	// Remove from the raw (non-transactional) collections.
 	for name, info := range st.database.Schema() {
 		if !info.global && info.rawAccess {
@@ -352,6 +399,10 @@ func (st *State) removeAllModelDocs(modelAssertion bson.D) error {
 		C:      modelEntityRefsC,
 		Id:     modelUUID,
 		Remove: true,
+	}, {
+		C:      controllersC,
+		Id:     quotaKey(st.modelTag),
+		Remove: true,
 	}, {
 		C:      modelsC,
 		Id:     modelUUID,
@@ -639,6 +690,9 @@ func (st *State) db() Database {
 // txnLogWatcher returns the TxnLogWatcher for the State. It is part
 // of the modelBackend interface.
 func (st *State) txnLogWatcher() watcher.BaseWatcher {
+	if st.replica != nil {
+		return newReplicaWatcher(st.workers.txnLogWatcher(), st.replica)
+	}
 	return st.workers.txnLogWatcher()
 }
 
@@ -771,7 +825,8 @@ func IsUpgradeInProgressError(err error) bool {
 // SetModelAgentVersion changes the agent version for the model to the
 // given version, only if the model is in a stable state (all agents are
 // running the current version). If this is a hosted model, newVersion
-// cannot be higher than the controller version.
+// cannot be higher than the controller version. If the model's agent
+// version is pinned, newVersion must be the pinned version.
 func (st *State) SetModelAgentVersion(newVersion version.Number, ignoreAgentVersions bool) (err error) {
 	if newVersion.Compare(jujuversion.Current) > 0 && !st.IsController() {
 		return errors.Errorf("model cannot be upgraded to %s while the controller is %s: upgrade 'controller' model first",
@@ -786,6 +841,20 @@ func (st *State) SetModelAgentVersion(newVersion version.Number, ignoreAgentVers
 	}
 	isCAAS := model.Type() == ModelTypeCAAS
 	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := model.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		pinned, isPinned := model.PinnedAgentVersion()
+		if isPinned && newVersion != pinned {
+			return nil, errors.Errorf("model agent version is pinned to %s", pinned)
+		}
+		pinAssert := bson.D{{"pinned-agent-version", bson.D{{"$exists", false}}}}
+		if isPinned {
+			pinAssert = bson.D{{"pinned-agent-version", pinned.String()}}
+		}
+
 		settings, err := readSettings(st.db(), settingsC, modelGlobalKey)
 		if err != nil {
 			return nil, errors.Annotatef(err, "model %q", st.modelTag.Id())
@@ -828,6 +897,10 @@ func (st *State) SetModelAgentVersion(newVersion version.Number, ignoreAgentVers
 				Update: bson.D{
 					{"$set", bson.D{{"settings.agent-version", newVersion.String()}}},
 				},
+			}, {
+				C:      modelsC,
+				Id:     model.UUID(),
+				Assert: pinAssert,
 			},
 		}
 		return ops, nil
@@ -1414,6 +1487,14 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			}
 			ops = append(ops, assignUnitOps(unitName, placement)...)
 		}
+		quotaOps, err := st.quotaOps(st.ModelUUID(), QuotaUsage{
+			Units:      args.NumUnits,
+			StorageMiB: uint64(args.NumUnits) * storageQuotaMiB(args.Storage),
+		})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, quotaOps...)
 		return ops, nil
 	}
 	// At the last moment before inserting the application, prime status history.
@@ -121,6 +121,37 @@ func (s *StateSuite) TestIsController(c *gc.C) {
 	c.Assert(st2.IsController(), jc.IsFalse)
 }
 
+func (s *StateSuite) TestReadOnlyReplica(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	replica, err := s.State.ReadOnlyReplica()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(replica.ModelUUID(), gc.Equals, s.State.ModelUUID())
+	c.Assert(replica.ControllerTag(), gc.Equals, s.State.ControllerTag())
+	m, err := replica.Machine(machine.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.Id(), gc.Equals, machine.Id())
+
+	// Watchers on the replica are driven by the original State's
+	// workers, which keep running when the replica is closed.
+	w := replica.WatchModelMachines()
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange("0")
+	wc.AssertNoChange()
+	statetesting.AssertStop(c, w)
+
+	err = replica.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	w2 := s.State.WatchModelMachines()
+	defer statetesting.AssertStop(c, w2)
+	wc2 := statetesting.NewStringsWatcherC(c, s.State, w2)
+	wc2.AssertChange("0", "1")
+	wc2.AssertNoChange()
+}
+
 func (s *StateSuite) TestControllerOwner(c *gc.C) {
 	owner, err := s.State.ControllerOwner()
 	c.Assert(err, jc.ErrorIsNil)
@@ -1039,6 +1070,20 @@ func (s *StateSuite) TestAddMachinePlacementIgnoresModelConstraints(c *gc.C) {
 	c.Assert(mcons, gc.DeepEquals, expectedCons)
 }
 
+func (s *StateSuite) TestAddMachineCloudInitUserData(c *gc.C) {
+	m, err := s.State.AddOneMachine(state.MachineTemplate{
+		Series:            "quantal",
+		Jobs:              []state.MachineJob{state.JobHostUnits},
+		CloudInitUserData: "packages: [htop]\n",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.CloudInitUserData(), gc.Equals, "packages: [htop]\n")
+
+	m, err = s.State.Machine(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(m.CloudInitUserData(), gc.Equals, "packages: [htop]\n")
+}
+
 func (s *StateSuite) TestAddMachineWithVolumes(c *gc.C) {
 	pm := poolmanager.New(state.NewStateSettings(s.State), provider.CommonStorageProviders())
 	_, err := pm.Create("loop-pool", provider.LoopProviderType, map[string]interface{}{})
@@ -1234,6 +1279,38 @@ func (s *StateSuite) TestAddContainerToMachineSupportingNoContainers(c *gc.C) {
 	s.assertMachineContainers(c, host, nil)
 }
 
+func (s *StateSuite) TestAddContainerToWindowsMachine(c *gc.C) {
+	host, err := s.State.AddMachine("win2012r2", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.AddMachineInsideMachine(state.MachineTemplate{
+		Series: "win2012r2",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}, "0", instance.LXD)
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: lxd containers with Windows series not supported")
+	s.assertMachineContainers(c, host, nil)
+}
+
+func (s *StateSuite) TestAddWindowsContainerToNewMachine(c *gc.C) {
+	_, err := s.State.AddMachineInsideNewMachine(
+		state.MachineTemplate{
+			Series: "win2012r2",
+			Jobs:   []state.MachineJob{state.JobHostUnits},
+		},
+		state.MachineTemplate{
+			Series: "quantal",
+			Jobs:   []state.MachineJob{state.JobHostUnits},
+		},
+		instance.LXD,
+	)
+	c.Assert(err, gc.ErrorMatches, "cannot add a new machine: lxd containers with Windows series not supported")
+}
+
+func (s *StateSuite) TestAddWindowsControllerMachine(c *gc.C) {
+	_, err := s.State.AddMachine("win2012r2", state.JobManageModel)
+	c.Assert(err, gc.ErrorMatches, `cannot add a new machine: controller machine with series "win2012r2" not supported`)
+}
+
 func (s *StateSuite) TestAddContainerToMachineLockedForSeriesUpgrade(c *gc.C) {
 	oneJob := []state.MachineJob{state.JobHostUnits}
 	host, err := s.State.AddMachine("xenial", oneJob...)
@@ -3943,6 +4020,56 @@ func (s *StateSuite) TestSetModelAgentVersionOnOtherModel(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, expected)
 }
 
+func (s *StateSuite) TestSetModelAgentVersionPinned(c *gc.C) {
+	current := version.MustParseBinary("1.24.7-trusty-amd64")
+	s.PatchValue(&jujuversion.Current, current.Number)
+	s.PatchValue(&arch.HostArch, func() string { return current.Arch })
+	s.PatchValue(&series.MustHostSeries, func() string { return current.Series })
+
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	otherModel, err := otherSt.Model()
+	c.Assert(err, jc.ErrorIsNil)
+
+	pinned := version.MustParse("1.24.6")
+	err = otherModel.SetPinnedAgentVersion(pinned)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = otherSt.SetModelAgentVersion(jujuversion.Current, false)
+	c.Assert(err, gc.ErrorMatches, "model agent version is pinned to 1.24.6")
+
+	err = otherSt.SetModelAgentVersion(pinned, false)
+	c.Assert(err, jc.ErrorIsNil)
+	assertAgentVersion(c, otherSt, "1.24.6")
+
+	err = otherModel.SetPinnedAgentVersion(version.Zero)
+	c.Assert(err, jc.ErrorIsNil)
+	err = otherSt.SetModelAgentVersion(jujuversion.Current, false)
+	c.Assert(err, jc.ErrorIsNil)
+	assertAgentVersion(c, otherSt, jujuversion.Current.String())
+}
+
+func (s *StateSuite) TestSetModelAgentVersionPinnedConcurrently(c *gc.C) {
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	otherModel, err := otherSt.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	modelConfig, err := otherModel.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	agentVersion, ok := modelConfig.AgentVersion()
+	c.Assert(ok, jc.IsTrue)
+	currentVersion := agentVersion.String()
+
+	defer state.SetBeforeHooks(c, otherSt, func() {
+		err := otherModel.SetPinnedAgentVersion(agentVersion)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err = otherSt.SetModelAgentVersion(version.MustParse("1.2.3"), false)
+	c.Assert(err, gc.ErrorMatches, "model agent version is pinned to "+currentVersion)
+	assertAgentVersion(c, otherSt, currentVersion)
+}
+
 func (s *StateSuite) TestSetModelAgentVersionExcessiveContention(c *gc.C) {
 	modelConfig, currentVersion := s.prepareAgentVersionTests(c, s.State)
 
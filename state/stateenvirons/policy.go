@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plugin"
 	"github.com/juju/juju/storage/provider"
 )
 
@@ -124,6 +125,18 @@ func (p environStatePolicy) StorageProviderRegistry() (storage.ProviderRegistry,
 	return NewStorageProviderRegistryForModel(model, p.getEnviron, p.getBroker)
 }
 
+// CloudDestroyer implements state.Policy.
+func (p environStatePolicy) CloudDestroyer() (environs.CloudDestroyer, error) {
+	model, err := p.st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.Type() != state.ModelTypeIAAS {
+		return nil, errors.NotImplementedf("CloudDestroyer")
+	}
+	return p.getEnviron(p.st)
+}
+
 // NewStorageProviderRegistryForModel returns a storage provider registry
 // for the specified model.
 func NewStorageProviderRegistryForModel(
@@ -145,9 +158,14 @@ func NewStorageProviderRegistryForModel(
 }
 
 // NewStorageProviderRegistry returns a storage.ProviderRegistry that chains
-// the provided registry with the common storage providers.
+// the provided registry with the common storage providers, and with the
+// storage plugins registered for the registry's cloud.
 func NewStorageProviderRegistry(reg storage.ProviderRegistry) storage.ProviderRegistry {
-	return storage.ChainedProviderRegistry{reg, provider.CommonStorageProviders()}
+	return storage.ChainedProviderRegistry{
+		reg,
+		provider.CommonStorageProviders(),
+		plugin.CloudRegistry(reg),
+	}
 }
 
 func environProvider(cloudType string) (environs.EnvironProvider, error) {
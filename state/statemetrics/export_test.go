@@ -23,6 +23,11 @@ func (c *Collector) UsersGauge() *prometheus.GaugeVec {
 	return c.users
 }
 
+// RelationSettingsGauge returns the internal gauge for testing.
+func (c *Collector) RelationSettingsGauge() *prometheus.GaugeVec {
+	return c.relationSettings
+}
+
 // ScrapeDuration returns the internal gauge for testing.
 func (c *Collector) ScrapeDurationGauge() prometheus.Gauge {
 	return c.scrapeDuration
@@ -134,6 +134,14 @@ func (m *mockState) release() bool {
 	return false
 }
 
+func (m *mockState) RelationSettingsSizes() (map[string]int, error) {
+	m.MethodCall(m, "RelationSettingsSizes")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.model.relationSettingsSizes, nil
+}
+
 func (m *mockState) AllMachines() ([]statemetrics.Machine, error) {
 	m.MethodCall(m, "AllMachines")
 	if err := m.NextErr(); err != nil {
@@ -152,6 +160,8 @@ type mockModel struct {
 	life     state.Life
 	status   status.StatusInfo
 	machines []*mockMachine
+
+	relationSettingsSizes map[string]int
 }
 
 func (m *mockModel) Life() state.Life {
@@ -17,6 +17,7 @@ type State interface {
 	AllModelUUIDs() ([]string, error)
 	AllUsers() ([]User, error)
 	ControllerTag() names.ControllerTag
+	RelationSettingsSizes() (map[string]int, error)
 	UserAccess(names.UserTag, names.Tag) (permission.UserAccess, error)
 }
 
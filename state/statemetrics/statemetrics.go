@@ -20,6 +20,8 @@ const (
 	domainLabel           = "domain"
 	agentStatusLabel      = "agent_status"
 	machineStatusLabel    = "machine_status"
+	modelLabel            = "model"
+	applicationLabel      = "application"
 )
 
 var (
@@ -34,6 +36,11 @@ var (
 		statusLabel,
 	}
 
+	relationSettingsLabelNames = []string{
+		modelLabel,
+		applicationLabel,
+	}
+
 	userLabelNames = []string{
 		controllerAccessLabel,
 		deletedLabel,
@@ -52,9 +59,10 @@ type Collector struct {
 	scrapeDuration prometheus.Gauge
 	scrapeErrors   prometheus.Gauge
 
-	models   *prometheus.GaugeVec
-	machines *prometheus.GaugeVec
-	users    *prometheus.GaugeVec
+	models           *prometheus.GaugeVec
+	machines         *prometheus.GaugeVec
+	users            *prometheus.GaugeVec
+	relationSettings *prometheus.GaugeVec
 }
 
 // New returns a new Collector.
@@ -100,6 +108,14 @@ func New(pool StatePool) *Collector {
 			},
 			userLabelNames,
 		),
+		relationSettings: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Name:      "relation_settings_bytes",
+				Help:      "Total size of the relation settings of each application's units.",
+			},
+			relationSettingsLabelNames,
+		),
 	}
 }
 
@@ -108,6 +124,7 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	c.machines.Describe(ch)
 	c.models.Describe(ch)
 	c.users.Describe(ch)
+	c.relationSettings.Describe(ch)
 
 	c.scrapeErrors.Describe(ch)
 	c.scrapeDuration.Describe(ch)
@@ -124,12 +141,14 @@ func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.machines.Reset()
 	c.models.Reset()
 	c.users.Reset()
+	c.relationSettings.Reset()
 
 	c.updateMetrics()
 
 	c.machines.Collect(ch)
 	c.models.Collect(ch)
 	c.users.Collect(ch)
+	c.relationSettings.Collect(ch)
 }
 
 func (c *Collector) updateMetrics() {
@@ -244,6 +263,19 @@ func (c *Collector) updateModelMetrics(modelUUID string) {
 		}).Inc()
 	}
 
+	sizes, err := st.RelationSettingsSizes()
+	if err != nil {
+		c.scrapeErrors.Inc()
+		logger.Debugf("error getting relation settings sizes: %v", err)
+		sizes = nil
+	}
+	for appName, size := range sizes {
+		c.relationSettings.With(prometheus.Labels{
+			modelLabel:       modelTag.Id(),
+			applicationLabel: appName,
+		}).Set(float64(size))
+	}
+
 	c.models.With(prometheus.Labels{
 		lifeLabel:   model.Life().String(),
 		statusLabel: string(modelStatus.Status),
@@ -58,6 +58,10 @@ func (s *collectorSuite) SetUpTest(c *gc.C) {
 				agentStatus:    status.StatusInfo{Status: status.Started},
 				instanceStatus: status.StatusInfo{Status: status.Running},
 			}},
+			relationSettingsSizes: map[string]int{
+				"mysql":     2048,
+				"wordpress": 512,
+			},
 		}, {
 			tag:    names.NewModelTag("1ab5799e-e72d-4de7-b70d-499edfab0e5c"),
 			life:   state.Dying,
@@ -90,6 +94,7 @@ func (s *collectorSuite) TestDescribe(c *gc.C) {
 		`.*fqName: "juju_state_machines".*`,
 		`.*fqName: "juju_state_models".*`,
 		`.*fqName: "juju_state_users".*`,
+		`.*fqName: "juju_state_relation_settings_bytes".*`,
 		`.*fqName: "juju_state_scrape_errors".*`,
 		`.*fqName: "juju_state_scrape_duration_seconds".*`,
 	}
@@ -216,6 +221,22 @@ func (s *collectorSuite) TestCollect(c *gc.C) {
 			},
 		},
 
+		// juju_state_relation_settings_bytes
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(2048)},
+			Label: []*dto.LabelPair{
+				labelpair("application", "mysql"),
+				labelpair("model", "b266dff7-eee8-4297-b03a-4692796ec193"),
+			},
+		},
+		{
+			Gauge: &dto.Gauge{Value: float64ptr(512)},
+			Label: []*dto.LabelPair{
+				labelpair("application", "wordpress"),
+				labelpair("model", "b266dff7-eee8-4297-b03a-4692796ec193"),
+			},
+		},
+
 		// juju_state_scrape_errors
 		{
 			Gauge: &dto.Gauge{Value: float64ptr(0)},
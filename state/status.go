@@ -687,7 +687,16 @@ func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 	return results, nil
 }
 
+// PruneStatusHistory prunes the status history collection, archiving
+// the pruned entries in the blobstore so they can still be queried
+// with ArchivedStatusHistory.
 func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
-	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, statusesHistoryC, "updated", NanoSeconds)
+	archives, closer := st.db().GetRawCollection(archivesC)
+	defer closer()
+	archiver := newHistoryArchiver(
+		st.MongoSession(), archives, st.clock(),
+		ArchiveStatusHistory, statusHistoryArchiveRecord,
+	)
+	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, statusesHistoryC, "updated", NanoSeconds, archiver)
 	return errors.Trace(err)
 }
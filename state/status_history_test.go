@@ -11,6 +11,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
@@ -183,6 +184,60 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 	}
 }
 
+func (s *StatusHistorySuite) TestPruneStatusHistoryArchives(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+
+	primeUnitStatusHistory(c, unit, 5, 0)
+	primeUnitStatusHistory(c, unit, 5, 24*time.Hour)
+	primeUnitAgentStatusHistory(c, unit.Agent(), 3, 24*time.Hour, "")
+
+	err := state.PruneStatusHistory(s.State, 10*time.Hour, 1024)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 6)
+
+	// The pruned history is archived, and returned oldest first.
+	archived, err := s.State.ArchivedStatusHistory(status.KindWorkload, unit.UnitTag(), time.Time{}, time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archived, gc.HasLen, 5)
+	for i, statusInfo := range archived {
+		c.Check(statusInfo.Status, gc.Equals, status.Active)
+		c.Check(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+			"$foo":   float64(i),
+			"$delta": float64(24 * time.Hour),
+		})
+		c.Check(statusInfo.Since, gc.NotNil)
+	}
+
+	archived, err = s.State.ArchivedStatusHistory(status.KindUnitAgent, unit.UnitTag(), time.Time{}, time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archived, gc.HasLen, 3)
+
+	archived, err = s.State.ArchivedStatusHistory(status.KindUnit, unit.UnitTag(), time.Time{}, time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archived, gc.HasLen, 8)
+
+	// Only the history recorded in the given range is returned.
+	from := *archived[0].Since
+	archived, err = s.State.ArchivedStatusHistory(status.KindWorkload, unit.UnitTag(), from.Add(time.Second), from.Add(2*time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archived, gc.HasLen, 2)
+
+	archives, err := s.State.Archives(state.ArchiveStatusHistory, time.Time{}, time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archives, gc.HasLen, 1)
+	c.Check(archives[0].Count, gc.Equals, 8)
+	c.Check(archives[0].Size, jc.GreaterThan, int64(0))
+}
+
+func (s *StatusHistorySuite) TestArchivedStatusHistoryInvalidKind(c *gc.C) {
+	_, err := s.State.ArchivedStatusHistory(status.KindMachine, names.NewUnitTag("foo/0"), time.Time{}, time.Time{})
+	c.Assert(err, gc.ErrorMatches, `juju-machine status history of "unit-foo-0" not valid`)
+}
+
 func (s *StatusHistorySuite) TestStatusHistoryFilterRunningUpdateStatusHook(c *gc.C) {
 
 	application := s.Factory.MakeApplication(c, nil)
@@ -23,6 +23,23 @@ import (
 	"github.com/juju/juju/storage/provider"
 )
 
+// StorageDisposition describes what should happen to a storage instance
+// when the unit it is attached to is destroyed.
+type StorageDisposition string
+
+const (
+	// StorageDetach detaches the storage from the unit, leaving it in
+	// the model. Storage that cannot be detached is destroyed.
+	StorageDetach StorageDisposition = "detach"
+
+	// StorageDestroy destroys the storage.
+	StorageDestroy StorageDisposition = "destroy"
+
+	// StorageRelease removes the storage from the model without
+	// destroying it in the cloud.
+	StorageRelease StorageDisposition = "release"
+)
+
 // StorageInstance represents the state of a unit or application-wide storage
 // instance in the model.
 type StorageInstance interface {
@@ -2130,6 +2147,12 @@ func (sb *storageBackend) addStorageForUnitOps(
 		return nil, nil, errors.Trace(err)
 	}
 	ops = append(ops, addUnitStorageOps...)
+
+	quotaOps, err := u.st.quotaOps(u.st.ModelUUID(), QuotaUsage{StorageMiB: cons.Size * cons.Count})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	ops = append(ops, quotaOps...)
 	return tags, ops, nil
 }
 
@@ -22,6 +22,7 @@ type MockPolicy struct {
 	GetConstraintsValidator       func() (constraints.Validator, error)
 	GetInstanceDistributor        func() (context.Distributor, error)
 	GetStorageProviderRegistry    func() (storage.ProviderRegistry, error)
+	GetCloudDestroyer             func() (environs.CloudDestroyer, error)
 }
 
 func (p *MockPolicy) Prechecker() (environs.InstancePrechecker, error) {
@@ -59,6 +60,13 @@ func (p *MockPolicy) StorageProviderRegistry() (storage.ProviderRegistry, error)
 	return nil, errors.NotImplementedf("StorageProviderRegistry")
 }
 
+func (p *MockPolicy) CloudDestroyer() (environs.CloudDestroyer, error) {
+	if p.GetCloudDestroyer != nil {
+		return p.GetCloudDestroyer()
+	}
+	return nil, errors.NotImplementedf("CloudDestroyer")
+}
+
 func (p *MockPolicy) ProviderConfigSchemaSource(cloudName string) (config.ConfigSchemaSource, error) {
 	if p.GetProviderConfigSchemaSource != nil {
 		return p.GetProviderConfigSchemaSource(cloudName)
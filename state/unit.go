@@ -97,6 +97,7 @@ type unitDoc struct {
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
+	Paused                 bool `bson:"paused,omitempty"`
 }
 
 // Unit represents the state of an application unit.
@@ -467,13 +468,14 @@ func (op *UpdateUnitOperation) Done(err error) error {
 // to a provisioned machine is Destroyed, it will be removed from state
 // directly.
 func (u *Unit) Destroy() error {
-	_, err := u.DestroyWithForce(false)
+	_, err := u.DestroyWithForce(false, 0)
 	return err
 }
 
 // DestroyWithForce does the same thing as Destroy() but
-// ignores errors.
-func (u *Unit) DestroyWithForce(force bool) (errs []error, err error) {
+// ignores errors. Forced cleanups scheduled for the unit
+// wait for maxWait before running.
+func (u *Unit) DestroyWithForce(force bool, maxWait time.Duration) (errs []error, err error) {
 	defer func() {
 		if err == nil {
 			// This is a white lie; the document might actually be removed.
@@ -482,6 +484,7 @@ func (u *Unit) DestroyWithForce(force bool) (errs []error, err error) {
 	}()
 	op := u.DestroyOperation()
 	op.Force = force
+	op.MaxWait = maxWait
 	err = u.st.ApplyOperation(op)
 	return op.Errors, err
 }
@@ -505,6 +508,11 @@ type DestroyUnitOperation struct {
 	// to the unit is destroyed. If this is false, then detachable
 	// storage will be detached and left in the model.
 	DestroyStorage bool
+
+	// StorageDisposition maps the IDs of storage instances attached
+	// to the unit to what should happen to them, overriding
+	// DestroyStorage for those instances.
+	StorageDisposition map[string]StorageDisposition
 }
 
 // Build is part of the ModelOperation interface.
@@ -615,7 +623,10 @@ func (op *DestroyUnitOperation) destroyOps() ([]txn.Op, error) {
 	// if the minUnits document exists, we need to increment the revno so that
 	// it is obvious the min units count is changing.
 	minUnitsOp := minUnitsTriggerOp(op.unit.st, op.unit.ApplicationName())
-	cleanupOp := newCleanupOp(cleanupDyingUnit, op.unit.doc.Name, op.DestroyStorage, op.Force)
+	cleanupOp := newCleanupOp(
+		cleanupDyingUnit, op.unit.doc.Name,
+		op.DestroyStorage, op.Force, op.StorageDisposition, op.MaxWait,
+	)
 
 	// If we're forcing destruction the assertion shouldn't be that
 	// life is alive, but that it's what we think it is now.
@@ -835,7 +846,7 @@ func (u *Unit) destroyHostOps(a *Application, op *ForcedOperation) (ops []txn.Op
 		if !op.Force {
 			cleanupOps = []txn.Op{newCleanupOp(cleanupDyingMachine, m.doc.Id, op.Force)}
 		} else {
-			cleanupOps = []txn.Op{newCleanupOp(cleanupForceDestroyedMachine, m.doc.Id)}
+			cleanupOps = []txn.Op{newCleanupOp(cleanupForceDestroyedMachine, m.doc.Id, op.MaxWait)}
 		}
 	}
 
@@ -1009,6 +1020,12 @@ type ForcedOperation struct {
 	// will be forced, i.e. ignore operational errors.
 	Force bool
 
+	// MaxWait specifies the amount of time that each step of a forced
+	// operation will wait for things to happen gracefully before
+	// forcing the next step to kick-off. It only makes sense in
+	// combination with Force set to true.
+	MaxWait time.Duration
+
 	// Errors contains errors encountered while applying this operation.
 	// Generally, these are non-fatal errors that have been encountered
 	// during, say, force. They may not have prevented the operation from being
@@ -1594,6 +1611,77 @@ func (u *Unit) ClosePorts(protocol string, fromPort, toPort int) (err error) {
 	return u.ClosePortsOnSubnet("", protocol, fromPort, toPort)
 }
 
+// OpenPortsOnEndpoint opens the given port range and protocol for the given
+// endpoint of the unit, which can be empty to open the range for all of the
+// unit's endpoints. Returns an error if the endpoint is not one of the unit's
+// charm endpoints, or if opening the requested range conflicts with a range
+// already opened by another unit on the unit's assigned machine.
+func (u *Unit) OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) (err error) {
+	ports, err := u.endpointPortRange(endpoint, protocol, fromPort, toPort)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot open ports %v for unit %q", ports, u)
+
+	machineID, err := u.AssignedMachineId()
+	if err != nil {
+		return errors.Annotatef(err, "unit %q has no assigned machine", u)
+	}
+	machinePorts, err := getOrCreatePorts(u.st, machineID, "")
+	if err != nil {
+		return errors.Annotate(err, "cannot get or create ports")
+	}
+	return machinePorts.OpenPorts(ports)
+}
+
+// ClosePortsOnEndpoint closes the given port range and protocol for the given
+// endpoint of the unit, which can be empty to close the range opened for all
+// of the unit's endpoints. Ranges opened for other endpoints are left open.
+func (u *Unit) ClosePortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) (err error) {
+	ports, err := u.endpointPortRange(endpoint, protocol, fromPort, toPort)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer errors.DeferredAnnotatef(&err, "cannot close ports %v for unit %q", ports, u)
+
+	machineID, err := u.AssignedMachineId()
+	if err != nil {
+		return errors.Annotatef(err, "unit %q has no assigned machine", u)
+	}
+	machinePorts, err := getOrCreatePorts(u.st, machineID, "")
+	if err != nil {
+		return errors.Annotate(err, "cannot get or create ports")
+	}
+	return machinePorts.ClosePorts(ports)
+}
+
+// endpointPortRange returns the unit's port range for the endpoint,
+// checking that the endpoint is defined by the unit's charm.
+func (u *Unit) endpointPortRange(endpoint, protocol string, fromPort, toPort int) (PortRange, error) {
+	ports, err := NewPortRange(u.Name(), fromPort, toPort, protocol)
+	if err != nil {
+		return PortRange{}, errors.Annotatef(err, "invalid port range %v-%v/%v", fromPort, toPort, protocol)
+	}
+	if endpoint == "" {
+		return ports, nil
+	}
+	app, err := u.Application()
+	if err != nil {
+		return PortRange{}, errors.Trace(err)
+	}
+	endpoints, err := app.Endpoints()
+	if err != nil {
+		return PortRange{}, errors.Trace(err)
+	}
+	for _, ep := range endpoints {
+		if ep.Name == endpoint {
+			ports.Endpoint = endpoint
+			return ports, nil
+		}
+	}
+	return PortRange{}, errors.NotFoundf("endpoint %q of unit %q", endpoint, u)
+}
+
 // OpenPortOnSubnet opens the given port and protocol for the unit on the given
 // subnet, which can be empty. When non-empty, subnetID must refer to an
 // existing, alive subnet, otherwise an error is returned.
@@ -1647,12 +1735,19 @@ func (u *Unit) OpenedPortsOnSubnet(subnetID string) ([]corenetwork.PortRange, er
 		return nil, errors.Annotatef(err, "failed getting ports for unit %q, subnet %q", u, subnetID)
 	}
 	ports := machinePorts.PortsForUnit(u.Name())
+	seen := make(map[corenetwork.PortRange]bool)
 	for _, port := range ports {
-		result = append(result, corenetwork.PortRange{
+		portRange := corenetwork.PortRange{
 			Protocol: port.Protocol,
 			FromPort: port.FromPort,
 			ToPort:   port.ToPort,
-		})
+		}
+		// The same range may be opened for several endpoints.
+		if seen[portRange] {
+			continue
+		}
+		seen[portRange] = true
+		result = append(result, portRange)
 	}
 	corenetwork.SortPortRanges(result)
 	return result, nil
@@ -1926,6 +2021,7 @@ func (u *Unit) assignToMachine(m *Machine, unused bool) (err error) {
 	}
 	u.doc.MachineId = m.doc.Id
 	m.doc.Clean = false
+	m.doc.Spare = false
 	return nil
 }
 
@@ -1980,6 +2076,14 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	}
 	storageOps = append(storageOps, attachmentOps...)
 
+	// Other applications' units on the machine may only be joined
+	// if the model's co-location policy allows it.
+	colocationOps, err := u.colocationOps(m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageOps = append(storageOps, colocationOps...)
+
 	assert := append(isAliveDoc, bson.D{{
 		// The unit's subordinates must not change while we're
 		// assigning it to a machine, to ensure machine storage
@@ -1995,6 +2099,13 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	if unused {
 		massert = append(massert, bson.D{{"clean", bson.D{{"$ne", false}}}}...)
 	}
+	// The machine must not have gained units since the co-location
+	// rules were checked.
+	principals := m.doc.Principals
+	if principals == nil {
+		principals = []string{}
+	}
+	massert = append(massert, bson.DocElem{"principals", bson.D{{"$not", bson.D{{"$elemMatch", bson.D{{"$nin", principals}}}}}}})
 	ops := []txn.Op{{
 		C:      unitsC,
 		Id:     u.doc.DocID,
@@ -2004,7 +2115,12 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 		C:      machinesC,
 		Id:     m.doc.DocID,
 		Assert: massert,
-		Update: bson.D{{"$addToSet", bson.D{{"principals", u.doc.Name}}}, {"$set", bson.D{{"clean", false}}}},
+		Update: bson.D{
+			{"$addToSet", bson.D{{"principals", u.doc.Name}}},
+			{"$set", bson.D{{"clean", false}}},
+			// A machine with a unit is no longer spare.
+			{"$unset", bson.D{{"spare", nil}}},
+		},
 	},
 		removeStagedAssignmentOp(u.doc.DocID),
 	}
@@ -2394,29 +2510,10 @@ func (u *Unit) assignToNewMachine(placement string) error {
 				return nil, errors.Trace(err)
 			}
 		}
-		cons, err := u.Constraints()
+		template, containerType, err := u.newMachineTemplate(placement)
 		if err != nil {
 			return nil, err
 		}
-		var containerType instance.ContainerType
-		if cons.HasContainer() {
-			containerType = *cons.Container
-		}
-		storageParams, err := u.storageParams()
-		if err != nil {
-			return nil, errors.Trace(err)
-		}
-		template := MachineTemplate{
-			Series:                u.doc.Series,
-			Constraints:           *cons,
-			Jobs:                  []MachineJob{JobHostUnits},
-			Placement:             placement,
-			Dirty:                 placement != "",
-			Volumes:               storageParams.volumes,
-			VolumeAttachments:     storageParams.volumeAttachments,
-			Filesystems:           storageParams.filesystems,
-			FilesystemAttachments: storageParams.filesystemAttachments,
-		}
 		// Get the ops necessary to create a new machine, and the
 		// machine doc that will be added with those operations
 		// (which includes the machine id).
@@ -2431,6 +2528,36 @@ func (u *Unit) assignToNewMachine(placement string) error {
 	return nil
 }
 
+// newMachineTemplate returns the template for a new machine to host
+// the unit, with the optional placement directive, along with the type
+// of container the unit's constraints require, if any.
+func (u *Unit) newMachineTemplate(placement string) (MachineTemplate, instance.ContainerType, error) {
+	cons, err := u.Constraints()
+	if err != nil {
+		return MachineTemplate{}, "", err
+	}
+	var containerType instance.ContainerType
+	if cons.HasContainer() {
+		containerType = *cons.Container
+	}
+	storageParams, err := u.storageParams()
+	if err != nil {
+		return MachineTemplate{}, "", errors.Trace(err)
+	}
+	template := MachineTemplate{
+		Series:                u.doc.Series,
+		Constraints:           *cons,
+		Jobs:                  []MachineJob{JobHostUnits},
+		Placement:             placement,
+		Dirty:                 placement != "",
+		Volumes:               storageParams.volumes,
+		VolumeAttachments:     storageParams.volumeAttachments,
+		Filesystems:           storageParams.filesystems,
+		FilesystemAttachments: storageParams.filesystemAttachments,
+	}
+	return template, containerType, nil
+}
+
 type byStorageInstance []StorageAttachment
 
 func (b byStorageInstance) Len() int      { return len(b) }
@@ -2813,7 +2940,7 @@ func (u *Unit) assignToCleanMaybeEmptyMachine(requireEmpty bool) (*Machine, erro
 			}
 		}
 		var ops []txn.Op
-		m, ops, err = u.assignToCleanMaybeEmptyMachineOps(requireEmpty)
+		m, ops, err = u.assignToCleanMaybeEmptyMachineOps(requireEmpty, nil)
 		return ops, err
 	}
 	if err := u.st.db().Run(buildTxn); err != nil {
@@ -2821,10 +2948,14 @@ func (u *Unit) assignToCleanMaybeEmptyMachine(requireEmpty bool) (*Machine, erro
 	}
 	u.doc.MachineId = m.doc.Id
 	m.doc.Clean = false
+	m.doc.Spare = false
 	return m, nil
 }
 
-func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine, _ []txn.Op, err error) {
+// assignToCleanMaybeEmptyMachineOps returns the machine chosen for the
+// unit and the txn.Ops to assign the unit to it. Machines with ids in
+// exclude are not considered.
+func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool, exclude set.Strings) (_ *Machine, _ []txn.Op, err error) {
 	failure := func(err error) (*Machine, []txn.Op, error) {
 		return nil, nil, err
 	}
@@ -2934,6 +3065,13 @@ func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine,
 	}
 	machines = append(machines, unprovisioned...)
 
+	// Prefer machines from the model's pool of spare machines, which
+	// were added for units to be assigned to; the replenishing worker
+	// will add others to take their place.
+	sort.SliceStable(machines, func(i, j int) bool {
+		return machines[i].doc.Spare && !machines[j].doc.Spare
+	})
+
 	// TODO(axw) 2014-05-30 #1253704
 	// We should not select a machine that is in the process
 	// of being provisioned. There's no point asserting that
@@ -2942,6 +3080,9 @@ func (u *Unit) assignToCleanMaybeEmptyMachineOps(requireEmpty bool) (_ *Machine,
 	// provisioned without the fact having yet been recorded
 	// in state.
 	for _, m := range machines {
+		if exclude.Contains(m.Id()) {
+			continue
+		}
 		// Check that the unit storage is compatible with
 		// the machine in question.
 		if err := validateDynamicMachineStorageParams(m, storageParams); err != nil {
@@ -0,0 +1,340 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// UnitPlacementPolicy controls how AssignUnits chooses machines for a
+// set of units.
+type UnitPlacementPolicy string
+
+const (
+	// PlacementPack assigns units to existing clean, empty machines
+	// that are suitable for them, and creates new machines only for
+	// the units that do not fit.
+	PlacementPack UnitPlacementPolicy = "pack"
+
+	// PlacementSpread assigns each unit to a new machine, spreading
+	// the new machines across availability zones so that each
+	// application's units are balanced between them.
+	PlacementSpread UnitPlacementPolicy = "spread"
+)
+
+// assignUnitsBatchSize is the number of units assigned by each
+// transaction run by AssignUnits.
+const assignUnitsBatchSize = 10
+
+// AssignUnitsArgs holds the arguments for AssignUnits.
+type AssignUnitsArgs struct {
+	// Units holds the names of the principal units to assign.
+	Units []string
+
+	// Policy determines how machines are chosen for the units.
+	Policy UnitPlacementPolicy
+
+	// Zones holds the availability zones across which the spread
+	// policy distributes new machines. If it is empty, the zone of
+	// each new machine is left to the provider.
+	Zones []string
+}
+
+// UnitPlacement records the machine chosen for a unit by AssignUnits.
+type UnitPlacement struct {
+	// Unit is the name of the unit.
+	Unit string
+
+	// MachineId is the id of the machine the unit was assigned to.
+	MachineId string
+
+	// Zone is the availability zone requested for the machine, if
+	// it was created with a zone placement directive.
+	Zone string
+
+	// NewMachine reports whether the machine was created to host
+	// the unit.
+	NewMachine bool
+
+	// Error holds the reason the unit could not be assigned, if any.
+	Error error
+}
+
+// AssignUnits chooses machines for the given principal units according
+// to the policy, and assigns the units to them in batches, rather than
+// running a transaction for each unit. The placements are returned in
+// the order of the units; any unit that could not be assigned has the
+// reason recorded in its placement's Error.
+func (st *State) AssignUnits(args AssignUnitsArgs) ([]UnitPlacement, error) {
+	switch args.Policy {
+	case PlacementPack, PlacementSpread:
+	default:
+		return nil, errors.NotValidf("unit placement policy %q", args.Policy)
+	}
+	p := &unitPlanner{
+		st:         st,
+		policy:     args.Policy,
+		zones:      args.Zones,
+		used:       set.NewStrings(),
+		zoneCounts: make(map[string]map[string]int),
+	}
+	placements := make([]UnitPlacement, len(args.Units))
+	var batch []*plannedAssignment
+	for i, name := range args.Units {
+		placements[i].Unit = name
+		a, err := p.plan(name)
+		if err != nil {
+			placements[i].Error = errors.Annotatef(err, "cannot assign unit %q to machine", name)
+			continue
+		}
+		a.index = i
+		batch = append(batch, a)
+		if len(batch) < assignUnitsBatchSize {
+			continue
+		}
+		if err := p.run(batch, placements); err != nil {
+			return nil, errors.Trace(err)
+		}
+		batch = nil
+	}
+	if len(batch) > 0 {
+		if err := p.run(batch, placements); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return placements, nil
+}
+
+// plannedAssignment holds the machine chosen for a unit, and the
+// operations that assign the unit to it.
+type plannedAssignment struct {
+	index      int
+	unit       *Unit
+	zone       string
+	machine    *Machine
+	newMachine bool
+	ops        []txn.Op
+}
+
+func (a *plannedAssignment) placement() UnitPlacement {
+	return UnitPlacement{
+		Unit:       a.unit.Name(),
+		MachineId:  a.machine.Id(),
+		Zone:       a.zone,
+		NewMachine: a.newMachine,
+	}
+}
+
+// unitPlanner chooses machines for the units passed to AssignUnits.
+type unitPlanner struct {
+	st     *State
+	policy UnitPlacementPolicy
+	zones  []string
+
+	// used holds the ids of the existing machines already chosen,
+	// so that each is chosen for at most one unit.
+	used set.Strings
+
+	// zoneCounts holds, for each application, the number of its
+	// units in each zone, including those planned so far.
+	zoneCounts map[string]map[string]int
+}
+
+// plan chooses a machine for the named unit.
+func (p *unitPlanner) plan(name string) (*plannedAssignment, error) {
+	u, err := p.st.Unit(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !u.IsPrincipal() {
+		return nil, errors.Errorf("subordinate unit %q cannot be assigned directly to a machine", name)
+	}
+	if u.doc.MachineId != "" {
+		return nil, alreadyAssignedErr
+	}
+	a := &plannedAssignment{unit: u}
+	if p.policy == PlacementSpread {
+		if a.zone, err = p.chooseZone(u); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := p.buildOps(a); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a, nil
+}
+
+// buildOps chooses the machine for the planned assignment, and sets
+// the operations that assign the unit to it.
+func (p *unitPlanner) buildOps(a *plannedAssignment) error {
+	u := a.unit
+	if p.policy == PlacementPack {
+		m, ops, err := u.assignToCleanMaybeEmptyMachineOps(true, p.used)
+		if err == nil {
+			p.used.Add(m.Id())
+			a.machine, a.newMachine, a.ops = m, false, ops
+			return nil
+		}
+		if errors.Cause(err) != noCleanMachines {
+			return errors.Trace(err)
+		}
+	}
+	var placement string
+	if a.zone != "" {
+		placement = "zone=" + a.zone
+	}
+	template, containerType, err := u.newMachineTemplate(placement)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, ops, err := u.assignToNewMachineOps(template, "", containerType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	a.machine, a.newMachine, a.ops = m, true, ops
+	return nil
+}
+
+// chooseZone returns the zone with the fewest of the unit's
+// application's units, or "" if no zones are suitable for the unit.
+func (p *unitPlanner) chooseZone(u *Unit) (string, error) {
+	zones := p.zones
+	cons, err := u.Constraints()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if cons.HasZones() {
+		allowed := set.NewStrings(*cons.Zones...)
+		zones = nil
+		for _, zone := range p.zones {
+			if allowed.Contains(zone) {
+				zones = append(zones, zone)
+			}
+		}
+	}
+	if len(zones) == 0 {
+		return "", nil
+	}
+	counts, err := p.applicationZoneCounts(u.ApplicationName())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	best := zones[0]
+	for _, zone := range zones[1:] {
+		if counts[zone] < counts[best] {
+			best = zone
+		}
+	}
+	counts[best]++
+	return best, nil
+}
+
+// applicationZoneCounts returns the number of the application's
+// assigned units in each zone.
+func (p *unitPlanner) applicationZoneCounts(appName string) (map[string]int, error) {
+	if counts, ok := p.zoneCounts[appName]; ok {
+		return counts, nil
+	}
+	app, err := p.st.Application(appName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	counts := make(map[string]int)
+	for _, u := range units {
+		if u.doc.MachineId == "" {
+			continue
+		}
+		m, err := p.st.Machine(u.doc.MachineId)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		zone, err := machineZone(m)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if zone != "" {
+			counts[zone]++
+		}
+	}
+	p.zoneCounts[appName] = counts
+	return counts, nil
+}
+
+// machineZone returns the availability zone of the machine's instance
+// or, if the machine is not yet provisioned, the zone it was placed
+// in, if any.
+func machineZone(m *Machine) (string, error) {
+	zone, err := m.AvailabilityZone()
+	if errors.IsNotProvisioned(err) {
+		if placement := m.Placement(); strings.HasPrefix(placement, "zone=") {
+			return strings.TrimPrefix(placement, "zone="), nil
+		}
+		return "", nil
+	}
+	return zone, errors.Trace(err)
+}
+
+// run assigns a batch of planned units in a single transaction,
+// recording the outcomes in placements. If the transaction aborts
+// because something changed after the batch was planned, the units
+// are instead assigned one at a time, each against fresh state.
+func (p *unitPlanner) run(batch []*plannedAssignment, placements []UnitPlacement) error {
+	var ops []txn.Op
+	for _, a := range batch {
+		ops = append(ops, a.ops...)
+	}
+	err := p.st.db().RunTransaction(ops)
+	if err == nil {
+		for _, a := range batch {
+			placements[a.index] = a.placement()
+		}
+		return nil
+	}
+	if err != txn.ErrAborted {
+		return errors.Trace(err)
+	}
+	logger.Debugf("assigning batch of %d units aborted; assigning them separately", len(batch))
+	for _, a := range batch {
+		name := a.unit.Name()
+		if err := p.runOne(a); err != nil {
+			placements[a.index] = UnitPlacement{
+				Unit:  name,
+				Error: errors.Annotatef(err, "cannot assign unit %q to machine", name),
+			}
+			continue
+		}
+		placements[a.index] = a.placement()
+	}
+	return nil
+}
+
+// runOne assigns a single planned unit, choosing its machine again
+// from the current state.
+func (p *unitPlanner) runOne(a *plannedAssignment) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		u, err := p.st.Unit(a.unit.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if u.doc.MachineId != "" {
+			return nil, alreadyAssignedErr
+		}
+		a.unit = u
+		if err := p.buildOps(a); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return a.ops, nil
+	}
+	return errors.Trace(p.st.db().Run(buildTxn))
+}
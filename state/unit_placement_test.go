@@ -0,0 +1,173 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/state"
+)
+
+type UnitPlacementSuite struct {
+	ConnSuite
+	wordpress *state.Application
+}
+
+var _ = gc.Suite(&UnitPlacementSuite{})
+
+func (s *UnitPlacementSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+}
+
+func (s *UnitPlacementSuite) addUnits(c *gc.C, n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		u, err := s.wordpress.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		names[i] = u.Name()
+	}
+	return names
+}
+
+func (s *UnitPlacementSuite) assertAssigned(c *gc.C, placement state.UnitPlacement) *state.Machine {
+	c.Assert(placement.Error, jc.ErrorIsNil)
+	u, err := s.State.Unit(placement.Unit)
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := u.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, placement.MachineId)
+	m, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+	return m
+}
+
+func (s *UnitPlacementSuite) TestAssignUnitsInvalidPolicy(c *gc.C) {
+	_, err := s.State.AssignUnits(state.AssignUnitsArgs{
+		Units:  s.addUnits(c, 1),
+		Policy: "scatter",
+	})
+	c.Assert(err, gc.ErrorMatches, `unit placement policy "scatter" not valid`)
+}
+
+func (s *UnitPlacementSuite) TestAssignUnitsPack(c *gc.C) {
+	clean1, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	clean2, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	units := s.addUnits(c, 3)
+
+	placements, err := s.State.AssignUnits(state.AssignUnitsArgs{
+		Units:  units,
+		Policy: state.PlacementPack,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(placements, gc.HasLen, 3)
+
+	existing := make(map[string]bool)
+	var created []string
+	for i, placement := range placements {
+		c.Check(placement.Unit, gc.Equals, units[i])
+		m := s.assertAssigned(c, placement)
+		if placement.NewMachine {
+			created = append(created, m.Id())
+		} else {
+			existing[m.Id()] = true
+		}
+	}
+	c.Check(existing, jc.DeepEquals, map[string]bool{
+		clean1.Id(): true,
+		clean2.Id(): true,
+	})
+	c.Check(created, gc.HasLen, 1)
+}
+
+func (s *UnitPlacementSuite) TestAssignUnitsSpread(c *gc.C) {
+	// One unit of the application is already in zone az1.
+	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	hc := instance.MustParseHardware("availability-zone=az1")
+	err = m.SetProvisioned("inst-id", "", "fake_nonce", &hc)
+	c.Assert(err, jc.ErrorIsNil)
+	u, err := s.wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToMachine(m)
+	c.Assert(err, jc.ErrorIsNil)
+
+	placements, err := s.State.AssignUnits(state.AssignUnitsArgs{
+		Units:  s.addUnits(c, 4),
+		Policy: state.PlacementSpread,
+		Zones:  []string{"az1", "az2", "az3"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(placements, gc.HasLen, 4)
+
+	machines := make(map[string]bool)
+	var zones []string
+	for _, placement := range placements {
+		m := s.assertAssigned(c, placement)
+		c.Check(placement.NewMachine, jc.IsTrue)
+		c.Check(m.Placement(), gc.Equals, "zone="+placement.Zone)
+		machines[m.Id()] = true
+		zones = append(zones, placement.Zone)
+	}
+	c.Check(machines, gc.HasLen, 4)
+	c.Check(zones, jc.DeepEquals, []string{"az2", "az3", "az1", "az2"})
+}
+
+func (s *UnitPlacementSuite) TestAssignUnitsInBatches(c *gc.C) {
+	placements, err := s.State.AssignUnits(state.AssignUnitsArgs{
+		Units:  s.addUnits(c, 12),
+		Policy: state.PlacementSpread,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(placements, gc.HasLen, 12)
+	machines := make(map[string]bool)
+	for _, placement := range placements {
+		m := s.assertAssigned(c, placement)
+		c.Check(placement.Zone, gc.Equals, "")
+		c.Check(m.Placement(), gc.Equals, "")
+		machines[m.Id()] = true
+	}
+	c.Check(machines, gc.HasLen, 12)
+}
+
+func (s *UnitPlacementSuite) TestAssignUnitsErrors(c *gc.C) {
+	units := s.addUnits(c, 2)
+	u, err := s.State.Unit(units[0])
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.AddTestingApplication(c, "logging", s.AddTestingCharm(c, "logging"))
+	eps, err := s.State.InferEndpoints("logging", "wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	ru, err := rel.Unit(u)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	placements, err := s.State.AssignUnits(state.AssignUnitsArgs{
+		Units:  []string{units[0], "logging/0", "wordpress/99", units[1]},
+		Policy: state.PlacementPack,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(placements, gc.HasLen, 4)
+	c.Check(placements[0].Error, gc.ErrorMatches, fmt.Sprintf(
+		`cannot assign unit %q to machine: unit is already assigned to a machine`, units[0],
+	))
+	c.Check(placements[1].Error, gc.ErrorMatches,
+		`cannot assign unit "logging/0" to machine: subordinate unit "logging/0" cannot be assigned directly to a machine`,
+	)
+	c.Check(placements[2].Error, gc.ErrorMatches,
+		`cannot assign unit "wordpress/99" to machine: unit "wordpress/99" not found`,
+	)
+	s.assertAssigned(c, placements[3])
+}
@@ -1421,6 +1421,53 @@ func (s *UnitSuite) TestUnitWaitAgentPresence(c *gc.C) {
 	c.Assert(alive, jc.IsFalse)
 }
 
+func (s *UnitSuite) TestSetPaused(c *gc.C) {
+	paused, err := s.unit.IsPaused()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paused, jc.IsFalse)
+
+	err = s.unit.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Paused(), jc.IsTrue)
+	paused, err = s.unit.IsPaused()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paused, jc.IsTrue)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.unit.Paused(), jc.IsTrue)
+
+	err = s.unit.SetPaused(false)
+	c.Assert(err, jc.ErrorIsNil)
+	paused, err = s.unit.IsPaused()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paused, jc.IsFalse)
+}
+
+func (s *UnitSuite) TestIsPausedApplicationPaused(c *gc.C) {
+	err := s.application.SetPaused(true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.IsPaused(), jc.IsTrue)
+
+	c.Assert(s.unit.Paused(), jc.IsFalse)
+	paused, err := s.unit.IsPaused()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paused, jc.IsTrue)
+
+	err = s.application.SetPaused(false)
+	c.Assert(err, jc.ErrorIsNil)
+	paused, err = s.unit.IsPaused()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(paused, jc.IsFalse)
+}
+
+func (s *UnitSuite) TestSetPausedNotAlive(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetPaused(true)
+	c.Assert(err, gc.ErrorMatches, `cannot set paused flag for unit "wordpress/0" to true: unit is not found or not alive`)
+}
+
 func (s *UnitSuite) TestResolve(c *gc.C) {
 	err := s.unit.Resolve(true)
 	c.Assert(err, gc.ErrorMatches, `unit "wordpress/0" is not in an error state`)
@@ -1633,6 +1680,46 @@ func (s *UnitSuite) testOpenedPorts(c *gc.C, subnetID, expectedErrorCauseMatches
 	}
 }
 
+func (s *UnitSuite) TestOpenClosePortsOnEndpoint(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.OpenPortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPortsOnEndpoint("monitoring-port", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPortsOnEndpoint("", "icmp", -1, -1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.OpenPortsOnEndpoint("bogus", "tcp", 8080, 8080)
+	c.Assert(err, gc.ErrorMatches, `endpoint "bogus" of unit "wordpress/0" not found`)
+
+	ports, err := machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRanges(), jc.SameContents, []state.PortRange{
+		{UnitName: "wordpress/0", FromPort: 80, ToPort: 80, Protocol: "tcp", Endpoint: "url"},
+		{UnitName: "wordpress/0", FromPort: 80, ToPort: 80, Protocol: "tcp", Endpoint: "monitoring-port"},
+		{UnitName: "wordpress/0", FromPort: -1, ToPort: -1, Protocol: "icmp"},
+	})
+	open, err := s.unit.OpenedPorts()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(open, gc.DeepEquals, []corenetwork.PortRange{
+		{-1, -1, "icmp"},
+		{80, 80, "tcp"},
+	})
+
+	// Closing the range for one endpoint leaves it open for the other.
+	err = s.unit.ClosePortsOnEndpoint("url", "tcp", 80, 80)
+	c.Assert(err, jc.ErrorIsNil)
+	ports, err = machine.OpenedPorts("")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ports.PortRanges(), jc.SameContents, []state.PortRange{
+		{UnitName: "wordpress/0", FromPort: 80, ToPort: 80, Protocol: "tcp", Endpoint: "monitoring-port"},
+		{UnitName: "wordpress/0", FromPort: -1, ToPort: -1, Protocol: "icmp"},
+	})
+}
+
 func (s *UnitSuite) TestOpenClosePortWhenDying(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -2349,7 +2436,7 @@ func (s *UnitSuite) TestDestroyWithForceWorksOnDyingUnit(c *gc.C) {
 
 	// Force-destroying the unit should schedule a cleanup so we get a
 	// chance for the fallback force-cleanup to run.
-	opErrs, err := unit.DestroyWithForce(true)
+	opErrs, err := unit.DestroyWithForce(true, dontWait)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(opErrs, gc.IsNil)
 
@@ -1445,6 +1445,49 @@ func (sb *storageBackend) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (e
 	return sb.mb.db().Run(buildTxn)
 }
 
+// SetImportedVolumeInfo replaces the VolumeInfo of a provisioned volume
+// in a model that is being imported by a migration. Unlike
+// SetVolumeInfo, the pool and volume ID may change, as the volume may
+// have been recreated by another storage provider.
+func (sb *storageBackend) SetImportedVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set imported info for volume %q", tag.Id())
+	if info.VolumeId == "" {
+		return errors.New("volume ID not set")
+	}
+	if info.Pool == "" {
+		return errors.New("pool not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := sb.Volume(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := v.Info(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if attempt > 0 {
+			models, closer := sb.mb.db().GetCollection(modelsC)
+			defer closer()
+			importing := bson.D{
+				{"_id", sb.mb.modelUUID()},
+				{"migration-mode", MigrationModeImporting},
+			}
+			if n, err := models.Find(importing).Count(); err != nil {
+				return nil, errors.Trace(err)
+			} else if n == 0 {
+				return nil, errors.New("model is not being imported")
+			}
+		}
+		ops := []txn.Op{{
+			C:      modelsC,
+			Id:     sb.mb.modelUUID(),
+			Assert: bson.D{{"migration-mode", MigrationModeImporting}},
+		}}
+		return append(ops, setVolumeInfoOps(tag, info, false)...), nil
+	}
+	return sb.mb.db().Run(buildTxn)
+}
+
 func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
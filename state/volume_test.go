@@ -241,6 +241,43 @@ func (s *VolumeStateSuite) TestSetVolumeInfoImmutable(c *gc.C) {
 	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
 }
 
+func (s *VolumeStateSuite) TestSetImportedVolumeInfo(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	volumeTag := volume.VolumeTag()
+
+	err = s.storageBackend.SetVolumeInfo(volumeTag, state.VolumeInfo{Size: 123, VolumeId: "vol-ume"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetMigrationMode(state.MigrationModeImporting)
+	c.Assert(err, jc.ErrorIsNil)
+
+	volumeInfoSet := state.VolumeInfo{Size: 123, VolumeId: "vol-new", Pool: "rootfs"}
+	err = s.storageBackend.SetImportedVolumeInfo(volumeTag, volumeInfoSet)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
+}
+
+func (s *VolumeStateSuite) TestSetImportedVolumeInfoNotImporting(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+	volumeTag := volume.VolumeTag()
+
+	volumeInfoSet := state.VolumeInfo{Size: 123, VolumeId: "vol-ume"}
+	err = s.storageBackend.SetVolumeInfo(volumeTag, volumeInfoSet)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.storageBackend.SetImportedVolumeInfo(volumeTag, state.VolumeInfo{
+		Size: 123, VolumeId: "vol-new", Pool: "rootfs",
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set imported info for volume "0/0": model is not being imported`)
+	volumeInfoSet.Pool = "loop-pool"
+	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
+}
+
 func (s *VolumeStateSuite) TestWatchVolumeAttachment(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
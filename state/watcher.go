@@ -1970,6 +1970,12 @@ func (a *Application) WatchCharmConfig() (NotifyWatcher, error) {
 	return newEntityWatcher(a.st, settingsC, a.st.docID(configKey)), nil
 }
 
+// WatchApplicationConfig returns a watcher for observing changes to the
+// application's configuration settings (as opposed to its charm config).
+func (a *Application) WatchApplicationConfig() NotifyWatcher {
+	return newEntityWatcher(a.st, settingsC, a.st.docID(a.applicationConfigKey()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's application configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -4,6 +4,11 @@
 package storage
 
 import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/schema"
 )
@@ -19,8 +24,30 @@ const (
 	// should not be relied upon until a storage source is
 	// constructed.
 	ConfigStorageDir = "storage-dir"
+
+	// ConfigFilesystemType is the type of filesystem, e.g. "xfs",
+	// that the machine storage provisioner creates on volumes to
+	// back filesystems.
+	ConfigFilesystemType = "filesystem-type"
+
+	// ConfigMountOptions is a comma-separated list of the options,
+	// e.g. "noatime,nodev", that filesystems are mounted with.
+	ConfigMountOptions = "mount-options"
+
+	// ConfigUID and ConfigGID are the numeric IDs of the user and
+	// group that own the root directories of mounted filesystems.
+	ConfigUID = "uid"
+	ConfigGID = "gid"
+
+	// ConfigMode is the octal permission mode, e.g. "0750", of the
+	// root directories of mounted filesystems.
+	ConfigMode = "mode"
 )
 
+// filesystemTypes are the types of filesystem that may be created
+// on volumes.
+var filesystemTypes = set.NewStrings("ext4", "xfs")
+
 // Config defines the configuration for a storage source.
 type Config struct {
 	name     string
@@ -28,17 +55,28 @@ type Config struct {
 	attrs    map[string]interface{}
 }
 
-var fields = schema.Fields{}
+var fields = schema.Fields{
+	ConfigFilesystemType: schema.String(),
+	ConfigMountOptions:   schema.String(),
+	ConfigUID:            schema.ForceInt(),
+	ConfigGID:            schema.ForceInt(),
+	ConfigMode:           schema.String(),
+}
 
 var configChecker = schema.FieldMap(
 	fields,
-	schema.Defaults{},
+	schema.Defaults{
+		ConfigFilesystemType: schema.Omit,
+		ConfigMountOptions:   schema.Omit,
+		ConfigUID:            schema.Omit,
+		ConfigGID:            schema.Omit,
+		ConfigMode:           schema.Omit,
+	},
 )
 
 // NewConfig creates a new Config for instantiating a storage source.
 func NewConfig(name string, provider ProviderType, attrs map[string]interface{}) (*Config, error) {
-	_, err := configChecker.Coerce(attrs, nil)
-	if err != nil {
+	if _, err := ParseFilesystemOptions(attrs); err != nil {
 		return nil, errors.Annotate(err, "validating common storage config")
 	}
 	return &Config{
@@ -76,3 +114,67 @@ func (c *Config) ValueString(name string) (string, bool) {
 	v, ok := c.attrs[name].(string)
 	return v, ok
 }
+
+// FilesystemOptions holds the common storage configuration for the
+// filesystems that the machine storage provisioner creates and mounts
+// on volumes.
+type FilesystemOptions struct {
+	// Type is the type of filesystem to create, or "" for the default.
+	Type string
+
+	// MountOptions holds the options to mount the filesystem with.
+	MountOptions []string
+
+	// UID and GID are the IDs of the user and group to own the root
+	// directory of the mounted filesystem, or -1 to leave it unchanged.
+	UID int
+	GID int
+
+	// Mode is the permission mode of the root directory of the mounted
+	// filesystem, or 0 to leave it unchanged.
+	Mode os.FileMode
+}
+
+// ParseFilesystemOptions returns the FilesystemOptions in the given
+// storage configuration attributes.
+func ParseFilesystemOptions(attrs map[string]interface{}) (FilesystemOptions, error) {
+	coerced, err := configChecker.Coerce(attrs, nil)
+	if err != nil {
+		return FilesystemOptions{}, errors.Trace(err)
+	}
+	values := coerced.(map[string]interface{})
+	opts := FilesystemOptions{UID: -1, GID: -1}
+	if fsType, ok := values[ConfigFilesystemType].(string); ok && fsType != "" {
+		if !filesystemTypes.Contains(fsType) {
+			return FilesystemOptions{}, errors.NotValidf(
+				"%s %q, expected one of %q", ConfigFilesystemType, fsType, filesystemTypes.SortedValues(),
+			)
+		}
+		opts.Type = fsType
+	}
+	if mountOptions, ok := values[ConfigMountOptions].(string); ok && mountOptions != "" {
+		for _, option := range strings.Split(mountOptions, ",") {
+			option = strings.TrimSpace(option)
+			if option == "" || strings.ContainsAny(option, " \t") {
+				return FilesystemOptions{}, errors.NotValidf("%s %q", ConfigMountOptions, mountOptions)
+			}
+			opts.MountOptions = append(opts.MountOptions, option)
+		}
+	}
+	for key, id := range map[string]*int{ConfigUID: &opts.UID, ConfigGID: &opts.GID} {
+		if value, ok := values[key].(int); ok {
+			if value < 0 {
+				return FilesystemOptions{}, errors.NotValidf("%s %d", key, value)
+			}
+			*id = value
+		}
+	}
+	if mode, ok := values[ConfigMode].(string); ok && mode != "" {
+		value, err := strconv.ParseUint(mode, 8, 32)
+		if err != nil || value > 0777 {
+			return FilesystemOptions{}, errors.NotValidf("%s %q", ConfigMode, mode)
+		}
+		opts.Mode = os.FileMode(value)
+	}
+	return opts, nil
+}
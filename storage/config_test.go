@@ -0,0 +1,79 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/storage"
+)
+
+type ConfigSuite struct{}
+
+var _ = gc.Suite(&ConfigSuite{})
+
+func (s *ConfigSuite) TestParseFilesystemOptionsDefaults(c *gc.C) {
+	opts, err := storage.ParseFilesystemOptions(map[string]interface{}{
+		"foo": "bar",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opts, jc.DeepEquals, storage.FilesystemOptions{
+		UID: -1,
+		GID: -1,
+	})
+}
+
+func (s *ConfigSuite) TestParseFilesystemOptions(c *gc.C) {
+	opts, err := storage.ParseFilesystemOptions(map[string]interface{}{
+		"filesystem-type": "xfs",
+		"mount-options":   "noatime, nodev",
+		"uid":             "1000",
+		"gid":             100,
+		"mode":            "0750",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(opts, jc.DeepEquals, storage.FilesystemOptions{
+		Type:         "xfs",
+		MountOptions: []string{"noatime", "nodev"},
+		UID:          1000,
+		GID:          100,
+		Mode:         0750,
+	})
+}
+
+func (s *ConfigSuite) TestParseFilesystemOptionsInvalid(c *gc.C) {
+	for _, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"filesystem-type": "btrfs"},
+		err:   `filesystem-type "btrfs", expected one of \["ext4" "xfs"\] not valid`,
+	}, {
+		attrs: map[string]interface{}{"mount-options": "noatime,,nodev"},
+		err:   `mount-options "noatime,,nodev" not valid`,
+	}, {
+		attrs: map[string]interface{}{"uid": "root"},
+		err:   `uid: expected number, got string\("root"\)`,
+	}, {
+		attrs: map[string]interface{}{"gid": -1},
+		err:   `gid -1 not valid`,
+	}, {
+		attrs: map[string]interface{}{"mode": "0999"},
+		err:   `mode "0999" not valid`,
+	}, {
+		attrs: map[string]interface{}{"mode": "01777"},
+		err:   `mode "01777" not valid`,
+	}} {
+		_, err := storage.ParseFilesystemOptions(test.attrs)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestNewConfigValidatesFilesystemOptions(c *gc.C) {
+	_, err := storage.NewConfig("pool", "loop", map[string]interface{}{
+		"filesystem-type": "btrfs",
+	})
+	c.Assert(err, gc.ErrorMatches, `validating common storage config: filesystem-type "btrfs", .* not valid`)
+}
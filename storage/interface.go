@@ -216,6 +216,42 @@ type VolumeImporter interface {
 	) (VolumeInfo, error)
 }
 
+// VolumeExport describes a portable copy of a volume, from which the
+// volume can be recreated by another storage provider.
+type VolumeExport struct {
+	// Location is the URL of the copy, which must be readable by
+	// the storage provider recreating the volume.
+	Location string
+
+	// Format is the disk format of the copy, e.g. "raw" or "qcow2".
+	Format string
+
+	// Size is the size of the volume in MiB.
+	Size uint64
+}
+
+// VolumeExporter provides an interface for exporting copies of
+// volumes, so that they can be recreated in another cloud when
+// a model is migrated.
+type VolumeExporter interface {
+	// ExportVolume makes a portable copy of the volume with the
+	// specified volume provider ID, such as by snapshotting it and
+	// exporting the snapshot as an image to object storage.
+	ExportVolume(ctx context.ProviderCallContext, volumeId string) (VolumeExport, error)
+
+	// RemoveVolumeExport removes a copy made by ExportVolume.
+	RemoveVolumeExport(ctx context.ProviderCallContext, export VolumeExport) error
+}
+
+// VolumeExportImporter provides an interface for recreating volumes
+// from copies made by another storage provider's VolumeExporter.
+type VolumeExportImporter interface {
+	// ImportVolumeExport creates a volume with the given parameters,
+	// whose content is that of the exported copy. The volume is not
+	// attached to any machine.
+	ImportVolumeExport(ctx context.ProviderCallContext, export VolumeExport, params VolumeParams) (Volume, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -337,6 +373,10 @@ type FilesystemAttachmentParams struct {
 	// Path is the path at which the filesystem is to be mounted on the machine that
 	// this attachment corresponds to.
 	Path string
+
+	// Attributes is a set of provider-specific options for the filesystem,
+	// as defined in the storage pool of the filesystem.
+	Attributes map[string]interface{}
 }
 
 // CreateVolumesResult contains the result of a VolumeSource.CreateVolumes call
@@ -14,8 +14,14 @@ func NewLocalPlan() common.Plan {
 
 type localPlan struct{}
 
+// AttachVolume is part of the common.Plan interface. Local volumes are
+// attached by the provider, so there is nothing to do. The name of the
+// device the volume is attached as is returned, if the provider knows
+// it.
 func (i *localPlan) AttachVolume(volumeInfo map[string]string) (storage.BlockDevice, error) {
-	return storage.BlockDevice{}, nil
+	return storage.BlockDevice{
+		DeviceName: volumeInfo["device-name"],
+	}, nil
 }
 
 func (i *localPlan) DetachVolume(volumeInfo map[string]string) error {
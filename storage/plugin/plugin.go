@@ -0,0 +1,139 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin
+
+import (
+	"bytes"
+	stdcontext "context"
+	"encoding/json"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/environs/context"
+)
+
+var logger = loggo.GetLogger("juju.storage.plugin")
+
+// DefaultTimeout is how long a plugin may take to complete an
+// operation before it is killed.
+const DefaultTimeout = 10 * time.Minute
+
+// Plugin runs the operations of a storage provider plugin binary.
+type Plugin struct {
+	// Name is the name of the plugin.
+	Name string
+
+	// Path is the path to the plugin binary.
+	Path string
+
+	// Timeout is how long the plugin may take to complete an
+	// operation. If it is zero, DefaultTimeout is used.
+	Timeout time.Duration
+}
+
+// Call runs the operation, passing it the request and decoding its
+// result into result, which may be nil if the operation has none. The
+// plugin is killed if it takes too long, or if ctx, which may be nil,
+// starts dying. If the plugin reports that the cloud credential is
+// invalid, the credential is invalidated through ctx.
+func (p *Plugin) Call(ctx context.ProviderCallContext, op string, request, result interface{}) error {
+	if request == nil {
+		request = struct{}{}
+	}
+	input, err := json.Marshal(request)
+	if err != nil {
+		return errors.Annotatef(err, "encoding storage plugin %q %s request", p.Name, op)
+	}
+
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+	runCtx, cancel := stdcontext.WithTimeout(stdcontext.Background(), timeout)
+	defer cancel()
+	if ctx != nil {
+		go func() {
+			select {
+			case <-ctx.Dying():
+				cancel()
+			case <-runCtx.Done():
+			}
+		}()
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(runCtx, p.Path, op)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+	if stderr.Len() > 0 {
+		logger.Debugf("storage plugin %q %s: %s", p.Name, op, strings.TrimSpace(stderr.String()))
+	}
+	if runErr != nil {
+		if runCtx.Err() == stdcontext.DeadlineExceeded {
+			return errors.Errorf("storage plugin %q %s timed out after %v", p.Name, op, timeout)
+		}
+		if runCtx.Err() != nil {
+			return errors.Errorf("storage plugin %q %s cancelled", p.Name, op)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return errors.Annotatef(runErr, "running storage plugin %q %s: %s", p.Name, op, msg)
+		}
+		return errors.Annotatef(runErr, "running storage plugin %q %s", p.Name, op)
+	}
+
+	var response Response
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return errors.Annotatef(err, "decoding storage plugin %q %s response", p.Name, op)
+	}
+	if response.Error != nil {
+		if response.Error.Code == CodeInvalidCredential && ctx != nil {
+			if err := ctx.InvalidateCredential(response.Error.Message); err != nil {
+				logger.Errorf("could not invalidate credential: %v", err)
+			}
+		}
+		return errors.Annotatef(toError(response.Error), "storage plugin %q %s", p.Name, op)
+	}
+	if result == nil {
+		return nil
+	}
+	if len(response.Result) == 0 {
+		return errors.Errorf("storage plugin %q %s returned no result", p.Name, op)
+	}
+	if err := json.Unmarshal(response.Result, result); err != nil {
+		return errors.Annotatef(err, "decoding storage plugin %q %s result", p.Name, op)
+	}
+	return nil
+}
+
+// Info returns the description of the plugin's storage provider,
+// checking that the plugin implements a protocol version this package
+// supports.
+func (p *Plugin) Info() (InfoResult, error) {
+	var info InfoResult
+	if err := p.Call(nil, OpInfo, nil, &info); err != nil {
+		return InfoResult{}, errors.Trace(err)
+	}
+	if info.ProtocolVersion != ProtocolVersion {
+		return InfoResult{}, errors.NotSupportedf(
+			"storage plugin %q protocol version %d (expected %d)",
+			p.Name, info.ProtocolVersion, ProtocolVersion,
+		)
+	}
+	// Plugins are only installed on the controllers, so the storage
+	// provisioners of the machines can't run them.
+	switch info.Scope {
+	case "", "environ":
+	case "machine":
+		return InfoResult{}, errors.NotSupportedf("storage plugin %q scope %q", p.Name, info.Scope)
+	default:
+		return InfoResult{}, errors.NotValidf("storage plugin %q scope %q", p.Name, info.Scope)
+	}
+	return info, nil
+}
@@ -0,0 +1,312 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// +build !windows
+
+package plugin_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plugin"
+	coretesting "github.com/juju/juju/testing"
+)
+
+// fakePlugin records each request it is sent, and replies with canned
+// responses.
+const fakePlugin = `#!/bin/sh
+cat > "$0.$1.request"
+case "$1" in
+info)
+	echo '{"result": {"protocol-version": %d, "scope": "%s", "dynamic": true, "releasable": true, "default-pools": [{"name": "fast", "attributes": {"tier": "ssd"}}]}}'
+	;;
+validate-config)
+	echo '{"error": {"message": "tier \"tape\" not valid", "code": "not-valid"}}'
+	;;
+create-volumes)
+	echo '{"result": {"results": [{"volume": {"volume-id": "vol-0", "size": 1024, "persistent": true}, "attachment": {"device-link": "/dev/disk/by-id/vol-0"}}, {"error": {"message": "quota exceeded"}}]}}'
+	;;
+list-volumes)
+	echo '{"result": {"volume-ids": ["vol-0"]}}'
+	;;
+describe-volumes)
+	echo '{"result": {"results": [{"error": {"message": "volume vol-1 not found", "code": "not-found"}}]}}'
+	;;
+destroy-volumes)
+	echo '{"result": {"results": [{}, {}]}}'
+	;;
+*)
+	echo "unknown operation $1" >&2
+	exit 1
+	;;
+esac
+`
+
+type pluginSuite struct {
+	testing.IsolationSuite
+	dir      string
+	registry *plugin.Registry
+}
+
+var _ = gc.Suite(&pluginSuite{})
+
+func (s *pluginSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.writePlugin(c, "netapp", plugin.ProtocolVersion, "environ")
+	s.registry = plugin.NewRegistry(s.dir)
+}
+
+func (s *pluginSuite) writePlugin(c *gc.C, name string, version int, scope string) {
+	script := fmt.Sprintf(fakePlugin, version, scope)
+	err := ioutil.WriteFile(filepath.Join(s.dir, plugin.BinaryName(name)), []byte(script), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *pluginSuite) lastRequest(c *gc.C, name, op string) map[string]interface{} {
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, plugin.BinaryName(name)+"."+op+".request"))
+	c.Assert(err, jc.ErrorIsNil)
+	var request map[string]interface{}
+	err = json.Unmarshal(data, &request)
+	c.Assert(err, jc.ErrorIsNil)
+	return request
+}
+
+func (s *pluginSuite) volumeSource(c *gc.C) storage.VolumeSource {
+	p, err := s.registry.StorageProvider("netapp")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := storage.NewConfig("fast", "netapp", map[string]interface{}{"tier": "ssd"})
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := p.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *pluginSuite) TestStorageProviderTypes(c *gc.C) {
+	s.writePlugin(c, "powerflex", plugin.ProtocolVersion, "environ")
+	types, err := s.registry.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, []storage.ProviderType{"netapp", "powerflex"})
+}
+
+func (s *pluginSuite) TestStorageProvider(c *gc.C) {
+	p, err := s.registry.StorageProvider("netapp")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+	c.Assert(p.Releasable(), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+
+	pools := p.DefaultPools()
+	c.Assert(pools, gc.HasLen, 1)
+	c.Assert(pools[0].Name(), gc.Equals, "fast")
+	c.Assert(pools[0].Provider(), gc.Equals, storage.ProviderType("netapp"))
+	c.Assert(pools[0].Attrs(), jc.DeepEquals, map[string]interface{}{"tier": "ssd"})
+
+	_, err = p.FilesystemSource(pools[0])
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *pluginSuite) TestStorageProviderNotInstalled(c *gc.C) {
+	_, err := s.registry.StorageProvider("powerflex")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.registry.StorageProvider("../netapp")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *pluginSuite) TestStorageProviderNoDirectory(c *gc.C) {
+	registry := plugin.NewRegistry(filepath.Join(s.dir, "lxd"))
+	types, err := registry.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, gc.HasLen, 0)
+}
+
+// configRegistry is a storage.ProviderRegistry with model config, as
+// an environ is.
+type configRegistry struct {
+	storage.StaticProviderRegistry
+	config *config.Config
+}
+
+func (r configRegistry) Config() *config.Config {
+	return r.config
+}
+
+func (s *pluginSuite) TestCloudRegistry(c *gc.C) {
+	pluginDir := c.MkDir()
+	s.PatchValue(&plugin.DefaultDir, pluginDir)
+	s.dir = filepath.Join(pluginDir, "someprovider")
+	err := os.Mkdir(s.dir, 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	s.writePlugin(c, "powerflex", plugin.ProtocolVersion, "environ")
+
+	registry := plugin.CloudRegistry(configRegistry{config: coretesting.ModelConfig(c)})
+	types, err := registry.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, jc.DeepEquals, []storage.ProviderType{"powerflex"})
+
+	registry = plugin.CloudRegistry(storage.StaticProviderRegistry{})
+	types, err = registry.StorageProviderTypes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(types, gc.HasLen, 0)
+}
+
+func (s *pluginSuite) TestStorageProviderProtocolVersion(c *gc.C) {
+	s.writePlugin(c, "powerflex", plugin.ProtocolVersion+1, "environ")
+	_, err := s.registry.StorageProvider("powerflex")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `storage plugin "powerflex" protocol version 2 \(expected 1\) not supported`)
+}
+
+func (s *pluginSuite) TestStorageProviderMachineScope(c *gc.C) {
+	s.writePlugin(c, "powerflex", plugin.ProtocolVersion, "machine")
+	_, err := s.registry.StorageProvider("powerflex")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `storage plugin "powerflex" scope "machine" not supported`)
+}
+
+func (s *pluginSuite) TestValidateConfig(c *gc.C) {
+	p, err := s.registry.StorageProvider("netapp")
+	c.Assert(err, jc.ErrorIsNil)
+	cfg, err := storage.NewConfig("slow", "netapp", map[string]interface{}{"tier": "tape"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `storage plugin "netapp" validate-config: tier "tape" not valid`)
+	c.Assert(s.lastRequest(c, "netapp", plugin.OpValidateConfig), jc.DeepEquals, map[string]interface{}{
+		"config": map[string]interface{}{"tier": "tape"},
+	})
+}
+
+func (s *pluginSuite) TestCreateVolumes(c *gc.C) {
+	source := s.volumeSource(c)
+	results, err := source.CreateVolumes(context.NewCloudCallContext(), []storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     1024,
+		Provider: "netapp",
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				Provider:   "netapp",
+				Machine:    names.NewMachineTag("1"),
+				InstanceId: "inst-1",
+			},
+			Volume: names.NewVolumeTag("0"),
+		},
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		Size:     2048,
+		Provider: "netapp",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumesResult{{
+		Volume: &storage.Volume{
+			Tag: names.NewVolumeTag("0"),
+			VolumeInfo: storage.VolumeInfo{
+				VolumeId:   "vol-0",
+				Size:       1024,
+				Persistent: true,
+			},
+		},
+		VolumeAttachment: &storage.VolumeAttachment{
+			Volume:  names.NewVolumeTag("0"),
+			Machine: names.NewMachineTag("1"),
+			VolumeAttachmentInfo: storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/disk/by-id/vol-0",
+			},
+		},
+	}, {
+		Error: &plugin.Error{Message: "quota exceeded"},
+	}})
+	c.Assert(s.lastRequest(c, "netapp", plugin.OpCreateVolumes), jc.DeepEquals, map[string]interface{}{
+		"config": map[string]interface{}{"tier": "ssd"},
+		"volumes": []interface{}{
+			map[string]interface{}{
+				"tag":  "volume-0",
+				"size": float64(1024),
+				"attachment": map[string]interface{}{
+					"volume":      "volume-0",
+					"machine":     "machine-1",
+					"instance-id": "inst-1",
+				},
+			},
+			map[string]interface{}{
+				"tag":  "volume-1",
+				"size": float64(2048),
+			},
+		},
+	})
+}
+
+func (s *pluginSuite) TestListVolumes(c *gc.C) {
+	volIds, err := s.volumeSource(c).ListVolumes(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volIds, jc.DeepEquals, []string{"vol-0"})
+}
+
+func (s *pluginSuite) TestDescribeVolumesNotFound(c *gc.C) {
+	results, err := s.volumeSource(c).DescribeVolumes(context.NewCloudCallContext(), []string{"vol-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *pluginSuite) TestDestroyVolumes(c *gc.C) {
+	errs, err := s.volumeSource(c).DestroyVolumes(context.NewCloudCallContext(), []string{"vol-0", "vol-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil, nil})
+	c.Assert(s.lastRequest(c, "netapp", plugin.OpDestroyVolumes), jc.DeepEquals, map[string]interface{}{
+		"config":     map[string]interface{}{"tier": "ssd"},
+		"volume-ids": []interface{}{"vol-0", "vol-1"},
+	})
+}
+
+func (s *pluginSuite) TestDestroyVolumesResultCount(c *gc.C) {
+	_, err := s.volumeSource(c).DestroyVolumes(context.NewCloudCallContext(), []string{"vol-0"})
+	c.Assert(err, gc.ErrorMatches, `storage plugin "netapp" destroy-volumes returned 2 results, expected 1`)
+}
+
+func (s *pluginSuite) TestUnknownOperation(c *gc.C) {
+	_, err := s.volumeSource(c).ReleaseVolumes(context.NewCloudCallContext(), []string{"vol-0"})
+	c.Assert(err, gc.ErrorMatches, `running storage plugin "netapp" release-volumes: unknown operation release-volumes: exit status 1`)
+}
+
+func (s *pluginSuite) TestInvalidCredential(c *gc.C) {
+	p := &plugin.Plugin{Name: "netapp", Path: filepath.Join(s.dir, "invalid")}
+	err := ioutil.WriteFile(p.Path, []byte(`#!/bin/sh
+echo '{"error": {"message": "token expired", "code": "invalid-credential"}}'
+`), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var invalidated string
+	ctx := context.NewCloudCallContext()
+	ctx.InvalidateCredentialFunc = func(reason string) error {
+		invalidated = reason
+		return nil
+	}
+	err = p.Call(ctx, plugin.OpListVolumes, plugin.ConfigRequest{}, nil)
+	c.Assert(err, gc.ErrorMatches, `storage plugin "netapp" list-volumes: token expired`)
+	c.Assert(invalidated, gc.Equals, "token expired")
+}
+
+func (s *pluginSuite) TestTimeout(c *gc.C) {
+	p := &plugin.Plugin{Name: "netapp", Path: filepath.Join(s.dir, "slow"), Timeout: 10 * time.Millisecond}
+	err := ioutil.WriteFile(p.Path, []byte("#!/bin/sh\nexec sleep 10\n"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.Call(nil, plugin.OpInfo, nil, nil)
+	c.Assert(err, gc.ErrorMatches, `storage plugin "netapp" info timed out after 10ms`)
+}
@@ -0,0 +1,289 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package plugin implements storage providers backed by external plugin
+// binaries, so that volume sources can be added to Juju without changing
+// it.
+//
+// A plugin named "netapp" is an executable called juju-storage-netapp.
+// It is registered for a cloud by installing it on the controllers, in
+// the storage plugin directory named after the cloud's type, and it adds
+// the storage provider type of the same name to every model on that
+// cloud. Its volumes are managed by the model's storage provisioner.
+//
+// Juju invokes the plugin once for each operation, with the operation
+// name as the only argument. The request is written to the plugin's
+// standard input as JSON, and the plugin must write a Response as JSON
+// to its standard output and exit with status 0. Anything the plugin
+// writes to its standard error is logged. Every request but "info"
+// includes the storage pool's configuration.
+//
+//	info                    InfoResult
+//	validate-config         ConfigRequest, no result
+//	validate-volume-params  ValidateVolumeParamsRequest, no result
+//	create-volumes          CreateVolumesRequest, CreateVolumesResults
+//	list-volumes            ConfigRequest, VolumeIdsResult
+//	describe-volumes        VolumeIdsRequest, DescribeVolumesResults
+//	destroy-volumes         VolumeIdsRequest, ErrorResults
+//	release-volumes         VolumeIdsRequest, ErrorResults
+//	attach-volumes          VolumeAttachmentsRequest, AttachVolumesResults
+//	detach-volumes          VolumeAttachmentsRequest, ErrorResults
+//
+// Results for several volumes must be in the same order as the request's
+// volumes, with an Error for each one that failed.
+package plugin
+
+import (
+	"encoding/json"
+
+	"github.com/juju/errors"
+)
+
+// ProtocolVersion is the version of the plugin protocol implemented by
+// this package. Plugins report the version they implement in their
+// InfoResult.
+const ProtocolVersion = 1
+
+// The operations plugins implement.
+const (
+	OpInfo                 = "info"
+	OpValidateConfig       = "validate-config"
+	OpValidateVolumeParams = "validate-volume-params"
+	OpCreateVolumes        = "create-volumes"
+	OpListVolumes          = "list-volumes"
+	OpDescribeVolumes      = "describe-volumes"
+	OpDestroyVolumes       = "destroy-volumes"
+	OpReleaseVolumes       = "release-volumes"
+	OpAttachVolumes        = "attach-volumes"
+	OpDetachVolumes        = "detach-volumes"
+)
+
+// The error codes plugins may report, which are converted to the
+// corresponding Juju errors.
+const (
+	CodeNotFound          = "not-found"
+	CodeNotSupported      = "not-supported"
+	CodeNotValid          = "not-valid"
+	CodeInvalidCredential = "invalid-credential"
+)
+
+// Response is written by a plugin in reply to every request.
+type Response struct {
+	// Result holds the operation's result, if it succeeded.
+	Result json.RawMessage `json:"result,omitempty"`
+
+	// Error describes why the operation failed, if it did.
+	Error *Error `json:"error,omitempty"`
+}
+
+// Error describes a failed operation, or the failure of an operation on
+// one volume.
+type Error struct {
+	Message string `json:"message"`
+	Code    string `json:"code,omitempty"`
+}
+
+// Error implements error.
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// toError returns the Juju error corresponding to the plugin error, or
+// nil if there is none.
+func toError(e *Error) error {
+	if e == nil {
+		return nil
+	}
+	switch e.Code {
+	case CodeNotFound:
+		return errors.NewNotFound(nil, e.Message)
+	case CodeNotSupported:
+		return errors.NewNotSupported(nil, e.Message)
+	case CodeNotValid:
+		return errors.NewNotValid(nil, e.Message)
+	}
+	return e
+}
+
+// InfoResult describes a plugin's storage provider.
+type InfoResult struct {
+	// ProtocolVersion is the version of the protocol the plugin
+	// implements.
+	ProtocolVersion int `json:"protocol-version"`
+
+	// Scope must be "environ", or empty, as the plugin's volumes are
+	// managed by the model's storage provisioner. Machine scoped
+	// plugins are not supported.
+	Scope string `json:"scope"`
+
+	// Dynamic is true if volumes may be created after the machines
+	// they are attached to.
+	Dynamic bool `json:"dynamic"`
+
+	// Releasable is true if the plugin implements release-volumes.
+	Releasable bool `json:"releasable"`
+
+	// DefaultPools holds the storage pools to create in each new
+	// model.
+	DefaultPools []Pool `json:"default-pools,omitempty"`
+}
+
+// Pool is a storage pool of the plugin's storage provider type.
+type Pool struct {
+	Name       string                 `json:"name"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// ConfigRequest holds the configuration of the storage pool an
+// operation is for.
+type ConfigRequest struct {
+	Config map[string]interface{} `json:"config"`
+}
+
+// ValidateVolumeParamsRequest is the request for validate-volume-params.
+type ValidateVolumeParamsRequest struct {
+	ConfigRequest
+	Volume VolumeParams `json:"volume"`
+}
+
+// CreateVolumesRequest is the request for create-volumes.
+type CreateVolumesRequest struct {
+	ConfigRequest
+	Volumes []VolumeParams `json:"volumes"`
+}
+
+// VolumeIdsRequest is the request for the operations on existing
+// volumes.
+type VolumeIdsRequest struct {
+	ConfigRequest
+	VolumeIds []string `json:"volume-ids"`
+}
+
+// VolumeAttachmentsRequest is the request for attach-volumes and
+// detach-volumes.
+type VolumeAttachmentsRequest struct {
+	ConfigRequest
+	Attachments []VolumeAttachmentParams `json:"attachments"`
+}
+
+// VolumeParams describes a volume to create.
+type VolumeParams struct {
+	// Tag is the tag Juju assigned to the volume.
+	Tag string `json:"tag"`
+
+	// Size is the minimum size of the volume in MiB.
+	Size uint64 `json:"size"`
+
+	// Attributes holds the storage pool's attributes.
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+
+	// ResourceTags holds the tags to set on the volume, if the
+	// storage supports tags.
+	ResourceTags map[string]string `json:"resource-tags,omitempty"`
+
+	// Attachment describes the machine the volume should be
+	// attached to initially, if any.
+	Attachment *VolumeAttachmentParams `json:"attachment,omitempty"`
+}
+
+// VolumeAttachmentParams describes a volume attachment to make or
+// remove.
+type VolumeAttachmentParams struct {
+	// Volume is the tag Juju assigned to the volume.
+	Volume string `json:"volume"`
+
+	// VolumeId is the plugin's id of the volume, if it has been
+	// created.
+	VolumeId string `json:"volume-id,omitempty"`
+
+	// Machine is the tag of the machine.
+	Machine string `json:"machine"`
+
+	// InstanceId is the cloud instance id of the machine, if it has
+	// been provisioned.
+	InstanceId string `json:"instance-id,omitempty"`
+
+	// ReadOnly is true if the volume should be attached read-only.
+	ReadOnly bool `json:"read-only,omitempty"`
+}
+
+// Volume describes a volume created by a plugin.
+type Volume struct {
+	VolumeId   string `json:"volume-id"`
+	HardwareId string `json:"hardware-id,omitempty"`
+	WWN        string `json:"wwn,omitempty"`
+	Size       uint64 `json:"size"`
+	Persistent bool   `json:"persistent"`
+}
+
+// VolumeAttachment describes how a volume is attached to a machine.
+type VolumeAttachment struct {
+	DeviceName string `json:"device-name,omitempty"`
+	DeviceLink string `json:"device-link,omitempty"`
+	BusAddress string `json:"bus-address,omitempty"`
+	ReadOnly   bool   `json:"read-only,omitempty"`
+
+	// Plan describes how the machine agent must initialise the
+	// attached device, if it needs to, such as logging in to an
+	// iSCSI target.
+	Plan *VolumeAttachmentPlan `json:"plan,omitempty"`
+}
+
+// VolumeAttachmentPlan describes how the machine agent must initialise
+// an attached device.
+type VolumeAttachmentPlan struct {
+	// DeviceType is "local" or "iscsi".
+	DeviceType       string            `json:"device-type"`
+	DeviceAttributes map[string]string `json:"device-attributes,omitempty"`
+}
+
+// VolumeIdsResult is the result of list-volumes.
+type VolumeIdsResult struct {
+	VolumeIds []string `json:"volume-ids"`
+}
+
+// CreateVolumesResults is the result of create-volumes.
+type CreateVolumesResults struct {
+	Results []CreateVolumeResult `json:"results"`
+}
+
+// CreateVolumeResult holds the volume created, and how it was attached
+// if the request asked for it to be.
+type CreateVolumeResult struct {
+	Volume     *Volume           `json:"volume,omitempty"`
+	Attachment *VolumeAttachment `json:"attachment,omitempty"`
+	Error      *Error            `json:"error,omitempty"`
+}
+
+// DescribeVolumesResults is the result of describe-volumes.
+type DescribeVolumesResults struct {
+	Results []DescribeVolumeResult `json:"results"`
+}
+
+// DescribeVolumeResult holds the description of a volume.
+type DescribeVolumeResult struct {
+	Volume *Volume `json:"volume,omitempty"`
+	Error  *Error  `json:"error,omitempty"`
+}
+
+// AttachVolumesResults is the result of attach-volumes.
+type AttachVolumesResults struct {
+	Results []AttachVolumeResult `json:"results"`
+}
+
+// AttachVolumeResult holds how a volume was attached.
+type AttachVolumeResult struct {
+	Attachment *VolumeAttachment `json:"attachment,omitempty"`
+	Error      *Error            `json:"error,omitempty"`
+}
+
+// ErrorResults is the result of operations that return only whether
+// each volume succeeded.
+type ErrorResults struct {
+	Results []ErrorResult `json:"results"`
+}
+
+// ErrorResult holds why the operation failed for a volume, if it did.
+type ErrorResult struct {
+	Error *Error `json:"error,omitempty"`
+}
@@ -0,0 +1,302 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+// pluginProvider is a storage.Provider whose volumes are managed by a
+// plugin.
+type pluginProvider struct {
+	plugin *Plugin
+	info   InfoResult
+}
+
+var _ storage.Provider = (*pluginProvider)(nil)
+
+// VolumeSource is part of the storage.Provider interface.
+func (p *pluginProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
+	return &volumeSource{
+		plugin: p.plugin,
+		config: cfg.Attrs(),
+	}, nil
+}
+
+// FilesystemSource is part of the storage.Provider interface.
+func (p *pluginProvider) FilesystemSource(cfg *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is part of the storage.Provider interface. Plugins provide
+// volumes, on which Juju manages any filesystems itself.
+func (p *pluginProvider) Supports(kind storage.StorageKind) bool {
+	return kind == storage.StorageKindBlock
+}
+
+// Scope is part of the storage.Provider interface. Plugin volumes are
+// always managed by the model's storage provisioner.
+func (p *pluginProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is part of the storage.Provider interface.
+func (p *pluginProvider) Dynamic() bool {
+	return p.info.Dynamic
+}
+
+// Releasable is part of the storage.Provider interface.
+func (p *pluginProvider) Releasable() bool {
+	return p.info.Releasable
+}
+
+// DefaultPools is part of the storage.Provider interface.
+func (p *pluginProvider) DefaultPools() []*storage.Config {
+	var pools []*storage.Config
+	for _, pool := range p.info.DefaultPools {
+		cfg, err := storage.NewConfig(pool.Name, storage.ProviderType(p.plugin.Name), pool.Attributes)
+		if err != nil {
+			logger.Warningf("ignoring storage plugin %q pool %q: %v", p.plugin.Name, pool.Name, err)
+			continue
+		}
+		pools = append(pools, cfg)
+	}
+	return pools
+}
+
+// ValidateConfig is part of the storage.Provider interface.
+func (p *pluginProvider) ValidateConfig(cfg *storage.Config) error {
+	return errors.Trace(p.plugin.Call(nil, OpValidateConfig, ConfigRequest{cfg.Attrs()}, nil))
+}
+
+// volumeSource is a storage.VolumeSource that passes each operation to a
+// plugin, along with the storage pool's configuration.
+type volumeSource struct {
+	plugin *Plugin
+	config map[string]interface{}
+}
+
+var _ storage.VolumeSource = (*volumeSource)(nil)
+
+// ValidateVolumeParams is part of the storage.VolumeSource interface.
+func (v *volumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	return errors.Trace(v.plugin.Call(nil, OpValidateVolumeParams, ValidateVolumeParamsRequest{
+		ConfigRequest: ConfigRequest{v.config},
+		Volume:        fromVolumeParams(params),
+	}, nil))
+}
+
+// CreateVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) CreateVolumes(ctx context.ProviderCallContext, params []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	request := CreateVolumesRequest{
+		ConfigRequest: ConfigRequest{v.config},
+		Volumes:       make([]VolumeParams, len(params)),
+	}
+	for i, p := range params {
+		request.Volumes[i] = fromVolumeParams(p)
+	}
+	var response CreateVolumesResults
+	if err := v.plugin.Call(ctx, OpCreateVolumes, request, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := v.checkResults(OpCreateVolumes, len(response.Results), len(params)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]storage.CreateVolumesResult, len(params))
+	for i, result := range response.Results {
+		if result.Error != nil || result.Volume == nil {
+			results[i].Error = v.volumeError(result.Error)
+			continue
+		}
+		results[i].Volume = &storage.Volume{
+			Tag:        params[i].Tag,
+			VolumeInfo: toVolumeInfo(result.Volume),
+		}
+		if result.Attachment != nil && params[i].Attachment != nil {
+			results[i].VolumeAttachment = &storage.VolumeAttachment{
+				Volume:               params[i].Tag,
+				Machine:              params[i].Attachment.Machine,
+				VolumeAttachmentInfo: toVolumeAttachmentInfo(result.Attachment),
+			}
+		}
+	}
+	return results, nil
+}
+
+// ListVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, error) {
+	var response VolumeIdsResult
+	if err := v.plugin.Call(ctx, OpListVolumes, ConfigRequest{v.config}, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return response.VolumeIds, nil
+}
+
+// DescribeVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DescribeVolumes(ctx context.ProviderCallContext, volIds []string) ([]storage.DescribeVolumesResult, error) {
+	var response DescribeVolumesResults
+	if err := v.plugin.Call(ctx, OpDescribeVolumes, v.volumeIdsRequest(volIds), &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := v.checkResults(OpDescribeVolumes, len(response.Results), len(volIds)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]storage.DescribeVolumesResult, len(volIds))
+	for i, result := range response.Results {
+		if result.Error != nil || result.Volume == nil {
+			results[i].Error = v.volumeError(result.Error)
+			continue
+		}
+		info := toVolumeInfo(result.Volume)
+		results[i].VolumeInfo = &info
+	}
+	return results, nil
+}
+
+// DestroyVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DestroyVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
+	return v.callErrorResults(ctx, OpDestroyVolumes, v.volumeIdsRequest(volIds), len(volIds))
+}
+
+// ReleaseVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volIds []string) ([]error, error) {
+	return v.callErrorResults(ctx, OpReleaseVolumes, v.volumeIdsRequest(volIds), len(volIds))
+}
+
+// AttachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) AttachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	var response AttachVolumesResults
+	if err := v.plugin.Call(ctx, OpAttachVolumes, v.attachmentsRequest(params), &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := v.checkResults(OpAttachVolumes, len(response.Results), len(params)); err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]storage.AttachVolumesResult, len(params))
+	for i, result := range response.Results {
+		if result.Error != nil || result.Attachment == nil {
+			results[i].Error = v.volumeError(result.Error)
+			continue
+		}
+		results[i].VolumeAttachment = &storage.VolumeAttachment{
+			Volume:               params[i].Volume,
+			Machine:              params[i].Machine,
+			VolumeAttachmentInfo: toVolumeAttachmentInfo(result.Attachment),
+		}
+	}
+	return results, nil
+}
+
+// DetachVolumes is part of the storage.VolumeSource interface.
+func (v *volumeSource) DetachVolumes(ctx context.ProviderCallContext, params []storage.VolumeAttachmentParams) ([]error, error) {
+	return v.callErrorResults(ctx, OpDetachVolumes, v.attachmentsRequest(params), len(params))
+}
+
+func (v *volumeSource) volumeIdsRequest(volIds []string) VolumeIdsRequest {
+	return VolumeIdsRequest{
+		ConfigRequest: ConfigRequest{v.config},
+		VolumeIds:     volIds,
+	}
+}
+
+func (v *volumeSource) attachmentsRequest(params []storage.VolumeAttachmentParams) VolumeAttachmentsRequest {
+	request := VolumeAttachmentsRequest{
+		ConfigRequest: ConfigRequest{v.config},
+		Attachments:   make([]VolumeAttachmentParams, len(params)),
+	}
+	for i, p := range params {
+		request.Attachments[i] = fromVolumeAttachmentParams(p)
+	}
+	return request
+}
+
+func (v *volumeSource) callErrorResults(ctx context.ProviderCallContext, op string, request interface{}, n int) ([]error, error) {
+	var response ErrorResults
+	if err := v.plugin.Call(ctx, op, request, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := v.checkResults(op, len(response.Results), n); err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]error, n)
+	for i, result := range response.Results {
+		if result.Error != nil {
+			results[i] = v.volumeError(result.Error)
+		}
+	}
+	return results, nil
+}
+
+func (v *volumeSource) checkResults(op string, got, expected int) error {
+	if got != expected {
+		return errors.Errorf("storage plugin %q %s returned %d results, expected %d", v.plugin.Name, op, got, expected)
+	}
+	return nil
+}
+
+// volumeError returns the error for a volume whose result had neither
+// a value nor an error.
+func (v *volumeSource) volumeError(e *Error) error {
+	if e == nil {
+		return errors.Errorf("storage plugin %q returned an empty result", v.plugin.Name)
+	}
+	return toError(e)
+}
+
+func fromVolumeParams(p storage.VolumeParams) VolumeParams {
+	result := VolumeParams{
+		Tag:          p.Tag.String(),
+		Size:         p.Size,
+		Attributes:   p.Attributes,
+		ResourceTags: p.ResourceTags,
+	}
+	if p.Attachment != nil {
+		attachment := fromVolumeAttachmentParams(*p.Attachment)
+		result.Attachment = &attachment
+	}
+	return result
+}
+
+func fromVolumeAttachmentParams(p storage.VolumeAttachmentParams) VolumeAttachmentParams {
+	var machine string
+	if p.Machine != nil {
+		machine = p.Machine.String()
+	}
+	return VolumeAttachmentParams{
+		Volume:     p.Volume.String(),
+		VolumeId:   p.VolumeId,
+		Machine:    machine,
+		InstanceId: string(p.InstanceId),
+		ReadOnly:   p.ReadOnly,
+	}
+}
+
+func toVolumeInfo(v *Volume) storage.VolumeInfo {
+	return storage.VolumeInfo{
+		VolumeId:   v.VolumeId,
+		HardwareId: v.HardwareId,
+		WWN:        v.WWN,
+		Size:       v.Size,
+		Persistent: v.Persistent,
+	}
+}
+
+func toVolumeAttachmentInfo(a *VolumeAttachment) storage.VolumeAttachmentInfo {
+	info := storage.VolumeAttachmentInfo{
+		DeviceName: a.DeviceName,
+		DeviceLink: a.DeviceLink,
+		BusAddress: a.BusAddress,
+		ReadOnly:   a.ReadOnly,
+	}
+	if a.Plan != nil {
+		info.PlanInfo = &storage.VolumeAttachmentPlanInfo{
+			DeviceType:       storage.DeviceType(a.Plan.DeviceType),
+			DeviceAttributes: a.Plan.DeviceAttributes,
+		}
+	}
+	return info
+}
@@ -0,0 +1,152 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package plugin
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/os/series"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/juju/paths"
+	"github.com/juju/juju/storage"
+)
+
+// DefaultDir is the directory on the controllers that storage plugins
+// are installed in. The plugins for a cloud are installed in the
+// subdirectory named after the cloud's type.
+var DefaultDir = filepath.Join(paths.MustSucceed(paths.DataDir(series.MustHostSeries())), "storage-plugins")
+
+// binaryPrefix is the prefix of the names of plugin binaries.
+const binaryPrefix = "juju-storage-"
+
+// validName matches the names of storage provider plugins, which are
+// also the names of the storage provider types they add.
+var validName = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+// BinaryName returns the name of the binary of the named plugin.
+func BinaryName(name string) string {
+	return binaryPrefix + name
+}
+
+// Registry is a storage.ProviderRegistry holding the storage provider
+// plugins installed in a directory. Each plugin is asked to describe
+// itself when it is first used, and again whenever its binary is
+// replaced.
+type Registry struct {
+	dir string
+
+	mu        sync.Mutex
+	providers map[storage.ProviderType]*registeredProvider
+}
+
+// registeredProvider holds a plugin's provider and the modification
+// time of the binary that described it.
+type registeredProvider struct {
+	provider *pluginProvider
+	modTime  time.Time
+}
+
+var _ storage.ProviderRegistry = (*Registry)(nil)
+
+// NewRegistry returns a registry of the plugins installed in dir. The
+// directory is read each time the registry is used, so that plugins
+// may be installed and removed while it is in use.
+func NewRegistry(dir string) *Registry {
+	return &Registry{
+		dir:       dir,
+		providers: make(map[storage.ProviderType]*registeredProvider),
+	}
+}
+
+// configGetter is implemented by registries, such as environs, that
+// have model config.
+type configGetter interface {
+	Config() *config.Config
+}
+
+// CloudRegistry returns a registry of the plugins registered for the
+// cloud of reg, which are those installed in the directory beneath
+// DefaultDir named after the cloud's type. If reg has no model config,
+// the returned registry is empty.
+func CloudRegistry(reg storage.ProviderRegistry) storage.ProviderRegistry {
+	getter, ok := reg.(configGetter)
+	if !ok || getter.Config() == nil {
+		return storage.StaticProviderRegistry{}
+	}
+	return NewRegistry(filepath.Join(DefaultDir, getter.Config().Type()))
+}
+
+// StorageProviderTypes is part of the storage.ProviderRegistry interface.
+func (r *Registry) StorageProviderTypes() ([]storage.ProviderType, error) {
+	names, err := r.installed()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	types := make([]storage.ProviderType, len(names))
+	for i, name := range names {
+		types[i] = storage.ProviderType(name)
+	}
+	return types, nil
+}
+
+// StorageProvider is part of the storage.ProviderRegistry interface.
+func (r *Registry) StorageProvider(t storage.ProviderType) (storage.Provider, error) {
+	if !validName.MatchString(string(t)) {
+		return nil, errors.NotFoundf("storage provider %q", t)
+	}
+	path := filepath.Join(r.dir, BinaryName(string(t)))
+	fi, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, errors.NotFoundf("storage provider %q", t)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if registered, ok := r.providers[t]; ok && registered.modTime.Equal(fi.ModTime()) {
+		return registered.provider, nil
+	}
+	plugin := &Plugin{Name: string(t), Path: path}
+	info, err := plugin.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider := &pluginProvider{plugin: plugin, info: info}
+	r.providers[t] = &registeredProvider{
+		provider: provider,
+		modTime:  fi.ModTime(),
+	}
+	return provider, nil
+}
+
+// installed returns the sorted names of the plugins installed in the
+// registry's directory. A missing directory has no plugins.
+func (r *Registry) installed() ([]string, error) {
+	infos, err := ioutil.ReadDir(r.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "reading storage plugin directory")
+	}
+	var names []string
+	for _, fi := range infos {
+		name := strings.TrimPrefix(fi.Name(), binaryPrefix)
+		if fi.IsDir() || name == fi.Name() || !validName.MatchString(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
@@ -14,8 +14,12 @@ var (
 
 	commonStorageProviders = map[storage.ProviderType]storage.Provider{
 		LoopProviderType:   &loopProvider{logAndExec},
+		LVMProviderType:    &lvmProvider{logAndExec},
+		NFSProviderType:    &nfsProvider{logAndExec},
+		RBDProviderType:    &rbdProvider{logAndExec},
 		RootfsProviderType: &rootfsProvider{logAndExec},
 		TmpfsProviderType:  &tmpfsProvider{logAndExec},
+		ZFSProviderType:    &zfsProvider{logAndExec},
 	}
 )
 
@@ -34,8 +34,12 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 	}
 	c.Assert(common, jc.SameContents, []storage.ProviderType{
 		provider.LoopProviderType,
+		provider.LVMProviderType,
+		provider.NFSProviderType,
+		provider.RBDProviderType,
 		provider.RootfsProviderType,
 		provider.TmpfsProviderType,
+		provider.ZFSProviderType,
 	})
 }
 
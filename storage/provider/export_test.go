@@ -5,6 +5,7 @@ package provider
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -139,3 +140,58 @@ func TmpfsFilesystemSource(etcDir, storageDir string, run func(string, ...string
 func TmpfsProvider(run func(string, ...string) (string, error)) storage.Provider {
 	return &tmpfsProvider{run}
 }
+
+func LVMVolumeSource(volumeGroup string, run func(string, ...string) (string, error)) storage.VolumeSource {
+	return &lvmVolumeSource{run, volumeGroup}
+}
+
+func LVMProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &lvmProvider{run}
+}
+
+func ZFSFilesystemSource(pool string, run func(string, ...string) (string, error)) (storage.FilesystemSource, *MockDirFuncs) {
+	d := &MockDirFuncs{
+		osDirFuncs{run},
+		"",
+		set.NewStrings(),
+	}
+	return &zfsFilesystemSource{d, run, pool}, d
+}
+
+func ZFSProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &zfsProvider{run}
+}
+
+func RBDVolumeSource(storageDir string, run func(string, ...string) (string, error)) (storage.VolumeSource, *MockDirFuncs) {
+	d := &MockDirFuncs{
+		osDirFuncs{run},
+		"",
+		set.NewStrings(),
+	}
+	return &rbdVolumeSource{
+		dirFuncs:    d,
+		run:         run,
+		monHosts:    "10.0.0.1,10.0.0.2",
+		user:        "juju",
+		keyring:     "AQBdHWBckey==",
+		keyringPath: filepath.Join(storageDir, "ceph.keyring"),
+		pool:        "rbd",
+	}, d
+}
+
+func RBDProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &rbdProvider{run}
+}
+
+func NFSFilesystemSource(etcDir, spec string, run func(string, ...string) (string, error)) (storage.FilesystemSource, *MockDirFuncs) {
+	d := &MockDirFuncs{
+		osDirFuncs{run},
+		etcDir,
+		set.NewStrings(),
+	}
+	return &nfsFilesystemSource{d, run, spec}, d
+}
+
+func NFSProvider(run func(string, ...string) (string, error)) storage.Provider {
+	return &nfsProvider{run}
+}
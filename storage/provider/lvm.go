@@ -0,0 +1,301 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	LVMProviderType = storage.ProviderType("lvm")
+
+	// LVMVolumeGroup is the name of the storage pool config attribute
+	// naming the LVM volume group in which logical volumes are created.
+	LVMVolumeGroup = "volume-group"
+
+	// lvmVolumeNamePrefix is the prefix of the names of the logical
+	// volumes created by the LVM provider.
+	lvmVolumeNamePrefix = "juju-"
+)
+
+// lvmProvider creates volume sources which use LVM logical volumes,
+// carved out of an existing volume group on the machine.
+type lvmProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var _ storage.Provider = (*lvmProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*lvmProvider) ValidateConfig(cfg *storage.Config) error {
+	vg, ok := cfg.ValueString(LVMVolumeGroup)
+	if !ok || vg == "" {
+		return errors.Errorf("%s not specified", LVMVolumeGroup)
+	}
+	if strings.ContainsAny(vg, "/ ") {
+		return errors.NotValidf("%s %q", LVMVolumeGroup, vg)
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *lvmProvider) VolumeSource(sourceConfig *storage.Config) (storage.VolumeSource, error) {
+	if err := p.ValidateConfig(sourceConfig); err != nil {
+		return nil, err
+	}
+	// The volume group is validated by ValidateConfig.
+	vg, _ := sourceConfig.ValueString(LVMVolumeGroup)
+	return &lvmVolumeSource{p.run, vg}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (*lvmProvider) FilesystemSource(providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*lvmProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*lvmProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*lvmProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*lvmProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*lvmProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// lvmVolumeSource is a volume source whose volumes are logical volumes
+// in an LVM volume group. The IDs of the volumes are the paths of the
+// logical volumes within LVM, i.e. "<volume-group>/<logical-volume>".
+type lvmVolumeSource struct {
+	run         runCommandFunc
+	volumeGroup string
+}
+
+var _ storage.VolumeSource = (*lvmVolumeSource)(nil)
+
+func (s *lvmVolumeSource) volumeId(tag names.VolumeTag) string {
+	return s.volumeGroup + "/" + lvmVolumeNamePrefix + tag.String()
+}
+
+// CreateVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := s.createVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume")
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) createVolume(params storage.VolumeParams) (*storage.Volume, error) {
+	volumeId := s.volumeId(params.Tag)
+	size, err := s.volumeSize(volumeId)
+	if errors.IsNotFound(err) {
+		// The logical volume name is the part of the ID after
+		// the volume group.
+		lvName := volumeId[len(s.volumeGroup)+1:]
+		if _, err := s.run(
+			"lvcreate", "--yes",
+			"-L", fmt.Sprintf("%dm", params.Size),
+			"-n", lvName, s.volumeGroup,
+		); err != nil {
+			return nil, errors.Annotatef(err, "creating logical volume %q", volumeId)
+		}
+		size = params.Size
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else {
+		// The logical volume was created by an earlier attempt.
+		logger.Debugf("logical volume %q already exists", volumeId)
+	}
+	return &storage.Volume{
+		params.Tag,
+		storage.VolumeInfo{
+			VolumeId: volumeId,
+			Size:     size,
+		},
+	}, nil
+}
+
+// volumeSize returns the size, in MiB, of the logical volume with the
+// given ID, or an error satisfying errors.IsNotFound if there is no such
+// logical volume.
+func (s *lvmVolumeSource) volumeSize(volumeId string) (uint64, error) {
+	out, err := s.run(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m",
+		"-o", "lv_size", volumeId,
+	)
+	if err != nil {
+		if isLVNotFound(err) {
+			return 0, errors.NotFoundf("logical volume %q", volumeId)
+		}
+		return 0, errors.Annotatef(err, "querying logical volume %q", volumeId)
+	}
+	size, err := strconv.ParseFloat(strings.TrimSpace(out), 64)
+	if err != nil {
+		return 0, errors.Annotatef(err, "parsing size of logical volume %q", volumeId)
+	}
+	return uint64(size), nil
+}
+
+// isLVNotFound reports whether the error is that of an LVM command
+// referring to a logical volume that does not exist.
+func isLVNotFound(err error) bool {
+	return strings.Contains(err.Error(), "Failed to find logical volume")
+}
+
+// ListVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, error) {
+	out, err := s.run("lvs", "--noheadings", "-o", "lv_name", s.volumeGroup)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing logical volumes in %q", s.volumeGroup)
+	}
+	var volumeIds []string
+	for _, line := range strings.Split(out, "\n") {
+		lvName := strings.TrimSpace(line)
+		if !strings.HasPrefix(lvName, lvmVolumeNamePrefix) {
+			// Only list the logical volumes created by Juju.
+			continue
+		}
+		volumeIds = append(volumeIds, s.volumeGroup+"/"+lvName)
+	}
+	return volumeIds, nil
+}
+
+// DescribeVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DescribeVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]storage.DescribeVolumesResult, error) {
+	results := make([]storage.DescribeVolumesResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		size, err := s.volumeSize(volumeId)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: volumeId,
+			Size:     size,
+		}
+	}
+	return results, nil
+}
+
+// DestroyVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DestroyVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
+	results := make([]error, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		if err := s.destroyVolume(volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) destroyVolume(volumeId string) error {
+	if !strings.HasPrefix(volumeId, s.volumeGroup+"/"+lvmVolumeNamePrefix) {
+		return errors.Errorf("invalid LVM volume ID %q", volumeId)
+	}
+	if _, err := s.run("lvremove", "--yes", volumeId); err != nil && !isLVNotFound(err) {
+		return errors.Annotate(err, "removing logical volume")
+	}
+	return nil
+}
+
+// ReleaseVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
+	return make([]error, len(volumeIds)), nil
+}
+
+// ValidateVolumeParams is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	// ValidateVolumeParams may be called on a machine other than the
+	// machine where the logical volume will be created, so we cannot
+	// check the free space in the volume group until CreateVolumes.
+	return nil
+}
+
+// AttachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) AttachVolumes(ctx context.ProviderCallContext, args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	volumeId := s.volumeId(arg.Volume)
+	// Activating the logical volume creates its device node,
+	// and the /dev/<volume-group>/<logical-volume> link to it.
+	if _, err := s.run("lvchange", "--activate", "y", volumeId); err != nil {
+		return nil, errors.Annotatef(err, "activating logical volume %q", volumeId)
+	}
+	permission := "rw"
+	if arg.ReadOnly {
+		permission = "r"
+	}
+	if _, err := s.run("lvchange", "--permission", permission, volumeId); err != nil && !isLVPermissionUnchanged(err) {
+		return nil, errors.Annotatef(err, "setting permission of logical volume %q", volumeId)
+	}
+	return &storage.VolumeAttachment{
+		arg.Volume,
+		arg.Machine,
+		storage.VolumeAttachmentInfo{
+			DeviceLink: "/dev/" + volumeId,
+			ReadOnly:   arg.ReadOnly,
+		},
+	}, nil
+}
+
+// isLVPermissionUnchanged reports whether the error is that of lvchange
+// refusing to set the permission a logical volume already has.
+func isLVPermissionUnchanged(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "is already read only") ||
+		strings.Contains(msg, "is already writable")
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DetachVolumes(ctx context.ProviderCallContext, args []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		volumeId := s.volumeId(arg.Volume)
+		if _, err := s.run("lvchange", "--activate", "n", volumeId); err != nil && !isLVNotFound(err) {
+			results[i] = errors.Annotatef(err, "detaching volume %s", arg.Volume.Id())
+		}
+	}
+	return results, nil
+}
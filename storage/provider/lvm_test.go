@@ -0,0 +1,248 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&lvmSuite{})
+
+type lvmSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+
+	callCtx context.ProviderCallContext
+}
+
+func (s *lvmSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *lvmSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *lvmSuite) lvmProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.LVMProvider(s.commands.run)
+}
+
+func (s *lvmSuite) lvmVolumeSource(c *gc.C) storage.VolumeSource {
+	s.commands = &mockRunCommand{c: c}
+	return provider.LVMVolumeSource("vg0", s.commands.run)
+}
+
+func (s *lvmSuite) TestVolumeSource(c *gc.C) {
+	p := s.lvmProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "volume-group not specified")
+	cfg, err = storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{
+		"volume-group": "vg0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *lvmSuite) TestValidateConfig(c *gc.C) {
+	p := s.lvmProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{
+		"volume-group": "vg0/lv",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `volume-group "vg0/lv" not valid`)
+}
+
+func (s *lvmSuite) TestFilesystemSource(c *gc.C) {
+	p := s.lvmProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, map[string]interface{}{
+		"volume-group": "vg0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "filesystems not supported")
+}
+
+func (s *lvmSuite) TestSupports(c *gc.C) {
+	p := s.lvmProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *lvmSuite) TestScope(c *gc.C) {
+	p := s.lvmProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *lvmSuite) TestCreateVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "vg0/juju-volume-0")
+	cmd.respond("", errors.New(`Failed to find logical volume "vg0/juju-volume-0"`))
+	s.commands.expect("lvcreate", "--yes", "-L", "1024m", "-n", "juju-volume-0", "vg0")
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumesResult{{
+		Volume: &storage.Volume{
+			Tag: names.NewVolumeTag("0"),
+			VolumeInfo: storage.VolumeInfo{
+				VolumeId: "vg0/juju-volume-0",
+				Size:     1024,
+			},
+		},
+	}})
+}
+
+func (s *lvmSuite) TestCreateVolumesExisting(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "vg0/juju-volume-0")
+	cmd.respond("  1028.00\n", nil)
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].Volume.VolumeInfo, jc.DeepEquals, storage.VolumeInfo{
+		VolumeId: "vg0/juju-volume-0",
+		Size:     1028,
+	})
+}
+
+func (s *lvmSuite) TestCreateVolumesError(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "vg0/juju-volume-0")
+	cmd.respond("", errors.New(`Failed to find logical volume "vg0/juju-volume-0"`))
+	cmd = s.commands.expect("lvcreate", "--yes", "-L", "1024m", "-n", "juju-volume-0", "vg0")
+	cmd.respond("", errors.New("Volume group \"vg0\" has insufficient free space"))
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating volume: creating logical volume "vg0/juju-volume-0": .* insufficient free space`)
+}
+
+func (s *lvmSuite) TestListVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	cmd := s.commands.expect("lvs", "--noheadings", "-o", "lv_name", "vg0")
+	cmd.respond("  juju-volume-0\n  root\n  juju-volume-1\n", nil)
+
+	volumeIds, err := source.ListVolumes(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, jc.DeepEquals, []string{"vg0/juju-volume-0", "vg0/juju-volume-1"})
+}
+
+func (s *lvmSuite) TestDescribeVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "vg0/juju-volume-0")
+	cmd.respond("  512.00\n", nil)
+	cmd = s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_size", "vg0/juju-volume-1")
+	cmd.respond("", errors.New(`Failed to find logical volume "vg0/juju-volume-1"`))
+
+	results, err := source.DescribeVolumes(s.callCtx, []string{"vg0/juju-volume-0", "vg0/juju-volume-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.DescribeVolumesResult{
+		VolumeInfo: &storage.VolumeInfo{VolumeId: "vg0/juju-volume-0", Size: 512},
+	})
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *lvmSuite) TestDestroyVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.commands.expect("lvremove", "--yes", "vg0/juju-volume-0")
+	cmd := s.commands.expect("lvremove", "--yes", "vg0/juju-volume-1")
+	cmd.respond("", errors.New(`Failed to find logical volume "vg0/juju-volume-1"`))
+
+	results, err := source.DestroyVolumes(s.callCtx, []string{
+		"vg0/juju-volume-0", "vg0/juju-volume-1", "vg0/root",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], jc.ErrorIsNil)
+	c.Assert(results[2], gc.ErrorMatches, `destroying "vg0/root": invalid LVM volume ID "vg0/root"`)
+}
+
+func (s *lvmSuite) TestAttachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.commands.expect("lvchange", "--activate", "y", "vg0/juju-volume-0")
+	s.commands.expect("lvchange", "--permission", "rw", "vg0/juju-volume-0")
+	s.commands.expect("lvchange", "--activate", "y", "vg0/juju-volume-1")
+	cmd := s.commands.expect("lvchange", "--permission", "r", "vg0/juju-volume-1")
+	cmd.respond("", errors.New(`Logical volume "juju-volume-1" is already read only.`))
+
+	results, err := source.AttachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume: names.NewVolumeTag("0"),
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}, {
+		Volume: names.NewVolumeTag("1"),
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/vg0/juju-volume-0",
+			},
+		},
+	}, {
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("1"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/vg0/juju-volume-1",
+				ReadOnly:   true,
+			},
+		},
+	}})
+}
+
+func (s *lvmSuite) TestDetachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c)
+	s.commands.expect("lvchange", "--activate", "n", "vg0/juju-volume-0")
+	cmd := s.commands.expect("lvchange", "--activate", "n", "vg0/juju-volume-1")
+	cmd.respond("", errors.New("device busy"))
+
+	results, err := source.DetachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume: names.NewVolumeTag("0"),
+	}, {
+		Volume: names.NewVolumeTag("1"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, "detaching volume 1: device busy")
+}
@@ -5,6 +5,7 @@ package provider
 
 import (
 	"bufio"
+	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
@@ -59,7 +60,8 @@ func (s *managedFilesystemSource) ValidateFilesystemParams(arg storage.Filesyste
 	// may be called when the backing volume is detached from the machine.
 	// We must not perform any validation here that would fail if the
 	// volume is detached.
-	return nil
+	_, err := storage.ParseFilesystemOptions(arg.Attributes)
+	return errors.Trace(err)
 }
 
 func (s *managedFilesystemSource) backingVolumeBlockDevice(v names.VolumeTag) (storage.BlockDevice, error) {
@@ -87,6 +89,10 @@ func (s *managedFilesystemSource) CreateFilesystems(ctx context.ProviderCallCont
 }
 
 func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams) (*storage.Filesystem, error) {
+	opts, err := storage.ParseFilesystemOptions(arg.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	blockDevice, err := s.backingVolumeBlockDevice(arg.Volume)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -101,7 +107,7 @@ func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams)
 		}
 		devicePath = partitionDevicePath(devicePath)
 	}
-	if err := createFilesystem(s.run, devicePath); err != nil {
+	if err := createFilesystem(s.run, devicePath, opts.Type); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &storage.Filesystem{
@@ -142,6 +148,10 @@ func (s *managedFilesystemSource) AttachFilesystems(ctx context.ProviderCallCont
 }
 
 func (s *managedFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	opts, err := storage.ParseFilesystemOptions(arg.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	filesystem, ok := s.filesystems[arg.Filesystem]
 	if !ok {
 		return nil, errors.Errorf("filesystem %v is not yet provisioned", arg.Filesystem.Id())
@@ -154,7 +164,12 @@ func (s *managedFilesystemSource) attachFilesystem(arg storage.FilesystemAttachm
 	if isDiskDevice(devicePath) {
 		devicePath = partitionDevicePath(devicePath)
 	}
-	if err := mountFilesystem(s.run, s.dirFuncs, devicePath, arg.Path, arg.ReadOnly); err != nil {
+	if err := mountFilesystem(s.run, s.dirFuncs, devicePath, arg.Path, arg.ReadOnly, opts.MountOptions); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The ownership of the filesystem is set even if it was already
+	// mounted, in case setting it failed after mounting it.
+	if err := setFilesystemOwnership(s.run, arg.Path, opts); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &storage.FilesystemAttachment{
@@ -196,9 +211,12 @@ func createPartition(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
-func createFilesystem(run runCommandFunc, devicePath string) error {
+func createFilesystem(run runCommandFunc, devicePath, fsType string) error {
 	logger.Debugf("attempting to create filesystem on %q", devicePath)
-	mkfscmd := "mkfs." + defaultFilesystemType
+	if fsType == "" {
+		fsType = defaultFilesystemType
+	}
+	mkfscmd := "mkfs." + fsType
 	_, err := run(mkfscmd, devicePath)
 	if err != nil {
 		return errors.Annotatef(err, "%s failed", mkfscmd)
@@ -207,7 +225,7 @@ func createFilesystem(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
-func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoint string, readOnly bool) error {
+func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoint string, readOnly bool, mountOptions []string) error {
 	logger.Debugf("attempting to mount filesystem on %q at %q", devicePath, mountPoint)
 	if err := dirFuncs.mkDirAll(mountPoint, 0755); err != nil {
 		return errors.Annotate(err, "creating mount point")
@@ -220,9 +238,14 @@ func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoi
 		logger.Debugf("filesystem on %q already mounted at %q", mountSource, mountPoint)
 		return nil
 	}
-	var args []string
+	var options []string
 	if readOnly {
-		args = append(args, "-o", "ro")
+		options = append(options, "ro")
+	}
+	options = append(options, mountOptions...)
+	var args []string
+	if len(options) > 0 {
+		args = append(args, "-o", strings.Join(options, ","))
 	}
 	args = append(args, devicePath, mountPoint)
 	if _, err := run("mount", args...); err != nil {
@@ -243,6 +266,30 @@ func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoi
 	return addFstabEntry(etcDir, devicePath, mountPoint, mtabEntry)
 }
 
+// setFilesystemOwnership sets the owner and permission mode of the root
+// directory of the filesystem mounted at the specified mount point, as
+// specified in the filesystem options.
+func setFilesystemOwnership(run runCommandFunc, mountPoint string, opts storage.FilesystemOptions) error {
+	if opts.UID >= 0 || opts.GID >= 0 {
+		var owner string
+		if opts.UID >= 0 {
+			owner = fmt.Sprint(opts.UID)
+		}
+		if opts.GID >= 0 {
+			owner += fmt.Sprintf(":%d", opts.GID)
+		}
+		if _, err := run("chown", owner, mountPoint); err != nil {
+			return errors.Annotate(err, "chown failed")
+		}
+	}
+	if opts.Mode != 0 {
+		if _, err := run("chmod", fmt.Sprintf("%04o", uint32(opts.Mode)), mountPoint); err != nil {
+			return errors.Annotate(err, "chmod failed")
+		}
+	}
+	return nil
+}
+
 // extractMtabEntry returns any /etc/mtab entry for the specified
 // device path and mount point, or "" if none exists.
 func extractMtabEntry(etcDir string, devicePath, mountPoint string) (string, error) {
@@ -121,6 +121,41 @@ func (s *managedfsSuite) TestCreateFilesystemsNoBlockDevice(c *gc.C) {
 	c.Assert(results[0].Error, gc.ErrorMatches, "backing-volume 0 is not yet attached")
 }
 
+func (s *managedfsSuite) TestCreateFilesystemsType(c *gc.C) {
+	source := s.initSource(c)
+	s.commands.expect("mkfs.xfs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		HardwareId: "weetbix",
+		Size:       3,
+	}
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		Size:   3,
+		Attributes: map[string]interface{}{
+			"filesystem-type": "xfs",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *managedfsSuite) TestCreateFilesystemsInvalidType(c *gc.C) {
+	source := s.initSource(c)
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		Size:   3,
+		Attributes: map[string]interface{}{
+			"filesystem-type": "btrfs",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, `filesystem-type "btrfs", .* not valid`)
+}
+
 const testMountPoint = "/in/the/place"
 
 func (s *managedfsSuite) TestAttachFilesystems(c *gc.C) {
@@ -159,6 +194,78 @@ func (s *managedfsSuite) TestAttachFilesystemsReattach(c *gc.C) {
 	s.testAttachFilesystems(c, true, true, mtabEntry, "")
 }
 
+func (s *managedfsSuite) TestAttachFilesystemsOptions(c *gc.C) {
+	source := s.initSource(c)
+	cmd := s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	cmd = s.commands.expect("df", "--output=source", testMountPoint)
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	s.commands.expect("mount", "-o", "ro,noatime,nodev", "/dev/sda1", testMountPoint)
+	s.commands.expect("chown", "1000:100", testMountPoint)
+	s.commands.expect("chmod", "0750", testMountPoint)
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		HardwareId: "capncrunch",
+		Size:       2,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/0"),
+		FilesystemId: "filesystem-0-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+			ReadOnly:   true,
+		},
+		Path: testMountPoint,
+		Attributes: map[string]interface{}{
+			"mount-options": "noatime,nodev",
+			"uid":           "1000",
+			"gid":           "100",
+			"mode":          "0750",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *managedfsSuite) TestAttachFilesystemsReattachSetsOwnership(c *gc.C) {
+	source := s.initSource(c)
+	cmd := s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	cmd = s.commands.expect("df", "--output=source", testMountPoint)
+	cmd.respond("headers\n/different/to/rootfs", nil)
+	s.commands.expect("chown", ":100", testMountPoint)
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		HardwareId: "capncrunch",
+		Size:       2,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0/0"),
+		FilesystemId: "filesystem-0-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+		},
+		Path: testMountPoint,
+		Attributes: map[string]interface{}{
+			"gid": "100",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
 func (s *managedfsSuite) testAttachFilesystems(c *gc.C, readOnly, reattach bool, mtab, fstab string) {
 	source := s.initSource(c)
 	cmd := s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
@@ -0,0 +1,210 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	NFSProviderType = storage.ProviderType("nfs")
+
+	// NFSServer is the name of the storage pool config attribute
+	// holding the address of the NFS server exporting the filesystems.
+	NFSServer = "nfs-server"
+
+	// NFSExport is the name of the storage pool config attribute
+	// holding the absolute path of the directory exported by the NFS
+	// server.
+	NFSExport = "nfs-export"
+)
+
+// nfsProvider creates filesystem sources which mount a directory
+// exported by an existing NFS server.
+type nfsProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var _ storage.Provider = (*nfsProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*nfsProvider) ValidateConfig(cfg *storage.Config) error {
+	server, ok := cfg.ValueString(NFSServer)
+	if !ok || server == "" {
+		return errors.Errorf("%s not specified", NFSServer)
+	}
+	if strings.ContainsAny(server, "/ ") {
+		return errors.NotValidf("%s %q", NFSServer, server)
+	}
+	export, ok := cfg.ValueString(NFSExport)
+	if !ok || export == "" {
+		return errors.Errorf("%s not specified", NFSExport)
+	}
+	if !path.IsAbs(export) {
+		return errors.Errorf("%s %q is not an absolute path", NFSExport, export)
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (*nfsProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *nfsProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	if err := p.ValidateConfig(sourceConfig); err != nil {
+		return nil, err
+	}
+	// The server and export are validated by ValidateConfig.
+	server, _ := sourceConfig.ValueString(NFSServer)
+	export, _ := sourceConfig.ValueString(NFSExport)
+	if strings.Contains(server, ":") {
+		// IPv6 addresses are bracketed in NFS filesystem specs.
+		server = "[" + server + "]"
+	}
+	return &nfsFilesystemSource{
+		&osDirFuncs{p.run},
+		p.run,
+		server + ":" + path.Clean(export),
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*nfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*nfsProvider) Scope() storage.Scope {
+	// The exports are mounted by the machines they are attached to.
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*nfsProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*nfsProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*nfsProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// nfsFilesystemSource is a filesystem source whose filesystems are all
+// the directory exported by an NFS server. The filesystems are shared:
+// the filesystems attached to different machines, e.g. those of the
+// units of an application, all hold the same data.
+//
+// The NFS server owns the data of the exported directory, so it is
+// neither created nor destroyed with the filesystems.
+type nfsFilesystemSource struct {
+	dirFuncs dirFuncs
+	run      runCommandFunc
+	// spec is the NFS filesystem spec of the export,
+	// i.e. "<server>:<export>".
+	spec string
+}
+
+var _ storage.FilesystemSource = (*nfsFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	// The size of the export is only known once it is mounted, and
+	// it is shared with other filesystems, so it is not checked.
+	return nil
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) CreateFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		// There is nothing to create; the filesystem is
+		// the export, which the NFS server provides.
+		results[i].Filesystem = &storage.Filesystem{
+			arg.Tag,
+			arg.Volume,
+			storage.FilesystemInfo{
+				FilesystemId: s.spec,
+				Size:         arg.Size,
+			},
+		}
+	}
+	return results, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	// DestroyFilesystems is a no-op; the data of the export
+	// belongs to the NFS server, and may be shared.
+	return make([]error, len(filesystemIds)), nil
+}
+
+// ReleaseFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) ReleaseFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	return make([]error, len(filesystemIds)), nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) AttachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching filesystem %v", arg.Filesystem.Id())
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *nfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	if arg.Path == "" {
+		return nil, errNoMountPoint
+	}
+	opts, err := storage.ParseFilesystemOptions(arg.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := mountFilesystem(s.run, s.dirFuncs, s.spec, arg.Path, arg.ReadOnly, opts.MountOptions); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The ownership of the export is set even if it was already
+	// mounted, in case setting it failed after mounting it.
+	if err := setFilesystemOwnership(s.run, arg.Path, opts); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			Path:     arg.Path,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DetachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
+			results[i] = err
+		}
+	}
+	return results, nil
+}
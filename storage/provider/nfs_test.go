@@ -0,0 +1,241 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&nfsSuite{})
+
+type nfsSuite struct {
+	testing.BaseSuite
+	commands   *mockRunCommand
+	fakeEtcDir string
+
+	callCtx context.ProviderCallContext
+}
+
+func (s *nfsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.fakeEtcDir = c.MkDir()
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *nfsSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *nfsSuite) nfsProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.NFSProvider(s.commands.run)
+}
+
+func (s *nfsSuite) nfsFilesystemSource(c *gc.C) storage.FilesystemSource {
+	s.commands = &mockRunCommand{c: c}
+	source, _ := provider.NFSFilesystemSource(s.fakeEtcDir, "10.0.0.1:/srv/share", s.commands.run)
+	return source
+}
+
+func (s *nfsSuite) TestValidateConfig(c *gc.C) {
+	p := s.nfsProvider(c)
+	for _, test := range []struct {
+		attrs map[string]interface{}
+		err   string
+	}{{
+		attrs: map[string]interface{}{"nfs-export": "/srv/share"},
+		err:   "nfs-server not specified",
+	}, {
+		attrs: map[string]interface{}{"nfs-server": "10.0.0.1"},
+		err:   "nfs-export not specified",
+	}, {
+		attrs: map[string]interface{}{"nfs-server": "10.0.0.1", "nfs-export": "srv/share"},
+		err:   `nfs-export "srv/share" is not an absolute path`,
+	}, {
+		attrs: map[string]interface{}{"nfs-server": "10.0.0.1:/srv", "nfs-export": "/srv/share"},
+		err:   `nfs-server "10.0.0.1:/srv" not valid`,
+	}} {
+		cfg, err := storage.NewConfig("name", provider.NFSProviderType, test.attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		err = p.ValidateConfig(cfg)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *nfsSuite) TestSupports(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestScope(c *gc.C) {
+	p := s.nfsProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *nfsSuite) TestCreateFilesystems(c *gc.C) {
+	p := s.nfsProvider(c)
+	for _, test := range []struct {
+		server, export string
+		expect         string
+	}{
+		{"nfs.example.com", "/srv/share/", "nfs.example.com:/srv/share"},
+		{"fd00::1", "/srv/share", "[fd00::1]:/srv/share"},
+	} {
+		cfg, err := storage.NewConfig("name", provider.NFSProviderType, map[string]interface{}{
+			"nfs-server": test.server,
+			"nfs-export": test.export,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		source, err := p.FilesystemSource(cfg)
+		c.Assert(err, jc.ErrorIsNil)
+
+		results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+			Tag:  names.NewFilesystemTag("0"),
+			Size: 1024,
+		}, {
+			Tag:  names.NewFilesystemTag("1"),
+			Size: 1024,
+		}})
+		c.Assert(err, jc.ErrorIsNil)
+		// All filesystems are the export, and share its data.
+		c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+			Filesystem: &storage.Filesystem{
+				Tag: names.NewFilesystemTag("0"),
+				FilesystemInfo: storage.FilesystemInfo{
+					FilesystemId: test.expect,
+					Size:         1024,
+				},
+			},
+		}, {
+			Filesystem: &storage.Filesystem{
+				Tag: names.NewFilesystemTag("1"),
+				FilesystemInfo: storage.FilesystemInfo{
+					FilesystemId: test.expect,
+					Size:         1024,
+				},
+			},
+		}})
+	}
+}
+
+func (s *nfsSuite) TestDestroyFilesystems(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	results, err := source.DestroyFilesystems(s.callCtx, []string{"10.0.0.1:/srv/share"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+}
+
+func (s *nfsSuite) TestAttachFilesystems(c *gc.C) {
+	mtabEntry := fmt.Sprintf("10.0.0.1:/srv/share %s nfs4 rw,noatime 0 0", testMountPoint)
+	err := ioutil.WriteFile(filepath.Join(s.fakeEtcDir, "mtab"), []byte(mtabEntry+"\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	source := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	cmd = s.commands.expect("df", "--output=source", testMountPoint)
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	s.commands.expect("mount", "-o", "noatime", "10.0.0.1:/srv/share", testMountPoint)
+	s.commands.expect("chown", "1000", testMountPoint)
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "10.0.0.1:/srv/share",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+		Path: testMountPoint,
+		Attributes: map[string]interface{}{
+			"mount-options": "noatime",
+			"uid":           "1000",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			names.NewFilesystemTag("0"),
+			names.NewMachineTag("0"),
+			storage.FilesystemAttachmentInfo{
+				Path: testMountPoint,
+			},
+		},
+	}})
+
+	data, err := ioutil.ReadFile(filepath.Join(s.fakeEtcDir, "fstab"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "\n"+mtabEntry+"\n")
+}
+
+func (s *nfsSuite) TestAttachFilesystemsReadOnly(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	cmd = s.commands.expect("df", "--output=source", testMountPoint)
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	s.commands.expect("mount", "-o", "ro", "10.0.0.1:/srv/share", testMountPoint)
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("0"),
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+		Path: testMountPoint,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].FilesystemAttachment.ReadOnly, jc.IsTrue)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsAlreadyMounted(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", filepath.Dir(testMountPoint))
+	cmd.respond("headers\n/same/as/rootfs", nil)
+	cmd = s.commands.expect("df", "--output=source", testMountPoint)
+	cmd.respond("headers\n10.0.0.1:/srv/share", nil)
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("0"),
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+		Path: testMountPoint,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsNoPath(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("0"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "attaching filesystem 0: filesystem mount point not specified")
+}
+
+func (s *nfsSuite) TestDetachFilesystems(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, s.callCtx, true, s.fakeEtcDir, "")
+}
+
+func (s *nfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	testDetachFilesystems(c, s.commands, source, s.callCtx, false, s.fakeEtcDir, "")
+}
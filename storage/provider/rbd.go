@@ -0,0 +1,394 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	RBDProviderType = storage.ProviderType("rbd")
+
+	// RBDMonHosts is the name of the storage pool config attribute
+	// listing the comma-separated addresses of the monitors of the
+	// Ceph cluster in which RBD images are created.
+	RBDMonHosts = "mon-hosts"
+
+	// RBDKeyring is the name of the storage pool config attribute
+	// holding the cephx key of the Ceph user, either bare or as the
+	// content of a keyring file.
+	RBDKeyring = "keyring"
+
+	// RBDUser is the name of the storage pool config attribute naming
+	// the Ceph user the cluster is accessed as. It defaults to "admin".
+	RBDUser = "user"
+
+	// RBDPool is the name of the storage pool config attribute naming
+	// the Ceph pool in which RBD images are created. It defaults to
+	// "rbd".
+	RBDPool = "rbd-pool"
+
+	rbdDefaultUser = "admin"
+	rbdDefaultPool = "rbd"
+
+	// rbdImageNamePrefix is the prefix of the names of the RBD images
+	// created by the RBD provider.
+	rbdImageNamePrefix = "juju-"
+)
+
+// rbdProvider creates volume sources which use RBD images in a Ceph
+// cluster, mapped on the machines with the kernel RBD driver.
+type rbdProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var _ storage.Provider = (*rbdProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*rbdProvider) ValidateConfig(cfg *storage.Config) error {
+	for _, attr := range []string{RBDMonHosts, RBDKeyring} {
+		if value, ok := cfg.ValueString(attr); !ok || value == "" {
+			return errors.Errorf("%s not specified", attr)
+		}
+	}
+	for _, attr := range []string{RBDUser, RBDPool} {
+		if value, ok := cfg.ValueString(attr); ok && strings.ContainsAny(value, "/ ") {
+			return errors.NotValidf("%s %q", attr, value)
+		}
+	}
+	return nil
+}
+
+// validateFullConfig validates a fully-constructed storage config,
+// combining the user-specified config and any internally specified
+// config.
+func (p *rbdProvider) validateFullConfig(cfg *storage.Config) error {
+	if err := p.ValidateConfig(cfg); err != nil {
+		return err
+	}
+	storageDir, ok := cfg.ValueString(storage.ConfigStorageDir)
+	if !ok || storageDir == "" {
+		return errors.New("storage directory not specified")
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *rbdProvider) VolumeSource(sourceConfig *storage.Config) (storage.VolumeSource, error) {
+	if err := p.validateFullConfig(sourceConfig); err != nil {
+		return nil, err
+	}
+	// The required attributes are validated by validateFullConfig.
+	monHosts, _ := sourceConfig.ValueString(RBDMonHosts)
+	keyring, _ := sourceConfig.ValueString(RBDKeyring)
+	storageDir, _ := sourceConfig.ValueString(storage.ConfigStorageDir)
+	user, _ := sourceConfig.ValueString(RBDUser)
+	if user == "" {
+		user = rbdDefaultUser
+	}
+	pool, _ := sourceConfig.ValueString(RBDPool)
+	if pool == "" {
+		pool = rbdDefaultPool
+	}
+	return &rbdVolumeSource{
+		dirFuncs:    &osDirFuncs{p.run},
+		run:         p.run,
+		monHosts:    monHosts,
+		user:        user,
+		keyring:     keyring,
+		keyringPath: filepath.Join(storageDir, sourceConfig.Name()+".keyring"),
+		pool:        pool,
+	}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (*rbdProvider) FilesystemSource(providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*rbdProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*rbdProvider) Scope() storage.Scope {
+	// RBD images are mapped with the kernel driver on the machines
+	// they are attached to, so they are managed by the machines.
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*rbdProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*rbdProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*rbdProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// rbdVolumeSource is a volume source whose volumes are RBD images in
+// a Ceph pool, managed with the rbd command. The IDs of the volumes are
+// the specs of the images, i.e. "<pool>/<image>".
+type rbdVolumeSource struct {
+	dirFuncs    dirFuncs
+	run         runCommandFunc
+	monHosts    string
+	user        string
+	keyring     string
+	keyringPath string
+	pool        string
+}
+
+var _ storage.VolumeSource = (*rbdVolumeSource)(nil)
+
+func (s *rbdVolumeSource) volumeId(tag names.VolumeTag) string {
+	return s.pool + "/" + rbdImageNamePrefix + tag.String()
+}
+
+// rbd runs the rbd command with the given arguments, connecting to the
+// cluster as the user of the source with its keyring.
+func (s *rbdVolumeSource) rbd(args ...string) (string, error) {
+	if err := s.writeKeyring(); err != nil {
+		return "", errors.Trace(err)
+	}
+	args = append([]string{
+		"-m", s.monHosts,
+		"--id", s.user,
+		"--keyring", s.keyringPath,
+	}, args...)
+	return s.run("rbd", args...)
+}
+
+// writeKeyring writes the keyring the rbd command authenticates with,
+// readable only by the machine agent. A bare key is written as the
+// keyring of the user of the source.
+func (s *rbdVolumeSource) writeKeyring() error {
+	keyring := strings.TrimSpace(s.keyring)
+	if !strings.HasPrefix(keyring, "[") {
+		keyring = fmt.Sprintf("[client.%s]\n\tkey = %s", s.user, keyring)
+	}
+	if err := ensureDir(s.dirFuncs, filepath.Dir(s.keyringPath)); err != nil {
+		return errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(s.keyringPath, []byte(keyring+"\n"), 0600); err != nil {
+		return errors.Annotate(err, "writing Ceph keyring")
+	}
+	return nil
+}
+
+// isRBDImageNotFound reports whether the error is that of an rbd
+// command referring to an image that does not exist.
+func isRBDImageNotFound(err error) bool {
+	return strings.Contains(err.Error(), "No such file or directory")
+}
+
+// CreateVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := s.createVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume")
+			continue
+		}
+		results[i].Volume = volume
+	}
+	return results, nil
+}
+
+func (s *rbdVolumeSource) createVolume(params storage.VolumeParams) (*storage.Volume, error) {
+	volumeId := s.volumeId(params.Tag)
+	info, err := s.describeVolume(volumeId)
+	if errors.IsNotFound(err) {
+		if _, err := s.rbd("create", "--size", fmt.Sprintf("%dM", params.Size), volumeId); err != nil {
+			return nil, errors.Annotatef(err, "creating RBD image %q", volumeId)
+		}
+		info = &storage.VolumeInfo{
+			VolumeId:   volumeId,
+			Size:       params.Size,
+			Persistent: true,
+		}
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else {
+		// The image was created by an earlier attempt.
+		logger.Debugf("RBD image %q already exists", volumeId)
+	}
+	return &storage.Volume{params.Tag, *info}, nil
+}
+
+// describeVolume returns the info of the volume with the given ID, or
+// an error satisfying errors.IsNotFound if there is no such image.
+func (s *rbdVolumeSource) describeVolume(volumeId string) (*storage.VolumeInfo, error) {
+	out, err := s.rbd("info", "--format", "json", volumeId)
+	if err != nil {
+		if isRBDImageNotFound(err) {
+			return nil, errors.NotFoundf("RBD image %q", volumeId)
+		}
+		return nil, errors.Annotatef(err, "querying RBD image %q", volumeId)
+	}
+	var image struct {
+		Size uint64 `json:"size"`
+	}
+	if err := json.Unmarshal([]byte(out), &image); err != nil {
+		return nil, errors.Annotatef(err, "parsing info of RBD image %q", volumeId)
+	}
+	return &storage.VolumeInfo{
+		VolumeId:   volumeId,
+		Size:       image.Size / (1024 * 1024),
+		Persistent: true,
+	}, nil
+}
+
+// ListVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) ListVolumes(ctx context.ProviderCallContext) ([]string, error) {
+	out, err := s.rbd("ls", "--format", "json", s.pool)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing RBD images in %q", s.pool)
+	}
+	var images []string
+	if err := json.Unmarshal([]byte(out), &images); err != nil {
+		return nil, errors.Annotatef(err, "parsing RBD images in %q", s.pool)
+	}
+	var volumeIds []string
+	for _, image := range images {
+		if !strings.HasPrefix(image, rbdImageNamePrefix) {
+			// Only list the images created by Juju.
+			continue
+		}
+		volumeIds = append(volumeIds, s.pool+"/"+image)
+	}
+	return volumeIds, nil
+}
+
+// DescribeVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) DescribeVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]storage.DescribeVolumesResult, error) {
+	results := make([]storage.DescribeVolumesResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		results[i].VolumeInfo, results[i].Error = s.describeVolume(volumeId)
+	}
+	return results, nil
+}
+
+// DestroyVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) DestroyVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
+	results := make([]error, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		if err := s.destroyVolume(volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
+func (s *rbdVolumeSource) destroyVolume(volumeId string) error {
+	if !strings.HasPrefix(volumeId, s.pool+"/"+rbdImageNamePrefix) {
+		return errors.Errorf("invalid RBD volume ID %q", volumeId)
+	}
+	if _, err := s.rbd("rm", "--no-progress", volumeId); err != nil && !isRBDImageNotFound(err) {
+		return errors.Annotate(err, "removing RBD image")
+	}
+	return nil
+}
+
+// ReleaseVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) ReleaseVolumes(ctx context.ProviderCallContext, volumeIds []string) ([]error, error) {
+	return make([]error, len(volumeIds)), nil
+}
+
+// ValidateVolumeParams is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	return nil
+}
+
+// AttachVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) AttachVolumes(ctx context.ProviderCallContext, args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+// deviceLink returns the link udev creates to the device an image is
+// mapped to. The kernel device name, e.g. "rbd0", may change when the
+// machine restarts, so only the link identifies the device.
+func (s *rbdVolumeSource) deviceLink(volumeId string) string {
+	return path.Join("/dev/rbd", volumeId)
+}
+
+func (s *rbdVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	volumeId := s.volumeId(arg.Volume)
+	deviceLink := s.deviceLink(volumeId)
+	if _, err := s.dirFuncs.lstat(deviceLink); err == nil {
+		// The image is already mapped.
+		logger.Debugf("RBD image %q already mapped", volumeId)
+	} else {
+		args := []string{"map"}
+		if arg.ReadOnly {
+			args = append(args, "--read-only")
+		}
+		args = append(args, volumeId)
+		if _, err := s.rbd(args...); err != nil {
+			return nil, errors.Annotatef(err, "mapping RBD image %q", volumeId)
+		}
+	}
+	return &storage.VolumeAttachment{
+		arg.Volume,
+		arg.Machine,
+		storage.VolumeAttachmentInfo{
+			DeviceLink: deviceLink,
+			ReadOnly:   arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (s *rbdVolumeSource) DetachVolumes(ctx context.ProviderCallContext, args []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := s.detachVolume(arg.Volume); err != nil {
+			results[i] = errors.Annotatef(err, "detaching volume %s", arg.Volume.Id())
+		}
+	}
+	return results, nil
+}
+
+func (s *rbdVolumeSource) detachVolume(tag names.VolumeTag) error {
+	volumeId := s.volumeId(tag)
+	deviceLink := s.deviceLink(volumeId)
+	if _, err := s.dirFuncs.lstat(deviceLink); err != nil {
+		// The image is not mapped.
+		return nil
+	}
+	// Unmapping the device link unmaps the device it links to.
+	if _, err := s.run("rbd", "unmap", deviceLink); err != nil {
+		return errors.Annotatef(err, "unmapping RBD image %q", volumeId)
+	}
+	return nil
+}
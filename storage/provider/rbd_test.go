@@ -0,0 +1,277 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&rbdSuite{})
+
+type rbdSuite struct {
+	testing.BaseSuite
+	storageDir string
+	commands   *mockRunCommand
+
+	callCtx context.ProviderCallContext
+}
+
+func (s *rbdSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.storageDir = c.MkDir()
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *rbdSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *rbdSuite) rbdProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.RBDProvider(s.commands.run)
+}
+
+func (s *rbdSuite) rbdVolumeSource(c *gc.C) (storage.VolumeSource, *provider.MockDirFuncs) {
+	s.commands = &mockRunCommand{c: c}
+	return provider.RBDVolumeSource(s.storageDir, s.commands.run)
+}
+
+// expectRBD adds an expected rbd command, run with the cluster
+// connection arguments of the volume source.
+func (s *rbdSuite) expectRBD(args ...string) *mockCommand {
+	args = append([]string{
+		"-m", "10.0.0.1,10.0.0.2",
+		"--id", "juju",
+		"--keyring", filepath.Join(s.storageDir, "ceph.keyring"),
+	}, args...)
+	return s.commands.expect("rbd", args...)
+}
+
+func (s *rbdSuite) TestVolumeSource(c *gc.C) {
+	p := s.rbdProvider(c)
+	cfg, err := storage.NewConfig("name", provider.RBDProviderType, map[string]interface{}{
+		"keyring": "AQBdHWBckey==",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "mon-hosts not specified")
+	cfg, err = storage.NewConfig("name", provider.RBDProviderType, map[string]interface{}{
+		"mon-hosts": "10.0.0.1",
+		"keyring":   "AQBdHWBckey==",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "storage directory not specified")
+	cfg, err = storage.NewConfig("name", provider.RBDProviderType, map[string]interface{}{
+		"mon-hosts":   "10.0.0.1",
+		"keyring":     "AQBdHWBckey==",
+		"storage-dir": s.storageDir,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *rbdSuite) TestValidateConfig(c *gc.C) {
+	p := s.rbdProvider(c)
+	cfg, err := storage.NewConfig("name", provider.RBDProviderType, map[string]interface{}{
+		"mon-hosts": "10.0.0.1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, "keyring not specified")
+
+	cfg, err = storage.NewConfig("name", provider.RBDProviderType, map[string]interface{}{
+		"mon-hosts": "10.0.0.1",
+		"keyring":   "AQBdHWBckey==",
+		"rbd-pool":  "rbd/images",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `rbd-pool "rbd/images" not valid`)
+}
+
+func (s *rbdSuite) TestSupports(c *gc.C) {
+	p := s.rbdProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *rbdSuite) TestScope(c *gc.C) {
+	p := s.rbdProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *rbdSuite) TestCreateVolumes(c *gc.C) {
+	source, _ := s.rbdVolumeSource(c)
+	cmd := s.expectRBD("info", "--format", "json", "rbd/juju-volume-0")
+	cmd.respond("", errors.New("rbd: error opening image juju-volume-0: (2) No such file or directory"))
+	s.expectRBD("create", "--size", "1024M", "rbd/juju-volume-0")
+	cmd = s.expectRBD("info", "--format", "json", "rbd/juju-volume-1")
+	cmd.respond(`{"name":"juju-volume-1","size":2147483648,"objects":512}`, nil)
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}, {
+		Tag:  names.NewVolumeTag("1"),
+		Size: 2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumesResult{{
+		Volume: &storage.Volume{
+			Tag: names.NewVolumeTag("0"),
+			VolumeInfo: storage.VolumeInfo{
+				VolumeId:   "rbd/juju-volume-0",
+				Size:       1024,
+				Persistent: true,
+			},
+		},
+	}, {
+		Volume: &storage.Volume{
+			Tag: names.NewVolumeTag("1"),
+			VolumeInfo: storage.VolumeInfo{
+				VolumeId:   "rbd/juju-volume-1",
+				Size:       2048,
+				Persistent: true,
+			},
+		},
+	}})
+
+	data, err := ioutil.ReadFile(filepath.Join(s.storageDir, "ceph.keyring"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "[client.juju]\n\tkey = AQBdHWBckey==\n")
+	info, err := os.Stat(filepath.Join(s.storageDir, "ceph.keyring"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Mode().Perm(), gc.Equals, os.FileMode(0600))
+}
+
+func (s *rbdSuite) TestCreateVolumesError(c *gc.C) {
+	source, _ := s.rbdVolumeSource(c)
+	cmd := s.expectRBD("info", "--format", "json", "rbd/juju-volume-0")
+	cmd.respond("", errors.New("rbd: couldn't connect to the cluster!"))
+
+	results, err := source.CreateVolumes(s.callCtx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating volume: querying RBD image "rbd/juju-volume-0": rbd: couldn't connect to the cluster!`)
+}
+
+func (s *rbdSuite) TestListVolumes(c *gc.C) {
+	source, _ := s.rbdVolumeSource(c)
+	cmd := s.expectRBD("ls", "--format", "json", "rbd")
+	cmd.respond(`["juju-volume-0","glance-image","juju-volume-1"]`, nil)
+
+	volumeIds, err := source.ListVolumes(s.callCtx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumeIds, jc.DeepEquals, []string{"rbd/juju-volume-0", "rbd/juju-volume-1"})
+}
+
+func (s *rbdSuite) TestDescribeVolumes(c *gc.C) {
+	source, _ := s.rbdVolumeSource(c)
+	cmd := s.expectRBD("info", "--format", "json", "rbd/juju-volume-0")
+	cmd.respond(`{"name":"juju-volume-0","size":536870912}`, nil)
+	cmd = s.expectRBD("info", "--format", "json", "rbd/juju-volume-1")
+	cmd.respond("", errors.New("rbd: error opening image juju-volume-1: (2) No such file or directory"))
+
+	results, err := source.DescribeVolumes(s.callCtx, []string{"rbd/juju-volume-0", "rbd/juju-volume-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.DescribeVolumesResult{
+		VolumeInfo: &storage.VolumeInfo{
+			VolumeId:   "rbd/juju-volume-0",
+			Size:       512,
+			Persistent: true,
+		},
+	})
+	c.Assert(results[1].Error, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *rbdSuite) TestDestroyVolumes(c *gc.C) {
+	source, _ := s.rbdVolumeSource(c)
+	s.expectRBD("rm", "--no-progress", "rbd/juju-volume-0")
+	cmd := s.expectRBD("rm", "--no-progress", "rbd/juju-volume-1")
+	cmd.respond("", errors.New("rbd: delete error: (2) No such file or directory"))
+
+	results, err := source.DestroyVolumes(s.callCtx, []string{
+		"rbd/juju-volume-0", "rbd/juju-volume-1", "rbd/glance-image",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], jc.ErrorIsNil)
+	c.Assert(results[2], gc.ErrorMatches, `destroying "rbd/glance-image": invalid RBD volume ID "rbd/glance-image"`)
+}
+
+func (s *rbdSuite) TestAttachVolumes(c *gc.C) {
+	source, dirFuncs := s.rbdVolumeSource(c)
+	s.expectRBD("map", "--read-only", "rbd/juju-volume-0")
+	// volume-1 is already mapped.
+	dirFuncs.Dirs.Add("/dev/rbd/rbd/juju-volume-1")
+
+	results, err := source.AttachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume: names.NewVolumeTag("0"),
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+	}, {
+		Volume: names.NewVolumeTag("1"),
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/rbd/rbd/juju-volume-0",
+				ReadOnly:   true,
+			},
+		},
+	}, {
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("1"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/rbd/rbd/juju-volume-1",
+			},
+		},
+	}})
+}
+
+func (s *rbdSuite) TestDetachVolumes(c *gc.C) {
+	source, dirFuncs := s.rbdVolumeSource(c)
+	dirFuncs.Dirs.Add("/dev/rbd/rbd/juju-volume-0")
+	s.commands.expect("rbd", "unmap", "/dev/rbd/rbd/juju-volume-0")
+
+	// volume-1 is not mapped, so there is nothing to unmap.
+	results, err := source.DetachVolumes(s.callCtx, []storage.VolumeAttachmentParams{{
+		Volume: names.NewVolumeTag("0"),
+	}, {
+		Volume: names.NewVolumeTag("1"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil, nil})
+}
@@ -0,0 +1,302 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	ZFSProviderType = storage.ProviderType("zfs")
+
+	// ZFSPool is the name of the storage pool config attribute naming
+	// the ZFS pool in which datasets are created.
+	ZFSPool = "zfs-pool"
+
+	// zfsDatasetNamePrefix is the prefix of the names of the datasets
+	// created by the ZFS provider.
+	zfsDatasetNamePrefix = "juju-"
+
+	// zfsMountpointNone is the value of the mountpoint property of
+	// datasets that are not mounted.
+	zfsMountpointNone = "none"
+)
+
+// zfsProvider creates filesystem sources which use ZFS datasets,
+// created in an existing ZFS pool on the machine.
+type zfsProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var _ storage.Provider = (*zfsProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*zfsProvider) ValidateConfig(cfg *storage.Config) error {
+	pool, ok := cfg.ValueString(ZFSPool)
+	if !ok || pool == "" {
+		return errors.Errorf("%s not specified", ZFSPool)
+	}
+	if strings.ContainsAny(pool, " @") {
+		return errors.NotValidf("%s %q", ZFSPool, pool)
+	}
+	return nil
+}
+
+// VolumeSource is defined on the Provider interface.
+func (*zfsProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *zfsProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	if err := p.ValidateConfig(sourceConfig); err != nil {
+		return nil, err
+	}
+	// The pool is validated by ValidateConfig.
+	pool, _ := sourceConfig.ValueString(ZFSPool)
+	return &zfsFilesystemSource{
+		&osDirFuncs{p.run},
+		p.run,
+		strings.TrimSuffix(pool, "/"),
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*zfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*zfsProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*zfsProvider) Dynamic() bool {
+	return true
+}
+
+// Releasable is defined on the Provider interface.
+func (*zfsProvider) Releasable() bool {
+	return false
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*zfsProvider) DefaultPools() []*storage.Config {
+	return nil
+}
+
+// zfsFilesystemSource is a filesystem source whose filesystems are
+// ZFS datasets in a pool. The IDs of the filesystems are the names of
+// the datasets, i.e. "<pool>/<dataset>". Filesystems are attached by
+// setting the mountpoint of their datasets, which ZFS mounts.
+type zfsFilesystemSource struct {
+	dirFuncs dirFuncs
+	run      runCommandFunc
+	pool     string
+}
+
+var _ storage.FilesystemSource = (*zfsFilesystemSource)(nil)
+
+func (s *zfsFilesystemSource) filesystemId(tag names.FilesystemTag) string {
+	return s.pool + "/" + zfsDatasetNamePrefix + tag.String()
+}
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *zfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	// ValidateFilesystemParams may be called on a machine other than
+	// the machine where the dataset will be created, so we cannot check
+	// the free space in the pool until CreateFilesystems.
+	return nil
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *zfsFilesystemSource) CreateFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.createFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating filesystem")
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *zfsFilesystemSource) createFilesystem(params storage.FilesystemParams) (*storage.Filesystem, error) {
+	filesystemId := s.filesystemId(params.Tag)
+	size, err := s.datasetQuota(filesystemId)
+	if errors.IsNotFound(err) {
+		// The quota limits the dataset to the requested size, and the
+		// dataset is only mounted once it is attached.
+		if _, err := s.run(
+			"zfs", "create",
+			"-o", fmt.Sprintf("quota=%dM", params.Size),
+			"-o", "mountpoint="+zfsMountpointNone,
+			filesystemId,
+		); err != nil {
+			return nil, errors.Annotatef(err, "creating dataset %q", filesystemId)
+		}
+		size = params.Size
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	} else {
+		// The dataset was created by an earlier attempt.
+		logger.Debugf("dataset %q already exists", filesystemId)
+	}
+	return &storage.Filesystem{
+		params.Tag,
+		params.Volume,
+		storage.FilesystemInfo{
+			FilesystemId: filesystemId,
+			Size:         size,
+		},
+	}, nil
+}
+
+// datasetProperty returns the value of the property of the dataset,
+// or an error satisfying errors.IsNotFound if there is no such dataset.
+func (s *zfsFilesystemSource) datasetProperty(dataset, property string) (string, error) {
+	out, err := s.run("zfs", "get", "-H", "-p", "-o", "value", property, dataset)
+	if err != nil {
+		if isDatasetNotFound(err) {
+			return "", errors.NotFoundf("dataset %q", dataset)
+		}
+		return "", errors.Annotatef(err, "getting %s of dataset %q", property, dataset)
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// datasetQuota returns the quota, in MiB, of the dataset.
+func (s *zfsFilesystemSource) datasetQuota(dataset string) (uint64, error) {
+	value, err := s.datasetProperty(dataset, "quota")
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	// The quota is reported in bytes, as -p was specified.
+	quota, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, errors.Annotatef(err, "parsing quota of dataset %q", dataset)
+	}
+	return quota / (1024 * 1024), nil
+}
+
+// isDatasetNotFound reports whether the error is that of a zfs command
+// referring to a dataset that does not exist.
+func isDatasetNotFound(err error) bool {
+	return strings.Contains(err.Error(), "dataset does not exist")
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *zfsFilesystemSource) DestroyFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	results := make([]error, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		if err := s.destroyFilesystem(filesystemId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", filesystemId)
+		}
+	}
+	return results, nil
+}
+
+func (s *zfsFilesystemSource) destroyFilesystem(filesystemId string) error {
+	if !strings.HasPrefix(filesystemId, s.pool+"/"+zfsDatasetNamePrefix) {
+		return errors.Errorf("invalid ZFS filesystem ID %q", filesystemId)
+	}
+	// -r destroys the snapshots of the dataset too.
+	if _, err := s.run("zfs", "destroy", "-r", filesystemId); err != nil && !isDatasetNotFound(err) {
+		return errors.Annotate(err, "destroying dataset")
+	}
+	return nil
+}
+
+// ReleaseFilesystems is defined on the FilesystemSource interface.
+func (s *zfsFilesystemSource) ReleaseFilesystems(ctx context.ProviderCallContext, filesystemIds []string) ([]error, error) {
+	return make([]error, len(filesystemIds)), nil
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *zfsFilesystemSource) AttachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching filesystem %v", arg.Filesystem.Id())
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *zfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	path := arg.Path
+	if path == "" {
+		return nil, errNoMountPoint
+	}
+	opts, err := storage.ParseFilesystemOptions(arg.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	filesystemId := s.filesystemId(arg.Filesystem)
+	mountpoint, err := s.datasetProperty(filesystemId, "mountpoint")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	readOnly := "off"
+	if arg.ReadOnly {
+		readOnly = "on"
+	}
+	if _, err := s.run("zfs", "set", "readonly="+readOnly, filesystemId); err != nil {
+		return nil, errors.Annotatef(err, "setting readonly of dataset %q", filesystemId)
+	}
+	if mountpoint != path {
+		if err := ensureDir(s.dirFuncs, path); err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Setting the mountpoint makes ZFS mount the dataset there.
+		if _, err := s.run("zfs", "set", "mountpoint="+path, filesystemId); err != nil {
+			return nil, errors.Annotatef(err, "mounting dataset %q", filesystemId)
+		}
+	}
+	// Ownership is set even if the dataset was already mounted, in
+	// case setting it failed in an earlier attempt. It cannot be set
+	// on read-only datasets, which keep the ownership they have.
+	if !arg.ReadOnly {
+		if err := setFilesystemOwnership(s.run, path, opts); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			Path:     path,
+			ReadOnly: arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *zfsFilesystemSource) DetachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		filesystemId := s.filesystemId(arg.Filesystem)
+		// Resetting the mountpoint makes ZFS unmount the dataset.
+		_, err := s.run("zfs", "set", "mountpoint="+zfsMountpointNone, filesystemId)
+		if err != nil && !isDatasetNotFound(err) {
+			results[i] = errors.Annotatef(err, "detaching filesystem %s", arg.Filesystem.Id())
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&zfsSuite{})
+
+type zfsSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+
+	callCtx context.ProviderCallContext
+}
+
+func (s *zfsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.callCtx = context.NewCloudCallContext()
+}
+
+func (s *zfsSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *zfsSuite) zfsProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.ZFSProvider(s.commands.run)
+}
+
+func (s *zfsSuite) zfsFilesystemSource(c *gc.C) (storage.FilesystemSource, *provider.MockDirFuncs) {
+	s.commands = &mockRunCommand{c: c}
+	return provider.ZFSFilesystemSource("tank", s.commands.run)
+}
+
+func (s *zfsSuite) TestFilesystemSource(c *gc.C) {
+	p := s.zfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.ZFSProviderType, map[string]interface{}{})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "zfs-pool not specified")
+	cfg, err = storage.NewConfig("name", provider.ZFSProviderType, map[string]interface{}{
+		"zfs-pool": "tank/juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.FilesystemSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *zfsSuite) TestValidateConfig(c *gc.C) {
+	p := s.zfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.ZFSProviderType, map[string]interface{}{
+		"zfs-pool": "tank@snap",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = p.ValidateConfig(cfg)
+	c.Assert(err, gc.ErrorMatches, `zfs-pool "tank@snap" not valid`)
+}
+
+func (s *zfsSuite) TestVolumeSource(c *gc.C) {
+	p := s.zfsProvider(c)
+	cfg, err := storage.NewConfig("name", provider.ZFSProviderType, map[string]interface{}{
+		"zfs-pool": "tank",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = p.VolumeSource(cfg)
+	c.Assert(err, gc.ErrorMatches, "volumes not supported")
+}
+
+func (s *zfsSuite) TestSupports(c *gc.C) {
+	p := s.zfsProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *zfsSuite) TestScope(c *gc.C) {
+	p := s.zfsProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+}
+
+func (s *zfsSuite) TestCreateFilesystems(c *gc.C) {
+	source, _ := s.zfsFilesystemSource(c)
+	cmd := s.commands.expect("zfs", "get", "-H", "-p", "-o", "value", "quota", "tank/juju-filesystem-0")
+	cmd.respond("", errors.New("cannot open 'tank/juju-filesystem-0': dataset does not exist"))
+	s.commands.expect("zfs", "create", "-o", "quota=1024M", "-o", "mountpoint=none", "tank/juju-filesystem-0")
+	cmd = s.commands.expect("zfs", "get", "-H", "-p", "-o", "value", "quota", "tank/juju-filesystem-1")
+	cmd.respond("2147483648\n", nil)
+
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("0"),
+		Size: 1024,
+	}, {
+		Tag:  names.NewFilesystemTag("1"),
+		Size: 2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("0"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "tank/juju-filesystem-0",
+				Size:         1024,
+			},
+		},
+	}, {
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("1"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "tank/juju-filesystem-1",
+				Size:         2048,
+			},
+		},
+	}})
+}
+
+func (s *zfsSuite) TestCreateFilesystemsError(c *gc.C) {
+	source, _ := s.zfsFilesystemSource(c)
+	cmd := s.commands.expect("zfs", "get", "-H", "-p", "-o", "value", "quota", "tank/juju-filesystem-0")
+	cmd.respond("", errors.New("cannot open 'tank': dataset does not exist"))
+	cmd = s.commands.expect("zfs", "create", "-o", "quota=1024M", "-o", "mountpoint=none", "tank/juju-filesystem-0")
+	cmd.respond("", errors.New("cannot create 'tank/juju-filesystem-0': no such pool 'tank'"))
+
+	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
+		Tag:  names.NewFilesystemTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, `creating filesystem: creating dataset "tank/juju-filesystem-0": .* no such pool 'tank'`)
+}
+
+func (s *zfsSuite) TestDestroyFilesystems(c *gc.C) {
+	source, _ := s.zfsFilesystemSource(c)
+	s.commands.expect("zfs", "destroy", "-r", "tank/juju-filesystem-0")
+	cmd := s.commands.expect("zfs", "destroy", "-r", "tank/juju-filesystem-1")
+	cmd.respond("", errors.New("cannot open 'tank/juju-filesystem-1': dataset does not exist"))
+
+	results, err := source.DestroyFilesystems(s.callCtx, []string{
+		"tank/juju-filesystem-0", "tank/juju-filesystem-1", "tank/home",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], jc.ErrorIsNil)
+	c.Assert(results[2], gc.ErrorMatches, `destroying "tank/home": invalid ZFS filesystem ID "tank/home"`)
+}
+
+func (s *zfsSuite) TestAttachFilesystems(c *gc.C) {
+	source, dirFuncs := s.zfsFilesystemSource(c)
+	cmd := s.commands.expect("zfs", "get", "-H", "-p", "-o", "value", "mountpoint", "tank/juju-filesystem-0")
+	cmd.respond("none\n", nil)
+	s.commands.expect("zfs", "set", "readonly=off", "tank/juju-filesystem-0")
+	s.commands.expect("zfs", "set", "mountpoint=/srv/data", "tank/juju-filesystem-0")
+	s.commands.expect("chown", "1000:1000", "/srv/data")
+	s.commands.expect("chmod", "0750", "/srv/data")
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("0"),
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+		Path: "/srv/data",
+		Attributes: map[string]interface{}{
+			"uid":  "1000",
+			"gid":  "1000",
+			"mode": "0750",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			names.NewFilesystemTag("0"),
+			names.NewMachineTag("0"),
+			storage.FilesystemAttachmentInfo{
+				Path: "/srv/data",
+			},
+		},
+	}})
+	c.Assert(dirFuncs.Dirs.Contains("/srv/data"), jc.IsTrue)
+}
+
+func (s *zfsSuite) TestAttachFilesystemsMounted(c *gc.C) {
+	source, _ := s.zfsFilesystemSource(c)
+	cmd := s.commands.expect("zfs", "get", "-H", "-p", "-o", "value", "mountpoint", "tank/juju-filesystem-0")
+	cmd.respond("/srv/data\n", nil)
+	s.commands.expect("zfs", "set", "readonly=on", "tank/juju-filesystem-0")
+
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("0"),
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+		Path: "/srv/data",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			names.NewFilesystemTag("0"),
+			names.NewMachineTag("0"),
+			storage.FilesystemAttachmentInfo{
+				Path:     "/srv/data",
+				ReadOnly: true,
+			},
+		},
+	}})
+}
+
+func (s *zfsSuite) TestAttachFilesystemsNoPath(c *gc.C) {
+	source, _ := s.zfsFilesystemSource(c)
+	results, err := source.AttachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("0"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "attaching filesystem 0: filesystem mount point not specified")
+}
+
+func (s *zfsSuite) TestDetachFilesystems(c *gc.C) {
+	source, _ := s.zfsFilesystemSource(c)
+	s.commands.expect("zfs", "set", "mountpoint=none", "tank/juju-filesystem-0")
+	cmd := s.commands.expect("zfs", "set", "mountpoint=none", "tank/juju-filesystem-1")
+	cmd.respond("", errors.New("cannot unmount '/srv/logs': pool or dataset is busy"))
+
+	results, err := source.DetachFilesystems(s.callCtx, []storage.FilesystemAttachmentParams{{
+		Filesystem: names.NewFilesystemTag("0"),
+		Path:       "/srv/data",
+	}, {
+		Filesystem: names.NewFilesystemTag("1"),
+		Path:       "/srv/logs",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, "detaching filesystem 1: cannot unmount '/srv/logs': pool or dataset is busy")
+}
@@ -48,3 +48,20 @@ type StorageAttachmentInfo struct {
 	// for a block-kind.
 	Location string
 }
+
+// Keys of the status data of volumes and filesystems, recording the
+// operations on them that failed and are to be retried.
+const (
+	// StatusDataAttempts is the key of the number of failed attempts
+	// made at the pending operation on a storage entity.
+	StatusDataAttempts = "attempts"
+
+	// StatusDataNextRetry is the key of the time, formatted as RFC3339,
+	// at which the failed operation on a storage entity is next retried.
+	StatusDataNextRetry = "next-retry"
+
+	// StatusDataRetryRequested is the key of the flag recording that a
+	// user requested the failed operation on a storage entity be retried
+	// immediately.
+	StatusDataRetryRequested = "retry-requested"
+)
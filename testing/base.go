@@ -53,6 +53,8 @@ func (s *JujuOSEnvSuite) SetUpTest(c *gc.C) {
 		osenv.JujuModelEnvKey,
 		osenv.JujuLoggingConfigEnvKey,
 		osenv.JujuFeatureFlagEnvKey,
+		osenv.JujuAPIRetryAttemptsEnvKey,
+		osenv.JujuAPIRetryDelayEnvKey,
 		osenv.XDGDataHome,
 	} {
 		s.oldEnvironment[name] = os.Getenv(name)
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/core/raftlease"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state"
 	raftleasestore "github.com/juju/juju/state/raftlease"
 )
@@ -62,6 +63,8 @@ type StateBackend interface {
 	UpdateKubernetesStorageConfig() error
 	EnsureDefaultModificationStatus() error
 	EnsureApplicationDeviceConstraints() error
+	CheckMongoUpgrade(mongo.Version) (state.MongoUpgradeCheck, error)
+	StartMongoUpgrade(mongo.Version) error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -239,3 +242,11 @@ func (s stateBackend) EnsureDefaultModificationStatus() error {
 func (s stateBackend) EnsureApplicationDeviceConstraints() error {
 	return state.EnsureApplicationDeviceConstraints(s.pool)
 }
+
+func (s stateBackend) CheckMongoUpgrade(target mongo.Version) (state.MongoUpgradeCheck, error) {
+	return s.pool.SystemState().CheckMongoUpgrade(target)
+}
+
+func (s stateBackend) StartMongoUpgrade(target mongo.Version) error {
+	return s.pool.SystemState().StartMongoUpgrade(target)
+}
@@ -8,6 +8,7 @@ import "github.com/juju/juju/environs"
 var (
 	UpgradeOperations      = &upgradeOperations
 	StateUpgradeOperations = &stateUpgradeOperations
+	InstalledMongoVersion  = &installedMongoVersion
 )
 
 type ModelConfigUpdater environConfigUpdater
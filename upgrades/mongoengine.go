@@ -0,0 +1,58 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+import (
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/mongo"
+)
+
+// installedMongoVersion returns the version and storage engine of the
+// best mongod installed on the controller. It is patched out in tests.
+var installedMongoVersion = func() (mongo.Version, error) {
+	_, version, err := mongo.NewMongodFinder().FindBest()
+	return version, errors.Trace(err)
+}
+
+// StartMongoStorageEngineMigration starts migrating the controller's
+// database to the storage engine of the mongod installed by the
+// upgrade, if the database still uses an older one. The pre-checks
+// and estimate are logged. If the checks fail the upgrade carries on,
+// and the migration can be started once the problems are fixed with
+// the HighAvailability facade's StartMongoUpgrade. The members are
+// resynced by the mongo upgrader worker on each controller, so that
+// the upgrade isn't held up while they copy their data.
+func StartMongoStorageEngineMigration(context Context) error {
+	target, err := installedMongoVersion()
+	if err != nil {
+		return errors.Annotate(err, "finding installed mongo")
+	}
+	st := context.State()
+	check, err := st.CheckMongoUpgrade(target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if check.Current.StorageEngine == target.StorageEngine {
+		logger.Debugf("database already uses storage engine %q", target.StorageEngine)
+		return nil
+	}
+	if len(check.Problems) > 0 {
+		logger.Warningf(
+			"not migrating database from mongo %s to %s: %s",
+			check.Current, target, strings.Join(check.Problems, "; "),
+		)
+		return nil
+	}
+	logger.Infof(
+		"migrating database from mongo %s to %s: %s to resync on %d controllers, "+
+			"estimated to take %v with %v of downtime",
+		check.Current, target, humanize.Bytes(uint64(check.DataSize)), check.Members,
+		check.Estimate.Duration, check.Estimate.Downtime,
+	)
+	return errors.Annotate(st.StartMongoUpgrade(target), "starting mongo upgrade")
+}
@@ -0,0 +1,115 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/upgrades"
+)
+
+type mongoEngineSuite struct {
+	coretesting.BaseSuite
+	backend *mockMongoUpgradeBackend
+	context *mockContext
+}
+
+var _ = gc.Suite(&mongoEngineSuite{})
+
+func (s *mongoEngineSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockMongoUpgradeBackend{
+		check: state.MongoUpgradeCheck{
+			Current:  mongo.Mongo24,
+			Target:   mongo.Mongo36wt,
+			DataSize: 1 << 30,
+			Members:  3,
+			Estimate: mongo.StorageEngineMigrationEstimate{
+				Duration: 5 * time.Minute,
+				Downtime: 45 * time.Second,
+			},
+		},
+	}
+	s.context = &mockContext{state: s.backend}
+	s.PatchValue(upgrades.InstalledMongoVersion, func() (mongo.Version, error) {
+		return mongo.Mongo36wt, nil
+	})
+}
+
+func (s *mongoEngineSuite) TestStartMigration(c *gc.C) {
+	err := upgrades.StartMongoStorageEngineMigration(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "CheckMongoUpgrade", "StartMongoUpgrade")
+	s.backend.CheckCall(c, 0, "CheckMongoUpgrade", mongo.Mongo36wt)
+	s.backend.CheckCall(c, 1, "StartMongoUpgrade", mongo.Mongo36wt)
+	c.Check(c.GetTestLog(), jc.Contains,
+		"migrating database from mongo 2.4/mmapv1 to 3.6/wiredTiger: 1.1 GB to resync on 3 controllers, "+
+			"estimated to take 5m0s with 45s of downtime",
+	)
+}
+
+func (s *mongoEngineSuite) TestAlreadyMigrated(c *gc.C) {
+	s.backend.check.Current = mongo.Mongo32wt
+	err := upgrades.StartMongoStorageEngineMigration(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "CheckMongoUpgrade")
+}
+
+func (s *mongoEngineSuite) TestChecksFail(c *gc.C) {
+	s.backend.check.Problems = []string{
+		`the controller has a single database member; run "juju enable-ha" before migrating`,
+	}
+	err := upgrades.StartMongoStorageEngineMigration(s.context)
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "CheckMongoUpgrade")
+	c.Check(c.GetTestLog(), jc.Contains,
+		`not migrating database from mongo 2.4/mmapv1 to 3.6/wiredTiger: the controller has a single database member`,
+	)
+}
+
+func (s *mongoEngineSuite) TestCheckError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	err := upgrades.StartMongoStorageEngineMigration(s.context)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.backend.CheckCallNames(c, "CheckMongoUpgrade")
+}
+
+func (s *mongoEngineSuite) TestStartError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	err := upgrades.StartMongoStorageEngineMigration(s.context)
+	c.Assert(err, gc.ErrorMatches, "starting mongo upgrade: boom")
+}
+
+func (s *mongoEngineSuite) TestNoMongoInstalled(c *gc.C) {
+	s.PatchValue(upgrades.InstalledMongoVersion, func() (mongo.Version, error) {
+		return mongo.Version{}, errors.New("no mongod")
+	})
+	err := upgrades.StartMongoStorageEngineMigration(s.context)
+	c.Assert(err, gc.ErrorMatches, "finding installed mongo: no mongod")
+	s.backend.CheckNoCalls(c)
+}
+
+type mockMongoUpgradeBackend struct {
+	upgrades.StateBackend
+	testing.Stub
+	check state.MongoUpgradeCheck
+}
+
+func (b *mockMongoUpgradeBackend) CheckMongoUpgrade(target mongo.Version) (state.MongoUpgradeCheck, error) {
+	b.MethodCall(b, "CheckMongoUpgrade", target)
+	return b.check, b.NextErr()
+}
+
+func (b *mockMongoUpgradeBackend) StartMongoUpgrade(target mongo.Version) error {
+	b.MethodCall(b, "StartMongoUpgrade", target)
+	return b.NextErr()
+}
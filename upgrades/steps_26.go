@@ -13,5 +13,10 @@ func stateStepsFor26() []Step {
 				return context.State().UpdateKubernetesStorageConfig()
 			},
 		},
+		&upgradeStep{
+			description: "start mongo storage engine migration",
+			targets:     []Target{DatabaseMaster},
+			run:         StartMongoStorageEngineMigration,
+		},
 	}
 }
@@ -25,3 +25,8 @@ func (s *steps26Suite) TestUpdateInheritedControllerConfig(c *gc.C) {
 	// Logic for step itself is tested in state package.
 	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
 }
+
+func (s *steps26Suite) TestStartMongoStorageEngineMigration(c *gc.C) {
+	step := findStateStep(c, v26, `start mongo storage engine migration`)
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
@@ -4,6 +4,8 @@
 package agentconfigupdater
 
 import (
+	"strconv"
+
 	"github.com/juju/errors"
 	"github.com/juju/pubsub"
 	"gopkg.in/juju/names.v2"
@@ -13,6 +15,7 @@ import (
 	coreagent "github.com/juju/juju/agent"
 	apiagent "github.com/juju/juju/api/agent"
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/mongo"
 	jworker "github.com/juju/juju/worker"
 )
@@ -39,6 +42,8 @@ type ManifoldConfig struct {
 // runs after the API connection has come up. If the machine agent is
 // a controller, it grabs the state serving info over the API and
 // records it to agent configuration, and then stops.
+// Otherwise it records the agent logging values of the controller
+// config, restarting the agent if they changed, and then stops.
 func Manifold(config ManifoldConfig) dependency.Manifold {
 	return dependency.Manifold{
 		Inputs: []string{
@@ -75,8 +80,9 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			if controller, err := isController(apiState, tag); err != nil {
 				return nil, errors.Annotate(err, "checking controller status")
 			} else if !controller {
-				// Not a controller, nothing to do.
-				return nil, dependency.ErrUninstall
+				// Not a controller, only the logging values need
+				// to be kept up to date.
+				return nil, updateLogValues(agent, apiState, config.Logger)
 			}
 
 			// Do the initial state serving info and mongo profile checks
@@ -98,6 +104,15 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			configMongoMemoryProfile := mongo.MemoryProfile(controllerConfig.MongoMemoryProfile())
 			mongoProfileChanged := agentsMongoMemoryProfile != configMongoMemoryProfile
 
+			// The raft worker reads its log thresholds from the agent
+			// config when it starts, so they need a restart too.
+			configRaftValues := raftValues(controllerConfig)
+			raftValuesChanged := valuesChanged(currentConfig.Value, configRaftValues)
+
+			// The agent's log writers are set up when it starts.
+			configLogValues := logValues(controllerConfig)
+			logValuesChanged := valuesChanged(currentConfig.Value, configLogValues)
+
 			info, err := apiState.StateServingInfo()
 			if err != nil {
 				return nil, errors.Annotate(err, "getting state serving info")
@@ -120,6 +135,18 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 					logger.Debugf("setting agent config mongo memory profile: %q => %q", agentsMongoMemoryProfile, configMongoMemoryProfile)
 					config.SetMongoMemoryProfile(configMongoMemoryProfile)
 				}
+				if raftValuesChanged {
+					logger.Debugf("setting agent config raft values: %v", configRaftValues)
+					for key, value := range configRaftValues {
+						config.SetValue(key, value)
+					}
+				}
+				if logValuesChanged {
+					logger.Debugf("setting agent config logging values: %v", configLogValues)
+					for key, value := range configLogValues {
+						config.SetValue(key, value)
+					}
+				}
 				return nil
 			})
 			if err != nil {
@@ -130,6 +157,14 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				logger.Infof("restarting agent for new mongo memory profile")
 				return nil, jworker.ErrRestartAgent
 			}
+			if raftValuesChanged {
+				logger.Infof("restarting agent for new raft log thresholds")
+				return nil, jworker.ErrRestartAgent
+			}
+			if logValuesChanged {
+				logger.Infof("restarting agent for new logging values")
+				return nil, jworker.ErrRestartAgent
+			}
 
 			// Only get the hub if we are a controller and we haven't updated
 			// the memory profile.
@@ -143,12 +178,91 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				Agent:        agent,
 				Hub:          hub,
 				MongoProfile: configMongoMemoryProfile,
+				RaftValues:   configRaftValues,
+				LogValues:    configLogValues,
 				Logger:       config.Logger,
 			})
 		},
 	}
 }
 
+// raftValues returns the agent config values holding the raft log
+// thresholds in the controller config. Default thresholds are recorded
+// as empty values, so that agents aren't restarted just because the
+// values have never been written.
+func raftValues(controllerConfig controller.Config) map[string]string {
+	values := make(map[string]string)
+	set := func(key string, value, defaultValue int) {
+		if value == defaultValue {
+			values[key] = ""
+		} else {
+			values[key] = strconv.Itoa(value)
+		}
+	}
+	set(coreagent.RaftSnapshotThreshold, controllerConfig.RaftSnapshotThreshold(), controller.DefaultRaftSnapshotThreshold)
+	set(coreagent.RaftTrailingLogs, controllerConfig.RaftTrailingLogs(), controller.DefaultRaftTrailingLogs)
+	return values
+}
+
+// logValues returns the agent config values holding where agents write
+// their logs and how their log files are rotated. As with raftValues,
+// defaults are recorded as empty values.
+func logValues(controllerConfig controller.Config) map[string]string {
+	values := map[string]string{
+		coreagent.AgentLogTarget:         "",
+		coreagent.AgentLogfileMaxSizeMB:  "",
+		coreagent.AgentLogfileMaxBackups: "",
+	}
+	if target := controllerConfig.AgentLogTarget(); target != controller.DefaultAgentLogTarget {
+		values[coreagent.AgentLogTarget] = target
+	}
+	if size := controllerConfig.AgentLogfileMaxSizeMB(); size != controller.DefaultAgentLogfileMaxSizeMB {
+		values[coreagent.AgentLogfileMaxSizeMB] = strconv.Itoa(size)
+	}
+	if backups := controllerConfig.AgentLogfileMaxBackups(); backups != controller.DefaultAgentLogfileMaxBackups {
+		values[coreagent.AgentLogfileMaxBackups] = strconv.Itoa(backups)
+	}
+	return values
+}
+
+// updateLogValues records the logging values of the controller config
+// in the agent config of a machine that isn't a controller. It returns
+// ErrRestartAgent if they changed, so that the agent's log writers are
+// set up again, and dependency.ErrUninstall otherwise.
+func updateLogValues(agent coreagent.Agent, apiState *apiagent.State, logger Logger) error {
+	controllerConfig, err := apiState.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "getting controller config")
+	}
+	configLogValues := logValues(controllerConfig)
+	if !valuesChanged(agent.CurrentConfig().Value, configLogValues) {
+		return dependency.ErrUninstall
+	}
+	err = agent.ChangeConfig(func(config coreagent.ConfigSetter) error {
+		logger.Debugf("setting agent config logging values: %v", configLogValues)
+		for key, value := range configLogValues {
+			config.SetValue(key, value)
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("restarting agent for new logging values")
+	return jworker.ErrRestartAgent
+}
+
+// valuesChanged returns whether any of the values differ from the
+// current ones.
+func valuesChanged(current func(key string) string, values map[string]string) bool {
+	for key, value := range values {
+		if current(key) != value {
+			return true
+		}
+	}
+	return false
+}
+
 func isController(apiState *apiagent.State, tag names.MachineTag) (bool, error) {
 	machine, err := apiState.Entity(tag)
 	if err != nil {
@@ -269,6 +269,22 @@ func (s *AgentConfigUpdaterSuite) TestProfileDifferenceRestarts(c *gc.C) {
 	c.Assert(a.conf.profileSet, jc.IsTrue)
 }
 
+func (s *AgentConfigUpdaterSuite) TestRaftValuesDifferenceRestarts(c *gc.C) {
+	const mockAPIPort = 1234
+
+	a := &mockAgent{}
+	a.conf.values = map[string]string{
+		agent.RaftSnapshotThreshold: "1024",
+	}
+	w, err := s.startManifold(c, a, mockAPIPort)
+	c.Assert(w, gc.IsNil)
+	c.Assert(err, gc.Equals, jworker.ErrRestartAgent)
+
+	// The controller config uses the default thresholds.
+	c.Assert(a.conf.values[agent.RaftSnapshotThreshold], gc.Equals, "")
+	c.Assert(a.conf.profileSet, jc.IsFalse)
+}
+
 func (s *AgentConfigUpdaterSuite) TestJobManageEnvironNotOverwriteCert(c *gc.C) {
 	// State serving info should be set for machines with JobManageEnviron.
 	const mockAPIPort = 1234
@@ -298,9 +314,45 @@ func (s *AgentConfigUpdaterSuite) TestJobHostUnits(c *gc.C) {
 	s.checkNotController(c, multiwatcher.JobHostUnits)
 }
 
+func (s *AgentConfigUpdaterSuite) TestJobHostUnitsLogValuesDifferenceRestarts(c *gc.C) {
+	a := &mockAgent{}
+	apiCaller := s.notControllerAPICaller(c, multiwatcher.JobHostUnits, map[string]interface{}{
+		"agent-log-target":          "file+journald",
+		"agent-logfile-max-backups": 5,
+	})
+	w, err := s.manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"agent":       a,
+		"api-caller":  apiCaller,
+		"central-hub": s.hub,
+	}))
+	c.Assert(w, gc.IsNil)
+	c.Assert(err, gc.Equals, jworker.ErrRestartAgent)
+
+	c.Assert(a.conf.values, jc.DeepEquals, map[string]string{
+		agent.AgentLogTarget:         "file+journald",
+		agent.AgentLogfileMaxSizeMB:  "",
+		agent.AgentLogfileMaxBackups: "5",
+	})
+	c.Assert(a.conf.ssiSet, jc.IsFalse)
+}
+
 func (s *AgentConfigUpdaterSuite) checkNotController(c *gc.C, job multiwatcher.MachineJob) {
 	a := &mockAgent{}
-	apiCaller := basetesting.APICallerFunc(
+	apiCaller := s.notControllerAPICaller(c, job, nil)
+	w, err := s.manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"agent":       a,
+		"api-caller":  apiCaller,
+		"central-hub": s.hub,
+	}))
+	c.Assert(w, gc.IsNil)
+	c.Assert(err, gc.Equals, dependency.ErrUninstall)
+
+	// State serving info shouldn't have been set for this job type.
+	c.Assert(a.conf.ssiSet, jc.IsFalse)
+}
+
+func (s *AgentConfigUpdaterSuite) notControllerAPICaller(c *gc.C, job multiwatcher.MachineJob, controllerConfig map[string]interface{}) basetesting.APICallerFunc {
+	return basetesting.APICallerFunc(
 		func(objType string, version int, id, request string, args, response interface{}) error {
 			c.Assert(objType, gc.Equals, "Agent")
 			switch request {
@@ -310,22 +362,17 @@ func (s *AgentConfigUpdaterSuite) checkNotController(c *gc.C, job multiwatcher.M
 				result.Entities = []params.AgentGetEntitiesResult{{
 					Jobs: []multiwatcher.MachineJob{job},
 				}}
+			case "ControllerConfig":
+				result := response.(*params.ControllerConfigResult)
+				*result = params.ControllerConfigResult{
+					Config: controllerConfig,
+				}
 			default:
 				c.Fatalf("not sure how to handle: %q", request)
 			}
 			return nil
 		},
 	)
-	w, err := s.manifold.Start(dt.StubContext(nil, map[string]interface{}{
-		"agent":       a,
-		"api-caller":  apiCaller,
-		"central-hub": s.hub,
-	}))
-	c.Assert(w, gc.IsNil)
-	c.Assert(err, gc.Equals, dependency.ErrUninstall)
-
-	// State serving info shouldn't have been set for this job type.
-	c.Assert(a.conf.ssiSet, jc.IsFalse)
 }
 
 type mockAgent struct {
@@ -349,6 +396,8 @@ type mockConfig struct {
 
 	profile    string
 	profileSet bool
+
+	values map[string]string
 }
 
 func (mc *mockConfig) Tag() names.Tag {
@@ -383,6 +432,17 @@ func (mc *mockConfig) SetMongoMemoryProfile(profile mongo.MemoryProfile) {
 	mc.profileSet = true
 }
 
+func (mc *mockConfig) Value(key string) string {
+	return mc.values[key]
+}
+
+func (mc *mockConfig) SetValue(key, value string) {
+	if mc.values == nil {
+		mc.values = make(map[string]string)
+	}
+	mc.values[key] = value
+}
+
 func (mc *mockConfig) LogDir() string {
 	return "log-dir"
 }
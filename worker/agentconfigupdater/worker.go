@@ -23,6 +23,8 @@ type WorkerConfig struct {
 	Agent        coreagent.Agent
 	Hub          *pubsub.StructuredHub
 	MongoProfile mongo.MemoryProfile
+	RaftValues   map[string]string
+	LogValues    map[string]string
 	Logger       Logger
 }
 
@@ -45,6 +47,8 @@ type agentConfigUpdater struct {
 
 	tomb         tomb.Tomb
 	mongoProfile mongo.MemoryProfile
+	raftValues   map[string]string
+	logValues    map[string]string
 }
 
 // NewWorker creates a new agent config updater worker.
@@ -57,6 +61,8 @@ func NewWorker(config WorkerConfig) (worker.Worker, error) {
 	w := &agentConfigUpdater{
 		config:       config,
 		mongoProfile: config.MongoProfile,
+		raftValues:   config.RaftValues,
+		logValues:    config.LogValues,
 	}
 	w.tomb.Go(func() error {
 		return w.loop(started)
@@ -91,14 +97,37 @@ func (w *agentConfigUpdater) onConfigChanged(topic string, data controllermsg.Co
 	}
 
 	mongoProfile := mongo.MemoryProfile(data.Config.MongoMemoryProfile())
-	if mongoProfile == w.mongoProfile {
+	mongoProfileChanged := mongoProfile != w.mongoProfile
+	newRaftValues := raftValues(data.Config)
+	raftValuesChanged := valuesChanged(func(key string) string {
+		return w.raftValues[key]
+	}, newRaftValues)
+	newLogValues := logValues(data.Config)
+	logValuesChanged := valuesChanged(func(key string) string {
+		return w.logValues[key]
+	}, newLogValues)
+	if !mongoProfileChanged && !raftValuesChanged && !logValuesChanged {
 		// Nothing to do, all good.
 		return
 	}
 
 	err = w.config.Agent.ChangeConfig(func(setter coreagent.ConfigSetter) error {
-		w.config.Logger.Debugf("setting agent config mongo memory profile: %q => %q", w.mongoProfile, mongoProfile)
-		setter.SetMongoMemoryProfile(mongoProfile)
+		if mongoProfileChanged {
+			w.config.Logger.Debugf("setting agent config mongo memory profile: %q => %q", w.mongoProfile, mongoProfile)
+			setter.SetMongoMemoryProfile(mongoProfile)
+		}
+		if raftValuesChanged {
+			w.config.Logger.Debugf("setting agent config raft values: %v", newRaftValues)
+			for key, value := range newRaftValues {
+				setter.SetValue(key, value)
+			}
+		}
+		if logValuesChanged {
+			w.config.Logger.Debugf("setting agent config logging values: %v", newLogValues)
+			for key, value := range newLogValues {
+				setter.SetValue(key, value)
+			}
+		}
 		return nil
 	})
 	if err != nil {
@@ -14,6 +14,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
+	"github.com/juju/juju/agent"
 	"github.com/juju/juju/controller"
 	controllermsg "github.com/juju/juju/pubsub/controller"
 	jworker "github.com/juju/juju/worker"
@@ -145,3 +146,82 @@ func (s *WorkerSuite) TestUpdateMongoProfile(c *gc.C) {
 
 	c.Assert(err, gc.Equals, jworker.ErrRestartAgent)
 }
+
+func (s *WorkerSuite) TestUpdateRaftValues(c *gc.C) {
+	w, err := agentconfigupdater.NewWorker(s.config)
+	c.Assert(w, gc.NotNil)
+	c.Check(err, jc.ErrorIsNil)
+
+	newConfig := controllermsg.ConfigChangedMessage{
+		Config: controller.Config{
+			controller.MongoMemoryProfile:    controller.DefaultMongoMemoryProfile,
+			controller.RaftSnapshotThreshold: controller.DefaultRaftSnapshotThreshold,
+		},
+	}
+	handled, err := s.hub.Publish(controllermsg.ConfigChanged, newConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-handled:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("event not handled")
+	}
+
+	// Thresholds the same, worker still alive.
+	workertest.CheckAlive(c, w)
+
+	newConfig.Config[controller.RaftTrailingLogs] = 512
+	handled, err = s.hub.Publish(controllermsg.ConfigChanged, newConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-handled:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("event not handled")
+	}
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.Equals, jworker.ErrRestartAgent)
+	c.Assert(s.agent.conf.values, jc.DeepEquals, map[string]string{
+		agent.RaftSnapshotThreshold: "",
+		agent.RaftTrailingLogs:      "512",
+	})
+}
+
+func (s *WorkerSuite) TestUpdateLogValues(c *gc.C) {
+	w, err := agentconfigupdater.NewWorker(s.config)
+	c.Assert(w, gc.NotNil)
+	c.Check(err, jc.ErrorIsNil)
+
+	newConfig := controllermsg.ConfigChangedMessage{
+		Config: controller.Config{
+			controller.MongoMemoryProfile: controller.DefaultMongoMemoryProfile,
+			controller.AgentLogTarget:     controller.DefaultAgentLogTarget,
+		},
+	}
+	handled, err := s.hub.Publish(controllermsg.ConfigChanged, newConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-handled:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("event not handled")
+	}
+
+	// Logging values the same, worker still alive.
+	workertest.CheckAlive(c, w)
+
+	newConfig.Config[controller.AgentLogTarget] = controller.AgentLogTargetJournald
+	handled, err = s.hub.Publish(controllermsg.ConfigChanged, newConfig)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-handled:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("event not handled")
+	}
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.Equals, jworker.ErrRestartAgent)
+	c.Assert(s.agent.conf.values, jc.DeepEquals, map[string]string{
+		agent.AgentLogTarget:         "journald",
+		agent.AgentLogfileMaxSizeMB:  "",
+		agent.AgentLogfileMaxBackups: "",
+	})
+}
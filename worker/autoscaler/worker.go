@@ -0,0 +1,146 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaler
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/autoscaler"
+	"github.com/juju/juju/core/autoscale"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.autoscaler")
+
+const (
+	// DefaultPeriod is the time between checks of the autoscaled
+	// applications.
+	DefaultPeriod = time.Minute
+
+	// DefaultWindow is how far back metric values are taken into
+	// account.
+	DefaultWindow = 10 * time.Minute
+)
+
+// Facade exposes the controller functionality needed by the
+// autoscaler.
+type Facade interface {
+	AutoscaledApplications() ([]autoscaler.Application, error)
+	ApplicationMetric(app names.ApplicationTag, metric string, since time.Time) ([]float64, error)
+	ScaleApplication(app names.ApplicationTag, event autoscale.Event) error
+}
+
+// Config holds the configuration and dependencies for an autoscaler
+// worker.
+type Config struct {
+	// Facade is used to read the autoscaling policies and metric
+	// values of the model's applications, and to scale them.
+	Facade Facade
+
+	// Clock is used to time the cooldowns and metric windows.
+	Clock clock.Clock
+
+	// NewTimer is used to schedule the checks.
+	NewTimer jworker.NewTimerFunc
+
+	// Period is the time between checks.
+	Period time.Duration
+
+	// Window is how far back metric values are taken into account.
+	Window time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start an
+// autoscaler.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewTimer == nil {
+		return errors.NotValidf("nil NewTimer")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	if config.Window <= 0 {
+		return errors.NotValidf("non-positive Window")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that periodically scales the model's
+// autoscaled applications. An application is kept within the minimum
+// and maximum number of units of its policy, and otherwise gains or
+// loses one unit at a time when its metric expression crosses the
+// policy's thresholds, no more often than the policy's cooldown allows.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	a := &autoscalerWorker{config: config}
+	scale := func(stop <-chan struct{}) error {
+		return a.scale()
+	}
+	return jworker.NewPeriodicWorker(scale, config.Period, config.NewTimer), nil
+}
+
+type autoscalerWorker struct {
+	config Config
+}
+
+// scale scales each of the autoscaled applications. Failing to scale
+// one application doesn't stop the others from being scaled.
+func (a *autoscalerWorker) scale() error {
+	apps, err := a.config.Facade.AutoscaledApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := a.config.Clock.Now()
+	for _, app := range apps {
+		if err := a.scaleApplication(app, now); err != nil {
+			logger.Warningf("cannot autoscale %s: %v", names.ReadableString(app.Tag), err)
+		}
+	}
+	return nil
+}
+
+func (a *autoscalerWorker) scaleApplication(app autoscaler.Application, now time.Time) error {
+	policy := app.Policy
+	var values []float64
+	if app.Units >= policy.MinUnits && app.Units <= policy.MaxUnits {
+		// Only changes made because of the metric expression wait
+		// for the cooldown to pass.
+		if n := len(app.Events); n > 0 && now.Before(app.Events[n-1].Time.Add(policy.Cooldown)) {
+			return nil
+		}
+		expr, err := autoscale.ParseExpression(policy.Metric)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		values, err = a.config.Facade.ApplicationMetric(app.Tag, expr.Metric, now.Add(-a.config.Window))
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	target, reason := policy.Target(app.Units, values)
+	if target == app.Units {
+		return nil
+	}
+	logger.Infof("scaling %s from %d to %d units: %s", names.ReadableString(app.Tag), app.Units, target, reason)
+	return errors.Trace(a.config.Facade.ScaleApplication(app.Tag, autoscale.Event{
+		Time:   now,
+		From:   app.Units,
+		To:     target,
+		Reason: reason,
+	}))
+}
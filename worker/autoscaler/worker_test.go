@@ -0,0 +1,249 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscaler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/api/autoscaler"
+	"github.com/juju/juju/core/autoscale"
+	coretesting "github.com/juju/juju/testing"
+	jworker "github.com/juju/juju/worker"
+	autoscalerworker "github.com/juju/juju/worker/autoscaler"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	facade *mockFacade
+	clock  *testclock.Clock
+	ticks  chan time.Time
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var (
+	now    = time.Date(2019, 5, 8, 12, 0, 0, 0, time.UTC)
+	policy = autoscale.Policy{
+		MinUnits:       1,
+		MaxUnits:       3,
+		Metric:         "max(cpu)",
+		ScaleUpAbove:   80,
+		ScaleDownBelow: 20,
+		Cooldown:       10 * time.Minute,
+	}
+	mysql = names.NewApplicationTag("mysql")
+	wp    = names.NewApplicationTag("wordpress")
+)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &mockFacade{
+		values: map[string][]float64{
+			"mysql":     {50, 90},
+			"wordpress": {10, 15},
+		},
+		scaled: make(chan scaleCall, 2),
+	}
+	s.clock = testclock.NewClock(now)
+	s.ticks = make(chan time.Time)
+}
+
+func (s *WorkerSuite) config() autoscalerworker.Config {
+	return autoscalerworker.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		NewTimer: func(time.Duration) jworker.PeriodicTimer {
+			return &fakeTimer{s.ticks}
+		},
+		Period: time.Minute,
+		Window: 5 * time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	_, err := autoscalerworker.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Clock = nil
+	_, err = autoscalerworker.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config()
+	config.Period = 0
+	_, err = autoscalerworker.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+
+	config = s.config()
+	config.Window = 0
+	_, err = autoscalerworker.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "non-positive Window not valid")
+}
+
+func (s *WorkerSuite) TestScalesOnMetric(c *gc.C) {
+	s.facade.apps = []autoscaler.Application{
+		{Tag: mysql, Policy: policy, Units: 2},
+		{Tag: wp, Policy: policy, Units: 2},
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.tick(c)
+	c.Assert(s.nextScale(c), jc.DeepEquals, scaleCall{mysql, autoscale.Event{
+		Time: now, From: 2, To: 3, Reason: "max(cpu) 90 above 80",
+	}})
+	c.Assert(s.nextScale(c), jc.DeepEquals, scaleCall{wp, autoscale.Event{
+		Time: now, From: 2, To: 1, Reason: "max(cpu) 15 below 20",
+	}})
+	c.Assert(s.facade.metricCalls(), jc.DeepEquals, []metricCall{
+		{mysql, "cpu", now.Add(-5 * time.Minute)},
+		{wp, "cpu", now.Add(-5 * time.Minute)},
+	})
+}
+
+func (s *WorkerSuite) TestCooldown(c *gc.C) {
+	s.facade.apps = []autoscaler.Application{{
+		Tag:    mysql,
+		Policy: policy,
+		Units:  2,
+		Events: []autoscale.Event{{Time: now.Add(-5 * time.Minute), From: 1, To: 2}},
+	}, {
+		Tag:    wp,
+		Policy: policy,
+		Units:  2,
+		Events: []autoscale.Event{{Time: now.Add(-10 * time.Minute), From: 3, To: 2}},
+	}}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.tick(c)
+	c.Assert(s.nextScale(c).App, gc.Equals, wp)
+	// Applications are scaled in order, so mysql has been checked.
+	c.Assert(s.facade.metricCalls(), jc.DeepEquals, []metricCall{
+		{wp, "cpu", now.Add(-5 * time.Minute)},
+	})
+}
+
+func (s *WorkerSuite) TestBoundsIgnoreCooldown(c *gc.C) {
+	s.facade.apps = []autoscaler.Application{{
+		Tag:    mysql,
+		Policy: policy,
+		Units:  5,
+		Events: []autoscale.Event{{Time: now, From: 4, To: 5}},
+	}}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.tick(c)
+	c.Assert(s.nextScale(c), jc.DeepEquals, scaleCall{mysql, autoscale.Event{
+		Time: now, From: 5, To: 3, Reason: "above maximum of 3 units",
+	}})
+	c.Assert(s.facade.metricCalls(), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestErrorDoesNotStopOtherApplications(c *gc.C) {
+	s.facade.apps = []autoscaler.Application{
+		{Tag: mysql, Policy: policy, Units: 2},
+		{Tag: wp, Policy: policy, Units: 2},
+	}
+	s.facade.metricErr = map[string]error{"mysql": errors.New("boom")}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.tick(c)
+	c.Assert(s.nextScale(c).App, gc.Equals, wp)
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := autoscalerworker.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) tick(c *gc.C) {
+	select {
+	case s.ticks <- time.Time{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out triggering autoscaling")
+	}
+}
+
+func (s *WorkerSuite) nextScale(c *gc.C) scaleCall {
+	select {
+	case call := <-s.facade.scaled:
+		return call
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for application to be scaled")
+	}
+	return scaleCall{}
+}
+
+type fakeTimer struct {
+	ticks chan time.Time
+}
+
+func (t *fakeTimer) Reset(time.Duration) bool {
+	return true
+}
+
+func (t *fakeTimer) CountDown() <-chan time.Time {
+	return t.ticks
+}
+
+type metricCall struct {
+	App    names.ApplicationTag
+	Metric string
+	Since  time.Time
+}
+
+type scaleCall struct {
+	App   names.ApplicationTag
+	Event autoscale.Event
+}
+
+type mockFacade struct {
+	mu        sync.Mutex
+	apps      []autoscaler.Application
+	values    map[string][]float64
+	metricErr map[string]error
+	metrics   []metricCall
+	scaled    chan scaleCall
+}
+
+func (f *mockFacade) AutoscaledApplications() ([]autoscaler.Application, error) {
+	return f.apps, nil
+}
+
+func (f *mockFacade) ApplicationMetric(app names.ApplicationTag, metric string, since time.Time) ([]float64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.metrics = append(f.metrics, metricCall{app, metric, since})
+	if err := f.metricErr[app.Id()]; err != nil {
+		return nil, err
+	}
+	return f.values[app.Id()], nil
+}
+
+func (f *mockFacade) metricCalls() []metricCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.metrics
+}
+
+func (f *mockFacade) ScaleApplication(app names.ApplicationTag, event autoscale.Event) error {
+	f.scaled <- scaleCall{app, event}
+	return nil
+}
@@ -4,6 +4,7 @@
 package caasfirewaller
 
 import (
+	"reflect"
 	"strings"
 
 	"github.com/juju/errors"
@@ -11,6 +12,7 @@ import (
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
 
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/environs/tags"
 )
 
@@ -26,6 +28,7 @@ type applicationWorker struct {
 
 	initial           bool
 	previouslyExposed bool
+	previousConfig    application.ConfigAttributes
 }
 
 func newApplicationWorker(
@@ -108,28 +111,37 @@ func (w *applicationWorker) processApplicationChange() (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if !w.initial && exposed == w.previouslyExposed {
-		return nil
-	}
-
-	w.initial = false
-	w.previouslyExposed = exposed
-	if exposed {
-		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
-		if err != nil {
-			return errors.Trace(err)
+	if !exposed {
+		if !w.initial && !w.previouslyExposed {
+			return nil
 		}
-		resourceTags := tags.ResourceTags(
-			names.NewModelTag(w.modelUUID),
-			names.NewControllerTag(w.controllerUUID),
-		)
-		if err := w.serviceExposer.ExposeService(w.application, resourceTags, appConfig); err != nil {
+		w.initial = false
+		w.previouslyExposed = false
+		w.previousConfig = nil
+		if err := w.serviceExposer.UnexposeService(w.application); err != nil {
 			return errors.Trace(err)
 		}
 		return nil
 	}
-	if err := w.serviceExposer.UnexposeService(w.application); err != nil {
+
+	// The application is exposed; (re)apply the exposure if it was
+	// not previously exposed or its config has changed since.
+	appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !w.initial && w.previouslyExposed && reflect.DeepEqual(appConfig, w.previousConfig) {
+		return nil
+	}
+	w.initial = false
+	w.previouslyExposed = true
+	resourceTags := tags.ResourceTags(
+		names.NewModelTag(w.modelUUID),
+		names.NewControllerTag(w.controllerUUID),
+	)
+	if err := w.serviceExposer.ExposeService(w.application, resourceTags, appConfig); err != nil {
 		return errors.Trace(err)
 	}
+	w.previousConfig = appConfig
 	return nil
 }
@@ -50,6 +50,7 @@ type mockApplicationGetter struct {
 	allWatcher *watchertest.MockStringsWatcher
 	appWatcher *watchertest.MockNotifyWatcher
 	exposed    bool
+	appConfig  application.ConfigAttributes
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -78,7 +79,10 @@ func (m *mockApplicationGetter) IsExposed(appName string) (bool, error) {
 
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig", appName)
-	return application.ConfigAttributes{"juju-external-hostname": "exthost"}, a.NextErr()
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.appConfig, nil
 }
 
 type mockLifeGetter struct {
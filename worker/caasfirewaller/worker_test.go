@@ -46,6 +46,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.applicationGetter = mockApplicationGetter{
 		allWatcher: watchertest.NewMockStringsWatcher(s.applicationChanges),
 		appWatcher: watchertest.NewMockNotifyWatcher(s.appExposedChange),
+		appConfig:  application.ConfigAttributes{"juju-external-hostname": "exthost"},
 	}
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.applicationGetter.allWatcher) })
 
@@ -188,6 +189,52 @@ func (s *WorkerSuite) TestUnexposedChange(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) TestExposedConfigChange(c *gc.C) {
+	w, err := caasfirewaller.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.applicationChanges <- []string{"gitlab"}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending applications change")
+	}
+
+	s.applicationGetter.exposed = true
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+
+	// An unrelated change does not expose the service again.
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+		c.Fatal("service exposed unexpectedly")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	newConfig := application.ConfigAttributes{
+		"juju-external-hostname":        "exthost",
+		"kubernetes-ingress-tls-secret": "gitlab-tls",
+	}
+	s.applicationGetter.appConfig = newConfig
+	s.sendApplicationExposedChange(c)
+	select {
+	case <-s.serviceExposed:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be exposed")
+	}
+	s.serviceExposer.CheckCallNames(c, "ExposeService", "ExposeService")
+	s.serviceExposer.CheckCall(c, 1, "ExposeService", "gitlab",
+		map[string]string{
+			"juju-controller-uuid": coretesting.ControllerTag.Id(),
+			"juju-model-uuid":      coretesting.ModelTag.Id()},
+		newConfig)
+}
+
 func (s *WorkerSuite) TestWatchApplicationDead(c *gc.C) {
 	w, err := caasfirewaller.NewWorker(s.config)
 	c.Assert(err, jc.ErrorIsNil)
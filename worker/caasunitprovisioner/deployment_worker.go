@@ -4,6 +4,8 @@
 package caasunitprovisioner
 
 import (
+	"reflect"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
@@ -13,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -74,12 +77,18 @@ func (w *deploymentWorker) loop() error {
 		cw       watcher.NotifyWatcher
 		specChan watcher.NotifyChannel
 
-		currentScale int
-		currentSpec  string
+		currentScale  int
+		currentSpec   string
+		currentConfig application.ConfigAttributes
 	)
 
 	gotSpecNotify := false
 	serviceUpdated := false
+	// Custom resources dropped from the pod spec are only removed
+	// by the broker, so it's called until there are none left. It
+	// starts out true so that any dropped while the worker wasn't
+	// running are removed too.
+	hadCustomResources := true
 	scale := 0
 	for {
 		select {
@@ -136,27 +145,31 @@ func (w *deploymentWorker) loop() error {
 		}
 		specStr := info.PodSpec
 
-		if scale == currentScale && specStr == currentSpec {
+		// The pod spec watcher also reports application config changes,
+		// so that the service type, annotations etc are reconciled.
+		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if scale == currentScale && specStr == currentSpec && reflect.DeepEqual(appConfig, currentConfig) {
 			continue
 		}
 
 		currentScale = scale
 		currentSpec = specStr
-
-		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
-		if err != nil {
-			return errors.Trace(err)
-		}
+		currentConfig = appConfig
 		spec, err := w.broker.Provider().ParsePodSpec(specStr)
 		if err != nil {
 			return errors.Annotate(err, "cannot parse pod spec")
 		}
-		if len(spec.CustomResourceDefinitions) > 0 {
+		hasCustomResources := len(spec.CustomResourceDefinitions) > 0 || len(spec.CustomResources) > 0
+		if hasCustomResources || hadCustomResources {
 			err = w.broker.EnsureCustomResourceDefinition(w.application, spec)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			logger.Debugf("created/updated custom resource definition for %q.", w.application)
+			logger.Debugf("created/updated custom resources for %q.", w.application)
+			hadCustomResources = hasCustomResources
 		}
 		serviceParams := &caas.ServiceParams{
 			PodSpec:      spec,
@@ -157,6 +157,7 @@ type mockApplicationGetter struct {
 	watcher      *watchertest.MockStringsWatcher
 	scaleWatcher *watchertest.MockNotifyWatcher
 	scale        int
+	config       application.ConfigAttributes
 }
 
 func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, error) {
@@ -169,6 +170,9 @@ func (m *mockApplicationGetter) WatchApplications() (watcher.StringsWatcher, err
 
 func (a *mockApplicationGetter) ApplicationConfig(appName string) (application.ConfigAttributes, error) {
 	a.MethodCall(a, "ApplicationConfig", appName)
+	if a.config != nil {
+		return a.config, a.NextErr()
+	}
 	return application.ConfigAttributes{
 		"juju-external-hostname": "exthost",
 	}, a.NextErr()
@@ -269,10 +269,11 @@ func (s *WorkerSuite) TestScaleChangedInJuju(c *gc.C) {
 	s.podSpecGetter.CheckCall(c, 2, "ProvisioningInfo", "gitlab")
 	s.lifeGetter.CheckCallNames(c, "Life")
 	s.lifeGetter.CheckCall(c, 0, "Life", "gitlab")
-	s.serviceBroker.CheckCallNames(c, "WatchService", "EnsureService", "Service")
-	s.serviceBroker.CheckCall(c, 1, "EnsureService",
+	s.serviceBroker.CheckCallNames(c, "WatchService", "EnsureCustomResourceDefinition", "EnsureService", "Service")
+	s.serviceBroker.CheckCall(c, 1, "EnsureCustomResourceDefinition", "gitlab", &parsedSpec)
+	s.serviceBroker.CheckCall(c, 2, "EnsureService",
 		"gitlab", expectedServiceParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
-	s.serviceBroker.CheckCall(c, 2, "Service", "gitlab")
+	s.serviceBroker.CheckCall(c, 3, "Service", "gitlab")
 
 	s.serviceBroker.ResetCalls()
 	// Add another unit.
@@ -432,6 +433,30 @@ containers:
 		"gitlab", expectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestApplicationConfigChange(c *gc.C) {
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+
+	newConfig := application.ConfigAttributes{
+		"juju-external-hostname":  "exthost",
+		"kubernetes-service-type": "LoadBalancer",
+	}
+	s.applicationGetter.config = newConfig
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService",
+		"gitlab", expectedServiceParams, 1, newConfig)
+}
+
 func (s *WorkerSuite) TestNewPodSpecChangeCrd(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
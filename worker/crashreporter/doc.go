@@ -0,0 +1,7 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crashreporter provides a worker which periodically uploads
+// the crash reports an agent has kept locally to the controller, where
+// they are listed by "juju crash-reports".
+package crashreporter
@@ -0,0 +1,42 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter
+
+import (
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	apicrashreporter "github.com/juju/juju/api/crashreporter"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+	"github.com/juju/juju/core/crashreport"
+	jworker "github.com/juju/juju/worker"
+)
+
+// ManifoldConfig defines the names of the manifolds on which a Manifold will depend.
+type ManifoldConfig engine.AgentAPIManifoldConfig
+
+// Manifold returns a dependency manifold that runs a crash reporter
+// worker, using the resource names defined in the supplied config.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	typedConfig := engine.AgentAPIManifoldConfig(config)
+	return engine.AgentAPIManifold(typedConfig, newWorker)
+}
+
+// newWorker non-trivially wraps NewWorker for use in a engine.AgentAPIManifold.
+func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	if apiCaller.BestFacadeVersion("CrashReporter") == 0 {
+		// The controller can't store crash reports; keep them
+		// until the agent is connected to one that can.
+		logger.Debugf("controller does not support crash reports, uninstalling")
+		return nil, dependency.ErrUninstall
+	}
+	return NewWorker(Config{
+		Facade:   apicrashreporter.NewFacade(apiCaller),
+		Store:    crashreport.NewStore(agent.CrashReportDir(a.CurrentConfig())),
+		NewTimer: jworker.NewTimer,
+		Period:   DefaultPeriod,
+	})
+}
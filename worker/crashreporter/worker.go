@@ -0,0 +1,97 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/core/crashreport"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.crashreporter")
+
+// DefaultPeriod is the time between uploads of pending crash reports.
+const DefaultPeriod = 5 * time.Minute
+
+// Facade uploads crash reports to the controller.
+type Facade interface {
+	Upload(crashreport.Report) error
+}
+
+// Store holds the crash reports of the agent until they're uploaded.
+type Store interface {
+	Pending() ([]crashreport.Report, error)
+	MarkUploaded(id string) error
+}
+
+// Config holds the configuration for a crash reporter worker.
+type Config struct {
+	// Facade is used to upload the crash reports.
+	Facade Facade
+
+	// Store holds the crash reports to upload.
+	Store Store
+
+	// NewTimer is used to schedule the uploads.
+	NewTimer jworker.NewTimerFunc
+
+	// Period is the time between uploads.
+	Period time.Duration
+}
+
+// Validate reports whether or not the configuration is valid.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Store == nil {
+		return errors.NotValidf("nil Store")
+	}
+	if config.NewTimer == nil {
+		return errors.NotValidf("nil NewTimer")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which periodically uploads the pending
+// crash reports of the agent to the controller.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	upload := func(stop <-chan struct{}) error {
+		return uploadPending(config, stop)
+	}
+	return jworker.NewPeriodicWorker(upload, config.Period, config.NewTimer), nil
+}
+
+func uploadPending(config Config, stop <-chan struct{}) error {
+	reports, err := config.Store.Pending()
+	if err != nil {
+		return errors.Annotate(err, "cannot read pending crash reports")
+	}
+	for _, r := range reports {
+		select {
+		case <-stop:
+			return nil
+		default:
+		}
+		if err := config.Facade.Upload(r); err != nil {
+			return errors.Annotatef(err, "cannot upload crash report %q", r.ID)
+		}
+		if err := config.Store.MarkUploaded(r.ID); err != nil {
+			return errors.Trace(err)
+		}
+		logger.Infof("uploaded crash report %q", r.ID)
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crashreporter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/crashreport"
+	coretesting "github.com/juju/juju/testing"
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/crashreporter"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	store *crashreport.Store
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var crashTime = time.Date(2019, 5, 3, 10, 11, 12, 0, time.UTC)
+
+type facadeFunc func(crashreport.Report) error
+
+func (f facadeFunc) Upload(r crashreport.Report) error {
+	return f(r)
+}
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.store = crashreport.NewStore(c.MkDir())
+}
+
+func (s *WorkerSuite) config(facade facadeFunc) crashreporter.Config {
+	return crashreporter.Config{
+		Facade:   facade,
+		Store:    s.store,
+		NewTimer: jworker.NewTimer,
+		Period:   time.Hour,
+	}
+}
+
+func (s *WorkerSuite) report(c *gc.C, i int) crashreport.Report {
+	r := crashreport.New("machine-0", "2.6.1", crashTime.Add(time.Duration(i)*time.Minute), "panic: boom", "", "")
+	c.Assert(s.store.Write(r), jc.ErrorIsNil)
+	return r
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config(nil)
+	config.Facade = nil
+	_, err := crashreporter.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config(func(crashreport.Report) error { return nil })
+	config.Store = nil
+	_, err = crashreporter.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil Store not valid")
+
+	config = s.config(func(crashreport.Report) error { return nil })
+	config.Period = 0
+	_, err = crashreporter.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestUploadsPending(c *gc.C) {
+	r0, r1 := s.report(c, 0), s.report(c, 1)
+	uploaded := make(chan crashreport.Report, 2)
+	upload := func(r crashreport.Report) error {
+		uploaded <- r
+		return nil
+	}
+	w, err := crashreporter.NewWorker(s.config(upload))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	for _, expect := range []crashreport.Report{r0, r1} {
+		select {
+		case r := <-uploaded:
+			c.Assert(r, jc.DeepEquals, expect)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for crash report to be uploaded")
+		}
+	}
+	workertest.CleanKill(c, w)
+
+	pending, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestUploadError(c *gc.C) {
+	r := s.report(c, 0)
+	upload := func(crashreport.Report) error {
+		return errors.New("boom")
+	}
+	w, err := crashreporter.NewWorker(s.config(upload))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, `cannot upload crash report ".*": boom`)
+
+	pending, err := s.store.Pending()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, jc.DeepEquals, []crashreport.Report{r})
+}
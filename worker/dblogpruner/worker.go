@@ -66,8 +66,9 @@ func (w *pruneWorker) Report() map[string]interface{} {
 	// The keys used give a nice output when alphabetical
 	// which is how the report yaml gets serialised.
 	result := map[string]interface{}{
-		"prune-age":  report.maxLogAge,
-		"prune-size": report.maxCollectionMB,
+		"prune-age":      report.maxLogAge,
+		"prune-interval": report.interval,
+		"prune-size":     report.maxCollectionMB,
 	}
 	if !report.lastPrune.IsZero() {
 		result["last-prune"] = report.lastPrune.Round(time.Second)
@@ -93,6 +94,7 @@ type report struct {
 	nextPrune       time.Time
 	maxLogAge       time.Duration
 	maxCollectionMB int
+	interval        time.Duration
 	message         string
 	pruning         bool
 }
@@ -102,6 +104,7 @@ func (w *pruneWorker) loop() error {
 	defer worker.Stop(controllerConfigWatcher)
 
 	var prune <-chan time.Time
+	interval := w.config.PruneInterval
 	for {
 		select {
 		case <-w.tomb.Dying():
@@ -124,23 +127,34 @@ func (w *pruneWorker) loop() error {
 				w.mu.Unlock()
 				logger.Infof("log pruning config: max age: %v, max collection size %dM", newMaxAge, newMaxCollectionMB)
 			}
-			if prune == nil {
+			newInterval := controllerConfig.PruneLogsInterval()
+			if newInterval <= 0 {
+				newInterval = w.config.PruneInterval
+			}
+			if prune == nil || newInterval != interval {
 				// We defer starting the timer until the
 				// controller configuration watcher fires
 				// for the first time, and we have correct
 				// configuration values for pruning below.
-				prune = w.config.Clock.After(w.config.PruneInterval)
+				// If the interval changes, the next prune
+				// is rescheduled from now.
+				if prune != nil {
+					logger.Infof("log pruning interval changed: %v", newInterval)
+				}
+				interval = newInterval
+				prune = w.config.Clock.After(interval)
 				w.mu.Lock()
-				w.current.nextPrune = w.config.Clock.Now().Add(w.config.PruneInterval)
+				w.current.interval = interval
+				w.current.nextPrune = w.config.Clock.Now().Add(interval)
 				w.mu.Unlock()
 			}
 
 		case <-prune:
 			now := w.config.Clock.Now()
-			prune = w.config.Clock.After(w.config.PruneInterval)
+			prune = w.config.Clock.After(interval)
 			w.mu.Lock()
 			w.current.lastPrune = now
-			w.current.nextPrune = now.Add(w.config.PruneInterval)
+			w.current.nextPrune = now.Add(interval)
 			w.current.pruning = true
 			w.mu.Unlock()
 
@@ -108,6 +108,28 @@ func (s *suite) TestWorkerHasReport(c *gc.C) {
 	c.Assert(ok, jc.IsTrue)
 }
 
+func (s *suite) TestReportsConfiguredInterval(c *gc.C) {
+	s.setupState(c, "24h", "1024M")
+	s.startWorker(c)
+
+	r, ok := s.pruner.(interface {
+		Report() map[string]interface{}
+	})
+	c.Assert(ok, jc.IsTrue)
+
+	err := s.state.UpdateControllerConfig(map[string]interface{}{
+		"prune-logs-interval": "10m",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for attempt := testing.LongAttempt.Start(); attempt.Next(); {
+		if r.Report()["prune-interval"] == 10*time.Minute {
+			return
+		}
+	}
+	c.Fatalf("prune interval not updated: %v", r.Report())
+}
+
 func (s *suite) TestPrunesOldLogs(c *gc.C) {
 	maxLogAge := 24 * time.Hour
 	s.setupState(c, "24h", "1000P")
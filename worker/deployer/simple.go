@@ -149,6 +149,10 @@ func (ctx *SimpleContext) DeployUnit(unitName, initialPassword string) (err erro
 			Values: map[string]string{
 				agent.ContainerType: containerType,
 				agent.Namespace:     namespace,
+				// Unit agents log as configured for their machine agent.
+				agent.AgentLogTarget:         ctx.agentConfig.Value(agent.AgentLogTarget),
+				agent.AgentLogfileMaxSizeMB:  ctx.agentConfig.Value(agent.AgentLogfileMaxSizeMB),
+				agent.AgentLogfileMaxBackups: ctx.agentConfig.Value(agent.AgentLogfileMaxBackups),
 			},
 		})
 	if err != nil {
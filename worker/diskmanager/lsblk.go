@@ -28,6 +28,7 @@ const (
 
 	typeDisk = "disk"
 	typeLoop = "loop"
+	typeLVM  = "lvm"
 	typePart = "part"
 )
 
@@ -97,11 +98,13 @@ func listBlockDevices() ([]storage.BlockDevice, error) {
 			}
 		}
 
-		// We may later want to expand this, e.g. to handle lvm,
-		// dmraid, crypt, etc., but this is enough to cover bases
-		// for now.
+		// We may later want to expand this, e.g. to handle dmraid,
+		// crypt, etc., but this is enough to cover bases for now.
+		// LVM logical volumes are listed so that the volumes of the
+		// LVM storage provider can be matched to their devices.
 		switch deviceType {
 		case typeLoop:
+		case typeLVM:
 		case typePart:
 		case typeDisk:
 			// Floppy disks, which have major device number 2,
@@ -237,6 +237,7 @@ KNAME="sda1" SIZE="254803968" LABEL="" UUID="" TYPE="part"
 KNAME="loop0" SIZE="254803968" LABEL="" UUID="" TYPE="loop"
 KNAME="sr0" SIZE="254803968" LABEL="" UUID="" TYPE="rom"
 KNAME="whatever" SIZE="254803968" LABEL="" UUID="" TYPE="lvm"
+KNAME="md0" SIZE="254803968" LABEL="" UUID="" TYPE="raid1"
 EOF`)
 
 	devices, err := diskmanager.ListBlockDevices()
@@ -250,5 +251,8 @@ EOF`)
 	}, {
 		DeviceName: "loop0",
 		Size:       243,
+	}, {
+		DeviceName: "whatever",
+		Size:       243,
 	}})
 }
@@ -0,0 +1,290 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnspublisher
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+// coreDNSTimeout is the time allowed for each request to the etcd
+// cluster backing CoreDNS.
+const coreDNSTimeout = 30 * time.Second
+
+// CoreDNSConfig holds the details needed to reach the etcd cluster
+// backing CoreDNS.
+type CoreDNSConfig struct {
+	// Endpoint is the https URL of the etcd cluster.
+	Endpoint string
+
+	// CACert is the certificate of the CA that signed the etcd
+	// cluster's certificate, or "" to trust the system's CAs.
+	CACert string
+
+	// ClientCert and ClientKey authenticate to the etcd cluster.
+	ClientCert string
+	ClientKey  string
+}
+
+// NewCoreDNSZones returns a DNSZones that publishes records for the
+// CoreDNS etcd plugin, using the JSON gateway of the etcd v3 cluster
+// described by the config. Requests are made over TLS, authenticating
+// with the client certificate.
+func NewCoreDNSZones(config CoreDNSConfig) (environs.DNSZones, error) {
+	clientCert, err := tls.X509KeyPair([]byte(config.ClientCert), []byte(config.ClientKey))
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing etcd client certificate")
+	}
+	tlsConfig := utils.SecureTLSConfig()
+	tlsConfig.Certificates = []tls.Certificate{clientCert}
+	if config.CACert != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(config.CACert)) {
+			return nil, errors.New("etcd CA certificate contains no PEM certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return &coreDNSZones{
+		endpoint: strings.TrimSuffix(config.Endpoint, "/"),
+		client: &http.Client{
+			Transport: utils.NewHttpTLSTransport(tlsConfig),
+			Timeout:   coreDNSTimeout,
+		},
+	}, nil
+}
+
+type coreDNSZones struct {
+	endpoint string
+	client   *http.Client
+}
+
+// OwnedDNSRecords is part of the environs.DNSZones interface.
+func (z *coreDNSZones) OwnedDNSRecords(ctx context.ProviderCallContext, zone, owner string) ([]environs.DNSRecord, error) {
+	recordSets, err := z.recordSets(zone)
+	if err != nil {
+		return nil, errors.Annotatef(err, "reading DNS zone %q", zone)
+	}
+	return environs.OwnedDNSRecords(recordSets, owner), nil
+}
+
+// SetDNSRecords is part of the environs.DNSZones interface. Each record
+// is replaced in a single etcd transaction, which deletes the name's
+// address and owner keys and puts them afresh.
+func (z *coreDNSZones) SetDNSRecords(ctx context.ProviderCallContext, zone, owner string, records []environs.DNSRecord) error {
+	recordSets, err := z.recordSets(zone)
+	if err != nil {
+		return errors.Annotatef(err, "reading DNS zone %q", zone)
+	}
+	ownership := environs.DNSNameOwnership(recordSets, owner)
+	var notOwned []string
+	for _, record := range records {
+		if owned, ok := ownership[record.Name]; ok && !owned {
+			notOwned = append(notOwned, record.Name)
+			continue
+		}
+		if err := z.setRecord(zone, owner, record); err != nil {
+			return errors.Annotatef(err, "setting records for %q", record.Name)
+		}
+	}
+	if len(notOwned) > 0 {
+		return environs.DNSNamesNotOwnedError(notOwned)
+	}
+	return nil
+}
+
+// etcdRequestOp and the types below mirror the etcd v3 JSON gateway's
+// requests and responses. Keys and values are []byte so that
+// encoding/json base64 encodes them, as the gateway expects.
+type etcdRequestOp struct {
+	RequestPut         *etcdPutRequest   `json:"request_put,omitempty"`
+	RequestDeleteRange *etcdRangeRequest `json:"request_delete_range,omitempty"`
+}
+
+type etcdPutRequest struct {
+	Key   []byte `json:"key"`
+	Value []byte `json:"value"`
+}
+
+type etcdRangeRequest struct {
+	Key      []byte `json:"key"`
+	RangeEnd []byte `json:"range_end,omitempty"`
+}
+
+type etcdRangeResponse struct {
+	Kvs []struct {
+		Key   []byte `json:"key"`
+		Value []byte `json:"value"`
+	} `json:"kvs"`
+}
+
+type etcdTxnRequest struct {
+	Success []etcdRequestOp `json:"success"`
+}
+
+// skyDNSRecord is the value the CoreDNS etcd plugin expects for each
+// record published under a name. Address records have a host, and TXT
+// records have text.
+type skyDNSRecord struct {
+	Host string `json:"host,omitempty"`
+	Text string `json:"text,omitempty"`
+	TTL  int    `json:"ttl"`
+}
+
+// The leaves of the keys written for each name. The address records
+// are written to numbered keys with the type's prefix, and the
+// ownership marker to the owner key.
+const (
+	coreDNSIPv4Prefix = "a-"
+	coreDNSIPv6Prefix = "aaaa-"
+	coreDNSOwnerKey   = "owner"
+)
+
+func (z *coreDNSZones) setRecord(zone, owner string, record environs.DNSRecord) error {
+	path := namePath(record.Name + "." + zone)
+	ops := []etcdRequestOp{{
+		RequestDeleteRange: prefixRange(path + "/" + coreDNSIPv4Prefix),
+	}, {
+		RequestDeleteRange: prefixRange(path + "/" + coreDNSIPv6Prefix),
+	}, {
+		RequestDeleteRange: &etcdRangeRequest{Key: []byte(path + "/" + coreDNSOwnerKey)},
+	}}
+	if len(record.Addresses) == 0 {
+		return z.post("/v3/kv/txn", etcdTxnRequest{Success: ops}, nil)
+	}
+
+	put := func(key string, value skyDNSRecord) error {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		ops = append(ops, etcdRequestOp{
+			RequestPut: &etcdPutRequest{Key: []byte(path + "/" + key), Value: data},
+		})
+		return nil
+	}
+	if err := put(coreDNSOwnerKey, skyDNSRecord{
+		Text: environs.DNSOwnerText(owner),
+		TTL:  environs.DNSRecordTTL,
+	}); err != nil {
+		return errors.Trace(err)
+	}
+	ipv4, ipv6 := environs.SplitDNSAddresses(record.Addresses)
+	for i, addr := range ipv4 {
+		key := fmt.Sprintf("%s%d", coreDNSIPv4Prefix, i)
+		if err := put(key, skyDNSRecord{Host: addr, TTL: environs.DNSRecordTTL}); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	for i, addr := range ipv6 {
+		key := fmt.Sprintf("%s%d", coreDNSIPv6Prefix, i)
+		if err := put(key, skyDNSRecord{Host: addr, TTL: environs.DNSRecordTTL}); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return z.post("/v3/kv/txn", etcdTxnRequest{Success: ops}, nil)
+}
+
+// recordSets reads the records in the zone. Each key beneath the
+// zone's path is taken to hold a record for the name given by the
+// path above the key's leaf, as written by setRecord. Values that
+// aren't CoreDNS address or TXT records are ignored.
+func (z *coreDNSZones) recordSets(zone string) ([]environs.DNSRecordSet, error) {
+	prefix := namePath(zone) + "/"
+	var resp etcdRangeResponse
+	if err := z.post("/v3/kv/range", prefixRange(prefix), &resp); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []environs.DNSRecordSet
+	for _, kv := range resp.Kvs {
+		path := strings.TrimPrefix(string(kv.Key), prefix)
+		leaf := strings.LastIndex(path, "/")
+		if leaf < 0 {
+			continue
+		}
+		var value skyDNSRecord
+		if err := json.Unmarshal(kv.Value, &value); err != nil {
+			continue
+		}
+		recordSet := environs.DNSRecordSet{Name: pathName(path[:leaf])}
+		ip := net.ParseIP(value.Host)
+		switch {
+		case value.Text != "":
+			recordSet.Type, recordSet.Values = "TXT", []string{value.Text}
+		case ip == nil:
+			continue
+		case ip.To4() != nil:
+			recordSet.Type, recordSet.Values = "A", []string{value.Host}
+		default:
+			recordSet.Type, recordSet.Values = "AAAA", []string{value.Host}
+		}
+		result = append(result, recordSet)
+	}
+	return result, nil
+}
+
+// namePath returns the path of the etcd keys holding the records for
+// the name. The CoreDNS etcd plugin reads the records for a name from
+// the keys under the name's labels, in reverse order, beneath "/skydns".
+func namePath(name string) string {
+	labels := strings.Split(strings.ToLower(strings.TrimSuffix(name, ".")), ".")
+	path := []string{"", "skydns"}
+	for i := len(labels) - 1; i >= 0; i-- {
+		path = append(path, labels[i])
+	}
+	return strings.Join(path, "/")
+}
+
+// pathName returns the name for a path of labels, relative to a zone's
+// path.
+func pathName(path string) string {
+	labels := strings.Split(path, "/")
+	for i, j := 0, len(labels)-1; i < j; i, j = i+1, j-1 {
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+	return strings.Join(labels, ".")
+}
+
+// prefixRange returns the etcd key range covering every key with the
+// given prefix.
+func prefixRange(prefix string) *etcdRangeRequest {
+	end := []byte(prefix)
+	end[len(end)-1]++
+	return &etcdRangeRequest{Key: []byte(prefix), RangeEnd: end}
+}
+
+// post sends the request to the etcd JSON gateway, decoding the
+// response into resp if it is not nil.
+func (z *coreDNSZones) post(path string, req, resp interface{}) error {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	r, err := z.client.Post(z.endpoint+path, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		msg, _ := ioutil.ReadAll(r.Body)
+		return errors.Errorf("etcd returned %s: %s", r.Status, strings.TrimSpace(string(msg)))
+	}
+	if resp == nil {
+		return nil
+	}
+	return errors.Trace(json.NewDecoder(r.Body).Decode(resp))
+}
@@ -0,0 +1,188 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnspublisher
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type coreDNSSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&coreDNSSuite{})
+
+// fakeEtcd serves the parts of the etcd v3 JSON gateway used by
+// coreDNSZones from an in-memory store.
+type fakeEtcd struct {
+	c    *gc.C
+	keys map[string]string
+}
+
+func (f *fakeEtcd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.c.Check(r.Method, gc.Equals, "POST")
+	switch r.URL.Path {
+	case "/v3/kv/range":
+		var req etcdRangeRequest
+		f.c.Assert(json.NewDecoder(r.Body).Decode(&req), jc.ErrorIsNil)
+		var resp etcdRangeResponse
+		for _, key := range f.sortedKeys() {
+			if key >= string(req.Key) && key < string(req.RangeEnd) {
+				resp.Kvs = append(resp.Kvs, struct {
+					Key   []byte `json:"key"`
+					Value []byte `json:"value"`
+				}{[]byte(key), []byte(f.keys[key])})
+			}
+		}
+		json.NewEncoder(w).Encode(resp)
+	case "/v3/kv/txn":
+		var req etcdTxnRequest
+		f.c.Assert(json.NewDecoder(r.Body).Decode(&req), jc.ErrorIsNil)
+		for _, op := range req.Success {
+			if put := op.RequestPut; put != nil {
+				f.keys[string(put.Key)] = string(put.Value)
+				continue
+			}
+			del := op.RequestDeleteRange
+			for key := range f.keys {
+				if key == string(del.Key) || key > string(del.Key) && key < string(del.RangeEnd) {
+					delete(f.keys, key)
+				}
+			}
+		}
+		w.Write([]byte(`{"succeeded":true}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (f *fakeEtcd) sortedKeys() []string {
+	var keys []string
+	for key := range f.keys {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (s *coreDNSSuite) TestNamePath(c *gc.C) {
+	path := namePath("wordpress-0.mymodel.Example.com.")
+	c.Check(path, gc.Equals, "/skydns/com/example/mymodel/wordpress-0")
+	c.Check(pathName("mymodel/wordpress-0"), gc.Equals, "wordpress-0.mymodel")
+	c.Check(string(prefixRange(path+"/a-").RangeEnd), gc.Equals, "/skydns/com/example/mymodel/wordpress-0/a.")
+}
+
+func (s *coreDNSSuite) TestSetAndReadRecords(c *gc.C) {
+	otherOwner := `{"text":"` + environs.DNSOwnerText("cafebabe") + `","ttl":60}`
+	etcd := &fakeEtcd{c: c, keys: map[string]string{
+		"/skydns/com/example/mymodel/www/x1":      `{"host":"203.0.113.40"}`,
+		"/skydns/com/example/mymodel/mysql/a-0":   `{"host":"203.0.113.20","ttl":60}`,
+		"/skydns/com/example/mymodel/mysql/owner": otherOwner,
+	}}
+	server := httptest.NewServer(etcd)
+	defer server.Close()
+	zones := &coreDNSZones{endpoint: server.URL, client: server.Client()}
+	ctx := context.NewCloudCallContext()
+
+	err := zones.SetDNSRecords(ctx, "example.com", "deadbeef", []environs.DNSRecord{
+		{Name: "wordpress.mymodel", Addresses: []string{"203.0.113.10", "2001:db8::10", "203.0.113.11"}},
+		{Name: "www.mymodel", Addresses: []string{"203.0.113.12"}},
+		{Name: "mysql.mymodel"},
+	})
+	c.Assert(err, gc.ErrorMatches, `not publishing www.mymodel, mysql.mymodel: names in use by others`)
+	c.Check(etcd.keys, jc.DeepEquals, map[string]string{
+		"/skydns/com/example/mymodel/www/x1":           `{"host":"203.0.113.40"}`,
+		"/skydns/com/example/mymodel/mysql/a-0":        `{"host":"203.0.113.20","ttl":60}`,
+		"/skydns/com/example/mymodel/mysql/owner":      otherOwner,
+		"/skydns/com/example/mymodel/wordpress/owner":  `{"text":"` + environs.DNSOwnerText("deadbeef") + `","ttl":60}`,
+		"/skydns/com/example/mymodel/wordpress/a-0":    `{"host":"203.0.113.10","ttl":60}`,
+		"/skydns/com/example/mymodel/wordpress/a-1":    `{"host":"203.0.113.11","ttl":60}`,
+		"/skydns/com/example/mymodel/wordpress/aaaa-0": `{"host":"2001:db8::10","ttl":60}`,
+	})
+
+	records, err := zones.OwnedDNSRecords(ctx, "example.com", "deadbeef")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(records, jc.DeepEquals, []environs.DNSRecord{{
+		Name:      "wordpress.mymodel",
+		Addresses: []string{"2001:db8::10", "203.0.113.10", "203.0.113.11"},
+	}})
+
+	err = zones.SetDNSRecords(ctx, "example.com", "deadbeef", []environs.DNSRecord{
+		{Name: "wordpress.mymodel", Addresses: []string{"203.0.113.10"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(etcd.sortedKeys(), jc.DeepEquals, []string{
+		"/skydns/com/example/mymodel/mysql/a-0",
+		"/skydns/com/example/mymodel/mysql/owner",
+		"/skydns/com/example/mymodel/wordpress/a-0",
+		"/skydns/com/example/mymodel/wordpress/owner",
+		"/skydns/com/example/mymodel/www/x1",
+	})
+
+	err = zones.SetDNSRecords(ctx, "example.com", "deadbeef", []environs.DNSRecord{
+		{Name: "wordpress.mymodel"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	records, err = zones.OwnedDNSRecords(ctx, "example.com", "deadbeef")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(records, gc.HasLen, 0)
+	c.Check(etcd.keys, gc.HasLen, 3)
+}
+
+func (s *coreDNSSuite) TestSetDNSRecordsError(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "etcdserver: permission denied", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	zones := &coreDNSZones{endpoint: server.URL, client: server.Client()}
+	err := zones.SetDNSRecords(context.NewCloudCallContext(), "example.com", "deadbeef", []environs.DNSRecord{
+		{Name: "mysql.mymodel"},
+	})
+	c.Assert(err, gc.ErrorMatches, `reading DNS zone "example.com": etcd returned 403 Forbidden: etcdserver: permission denied`)
+}
+
+func (s *coreDNSSuite) TestNewCoreDNSZonesTLS(c *gc.C) {
+	zones, err := NewCoreDNSZones(CoreDNSConfig{
+		Endpoint:   "https://10.0.0.1:2379/",
+		CACert:     coretesting.CACert,
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	z := zones.(*coreDNSZones)
+	c.Check(z.endpoint, gc.Equals, "https://10.0.0.1:2379")
+	tlsConfig := z.client.Transport.(*http.Transport).TLSClientConfig
+	c.Check(tlsConfig.Certificates, gc.HasLen, 1)
+	c.Check(tlsConfig.RootCAs, gc.NotNil)
+	c.Check(tlsConfig.InsecureSkipVerify, jc.IsFalse)
+}
+
+func (s *coreDNSSuite) TestNewCoreDNSZonesInvalid(c *gc.C) {
+	_, err := NewCoreDNSZones(CoreDNSConfig{
+		Endpoint:   "https://10.0.0.1:2379",
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.CAKey,
+	})
+	c.Check(err, gc.ErrorMatches, "parsing etcd client certificate: .*")
+
+	_, err = NewCoreDNSZones(CoreDNSConfig{
+		Endpoint:   "https://10.0.0.1:2379",
+		CACert:     "not a certificate",
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	})
+	c.Check(err, gc.ErrorMatches, "etcd CA certificate contains no PEM certificates")
+}
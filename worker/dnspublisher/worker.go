@@ -0,0 +1,369 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnspublisher
+
+import (
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+)
+
+var logger = loggo.GetLogger("juju.worker.dnspublisher")
+
+// DefaultPollInterval is the time between checks of the model's unit
+// addresses. Units coming and going, and their addresses changing, do
+// not change the lifecycles of their applications, so the worker polls
+// for them as well as watching the applications.
+const DefaultPollInterval = 30 * time.Second
+
+// Facade exposes the controller functionality needed by the DNS
+// publisher.
+type Facade interface {
+	WatchApplications() (watcher.StringsWatcher, error)
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
+	Applications() ([]params.DNSApplication, error)
+}
+
+// Config holds the configuration and dependencies for a DNS publisher
+// worker.
+type Config struct {
+	// Facade is used to read the model's applications and config.
+	Facade Facade
+
+	// Environ is the model's environ. Its DNSZones implementation, if
+	// any, is used when publishing to Route53 or Designate.
+	Environ environs.Environ
+
+	// NewCoreDNSZones returns the DNSZones used when publishing to
+	// CoreDNS, given the details of its etcd cluster.
+	NewCoreDNSZones func(CoreDNSConfig) (environs.DNSZones, error)
+
+	// CallContext is passed to the DNSZones methods.
+	CallContext context.ProviderCallContext
+
+	// Clock is used to schedule polling.
+	Clock clock.Clock
+
+	// PollInterval is the time between checks of the model's unit
+	// addresses.
+	PollInterval time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start a
+// DNS publisher.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Environ == nil {
+		return errors.NotValidf("nil Environ")
+	}
+	if config.NewCoreDNSZones == nil {
+		return errors.NotValidf("nil NewCoreDNSZones")
+	}
+	if config.CallContext == nil {
+		return errors.NotValidf("nil CallContext")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// cloudDNSProviders maps the DNS providers hosted by a cloud to the
+// type of the cloud's environ.
+var cloudDNSProviders = map[string]string{
+	config.DNSProviderRoute53:   "ec2",
+	config.DNSProviderDesignate: "openstack",
+}
+
+// NewWorker returns a worker that publishes the public addresses of the
+// model's applications and units in the DNS zone configured for the
+// model, if any.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &dnsPublisher{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// target identifies where records are published.
+type target struct {
+	provider string
+	zone     string
+	coreDNS  CoreDNSConfig
+}
+
+type dnsPublisher struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// owner is the UUID of the model, which marks the records it
+	// publishes as its own.
+	owner string
+
+	// model is the name of the model, which is the last label of the
+	// names of all its records.
+	model string
+
+	target target
+	zones  environs.DNSZones
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *dnsPublisher) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *dnsPublisher) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *dnsPublisher) loop() error {
+	configWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(configWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	appWatcher, err := w.config.Facade.WatchApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(appWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var poll <-chan time.Time
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("model config watcher closed")
+			}
+			if err := w.updateTarget(); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-appWatcher.Changes():
+			if !ok {
+				return errors.New("application watcher closed")
+			}
+		case <-poll:
+		}
+		if err := w.publish(); err != nil {
+			return errors.Trace(err)
+		}
+		poll = w.config.Clock.After(w.config.PollInterval)
+	}
+}
+
+// updateTarget reads the DNS settings from the model config. If they
+// have changed, the records published under the old settings are
+// removed, and everything is published afresh under the new ones.
+func (w *dnsPublisher) updateTarget() error {
+	cfg, err := w.config.Facade.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.owner = cfg.UUID()
+	w.model = cfg.Name()
+	newTarget := target{
+		provider: cfg.DNSProvider(),
+		zone:     cfg.DNSZone(),
+	}
+	if newTarget.provider == config.DNSProviderCoreDNS {
+		newTarget.coreDNS = CoreDNSConfig{
+			Endpoint:   cfg.DNSEndpoint(),
+			CACert:     cfg.DNSCACert(),
+			ClientCert: cfg.DNSClientCert(),
+			ClientKey:  cfg.DNSClientKey(),
+		}
+	}
+	if newTarget == w.target {
+		return nil
+	}
+	if w.zones != nil {
+		w.removeRecords()
+	}
+	w.target = newTarget
+	w.zones = nil
+
+	switch newTarget.provider {
+	case "":
+		logger.Debugf("not publishing addresses in DNS")
+	case config.DNSProviderCoreDNS:
+		zones, err := w.config.NewCoreDNSZones(newTarget.coreDNS)
+		if err != nil {
+			logger.Errorf("cannot publish addresses in CoreDNS: %v", err)
+			return nil
+		}
+		w.zones = zones
+	default:
+		envType := w.config.Environ.Config().Type()
+		zones, ok := environs.SupportsDNSZones(w.config.Environ)
+		if !ok || cloudDNSProviders[newTarget.provider] != envType {
+			logger.Errorf("cannot publish addresses in %s from a %q model", newTarget.provider, envType)
+			return nil
+		}
+		w.zones = zones
+	}
+	if w.zones != nil {
+		logger.Infof("publishing addresses in %s zone %q", newTarget.provider, newTarget.zone)
+	}
+	return nil
+}
+
+// removeRecords removes the records owned by the model from the zone
+// they are currently published in.
+func (w *dnsPublisher) removeRecords() {
+	owned, err := w.zones.OwnedDNSRecords(w.config.CallContext, w.target.zone, w.owner)
+	if err == nil && len(owned) > 0 {
+		for i := range owned {
+			owned[i].Addresses = nil
+		}
+		err = w.zones.SetDNSRecords(w.config.CallContext, w.target.zone, w.owner, owned)
+	}
+	if err != nil {
+		// The records cannot be published in the old zone any more,
+		// so there is no point in retrying.
+		logger.Warningf("cannot remove records from DNS zone %q: %v", w.target.zone, err)
+	}
+}
+
+// publish compares the records owned by the model in the zone with
+// those it should have, and sends the ones that differ. The zone is
+// read afresh each time, so records changed or removed by others, and
+// changes that failed to be sent, are put right on the next poll.
+func (w *dnsPublisher) publish() error {
+	if w.zones == nil {
+		return nil
+	}
+	apps, err := w.config.Facade.Applications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	desired := records(apps, w.model)
+
+	// Failures talking to the DNS service shouldn't bounce the worker;
+	// the zone is reconciled again on the next poll.
+	owned, err := w.zones.OwnedDNSRecords(w.config.CallContext, w.target.zone, w.owner)
+	if err != nil {
+		logger.Errorf("cannot read DNS zone %q: %v", w.target.zone, err)
+		return nil
+	}
+	published := make(map[string][]string)
+	for _, record := range owned {
+		published[record.Name] = record.Addresses
+	}
+
+	var changes []environs.DNSRecord
+	for name, addrs := range desired {
+		if current, ok := published[name]; ok && equalAddresses(current, addrs) {
+			continue
+		}
+		changes = append(changes, environs.DNSRecord{
+			Name:      name,
+			Addresses: addrs,
+		})
+	}
+	for name := range published {
+		if _, ok := desired[name]; !ok {
+			changes = append(changes, environs.DNSRecord{Name: name})
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	logger.Debugf("updating %d records in DNS zone %q", len(changes), w.target.zone)
+	if err := w.zones.SetDNSRecords(w.config.CallContext, w.target.zone, w.owner, changes); err != nil {
+		logger.Errorf("cannot update DNS zone %q: %v", w.target.zone, err)
+	}
+	return nil
+}
+
+// records returns the addresses to publish for the applications, keyed
+// by record name. The model's name is the last label of every name, so
+// that models publishing in the same zone don't clash. Each application
+// is published under its name, with the addresses of all of its units,
+// and each unit is published under its name with the "/" replaced by
+// "-", so unit "wordpress/0" of model "prod" is published as
+// "wordpress-0.prod". Every hyphen-separated part of an application
+// name contains a letter, so application and unit names never clash.
+func records(apps []params.DNSApplication, model string) map[string][]string {
+	result := make(map[string][]string)
+	for _, app := range apps {
+		for _, unit := range app.Units {
+			if net.ParseIP(unit.Address) == nil {
+				logger.Debugf("not publishing unit %q address %q: not an IP address", unit.Name, unit.Address)
+				continue
+			}
+			unitName := strings.Replace(unit.Name, "/", "-", 1) + "." + model
+			result[unitName] = []string{unit.Address}
+			appName := app.Name + "." + model
+			result[appName] = append(result[appName], unit.Address)
+		}
+	}
+	for name, addrs := range result {
+		sort.Strings(addrs)
+		result[name] = dedupe(addrs)
+	}
+	return result
+}
+
+// dedupe removes adjacent duplicates from the sorted addresses.
+func dedupe(addrs []string) []string {
+	result := addrs[:0]
+	for _, addr := range addrs {
+		if len(result) == 0 || addr != result[len(result)-1] {
+			result = append(result, addr)
+		}
+	}
+	return result
+}
+
+func equalAddresses(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,394 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dnspublisher_test
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/dnspublisher"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock          *testclock.Clock
+	facade         *mockFacade
+	environ        *mockEnviron
+	zones          *mockZones
+	coreDNSConfigs []dnspublisher.CoreDNSConfig
+	coreDNSError   error
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Time{})
+	s.facade = &mockFacade{
+		configChanges: make(chan struct{}, 1),
+		appChanges:    make(chan []string, 1),
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"dns-provider":    "coredns",
+			"dns-zone":        "example.com",
+			"dns-endpoint":    "https://10.0.0.1:2379",
+			"dns-client-cert": coretesting.ServerCert,
+			"dns-client-key":  coretesting.ServerKey,
+		}),
+		apps: []params.DNSApplication{{
+			Name: "wordpress",
+			Units: []params.DNSUnit{
+				{Name: "wordpress/0", Address: "203.0.113.10"},
+				{Name: "wordpress/1", Address: "2001:db8::1"},
+				{Name: "wordpress/2", Address: "wordpress-2.example.org"},
+			},
+		}, {
+			Name: "mysql",
+		}},
+	}
+	s.environ = &mockEnviron{
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{"type": "ec2"}),
+	}
+	s.zones = &mockZones{
+		calls:   make(chan zonesCall, 10),
+		records: make(map[string]map[string][]string),
+	}
+	s.coreDNSConfigs = nil
+	s.coreDNSError = nil
+}
+
+func (s *WorkerSuite) config() dnspublisher.Config {
+	return dnspublisher.Config{
+		Facade:  s.facade,
+		Environ: s.environ,
+		NewCoreDNSZones: func(config dnspublisher.CoreDNSConfig) (environs.DNSZones, error) {
+			s.coreDNSConfigs = append(s.coreDNSConfigs, config)
+			if s.coreDNSError != nil {
+				return nil, s.coreDNSError
+			}
+			return s.zones, nil
+		},
+		CallContext:  context.NewCloudCallContext(),
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+	}
+}
+
+// published holds the records first published for the model.
+var published = []environs.DNSRecord{
+	{Name: "wordpress-0.testmodel", Addresses: []string{"203.0.113.10"}},
+	{Name: "wordpress-1.testmodel", Addresses: []string{"2001:db8::1"}},
+	{Name: "wordpress.testmodel", Addresses: []string{"2001:db8::1", "203.0.113.10"}},
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Environ = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Environ not valid")
+
+	config = s.config()
+	config.PollInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive PollInterval not valid")
+}
+
+func (s *WorkerSuite) TestPublishes(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	call := s.nextCall(c)
+	c.Check(call.zone, gc.Equals, "example.com")
+	c.Check(call.owner, gc.Equals, coretesting.ModelTag.Id())
+	c.Check(call.records, jc.DeepEquals, published)
+	c.Check(s.coreDNSConfigs, jc.DeepEquals, []dnspublisher.CoreDNSConfig{{
+		Endpoint:   "https://10.0.0.1:2379",
+		ClientCert: coretesting.ServerCert,
+		ClientKey:  coretesting.ServerKey,
+	}})
+}
+
+func (s *WorkerSuite) TestPublishesChangesOnly(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.nextCall(c)
+
+	s.facade.setApps([]params.DNSApplication{{
+		Name: "wordpress",
+		Units: []params.DNSUnit{
+			{Name: "wordpress/0", Address: "203.0.113.10"},
+			{Name: "wordpress/3", Address: "203.0.113.13"},
+		},
+	}})
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+
+	call := s.nextCall(c)
+	c.Check(call.records, jc.DeepEquals, []environs.DNSRecord{
+		{Name: "wordpress-1.testmodel"},
+		{Name: "wordpress-3.testmodel", Addresses: []string{"203.0.113.13"}},
+		{Name: "wordpress.testmodel", Addresses: []string{"203.0.113.10", "203.0.113.13"}},
+	})
+
+	// Nothing has changed, so nothing is published.
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertNoCall(c)
+}
+
+func (s *WorkerSuite) TestRepublishesRecordsChangedInZone(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.nextCall(c)
+
+	// Someone else removes a record, and changes another.
+	s.zones.setRecord("example.com", "wordpress-0.testmodel", nil)
+	s.zones.setRecord("example.com", "wordpress-1.testmodel", []string{"2001:db8::99"})
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+
+	call := s.nextCall(c)
+	c.Check(call.records, jc.DeepEquals, published[:2])
+}
+
+func (s *WorkerSuite) TestRetriesAfterError(c *gc.C) {
+	s.zones.setError(errors.New("boom"))
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.nextCall(c)
+
+	s.zones.setError(nil)
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	call := s.nextCall(c)
+	c.Check(call.records, jc.DeepEquals, published)
+}
+
+func (s *WorkerSuite) TestZoneChangeRemovesOldRecords(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	s.nextCall(c)
+
+	newConfig, err := s.facade.config.Apply(map[string]interface{}{
+		"dns-zone": "example.net",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.setConfig(newConfig)
+	s.facade.configChanges <- struct{}{}
+
+	call := s.nextCall(c)
+	c.Check(call.zone, gc.Equals, "example.com")
+	c.Check(call.records, jc.DeepEquals, []environs.DNSRecord{
+		{Name: "wordpress-0.testmodel"},
+		{Name: "wordpress-1.testmodel"},
+		{Name: "wordpress.testmodel"},
+	})
+	call = s.nextCall(c)
+	c.Check(call.zone, gc.Equals, "example.net")
+	c.Check(call.records, jc.DeepEquals, published)
+}
+
+func (s *WorkerSuite) TestCloudDNSProvider(c *gc.C) {
+	cfg, err := s.facade.config.Apply(map[string]interface{}{
+		"dns-provider": "route53",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.setConfig(cfg)
+	s.environ.zones = s.zones
+
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	call := s.nextCall(c)
+	c.Check(call.records, jc.DeepEquals, published)
+	c.Check(s.coreDNSConfigs, gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestCloudDNSProviderMismatch(c *gc.C) {
+	cfg, err := s.facade.config.Apply(map[string]interface{}{
+		"dns-provider": "designate",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.setConfig(cfg)
+	s.environ.zones = s.zones
+
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertNoCall(c)
+}
+
+func (s *WorkerSuite) TestCoreDNSError(c *gc.C) {
+	s.coreDNSError = errors.New("bad certificate")
+
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertNoCall(c)
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := dnspublisher.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.configChanges <- struct{}{}
+	s.facade.appChanges <- []string{"wordpress", "mysql"}
+	return w
+}
+
+func (s *WorkerSuite) nextCall(c *gc.C) zonesCall {
+	select {
+	case call := <-s.zones.calls:
+		return call
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for records to be published")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNoCall(c *gc.C) {
+	select {
+	case call := <-s.zones.calls:
+		c.Fatalf("unexpected records published: %#v", call)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type mockFacade struct {
+	mu            sync.Mutex
+	configChanges chan struct{}
+	appChanges    chan []string
+	config        *config.Config
+	apps          []params.DNSApplication
+}
+
+func (f *mockFacade) setConfig(cfg *config.Config) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.config = cfg
+}
+
+func (f *mockFacade) setApps(apps []params.DNSApplication) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.apps = apps
+}
+
+func (f *mockFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	return watchertest.NewMockNotifyWatcher(f.configChanges), nil
+}
+
+func (f *mockFacade) WatchApplications() (watcher.StringsWatcher, error) {
+	return watchertest.NewMockStringsWatcher(f.appChanges), nil
+}
+
+func (f *mockFacade) ModelConfig() (*config.Config, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.config, nil
+}
+
+func (f *mockFacade) Applications() ([]params.DNSApplication, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.apps, nil
+}
+
+type mockEnviron struct {
+	environs.Environ
+	config *config.Config
+	zones  environs.DNSZones
+}
+
+func (e *mockEnviron) Config() *config.Config {
+	return e.config
+}
+
+func (e *mockEnviron) OwnedDNSRecords(ctx context.ProviderCallContext, zone, owner string) ([]environs.DNSRecord, error) {
+	if e.zones == nil {
+		return nil, errors.NotSupportedf("DNS zones")
+	}
+	return e.zones.OwnedDNSRecords(ctx, zone, owner)
+}
+
+func (e *mockEnviron) SetDNSRecords(ctx context.ProviderCallContext, zone, owner string, records []environs.DNSRecord) error {
+	if e.zones == nil {
+		return errors.NotSupportedf("DNS zones")
+	}
+	return e.zones.SetDNSRecords(ctx, zone, owner, records)
+}
+
+type zonesCall struct {
+	zone    string
+	owner   string
+	records []environs.DNSRecord
+}
+
+// mockZones holds the addresses of the records owned by the model in
+// each zone, and reports each call to SetDNSRecords.
+type mockZones struct {
+	mu      sync.Mutex
+	err     error
+	records map[string]map[string][]string
+	calls   chan zonesCall
+}
+
+func (z *mockZones) setError(err error) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	z.err = err
+}
+
+func (z *mockZones) setRecord(zone, name string, addrs []string) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	if z.records[zone] == nil {
+		z.records[zone] = make(map[string][]string)
+	}
+	if len(addrs) == 0 {
+		delete(z.records[zone], name)
+	} else {
+		z.records[zone][name] = addrs
+	}
+}
+
+func (z *mockZones) OwnedDNSRecords(ctx context.ProviderCallContext, zone, owner string) ([]environs.DNSRecord, error) {
+	z.mu.Lock()
+	defer z.mu.Unlock()
+	var result []environs.DNSRecord
+	for name, addrs := range z.records[zone] {
+		result = append(result, environs.DNSRecord{Name: name, Addresses: addrs})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+func (z *mockZones) SetDNSRecords(ctx context.ProviderCallContext, zone, owner string, records []environs.DNSRecord) error {
+	z.calls <- zonesCall{zone, owner, records}
+	z.mu.Lock()
+	err := z.err
+	z.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		z.setRecord(zone, record.Name, record.Addresses)
+	}
+	return nil
+}
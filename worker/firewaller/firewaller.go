@@ -5,6 +5,7 @@ package firewaller
 
 import (
 	"io"
+	"reflect"
 	"strings"
 	"time"
 
@@ -69,6 +70,12 @@ type EnvironFirewaller interface {
 	environs.Firewaller
 }
 
+// EnvironLoadBalancers defines methods to allow the worker to provision
+// load balancers for exposed applications in a Juju cloud environment.
+type EnvironLoadBalancers interface {
+	environs.LoadBalancers
+}
+
 // EnvironInstances defines methods to allow the worker to perform
 // operations on instances in a Juju cloud environment.
 type EnvironInstances interface {
@@ -86,6 +93,11 @@ type Config struct {
 	EnvironFirewaller  EnvironFirewaller
 	EnvironInstances   EnvironInstances
 
+	// EnvironLoadBalancers is used to provision load balancers for
+	// applications exposed through one. It is nil if the environment
+	// does not support load balancers.
+	EnvironLoadBalancers EnvironLoadBalancers
+
 	NewCrossModelFacadeFunc newCrossModelFacadeFunc
 
 	Clock clock.Clock
@@ -119,7 +131,14 @@ func (cfg Config) Validate() error {
 	return nil
 }
 
-type portRanges map[corenetwork.PortRange]bool
+// endpointPortRange is a port range opened by a unit for one of its
+// endpoints, or for all of them if the endpoint is empty.
+type endpointPortRange struct {
+	corenetwork.PortRange
+	Endpoint string
+}
+
+type portRanges map[endpointPortRange]bool
 
 // Firewaller watches the state for port ranges opened or closed on
 // machines and reflects those changes onto the backing environment.
@@ -130,6 +149,7 @@ type Firewaller struct {
 	remoteRelationsApi *remoterelations.Client
 	environFirewaller  EnvironFirewaller
 	environInstances   EnvironInstances
+	environLBs         EnvironLoadBalancers
 
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
@@ -149,6 +169,12 @@ type Firewaller struct {
 	relationWorkerRunner       *worker.Runner
 	pollClock                  clock.Clock
 
+	// loadBalancerRetries holds the times at which the load balancers
+	// of applications that failed to update are next tried, and
+	// loadBalancerRetry fires at the earliest of them.
+	loadBalancerRetries map[names.ApplicationTag]time.Time
+	loadBalancerRetry   <-chan time.Time
+
 	cloudCallContext context.ProviderCallContext
 }
 
@@ -167,6 +193,7 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		remoteRelationsApi:         cfg.RemoteRelationsApi,
 		environFirewaller:          cfg.EnvironFirewaller,
 		environInstances:           cfg.EnvironInstances,
+		environLBs:                 cfg.EnvironLoadBalancers,
 		newRemoteFirewallerAPIFunc: cfg.NewCrossModelFacadeFunc,
 		modelUUID:                  cfg.ModelUUID,
 		machineds:                  make(map[names.MachineTag]*machineData),
@@ -177,6 +204,7 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		relationIngress:            make(map[names.RelationTag]*remoteRelationData),
 		localRelationsChange:       make(chan *remoteRelationNetworkChange),
 		pollClock:                  clk,
+		loadBalancerRetries:        make(map[names.ApplicationTag]time.Time),
 		relationWorkerRunner: worker.NewRunner(worker.RunnerParams{
 			Clock: clk,
 
@@ -306,6 +334,7 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.loadBalanced = change.loadBalanced
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -313,6 +342,9 @@ func (fw *Firewaller) loop() error {
 			if err := fw.flushUnits(unitds); err != nil {
 				return errors.Annotate(err, "cannot change firewall ports")
 			}
+			fw.flushLoadBalancer(change.applicationd)
+		case <-fw.loadBalancerRetry:
+			fw.retryLoadBalancers()
 		}
 	}
 }
@@ -480,12 +512,22 @@ func (fw *Firewaller) startApplication(app *firewaller.Application) error {
 		exposed:     exposed,
 		unitds:      make(map[names.UnitTag]*unitData),
 	}
+	if fw.environLBs != nil {
+		applicationd.loadBalanced, applicationd.loadBalancerAddresses, err = app.LoadBalancer()
+		if err != nil {
+			return err
+		}
+		// A previous worker may have left a load balancer behind,
+		// even without recording its addresses.
+		applicationd.loadBalancerExists = applicationd.loadBalanced || len(applicationd.loadBalancerAddresses) > 0
+	}
 	fw.applicationids[app.Tag()] = applicationd
 
+	loadBalanced := applicationd.loadBalanced
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, loadBalanced)
 		},
 	})
 	if err != nil {
@@ -658,19 +700,19 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 		return err
 	}
 
-	ports, err := m.OpenedPorts(subnetTag)
+	ports, err := m.OpenedPortRanges(subnetTag)
 	if err != nil {
 		return err
 	}
 
 	newPortRanges := make(map[names.UnitTag]portRanges)
-	for portRange, unitTag := range ports {
-		unitd, ok := machined.unitds[unitTag]
+	for _, port := range ports {
+		unitd, ok := machined.unitds[port.UnitTag]
 		if !ok {
 			// It is common to receive port change notification before
 			// registering a unit. Skip handling the port change - it will
 			// be handled when the unit is registered.
-			logger.Debugf("failed to lookup %q, skipping port change", unitTag)
+			logger.Debugf("failed to lookup %q, skipping port change", port.UnitTag)
 			return nil
 		}
 		ranges, ok := newPortRanges[unitd.tag]
@@ -678,12 +720,19 @@ func (fw *Firewaller) openedPortsChanged(machineTag names.MachineTag, subnetTag
 			ranges = make(portRanges)
 			newPortRanges[unitd.tag] = ranges
 		}
-		ranges[portRange] = true
+		ranges[endpointPortRange{port.PortRange, port.Endpoint}] = true
 	}
 
 	if !unitPortsEqual(machined.definedPorts, newPortRanges) {
 		machined.definedPorts = newPortRanges
-		return fw.flushMachine(machined)
+		if err := fw.flushMachine(machined); err != nil {
+			return err
+		}
+		unitds := []*unitData{}
+		for _, unitd := range machined.unitds {
+			unitds = append(unitds, unitd)
+		}
+		fw.flushLoadBalancers(unitds)
 	}
 	return nil
 }
@@ -720,7 +769,8 @@ func portRangesEqual(a, b portRanges) bool {
 	return true
 }
 
-// flushUnits opens and closes ports for the passed unit data.
+// flushUnits opens and closes ports for the passed unit data, and
+// updates the load balancers of their applications.
 func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 	machineds := map[names.MachineTag]*machineData{}
 	for _, unitd := range unitds {
@@ -731,6 +781,201 @@ func (fw *Firewaller) flushUnits(unitds []*unitData) error {
 			return err
 		}
 	}
+	fw.flushLoadBalancers(unitds)
+	return nil
+}
+
+// flushLoadBalancers updates the load balancers of the applications of
+// the passed unit data.
+func (fw *Firewaller) flushLoadBalancers(unitds []*unitData) {
+	if fw.environLBs == nil {
+		return
+	}
+	applicationds := make(map[names.ApplicationTag]*applicationData)
+	for _, unitd := range unitds {
+		if unitd.applicationd != nil {
+			applicationds[unitd.applicationd.application.Tag()] = unitd.applicationd
+		}
+	}
+	for _, applicationd := range applicationds {
+		fw.flushLoadBalancer(applicationd)
+	}
+}
+
+// flushLoadBalancer provisions, updates or removes the load balancer of
+// the passed application, so that one exists while the application is
+// exposed through a load balancer and has units with open ports on
+// provisioned machines. Failures are logged rather than returned, so
+// that a cloud refusing a load balancer does not stop the firewaller
+// managing ports; the load balancer is retried with backoff, or sooner
+// on the application's next change.
+func (fw *Firewaller) flushLoadBalancer(applicationd *applicationData) {
+	if fw.environLBs == nil {
+		return
+	}
+	appTag := applicationd.application.Tag()
+	err := fw.updateLoadBalancer(applicationd)
+	if err == nil {
+		applicationd.loadBalancerFailures = 0
+		if _, ok := fw.loadBalancerRetries[appTag]; ok {
+			delete(fw.loadBalancerRetries, appTag)
+			fw.scheduleLoadBalancerRetry()
+		}
+		return
+	}
+	applicationd.loadBalancerSpec = nil
+	applicationd.loadBalancerFailures++
+	delay := loadBalancerRetryDelay(applicationd.loadBalancerFailures)
+	logger.Errorf("cannot update load balancer for %q (retrying in %v): %v", applicationd.application.Name(), delay, err)
+	fw.loadBalancerRetries[appTag] = fw.pollClock.Now().Add(delay)
+	fw.scheduleLoadBalancerRetry()
+}
+
+const (
+	// minLoadBalancerRetryDelay and maxLoadBalancerRetryDelay bound
+	// how long the worker waits before retrying a load balancer that
+	// failed to update.
+	minLoadBalancerRetryDelay = 10 * time.Second
+	maxLoadBalancerRetryDelay = 10 * time.Minute
+)
+
+// loadBalancerRetryDelay returns how long to wait before retrying a
+// load balancer after the given number of consecutive failures.
+func loadBalancerRetryDelay(failures int) time.Duration {
+	delay := minLoadBalancerRetryDelay
+	for i := 1; i < failures && delay < maxLoadBalancerRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxLoadBalancerRetryDelay {
+		delay = maxLoadBalancerRetryDelay
+	}
+	return delay
+}
+
+// scheduleLoadBalancerRetry arranges for loadBalancerRetry to fire
+// when the earliest pending load balancer retry is due.
+func (fw *Firewaller) scheduleLoadBalancerRetry() {
+	fw.loadBalancerRetry = nil
+	var next time.Time
+	for _, at := range fw.loadBalancerRetries {
+		if next.IsZero() || at.Before(next) {
+			next = at
+		}
+	}
+	if !next.IsZero() {
+		fw.loadBalancerRetry = fw.pollClock.After(next.Sub(fw.pollClock.Now()))
+	}
+}
+
+// retryLoadBalancers retries the load balancers whose retries are due.
+func (fw *Firewaller) retryLoadBalancers() {
+	now := fw.pollClock.Now()
+	var due []names.ApplicationTag
+	for appTag, at := range fw.loadBalancerRetries {
+		if !at.After(now) {
+			due = append(due, appTag)
+		}
+	}
+	for _, appTag := range due {
+		delete(fw.loadBalancerRetries, appTag)
+		if applicationd, ok := fw.applicationids[appTag]; ok {
+			fw.flushLoadBalancer(applicationd)
+		}
+	}
+	fw.scheduleLoadBalancerRetry()
+}
+
+func (fw *Firewaller) updateLoadBalancer(applicationd *applicationData) error {
+	appName := applicationd.application.Name()
+	var spec environs.LoadBalancerSpec
+	if applicationd.exposed && applicationd.loadBalanced {
+		var err error
+		if spec, err = fw.loadBalancerSpec(applicationd); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(spec.PortRanges) == 0 || len(spec.Instances) == 0 {
+		applicationd.loadBalancerSpec = nil
+		if !applicationd.loadBalancerExists {
+			return nil
+		}
+		if err := fw.environLBs.DeleteLoadBalancer(fw.cloudCallContext, appName); err != nil {
+			return errors.Annotate(err, "removing load balancer")
+		}
+		logger.Infof("removed load balancer for %q", appName)
+		applicationd.loadBalancerExists = false
+		if len(applicationd.loadBalancerAddresses) == 0 {
+			return nil
+		}
+		return fw.setLoadBalancerAddresses(applicationd, nil)
+	}
+	if reflect.DeepEqual(applicationd.loadBalancerSpec, &spec) {
+		return nil
+	}
+	// A failed call may still have created the load balancer.
+	applicationd.loadBalancerExists = true
+	addrs, err := fw.environLBs.EnsureLoadBalancer(fw.cloudCallContext, spec)
+	if err != nil {
+		return errors.Annotate(err, "provisioning load balancer")
+	}
+	logger.Infof("load balancer for %q forwards %v to %v", appName, spec.PortRanges, spec.Instances)
+	applicationd.loadBalancerSpec = &spec
+	if reflect.DeepEqual(applicationd.loadBalancerAddresses, addrs) {
+		return nil
+	}
+	return fw.setLoadBalancerAddresses(applicationd, addrs)
+}
+
+// loadBalancerSpec returns the load balancer spec for the passed
+// application, forwarding the ports opened by its units to the
+// instances of their machines.
+func (fw *Firewaller) loadBalancerSpec(applicationd *applicationData) (environs.LoadBalancerSpec, error) {
+	spec := environs.LoadBalancerSpec{
+		ApplicationName: applicationd.application.Name(),
+	}
+	ports := make(map[corenetwork.PortRange]bool)
+	instanceIds := set.NewStrings()
+	for unitTag, unitd := range applicationd.unitds {
+		unitPorts := unitd.machined.definedPorts[unitTag]
+		if len(unitPorts) == 0 {
+			continue
+		}
+		m, err := unitd.machined.machine()
+		if params.IsCodeNotFound(err) {
+			continue
+		} else if err != nil {
+			return spec, errors.Trace(err)
+		}
+		instanceId, err := m.InstanceId()
+		if params.IsCodeNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return spec, errors.Trace(err)
+		}
+		instanceIds.Add(string(instanceId))
+		for portRange := range unitPorts {
+			ports[portRange.PortRange] = true
+		}
+	}
+	for portRange := range ports {
+		spec.PortRanges = append(spec.PortRanges, portRange)
+	}
+	corenetwork.SortPortRanges(spec.PortRanges)
+	for _, id := range instanceIds.SortedValues() {
+		spec.Instances = append(spec.Instances, instance.Id(id))
+	}
+	return spec, nil
+}
+
+// setLoadBalancerAddresses records the addresses of the passed
+// application's load balancer. The application may already have been
+// removed, once the load balancer is no longer needed.
+func (fw *Firewaller) setLoadBalancerAddresses(applicationd *applicationData, addrs []network.Address) error {
+	err := applicationd.application.SetLoadBalancerAddresses(addrs)
+	if err != nil && !params.IsCodeNotFound(err) {
+		return errors.Annotate(err, "recording load balancer addresses")
+	}
+	applicationd.loadBalancerAddresses = addrs
 	return nil
 }
 
@@ -760,26 +1005,35 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 				continue
 			}
 
-			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
-			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
-				// Not exposed, so add any ingress rules required by remote relations.
-				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
-					return nil, errors.Trace(err)
-				}
-				logger.Debugf("CIDRS for %v: %v", unitTag, cidrs.Values())
-			}
-			if cidrs.Size() > 0 {
-				for portRange := range portRanges {
-					sourceCidrs := cidrs.SortedValues()
-					rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
-					if err != nil {
-						return nil, errors.Trace(err)
+			// Ranges opened for an endpoint only admit the remote
+			// relations on that endpoint, so the CIDRs are gathered
+			// once for each endpoint the unit has opened ranges for.
+			endpointCidrs := make(map[string][]string)
+			for portRange := range portRanges {
+				sourceCidrs, ok := endpointCidrs[portRange.Endpoint]
+				if !ok {
+					cidrs := set.NewStrings()
+					// If the unit is exposed, allow access from everywhere.
+					if unitd.applicationd.exposed {
+						cidrs.Add("0.0.0.0/0")
+					} else {
+						// Not exposed, so add any ingress rules required by remote relations.
+						if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), portRange.Endpoint, cidrs); err != nil {
+							return nil, errors.Trace(err)
+						}
+						logger.Debugf("CIDRS for %v, endpoint %q: %v", unitTag, portRange.Endpoint, cidrs.Values())
 					}
-					want = append(want, rule)
+					sourceCidrs = cidrs.SortedValues()
+					endpointCidrs[portRange.Endpoint] = sourceCidrs
 				}
+				if len(sourceCidrs) == 0 {
+					continue
+				}
+				rule, err := network.NewIngressRule(portRange.Protocol, portRange.FromPort, portRange.ToPort, sourceCidrs...)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				want = append(want, rule)
 			}
 		}
 	}
@@ -789,7 +1043,10 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 // TODO(wallyworld) - consider making this configurable.
 const maxAllowedCIDRS = 20
 
-func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag, cidrs set.Strings) error {
+// updateForRemoteRelationIngress adds to cidrs the networks of the remote
+// relations of the application that require ingress. If endpoint is not
+// empty, only the relations on that endpoint are considered.
+func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag, endpoint string, cidrs set.Strings) error {
 	logger.Debugf("finding egress rules for %v", appTag)
 	// Now create the rules for any remote relations of which the
 	// unit's application is a part.
@@ -798,6 +1055,9 @@ func (fw *Firewaller) updateForRemoteRelationIngress(appTag names.ApplicationTag
 		if data.localApplicationTag != appTag {
 			continue
 		}
+		if endpoint != "" && data.localEndpoint != endpoint {
+			continue
+		}
 		if !data.ingressRequired {
 			continue
 		}
@@ -1009,7 +1269,16 @@ func (fw *Firewaller) forgetUnit(unitd *unitData) {
 	logger.Debugf("stopped watching %q", unitd.tag)
 	if stoppedApplication {
 		applicationTag := applicationd.application.Tag()
+		if applicationd.loadBalancerExists {
+			// Without units there's nothing to balance, and nothing
+			// would remove the load balancer later.
+			applicationd.exposed = false
+			if err := fw.updateLoadBalancer(applicationd); err != nil {
+				logger.Errorf("cannot remove load balancer for %q: %v", applicationd.application.Name(), err)
+			}
+		}
 		delete(fw.applicationids, applicationTag)
+		delete(fw.loadBalancerRetries, applicationTag)
 		logger.Debugf("stopped watching %q", applicationTag)
 	}
 }
@@ -1086,10 +1355,12 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed and load balanced flags
+// for one specific application.
 type exposedChange struct {
 	applicationd *applicationData
 	exposed      bool
+	loadBalanced bool
 }
 
 // applicationData holds application details and watches exposure changes.
@@ -1099,10 +1370,31 @@ type applicationData struct {
 	application *firewaller.Application
 	exposed     bool
 	unitds      map[names.UnitTag]*unitData
+
+	// loadBalanced is true if the application is exposed through a
+	// load balancer.
+	loadBalanced bool
+
+	// loadBalancerSpec holds the spec of the application's load
+	// balancer, as last provisioned by this worker.
+	loadBalancerSpec *environs.LoadBalancerSpec
+
+	// loadBalancerAddresses holds the addresses recorded for the
+	// application's load balancer.
+	loadBalancerAddresses []network.Address
+
+	// loadBalancerExists is true if the application's load balancer
+	// may exist, and must be deleted once it is no longer needed.
+	loadBalancerExists bool
+
+	// loadBalancerFailures counts the consecutive failures to update
+	// the application's load balancer.
+	loadBalancerFailures int
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's exposed and load balanced flags
+// for changes.
+func (ad *applicationData) watchLoop(exposed, loadBalanced bool) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1129,17 +1421,28 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return errors.Trace(err)
 			}
-			if change == exposed {
+			loadBalancedChange := loadBalanced
+			if ad.fw.environLBs != nil {
+				loadBalancedChange, _, err = ad.application.LoadBalancer()
+				if errors.IsNotFound(err) {
+					logger.Debugf("application(%q).LoadBalancer() returned NotFound: %v", ad.application.Name(), err)
+					return nil
+				} else if err != nil {
+					return errors.Trace(err)
+				}
+			}
+			if change == exposed && loadBalancedChange == loadBalanced {
 				logger.Tracef("application(%q).IsExposed() == %v (unchanged)", ad.application.Name(), exposed)
 				continue
 			}
 			logger.Tracef("application(%q).IsExposed() changed %v => %v", ad.application.Name(), exposed, change)
 
 			exposed = change
+			loadBalanced = loadBalancedChange
 			select {
 			case <-ad.catacomb.Dying():
 				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
+			case ad.fw.exposedChange <- &exposedChange{ad, change, loadBalancedChange}:
 			}
 		}
 	}
@@ -1285,6 +1588,7 @@ type remoteRelationData struct {
 
 	tag                 names.RelationTag
 	localApplicationTag names.ApplicationTag
+	localEndpoint       string
 	relationToken       string
 	applicationToken    string
 	remoteModelUUID     string
@@ -1317,6 +1621,7 @@ func (fw *Firewaller) startRelation(rel *params.RemoteRelation, role charm.Relat
 		tag:                 tag,
 		remoteModelUUID:     rel.SourceModelUUID,
 		localApplicationTag: names.NewApplicationTag(rel.ApplicationName),
+		localEndpoint:       rel.Endpoint.Name,
 		endpointRole:        role,
 		relationReady:       make(chan remoteRelationInfo),
 	}
@@ -29,6 +29,8 @@ import (
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/instance"
+	corenetwork "github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -196,12 +198,14 @@ func (s *firewallerBaseSuite) startInstance(c *gc.C, m *state.Machine) instances
 
 type InstanceModeSuite struct {
 	firewallerBaseSuite
+	environLBs firewaller.EnvironLoadBalancers
 }
 
 var _ = gc.Suite(&InstanceModeSuite{})
 
 func (s *InstanceModeSuite) SetUpTest(c *gc.C) {
 	s.firewallerBaseSuite.setUpTest(c, config.FwInstance)
+	s.environLBs = nil
 }
 
 func (s *InstanceModeSuite) TearDownTest(c *gc.C) {
@@ -234,12 +238,13 @@ func (s *InstanceModeSuite) newFirewallerWithClock(c *gc.C, clock clock.Clock) w
 	c.Assert(ok, gc.Equals, true)
 
 	cfg := firewaller.Config{
-		ModelUUID:          s.State.ModelUUID(),
-		Mode:               config.FwInstance,
-		EnvironFirewaller:  fwEnv,
-		EnvironInstances:   s.Environ,
-		FirewallerAPI:      s.firewaller,
-		RemoteRelationsApi: s.remoteRelations,
+		ModelUUID:            s.State.ModelUUID(),
+		Mode:                 config.FwInstance,
+		EnvironFirewaller:    fwEnv,
+		EnvironInstances:     s.Environ,
+		EnvironLoadBalancers: s.environLBs,
+		FirewallerAPI:        s.firewaller,
+		RemoteRelationsApi:   s.remoteRelations,
 		NewCrossModelFacadeFunc: func(*api.Info) (firewaller.CrossModelFirewallerFacadeCloser, error) {
 			return s.crossmodelFirewaller, nil
 		},
@@ -306,6 +311,129 @@ func (s *InstanceModeSuite) TestExposedApplication(c *gc.C) {
 	})
 }
 
+func (s *InstanceModeSuite) TestExposedApplicationWithLoadBalancer(c *gc.C) {
+	lbs := &mockLoadBalancers{
+		ensured: make(chan environs.LoadBalancerSpec, 10),
+		deleted: make(chan string, 10),
+	}
+	s.environLBs = lbs
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposedWithLoadBalancer()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+
+	select {
+	case spec := <-lbs.ensured:
+		c.Assert(spec, jc.DeepEquals, environs.LoadBalancerSpec{
+			ApplicationName: "wordpress",
+			PortRanges:      []corenetwork.PortRange{{FromPort: 80, ToPort: 80, Protocol: "tcp"}},
+			Instances:       []instance.Id{inst.Id()},
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for load balancer")
+	}
+	s.assertLoadBalancerAddresses(c, app, network.NewAddresses("203.0.113.10"))
+
+	err = app.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case name := <-lbs.deleted:
+		c.Assert(name, gc.Equals, "wordpress")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for load balancer removal")
+	}
+	s.assertLoadBalancerAddresses(c, app, nil)
+}
+
+func (s *InstanceModeSuite) TestLoadBalancerRetriedAfterFailure(c *gc.C) {
+	lbs := &mockLoadBalancers{
+		ensured:      make(chan environs.LoadBalancerSpec, 10),
+		deleted:      make(chan string, 10),
+		ensureErrors: []error{errors.New("quota exceeded")},
+	}
+	s.environLBs = lbs
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposedWithLoadBalancer()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The first attempt fails, and is retried without any further
+	// change to the application.
+	for i := 0; i < 2; i++ {
+		select {
+		case <-lbs.ensured:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for load balancer attempt %d", i+1)
+		}
+	}
+	s.assertLoadBalancerAddresses(c, app, network.NewAddresses("203.0.113.10"))
+}
+
+func (s *InstanceModeSuite) TestLoadBalancerRemovedWithoutAddresses(c *gc.C) {
+	lbs := &mockLoadBalancers{
+		ensured:      make(chan environs.LoadBalancerSpec, 10),
+		deleted:      make(chan string, 10),
+		ensureErrors: []error{errors.New("timed out"), errors.New("timed out")},
+	}
+	s.environLBs = lbs
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposedWithLoadBalancer()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-lbs.ensured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for load balancer")
+	}
+
+	// No addresses were recorded, but the failed call may have
+	// created the load balancer, so it is still removed.
+	err = app.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case name := <-lbs.deleted:
+		c.Assert(name, gc.Equals, "wordpress")
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for load balancer removal")
+	}
+}
+
+func (s *InstanceModeSuite) assertLoadBalancerAddresses(c *gc.C, app *state.Application, expected []network.Address) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		err := app.Refresh()
+		c.Assert(err, jc.ErrorIsNil)
+		addrs := app.LoadBalancerAddresses()
+		if len(addrs) == len(expected) && (len(addrs) == 0 || reflect.DeepEqual(addrs, expected)) {
+			return
+		}
+		if !a.HasNext() {
+			c.Fatalf("load balancer addresses %v, expected %v", addrs, expected)
+		}
+	}
+}
+
 func (s *InstanceModeSuite) TestMultipleExposedApplications(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -1279,6 +1407,59 @@ func (s *InstanceModeSuite) TestRemoteRelationIngressMergesCIDRS(c *gc.C) {
 	s.assertIngressCidrs(c, ingress, expected)
 }
 
+func (s *InstanceModeSuite) TestRemoteRelationIngressForEndpoint(c *gc.C) {
+	// Set up the offering model - create the local app, with ports
+	// opened for the offered endpoint and for another endpoint.
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	u, m := s.addUnit(c, mysql)
+	inst := s.startInstance(c, m)
+	err := u.OpenPortsOnEndpoint("server", "tcp", 3306, 3306)
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.OpenPortsOnEndpoint("server-admin", "tcp", 3307, 3307)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Set up the offering model - create the remote app.
+	consumingModelTag := names.NewModelTag(utils.MustNewUUID().String())
+	relToken := utils.MustNewUUID().String()
+	appToken := utils.MustNewUUID().String()
+	app, err := s.State.AddRemoteApplication(state.AddRemoteApplicationParams{
+		Name: "wordpress", SourceModel: consumingModelTag, IsConsumerProxy: true,
+		Endpoints: []charm.Relation{{Name: "db", Interface: "mysql", Role: "requirer", Scope: "global"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	rel, err := s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+	re := s.State.RemoteEntities()
+	err = re.ImportRemoteEntity(rel.Tag(), relToken)
+	c.Assert(err, jc.ErrorIsNil)
+	err = re.ImportRemoteEntity(app.Tag(), appToken)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), nil)
+
+	// Only the range opened for the related endpoint admits the
+	// relation's ingress networks.
+	rin := state.NewRelationIngressNetworks(s.State)
+	_, err = rin.Save(rel.Tag().Id(), false, []string{"10.0.0.4/16"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 3306, 3306, "10.0.0.4/16"),
+	})
+
+	// Exposing the application opens both ranges to everyone.
+	err = mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 3306, 3306, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", 3307, 3307, "0.0.0.0/0"),
+	})
+}
+
 type GlobalModeSuite struct {
 	firewallerBaseSuite
 }
@@ -1563,3 +1744,27 @@ func (s *NoneModeSuite) TestStopImmediately(c *gc.C) {
 	_, err := firewaller.NewFirewaller(cfg)
 	c.Assert(err, gc.ErrorMatches, `invalid firewall-mode "none"`)
 }
+
+type mockLoadBalancers struct {
+	ensured chan environs.LoadBalancerSpec
+	deleted chan string
+
+	// ensureErrors are returned by the first calls to
+	// EnsureLoadBalancer, in order.
+	ensureErrors []error
+}
+
+func (m *mockLoadBalancers) EnsureLoadBalancer(ctx context.ProviderCallContext, spec environs.LoadBalancerSpec) ([]network.Address, error) {
+	m.ensured <- spec
+	if len(m.ensureErrors) > 0 {
+		err := m.ensureErrors[0]
+		m.ensureErrors = m.ensureErrors[1:]
+		return nil, err
+	}
+	return network.NewAddresses("203.0.113.10"), nil
+}
+
+func (m *mockLoadBalancers) DeleteLoadBalancer(ctx context.ProviderCallContext, applicationName string) error {
+	m.deleted <- applicationName
+	return nil
+}
@@ -122,12 +122,20 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		return nil, errors.Trace(err)
 	}
 
+	// Load balancers are optional; a nil EnvironLoadBalancers leaves
+	// applications exposed through one without a load balancer.
+	var lbEnv EnvironLoadBalancers
+	if lbs, ok := environs.SupportsLoadBalancers(environ); ok {
+		lbEnv = lbs
+	}
+
 	w, err := cfg.NewFirewallerWorker(Config{
 		ModelUUID:               agent.CurrentConfig().Model().Id(),
 		RemoteRelationsApi:      remoteRelationsAPI,
 		FirewallerAPI:           firewallerAPI,
 		EnvironFirewaller:       fwEnv,
 		EnvironInstances:        environ,
+		EnvironLoadBalancers:    lbEnv,
 		Mode:                    mode,
 		NewCrossModelFacadeFunc: crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
 		CredentialAPI:           credentialAPI,
@@ -5,6 +5,7 @@ package httpserver
 
 import (
 	"crypto/tls"
+	"net/http"
 	"time"
 
 	"github.com/juju/clock"
@@ -42,7 +43,7 @@ type ManifoldConfig struct {
 	PrometheusRegisterer prometheus.Registerer
 
 	GetControllerConfig func(*state.State) (controller.Config, error)
-	NewTLSConfig        func(*state.State, func() *tls.Certificate) (*tls.Config, error)
+	NewTLSConfig        func(*state.State, func() *tls.Certificate) (*tls.Config, http.Handler, error)
 	NewWorker           func(Config) (worker.Worker, error)
 }
 
@@ -93,6 +94,10 @@ func (config ManifoldConfig) Validate() error {
 	return nil
 }
 
+// autocertChallengePort is the port on which ACME http-01 challenges
+// are answered; ACME servers always connect to port 80.
+const autocertChallengePort = 80
+
 // Manifold returns a dependency.Manifold that will run an HTTP server
 // worker. The manifold outputs an *apiserverhttp.Mux, for other workers
 // to register handlers against.
@@ -155,7 +160,7 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 	}()
 
 	systemState := statePool.SystemState()
-	tlsConfig, err := config.NewTLSConfig(systemState, getCertificate)
+	tlsConfig, autocertHandler, err := config.NewTLSConfig(systemState, getCertificate)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -176,6 +181,9 @@ func (config ManifoldConfig) start(context dependency.Context) (_ worker.Worker,
 		APIPort:              controllerConfig.APIPort(),
 		APIPortOpenDelay:     controllerConfig.APIPortOpenDelay(),
 		ControllerAPIPort:    controllerConfig.ControllerAPIPort(),
+		AutocertDNSName:      controllerConfig.AutocertDNSName(),
+		AutocertHandler:      autocertHandler,
+		AutocertListenPort:   autocertChallengePort,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
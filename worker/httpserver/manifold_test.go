@@ -5,6 +5,7 @@ package httpserver_test
 
 import (
 	"crypto/tls"
+	"net/http"
 	"time"
 
 	"github.com/juju/clock/testclock"
@@ -37,6 +38,7 @@ type ManifoldSuite struct {
 	prometheusRegisterer stubPrometheusRegisterer
 	certWatcher          stubCertWatcher
 	tlsConfig            *tls.Config
+	autocertHandler      http.Handler
 	controllerConfig     controller.Config
 
 	stub testing.Stub
@@ -54,10 +56,12 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	s.prometheusRegisterer = stubPrometheusRegisterer{}
 	s.certWatcher = stubCertWatcher{}
 	s.tlsConfig = &tls.Config{}
+	s.autocertHandler = http.NewServeMux()
 	s.controllerConfig = controller.Config(map[string]interface{}{
 		"api-port":            1024,
 		"controller-api-port": 2048,
 		"api-port-open-delay": "5s",
+		"autocert-dns-name":   "public.invalid",
 	})
 	s.stub.ResetCalls()
 
@@ -107,12 +111,12 @@ func (s *ManifoldSuite) getControllerConfig(st *state.State) (controller.Config,
 func (s *ManifoldSuite) newTLSConfig(
 	st *state.State,
 	getCertificate func() *tls.Certificate,
-) (*tls.Config, error) {
+) (*tls.Config, http.Handler, error) {
 	s.stub.MethodCall(s, "NewTLSConfig", st)
 	if err := s.stub.NextErr(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return s.tlsConfig, nil
+	return s.tlsConfig, s.autocertHandler, nil
 }
 
 func (s *ManifoldSuite) newWorker(config httpserver.Config) (worker.Worker, error) {
@@ -168,6 +172,9 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 		ControllerAPIPort:    2048,
 		MuxShutdownWait:      1 * time.Minute,
 		LogDir:               "log-dir",
+		AutocertDNSName:      "public.invalid",
+		AutocertHandler:      s.autocertHandler,
+		AutocertListenPort:   80,
 	})
 }
 
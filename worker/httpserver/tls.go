@@ -6,6 +6,7 @@ package httpserver
 import (
 	"crypto/tls"
 	"net"
+	"net/http"
 	"strings"
 
 	"github.com/juju/errors"
@@ -13,29 +14,42 @@ import (
 	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/state"
 )
 
 // NewTLSConfig returns the TLS configuration for the HTTP server to use
-// based on controller configuration stored in the state database.
-func NewTLSConfig(st *state.State, getCertificate func() *tls.Certificate) (*tls.Config, error) {
+// based on controller configuration stored in the state database. When
+// the controller is configured to answer ACME http-01 challenges, the
+// handler for those challenges is also returned; otherwise it is nil.
+func NewTLSConfig(st *state.State, getCertificate func() *tls.Certificate) (*tls.Config, http.Handler, error) {
 	controllerConfig, err := st.ControllerConfig()
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
-	return newTLSConfig(
+	tlsConfig, m := newTLSConfig(
 		controllerConfig.AutocertDNSName(),
 		controllerConfig.AutocertURL(),
 		st.AutocertCache(),
 		getCertificate,
-	), nil
+	)
+	var challengeHandler http.Handler
+	if m != nil && controllerConfig.AutocertChallenge() == controller.AutocertChallengeHTTP01 {
+		// Asking the manager for its HTTP handler is also what
+		// enables it to attempt http-01 challenges.
+		challengeHandler = m.HTTPHandler(nil)
+	}
+	return tlsConfig, challengeHandler, nil
 }
 
+// newTLSConfig returns the TLS configuration for the HTTP server, and
+// the autocert manager used to obtain and renew official certificates,
+// which is nil if no autocert DNS name is configured.
 func newTLSConfig(
 	autocertDNSName, autocertURL string,
 	autocertCache autocert.Cache,
 	getLocalCertificate func() *tls.Certificate,
-) *tls.Config {
+) (*tls.Config, *autocert.Manager) {
 	// localCertificate calls getLocalCertificate, returning the result
 	// and reporting whether it should be used to serve a connection
 	// addressed to the given server name.
@@ -68,9 +82,9 @@ func newTLSConfig(
 			cert, _ := localCertificate(clientHello.ServerName)
 			return cert, nil
 		}
-		return tlsConfig
+		return tlsConfig, nil
 	}
-	m := autocert.Manager{
+	m := &autocert.Manager{
 		Prompt:     autocert.AcceptTOS,
 		Cache:      autocertCache,
 		HostPolicy: autocert.HostWhitelist(autocertDNSName),
@@ -100,5 +114,5 @@ func newTLSConfig(
 		"h2", "http/1.1", // Enable HTTP/2.
 		acme.ALPNProto, // Enable TLS-ALPN ACME challenges.
 	}
-	return tlsConfig
+	return tlsConfig, m
 }
@@ -44,7 +44,7 @@ type TLSStateSuite struct {
 var _ = gc.Suite(&TLSStateSuite{})
 
 func (s *TLSStateSuite) TestNewTLSConfig(c *gc.C) {
-	tlsConfig, err := httpserver.NewTLSConfig(s.State, s.getCertificate)
+	tlsConfig, _, err := httpserver.NewTLSConfig(s.State, s.getCertificate)
 	c.Assert(err, jc.ErrorIsNil)
 
 	cert, err := tlsConfig.GetCertificate(&tls.ClientHelloInfo{
@@ -80,7 +80,7 @@ func (s *TLSStateAutocertSuite) SetUpTest(c *gc.C) {
 }
 
 func (s *TLSStateAutocertSuite) TestAutocertExceptions(c *gc.C) {
-	tlsConfig, err := httpserver.NewTLSConfig(s.State, s.getCertificate)
+	tlsConfig, _, err := httpserver.NewTLSConfig(s.State, s.getCertificate)
 	c.Assert(err, jc.ErrorIsNil)
 	s.testGetCertificate(c, tlsConfig, "127.0.0.1")
 	s.testGetCertificate(c, tlsConfig, "juju-apiserver")
@@ -89,20 +89,48 @@ func (s *TLSStateAutocertSuite) TestAutocertExceptions(c *gc.C) {
 }
 
 func (s *TLSStateAutocertSuite) TestAutocert(c *gc.C) {
-	tlsConfig, err := httpserver.NewTLSConfig(s.State, s.getCertificate)
+	tlsConfig, challengeHandler, err := httpserver.NewTLSConfig(s.State, s.getCertificate)
 	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(challengeHandler, gc.IsNil)
 	s.testGetCertificate(c, tlsConfig, "public.invalid")
 	c.Assert(s.autocertQueried, jc.IsTrue)
 	c.Assert(tlsConfig.NextProtos, jc.DeepEquals, []string{"h2", "http/1.1", acme.ALPNProto})
 }
 
 func (s *TLSStateAutocertSuite) TestAutocertHostPolicy(c *gc.C) {
-	tlsConfig, err := httpserver.NewTLSConfig(s.State, s.getCertificate)
+	tlsConfig, _, err := httpserver.NewTLSConfig(s.State, s.getCertificate)
 	c.Assert(err, jc.ErrorIsNil)
 	s.testGetCertificate(c, tlsConfig, "always.invalid")
 	c.Assert(s.autocertQueried, jc.IsFalse)
 }
 
+type TLSStateAutocertHTTPSuite struct {
+	tlsStateFixture
+}
+
+var _ = gc.Suite(&TLSStateAutocertHTTPSuite{})
+
+func (s *TLSStateAutocertHTTPSuite) SetUpSuite(c *gc.C) {
+	s.ControllerConfig = map[string]interface{}{
+		"autocert-dns-name":  "public.invalid",
+		"autocert-url":       "https://0.1.2.3/no-autocert-here",
+		"autocert-challenge": "http-01",
+	}
+	s.tlsStateFixture.SetUpSuite(c)
+}
+
+func (s *TLSStateAutocertHTTPSuite) TestChallengeHandler(c *gc.C) {
+	_, challengeHandler, err := httpserver.NewTLSConfig(s.State, s.getCertificate)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(challengeHandler, gc.NotNil)
+
+	// Requests for unknown challenge tokens are not found.
+	req := httptest.NewRequest("GET", "http://public.invalid/.well-known/acme-challenge/unknown", nil)
+	rec := httptest.NewRecorder()
+	challengeHandler.ServeHTTP(rec, req)
+	c.Assert(rec.Code, gc.Equals, http.StatusNotFound)
+}
+
 func (s *TLSStateAutocertSuite) testGetCertificate(c *gc.C, tlsConfig *tls.Config, serverName string) {
 	cert, err := tlsConfig.GetCertificate(&tls.ClientHelloInfo{
 		ServerName: serverName,
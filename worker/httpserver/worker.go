@@ -44,6 +44,16 @@ type Config struct {
 	APIPort              int
 	APIPortOpenDelay     time.Duration
 	ControllerAPIPort    int
+
+	// AutocertDNSName, if set, is the DNS name for which an official
+	// certificate is requested as soon as the server is running, so
+	// that it is obtained (and kept renewed) before clients need it.
+	AutocertDNSName string
+
+	// AutocertHandler, if set, answers ACME http-01 challenges. It is
+	// served over plain HTTP on AutocertListenPort.
+	AutocertHandler    http.Handler
+	AutocertListenPort int
 }
 
 // Validate validates the API server configuration.
@@ -86,11 +96,24 @@ func NewWorker(config Config) (*Worker, error) {
 	}
 	w.holdable = newHeldListener(listener, config.Clock)
 
+	if config.AutocertHandler != nil {
+		listenAddr := net.JoinHostPort("", strconv.Itoa(config.AutocertListenPort))
+		w.autocertListener, err = net.Listen("tcp", listenAddr)
+		if err != nil {
+			listener.Close()
+			return nil, errors.Annotate(err, "cannot listen for ACME challenges")
+		}
+		logger.Infof("listening for ACME challenges on %q", w.autocertListener.Addr())
+	}
+
 	if err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
 		Work: w.loop,
 	}); err != nil {
 		listener.Close()
+		if w.autocertListener != nil {
+			w.autocertListener.Close()
+		}
 		return nil, errors.Trace(err)
 	}
 	return w, nil
@@ -106,6 +129,10 @@ type Worker struct {
 	url      chan string
 	holdable *heldListener
 
+	// autocertListener is the plain HTTP listener used to answer
+	// ACME http-01 challenges, if enabled.
+	autocertListener net.Listener
+
 	// mu controls access to both status and reporter.
 	mu     sync.Mutex
 	status string
@@ -133,6 +160,9 @@ func (w *Worker) Report() map[string]interface{} {
 		result["api-port-open-delay"] = w.config.APIPortOpenDelay
 		result["controller-api-port"] = w.config.ControllerAPIPort
 	}
+	if w.autocertListener != nil {
+		result["acme-challenge-listening"] = w.autocertListener.Addr().String()
+	}
 	w.mu.Unlock()
 	return result
 }
@@ -173,6 +203,25 @@ func (w *Worker) loop() error {
 		w.catacomb.Kill(err)
 	}()
 
+	if w.autocertListener != nil {
+		challengeServer := &http.Server{
+			Handler:  w.config.AutocertHandler,
+			ErrorLog: serverLog,
+		}
+		go func() {
+			err := challengeServer.Serve(w.autocertListener)
+			if err != nil && err != http.ErrServerClosed {
+				logger.Errorf("ACME challenge server finished with error %v", err)
+			}
+		}()
+		defer func() {
+			w.catacomb.Kill(challengeServer.Close())
+		}()
+	}
+	if w.config.AutocertDNSName != "" && w.config.TLSConfig.GetCertificate != nil {
+		go w.requestAutocertCertificate()
+	}
+
 	w.mu.Lock()
 	w.status = "running"
 	w.mu.Unlock()
@@ -193,6 +242,19 @@ func (w *Worker) loop() error {
 	}
 }
 
+// requestAutocertCertificate asks the TLS configuration for the
+// certificate of the autocert DNS name, which obtains it from the ACME
+// server if it isn't already cached. Once a certificate has been
+// obtained, the autocert manager renews it before it expires.
+func (w *Worker) requestAutocertCertificate() {
+	_, err := w.config.TLSConfig.GetCertificate(&tls.ClientHelloInfo{
+		ServerName: w.config.AutocertDNSName,
+	})
+	if err != nil {
+		logger.Errorf("cannot get certificate for %q: %v", w.config.AutocertDNSName, err)
+	}
+}
+
 func (w *Worker) shutdown() error {
 	muxDone := make(chan struct{})
 	go func() {
@@ -431,3 +431,25 @@ func (s *WorkerControllerPortSuite) TestDualPortListenerWithDelay(c *gc.C) {
 	reportPorts["agent"] = fmt.Sprintf("[::]:%d", s.config.APIPort)
 	c.Check(worker.Report(), jc.DeepEquals, report)
 }
+
+func (s *WorkerSuite) TestAutocertChallengeListener(c *gc.C) {
+	config := s.config
+	config.AutocertHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "challenge "+r.URL.Path)
+	})
+	config.AutocertListenPort = 0
+	worker, err := httpserver.NewWorker(config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, worker)
+
+	report := worker.Report()
+	addr, ok := report["acme-challenge-listening"].(string)
+	c.Assert(ok, jc.IsTrue)
+
+	resp, err := http.Get("http://" + addr + "/.well-known/acme-challenge/token")
+	c.Assert(err, jc.ErrorIsNil)
+	defer resp.Body.Close()
+	out, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Equals, "challenge /.well-known/acme-challenge/token")
+}
@@ -0,0 +1,147 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package idleadvisor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/api/idleadvisor"
+	"github.com/juju/juju/environs/config"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.idleadvisor")
+
+// DefaultPeriod is the time between analyses of the model's utilisation.
+const DefaultPeriod = time.Hour
+
+// Facade exposes the controller functionality needed by the idle
+// advisor.
+type Facade interface {
+	ModelConfig() (*config.Config, error)
+	ModelUtilisation(metric string, since time.Time) (idleadvisor.ModelUtilisation, error)
+	IdleResources() ([]idleadvisor.IdleResource, error)
+	SetIdleResources([]idleadvisor.IdleResource) error
+}
+
+// Config holds the configuration and dependencies for an idle advisor
+// worker.
+type Config struct {
+	// Facade is used to read the model config and utilisation, and to
+	// record the idle machines and units.
+	Facade Facade
+
+	// Clock is used to time how long machines and units are idle.
+	Clock clock.Clock
+
+	// NewTimer is used to schedule the analyses.
+	NewTimer jworker.NewTimerFunc
+
+	// Period is the time between analyses.
+	Period time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start an
+// idle advisor.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewTimer == nil {
+		return errors.NotValidf("nil NewTimer")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that periodically records the model's
+// idle machines and units. Machines are idle when they host no units or
+// containers, and units are idle when the values they record for the
+// model's idle-utilisation-metric stay below its
+// idle-utilisation-threshold. Idle resources are only reported to
+// users, and never removed.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	a := &advisor{config: config}
+	analyse := func(stop <-chan struct{}) error {
+		return a.analyse()
+	}
+	return jworker.NewPeriodicWorker(analyse, config.Period, config.NewTimer), nil
+}
+
+type advisor struct {
+	config Config
+}
+
+// analyse records the machines and units that are idle now. Those that
+// were already idle, for the same reason, keep the time they were first
+// found to be idle.
+func (a *advisor) analyse() error {
+	cfg, err := a.config.Facade.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	previous, err := a.config.Facade.IdleResources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	days := cfg.IdleResourceDays()
+	if days == 0 {
+		if len(previous) == 0 {
+			return nil
+		}
+		return errors.Trace(a.config.Facade.SetIdleResources(nil))
+	}
+
+	now := a.config.Clock.Now()
+	metric := cfg.IdleUtilisationMetric()
+	threshold := cfg.IdleUtilisationThreshold()
+	utilisation, err := a.config.Facade.ModelUtilisation(metric, now.Add(-a.config.Period))
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	previousByTag := make(map[string]idleadvisor.IdleResource)
+	for _, r := range previous {
+		previousByTag[r.Entity.String()] = r
+	}
+	idleFor := time.Duration(days) * 24 * time.Hour
+	var idle []idleadvisor.IdleResource
+	addIdle := func(tag names.Tag, reason string) {
+		r := idleadvisor.IdleResource{Entity: tag, Since: now, Reason: reason}
+		if p, ok := previousByTag[tag.String()]; ok && p.Reason == reason {
+			r.Since = p.Since
+		}
+		// Log each resource once, when it is first reported.
+		if d := now.Sub(r.Since); d >= idleFor && d < idleFor+a.config.Period {
+			logger.Infof("%s has been idle for %d days: %s", names.ReadableString(tag), days, reason)
+		}
+		idle = append(idle, r)
+	}
+	for _, m := range utilisation.Machines {
+		if m.Units == 0 && m.Containers == 0 {
+			addIdle(m.Tag, "no units or containers")
+		}
+	}
+	for _, u := range utilisation.Units {
+		if u.Peak != nil && *u.Peak < float64(threshold) {
+			addIdle(u.Tag, fmt.Sprintf("%s below %d", metric, threshold))
+		}
+	}
+	return errors.Trace(a.config.Facade.SetIdleResources(idle))
+}
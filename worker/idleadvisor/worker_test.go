@@ -0,0 +1,204 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package idleadvisor_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/api/idleadvisor"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	jworker "github.com/juju/juju/worker"
+	idleadvisorworker "github.com/juju/juju/worker/idleadvisor"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	facade *mockFacade
+	clock  *testclock.Clock
+	ticks  chan time.Time
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var (
+	now      = time.Date(2019, 5, 8, 12, 0, 0, 0, time.UTC)
+	peakLow  = 1.5
+	peakHigh = 50.0
+)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &mockFacade{
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"idle-utilisation-metric": "cpu",
+		}),
+		utilisation: idleadvisor.ModelUtilisation{
+			Machines: []idleadvisor.MachineUtilisation{
+				{Tag: names.NewMachineTag("1")},
+				{Tag: names.NewMachineTag("2"), Units: 2},
+				{Tag: names.NewMachineTag("3"), Containers: 1},
+			},
+			Units: []idleadvisor.UnitUtilisation{
+				{Tag: names.NewUnitTag("mysql/0"), Peak: &peakLow},
+				{Tag: names.NewUnitTag("mysql/1"), Peak: &peakHigh},
+				{Tag: names.NewUnitTag("mysql/2")},
+			},
+		},
+		set: make(chan []idleadvisor.IdleResource, 1),
+	}
+	s.clock = testclock.NewClock(now)
+	s.ticks = make(chan time.Time)
+}
+
+func (s *WorkerSuite) config() idleadvisorworker.Config {
+	return idleadvisorworker.Config{
+		Facade: s.facade,
+		Clock:  s.clock,
+		NewTimer: func(time.Duration) jworker.PeriodicTimer {
+			return &fakeTimer{s.ticks}
+		},
+		Period: time.Hour,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	_, err := idleadvisorworker.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.Clock = nil
+	_, err = idleadvisorworker.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+
+	config = s.config()
+	config.Period = 0
+	_, err = idleadvisorworker.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestRecordsIdleResources(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.analyse(c), jc.DeepEquals, []idleadvisor.IdleResource{
+		{Entity: names.NewMachineTag("1"), Since: now, Reason: "no units or containers"},
+		{Entity: names.NewUnitTag("mysql/0"), Since: now, Reason: "cpu below 5"},
+	})
+	c.Assert(s.facade.since, gc.Equals, now.Add(-time.Hour))
+}
+
+func (s *WorkerSuite) TestKeepsIdleSince(c *gc.C) {
+	earlier := now.Add(-72 * time.Hour)
+	s.facade.idle = []idleadvisor.IdleResource{
+		{Entity: names.NewMachineTag("1"), Since: earlier, Reason: "no units or containers"},
+		{Entity: names.NewMachineTag("2"), Since: earlier, Reason: "no units or containers"},
+		{Entity: names.NewUnitTag("mysql/0"), Since: earlier, Reason: "cpu below 10"},
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.analyse(c), jc.DeepEquals, []idleadvisor.IdleResource{
+		{Entity: names.NewMachineTag("1"), Since: earlier, Reason: "no units or containers"},
+		{Entity: names.NewUnitTag("mysql/0"), Since: now, Reason: "cpu below 5"},
+	})
+}
+
+func (s *WorkerSuite) TestDisabled(c *gc.C) {
+	cfg, err := s.facade.config.Apply(map[string]interface{}{
+		"idle-resource-days": 0,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.config = cfg
+	s.facade.idle = []idleadvisor.IdleResource{
+		{Entity: names.NewMachineTag("1"), Since: now, Reason: "no units or containers"},
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.analyse(c), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := idleadvisorworker.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+// analyse triggers an analysis, and returns the idle resources
+// recorded by it.
+func (s *WorkerSuite) analyse(c *gc.C) []idleadvisor.IdleResource {
+	select {
+	case s.ticks <- time.Time{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out triggering analysis")
+	}
+	select {
+	case idle := <-s.facade.set:
+		return idle
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for idle resources to be recorded")
+	}
+	return nil
+}
+
+type fakeTimer struct {
+	ticks chan time.Time
+}
+
+func (t *fakeTimer) Reset(time.Duration) bool {
+	return true
+}
+
+func (t *fakeTimer) CountDown() <-chan time.Time {
+	return t.ticks
+}
+
+type mockFacade struct {
+	mu          sync.Mutex
+	config      *config.Config
+	utilisation idleadvisor.ModelUtilisation
+	idle        []idleadvisor.IdleResource
+	since       time.Time
+	set         chan []idleadvisor.IdleResource
+}
+
+func (f *mockFacade) ModelConfig() (*config.Config, error) {
+	return f.config, nil
+}
+
+func (f *mockFacade) ModelUtilisation(metric string, since time.Time) (idleadvisor.ModelUtilisation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.since = since
+	if metric == "" {
+		return idleadvisor.ModelUtilisation{Machines: f.utilisation.Machines}, nil
+	}
+	return f.utilisation, nil
+}
+
+func (f *mockFacade) IdleResources() ([]idleadvisor.IdleResource, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.idle, nil
+}
+
+func (f *mockFacade) SetIdleResources(idle []idleadvisor.IdleResource) error {
+	f.mu.Lock()
+	f.idle = idle
+	f.mu.Unlock()
+	f.set <- idle
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+)
+
+// queryPaths maps the names of the reports that can be fetched with
+// Query to the paths the introspection worker serves them at.
+var queryPaths = map[string]string{
+	"engine-report": "/depengine",
+	"goroutines":    "/debug/pprof/goroutine?debug=1",
+	"metrics":       "/metrics/",
+}
+
+// SocketName returns the name of the abstract domain socket that the
+// introspection worker of the agent with the given tag listens on.
+func SocketName(agentTag names.Tag) string {
+	return "jujud-" + agentTag.String()
+}
+
+// Query fetches the named report, one of "engine-report", "goroutines"
+// or "metrics", from the introspection worker listening on the abstract
+// domain socket with the given name.
+func Query(socketName, query string) (string, error) {
+	path, ok := queryPaths[query]
+	if !ok {
+		return "", errors.NotValidf("introspection query %q", query)
+	}
+	client := &http.Client{
+		Transport: &http.Transport{
+			Dial: func(proto, addr string) (net.Conn, error) {
+				return net.Dial("unix", "@"+socketName)
+			},
+		},
+	}
+	resp, err := client.Get("http://unix.socket" + path)
+	if err != nil {
+		return "", errors.Annotatef(err, "querying introspection socket %q", socketName)
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Annotatef(err, "reading %s", query)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", errors.Errorf(
+			"%s: response returned %d (%s): %s",
+			query, resp.StatusCode, http.StatusText(resp.StatusCode),
+			strings.TrimSpace(string(body)),
+		)
+	}
+	return string(body), nil
+}
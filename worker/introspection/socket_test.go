@@ -19,6 +19,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
@@ -214,6 +215,26 @@ func (s *introspectionSuite) TestPrometheusMetrics(c *gc.C) {
 // matches fails if regex is not found in the contents of b.
 // b is expected to be the response from the pprof http server, and will
 // contain some HTTP preamble that should be ignored.
+func (s *introspectionSuite) TestQueryGoroutines(c *gc.C) {
+	out, err := introspection.Query(s.name, "goroutines")
+	c.Assert(err, jc.ErrorIsNil)
+	matches(c, []byte(out), `^goroutine profile: total \d+`)
+}
+
+func (s *introspectionSuite) TestQueryMissingDepEngineReporter(c *gc.C) {
+	_, err := introspection.Query(s.name, "engine-report")
+	c.Assert(err, gc.ErrorMatches, `engine-report: response returned 404 \(Not Found\): missing dependency engine reporter`)
+}
+
+func (s *introspectionSuite) TestQueryInvalid(c *gc.C) {
+	_, err := introspection.Query(s.name, "heap")
+	c.Assert(err, gc.ErrorMatches, `introspection query "heap" not valid`)
+}
+
+func (s *suite) TestSocketName(c *gc.C) {
+	c.Assert(introspection.SocketName(names.NewUnitTag("mysql/0")), gc.Equals, "jujud-unit-mysql-0")
+}
+
 func matches(c *gc.C, b []byte, regex string) {
 	re, err := regexp.Compile(regex)
 	c.Assert(err, jc.ErrorIsNil)
@@ -14,8 +14,10 @@ import (
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/utils/exec"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/worker/introspection"
 )
 
 // RunAsUser is the user that the machine juju-run action is executed as.
@@ -35,6 +37,8 @@ func HandleAction(name string, params map[string]interface{}) (results map[strin
 	switch name {
 	case actions.JujuRunActionName:
 		return handleJujuRunAction(params)
+	case actions.JujuIntrospectActionName:
+		return handleJujuIntrospectAction(params)
 	default:
 		return nil, errors.Errorf("unexpected action %s", name)
 	}
@@ -62,6 +66,29 @@ func handleJujuRunAction(params map[string]interface{}) (results map[string]inte
 	return actionResults, nil
 }
 
+// IntrospectionQuery fetches a report from the introspection worker
+// listening on the named socket.
+var IntrospectionQuery = introspection.Query
+
+func handleJujuIntrospectAction(params map[string]interface{}) (results map[string]interface{}, err error) {
+	// The spec checks that the parameters are available so we don't need to check again here
+	agent, _ := params["agent"].(string)
+	query, _ := params["query"].(string)
+	agentTag, err := names.ParseTag(agent)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	logger.Tracef("juju introspect %q of %s", query, agent)
+
+	output, err := IntrospectionQuery(introspection.SocketName(agentTag), query)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	actionResults := map[string]interface{}{}
+	storeOutput(actionResults, "Stdout", []byte(output))
+	return actionResults, nil
+}
+
 func runCommandWithTimeout(command string, timeout time.Duration, clock clock.Clock) (*exec.ExecResponse, error) {
 	cmd := exec.RunParams{
 		Commands:    command,
@@ -78,3 +78,48 @@ func (s *HandleSuite) TestErrorRun(c *gc.C) {
 	c.Assert(results["Stdout"], gc.Equals, "")
 	c.Assert(results["Stderr"], gc.Equals, "")
 }
+
+func (s *HandleSuite) TestIntrospect(c *gc.C) {
+	var socketName, query string
+	s.PatchValue(&machineactions.IntrospectionQuery, func(name, q string) (string, error) {
+		socketName, query = name, q
+		return "goroutine profile: total 42\n", nil
+	})
+	params := map[string]interface{}{
+		"agent": "unit-mysql-0",
+		"query": "goroutines",
+	}
+
+	results, err := machineactions.HandleAction(actions.JujuIntrospectActionName, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, map[string]interface{}{
+		"Stdout": "goroutine profile: total 42\n",
+	})
+	c.Assert(socketName, gc.Equals, "jujud-unit-mysql-0")
+	c.Assert(query, gc.Equals, "goroutines")
+}
+
+func (s *HandleSuite) TestIntrospectInvalidQuery(c *gc.C) {
+	params := map[string]interface{}{
+		"agent": "unit-mysql-0",
+		"query": "heap",
+	}
+
+	results, err := machineactions.HandleAction(actions.JujuIntrospectActionName, params)
+	c.Assert(err, gc.ErrorMatches, "invalid action parameters")
+	c.Assert(results, gc.IsNil)
+}
+
+func (s *HandleSuite) TestIntrospectError(c *gc.C) {
+	s.PatchValue(&machineactions.IntrospectionQuery, func(name, q string) (string, error) {
+		return "", errors.New("connection refused")
+	})
+	params := map[string]interface{}{
+		"agent": "machine-0",
+		"query": "engine-report",
+	}
+
+	results, err := machineactions.HandleAction(actions.JujuIntrospectActionName, params)
+	c.Assert(err, gc.ErrorMatches, "connection refused")
+	c.Assert(results, gc.IsNil)
+}
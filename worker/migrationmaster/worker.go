@@ -92,6 +92,32 @@ type Facade interface {
 	// OpenResource downloads a single resource for an application.
 	OpenResource(string, string) (io.ReadCloser, error)
 
+	// CheckVolumeExports returns an error if any of the given
+	// volumes of the model cannot be exported.
+	CheckVolumeExports([]names.VolumeTag) error
+
+	// ExportVolume makes a portable copy of a volume of the model,
+	// so that it can be recreated by the target controller.
+	ExportVolume(names.VolumeTag) (coremigration.VolumeExport, error)
+
+	// RemoveVolumeExport removes a copy made by ExportVolume.
+	RemoveVolumeExport(coremigration.VolumeExport) error
+
+	// MachinesToReprovision returns the machines of the model that
+	// must be provisioned again when it moves to another region.
+	MachinesToReprovision() ([]names.MachineTag, error)
+
+	// SnapshotMachine makes an image of a machine's instance in the
+	// specified region.
+	SnapshotMachine(names.MachineTag, string) (coremigration.MachineSnapshot, error)
+
+	// RemoveMachineSnapshot removes an image made by SnapshotMachine.
+	RemoveMachineSnapshot(coremigration.MachineSnapshot) error
+
+	// StopMachineInstance stops the instance of a machine which has
+	// been provisioned again by the target controller.
+	StopMachineInstance(names.MachineTag) error
+
 	// Reap removes all documents of the model associated with the API
 	// connection.
 	Reap() error
@@ -186,6 +212,12 @@ type Worker struct {
 	config      Config
 	logger      loggo.Logger
 	lastFailure string
+
+	// importedVolumes holds the volumes recreated by the target
+	// controller during storage translation, which are removed if
+	// the migration is aborted. Volumes recreated before the worker
+	// was restarted are not known, and must be removed by hand.
+	importedVolumes []names.VolumeTag
 }
 
 // Kill implements worker.Worker.
@@ -220,14 +252,18 @@ func (w *Worker) run() error {
 			phase, err = w.doQUIESCE(status)
 		case coremigration.IMPORT:
 			phase, err = w.doIMPORT(status.TargetInfo, status.ModelUUID)
+		case coremigration.STORAGETRANSLATION:
+			phase, err = w.doSTORAGETRANSLATION(status.TargetInfo, status.ModelUUID)
 		case coremigration.VALIDATION:
 			phase, err = w.doVALIDATION(status)
 		case coremigration.SUCCESS:
 			phase, err = w.doSUCCESS(status)
+		case coremigration.REPROVISION:
+			phase, err = w.doREPROVISION(status)
 		case coremigration.LOGTRANSFER:
 			phase, err = w.doLOGTRANSFER(status.TargetInfo, status.ModelUUID)
 		case coremigration.REAP:
-			phase, err = w.doREAP()
+			phase, err = w.doREAP(status)
 		case coremigration.ABORT:
 			phase, err = w.doABORT(status.TargetInfo, status.ModelUUID)
 		default:
@@ -254,6 +290,11 @@ func (w *Worker) run() error {
 		status.Phase = phase
 
 		if modelHasMigrated(phase) {
+			if status.Mode == coremigration.ModeCopy {
+				// A copied model stays with this controller, so
+				// wait for the next migration attempt.
+				return ErrInactive
+			}
 			return ErrMigrated
 		} else if phase.IsTerminal() {
 			// Some other terminal phase (aborted), exit and try
@@ -345,6 +386,9 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 		return errors.Errorf("unexpected target controller UUID (got %s, expected %s)",
 			conn.ControllerTag(), status.TargetInfo.ControllerTag)
 	}
+	if status.TargetRegion != "" && conn.BestFacadeVersion("MigrationTarget") < 3 {
+		return errors.New("target controller does not support moving models to another region")
+	}
 
 	targetClient := migrationtarget.NewClient(conn)
 	err = targetClient.Prechecks(model)
@@ -352,11 +396,25 @@ func (w *Worker) prechecks(status coremigration.MigrationStatus) error {
 }
 
 func (w *Worker) doIMPORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
-	err := w.transferModel(targetInfo, modelUUID)
+	translations, err := w.transferModel(targetInfo, modelUUID)
 	if err != nil {
 		w.setErrorStatus("model data transfer failed, %v", err)
 		return coremigration.ABORT, nil
 	}
+	if len(translations) > 0 {
+		// Find out whether every volume can be exported before
+		// exporting any, so that a model whose storage can't be
+		// translated isn't left half copied.
+		tags := make([]names.VolumeTag, len(translations))
+		for i, translation := range translations {
+			tags[i] = translation.Tag
+		}
+		if err := w.config.Facade.CheckVolumeExports(tags); err != nil {
+			w.setErrorStatus("storage translation precheck failed, %v", err)
+			return coremigration.ABORT, nil
+		}
+		return coremigration.STORAGETRANSLATION, nil
+	}
 	return coremigration.VALIDATION, nil
 }
 
@@ -390,28 +448,37 @@ func (w *uploadWrapper) SetUnitResource(unitName string, res resource.Resource)
 	return w.client.SetUnitResource(w.modelUUID, unitName, res)
 }
 
-func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID string) error {
+// transferModel imports the model into the target controller, and
+// returns the volumes that the target controller needs recreated.
+func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID string) ([]coremigration.VolumeTranslation, error) {
 	w.setInfoStatus("exporting model")
 	serialized, err := w.config.Facade.Export()
 	if err != nil {
-		return errors.Annotate(err, "model export failed")
+		return nil, errors.Annotate(err, "model export failed")
 	}
 
 	w.setInfoStatus("importing model into target controller")
 	conn, err := w.openAPIConn(targetInfo)
 	if err != nil {
-		return errors.Annotate(err, "failed to connect to target controller")
+		return nil, errors.Annotate(err, "failed to connect to target controller")
 	}
 	defer conn.Close()
+	modelBytes := serialized.Bytes
+	if conn.BestFacadeVersion("MigrationTarget") < 4 {
+		modelBytes, err = migration.DropPinnedAgentVersion(modelBytes)
+		if err != nil {
+			return nil, errors.Annotate(err, "model export failed")
+		}
+	}
 	targetClient := migrationtarget.NewClient(conn)
-	err = targetClient.Import(serialized.Bytes)
+	err = targetClient.Import(modelBytes)
 	if err != nil {
-		return errors.Annotate(err, "failed to import model into target controller")
+		return nil, errors.Annotate(err, "failed to import model into target controller")
 	}
 
 	if wrench.IsActive("migrationmaster", "die-in-export") {
 		// Simulate a abort causing failure to test last status not over written.
-		return errors.New("wrench in the transferModel works")
+		return nil, errors.New("wrench in the transferModel works")
 	}
 
 	w.setInfoStatus("uploading model binaries into target controller")
@@ -429,7 +496,70 @@ func (w *Worker) transferModel(targetInfo coremigration.TargetInfo, modelUUID st
 		ResourceDownloader: w.config.Facade,
 		ResourceUploader:   wrapper,
 	})
-	return errors.Annotate(err, "failed to migrate binaries")
+	if err != nil {
+		return nil, errors.Annotate(err, "failed to migrate binaries")
+	}
+
+	translations, err := targetClient.StorageTranslations(modelUUID)
+	return translations, errors.Annotate(err, "failed to check storage in target controller")
+}
+
+func (w *Worker) doSTORAGETRANSLATION(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
+	client, closer, err := w.openTargetAPI(targetInfo)
+	if err != nil {
+		return coremigration.UNKNOWN, errors.Trace(err)
+	}
+	defer closer()
+
+	// Volumes which have already been recreated are not reported
+	// again, so an interrupted translation carries on where it left
+	// off.
+	translations, err := client.StorageTranslations(modelUUID)
+	if err != nil {
+		w.setErrorStatus("storage translation failed, %v", err)
+		return coremigration.ABORT, nil
+	}
+	for i, translation := range translations {
+		if w.killed() {
+			return coremigration.UNKNOWN, w.catacomb.ErrDying()
+		}
+		if err := w.translateVolume(client, modelUUID, translation, i+1, len(translations)); err != nil {
+			w.setErrorStatus("storage translation failed, volume %s: %v", translation.Tag.Id(), err)
+			return coremigration.ABORT, nil
+		}
+	}
+	w.setInfoStatus("translated %d volume(s)", len(translations))
+	return coremigration.VALIDATION, nil
+}
+
+// translateVolume exports a copy of the volume from the source cloud,
+// and recreates the volume from it in the target cloud. The copy is
+// removed afterwards, whether or not the volume could be recreated.
+func (w *Worker) translateVolume(
+	targetClient *migrationtarget.Client,
+	modelUUID string,
+	translation coremigration.VolumeTranslation,
+	n, total int,
+) error {
+	w.setInfoStatus("translating storage, exporting volume %s from pool %q (%d of %d)",
+		translation.Tag.Id(), translation.Pool, n, total)
+	export, err := w.config.Facade.ExportVolume(translation.Tag)
+	if err != nil {
+		return errors.Annotate(err, "exporting volume")
+	}
+	defer func() {
+		if err := w.config.Facade.RemoveVolumeExport(export); err != nil {
+			w.logger.Warningf("failed to remove export of volume %s, %v", translation.Tag.Id(), err)
+		}
+	}()
+
+	w.setInfoStatus("translating storage, recreating volume %s in pool %q (%d of %d)",
+		translation.Tag.Id(), translation.TargetPool, n, total)
+	if err := targetClient.ImportVolumeExport(modelUUID, export); err != nil {
+		return errors.Annotate(err, "recreating volume")
+	}
+	w.importedVolumes = append(w.importedVolumes, translation.Tag)
+	return nil
 }
 
 func (w *Worker) doVALIDATION(status coremigration.MigrationStatus) (coremigration.Phase, error) {
@@ -504,7 +634,11 @@ func (w *Worker) doSUCCESS(status coremigration.MigrationStatus) (coremigration.
 	}
 	// There's no turning back from SUCCESS - any problems should have
 	// been picked up in VALIDATION. After the minion wait in the
-	// SUCCESS phase, the migration can only proceed to LOGTRANSFER.
+	// SUCCESS phase, the migration can only proceed to REPROVISION or
+	// LOGTRANSFER.
+	if status.TargetRegion != "" {
+		return coremigration.REPROVISION, nil
+	}
 	return coremigration.LOGTRANSFER, nil
 }
 
@@ -521,6 +655,70 @@ func (w *Worker) transferResources(targetInfo coremigration.TargetInfo, modelUUI
 	return errors.Trace(err)
 }
 
+func (w *Worker) doREPROVISION(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	// The target controller is in charge of the model now, so a
+	// failure here can't abort the migration. It is reported, and the
+	// machines must be dealt with by hand.
+	if err := w.reprovisionMachines(status); err != nil {
+		w.setErrorStatus("successful, but re-provisioning machines in region %q failed, %v",
+			status.TargetRegion, err)
+	}
+	return coremigration.LOGTRANSFER, nil
+}
+
+// reprovisionMachines has the target controller move the model to the
+// target region, and provision its machines again there from images of
+// their instances. The old instances are stopped unless the model is
+// being copied.
+func (w *Worker) reprovisionMachines(status coremigration.MigrationStatus) error {
+	machines, err := w.config.Facade.MachinesToReprovision()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var snapshots []coremigration.MachineSnapshot
+	for i, tag := range machines {
+		w.setInfoStatus("successful, snapshotting machine %s in region %q (%d of %d)",
+			tag.Id(), status.TargetRegion, i+1, len(machines))
+		snapshot, err := w.config.Facade.SnapshotMachine(tag, status.TargetRegion)
+		if err != nil {
+			w.removeMachineSnapshots(snapshots)
+			return errors.Annotatef(err, "snapshotting machine %s", tag.Id())
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	client, closer, err := w.openTargetAPI(status.TargetInfo)
+	if err != nil {
+		w.removeMachineSnapshots(snapshots)
+		return errors.Trace(err)
+	}
+	defer closer()
+	w.setInfoStatus("successful, re-provisioning %d machine(s) in region %q",
+		len(snapshots), status.TargetRegion)
+	if err := client.ReprovisionMachines(status.ModelUUID, status.TargetRegion, snapshots); err != nil {
+		w.removeMachineSnapshots(snapshots)
+		return errors.Trace(err)
+	}
+
+	if status.Mode == coremigration.ModeCopy {
+		return nil
+	}
+	for _, tag := range machines {
+		if err := w.config.Facade.StopMachineInstance(tag); err != nil {
+			w.logger.Warningf("failed to stop old instance of machine %s, %v", tag.Id(), err)
+		}
+	}
+	return nil
+}
+
+func (w *Worker) removeMachineSnapshots(snapshots []coremigration.MachineSnapshot) {
+	for _, snapshot := range snapshots {
+		if err := w.config.Facade.RemoveMachineSnapshot(snapshot); err != nil {
+			w.logger.Warningf("failed to remove snapshot of machine %s, %v", snapshot.Tag.Id(), err)
+		}
+	}
+}
+
 func (w *Worker) doLOGTRANSFER(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
 	err := w.transferLogs(targetInfo, modelUUID)
 	if err != nil {
@@ -587,6 +785,7 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 				Location: msg.Location,
 				Level:    msg.Severity,
 				Message:  msg.Message,
+				Labels:   msg.Labels,
 			})
 			if err != nil {
 				return errors.Trace(err)
@@ -604,7 +803,12 @@ func (w *Worker) transferLogs(targetInfo coremigration.TargetInfo, modelUUID str
 	}
 }
 
-func (w *Worker) doREAP() (coremigration.Phase, error) {
+func (w *Worker) doREAP(status coremigration.MigrationStatus) (coremigration.Phase, error) {
+	if status.Mode == coremigration.ModeCopy {
+		// The model is left in place when it is copied.
+		w.setInfoStatus("successful, model copied to target controller")
+		return coremigration.DONE, nil
+	}
 	w.setInfoStatus("successful, removing model from source controller")
 	// NOTE(babbageclunk): Calling Reap will set the migration phase
 	// to DONE if successful - this avoids a race where this worker is
@@ -619,6 +823,15 @@ func (w *Worker) doREAP() (coremigration.Phase, error) {
 }
 
 func (w *Worker) doABORT(targetInfo coremigration.TargetInfo, modelUUID string) (coremigration.Phase, error) {
+	if len(w.importedVolumes) > 0 {
+		w.setInfoStatus("aborted, removing %d recreated volume(s) from target controller: %s",
+			len(w.importedVolumes), w.lastFailure)
+		if err := w.removeImportedVolumes(targetInfo, modelUUID); err != nil {
+			// As with the model itself, removing the volumes is a
+			// best efforts attempt.
+			w.logger.Warningf("failed to remove volumes from target controller, %v", err)
+		}
+	}
 	w.setInfoStatus("aborted, removing model from target controller: %s", w.lastFailure)
 	if err := w.removeImportedModel(targetInfo, modelUUID); err != nil {
 		// This isn't fatal. Removing the imported model is a best
@@ -628,6 +841,19 @@ func (w *Worker) doABORT(targetInfo coremigration.TargetInfo, modelUUID string)
 	return coremigration.ABORTDONE, nil
 }
 
+func (w *Worker) removeImportedVolumes(targetInfo coremigration.TargetInfo, modelUUID string) error {
+	client, closer, err := w.openTargetAPI(targetInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer closer()
+	if err := client.RemoveImportedVolumes(modelUUID, w.importedVolumes); err != nil {
+		return errors.Trace(err)
+	}
+	w.importedVolumes = nil
+	return nil
+}
+
 func (w *Worker) removeImportedModel(targetInfo coremigration.TargetInfo, modelUUID string) error {
 	conn, err := w.openAPIConn(targetInfo)
 	if err != nil {
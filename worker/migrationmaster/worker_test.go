@@ -125,6 +125,30 @@ var (
 			params.ModelArgs{ModelTag: modelTag.String()},
 		},
 	}
+	storageTranslationsCall = jujutesting.StubCall{
+		"MigrationTarget.StorageTranslations",
+		[]interface{}{
+			params.ModelArgs{ModelTag: modelTag.String()},
+		},
+	}
+	reprovisionMachinesCall = jujutesting.StubCall{
+		"MigrationTarget.ReprovisionMachines",
+		[]interface{}{
+			params.ReprovisionMachinesArgs{
+				ModelTag: modelTag.String(),
+				Region:   "nether-region",
+				Snapshots: []params.MigrationMachineSnapshot{{
+					MachineTag: "machine-0",
+					Region:     "nether-region",
+					ImageId:    "image-0",
+				}, {
+					MachineTag: "machine-1",
+					Region:     "nether-region",
+					ImageId:    "image-1",
+				}},
+			},
+		},
+	}
 	watchStatusLockdownCalls = []jujutesting.StubCall{
 		{"facade.Watch", nil},
 		{"facade.MigrationStatus", nil},
@@ -477,6 +501,26 @@ func (s *Suite) TestQUIESCETargetChecksFail(c *gc.C) {
 	))
 }
 
+func (s *Suite) TestQUIESCETargetRegionNotSupported(c *gc.C) {
+	status := s.makeStatus(coremigration.QUIESCE)
+	status.TargetRegion = "nether-region"
+	s.facade.queueStatus(status)
+
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Prechecks", nil},
+			{"facade.ModelInfo", nil},
+			apiOpenControllerCall,
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+	c.Assert(s.facade.statuses, jc.Contains,
+		"target controller does not support moving models to another region")
+}
+
 func (s *Suite) TestExportFailure(c *gc.C) {
 	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
 	s.facade.exportErr = errors.New("boom")
@@ -525,6 +569,259 @@ func (s *Suite) TestImportFailure(c *gc.C) {
 	))
 }
 
+func (s *Suite) TestStorageTranslation(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.facade.queueMinionReports(coremigration.MinionReports{
+		MigrationId:    "model-uuid:2",
+		Phase:          coremigration.VALIDATION,
+		FailedMachines: []string{"42"},
+	})
+	s.connection.facadeVersion = 2
+	s.connection.translations = []params.MigrationVolumeTranslation{{
+		VolumeTag:  "volume-0",
+		VolumeId:   "vol-0",
+		Pool:       "ebs",
+		TargetPool: "cinder",
+		Size:       1024,
+	}, {
+		VolumeTag:  "volume-1",
+		VolumeId:   "vol-1",
+		Pool:       "ebs",
+		TargetPool: "cinder",
+		Size:       2048,
+	}}
+
+	// The volumes are recreated, and then removed again when the
+	// migration is aborted during validation.
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			{"UploadBinaries", []interface{}{
+				[]string{"charm0", "charm1"},
+				fakeCharmDownloader,
+				map[version.Binary]string{
+					version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
+				},
+				fakeToolsDownloader,
+				s.facade.exportedResources,
+				s.facade,
+			}},
+			storageTranslationsCall,
+			apiCloseCall,
+			{"facade.CheckVolumeExports", []interface{}{
+				[]names.VolumeTag{names.NewVolumeTag("0"), names.NewVolumeTag("1")},
+			}},
+			{"facade.SetPhase", []interface{}{coremigration.STORAGETRANSLATION}},
+
+			// STORAGETRANSLATION
+			apiOpenControllerCall,
+			storageTranslationsCall,
+			{"facade.ExportVolume", []interface{}{names.NewVolumeTag("0")}},
+			importVolumeExportCall("0"),
+			{"facade.RemoveVolumeExport", []interface{}{volumeExport("0")}},
+			{"facade.ExportVolume", []interface{}{names.NewVolumeTag("1")}},
+			importVolumeExportCall("1"),
+			{"facade.RemoveVolumeExport", []interface{}{volumeExport("1")}},
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.VALIDATION}},
+
+			// VALIDATION
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			{"facade.SetPhase", []interface{}{coremigration.ABORT}},
+
+			// ABORT
+			apiOpenControllerCall,
+			{"MigrationTarget.RemoveImportedVolumes", []interface{}{params.MigrationVolumesArgs{
+				ModelTag:   modelTag.String(),
+				VolumeTags: []string{"volume-0", "volume-1"},
+			}}},
+			apiCloseCall,
+			apiOpenControllerCall,
+			abortCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.ABORTDONE}},
+		},
+	))
+	c.Assert(s.facade.statuses, jc.Contains,
+		`translating storage, recreating volume 1 in pool "cinder" (2 of 2)`)
+}
+
+func (s *Suite) TestReprovision(c *gc.C) {
+	status := s.makeStatus(coremigration.SUCCESS)
+	status.TargetRegion = "nether-region"
+	s.facade.queueStatus(status)
+	s.facade.queueMinionReports(makeMinionReports(coremigration.SUCCESS))
+	s.connection.facadeVersion = 3
+
+	s.checkWorkerReturns(c, migrationmaster.ErrMigrated)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.WatchMinionReports", nil},
+			{"facade.MinionReports", nil},
+			apiOpenControllerCall,
+			adoptResourcesCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.REPROVISION}},
+
+			// REPROVISION
+			{"facade.MachinesToReprovision", nil},
+			{"facade.SnapshotMachine", []interface{}{names.NewMachineTag("0"), "nether-region"}},
+			{"facade.SnapshotMachine", []interface{}{names.NewMachineTag("1"), "nether-region"}},
+			apiOpenControllerCall,
+			reprovisionMachinesCall,
+			apiCloseCall,
+			{"facade.StopMachineInstance", []interface{}{names.NewMachineTag("0")}},
+			{"facade.StopMachineInstance", []interface{}{names.NewMachineTag("1")}},
+			{"facade.SetPhase", []interface{}{coremigration.LOGTRANSFER}},
+
+			apiOpenControllerCall,
+			latestLogTimeCall,
+			{"StreamModelLog", []interface{}{time.Time{}}},
+			openDestLogStreamCall,
+			{"facade.SetPhase", []interface{}{coremigration.REAP}},
+			{"facade.Reap", nil},
+			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+		},
+	))
+}
+
+func (s *Suite) TestReprovisionCopy(c *gc.C) {
+	status := s.makeStatus(coremigration.REPROVISION)
+	status.TargetRegion = "nether-region"
+	status.Mode = coremigration.ModeCopy
+	s.facade.queueStatus(status)
+	s.connection.facadeVersion = 3
+
+	// The old instances are left running, and the model is not
+	// removed from this controller.
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MachinesToReprovision", nil},
+			{"facade.SnapshotMachine", []interface{}{names.NewMachineTag("0"), "nether-region"}},
+			{"facade.SnapshotMachine", []interface{}{names.NewMachineTag("1"), "nether-region"}},
+			apiOpenControllerCall,
+			reprovisionMachinesCall,
+			apiCloseCall,
+			{"facade.SetPhase", []interface{}{coremigration.LOGTRANSFER}},
+
+			apiOpenControllerCall,
+			latestLogTimeCall,
+			{"StreamModelLog", []interface{}{time.Time{}}},
+			openDestLogStreamCall,
+			{"facade.SetPhase", []interface{}{coremigration.REAP}},
+			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+		},
+	))
+}
+
+func (s *Suite) TestReprovisionFailure(c *gc.C) {
+	status := s.makeStatus(coremigration.REPROVISION)
+	status.TargetRegion = "nether-region"
+	s.facade.queueStatus(status)
+	s.facade.snapshotErrs = map[string]error{"1": errors.New("boom")}
+	s.connection.facadeVersion = 3
+
+	// The snapshots already made are removed, and the migration
+	// carries on regardless.
+	s.checkWorkerReturns(c, migrationmaster.ErrMigrated)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.MachinesToReprovision", nil},
+			{"facade.SnapshotMachine", []interface{}{names.NewMachineTag("0"), "nether-region"}},
+			{"facade.SnapshotMachine", []interface{}{names.NewMachineTag("1"), "nether-region"}},
+			{"facade.RemoveMachineSnapshot", []interface{}{machineSnapshot("0")}},
+			{"facade.SetPhase", []interface{}{coremigration.LOGTRANSFER}},
+
+			apiOpenControllerCall,
+			latestLogTimeCall,
+			{"StreamModelLog", []interface{}{time.Time{}}},
+			openDestLogStreamCall,
+			{"facade.SetPhase", []interface{}{coremigration.REAP}},
+			{"facade.Reap", nil},
+			{"facade.SetPhase", []interface{}{coremigration.DONE}},
+		},
+	))
+	c.Assert(s.facade.statuses, jc.Contains,
+		`successful, but re-provisioning machines in region "nether-region" failed, snapshotting machine 1: boom`)
+}
+
+func (s *Suite) TestStorageTranslationPrecheckFailure(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.IMPORT))
+	s.facade.checkVolumeExportsErr = errors.New(`exporting volumes from pool "ebs" not supported`)
+	s.connection.facadeVersion = 2
+	s.connection.translations = []params.MigrationVolumeTranslation{{
+		VolumeTag:  "volume-0",
+		VolumeId:   "vol-0",
+		Pool:       "ebs",
+		TargetPool: "cinder",
+		Size:       1024,
+	}}
+
+	// No volume is exported when any of them can't be.
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			{"facade.Export", nil},
+			apiOpenControllerCall,
+			importCall,
+			{"UploadBinaries", []interface{}{
+				[]string{"charm0", "charm1"},
+				fakeCharmDownloader,
+				map[version.Binary]string{
+					version.MustParseBinary("2.1.0-trusty-amd64"): "/tools/0",
+				},
+				fakeToolsDownloader,
+				s.facade.exportedResources,
+				s.facade,
+			}},
+			storageTranslationsCall,
+			apiCloseCall,
+			{"facade.CheckVolumeExports", []interface{}{[]names.VolumeTag{names.NewVolumeTag("0")}}},
+		},
+		abortCalls,
+	))
+	c.Assert(s.facade.statuses, jc.Contains,
+		`storage translation precheck failed, exporting volumes from pool "ebs" not supported`)
+}
+
+func (s *Suite) TestStorageTranslationFailure(c *gc.C) {
+	s.facade.queueStatus(s.makeStatus(coremigration.STORAGETRANSLATION))
+	s.facade.exportVolumeErr = errors.New("boom")
+	s.connection.facadeVersion = 2
+	s.connection.translations = []params.MigrationVolumeTranslation{{
+		VolumeTag:  "volume-0",
+		VolumeId:   "vol-0",
+		Pool:       "ebs",
+		TargetPool: "cinder",
+		Size:       1024,
+	}}
+
+	// Nothing was recreated, so there is nothing to roll back.
+	s.checkWorkerReturns(c, migrationmaster.ErrInactive)
+	s.stub.CheckCalls(c, joinCalls(
+		watchStatusLockdownCalls,
+		[]jujutesting.StubCall{
+			apiOpenControllerCall,
+			storageTranslationsCall,
+			{"facade.ExportVolume", []interface{}{names.NewVolumeTag("0")}},
+			apiCloseCall,
+		},
+		abortCalls,
+	))
+	c.Assert(s.facade.statuses, jc.Contains,
+		"storage translation failed, volume 0: exporting volume: boom")
+}
+
 func (s *Suite) TestVALIDATIONMinionWaitWatchError(c *gc.C) {
 	s.checkMinionWaitWatchError(c, coremigration.VALIDATION)
 }
@@ -1086,7 +1383,10 @@ type stubMasterFacade struct {
 	minionReports         []coremigration.MinionReports
 	minionReportsErr      error
 
-	exportedResources []coremigration.SerializedModelResource
+	exportedResources     []coremigration.SerializedModelResource
+	checkVolumeExportsErr error
+	exportVolumeErr       error
+	snapshotErrs          map[string]error
 
 	statuses []string
 }
@@ -1193,6 +1493,47 @@ func (f *stubMasterFacade) Export() (coremigration.SerializedModel, error) {
 	}, nil
 }
 
+func (f *stubMasterFacade) CheckVolumeExports(tags []names.VolumeTag) error {
+	f.stub.AddCall("facade.CheckVolumeExports", tags)
+	return f.checkVolumeExportsErr
+}
+
+func (f *stubMasterFacade) ExportVolume(tag names.VolumeTag) (coremigration.VolumeExport, error) {
+	f.stub.AddCall("facade.ExportVolume", tag)
+	if f.exportVolumeErr != nil {
+		return coremigration.VolumeExport{}, f.exportVolumeErr
+	}
+	return volumeExport(tag.Id()), nil
+}
+
+func (f *stubMasterFacade) RemoveVolumeExport(export coremigration.VolumeExport) error {
+	f.stub.AddCall("facade.RemoveVolumeExport", export)
+	return nil
+}
+
+func (f *stubMasterFacade) MachinesToReprovision() ([]names.MachineTag, error) {
+	f.stub.AddCall("facade.MachinesToReprovision")
+	return []names.MachineTag{names.NewMachineTag("0"), names.NewMachineTag("1")}, nil
+}
+
+func (f *stubMasterFacade) SnapshotMachine(tag names.MachineTag, region string) (coremigration.MachineSnapshot, error) {
+	f.stub.AddCall("facade.SnapshotMachine", tag, region)
+	if err := f.snapshotErrs[tag.Id()]; err != nil {
+		return coremigration.MachineSnapshot{}, err
+	}
+	return machineSnapshot(tag.Id()), nil
+}
+
+func (f *stubMasterFacade) RemoveMachineSnapshot(snapshot coremigration.MachineSnapshot) error {
+	f.stub.AddCall("facade.RemoveMachineSnapshot", snapshot)
+	return nil
+}
+
+func (f *stubMasterFacade) StopMachineInstance(tag names.MachineTag) error {
+	f.stub.AddCall("facade.StopMachineInstance", tag)
+	return nil
+}
+
 func (f *stubMasterFacade) SetPhase(phase coremigration.Phase) error {
 	f.stub.AddCall("facade.SetPhase", phase)
 	return nil
@@ -1256,10 +1597,16 @@ type stubConnection struct {
 
 	machineErrs     []string
 	checkMachineErr error
+
+	facadeVersion int
+	translations  []params.MigrationVolumeTranslation
 }
 
 func (c *stubConnection) BestFacadeVersion(string) int {
-	return 1
+	if c.facadeVersion == 0 {
+		return 1
+	}
+	return c.facadeVersion
 }
 
 func (c *stubConnection) APICall(objType string, version int, id, request string, args, response interface{}) error {
@@ -1271,7 +1618,15 @@ func (c *stubConnection) APICall(objType string, version int, id, request string
 			return c.prechecksErr
 		case "Import":
 			return c.importErr
-		case "Activate", "AdoptResources":
+		case "Activate", "AdoptResources", "ImportVolumeExport", "ReprovisionMachines":
+			return nil
+		case "StorageTranslations":
+			results := response.(*params.MigrationVolumeTranslations)
+			results.Volumes = c.translations
+			return nil
+		case "RemoveImportedVolumes":
+			results := response.(*params.ErrorResults)
+			results.Results = make([]params.ErrorResult, len(args.(params.MigrationVolumesArgs).VolumeTags))
 			return nil
 		case "LatestLogTime":
 			responseTime := response.(*time.Time)
@@ -1315,6 +1670,39 @@ func (c *stubConnection) ConnectControllerStream(path string, attrs url.Values,
 	return c.logStream, nil
 }
 
+func machineSnapshot(id string) coremigration.MachineSnapshot {
+	return coremigration.MachineSnapshot{
+		Tag:     names.NewMachineTag(id),
+		Region:  "nether-region",
+		ImageId: "image-" + id,
+	}
+}
+
+func volumeExport(id string) coremigration.VolumeExport {
+	return coremigration.VolumeExport{
+		Tag:      names.NewVolumeTag(id),
+		Location: "swift://exports/" + id,
+		Format:   "raw",
+		Size:     1024,
+	}
+}
+
+func importVolumeExportCall(id string) jujutesting.StubCall {
+	export := volumeExport(id)
+	return jujutesting.StubCall{
+		"MigrationTarget.ImportVolumeExport",
+		[]interface{}{params.ImportVolumeExportArgs{
+			ModelTag: modelTag.String(),
+			Export: params.MigrationVolumeExport{
+				VolumeTag: export.Tag.String(),
+				Location:  export.Location,
+				Format:    export.Format,
+				Size:      export.Size,
+			},
+		}},
+	}
+}
+
 func makeStubUploadBinaries(stub *jujutesting.Stub) func(migration.UploadBinariesConfig) error {
 	return func(config migration.UploadBinariesConfig) error {
 		stub.AddCall(
@@ -188,6 +188,12 @@ func (w *Worker) validate(status watcher.MigrationStatus) error {
 }
 
 func (w *Worker) doSUCCESS(status watcher.MigrationStatus) error {
+	if status.Copy {
+		// The model stays with the source controller when it is
+		// copied, and so does the agent.
+		return w.report(status, true)
+	}
+
 	hps, err := apiAddrsToHostPorts(status.TargetAPIAddrs)
 	if err != nil {
 		return errors.Annotate(err, "converting API addresses")
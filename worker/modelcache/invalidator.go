@@ -0,0 +1,47 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcache
+
+import (
+	"sync"
+
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/cache"
+)
+
+// Invalidator passes the models changed by transactions run by the
+// controller to the cache, so that memoized reads of those models are
+// discarded. Its AfterRunTransaction method is intended to be used as
+// a state.RunTransactionObserverFunc. Transactions run before the cache
+// worker has started, or after it has stopped, are ignored.
+type Invalidator struct {
+	mu         sync.Mutex
+	controller *cache.Controller
+}
+
+// NewInvalidator returns a new Invalidator.
+func NewInvalidator() *Invalidator {
+	return &Invalidator{}
+}
+
+// AfterRunTransaction invalidates the cached model that a successfully
+// applied transaction was run against.
+func (i *Invalidator) AfterRunTransaction(dbName, modelUUID string, ops []txn.Op, err error) {
+	if err != nil || modelUUID == "" {
+		return
+	}
+	i.mu.Lock()
+	controller := i.controller
+	i.mu.Unlock()
+	if controller != nil {
+		controller.Invalidate(modelUUID)
+	}
+}
+
+func (i *Invalidator) setController(controller *cache.Controller) {
+	i.mu.Lock()
+	i.controller = controller
+	i.mu.Unlock()
+}
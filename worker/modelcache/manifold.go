@@ -28,6 +28,10 @@ type ManifoldConfig struct {
 
 	PrometheusRegisterer prometheus.Registerer
 
+	// Invalidator is optional; if set, it passes the transactions
+	// run by the controller to the cache.
+	Invalidator *Invalidator
+
 	NewWorker func(Config) (worker.Worker, error)
 }
 
@@ -81,6 +85,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		Logger:               config.Logger,
 		StatePool:            statePool,
 		PrometheusRegisterer: config.PrometheusRegisterer,
+		Invalidator:          config.Invalidator,
 		Cleanup: func() {
 			stTracker.Done()
 		},
@@ -27,6 +27,9 @@ type Config struct {
 	StatePool            *state.StatePool
 	PrometheusRegisterer prometheus.Registerer
 	Cleanup              func()
+	// Invalidator, if set, is connected to the cache controller
+	// while the worker runs.
+	Invalidator *Invalidator
 	// Notify is used primarily for testing, and is passed through
 	// to the cache.Controller. It is called every time the controller
 	// processes an event.
@@ -78,6 +81,9 @@ func NewWorker(config Config) (worker.Worker, error) {
 		return nil, errors.Trace(err)
 	}
 	w.controller = controller
+	if config.Invalidator != nil {
+		config.Invalidator.setController(controller)
+	}
 	if err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
 		Work: w.loop,
@@ -97,6 +103,10 @@ func (c *cacheWorker) loop() error {
 	defer c.config.Cleanup()
 	pool := c.config.StatePool
 
+	if invalidator := c.config.Invalidator; invalidator != nil {
+		defer invalidator.setController(nil)
+	}
+
 	allWatcherStarts := prometheus.NewCounter(prometheus.CounterOpts{
 		Namespace: "juju_worker_modelcache",
 		Name:      "watcher_starts",
@@ -164,6 +164,37 @@ func (s *WorkerSuite) TestModelConfigChange(c *gc.C) {
 	c.Assert(cachedModel.Config()["logging-config"], gc.Equals, expected)
 }
 
+func (s *WorkerSuite) TestInvalidator(c *gc.C) {
+	changes := s.captureEvents(c, modelEvents)
+	invalidator := modelcache.NewInvalidator()
+	s.config.Invalidator = invalidator
+	w := s.start(c)
+	// discard initial event
+	s.nextChange(c, changes)
+
+	controller := s.getController(c, w)
+	cachedModel, err := controller.Model(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	reads := 0
+	read := func() (interface{}, error) {
+		reads++
+		return reads, nil
+	}
+	_, err = cachedModel.Memo("status", time.Minute, read)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Failed transactions don't invalidate the model.
+	invalidator.AfterRunTransaction("juju", s.State.ModelUUID(), nil, errors.New("boom"))
+	value, err := cachedModel.Memo("status", time.Minute, read)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, gc.Equals, 1)
+
+	invalidator.AfterRunTransaction("juju", s.State.ModelUUID(), nil, nil)
+	value, err = cachedModel.Memo("status", time.Minute, read)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(value, gc.Equals, 2)
+}
+
 func (s *WorkerSuite) TestNewModel(c *gc.C) {
 	changes := s.captureEvents(c, modelEvents)
 	w := s.start(c)
@@ -0,0 +1,118 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongoupgrader
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run a mongo
+// upgrader worker in a dependency.Engine.
+type ManifoldConfig struct {
+	AgentName string
+	ClockName string
+	StateName string
+
+	PollInterval  time.Duration
+	ResyncTimeout time.Duration
+	NewBackend    func(*state.StatePool) Backend
+	NewWorker     func(Config) (worker.Worker, error)
+}
+
+// Validate returns an error if the config cannot be used to start a
+// worker.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	if config.ResyncTimeout <= 0 {
+		return errors.NotValidf("non-positive ResyncTimeout")
+	}
+	if config.NewBackend == nil {
+		return errors.NotValidf("nil NewBackend")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a mongo
+// upgrader worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.ClockName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w, err := config.NewWorker(Config{
+		Agent:          agent,
+		Backend:        config.NewBackend(statePool),
+		Clock:          clock,
+		PollInterval:   config.PollInterval,
+		ResyncTimeout:  config.ResyncTimeout,
+		StopMongo:      mongo.StopService,
+		EnsureMongo:    mongo.EnsureServer,
+		MoveDataDir:    mongo.MoveDataDirAside,
+		RestoreDataDir: mongo.RestoreDataDir,
+	})
+	if err != nil {
+		stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	go func() {
+		w.Wait()
+		stTracker.Done()
+	}()
+	return w, nil
+}
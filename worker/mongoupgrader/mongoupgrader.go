@@ -0,0 +1,350 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package mongoupgrader migrates the local controller's database to the
+// storage engine requested through the HighAvailability facade.
+// Controllers take turns: each one steps down if it is the replica set
+// primary, moves its data files aside and restarts mongod, which then
+// resyncs from the other members of the replica set. If the new mongod
+// can't be started, the old data files are put back and the previous
+// mongod restarted. Progress is recorded in state as the worker goes,
+// for the HighAvailability facade to report.
+package mongoupgrader
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/replicaset"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.worker.mongoupgrader")
+
+// jujuMachineKey is the key of the replica set member tag holding the
+// id of the controller machine.
+const jujuMachineKey = "juju-machine-id"
+
+// Backend defines the state methods needed by the mongo upgrader.
+type Backend interface {
+	MongoUpgradeStatus() (*state.MongoUpgradeStatus, error)
+	WatchMongoUpgrade() state.NotifyWatcher
+	SetMongoUpgradeMember(machineId string, phase state.MongoUpgradePhase, message string) error
+	SetMongoUpgradeProgress(machineId string, message string) error
+	ReplicaSetMembers() ([]replicaset.Member, error)
+	ReplicaSetStatus() (*replicaset.Status, error)
+	StepDownReplicaSetPrimary() error
+}
+
+// Config holds the configuration for a mongo upgrader worker.
+type Config struct {
+	Agent   agent.Agent
+	Backend Backend
+	Clock   clock.Clock
+
+	// PollInterval is how often the replica set is checked while the
+	// local database resyncs, or while waiting for another controller
+	// to finish resyncing its own.
+	PollInterval time.Duration
+
+	// ResyncTimeout is how long the local database may take to resync
+	// before the migration is recorded as failed.
+	ResyncTimeout time.Duration
+
+	StopMongo      func() error
+	EnsureMongo    func(mongo.EnsureServerParams) (mongo.Version, error)
+	MoveDataDir    func(dbDir string, engine mongo.StorageEngine, now time.Time) (string, error)
+	RestoreDataDir func(dbDir, aside string) error
+}
+
+// Validate returns an error if the config cannot be used to start a
+// worker.
+func (config Config) Validate() error {
+	if config.Agent == nil {
+		return errors.NotValidf("nil Agent")
+	}
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	if config.ResyncTimeout <= 0 {
+		return errors.NotValidf("non-positive ResyncTimeout")
+	}
+	if config.StopMongo == nil {
+		return errors.NotValidf("nil StopMongo")
+	}
+	if config.EnsureMongo == nil {
+		return errors.NotValidf("nil EnsureMongo")
+	}
+	if config.MoveDataDir == nil {
+		return errors.NotValidf("nil MoveDataDir")
+	}
+	if config.RestoreDataDir == nil {
+		return errors.NotValidf("nil RestoreDataDir")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which migrates the local controller's
+// database whenever a migration is started.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &mongoUpgrader{
+		config:    config,
+		machineId: config.Agent.CurrentConfig().Tag().Id(),
+	}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type mongoUpgrader struct {
+	tomb      tomb.Tomb
+	config    Config
+	machineId string
+}
+
+func (w *mongoUpgrader) loop() error {
+	watcher := w.config.Backend.WatchMongoUpgrade()
+	defer worker.Stop(watcher)
+
+	var poll <-chan time.Time
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("mongo upgrade watcher closed")
+			}
+		case <-poll:
+		}
+		waiting, err := w.step()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		poll = nil
+		if waiting {
+			poll = w.config.Clock.After(w.config.PollInterval)
+		}
+	}
+}
+
+// step moves the local controller's part in the migration on as far as
+// it can. It returns whether it is waiting, either for another
+// controller to finish resyncing or for the local database to resync.
+func (w *mongoUpgrader) step() (bool, error) {
+	backend := w.config.Backend
+	status, err := backend.MongoUpgradeStatus()
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Annotate(err, "getting mongo upgrade status")
+	}
+	member, ok := status.Member(w.machineId)
+	if !ok {
+		logger.Debugf("machine %s is not part of the mongo upgrade", w.machineId)
+		return false, nil
+	}
+
+	switch member.Phase {
+	case state.MongoUpgradePending:
+		dbDir := mongo.DbDir(w.config.Agent.CurrentConfig().DataDir())
+		engine, err := mongo.DataDirStorageEngine(dbDir)
+		if err != nil {
+			return false, errors.Annotate(err, "detecting storage engine")
+		}
+		if engine == status.Target.StorageEngine {
+			logger.Infof("database already uses storage engine %q", engine)
+			return false, w.setPhase(state.MongoUpgradeDone, "")
+		}
+		err = backend.SetMongoUpgradeMember(w.machineId, state.MongoUpgradeResyncing, "")
+		if errors.Cause(err) == state.ErrMongoResyncInProgress {
+			logger.Infof("waiting for another controller to finish resyncing its database")
+			return true, nil
+		} else if err != nil {
+			return false, errors.Annotate(err, "starting mongo resync")
+		}
+		if err := w.resync(status.Target, engine); err != nil {
+			logger.Errorf("cannot migrate database to mongo %s: %v", status.Target, err)
+			return false, w.setPhase(state.MongoUpgradeFailed, err.Error())
+		}
+		return true, nil
+
+	case state.MongoUpgradeResyncing:
+		// Either we just restarted mongod, or the agent restarted
+		// while the database was resyncing.
+		synced, progress := w.memberSynced()
+		if synced {
+			logger.Infof("database migrated to mongo %s", status.Target)
+			return false, w.setPhase(state.MongoUpgradeDone, "")
+		}
+		if w.config.Clock.Now().Sub(member.Updated) > w.config.ResyncTimeout {
+			return false, w.setPhase(state.MongoUpgradeFailed, "timed out waiting for database resync")
+		}
+		if progress != member.Message {
+			w.setProgress(progress)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// resync restarts mongod with an empty database directory, so that it
+// resyncs from the other members of the replica set.
+func (w *mongoUpgrader) resync(target mongo.Version, engine mongo.StorageEngine) error {
+	backend := w.config.Backend
+	memberId, err := w.memberId()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	status, err := backend.ReplicaSetStatus()
+	if err != nil {
+		return errors.Annotate(err, "getting replica set status")
+	}
+	for _, member := range status.Members {
+		if member.Id == memberId && member.State == replicaset.PrimaryState {
+			w.setProgress("stepping down as replica set primary")
+			if err := backend.StepDownReplicaSetPrimary(); err != nil {
+				return errors.Annotate(err, "stepping down as primary")
+			}
+		}
+	}
+
+	agentConfig := w.config.Agent.CurrentConfig()
+	previous := agentConfig.MongoVersion()
+	ensureParams, err := agent.NewEnsureServerParams(agentConfig)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.setProgress(fmt.Sprintf("moving %s data files aside", engine))
+	if err := w.config.StopMongo(); err != nil {
+		return errors.Annotate(err, "stopping mongo")
+	}
+	dbDir := mongo.DbDir(agentConfig.DataDir())
+	aside, err := w.config.MoveDataDir(dbDir, engine, w.config.Clock.Now())
+	if err != nil {
+		// The data files are where they were, so mongod can
+		// simply be started again.
+		if _, startErr := w.config.EnsureMongo(ensureParams); startErr != nil {
+			logger.Errorf("cannot restart mongo: %v", startErr)
+		}
+		return errors.Trace(err)
+	}
+	logger.Infof("moved %s data files to %s", engine, aside)
+
+	w.setProgress(fmt.Sprintf("starting mongo %s", target))
+	version, err := w.config.EnsureMongo(ensureParams)
+	if err != nil {
+		err = errors.Annotate(err, "starting mongo")
+	} else if version.StorageEngine != target.StorageEngine || version.NewerThan(target) < 0 {
+		err = errors.Errorf("mongo %s started, expected %s", version, target)
+	}
+	if err != nil {
+		if rollbackErr := w.rollback(ensureParams, previous, dbDir, aside); rollbackErr != nil {
+			return errors.Errorf("%v; cannot restore %s data files from %s: %v", err, engine, aside, rollbackErr)
+		}
+		logger.Infof("restored %s data files and restarted mongo %s", engine, previous)
+		return errors.Trace(err)
+	}
+	return errors.Trace(w.config.Agent.ChangeConfig(func(setter agent.ConfigSetter) error {
+		setter.SetMongoVersion(version)
+		return nil
+	}))
+}
+
+// rollback puts back the data files that were moved aside, and starts
+// the mongod that wrote them.
+func (w *mongoUpgrader) rollback(ensureParams mongo.EnsureServerParams, previous mongo.Version, dbDir, aside string) error {
+	if err := w.config.StopMongo(); err != nil {
+		return errors.Annotate(err, "stopping mongo")
+	}
+	if err := w.config.RestoreDataDir(dbDir, aside); err != nil {
+		return errors.Trace(err)
+	}
+	ensureParams.Version = previous
+	if _, err := w.config.EnsureMongo(ensureParams); err != nil {
+		return errors.Annotatef(err, "starting mongo %s", previous)
+	}
+	return nil
+}
+
+// memberSynced returns whether the local replica set member has
+// finished resyncing and, if it hasn't, a description of how far it
+// has got.
+func (w *mongoUpgrader) memberSynced() (bool, string) {
+	// Mongo may not be answering while it restarts.
+	memberId, err := w.memberId()
+	if err != nil {
+		logger.Debugf("cannot find replica set member: %v", err)
+		return false, "waiting for mongo to start"
+	}
+	status, err := w.config.Backend.ReplicaSetStatus()
+	if err != nil {
+		logger.Debugf("cannot get replica set status: %v", err)
+		return false, "waiting for mongo to start"
+	}
+	for _, member := range status.Members {
+		if member.Id != memberId {
+			continue
+		}
+		if member.Healthy && (member.State == replicaset.PrimaryState || member.State == replicaset.SecondaryState) {
+			return true, ""
+		}
+		logger.Infof("waiting for database resync, member state is %s", member.State)
+		return false, fmt.Sprintf("resyncing from peers, member state is %s", member.State)
+	}
+	return false, "waiting for mongo to join the replica set"
+}
+
+// memberId returns the replica set member id of the local controller.
+func (w *mongoUpgrader) memberId() (int, error) {
+	members, err := w.config.Backend.ReplicaSetMembers()
+	if err != nil {
+		return 0, errors.Annotate(err, "getting replica set members")
+	}
+	for _, member := range members {
+		if member.Tags[jujuMachineKey] == w.machineId {
+			return member.Id, nil
+		}
+	}
+	return 0, errors.NotFoundf("replica set member for machine %s", w.machineId)
+}
+
+func (w *mongoUpgrader) setPhase(phase state.MongoUpgradePhase, message string) error {
+	err := w.config.Backend.SetMongoUpgradeMember(w.machineId, phase, message)
+	return errors.Annotate(err, "recording mongo upgrade progress")
+}
+
+// setProgress records what the local controller is doing while it
+// resyncs. Failing to record it doesn't stop the migration.
+func (w *mongoUpgrader) setProgress(message string) {
+	logger.Infof("mongo upgrade: %s", message)
+	if err := w.config.Backend.SetMongoUpgradeProgress(w.machineId, message); err != nil {
+		logger.Warningf("cannot record mongo upgrade progress: %v", err)
+	}
+}
+
+// Kill implements Worker.Kill().
+func (w *mongoUpgrader) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait().
+func (w *mongoUpgrader) Wait() error {
+	return w.tomb.Wait()
+}
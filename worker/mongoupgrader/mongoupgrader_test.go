@@ -0,0 +1,468 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongoupgrader_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/mongoupgrader"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock   *testclock.Clock
+	dataDir string
+	agent   *fakeAgent
+	backend *fakeBackend
+
+	// started is the version of mongod reported by EnsureMongo.
+	started mongo.Version
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.dataDir = c.MkDir()
+	dbDir := mongo.DbDir(s.dataDir)
+	err := os.MkdirAll(dbDir, 0700)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(filepath.Join(dbDir, "local.ns"), nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.agent = &fakeAgent{conf: &fakeConfig{dataDir: s.dataDir, mongoVersion: mongo.Mongo24}}
+	s.started = mongo.Mongo36wt
+	s.backend = newFakeBackend(s.clock)
+	s.backend.members = []replicaset.Member{
+		{Id: 1, Tags: map[string]string{"juju-machine-id": "0"}},
+		{Id: 2, Tags: map[string]string{"juju-machine-id": "1"}},
+	}
+	s.backend.replicaSet = []*replicaset.Status{{
+		Members: []replicaset.MemberStatus{
+			{Id: 1, Healthy: true, State: replicaset.PrimaryState},
+			{Id: 2, Healthy: true, State: replicaset.SecondaryState},
+		},
+	}, {
+		Members: []replicaset.MemberStatus{
+			{Id: 1, Healthy: true, State: replicaset.Startup2State},
+			{Id: 2, Healthy: true, State: replicaset.PrimaryState},
+		},
+	}, {
+		Members: []replicaset.MemberStatus{
+			{Id: 1, Healthy: true, State: replicaset.SecondaryState},
+			{Id: 2, Healthy: true, State: replicaset.PrimaryState},
+		},
+	}}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := mongoupgrader.NewWorker(mongoupgrader.Config{
+		Agent:         s.agent,
+		Backend:       s.backend,
+		Clock:         s.clock,
+		PollInterval:  time.Minute,
+		ResyncTimeout: time.Hour,
+		StopMongo: func() error {
+			return s.backend.call("StopMongo")
+		},
+		EnsureMongo: func(args mongo.EnsureServerParams) (mongo.Version, error) {
+			version := s.started
+			if args.Version != (mongo.Version{}) {
+				version = args.Version
+			}
+			return version, s.backend.call("EnsureMongo", args.DataDir, args.StatePort, args.Version)
+		},
+		MoveDataDir: func(dbDir string, engine mongo.StorageEngine, now time.Time) (string, error) {
+			return dbDir + ".aside", s.backend.call("MoveDataDir", dbDir, engine)
+		},
+		RestoreDataDir: func(dbDir, aside string) error {
+			return s.backend.call("RestoreDataDir", dbDir, aside)
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) startUpgrade() {
+	s.backend.setStatus(&state.MongoUpgradeStatus{
+		Target: mongo.Mongo36wt,
+		Members: []state.MongoUpgradeMember{
+			{MachineId: "0", Phase: state.MongoUpgradePending, Updated: s.clock.Now()},
+			{MachineId: "1", Phase: state.MongoUpgradePending, Updated: s.clock.Now()},
+		},
+	})
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C, name string) {
+	for {
+		select {
+		case call := <-s.backend.calls:
+			if call == name {
+				return
+			}
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %s", name)
+		}
+	}
+}
+
+func (s *WorkerSuite) advance(c *gc.C) {
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := mongoupgrader.NewWorker(mongoupgrader.Config{
+		Agent:        s.agent,
+		Backend:      s.backend,
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive ResyncTimeout not valid")
+}
+
+func (s *WorkerSuite) TestNotRequested(c *gc.C) {
+	w := s.startWorker(c)
+	s.waitCall(c, "MongoUpgradeStatus")
+	workertest.CleanKill(c, w)
+	s.backend.stub.CheckCallNames(c, "MongoUpgradeStatus")
+}
+
+func (s *WorkerSuite) TestAlreadyMigrated(c *gc.C) {
+	err := ioutil.WriteFile(filepath.Join(mongo.DbDir(s.dataDir), "WiredTiger"), nil, 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.startUpgrade()
+	s.startWorker(c)
+	s.waitCall(c, "SetMongoUpgradeMember")
+
+	s.backend.stub.CheckCallNames(c, "MongoUpgradeStatus", "SetMongoUpgradeMember")
+	s.backend.stub.CheckCall(c, 1, "SetMongoUpgradeMember", "0", state.MongoUpgradeDone, "")
+}
+
+func (s *WorkerSuite) TestMigrate(c *gc.C) {
+	s.startUpgrade()
+	s.backend.stub.SetErrors(
+		nil, // MongoUpgradeStatus
+		state.ErrMongoResyncInProgress,
+	)
+	s.startWorker(c)
+	s.waitCall(c, "SetMongoUpgradeMember")
+
+	// Another controller finished resyncing.
+	s.advance(c)
+	s.waitCall(c, "EnsureMongo")
+	s.advance(c)
+	s.waitCall(c, "ReplicaSetStatus")
+	s.advance(c)
+	s.waitCall(c, "SetMongoUpgradeMember")
+
+	s.backend.stub.CheckCallNames(c,
+		"MongoUpgradeStatus",
+		"SetMongoUpgradeMember", // another controller is resyncing
+		"MongoUpgradeStatus",
+		"SetMongoUpgradeMember",
+		"ReplicaSetMembers",
+		"ReplicaSetStatus",
+		"SetMongoUpgradeProgress",
+		"StepDownReplicaSetPrimary",
+		"SetMongoUpgradeProgress",
+		"StopMongo",
+		"MoveDataDir",
+		"SetMongoUpgradeProgress",
+		"EnsureMongo",
+		"MongoUpgradeStatus",
+		"ReplicaSetMembers",
+		"ReplicaSetStatus",
+		"SetMongoUpgradeProgress",
+		"MongoUpgradeStatus",
+		"ReplicaSetMembers",
+		"ReplicaSetStatus",
+		"SetMongoUpgradeMember",
+	)
+	s.backend.stub.CheckCall(c, 3, "SetMongoUpgradeMember", "0", state.MongoUpgradeResyncing, "")
+	s.backend.stub.CheckCall(c, 6, "SetMongoUpgradeProgress", "0", "stepping down as replica set primary")
+	s.backend.stub.CheckCall(c, 8, "SetMongoUpgradeProgress", "0", "moving mmapv1 data files aside")
+	s.backend.stub.CheckCall(c, 10, "MoveDataDir", mongo.DbDir(s.dataDir), mongo.MMAPV1)
+	s.backend.stub.CheckCall(c, 11, "SetMongoUpgradeProgress", "0", "starting mongo 3.6/wiredTiger")
+	s.backend.stub.CheckCall(c, 12, "EnsureMongo", s.dataDir, 37017, mongo.Version{})
+	s.backend.stub.CheckCall(c, 16, "SetMongoUpgradeProgress", "0", "resyncing from peers, member state is STARTUP2")
+	s.backend.stub.CheckCall(c, 20, "SetMongoUpgradeMember", "0", state.MongoUpgradeDone, "")
+	c.Assert(s.agent.conf.mongoVersion, gc.Equals, mongo.Mongo36wt)
+}
+
+func (s *WorkerSuite) TestMigrateFailure(c *gc.C) {
+	s.startUpgrade()
+	s.backend.stub.SetErrors(
+		nil, // MongoUpgradeStatus
+		nil, // SetMongoUpgradeMember
+		nil, // ReplicaSetMembers
+		nil, // ReplicaSetStatus
+		nil, // SetMongoUpgradeProgress
+		nil, // StepDownReplicaSetPrimary
+		nil, // SetMongoUpgradeProgress
+		errors.New("boom"),
+	)
+	w := s.startWorker(c)
+	s.waitCall(c, "StopMongo")
+	s.waitCall(c, "SetMongoUpgradeMember")
+
+	s.backend.stub.CheckCall(c, 8, "SetMongoUpgradeMember", "0", state.MongoUpgradeFailed, "stopping mongo: boom")
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestMigrateRollback(c *gc.C) {
+	s.started = mongo.Mongo26
+	s.startUpgrade()
+	w := s.startWorker(c)
+	s.waitCall(c, "RestoreDataDir")
+	s.waitCall(c, "SetMongoUpgradeMember")
+
+	dbDir := mongo.DbDir(s.dataDir)
+	s.backend.stub.CheckCallNames(c,
+		"MongoUpgradeStatus",
+		"SetMongoUpgradeMember",
+		"ReplicaSetMembers",
+		"ReplicaSetStatus",
+		"SetMongoUpgradeProgress",
+		"StepDownReplicaSetPrimary",
+		"SetMongoUpgradeProgress",
+		"StopMongo",
+		"MoveDataDir",
+		"SetMongoUpgradeProgress",
+		"EnsureMongo",
+		"StopMongo",
+		"RestoreDataDir",
+		"EnsureMongo",
+		"SetMongoUpgradeMember",
+	)
+	s.backend.stub.CheckCall(c, 12, "RestoreDataDir", dbDir, dbDir+".aside")
+	s.backend.stub.CheckCall(c, 13, "EnsureMongo", s.dataDir, 37017, mongo.Mongo24)
+	s.backend.stub.CheckCall(c, 14, "SetMongoUpgradeMember", "0", state.MongoUpgradeFailed,
+		"mongo 2.6/mmapv1 started, expected 3.6/wiredTiger")
+	// The agent keeps running the mongod that wrote the data files.
+	c.Assert(s.agent.conf.mongoVersion, gc.Equals, mongo.Mongo24)
+	workertest.CheckAlive(c, w)
+}
+
+func (s *WorkerSuite) TestMigrateRollbackFailure(c *gc.C) {
+	s.startUpgrade()
+	s.backend.stub.SetErrors(
+		nil, // MongoUpgradeStatus
+		nil, // SetMongoUpgradeMember
+		nil, // ReplicaSetMembers
+		nil, // ReplicaSetStatus
+		nil, // SetMongoUpgradeProgress
+		nil, // StepDownReplicaSetPrimary
+		nil, // SetMongoUpgradeProgress
+		nil, // StopMongo
+		nil, // MoveDataDir
+		nil, // SetMongoUpgradeProgress
+		errors.New("no mongod"),
+		nil, // StopMongo
+		errors.New("boom"),
+	)
+	s.startWorker(c)
+	s.waitCall(c, "RestoreDataDir")
+	s.waitCall(c, "SetMongoUpgradeMember")
+
+	dbDir := mongo.DbDir(s.dataDir)
+	s.backend.stub.CheckCall(c, 13, "SetMongoUpgradeMember", "0", state.MongoUpgradeFailed,
+		"starting mongo: no mongod; cannot restore mmapv1 data files from "+dbDir+".aside: boom")
+}
+
+func (s *WorkerSuite) TestResyncTimeout(c *gc.C) {
+	s.startUpgrade()
+	s.backend.status.Members[0].Phase = state.MongoUpgradeResyncing
+	s.backend.replicaSet = s.backend.replicaSet[1:2]
+	s.startWorker(c)
+	s.waitCall(c, "SetMongoUpgradeProgress")
+
+	c.Assert(s.clock.WaitAdvance(time.Hour+time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitCall(c, "SetMongoUpgradeMember")
+	s.backend.stub.CheckCall(c, 3, "SetMongoUpgradeProgress", "0", "resyncing from peers, member state is STARTUP2")
+	s.backend.stub.CheckCall(c, 7, "SetMongoUpgradeMember", "0", state.MongoUpgradeFailed, "timed out waiting for database resync")
+}
+
+func (s *WorkerSuite) TestPreviouslyFailed(c *gc.C) {
+	s.startUpgrade()
+	s.backend.status.Members[0].Phase = state.MongoUpgradeFailed
+	w := s.startWorker(c)
+	s.waitCall(c, "MongoUpgradeStatus")
+	workertest.CleanKill(c, w)
+	s.backend.stub.CheckCallNames(c, "MongoUpgradeStatus")
+}
+
+type fakeBackend struct {
+	stub    testing.Stub
+	clock   *testclock.Clock
+	calls   chan string
+	changes chan struct{}
+
+	mu         sync.Mutex
+	status     *state.MongoUpgradeStatus
+	members    []replicaset.Member
+	replicaSet []*replicaset.Status
+}
+
+func newFakeBackend(clock *testclock.Clock) *fakeBackend {
+	return &fakeBackend{
+		clock:   clock,
+		calls:   make(chan string, 100),
+		changes: make(chan struct{}, 1),
+	}
+}
+
+func (b *fakeBackend) setStatus(status *state.MongoUpgradeStatus) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.status = status
+}
+
+func (b *fakeBackend) call(name string, args ...interface{}) error {
+	b.stub.MethodCall(b, name, args...)
+	b.calls <- name
+	return b.stub.NextErr()
+}
+
+func (b *fakeBackend) MongoUpgradeStatus() (*state.MongoUpgradeStatus, error) {
+	if err := b.call("MongoUpgradeStatus"); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.status == nil {
+		return nil, errors.NotFoundf("mongo upgrade")
+	}
+	status := *b.status
+	status.Members = append([]state.MongoUpgradeMember(nil), b.status.Members...)
+	return &status, nil
+}
+
+func (b *fakeBackend) WatchMongoUpgrade() state.NotifyWatcher {
+	b.changes <- struct{}{}
+	return watchertest.NewNotifyWatcher(b.changes)
+}
+
+func (b *fakeBackend) SetMongoUpgradeMember(machineId string, phase state.MongoUpgradePhase, message string) error {
+	if err := b.call("SetMongoUpgradeMember", machineId, phase, message); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, member := range b.status.Members {
+		if member.MachineId == machineId {
+			b.status.Members[i].Phase = phase
+			b.status.Members[i].Message = message
+			b.status.Members[i].Updated = b.clock.Now()
+		}
+	}
+	return nil
+}
+
+func (b *fakeBackend) SetMongoUpgradeProgress(machineId string, message string) error {
+	if err := b.call("SetMongoUpgradeProgress", machineId, message); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for i, member := range b.status.Members {
+		if member.MachineId == machineId {
+			b.status.Members[i].Message = message
+		}
+	}
+	return nil
+}
+
+func (b *fakeBackend) ReplicaSetMembers() ([]replicaset.Member, error) {
+	if err := b.call("ReplicaSetMembers"); err != nil {
+		return nil, err
+	}
+	return b.members, nil
+}
+
+func (b *fakeBackend) ReplicaSetStatus() (*replicaset.Status, error) {
+	if err := b.call("ReplicaSetStatus"); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	status := b.replicaSet[0]
+	if len(b.replicaSet) > 1 {
+		b.replicaSet = b.replicaSet[1:]
+	}
+	return status, nil
+}
+
+func (b *fakeBackend) StepDownReplicaSetPrimary() error {
+	return b.call("StepDownReplicaSetPrimary")
+}
+
+type fakeAgent struct {
+	agent.Agent
+	conf *fakeConfig
+}
+
+func (a *fakeAgent) CurrentConfig() agent.Config {
+	return a.conf
+}
+
+func (a *fakeAgent) ChangeConfig(mutate agent.ConfigMutator) error {
+	return mutate(a.conf)
+}
+
+type fakeConfig struct {
+	agent.ConfigSetter
+	dataDir      string
+	mongoVersion mongo.Version
+}
+
+func (c *fakeConfig) Tag() names.Tag {
+	return names.NewMachineTag("0")
+}
+
+func (c *fakeConfig) DataDir() string {
+	return c.dataDir
+}
+
+func (c *fakeConfig) Value(key string) string {
+	return ""
+}
+
+func (c *fakeConfig) StateServingInfo() (params.StateServingInfo, bool) {
+	return params.StateServingInfo{StatePort: 37017}, true
+}
+
+func (c *fakeConfig) MongoMemoryProfile() mongo.MemoryProfile {
+	return mongo.MemoryProfileDefault
+}
+
+func (c *fakeConfig) MongoVersion() mongo.Version {
+	return c.mongoVersion
+}
+
+func (c *fakeConfig) SetMongoVersion(v mongo.Version) {
+	c.mongoVersion = v
+}
@@ -0,0 +1,35 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package mongoupgrader
+
+import (
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/state"
+)
+
+// NewBackend returns a Backend that records the progress of the
+// migration in the controller's state, and manages its replica set.
+func NewBackend(pool *state.StatePool) Backend {
+	return backendShim{pool.SystemState()}
+}
+
+type backendShim struct {
+	*state.State
+}
+
+// ReplicaSetMembers is part of the Backend interface.
+func (b backendShim) ReplicaSetMembers() ([]replicaset.Member, error) {
+	return replicaset.CurrentMembers(b.MongoSession())
+}
+
+// ReplicaSetStatus is part of the Backend interface.
+func (b backendShim) ReplicaSetStatus() (*replicaset.Status, error) {
+	return replicaset.CurrentStatus(b.MongoSession())
+}
+
+// StepDownReplicaSetPrimary is part of the Backend interface.
+func (b backendShim) StepDownReplicaSetPrimary() error {
+	return replicaset.StepDownPrimary(b.MongoSession())
+}
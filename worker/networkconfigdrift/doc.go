@@ -0,0 +1,10 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package networkconfigdrift provides a worker which periodically
+// compares the network configuration observed on a machine with the
+// link-layer devices and addresses recorded for it in state. Any drift
+// is recorded against the machine, where it is reported by
+// "juju show-machine", and the machine's netplan configuration may
+// optionally be re-applied to restore the recorded one.
+package networkconfigdrift
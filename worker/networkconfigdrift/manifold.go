@@ -0,0 +1,88 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package networkconfigdrift
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/exec"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/agent"
+	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	apimachiner "github.com/juju/juju/api/machiner"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/jujud/agent/engine"
+	jworker "github.com/juju/juju/worker"
+)
+
+// ManifoldConfig defines the names of the manifolds on which a Manifold will depend.
+type ManifoldConfig engine.AgentAPIManifoldConfig
+
+// Manifold returns a dependency manifold that runs a network config drift
+// worker, using the resource names defined in the supplied config.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	typedConfig := engine.AgentAPIManifoldConfig(config)
+	return engine.AgentAPIManifold(typedConfig, newWorker)
+}
+
+// netplanDir is where the machine's netplan configuration is found.
+const netplanDir = "/etc/netplan"
+
+// newWorker non-trivially wraps NewWorker for use in a engine.AgentAPIManifold.
+func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	tag, ok := a.CurrentConfig().Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.Errorf("expected MachineTag, got %#v", a.CurrentConfig().Tag())
+	}
+	agentFacade, err := apiagent.NewState(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelConfig, err := agentFacade.ModelConfig()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot read model config")
+	}
+	machine, err := apimachiner.NewState(apiCaller).Machine(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var reapply func() error
+	if modelConfig.ReapplyNetworkConfigOnDrift() {
+		if _, err := os.Stat(netplanDir); err == nil {
+			reapply = applyNetplan
+		} else {
+			logger.Infof("not re-applying network config on drift: %q not found", netplanDir)
+		}
+	}
+	return NewWorker(Config{
+		Machine: machine,
+		ObserveNetworkConfig: func() ([]params.NetworkConfig, error) {
+			return common.GetObservedNetworkConfig(common.DefaultNetworkConfigSource())
+		},
+		ReapplyNetworkConfig: reapply,
+		NewTimer:             jworker.NewTimer,
+		Period:               DefaultPeriod,
+	})
+}
+
+// applyNetplan re-applies the machine's netplan configuration.
+func applyNetplan() error {
+	result, err := exec.RunCommands(exec.RunParams{
+		Commands: "netplan generate && netplan apply",
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Code != 0 {
+		return errors.Errorf("netplan apply failed with code %d: %s", result.Code, result.Stderr)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package networkconfigdrift
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.networkconfigdrift")
+
+// DefaultPeriod is the time between network configuration checks.
+const DefaultPeriod = 5 * time.Minute
+
+// Machine records the drift between the network configuration
+// observed on the machine and the one recorded for it.
+type Machine interface {
+	RecordNetworkConfigDrift([]params.NetworkConfig) ([]string, error)
+}
+
+// Config holds the configuration for a network config drift worker.
+type Config struct {
+	// Machine is used to record the drift found.
+	Machine Machine
+
+	// ObserveNetworkConfig returns the network configuration
+	// of the local host.
+	ObserveNetworkConfig func() ([]params.NetworkConfig, error)
+
+	// ReapplyNetworkConfig, if non-nil, is called to restore the
+	// machine's network configuration when drift is found.
+	ReapplyNetworkConfig func() error
+
+	// NewTimer is used to schedule the checks.
+	NewTimer jworker.NewTimerFunc
+
+	// Period is the time between checks.
+	Period time.Duration
+}
+
+// Validate reports whether or not the configuration is valid.
+func (config Config) Validate() error {
+	if config.Machine == nil {
+		return errors.NotValidf("nil Machine")
+	}
+	if config.ObserveNetworkConfig == nil {
+		return errors.NotValidf("nil ObserveNetworkConfig")
+	}
+	if config.NewTimer == nil {
+		return errors.NotValidf("nil NewTimer")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which periodically compares the network
+// configuration observed on the machine with the recorded one.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	check := func(stop <-chan struct{}) error {
+		return checkNetworkConfig(config)
+	}
+	return jworker.NewPeriodicWorker(check, config.Period, config.NewTimer), nil
+}
+
+func checkNetworkConfig(config Config) error {
+	observedConfig, err := config.ObserveNetworkConfig()
+	if err != nil {
+		return errors.Annotate(err, "cannot discover observed network config")
+	}
+	if len(observedConfig) == 0 {
+		logger.Debugf("no observed network config found to compare")
+		return nil
+	}
+	drift, err := config.Machine.RecordNetworkConfigDrift(observedConfig)
+	if err != nil {
+		return errors.Annotate(err, "cannot record network config drift")
+	}
+	if len(drift) == 0 {
+		logger.Tracef("no network config drift found")
+		return nil
+	}
+	logger.Warningf("network config has drifted: %v", drift)
+	if config.ReapplyNetworkConfig == nil {
+		return nil
+	}
+	logger.Infof("re-applying network config")
+	if err := config.ReapplyNetworkConfig(); err != nil {
+		// Leave the drift recorded, and try again next time.
+		logger.Errorf("cannot re-apply network config: %v", err)
+	}
+	return nil
+}
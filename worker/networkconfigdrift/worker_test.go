@@ -0,0 +1,122 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package networkconfigdrift_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/networkconfigdrift"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var observedConfig = []params.NetworkConfig{{
+	InterfaceName: "eth0",
+	InterfaceType: "ethernet",
+	MACAddress:    "aa:bb:cc:dd:ee:f0",
+	CIDR:          "10.0.0.0/24",
+	Address:       "10.0.0.2",
+}}
+
+type machineFunc func([]params.NetworkConfig) ([]string, error)
+
+func (f machineFunc) RecordNetworkConfigDrift(config []params.NetworkConfig) ([]string, error) {
+	return f(config)
+}
+
+func (s *WorkerSuite) config(machine machineFunc, reapply func() error) networkconfigdrift.Config {
+	return networkconfigdrift.Config{
+		Machine: machine,
+		ObserveNetworkConfig: func() ([]params.NetworkConfig, error) {
+			return observedConfig, nil
+		},
+		ReapplyNetworkConfig: reapply,
+		NewTimer:             jworker.NewTimer,
+		Period:               time.Hour,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config(nil, nil)
+	config.Machine = nil
+	_, err := networkconfigdrift.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil Machine not valid")
+
+	config = s.config(func([]params.NetworkConfig) ([]string, error) { return nil, nil }, nil)
+	config.Period = 0
+	_, err = networkconfigdrift.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestRecordsDriftAndReapplies(c *gc.C) {
+	recorded := make(chan []params.NetworkConfig, 1)
+	machine := func(config []params.NetworkConfig) ([]string, error) {
+		recorded <- config
+		return []string{`device "eth1" not observed`}, nil
+	}
+	reapplied := make(chan struct{}, 1)
+	reapply := func() error {
+		reapplied <- struct{}{}
+		return nil
+	}
+	w, err := networkconfigdrift.NewWorker(s.config(machine, reapply))
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case config := <-recorded:
+		c.Assert(config, jc.DeepEquals, observedConfig)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for network config drift to be recorded")
+	}
+	select {
+	case <-reapplied:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for network config to be re-applied")
+	}
+}
+
+func (s *WorkerSuite) TestNoDriftDoesNotReapply(c *gc.C) {
+	recorded := make(chan struct{}, 1)
+	machine := func([]params.NetworkConfig) ([]string, error) {
+		recorded <- struct{}{}
+		return nil, nil
+	}
+	reapply := func() error {
+		c.Errorf("unexpected re-apply of network config")
+		return nil
+	}
+	w, err := networkconfigdrift.NewWorker(s.config(machine, reapply))
+	c.Assert(err, jc.ErrorIsNil)
+
+	select {
+	case <-recorded:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for network config drift to be recorded")
+	}
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestRecordError(c *gc.C) {
+	machine := func([]params.NetworkConfig) ([]string, error) {
+		return nil, errors.New("boom")
+	}
+	w, err := networkconfigdrift.NewWorker(s.config(machine, nil))
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot record network config drift: boom")
+}
@@ -0,0 +1,140 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphanfinder
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.orphanfinder")
+
+// DefaultPeriod is the time between searches for orphaned resources.
+const DefaultPeriod = 30 * time.Minute
+
+// Facade exposes the controller functionality needed by the orphan
+// finder.
+type Facade interface {
+	ModelConfig() (*config.Config, error)
+	KnownModelResources() (environs.KnownModelResources, error)
+}
+
+// Config holds the configuration and dependencies for an orphan finder
+// worker.
+type Config struct {
+	// Facade is used to read the model config, and the resources Juju
+	// knows about.
+	Facade Facade
+
+	// ModelResources is used to list and remove the provider resources
+	// tagged with the model.
+	ModelResources environs.ModelResources
+
+	// CallContext is passed to the ModelResources methods.
+	CallContext context.ProviderCallContext
+
+	// NewTimer is used to schedule the searches.
+	NewTimer jworker.NewTimerFunc
+
+	// Period is the time between searches.
+	Period time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start an
+// orphan finder.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.ModelResources == nil {
+		return errors.NotValidf("nil ModelResources")
+	}
+	if config.CallContext == nil {
+		return errors.NotValidf("nil CallContext")
+	}
+	if config.NewTimer == nil {
+		return errors.NotValidf("nil NewTimer")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that periodically searches for provider
+// resources tagged with the model but unknown to Juju, such as those
+// leaked when force-destroying a machine is interrupted. Orphans are
+// logged and, if the model's remove-orphaned-resources config is set,
+// removed.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	f := &finder{config: config}
+	find := func(stop <-chan struct{}) error {
+		return f.find()
+	}
+	return jworker.NewPeriodicWorker(find, config.Period, config.NewTimer), nil
+}
+
+type finder struct {
+	config Config
+
+	// previous holds the orphans found by the previous search.
+	previous map[environs.ModelResource]bool
+}
+
+// find searches for orphaned resources. A resource is only removed once
+// it has been found by two successive searches, so that resources being
+// created, which the provider has but Juju has not yet recorded, are
+// left alone.
+func (f *finder) find() error {
+	cfg, err := f.config.Facade.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The provider's resources are listed before reading what Juju knows
+	// about, so that resources created in between are not reported.
+	resources, err := f.config.ModelResources.AllModelResources(f.config.CallContext)
+	if err != nil {
+		return errors.Annotate(err, "listing provider resources")
+	}
+	known, err := f.config.Facade.KnownModelResources()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	orphans := environs.OrphanedModelResources(resources, known)
+	found := make(map[environs.ModelResource]bool)
+	var confirmed []environs.ModelResource
+	for _, orphan := range orphans {
+		found[orphan] = true
+		if f.previous[orphan] {
+			confirmed = append(confirmed, orphan)
+		} else {
+			logger.Warningf("found %s %q unknown to Juju", orphan.Kind, orphan.Id)
+		}
+	}
+	f.previous = found
+	if len(confirmed) == 0 || !cfg.RemoveOrphanedResources() {
+		return nil
+	}
+
+	logger.Infof("removing %d orphaned resources", len(confirmed))
+	if err := f.config.ModelResources.DestroyModelResources(f.config.CallContext, confirmed); err != nil {
+		return errors.Annotate(err, "removing orphaned resources")
+	}
+	for _, orphan := range confirmed {
+		delete(f.previous, orphan)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package orphanfinder_test
+
+import (
+	"sync"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	coretesting "github.com/juju/juju/testing"
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/orphanfinder"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	facade    *mockFacade
+	resources *mockModelResources
+	ticks     chan time.Time
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var (
+	knownInstance  = environs.ModelResource{Kind: environs.InstanceResource, Id: "i-0"}
+	leakedInstance = environs.ModelResource{Kind: environs.InstanceResource, Id: "i-1"}
+	leakedGroup    = environs.ModelResource{Kind: environs.SecurityGroupResource, Id: "sg-1", Owner: "machine-1"}
+)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &mockFacade{
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"remove-orphaned-resources": true,
+		}),
+		known: environs.KnownModelResources{
+			InstanceIds: []string{"i-0"},
+			Machines:    []string{"machine-0"},
+		},
+		read: make(chan struct{}),
+	}
+	s.resources = &mockModelResources{
+		resources: []environs.ModelResource{knownInstance, leakedInstance, leakedGroup},
+		destroyed: make(chan []environs.ModelResource, 1),
+	}
+	s.ticks = make(chan time.Time)
+}
+
+func (s *WorkerSuite) config() orphanfinder.Config {
+	return orphanfinder.Config{
+		Facade:         s.facade,
+		ModelResources: s.resources,
+		CallContext:    context.NewCloudCallContext(),
+		NewTimer: func(time.Duration) jworker.PeriodicTimer {
+			return &fakeTimer{s.ticks}
+		},
+		Period: time.Hour,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	_, err := orphanfinder.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.ModelResources = nil
+	_, err = orphanfinder.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil ModelResources not valid")
+
+	config = s.config()
+	config.Period = 0
+	_, err = orphanfinder.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestRemovesOrphansFoundTwice(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.find(c)
+	s.assertNotDestroyed(c)
+	s.find(c)
+	select {
+	case destroyed := <-s.resources.destroyed:
+		c.Assert(destroyed, jc.DeepEquals, []environs.ModelResource{leakedInstance, leakedGroup})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for orphans to be removed")
+	}
+}
+
+func (s *WorkerSuite) TestIgnoresResourcesBecomingKnown(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.find(c)
+	s.facade.setKnown(environs.KnownModelResources{
+		InstanceIds: []string{"i-0", "i-1"},
+		Machines:    []string{"machine-0", "machine-1"},
+	})
+	s.find(c)
+	s.assertNotDestroyed(c)
+}
+
+func (s *WorkerSuite) TestDoesNotRemoveUnlessConfigured(c *gc.C) {
+	cfg, err := s.facade.config.Apply(map[string]interface{}{
+		"remove-orphaned-resources": false,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.config = cfg
+
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.find(c)
+	s.find(c)
+	s.assertNotDestroyed(c)
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := orphanfinder.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+// find triggers a search, and waits for the resources known to Juju to
+// be read.
+func (s *WorkerSuite) find(c *gc.C) {
+	select {
+	case s.ticks <- time.Time{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out triggering search")
+	}
+	select {
+	case <-s.facade.read:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for known resources to be read")
+	}
+}
+
+func (s *WorkerSuite) assertNotDestroyed(c *gc.C) {
+	select {
+	case destroyed := <-s.resources.destroyed:
+		c.Fatalf("unexpected removal of %v", destroyed)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeTimer struct {
+	ticks chan time.Time
+}
+
+func (t *fakeTimer) Reset(time.Duration) bool {
+	return true
+}
+
+func (t *fakeTimer) CountDown() <-chan time.Time {
+	return t.ticks
+}
+
+type mockFacade struct {
+	mu     sync.Mutex
+	config *config.Config
+	known  environs.KnownModelResources
+	read   chan struct{}
+}
+
+func (f *mockFacade) setKnown(known environs.KnownModelResources) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.known = known
+}
+
+func (f *mockFacade) ModelConfig() (*config.Config, error) {
+	return f.config, nil
+}
+
+func (f *mockFacade) KnownModelResources() (environs.KnownModelResources, error) {
+	f.mu.Lock()
+	known := f.known
+	f.mu.Unlock()
+	f.read <- struct{}{}
+	return known, nil
+}
+
+type mockModelResources struct {
+	resources []environs.ModelResource
+	destroyed chan []environs.ModelResource
+}
+
+func (m *mockModelResources) AllModelResources(context.ProviderCallContext) ([]environs.ModelResource, error) {
+	return m.resources, nil
+}
+
+func (m *mockModelResources) DestroyModelResources(ctx context.ProviderCallContext, resources []environs.ModelResource) error {
+	m.destroyed <- resources
+	return nil
+}
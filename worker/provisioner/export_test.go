@@ -5,6 +5,7 @@ package provisioner
 
 import (
 	"sort"
+	"time"
 
 	"github.com/juju/version"
 
@@ -36,14 +37,21 @@ func GetRetryWatcher(p Provisioner) (watcher.NotifyWatcher, error) {
 }
 
 var (
-	GetContainerInitialiser = &getContainerInitialiser
-	GetToolsFinder          = &getToolsFinder
-	RetryStrategyDelay      = &retryStrategyDelay
-	RetryStrategyCount      = &retryStrategyCount
+	GetContainerInitialiser   = &getContainerInitialiser
+	GetToolsFinder            = &getToolsFinder
+	RetryStrategyDelay        = &retryStrategyDelay
+	RetryStrategyCount        = &retryStrategyCount
+	MaxStartInstanceBatchSize = &maxStartInstanceBatchSize
 )
 
 var ClassifyMachine = classifyMachine
 
+// RetryStrategyDelayBefore returns how long the given strategy waits
+// before the given retry.
+func RetryStrategyDelayBefore(s RetryStrategy, retry int) time.Duration {
+	return s.delayBefore(retry)
+}
+
 // GetCopyAvailabilityZoneMachines returns a copy of p.(*provisionerTask).availabilityZoneMachines
 func GetCopyAvailabilityZoneMachines(p ProvisionerTask) []AvailabilityZoneMachine {
 	task := p.(*provisionerTask)
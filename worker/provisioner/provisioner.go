@@ -31,8 +31,13 @@ var _ Provisioner = (*environProvisioner)(nil)
 var _ Provisioner = (*containerProvisioner)(nil)
 
 var (
-	retryStrategyDelay = 10 * time.Second
-	retryStrategyCount = 10
+	retryStrategyDelay    = 10 * time.Second
+	retryStrategyMaxDelay = 5 * time.Minute
+	retryStrategyCount    = 10
+
+	// maxStartInstanceBatchSize is the largest number of instances
+	// started with a single request to a broker that supports batching.
+	maxStartInstanceBatchSize = 20
 )
 
 // Provisioner represents a running provisioner worker.
@@ -77,17 +82,61 @@ type provisioner struct {
 //
 // TODO(katco): 2016-08-09: lp:1611427
 type RetryStrategy struct {
-	retryDelay time.Duration
-	retryCount int
+	retryDelay    time.Duration
+	retryMaxDelay time.Duration
+	retryCount    int
 }
 
 // NewRetryStrategy returns a new retry strategy with the specified delay and
 // count for use with retryable provisioning errors.
 func NewRetryStrategy(delay time.Duration, count int) RetryStrategy {
+	return NewBackoffRetryStrategy(delay, delay, count)
+}
+
+// NewBackoffRetryStrategy returns a new retry strategy for use with
+// retryable provisioning errors, which waits for the specified delay
+// before the first retry and doubles the wait after each retry, up to
+// maxDelay.
+func NewBackoffRetryStrategy(delay, maxDelay time.Duration, count int) RetryStrategy {
+	if maxDelay < delay {
+		maxDelay = delay
+	}
 	return RetryStrategy{
-		retryDelay: delay,
-		retryCount: count,
+		retryDelay:    delay,
+		retryMaxDelay: maxDelay,
+		retryCount:    count,
+	}
+}
+
+// delayBefore returns how long to wait before the given retry,
+// counting from zero.
+func (s RetryStrategy) delayBefore(retry int) time.Duration {
+	delay := s.retryDelay
+	for i := 0; i < retry && delay < s.retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.retryMaxDelay {
+		delay = s.retryMaxDelay
+	}
+	return delay
+}
+
+// retryStrategyFromConfig returns the retry strategy configured for the
+// model, using the provisioner's defaults for any value not set.
+func retryStrategyFromConfig(cfg *config.Config) RetryStrategy {
+	delay, ok := cfg.ProvisionerRetryDelay()
+	if !ok {
+		delay = retryStrategyDelay
+	}
+	maxDelay, ok := cfg.ProvisionerRetryMaxDelay()
+	if !ok {
+		maxDelay = retryStrategyMaxDelay
+	}
+	count, ok := cfg.ProvisionerRetryCount()
+	if !ok {
+		count = retryStrategyCount
 	}
+	return NewBackoffRetryStrategy(delay, maxDelay, count)
 }
 
 // configObserver is implemented so that tests can see when the environment
@@ -184,7 +233,7 @@ func (p *provisioner) getStartTask(harvestMode config.HarvestMode) (ProvisionerT
 		p.broker,
 		auth,
 		modelCfg.ImageStream(),
-		RetryStrategy{retryDelay: retryStrategyDelay, retryCount: retryStrategyCount},
+		retryStrategyFromConfig(modelCfg),
 		p.callContext,
 	)
 	if err != nil {
@@ -267,6 +316,7 @@ func (p *environProvisioner) loop() error {
 				return errors.Annotate(err, "loaded invalid model configuration")
 			}
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetRetryStrategy(retryStrategyFromConfig(modelConfig))
 		}
 	}
 }
@@ -367,6 +417,7 @@ func (p *containerProvisioner) loop() error {
 			}
 			p.configObserver.notify(modelConfig)
 			task.SetHarvestMode(modelConfig.ProvisionerHarvestMode())
+			task.SetRetryStrategy(retryStrategyFromConfig(modelConfig))
 		}
 	}
 }
@@ -54,6 +54,11 @@ type ProvisionerTask interface {
 	// should harvest machines. See config.HarvestMode for
 	// documentation of behavior.
 	SetHarvestMode(mode config.HarvestMode)
+
+	// SetRetryStrategy sets the strategy used to retry failed attempts
+	// to start instances. It applies to machines started after the
+	// call.
+	SetRetryStrategy(strategy RetryStrategy)
 }
 
 type MachineGetter interface {
@@ -150,6 +155,7 @@ type provisionerTask struct {
 	harvestMode                config.HarvestMode
 	harvestModeChan            chan config.HarvestMode
 	retryStartInstanceStrategy RetryStrategy
+	retryStrategyMutex         sync.Mutex
 	// instance id -> instance
 	instances map[instance.Id]instances.Instance
 	// machine id -> machine
@@ -232,6 +238,19 @@ func (task *provisionerTask) SetHarvestMode(mode config.HarvestMode) {
 	}
 }
 
+// SetRetryStrategy implements ProvisionerTask.SetRetryStrategy().
+func (task *provisionerTask) SetRetryStrategy(strategy RetryStrategy) {
+	task.retryStrategyMutex.Lock()
+	defer task.retryStrategyMutex.Unlock()
+	task.retryStartInstanceStrategy = strategy
+}
+
+func (task *provisionerTask) retryStrategy() RetryStrategy {
+	task.retryStrategyMutex.Lock()
+	defer task.retryStrategyMutex.Unlock()
+	return task.retryStartInstanceStrategy
+}
+
 func (task *provisionerTask) processMachinesWithTransientErrors() error {
 	results, err := task.machineGetter.MachinesWithTransientErrors()
 	if err != nil {
@@ -1141,27 +1160,31 @@ func (task *provisionerTask) startMachines(machines []apiprovisioner.MachineProv
 		return err
 	}
 
-	var wg sync.WaitGroup
 	errMachines := make([]error, len(machines))
-	for i, m := range machines {
-		if machineDistributionGroups[i].Err != nil {
-			task.setErrorStatus(
-				"fetching distribution groups for machine %q: %v",
-				m, machineDistributionGroups[i].Err,
-			)
-			continue
-		}
-		wg.Add(1)
-		go func(machine apiprovisioner.MachineProvisioner, dg []string, index int) {
-			defer wg.Done()
-			if err := task.startMachine(machine, dg); err != nil {
-				task.removeMachineFromAZMap(machine)
-				errMachines[index] = err
+	if batcher, ok := task.broker.(environs.InstanceBatchBroker); ok && len(machines) > 1 {
+		task.startMachinesInBatches(batcher, machines, machineDistributionGroups, errMachines)
+	} else {
+		var wg sync.WaitGroup
+		for i, m := range machines {
+			if machineDistributionGroups[i].Err != nil {
+				task.setErrorStatus(
+					"fetching distribution groups for machine %q: %v",
+					m, machineDistributionGroups[i].Err,
+				)
+				continue
 			}
-		}(m, machineDistributionGroups[i].MachineIds, i)
+			wg.Add(1)
+			go func(machine apiprovisioner.MachineProvisioner, dg []string, index int) {
+				defer wg.Done()
+				if err := task.startMachine(machine, dg); err != nil {
+					task.removeMachineFromAZMap(machine)
+					errMachines[index] = err
+				}
+			}(m, machineDistributionGroups[i].MachineIds, i)
+		}
+		wg.Wait()
 	}
 
-	wg.Wait()
 	select {
 	case <-task.catacomb.Dying():
 		return task.catacomb.ErrDying()
@@ -1179,16 +1202,202 @@ func (task *provisionerTask) startMachines(machines []apiprovisioner.MachineProv
 	return nil
 }
 
+// pendingStart holds what is needed to start the instance for a machine
+// that is waiting to be included in a batch.
+type pendingStart struct {
+	index             int
+	machine           apiprovisioner.MachineProvisioner
+	distributionGroup []string
+	params            environs.StartInstanceParams
+}
+
+// startMachinesInBatches starts instances for the given machines using
+// a broker that can start several instances with one request. Machines
+// whose instances would be started with identical series, constraints
+// and availability zone are grouped into batches of up to
+// maxStartInstanceBatchSize. Any instance a batch fails to start is
+// retried individually. Errors are recorded in errMachines, at the
+// index of the machine they belong to.
+func (task *provisionerTask) startMachinesInBatches(
+	batcher environs.InstanceBatchBroker,
+	machines []apiprovisioner.MachineProvisioner,
+	distributionGroups []apiprovisioner.DistributionGroupResult,
+	errMachines []error,
+) {
+	var batches [][]pendingStart
+	openBatches := make(map[string]int)
+	for i, m := range machines {
+		if distributionGroups[i].Err != nil {
+			task.setErrorStatus(
+				"fetching distribution groups for machine %q: %v",
+				m, distributionGroups[i].Err,
+			)
+			continue
+		}
+		startInstanceParams, err := task.prepareToStartMachine(m)
+		if err != nil {
+			task.removeMachineFromAZMap(m)
+			errMachines[i] = err
+			continue
+		}
+		if startInstanceParams == nil {
+			continue
+		}
+		dg := distributionGroups[i].MachineIds
+		if startInstanceParams.AvailabilityZone, err = task.machineAvailabilityZoneDistribution(
+			m.Id(), dg, startInstanceParams.Constraints,
+		); err != nil {
+			errMachines[i] = task.setErrorStatus("cannot start instance for machine %q: %v", m, err)
+			continue
+		}
+		start := pendingStart{
+			index:             i,
+			machine:           m,
+			distributionGroup: dg,
+			params:            *startInstanceParams,
+		}
+		key, ok := startInstanceBatchKey(start.params)
+		if !ok {
+			batches = append(batches, []pendingStart{start})
+			continue
+		}
+		if bi, ok := openBatches[key]; ok {
+			batches[bi] = append(batches[bi], start)
+			if len(batches[bi]) >= maxStartInstanceBatchSize {
+				delete(openBatches, key)
+			}
+			continue
+		}
+		openBatches[key] = len(batches)
+		batches = append(batches, []pendingStart{start})
+	}
+
+	var wg sync.WaitGroup
+	for _, batch := range batches {
+		wg.Add(1)
+		go func(batch []pendingStart) {
+			defer wg.Done()
+			for _, result := range task.startBatch(batcher, batch) {
+				if result.err != nil {
+					task.removeMachineFromAZMap(result.machine)
+					errMachines[result.index] = result.err
+				}
+			}
+		}(batch)
+	}
+	wg.Wait()
+}
+
+// batchStartOutcome records the outcome of starting the instance for
+// one of the machines in a batch.
+type batchStartOutcome struct {
+	index   int
+	machine apiprovisioner.MachineProvisioner
+	err     error
+}
+
+// startBatch starts the instances for a batch of machines, falling back
+// to starting them one at a time, with retries, for any instance the
+// batch request failed to start.
+func (task *provisionerTask) startBatch(
+	batcher environs.InstanceBatchBroker,
+	batch []pendingStart,
+) []batchStartOutcome {
+	outcomes := make([]batchStartOutcome, len(batch))
+	var results []environs.BatchStartInstanceResult
+	if len(batch) > 1 {
+		args := make([]environs.StartInstanceParams, len(batch))
+		for i, start := range batch {
+			args[i] = start.params
+		}
+		logger.Infof("starting %d instances with a single request", len(batch))
+		results = batcher.StartInstances(task.cloudCallCtx, args)
+	}
+	for i, start := range batch {
+		outcomes[i] = batchStartOutcome{index: start.index, machine: start.machine}
+		if i < len(results) && results[i].Error == nil && results[i].Result != nil {
+			outcomes[i].err = task.recordStartedInstance(start.machine, start.params, results[i].Result)
+			continue
+		}
+		if i < len(results) && results[i].Error != nil {
+			err := results[i].Error
+			if kind, _ := environs.ClassifyProvisioningError(err); kind != environs.ProvisioningErrorRetryable {
+				outcomes[i].err = task.setStartInstanceErrorStatus(start.machine, err)
+				continue
+			}
+			logger.Warningf("failed to start machine %s in batch, retrying individually: %v", start.machine, err)
+			if start.params.AvailabilityZone != "" && !environs.IsAvailabilityZoneIndependent(err) {
+				if _, err := task.markMachineFailedInAZ(start.machine, start.params.AvailabilityZone); err != nil {
+					outcomes[i].err = err
+					continue
+				}
+			}
+		}
+		// The zone chosen when grouping the batch is chosen again
+		// when starting the instance on its own.
+		task.releaseMachineZone(start.machine, start.params.AvailabilityZone)
+		outcomes[i].err = task.startInstanceWithRetries(start.machine, start.distributionGroup, start.params)
+	}
+	return outcomes
+}
+
+// startInstanceBatchKey returns a key identifying the start instance
+// parameters that can be batched together with the given parameters,
+// and whether they can be batched at all. Placement directives and
+// storage are specific to each instance, so instances requiring them
+// are always started individually.
+func startInstanceBatchKey(args environs.StartInstanceParams) (string, bool) {
+	if args.Placement != "" || len(args.Volumes) > 0 || len(args.VolumeAttachments) > 0 {
+		return "", false
+	}
+	if args.InstanceConfig == nil {
+		return "", false
+	}
+	return strings.Join([]string{
+		args.InstanceConfig.Series,
+		args.Constraints.String(),
+		args.AvailabilityZone,
+	}, "\x00"), true
+}
+
 func (task *provisionerTask) setErrorStatus(message string, machine apiprovisioner.MachineProvisioner, err error) error {
+	return task.setErrorStatusWithData(message, machine, err, nil)
+}
+
+// setStartInstanceErrorStatus sets the machine's instance status to
+// reflect a failure to start its instance, recording how the failure
+// was classified in the status data.
+func (task *provisionerTask) setStartInstanceErrorStatus(machine apiprovisioner.MachineProvisioner, err error) error {
+	return task.setErrorStatusWithData(
+		"cannot start instance for machine %q: %v", machine, err, provisioningErrorData(err),
+	)
+}
+
+func (task *provisionerTask) setErrorStatusWithData(
+	message string, machine apiprovisioner.MachineProvisioner, err error, data map[string]interface{},
+) error {
 	logger.Errorf(message, machine, err)
 	errForStatus := errors.Cause(err)
-	if err2 := machine.SetInstanceStatus(status.ProvisioningError, errForStatus.Error(), nil); err2 != nil {
+	if err2 := machine.SetInstanceStatus(status.ProvisioningError, errForStatus.Error(), data); err2 != nil {
 		// Something is wrong with this machine, better report it back.
 		return errors.Annotatef(err2, "cannot set error status for machine %q", machine)
 	}
 	return nil
 }
 
+// provisioningErrorData returns the status data recording how a
+// provisioning error was classified, so that clients can tell failures
+// that need the user's attention from ones that may go away by
+// themselves.
+func provisioningErrorData(err error) map[string]interface{} {
+	kind, code := environs.ClassifyProvisioningError(err)
+	data := map[string]interface{}{"kind": string(kind)}
+	if code != "" {
+		data["code"] = code
+	}
+	return data
+}
+
 // setupToStartMachine gathers the necessary information,
 // based on the specified machine, to create ProvisioningInfo
 // and StartInstanceParams to be used by startMachine.
@@ -1266,38 +1475,67 @@ func (task *provisionerTask) startMachine(
 	machine apiprovisioner.MachineProvisioner,
 	distributionGroupMachineIds []string,
 ) error {
+	startInstanceParams, err := task.prepareToStartMachine(machine)
+	if err != nil || startInstanceParams == nil {
+		return err
+	}
+	return task.startInstanceWithRetries(machine, distributionGroupMachineIds, *startInstanceParams)
+}
+
+// prepareToStartMachine returns the parameters with which to start an
+// instance for the machine. If the parameters could not be determined,
+// the machine's status is set to reflect that and nil parameters are
+// returned.
+func (task *provisionerTask) prepareToStartMachine(
+	machine apiprovisioner.MachineProvisioner,
+) (*environs.StartInstanceParams, error) {
 	v, err := machine.ModelAgentVersion()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	startInstanceParams, err := task.setupToStartMachine(machine, v)
 	if err != nil {
-		return task.setErrorStatus("%v", machine, err)
+		return nil, task.setErrorStatus("%v", machine, err)
 	}
 
 	// Figure out if the zones available to use for a new instance are
 	// restricted based on placement, and if so exclude those machines
 	// from being started in any other zone.
 	if err := task.populateExcludedMachines(machine.Id(), startInstanceParams); err != nil {
-		return err
+		return nil, err
 	}
 
 	// TODO (jam): 2017-01-19 Should we be setting this earlier in the cycle?
 	if err := machine.SetInstanceStatus(status.Provisioning, "starting", nil); err != nil {
 		logger.Errorf("%v", err)
 	}
+	return &startInstanceParams, nil
+}
+
+// startInstanceWithRetries starts an instance for the machine, retrying
+// according to the task's retry strategy, and records the instance
+// against the machine.
+func (task *provisionerTask) startInstanceWithRetries(
+	machine apiprovisioner.MachineProvisioner,
+	distributionGroupMachineIds []string,
+	startInstanceParams environs.StartInstanceParams,
+) error {
+	var err error
 
 	// TODO ProvisionerParallelization 2017-10-03
-	// Improve the retry loop, newer methodology
 	// Is rate limiting handled correctly?
 	var result *environs.StartInstanceResult
 
-	// Attempt creating the instance "retryCount" times. If the provider
-	// supports availability zones and we're automatically distributing
-	// across the zones, then we try each zone for every attempt, or until
-	// one of the StartInstance calls returns an error satisfying
-	// environs.IsAvailabilityZoneIndependent.
-	for attemptsLeft := task.retryStartInstanceStrategy.retryCount; attemptsLeft >= 0; {
+	// Attempt creating the instance "retryCount" times, backing off
+	// between attempts. If the provider supports availability zones and
+	// we're automatically distributing across the zones, then we try each
+	// zone for every attempt, or until one of the StartInstance calls
+	// returns an error satisfying environs.IsAvailabilityZoneIndependent.
+	// Errors the provider classifies as fatal or as needing user action
+	// are not retried at all.
+	retryStrategy := task.retryStrategy()
+	retries := 0
+	for attemptsLeft := retryStrategy.retryCount; attemptsLeft >= 0; {
 		if startInstanceParams.AvailabilityZone, err = task.machineAvailabilityZoneDistribution(
 			machine.Id(), distributionGroupMachineIds, startInstanceParams.Constraints,
 		); err != nil {
@@ -1312,15 +1550,21 @@ func (task *provisionerTask) startMachine(
 		if err == nil {
 			result = attemptResult
 			break
-		} else if attemptsLeft <= 0 {
+		}
+		if kind, _ := environs.ClassifyProvisioningError(err); kind != environs.ProvisioningErrorRetryable {
+			task.removeMachineFromAZMap(machine)
+			return task.setStartInstanceErrorStatus(machine, err)
+		}
+		if attemptsLeft <= 0 {
 			// Set the state to error, so the machine will be skipped
 			// next time until the error is resolved.
 			task.removeMachineFromAZMap(machine)
-			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
+			return task.setStartInstanceErrorStatus(machine, err)
 		}
 
 		retrying := true
 		retryMsg := ""
+		retryDelay := retryStrategy.delayBefore(retries)
 		if startInstanceParams.AvailabilityZone != "" && !environs.IsAvailabilityZoneIndependent(err) {
 			// We've specified a zone, and the error may be specific to
 			// that zone. Retry in another zone if there are any untried.
@@ -1335,7 +1579,7 @@ func (task *provisionerTask) startMachine(
 				retryMsg = fmt.Sprintf(
 					"failed to start machine %s in zone %q, retrying in %v with new availability zone: %s",
 					machine, startInstanceParams.AvailabilityZone,
-					task.retryStartInstanceStrategy.retryDelay, err,
+					retryDelay, err,
 				)
 				logger.Debugf("%s", retryMsg)
 				// There's still more zones to try, so don't decrement "attemptsLeft" yet.
@@ -1350,10 +1594,11 @@ func (task *provisionerTask) startMachine(
 		if retrying {
 			retryMsg = fmt.Sprintf(
 				"failed to start machine %s (%s), retrying in %v (%d more attempts)",
-				machine, err.Error(), task.retryStartInstanceStrategy.retryDelay, attemptsLeft,
+				machine, err.Error(), retryDelay, attemptsLeft,
 			)
 			logger.Warningf("%s", retryMsg)
 			attemptsLeft--
+			retries++
 		}
 
 		if err3 := machine.SetInstanceStatus(status.Provisioning, retryMsg, nil); err3 != nil {
@@ -1363,10 +1608,19 @@ func (task *provisionerTask) startMachine(
 		select {
 		case <-task.catacomb.Dying():
 			return task.catacomb.ErrDying()
-		case <-time.After(task.retryStartInstanceStrategy.retryDelay):
+		case <-time.After(retryDelay):
 		}
 	}
+	return task.recordStartedInstance(machine, startInstanceParams, result)
+}
 
+// recordStartedInstance records the details of an instance started for
+// the machine. If that fails, the instance is stopped again.
+func (task *provisionerTask) recordStartedInstance(
+	machine apiprovisioner.MachineProvisioner,
+	startInstanceParams environs.StartInstanceParams,
+	result *environs.StartInstanceResult,
+) error {
 	networkConfig := networkingcommon.NetworkConfigFromInterfaceInfo(result.NetworkInfo)
 	volumes := volumesToAPIServer(result.Volumes)
 	volumeNameToAttachmentInfo := volumeAttachmentsToAPIServer(result.VolumeAttachments)
@@ -1475,6 +1729,23 @@ func (task *provisionerTask) addMachineToAZMap(machine *apiprovisioner.Machine,
 	return
 }
 
+// releaseMachineZone removes the specified machine from the machines
+// recorded as being started in the given zone, without recording a
+// failure.
+func (task *provisionerTask) releaseMachineZone(machine apiprovisioner.MachineProvisioner, zone string) {
+	if zone == "" {
+		return
+	}
+	task.machinesMutex.Lock()
+	defer task.machinesMutex.Unlock()
+	for _, zoneMachines := range task.availabilityZoneMachines {
+		if zone == zoneMachines.ZoneName {
+			zoneMachines.MachineIds.Remove(machine.Id())
+			break
+		}
+	}
+}
+
 // removeMachineFromAZMap removes the specified machine from availabilityZoneMachines.
 // It is assumed this is called when the machines are being deleted from state, or failed
 // provisioning.
@@ -211,6 +211,174 @@ func (s *ProvisionerTaskSuite) TestProvisionerRetries(c *gc.C) {
 	s.instanceBroker.CheckCallNames(c, "StartInstance", "StartInstance")
 }
 
+func (s *ProvisionerTaskSuite) TestProvisionerDoesNotRetryErrorsNeedingUserAction(c *gc.C) {
+	s.instanceBroker.SetErrors(
+		common.ProvisioningError(
+			errors.New("instance quota exceeded"),
+			environs.ProvisioningErrorNeedsUserAction,
+			"quota-exceeded",
+		),
+	)
+
+	task := s.newProvisionerTaskWithRetry(c,
+		config.HarvestAll,
+		&mockDistributionGroupFinder{},
+		mockToolsFinder{},
+		provisioner.NewRetryStrategy(0*time.Second, 2),
+	)
+
+	m0 := &testMachine{
+		id: "0",
+	}
+	s.machineStatusResults = []apiprovisioner.MachineStatusResult{
+		{Machine: m0, Status: params.StatusResult{}},
+	}
+	s.sendMachineErrorRetryChange(c)
+
+	s.waitForTask(c, []string{"StartInstance"})
+
+	workertest.CleanKill(c, task)
+	close(s.instanceBroker.callsChan)
+	s.instanceBroker.CheckCallNames(c, "StartInstance")
+
+	m0.mu.Lock()
+	defer m0.mu.Unlock()
+	c.Check(m0.instStatusMsg, gc.Equals, "instance quota exceeded")
+	c.Check(m0.instStatusData, jc.DeepEquals, map[string]interface{}{
+		"kind": "needs-user-action",
+		"code": "quota-exceeded",
+	})
+}
+
+func (s *ProvisionerTaskSuite) TestProvisionerRecordsRetryableErrorKind(c *gc.C) {
+	s.instanceBroker.SetErrors(
+		errors.New("errors 1"),
+		errors.New("errors 2"),
+	)
+
+	task := s.newProvisionerTaskWithRetry(c,
+		config.HarvestAll,
+		&mockDistributionGroupFinder{},
+		mockToolsFinder{},
+		provisioner.NewRetryStrategy(0*time.Second, 1),
+	)
+
+	m0 := &testMachine{
+		id: "0",
+	}
+	s.machineStatusResults = []apiprovisioner.MachineStatusResult{
+		{Machine: m0, Status: params.StatusResult{}},
+	}
+	s.sendMachineErrorRetryChange(c)
+
+	s.waitForTask(c, []string{"StartInstance", "StartInstance"})
+
+	workertest.CleanKill(c, task)
+	close(s.instanceBroker.callsChan)
+
+	m0.mu.Lock()
+	defer m0.mu.Unlock()
+	c.Check(m0.instStatusMsg, gc.Equals, "errors 2")
+	c.Check(m0.instStatusData, jc.DeepEquals, map[string]interface{}{
+		"kind": "retryable",
+	})
+}
+
+func (s *ProvisionerTaskSuite) TestProvisionerStartsIdenticalMachinesInBatch(c *gc.C) {
+	broker := &testBatchInstanceBroker{
+		testInstanceBroker: s.instanceBroker,
+		startInstancesFunc: func(args []environs.StartInstanceParams) []environs.BatchStartInstanceResult {
+			return []environs.BatchStartInstanceResult{
+				{Result: &environs.StartInstanceResult{Instance: &testInstance{id: "inst-0"}}},
+				{Error: errors.New("batch capacity exceeded")},
+			}
+		},
+	}
+	s.instanceBroker.SetErrors(
+		common.ProvisioningError(
+			errors.New("instance quota exceeded"),
+			environs.ProvisioningErrorNeedsUserAction,
+			"quota-exceeded",
+		),
+	)
+
+	task := s.newProvisionerTaskWithRetryAndBroker(c,
+		broker,
+		provisioner.NewRetryStrategy(0*time.Second, 2),
+	)
+
+	m0 := &testMachine{id: "0"}
+	m1 := &testMachine{id: "1"}
+	s.machineStatusResults = []apiprovisioner.MachineStatusResult{
+		{Machine: m0, Status: params.StatusResult{}},
+		{Machine: m1, Status: params.StatusResult{}},
+	}
+	s.sendMachineErrorRetryChange(c)
+
+	// The machines are started with one request, and the machine the
+	// batch failed to start is then started on its own.
+	s.waitForTask(c, []string{"StartInstances", "StartInstance"})
+
+	workertest.CleanKill(c, task)
+	close(s.instanceBroker.callsChan)
+	s.instanceBroker.CheckCallNames(c, "StartInstances", "StartInstance")
+	batchArgs := s.instanceBroker.Calls()[0].Args[1].([]environs.StartInstanceParams)
+	c.Assert(batchArgs, gc.HasLen, 2)
+	c.Check(batchArgs[0].InstanceConfig.MachineId, gc.Equals, "0")
+	c.Check(batchArgs[1].InstanceConfig.MachineId, gc.Equals, "1")
+	singleArgs := s.instanceBroker.Calls()[1].Args[1].(environs.StartInstanceParams)
+	c.Check(singleArgs.InstanceConfig.MachineId, gc.Equals, "1")
+
+	m1.mu.Lock()
+	defer m1.mu.Unlock()
+	c.Check(m1.instStatusMsg, gc.Equals, "instance quota exceeded")
+}
+
+func (s *ProvisionerTaskSuite) TestProvisionerDoesNotBatchDifferentConstraints(c *gc.C) {
+	broker := &testBatchInstanceBroker{
+		testInstanceBroker: s.instanceBroker,
+		startInstancesFunc: func(args []environs.StartInstanceParams) []environs.BatchStartInstanceResult {
+			c.Errorf("unexpected batch of %d instances", len(args))
+			return make([]environs.BatchStartInstanceResult, len(args))
+		},
+	}
+	s.instanceBroker.SetErrors(
+		common.ProvisioningError(errors.New("fatal 1"), environs.ProvisioningErrorFatal, ""),
+		common.ProvisioningError(errors.New("fatal 2"), environs.ProvisioningErrorFatal, ""),
+	)
+
+	task := s.newProvisionerTaskWithRetryAndBroker(c,
+		broker,
+		provisioner.NewRetryStrategy(0*time.Second, 2),
+	)
+
+	s.machineStatusResults = []apiprovisioner.MachineStatusResult{
+		{Machine: &testMachine{id: "0", constraints: "mem=4G"}, Status: params.StatusResult{}},
+		{Machine: &testMachine{id: "1", constraints: "mem=8G"}, Status: params.StatusResult{}},
+	}
+	s.sendMachineErrorRetryChange(c)
+
+	s.waitForTask(c, []string{"StartInstance", "StartInstance"})
+
+	workertest.CleanKill(c, task)
+	close(s.instanceBroker.callsChan)
+	s.instanceBroker.CheckCallNames(c, "StartInstance", "StartInstance")
+}
+
+func (s *ProvisionerTaskSuite) TestBackoffRetryStrategy(c *gc.C) {
+	strategy := provisioner.NewBackoffRetryStrategy(10*time.Second, time.Minute, 10)
+	var delays []time.Duration
+	for retry := 0; retry < 5; retry++ {
+		delays = append(delays, provisioner.RetryStrategyDelayBefore(strategy, retry))
+	}
+	c.Assert(delays, jc.DeepEquals, []time.Duration{
+		10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute,
+	})
+
+	strategy = provisioner.NewRetryStrategy(10*time.Second, 10)
+	c.Assert(provisioner.RetryStrategyDelayBefore(strategy, 3), gc.Equals, 10*time.Second)
+}
+
 func (s *ProvisionerTaskSuite) TestProcessProfileChanges(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -717,6 +885,31 @@ func (s *ProvisionerTaskSuite) newProvisionerTaskWithRetry(
 	return w
 }
 
+func (s *ProvisionerTaskSuite) newProvisionerTaskWithRetryAndBroker(
+	c *gc.C,
+	broker environs.InstanceBroker,
+	retryStrategy provisioner.RetryStrategy,
+) provisioner.ProvisionerTask {
+	w, err := provisioner.NewProvisionerTask(
+		coretesting.ControllerTag.Id(),
+		names.NewMachineTag("0"),
+		config.HarvestAll,
+		s.machineGetter,
+		&mockDistributionGroupFinder{},
+		mockToolsFinder{},
+		s.modelMachinesWatcher,
+		s.machineErrorRetryWatcher,
+		s.modelMachinesProfileWatcher,
+		broker,
+		s.auth,
+		imagemetadata.ReleasedStream,
+		retryStrategy,
+		s.callCtx,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
 func (s *ProvisionerTaskSuite) newProvisionerTaskWithBroker(
 	c *gc.C, broker environs.InstanceBroker, distributionGroups map[names.MachineTag][]string,
 ) provisioner.ProvisionerTask {
@@ -789,6 +982,18 @@ func (t *testInstanceBroker) MaintainInstance(ctx context.ProviderCallContext, a
 	return nil
 }
 
+type testBatchInstanceBroker struct {
+	*testInstanceBroker
+
+	startInstancesFunc func(args []environs.StartInstanceParams) []environs.BatchStartInstanceResult
+}
+
+func (t *testBatchInstanceBroker) StartInstances(ctx context.ProviderCallContext, args []environs.StartInstanceParams) []environs.BatchStartInstanceResult {
+	t.AddCall("StartInstances", ctx, args)
+	t.callsChan <- "StartInstances"
+	return t.startInstancesFunc(args)
+}
+
 type testInstance struct {
 	instances.Instance
 	id string
@@ -811,8 +1016,9 @@ type testMachine struct {
 	markForRemoval bool
 	constraints    string
 
-	instStatusMsg string
-	modStatusMsg  string
+	instStatusMsg  string
+	instStatusData map[string]interface{}
+	modStatusMsg   string
 }
 
 func (m *testMachine) Id() string {
@@ -853,9 +1059,10 @@ func (m *testMachine) MachineTag() names.MachineTag {
 	return names.NewMachineTag(m.id)
 }
 
-func (m *testMachine) SetInstanceStatus(_ status.Status, message string, _ map[string]interface{}) error {
+func (m *testMachine) SetInstanceStatus(_ status.Status, message string, data map[string]interface{}) error {
 	m.mu.Lock()
 	m.instStatusMsg = message
+	m.instStatusData = data
 	m.mu.Unlock()
 	return nil
 }
@@ -66,6 +66,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			w, err := config.WorkerFunc(Config{
 				SystemdFiles:    []string{"/etc/juju-proxy-systemd.conf"},
 				EnvFiles:        []string{"/etc/juju-proxy.conf"},
+				AptSourcesFile:  "/etc/apt/sources.list",
 				RegistryPath:    `HKCU:\Software\Microsoft\Windows\CurrentVersion\Internet Settings`,
 				API:             proxyAPI,
 				ExternalUpdate:  config.ExternalUpdate,
@@ -8,6 +8,8 @@ import (
 	"io"
 	"io/ioutil"
 	stdexec "os/exec"
+	"regexp"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/os"
@@ -26,6 +28,7 @@ type Config struct {
 	RegistryPath    string
 	EnvFiles        []string
 	SystemdFiles    []string
+	AptSourcesFile  string
 	API             API
 	ExternalUpdate  func(proxy.Settings) error
 	InProcessUpdate func(proxy.Settings) error
@@ -67,6 +70,8 @@ type proxyWorker struct {
 	snapStoreProxy      string
 	snapStoreAssertions string
 
+	aptMirror string
+
 	// The whole point of the first value is to make sure that the the files
 	// are written out the first time through, even if they are the same as
 	// "last" time, as the initial value for last time is the zeroed struct.
@@ -267,6 +272,86 @@ func (w *proxyWorker) handleAptProxyValues(aptSettings proxy.Settings) error {
 	return nil
 }
 
+// defaultAptMirror is the archive the APT sources of Ubuntu machines
+// point at when no mirror is configured.
+const defaultAptMirror = "http://archive.ubuntu.com/ubuntu"
+
+// ubuntuArchiveRegexp matches the URI of the primary Ubuntu archive, or one
+// of its regional variants, as written to the APT sources at install time.
+var ubuntuArchiveRegexp = regexp.MustCompile(`^https?://([a-z]{2}\.)?archive\.ubuntu\.com/ubuntu/?$`)
+
+func (w *proxyWorker) handleAptMirrorValue(mirror string) {
+	if os.HostOS() != os.Ubuntu {
+		w.config.Logger.Tracef("apt mirror only updated on ubuntu")
+		return
+	}
+	if w.config.RunFunc == nil || w.config.AptSourcesFile == "" {
+		w.config.Logger.Tracef("apt mirror not updated by unit agents")
+		return
+	}
+	if mirror == w.aptMirror {
+		// On the first pass this is only true if no mirror is set;
+		// a configured mirror is always (idempotently) reapplied.
+		return
+	}
+	w.config.Logger.Debugf("new apt mirror %q", mirror)
+	content, err := ioutil.ReadFile(w.config.AptSourcesFile)
+	if err != nil {
+		w.config.Logger.Errorf("error reading apt sources: %v", err)
+		return
+	}
+	updated := replaceAptMirror(string(content), w.aptMirror, mirror)
+	if updated != string(content) {
+		if err := ioutil.WriteFile(w.config.AptSourcesFile, []byte(updated), 0644); err != nil {
+			// It isn't really fatal, but we should record it.
+			w.config.Logger.Errorf("error writing apt sources: %v", err)
+			return
+		}
+	}
+	w.aptMirror = mirror
+}
+
+// replaceAptMirror returns the APT sources content with the URIs of the
+// deb and deb-src entries pointing at the old mirror replaced by the new
+// mirror. When setting a mirror, entries pointing at the primary Ubuntu
+// archive are also replaced, as the old mirror is not known after an
+// agent restart. An empty new mirror restores the primary Ubuntu archive.
+func replaceAptMirror(content, oldMirror, newMirror string) string {
+	replaceArchive := newMirror != ""
+	if newMirror == "" {
+		newMirror = defaultAptMirror
+	}
+	oldMirror = strings.TrimSuffix(oldMirror, "/")
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || (fields[0] != "deb" && fields[0] != "deb-src") {
+			continue
+		}
+		// Skip any options, e.g. "deb [arch=amd64] uri suite".
+		uriIndex := 1
+		if strings.HasPrefix(fields[1], "[") {
+			for uriIndex < len(fields) && !strings.HasSuffix(fields[uriIndex], "]") {
+				uriIndex++
+			}
+			uriIndex++
+		}
+		if uriIndex >= len(fields) {
+			continue
+		}
+		uri := fields[uriIndex]
+		matches := replaceArchive && ubuntuArchiveRegexp.MatchString(uri)
+		if oldMirror != "" {
+			matches = matches || strings.TrimSuffix(uri, "/") == oldMirror
+		}
+		if !matches || uri == newMirror {
+			continue
+		}
+		lines[i] = strings.Replace(line, uri, newMirror, 1)
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (w *proxyWorker) onChange() error {
 	config, err := w.config.API.ProxyConfig()
 	if err != nil {
@@ -275,6 +360,7 @@ func (w *proxyWorker) onChange() error {
 
 	w.handleProxyValues(config.LegacyProxy, config.JujuProxy)
 	w.handleSnapProxyValues(config.SnapProxy, config.SnapStoreProxyId, config.SnapStoreProxyAssertions)
+	w.handleAptMirrorValue(config.AptMirror)
 	return w.handleAptProxyValues(config.APTProxy)
 }
 
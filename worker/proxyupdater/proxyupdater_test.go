@@ -495,3 +495,28 @@ func (s *ProxyUpdaterSuite) TestSnapStoreProxy(c *gc.C) {
 	})
 	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"please trust us", "snap", "ack", "/dev/stdin"})
 }
+
+func (s *ProxyUpdaterSuite) TestReplaceAptMirror(c *gc.C) {
+	sources := `# See http://help.ubuntu.com/community/UpgradeNotes
+deb http://gb.archive.ubuntu.com/ubuntu/ bionic main restricted
+deb-src http://archive.ubuntu.com/ubuntu bionic main restricted
+deb [arch=amd64] http://archive.ubuntu.com/ubuntu bionic-updates main
+deb http://security.ubuntu.com/ubuntu bionic-security main
+`
+	mirrored := proxyupdater.ReplaceAptMirror(sources, "", "http://mirror.example.com/ubuntu")
+	c.Assert(mirrored, gc.Equals, `# See http://help.ubuntu.com/community/UpgradeNotes
+deb http://mirror.example.com/ubuntu bionic main restricted
+deb-src http://mirror.example.com/ubuntu bionic main restricted
+deb [arch=amd64] http://mirror.example.com/ubuntu bionic-updates main
+deb http://security.ubuntu.com/ubuntu bionic-security main
+`)
+
+	changed := proxyupdater.ReplaceAptMirror(mirrored, "http://mirror.example.com/ubuntu", "http://other.example.com/ubuntu")
+	c.Assert(changed, gc.Equals, strings.Replace(mirrored, "mirror.example.com", "other.example.com", -1))
+
+	restored := proxyupdater.ReplaceAptMirror(changed, "http://other.example.com/ubuntu", "")
+	c.Assert(restored, gc.Equals, strings.Replace(mirrored, "mirror.example.com", "archive.ubuntu.com", -1))
+
+	// Without a mirror to apply, regional archives are left alone.
+	c.Assert(proxyupdater.ReplaceAptMirror(sources, "", ""), gc.Equals, sources)
+}
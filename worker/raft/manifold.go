@@ -5,6 +5,7 @@ package raft
 
 import (
 	"path/filepath"
+	"strconv"
 
 	"github.com/hashicorp/raft"
 	"github.com/juju/clock"
@@ -13,7 +14,7 @@ import (
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 
-	"github.com/juju/juju/agent"
+	coreagent "github.com/juju/juju/agent"
 )
 
 // ManifoldConfig holds the information necessary to run a raft
@@ -76,7 +77,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
-	var agent agent.Agent
+	var agent coreagent.Agent
 	if err := context.Get(config.AgentName, &agent); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -98,10 +99,28 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		LocalID:              raft.ServerID(agentConfig.Tag().Id()),
 		Transport:            transport,
 		Clock:                clk,
+		SnapshotThreshold:    config.agentUint64(agentConfig, coreagent.RaftSnapshotThreshold),
+		TrailingLogs:         config.agentUint64(agentConfig, coreagent.RaftTrailingLogs),
 		PrometheusRegisterer: config.PrometheusRegisterer,
 	})
 }
 
+// agentUint64 returns the numeric agent config value with the given
+// key, or zero if it is missing or invalid so that the raft default
+// is used.
+func (config ManifoldConfig) agentUint64(agentConfig coreagent.Config, key string) uint64 {
+	value := agentConfig.Value(key)
+	if value == "" {
+		return 0
+	}
+	result, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		config.Logger.Warningf("ignoring invalid %s value %q: %v", key, value, err)
+		return 0
+	}
+	return result
+}
+
 func raftOutput(in worker.Worker, out interface{}) error {
 	w, ok := in.(withRaftOutputs)
 	if !ok {
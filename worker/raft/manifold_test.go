@@ -133,6 +133,19 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 	})
 }
 
+func (s *ManifoldSuite) TestStartRaftLogThresholds(c *gc.C) {
+	s.agent.conf.values = map[string]string{
+		"RAFT_SNAPSHOT_THRESHOLD": "1024",
+		"RAFT_TRAILING_LOGS":      "not-a-number",
+	}
+	s.startWorkerClean(c)
+
+	s.stub.CheckCallNames(c, "NewWorker")
+	config := s.stub.Calls()[0].Args[0].(raft.Config)
+	c.Assert(config.SnapshotThreshold, gc.Equals, uint64(1024))
+	c.Assert(config.TrailingLogs, gc.Equals, uint64(0))
+}
+
 func (s *ManifoldSuite) TestOutput(c *gc.C) {
 	w := s.startWorkerClean(c)
 
@@ -6,6 +6,7 @@ package raft
 import (
 	"github.com/armon/go-metrics"
 	pmetrics "github.com/armon/go-metrics/prometheus"
+	"github.com/hashicorp/raft"
 	"github.com/juju/errors"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -59,5 +60,72 @@ func registerMetrics(registry prometheus.Registerer, logger Logger) {
 	if err != nil {
 		logger.Warningf("registering metrics collector failed: %v", err)
 	}
+}
+
+const (
+	metricsNamespace = "juju"
+	metricsSubsystem = "raft"
+)
+
+var (
+	logSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "log_size_bytes"),
+		"The size of the raft log store file.",
+		nil, nil,
+	)
+	logEntriesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "log_entries"),
+		"The number of entries in the raft log store.",
+		nil, nil,
+	)
+	snapshotSizeDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(metricsNamespace, metricsSubsystem, "snapshots_size_bytes"),
+		"The total size of the retained raft snapshots.",
+		nil, nil,
+	)
+)
+
+// storageCollector is a prometheus.Collector that reports the disk
+// space used by the raft node.
+type storageCollector struct {
+	dir    string
+	logs   raft.LogStore
+	logger Logger
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c storageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- logSizeDesc
+	ch <- logEntriesDesc
+	ch <- snapshotSizeDesc
+}
 
+// Collect is part of the prometheus.Collector interface.
+func (c storageCollector) Collect(ch chan<- prometheus.Metric) {
+	size, err := ReadStorageSize(c.dir, c.logs)
+	if err != nil {
+		c.logger.Warningf("reading raft storage size failed: %v", err)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(logSizeDesc, prometheus.GaugeValue, float64(size.LogBytes))
+	ch <- prometheus.MustNewConstMetric(logEntriesDesc, prometheus.GaugeValue, float64(size.LogEntries))
+	ch <- prometheus.MustNewConstMetric(snapshotSizeDesc, prometheus.GaugeValue, float64(size.SnapshotBytes))
+}
+
+// registerStorageMetrics registers a collector reporting the disk space
+// used by the raft node, and returns a function that unregisters it.
+// Any collector left behind by a previous run of the worker is replaced,
+// for the same reason as in registerMetrics.
+func registerStorageMetrics(registry prometheus.Registerer, logger Logger, dir string, logs raft.LogStore) func() {
+	collector := storageCollector{
+		dir:    dir,
+		logs:   logs,
+		logger: logger,
+	}
+	registry.Unregister(collector)
+	if err := registry.Register(collector); err != nil {
+		logger.Warningf("registering raft storage metrics collector failed: %v", err)
+		return func() {}
+	}
+	return func() { registry.Unregister(collector) }
 }
@@ -25,6 +25,7 @@ type mockAgentConfig struct {
 	agent.Config
 	dataDir string
 	tag     names.Tag
+	values  map[string]string
 }
 
 func (c *mockAgentConfig) Tag() names.Tag {
@@ -35,6 +36,10 @@ func (c *mockAgentConfig) DataDir() string {
 	return c.dataDir
 }
 
+func (c *mockAgentConfig) Value(key string) string {
+	return c.values[key]
+}
+
 type mockRaftWorker struct {
 	worker.Worker
 	testing.Stub
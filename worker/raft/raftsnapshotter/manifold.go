@@ -0,0 +1,72 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftsnapshotter
+
+import (
+	"path/filepath"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/agent"
+)
+
+// ManifoldConfig holds the information necessary to run a raft
+// snapshotter worker in a dependency.Engine.
+type ManifoldConfig struct {
+	RaftName       string
+	CentralHubName string
+	AgentName      string
+
+	Logger    loggo.Logger
+	NewWorker func(Config) (worker.Worker, error)
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	var r *raft.Raft
+	if err := context.Get(config.RaftName, &r); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var logStore raft.LogStore
+	if err := context.Get(config.RaftName, &logStore); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var hub *pubsub.StructuredHub
+	if err := context.Get(config.CentralHubName, &hub); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	agentConfig := agent.CurrentConfig()
+
+	return config.NewWorker(Config{
+		Raft:       r,
+		LogStore:   logStore,
+		Hub:        hub,
+		Logger:     config.Logger,
+		MachineID:  agentConfig.Tag().Id(),
+		StorageDir: filepath.Join(agentConfig.DataDir(), "raft"),
+	})
+}
+
+// Manifold returns a dependency.Manifold for running a raftsnapshotter
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.RaftName,
+			config.CentralHubName,
+			config.AgentName,
+		},
+		Start: config.start,
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftsnapshotter
+
+import (
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/pubsub"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	raftmsg "github.com/juju/juju/pubsub/raft"
+	jujuraft "github.com/juju/juju/worker/raft"
+)
+
+// RaftNode captures the part of the *raft.Raft API needed by the
+// snapshotter worker.
+type RaftNode interface {
+	Snapshot() raft.SnapshotFuture
+}
+
+// Logger represents the logging methods called.
+type Logger interface {
+	Infof(message string, args ...interface{})
+	Warningf(message string, args ...interface{})
+}
+
+// This worker takes a snapshot of the local raft node whenever one is
+// requested over the hub, so that the log entries covered by the
+// snapshot are discarded without waiting for the snapshot threshold
+// to be reached. It responds with the disk space used by the node.
+
+// Config holds the values needed by the worker.
+type Config struct {
+	Raft       RaftNode
+	LogStore   raft.LogStore
+	Hub        *pubsub.StructuredHub
+	Logger     Logger
+	MachineID  string
+	StorageDir string
+}
+
+// Validate validates the raft snapshotter configuration.
+func (config Config) Validate() error {
+	if config.Raft == nil {
+		return errors.NotValidf("nil Raft")
+	}
+	if config.LogStore == nil {
+		return errors.NotValidf("nil LogStore")
+	}
+	if config.Hub == nil {
+		return errors.NotValidf("nil Hub")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.MachineID == "" {
+		return errors.NotValidf("empty MachineID")
+	}
+	if config.StorageDir == "" {
+		return errors.NotValidf("empty StorageDir")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that snapshots the raft node on request.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &snapshotWorker{
+		config:   config,
+		requests: make(chan raftmsg.SnapshotRequest),
+	}
+	unsubscribe, err := config.Hub.Subscribe(
+		raftmsg.SnapshotRequestTopic,
+		w.snapshotRequested,
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "subscribing to snapshot requests")
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: func() error {
+			defer unsubscribe()
+			return w.loop()
+		},
+	}); err != nil {
+		unsubscribe()
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+type snapshotWorker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+	requests chan raftmsg.SnapshotRequest
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *snapshotWorker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *snapshotWorker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *snapshotWorker) loop() error {
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case req := <-w.requests:
+			response := w.snapshot()
+			if _, err := w.config.Hub.Publish(req.ResponseTopic, response); err != nil {
+				return errors.Annotate(err, "publishing snapshot response")
+			}
+		}
+	}
+}
+
+func (w *snapshotWorker) snapshot() raftmsg.SnapshotResponse {
+	response := raftmsg.SnapshotResponse{MachineID: w.config.MachineID}
+	w.config.Logger.Infof("taking raft snapshot on request")
+	err := w.config.Raft.Snapshot().Error()
+	if err != nil && err != raft.ErrNothingNewToSnapshot {
+		w.config.Logger.Warningf("raft snapshot failed: %v", err)
+		response.Error = err.Error()
+		return response
+	}
+	size, err := jujuraft.ReadStorageSize(w.config.StorageDir, w.config.LogStore)
+	if err != nil {
+		response.Error = err.Error()
+		return response
+	}
+	response.LogBytes = size.LogBytes
+	response.LogEntries = size.LogEntries
+	response.SnapshotBytes = size.SnapshotBytes
+	return response
+}
+
+func (w *snapshotWorker) snapshotRequested(_ string, req raftmsg.SnapshotRequest, err error) {
+	if err != nil {
+		w.catacomb.Kill(errors.Annotate(err, "snapshot request callback failed"))
+		return
+	}
+	select {
+	case <-w.catacomb.Dying():
+	case w.requests <- req:
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raftsnapshotter_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/pubsub/centralhub"
+	raftmsg "github.com/juju/juju/pubsub/raft"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/raft/raftsnapshotter"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+	raft     *mockRaft
+	logStore *mockLogStore
+	hub      *pubsub.StructuredHub
+	config   raftsnapshotter.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.raft = &mockRaft{}
+	s.logStore = &mockLogStore{first: 3, last: 10}
+	s.hub = centralhub.New(names.NewMachineTag("23"))
+	dir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(dir, "logs"), make([]byte, 100), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	s.config = raftsnapshotter.Config{
+		Raft:       s.raft,
+		LogStore:   s.logStore,
+		Hub:        s.hub,
+		Logger:     loggo.GetLogger("raftsnapshotter_test"),
+		MachineID:  "23",
+		StorageDir: dir,
+	}
+}
+
+func (s *WorkerSuite) TestValidateErrors(c *gc.C) {
+	for i, test := range []struct {
+		f      func(*raftsnapshotter.Config)
+		expect string
+	}{{
+		func(cfg *raftsnapshotter.Config) { cfg.Raft = nil },
+		"nil Raft not valid",
+	}, {
+		func(cfg *raftsnapshotter.Config) { cfg.LogStore = nil },
+		"nil LogStore not valid",
+	}, {
+		func(cfg *raftsnapshotter.Config) { cfg.Hub = nil },
+		"nil Hub not valid",
+	}, {
+		func(cfg *raftsnapshotter.Config) { cfg.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(cfg *raftsnapshotter.Config) { cfg.MachineID = "" },
+		"empty MachineID not valid",
+	}, {
+		func(cfg *raftsnapshotter.Config) { cfg.StorageDir = "" },
+		"empty StorageDir not valid",
+	}} {
+		c.Logf("test #%d (%s)", i, test.expect)
+		config := s.config
+		test.f(&config)
+		w, err := raftsnapshotter.NewWorker(config)
+		c.Check(w, gc.IsNil)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *WorkerSuite) requestSnapshot(c *gc.C) raftmsg.SnapshotResponse {
+	responses := make(chan raftmsg.SnapshotResponse, 1)
+	unsubscribe, err := s.hub.Subscribe("test.response",
+		func(_ string, resp raftmsg.SnapshotResponse, err error) {
+			c.Check(err, jc.ErrorIsNil)
+			responses <- resp
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	defer unsubscribe()
+
+	_, err = s.hub.Publish(raftmsg.SnapshotRequestTopic, raftmsg.SnapshotRequest{
+		ResponseTopic: "test.response",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case resp := <-responses:
+		return resp
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for snapshot response")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) TestSnapshot(c *gc.C) {
+	w, err := raftsnapshotter.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	resp := s.requestSnapshot(c)
+	c.Assert(resp, jc.DeepEquals, raftmsg.SnapshotResponse{
+		MachineID:  "23",
+		LogBytes:   100,
+		LogEntries: 8,
+	})
+	s.raft.CheckCallNames(c, "Snapshot")
+}
+
+func (s *WorkerSuite) TestSnapshotNothingNew(c *gc.C) {
+	s.raft.SetErrors(raft.ErrNothingNewToSnapshot)
+	w, err := raftsnapshotter.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	resp := s.requestSnapshot(c)
+	c.Assert(resp.Error, gc.Equals, "")
+	c.Assert(resp.LogEntries, gc.Equals, uint64(8))
+}
+
+func (s *WorkerSuite) TestSnapshotError(c *gc.C) {
+	s.raft.SetErrors(errors.New("disk full"))
+	w, err := raftsnapshotter.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	resp := s.requestSnapshot(c)
+	c.Assert(resp, jc.DeepEquals, raftmsg.SnapshotResponse{
+		MachineID: "23",
+		Error:     "disk full",
+	})
+}
+
+type mockRaft struct {
+	testing.Stub
+}
+
+func (r *mockRaft) Snapshot() raft.SnapshotFuture {
+	r.MethodCall(r, "Snapshot")
+	return &mockSnapshotFuture{err: r.NextErr()}
+}
+
+type mockSnapshotFuture struct {
+	raft.SnapshotFuture
+	err error
+}
+
+func (f *mockSnapshotFuture) Error() error {
+	return f.err
+}
+
+type mockLogStore struct {
+	raft.LogStore
+	first, last uint64
+}
+
+func (s *mockLogStore) FirstIndex() (uint64, error) {
+	return s.first, nil
+}
+
+func (s *mockLogStore) LastIndex() (uint64, error) {
+	return s.last, nil
+}
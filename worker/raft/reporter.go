@@ -49,5 +49,17 @@ func (w *Worker) Report() map[string]interface{} {
 	}
 	out["cluster-config"] = config
 
+	storage := make(map[string]interface{})
+	if logStore, err := w.LogStore(); err != nil {
+		storage[dependency.KeyError] = err.Error()
+	} else if size, err := ReadStorageSize(w.config.StorageDir, logStore); err != nil {
+		storage[dependency.KeyError] = err.Error()
+	} else {
+		storage["log-size"] = humanize.IBytes(uint64(size.LogBytes))
+		storage["log-entries"] = size.LogEntries
+		storage["snapshots-size"] = humanize.IBytes(uint64(size.SnapshotBytes))
+	}
+	out["storage"] = storage
+
 	return out
 }
@@ -0,0 +1,70 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/errors"
+)
+
+// snapshotsDir is the directory, under the storage directory, in which
+// the file snapshot store keeps its snapshots.
+const snapshotsDir = "snapshots"
+
+// StorageSize describes the disk space used by a raft node.
+type StorageSize struct {
+	// LogBytes is the size of the log store file. The file doesn't
+	// shrink when entries are discarded after a snapshot, but the
+	// space is reused for new entries.
+	LogBytes int64
+
+	// LogEntries is the number of entries held in the log store.
+	LogEntries uint64
+
+	// SnapshotBytes is the total size of the retained snapshots.
+	SnapshotBytes int64
+}
+
+// ReadStorageSize returns the disk space used by the raft node whose
+// artifacts are stored in dir, and whose log store is logs.
+func ReadStorageSize(dir string, logs raft.LogStore) (StorageSize, error) {
+	var size StorageSize
+	info, err := os.Stat(filepath.Join(dir, "logs"))
+	if err != nil && !os.IsNotExist(err) {
+		return size, errors.Trace(err)
+	} else if err == nil {
+		size.LogBytes = info.Size()
+	}
+
+	err = filepath.Walk(filepath.Join(dir, snapshotsDir), func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) {
+			return nil
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		if !info.IsDir() {
+			size.SnapshotBytes += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return size, errors.Annotate(err, "reading snapshot sizes")
+	}
+
+	first, err := logs.FirstIndex()
+	if err != nil {
+		return size, errors.Annotate(err, "getting first log index")
+	}
+	last, err := logs.LastIndex()
+	if err != nil {
+		return size, errors.Annotate(err, "getting last log index")
+	}
+	if last > 0 {
+		size.LogEntries = last - first + 1
+	}
+	return size, nil
+}
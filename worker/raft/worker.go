@@ -130,6 +130,14 @@ type Config struct {
 	// to retain on disk. If zero, defaults to 2.
 	SnapshotRetention int
 
+	// SnapshotThreshold, if non-zero, will override the default
+	// number of log entries written between snapshots.
+	SnapshotThreshold uint64
+
+	// TrailingLogs, if non-zero, will override the default number
+	// of log entries kept after a snapshot.
+	TrailingLogs uint64
+
 	// PrometheusRegisterer is used to register the raft metrics.
 	PrometheusRegisterer prometheus.Registerer
 }
@@ -308,6 +316,13 @@ func (w *Worker) loop(raftConfig *raft.Config) (loopErr error) {
 	logStore := &syncLogStore{store: rawLogStore}
 	defer logStore.Close()
 
+	if w.config.PrometheusRegisterer != nil {
+		unregister := registerStorageMetrics(
+			w.config.PrometheusRegisterer, w.config.Logger, w.config.StorageDir, logStore,
+		)
+		defer unregister()
+	}
+
 	snapshotRetention := w.config.SnapshotRetention
 	if snapshotRetention == 0 {
 		snapshotRetention = defaultSnapshotRetention
@@ -393,6 +408,12 @@ func NewRaftConfig(config Config) (*raft.Config, error) {
 	maybeOverrideDuration(config.ElectionTimeout, &raftConfig.ElectionTimeout)
 	maybeOverrideDuration(config.HeartbeatTimeout, &raftConfig.HeartbeatTimeout)
 	maybeOverrideDuration(config.LeaderLeaseTimeout, &raftConfig.LeaderLeaseTimeout)
+	if config.SnapshotThreshold != 0 {
+		raftConfig.SnapshotThreshold = config.SnapshotThreshold
+	}
+	if config.TrailingLogs != 0 {
+		raftConfig.TrailingLogs = config.TrailingLogs
+	}
 
 	if err := raft.ValidateConfig(raftConfig); err != nil {
 		return nil, errors.Annotate(err, "validating raft config")
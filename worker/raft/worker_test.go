@@ -249,6 +249,30 @@ func (s *WorkerSuite) TestLogStore(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *WorkerSuite) TestStorageSize(c *gc.C) {
+	r := s.waitLeader(c)
+	for i := 0; i < 5; i++ {
+		c.Assert(r.Apply([]byte("command"), time.Minute).Error(), jc.ErrorIsNil)
+	}
+	logStore, err := s.worker.LogStore()
+	c.Assert(err, jc.ErrorIsNil)
+
+	size, err := raft.ReadStorageSize(s.config.StorageDir, logStore)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size.LogBytes, jc.GreaterThan, int64(0))
+	c.Assert(size.LogEntries, jc.GreaterThan, uint64(5))
+	c.Assert(size.SnapshotBytes, gc.Equals, int64(0))
+
+	c.Assert(r.Snapshot().Error(), jc.ErrorIsNil)
+	size, err = raft.ReadStorageSize(s.config.StorageDir, logStore)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(size.SnapshotBytes, jc.GreaterThan, int64(0))
+
+	report := s.worker.Report()
+	c.Assert(report["storage"], gc.FitsTypeOf, map[string]interface{}{})
+	c.Assert(report["storage"].(map[string]interface{})["log-entries"], gc.Equals, size.LogEntries)
+}
+
 func (s *WorkerSuite) newRaft(c *gc.C, id coreraft.ServerID) (
 	*coreraft.Raft, *coreraft.InmemTransport,
 ) {
@@ -0,0 +1,111 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sparemachines
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/config"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.sparemachines")
+
+// DefaultPeriod is the time between checks of the model's pool of
+// spare machines.
+const DefaultPeriod = time.Minute
+
+// Facade exposes the controller functionality needed by the spare
+// machines worker.
+type Facade interface {
+	ModelConfig() (*config.Config, error)
+	SpareMachines() ([]names.MachineTag, error)
+	AddSpareMachines(count int, cons constraints.Value) error
+	RemoveSpareMachines([]names.MachineTag) error
+}
+
+// Config holds the configuration and dependencies for a spare machines
+// worker.
+type Config struct {
+	// Facade is used to read the model config, and to add and remove
+	// spare machines.
+	Facade Facade
+
+	// NewTimer is used to schedule the checks.
+	NewTimer jworker.NewTimerFunc
+
+	// Period is the time between checks.
+	Period time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start a
+// spare machines worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.NewTimer == nil {
+		return errors.NotValidf("nil NewTimer")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that periodically keeps the number of
+// machines in the model's pool of spare machines at the model's
+// spare-machines config value. Spare machines leave the pool when units
+// are assigned to them, and the worker adds others, with the model's
+// spare-machine-constraints, to take their place.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	r := &replenisher{config: config}
+	replenish := func(stop <-chan struct{}) error {
+		return r.replenish()
+	}
+	return jworker.NewPeriodicWorker(replenish, config.Period, config.NewTimer), nil
+}
+
+type replenisher struct {
+	config Config
+}
+
+// replenish adds or removes spare machines so the pool holds the
+// configured number. The most recently added machines are removed
+// first.
+func (r *replenisher) replenish() error {
+	cfg, err := r.config.Facade.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	spares, err := r.config.Facade.SpareMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	wanted := cfg.SpareMachines()
+	switch {
+	case len(spares) < wanted:
+		cons, err := cfg.SpareMachineConstraints()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		n := wanted - len(spares)
+		logger.Infof("adding %d spare machines", n)
+		return errors.Annotate(r.config.Facade.AddSpareMachines(n, cons), "cannot add spare machines")
+	case len(spares) > wanted:
+		excess := spares[wanted:]
+		logger.Infof("removing %d spare machines", len(excess))
+		return errors.Annotate(r.config.Facade.RemoveSpareMachines(excess), "cannot remove spare machines")
+	}
+	return nil
+}
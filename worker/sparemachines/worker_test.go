@@ -0,0 +1,193 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package sparemachines_test
+
+import (
+	"sync"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/sparemachines"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	facade *mockFacade
+	ticks  chan time.Time
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &mockFacade{
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"spare-machines":            3,
+			"spare-machine-constraints": "mem=4G",
+		}),
+		changes: make(chan string, 1),
+	}
+	s.ticks = make(chan time.Time)
+}
+
+func (s *WorkerSuite) config() sparemachines.Config {
+	return sparemachines.Config{
+		Facade: s.facade,
+		NewTimer: func(time.Duration) jworker.PeriodicTimer {
+			return &fakeTimer{s.ticks}
+		},
+		Period: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	_, err := sparemachines.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.NewTimer = nil
+	_, err = sparemachines.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil NewTimer not valid")
+
+	config = s.config()
+	config.Period = 0
+	_, err = sparemachines.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestAddsSpareMachines(c *gc.C) {
+	s.facade.spares = []names.MachineTag{names.NewMachineTag("4")}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.replenish(c), gc.Equals, "add")
+	c.Assert(s.facade.added, gc.Equals, 2)
+	c.Assert(s.facade.cons, jc.DeepEquals, constraints.MustParse("mem=4G"))
+}
+
+func (s *WorkerSuite) TestRemovesExcessSpareMachines(c *gc.C) {
+	s.facade.spares = []names.MachineTag{
+		names.NewMachineTag("4"),
+		names.NewMachineTag("5"),
+		names.NewMachineTag("6"),
+		names.NewMachineTag("7"),
+		names.NewMachineTag("8"),
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.replenish(c), gc.Equals, "remove")
+	c.Assert(s.facade.removed, jc.DeepEquals, []names.MachineTag{
+		names.NewMachineTag("7"),
+		names.NewMachineTag("8"),
+	})
+}
+
+func (s *WorkerSuite) TestPoolFull(c *gc.C) {
+	s.facade.spares = []names.MachineTag{
+		names.NewMachineTag("4"),
+		names.NewMachineTag("5"),
+		names.NewMachineTag("6"),
+	}
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.tick(c)
+	// A second tick is only taken once the first check has finished.
+	s.tick(c)
+	workertest.CheckAlive(c, w)
+	select {
+	case change := <-s.facade.changes:
+		c.Fatalf("unexpected %s", change)
+	default:
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := sparemachines.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+func (s *WorkerSuite) tick(c *gc.C) {
+	select {
+	case s.ticks <- time.Time{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out triggering check")
+	}
+}
+
+// replenish triggers a check of the pool, and returns the change made
+// by it.
+func (s *WorkerSuite) replenish(c *gc.C) string {
+	s.tick(c)
+	select {
+	case change := <-s.facade.changes:
+		return change
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for spare machines to change")
+	}
+	return ""
+}
+
+type fakeTimer struct {
+	ticks chan time.Time
+}
+
+func (t *fakeTimer) Reset(time.Duration) bool {
+	return true
+}
+
+func (t *fakeTimer) CountDown() <-chan time.Time {
+	return t.ticks
+}
+
+type mockFacade struct {
+	mu      sync.Mutex
+	config  *config.Config
+	spares  []names.MachineTag
+	added   int
+	cons    constraints.Value
+	removed []names.MachineTag
+	changes chan string
+}
+
+func (f *mockFacade) ModelConfig() (*config.Config, error) {
+	return f.config, nil
+}
+
+func (f *mockFacade) SpareMachines() ([]names.MachineTag, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.spares, nil
+}
+
+func (f *mockFacade) AddSpareMachines(count int, cons constraints.Value) error {
+	f.mu.Lock()
+	f.added = count
+	f.cons = cons
+	f.mu.Unlock()
+	f.changes <- "add"
+	return nil
+}
+
+func (f *mockFacade) RemoveSpareMachines(tags []names.MachineTag) error {
+	f.mu.Lock()
+	f.removed = tags
+	f.mu.Unlock()
+	f.changes <- "remove"
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stagedupgrader_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/worker/stagedupgrader"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+	config stagedupgrader.ManifoldConfig
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = stagedupgrader.ManifoldConfig{
+		ClockName:    "clock",
+		StateName:    "state",
+		PollInterval: time.Minute,
+		NewBackend: func(*state.StatePool) stagedupgrader.Backend {
+			return nil
+		},
+		NewWorker: func(stagedupgrader.Config) (worker.Worker, error) {
+			return nil, errors.New("boom")
+		},
+	}
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	c.Check(stagedupgrader.Manifold(s.config).Inputs, jc.DeepEquals, []string{"clock", "state"})
+}
+
+func (s *ManifoldSuite) TestValid(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ManifoldSuite) TestMissingClockName(c *gc.C) {
+	s.config.ClockName = ""
+	s.checkNotValid(c, "empty ClockName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingStateName(c *gc.C) {
+	s.config.StateName = ""
+	s.checkNotValid(c, "empty StateName not valid")
+}
+
+func (s *ManifoldSuite) TestZeroPollInterval(c *gc.C) {
+	s.config.PollInterval = 0
+	s.checkNotValid(c, "non-positive PollInterval not valid")
+}
+
+func (s *ManifoldSuite) TestMissingNewBackend(c *gc.C) {
+	s.config.NewBackend = nil
+	s.checkNotValid(c, "nil NewBackend not valid")
+}
+
+func (s *ManifoldSuite) TestMissingNewWorker(c *gc.C) {
+	s.config.NewWorker = nil
+	s.checkNotValid(c, "nil NewWorker not valid")
+}
+
+func (s *ManifoldSuite) checkNotValid(c *gc.C, expect string) {
+	err := s.config.Validate()
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
@@ -0,0 +1,46 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stagedupgrader
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/version"
+
+	"github.com/juju/juju/state"
+)
+
+// NewBackend returns a Backend that upgrades the models in the given
+// state pool.
+func NewBackend(pool *state.StatePool) Backend {
+	return backendShim{
+		State: pool.SystemState(),
+		pool:  pool,
+	}
+}
+
+type backendShim struct {
+	*state.State
+	pool *state.StatePool
+}
+
+// UpgradeModel is part of the Backend interface.
+func (b backendShim) UpgradeModel(modelUUID string, target version.Number) error {
+	st, err := b.pool.Get(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer st.Release()
+	return errors.Trace(st.SetModelAgentVersion(target, false))
+}
+
+// ModelUpgradeHealth is part of the Backend interface.
+func (b backendShim) ModelUpgradeHealth(modelUUID string, target version.Number) (state.ModelUpgradeHealth, error) {
+	st, err := b.pool.Get(modelUUID)
+	if err != nil {
+		return state.ModelUpgradeHealth{}, errors.Trace(err)
+	}
+	defer st.Release()
+	health, err := st.ModelUpgradeHealth(target)
+	return health, errors.Trace(err)
+}
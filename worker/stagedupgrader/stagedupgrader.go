@@ -0,0 +1,204 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package stagedupgrader runs staged upgrades of the hosted models.
+// The models in each wave are upgraded together, and the upgrade only
+// moves on to the next wave once every agent in the wave is running the
+// target version and none are in error.
+package stagedupgrader
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/version"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.worker.stagedupgrader")
+
+// Backend defines the state methods needed by the staged upgrader.
+type Backend interface {
+	StagedUpgrade() (*state.StagedUpgrade, error)
+	WatchStagedUpgrade() state.NotifyWatcher
+	AdvanceStagedUpgrade(wave int) error
+	FailStagedUpgrade(wave int, message string) error
+
+	// UpgradeModel sets the agent version of the model with the given
+	// UUID. It does nothing if the model is already at that version.
+	UpgradeModel(modelUUID string, target version.Number) error
+
+	// ModelUpgradeHealth reports the agents in the model with the
+	// given UUID that hold up the upgrade.
+	ModelUpgradeHealth(modelUUID string, target version.Number) (state.ModelUpgradeHealth, error)
+}
+
+// Config holds the configuration for a staged upgrader worker.
+type Config struct {
+	Backend Backend
+	Clock   clock.Clock
+
+	// PollInterval is how often the health of the current wave is
+	// checked while an upgrade is running.
+	PollInterval time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start a
+// worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.PollInterval <= 0 {
+		return errors.NotValidf("non-positive PollInterval")
+	}
+	return nil
+}
+
+// NewWorker returns a worker which drives the controller's staged
+// upgrade, if there is one running.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &stagedUpgrader{config: config}
+	w.tomb.Go(w.loop)
+	return w, nil
+}
+
+type stagedUpgrader struct {
+	tomb   tomb.Tomb
+	config Config
+
+	mu     sync.Mutex
+	report map[string]interface{}
+}
+
+// Report is shown in the engine report.
+func (w *stagedUpgrader) Report() map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	result := make(map[string]interface{})
+	for k, v := range w.report {
+		result[k] = v
+	}
+	return result
+}
+
+func (w *stagedUpgrader) loop() error {
+	watcher := w.config.Backend.WatchStagedUpgrade()
+	defer worker.Stop(watcher)
+
+	var poll <-chan time.Time
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return tomb.ErrDying
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.New("staged upgrade watcher closed")
+			}
+		case <-poll:
+		}
+		running, err := w.step()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		poll = nil
+		if running {
+			poll = w.config.Clock.After(w.config.PollInterval)
+		}
+	}
+}
+
+// step upgrades the models in the current wave and checks whether the
+// wave can be completed. It returns whether the upgrade is still
+// running.
+func (w *stagedUpgrader) step() (bool, error) {
+	backend := w.config.Backend
+	upgrade, err := backend.StagedUpgrade()
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	w.setReport(upgrade)
+	if upgrade.Finished() {
+		return false, nil
+	}
+
+	waveNum := upgrade.Wave + 1
+	models := upgrade.Waves[upgrade.Wave]
+	for _, uuid := range models {
+		if err := backend.UpgradeModel(uuid, upgrade.Target); err != nil {
+			return false, w.fail(upgrade, fmt.Sprintf(
+				"wave %d: cannot upgrade model %q: %v", waveNum, uuid, err))
+		}
+	}
+
+	var waiting []string
+	for _, uuid := range models {
+		health, err := backend.ModelUpgradeHealth(uuid, upgrade.Target)
+		if err != nil {
+			return false, errors.Annotatef(err, "checking health of model %q", uuid)
+		}
+		if len(health.InError) > 0 {
+			return false, w.fail(upgrade, fmt.Sprintf(
+				"wave %d: model %q has %s", waveNum, uuid, health))
+		}
+		if !health.Healthy() {
+			waiting = append(waiting, fmt.Sprintf("model %q has %s", uuid, health))
+		}
+	}
+
+	if len(waiting) == 0 {
+		logger.Infof("staged upgrade to %s: wave %d of %d completed", upgrade.Target, waveNum, len(upgrade.Waves))
+		// The watcher fires once the upgrade has advanced.
+		return true, errors.Trace(backend.AdvanceStagedUpgrade(upgrade.Wave))
+	}
+	if w.config.Clock.Now().Sub(upgrade.WaveStarted) > upgrade.WaveTimeout {
+		return false, w.fail(upgrade, fmt.Sprintf(
+			"wave %d timed out after %v: %s", waveNum, upgrade.WaveTimeout, strings.Join(waiting, "; ")))
+	}
+	logger.Debugf("staged upgrade to %s: waiting for wave %d: %s", upgrade.Target, waveNum, strings.Join(waiting, "; "))
+	return true, nil
+}
+
+func (w *stagedUpgrader) fail(upgrade *state.StagedUpgrade, message string) error {
+	logger.Errorf("staged upgrade to %s failed: %s", upgrade.Target, message)
+	return errors.Trace(w.config.Backend.FailStagedUpgrade(upgrade.Wave, message))
+}
+
+func (w *stagedUpgrader) setReport(upgrade *state.StagedUpgrade) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.report = map[string]interface{}{
+		"target": upgrade.Target.String(),
+		"status": string(upgrade.Status),
+		"wave":   fmt.Sprintf("%d/%d", upgrade.Wave+1, len(upgrade.Waves)),
+	}
+	if upgrade.Message != "" {
+		w.report["message"] = upgrade.Message
+	}
+}
+
+// Kill implements Worker.Kill().
+func (w *stagedUpgrader) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait().
+func (w *stagedUpgrader) Wait() error {
+	return w.tomb.Wait()
+}
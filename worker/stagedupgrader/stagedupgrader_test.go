@@ -0,0 +1,225 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stagedupgrader_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/stagedupgrader"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	clock   *testclock.Clock
+	backend *fakeBackend
+	target  version.Number
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.target = version.MustParse("2.6.1")
+	s.backend = newFakeBackend()
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := stagedupgrader.NewWorker(stagedupgrader.Config{
+		Backend:      s.backend,
+		Clock:        s.clock,
+		PollInterval: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) setUpgrade() {
+	s.backend.setUpgrade(&state.StagedUpgrade{
+		Target:      s.target,
+		Waves:       [][]string{{"uuid-a", "uuid-b"}, {"uuid-c"}},
+		WaveTimeout: time.Hour,
+		Status:      state.StagedUpgradeRunning,
+		WaveStarted: s.clock.Now(),
+	})
+}
+
+func (s *WorkerSuite) waitCall(c *gc.C, name string) {
+	for {
+		select {
+		case call := <-s.backend.calls:
+			if call == name {
+				return
+			}
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for %s", name)
+		}
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	_, err := stagedupgrader.NewWorker(stagedupgrader.Config{
+		Backend: s.backend,
+		Clock:   s.clock,
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive PollInterval not valid")
+}
+
+func (s *WorkerSuite) TestNoUpgrade(c *gc.C) {
+	w := s.startWorker(c)
+	s.waitCall(c, "StagedUpgrade")
+	workertest.CleanKill(c, w)
+	s.backend.stub.CheckCallNames(c, "StagedUpgrade")
+}
+
+func (s *WorkerSuite) TestHealthyWaveAdvances(c *gc.C) {
+	s.setUpgrade()
+	s.startWorker(c)
+	s.waitCall(c, "AdvanceStagedUpgrade")
+
+	s.backend.stub.CheckCalls(c, []testing.StubCall{
+		{"StagedUpgrade", nil},
+		{"UpgradeModel", []interface{}{"uuid-a", s.target}},
+		{"UpgradeModel", []interface{}{"uuid-b", s.target}},
+		{"ModelUpgradeHealth", []interface{}{"uuid-a", s.target}},
+		{"ModelUpgradeHealth", []interface{}{"uuid-b", s.target}},
+		{"AdvanceStagedUpgrade", []interface{}{0}},
+	})
+}
+
+func (s *WorkerSuite) TestWaitsForAgents(c *gc.C) {
+	s.setUpgrade()
+	s.backend.health["uuid-b"] = state.ModelUpgradeHealth{NotUpgraded: []string{"machine-0"}}
+	s.startWorker(c)
+	s.waitCall(c, "ModelUpgradeHealth")
+	s.waitCall(c, "ModelUpgradeHealth")
+
+	s.backend.setHealth("uuid-b", state.ModelUpgradeHealth{})
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitCall(c, "AdvanceStagedUpgrade")
+	s.backend.stub.CheckCall(c, 10, "AdvanceStagedUpgrade", 0)
+}
+
+func (s *WorkerSuite) TestAgentInErrorFails(c *gc.C) {
+	s.setUpgrade()
+	s.backend.health["uuid-a"] = state.ModelUpgradeHealth{InError: []string{"unit-foo-0"}}
+	s.startWorker(c)
+	s.waitCall(c, "FailStagedUpgrade")
+
+	s.backend.stub.CheckCall(c, 4, "FailStagedUpgrade", 0,
+		`wave 1: model "uuid-a" has 1 agents in error: [unit-foo-0]`)
+}
+
+func (s *WorkerSuite) TestUpgradeModelErrorFails(c *gc.C) {
+	s.setUpgrade()
+	s.backend.stub.SetErrors(nil, errors.New("boom"))
+	s.startWorker(c)
+	s.waitCall(c, "FailStagedUpgrade")
+
+	s.backend.stub.CheckCall(c, 2, "FailStagedUpgrade", 0,
+		`wave 1: cannot upgrade model "uuid-a": boom`)
+}
+
+func (s *WorkerSuite) TestWaveTimeout(c *gc.C) {
+	s.setUpgrade()
+	s.backend.health["uuid-b"] = state.ModelUpgradeHealth{NotUpgraded: []string{"machine-0"}}
+	s.startWorker(c)
+	s.waitCall(c, "ModelUpgradeHealth")
+	s.waitCall(c, "ModelUpgradeHealth")
+
+	c.Assert(s.clock.WaitAdvance(time.Hour+time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitCall(c, "FailStagedUpgrade")
+	s.backend.stub.CheckCall(c, 10, "FailStagedUpgrade", 0,
+		`wave 1 timed out after 1h0m0s: model "uuid-b" has 1 agents not upgraded: [machine-0]`)
+}
+
+type fakeBackend struct {
+	stub    testing.Stub
+	calls   chan string
+	changes chan struct{}
+
+	mu      sync.Mutex
+	upgrade *state.StagedUpgrade
+	health  map[string]state.ModelUpgradeHealth
+}
+
+func newFakeBackend() *fakeBackend {
+	return &fakeBackend{
+		calls:   make(chan string, 100),
+		changes: make(chan struct{}, 1),
+		health:  make(map[string]state.ModelUpgradeHealth),
+	}
+}
+
+func (b *fakeBackend) setUpgrade(upgrade *state.StagedUpgrade) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.upgrade = upgrade
+}
+
+func (b *fakeBackend) setHealth(uuid string, health state.ModelUpgradeHealth) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.health[uuid] = health
+}
+
+func (b *fakeBackend) call(name string, args ...interface{}) error {
+	b.stub.MethodCall(b, name, args...)
+	b.calls <- name
+	return b.stub.NextErr()
+}
+
+func (b *fakeBackend) StagedUpgrade() (*state.StagedUpgrade, error) {
+	if err := b.call("StagedUpgrade"); err != nil {
+		return nil, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.upgrade == nil {
+		return nil, errors.NotFoundf("staged upgrade")
+	}
+	upgrade := *b.upgrade
+	return &upgrade, nil
+}
+
+func (b *fakeBackend) WatchStagedUpgrade() state.NotifyWatcher {
+	b.changes <- struct{}{}
+	return watchertest.NewNotifyWatcher(b.changes)
+}
+
+func (b *fakeBackend) AdvanceStagedUpgrade(wave int) error {
+	return b.call("AdvanceStagedUpgrade", wave)
+}
+
+func (b *fakeBackend) FailStagedUpgrade(wave int, message string) error {
+	return b.call("FailStagedUpgrade", wave, message)
+}
+
+func (b *fakeBackend) UpgradeModel(modelUUID string, target version.Number) error {
+	return b.call("UpgradeModel", modelUUID, target)
+}
+
+func (b *fakeBackend) ModelUpgradeHealth(modelUUID string, target version.Number) (state.ModelUpgradeHealth, error) {
+	if err := b.call("ModelUpgradeHealth", modelUUID, target); err != nil {
+		return state.ModelUpgradeHealth{}, err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.health[modelUUID], nil
+}
@@ -16,6 +16,7 @@ import (
 	"gopkg.in/tomb.v2"
 
 	coreagent "github.com/juju/juju/agent"
+	"github.com/juju/juju/provider/external"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/statemetrics"
 	"github.com/juju/juju/wrench"
@@ -173,6 +174,10 @@ func (w *stateWorker) loop() error {
 		defer w.prometheusRegisterer.Unregister(collector)
 	}
 
+	// Out-of-tree providers are registered for as long as the
+	// controller's state is available.
+	defer external.RegisterProviders(controllerConfig.ExternalProviders())()
+
 	w.setStatePool(pool)
 	defer w.setStatePool(nil)
 
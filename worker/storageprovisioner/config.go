@@ -26,6 +26,10 @@ type Config struct {
 	Status           StatusSetter
 	Clock            clock.Clock
 	CloudCallContext environscontext.ProviderCallContext
+
+	// Retries, if non-nil, is used to learn about requests to retry
+	// failed storage operations immediately.
+	Retries RetryRequester
 }
 
 // Validate returns an error if the config cannot be relied upon to start a worker.
@@ -7,6 +7,7 @@ import "gopkg.in/juju/worker.v1"
 
 var (
 	NewManagedFilesystemSource = &newManagedFilesystemSource
+	PlanByType                 = &planByType
 )
 
 func StorageWorker(parent worker.Worker, appName string) (worker.Worker, bool) {
@@ -145,6 +145,7 @@ func updatePendingFilesystem(ctx *context, params storage.FilesystemParams) {
 
 func removePendingFilesystem(ctx *context, tag names.FilesystemTag) {
 	delete(ctx.incompleteFilesystemParams, tag)
+	delete(ctx.failedOperations, tag)
 	ctx.schedule.Remove(tag)
 }
 
@@ -193,6 +194,7 @@ func updatePendingFilesystemAttachment(
 // there.
 func removePendingFilesystemAttachment(ctx *context, id params.MachineStorageId) {
 	delete(ctx.incompleteFilesystemAttachmentParams, id)
+	delete(ctx.failedOperations, id)
 	ctx.schedule.Remove(id)
 }
 
@@ -510,5 +512,6 @@ func filesystemAttachmentParamsFromParams(in params.FilesystemAttachmentParams)
 		Filesystem:   filesystemTag,
 		FilesystemId: in.FilesystemId,
 		Path:         in.MountPoint,
+		Attributes:   in.Attributes,
 	}, nil
 }
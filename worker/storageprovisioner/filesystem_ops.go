@@ -87,7 +87,7 @@ func createFilesystems(ctx *context, ops map[names.FilesystemTag]*createFilesyst
 			filesystems = append(filesystems, *result.Filesystem)
 		}
 	}
-	scheduleOperations(ctx, reschedule...)
+	rescheduleOperations(ctx, reschedule, statuses)
 	setStatus(ctx, statuses)
 	if len(filesystems) == 0 {
 		return nil
@@ -177,7 +177,7 @@ func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFile
 			filesystemAttachments = append(filesystemAttachments, *result.FilesystemAttachment)
 		}
 	}
-	scheduleOperations(ctx, reschedule...)
+	rescheduleOperations(ctx, reschedule, statuses)
 	setStatus(ctx, statuses)
 	if err := setFilesystemAttachmentInfo(ctx, filesystemAttachments); err != nil {
 		return errors.Trace(err)
@@ -261,7 +261,7 @@ func removeFilesystems(ctx *context, ops map[names.FilesystemTag]*removeFilesyst
 			}
 		}
 	}
-	scheduleOperations(ctx, reschedule...)
+	rescheduleOperations(ctx, reschedule, statuses)
 	setStatus(ctx, statuses)
 	if err := removeEntities(ctx, remove); err != nil {
 		return errors.Annotate(err, "removing filesystems from state")
@@ -349,7 +349,7 @@ func detachFilesystems(ctx *context, ops map[params.MachineStorageId]*detachFile
 			remove = append(remove, id)
 		}
 	}
-	scheduleOperations(ctx, reschedule...)
+	rescheduleOperations(ctx, reschedule, statuses)
 	setStatus(ctx, statuses)
 	if err := removeAttachments(ctx, remove); err != nil {
 		return errors.Annotate(err, "removing attachments from state")
@@ -74,6 +74,18 @@ func (s *Schedule) Remove(key interface{}) {
 	}
 }
 
+// Reschedule changes the time of the item corresponding to the specified
+// key, and reports whether the item exists.
+func (s *Schedule) Reschedule(key interface{}, t time.Time) bool {
+	item, ok := s.m[key]
+	if !ok {
+		return false
+	}
+	item.t = t
+	heap.Fix(&s.items, item.i)
+	return true
+}
+
 type scheduleItems []*scheduleItem
 
 type scheduleItem struct {
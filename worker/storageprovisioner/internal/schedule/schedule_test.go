@@ -99,6 +99,31 @@ func (*scheduleSuite) TestRemoveKeyNotFound(c *gc.C) {
 	s.Remove("0") // does not explode
 }
 
+func (*scheduleSuite) TestReschedule(c *gc.C) {
+	clock := testclock.NewClock(time.Time{})
+	now := clock.Now()
+	s := schedule.NewSchedule(clock)
+
+	s.Add("k0", "v0", now.Add(3*time.Second))
+	s.Add("k1", "v1", now.Add(2*time.Second))
+	c.Assert(s.Reschedule("k0", now.Add(time.Second)), jc.IsTrue)
+
+	clock.Advance(time.Second) // T+1
+	assertReady(c, s, clock, "v0")
+
+	c.Assert(s.Reschedule("k1", now.Add(4*time.Second)), jc.IsTrue)
+	clock.Advance(2 * time.Second) // T+3
+	assertReady(c, s, clock /* nothing */)
+
+	clock.Advance(time.Second) // T+4
+	assertReady(c, s, clock, "v1")
+}
+
+func (*scheduleSuite) TestRescheduleKeyNotFound(c *gc.C) {
+	s := schedule.NewSchedule(testclock.NewClock(time.Time{}))
+	c.Assert(s.Reschedule("0", time.Time{}), jc.IsFalse)
+}
+
 func assertNextOp(c *gc.C, s *schedule.Schedule, clock *testclock.Clock, d time.Duration) {
 	next := s.Next()
 	c.Assert(next, gc.NotNil)
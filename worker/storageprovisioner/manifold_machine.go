@@ -59,6 +59,7 @@ func (config MachineManifoldConfig) newWorker(a agent.Agent, apiCaller base.APIC
 		Registry:         provider.CommonStorageProviders(),
 		Machines:         api,
 		Status:           api,
+		Retries:          api,
 		Clock:            config.Clock,
 		CloudCallContext: common.NewCloudCallContext(credentialAPI, nil),
 	})
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/storageprovisioner"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plugin"
 	"github.com/juju/juju/worker/common"
 )
 
@@ -46,6 +47,9 @@ func ModelManifold(config ModelManifoldConfig) dependency.Manifold {
 			if err := context.Get(config.StorageRegistryName, &registry); err != nil {
 				return nil, errors.Trace(err)
 			}
+			// The storage plugins registered for the model's cloud are
+			// run by this worker.
+			registry = storage.ChainedProviderRegistry{registry, plugin.CloudRegistry(registry)}
 
 			api, err := storageprovisioner.NewState(apiCaller)
 			if err != nil {
@@ -67,6 +71,7 @@ func ModelManifold(config ModelManifoldConfig) dependency.Manifold {
 				Registry:         registry,
 				Machines:         api,
 				Status:           api,
+				Retries:          api,
 				Clock:            clock,
 				CloudCallContext: common.NewCloudCallContext(credentialAPI, nil),
 			})
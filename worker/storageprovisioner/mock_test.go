@@ -147,6 +147,9 @@ type mockVolumeAccessor struct {
 	setVolumeInfo               func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo     func([]params.VolumeAttachment) ([]params.ErrorResult, error)
 	createVolumeAttachmentPlans func([]params.VolumeAttachmentPlan) ([]params.ErrorResult, error)
+	volumeAttachmentPlans       func([]params.MachineStorageId) ([]params.VolumeAttachmentPlanResult, error)
+	setVolumeAttachmentPlanInfo func([]params.VolumeAttachmentPlan) ([]params.ErrorResult, error)
+	removeVolumeAttachmentPlan  func([]params.MachineStorageId) ([]params.ErrorResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -305,14 +308,23 @@ func (v *mockVolumeAccessor) CreateVolumeAttachmentPlans(volumeAttachmentPlans [
 }
 
 func (v *mockVolumeAccessor) RemoveVolumeAttachmentPlan(machineIds []params.MachineStorageId) ([]params.ErrorResult, error) {
+	if v.removeVolumeAttachmentPlan != nil {
+		return v.removeVolumeAttachmentPlan(machineIds)
+	}
 	return make([]params.ErrorResult, len(machineIds)), nil
 }
 
 func (v *mockVolumeAccessor) SetVolumeAttachmentPlanBlockInfo(volumeAttachmentPlans []params.VolumeAttachmentPlan) ([]params.ErrorResult, error) {
+	if v.setVolumeAttachmentPlanInfo != nil {
+		return v.setVolumeAttachmentPlanInfo(volumeAttachmentPlans)
+	}
 	return make([]params.ErrorResult, len(volumeAttachmentPlans)), nil
 }
 
-func (v *mockVolumeAccessor) VolumeAttachmentPlans([]params.MachineStorageId) ([]params.VolumeAttachmentPlanResult, error) {
+func (v *mockVolumeAccessor) VolumeAttachmentPlans(ids []params.MachineStorageId) ([]params.VolumeAttachmentPlanResult, error) {
+	if v.volumeAttachmentPlans != nil {
+		return v.volumeAttachmentPlans(ids)
+	}
 	return []params.VolumeAttachmentPlanResult{}, nil
 }
 
@@ -916,3 +928,42 @@ func (m *mockStatusSetter) SetStatus(args []params.EntityStatusArgs) error {
 	m.args = append(m.args, args...)
 	return nil
 }
+
+type mockRetryRequester struct {
+	watcher        *mockNotifyWatcher
+	retryRequested func([]names.Tag) ([]params.BoolResult, error)
+}
+
+func newMockRetryRequester() *mockRetryRequester {
+	return &mockRetryRequester{watcher: newMockNotifyWatcher()}
+}
+
+func (m *mockRetryRequester) WatchRetryRequests() (watcher.NotifyWatcher, error) {
+	return m.watcher, nil
+}
+
+func (m *mockRetryRequester) RetryRequested(tags []names.Tag) ([]params.BoolResult, error) {
+	if m.retryRequested != nil {
+		return m.retryRequested(tags)
+	}
+	return make([]params.BoolResult, len(tags)), nil
+}
+
+type mockPlan struct {
+	attachVolume func(map[string]string) (storage.BlockDevice, error)
+	detachVolume func(map[string]string) error
+}
+
+func (p *mockPlan) AttachVolume(volumeInfo map[string]string) (storage.BlockDevice, error) {
+	if p.attachVolume != nil {
+		return p.attachVolume(volumeInfo)
+	}
+	return storage.BlockDevice{}, nil
+}
+
+func (p *mockPlan) DetachVolume(volumeInfo map[string]string) error {
+	if p.detachVolume != nil {
+		return p.detachVolume(volumeInfo)
+	}
+	return nil
+}
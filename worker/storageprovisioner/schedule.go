@@ -3,7 +3,15 @@
 
 package storageprovisioner
 
-import "time"
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+)
 
 // minRetryDelay is the minimum delay to apply
 // to operation retries; this does not apply to
@@ -33,6 +41,100 @@ func scheduleOperations(ctx *context, ops ...scheduleOp) {
 	}
 }
 
+// rescheduleOperations reschedules the given operations after they
+// failed, and records in the statuses of their storage entities how
+// many attempts have been made at them and when they will next be
+// retried. The operations are tracked until they are next executed,
+// so that users may request they be retried sooner.
+func rescheduleOperations(ctx *context, ops []scheduleOp, statuses []params.EntityStatusArgs) {
+	if len(ops) == 0 {
+		return
+	}
+	now := ctx.config.Clock.Now()
+	data := make(map[string]map[string]interface{})
+	for _, op := range ops {
+		k := op.key()
+		next := now.Add(op.delay())
+		ctx.schedule.Add(k, op, next)
+		ctx.failedOperations[k] = op
+		tag, err := operationStatusTag(k)
+		if err != nil {
+			logger.Errorf("%v", err)
+			continue
+		}
+		data[tag.String()] = map[string]interface{}{
+			storage.StatusDataAttempts:  op.failed(),
+			storage.StatusDataNextRetry: next.UTC().Format(time.RFC3339),
+		}
+	}
+	for i, s := range statuses {
+		if d, ok := data[s.Tag]; ok {
+			statuses[i].Data = d
+		}
+	}
+}
+
+// retryRequestedOperations immediately retries the failed operations
+// on the storage entities whose retry users have requested.
+func retryRequestedOperations(ctx *context) error {
+	if len(ctx.failedOperations) == 0 {
+		return nil
+	}
+	keys := make([]interface{}, 0, len(ctx.failedOperations))
+	tags := make([]names.Tag, 0, len(ctx.failedOperations))
+	for k := range ctx.failedOperations {
+		tag, err := operationStatusTag(k)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		keys = append(keys, k)
+		tags = append(tags, tag)
+	}
+	results, err := ctx.config.Retries.RetryRequested(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting storage retry requests")
+	}
+	now := ctx.config.Clock.Now()
+	for i, result := range results {
+		if result.Error != nil {
+			if params.IsCodeNotFound(result.Error) {
+				// The entity has been removed; its operation
+				// will be dropped from the schedule too.
+				continue
+			}
+			return errors.Annotatef(result.Error, "getting retry request for %s", names.ReadableString(tags[i]))
+		}
+		if !result.Result {
+			continue
+		}
+		k := keys[i]
+		op := ctx.failedOperations[k]
+		delete(ctx.failedOperations, k)
+		if !ctx.schedule.Reschedule(k, now) {
+			continue
+		}
+		logger.Debugf("retrying operation on %s on request", names.ReadableString(tags[i]))
+		op.reset()
+	}
+	return nil
+}
+
+// operationStatusTag returns the tag of the storage entity whose status
+// reflects the progress of the operation with the given key.
+func operationStatusTag(key interface{}) (names.Tag, error) {
+	switch key := key.(type) {
+	case names.Tag:
+		return key, nil
+	case params.MachineStorageId:
+		tag, err := names.ParseTag(key.AttachmentTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return tag, nil
+	}
+	return nil, errors.Errorf("unexpected operation key %v", key)
+}
+
 // scheduleOp is an interface implemented by schedule
 // operations.
 type scheduleOp interface {
@@ -43,13 +145,24 @@ type scheduleOp interface {
 	// delay is the amount of time to delay
 	// before next executing the operation.
 	delay() time.Duration
+
+	// failed records that an attempt at the
+	// operation failed, and returns the number
+	// of failed attempts.
+	failed() int
+
+	// reset resets the delay, after the operation
+	// has been rescheduled to be executed now.
+	reset()
 }
 
 // exponentialBackoff is a type that can be embedded to implement the
 // delay() method of scheduleOp, providing truncated binary exponential
-// backoff for operations that may be rescheduled.
+// backoff for operations that may be rescheduled. It also counts the
+// failed attempts at the operations.
 type exponentialBackoff struct {
-	d time.Duration
+	d        time.Duration
+	failures int
 }
 
 func (s *exponentialBackoff) delay() time.Duration {
@@ -64,3 +177,12 @@ func (s *exponentialBackoff) delay() time.Duration {
 	}
 	return current
 }
+
+func (s *exponentialBackoff) failed() int {
+	s.failures++
+	return s.failures
+}
+
+func (s *exponentialBackoff) reset() {
+	s.d = minRetryDelay
+}
@@ -173,6 +173,18 @@ type StatusSetter interface {
 	SetStatus([]params.EntityStatusArgs) error
 }
 
+// RetryRequester defines an interface used to learn which storage
+// entities users have requested the failed operations on be retried.
+type RetryRequester interface {
+	// WatchRetryRequests returns a watcher that notifies when
+	// retries of failed storage operations may have been requested.
+	WatchRetryRequests() (watcher.NotifyWatcher, error)
+
+	// RetryRequested reports whether retries of the failed operations
+	// on each of the specified volumes and filesystems were requested.
+	RetryRequested([]names.Tag) ([]params.BoolResult, error)
+}
+
 // NewStorageProvisioner returns a Worker which manages
 // provisioning (deprovisioning), and attachment (detachment)
 // of first-class volumes and filesystems.
@@ -222,6 +234,7 @@ func (w *storageProvisioner) loop() error {
 		volumeAttachmentPlansChanges watcher.MachineStorageIdsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
 		machineBlockDevicesChanges   <-chan struct{}
+		retryRequestsChanges         watcher.NotifyChannel
 	)
 	machineChanges := make(chan names.MachineTag)
 
@@ -265,6 +278,7 @@ func (w *storageProvisioner) loop() error {
 		incompleteFilesystemParams:           make(map[names.FilesystemTag]storage.FilesystemParams),
 		incompleteFilesystemAttachmentParams: make(map[params.MachineStorageId]storage.FilesystemAttachmentParams),
 		pendingVolumeBlockDevices:            names.NewSet(),
+		failedOperations:                     make(map[interface{}]scheduleOp),
 	}
 	ctx.managedFilesystemSource = newManagedFilesystemSource(
 		ctx.volumeBlockDevices, ctx.filesystems,
@@ -314,6 +328,23 @@ func (w *storageProvisioner) loop() error {
 	}
 	filesystemAttachmentsChanges = filesystemAttachmentsWatcher.Changes()
 
+	if w.config.Retries != nil {
+		retryRequestsWatcher, err := w.config.Retries.WatchRetryRequests()
+		if errors.IsNotSupported(err) {
+			// The controller is too old to record retry
+			// requests; failed operations are only retried
+			// after backing off.
+			logger.Debugf("not watching storage retry requests: %v", err)
+		} else if err != nil {
+			return errors.Annotate(err, "watching storage retry requests")
+		} else {
+			if err := w.catacomb.Add(retryRequestsWatcher); err != nil {
+				return errors.Trace(err)
+			}
+			retryRequestsChanges = retryRequestsWatcher.Changes()
+		}
+	}
+
 	for {
 
 		// Check if block devices need to be refreshed.
@@ -366,6 +397,13 @@ func (w *storageProvisioner) loop() error {
 			if err := machineBlockDevicesChanged(&ctx); err != nil {
 				return errors.Trace(err)
 			}
+		case _, ok := <-retryRequestsChanges:
+			if !ok {
+				return errors.New("storage retry requests watcher closed")
+			}
+			if err := retryRequestedOperations(&ctx); err != nil {
+				return errors.Trace(err)
+			}
 		case machineTag := <-machineChanges:
 			if err := refreshMachine(&ctx, machineTag); err != nil {
 				return errors.Trace(err)
@@ -393,6 +431,7 @@ func processSchedule(ctx *context) error {
 	for _, item := range ready {
 		op := item.(scheduleOp)
 		key := op.key()
+		delete(ctx.failedOperations, key)
 		switch op := op.(type) {
 		case *createVolumeOp:
 			createVolumeOps[key.(names.VolumeTag)] = op
@@ -487,6 +526,11 @@ type context struct {
 	// schedule is the schedule of storage operations.
 	schedule *schedule.Schedule
 
+	// failedOperations contains the operations in the schedule that
+	// failed, and are waiting to be retried. They are retried early
+	// if users request it.
+	failedOperations map[interface{}]scheduleOp
+
 	// incompleteVolumeParams contains incomplete parameters for volumes.
 	//
 	// Volume parameters are incomplete when they lack information about
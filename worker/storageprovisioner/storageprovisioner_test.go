@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/plans/common"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/storageprovisioner"
 )
@@ -267,15 +268,94 @@ func (s *storageProvisionerSuite) TestCreateVolumeRetry(c *gc.C) {
 	})
 
 	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
-		{Tag: "volume-1", Status: "pending", Info: "badness"},
-		{Tag: "volume-1", Status: "pending", Info: "badness"},
-		{Tag: "volume-1", Status: "pending", Info: "badness"},
-		{Tag: "volume-1", Status: "pending", Info: "badness"},
-		{Tag: "volume-1", Status: "pending", Info: "badness"},
-		{Tag: "volume-1", Status: "pending", Info: "badness"},
-		{Tag: "volume-1", Status: "pending", Info: "badness"},
-		{Tag: "volume-1", Status: "pending", Info: "badness"},
-		{Tag: "volume-1", Status: "pending", Info: "badness"},
+		{Tag: "volume-1", Status: "pending", Info: "badness", Data: retryData(createVolumeTimes, 1)},
+		{Tag: "volume-1", Status: "pending", Info: "badness", Data: retryData(createVolumeTimes, 2)},
+		{Tag: "volume-1", Status: "pending", Info: "badness", Data: retryData(createVolumeTimes, 3)},
+		{Tag: "volume-1", Status: "pending", Info: "badness", Data: retryData(createVolumeTimes, 4)},
+		{Tag: "volume-1", Status: "pending", Info: "badness", Data: retryData(createVolumeTimes, 5)},
+		{Tag: "volume-1", Status: "pending", Info: "badness", Data: retryData(createVolumeTimes, 6)},
+		{Tag: "volume-1", Status: "pending", Info: "badness", Data: retryData(createVolumeTimes, 7)},
+		{Tag: "volume-1", Status: "pending", Info: "badness", Data: retryData(createVolumeTimes, 8)},
+		{Tag: "volume-1", Status: "pending", Info: "badness", Data: retryData(createVolumeTimes, 9)},
+		{Tag: "volume-1", Status: "attaching", Info: ""},
+	})
+}
+
+func (s *storageProvisionerSuite) TestCreateVolumeRetryRequested(c *gc.C) {
+	volumeInfoSet := make(chan interface{})
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionedMachines["machine-1"] = instance.Id("already-provisioned-1")
+	volumeAccessor.setVolumeInfo = func(volumes []params.Volume) ([]params.ErrorResult, error) {
+		defer close(volumeInfoSet)
+		return make([]params.ErrorResult, len(volumes)), nil
+	}
+
+	// Only operations that are due now are executed: retries
+	// are never due, unless they are requested.
+	clock := &mockClock{}
+	clock.onAfter = func(d time.Duration) <-chan time.Time {
+		ch := make(chan time.Time, 1)
+		if d <= 0 {
+			ch <- clock.now
+		}
+		return ch
+	}
+
+	var createVolumeCalls int
+	s.provider.createVolumesFunc = func(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+		createVolumeCalls++
+		if createVolumeCalls == 1 {
+			return []storage.CreateVolumesResult{{Error: errors.New("badness")}}, nil
+		}
+		return []storage.CreateVolumesResult{{
+			Volume: &storage.Volume{Tag: args[0].Tag},
+		}}, nil
+	}
+
+	statusSet := make(chan interface{}, 2)
+	statusSetter := &mockStatusSetter{}
+	statusSetter.setStatus = func(args []params.EntityStatusArgs) error {
+		statusSetter.args = append(statusSetter.args, args...)
+		statusSet <- args
+		return nil
+	}
+
+	retryRequested := make(chan interface{}, 1)
+	retries := newMockRetryRequester()
+	retries.retryRequested = func(tags []names.Tag) ([]params.BoolResult, error) {
+		retryRequested <- tags
+		return []params.BoolResult{{Result: true}}, nil
+	}
+
+	args := &workerArgs{
+		volumes:      volumeAccessor,
+		clock:        clock,
+		registry:     s.registry,
+		statusSetter: statusSetter,
+		retries:      retries,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.attachmentsWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-1", AttachmentTag: "volume-1",
+	}}
+	volumeAccessor.volumesWatcher.changes <- []string{"1"}
+	waitChannel(c, statusSet, "waiting for volume status to be set")
+
+	retries.watcher.changes <- struct{}{}
+	tags := waitChannel(c, retryRequested, "waiting for retry requests to be checked")
+	c.Assert(tags, jc.DeepEquals, []names.Tag{names.NewVolumeTag("1")})
+	waitChannel(c, volumeInfoSet, "waiting for volume info to be set")
+	c.Assert(createVolumeCalls, gc.Equals, 2)
+
+	// The volume was created at T0, without waiting for the retry.
+	c.Assert(statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-1", Status: "pending", Info: "badness", Data: map[string]interface{}{
+			"attempts":   1,
+			"next-retry": "0001-01-01T00:00:30Z",
+		}},
 		{Tag: "volume-1", Status: "attaching", Info: ""},
 	})
 }
@@ -336,15 +416,15 @@ func (s *storageProvisionerSuite) TestCreateFilesystemRetry(c *gc.C) {
 	})
 
 	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
-		{Tag: "filesystem-1", Status: "pending", Info: "badness"},
-		{Tag: "filesystem-1", Status: "pending", Info: "badness"},
-		{Tag: "filesystem-1", Status: "pending", Info: "badness"},
-		{Tag: "filesystem-1", Status: "pending", Info: "badness"},
-		{Tag: "filesystem-1", Status: "pending", Info: "badness"},
-		{Tag: "filesystem-1", Status: "pending", Info: "badness"},
-		{Tag: "filesystem-1", Status: "pending", Info: "badness"},
-		{Tag: "filesystem-1", Status: "pending", Info: "badness"},
-		{Tag: "filesystem-1", Status: "pending", Info: "badness"},
+		{Tag: "filesystem-1", Status: "pending", Info: "badness", Data: retryData(createFilesystemTimes, 1)},
+		{Tag: "filesystem-1", Status: "pending", Info: "badness", Data: retryData(createFilesystemTimes, 2)},
+		{Tag: "filesystem-1", Status: "pending", Info: "badness", Data: retryData(createFilesystemTimes, 3)},
+		{Tag: "filesystem-1", Status: "pending", Info: "badness", Data: retryData(createFilesystemTimes, 4)},
+		{Tag: "filesystem-1", Status: "pending", Info: "badness", Data: retryData(createFilesystemTimes, 5)},
+		{Tag: "filesystem-1", Status: "pending", Info: "badness", Data: retryData(createFilesystemTimes, 6)},
+		{Tag: "filesystem-1", Status: "pending", Info: "badness", Data: retryData(createFilesystemTimes, 7)},
+		{Tag: "filesystem-1", Status: "pending", Info: "badness", Data: retryData(createFilesystemTimes, 8)},
+		{Tag: "filesystem-1", Status: "pending", Info: "badness", Data: retryData(createFilesystemTimes, 9)},
 		{Tag: "filesystem-1", Status: "attaching", Info: ""},
 	})
 }
@@ -417,16 +497,16 @@ func (s *storageProvisionerSuite) TestAttachVolumeRetry(c *gc.C) {
 	})
 
 	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
-		{Tag: "volume-1", Status: "attaching", Info: ""},        // CreateVolumes
-		{Tag: "volume-1", Status: "attaching", Info: "badness"}, // AttachVolumes
-		{Tag: "volume-1", Status: "attaching", Info: "badness"},
-		{Tag: "volume-1", Status: "attaching", Info: "badness"},
-		{Tag: "volume-1", Status: "attaching", Info: "badness"},
-		{Tag: "volume-1", Status: "attaching", Info: "badness"},
-		{Tag: "volume-1", Status: "attaching", Info: "badness"},
-		{Tag: "volume-1", Status: "attaching", Info: "badness"},
-		{Tag: "volume-1", Status: "attaching", Info: "badness"},
-		{Tag: "volume-1", Status: "attaching", Info: "badness"},
+		{Tag: "volume-1", Status: "attaching", Info: ""},                                               // CreateVolumes
+		{Tag: "volume-1", Status: "attaching", Info: "badness", Data: retryData(attachVolumeTimes, 1)}, // AttachVolumes
+		{Tag: "volume-1", Status: "attaching", Info: "badness", Data: retryData(attachVolumeTimes, 2)},
+		{Tag: "volume-1", Status: "attaching", Info: "badness", Data: retryData(attachVolumeTimes, 3)},
+		{Tag: "volume-1", Status: "attaching", Info: "badness", Data: retryData(attachVolumeTimes, 4)},
+		{Tag: "volume-1", Status: "attaching", Info: "badness", Data: retryData(attachVolumeTimes, 5)},
+		{Tag: "volume-1", Status: "attaching", Info: "badness", Data: retryData(attachVolumeTimes, 6)},
+		{Tag: "volume-1", Status: "attaching", Info: "badness", Data: retryData(attachVolumeTimes, 7)},
+		{Tag: "volume-1", Status: "attaching", Info: "badness", Data: retryData(attachVolumeTimes, 8)},
+		{Tag: "volume-1", Status: "attaching", Info: "badness", Data: retryData(attachVolumeTimes, 9)},
 		{Tag: "volume-1", Status: "attached", Info: ""},
 	})
 }
@@ -499,16 +579,16 @@ func (s *storageProvisionerSuite) TestAttachFilesystemRetry(c *gc.C) {
 	})
 
 	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
-		{Tag: "filesystem-1", Status: "attaching", Info: ""},        // CreateFilesystems
-		{Tag: "filesystem-1", Status: "attaching", Info: "badness"}, // AttachFilesystems
-		{Tag: "filesystem-1", Status: "attaching", Info: "badness"},
-		{Tag: "filesystem-1", Status: "attaching", Info: "badness"},
-		{Tag: "filesystem-1", Status: "attaching", Info: "badness"},
-		{Tag: "filesystem-1", Status: "attaching", Info: "badness"},
-		{Tag: "filesystem-1", Status: "attaching", Info: "badness"},
-		{Tag: "filesystem-1", Status: "attaching", Info: "badness"},
-		{Tag: "filesystem-1", Status: "attaching", Info: "badness"},
-		{Tag: "filesystem-1", Status: "attaching", Info: "badness"},
+		{Tag: "filesystem-1", Status: "attaching", Info: ""},                                                   // CreateFilesystems
+		{Tag: "filesystem-1", Status: "attaching", Info: "badness", Data: retryData(attachFilesystemTimes, 1)}, // AttachFilesystems
+		{Tag: "filesystem-1", Status: "attaching", Info: "badness", Data: retryData(attachFilesystemTimes, 2)},
+		{Tag: "filesystem-1", Status: "attaching", Info: "badness", Data: retryData(attachFilesystemTimes, 3)},
+		{Tag: "filesystem-1", Status: "attaching", Info: "badness", Data: retryData(attachFilesystemTimes, 4)},
+		{Tag: "filesystem-1", Status: "attaching", Info: "badness", Data: retryData(attachFilesystemTimes, 5)},
+		{Tag: "filesystem-1", Status: "attaching", Info: "badness", Data: retryData(attachFilesystemTimes, 6)},
+		{Tag: "filesystem-1", Status: "attaching", Info: "badness", Data: retryData(attachFilesystemTimes, 7)},
+		{Tag: "filesystem-1", Status: "attaching", Info: "badness", Data: retryData(attachFilesystemTimes, 8)},
+		{Tag: "filesystem-1", Status: "attaching", Info: "badness", Data: retryData(attachFilesystemTimes, 9)},
 		{Tag: "filesystem-1", Status: "attached", Info: ""},
 	})
 }
@@ -1002,6 +1082,126 @@ func (s *storageProvisionerSuite) TestFilesystemAttachmentAdded(c *gc.C) {
 	assertNoEvent(c, filesystemAttachmentInfoSet, "filesystem attachment info set")
 }
 
+// volumeAttachmentPlans returns the attachment plans of machine 0 for
+// the given volumes, with the given life.
+func volumeAttachmentPlans(life params.Life, volumeIds ...string) []params.VolumeAttachmentPlanResult {
+	results := make([]params.VolumeAttachmentPlanResult, len(volumeIds))
+	for i, id := range volumeIds {
+		results[i].Result = params.VolumeAttachmentPlan{
+			VolumeTag:  names.NewVolumeTag(id).String(),
+			MachineTag: "machine-0",
+			Life:       life,
+			PlanInfo: params.VolumeAttachmentPlanInfo{
+				DeviceType:       storage.DeviceTypeISCSI,
+				DeviceAttributes: map[string]string{"iqn": "iqn.target-" + id},
+			},
+		}
+	}
+	return results
+}
+
+func (s *storageProvisionerSuite) TestAttachVolumePlans(c *gc.C) {
+	s.PatchValue(storageprovisioner.PlanByType, func(storage.DeviceType) (common.Plan, error) {
+		return &mockPlan{
+			attachVolume: func(info map[string]string) (storage.BlockDevice, error) {
+				if info["iqn"] == "iqn.target-0/2" {
+					return storage.BlockDevice{}, errors.New("login failed")
+				}
+				return storage.BlockDevice{DeviceName: "sdb"}, nil
+			},
+		}, nil
+	})
+
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeAttachmentPlans = func([]params.MachineStorageId) ([]params.VolumeAttachmentPlanResult, error) {
+		return volumeAttachmentPlans(params.Alive, "0/1", "0/2"), nil
+	}
+	blockInfoSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeAttachmentPlanInfo = func(plans []params.VolumeAttachmentPlan) ([]params.ErrorResult, error) {
+		blockInfoSet <- plans
+		return make([]params.ErrorResult, len(plans)), nil
+	}
+
+	args := &workerArgs{
+		scope:    names.NewMachineTag("0"),
+		volumes:  volumeAccessor,
+		registry: s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer worker.Kill()
+
+	volumeAccessor.attachmentPlansWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-0", AttachmentTag: "volume-0-1",
+	}, {
+		MachineTag: "machine-0", AttachmentTag: "volume-0-2",
+	}}
+
+	// The plan of volume 0/1 is carried out, and its block device
+	// published, even though the plan of volume 0/2 fails.
+	expectPlan := volumeAttachmentPlans(params.Alive, "0/1")[0].Result
+	expectPlan.BlockDevice = storage.BlockDevice{DeviceName: "sdb"}
+	plans := waitChannel(c, blockInfoSet, "waiting for block info to be set")
+	c.Assert(plans, jc.DeepEquals, []params.VolumeAttachmentPlan{expectPlan})
+
+	err := workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.ErrorMatches, "failed to attach volume 0/2")
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-0-1", Status: "attaching"},
+		{Tag: "volume-0-2", Status: "attaching"},
+		{Tag: "volume-0-1", Status: "attached"},
+		{Tag: "volume-0-2", Status: "error", Info: "attaching volume: login failed"},
+	})
+}
+
+func (s *storageProvisionerSuite) TestDetachVolumePlans(c *gc.C) {
+	s.PatchValue(storageprovisioner.PlanByType, func(storage.DeviceType) (common.Plan, error) {
+		return &mockPlan{
+			detachVolume: func(info map[string]string) error {
+				if info["iqn"] == "iqn.target-0/2" {
+					return errors.New("logout failed")
+				}
+				return nil
+			},
+		}, nil
+	})
+
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.volumeAttachmentPlans = func([]params.MachineStorageId) ([]params.VolumeAttachmentPlanResult, error) {
+		return volumeAttachmentPlans(params.Dying, "0/1", "0/2"), nil
+	}
+	plansRemoved := make(chan interface{}, 1)
+	volumeAccessor.removeVolumeAttachmentPlan = func(ids []params.MachineStorageId) ([]params.ErrorResult, error) {
+		plansRemoved <- ids
+		return make([]params.ErrorResult, len(ids)), nil
+	}
+
+	args := &workerArgs{
+		scope:    names.NewMachineTag("0"),
+		volumes:  volumeAccessor,
+		registry: s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer worker.Kill()
+
+	volumeAccessor.attachmentPlansWatcher.changes <- []watcher.MachineStorageId{{
+		MachineTag: "machine-0", AttachmentTag: "volume-0-1",
+	}, {
+		MachineTag: "machine-0", AttachmentTag: "volume-0-2",
+	}}
+
+	// Only the plan that was undone is removed.
+	ids := waitChannel(c, plansRemoved, "waiting for plans to be removed")
+	c.Assert(ids, jc.DeepEquals, []params.MachineStorageId{{
+		MachineTag: "machine-0", AttachmentTag: "volume-0-1",
+	}})
+
+	err := workertest.CheckKilled(c, worker)
+	c.Assert(err, gc.ErrorMatches, "failed to detach volume 0/2")
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
+		{Tag: "volume-0-2", Status: "error", Info: "detaching volume: logout failed"},
+	})
+}
+
 func (s *storageProvisionerSuite) TestCreateVolumeBackedFilesystem(c *gc.C) {
 	filesystemInfoSet := make(chan interface{})
 	filesystemAccessor := newMockFilesystemAccessor()
@@ -1419,15 +1619,15 @@ func (s *storageProvisionerSuite) TestDetachVolumesRetry(c *gc.C) {
 	})
 
 	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
-		{Tag: "volume-1", Status: "detaching", Info: "badness"}, // DetachVolumes
-		{Tag: "volume-1", Status: "detaching", Info: "badness"},
-		{Tag: "volume-1", Status: "detaching", Info: "badness"},
-		{Tag: "volume-1", Status: "detaching", Info: "badness"},
-		{Tag: "volume-1", Status: "detaching", Info: "badness"},
-		{Tag: "volume-1", Status: "detaching", Info: "badness"},
-		{Tag: "volume-1", Status: "detaching", Info: "badness"},
-		{Tag: "volume-1", Status: "detaching", Info: "badness"},
-		{Tag: "volume-1", Status: "detaching", Info: "badness"},
+		{Tag: "volume-1", Status: "detaching", Info: "badness", Data: retryData(detachVolumeTimes, 1)}, // DetachVolumes
+		{Tag: "volume-1", Status: "detaching", Info: "badness", Data: retryData(detachVolumeTimes, 2)},
+		{Tag: "volume-1", Status: "detaching", Info: "badness", Data: retryData(detachVolumeTimes, 3)},
+		{Tag: "volume-1", Status: "detaching", Info: "badness", Data: retryData(detachVolumeTimes, 4)},
+		{Tag: "volume-1", Status: "detaching", Info: "badness", Data: retryData(detachVolumeTimes, 5)},
+		{Tag: "volume-1", Status: "detaching", Info: "badness", Data: retryData(detachVolumeTimes, 6)},
+		{Tag: "volume-1", Status: "detaching", Info: "badness", Data: retryData(detachVolumeTimes, 7)},
+		{Tag: "volume-1", Status: "detaching", Info: "badness", Data: retryData(detachVolumeTimes, 8)},
+		{Tag: "volume-1", Status: "detaching", Info: "badness", Data: retryData(detachVolumeTimes, 9)},
 		{Tag: "volume-1", Status: "detached", Info: ""},
 	})
 }
@@ -1678,15 +1878,15 @@ func (s *storageProvisionerSuite) TestDestroyVolumesRetry(c *gc.C) {
 	})
 
 	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
-		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness"},
-		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness"},
-		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness"},
-		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness"},
-		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness"},
-		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness"},
-		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness"},
-		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness"},
-		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness"},
+		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness", Data: retryData(destroyVolumeTimes, 1)},
+		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness", Data: retryData(destroyVolumeTimes, 2)},
+		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness", Data: retryData(destroyVolumeTimes, 3)},
+		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness", Data: retryData(destroyVolumeTimes, 4)},
+		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness", Data: retryData(destroyVolumeTimes, 5)},
+		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness", Data: retryData(destroyVolumeTimes, 6)},
+		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness", Data: retryData(destroyVolumeTimes, 7)},
+		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness", Data: retryData(destroyVolumeTimes, 8)},
+		{Tag: "volume-1", Status: "error", Info: "destroying volume: badness", Data: retryData(destroyVolumeTimes, 9)},
 	})
 }
 
@@ -1835,15 +2035,15 @@ func (s *storageProvisionerSuite) TestDestroyFilesystemsRetry(c *gc.C) {
 	})
 
 	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{
-		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later"},
-		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later"},
-		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later"},
-		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later"},
-		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later"},
-		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later"},
-		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later"},
-		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later"},
-		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later"},
+		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later", Data: retryData(destroyFilesystemTimes, 1)},
+		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later", Data: retryData(destroyFilesystemTimes, 2)},
+		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later", Data: retryData(destroyFilesystemTimes, 3)},
+		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later", Data: retryData(destroyFilesystemTimes, 4)},
+		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later", Data: retryData(destroyFilesystemTimes, 5)},
+		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later", Data: retryData(destroyFilesystemTimes, 6)},
+		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later", Data: retryData(destroyFilesystemTimes, 7)},
+		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later", Data: retryData(destroyFilesystemTimes, 8)},
+		{Tag: "filesystem-0", Status: "error", Info: "removing filesystem: destroyFilesystems failed, please retry later", Data: retryData(destroyFilesystemTimes, 9)},
 	})
 }
 
@@ -2059,6 +2259,9 @@ func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 	if args.statusSetter == nil {
 		args.statusSetter = &mockStatusSetter{}
 	}
+	if args.retries == nil {
+		args.retries = newMockRetryRequester()
+	}
 	worker, err := storageprovisioner.NewStorageProvisioner(storageprovisioner.Config{
 		Scope:            args.scope,
 		StorageDir:       storageDir,
@@ -2070,6 +2273,7 @@ func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 		Status:           args.statusSetter,
 		Clock:            args.clock,
 		CloudCallContext: context.NewCloudCallContext(),
+		Retries:          args.retries,
 	})
 	c.Assert(err, jc.ErrorIsNil)
 	return worker
@@ -2084,6 +2288,17 @@ type workerArgs struct {
 	machines     *mockMachineAccessor
 	clock        clock.Clock
 	statusSetter *mockStatusSetter
+	retries      *mockRetryRequester
+}
+
+// retryData returns the status data recorded when the failed attempt
+// with the given number, of those made at the given times, is
+// rescheduled for the next of them.
+func retryData(times []time.Time, attempt int) map[string]interface{} {
+	return map[string]interface{}{
+		"attempts":   attempt,
+		"next-retry": times[attempt].UTC().Format(time.RFC3339),
+	}
 }
 
 func waitChannel(c *gc.C, ch <-chan interface{}, activity string) interface{} {
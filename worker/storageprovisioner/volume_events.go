@@ -4,11 +4,14 @@
 package storageprovisioner
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/storage"
 	"github.com/juju/juju/storage/plans"
@@ -94,68 +97,128 @@ func volumeAttachmentPlansChanged(ctx *context, watcherIds []watcher.MachineStor
 	return nil
 }
 
+// planByType returns the plan the machine agent follows to initialize
+// the volumes attached with plans of the given device type.
+var planByType = plans.PlanByType
+
+// processAliveVolumePlans carries out the attachment plans of volumes
+// attached to the machine, such as logging in to their iSCSI targets,
+// and publishes the block devices the volumes appear as. The status of
+// each volume reports the progress of its plan; a plan that fails does
+// not stop the others from being carried out, but an error is returned
+// so that the plans are retried.
 func processAliveVolumePlans(ctx *context, volumePlans []params.VolumeAttachmentPlanResult) error {
-	volumeAttachmentPlans := make([]params.VolumeAttachmentPlan, len(volumePlans))
-	volumeTags := make([]names.VolumeTag, len(volumePlans))
-	for i, val := range volumePlans {
-		volumeAttachmentPlans[i] = val.Result
+	var volumeAttachmentPlans []params.VolumeAttachmentPlan
+	var volumeTags []names.VolumeTag
+	var statuses []params.EntityStatusArgs
+	var failed []string
+	for _, val := range volumePlans {
 		tag, err := names.ParseVolumeTag(val.Result.VolumeTag)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		volumeTags[i] = tag
-	}
-
-	for idx, val := range volumeAttachmentPlans {
-		volPlan, err := plans.PlanByType(val.PlanInfo.DeviceType)
+		volPlan, err := planByType(val.Result.PlanInfo.DeviceType)
 		if err != nil {
 			if !errors.IsNotFound(err) {
 				return errors.Trace(err)
 			}
 			continue
 		}
-		if blockDeviceInfo, err := volPlan.AttachVolume(val.PlanInfo.DeviceAttributes); err != nil {
-			return errors.Trace(err)
-		} else {
-			volumeAttachmentPlans[idx].BlockDevice = blockDeviceInfo
+		setStatus(ctx, []params.EntityStatusArgs{{
+			Tag:    tag.String(),
+			Status: status.Attaching.String(),
+		}})
+		blockDeviceInfo, err := volPlan.AttachVolume(val.Result.PlanInfo.DeviceAttributes)
+		if err != nil {
+			logger.Debugf("failed to attach %s: %v", names.ReadableString(tag), err)
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    tag.String(),
+				Status: status.Error.String(),
+				Info:   errors.Annotate(err, "attaching volume").Error(),
+			})
+			failed = append(failed, names.ReadableString(tag))
+			continue
 		}
+		statuses = append(statuses, params.EntityStatusArgs{
+			Tag:    tag.String(),
+			Status: status.Attached.String(),
+		})
+		plan := val.Result
+		plan.BlockDevice = blockDeviceInfo
+		volumeAttachmentPlans = append(volumeAttachmentPlans, plan)
+		volumeTags = append(volumeTags, tag)
 	}
+	setStatus(ctx, statuses)
 
-	results, err := ctx.config.Volumes.SetVolumeAttachmentPlanBlockInfo(volumeAttachmentPlans)
-	if err != nil {
-		return errors.Trace(err)
-	}
-	for _, result := range results {
-		if result.Error != nil {
-			return errors.Errorf("failed to publish block info to state: %s", result.Error)
+	if len(volumeAttachmentPlans) > 0 {
+		results, err := ctx.config.Volumes.SetVolumeAttachmentPlanBlockInfo(volumeAttachmentPlans)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, result := range results {
+			if result.Error != nil {
+				return errors.Errorf("failed to publish block info to state: %s", result.Error)
+			}
+		}
+		if err := refreshVolumeBlockDevices(ctx, volumeTags); err != nil {
+			return errors.Trace(err)
 		}
 	}
-	return refreshVolumeBlockDevices(ctx, volumeTags)
+	if len(failed) > 0 {
+		return errors.Errorf("failed to attach %s", strings.Join(failed, ", "))
+	}
+	return nil
 }
 
+// processDyingVolumePlans undoes the attachment plans of volumes being
+// detached from the machine, and removes the plans that were undone.
+// A plan that fails to be undone is kept, and an error returned so
+// that it is retried.
 func processDyingVolumePlans(ctx *context, volumePlans []params.VolumeAttachmentPlanResult) error {
-	ids := volumePlansToMachineIds(volumePlans)
+	var remove []params.VolumeAttachmentPlanResult
+	var statuses []params.EntityStatusArgs
+	var failed []string
 	for _, val := range volumePlans {
-		volPlan, err := plans.PlanByType(val.Result.PlanInfo.DeviceType)
+		tag, err := names.ParseVolumeTag(val.Result.VolumeTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volPlan, err := planByType(val.Result.PlanInfo.DeviceType)
 		if err != nil {
 			if !errors.IsNotFound(err) {
 				return errors.Trace(err)
 			}
+			remove = append(remove, val)
 			continue
 		}
 		if err := volPlan.DetachVolume(val.Result.PlanInfo.DeviceAttributes); err != nil {
-			return errors.Trace(err)
+			logger.Debugf("failed to detach %s: %v", names.ReadableString(tag), err)
+			statuses = append(statuses, params.EntityStatusArgs{
+				Tag:    tag.String(),
+				Status: status.Error.String(),
+				Info:   errors.Annotate(err, "detaching volume").Error(),
+			})
+			failed = append(failed, names.ReadableString(tag))
+			continue
 		}
+		remove = append(remove, val)
 	}
-	results, err := ctx.config.Volumes.RemoveVolumeAttachmentPlan(ids)
-	if err != nil {
-		return err
-	}
-	for _, result := range results {
-		if result.Error != nil {
-			return errors.Annotate(result.Error, "removing volume plan")
+	setStatus(ctx, statuses)
+
+	if len(remove) > 0 {
+		results, err := ctx.config.Volumes.RemoveVolumeAttachmentPlan(volumePlansToMachineIds(remove))
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			if result.Error != nil {
+				return errors.Annotate(result.Error, "removing volume plan")
+			}
 		}
 	}
+	if len(failed) > 0 {
+		return errors.Errorf("failed to detach %s", strings.Join(failed, ", "))
+	}
 	return nil
 }
 
@@ -263,6 +326,7 @@ func updatePendingVolume(ctx *context, params storage.VolumeParams) {
 // incomplete set and/or the schedule if it exists there.
 func removePendingVolume(ctx *context, tag names.VolumeTag) {
 	delete(ctx.incompleteVolumeParams, tag)
+	delete(ctx.failedOperations, tag)
 	ctx.schedule.Remove(tag)
 }
 
@@ -290,6 +354,7 @@ func updatePendingVolumeAttachment(
 // there.
 func removePendingVolumeAttachment(ctx *context, id params.MachineStorageId) {
 	delete(ctx.incompleteVolumeAttachmentParams, id)
+	delete(ctx.failedOperations, id)
 	ctx.schedule.Remove(id)
 }
 
@@ -85,7 +85,7 @@ func createVolumes(ctx *context, ops map[names.VolumeTag]*createVolumeOp) error
 			}
 		}
 	}
-	scheduleOperations(ctx, reschedule...)
+	rescheduleOperations(ctx, reschedule, statuses)
 	setStatus(ctx, statuses)
 	if len(volumes) == 0 {
 		return nil
@@ -183,7 +183,7 @@ func attachVolumes(ctx *context, ops map[params.MachineStorageId]*attachVolumeOp
 			volumeAttachments = append(volumeAttachments, *result.VolumeAttachment)
 		}
 	}
-	scheduleOperations(ctx, reschedule...)
+	rescheduleOperations(ctx, reschedule, statuses)
 	setStatus(ctx, statuses)
 	if err := createVolumeAttachmentPlans(ctx, volumeAttachments); err != nil {
 		return errors.Trace(err)
@@ -316,7 +316,7 @@ func removeVolumes(ctx *context, ops map[names.VolumeTag]*removeVolumeOp) error
 			return errors.Trace(err)
 		}
 	}
-	scheduleOperations(ctx, reschedule...)
+	rescheduleOperations(ctx, reschedule, statuses)
 	setStatus(ctx, statuses)
 	if err := removeEntities(ctx, remove); err != nil {
 		return errors.Annotate(err, "removing volumes from state")
@@ -403,7 +403,7 @@ func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp
 			remove = append(remove, id)
 		}
 	}
-	scheduleOperations(ctx, reschedule...)
+	rescheduleOperations(ctx, reschedule, statuses)
 	setStatus(ctx, statuses)
 	if err := removeAttachments(ctx, remove); err != nil {
 		return errors.Annotate(err, "removing attachments from state")
@@ -0,0 +1,104 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tagreconciler
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/tagreconciler"
+	"github.com/juju/juju/environs"
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/common"
+)
+
+// ManifoldConfig holds the names of the resources used by, and the
+// functions used to create, a tag reconciler worker.
+type ManifoldConfig struct {
+	APICallerName string
+	EnvironName   string
+
+	NewFacade                    func(base.APICaller) (Facade, error)
+	NewWorker                    func(Config) (worker.Worker, error)
+	NewCredentialValidatorFacade func(base.APICaller) (common.CredentialAPI, error)
+}
+
+// Validate returns an error if the config cannot be used to start a
+// tag reconciler.
+func (config ManifoldConfig) Validate() error {
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.EnvironName == "" {
+		return errors.NotValidf("empty EnvironName")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	if config.NewCredentialValidatorFacade == nil {
+		return errors.NotValidf("nil NewCredentialValidatorFacade")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that runs a tag reconciler.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+			config.EnvironName,
+		},
+		Start: config.start,
+	}
+}
+
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var environ environs.Environ
+	if err := context.Get(config.EnvironName, &environ); err != nil {
+		return nil, errors.Trace(err)
+	}
+	resourceTagger, ok := environs.SupportsResourceTagger(environ)
+	if !ok {
+		// There's nothing to reconcile if the provider can't read the
+		// tags of the resources it creates for the model.
+		logger.Debugf("uninstalling worker because the environ cannot read resource tags %T", environ)
+		return nil, dependency.ErrUninstall
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	facade, err := config.NewFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	credentialAPI, err := config.NewCredentialValidatorFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := config.NewWorker(Config{
+		Facade:         facade,
+		ResourceTagger: resourceTagger,
+		CallContext:    common.NewCloudCallContext(credentialAPI, nil),
+		NewTimer:       jworker.NewTimer,
+		Period:         DefaultPeriod,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// NewFacade returns a new tag reconciler facade, using the API caller.
+func NewFacade(apiCaller base.APICaller) (Facade, error) {
+	return tagreconciler.NewAPI(apiCaller), nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tagreconciler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.tagreconciler")
+
+// DefaultPeriod is the time between reconciliations.
+const DefaultPeriod = time.Hour
+
+// Facade exposes the controller functionality needed by the tag
+// reconciler.
+type Facade interface {
+	ModelConfig() (*config.Config, error)
+	ExpectedResourceTags() ([]environs.TaggedResource, error)
+}
+
+// Config holds the configuration and dependencies for a tag reconciler
+// worker.
+type Config struct {
+	// Facade is used to read the model config, and the tags Juju
+	// expects the model's instances and volumes to have.
+	Facade Facade
+
+	// ResourceTagger is used to read and set the tags of the model's
+	// instances and volumes.
+	ResourceTagger environs.ResourceTagger
+
+	// CallContext is passed to the ResourceTagger methods.
+	CallContext context.ProviderCallContext
+
+	// NewTimer is used to schedule the reconciliations.
+	NewTimer jworker.NewTimerFunc
+
+	// Period is the time between reconciliations.
+	Period time.Duration
+}
+
+// Validate returns an error if the config cannot be used to start a
+// tag reconciler.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.ResourceTagger == nil {
+		return errors.NotValidf("nil ResourceTagger")
+	}
+	if config.CallContext == nil {
+		return errors.NotValidf("nil CallContext")
+	}
+	if config.NewTimer == nil {
+		return errors.NotValidf("nil NewTimer")
+	}
+	if config.Period <= 0 {
+		return errors.NotValidf("non-positive Period")
+	}
+	return nil
+}
+
+// NewWorker returns a worker that periodically compares the tags of the
+// model's instances and volumes with those Juju set on them, such as
+// the model and controller UUIDs, the units deployed to a machine and
+// the model's resource-tags. Tags that were changed or removed, by users
+// or by model migration, are logged and, if the model's
+// tag-reconciliation config is "enabled", set again. Tags that Juju did
+// not set are left alone.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	r := &reconciler{config: config}
+	reconcile := func(stop <-chan struct{}) error {
+		return r.reconcile()
+	}
+	return jworker.NewPeriodicWorker(reconcile, config.Period, config.NewTimer), nil
+}
+
+type reconciler struct {
+	config Config
+}
+
+func (r *reconciler) reconcile() error {
+	cfg, err := r.config.Facade.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	mode := cfg.TagReconciliation()
+	if mode == config.TagReconciliationDisabled {
+		return nil
+	}
+	expected, err := r.config.Facade.ExpectedResourceTags()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(expected) == 0 {
+		return nil
+	}
+	resources := make([]environs.ModelResource, len(expected))
+	for i, resource := range expected {
+		resources[i] = resource.ModelResource
+	}
+	actual, err := r.config.ResourceTagger.ResourceTags(r.config.CallContext, resources)
+	if err != nil {
+		return errors.Annotate(err, "reading resource tags")
+	}
+
+	var drifted int
+	for i, resource := range expected {
+		if actual[i] == nil {
+			// The resource has gone since state was read.
+			continue
+		}
+		tags := make(map[string]string)
+		for k, v := range resource.Tags {
+			tags[k] = v
+		}
+		for k, v := range r.config.ResourceTagger.ProviderResourceTags(resource.ModelResource) {
+			tags[k] = v
+		}
+		drift := environs.ResourceTagDrift(tags, actual[i])
+		if drift == nil {
+			continue
+		}
+		drifted++
+		if mode == config.TagReconciliationDryRun {
+			logger.Infof("%s %q has drifted tags, would set %s", resource.Kind, resource.Id, formatTags(drift))
+			continue
+		}
+		logger.Infof("correcting tags of %s %q: setting %s", resource.Kind, resource.Id, formatTags(drift))
+		err := r.config.ResourceTagger.TagResource(r.config.CallContext, resource.ModelResource, drift)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return errors.Annotatef(err, "tagging %s %q", resource.Kind, resource.Id)
+		}
+	}
+	if drifted > 0 && mode == config.TagReconciliationDryRun {
+		logger.Warningf(
+			"%d of %d instances and volumes have drifted tags; set %s to %q to correct them",
+			drifted, len(expected), config.TagReconciliationKey, config.TagReconciliationEnabled,
+		)
+	}
+	return nil
+}
+
+// formatTags returns the tags as space separated key=value pairs,
+// sorted by key.
+func formatTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, " ")
+}
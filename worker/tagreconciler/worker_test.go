@@ -0,0 +1,235 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package tagreconciler_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	coretesting "github.com/juju/juju/testing"
+	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/worker/tagreconciler"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+
+	facade *mockFacade
+	tagger *mockResourceTagger
+	ticks  chan time.Time
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var (
+	instance0 = environs.ModelResource{Kind: environs.InstanceResource, Id: "i-0", Owner: "machine-0"}
+	instance1 = environs.ModelResource{Kind: environs.InstanceResource, Id: "i-1", Owner: "machine-1"}
+	volume0   = environs.ModelResource{Kind: environs.VolumeResource, Id: "vol-0", Owner: "volume-0"}
+)
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &mockFacade{
+		config: coretesting.CustomModelConfig(c, coretesting.Attrs{
+			"tag-reconciliation": "enabled",
+		}),
+		expected: []environs.TaggedResource{{
+			ModelResource: instance0,
+			Tags:          map[string]string{"juju-model-uuid": "new-model"},
+		}, {
+			ModelResource: instance1,
+			Tags:          map[string]string{"juju-model-uuid": "new-model"},
+		}, {
+			ModelResource: volume0,
+			Tags:          map[string]string{"juju-model-uuid": "new-model"},
+		}},
+		read: make(chan struct{}),
+	}
+	s.tagger = &mockResourceTagger{
+		tags: map[string]map[string]string{
+			// i-0 was migrated from another model, and its name
+			// was edited by a user.
+			"i-0": {"juju-model-uuid": "old-model", "Name": "mine", "owner": "me"},
+			// i-1 is already correct, and vol-0 has gone.
+			"i-1": {"juju-model-uuid": "new-model", "Name": "juju-machine-1"},
+		},
+		tagged: make(chan taggedResource, 3),
+	}
+	s.ticks = make(chan time.Time)
+}
+
+func (s *WorkerSuite) config() tagreconciler.Config {
+	return tagreconciler.Config{
+		Facade:         s.facade,
+		ResourceTagger: s.tagger,
+		CallContext:    context.NewCloudCallContext(),
+		NewTimer: func(time.Duration) jworker.PeriodicTimer {
+			return &fakeTimer{s.ticks}
+		},
+		Period: time.Hour,
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Facade = nil
+	_, err := tagreconciler.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil Facade not valid")
+
+	config = s.config()
+	config.ResourceTagger = nil
+	_, err = tagreconciler.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "nil ResourceTagger not valid")
+
+	config = s.config()
+	config.Period = 0
+	_, err = tagreconciler.NewWorker(config)
+	c.Assert(err, gc.ErrorMatches, "non-positive Period not valid")
+}
+
+func (s *WorkerSuite) TestCorrectsDriftedTags(c *gc.C) {
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.reconcile(c)
+	select {
+	case tagged := <-s.tagger.tagged:
+		c.Assert(tagged, jc.DeepEquals, taggedResource{
+			resource: instance0,
+			tags: map[string]string{
+				"juju-model-uuid": "new-model",
+				"Name":            "juju-machine-0",
+			},
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for tags to be corrected")
+	}
+	s.assertNotTagged(c)
+}
+
+func (s *WorkerSuite) TestDryRun(c *gc.C) {
+	s.setMode(c, config.TagReconciliationDryRun)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	s.reconcile(c)
+	s.assertNotTagged(c)
+	c.Assert(c.GetTestLog(), jc.Contains,
+		`instance "i-0" has drifted tags, would set Name="juju-machine-0" juju-model-uuid="new-model"`)
+}
+
+func (s *WorkerSuite) TestDisabled(c *gc.C) {
+	s.setMode(c, config.TagReconciliationDisabled)
+	w := s.startWorker(c)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case s.ticks <- time.Time{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out triggering reconciliation")
+	}
+	select {
+	case <-s.facade.read:
+		c.Fatalf("unexpected read of expected tags")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) setMode(c *gc.C, mode string) {
+	cfg, err := s.facade.config.Apply(map[string]interface{}{
+		"tag-reconciliation": mode,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.config = cfg
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := tagreconciler.NewWorker(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	return w
+}
+
+// reconcile triggers a reconciliation, and waits for the expected tags
+// to be read.
+func (s *WorkerSuite) reconcile(c *gc.C) {
+	select {
+	case s.ticks <- time.Time{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out triggering reconciliation")
+	}
+	select {
+	case <-s.facade.read:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for expected tags to be read")
+	}
+}
+
+func (s *WorkerSuite) assertNotTagged(c *gc.C) {
+	select {
+	case tagged := <-s.tagger.tagged:
+		c.Fatalf("unexpected tagging of %v", tagged)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+type fakeTimer struct {
+	ticks chan time.Time
+}
+
+func (t *fakeTimer) Reset(time.Duration) bool {
+	return true
+}
+
+func (t *fakeTimer) CountDown() <-chan time.Time {
+	return t.ticks
+}
+
+type mockFacade struct {
+	config   *config.Config
+	expected []environs.TaggedResource
+	read     chan struct{}
+}
+
+func (f *mockFacade) ModelConfig() (*config.Config, error) {
+	return f.config, nil
+}
+
+func (f *mockFacade) ExpectedResourceTags() ([]environs.TaggedResource, error) {
+	f.read <- struct{}{}
+	return f.expected, nil
+}
+
+type taggedResource struct {
+	resource environs.ModelResource
+	tags     map[string]string
+}
+
+type mockResourceTagger struct {
+	tags   map[string]map[string]string
+	tagged chan taggedResource
+}
+
+func (m *mockResourceTagger) ResourceTags(ctx context.ProviderCallContext, resources []environs.ModelResource) ([]map[string]string, error) {
+	result := make([]map[string]string, len(resources))
+	for i, resource := range resources {
+		result[i] = m.tags[resource.Id]
+	}
+	return result, nil
+}
+
+func (m *mockResourceTagger) ProviderResourceTags(resource environs.ModelResource) map[string]string {
+	return map[string]string{"Name": "juju-" + resource.Owner}
+}
+
+func (m *mockResourceTagger) TagResource(ctx context.ProviderCallContext, resource environs.ModelResource, tags map[string]string) error {
+	m.tagged <- taggedResource{resource, tags}
+	return nil
+}
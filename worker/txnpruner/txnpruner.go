@@ -4,35 +4,130 @@
 package txnpruner
 
 import (
+	"sync"
 	"time"
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
 
-	jworker "github.com/juju/juju/worker"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
 )
 
+var logger = loggo.GetLogger("juju.worker.txnpruner")
+
 // TransactionPruner defines the interface for types capable of
 // pruning transactions.
 type TransactionPruner interface {
 	MaybePruneTransactions() error
+
+	// ControllerConfig and WatchControllerConfig allow the
+	// pruning interval to be changed while the worker runs.
+	ControllerConfig() (controller.Config, error)
+	WatchControllerConfig() state.NotifyWatcher
 }
 
 // New returns a worker which periodically prunes the data for
-// completed transactions.
+// completed transactions. The given interval is used unless the
+// controller's prune-txn-interval is set.
 func New(tp TransactionPruner, interval time.Duration, clock clock.Clock) worker.Worker {
-	return jworker.NewSimpleWorker(func(stopCh <-chan struct{}) error {
-		for {
-			select {
-			case <-clock.After(interval):
-				err := tp.MaybePruneTransactions()
-				if err != nil {
-					return errors.Annotate(err, "pruning failed, txnpruner stopping")
-				}
-			case <-stopCh:
-				return nil
+	w := &txnPruner{
+		tp:              tp,
+		defaultInterval: interval,
+		clock:           clock,
+	}
+	w.tomb.Go(w.loop)
+	return w
+}
+
+type txnPruner struct {
+	tomb            tomb.Tomb
+	tp              TransactionPruner
+	defaultInterval time.Duration
+	clock           clock.Clock
+
+	mu        sync.Mutex
+	interval  time.Duration
+	lastPrune time.Time
+	nextPrune time.Time
+}
+
+// Report is shown in the engine report.
+func (w *txnPruner) Report() map[string]interface{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	result := map[string]interface{}{
+		"prune-interval": w.interval,
+	}
+	if !w.lastPrune.IsZero() {
+		result["last-prune"] = w.lastPrune.Round(time.Second)
+	}
+	if !w.nextPrune.IsZero() {
+		result["next-prune"] = w.nextPrune.Round(time.Second)
+	}
+	return result
+}
+
+func (w *txnPruner) loop() error {
+	configWatcher := w.tp.WatchControllerConfig()
+	defer worker.Stop(configWatcher)
+
+	var prune <-chan time.Time
+	for {
+		select {
+		case <-w.tomb.Dying():
+			return nil
+
+		case _, ok := <-configWatcher.Changes():
+			if !ok {
+				return errors.New("controller configuration watcher closed")
 			}
+			cfg, err := w.tp.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load controller configuration")
+			}
+			interval := cfg.PruneTxnInterval()
+			if interval <= 0 {
+				interval = w.defaultInterval
+			}
+			w.mu.Lock()
+			changed := interval != w.interval
+			w.mu.Unlock()
+			if prune != nil && !changed {
+				continue
+			}
+			if prune != nil {
+				logger.Infof("txn pruning interval changed: %v", interval)
+			}
+			prune = w.clock.After(interval)
+			w.mu.Lock()
+			w.interval = interval
+			w.nextPrune = w.clock.Now().Add(interval)
+			w.mu.Unlock()
+
+		case <-prune:
+			if err := w.tp.MaybePruneTransactions(); err != nil {
+				return errors.Annotate(err, "pruning failed, txnpruner stopping")
+			}
+			now := w.clock.Now()
+			w.mu.Lock()
+			prune = w.clock.After(w.interval)
+			w.lastPrune = now
+			w.nextPrune = now.Add(w.interval)
+			w.mu.Unlock()
 		}
-	})
+	}
+}
+
+// Kill implements Worker.Kill().
+func (w *txnPruner) Kill() {
+	w.tomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait().
+func (w *txnPruner) Wait() error {
+	return w.tomb.Wait()
 }
@@ -4,6 +4,7 @@
 package txnpruner_test
 
 import (
+	"sync"
 	"time"
 
 	"github.com/juju/clock"
@@ -11,6 +12,9 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher/watchertest"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/txnpruner"
 )
@@ -70,14 +74,99 @@ func (s *TxnPrunerSuite) TestStops(c *gc.C) {
 	}
 }
 
+func (s *TxnPrunerSuite) TestIntervalFromControllerConfig(c *gc.C) {
+	fakePruner := newFakeTransactionPruner()
+	fakePruner.config = controller.Config{controller.PruneTxnInterval: "10m"}
+	testClock := testclock.NewClock(time.Now())
+	p := txnpruner.New(fakePruner, time.Minute, testClock)
+	defer p.Kill()
+
+	err := testClock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-fakePruner.pruneCh:
+		c.Fatal("pruned at the default interval")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	err = testClock.WaitAdvance(9*time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-fakePruner.pruneCh:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for pruning to happen")
+	}
+
+	reporter, ok := p.(interface {
+		Report() map[string]interface{}
+	})
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(reporter.Report()["prune-interval"], gc.Equals, 10*time.Minute)
+}
+
+func (s *TxnPrunerSuite) TestIntervalChange(c *gc.C) {
+	fakePruner := newFakeTransactionPruner()
+	testClock := testclock.NewClock(time.Now())
+	p := txnpruner.New(fakePruner, time.Hour, testClock)
+	defer p.Kill()
+
+	select {
+	case <-testClock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to start")
+	}
+
+	fakePruner.setConfig(controller.Config{controller.PruneTxnInterval: "1m"})
+	select {
+	case fakePruner.configCh <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending config change")
+	}
+
+	err := testClock.WaitAdvance(time.Minute, coretesting.LongWait, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-fakePruner.pruneCh:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for pruning to happen")
+	}
+}
+
 func newFakeTransactionPruner() *fakeTransactionPruner {
 	return &fakeTransactionPruner{
-		pruneCh: make(chan bool),
+		pruneCh:  make(chan bool),
+		configCh: make(chan struct{}, 1),
+		config:   controller.Config{},
 	}
 }
 
 type fakeTransactionPruner struct {
-	pruneCh chan bool
+	pruneCh  chan bool
+	configCh chan struct{}
+
+	mu     sync.Mutex
+	config controller.Config
+}
+
+func (p *fakeTransactionPruner) setConfig(config controller.Config) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.config = config
+}
+
+// ControllerConfig implements the txnpruner.TransactionPruner
+// interface.
+func (p *fakeTransactionPruner) ControllerConfig() (controller.Config, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.config, nil
+}
+
+// WatchControllerConfig implements the txnpruner.TransactionPruner
+// interface. The initial event is sent straight away.
+func (p *fakeTransactionPruner) WatchControllerConfig() state.NotifyWatcher {
+	p.configCh <- struct{}{}
+	return watchertest.NewNotifyWatcher(p.configCh)
 }
 
 // MaybePruneTransactions implements the txnpruner.TransactionPruner
@@ -0,0 +1,187 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ChecksFile is the name of the file, in the root of the charm directory,
+// that declares the unit's health checks.
+const ChecksFile = "checks.yaml"
+
+const (
+	defaultInterval  = 30 * time.Second
+	defaultTimeout   = 5 * time.Second
+	defaultThreshold = 3
+)
+
+// Level describes what a failing check says about the unit.
+type Level string
+
+const (
+	// Liveness checks report whether the unit's workload is running.
+	Liveness Level = "liveness"
+
+	// Readiness checks report whether the unit's workload is able to
+	// serve requests.
+	Readiness Level = "readiness"
+)
+
+// Check holds a single health check declared by a charm. Exactly one of
+// HTTP, TCP and Exec is set.
+type Check struct {
+	// Name is the name of the check, as given in the checks file.
+	Name string
+
+	// Level describes what a failing check says about the unit.
+	Level Level
+
+	// HTTP holds a URL that must respond with a 2xx or 3xx status.
+	HTTP string
+
+	// TCP holds a host:port address that must accept connections.
+	TCP string
+
+	// Exec holds a command, run in the charm directory, that must
+	// exit with a zero status.
+	Exec string
+
+	// Interval is the time between consecutive runs of the check.
+	Interval time.Duration
+
+	// Timeout is the time after which a run of the check is
+	// considered to have failed.
+	Timeout time.Duration
+
+	// Threshold is the number of consecutive failures after which
+	// the unit is considered unhealthy.
+	Threshold int
+
+	// OnFailure, if set, names a hook in the charm's hooks directory
+	// that is run when the check starts failing.
+	OnFailure string
+}
+
+type checksDoc struct {
+	Checks map[string]checkDoc `yaml:"checks"`
+}
+
+type checkDoc struct {
+	Level     Level  `yaml:"level"`
+	HTTP      string `yaml:"http"`
+	TCP       string `yaml:"tcp"`
+	Exec      string `yaml:"exec"`
+	Interval  string `yaml:"interval"`
+	Timeout   string `yaml:"timeout"`
+	Threshold int    `yaml:"threshold"`
+	OnFailure string `yaml:"on-failure"`
+}
+
+var validName = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+// ReadChecks returns the health checks declared in the checks file of
+// the given charm directory, sorted by name. If the charm does not
+// declare any checks, ReadChecks returns no checks and no error.
+func ReadChecks(charmDir string) ([]Check, error) {
+	data, err := ioutil.ReadFile(filepath.Join(charmDir, ChecksFile))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return ParseChecks(data)
+}
+
+// ParseChecks parses and validates the contents of a checks file,
+// filling in defaults for unspecified settings.
+func ParseChecks(data []byte) ([]Check, error) {
+	var doc checksDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.Annotate(err, "cannot parse health checks")
+	}
+	checks := make([]Check, 0, len(doc.Checks))
+	for name, cd := range doc.Checks {
+		check, err := parseCheck(name, cd)
+		if err != nil {
+			return nil, errors.Annotatef(err, "health check %q", name)
+		}
+		checks = append(checks, check)
+	}
+	sort.Slice(checks, func(i, j int) bool {
+		return checks[i].Name < checks[j].Name
+	})
+	return checks, nil
+}
+
+func parseCheck(name string, doc checkDoc) (Check, error) {
+	if !validName.MatchString(name) {
+		return Check{}, errors.NotValidf("name")
+	}
+	check := Check{
+		Name:      name,
+		Level:     doc.Level,
+		HTTP:      doc.HTTP,
+		TCP:       doc.TCP,
+		Exec:      doc.Exec,
+		Interval:  defaultInterval,
+		Timeout:   defaultTimeout,
+		Threshold: defaultThreshold,
+		OnFailure: doc.OnFailure,
+	}
+	switch check.Level {
+	case "":
+		check.Level = Readiness
+	case Liveness, Readiness:
+	default:
+		return Check{}, errors.NotValidf("level %q", doc.Level)
+	}
+
+	probes := 0
+	for _, probe := range []string{doc.HTTP, doc.TCP, doc.Exec} {
+		if probe != "" {
+			probes++
+		}
+	}
+	if probes != 1 {
+		return Check{}, errors.New("expected exactly one of http, tcp or exec")
+	}
+
+	var err error
+	if doc.Interval != "" {
+		if check.Interval, err = parsePositiveDuration("interval", doc.Interval); err != nil {
+			return Check{}, errors.Trace(err)
+		}
+	}
+	if doc.Timeout != "" {
+		if check.Timeout, err = parsePositiveDuration("timeout", doc.Timeout); err != nil {
+			return Check{}, errors.Trace(err)
+		}
+	}
+	if doc.Threshold < 0 {
+		return Check{}, errors.NotValidf("threshold %d", doc.Threshold)
+	} else if doc.Threshold > 0 {
+		check.Threshold = doc.Threshold
+	}
+	if check.OnFailure != "" && !validName.MatchString(check.OnFailure) {
+		return Check{}, errors.NotValidf("on-failure hook %q", check.OnFailure)
+	}
+	return check, nil
+}
+
+func parsePositiveDuration(setting, value string) (time.Duration, error) {
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, errors.NotValidf("%s %q", setting, value)
+	}
+	return d, nil
+}
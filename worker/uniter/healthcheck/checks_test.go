@@ -0,0 +1,117 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/healthcheck"
+)
+
+type checksSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&checksSuite{})
+
+func (s *checksSuite) TestParseChecks(c *gc.C) {
+	checks, err := healthcheck.ParseChecks([]byte(`
+checks:
+  web:
+    level: liveness
+    http: http://localhost:8080/health
+    interval: 10s
+    timeout: 2s
+    threshold: 5
+    on-failure: restart-web
+  db:
+    tcp: localhost:5432
+  cache:
+    exec: ./check-cache
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, jc.DeepEquals, []healthcheck.Check{{
+		Name:      "cache",
+		Level:     healthcheck.Readiness,
+		Exec:      "./check-cache",
+		Interval:  30 * time.Second,
+		Timeout:   5 * time.Second,
+		Threshold: 3,
+	}, {
+		Name:      "db",
+		Level:     healthcheck.Readiness,
+		TCP:       "localhost:5432",
+		Interval:  30 * time.Second,
+		Timeout:   5 * time.Second,
+		Threshold: 3,
+	}, {
+		Name:      "web",
+		Level:     healthcheck.Liveness,
+		HTTP:      "http://localhost:8080/health",
+		Interval:  10 * time.Second,
+		Timeout:   2 * time.Second,
+		Threshold: 5,
+		OnFailure: "restart-web",
+	}})
+}
+
+func (s *checksSuite) TestParseChecksErrors(c *gc.C) {
+	for i, test := range []struct {
+		yaml string
+		err  string
+	}{{
+		yaml: "checks: [web]",
+		err:  "cannot parse health checks: .*",
+	}, {
+		yaml: "checks: {Web: {tcp: localhost:80}}",
+		err:  `health check "Web": name not valid`,
+	}, {
+		yaml: "checks: {web: {}}",
+		err:  `health check "web": expected exactly one of http, tcp or exec`,
+	}, {
+		yaml: "checks: {web: {tcp: localhost:80, exec: ./check}}",
+		err:  `health check "web": expected exactly one of http, tcp or exec`,
+	}, {
+		yaml: "checks: {web: {tcp: localhost:80, level: startup}}",
+		err:  `health check "web": level "startup" not valid`,
+	}, {
+		yaml: "checks: {web: {tcp: localhost:80, interval: 0s}}",
+		err:  `health check "web": interval "0s" not valid`,
+	}, {
+		yaml: "checks: {web: {tcp: localhost:80, timeout: soon}}",
+		err:  `health check "web": timeout "soon" not valid`,
+	}, {
+		yaml: "checks: {web: {tcp: localhost:80, threshold: -1}}",
+		err:  `health check "web": threshold -1 not valid`,
+	}, {
+		yaml: "checks: {web: {tcp: localhost:80, on-failure: ../install}}",
+		err:  `health check "web": on-failure hook "../install" not valid`,
+	}} {
+		c.Logf("test %d: %s", i, test.yaml)
+		_, err := healthcheck.ParseChecks([]byte(test.yaml))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *checksSuite) TestReadChecksNoFile(c *gc.C) {
+	checks, err := healthcheck.ReadChecks(c.MkDir())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 0)
+}
+
+func (s *checksSuite) TestReadChecks(c *gc.C) {
+	charmDir := c.MkDir()
+	err := ioutil.WriteFile(filepath.Join(charmDir, healthcheck.ChecksFile), []byte("checks: {db: {tcp: localhost:5432}}"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	checks, err := healthcheck.ReadChecks(charmDir)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(checks, gc.HasLen, 1)
+	c.Assert(checks[0].TCP, gc.Equals, "localhost:5432")
+}
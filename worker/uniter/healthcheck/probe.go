@@ -0,0 +1,65 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os/exec"
+
+	"github.com/juju/errors"
+)
+
+// Probe runs a single health check, returning an error if the check
+// fails.
+type Probe func(check Check, charmDir string) error
+
+// DefaultProbe runs the HTTP, TCP or exec probe of the check.
+func DefaultProbe(check Check, charmDir string) error {
+	switch {
+	case check.HTTP != "":
+		return probeHTTP(check)
+	case check.TCP != "":
+		return probeTCP(check)
+	case check.Exec != "":
+		return probeExec(check, charmDir)
+	}
+	return errors.NotValidf("health check %q without probe", check.Name)
+}
+
+func probeHTTP(check Check) error {
+	client := &http.Client{Timeout: check.Timeout}
+	resp, err := client.Get(check.HTTP)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return errors.Errorf("unexpected status %q", resp.Status)
+	}
+	return nil
+}
+
+func probeTCP(check Check) error {
+	conn, err := net.DialTimeout("tcp", check.TCP, check.Timeout)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return conn.Close()
+}
+
+func probeExec(check Check, charmDir string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), check.Timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "/bin/sh", "-c", check.Exec)
+	cmd.Dir = charmDir
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return errors.Errorf("timed out after %v", check.Timeout)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/healthcheck"
+)
+
+type probeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&probeSuite{})
+
+func (s *probeSuite) TestHTTP(c *gc.C) {
+	healthy := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !healthy {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	check := healthcheck.Check{HTTP: server.URL, Timeout: time.Second}
+	c.Assert(healthcheck.DefaultProbe(check, c.MkDir()), jc.ErrorIsNil)
+	healthy = false
+	c.Assert(healthcheck.DefaultProbe(check, c.MkDir()), gc.ErrorMatches, `unexpected status "503 Service Unavailable"`)
+}
+
+func (s *probeSuite) TestTCP(c *gc.C) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	addr := listener.Addr().String()
+
+	check := healthcheck.Check{TCP: addr, Timeout: time.Second}
+	c.Assert(healthcheck.DefaultProbe(check, c.MkDir()), jc.ErrorIsNil)
+	listener.Close()
+	c.Assert(healthcheck.DefaultProbe(check, c.MkDir()), gc.NotNil)
+}
+
+func (s *probeSuite) TestExec(c *gc.C) {
+	check := healthcheck.Check{Exec: "test -f ready", Timeout: time.Second}
+	charmDir := c.MkDir()
+	c.Assert(healthcheck.DefaultProbe(check, charmDir), gc.ErrorMatches, "exit status 1")
+	check.Exec = "test -d ."
+	c.Assert(healthcheck.DefaultProbe(check, charmDir), jc.ErrorIsNil)
+}
+
+func (s *probeSuite) TestExecTimeout(c *gc.C) {
+	check := healthcheck.Check{Exec: "sleep 10", Timeout: 10 * time.Millisecond}
+	c.Assert(healthcheck.DefaultProbe(check, c.MkDir()), gc.ErrorMatches, "timed out after 10ms")
+}
@@ -0,0 +1,234 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthcheck provides a worker that continuously runs the health
+// checks declared by a unit's charm, independently of hook execution.
+//
+// Checks are declared in a checks.yaml file in the root of the charm:
+//
+//	checks:
+//	  web:
+//	    level: liveness
+//	    http: http://localhost:8080/health
+//	    interval: 10s
+//	    timeout: 3s
+//	    threshold: 3
+//	    on-failure: restart-web
+//	  db:
+//	    tcp: localhost:5432
+//
+// When a check fails threshold times in a row, the unit's workload status
+// is set to blocked and the on-failure hook, if any, is run. Once all checks
+// pass again, the workload status is set back to active.
+package healthcheck
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/status"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.healthcheck")
+
+// reloadInterval is the longest time the worker waits before re-reading
+// the checks file, so that checks added by a charm upgrade are picked up.
+const reloadInterval = time.Minute
+
+// StatusSetter sets the workload status of the unit.
+type StatusSetter interface {
+	SetUnitStatus(unitStatus status.Status, info string, data map[string]interface{}) error
+}
+
+// Config holds the configuration of a health check worker.
+type Config struct {
+	// CharmDir is the directory holding the unit's charm.
+	CharmDir string
+
+	// StatusSetter is used to report the health of the unit.
+	StatusSetter StatusSetter
+
+	// RunHook runs the named hook of the charm in a hook context.
+	RunHook func(name string) error
+
+	// Probe runs a single health check.
+	Probe Probe
+
+	// Clock is used to schedule health checks.
+	Clock clock.Clock
+}
+
+// Validate returns an error if the config cannot be used to start a
+// health check worker.
+func (config Config) Validate() error {
+	if config.CharmDir == "" {
+		return errors.NotValidf("empty CharmDir")
+	}
+	if config.StatusSetter == nil {
+		return errors.NotValidf("nil StatusSetter")
+	}
+	if config.RunHook == nil {
+		return errors.NotValidf("nil RunHook")
+	}
+	if config.Probe == nil {
+		return errors.NotValidf("nil Probe")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// Worker runs the health checks declared by a unit's charm.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	states   map[string]*checkState
+	reported string
+}
+
+// checkState records the outcome of the recent runs of a check.
+type checkState struct {
+	next     time.Time
+	failures int
+	failing  bool
+}
+
+// NewWorker returns a worker that runs the health checks declared by the
+// charm in config.CharmDir.
+func NewWorker(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+		states: make(map[string]*checkState),
+	}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		checks, err := ReadChecks(w.config.CharmDir)
+		if err != nil {
+			// A broken checks file must not stop the unit agent;
+			// it is reported, and checked again later.
+			logger.Warningf("cannot read health checks: %v", err)
+			checks = nil
+		}
+		delay, err := w.runChecks(checks)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(delay):
+		}
+	}
+}
+
+// runChecks runs the checks that are due, reports the resulting health
+// of the unit, and returns the time until a check is next due.
+func (w *Worker) runChecks(checks []Check) (time.Duration, error) {
+	now := w.config.Clock.Now()
+	delay := reloadInterval
+	states := make(map[string]*checkState)
+	for _, check := range checks {
+		state, ok := w.states[check.Name]
+		if !ok {
+			state = &checkState{}
+		}
+		states[check.Name] = state
+		if !now.Before(state.next) {
+			w.runCheck(check, state)
+			state.next = now.Add(check.Interval)
+		}
+		if d := state.next.Sub(now); d < delay {
+			delay = d
+		}
+	}
+	w.states = states
+	return delay, errors.Trace(w.reportStatus(checks))
+}
+
+func (w *Worker) runCheck(check Check, state *checkState) {
+	err := w.config.Probe(check, w.config.CharmDir)
+	if err == nil {
+		if state.failing {
+			logger.Infof("health check %q passing again", check.Name)
+		}
+		state.failures = 0
+		state.failing = false
+		return
+	}
+	state.failures++
+	logger.Debugf("health check %q failed (%d/%d): %v", check.Name, state.failures, check.Threshold, err)
+	if state.failing || state.failures < check.Threshold {
+		return
+	}
+	state.failing = true
+	logger.Warningf("health check %q failing: %v", check.Name, err)
+	if check.OnFailure == "" {
+		return
+	}
+	if err := w.config.RunHook(check.OnFailure); err != nil {
+		logger.Errorf("running %q hook for failing health check %q: %v", check.OnFailure, check.Name, err)
+	}
+}
+
+// reportStatus sets the workload status of the unit when it becomes
+// unhealthy, or healthy again. Failing liveness checks take precedence
+// over failing readiness checks.
+func (w *Worker) reportStatus(checks []Check) error {
+	var failing *Check
+	for i, check := range checks {
+		if !w.states[check.Name].failing {
+			continue
+		}
+		if failing == nil || failing.Level == Readiness && check.Level == Liveness {
+			failing = &checks[i]
+		}
+	}
+
+	var message string
+	if failing != nil {
+		message = fmt.Sprintf("%s check %q failing", failing.Level, failing.Name)
+	}
+	if message == w.reported {
+		return nil
+	}
+	var err error
+	if failing != nil {
+		err = w.config.StatusSetter.SetUnitStatus(status.Blocked, message, nil)
+	} else {
+		err = w.config.StatusSetter.SetUnitStatus(status.Active, "", nil)
+	}
+	if err != nil {
+		return errors.Annotate(err, "setting unit status")
+	}
+	w.reported = message
+	return nil
+}
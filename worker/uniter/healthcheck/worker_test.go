@@ -0,0 +1,225 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthcheck_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/uniter/healthcheck"
+)
+
+type workerSuite struct {
+	testing.IsolationSuite
+
+	charmDir string
+	clock    *testclock.Clock
+	probe    *fakeProbe
+	statuses chan statusChange
+	hooks    chan string
+}
+
+var _ = gc.Suite(&workerSuite{})
+
+type statusChange struct {
+	status status.Status
+	info   string
+}
+
+func (s *workerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.charmDir = c.MkDir()
+	s.clock = testclock.NewClock(time.Time{})
+	s.probe = &fakeProbe{failing: make(map[string]bool)}
+	s.statuses = make(chan statusChange, 10)
+	s.hooks = make(chan string, 10)
+}
+
+func (s *workerSuite) writeChecks(c *gc.C, checks string) {
+	err := ioutil.WriteFile(filepath.Join(s.charmDir, healthcheck.ChecksFile), []byte(checks), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *workerSuite) startWorker(c *gc.C) *healthcheck.Worker {
+	w, err := healthcheck.NewWorker(healthcheck.Config{
+		CharmDir:     s.charmDir,
+		StatusSetter: statusSetterFunc(s.setStatus),
+		RunHook: func(name string) error {
+			s.hooks <- name
+			return nil
+		},
+		Probe: s.probe.run,
+		Clock: s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	return w
+}
+
+func (s *workerSuite) setStatus(st status.Status, info string, _ map[string]interface{}) error {
+	s.statuses <- statusChange{st, info}
+	return nil
+}
+
+func (s *workerSuite) advance(c *gc.C, d time.Duration) {
+	c.Assert(s.clock.WaitAdvance(d, coretesting.LongWait, 1), jc.ErrorIsNil)
+}
+
+func (s *workerSuite) assertStatus(c *gc.C, expect statusChange) {
+	select {
+	case change := <-s.statuses:
+		c.Assert(change, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for status %v", expect)
+	}
+}
+
+func (s *workerSuite) assertHook(c *gc.C, expect string) {
+	select {
+	case name := <-s.hooks:
+		c.Assert(name, gc.Equals, expect)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for hook %q", expect)
+	}
+}
+
+func (s *workerSuite) assertNoChanges(c *gc.C) {
+	select {
+	case change := <-s.statuses:
+		c.Fatalf("unexpected status %v", change)
+	case name := <-s.hooks:
+		c.Fatalf("unexpected hook %q", name)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *workerSuite) TestValidate(c *gc.C) {
+	_, err := healthcheck.NewWorker(healthcheck.Config{})
+	c.Assert(err, gc.ErrorMatches, "empty CharmDir not valid")
+}
+
+func (s *workerSuite) TestFailingCheckBlocksUnitAndRunsHook(c *gc.C) {
+	s.writeChecks(c, `
+checks:
+  web:
+    level: liveness
+    tcp: localhost:8080
+    interval: 10s
+    threshold: 2
+    on-failure: restart-web
+`)
+	s.probe.setFailing("web", true)
+	s.startWorker(c)
+
+	// The first failure is below the threshold.
+	s.advance(c, 10*time.Second)
+	s.assertStatus(c, statusChange{status.Blocked, `liveness check "web" failing`})
+	s.assertHook(c, "restart-web")
+
+	// Further failures neither report again nor rerun the hook.
+	s.advance(c, 10*time.Second)
+	s.assertNoChanges(c)
+
+	s.probe.setFailing("web", false)
+	s.advance(c, 10*time.Second)
+	s.assertStatus(c, statusChange{status.Active, ""})
+}
+
+func (s *workerSuite) TestLivenessTakesPrecedence(c *gc.C) {
+	s.writeChecks(c, `
+checks:
+  api:
+    tcp: localhost:8080
+    threshold: 1
+  web:
+    level: liveness
+    tcp: localhost:80
+    threshold: 1
+`)
+	s.probe.setFailing("api", true)
+	s.probe.setFailing("web", true)
+	s.startWorker(c)
+	s.assertStatus(c, statusChange{status.Blocked, `liveness check "web" failing`})
+
+	s.probe.setFailing("web", false)
+	s.advance(c, 30*time.Second)
+	s.assertStatus(c, statusChange{status.Blocked, `readiness check "api" failing`})
+}
+
+func (s *workerSuite) TestNoChecks(c *gc.C) {
+	s.startWorker(c)
+	s.advance(c, time.Minute)
+	s.assertNoChanges(c)
+	c.Assert(s.probe.calls(), gc.Equals, 0)
+}
+
+func (s *workerSuite) TestChecksReloaded(c *gc.C) {
+	s.startWorker(c)
+	s.writeChecks(c, "checks: {db: {tcp: localhost:5432, threshold: 1}}")
+	s.probe.setFailing("db", true)
+	s.advance(c, time.Minute)
+	s.assertStatus(c, statusChange{status.Blocked, `readiness check "db" failing`})
+}
+
+func (s *workerSuite) TestSetStatusError(c *gc.C) {
+	s.writeChecks(c, "checks: {db: {tcp: localhost:5432, threshold: 1}}")
+	s.probe.setFailing("db", true)
+	w, err := healthcheck.NewWorker(healthcheck.Config{
+		CharmDir: s.charmDir,
+		StatusSetter: statusSetterFunc(func(status.Status, string, map[string]interface{}) error {
+			return errors.New("boom")
+		}),
+		RunHook: func(string) error { return nil },
+		Probe:   s.probe.run,
+		Clock:   s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "setting unit status: boom")
+}
+
+type statusSetterFunc func(status.Status, string, map[string]interface{}) error
+
+func (f statusSetterFunc) SetUnitStatus(st status.Status, info string, data map[string]interface{}) error {
+	return f(st, info, data)
+}
+
+type fakeProbe struct {
+	mu      sync.Mutex
+	failing map[string]bool
+	count   int
+}
+
+func (p *fakeProbe) setFailing(name string, failing bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failing[name] = failing
+}
+
+func (p *fakeProbe) calls() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.count
+}
+
+func (p *fakeProbe) run(check healthcheck.Check, _ string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.count++
+	if p.failing[check.Name] {
+		return errors.New("connection refused")
+	}
+	return nil
+}
@@ -77,9 +77,15 @@ func (r *Relationer) SetDying() error {
 }
 
 // die is run when the relationer has no further responsibilities; it leaves
-// relation scope, and removes the local relation state directory.
+// relation scope, and removes the local relation state directory. If the
+// relation has a drain timeout, the unit instead drains its scope, giving
+// its counterparts until the timeout to run their departed hooks.
 func (r *Relationer) die() error {
-	if err := r.ru.LeaveScope(); err != nil {
+	if r.ru.Relation().DrainTimeout() > 0 {
+		if err := r.ru.DrainScope(); err != nil {
+			return errors.Annotatef(err, "draining scope of relation %q", r.ru.Relation())
+		}
+	} else if err := r.ru.LeaveScope(); err != nil {
 		return errors.Annotatef(err, "leaving scope of relation %q", r.ru.Relation())
 	}
 	return r.dir.Remove()
@@ -198,6 +198,7 @@ type mockUnit struct {
 	tag                              names.UnitTag
 	life                             params.Life
 	resolved                         params.ResolvedMode
+	paused                           bool
 	application                      mockApplication
 	unitWatcher                      *mockNotifyWatcher
 	addressesWatcher                 *mockStringsWatcher
@@ -222,6 +223,10 @@ func (u *mockUnit) Resolved() params.ResolvedMode {
 	return u.resolved
 }
 
+func (u *mockUnit) Paused() bool {
+	return u.paused
+}
+
 func (u *mockUnit) Application() (remotestate.Application, error) {
 	return &u.application, nil
 }
@@ -41,6 +41,10 @@ type Snapshot struct {
 	// hook execution errors.
 	ResolvedMode params.ResolvedMode
 
+	// Paused reports whether hook execution is paused
+	// for the unit or its application.
+	Paused bool
+
 	// RetryHookVersion increments each time a failed
 	// hook is meant to be retried if ResolvedMode is
 	// set to ResolvedNone.
@@ -36,6 +36,7 @@ type Unit interface {
 	Life() params.Life
 	Refresh() error
 	Resolved() params.ResolvedMode
+	Paused() bool
 	Application() (Application, error)
 	Tag() names.UnitTag
 	Watch() (watcher.NotifyWatcher, error)
@@ -648,6 +648,7 @@ func (w *RemoteStateWatcher) unitChanged() error {
 	defer w.mu.Unlock()
 	w.current.Life = w.unit.Life()
 	w.current.ResolvedMode = w.unit.Resolved()
+	w.current.Paused = w.unit.Paused()
 	return nil
 }
 
@@ -664,10 +665,16 @@ func (w *RemoteStateWatcher) applicationChanged() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	// Pausing the application pauses the unit, but only
+	// changes the application, so refresh the unit too.
+	if err := w.unit.Refresh(); err != nil {
+		return errors.Trace(err)
+	}
 	w.mu.Lock()
 	w.current.CharmURL = url
 	w.current.ForceCharmUpgrade = force
 	w.current.CharmModifiedVersion = ver
+	w.current.Paused = w.unit.Paused()
 	w.mu.Unlock()
 	return nil
 }
@@ -303,6 +303,24 @@ func (s *WatcherSuite) TestActionsReceived(c *gc.C) {
 	c.Assert(s.watcher.Snapshot().Actions, gc.DeepEquals, []string{"an-action"})
 }
 
+func (s *WatcherSuite) TestPausedChanged(c *gc.C) {
+	s.signalAll()
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Paused, jc.IsFalse)
+
+	// Pausing the unit changes the unit.
+	s.st.unit.paused = true
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Paused, jc.IsTrue)
+
+	// Resuming the application changes the application.
+	s.st.unit.paused = false
+	s.applicationWatcher.changes <- struct{}{}
+	assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+	c.Assert(s.watcher.Snapshot().Paused, jc.IsFalse)
+}
+
 func (s *WatcherSuite) TestClearResolvedMode(c *gc.C) {
 	s.st.unit.resolved = params.ResolvedRetryHooks
 	s.signalAll()
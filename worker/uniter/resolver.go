@@ -54,6 +54,14 @@ func (s *uniterResolver) NextOp(
 		return nil, resolver.ErrTerminate
 	}
 
+	// While the unit is paused for maintenance, no hooks are run so
+	// that the agent doesn't race the operators. Actions and commands
+	// are still run, as they are run by the operators themselves. A
+	// unit that is being removed is never held back by being paused.
+	if remoteState.Paused && remoteState.Life == params.Alive {
+		return s.nextOpPaused(localState, remoteState, opFactory)
+	}
+
 	// Operations for series-upgrade need to be resolved early,
 	// in particular because no other operations should be run when the unit
 	// has completed preparation and is waiting for upgrade completion.
@@ -150,6 +158,26 @@ func (s *uniterResolver) NextOp(
 	}
 }
 
+// nextOpPaused is called while the unit is paused. Only actions and
+// commands are considered; everything else waits for the unit to be
+// resumed.
+func (s *uniterResolver) nextOpPaused(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	op, err := s.config.Actions.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+	op, err = s.config.Commands.NextOp(localState, remoteState, opFactory)
+	if errors.Cause(err) != resolver.ErrNoOperation {
+		return op, err
+	}
+	logger.Debugf("unit is paused; waiting to be resumed")
+	return nil, resolver.ErrNoOperation
+}
+
 // nextOpConflicted is called after an upgrade operation has failed, and hasn't
 // yet been resolved or reverted. When in this mode, the resolver will only
 // consider those two possibilities for progressing.
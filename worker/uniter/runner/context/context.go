@@ -189,6 +189,10 @@ type HookContext struct {
 	// opened each range and the relevant relation.
 	machinePorts map[network.PortRange]params.RelationUnit
 
+	// endpointPorts contains cached information about the port ranges
+	// opened by the unit, grouped by the endpoint they were opened for.
+	endpointPorts map[string][]network.PortRange
+
 	// assignedMachineTag contains the tag of the unit's assigned
 	// machine.
 	assignedMachineTag names.MachineTag
@@ -461,21 +465,39 @@ func (ctx *HookContext) AddUnitStorage(cons map[string]params.StorageConstraints
 }
 
 func (ctx *HookContext) OpenPorts(protocol string, fromPort, toPort int) error {
+	return ctx.OpenPortsOnEndpoint("", protocol, fromPort, toPort)
+}
+
+func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error {
+	return ctx.ClosePortsOnEndpoint("", protocol, fromPort, toPort)
+}
+
+func (ctx *HookContext) OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
 	return tryOpenPorts(
-		protocol, fromPort, toPort,
+		endpoint, protocol, fromPort, toPort,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
 }
 
-func (ctx *HookContext) ClosePorts(protocol string, fromPort, toPort int) error {
+func (ctx *HookContext) ClosePortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
 	return tryClosePorts(
-		protocol, fromPort, toPort,
+		endpoint, protocol, fromPort, toPort,
 		ctx.unit.Tag(),
 		ctx.machinePorts, ctx.pendingPorts,
 	)
 }
 
+func (ctx *HookContext) OpenedEndpointPorts() map[string][]network.PortRange {
+	result := make(map[string][]network.PortRange)
+	for endpoint, portRanges := range ctx.endpointPorts {
+		unitRanges := append([]network.PortRange(nil), portRanges...)
+		network.SortPortRanges(unitRanges)
+		result[endpoint] = unitRanges
+	}
+	return result
+}
+
 func (ctx *HookContext) OpenedPorts() []network.PortRange {
 	var unitRanges []network.PortRange
 	for portRange, relUnit := range ctx.machinePorts {
@@ -744,9 +766,11 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 	for id, rctx := range ctx.relations {
 		if writeChanges {
 			if e := rctx.WriteSettings(); e != nil {
-				e = errors.Errorf(
-					"could not write settings from %q to relation %d: %v",
-					process, id, e,
+				// Annotate rather than wrap, so that a SettingsTooLarge
+				// error keeps its code and info.
+				e = errors.Annotatef(e,
+					"could not write settings from %q to relation %d",
+					process, id,
 				)
 				logger.Errorf("%v", e)
 				if ctxErr == nil {
@@ -761,14 +785,16 @@ func (ctx *HookContext) Flush(process string, ctxErr error) (err error) {
 			var e error
 			var op string
 			if rangeInfo.ShouldOpen {
-				e = ctx.unit.OpenPorts(
+				e = ctx.unit.OpenPortsOnEndpoint(
+					rangeKey.Endpoint,
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
 				)
 				op = "open"
 			} else {
-				e = ctx.unit.ClosePorts(
+				e = ctx.unit.ClosePortsOnEndpoint(
+					rangeKey.Endpoint,
 					rangeKey.Ports.Protocol,
 					rangeKey.Ports.FromPort,
 					rangeKey.Ports.ToPort,
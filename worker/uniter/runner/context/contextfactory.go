@@ -326,6 +326,10 @@ func (f *contextFactory) updateContext(ctx *HookContext) (err error) {
 		if err != nil {
 			return errors.Trace(err)
 		}
+		ctx.endpointPorts, err = f.state.OpenedEndpointPorts(f.machineTag, f.unit.Tag())
+		if err != nil {
+			return errors.Trace(err)
+		}
 
 		// Calling these last, because there's a potential race: they're not guaranteed
 		// to be set in time to be needed for a hook. If they're not, we just leave them
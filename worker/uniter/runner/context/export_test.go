@@ -76,6 +76,10 @@ func NewHookContext(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	ctx.endpointPorts, err = state.OpenedEndpointPorts(ctx.assignedMachineTag, unit.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	statusCode, statusInfo, err := unit.MeterStatus()
 	if err != nil {
@@ -21,11 +21,13 @@ type PortRangeInfo struct {
 	RelationTag names.RelationTag
 }
 
-// PortRange contains a port range and a relation id. Used as key to
-// pendingRelations and is only exported for testing.
+// PortRange contains a port range, a relation id and the endpoint the
+// range applies to. Used as key to pendingRelations and is only exported
+// for testing.
 type PortRange struct {
 	Ports      network.PortRange
 	RelationId int
+	Endpoint   string
 }
 
 func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange, error) {
@@ -42,6 +44,7 @@ func validatePortRange(protocol string, fromPort, toPort int) (network.PortRange
 }
 
 func tryOpenPorts(
+	endpoint string,
 	protocol string,
 	fromPort, toPort int,
 	unitTag names.UnitTag,
@@ -61,6 +64,7 @@ func tryOpenPorts(
 	rangeKey := PortRange{
 		Ports:      newRange,
 		RelationId: relationId,
+		Endpoint:   endpoint,
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
@@ -88,8 +92,11 @@ func tryOpenPorts(
 		if newRange.ConflictsWith(portRange) {
 			if portRange == newRange && relUnitTag == unitTag {
 				// The same unit trying to open the same range is just
-				// ignored.
-				return nil
+				// ignored, unless it is opening it for an endpoint.
+				if endpoint == "" {
+					return nil
+				}
+				continue
 			}
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with existing %v (unit %q)",
@@ -99,6 +106,10 @@ func tryOpenPorts(
 	}
 	// Ensure other pending port ranges do not conflict with this one.
 	for rangeKey, rangeInfo := range pendingPorts {
+		if rangeKey.Ports == newRange {
+			// The same range requested for another endpoint.
+			continue
+		}
 		if newRange.ConflictsWith(rangeKey.Ports) && rangeInfo.ShouldOpen {
 			return errors.Errorf(
 				"cannot open %v (unit %q): conflicts with %v requested earlier",
@@ -114,6 +125,7 @@ func tryOpenPorts(
 }
 
 func tryClosePorts(
+	endpoint string,
 	protocol string,
 	fromPort, toPort int,
 	unitTag names.UnitTag,
@@ -133,6 +145,7 @@ func tryClosePorts(
 	rangeKey := PortRange{
 		Ports:      newRange,
 		RelationId: relationId,
+		Endpoint:   endpoint,
 	}
 
 	rangeInfo, isKnown := pendingPorts[rangeKey]
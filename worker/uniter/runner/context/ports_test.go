@@ -116,6 +116,12 @@ func makeMachinePorts(
 
 func makePendingPorts(
 	proto string, fromPort, toPort int, shouldOpen bool,
+) map[context.PortRange]context.PortRangeInfo {
+	return makeEndpointPendingPorts("", proto, fromPort, toPort, shouldOpen)
+}
+
+func makeEndpointPendingPorts(
+	endpoint, proto string, fromPort, toPort int, shouldOpen bool,
 ) map[context.PortRange]context.PortRangeInfo {
 	result := make(map[context.PortRange]context.PortRangeInfo)
 	portRange := network.PortRange{
@@ -126,6 +132,7 @@ func makePendingPorts(
 	key := context.PortRange{
 		Ports:      portRange,
 		RelationId: -1,
+		Endpoint:   endpoint,
 	}
 	result[key] = context.PortRangeInfo{
 		ShouldOpen: shouldOpen,
@@ -135,6 +142,7 @@ func makePendingPorts(
 
 type portsTest struct {
 	about         string
+	endpoint      string
 	proto         string
 	ports         []int
 	machinePorts  map[network.PortRange]params.RelationUnit
@@ -196,12 +204,31 @@ func (s *PortsSuite) TestTryOpenPorts(c *gc.C) {
 		about:        "try opening a range conflicting with another pending range",
 		pendingPorts: makePendingPorts("tcp", 5, 25, true),
 		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with 5-25/tcp requested earlier`,
+	}, {
+		about:         "open an existing range for an endpoint",
+		endpoint:      "website",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
+		expectPending: makeEndpointPendingPorts("website", "tcp", 10, 20, true),
+	}, {
+		about:        "try opening a range for an endpoint conflicting with another unit",
+		endpoint:     "website",
+		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
+		expectErr:    `cannot open 10-20/tcp \(unit "u/0"\): conflicts with existing 10-20/tcp \(unit "u/1"\)`,
+	}, {
+		about:        "open a range pending to be opened for another endpoint",
+		endpoint:     "website",
+		pendingPorts: makePendingPorts("tcp", 10, 20, true),
+		expectPending: map[context.PortRange]context.PortRangeInfo{
+			{Ports: network.PortRange{FromPort: 10, ToPort: 20, Protocol: "tcp"}, RelationId: -1}:                      {ShouldOpen: true},
+			{Ports: network.PortRange{FromPort: 10, ToPort: 20, Protocol: "tcp"}, RelationId: -1, Endpoint: "website"}: {ShouldOpen: true},
+		},
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
 
 		test = test.withDefaults("tcp", 10, 20)
 		err := context.TryOpenPorts(
+			test.endpoint,
 			test.proto,
 			test.ports[0],
 			test.ports[1],
@@ -250,12 +277,18 @@ func (s *PortsSuite) TestTryClosePorts(c *gc.C) {
 		about:        "try closing a range of another unit",
 		machinePorts: makeMachinePorts("u/1", "tcp", 10, 20),
 		expectErr:    `cannot close 10-20/tcp \(opened by "u/1"\) from "u/0"`,
+	}, {
+		about:         "close an existing range for an endpoint",
+		endpoint:      "website",
+		machinePorts:  makeMachinePorts("u/0", "tcp", 10, 20),
+		expectPending: makeEndpointPendingPorts("website", "tcp", 10, 20, false),
 	}}
 	for i, test := range tests {
 		c.Logf("test %d: %s", i, test.about)
 
 		test = test.withDefaults("tcp", 10, 20)
 		err := context.TryClosePorts(
+			test.endpoint,
 			test.proto,
 			test.ports[0],
 			test.ports[1],
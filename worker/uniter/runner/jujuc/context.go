@@ -159,6 +159,21 @@ type ContextNetworking interface {
 	// protocol, then by number.
 	OpenedPorts() []network.PortRange
 
+	// OpenPortsOnEndpoint marks the supplied port range for opening
+	// on the given endpoint when the executing unit's application is
+	// exposed. An empty endpoint applies to all endpoints.
+	OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error
+
+	// ClosePortsOnEndpoint marks the supplied port range, previously
+	// opened for the given endpoint, for closing.
+	ClosePortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error
+
+	// OpenedEndpointPorts returns the port ranges currently opened by
+	// this unit on its assigned machine, grouped by the endpoint they
+	// were opened for. Ranges opened for all endpoints are keyed by
+	// the empty string.
+	OpenedEndpointPorts() map[string][]network.PortRange
+
 	// NetworkInfo returns the network info for the given bindings on the given relation.
 	NetworkInfo(bindingNames []string, relationId int) (map[string]params.NetworkInfoResult, error)
 }
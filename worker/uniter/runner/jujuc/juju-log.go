@@ -13,6 +13,7 @@ import (
 	"github.com/juju/loggo"
 
 	jujucmd "github.com/juju/juju/cmd"
+	corelogger "github.com/juju/juju/core/logger"
 )
 
 // JujuLogCommandLogger provides a Logger interface for the juju-log command.
@@ -41,6 +42,8 @@ type JujuLogCommand struct {
 	Message       string
 	Debug         bool
 	Level         string
+	Labels        corelogger.Labels
+	labelArgs     []string
 	formatFlag    string // deprecated
 	loggerFactory JujuLogCommandLoggerFactory
 }
@@ -50,10 +53,17 @@ func NewJujuLogCommand(ctx Context) (cmd.Command, error) {
 }
 
 func (c *JujuLogCommand) Info() *cmd.Info {
+	doc := `
+Labels given as key=value pairs with --label are recorded with the
+message, so that its log records can be selected with the --include-label
+option of debug-log. Keys start with a letter, and neither keys nor
+values may contain spaces.
+`
 	return jujucmd.Info(&cmd.Info{
 		Name:    "juju-log",
 		Args:    "<message>",
 		Purpose: "write a message to the juju log",
+		Doc:     doc,
 	})
 }
 
@@ -61,6 +71,7 @@ func (c *JujuLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.Debug, "debug", false, "log at debug level")
 	f.StringVar(&c.Level, "l", "INFO", "Send log message at the given level")
 	f.StringVar(&c.Level, "log-level", "INFO", "")
+	f.Var(cmd.NewAppendStringsValue(&c.labelArgs), "label", "Label the message with a key=value pair")
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
 }
 
@@ -69,6 +80,19 @@ func (c *JujuLogCommand) Init(args []string) error {
 		return errors.New("no message specified")
 	}
 	c.Message = strings.Join(args, " ")
+	for _, arg := range c.labelArgs {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return errors.Errorf("expected key=value label, got %q", arg)
+		}
+		if err := corelogger.ValidateLabel(parts[0], parts[1]); err != nil {
+			return errors.Trace(err)
+		}
+		if c.Labels == nil {
+			c.Labels = make(corelogger.Labels)
+		}
+		c.Labels[parts[0]] = parts[1]
+	}
 	if c.loggerFactory == nil {
 		c.loggerFactory = loggoLoggerFactory{}
 	}
@@ -103,7 +127,7 @@ func (c *JujuLogCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
-	logger.Logf(logLevel, "%s%s", prefix, c.Message)
+	logger.Logf(logLevel, "%s%s", prefix, corelogger.FormatMessage(c.Message, c.Labels))
 	return nil
 }
 
@@ -96,6 +96,30 @@ func (s *JujuLogSuite) TestRunWithNoErrorsLogsOnRun(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
 }
 
+func (s *JujuLogSuite) TestRunWithLabels(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	cmd, context, logger := s.newJujuLogCommandWithMocks(ctrl, "")
+	logger.EXPECT().Logf(loggo.INFO, "%s%s", "", "backup started [labels: db=main stage=dump]")
+
+	context.EXPECT().HookRelation().Return(nil, errors.NotFoundf("not found"))
+	context.EXPECT().UnitName().Return("")
+
+	_, err := cmdtesting.RunCommand(c, cmd, "--label", "stage=dump", "--label", "db=main", "backup", "started")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *JujuLogSuite) TestLogInitInvalidLabel(c *gc.C) {
+	cmd := s.newJujuLogCommand(c)
+	err := cmdtesting.InitCommand(cmd, []string{"--label", "stage", "msg"})
+	c.Assert(err, gc.ErrorMatches, `expected key=value label, got "stage"`)
+
+	cmd = s.newJujuLogCommand(c)
+	err = cmdtesting.InitCommand(cmd, []string{"--label", "stage=two words", "msg"})
+	c.Assert(err, gc.ErrorMatches, `value "two words" of label "stage" not valid`)
+}
+
 func (s *JujuLogSuite) TestRunWithErrorIsNotImplementedLogsOnRun(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
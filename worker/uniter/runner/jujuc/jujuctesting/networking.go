@@ -17,6 +17,7 @@ type NetworkInterface struct {
 	PublicAddress      string
 	PrivateAddress     string
 	Ports              []network.PortRange
+	EndpointPorts      map[string][]network.PortRange
 	NetworkInfoResults map[string]params.NetworkInfoResult
 }
 
@@ -51,6 +52,45 @@ func (ni *NetworkInterface) RemovePorts(protocol string, from, to int) {
 	network.SortPortRanges(ni.Ports)
 }
 
+// AddEndpointPorts adds the specified port range for the endpoint.
+func (ni *NetworkInterface) AddEndpointPorts(endpoint, protocol string, from, to int) {
+	if ni.EndpointPorts == nil {
+		ni.EndpointPorts = make(map[string][]network.PortRange)
+	}
+	ni.EndpointPorts[endpoint] = append(ni.EndpointPorts[endpoint], network.PortRange{
+		Protocol: protocol,
+		FromPort: from,
+		ToPort:   to,
+	})
+	network.SortPortRanges(ni.EndpointPorts[endpoint])
+}
+
+// RemoveEndpointPorts removes the specified port range for the endpoint.
+func (ni *NetworkInterface) RemoveEndpointPorts(endpoint, protocol string, from, to int) {
+	portRange := network.PortRange{
+		Protocol: protocol,
+		FromPort: from,
+		ToPort:   to,
+	}
+	portRanges := ni.EndpointPorts[endpoint]
+	for i, port := range portRanges {
+		if port == portRange {
+			portRanges = append(portRanges[:i], portRanges[i+1:]...)
+			break
+		}
+	}
+	if len(portRanges) == 0 {
+		delete(ni.EndpointPorts, endpoint)
+	} else {
+		ni.EndpointPorts[endpoint] = portRanges
+	}
+}
+
+// CheckEndpointPorts checks the current ports by endpoint.
+func (ni *NetworkInterface) CheckEndpointPorts(c *gc.C, expected map[string][]network.PortRange) {
+	c.Check(ni.EndpointPorts, jc.DeepEquals, expected)
+}
+
 // ContextNetworking is a test double for jujuc.ContextNetworking.
 type ContextNetworking struct {
 	contextBase
@@ -103,6 +143,36 @@ func (c *ContextNetworking) OpenedPorts() []network.PortRange {
 	return c.info.Ports
 }
 
+// OpenPortsOnEndpoint implements jujuc.ContextNetworking.
+func (c *ContextNetworking) OpenPortsOnEndpoint(endpoint, protocol string, from, to int) error {
+	c.stub.AddCall("OpenPortsOnEndpoint", endpoint, protocol, from, to)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.AddEndpointPorts(endpoint, protocol, from, to)
+	return nil
+}
+
+// ClosePortsOnEndpoint implements jujuc.ContextNetworking.
+func (c *ContextNetworking) ClosePortsOnEndpoint(endpoint, protocol string, from, to int) error {
+	c.stub.AddCall("ClosePortsOnEndpoint", endpoint, protocol, from, to)
+	if err := c.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	c.info.RemoveEndpointPorts(endpoint, protocol, from, to)
+	return nil
+}
+
+// OpenedEndpointPorts implements jujuc.ContextNetworking.
+func (c *ContextNetworking) OpenedEndpointPorts() map[string][]network.PortRange {
+	c.stub.AddCall("OpenedEndpointPorts")
+	c.stub.NextErr()
+
+	return c.info.EndpointPorts
+}
+
 // NetworkInfo implements jujuc.ContextNetworking.
 func (c *ContextNetworking) NetworkInfo(bindingNames []string, relationId int) (map[string]params.NetworkInfoResult, error) {
 	c.stub.AddCall("NetworkInfo", bindingNames, relationId)
@@ -90,6 +90,10 @@ func (r *ContextRelation) UnitNames() []string {
 
 	var s []string // initially nil to match the true context.
 	for name := range r.info.Units {
+		// Like the true context, only list the remote units.
+		if name == r.info.UnitName {
+			continue
+		}
 		s = append(s, name)
 	}
 	sort.Strings(s)
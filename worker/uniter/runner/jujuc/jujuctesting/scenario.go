@@ -0,0 +1,259 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charm.v6/hooks"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc"
+)
+
+// Hook is a charm hook implemented in Go, run by a Scenario.
+type Hook func(ctx *HookContext) error
+
+// HookContext is the context a Hook is run in. It gives the hook the
+// jujuc.Context of the unit, and runs hook tools against it like a
+// charm hook would.
+type HookContext struct {
+	jujuc.Context
+	hookName string
+}
+
+// HookName returns the name of the running hook,
+// e.g. "install" or "db-relation-joined".
+func (ctx *HookContext) HookName() string {
+	return ctx.hookName
+}
+
+// Run runs the named hook tool, e.g. "relation-set", with the given
+// arguments, and returns what it wrote to stdout.
+func (ctx *HookContext) Run(name string, args ...string) (string, error) {
+	command, err := jujuc.NewCommand(ctx, name+jujuc.CmdSuffix)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	var stdout, stderr bytes.Buffer
+	cmdCtx := &cmd.Context{
+		Dir:    ".",
+		Stdin:  &bytes.Buffer{},
+		Stdout: &stdout,
+		Stderr: &stderr,
+	}
+	if code := cmd.Main(command, cmdCtx, args); code != 0 {
+		return "", errors.Errorf("%s failed: %s", name, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// Scenario is an in-memory harness driving the hooks of a unit's
+// charm, as the uniter would, against fake state. It allows charm and
+// facade developers to test sequences of hooks, such as install,
+// config-changed and relation-joined, without a controller.
+//
+// Hooks that the charm does not implement are skipped, as they are
+// when a charm has no file for a hook.
+type Scenario struct {
+	// Info holds the state seen and changed by the hooks. It may be
+	// changed between hooks to set up the state of the next ones.
+	Info *ContextInfo
+
+	// Stub records the calls made by the hooks to their context. Its
+	// errors may be set to make the calls fail.
+	Stub *testing.Stub
+
+	hooks map[string]Hook
+	fired []string
+}
+
+// NewScenario returns a Scenario for the named unit, with the hooks of
+// its charm keyed by hook name, e.g. "db-relation-changed".
+func NewScenario(unitName string, charmHooks map[string]Hook) *Scenario {
+	info := &ContextInfo{}
+	info.Unit.Name = unitName
+	info.ConfigSettings = charm.Settings{}
+	return &Scenario{
+		Info:  info,
+		Stub:  &testing.Stub{},
+		hooks: charmHooks,
+	}
+}
+
+// Fired returns the names of the hooks fired so far, in order,
+// including those the charm does not implement.
+func (s *Scenario) Fired() []string {
+	return append([]string(nil), s.fired...)
+}
+
+// Deploy fires the hooks run when the unit is deployed: install,
+// leader-elected or leader-settings-changed, config-changed and start.
+func (s *Scenario) Deploy() error {
+	leaderHook := hooks.LeaderSettingsChanged
+	if s.Info.IsLeader {
+		leaderHook = hooks.LeaderElected
+	}
+	for _, kind := range []hooks.Kind{
+		hooks.Install,
+		leaderHook,
+		hooks.ConfigChanged,
+		hooks.Start,
+	} {
+		if err := s.RunHook(string(kind)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ChangeConfig updates the config settings of the unit with the given
+// settings, removing those set to nil, and fires config-changed.
+func (s *Scenario) ChangeConfig(settings charm.Settings) error {
+	for key, value := range settings {
+		if value == nil {
+			delete(s.Info.ConfigSettings, key)
+		} else {
+			s.Info.ConfigSettings[key] = value
+		}
+	}
+	return s.RunHook(string(hooks.ConfigChanged))
+}
+
+// ElectLeader makes the unit the leader of its application and fires
+// leader-elected.
+func (s *Scenario) ElectLeader() error {
+	s.Info.IsLeader = true
+	return s.RunHook(string(hooks.LeaderElected))
+}
+
+// AddRelation adds a relation with the given id on the named endpoint
+// of the unit, without any remote units. No hook is fired until a
+// remote unit joins the relation.
+func (s *Scenario) AddRelation(id int, endpoint string) {
+	relation := s.Info.SetNewRelation(id, endpoint, s.Stub)
+	relation.UnitName = s.Info.Unit.Name
+	relation.SetRelated(s.Info.Unit.Name, Settings{})
+}
+
+// JoinRelation adds the remote unit with the given settings to the
+// relation, and fires relation-joined and relation-changed.
+func (s *Scenario) JoinRelation(id int, remoteUnit string, settings map[string]string) error {
+	relation, err := s.relation(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	relation.SetRelated(remoteUnit, copySettings(settings))
+	if err := s.runRelationHook(relation, hooks.RelationJoined, remoteUnit); err != nil {
+		return err
+	}
+	return s.runRelationHook(relation, hooks.RelationChanged, remoteUnit)
+}
+
+// ChangeRelation replaces the settings of the remote unit in the
+// relation, and fires relation-changed.
+func (s *Scenario) ChangeRelation(id int, remoteUnit string, settings map[string]string) error {
+	relation, err := s.relation(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := relation.Units[remoteUnit]; !ok {
+		return errors.NotFoundf("unit %q in relation %d", remoteUnit, id)
+	}
+	relation.SetRelated(remoteUnit, copySettings(settings))
+	return s.runRelationHook(relation, hooks.RelationChanged, remoteUnit)
+}
+
+// DepartRelation removes the remote unit from the relation, and fires
+// relation-departed.
+func (s *Scenario) DepartRelation(id int, remoteUnit string) error {
+	relation, err := s.relation(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := relation.Units[remoteUnit]; !ok {
+		return errors.NotFoundf("unit %q in relation %d", remoteUnit, id)
+	}
+	delete(relation.Units, remoteUnit)
+	return s.runRelationHook(relation, hooks.RelationDeparted, remoteUnit)
+}
+
+// BreakRelation departs the remote units of the relation, fires
+// relation-broken and removes the relation.
+func (s *Scenario) BreakRelation(id int) error {
+	relation, err := s.relation(id)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var remoteUnits []string
+	for unit := range relation.Units {
+		if unit != s.Info.Unit.Name {
+			remoteUnits = append(remoteUnits, unit)
+		}
+	}
+	sort.Strings(remoteUnits)
+	for _, unit := range remoteUnits {
+		if err := s.DepartRelation(id, unit); err != nil {
+			return err
+		}
+	}
+	if err := s.runRelationHook(relation, hooks.RelationBroken, ""); err != nil {
+		return err
+	}
+	delete(s.Info.Relations.Relations, id)
+	return nil
+}
+
+// RunHook fires the named hook outside of any relation, e.g. "stop" or
+// "update-status".
+func (s *Scenario) RunHook(name string) error {
+	s.Info.RelationHook.Reset()
+	return s.run(name)
+}
+
+// copySettings returns a copy of the given relation settings, so that
+// the hooks don't change the caller's map.
+func copySettings(settings map[string]string) Settings {
+	result := make(Settings)
+	for key, value := range settings {
+		result[key] = value
+	}
+	return result
+}
+
+func (s *Scenario) relation(id int) (*Relation, error) {
+	relCtx, ok := s.Info.Relations.Relations[id]
+	if !ok {
+		return nil, errors.NotFoundf("relation %d", id)
+	}
+	return relCtx.(*ContextRelation).info, nil
+}
+
+func (s *Scenario) runRelationHook(relation *Relation, kind hooks.Kind, remoteUnit string) error {
+	s.Info.SetAsRelationHook(relation.Id, remoteUnit)
+	defer s.Info.RelationHook.Reset()
+	return s.run(fmt.Sprintf("%s-%s", relation.Name, kind))
+}
+
+func (s *Scenario) run(name string) error {
+	s.fired = append(s.fired, name)
+	hook, ok := s.hooks[name]
+	if !ok {
+		return nil
+	}
+	ctx := &HookContext{
+		Context:  s.Info.Context(s.Stub),
+		hookName: name,
+	}
+	if err := hook(ctx); err != nil {
+		return errors.Annotatef(err, "hook %q failed", name)
+	}
+	return nil
+}
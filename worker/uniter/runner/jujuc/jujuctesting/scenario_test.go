@@ -0,0 +1,145 @@
+// Copyright 2019 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuctesting_test
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/worker/uniter/runner/jujuc/jujuctesting"
+)
+
+type scenarioSuite struct {
+	testing.IsolationSuite
+
+	scenario *jujuctesting.Scenario
+	hosts    []string
+}
+
+var _ = gc.Suite(&scenarioSuite{})
+
+func (s *scenarioSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.hosts = nil
+	s.scenario = jujuctesting.NewScenario("wordpress/0", map[string]jujuctesting.Hook{
+		"install": func(ctx *jujuctesting.HookContext) error {
+			_, err := ctx.Run("status-set", "maintenance", "installing")
+			return err
+		},
+		"config-changed": func(ctx *jujuctesting.HookContext) error {
+			title, err := ctx.Run("config-get", "title")
+			if err != nil {
+				return err
+			}
+			_, err = ctx.Run("status-set", "active", "serving "+strings.TrimSpace(title))
+			return err
+		},
+		"db-relation-joined": func(ctx *jujuctesting.HookContext) error {
+			_, err := ctx.Run("relation-set", "database=wordpress")
+			return err
+		},
+		"db-relation-changed": func(ctx *jujuctesting.HookContext) error {
+			host, err := ctx.Run("relation-get", "host")
+			if err != nil {
+				return err
+			}
+			s.hosts = append(s.hosts, strings.TrimSpace(host))
+			return nil
+		},
+		"db-relation-departed": func(ctx *jujuctesting.HookContext) error {
+			units, err := ctx.Run("relation-list")
+			if err != nil {
+				return err
+			}
+			s.hosts = append(s.hosts, "left: "+strings.TrimSpace(units))
+			return nil
+		},
+	})
+	s.scenario.Info.ConfigSettings["title"] = "My Blog"
+}
+
+func (s *scenarioSuite) TestDeploy(c *gc.C) {
+	err := s.scenario.Deploy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.scenario.Fired(), jc.DeepEquals, []string{
+		"install", "leader-settings-changed", "config-changed", "start",
+	})
+	c.Check(s.scenario.Info.UnitStatus.Status, gc.Equals, "active")
+	c.Check(s.scenario.Info.UnitStatus.Info, gc.Equals, "serving My Blog")
+}
+
+func (s *scenarioSuite) TestDeployLeader(c *gc.C) {
+	s.scenario.Info.IsLeader = true
+	err := s.scenario.Deploy()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.scenario.Fired()[1], gc.Equals, "leader-elected")
+}
+
+func (s *scenarioSuite) TestChangeConfig(c *gc.C) {
+	err := s.scenario.ChangeConfig(charm.Settings{"title": "Other Blog"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.scenario.Fired(), jc.DeepEquals, []string{"config-changed"})
+	c.Check(s.scenario.Info.UnitStatus.Info, gc.Equals, "serving Other Blog")
+}
+
+func (s *scenarioSuite) TestRelationLifecycle(c *gc.C) {
+	s.scenario.AddRelation(7, "db")
+	err := s.scenario.JoinRelation(7, "mysql/0", map[string]string{"host": "10.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.scenario.JoinRelation(7, "mysql/1", map[string]string{"host": "10.0.0.2"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.scenario.ChangeRelation(7, "mysql/0", map[string]string{"host": "10.0.0.3"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.scenario.BreakRelation(7)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.scenario.Fired(), jc.DeepEquals, []string{
+		"db-relation-joined",
+		"db-relation-changed",
+		"db-relation-joined",
+		"db-relation-changed",
+		"db-relation-changed",
+		"db-relation-departed",
+		"db-relation-departed",
+		"db-relation-broken",
+	})
+	c.Check(s.hosts, jc.DeepEquals, []string{
+		"10.0.0.1",
+		"10.0.0.2",
+		"10.0.0.3",
+		"left: mysql/1",
+		"left: ",
+	})
+	c.Check(s.scenario.Info.Relations.Relations, gc.HasLen, 0)
+}
+
+func (s *scenarioSuite) TestRelationSettingsWritten(c *gc.C) {
+	s.scenario.AddRelation(7, "db")
+	err := s.scenario.JoinRelation(7, "mysql/0", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	relation, err := s.scenario.Info.Context(s.scenario.Stub).Relation(7)
+	c.Assert(err, jc.ErrorIsNil)
+	settings, err := relation.ReadSettings("wordpress/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(settings["database"], gc.Equals, "wordpress")
+}
+
+func (s *scenarioSuite) TestUnknownRelation(c *gc.C) {
+	err := s.scenario.JoinRelation(7, "mysql/0", nil)
+	c.Assert(err, gc.ErrorMatches, "relation 7 not found")
+	c.Check(s.scenario.Fired(), gc.HasLen, 0)
+}
+
+func (s *scenarioSuite) TestHookFailure(c *gc.C) {
+	s.scenario.Stub.SetErrors(errors.New("boom"))
+	err := s.scenario.Deploy()
+	c.Assert(err, gc.ErrorMatches, `hook "install" failed: status-set failed: .*boom`)
+	c.Check(s.scenario.Fired(), jc.DeepEquals, []string{"install"})
+}
@@ -4,17 +4,23 @@
 package jujuc
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/juju/cmd"
 	"github.com/juju/gnuflag"
 
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/core/network"
 )
 
 // OpenedPortsCommand implements the opened-ports command.
 type OpenedPortsCommand struct {
 	cmd.CommandBase
-	ctx Context
-	out cmd.Output
+	ctx           Context
+	out           cmd.Output
+	showEndpoints bool
 }
 
 func NewOpenedPortsCommand(ctx Context) (cmd.Command, error) {
@@ -23,7 +29,11 @@ func NewOpenedPortsCommand(ctx Context) (cmd.Command, error) {
 
 func (c *OpenedPortsCommand) Info() *cmd.Info {
 	doc := `Each list entry has format <port>/<protocol> (e.g. "80/tcp") or
-<from>-<to>/<protocol> (e.g. "8080-8088/udp").`
+<from>-<to>/<protocol> (e.g. "8080-8088/udp").
+
+If the --endpoints option is specified, each entry is followed by the
+endpoints the range is opened for (e.g. "80/tcp (website,monitoring)"),
+where "*" means all of the unit's endpoints.`
 	return jujucmd.Info(&cmd.Info{
 		Name:    "opened-ports",
 		Purpose: "lists all ports or ranges opened by the unit",
@@ -33,6 +43,7 @@ func (c *OpenedPortsCommand) Info() *cmd.Info {
 
 func (c *OpenedPortsCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
+	f.BoolVar(&c.showEndpoints, "endpoints", false, "display the list of endpoints each port range is opened for")
 }
 
 func (c *OpenedPortsCommand) Init(args []string) error {
@@ -40,6 +51,9 @@ func (c *OpenedPortsCommand) Init(args []string) error {
 }
 
 func (c *OpenedPortsCommand) Run(ctx *cmd.Context) error {
+	if c.showEndpoints {
+		return c.out.Write(ctx, c.endpointPortRanges())
+	}
 	unitPorts := c.ctx.OpenedPorts()
 	results := make([]string, len(unitPorts))
 	for i, portRange := range unitPorts {
@@ -47,3 +61,29 @@ func (c *OpenedPortsCommand) Run(ctx *cmd.Context) error {
 	}
 	return c.out.Write(ctx, results)
 }
+
+// endpointPortRanges returns the unit's opened port ranges, each followed
+// by the sorted list of endpoints it is opened for.
+func (c *OpenedPortsCommand) endpointPortRanges() []string {
+	endpointsByRange := make(map[network.PortRange][]string)
+	var portRanges []network.PortRange
+	for endpoint, endpointPorts := range c.ctx.OpenedEndpointPorts() {
+		if endpoint == "" {
+			endpoint = "*"
+		}
+		for _, portRange := range endpointPorts {
+			if _, ok := endpointsByRange[portRange]; !ok {
+				portRanges = append(portRanges, portRange)
+			}
+			endpointsByRange[portRange] = append(endpointsByRange[portRange], endpoint)
+		}
+	}
+	network.SortPortRanges(portRanges)
+	results := make([]string, len(portRanges))
+	for i, portRange := range portRanges {
+		endpoints := endpointsByRange[portRange]
+		sort.Strings(endpoints)
+		results[i] = fmt.Sprintf("%s (%s)", portRange, strings.Join(endpoints, ","))
+	}
+	return results
+}
@@ -80,7 +80,28 @@ lists all ports or ranges opened by the unit
 Details:
 Each list entry has format <port>/<protocol> (e.g. "80/tcp") or
 <from>-<to>/<protocol> (e.g. "8080-8088/udp").
+
+If the --endpoints option is specified, each entry is followed by the
+endpoints the range is opened for (e.g. "80/tcp (website,monitoring)"),
+where "*" means all of the unit's endpoints.
+`[1:])
+}
+
+func (s *OpenedPortsSuite) TestRunWithEndpoints(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	hctx.OpenPortsOnEndpoint("", "tcp", 80, 80)
+	hctx.OpenPortsOnEndpoint("website", "tcp", 80, 80)
+	hctx.OpenPortsOnEndpoint("website", "tcp", 443, 443)
+	hctx.OpenPortsOnEndpoint("monitoring", "tcp", 443, 443)
+	hctx.OpenPortsOnEndpoint("monitoring", "icmp", -1, -1)
+
+	stdout, stderr := s.runCommand(c, hctx, "--endpoints")
+	c.Check(stdout, gc.Equals, `
+icmp (monitoring)
+80/tcp (*,website)
+443/tcp (monitoring,website)
 `[1:])
+	c.Check(stderr, gc.Equals, "")
 }
 
 func (s *OpenedPortsSuite) getContextAndOpenPorts(c *gc.C) *Context {
@@ -126,10 +126,11 @@ func parseArguments(args []string) (portRange, error) {
 type portCommand struct {
 	cmd.CommandBase
 	info       *cmd.Info
-	action     func(*portCommand) error
+	action     func(c *portCommand, endpoint string) error
 	Protocol   string
 	FromPort   int
 	ToPort     int
+	endpoints  []string
 	formatFlag string // deprecated
 }
 
@@ -139,6 +140,7 @@ func (c *portCommand) Info() *cmd.Info {
 
 func (c *portCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.formatFlag, "format", "", "deprecated format flag")
+	f.Var(cmd.NewAppendStringsValue(&c.endpoints), "endpoints", "a comma-delimited list of endpoints to apply the port range to")
 }
 
 func (c *portCommand) Init(args []string) error {
@@ -154,6 +156,13 @@ func (c *portCommand) Init(args []string) error {
 	c.FromPort = portRange.fromPort
 	c.ToPort = portRange.toPort
 	c.Protocol = portRange.protocol
+
+	for i, endpoint := range c.endpoints {
+		c.endpoints[i] = strings.TrimSpace(endpoint)
+		if c.endpoints[i] == "" {
+			return errors.Errorf("invalid --endpoints value %q", strings.Join(c.endpoints, ","))
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
@@ -161,21 +170,38 @@ func (c *portCommand) Run(ctx *cmd.Context) error {
 	if c.formatFlag != "" {
 		fmt.Fprintf(ctx.Stderr, "--format flag deprecated for command %q", c.Info().Name)
 	}
-	return c.action(c)
+	if len(c.endpoints) == 0 {
+		return c.action(c, "")
+	}
+	for _, endpoint := range c.endpoints {
+		if err := c.action(c, endpoint); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
 
 var openPortInfo = &cmd.Info{
 	Name:    "open-port",
 	Args:    portFormat,
 	Purpose: "register a port or range to open",
-	Doc:     "The port range will only be open while the application is exposed.",
+	Doc: `
+The port range will only be open while the application is exposed.
+
+By default the port range applies to all of the unit's endpoints. The
+--endpoints option restricts it to the given comma-delimited list of
+endpoints instead.
+`,
 }
 
 func NewOpenPortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: openPortInfo,
-		action: func(c *portCommand) error {
-			return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+		action: func(c *portCommand, endpoint string) error {
+			if endpoint == "" {
+				return ctx.OpenPorts(c.Protocol, c.FromPort, c.ToPort)
+			}
+			return ctx.OpenPortsOnEndpoint(endpoint, c.Protocol, c.FromPort, c.ToPort)
 		},
 	}, nil
 }
@@ -184,13 +210,20 @@ var closePortInfo = &cmd.Info{
 	Name:    "close-port",
 	Args:    portFormat,
 	Purpose: "ensure a port or range is always closed",
+	Doc: `
+The --endpoints option closes the port range only for the given
+comma-delimited list of endpoints it was opened for.
+`,
 }
 
 func NewClosePortCommand(ctx Context) (cmd.Command, error) {
 	return &portCommand{
 		info: closePortInfo,
-		action: func(c *portCommand) error {
-			return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+		action: func(c *portCommand, endpoint string) error {
+			if endpoint == "" {
+				return ctx.ClosePorts(c.Protocol, c.FromPort, c.ToPort)
+			}
+			return ctx.ClosePortsOnEndpoint(endpoint, c.Protocol, c.FromPort, c.ToPort)
 		},
 	}, nil
 }
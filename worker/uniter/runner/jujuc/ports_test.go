@@ -109,6 +109,42 @@ var badPortsTests = []struct {
 	{[]string{"80-90/http"}, `protocol must be "tcp", "udp", or "icmp"; got "http"`},
 	{[]string{"20-10/tcp"}, `invalid port range 20-10/tcp; expected fromPort <= toPort`},
 	{[]string{"80/icmp"}, `protocol "icmp" doesn't support any ports; got "80"`},
+	{[]string{"--endpoints", "website,", "80"}, `invalid --endpoints value "website,"`},
+}
+
+func (s *PortsSuite) TestOpenCloseOnEndpoints(c *gc.C) {
+	hctx := s.GetHookContext(c, -1, "")
+	for i, t := range []struct {
+		cmd    []string
+		expect map[string][]network.PortRange
+	}{{
+		cmd: []string{"open-port", "--endpoints", "website,monitoring", "80"},
+		expect: map[string][]network.PortRange{
+			"website":    makeRanges("80/tcp"),
+			"monitoring": makeRanges("80/tcp"),
+		},
+	}, {
+		cmd: []string{"open-port", "--endpoints", "monitoring", "icmp"},
+		expect: map[string][]network.PortRange{
+			"website":    makeRanges("80/tcp"),
+			"monitoring": makeRanges("icmp", "80/tcp"),
+		},
+	}, {
+		cmd: []string{"close-port", "--endpoints", "website", "80"},
+		expect: map[string][]network.PortRange{
+			"monitoring": makeRanges("icmp", "80/tcp"),
+		},
+	}} {
+		c.Logf("test %d: %v", i, t.cmd)
+		com, err := jujuc.NewCommand(hctx, cmdString(t.cmd[0]))
+		c.Assert(err, jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, t.cmd[1:])
+		c.Check(code, gc.Equals, 0)
+		c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+		hctx.info.CheckEndpointPorts(c, t.expect)
+		hctx.info.CheckPorts(c, nil)
+	}
 }
 
 func (s *PortsSuite) TestBadArgs(c *gc.C) {
@@ -136,6 +172,10 @@ register a port or range to open
 
 Details:
 The port range will only be open while the application is exposed.
+
+By default the port range applies to all of the unit's endpoints. The
+--endpoints option restricts it to the given comma-delimited list of
+endpoints instead.
 `[1:])
 
 	close, err := jujuc.NewCommand(hctx, cmdString("close-port"))
@@ -145,6 +185,10 @@ Usage: close-port <port>[/<protocol>] or <from>-<to>[/<protocol>] or icmp
 
 Summary:
 ensure a port or range is always closed
+
+Details:
+The --endpoints option closes the port range only for the given
+comma-delimited list of endpoints it was opened for.
 `[1:])
 }
 
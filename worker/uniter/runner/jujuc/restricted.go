@@ -85,6 +85,19 @@ func (*RestrictedContext) ClosePorts(protocol string, fromPort, toPort int) erro
 // OpenedPorts implements hooks.Context.
 func (*RestrictedContext) OpenedPorts() []network.PortRange { return nil }
 
+// OpenPortsOnEndpoint implements hooks.Context.
+func (*RestrictedContext) OpenPortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
+// ClosePortsOnEndpoint implements hooks.Context.
+func (*RestrictedContext) ClosePortsOnEndpoint(endpoint, protocol string, fromPort, toPort int) error {
+	return ErrRestrictedContext
+}
+
+// OpenedEndpointPorts implements hooks.Context.
+func (*RestrictedContext) OpenedEndpointPorts() map[string][]network.PortRange { return nil }
+
 // NetworkInfo implements hooks.Context.
 func (*RestrictedContext) NetworkInfo(bindingNames []string, relationId int) (map[string]params.NetworkInfoResult, error) {
 	return map[string]params.NetworkInfoResult{}, ErrRestrictedContext
@@ -6,6 +6,7 @@ package uniter
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/juju/clock"
@@ -31,6 +32,7 @@ import (
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/uniter/actions"
 	"github.com/juju/juju/worker/uniter/charm"
+	"github.com/juju/juju/worker/uniter/healthcheck"
 	"github.com/juju/juju/worker/uniter/hook"
 	uniterleadership "github.com/juju/juju/worker/uniter/leadership"
 	"github.com/juju/juju/worker/uniter/operation"
@@ -599,6 +601,42 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if err := u.catacomb.Add(rlw); err != nil {
 		return errors.Trace(err)
 	}
+
+	// Health checks are only run by IAAS units; CAAS workloads
+	// are probed by the container orchestrator.
+	if u.modelType == model.IAAS {
+		healthChecker, err := healthcheck.NewWorker(healthcheck.Config{
+			CharmDir:     u.paths.State.CharmDir,
+			StatusSetter: u.unit,
+			RunHook: func(name string) error {
+				return runHealthCheckHook(commandRunner, name)
+			},
+			Probe: healthcheck.DefaultProbe,
+			Clock: u.clock,
+		})
+		if err != nil {
+			return errors.Annotate(err, "creating health checker")
+		}
+		if err := u.catacomb.Add(healthChecker); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// runHealthCheckHook runs the named hook of the charm, in response to a
+// failing health check, in the same way as "juju-run" commands are run.
+func runHealthCheckHook(runner CommandRunner, name string) error {
+	response, err := runner.RunCommands(RunCommandsArgs{
+		Commands:   filepath.Join("hooks", name),
+		RelationId: -1,
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if response.Code != 0 {
+		return errors.Errorf("hook %q exited with code %d", name, response.Code)
+	}
 	return nil
 }
 
@@ -212,6 +212,18 @@ func (u *Upgrader) loop() error {
 			u.config.InitialUpgradeCheckComplete.Unlock()
 			continue
 		}
+		// Only roll the upgrade out within the maintenance window; if
+		// it's closed, come back when it next opens.
+		window, err := u.st.MaintenanceWindow(u.tag.String())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if delay := window.Until(time.Now()); delay > 0 {
+			logger.Infof("upgrade to %v deferred for %v until maintenance window %v", wantVersion, delay, window)
+			u.config.InitialUpgradeCheckComplete.Unlock()
+			retry = retryAfter(delay)
+			continue
+		}
 		logger.Infof("upgrade requested from %v to %v", jujuversion.Current, wantVersion)
 
 		// Check if tools have already been downloaded.
@@ -242,6 +242,62 @@ func (s *UpgraderSuite) TestUpgraderRetryAndChanged(c *gc.C) {
 	}
 }
 
+func (s *UpgraderSuite) TestUpgraderWaitsForMaintenanceWindow(c *gc.C) {
+	stor := s.DefaultToolsStorage
+	oldTools := envtesting.PrimeTools(c, stor, s.DataDir(), s.Environ.Config().AgentStream(), version.MustParseBinary("5.4.3-precise-amd64"))
+	s.patchVersion(oldTools.Version)
+	newTools := envtesting.AssertUploadFakeToolsVersions(
+		c, stor, s.Environ.Config().AgentStream(), s.Environ.Config().AgentStream(), version.MustParseBinary("5.4.5-precise-amd64"))[0]
+	err := statetesting.SetAgentVersion(s.State, newTools.Version.Number)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// A maintenance window that opens a couple of hours from now.
+	start := (time.Now().UTC().Hour() + 2) % 24
+	err = s.Model.UpdateModelConfig(map[string]interface{}{
+		"maintenance-window": fmt.Sprintf("%02d:00-%02d:00", start, (start+1)%24),
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	delays := make(chan time.Duration, 1)
+	*upgrader.RetryAfter = func(delay time.Duration) <-chan time.Time {
+		select {
+		case delays <- delay:
+		default:
+		}
+		return nil
+	}
+	u := s.makeUpgrader(c)
+	defer u.Stop()
+
+	select {
+	case delay := <-delays:
+		c.Assert(delay > time.Hour && delay <= 2*time.Hour, jc.IsTrue, gc.Commentf("delay %v", delay))
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("upgrader did not wait for the maintenance window")
+	}
+	s.expectInitialUpgradeCheckDone(c)
+
+	// Opening the window lets the upgrade proceed.
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"maintenance-window": "any"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.BackingState.StartSync()
+	done := make(chan error)
+	go func() {
+		done <- u.Wait()
+	}()
+	select {
+	case err := <-done:
+		envtesting.CheckUpgraderReadyError(c, err, &upgrader.UpgradeReadyError{
+			AgentName: s.machine.Tag().String(),
+			OldTools:  oldTools.Version,
+			NewTools:  newTools.Version,
+			DataDir:   s.DataDir(),
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("upgrader did not quit after upgrading")
+	}
+}
+
 func (s *UpgraderSuite) TestChangeAgentTools(c *gc.C) {
 	oldTools := &coretools.Tools{
 		Version: version.MustParseBinary("1.2.3-quantal-amd64"),